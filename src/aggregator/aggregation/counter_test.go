@@ -74,3 +74,20 @@ func TestCounterCustomAggregationType(t *testing.T) {
 		}
 	}
 }
+
+func TestCounterSnapshotRestore(t *testing.T) {
+	opts := NewOptions()
+	opts.HasExpensiveAggregations = true
+
+	c := NewCounter(opts)
+	for i := 1; i <= 100; i++ {
+		c.Update(int64(i))
+	}
+
+	restored := NewCounter(opts)
+	restored.Restore(c.Snapshot())
+	require.Equal(t, c.Snapshot(), restored.Snapshot())
+	for aggType := range aggregation.ValidTypes {
+		require.Equal(t, c.ValueOf(aggType), restored.ValueOf(aggType))
+	}
+}
@@ -124,5 +124,37 @@ func (g *Gauge) ValueOf(aggType aggregation.Type) float64 {
 	}
 }
 
+// GaugeSnapshot is a snapshot of the values aggregated by a gauge.
+type GaugeSnapshot struct {
+	Last  float64
+	Sum   float64
+	SumSq float64
+	Count int64
+	Max   float64
+	Min   float64
+}
+
+// Snapshot returns a snapshot of the gauge values.
+func (g *Gauge) Snapshot() GaugeSnapshot {
+	return GaugeSnapshot{
+		Last:  g.last,
+		Sum:   g.sum,
+		SumSq: g.sumSq,
+		Count: g.count,
+		Max:   g.max,
+		Min:   g.min,
+	}
+}
+
+// Restore restores the gauge values from a snapshot.
+func (g *Gauge) Restore(snapshot GaugeSnapshot) {
+	g.last = snapshot.Last
+	g.sum = snapshot.Sum
+	g.sumSq = snapshot.SumSq
+	g.count = snapshot.Count
+	g.max = snapshot.Max
+	g.min = snapshot.Min
+}
+
 // Close closes the gauge.
 func (g *Gauge) Close() {}
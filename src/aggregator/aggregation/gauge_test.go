@@ -78,3 +78,20 @@ func TestGaugeCustomAggregationType(t *testing.T) {
 		}
 	}
 }
+
+func TestGaugeSnapshotRestore(t *testing.T) {
+	opts := NewOptions()
+	opts.HasExpensiveAggregations = true
+
+	g := NewGauge(opts)
+	for i := 1; i <= 100; i++ {
+		g.Update(float64(i))
+	}
+
+	restored := NewGauge(opts)
+	restored.Restore(g.Snapshot())
+	require.Equal(t, g.Snapshot(), restored.Snapshot())
+	for aggType := range aggregation.ValidTypes {
+		require.Equal(t, g.ValueOf(aggType), restored.ValueOf(aggType))
+	}
+}
@@ -0,0 +1,303 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+const (
+	histogramBucketSuffixPrefix = ".bucket.le_"
+	histogramInfBoundString     = "+Inf"
+)
+
+var (
+	errEmptyHistogramBuckets         = errors.New("histogram buckets are empty")
+	errHistogramBucketsNotAscending  = errors.New("histogram bucket bounds are not strictly ascending")
+	errHistogramBucketBoundNotFinite = errors.New("histogram bucket bound is not finite")
+	errHistogramBucketsMismatch      = errors.New("histogram buckets do not match")
+	errInvalidHistogramBucketCount   = errors.New("histogram bucket count is not a non-negative integer")
+	errNoHistogram                   = errors.New("no histogram tracked")
+)
+
+// HistogramBuckets are the upper bounds of the buckets of a histogram in
+// ascending order. Values larger than the last bound are counted in an
+// implicit overflow bucket whose upper bound is +Inf.
+type HistogramBuckets []float64
+
+// NewFixedHistogramBuckets creates count buckets of the same width, with the
+// first bucket having an upper bound of start.
+func NewFixedHistogramBuckets(start, width float64, count int) (HistogramBuckets, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("invalid histogram bucket count %d", count)
+	}
+	if width <= 0 {
+		return nil, fmt.Errorf("invalid histogram bucket width %v", width)
+	}
+	buckets := make(HistogramBuckets, count)
+	for i := 0; i < count; i++ {
+		buckets[i] = start + float64(i)*width
+	}
+	return buckets, buckets.Validate()
+}
+
+// NewExponentialHistogramBuckets creates count buckets whose upper bounds grow
+// by factor, with the first bucket having an upper bound of start.
+func NewExponentialHistogramBuckets(start, factor float64, count int) (HistogramBuckets, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("invalid histogram bucket count %d", count)
+	}
+	if start <= 0 {
+		return nil, fmt.Errorf("invalid histogram bucket start %v", start)
+	}
+	if factor <= 1 {
+		return nil, fmt.Errorf("invalid histogram bucket factor %v", factor)
+	}
+	buckets := make(HistogramBuckets, count)
+	for i := 0; i < count; i++ {
+		buckets[i] = start * math.Pow(factor, float64(i))
+	}
+	return buckets, buckets.Validate()
+}
+
+// Validate validates the histogram buckets.
+func (b HistogramBuckets) Validate() error {
+	if len(b) == 0 {
+		return errEmptyHistogramBuckets
+	}
+	for i, bound := range b {
+		if math.IsNaN(bound) || math.IsInf(bound, 0) {
+			return errHistogramBucketBoundNotFinite
+		}
+		if i > 0 && bound <= b[i-1] {
+			return errHistogramBucketsNotAscending
+		}
+	}
+	return nil
+}
+
+// Equal returns true if the buckets have the same bounds.
+func (b HistogramBuckets) Equal(other HistogramBuckets) bool {
+	if len(b) != len(other) {
+		return false
+	}
+	for i := range b {
+		if b[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// UpperBound returns the upper bound of the bucket at the given index, which
+// is +Inf for the overflow bucket.
+func (b HistogramBuckets) UpperBound(idx int) float64 {
+	if idx >= len(b) {
+		return math.Inf(1)
+	}
+	return b[idx]
+}
+
+// HistogramBucketSuffix returns the suffix of the series holding the
+// cumulative count of the bucket with the given upper bound.
+func HistogramBucketSuffix(upperBound float64) []byte {
+	return append([]byte(histogramBucketSuffixPrefix), formatHistogramBound(upperBound)...)
+}
+
+// ParseHistogramBucketSuffix parses the upper bound from a histogram bucket
+// suffix, returning false if the suffix is not a histogram bucket suffix.
+func ParseHistogramBucketSuffix(suffix []byte) (string, bool) {
+	if len(suffix) <= len(histogramBucketSuffixPrefix) ||
+		string(suffix[:len(histogramBucketSuffixPrefix)]) != histogramBucketSuffixPrefix {
+		return "", false
+	}
+	bound := string(suffix[len(histogramBucketSuffixPrefix):])
+	if bound == histogramInfBoundString {
+		return bound, true
+	}
+	if _, err := strconv.ParseFloat(bound, 64); err != nil {
+		return "", false
+	}
+	return bound, true
+}
+
+func formatHistogramBound(bound float64) string {
+	if math.IsInf(bound, 1) {
+		return histogramInfBoundString
+	}
+	return strconv.FormatFloat(bound, 'f', -1, 64)
+}
+
+// Histogram counts values in buckets with fixed bounds. Histograms with the
+// same buckets can be merged without losing accuracy, which makes them
+// suitable for computing quantiles across aggregation stages. Histogram APIs
+// are not thread-safe.
+type Histogram struct {
+	buckets HistogramBuckets
+	counts  []int64 // Number of values in each bucket, including the overflow bucket.
+	count   int64   // Number of values received.
+	sum     float64 // Sum of the values.
+}
+
+// NewHistogram creates a new histogram.
+func NewHistogram(buckets HistogramBuckets) Histogram {
+	return Histogram{
+		buckets: buckets,
+		counts:  make([]int64, len(buckets)+1),
+	}
+}
+
+// Add adds a value.
+func (h *Histogram) Add(value float64) {
+	idx := sort.SearchFloat64s(h.buckets, value)
+	h.counts[idx]++
+	h.count++
+	h.sum += value
+}
+
+// Merge merges the values of another histogram with the same buckets.
+func (h *Histogram) Merge(other *Histogram) error {
+	if !h.buckets.Equal(other.buckets) {
+		return errHistogramBucketsMismatch
+	}
+	for i, c := range other.counts {
+		h.counts[i] += c
+	}
+	h.count += other.count
+	h.sum += other.sum
+	return nil
+}
+
+// AddBucketCounts adds the count of each bucket, including the overflow bucket,
+// of a histogram with the same buckets. The sum of the values counted is not
+// known and hence not updated.
+func (h *Histogram) AddBucketCounts(counts []float64) error {
+	if len(counts) != len(h.counts) {
+		return errHistogramBucketsMismatch
+	}
+	for _, c := range counts {
+		if c < 0 || c != math.Trunc(c) || math.IsInf(c, 0) {
+			return errInvalidHistogramBucketCount
+		}
+	}
+	for i, c := range counts {
+		h.counts[i] += int64(c)
+		h.count += int64(c)
+	}
+	return nil
+}
+
+// Buckets returns the histogram buckets.
+func (h *Histogram) Buckets() HistogramBuckets { return h.buckets }
+
+// NumBuckets returns the number of buckets, including the overflow bucket.
+func (h *Histogram) NumBuckets() int { return len(h.counts) }
+
+// BucketCount returns the number of values in the bucket at the given index.
+func (h *Histogram) BucketCount(idx int) int64 { return h.counts[idx] }
+
+// CumulativeCount returns the number of values less than or equal to the
+// upper bound of the bucket at the given index.
+func (h *Histogram) CumulativeCount(idx int) int64 {
+	var cumulative int64
+	for i := 0; i <= idx; i++ {
+		cumulative += h.counts[i]
+	}
+	return cumulative
+}
+
+// Count returns the number of values received.
+func (h *Histogram) Count() int64 { return h.count }
+
+// Sum returns the sum of the values received.
+func (h *Histogram) Sum() float64 { return h.sum }
+
+// Quantile returns the estimated value at a given quantile, interpolating
+// linearly within the bucket containing the quantile. Values in the overflow
+// bucket are estimated as the largest finite bucket bound.
+func (h *Histogram) Quantile(q float64) float64 {
+	if h.count == 0 {
+		return 0.0
+	}
+	if q <= 0 {
+		q = 0
+	} else if q > 1 {
+		q = 1
+	}
+	var (
+		rank       = q * float64(h.count)
+		cumulative int64
+	)
+	for i, c := range h.counts {
+		if c == 0 || float64(cumulative+c) < rank {
+			cumulative += c
+			continue
+		}
+		if i == len(h.buckets) {
+			return h.buckets[len(h.buckets)-1]
+		}
+		upper := h.buckets[i]
+		lower := 0.0
+		if i > 0 {
+			lower = h.buckets[i-1]
+		} else if upper <= 0 {
+			return upper
+		}
+		return lower + (upper-lower)*(rank-float64(cumulative))/float64(c)
+	}
+	return h.buckets[len(h.buckets)-1]
+}
+
+// HistogramSnapshot is a snapshot of the values counted by a histogram.
+type HistogramSnapshot struct {
+	Buckets HistogramBuckets
+	Counts  []int64
+	Count   int64
+	Sum     float64
+}
+
+// Snapshot returns a snapshot of the histogram values.
+func (h *Histogram) Snapshot() HistogramSnapshot {
+	counts := make([]int64, len(h.counts))
+	copy(counts, h.counts)
+	return HistogramSnapshot{
+		Buckets: h.buckets,
+		Counts:  counts,
+		Count:   h.count,
+		Sum:     h.sum,
+	}
+}
+
+// Restore restores the histogram values from a snapshot of a histogram with
+// the same buckets.
+func (h *Histogram) Restore(snapshot HistogramSnapshot) error {
+	if !h.buckets.Equal(snapshot.Buckets) || len(snapshot.Counts) != len(h.counts) {
+		return errHistogramBucketsMismatch
+	}
+	copy(h.counts, snapshot.Counts)
+	h.count = snapshot.Count
+	h.sum = snapshot.Sum
+	return nil
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFixedHistogramBuckets(t *testing.T) {
+	buckets, err := NewFixedHistogramBuckets(10, 5, 4)
+	require.NoError(t, err)
+	require.Equal(t, HistogramBuckets{10, 15, 20, 25}, buckets)
+
+	_, err = NewFixedHistogramBuckets(10, 0, 4)
+	require.Error(t, err)
+	_, err = NewFixedHistogramBuckets(10, 5, 0)
+	require.Error(t, err)
+}
+
+func TestNewExponentialHistogramBuckets(t *testing.T) {
+	buckets, err := NewExponentialHistogramBuckets(1, 2, 5)
+	require.NoError(t, err)
+	require.Equal(t, HistogramBuckets{1, 2, 4, 8, 16}, buckets)
+
+	_, err = NewExponentialHistogramBuckets(0, 2, 5)
+	require.Error(t, err)
+	_, err = NewExponentialHistogramBuckets(1, 1, 5)
+	require.Error(t, err)
+	_, err = NewExponentialHistogramBuckets(1, 2, -1)
+	require.Error(t, err)
+}
+
+func TestHistogramBucketsValidate(t *testing.T) {
+	require.NoError(t, HistogramBuckets{-1, 0, 1}.Validate())
+	require.Equal(t, errEmptyHistogramBuckets, HistogramBuckets{}.Validate())
+	require.Equal(t, errHistogramBucketsNotAscending, HistogramBuckets{1, 1}.Validate())
+	require.Equal(t, errHistogramBucketsNotAscending, HistogramBuckets{2, 1}.Validate())
+	require.Equal(t, errHistogramBucketBoundNotFinite, HistogramBuckets{1, math.Inf(1)}.Validate())
+	require.Equal(t, errHistogramBucketBoundNotFinite, HistogramBuckets{math.NaN()}.Validate())
+}
+
+func TestHistogramBucketSuffix(t *testing.T) {
+	inputs := []struct {
+		bound    float64
+		expected string
+	}{
+		{bound: 0.25, expected: "0.25"},
+		{bound: 10, expected: "10"},
+		{bound: -3, expected: "-3"},
+		{bound: math.Inf(1), expected: "+Inf"},
+	}
+	for _, input := range inputs {
+		suffix := HistogramBucketSuffix(input.bound)
+		require.Equal(t, ".bucket.le_"+input.expected, string(suffix))
+		bound, ok := ParseHistogramBucketSuffix(suffix)
+		require.True(t, ok)
+		require.Equal(t, input.expected, bound)
+	}
+
+	for _, suffix := range []string{"", ".p99", ".bucket.le_", ".bucket.le_foo"} {
+		_, ok := ParseHistogramBucketSuffix([]byte(suffix))
+		require.False(t, ok)
+	}
+}
+
+func TestHistogramAdd(t *testing.T) {
+	h := NewHistogram(HistogramBuckets{1, 2, 4})
+	for _, v := range []float64{0.5, 1, 1.5, 1.5, 3, 10} {
+		h.Add(v)
+	}
+	require.Equal(t, 4, h.NumBuckets())
+	require.Equal(t, int64(6), h.Count())
+	require.Equal(t, 17.5, h.Sum())
+
+	expectedCounts := []int64{2, 2, 1, 1}
+	expectedCumulative := []int64{2, 4, 5, 6}
+	for i := 0; i < h.NumBuckets(); i++ {
+		require.Equal(t, expectedCounts[i], h.BucketCount(i))
+		require.Equal(t, expectedCumulative[i], h.CumulativeCount(i))
+	}
+	require.Equal(t, math.Inf(1), h.Buckets().UpperBound(3))
+}
+
+func TestHistogramQuantile(t *testing.T) {
+	h := NewHistogram(HistogramBuckets{1, 2, 4})
+	require.Equal(t, 0.0, h.Quantile(0.5))
+
+	for _, v := range []float64{0.5, 1.5, 1.5, 3} {
+		h.Add(v)
+	}
+	require.Equal(t, 0.0, h.Quantile(0))
+	require.Equal(t, 1.0, h.Quantile(0.25))
+	require.Equal(t, 1.5, h.Quantile(0.5))
+	require.Equal(t, 4.0, h.Quantile(1))
+
+	// Values in the overflow bucket are capped at the largest bucket bound.
+	h.Add(100)
+	h.Add(100)
+	require.Equal(t, 4.0, h.Quantile(0.99))
+}
+
+func TestHistogramMerge(t *testing.T) {
+	buckets, err := NewExponentialHistogramBuckets(1, 2, 8)
+	require.NoError(t, err)
+
+	var (
+		merged   = NewHistogram(buckets)
+		combined = NewHistogram(buckets)
+	)
+	for i := 0; i < 3; i++ {
+		h := NewHistogram(buckets)
+		for j := 0; j < 50; j++ {
+			v := float64(i*50 + j)
+			h.Add(v)
+			combined.Add(v)
+		}
+		require.NoError(t, merged.Merge(&h))
+	}
+	require.Equal(t, combined.Snapshot(), merged.Snapshot())
+	require.Equal(t, combined.Quantile(0.99), merged.Quantile(0.99))
+
+	other := NewHistogram(HistogramBuckets{1, 2})
+	require.Equal(t, errHistogramBucketsMismatch, merged.Merge(&other))
+}
+
+func TestHistogramAddBucketCounts(t *testing.T) {
+	h := NewHistogram(HistogramBuckets{10, 100})
+	h.Add(5)
+	require.NoError(t, h.AddBucketCounts([]float64{1, 2, 3}))
+	require.Equal(t, int64(7), h.Count())
+	require.Equal(t, int64(2), h.BucketCount(0))
+	require.Equal(t, int64(2), h.BucketCount(1))
+	require.Equal(t, int64(3), h.BucketCount(2))
+
+	require.Equal(t, errHistogramBucketsMismatch, h.AddBucketCounts([]float64{1, 2}))
+	require.Equal(t, errInvalidHistogramBucketCount, h.AddBucketCounts([]float64{1, -1, 0}))
+	require.Equal(t, errInvalidHistogramBucketCount, h.AddBucketCounts([]float64{1, 0.5, 0}))
+	require.Equal(t, int64(7), h.Count())
+}
+
+func TestHistogramSnapshotRestore(t *testing.T) {
+	buckets := HistogramBuckets{1, 2, 4}
+	h := NewHistogram(buckets)
+	for _, v := range []float64{0.5, 1.5, 3, 5} {
+		h.Add(v)
+	}
+
+	restored := NewHistogram(buckets)
+	require.NoError(t, restored.Restore(h.Snapshot()))
+	require.Equal(t, h.Snapshot(), restored.Snapshot())
+
+	// Mutating the histogram does not change the snapshot taken.
+	snapshot := h.Snapshot()
+	h.Add(1)
+	require.Equal(t, int64(1), snapshot.Counts[1])
+
+	other := NewHistogram(HistogramBuckets{1, 2, 8})
+	require.Equal(t, errHistogramBucketsMismatch, other.Restore(snapshot))
+}
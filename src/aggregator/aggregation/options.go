@@ -31,6 +31,11 @@ type Options struct {
 	// HasExpensiveAggregations means expensive (multiplication／division)
 	// aggregation types are enabled.
 	HasExpensiveAggregations bool
+
+	// HistogramBuckets are the buckets of the histogram tracked by timers
+	// in addition to their quantile stream, or nil if timers do not track
+	// a histogram.
+	HistogramBuckets HistogramBuckets
 }
 
 // NewOptions creates a new aggregation options.
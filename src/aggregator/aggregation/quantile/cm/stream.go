@@ -187,6 +187,39 @@ func (s *stream) ResetSetData(quantiles []float64) {
 	s.compressMinRank = 0
 }
 
+func (s *stream) Snapshot() StreamSnapshot {
+	s.Flush()
+	snapshot := StreamSnapshot{
+		NumValues: s.numValues,
+		Samples:   make([]SampleSnapshot, 0, s.samples.Len()),
+	}
+	for sample := s.samples.Front(); sample != nil; sample = sample.next {
+		snapshot.Samples = append(snapshot.Samples, SampleSnapshot{
+			Value:    sample.value,
+			NumRanks: sample.numRanks,
+			Delta:    sample.delta,
+		})
+	}
+	return snapshot
+}
+
+func (s *stream) Restore(snapshot StreamSnapshot) {
+	s.releaseSamples()
+	s.insertAndCompressCounter = 0
+	s.flushCounter = 0
+	s.bufLess = s.bufLess[:0]
+	s.bufMore = s.bufMore[:0]
+	s.insertCursor = nil
+	s.compressCursor = nil
+	s.compressMinRank = 0
+	for _, ss := range snapshot.Samples {
+		sample := s.acquireSampleFn()
+		sample.setData(ss.Value, ss.NumRanks, ss.Delta)
+		s.samples.PushBack(sample)
+	}
+	s.numValues = snapshot.NumValues
+}
+
 func (s *stream) Close() {
 	if s.closed {
 		return
@@ -196,12 +229,7 @@ func (s *stream) Close() {
 	// Returning resources back to pools.
 	s.floatsPool.Put(s.bufLess)
 	s.floatsPool.Put(s.bufMore)
-	sample := s.samples.Front()
-	for sample != nil {
-		next := sample.next
-		s.releaseSampleFn(sample)
-		sample = next
-	}
+	s.releaseSamples()
 
 	// Clear out slices/lists/pointer to reduce GC overhead.
 	s.bufLess = nil
@@ -212,6 +240,17 @@ func (s *stream) Close() {
 	s.streamPool.Put(s)
 }
 
+// releaseSamples returns the samples in the stream to the pool.
+func (s *stream) releaseSamples() {
+	sample := s.samples.Front()
+	for sample != nil {
+		next := sample.next
+		s.releaseSampleFn(sample)
+		sample = next
+	}
+	s.samples.Reset()
+}
+
 // addToBuffer adds a new sample to the buffer.
 func (s *stream) addToBuffer(value float64) {
 	if s.numValues > 0 && value < s.insertPointValue() {
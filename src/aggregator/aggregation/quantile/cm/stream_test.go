@@ -97,6 +97,31 @@ func TestStreamWithThreeSamples(t *testing.T) {
 	}
 }
 
+func TestStreamSnapshotRestore(t *testing.T) {
+	opts := testStreamOptions()
+	s := NewStream(testQuantiles, opts)
+	for i := 0; i < 10000; i++ {
+		s.Add(float64(i))
+	}
+	snapshot := s.Snapshot()
+	require.Equal(t, int64(10000), snapshot.NumValues)
+
+	restored := NewStream(testQuantiles, opts)
+	restored.Add(-1.0)
+	restored.Restore(snapshot)
+	require.Equal(t, snapshot, restored.Snapshot())
+	require.Equal(t, s.Min(), restored.Min())
+	require.Equal(t, s.Max(), restored.Max())
+	for _, q := range testQuantiles {
+		require.Equal(t, s.Quantile(q), restored.Quantile(q))
+	}
+
+	// Values added after the restore are merged with the restored samples.
+	restored.Add(20000.0)
+	restored.Flush()
+	require.Equal(t, 20000.0, restored.Max())
+}
+
 func TestStreamWithIncreasingSamplesNoPeriodicInsertCompressNoPeriodicFlush(t *testing.T) {
 	opts := testStreamOptions()
 	testStreamWithIncreasingSamples(t, opts)
@@ -65,6 +65,26 @@ type Stream interface {
 
 	// ResetSetData resets the stream and sets data.
 	ResetSetData(quantiles []float64)
+
+	// Snapshot flushes the stream and returns a snapshot of its samples.
+	Snapshot() StreamSnapshot
+
+	// Restore replaces the samples of the stream with those in the snapshot.
+	Restore(snapshot StreamSnapshot)
+}
+
+// SampleSnapshot is a snapshot of a sample.
+type SampleSnapshot struct {
+	Value    float64
+	NumRanks int64
+	Delta    int64
+}
+
+// StreamSnapshot is a snapshot of the samples in a stream, used to
+// persist the state of a stream and restore it later.
+type StreamSnapshot struct {
+	NumValues int64
+	Samples   []SampleSnapshot
 }
 
 // StreamAlloc allocates a stream.
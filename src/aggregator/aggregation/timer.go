@@ -29,24 +29,54 @@ import (
 type Timer struct {
 	Options
 
-	count  int64     // Number of values received.
-	sum    float64   // Sum of the values.
-	sumSq  float64   // Sum of squared values.
-	stream cm.Stream // Stream of values received.
+	count     int64      // Number of values received.
+	sum       float64    // Sum of the values.
+	sumSq     float64    // Sum of squared values.
+	stream    cm.Stream  // Stream of values received.
+	histogram *Histogram // Histogram of values received, if histogram buckets are configured.
 }
 
 // NewTimer creates a new timer
 func NewTimer(quantiles []float64, streamOpts cm.Options, opts Options) Timer {
 	stream := streamOpts.StreamPool().Get()
 	stream.ResetSetData(quantiles)
+	var histogram *Histogram
+	if len(opts.HistogramBuckets) > 0 {
+		h := NewHistogram(opts.HistogramBuckets)
+		histogram = &h
+	}
 	return Timer{
-		Options: opts,
-		stream:  stream,
+		Options:   opts,
+		stream:    stream,
+		histogram: histogram,
 	}
 }
 
 // Add adds a timer value.
 func (t *Timer) Add(value float64) {
+	t.add(value)
+	if t.histogram != nil {
+		t.histogram.Add(value)
+	}
+}
+
+// AddForwardedHistogram adds values forwarded from a previous aggregation
+// stage along with the bucket counts of the histogram of the values they were
+// aggregated from. The forwarded values are not counted in the histogram.
+func (t *Timer) AddForwardedHistogram(values []float64, bucketCounts []float64) error {
+	if t.histogram == nil {
+		return errNoHistogram
+	}
+	if err := t.histogram.AddBucketCounts(bucketCounts); err != nil {
+		return err
+	}
+	for _, v := range values {
+		t.add(v)
+	}
+	return nil
+}
+
+func (t *Timer) add(value float64) {
 	t.count++
 	t.sum += value
 	t.stream.Add(value)
@@ -69,6 +99,10 @@ func (t *Timer) Quantile(q float64) float64 {
 	return t.stream.Quantile(q)
 }
 
+// Histogram returns the histogram of the timer values, or nil if the timer
+// does not track a histogram.
+func (t *Timer) Histogram() *Histogram { return t.histogram }
+
 // Count returns the number of values received.
 func (t *Timer) Count() int64 { return t.count }
 
@@ -128,5 +162,44 @@ func (t *Timer) ValueOf(aggType aggregation.Type) float64 {
 	return 0
 }
 
+// TimerSnapshot is a snapshot of the values aggregated by a timer.
+type TimerSnapshot struct {
+	Count     int64
+	Sum       float64
+	SumSq     float64
+	Stream    cm.StreamSnapshot
+	Histogram *HistogramSnapshot
+}
+
+// Snapshot returns a snapshot of the timer values.
+func (t *Timer) Snapshot() TimerSnapshot {
+	snapshot := TimerSnapshot{
+		Count:  t.count,
+		Sum:    t.sum,
+		SumSq:  t.sumSq,
+		Stream: t.stream.Snapshot(),
+	}
+	if t.histogram != nil {
+		histogram := t.histogram.Snapshot()
+		snapshot.Histogram = &histogram
+	}
+	return snapshot
+}
+
+// Restore restores the timer values from a snapshot. The histogram is only
+// restored if the timer tracks a histogram with the same buckets as the one
+// in the snapshot.
+func (t *Timer) Restore(snapshot TimerSnapshot) {
+	t.count = snapshot.Count
+	t.sum = snapshot.Sum
+	t.sumSq = snapshot.SumSq
+	t.stream.Restore(snapshot.Stream)
+	if t.histogram != nil && snapshot.Histogram != nil {
+		// NB: Restoring fails if the histogram buckets were reconfigured since
+		// the snapshot was taken, in which case the histogram starts out empty.
+		_ = t.histogram.Restore(*snapshot.Histogram)
+	}
+}
+
 // Close closes the timer.
 func (t *Timer) Close() { t.stream.Close() }
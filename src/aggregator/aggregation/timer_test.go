@@ -169,3 +169,70 @@ func TestTimerAggregationsNotExpensive(t *testing.T) {
 	// Closing the timer a second time should be a no op.
 	timer.Close()
 }
+
+func TestTimerSnapshotRestore(t *testing.T) {
+	opts := NewOptions()
+	opts.ResetSetData(testAggTypes)
+
+	timer := NewTimer(testQuantiles, cm.NewOptions(), opts)
+	for i := 1; i <= 100; i++ {
+		timer.Add(float64(i))
+	}
+
+	restored := NewTimer(testQuantiles, cm.NewOptions(), opts)
+	restored.Restore(timer.Snapshot())
+	require.Equal(t, timer.Snapshot(), restored.Snapshot())
+	for _, aggType := range testAggTypes {
+		require.Equal(t, timer.ValueOf(aggType), restored.ValueOf(aggType))
+	}
+}
+
+func TestTimerHistogram(t *testing.T) {
+	timer := NewTimer(testQuantiles, cm.NewOptions(), NewOptions())
+	require.Nil(t, timer.Histogram())
+	require.Nil(t, timer.Snapshot().Histogram)
+
+	opts := NewOptions()
+	opts.HistogramBuckets = HistogramBuckets{10, 50, 100}
+	timer = NewTimer(testQuantiles, cm.NewOptions(), opts)
+	for i := 1; i <= 100; i++ {
+		timer.Add(float64(i))
+	}
+	h := timer.Histogram()
+	require.NotNil(t, h)
+	require.Equal(t, timer.Count(), h.Count())
+	require.Equal(t, timer.Sum(), h.Sum())
+	require.Equal(t, int64(10), h.CumulativeCount(0))
+	require.Equal(t, int64(50), h.CumulativeCount(1))
+	require.Equal(t, int64(100), h.CumulativeCount(2))
+
+	restored := NewTimer(testQuantiles, cm.NewOptions(), opts)
+	restored.Restore(timer.Snapshot())
+	require.Equal(t, timer.Snapshot(), restored.Snapshot())
+
+	// Restoring a histogram with different buckets leaves the histogram empty.
+	opts.HistogramBuckets = HistogramBuckets{10, 100}
+	reconfigured := NewTimer(testQuantiles, cm.NewOptions(), opts)
+	reconfigured.Restore(timer.Snapshot())
+	require.Equal(t, timer.Count(), reconfigured.Count())
+	require.Equal(t, int64(0), reconfigured.Histogram().Count())
+}
+
+func TestTimerAddForwardedHistogram(t *testing.T) {
+	timer := NewTimer(testQuantiles, cm.NewOptions(), NewOptions())
+	require.Equal(t, errNoHistogram, timer.AddForwardedHistogram([]float64{1}, []float64{1, 0}))
+
+	opts := NewOptions()
+	opts.HistogramBuckets = HistogramBuckets{10, 100}
+	timer = NewTimer(testQuantiles, cm.NewOptions(), opts)
+	require.NoError(t, timer.AddForwardedHistogram([]float64{20, 30}, []float64{4, 5, 6}))
+	require.Equal(t, int64(2), timer.Count())
+	require.Equal(t, 50.0, timer.Sum())
+
+	// The forwarded values are not counted in the histogram.
+	h := timer.Histogram()
+	require.Equal(t, int64(15), h.Count())
+	require.Equal(t, int64(4), h.BucketCount(0))
+	require.Equal(t, int64(5), h.BucketCount(1))
+	require.Equal(t, int64(6), h.BucketCount(2))
+}
@@ -21,10 +21,28 @@
 package aggregator
 
 import (
+	"errors"
+	"math"
+
 	"github.com/m3db/m3/src/aggregator/aggregation"
 	"github.com/m3db/m3metrics/metric/unaggregated"
 )
 
+var (
+	errAggregationSnapshotTypeMismatch = errors.New("aggregation snapshot type does not match the aggregation type")
+	errAggregationHistogramNotTracked  = errors.New("aggregation does not track a histogram")
+	errInvalidForwardedHistogramFrame  = errors.New("invalid forwarded histogram frame")
+	errForwardedHistogramBucketsDiffer = errors.New("forwarded histogram frame has a different number of buckets")
+)
+
+// aggregationSnapshot is a snapshot of the values of an aggregation, where
+// only the snapshot matching the type of the aggregation is set.
+type aggregationSnapshot struct {
+	Counter *aggregation.CounterSnapshot
+	Gauge   *aggregation.GaugeSnapshot
+	Timer   *aggregation.TimerSnapshot
+}
+
 // counterAggregation is a counter aggregation.
 type counterAggregation struct {
 	aggregation.Counter
@@ -37,6 +55,25 @@ func newCounterAggregation(c aggregation.Counter) counterAggregation {
 func (c *counterAggregation) Add(value float64)                    { c.Counter.Update(int64(value)) }
 func (c *counterAggregation) AddUnion(mu unaggregated.MetricUnion) { c.Counter.Update(mu.CounterVal) }
 
+func (c *counterAggregation) Snapshot() aggregationSnapshot {
+	snapshot := c.Counter.Snapshot()
+	return aggregationSnapshot{Counter: &snapshot}
+}
+
+func (c *counterAggregation) Restore(snapshot aggregationSnapshot) error {
+	if snapshot.Counter == nil {
+		return errAggregationSnapshotTypeMismatch
+	}
+	c.Counter.Restore(*snapshot.Counter)
+	return nil
+}
+
+func (c *counterAggregation) Histogram() *aggregation.Histogram { return nil }
+
+func (c *counterAggregation) AddForwardedHistogram(_ []float64, _ []float64) error {
+	return errAggregationHistogramNotTracked
+}
+
 // timerAggregation is a timer aggregation.
 type timerAggregation struct {
 	aggregation.Timer
@@ -46,6 +83,19 @@ func newTimerAggregation(t aggregation.Timer) timerAggregation   { return timerA
 func (t *timerAggregation) Add(value float64)                    { t.Timer.Add(value) }
 func (t *timerAggregation) AddUnion(mu unaggregated.MetricUnion) { t.Timer.AddBatch(mu.BatchTimerVal) }
 
+func (t *timerAggregation) Snapshot() aggregationSnapshot {
+	snapshot := t.Timer.Snapshot()
+	return aggregationSnapshot{Timer: &snapshot}
+}
+
+func (t *timerAggregation) Restore(snapshot aggregationSnapshot) error {
+	if snapshot.Timer == nil {
+		return errAggregationSnapshotTypeMismatch
+	}
+	t.Timer.Restore(*snapshot.Timer)
+	return nil
+}
+
 // gaugeAggregation is a gauge aggregation.
 type gaugeAggregation struct {
 	aggregation.Gauge
@@ -54,3 +104,91 @@ type gaugeAggregation struct {
 func newGaugeAggregation(g aggregation.Gauge) gaugeAggregation   { return gaugeAggregation{Gauge: g} }
 func (g *gaugeAggregation) Add(value float64)                    { g.Gauge.Update(value) }
 func (g *gaugeAggregation) AddUnion(mu unaggregated.MetricUnion) { g.Gauge.Update(mu.GaugeVal) }
+
+func (g *gaugeAggregation) Snapshot() aggregationSnapshot {
+	snapshot := g.Gauge.Snapshot()
+	return aggregationSnapshot{Gauge: &snapshot}
+}
+
+func (g *gaugeAggregation) Restore(snapshot aggregationSnapshot) error {
+	if snapshot.Gauge == nil {
+		return errAggregationSnapshotTypeMismatch
+	}
+	g.Gauge.Restore(*snapshot.Gauge)
+	return nil
+}
+
+func (g *gaugeAggregation) Histogram() *aggregation.Histogram { return nil }
+
+func (g *gaugeAggregation) AddForwardedHistogram(_ []float64, _ []float64) error {
+	return errAggregationHistogramNotTracked
+}
+
+// forEachForwardedHistogramFrame calls fn with the aggregated values and the
+// histogram bucket counts of each frame in the values forwarded for an
+// aggregation tracking a histogram with numBuckets buckets. Each element
+// forwarding to the aggregation writes a frame holding its aggregated values,
+// followed by the count of each bucket, the number of buckets and the number
+// of aggregated values. Frames with a different number of buckets than the
+// aggregation are rejected, as the buckets of the forwarding element do not
+// line up with the buckets of the aggregation. All frames are validated
+// before fn is called for any of them.
+func forEachForwardedHistogramFrame(
+	values []float64,
+	numBuckets int,
+	fn func(values []float64, bucketCounts []float64) error,
+) error {
+	for rest := values; len(rest) > 0; {
+		var err error
+		if rest, _, _, err = lastForwardedHistogramFrame(rest, numBuckets); err != nil {
+			return err
+		}
+	}
+	for rest := values; len(rest) > 0; {
+		var frameValues, bucketCounts []float64
+		rest, frameValues, bucketCounts, _ = lastForwardedHistogramFrame(rest, numBuckets)
+		if err := fn(frameValues, bucketCounts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lastForwardedHistogramFrame splits the last frame from the forwarded values,
+// returning the values preceding the frame, and the aggregated values and the
+// histogram bucket counts of the frame.
+func lastForwardedHistogramFrame(
+	values []float64,
+	numBuckets int,
+) ([]float64, []float64, []float64, error) {
+	n := len(values)
+	if n < 2 {
+		return nil, nil, nil, errInvalidForwardedHistogramFrame
+	}
+	frameNumBuckets := values[n-2]
+	if !isNonNegativeInteger(frameNumBuckets) {
+		return nil, nil, nil, errInvalidForwardedHistogramFrame
+	}
+	if frameNumBuckets != float64(numBuckets) {
+		return nil, nil, nil, errForwardedHistogramBucketsDiffer
+	}
+	numValues := values[n-1]
+	if !isNonNegativeInteger(numValues) || numValues > float64(n-numBuckets-2) {
+		return nil, nil, nil, errInvalidForwardedHistogramFrame
+	}
+	var (
+		countsStart  = n - 2 - numBuckets
+		valuesStart  = countsStart - int(numValues)
+		bucketCounts = values[countsStart : n-2]
+	)
+	for _, c := range bucketCounts {
+		if !isNonNegativeInteger(c) {
+			return nil, nil, nil, errInvalidForwardedHistogramFrame
+		}
+	}
+	return values[:valuesStart], values[valuesStart:countsStart], bucketCounts, nil
+}
+
+func isNonNegativeInteger(v float64) bool {
+	return v >= 0 && !math.IsInf(v, 0) && v == math.Trunc(v)
+}
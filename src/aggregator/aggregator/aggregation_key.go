@@ -22,6 +22,8 @@ package aggregator
 
 import (
 	"github.com/m3db/m3metrics/aggregation"
+	"github.com/m3db/m3metrics/generated/proto/metricpb"
+	"github.com/m3db/m3metrics/metadata"
 	"github.com/m3db/m3metrics/pipeline/applied"
 	"github.com/m3db/m3metrics/policy"
 )
@@ -41,3 +43,41 @@ func (k aggregationKey) Equal(other aggregationKey) bool {
 		k.numForwardedTimes == other.numForwardedTimes &&
 		k.idPrefixSuffixType == other.idPrefixSuffixType
 }
+
+// toProto encodes the aggregation id, storage policy, pipeline and number of
+// forwarded times of the key as a forward metadata protobuf message.
+func (k aggregationKey) toProto() ([]byte, error) {
+	md := metadata.ForwardMetadata{
+		AggregationID:     k.aggregationID,
+		StoragePolicy:     k.storagePolicy,
+		Pipeline:          k.pipeline,
+		NumForwardedTimes: k.numForwardedTimes,
+	}
+	var pb metricpb.ForwardMetadata
+	if err := md.ToProto(&pb); err != nil {
+		return nil, err
+	}
+	return pb.Marshal()
+}
+
+// newAggregationKeyFromProto decodes an aggregation key encoded by toProto.
+func newAggregationKeyFromProto(
+	data []byte,
+	idPrefixSuffixType IDPrefixSuffixType,
+) (aggregationKey, error) {
+	var pb metricpb.ForwardMetadata
+	if err := pb.Unmarshal(data); err != nil {
+		return aggregationKey{}, err
+	}
+	var md metadata.ForwardMetadata
+	if err := md.FromProto(pb); err != nil {
+		return aggregationKey{}, err
+	}
+	return aggregationKey{
+		aggregationID:      md.AggregationID,
+		storagePolicy:      md.StoragePolicy,
+		pipeline:           md.Pipeline,
+		numForwardedTimes:  md.NumForwardedTimes,
+		idPrefixSuffixType: idPrefixSuffixType,
+	}, nil
+}
@@ -173,3 +173,31 @@ func TestAggregationKeyEqual(t *testing.T) {
 		require.Equal(t, input.expected, input.b.Equal(input.a))
 	}
 }
+
+func TestAggregationKeyProtoRoundTrip(t *testing.T) {
+	key := aggregationKey{
+		aggregationID: aggregation.MustCompressTypes(aggregation.Sum),
+		storagePolicy: policy.NewStoragePolicy(10*time.Second, xtime.Second, 48*time.Hour),
+		pipeline: applied.NewPipeline([]applied.OpUnion{
+			{
+				Type:           pipeline.TransformationOpType,
+				Transformation: pipeline.TransformationOp{Type: transformation.Absolute},
+			},
+			{
+				Type: pipeline.RollupOpType,
+				Rollup: applied.RollupOp{
+					ID:            []byte("foo.baz"),
+					AggregationID: aggregation.DefaultID,
+				},
+			},
+		}),
+		numForwardedTimes:  2,
+		idPrefixSuffixType: WithPrefixWithSuffix,
+	}
+	data, err := key.toProto()
+	require.NoError(t, err)
+
+	decoded, err := newAggregationKeyFromProto(data, WithPrefixWithSuffix)
+	require.NoError(t, err)
+	require.True(t, key.Equal(decoded))
+}
@@ -105,3 +105,43 @@ func TestGaugeAggregationAddUnion(t *testing.T) {
 	require.Equal(t, int64(3), g.Count())
 	require.Equal(t, 123.456, g.Sum())
 }
+
+func TestForEachForwardedHistogramFrame(t *testing.T) {
+	type frame struct {
+		values       []float64
+		bucketCounts []float64
+	}
+	var frames []frame
+	fn := func(values []float64, bucketCounts []float64) error {
+		frames = append(frames, frame{values: values, bucketCounts: bucketCounts})
+		return nil
+	}
+
+	// Two frames with two buckets, holding one and zero aggregated values.
+	values := []float64{1.5, 3, 4, 2, 1, 5, 6, 2, 0}
+	require.NoError(t, forEachForwardedHistogramFrame(values, 2, fn))
+	require.Equal(t, []frame{
+		{values: []float64{}, bucketCounts: []float64{5, 6}},
+		{values: []float64{1.5}, bucketCounts: []float64{3, 4}},
+	}, frames)
+
+	invalid := [][]float64{
+		{1},
+		{1.5, 3, 4, 2, 2},
+		{1.5, 3, 4, 2, 0.5},
+		{1.5, 3, -4, 2, 1},
+		{1.5, 3, 4, -2, 1},
+		{7, 1.5, 3, 4, 2, 1},
+	}
+	for _, values := range invalid {
+		frames = frames[:0]
+		require.Equal(t, errInvalidForwardedHistogramFrame, forEachForwardedHistogramFrame(values, 2, fn))
+		require.Empty(t, frames)
+	}
+
+	// Frames forwarded with a different number of buckets are rejected.
+	frames = frames[:0]
+	values = []float64{1.5, 3, 4, 2, 1, 5, 6, 7, 3, 0}
+	require.Equal(t, errForwardedHistogramBucketsDiffer, forEachForwardedHistogramFrame(values, 2, fn))
+	require.Empty(t, frames)
+}
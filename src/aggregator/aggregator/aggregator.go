@@ -31,6 +31,7 @@ import (
 
 	"github.com/m3db/m3/src/aggregator/aggregator/handler"
 	"github.com/m3db/m3/src/aggregator/client"
+	schema "github.com/m3db/m3/src/aggregator/generated/proto/flush"
 	"github.com/m3db/m3/src/aggregator/sharding"
 	"github.com/m3db/m3cluster/placement"
 	"github.com/m3db/m3cluster/shard"
@@ -58,6 +59,26 @@ var (
 	errShardNotOwned                 = errors.New("aggregator shard is not owned")
 )
 
+// IsTransientError returns true if a metric failed to be added because of a
+// transient condition, such as shard ownership or the placement changing or a
+// rate limit being exceeded, so that adding the metric again may succeed.
+func IsTransientError(err error) bool {
+	switch err {
+	case errShardNotOwned,
+		errActivePlacementChanged,
+		errAggregatorNotOpenOrClosed,
+		errAggregatorShardClosed,
+		errAggregatorShardNotWriteable,
+		errMetricMapClosed,
+		errEntryClosed,
+		errWriteNewMetricRateLimitExceeded,
+		errWriteValueRateLimitExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
 // Aggregator aggregates different types of metrics.
 type Aggregator interface {
 	// Open opens the aggregator.
@@ -100,6 +121,8 @@ type aggregator struct {
 	flushHandler      handler.Handler
 	adminClient       client.AdminClient
 	resignTimeout     time.Duration
+	checkpointStore   CheckpointStore
+	checkpointEvery   time.Duration
 
 	shardSetID          uint32
 	shardSetOpen        bool
@@ -112,6 +135,7 @@ type aggregator struct {
 	wg                  sync.WaitGroup
 	sleepFn             sleepFn
 	shardsPendingClose  int32
+	checkpointLock      sync.Mutex
 	metrics             aggregatorMetrics
 }
 
@@ -133,6 +157,8 @@ func NewAggregator(opts Options) Aggregator {
 		flushHandler:      opts.FlushHandler(),
 		adminClient:       opts.AdminClient(),
 		resignTimeout:     opts.ResignTimeout(),
+		checkpointStore:   opts.CheckpointStore(),
+		checkpointEvery:   opts.CheckpointEvery(),
 		metrics:           newAggregatorMetrics(scope, samplingRate, opts.MaxAllowedForwardingDelayFn()),
 		doneCh:            make(chan struct{}),
 		sleepFn:           time.Sleep,
@@ -146,6 +172,11 @@ func (agg *aggregator) Open() error {
 	if agg.state != aggregatorNotOpen {
 		return errAggregatorAlreadyOpenOrClosed
 	}
+	if agg.checkpointStore != nil {
+		if err := agg.flushManager.RegisterLeaderTakeoverFn(agg.restoreOwnedShards); err != nil {
+			return err
+		}
+	}
 	if err := agg.placementManager.Open(); err != nil {
 		return err
 	}
@@ -160,6 +191,10 @@ func (agg *aggregator) Open() error {
 		agg.wg.Add(1)
 		go agg.tick()
 	}
+	if agg.checkpointStore != nil && agg.checkpointEvery > 0 {
+		agg.wg.Add(1)
+		go agg.checkpoint()
+	}
 	agg.state = aggregatorOpen
 	return nil
 }
@@ -251,6 +286,9 @@ func (agg *aggregator) Close() error {
 		return errAggregatorNotOpenOrClosed
 	}
 	close(agg.doneCh)
+	// NB: the shards are checkpointed before they are closed so the in-flight
+	// aggregation state is restored when the aggregator restarts.
+	agg.checkpointShards(agg.ownedShardsWithLock())
 	for _, shardID := range agg.shardIDs {
 		agg.shards[shardID].Close()
 	}
@@ -322,10 +360,14 @@ func (agg *aggregator) processPlacementWithLock(
 	} else {
 		return err
 	}
-	agg.updateShardsWithLock(newStagedPlacement, newPlacement, newShardSet)
+	added := agg.updateShardsWithLock(newStagedPlacement, newPlacement, newShardSet)
 	if err := agg.updateShardSetIDWithLock(instance); err != nil {
 		return err
 	}
+	// NB: the shards added are restored after the shard set is opened so that
+	// the flush times of the shard set are available to skip the aggregation
+	// windows that have already been flushed.
+	agg.restoreShardsWithLock(added)
 	agg.metrics.placement.updated.Inc(1)
 	return nil
 }
@@ -437,9 +479,10 @@ func (agg *aggregator) updateShardsWithLock(
 	newStagedPlacement placement.ActiveStagedPlacement,
 	newPlacement placement.Placement,
 	newShardSet shard.Shards,
-) {
+) []*aggregatorShard {
 	var (
 		incoming []*aggregatorShard
+		added    []*aggregatorShard
 		closing  = make([]*aggregatorShard, 0, len(agg.shardIDs))
 	)
 	for _, shard := range agg.shards {
@@ -468,6 +511,7 @@ func (agg *aggregator) updateShardsWithLock(
 			incoming[shardID] = agg.shards[shardID]
 		} else {
 			incoming[shardID] = newAggregatorShard(shardID, agg.opts)
+			added = append(added, incoming[shardID])
 			agg.metrics.shards.add.Inc(1)
 		}
 		shardTimeRange := timeRange{
@@ -482,6 +526,112 @@ func (agg *aggregator) updateShardsWithLock(
 	agg.currStagedPlacement = newStagedPlacement
 	agg.currPlacement = newPlacement
 	agg.closeShardsAsync(closing)
+	return added
+}
+
+// restoreShardsWithLock restores the in-flight aggregation state of the shards
+// from their checkpoints if any.
+func (agg *aggregator) restoreShardsWithLock(shards []*aggregatorShard) {
+	if agg.checkpointStore == nil || len(shards) == 0 {
+		return
+	}
+	// NB: the flush times may not be available (e.g., the instance does not own
+	// a shard set), in which case all aggregation windows are restored and the
+	// ones already flushed are discarded by the follower flush manager.
+	flushTimes, err := agg.flushTimesManager.Get()
+	if err != nil {
+		flushTimes = nil
+	}
+	for _, shard := range shards {
+		data, err := agg.checkpointStore.Load(shard.ID())
+		if err == ErrCheckpointNotFound {
+			continue
+		}
+		if err != nil {
+			agg.metrics.checkpoint.loadErrors.Inc(1)
+			continue
+		}
+		var shardFlushTimes *schema.ShardFlushTimes
+		if flushTimes != nil {
+			shardFlushTimes = flushTimes.ByShard[shard.ID()]
+		}
+		if err := shard.Restore(data, shardFlushTimes); err != nil {
+			agg.metrics.checkpoint.restoreErrors.Inc(1)
+			continue
+		}
+		agg.metrics.checkpoint.restored.Inc(1)
+	}
+}
+
+// restoreOwnedShards restores the in-flight aggregation state of the owned
+// shards when the instance takes over as the leader, so that the windows
+// checkpointed but not yet flushed by the previous leader are not lost.
+func (agg *aggregator) restoreOwnedShards() {
+	agg.RLock()
+	defer agg.RUnlock()
+
+	if agg.state != aggregatorOpen {
+		return
+	}
+	agg.restoreShardsWithLock(agg.ownedShardsWithLock())
+}
+
+func (agg *aggregator) ownedShardsWithLock() []*aggregatorShard {
+	shards := make([]*aggregatorShard, 0, len(agg.shardIDs))
+	for _, shardID := range agg.shardIDs {
+		shards = append(shards, agg.shards[shardID])
+	}
+	return shards
+}
+
+func (agg *aggregator) checkpoint() {
+	defer agg.wg.Done()
+
+	ticker := time.NewTicker(agg.checkpointEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-agg.doneCh:
+			return
+		case <-ticker.C:
+			agg.RLock()
+			if agg.state != aggregatorOpen {
+				agg.RUnlock()
+				return
+			}
+			shards := agg.ownedShardsWithLock()
+			agg.RUnlock()
+			agg.checkpointShards(shards)
+		}
+	}
+}
+
+// checkpointShards checkpoints the in-flight aggregation state of the shards.
+func (agg *aggregator) checkpointShards(shards []*aggregatorShard) {
+	if agg.checkpointStore == nil {
+		return
+	}
+	agg.checkpointLock.Lock()
+	defer agg.checkpointLock.Unlock()
+
+	start := agg.nowFn()
+	for _, shard := range shards {
+		data, err := shard.Checkpoint()
+		if err == errAggregatorShardClosed {
+			continue
+		}
+		if err != nil {
+			agg.metrics.checkpoint.checkpointErrors.Inc(1)
+			continue
+		}
+		if err := agg.checkpointStore.Store(shard.ID(), data); err != nil {
+			agg.metrics.checkpoint.storeErrors.Inc(1)
+			continue
+		}
+		agg.metrics.checkpoint.checkpointed.Inc(1)
+	}
+	agg.metrics.checkpoint.duration.Record(agg.nowFn().Sub(start))
 }
 
 func (agg *aggregator) checkMetricType(mu unaggregated.MetricUnion) error {
@@ -836,6 +986,28 @@ func (m aggregatorTickMetrics) Report(tickResult tickResult, duration time.Durat
 	m.forwarded.Report(tickResult.forwarded)
 }
 
+type aggregatorCheckpointMetrics struct {
+	checkpointed     tally.Counter
+	checkpointErrors tally.Counter
+	storeErrors      tally.Counter
+	restored         tally.Counter
+	loadErrors       tally.Counter
+	restoreErrors    tally.Counter
+	duration         tally.Timer
+}
+
+func newAggregatorCheckpointMetrics(scope tally.Scope) aggregatorCheckpointMetrics {
+	return aggregatorCheckpointMetrics{
+		checkpointed:     scope.Counter("checkpointed"),
+		checkpointErrors: scope.Counter("checkpoint-errors"),
+		storeErrors:      scope.Counter("store-errors"),
+		restored:         scope.Counter("restored"),
+		loadErrors:       scope.Counter("load-errors"),
+		restoreErrors:    scope.Counter("restore-errors"),
+		duration:         scope.Timer("duration"),
+	}
+}
+
 type aggregatorShardsMetrics struct {
 	add          tally.Counter
 	close        tally.Counter
@@ -900,6 +1072,7 @@ type aggregatorMetrics struct {
 	shards       aggregatorShardsMetrics
 	shardSetID   aggregatorShardSetIDMetrics
 	tick         aggregatorTickMetrics
+	checkpoint   aggregatorCheckpointMetrics
 }
 
 func newAggregatorMetrics(
@@ -914,6 +1087,7 @@ func newAggregatorMetrics(
 	shardsScope := scope.SubScope("shards")
 	shardSetIDScope := scope.SubScope("shard-set-id")
 	tickScope := scope.SubScope("tick")
+	checkpointScope := scope.SubScope("checkpoint")
 	return aggregatorMetrics{
 		counters:     scope.Counter("counters"),
 		timers:       scope.Counter("timers"),
@@ -928,6 +1102,7 @@ func newAggregatorMetrics(
 		shards:       newAggregatorShardsMetrics(shardsScope),
 		shardSetID:   newAggregatorShardSetIDMetrics(shardSetIDScope),
 		tick:         newAggregatorTickMetrics(tickScope),
+		checkpoint:   newAggregatorCheckpointMetrics(checkpointScope),
 	}
 }
 
@@ -1005,6 +1005,31 @@ func TestAggregatorAddTimedMetrics(t *testing.T) {
 	require.Equal(t, 0, len(gauges))
 }
 
+func TestIsTransientError(t *testing.T) {
+	for _, err := range []error{
+		errShardNotOwned,
+		errActivePlacementChanged,
+		errAggregatorShardNotWriteable,
+		errWriteNewMetricRateLimitExceeded,
+		errWriteValueRateLimitExceeded,
+	} {
+		require.True(t, IsTransientError(err), err.Error())
+	}
+
+	for _, err := range []error{
+		errTooFarInTheFuture,
+		errTooFarInThePast,
+		errArrivedTooLate,
+		errEmptyMetadatas,
+		errNoPipelinesInMetadata,
+		errInvalidMetricType,
+		errors.New("foo"),
+	} {
+		require.False(t, IsTransientError(err), err.Error())
+	}
+	require.False(t, IsTransientError(nil))
+}
+
 func testAggregator(t *testing.T, ctrl *gomock.Controller) (*aggregator, kv.Store) {
 	proto := testStagedPlacementProtoWithNumShards(t, testInstanceID, testShardSetID, testNumShards)
 	return testAggregatorWithCustomPlacements(t, ctrl, proto)
@@ -0,0 +1,354 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregator
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/m3db/m3/src/aggregator/aggregation"
+	"github.com/m3db/m3/src/aggregator/aggregation/quantile/cm"
+	checkpointpb "github.com/m3db/m3/src/aggregator/generated/proto/checkpoint"
+	schema "github.com/m3db/m3/src/aggregator/generated/proto/flush"
+	"github.com/m3db/m3metrics/metric"
+)
+
+const (
+	checkpointVersion       = 2
+	checkpointFileSuffix    = ".checkpoint"
+	checkpointTmpFileSuffix = ".tmp"
+	checkpointDirPerm       = 0755
+	checkpointFilePerm      = 0644
+)
+
+var (
+	// ErrCheckpointNotFound is returned when there is no checkpoint for a shard.
+	ErrCheckpointNotFound = errors.New("checkpoint not found")
+
+	errCheckpointVersionMismatch = errors.New("checkpoint version mismatch")
+)
+
+// CheckpointStore stores checkpoints of the in-flight aggregation state of shards
+// so that the state can be restored after the aggregator restarts.
+type CheckpointStore interface {
+	// Store stores the checkpoint of a shard, replacing the existing one if any.
+	Store(shard uint32, data []byte) error
+
+	// Load loads the checkpoint of a shard, or returns ErrCheckpointNotFound
+	// if there is no checkpoint for the shard.
+	Load(shard uint32) ([]byte, error)
+}
+
+// isWindowFlushedFn determines whether an aggregation window starting at
+// a given time has already been flushed.
+type isWindowFlushedFn func(windowStartNanos int64) bool
+
+// listFlushedNanosFn returns the time before which the aggregation windows
+// of a metric list have been flushed.
+type listFlushedNanosFn func(listID metricListID) int64
+
+// windowCheckpoint is a checkpoint of an aggregation window.
+type windowCheckpoint struct {
+	StartAtNanos int64
+	SourcesSeen  []uint64
+	Aggregation  aggregationSnapshot
+}
+
+// elemCheckpoint is a checkpoint of the aggregation windows of an element.
+type elemCheckpoint struct {
+	ListType           metricListType
+	MetricType         metric.Type
+	ID                 []byte
+	Key                []byte
+	IDPrefixSuffixType IDPrefixSuffixType
+	Windows            []windowCheckpoint
+}
+
+// listID returns the id of the metric list the element belongs to.
+func (c elemCheckpoint) listID(key aggregationKey) metricListID {
+	resolution := key.storagePolicy.Resolution().Window
+	switch c.ListType {
+	case forwardedMetricListType:
+		return forwardedMetricListID{
+			resolution:        resolution,
+			numForwardedTimes: key.numForwardedTimes,
+		}.toMetricListID()
+	case timedMetricListType:
+		return timedMetricListID{resolution: resolution}.toMetricListID()
+	default:
+		return standardMetricListID{resolution: resolution}.toMetricListID()
+	}
+}
+
+// shardCheckpoint is a checkpoint of the in-flight aggregation state of a shard.
+type shardCheckpoint struct {
+	Version        int
+	Shard          uint32
+	CreatedAtNanos int64
+	Elems          []elemCheckpoint
+}
+
+func encodeShardCheckpoint(checkpoint shardCheckpoint) ([]byte, error) {
+	pb := &checkpointpb.ShardCheckpoint{
+		Version:        int32(checkpoint.Version),
+		Shard:          checkpoint.Shard,
+		CreatedAtNanos: checkpoint.CreatedAtNanos,
+		Elems:          make([]*checkpointpb.ElemCheckpoint, 0, len(checkpoint.Elems)),
+	}
+	for _, elem := range checkpoint.Elems {
+		pb.Elems = append(pb.Elems, elem.toProto())
+	}
+	return pb.Marshal()
+}
+
+func decodeShardCheckpoint(data []byte) (shardCheckpoint, error) {
+	var pb checkpointpb.ShardCheckpoint
+	if err := pb.Unmarshal(data); err != nil {
+		return shardCheckpoint{}, err
+	}
+	if int(pb.Version) != checkpointVersion {
+		return shardCheckpoint{}, errCheckpointVersionMismatch
+	}
+	checkpoint := shardCheckpoint{
+		Version:        int(pb.Version),
+		Shard:          pb.Shard,
+		CreatedAtNanos: pb.CreatedAtNanos,
+	}
+	if len(pb.Elems) > 0 {
+		checkpoint.Elems = make([]elemCheckpoint, 0, len(pb.Elems))
+	}
+	for _, elemPB := range pb.Elems {
+		checkpoint.Elems = append(checkpoint.Elems, newElemCheckpointFromProto(elemPB))
+	}
+	return checkpoint, nil
+}
+
+func (c elemCheckpoint) toProto() *checkpointpb.ElemCheckpoint {
+	pb := &checkpointpb.ElemCheckpoint{
+		ListType:           int32(c.ListType),
+		MetricType:         int32(c.MetricType),
+		Id:                 c.ID,
+		Key:                c.Key,
+		IdPrefixSuffixType: int32(c.IDPrefixSuffixType),
+		Windows:            make([]*checkpointpb.WindowCheckpoint, 0, len(c.Windows)),
+	}
+	for _, window := range c.Windows {
+		pb.Windows = append(pb.Windows, &checkpointpb.WindowCheckpoint{
+			StartAtNanos: window.StartAtNanos,
+			SourcesSeen:  window.SourcesSeen,
+			Aggregation:  window.Aggregation.toProto(),
+		})
+	}
+	return pb
+}
+
+func newElemCheckpointFromProto(pb *checkpointpb.ElemCheckpoint) elemCheckpoint {
+	checkpoint := elemCheckpoint{
+		ListType:           metricListType(pb.ListType),
+		MetricType:         metric.Type(pb.MetricType),
+		ID:                 pb.Id,
+		Key:                pb.Key,
+		IDPrefixSuffixType: IDPrefixSuffixType(pb.IdPrefixSuffixType),
+	}
+	if len(pb.Windows) > 0 {
+		checkpoint.Windows = make([]windowCheckpoint, 0, len(pb.Windows))
+	}
+	for _, windowPB := range pb.Windows {
+		checkpoint.Windows = append(checkpoint.Windows, windowCheckpoint{
+			StartAtNanos: windowPB.StartAtNanos,
+			SourcesSeen:  windowPB.SourcesSeen,
+			Aggregation:  newAggregationSnapshotFromProto(windowPB.Aggregation),
+		})
+	}
+	return checkpoint
+}
+
+func (s aggregationSnapshot) toProto() *checkpointpb.AggregationSnapshot {
+	pb := &checkpointpb.AggregationSnapshot{}
+	if s.Counter != nil {
+		pb.Counter = &checkpointpb.CounterSnapshot{
+			Sum:   s.Counter.Sum,
+			SumSq: s.Counter.SumSq,
+			Count: s.Counter.Count,
+			Max:   s.Counter.Max,
+			Min:   s.Counter.Min,
+		}
+	}
+	if s.Gauge != nil {
+		pb.Gauge = &checkpointpb.GaugeSnapshot{
+			Last:  s.Gauge.Last,
+			Sum:   s.Gauge.Sum,
+			SumSq: s.Gauge.SumSq,
+			Count: s.Gauge.Count,
+			Max:   s.Gauge.Max,
+			Min:   s.Gauge.Min,
+		}
+	}
+	if s.Timer != nil {
+		samples := make([]*checkpointpb.SampleSnapshot, 0, len(s.Timer.Stream.Samples))
+		for _, sample := range s.Timer.Stream.Samples {
+			samples = append(samples, &checkpointpb.SampleSnapshot{
+				Value:    sample.Value,
+				NumRanks: sample.NumRanks,
+				Delta:    sample.Delta,
+			})
+		}
+		pb.Timer = &checkpointpb.TimerSnapshot{
+			Count: s.Timer.Count,
+			Sum:   s.Timer.Sum,
+			SumSq: s.Timer.SumSq,
+			Stream: &checkpointpb.StreamSnapshot{
+				NumValues: s.Timer.Stream.NumValues,
+				Samples:   samples,
+			},
+		}
+		if h := s.Timer.Histogram; h != nil {
+			pb.Timer.Histogram = &checkpointpb.HistogramSnapshot{
+				Buckets: h.Buckets,
+				Counts:  h.Counts,
+				Count:   h.Count,
+				Sum:     h.Sum,
+			}
+		}
+	}
+	return pb
+}
+
+func newAggregationSnapshotFromProto(pb *checkpointpb.AggregationSnapshot) aggregationSnapshot {
+	var snapshot aggregationSnapshot
+	if pb == nil {
+		return snapshot
+	}
+	if c := pb.Counter; c != nil {
+		snapshot.Counter = &aggregation.CounterSnapshot{
+			Sum:   c.Sum,
+			SumSq: c.SumSq,
+			Count: c.Count,
+			Max:   c.Max,
+			Min:   c.Min,
+		}
+	}
+	if g := pb.Gauge; g != nil {
+		snapshot.Gauge = &aggregation.GaugeSnapshot{
+			Last:  g.Last,
+			Sum:   g.Sum,
+			SumSq: g.SumSq,
+			Count: g.Count,
+			Max:   g.Max,
+			Min:   g.Min,
+		}
+	}
+	if t := pb.Timer; t != nil {
+		snapshot.Timer = &aggregation.TimerSnapshot{
+			Count: t.Count,
+			Sum:   t.Sum,
+			SumSq: t.SumSq,
+		}
+		if t.Stream != nil {
+			snapshot.Timer.Stream.NumValues = t.Stream.NumValues
+			if len(t.Stream.Samples) > 0 {
+				snapshot.Timer.Stream.Samples = make([]cm.SampleSnapshot, 0, len(t.Stream.Samples))
+			}
+			for _, sample := range t.Stream.Samples {
+				snapshot.Timer.Stream.Samples = append(snapshot.Timer.Stream.Samples, cm.SampleSnapshot{
+					Value:    sample.Value,
+					NumRanks: sample.NumRanks,
+					Delta:    sample.Delta,
+				})
+			}
+		}
+		if h := t.Histogram; h != nil {
+			snapshot.Timer.Histogram = &aggregation.HistogramSnapshot{
+				Buckets: h.Buckets,
+				Counts:  h.Counts,
+				Count:   h.Count,
+				Sum:     h.Sum,
+			}
+		}
+	}
+	return snapshot
+}
+
+// newListFlushedNanosFn returns a function returning the flushed times of the
+// metric lists of a shard from the shard flush times, which may be nil if the
+// flush times of the shard are not known.
+func newListFlushedNanosFn(flushTimes *schema.ShardFlushTimes) listFlushedNanosFn {
+	return func(listID metricListID) int64 {
+		if flushTimes == nil {
+			return 0
+		}
+		switch listID.listType {
+		case standardMetricListType:
+			return flushTimes.StandardByResolution[int64(listID.standard.resolution)]
+		case forwardedMetricListType:
+			forwardedFlushTimes, exists := flushTimes.ForwardedByResolution[int64(listID.forwarded.resolution)]
+			if !exists || forwardedFlushTimes == nil {
+				return 0
+			}
+			return forwardedFlushTimes.ByNumForwardedTimes[int32(listID.forwarded.numForwardedTimes)]
+		case timedMetricListType:
+			return flushTimes.TimedByResolution[int64(listID.timed.resolution)]
+		default:
+			return 0
+		}
+	}
+}
+
+// fileCheckpointStore stores the checkpoint of each shard in a file under
+// a directory on local disk.
+type fileCheckpointStore struct {
+	dir string
+}
+
+// NewFileCheckpointStore creates a new checkpoint store storing checkpoints
+// in files under the given directory.
+func NewFileCheckpointStore(dir string) CheckpointStore {
+	return &fileCheckpointStore{dir: dir}
+}
+
+func (s *fileCheckpointStore) Store(shard uint32, data []byte) error {
+	if err := os.MkdirAll(s.dir, checkpointDirPerm); err != nil {
+		return err
+	}
+	// NB: the checkpoint is written to a temporary file first and then renamed
+	// so that a partially written checkpoint never replaces a complete one.
+	filePath := s.filePath(shard)
+	tmpFilePath := filePath + checkpointTmpFileSuffix
+	if err := ioutil.WriteFile(tmpFilePath, data, checkpointFilePerm); err != nil {
+		return err
+	}
+	return os.Rename(tmpFilePath, filePath)
+}
+
+func (s *fileCheckpointStore) Load(shard uint32) ([]byte, error) {
+	data, err := ioutil.ReadFile(s.filePath(shard))
+	if os.IsNotExist(err) {
+		return nil, ErrCheckpointNotFound
+	}
+	return data, err
+}
+
+func (s *fileCheckpointStore) filePath(shard uint32) string {
+	return filepath.Join(s.dir, fmt.Sprintf("shard-%d%s", shard, checkpointFileSuffix))
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregator
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	schema "github.com/m3db/m3/src/aggregator/generated/proto/flush"
+	"github.com/m3db/m3x/clock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileCheckpointStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checkpoint")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store := NewFileCheckpointStore(dir)
+	_, err = store.Load(testShard)
+	require.Equal(t, ErrCheckpointNotFound, err)
+
+	require.NoError(t, store.Store(testShard, []byte("foo")))
+	require.NoError(t, store.Store(testShard, []byte("bar")))
+	data, err := store.Load(testShard)
+	require.NoError(t, err)
+	require.Equal(t, []byte("bar"), data)
+
+	_, err = store.Load(testShard + 1)
+	require.Equal(t, ErrCheckpointNotFound, err)
+}
+
+func TestAggregatorShardCheckpointRestore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Unix(0, 12345)
+	clockOpts := clock.NewOptions().SetNowFn(func() time.Time {
+		return now
+	})
+	opts := testOptions(ctrl).SetClockOptions(clockOpts)
+	shard := newAggregatorShard(testShard, opts)
+	shard.SetWriteableRange(timeRange{cutoverNanos: 0, cutoffNanos: math.MaxInt64})
+	require.NoError(t, shard.AddUntimed(testCounter, testDefaultStagedMetadatas))
+	require.NoError(t, shard.AddUntimed(testBatchTimer, testDefaultStagedMetadatas))
+	require.NoError(t, shard.AddTimed(testTimedMetric, testTimedMetadata))
+	require.NoError(t, shard.AddForwarded(testForwardedMetric, testForwardMetadata))
+
+	data, err := shard.Checkpoint()
+	require.NoError(t, err)
+	expected, err := decodeShardCheckpoint(data)
+	require.NoError(t, err)
+	require.Equal(t, testShard, expected.Shard)
+	require.Equal(t, now.UnixNano(), expected.CreatedAtNanos)
+	numDefaultStoragePolicies := len(opts.DefaultStoragePolicies())
+	require.Equal(t, 2*numDefaultStoragePolicies+2, len(expected.Elems))
+
+	// Restore the checkpoint into a new shard and assert the restored state
+	// matches the state checkpointed.
+	restored := newAggregatorShard(testShard, opts)
+	require.NoError(t, restored.Restore(data, nil))
+	restoredData, err := restored.Checkpoint()
+	require.NoError(t, err)
+	actual, err := decodeShardCheckpoint(restoredData)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	// Restore the checkpoint into a new shard whose standard metric lists have
+	// been flushed and assert only the timed and forwarded metrics are restored.
+	flushTimes := &schema.ShardFlushTimes{
+		StandardByResolution: make(map[int64]int64),
+	}
+	for _, sp := range opts.DefaultStoragePolicies() {
+		flushTimes.StandardByResolution[int64(sp.Resolution().Window)] = now.Add(time.Hour).UnixNano()
+	}
+	restored = newAggregatorShard(testShard, opts)
+	require.NoError(t, restored.Restore(data, flushTimes))
+	restoredData, err = restored.Checkpoint()
+	require.NoError(t, err)
+	actual, err = decodeShardCheckpoint(restoredData)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(actual.Elems))
+	require.Equal(t, timedMetricListType, actual.Elems[0].ListType)
+	require.Equal(t, forwardedMetricListType, actual.Elems[1].ListType)
+}
+
+func TestAggregatorShardRestoreDifferentShard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := testOptions(ctrl)
+	data, err := newAggregatorShard(testShard, opts).Checkpoint()
+	require.NoError(t, err)
+	require.Equal(t, errCheckpointShardMismatch, newAggregatorShard(testShard+1, opts).Restore(data, nil))
+}
@@ -143,6 +143,14 @@ type metricElem interface {
 	// will be deleted once its aggregated values have been flushed.
 	MarkAsTombstoned()
 
+	// Checkpoint returns a checkpoint of the aggregation windows that have
+	// not been consumed yet.
+	Checkpoint() []windowCheckpoint
+
+	// Restore restores the aggregation windows from a checkpoint, skipping
+	// the windows that have already been flushed.
+	Restore(windows []windowCheckpoint, isFlushedFn isWindowFlushedFn) error
+
 	// Close closes the element.
 	Close()
 }
@@ -301,6 +309,7 @@ func (e timerElemBase) TypeStringFor(aggTypesOpts maggregation.TypesOptions, agg
 func (e timerElemBase) ElemPool(opts Options) TimerElemPool { return opts.TimerElemPool() }
 
 func (e timerElemBase) NewAggregation(opts Options, aggOpts raggregation.Options) timerAggregation {
+	aggOpts.HistogramBuckets = opts.TimerHistogramBuckets()
 	newTimer := raggregation.NewTimer(e.quantiles, opts.StreamOptions(), aggOpts)
 	return newTimerAggregation(newTimer)
 }
@@ -1082,6 +1082,144 @@ func TestTimerElemConsumeCustomAggregationCustomPipeline(t *testing.T) {
 	require.Equal(t, 0, len(e.values))
 }
 
+func TestTimerElemConsumeWithHistogram(t *testing.T) {
+	opts := NewOptions().SetTimerHistogramBuckets(raggregation.HistogramBuckets{2, 5})
+	aggTypes := maggregation.Types{maggregation.Count}
+	e, err := NewTimerElem(testBatchTimerID, testStoragePolicy, aggTypes, applied.DefaultPipeline, testNumForwardedTimes, WithPrefixWithSuffix, opts)
+	require.NoError(t, err)
+	require.NoError(t, e.AddUnion(testTimestamps[0], testBatchTimer))
+
+	localFn, localRes := testFlushLocalMetricFn()
+	forwardFn, forwardRes := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, onForwardedFlushedRes := testOnForwardedFlushedFn()
+	require.False(t, e.Consume(testAlignedStarts[1], isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn))
+
+	var (
+		fullPrefix = opts.FullTimerPrefix()
+		timeNanos  = testAlignedStarts[1]
+		expected   = []testLocalMetricWithMetadata{
+			{
+				idPrefix:  fullPrefix,
+				id:        testBatchTimerID,
+				idSuffix:  opts.AggregationTypesOptions().TypeStringForTimer(maggregation.Count),
+				timeNanos: timeNanos,
+				value:     5,
+				sp:        testStoragePolicy,
+			},
+			{
+				idPrefix:  fullPrefix,
+				id:        testBatchTimerID,
+				idSuffix:  []byte(".bucket.le_2"),
+				timeNanos: timeNanos,
+				value:     1,
+				sp:        testStoragePolicy,
+			},
+			{
+				idPrefix:  fullPrefix,
+				id:        testBatchTimerID,
+				idSuffix:  []byte(".bucket.le_5"),
+				timeNanos: timeNanos,
+				value:     4,
+				sp:        testStoragePolicy,
+			},
+			{
+				idPrefix:  fullPrefix,
+				id:        testBatchTimerID,
+				idSuffix:  []byte(".bucket.le_+Inf"),
+				timeNanos: timeNanos,
+				value:     5,
+				sp:        testStoragePolicy,
+			},
+		}
+	)
+	require.Equal(t, expected, *localRes)
+	require.Equal(t, 0, len(*forwardRes))
+	require.Equal(t, 0, len(*onForwardedFlushedRes))
+	require.Equal(t, 0, len(e.values))
+}
+
+func TestTimerElemForwardHistogram(t *testing.T) {
+	opts := NewOptions().SetTimerHistogramBuckets(raggregation.HistogramBuckets{2, 5})
+	aggTypes := maggregation.Types{maggregation.Count}
+	rollupPipeline := applied.NewPipeline([]applied.OpUnion{
+		{
+			Type: pipeline.RollupOpType,
+			Rollup: applied.RollupOp{
+				ID:            []byte("foo.bar"),
+				AggregationID: maggregation.MustCompressTypes(maggregation.Count),
+			},
+		},
+	})
+	e, err := NewTimerElem(testBatchTimerID, testStoragePolicy, aggTypes, rollupPipeline, testNumForwardedTimes, WithPrefixWithSuffix, opts)
+	require.NoError(t, err)
+	require.NoError(t, e.AddUnion(testTimestamps[0], testBatchTimer))
+
+	localFn, localRes := testFlushLocalMetricFn()
+	forwardFn, forwardRes := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, _ := testOnForwardedFlushedFn()
+	require.False(t, e.Consume(testAlignedStarts[1], isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, 0, len(*localRes))
+
+	// The count is followed by the bucket counts, the number of buckets and
+	// the number of values.
+	var forwarded []float64
+	for _, res := range *forwardRes {
+		forwarded = append(forwarded, res.value)
+	}
+	require.Equal(t, []float64{5, 1, 3, 1, 3, 1}, forwarded)
+
+	// Merge the histograms forwarded by two sources in the next stage.
+	rollup, err := NewTimerElem([]byte("foo.bar"), testStoragePolicy, aggTypes, applied.DefaultPipeline, testNumForwardedTimes+1, WithPrefixWithSuffix, opts)
+	require.NoError(t, err)
+	require.NoError(t, rollup.AddUnique(testTimestamps[0], forwarded, 0))
+	require.NoError(t, rollup.AddUnique(testTimestamps[0], forwarded, 1))
+	require.Equal(t, errInvalidForwardedHistogramFrame, rollup.AddUnique(testTimestamps[0], []float64{5}, 2))
+	require.Equal(t, errForwardedHistogramBucketsDiffer, rollup.AddUnique(testTimestamps[0], []float64{5, 1, 4, 2, 1}, 2))
+
+	localFn, localRes = testFlushLocalMetricFn()
+	require.False(t, rollup.Consume(testAlignedStarts[1], isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn))
+
+	var (
+		fullPrefix = opts.FullTimerPrefix()
+		timeNanos  = testAlignedStarts[1]
+		expected   = []testLocalMetricWithMetadata{
+			{
+				idPrefix:  fullPrefix,
+				id:        []byte("foo.bar"),
+				idSuffix:  opts.AggregationTypesOptions().TypeStringForTimer(maggregation.Count),
+				timeNanos: timeNanos,
+				value:     2,
+				sp:        testStoragePolicy,
+			},
+			{
+				idPrefix:  fullPrefix,
+				id:        []byte("foo.bar"),
+				idSuffix:  []byte(".bucket.le_2"),
+				timeNanos: timeNanos,
+				value:     2,
+				sp:        testStoragePolicy,
+			},
+			{
+				idPrefix:  fullPrefix,
+				id:        []byte("foo.bar"),
+				idSuffix:  []byte(".bucket.le_5"),
+				timeNanos: timeNanos,
+				value:     8,
+				sp:        testStoragePolicy,
+			},
+			{
+				idPrefix:  fullPrefix,
+				id:        []byte("foo.bar"),
+				idSuffix:  []byte(".bucket.le_+Inf"),
+				timeNanos: timeNanos,
+				value:     10,
+				sp:        testStoragePolicy,
+			},
+		}
+	)
+	require.Equal(t, expected, *localRes)
+}
+
 func TestTimerElemClose(t *testing.T) {
 	// Set up stream options.
 	streamOpts, p, numAlloc := testStreamOptions(t, len(testAlignedStarts)-1)
@@ -23,6 +23,7 @@ package aggregator
 import (
 	"container/list"
 	"errors"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -59,6 +60,17 @@ var (
 	errArrivedTooLate              = errors.New("arrived too late")
 )
 
+// partialAddError is returned when a metric fails to be added after some of its
+// values have already been added, adding the metric again would add these values
+// twice so the error is never transient.
+type partialAddError struct {
+	err error
+}
+
+func (e partialAddError) Error() string {
+	return fmt.Sprintf("metric partially added: %v", e.err)
+}
+
 type rateLimitEntryMetrics struct {
 	valueRateLimitExceeded tally.Counter
 	droppedValues          tally.Counter
@@ -331,6 +343,9 @@ func (e *Entry) writeBatchTimerWithMetadatas(
 		splitTimer := metric
 		splitTimer.BatchTimerVal = timerValues[start:end]
 		if err := e.addUntimed(splitTimer, metadatas); err != nil {
+			if start > 0 {
+				return partialAddError{err: err}
+			}
 			return err
 		}
 	}
@@ -550,7 +565,7 @@ func (e *Entry) addNewAggregationKeyWithLock(
 	if err != nil {
 		return nil, err
 	}
-	newAggregations = append(newAggregations, aggregationValue{key: key, elem: newListElem})
+	newAggregations = append(newAggregations, aggregationValue{key: key, listID: listID, elem: newListElem})
 	return newAggregations, nil
 }
 
@@ -883,6 +898,67 @@ func (e *Entry) addForwardedWithLock(
 	return err
 }
 
+// Checkpoint returns a checkpoint of the aggregations of the entry that have
+// not been consumed yet.
+func (e *Entry) Checkpoint() ([]elemCheckpoint, error) {
+	e.RLock()
+	defer e.RUnlock()
+
+	if e.closed {
+		return nil, nil
+	}
+	checkpoints := make([]elemCheckpoint, 0, len(e.aggregations))
+	for _, val := range e.aggregations {
+		elem := val.elem.Value.(metricElem)
+		windows := elem.Checkpoint()
+		if len(windows) == 0 {
+			continue
+		}
+		key, err := val.key.toProto()
+		if err != nil {
+			return nil, err
+		}
+		checkpoints = append(checkpoints, elemCheckpoint{
+			ListType:           val.listID.listType,
+			MetricType:         elem.Type(),
+			ID:                 append([]byte(nil), elem.ID()...),
+			Key:                key,
+			IDPrefixSuffixType: val.key.idPrefixSuffixType,
+			Windows:            windows,
+		})
+	}
+	return checkpoints, nil
+}
+
+// Restore restores an aggregation of the entry from a checkpoint, creating the
+// aggregation if it does not exist yet.
+func (e *Entry) Restore(checkpoint elemCheckpoint, flushedNanosFn listFlushedNanosFn) error {
+	key, err := newAggregationKeyFromProto(checkpoint.Key, checkpoint.IDPrefixSuffixType)
+	if err != nil {
+		return err
+	}
+	listID := checkpoint.listID(key)
+
+	e.Lock()
+	defer e.Unlock()
+
+	if e.closed {
+		return errEntryClosed
+	}
+	idx := e.aggregations.index(key)
+	if idx < 0 {
+		elemID := e.maybeCopyIDWithLock(checkpoint.ID)
+		newAggregations, err := e.addNewAggregationKeyWithLock(checkpoint.MetricType, elemID, key, listID, e.aggregations)
+		if err != nil {
+			return err
+		}
+		e.aggregations = newAggregations
+		idx = e.aggregations.index(key)
+	}
+	elem := e.aggregations[idx].elem.Value.(metricElem)
+	return elem.Restore(checkpoint.Windows, listID.isWindowFlushedFn(flushedNanosFn(listID)))
+}
+
 func (e *Entry) writerCount() int        { return int(atomic.LoadInt32(&e.numWriters)) }
 func (e *Entry) lastAccessed() time.Time { return time.Unix(0, atomic.LoadInt64(&e.lastAccessNanos)) }
 
@@ -926,8 +1002,9 @@ func (e *Entry) applyValueRateLimit(numValues int64, m rateLimitEntryMetrics) er
 }
 
 type aggregationValue struct {
-	key  aggregationKey
-	elem *list.Element
+	key    aggregationKey
+	listID metricListID
+	elem   *list.Element
 }
 
 // TODO(xichen): benchmark the performance of using a single slice
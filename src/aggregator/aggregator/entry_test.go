@@ -396,6 +396,36 @@ func TestEntryAddBatchTimerWithTimerBatchSizeLimitError(t *testing.T) {
 	require.Equal(t, errEntryClosed, e.AddUntimed(bt, testDefaultStagedMetadatas))
 }
 
+func TestEntryAddBatchTimerWithTimerBatchSizeLimitPartialError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	e, _, now := testEntry(ctrl)
+	*now = time.Unix(105, 0)
+	e.opts = e.opts.
+		SetMaxTimerBatchSizePerWrite(2).
+		SetDefaultStoragePolicies(testDefaultStoragePolicies)
+
+	// Close the entry once the first batch of values has been added.
+	var numAdds int
+	e.opts = e.opts.SetClockOptions(e.opts.ClockOptions().SetNowFn(func() time.Time {
+		numAdds++
+		if numAdds > 1 {
+			e.closed = true
+		}
+		return *now
+	}))
+
+	bt := unaggregated.MetricUnion{
+		Type:          metric.TimerType,
+		ID:            testBatchTimerID,
+		BatchTimerVal: []float64{1.0, 3.5, 2.2, 6.5, 4.8},
+	}
+	err := e.AddUntimed(bt, testDefaultStagedMetadatas)
+	require.Equal(t, partialAddError{err: errEntryClosed}, err)
+	require.False(t, IsTransientError(err))
+}
+
 func TestEntryAddUntimedEmptyMetadatasError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -56,10 +56,18 @@ type FlushManager interface {
 	// Unregister unregisters a flusher with the flush manager.
 	Unregister(flusher flushingMetricList) error
 
+	// RegisterLeaderTakeoverFn registers a function to be called asynchronously
+	// whenever the instance takes over as the leader. It must be called before
+	// the flush manager is opened.
+	RegisterLeaderTakeoverFn(fn LeaderTakeoverFn) error
+
 	// Close closes the flush manager.
 	Close() error
 }
 
+// LeaderTakeoverFn is called when the instance takes over as the leader.
+type LeaderTakeoverFn func()
+
 // FlushStatus is the flush status.
 type FlushStatus struct {
 	ElectionState ElectionState `json:"electionState"`
@@ -129,6 +137,7 @@ type flushManager struct {
 	electionState ElectionState
 	leaderMgr     roleBasedFlushManager
 	followerMgr   roleBasedFlushManager
+	takeoverFn    LeaderTakeoverFn
 	nowFn         clock.NowFn
 	sleepFn       sleepFn
 }
@@ -227,6 +236,18 @@ func (mgr *flushManager) Unregister(flusher flushingMetricList) error {
 	return bucket.Remove(flusher)
 }
 
+func (mgr *flushManager) RegisterLeaderTakeoverFn(fn LeaderTakeoverFn) error {
+	mgr.Lock()
+	defer mgr.Unlock()
+
+	if mgr.state != flushManagerNotOpen {
+		return errFlushManagerAlreadyOpenOrClosed
+	}
+	mgr.takeoverFn = fn
+	mgr.resetWithLock()
+	return nil
+}
+
 func (mgr *flushManager) Status() FlushStatus {
 	mgr.RLock()
 	electionState := mgr.electionState
@@ -259,7 +280,7 @@ func (mgr *flushManager) resetWithLock() {
 	mgr.state = flushManagerNotOpen
 	mgr.doneCh = make(chan struct{})
 	mgr.electionState = FollowerState
-	mgr.leaderMgr = newLeaderFlushManager(mgr.doneCh, mgr.leaderOpts)
+	mgr.leaderMgr = newLeaderFlushManager(mgr.doneCh, mgr.leaderOpts, mgr.takeoverFn)
 	mgr.leaderMgr.Init(mgr.buckets)
 	mgr.followerMgr = newFollowerFlushManager(mgr.doneCh, mgr.followerOpts)
 	mgr.followerMgr.Init(mgr.buckets)
@@ -81,6 +81,20 @@ func TestFlushManagerOpenSuccess(t *testing.T) {
 	require.NoError(t, mgr.Open())
 }
 
+func TestFlushManagerRegisterLeaderTakeoverFn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mgr, _ := testFlushManager(t, ctrl)
+	require.NoError(t, mgr.RegisterLeaderTakeoverFn(func() {}))
+	require.NotNil(t, mgr.takeoverFn)
+	require.NotNil(t, mgr.leaderMgr.(*leaderFlushManager).takeoverFn)
+
+	// Registering the function after the manager is open causes an error.
+	mgr.state = flushManagerOpen
+	require.Equal(t, errFlushManagerAlreadyOpenOrClosed, mgr.RegisterLeaderTakeoverFn(func() {}))
+}
+
 func TestFlushManagerRegisterStandardFlushingMetricList(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -51,6 +51,21 @@ type typeSpecificAggregation interface {
 	// ValueOf returns the value for the given aggregation type.
 	ValueOf(aggType maggregation.Type) float64
 
+	// Snapshot returns a snapshot of the aggregated values.
+	Snapshot() aggregationSnapshot
+
+	// Restore restores the aggregated values from a snapshot.
+	Restore(snapshot aggregationSnapshot) error
+
+	// Histogram returns the histogram of the aggregated values, or nil if
+	// the aggregation does not track a histogram.
+	Histogram() *raggregation.Histogram
+
+	// AddForwardedHistogram adds values forwarded from a previous aggregation
+	// stage along with the bucket counts of the histogram they were aggregated
+	// from, returning an error if the aggregation does not track a histogram.
+	AddForwardedHistogram(values []float64, bucketCounts []float64) error
+
 	// Close closes the aggregation object.
 	Close()
 }
@@ -248,6 +263,16 @@ func (e *GenericElem) AddUnique(timestamp time.Time, values []float64, sourceID
 		lockedAgg.Unlock()
 		return errDuplicateForwardingSource
 	}
+	if histogram := lockedAgg.aggregation.Histogram(); histogram != nil {
+		// NB: The frames are validated before any of them is added, so the source
+		// is only marked as seen if the values are added.
+		err := forEachForwardedHistogramFrame(values, histogram.NumBuckets(), lockedAgg.aggregation.AddForwardedHistogram)
+		if err == nil {
+			lockedAgg.sourcesSeen.Set(source)
+		}
+		lockedAgg.Unlock()
+		return err
+	}
 	lockedAgg.sourcesSeen.Set(source)
 	for _, v := range values {
 		lockedAgg.aggregation.Add(v)
@@ -364,6 +389,78 @@ func (e *GenericElem) Close() {
 	pool.Put(e)
 }
 
+// Checkpoint returns a checkpoint of the aggregation windows of the element
+// that have not been consumed yet.
+func (e *GenericElem) Checkpoint() []windowCheckpoint {
+	e.RLock()
+	defer e.RUnlock()
+
+	if e.closed {
+		return nil
+	}
+	windows := make([]windowCheckpoint, 0, len(e.values))
+	for _, value := range e.values {
+		lockedAgg := value.lockedAgg
+		lockedAgg.Lock()
+		if lockedAgg.closed {
+			lockedAgg.Unlock()
+			continue
+		}
+		window := windowCheckpoint{
+			StartAtNanos: value.startAtNanos,
+			Aggregation:  lockedAgg.aggregation.Snapshot(),
+		}
+		if lockedAgg.sourcesSeen != nil {
+			window.SourcesSeen = append([]uint64(nil), lockedAgg.sourcesSeen.Bytes()...)
+		}
+		lockedAgg.Unlock()
+		windows = append(windows, window)
+	}
+	return windows
+}
+
+// Restore restores the aggregation windows of the element from a checkpoint.
+// Windows that already exist in the element are left untouched as they hold
+// values received after the checkpoint was taken, and windows that have
+// already been flushed are skipped.
+func (e *GenericElem) Restore(windows []windowCheckpoint, isFlushedFn isWindowFlushedFn) error {
+	e.Lock()
+	defer e.Unlock()
+
+	if e.closed {
+		return errElemClosed
+	}
+	for _, window := range windows {
+		if isFlushedFn(window.StartAtNanos) {
+			continue
+		}
+		idx, found := e.indexOfWithLock(window.StartAtNanos)
+		if found {
+			continue
+		}
+		aggregation := e.NewAggregation(e.opts, e.aggOpts)
+		if err := aggregation.Restore(window.Aggregation); err != nil {
+			aggregation.Close()
+			return err
+		}
+		var sourcesSeen *bitset.BitSet
+		if window.SourcesSeen != nil {
+			sourcesSeen = bitset.From(append([]uint64(nil), window.SourcesSeen...))
+		}
+		numValues := len(e.values)
+		e.values = append(e.values, timedAggregation{})
+		copy(e.values[idx+1:numValues+1], e.values[idx:numValues])
+		e.values[idx] = timedAggregation{
+			startAtNanos: window.StartAtNanos,
+			lockedAgg: &lockedAggregation{
+				sourcesSeen: sourcesSeen,
+				aggregation: aggregation,
+			},
+		}
+	}
+	return nil
+}
+
 // findOrCreate finds the aggregation for a given time, or creates one
 // if it doesn't exist.
 func (e *GenericElem) findOrCreate(
@@ -461,8 +558,9 @@ func (e *GenericElem) processValueWithAggregationLock(
 	flushForwardedFn flushForwardedMetricFn,
 ) {
 	var (
-		transformations  = e.parsedPipeline.Transformations
-		discardNaNValues = e.opts.DiscardNaNAggregatedValues()
+		transformations    = e.parsedPipeline.Transformations
+		discardNaNValues   = e.opts.DiscardNaNAggregatedValues()
+		numForwardedValues int
 	)
 	for aggTypeIdx, aggType := range e.aggTypes {
 		value := lockedAgg.aggregation.ValueOf(aggType)
@@ -498,7 +596,58 @@ func (e *GenericElem) processValueWithAggregationLock(
 		} else {
 			forwardedAggregationKey, _ := e.ForwardedAggregationKey()
 			flushForwardedFn(e.writeForwardedMetricFn, forwardedAggregationKey, timeNanos, value)
+			numForwardedValues++
+		}
+	}
+	if histogram := lockedAgg.aggregation.Histogram(); histogram != nil {
+		if e.parsedPipeline.HasRollup {
+			e.forwardHistogramWithAggregationLock(timeNanos, histogram, numForwardedValues, flushForwardedFn)
+		} else {
+			e.processHistogramWithAggregationLock(timeNanos, histogram, flushLocalFn)
 		}
 	}
 	e.lastConsumedAtNanos = timeNanos
 }
+
+// forwardHistogramWithAggregationLock forwards the count of each bucket of the
+// histogram after the aggregated values forwarded for the same aggregation,
+// followed by the number of buckets and the number of aggregated values. This
+// completes the frame of values forwarded by the element, which the next
+// aggregation stage uses to merge the histograms of all the elements
+// forwarding to it.
+func (e *GenericElem) forwardHistogramWithAggregationLock(
+	timeNanos int64,
+	histogram *raggregation.Histogram,
+	numForwardedValues int,
+	flushForwardedFn flushForwardedMetricFn,
+) {
+	forwardedAggregationKey, _ := e.ForwardedAggregationKey()
+	for i := 0; i < histogram.NumBuckets(); i++ {
+		flushForwardedFn(e.writeForwardedMetricFn, forwardedAggregationKey, timeNanos, float64(histogram.BucketCount(i)))
+	}
+	flushForwardedFn(e.writeForwardedMetricFn, forwardedAggregationKey, timeNanos, float64(histogram.NumBuckets()))
+	flushForwardedFn(e.writeForwardedMetricFn, forwardedAggregationKey, timeNanos, float64(numForwardedValues))
+}
+
+// processHistogramWithAggregationLock flushes the cumulative count of each bucket
+// of the histogram as a separate series. The bucket series are only flushed for
+// local metrics with a suffixed ID, and transformations are not applied to
+// bucket counts.
+func (e *GenericElem) processHistogramWithAggregationLock(
+	timeNanos int64,
+	histogram *raggregation.Histogram,
+	flushLocalFn flushLocalMetricFn,
+) {
+	if e.idPrefixSuffixType != WithPrefixWithSuffix {
+		return
+	}
+	var (
+		fullPrefix = e.FullPrefix(e.opts)
+		suffixes   = e.opts.TimerHistogramBucketSuffixes()
+		cumulative int64
+	)
+	for i := 0; i < histogram.NumBuckets(); i++ {
+		cumulative += histogram.BucketCount(i)
+		flushLocalFn(fullPrefix, e.id, suffixes[i], timeNanos, float64(cumulative), e.sp)
+	}
+}
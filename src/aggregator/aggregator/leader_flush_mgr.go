@@ -48,6 +48,7 @@ func newLeaderFlusherMetrics(scope tally.Scope) leaderFlusherMetrics {
 
 type leaderFlushManagerMetrics struct {
 	queueSize tally.Gauge
+	takeovers tally.Counter
 	standard  leaderFlusherMetrics
 	forwarded leaderFlusherMetrics
 	timed     leaderFlusherMetrics
@@ -59,6 +60,7 @@ func newLeaderFlushManagerMetrics(scope tally.Scope) leaderFlushManagerMetrics {
 	timedScope := scope.Tagged(map[string]string{"flusher-type": "timed"})
 	return leaderFlushManagerMetrics{
 		queueSize: scope.Gauge("queue-size"),
+		takeovers: scope.Counter("takeovers"),
 		standard:  newLeaderFlusherMetrics(standardScope),
 		forwarded: newLeaderFlusherMetrics(forwardedScope),
 		timed:     newLeaderFlusherMetrics(timedScope),
@@ -81,6 +83,8 @@ type leaderFlushManager struct {
 	scope                  tally.Scope
 
 	doneCh              <-chan struct{}
+	takeoverFn          LeaderTakeoverFn
+	opened              bool
 	flushTimes          flushMetadataHeap
 	flushedByShard      map[uint32]*schema.ShardFlushTimes
 	lastPersistAtNanos  int64
@@ -92,6 +96,7 @@ type leaderFlushManager struct {
 func newLeaderFlushManager(
 	doneCh <-chan struct{},
 	opts FlushManagerOptions,
+	takeoverFn LeaderTakeoverFn,
 ) roleBasedFlushManager {
 	nowFn := opts.ClockOptions().NowFn()
 	instrumentOpts := opts.InstrumentOptions()
@@ -107,6 +112,7 @@ func newLeaderFlushManager(
 		logger:                 instrumentOpts.Logger(),
 		scope:                  scope,
 		doneCh:                 doneCh,
+		takeoverFn:             takeoverFn,
 		flushedByShard:         make(map[uint32]*schema.ShardFlushTimes, defaultInitialFlushCapacity),
 		lastPersistAtNanos:     nowFn().UnixNano(),
 		metrics:                newLeaderFlushManagerMetrics(scope),
@@ -118,17 +124,29 @@ func newLeaderFlushManager(
 	return mgr
 }
 
-func (mgr *leaderFlushManager) Open() {}
+func (mgr *leaderFlushManager) Open() {
+	mgr.Lock()
+	mgr.opened = true
+	mgr.Unlock()
+}
 
 // Init initializes the leader flush manager by enqueuing all
-// the flushers in the buckets.
+// the flushers in the buckets. If the manager is already open,
+// the instance is taking over as the leader and the takeover
+// function is called asynchronously so the caller is not blocked.
 func (mgr *leaderFlushManager) Init(buckets []*flushBucket) {
 	mgr.Lock()
 	mgr.flushTimes.Reset()
 	for bucketIdx, bucket := range buckets {
 		mgr.enqueueBucketWithLock(bucketIdx, bucket)
 	}
+	takeover := mgr.opened && mgr.takeoverFn != nil
 	mgr.Unlock()
+
+	if takeover {
+		mgr.metrics.takeovers.Inc(1)
+		go mgr.takeoverFn()
+	}
 }
 
 func (mgr *leaderFlushManager) Prepare(buckets []*flushBucket) (flushTask, time.Duration) {
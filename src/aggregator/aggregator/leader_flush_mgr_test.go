@@ -148,7 +148,7 @@ func TestLeaderFlushManagerInit(t *testing.T) {
 	nowFn := func() time.Time { return now }
 	doneCh := make(chan struct{})
 	opts := NewFlushManagerOptions()
-	mgr := newLeaderFlushManager(doneCh, opts).(*leaderFlushManager)
+	mgr := newLeaderFlushManager(doneCh, opts, nil).(*leaderFlushManager)
 	mgr.nowFn = nowFn
 
 	mgr.Init(testFlushBuckets(ctrl))
@@ -164,6 +164,33 @@ func TestLeaderFlushManagerInit(t *testing.T) {
 	validateFlushMetadataHeap(t, expectedFlushTimes, mgr.flushTimes)
 }
 
+func TestLeaderFlushManagerInitTakeover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	takeoverCh := make(chan struct{}, 2)
+	takeoverFn := func() { takeoverCh <- struct{}{} }
+	doneCh := make(chan struct{})
+	opts := NewFlushManagerOptions()
+	mgr := newLeaderFlushManager(doneCh, opts, takeoverFn).(*leaderFlushManager)
+
+	// Initializing before the manager is open is not a takeover.
+	mgr.Init(testFlushBuckets(ctrl))
+	select {
+	case <-takeoverCh:
+		require.FailNow(t, "unexpected takeover before the manager is open")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	mgr.Open()
+	mgr.Init(testFlushBuckets(ctrl))
+	select {
+	case <-takeoverCh:
+	case <-time.After(time.Second):
+		require.FailNow(t, "expected takeover after the manager is open")
+	}
+}
+
 func TestLeaderFlushManagerOnFlusherAdded(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -172,7 +199,7 @@ func TestLeaderFlushManagerOnFlusherAdded(t *testing.T) {
 	nowFn := func() time.Time { return now }
 	doneCh := make(chan struct{})
 	opts := NewFlushManagerOptions()
-	mgr := newLeaderFlushManager(doneCh, opts).(*leaderFlushManager)
+	mgr := newLeaderFlushManager(doneCh, opts, nil).(*leaderFlushManager)
 	mgr.nowFn = nowFn
 
 	buckets := testFlushBuckets(ctrl)
@@ -236,7 +263,7 @@ func TestLeaderFlushManagerPrepareNoFlushNoPersist(t *testing.T) {
 
 	flushTimesManager := NewMockFlushTimesManager(ctrl)
 	opts := NewFlushManagerOptions().SetJitterEnabled(false)
-	mgr := newLeaderFlushManager(doneCh, opts).(*leaderFlushManager)
+	mgr := newLeaderFlushManager(doneCh, opts, nil).(*leaderFlushManager)
 	mgr.nowFn = nowFn
 	mgr.flushTimesManager = flushTimesManager
 
@@ -275,7 +302,7 @@ func TestLeaderFlushManagerPrepareNoFlushWithPersistOnce(t *testing.T) {
 	opts := NewFlushManagerOptions().
 		SetJitterEnabled(false).
 		SetFlushTimesPersistEvery(time.Second)
-	mgr := newLeaderFlushManager(doneCh, opts).(*leaderFlushManager)
+	mgr := newLeaderFlushManager(doneCh, opts, nil).(*leaderFlushManager)
 	mgr.nowFn = nowFn
 	mgr.flushedSincePersist = true
 	mgr.flushTimesManager = flushTimesManager
@@ -318,7 +345,7 @@ func TestLeaderFlushManagerPrepareNoFlushWithPersistTwice(t *testing.T) {
 	opts := NewFlushManagerOptions().
 		SetJitterEnabled(false).
 		SetFlushTimesPersistEvery(time.Second)
-	mgr := newLeaderFlushManager(doneCh, opts).(*leaderFlushManager)
+	mgr := newLeaderFlushManager(doneCh, opts, nil).(*leaderFlushManager)
 	mgr.nowFn = nowFn
 	mgr.lastPersistAtNanos = now.Add(-2 * time.Second).UnixNano()
 	mgr.flushedSincePersist = true
@@ -379,7 +406,7 @@ func TestLeaderFlushManagerPrepareWithFlushAndPersist(t *testing.T) {
 	opts := NewFlushManagerOptions().
 		SetJitterEnabled(false).
 		SetFlushTimesPersistEvery(time.Second)
-	mgr := newLeaderFlushManager(doneCh, opts).(*leaderFlushManager)
+	mgr := newLeaderFlushManager(doneCh, opts, nil).(*leaderFlushManager)
 	mgr.nowFn = nowFn
 	mgr.lastPersistAtNanos = now.UnixNano()
 	mgr.flushedSincePersist = true
@@ -419,7 +446,7 @@ func TestLeaderFlushManagerOnBucketAdded(t *testing.T) {
 	nowFn := func() time.Time { return now }
 	doneCh := make(chan struct{})
 	opts := NewFlushManagerOptions().SetJitterEnabled(false)
-	mgr := newLeaderFlushManager(doneCh, opts).(*leaderFlushManager)
+	mgr := newLeaderFlushManager(doneCh, opts, nil).(*leaderFlushManager)
 	mgr.nowFn = nowFn
 
 	buckets := testFlushBuckets(ctrl)
@@ -463,7 +490,7 @@ func TestLeaderFlushTaskRunShardsError(t *testing.T) {
 	placementManager.EXPECT().Shards().Return(nil, errShards)
 
 	opts := NewFlushManagerOptions()
-	mgr := newLeaderFlushManager(doneCh, opts).(*leaderFlushManager)
+	mgr := newLeaderFlushManager(doneCh, opts, nil).(*leaderFlushManager)
 	mgr.placementManager = placementManager
 	flushTask := &leaderFlushTask{
 		mgr:      mgr,
@@ -493,7 +520,7 @@ func TestLeaderFlushTaskRunShardNotFound(t *testing.T) {
 	placementManager.EXPECT().Shards().Return(shard.NewShards(nil), nil)
 
 	opts := NewFlushManagerOptions()
-	mgr := newLeaderFlushManager(doneCh, opts).(*leaderFlushManager)
+	mgr := newLeaderFlushManager(doneCh, opts, nil).(*leaderFlushManager)
 	mgr.placementManager = placementManager
 	flushTask := &leaderFlushTask{
 		mgr:      mgr,
@@ -542,7 +569,7 @@ func TestLeaderFlushTaskRunWithFlushes(t *testing.T) {
 		Return(shard.NewShards(shards), nil)
 
 	opts := NewFlushManagerOptions().SetJitterEnabled(false)
-	mgr := newLeaderFlushManager(doneCh, opts).(*leaderFlushManager)
+	mgr := newLeaderFlushManager(doneCh, opts, nil).(*leaderFlushManager)
 	mgr.placementManager = placementManager
 	flushTask := &leaderFlushTask{
 		mgr:      mgr,
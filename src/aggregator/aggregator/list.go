@@ -789,6 +789,43 @@ type metricListID struct {
 	timed     timedMetricListID
 }
 
+// metricCategory returns the category of the metrics stored in lists of the type.
+func (t metricListType) metricCategory() metricCategory {
+	switch t {
+	case standardMetricListType:
+		return untimedMetric
+	case forwardedMetricListType:
+		return forwardedMetric
+	case timedMetricListType:
+		return timedMetric
+	default:
+		return unknownMetricCategory
+	}
+}
+
+// isWindowFlushedFn returns the function determining whether an aggregation
+// window of the list has been flushed given the last flushed time of the list.
+func (id metricListID) isWindowFlushedFn(lastFlushedNanos int64) isWindowFlushedFn {
+	var (
+		resolution      time.Duration
+		isEarlierThanFn isEarlierThanFn
+	)
+	switch id.listType {
+	case forwardedMetricListType:
+		resolution = id.forwarded.resolution
+		isEarlierThanFn = isForwardedMetricEarlierThan
+	case timedMetricListType:
+		resolution = id.timed.resolution
+		isEarlierThanFn = isStandardMetricEarlierThan
+	default:
+		resolution = id.standard.resolution
+		isEarlierThanFn = isStandardMetricEarlierThan
+	}
+	return func(windowStartNanos int64) bool {
+		return isEarlierThanFn(windowStartNanos, resolution, lastFlushedNanos)
+	}
+}
+
 func newMetricList(shard uint32, id metricListID, opts Options) (metricList, error) {
 	switch id.listType {
 	case standardMetricListType:
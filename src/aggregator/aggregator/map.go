@@ -36,6 +36,7 @@ import (
 	"github.com/m3db/m3metrics/metric/unaggregated"
 	"github.com/m3db/m3x/clock"
 	"github.com/m3db/m3x/close"
+	xerrors "github.com/m3db/m3x/errors"
 
 	"github.com/uber-go/tally"
 )
@@ -54,7 +55,6 @@ var (
 type metricCategory int
 
 const (
-	// nolint
 	unknownMetricCategory metricCategory = iota
 	untimedMetric
 	forwardedMetric
@@ -67,6 +67,11 @@ type entryKey struct {
 	idHash         hash.Hash128
 }
 
+type createEntryOptions struct {
+	// skipNewMetricRateLimit determines whether to skip the new metric rate limit.
+	skipNewMetricRateLimit bool
+}
+
 type hashedEntry struct {
 	key   entryKey
 	entry *Entry
@@ -151,7 +156,7 @@ func (m *metricMap) AddUntimed(
 		metricType:     metric.Type,
 		idHash:         hash.Murmur3Hash128(metric.ID),
 	}
-	entry, err := m.findOrCreate(key)
+	entry, err := m.findOrCreate(key, createEntryOptions{})
 	if err != nil {
 		return err
 	}
@@ -169,7 +174,7 @@ func (m *metricMap) AddTimed(
 		metricType:     metric.Type,
 		idHash:         hash.Murmur3Hash128(metric.ID),
 	}
-	entry, err := m.findOrCreate(key)
+	entry, err := m.findOrCreate(key, createEntryOptions{})
 	if err != nil {
 		return err
 	}
@@ -187,7 +192,7 @@ func (m *metricMap) AddForwarded(
 		metricType:     metric.Type,
 		idHash:         hash.Murmur3Hash128(metric.ID),
 	}
-	entry, err := m.findOrCreate(key)
+	entry, err := m.findOrCreate(key, createEntryOptions{})
 	if err != nil {
 		return err
 	}
@@ -237,7 +242,55 @@ func (m *metricMap) Close() {
 	m.closed = true
 }
 
-func (m *metricMap) findOrCreate(key entryKey) (*Entry, error) {
+// Checkpoint returns a checkpoint of the aggregations of all the entries
+// in the map that have not been consumed yet.
+func (m *metricMap) Checkpoint() ([]elemCheckpoint, error) {
+	var (
+		checkpoints []elemCheckpoint
+		multiErr    = xerrors.NewMultiError()
+	)
+	// NB: the entry list deletion lock is held to ensure no entries get deleted
+	// while we iterate over the list, see SetRuntimeOptions for details.
+	m.entryListDelLock.Lock()
+	m.forEachEntry(func(entry hashedEntry) {
+		entryCheckpoints, err := entry.entry.Checkpoint()
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			return
+		}
+		checkpoints = append(checkpoints, entryCheckpoints...)
+	})
+	m.entryListDelLock.Unlock()
+	return checkpoints, multiErr.FinalError()
+}
+
+// Restore restores the aggregations of the entries in the map from checkpoints,
+// using the flushed times of the metric lists to skip aggregation windows that
+// have already been flushed.
+func (m *metricMap) Restore(checkpoints []elemCheckpoint, flushedNanosFn listFlushedNanosFn) error {
+	multiErr := xerrors.NewMultiError()
+	for _, checkpoint := range checkpoints {
+		key := entryKey{
+			metricCategory: checkpoint.ListType.metricCategory(),
+			metricType:     checkpoint.MetricType,
+			idHash:         hash.Murmur3Hash128(checkpoint.ID),
+		}
+		// Restored metrics are not new metrics and as such are not subject to the
+		// new metric rate limit.
+		entry, err := m.findOrCreate(key, createEntryOptions{skipNewMetricRateLimit: true})
+		if err != nil {
+			return err
+		}
+		err = entry.Restore(checkpoint, flushedNanosFn)
+		entry.DecWriter()
+		if err != nil {
+			multiErr = multiErr.Add(err)
+		}
+	}
+	return multiErr.FinalError()
+}
+
+func (m *metricMap) findOrCreate(key entryKey, createOpts createEntryOptions) (*Entry, error) {
 	m.RLock()
 	if m.closed {
 		m.RUnlock()
@@ -270,9 +323,11 @@ func (m *metricMap) findOrCreate(key entryKey) (*Entry, error) {
 	if m.firstInsertAt.IsZero() {
 		m.firstInsertAt = now
 	}
-	if err := m.applyNewMetricRateLimitWithLock(now); err != nil {
-		m.Unlock()
-		return nil, err
+	if !createOpts.skipNewMetricRateLimit {
+		if err := m.applyNewMetricRateLimitWithLock(now); err != nil {
+			m.Unlock()
+			return nil, err
+		}
 	}
 	entry = m.entryPool.Get()
 	entry.ResetSetData(m.metricLists, m.runtimeOpts, m.opts)
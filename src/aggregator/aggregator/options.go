@@ -24,6 +24,7 @@ import (
 	"sync"
 	"time"
 
+	raggregation "github.com/m3db/m3/src/aggregator/aggregation"
 	"github.com/m3db/m3/src/aggregator/aggregation/quantile/cm"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler"
 	"github.com/m3db/m3/src/aggregator/client"
@@ -49,6 +50,7 @@ var (
 	defaultMaxNumCachedSourceSets     = 2
 	defaultDiscardNaNAggregatedValues = true
 	defaultResignTimeout              = 5 * time.Minute
+	defaultCheckpointEvery            = time.Minute
 	defaultDefaultStoragePolicies     = []policy.StoragePolicy{
 		policy.NewStoragePolicy(10*time.Second, xtime.Second, 2*24*time.Hour),
 		policy.NewStoragePolicy(time.Minute, xtime.Minute, 40*24*time.Hour),
@@ -234,6 +236,20 @@ type Options interface {
 	// ResignTimeout returns the resign timeout.
 	ResignTimeout() time.Duration
 
+	// SetCheckpointStore sets the store for checkpoints of the in-flight aggregation
+	// state, checkpointing is disabled if the store is nil.
+	SetCheckpointStore(value CheckpointStore) Options
+
+	// CheckpointStore returns the store for checkpoints of the in-flight aggregation
+	// state, checkpointing is disabled if the store is nil.
+	CheckpointStore() CheckpointStore
+
+	// SetCheckpointEvery sets how frequently the in-flight aggregation state is checkpointed.
+	SetCheckpointEvery(value time.Duration) Options
+
+	// CheckpointEvery returns how frequently the in-flight aggregation state is checkpointed.
+	CheckpointEvery() time.Duration
+
 	// SetMaxAllowedForwardingDelayFn sets the function that determines the maximum forwarding
 	// delay for given metric resolution and number of times the metric has been forwarded.
 	SetMaxAllowedForwardingDelayFn(value MaxAllowedForwardingDelayFn) Options
@@ -266,6 +282,16 @@ type Options interface {
 	// DiscardNaNAggregatedValues determines whether NaN aggregated values are discarded.
 	DiscardNaNAggregatedValues() bool
 
+	// SetTimerHistogramBuckets sets the buckets of the histogram tracked by timers, timers
+	// do not track a histogram if the buckets are nil. The histogram bucket counts are
+	// forwarded with the values of timers in rollup pipelines, so aggregators forwarding
+	// metrics to each other must be configured with the same buckets.
+	SetTimerHistogramBuckets(value raggregation.HistogramBuckets) Options
+
+	// TimerHistogramBuckets returns the buckets of the histogram tracked by timers, timers
+	// do not track a histogram if the buckets are nil.
+	TimerHistogramBuckets() raggregation.HistogramBuckets
+
 	// SetEntryPool sets the entry pool.
 	SetEntryPool(value EntryPool) Options
 
@@ -300,6 +326,10 @@ type Options interface {
 
 	// FullGaugePrefix returns the full prefix for gauges.
 	FullGaugePrefix() []byte
+
+	// TimerHistogramBucketSuffixes returns the suffixes of the timer histogram bucket
+	// series, including the suffix of the overflow bucket.
+	TimerHistogramBucketSuffixes() [][]byte
 }
 
 type options struct {
@@ -329,11 +359,14 @@ type options struct {
 	flushTimesManager                FlushTimesManager
 	electionManager                  ElectionManager
 	resignTimeout                    time.Duration
+	checkpointStore                  CheckpointStore
+	checkpointEvery                  time.Duration
 	maxAllowedForwardingDelayFn      MaxAllowedForwardingDelayFn
 	bufferForPastTimedMetricFn       BufferForPastTimedMetricFn
 	bufferForFutureTimedMetric       time.Duration
 	maxNumCachedSourceSets           int
 	discardNaNAggregatedValues       bool
+	timerHistogramBuckets            raggregation.HistogramBuckets
 	entryPool                        EntryPool
 	counterElemPool                  CounterElemPool
 	timerElemPool                    TimerElemPool
@@ -344,6 +377,8 @@ type options struct {
 	fullTimerPrefix   []byte
 	fullGaugePrefix   []byte
 	timerQuantiles    []float64
+
+	timerHistogramBucketSuffixes [][]byte
 }
 
 // NewOptions create a new set of options.
@@ -372,6 +407,7 @@ func NewOptions() Options {
 		maxTimerBatchSizePerWrite:        defaultMaxTimerBatchSizePerWrite,
 		defaultStoragePolicies:           defaultDefaultStoragePolicies,
 		resignTimeout:                    defaultResignTimeout,
+		checkpointEvery:                  defaultCheckpointEvery,
 		maxAllowedForwardingDelayFn:      defaultMaxAllowedForwardingDelayFn,
 		bufferForPastTimedMetricFn:       defaultBufferForPastTimedMetricFn,
 		bufferForFutureTimedMetric:       defaultTimedMetricBuffer,
@@ -642,6 +678,26 @@ func (o *options) ResignTimeout() time.Duration {
 	return o.resignTimeout
 }
 
+func (o *options) SetCheckpointStore(value CheckpointStore) Options {
+	opts := *o
+	opts.checkpointStore = value
+	return &opts
+}
+
+func (o *options) CheckpointStore() CheckpointStore {
+	return o.checkpointStore
+}
+
+func (o *options) SetCheckpointEvery(value time.Duration) Options {
+	opts := *o
+	opts.checkpointEvery = value
+	return &opts
+}
+
+func (o *options) CheckpointEvery() time.Duration {
+	return o.checkpointEvery
+}
+
 func (o *options) SetMaxAllowedForwardingDelayFn(value MaxAllowedForwardingDelayFn) Options {
 	opts := *o
 	opts.maxAllowedForwardingDelayFn = value
@@ -692,6 +748,17 @@ func (o *options) DiscardNaNAggregatedValues() bool {
 	return o.discardNaNAggregatedValues
 }
 
+func (o *options) SetTimerHistogramBuckets(value raggregation.HistogramBuckets) Options {
+	opts := *o
+	opts.timerHistogramBuckets = value
+	opts.computeTimerHistogramBucketSuffixes()
+	return &opts
+}
+
+func (o *options) TimerHistogramBuckets() raggregation.HistogramBuckets {
+	return o.timerHistogramBuckets
+}
+
 func (o *options) SetEntryPool(value EntryPool) Options {
 	opts := *o
 	opts.entryPool = value
@@ -748,6 +815,10 @@ func (o *options) TimerQuantiles() []float64 {
 	return o.timerQuantiles
 }
 
+func (o *options) TimerHistogramBucketSuffixes() [][]byte {
+	return o.timerHistogramBucketSuffixes
+}
+
 func (o *options) initPools() {
 	defaultRuntimeOpts := runtime.NewOptions()
 	o.entryPool = NewEntryPool(nil)
@@ -773,6 +844,7 @@ func (o *options) initPools() {
 
 func (o *options) computeAllDerived() {
 	o.computeFullPrefixes()
+	o.computeTimerHistogramBucketSuffixes()
 }
 
 func (o *options) computeFullPrefixes() {
@@ -802,6 +874,18 @@ func (o *options) computeFullGaugePrefix() {
 	o.fullGaugePrefix = fullGaugePrefix
 }
 
+func (o *options) computeTimerHistogramBucketSuffixes() {
+	if len(o.timerHistogramBuckets) == 0 {
+		o.timerHistogramBucketSuffixes = nil
+		return
+	}
+	suffixes := make([][]byte, 0, len(o.timerHistogramBuckets)+1)
+	for i := 0; i <= len(o.timerHistogramBuckets); i++ {
+		suffixes = append(suffixes, raggregation.HistogramBucketSuffix(o.timerHistogramBuckets.UpperBound(i)))
+	}
+	o.timerHistogramBucketSuffixes = suffixes
+}
+
 func defaultMaxAllowedForwardingDelayFn(
 	resolution time.Duration,
 	numForwardedTimes int,
@@ -25,6 +25,7 @@ import (
 	"testing"
 	"time"
 
+	raggregation "github.com/m3db/m3/src/aggregator/aggregation"
 	"github.com/m3db/m3/src/aggregator/aggregation/quantile/cm"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler"
 	"github.com/m3db/m3/src/aggregator/client"
@@ -208,6 +209,18 @@ func TestSetDiscardNaNAggregatedValues(t *testing.T) {
 	require.Equal(t, value, o.DiscardNaNAggregatedValues())
 }
 
+func TestSetTimerHistogramBuckets(t *testing.T) {
+	o := NewOptions()
+	require.Nil(t, o.TimerHistogramBuckets())
+	require.Nil(t, o.TimerHistogramBucketSuffixes())
+
+	value := raggregation.HistogramBuckets{0.5, 1}
+	o = o.SetTimerHistogramBuckets(value)
+	require.Equal(t, value, o.TimerHistogramBuckets())
+	expected := [][]byte{[]byte(".bucket.le_0.5"), []byte(".bucket.le_1"), []byte(".bucket.le_+Inf")}
+	require.Equal(t, expected, o.TimerHistogramBucketSuffixes())
+}
+
 func TestSetCounterElemPool(t *testing.T) {
 	value := NewCounterElemPool(nil)
 	o := NewOptions().SetCounterElemPool(value)
@@ -27,6 +27,7 @@ import (
 	"sync"
 	"time"
 
+	schema "github.com/m3db/m3/src/aggregator/generated/proto/flush"
 	"github.com/m3db/m3metrics/metadata"
 	"github.com/m3db/m3metrics/metric/aggregated"
 	"github.com/m3db/m3metrics/metric/unaggregated"
@@ -38,6 +39,7 @@ import (
 var (
 	errAggregatorShardClosed       = errors.New("aggregator shard is closed")
 	errAggregatorShardNotWriteable = errors.New("aggregator shard is not writeable")
+	errCheckpointShardMismatch     = errors.New("checkpoint is for a different shard")
 )
 
 type addUntimedFn func(
@@ -228,6 +230,47 @@ func (s *aggregatorShard) Tick(target time.Duration) tickResult {
 	return s.metricMap.Tick(target)
 }
 
+// Checkpoint returns an encoded checkpoint of the in-flight aggregation
+// state of the shard.
+func (s *aggregatorShard) Checkpoint() ([]byte, error) {
+	s.RLock()
+	if s.closed {
+		s.RUnlock()
+		return nil, errAggregatorShardClosed
+	}
+	elems, err := s.metricMap.Checkpoint()
+	s.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return encodeShardCheckpoint(shardCheckpoint{
+		Version:        checkpointVersion,
+		Shard:          s.shard,
+		CreatedAtNanos: s.nowFn().UnixNano(),
+		Elems:          elems,
+	})
+}
+
+// Restore restores the in-flight aggregation state of the shard from an encoded
+// checkpoint, skipping the aggregation windows that have already been flushed
+// according to the shard flush times, which may be nil if they are not known.
+func (s *aggregatorShard) Restore(data []byte, flushTimes *schema.ShardFlushTimes) error {
+	checkpoint, err := decodeShardCheckpoint(data)
+	if err != nil {
+		return err
+	}
+	if checkpoint.Shard != s.shard {
+		return errCheckpointShardMismatch
+	}
+	s.RLock()
+	defer s.RUnlock()
+
+	if s.closed {
+		return errAggregatorShardClosed
+	}
+	return s.metricMap.Restore(checkpoint.Elems, newListFlushedNanosFn(flushTimes))
+}
+
 func (s *aggregatorShard) Close() {
 	s.Lock()
 	defer s.Unlock()
@@ -30,6 +30,7 @@ import (
 	"sync"
 	"time"
 
+	raggregation "github.com/m3db/m3/src/aggregator/aggregation"
 	maggregation "github.com/m3db/m3metrics/aggregation"
 	"github.com/m3db/m3metrics/metric/id"
 	"github.com/m3db/m3metrics/metric/unaggregated"
@@ -194,6 +195,16 @@ func (e *TimerElem) AddUnique(timestamp time.Time, values []float64, sourceID ui
 		lockedAgg.Unlock()
 		return errDuplicateForwardingSource
 	}
+	if histogram := lockedAgg.aggregation.Histogram(); histogram != nil {
+		// NB: The frames are validated before any of them is added, so the source
+		// is only marked as seen if the values are added.
+		err := forEachForwardedHistogramFrame(values, histogram.NumBuckets(), lockedAgg.aggregation.AddForwardedHistogram)
+		if err == nil {
+			lockedAgg.sourcesSeen.Set(source)
+		}
+		lockedAgg.Unlock()
+		return err
+	}
 	lockedAgg.sourcesSeen.Set(source)
 	for _, v := range values {
 		lockedAgg.aggregation.Add(v)
@@ -310,6 +321,78 @@ func (e *TimerElem) Close() {
 	pool.Put(e)
 }
 
+// Checkpoint returns a checkpoint of the aggregation windows of the element
+// that have not been consumed yet.
+func (e *TimerElem) Checkpoint() []windowCheckpoint {
+	e.RLock()
+	defer e.RUnlock()
+
+	if e.closed {
+		return nil
+	}
+	windows := make([]windowCheckpoint, 0, len(e.values))
+	for _, value := range e.values {
+		lockedAgg := value.lockedAgg
+		lockedAgg.Lock()
+		if lockedAgg.closed {
+			lockedAgg.Unlock()
+			continue
+		}
+		window := windowCheckpoint{
+			StartAtNanos: value.startAtNanos,
+			Aggregation:  lockedAgg.aggregation.Snapshot(),
+		}
+		if lockedAgg.sourcesSeen != nil {
+			window.SourcesSeen = append([]uint64(nil), lockedAgg.sourcesSeen.Bytes()...)
+		}
+		lockedAgg.Unlock()
+		windows = append(windows, window)
+	}
+	return windows
+}
+
+// Restore restores the aggregation windows of the element from a checkpoint.
+// Windows that already exist in the element are left untouched as they hold
+// values received after the checkpoint was taken, and windows that have
+// already been flushed are skipped.
+func (e *TimerElem) Restore(windows []windowCheckpoint, isFlushedFn isWindowFlushedFn) error {
+	e.Lock()
+	defer e.Unlock()
+
+	if e.closed {
+		return errElemClosed
+	}
+	for _, window := range windows {
+		if isFlushedFn(window.StartAtNanos) {
+			continue
+		}
+		idx, found := e.indexOfWithLock(window.StartAtNanos)
+		if found {
+			continue
+		}
+		aggregation := e.NewAggregation(e.opts, e.aggOpts)
+		if err := aggregation.Restore(window.Aggregation); err != nil {
+			aggregation.Close()
+			return err
+		}
+		var sourcesSeen *bitset.BitSet
+		if window.SourcesSeen != nil {
+			sourcesSeen = bitset.From(append([]uint64(nil), window.SourcesSeen...))
+		}
+		numValues := len(e.values)
+		e.values = append(e.values, timedTimer{})
+		copy(e.values[idx+1:numValues+1], e.values[idx:numValues])
+		e.values[idx] = timedTimer{
+			startAtNanos: window.StartAtNanos,
+			lockedAgg: &lockedTimerAggregation{
+				sourcesSeen: sourcesSeen,
+				aggregation: aggregation,
+			},
+		}
+	}
+	return nil
+}
+
 // findOrCreate finds the aggregation for a given time, or creates one
 // if it doesn't exist.
 func (e *TimerElem) findOrCreate(
@@ -407,8 +490,9 @@ func (e *TimerElem) processValueWithAggregationLock(
 	flushForwardedFn flushForwardedMetricFn,
 ) {
 	var (
-		transformations  = e.parsedPipeline.Transformations
-		discardNaNValues = e.opts.DiscardNaNAggregatedValues()
+		transformations    = e.parsedPipeline.Transformations
+		discardNaNValues   = e.opts.DiscardNaNAggregatedValues()
+		numForwardedValues int
 	)
 	for aggTypeIdx, aggType := range e.aggTypes {
 		value := lockedAgg.aggregation.ValueOf(aggType)
@@ -444,7 +528,58 @@ func (e *TimerElem) processValueWithAggregationLock(
 		} else {
 			forwardedAggregationKey, _ := e.ForwardedAggregationKey()
 			flushForwardedFn(e.writeForwardedMetricFn, forwardedAggregationKey, timeNanos, value)
+			numForwardedValues++
+		}
+	}
+	if histogram := lockedAgg.aggregation.Histogram(); histogram != nil {
+		if e.parsedPipeline.HasRollup {
+			e.forwardHistogramWithAggregationLock(timeNanos, histogram, numForwardedValues, flushForwardedFn)
+		} else {
+			e.processHistogramWithAggregationLock(timeNanos, histogram, flushLocalFn)
 		}
 	}
 	e.lastConsumedAtNanos = timeNanos
 }
+
+// forwardHistogramWithAggregationLock forwards the count of each bucket of the
+// histogram after the aggregated values forwarded for the same aggregation,
+// followed by the number of buckets and the number of aggregated values. This
+// completes the frame of values forwarded by the element, which the next
+// aggregation stage uses to merge the histograms of all the elements
+// forwarding to it.
+func (e *TimerElem) forwardHistogramWithAggregationLock(
+	timeNanos int64,
+	histogram *raggregation.Histogram,
+	numForwardedValues int,
+	flushForwardedFn flushForwardedMetricFn,
+) {
+	forwardedAggregationKey, _ := e.ForwardedAggregationKey()
+	for i := 0; i < histogram.NumBuckets(); i++ {
+		flushForwardedFn(e.writeForwardedMetricFn, forwardedAggregationKey, timeNanos, float64(histogram.BucketCount(i)))
+	}
+	flushForwardedFn(e.writeForwardedMetricFn, forwardedAggregationKey, timeNanos, float64(histogram.NumBuckets()))
+	flushForwardedFn(e.writeForwardedMetricFn, forwardedAggregationKey, timeNanos, float64(numForwardedValues))
+}
+
+// processHistogramWithAggregationLock flushes the cumulative count of each bucket
+// of the histogram as a separate series. The bucket series are only flushed for
+// local metrics with a suffixed ID, and transformations are not applied to
+// bucket counts.
+func (e *TimerElem) processHistogramWithAggregationLock(
+	timeNanos int64,
+	histogram *raggregation.Histogram,
+	flushLocalFn flushLocalMetricFn,
+) {
+	if e.idPrefixSuffixType != WithPrefixWithSuffix {
+		return
+	}
+	var (
+		fullPrefix = e.FullPrefix(e.opts)
+		suffixes   = e.opts.TimerHistogramBucketSuffixes()
+		cumulative int64
+	)
+	for i := 0; i < histogram.NumBuckets(); i++ {
+		cumulative += histogram.BucketCount(i)
+		flushLocalFn(fullPrefix, e.id, suffixes[i], timeNanos, float64(cumulative), e.sp)
+	}
+}
@@ -107,6 +107,7 @@ type clientMetrics struct {
 	writeUntimedCounter    instrument.MethodMetrics
 	writeUntimedBatchTimer instrument.MethodMetrics
 	writeUntimedGauge      instrument.MethodMetrics
+	writeTimed             instrument.MethodMetrics
 	writeForwarded         instrument.MethodMetrics
 	flush                  instrument.MethodMetrics
 	shardNotOwned          tally.Counter
@@ -118,6 +119,7 @@ func newClientMetrics(scope tally.Scope, sampleRate float64) clientMetrics {
 		writeUntimedCounter:    instrument.NewMethodMetrics(scope, "writeUntimedCounter", sampleRate),
 		writeUntimedBatchTimer: instrument.NewMethodMetrics(scope, "writeUntimedBatchTimer", sampleRate),
 		writeUntimedGauge:      instrument.NewMethodMetrics(scope, "writeUntimedGauge", sampleRate),
+		writeTimed:             instrument.NewMethodMetrics(scope, "writeTimed", sampleRate),
 		writeForwarded:         instrument.NewMethodMetrics(scope, "writeForwarded", sampleRate),
 		flush:                  instrument.NewMethodMetrics(scope, "flush", sampleRate),
 		shardNotOwned:          scope.Counter("shard-not-owned"),
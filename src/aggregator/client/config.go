@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/aggregator/sharding"
+	producerconfig "github.com/m3db/m3/src/msg/producer/config"
 	m3clusterclient "github.com/m3db/m3cluster/client"
 	"github.com/m3db/m3cluster/kv"
 	"github.com/m3db/m3cluster/placement"
@@ -49,6 +50,7 @@ type Configuration struct {
 	QueueSize                  int                            `yaml:"queueSize"`
 	QueueDropType              *DropType                      `yaml:"queueDropType"`
 	Connection                 ConnectionConfiguration        `yaml:"connection"`
+	M3Msg                      *M3MsgConfiguration            `yaml:"m3msg"`
 }
 
 // NewAdminClient creates a new admin client.
@@ -70,6 +72,9 @@ func (c *Configuration) NewClient(
 	clockOpts clock.Options,
 	instrumentOpts instrument.Options,
 ) (Client, error) {
+	if c.M3Msg != nil {
+		return c.newM3MsgClient(kvClient, clockOpts, instrumentOpts)
+	}
 	opts, err := c.newClientOptions(kvClient, clockOpts, instrumentOpts)
 	if err != nil {
 		return nil, err
@@ -77,6 +82,41 @@ func (c *Configuration) NewClient(
 	return NewClient(opts), nil
 }
 
+func (c *Configuration) newM3MsgClient(
+	kvClient m3clusterclient.Client,
+	clockOpts clock.Options,
+	instrumentOpts instrument.Options,
+) (Client, error) {
+	scope := instrumentOpts.MetricsScope()
+	iOpts := instrumentOpts.SetMetricsScope(scope.SubScope("producer"))
+	p, err := c.M3Msg.Producer.NewProducer(kvClient, iOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	iOpts = instrumentOpts.SetMetricsScope(scope.SubScope("encoder"))
+	encoderOpts := c.Encoder.NewEncoderOptions(iOpts)
+
+	shardFn, err := c.shardFn()
+	if err != nil {
+		return nil, err
+	}
+
+	m3msgOpts := NewM3MsgOptions().
+		SetProducer(p).
+		SetNumShards(c.M3Msg.TotalShards)
+	opts := NewOptions().
+		SetClockOptions(clockOpts).
+		SetInstrumentOptions(instrumentOpts).
+		SetShardFn(shardFn).
+		SetEncoderOptions(encoderOpts).
+		SetM3MsgOptions(m3msgOpts)
+	if c.MaxTimerBatchSize != 0 {
+		opts = opts.SetMaxTimerBatchSize(c.MaxTimerBatchSize)
+	}
+	return NewM3MsgClient(opts)
+}
+
 func (c *Configuration) newClientOptions(
 	kvClient m3clusterclient.Client,
 	clockOpts clock.Options,
@@ -100,12 +140,7 @@ func (c *Configuration) newClientOptions(
 	iOpts = instrumentOpts.SetMetricsScope(scope.SubScope("placement-watcher"))
 	watcherOpts := c.PlacementWatcher.NewOptions(placementStore, iOpts)
 
-	// Get the shard fn.
-	hashType := sharding.DefaultHash
-	if c.HashType != nil {
-		hashType = *c.HashType
-	}
-	shardFn, err := hashType.ShardFn()
+	shardFn, err := c.shardFn()
 	if err != nil {
 		return nil, err
 	}
@@ -139,6 +174,25 @@ func (c *Configuration) newClientOptions(
 	return opts, nil
 }
 
+func (c *Configuration) shardFn() (sharding.ShardFn, error) {
+	hashType := sharding.DefaultHash
+	if c.HashType != nil {
+		hashType = *c.HashType
+	}
+	return hashType.ShardFn()
+}
+
+// M3MsgConfiguration contains the configuration for writing metrics to an
+// m3msg topic consumed by the aggregation servers instead of writing to
+// the aggregation servers directly.
+type M3MsgConfiguration struct {
+	// Total number of shards of the m3msg topic.
+	TotalShards int `yaml:"totalShards" validate:"nonzero"`
+
+	// Producer configs the m3msg producer.
+	Producer producerconfig.ProducerConfiguration `yaml:"producer"`
+}
+
 // ConnectionConfiguration contains the connection configuration.
 type ConnectionConfiguration struct {
 	ConnectionTimeout            time.Duration        `yaml:"connectionTimeout"`
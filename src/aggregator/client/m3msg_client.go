@@ -0,0 +1,265 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/m3db/m3/src/aggregator/sharding"
+	"github.com/m3db/m3/src/msg/producer"
+	"github.com/m3db/m3metrics/encoding"
+	"github.com/m3db/m3metrics/encoding/protobuf"
+	"github.com/m3db/m3metrics/metadata"
+	"github.com/m3db/m3metrics/metric/aggregated"
+	"github.com/m3db/m3metrics/metric/id"
+	"github.com/m3db/m3metrics/metric/unaggregated"
+	"github.com/m3db/m3x/clock"
+)
+
+var errNoM3MsgOptions = errors.New("no m3msg options set")
+
+// m3msgClient writes metrics to an m3msg topic consumed by the aggregation servers,
+// which only acknowledge the metrics once they have been added to the aggregator.
+// Each metric is written as a separate message so a message that fails to be added
+// can be redelivered without adding any other metric twice.
+type m3msgClient struct {
+	sync.RWMutex
+
+	producer          producer.Producer
+	numShards         int
+	shardFn           sharding.ShardFn
+	encoderOpts       protobuf.UnaggregatedOptions
+	maxTimerBatchSize int
+	nowFn             clock.NowFn
+	state             clientState
+	metrics           clientMetrics
+}
+
+// NewM3MsgClient creates a new client writing metrics to an m3msg topic.
+func NewM3MsgClient(opts Options) (AdminClient, error) {
+	m3msgOpts := opts.M3MsgOptions()
+	if m3msgOpts == nil {
+		return nil, errNoM3MsgOptions
+	}
+	if err := m3msgOpts.Validate(); err != nil {
+		return nil, err
+	}
+	instrumentOpts := opts.InstrumentOptions()
+	return &m3msgClient{
+		producer:          m3msgOpts.Producer(),
+		numShards:         m3msgOpts.NumShards(),
+		shardFn:           opts.ShardFn(),
+		encoderOpts:       opts.EncoderOptions(),
+		maxTimerBatchSize: opts.MaxTimerBatchSize(),
+		nowFn:             opts.ClockOptions().NowFn(),
+		metrics:           newClientMetrics(instrumentOpts.MetricsScope(), instrumentOpts.MetricsSamplingRate()),
+	}, nil
+}
+
+func (c *m3msgClient) Init() error {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.state != clientUninitialized {
+		return errClientIsInitializedOrClosed
+	}
+	c.state = clientInitialized
+	return c.producer.Init()
+}
+
+func (c *m3msgClient) WriteUntimedCounter(
+	counter unaggregated.Counter,
+	metadatas metadata.StagedMetadatas,
+) error {
+	callStart := c.nowFn()
+	err := c.write(counter.ID, encoding.UnaggregatedMessageUnion{
+		Type: encoding.CounterWithMetadatasType,
+		CounterWithMetadatas: unaggregated.CounterWithMetadatas{
+			Counter:         counter,
+			StagedMetadatas: metadatas,
+		},
+	})
+	c.metrics.writeUntimedCounter.ReportSuccessOrError(err, c.nowFn().Sub(callStart))
+	return err
+}
+
+func (c *m3msgClient) WriteUntimedBatchTimer(
+	batchTimer unaggregated.BatchTimer,
+	metadatas metadata.StagedMetadatas,
+) error {
+	callStart := c.nowFn()
+	err := c.writeBatchTimer(batchTimer, metadatas)
+	c.metrics.writeUntimedBatchTimer.ReportSuccessOrError(err, c.nowFn().Sub(callStart))
+	return err
+}
+
+func (c *m3msgClient) WriteUntimedGauge(
+	gauge unaggregated.Gauge,
+	metadatas metadata.StagedMetadatas,
+) error {
+	callStart := c.nowFn()
+	err := c.write(gauge.ID, encoding.UnaggregatedMessageUnion{
+		Type: encoding.GaugeWithMetadatasType,
+		GaugeWithMetadatas: unaggregated.GaugeWithMetadatas{
+			Gauge:           gauge,
+			StagedMetadatas: metadatas,
+		},
+	})
+	c.metrics.writeUntimedGauge.ReportSuccessOrError(err, c.nowFn().Sub(callStart))
+	return err
+}
+
+func (c *m3msgClient) WriteTimed(
+	metric aggregated.Metric,
+	metadata metadata.TimedMetadata,
+) error {
+	callStart := c.nowFn()
+	err := c.write(metric.ID, encoding.UnaggregatedMessageUnion{
+		Type: encoding.TimedMetricWithMetadataType,
+		TimedMetricWithMetadata: aggregated.TimedMetricWithMetadata{
+			Metric:        metric,
+			TimedMetadata: metadata,
+		},
+	})
+	c.metrics.writeTimed.ReportSuccessOrError(err, c.nowFn().Sub(callStart))
+	return err
+}
+
+func (c *m3msgClient) WriteForwarded(
+	metric aggregated.ForwardedMetric,
+	metadata metadata.ForwardMetadata,
+) error {
+	callStart := c.nowFn()
+	err := c.write(metric.ID, encoding.UnaggregatedMessageUnion{
+		Type: encoding.ForwardedMetricWithMetadataType,
+		ForwardedMetricWithMetadata: aggregated.ForwardedMetricWithMetadata{
+			ForwardedMetric: metric,
+			ForwardMetadata: metadata,
+		},
+	})
+	c.metrics.writeForwarded.ReportSuccessOrError(err, c.nowFn().Sub(callStart))
+	return err
+}
+
+// Flush is a no-op since the messages are buffered and retried by the producer.
+func (c *m3msgClient) Flush() error {
+	c.RLock()
+	defer c.RUnlock()
+
+	if c.state != clientInitialized {
+		return errClientIsUninitializedOrClosed
+	}
+	return nil
+}
+
+func (c *m3msgClient) Close() error {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.state != clientInitialized {
+		return errClientIsUninitializedOrClosed
+	}
+	c.state = clientClosed
+	c.producer.Close(producer.WaitForConsumption)
+	return nil
+}
+
+func (c *m3msgClient) writeBatchTimer(
+	batchTimer unaggregated.BatchTimer,
+	metadatas metadata.StagedMetadatas,
+) error {
+	// If there is no limit on the timer batch size, write the full batch.
+	timerValues := batchTimer.Values
+	if c.maxTimerBatchSize == 0 || len(timerValues) <= c.maxTimerBatchSize {
+		return c.write(batchTimer.ID, newBatchTimerMessage(batchTimer, metadatas))
+	}
+
+	// Otherwise, honor maximum timer batch size.
+	for start := 0; start < len(timerValues); start += c.maxTimerBatchSize {
+		end := start + c.maxTimerBatchSize
+		if end > len(timerValues) {
+			end = len(timerValues)
+		}
+		singleBatchTimer := unaggregated.BatchTimer{
+			ID:     batchTimer.ID,
+			Values: timerValues[start:end],
+		}
+		if err := c.write(batchTimer.ID, newBatchTimerMessage(singleBatchTimer, metadatas)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *m3msgClient) write(metricID id.RawID, msg encoding.UnaggregatedMessageUnion) error {
+	c.RLock()
+	defer c.RUnlock()
+
+	if c.state != clientInitialized {
+		return errClientIsUninitializedOrClosed
+	}
+	encoder := protobuf.NewUnaggregatedEncoder(c.encoderOpts)
+	if err := encoder.EncodeMessage(msg); err != nil {
+		encoder.Relinquish().Close()
+		return err
+	}
+	shard := c.shardFn(metricID, c.numShards)
+	return c.producer.Produce(newM3MsgMessage(shard, encoder.Relinquish()))
+}
+
+func newBatchTimerMessage(
+	batchTimer unaggregated.BatchTimer,
+	metadatas metadata.StagedMetadatas,
+) encoding.UnaggregatedMessageUnion {
+	return encoding.UnaggregatedMessageUnion{
+		Type: encoding.BatchTimerWithMetadatasType,
+		BatchTimerWithMetadatas: unaggregated.BatchTimerWithMetadatas{
+			BatchTimer:      batchTimer,
+			StagedMetadatas: metadatas,
+		},
+	}
+}
+
+type m3msgMessage struct {
+	shard  uint32
+	buffer protobuf.Buffer
+}
+
+func newM3MsgMessage(shard uint32, buffer protobuf.Buffer) producer.Message {
+	return m3msgMessage{shard: shard, buffer: buffer}
+}
+
+func (m m3msgMessage) Shard() uint32 {
+	return m.shard
+}
+
+func (m m3msgMessage) Bytes() []byte {
+	return m.buffer.Bytes()
+}
+
+func (m m3msgMessage) Size() int {
+	return len(m.Bytes())
+}
+
+func (m m3msgMessage) Finalize(producer.FinalizeReason) {
+	m.buffer.Close()
+}
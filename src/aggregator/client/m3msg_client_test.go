@@ -0,0 +1,134 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/m3db/m3/src/msg/producer"
+	"github.com/m3db/m3metrics/encoding"
+	"github.com/m3db/m3metrics/encoding/protobuf"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewM3MsgClientNoOptions(t *testing.T) {
+	_, err := NewM3MsgClient(testOptions())
+	require.Equal(t, errNoM3MsgOptions, err)
+
+	_, err = NewM3MsgClient(testOptions().SetM3MsgOptions(NewM3MsgOptions()))
+	require.Equal(t, errNoM3MsgProducer, err)
+}
+
+func TestM3MsgClientWriteUninitializedOrClosed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := producer.NewMockProducer(ctrl)
+	p.EXPECT().Init().Return(nil)
+	p.EXPECT().Close(producer.WaitForConsumption)
+
+	c := testM3MsgClient(t, p)
+	require.Equal(t, errClientIsUninitializedOrClosed, c.WriteUntimedCounter(testCounter.Counter(), testStagedMetadatas))
+
+	require.NoError(t, c.Init())
+	require.Equal(t, errClientIsInitializedOrClosed, c.Init())
+	require.NoError(t, c.Close())
+	require.Equal(t, errClientIsUninitializedOrClosed, c.WriteUntimedCounter(testCounter.Counter(), testStagedMetadatas))
+	require.Equal(t, errClientIsUninitializedOrClosed, c.Close())
+}
+
+func TestM3MsgClientWriteSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var produced []encoding.UnaggregatedMessageUnion
+	p := producer.NewMockProducer(ctrl)
+	p.EXPECT().Init().Return(nil)
+	p.EXPECT().Produce(gomock.Any()).DoAndReturn(func(m producer.Message) error {
+		require.Equal(t, uint32(1), m.Shard())
+		it := protobuf.NewUnaggregatedIterator(bytes.NewReader(m.Bytes()), protobuf.NewUnaggregatedOptions())
+		defer it.Close()
+		require.True(t, it.Next())
+		produced = append(produced, it.Current())
+		require.False(t, it.Next())
+		m.Finalize(producer.Consumed)
+		return nil
+	}).Times(5)
+
+	c := testM3MsgClient(t, p)
+	require.NoError(t, c.Init())
+	require.NoError(t, c.WriteUntimedCounter(testCounter.Counter(), testStagedMetadatas))
+	require.NoError(t, c.WriteUntimedBatchTimer(testBatchTimer.BatchTimer(), testStagedMetadatas))
+	require.NoError(t, c.WriteUntimedGauge(testGauge.Gauge(), testStagedMetadatas))
+	require.NoError(t, c.WriteTimed(testTimed, testTimedMetadata))
+	require.NoError(t, c.WriteForwarded(testForwarded, testForwardMetadata))
+	require.NoError(t, c.Flush())
+
+	require.Equal(t, 5, len(produced))
+	require.Equal(t, encoding.CounterWithMetadatasType, produced[0].Type)
+	require.Equal(t, testCounter.ID, produced[0].CounterWithMetadatas.ID)
+	require.Equal(t, testCounter.CounterVal, produced[0].CounterWithMetadatas.Value)
+	require.Equal(t, encoding.BatchTimerWithMetadatasType, produced[1].Type)
+	require.Equal(t, testBatchTimer.BatchTimerVal, produced[1].BatchTimerWithMetadatas.Values)
+	require.Equal(t, encoding.GaugeWithMetadatasType, produced[2].Type)
+	require.Equal(t, testGauge.GaugeVal, produced[2].GaugeWithMetadatas.Value)
+	require.Equal(t, encoding.TimedMetricWithMetadataType, produced[3].Type)
+	require.Equal(t, testTimed.Value, produced[3].TimedMetricWithMetadata.Value)
+	require.Equal(t, encoding.ForwardedMetricWithMetadataType, produced[4].Type)
+	require.Equal(t, testForwarded.Values, produced[4].ForwardedMetricWithMetadata.Values)
+}
+
+func TestM3MsgClientWriteBatchTimerSplitsBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var values []float64
+	p := producer.NewMockProducer(ctrl)
+	p.EXPECT().Init().Return(nil)
+	p.EXPECT().Produce(gomock.Any()).DoAndReturn(func(m producer.Message) error {
+		it := protobuf.NewUnaggregatedIterator(bytes.NewReader(m.Bytes()), protobuf.NewUnaggregatedOptions())
+		defer it.Close()
+		require.True(t, it.Next())
+		timerValues := it.Current().BatchTimerWithMetadatas.Values
+		require.True(t, len(timerValues) <= 2)
+		values = append(values, timerValues...)
+		m.Finalize(producer.Consumed)
+		return nil
+	}).Times(2)
+
+	c, err := NewM3MsgClient(testOptions().
+		SetMaxTimerBatchSize(2).
+		SetM3MsgOptions(NewM3MsgOptions().SetProducer(p).SetNumShards(4)))
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+	require.NoError(t, c.WriteUntimedBatchTimer(testBatchTimer.BatchTimer(), testStagedMetadatas))
+	require.Equal(t, testBatchTimer.BatchTimerVal, values)
+}
+
+func testM3MsgClient(t *testing.T, p producer.Producer) AdminClient {
+	c, err := NewM3MsgClient(testOptions().
+		SetM3MsgOptions(NewM3MsgOptions().SetProducer(p).SetNumShards(4)))
+	require.NoError(t, err)
+	return c
+}
@@ -127,6 +127,12 @@ type Options interface {
 	// QueueDropType returns sets the strategy for which metrics should metrics should be dropped
 	// when the queue is full.
 	QueueDropType() DropType
+
+	// SetM3MsgOptions sets the options used by clients writing to m3msg topics.
+	SetM3MsgOptions(value M3MsgOptions) Options
+
+	// M3MsgOptions returns the options used by clients writing to m3msg topics.
+	M3MsgOptions() M3MsgOptions
 }
 
 type options struct {
@@ -142,6 +148,7 @@ type options struct {
 	maxTimerBatchSize          int
 	instanceQueueSize          int
 	dropType                   DropType
+	m3msgOpts                  M3MsgOptions
 }
 
 // NewOptions creates a new set of client options.
@@ -281,3 +288,13 @@ func (o *options) SetQueueDropType(value DropType) Options {
 func (o *options) QueueDropType() DropType {
 	return o.dropType
 }
+
+func (o *options) SetM3MsgOptions(value M3MsgOptions) Options {
+	opts := *o
+	opts.m3msgOpts = value
+	return &opts
+}
+
+func (o *options) M3MsgOptions() M3MsgOptions {
+	return o.m3msgOpts
+}
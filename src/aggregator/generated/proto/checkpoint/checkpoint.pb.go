@@ -0,0 +1,2811 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: github.com/m3db/m3/src/aggregator/generated/proto/checkpoint/checkpoint.proto
+
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+/*
+	Package checkpoint is a generated protocol buffer package.
+
+	It is generated from these files:
+		github.com/m3db/m3/src/aggregator/generated/proto/checkpoint/checkpoint.proto
+
+	It has these top-level messages:
+		ShardCheckpoint
+		ElemCheckpoint
+		WindowCheckpoint
+		AggregationSnapshot
+		CounterSnapshot
+		GaugeSnapshot
+		TimerSnapshot
+		StreamSnapshot
+		SampleSnapshot
+		HistogramSnapshot
+*/
+package checkpoint
+
+import proto "github.com/gogo/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import binary "encoding/binary"
+
+import io "io"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+type ShardCheckpoint struct {
+	Version        int32             `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Shard          uint32            `protobuf:"varint,2,opt,name=shard,proto3" json:"shard,omitempty"`
+	CreatedAtNanos int64             `protobuf:"varint,3,opt,name=created_at_nanos,json=createdAtNanos,proto3" json:"created_at_nanos,omitempty"`
+	Elems          []*ElemCheckpoint `protobuf:"bytes,4,rep,name=elems" json:"elems,omitempty"`
+}
+
+func (m *ShardCheckpoint) Reset()                    { *m = ShardCheckpoint{} }
+func (m *ShardCheckpoint) String() string            { return proto.CompactTextString(m) }
+func (*ShardCheckpoint) ProtoMessage()               {}
+func (*ShardCheckpoint) Descriptor() ([]byte, []int) { return fileDescriptorCheckpoint, []int{0} }
+
+func (m *ShardCheckpoint) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *ShardCheckpoint) GetShard() uint32 {
+	if m != nil {
+		return m.Shard
+	}
+	return 0
+}
+
+func (m *ShardCheckpoint) GetCreatedAtNanos() int64 {
+	if m != nil {
+		return m.CreatedAtNanos
+	}
+	return 0
+}
+
+func (m *ShardCheckpoint) GetElems() []*ElemCheckpoint {
+	if m != nil {
+		return m.Elems
+	}
+	return nil
+}
+
+type ElemCheckpoint struct {
+	ListType           int32               `protobuf:"varint,1,opt,name=list_type,json=listType,proto3" json:"list_type,omitempty"`
+	MetricType         int32               `protobuf:"varint,2,opt,name=metric_type,json=metricType,proto3" json:"metric_type,omitempty"`
+	Id                 []byte              `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	Key                []byte              `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	IdPrefixSuffixType int32               `protobuf:"varint,5,opt,name=id_prefix_suffix_type,json=idPrefixSuffixType,proto3" json:"id_prefix_suffix_type,omitempty"`
+	Windows            []*WindowCheckpoint `protobuf:"bytes,6,rep,name=windows" json:"windows,omitempty"`
+}
+
+func (m *ElemCheckpoint) Reset()                    { *m = ElemCheckpoint{} }
+func (m *ElemCheckpoint) String() string            { return proto.CompactTextString(m) }
+func (*ElemCheckpoint) ProtoMessage()               {}
+func (*ElemCheckpoint) Descriptor() ([]byte, []int) { return fileDescriptorCheckpoint, []int{1} }
+
+func (m *ElemCheckpoint) GetListType() int32 {
+	if m != nil {
+		return m.ListType
+	}
+	return 0
+}
+
+func (m *ElemCheckpoint) GetMetricType() int32 {
+	if m != nil {
+		return m.MetricType
+	}
+	return 0
+}
+
+func (m *ElemCheckpoint) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *ElemCheckpoint) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *ElemCheckpoint) GetIdPrefixSuffixType() int32 {
+	if m != nil {
+		return m.IdPrefixSuffixType
+	}
+	return 0
+}
+
+func (m *ElemCheckpoint) GetWindows() []*WindowCheckpoint {
+	if m != nil {
+		return m.Windows
+	}
+	return nil
+}
+
+type WindowCheckpoint struct {
+	StartAtNanos int64                `protobuf:"varint,1,opt,name=start_at_nanos,json=startAtNanos,proto3" json:"start_at_nanos,omitempty"`
+	SourcesSeen  []uint64             `protobuf:"varint,2,rep,packed,name=sources_seen,json=sourcesSeen" json:"sources_seen,omitempty"`
+	Aggregation  *AggregationSnapshot `protobuf:"bytes,3,opt,name=aggregation" json:"aggregation,omitempty"`
+}
+
+func (m *WindowCheckpoint) Reset()                    { *m = WindowCheckpoint{} }
+func (m *WindowCheckpoint) String() string            { return proto.CompactTextString(m) }
+func (*WindowCheckpoint) ProtoMessage()               {}
+func (*WindowCheckpoint) Descriptor() ([]byte, []int) { return fileDescriptorCheckpoint, []int{2} }
+
+func (m *WindowCheckpoint) GetStartAtNanos() int64 {
+	if m != nil {
+		return m.StartAtNanos
+	}
+	return 0
+}
+
+func (m *WindowCheckpoint) GetSourcesSeen() []uint64 {
+	if m != nil {
+		return m.SourcesSeen
+	}
+	return nil
+}
+
+func (m *WindowCheckpoint) GetAggregation() *AggregationSnapshot {
+	if m != nil {
+		return m.Aggregation
+	}
+	return nil
+}
+
+type AggregationSnapshot struct {
+	Counter *CounterSnapshot `protobuf:"bytes,1,opt,name=counter" json:"counter,omitempty"`
+	Gauge   *GaugeSnapshot   `protobuf:"bytes,2,opt,name=gauge" json:"gauge,omitempty"`
+	Timer   *TimerSnapshot   `protobuf:"bytes,3,opt,name=timer" json:"timer,omitempty"`
+}
+
+func (m *AggregationSnapshot) Reset()                    { *m = AggregationSnapshot{} }
+func (m *AggregationSnapshot) String() string            { return proto.CompactTextString(m) }
+func (*AggregationSnapshot) ProtoMessage()               {}
+func (*AggregationSnapshot) Descriptor() ([]byte, []int) { return fileDescriptorCheckpoint, []int{3} }
+
+func (m *AggregationSnapshot) GetCounter() *CounterSnapshot {
+	if m != nil {
+		return m.Counter
+	}
+	return nil
+}
+
+func (m *AggregationSnapshot) GetGauge() *GaugeSnapshot {
+	if m != nil {
+		return m.Gauge
+	}
+	return nil
+}
+
+func (m *AggregationSnapshot) GetTimer() *TimerSnapshot {
+	if m != nil {
+		return m.Timer
+	}
+	return nil
+}
+
+type CounterSnapshot struct {
+	Sum   int64 `protobuf:"varint,1,opt,name=sum,proto3" json:"sum,omitempty"`
+	SumSq int64 `protobuf:"varint,2,opt,name=sum_sq,json=sumSq,proto3" json:"sum_sq,omitempty"`
+	Count int64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Max   int64 `protobuf:"varint,4,opt,name=max,proto3" json:"max,omitempty"`
+	Min   int64 `protobuf:"varint,5,opt,name=min,proto3" json:"min,omitempty"`
+}
+
+func (m *CounterSnapshot) Reset()                    { *m = CounterSnapshot{} }
+func (m *CounterSnapshot) String() string            { return proto.CompactTextString(m) }
+func (*CounterSnapshot) ProtoMessage()               {}
+func (*CounterSnapshot) Descriptor() ([]byte, []int) { return fileDescriptorCheckpoint, []int{4} }
+
+func (m *CounterSnapshot) GetSum() int64 {
+	if m != nil {
+		return m.Sum
+	}
+	return 0
+}
+
+func (m *CounterSnapshot) GetSumSq() int64 {
+	if m != nil {
+		return m.SumSq
+	}
+	return 0
+}
+
+func (m *CounterSnapshot) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *CounterSnapshot) GetMax() int64 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+func (m *CounterSnapshot) GetMin() int64 {
+	if m != nil {
+		return m.Min
+	}
+	return 0
+}
+
+type GaugeSnapshot struct {
+	Last  float64 `protobuf:"fixed64,1,opt,name=last,proto3" json:"last,omitempty"`
+	Sum   float64 `protobuf:"fixed64,2,opt,name=sum,proto3" json:"sum,omitempty"`
+	SumSq float64 `protobuf:"fixed64,3,opt,name=sum_sq,json=sumSq,proto3" json:"sum_sq,omitempty"`
+	Count int64   `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	Max   float64 `protobuf:"fixed64,5,opt,name=max,proto3" json:"max,omitempty"`
+	Min   float64 `protobuf:"fixed64,6,opt,name=min,proto3" json:"min,omitempty"`
+}
+
+func (m *GaugeSnapshot) Reset()                    { *m = GaugeSnapshot{} }
+func (m *GaugeSnapshot) String() string            { return proto.CompactTextString(m) }
+func (*GaugeSnapshot) ProtoMessage()               {}
+func (*GaugeSnapshot) Descriptor() ([]byte, []int) { return fileDescriptorCheckpoint, []int{5} }
+
+func (m *GaugeSnapshot) GetLast() float64 {
+	if m != nil {
+		return m.Last
+	}
+	return 0
+}
+
+func (m *GaugeSnapshot) GetSum() float64 {
+	if m != nil {
+		return m.Sum
+	}
+	return 0
+}
+
+func (m *GaugeSnapshot) GetSumSq() float64 {
+	if m != nil {
+		return m.SumSq
+	}
+	return 0
+}
+
+func (m *GaugeSnapshot) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *GaugeSnapshot) GetMax() float64 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+func (m *GaugeSnapshot) GetMin() float64 {
+	if m != nil {
+		return m.Min
+	}
+	return 0
+}
+
+type TimerSnapshot struct {
+	Count     int64              `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Sum       float64            `protobuf:"fixed64,2,opt,name=sum,proto3" json:"sum,omitempty"`
+	SumSq     float64            `protobuf:"fixed64,3,opt,name=sum_sq,json=sumSq,proto3" json:"sum_sq,omitempty"`
+	Stream    *StreamSnapshot    `protobuf:"bytes,4,opt,name=stream" json:"stream,omitempty"`
+	Histogram *HistogramSnapshot `protobuf:"bytes,5,opt,name=histogram" json:"histogram,omitempty"`
+}
+
+func (m *TimerSnapshot) Reset()                    { *m = TimerSnapshot{} }
+func (m *TimerSnapshot) String() string            { return proto.CompactTextString(m) }
+func (*TimerSnapshot) ProtoMessage()               {}
+func (*TimerSnapshot) Descriptor() ([]byte, []int) { return fileDescriptorCheckpoint, []int{6} }
+
+func (m *TimerSnapshot) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *TimerSnapshot) GetSum() float64 {
+	if m != nil {
+		return m.Sum
+	}
+	return 0
+}
+
+func (m *TimerSnapshot) GetSumSq() float64 {
+	if m != nil {
+		return m.SumSq
+	}
+	return 0
+}
+
+func (m *TimerSnapshot) GetStream() *StreamSnapshot {
+	if m != nil {
+		return m.Stream
+	}
+	return nil
+}
+
+func (m *TimerSnapshot) GetHistogram() *HistogramSnapshot {
+	if m != nil {
+		return m.Histogram
+	}
+	return nil
+}
+
+type StreamSnapshot struct {
+	NumValues int64             `protobuf:"varint,1,opt,name=num_values,json=numValues,proto3" json:"num_values,omitempty"`
+	Samples   []*SampleSnapshot `protobuf:"bytes,2,rep,name=samples" json:"samples,omitempty"`
+}
+
+func (m *StreamSnapshot) Reset()                    { *m = StreamSnapshot{} }
+func (m *StreamSnapshot) String() string            { return proto.CompactTextString(m) }
+func (*StreamSnapshot) ProtoMessage()               {}
+func (*StreamSnapshot) Descriptor() ([]byte, []int) { return fileDescriptorCheckpoint, []int{7} }
+
+func (m *StreamSnapshot) GetNumValues() int64 {
+	if m != nil {
+		return m.NumValues
+	}
+	return 0
+}
+
+func (m *StreamSnapshot) GetSamples() []*SampleSnapshot {
+	if m != nil {
+		return m.Samples
+	}
+	return nil
+}
+
+type SampleSnapshot struct {
+	Value    float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	NumRanks int64   `protobuf:"varint,2,opt,name=num_ranks,json=numRanks,proto3" json:"num_ranks,omitempty"`
+	Delta    int64   `protobuf:"varint,3,opt,name=delta,proto3" json:"delta,omitempty"`
+}
+
+func (m *SampleSnapshot) Reset()                    { *m = SampleSnapshot{} }
+func (m *SampleSnapshot) String() string            { return proto.CompactTextString(m) }
+func (*SampleSnapshot) ProtoMessage()               {}
+func (*SampleSnapshot) Descriptor() ([]byte, []int) { return fileDescriptorCheckpoint, []int{8} }
+
+func (m *SampleSnapshot) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *SampleSnapshot) GetNumRanks() int64 {
+	if m != nil {
+		return m.NumRanks
+	}
+	return 0
+}
+
+func (m *SampleSnapshot) GetDelta() int64 {
+	if m != nil {
+		return m.Delta
+	}
+	return 0
+}
+
+type HistogramSnapshot struct {
+	Buckets []float64 `protobuf:"fixed64,1,rep,packed,name=buckets" json:"buckets,omitempty"`
+	Counts  []int64   `protobuf:"varint,2,rep,packed,name=counts" json:"counts,omitempty"`
+	Count   int64     `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Sum     float64   `protobuf:"fixed64,4,opt,name=sum,proto3" json:"sum,omitempty"`
+}
+
+func (m *HistogramSnapshot) Reset()                    { *m = HistogramSnapshot{} }
+func (m *HistogramSnapshot) String() string            { return proto.CompactTextString(m) }
+func (*HistogramSnapshot) ProtoMessage()               {}
+func (*HistogramSnapshot) Descriptor() ([]byte, []int) { return fileDescriptorCheckpoint, []int{9} }
+
+func (m *HistogramSnapshot) GetBuckets() []float64 {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+func (m *HistogramSnapshot) GetCounts() []int64 {
+	if m != nil {
+		return m.Counts
+	}
+	return nil
+}
+
+func (m *HistogramSnapshot) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *HistogramSnapshot) GetSum() float64 {
+	if m != nil {
+		return m.Sum
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*ShardCheckpoint)(nil), "ShardCheckpoint")
+	proto.RegisterType((*ElemCheckpoint)(nil), "ElemCheckpoint")
+	proto.RegisterType((*WindowCheckpoint)(nil), "WindowCheckpoint")
+	proto.RegisterType((*AggregationSnapshot)(nil), "AggregationSnapshot")
+	proto.RegisterType((*CounterSnapshot)(nil), "CounterSnapshot")
+	proto.RegisterType((*GaugeSnapshot)(nil), "GaugeSnapshot")
+	proto.RegisterType((*TimerSnapshot)(nil), "TimerSnapshot")
+	proto.RegisterType((*StreamSnapshot)(nil), "StreamSnapshot")
+	proto.RegisterType((*SampleSnapshot)(nil), "SampleSnapshot")
+	proto.RegisterType((*HistogramSnapshot)(nil), "HistogramSnapshot")
+}
+func (m *ShardCheckpoint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ShardCheckpoint) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Version != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.Version))
+	}
+	if m.Shard != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.Shard))
+	}
+	if m.CreatedAtNanos != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.CreatedAtNanos))
+	}
+	if len(m.Elems) > 0 {
+		for _, msg := range m.Elems {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintCheckpoint(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *ElemCheckpoint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ElemCheckpoint) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ListType != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.ListType))
+	}
+	if m.MetricType != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.MetricType))
+	}
+	if len(m.Id) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	if len(m.Key) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
+	if m.IdPrefixSuffixType != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.IdPrefixSuffixType))
+	}
+	if len(m.Windows) > 0 {
+		for _, msg := range m.Windows {
+			dAtA[i] = 0x32
+			i++
+			i = encodeVarintCheckpoint(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *WindowCheckpoint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WindowCheckpoint) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.StartAtNanos != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.StartAtNanos))
+	}
+	if len(m.SourcesSeen) > 0 {
+		dAtA2 := make([]byte, len(m.SourcesSeen)*10)
+		var j1 int
+		for _, num := range m.SourcesSeen {
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
+		}
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(j1))
+		i += copy(dAtA[i:], dAtA2[:j1])
+	}
+	if m.Aggregation != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.Aggregation.Size()))
+		n3, err := m.Aggregation.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	return i, nil
+}
+
+func (m *AggregationSnapshot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AggregationSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Counter != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.Counter.Size()))
+		n4, err := m.Counter.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
+	if m.Gauge != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.Gauge.Size()))
+		n5, err := m.Gauge.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	if m.Timer != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.Timer.Size()))
+		n6, err := m.Timer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	return i, nil
+}
+
+func (m *CounterSnapshot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CounterSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Sum != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.Sum))
+	}
+	if m.SumSq != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.SumSq))
+	}
+	if m.Count != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.Count))
+	}
+	if m.Max != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.Max))
+	}
+	if m.Min != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.Min))
+	}
+	return i, nil
+}
+
+func (m *GaugeSnapshot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GaugeSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Last != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Last))))
+		i += 8
+	}
+	if m.Sum != 0 {
+		dAtA[i] = 0x11
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Sum))))
+		i += 8
+	}
+	if m.SumSq != 0 {
+		dAtA[i] = 0x19
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.SumSq))))
+		i += 8
+	}
+	if m.Count != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.Count))
+	}
+	if m.Max != 0 {
+		dAtA[i] = 0x29
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Max))))
+		i += 8
+	}
+	if m.Min != 0 {
+		dAtA[i] = 0x31
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Min))))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *TimerSnapshot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TimerSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Count != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.Count))
+	}
+	if m.Sum != 0 {
+		dAtA[i] = 0x11
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Sum))))
+		i += 8
+	}
+	if m.SumSq != 0 {
+		dAtA[i] = 0x19
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.SumSq))))
+		i += 8
+	}
+	if m.Stream != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.Stream.Size()))
+		n7, err := m.Stream.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
+	if m.Histogram != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.Histogram.Size()))
+		n8, err := m.Histogram.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
+	}
+	return i, nil
+}
+
+func (m *StreamSnapshot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.NumValues != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.NumValues))
+	}
+	if len(m.Samples) > 0 {
+		for _, msg := range m.Samples {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintCheckpoint(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *SampleSnapshot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SampleSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Value != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Value))))
+		i += 8
+	}
+	if m.NumRanks != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.NumRanks))
+	}
+	if m.Delta != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.Delta))
+	}
+	return i, nil
+}
+
+func (m *HistogramSnapshot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HistogramSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Buckets) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.Buckets)*8))
+		for _, num := range m.Buckets {
+			f9 := math.Float64bits(float64(num))
+			binary.LittleEndian.PutUint64(dAtA[i:], uint64(f9))
+			i += 8
+		}
+	}
+	if len(m.Counts) > 0 {
+		dAtA11 := make([]byte, len(m.Counts)*10)
+		var j10 int
+		for _, num1 := range m.Counts {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA11[j10] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j10++
+			}
+			dAtA11[j10] = uint8(num)
+			j10++
+		}
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(j10))
+		i += copy(dAtA[i:], dAtA11[:j10])
+	}
+	if m.Count != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.Count))
+	}
+	if m.Sum != 0 {
+		dAtA[i] = 0x21
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Sum))))
+		i += 8
+	}
+	return i, nil
+}
+
+func encodeVarintCheckpoint(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *ShardCheckpoint) Size() (n int) {
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovCheckpoint(uint64(m.Version))
+	}
+	if m.Shard != 0 {
+		n += 1 + sovCheckpoint(uint64(m.Shard))
+	}
+	if m.CreatedAtNanos != 0 {
+		n += 1 + sovCheckpoint(uint64(m.CreatedAtNanos))
+	}
+	if len(m.Elems) > 0 {
+		for _, e := range m.Elems {
+			l = e.Size()
+			n += 1 + l + sovCheckpoint(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ElemCheckpoint) Size() (n int) {
+	var l int
+	_ = l
+	if m.ListType != 0 {
+		n += 1 + sovCheckpoint(uint64(m.ListType))
+	}
+	if m.MetricType != 0 {
+		n += 1 + sovCheckpoint(uint64(m.MetricType))
+	}
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
+	if m.IdPrefixSuffixType != 0 {
+		n += 1 + sovCheckpoint(uint64(m.IdPrefixSuffixType))
+	}
+	if len(m.Windows) > 0 {
+		for _, e := range m.Windows {
+			l = e.Size()
+			n += 1 + l + sovCheckpoint(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *WindowCheckpoint) Size() (n int) {
+	var l int
+	_ = l
+	if m.StartAtNanos != 0 {
+		n += 1 + sovCheckpoint(uint64(m.StartAtNanos))
+	}
+	if len(m.SourcesSeen) > 0 {
+		l = 0
+		for _, e := range m.SourcesSeen {
+			l += sovCheckpoint(uint64(e))
+		}
+		n += 1 + sovCheckpoint(uint64(l)) + l
+	}
+	if m.Aggregation != nil {
+		l = m.Aggregation.Size()
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
+	return n
+}
+
+func (m *AggregationSnapshot) Size() (n int) {
+	var l int
+	_ = l
+	if m.Counter != nil {
+		l = m.Counter.Size()
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
+	if m.Gauge != nil {
+		l = m.Gauge.Size()
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
+	if m.Timer != nil {
+		l = m.Timer.Size()
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
+	return n
+}
+
+func (m *CounterSnapshot) Size() (n int) {
+	var l int
+	_ = l
+	if m.Sum != 0 {
+		n += 1 + sovCheckpoint(uint64(m.Sum))
+	}
+	if m.SumSq != 0 {
+		n += 1 + sovCheckpoint(uint64(m.SumSq))
+	}
+	if m.Count != 0 {
+		n += 1 + sovCheckpoint(uint64(m.Count))
+	}
+	if m.Max != 0 {
+		n += 1 + sovCheckpoint(uint64(m.Max))
+	}
+	if m.Min != 0 {
+		n += 1 + sovCheckpoint(uint64(m.Min))
+	}
+	return n
+}
+
+func (m *GaugeSnapshot) Size() (n int) {
+	var l int
+	_ = l
+	if m.Last != 0 {
+		n += 9
+	}
+	if m.Sum != 0 {
+		n += 9
+	}
+	if m.SumSq != 0 {
+		n += 9
+	}
+	if m.Count != 0 {
+		n += 1 + sovCheckpoint(uint64(m.Count))
+	}
+	if m.Max != 0 {
+		n += 9
+	}
+	if m.Min != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *TimerSnapshot) Size() (n int) {
+	var l int
+	_ = l
+	if m.Count != 0 {
+		n += 1 + sovCheckpoint(uint64(m.Count))
+	}
+	if m.Sum != 0 {
+		n += 9
+	}
+	if m.SumSq != 0 {
+		n += 9
+	}
+	if m.Stream != nil {
+		l = m.Stream.Size()
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
+	if m.Histogram != nil {
+		l = m.Histogram.Size()
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
+	return n
+}
+
+func (m *StreamSnapshot) Size() (n int) {
+	var l int
+	_ = l
+	if m.NumValues != 0 {
+		n += 1 + sovCheckpoint(uint64(m.NumValues))
+	}
+	if len(m.Samples) > 0 {
+		for _, e := range m.Samples {
+			l = e.Size()
+			n += 1 + l + sovCheckpoint(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SampleSnapshot) Size() (n int) {
+	var l int
+	_ = l
+	if m.Value != 0 {
+		n += 9
+	}
+	if m.NumRanks != 0 {
+		n += 1 + sovCheckpoint(uint64(m.NumRanks))
+	}
+	if m.Delta != 0 {
+		n += 1 + sovCheckpoint(uint64(m.Delta))
+	}
+	return n
+}
+
+func (m *HistogramSnapshot) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Buckets) > 0 {
+		n += 1 + sovCheckpoint(uint64(len(m.Buckets)*8)) + len(m.Buckets)*8
+	}
+	if len(m.Counts) > 0 {
+		l = 0
+		for _, e := range m.Counts {
+			l += sovCheckpoint(uint64(e))
+		}
+		n += 1 + sovCheckpoint(uint64(l)) + l
+	}
+	if m.Count != 0 {
+		n += 1 + sovCheckpoint(uint64(m.Count))
+	}
+	if m.Sum != 0 {
+		n += 9
+	}
+	return n
+}
+
+func sovCheckpoint(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozCheckpoint(x uint64) (n int) {
+	return sovCheckpoint(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ShardCheckpoint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShardCheckpoint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShardCheckpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+			}
+			m.Shard = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Shard |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAtNanos", wireType)
+			}
+			m.CreatedAtNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CreatedAtNanos |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Elems", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Elems = append(m.Elems, &ElemCheckpoint{})
+			if err := m.Elems[len(m.Elems)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCheckpoint(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ElemCheckpoint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ElemCheckpoint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ElemCheckpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ListType", wireType)
+			}
+			m.ListType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ListType |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MetricType", wireType)
+			}
+			m.MetricType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MetricType |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = append(m.Id[:0], dAtA[iNdEx:postIndex]...)
+			if m.Id == nil {
+				m.Id = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IdPrefixSuffixType", wireType)
+			}
+			m.IdPrefixSuffixType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IdPrefixSuffixType |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Windows", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Windows = append(m.Windows, &WindowCheckpoint{})
+			if err := m.Windows[len(m.Windows)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCheckpoint(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WindowCheckpoint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WindowCheckpoint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WindowCheckpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartAtNanos", wireType)
+			}
+			m.StartAtNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartAtNanos |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.SourcesSeen = append(m.SourcesSeen, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthCheckpoint
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.SourcesSeen = append(m.SourcesSeen, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourcesSeen", wireType)
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Aggregation", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Aggregation == nil {
+				m.Aggregation = &AggregationSnapshot{}
+			}
+			if err := m.Aggregation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCheckpoint(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AggregationSnapshot) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AggregationSnapshot: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AggregationSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Counter", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Counter == nil {
+				m.Counter = &CounterSnapshot{}
+			}
+			if err := m.Counter.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Gauge", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Gauge == nil {
+				m.Gauge = &GaugeSnapshot{}
+			}
+			if err := m.Gauge.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Timer == nil {
+				m.Timer = &TimerSnapshot{}
+			}
+			if err := m.Timer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCheckpoint(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CounterSnapshot) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CounterSnapshot: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CounterSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sum", wireType)
+			}
+			m.Sum = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sum |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SumSq", wireType)
+			}
+			m.SumSq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SumSq |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Max", wireType)
+			}
+			m.Max = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Max |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Min", wireType)
+			}
+			m.Min = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Min |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCheckpoint(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GaugeSnapshot) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GaugeSnapshot: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GaugeSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Last", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Last = float64(math.Float64frombits(v))
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sum", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Sum = float64(math.Float64frombits(v))
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SumSq", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.SumSq = float64(math.Float64frombits(v))
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Max", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Max = float64(math.Float64frombits(v))
+		case 6:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Min", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Min = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCheckpoint(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TimerSnapshot) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TimerSnapshot: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TimerSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sum", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Sum = float64(math.Float64frombits(v))
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SumSq", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.SumSq = float64(math.Float64frombits(v))
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stream", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Stream == nil {
+				m.Stream = &StreamSnapshot{}
+			}
+			if err := m.Stream.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Histogram", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Histogram == nil {
+				m.Histogram = &HistogramSnapshot{}
+			}
+			if err := m.Histogram.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCheckpoint(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StreamSnapshot) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StreamSnapshot: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StreamSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumValues", wireType)
+			}
+			m.NumValues = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumValues |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Samples", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Samples = append(m.Samples, &SampleSnapshot{})
+			if err := m.Samples[len(m.Samples)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCheckpoint(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SampleSnapshot) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SampleSnapshot: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SampleSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Value = float64(math.Float64frombits(v))
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumRanks", wireType)
+			}
+			m.NumRanks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumRanks |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Delta", wireType)
+			}
+			m.Delta = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Delta |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCheckpoint(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HistogramSnapshot) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HistogramSnapshot: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HistogramSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 1 {
+				var v uint64
+				if (iNdEx + 8) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+				iNdEx += 8
+				v2 := float64(math.Float64frombits(v))
+				m.Buckets = append(m.Buckets, v2)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthCheckpoint
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					v2 := float64(math.Float64frombits(v))
+					m.Buckets = append(m.Buckets, v2)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Buckets", wireType)
+			}
+		case 2:
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (int64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Counts = append(m.Counts, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthCheckpoint
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (int64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Counts = append(m.Counts, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Counts", wireType)
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sum", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Sum = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCheckpoint(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipCheckpoint(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			iNdEx += length
+			if length < 0 {
+				return 0, ErrInvalidLengthCheckpoint
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return 0, ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipCheckpoint(dAtA[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthCheckpoint = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowCheckpoint   = fmt.Errorf("proto: integer overflow")
+)
+
+func init() {
+	proto.RegisterFile("github.com/m3db/m3/src/aggregator/generated/proto/checkpoint/checkpoint.proto", fileDescriptorCheckpoint)
+}
+
+var fileDescriptorCheckpoint = []byte{
+	// 736 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xdd, 0x8e, 0xe3, 0x34,
+	0x14, 0xc6, 0x4d, 0xd3, 0xee, 0x9c, 0xcc, 0x64, 0xba, 0x66, 0x17, 0x45, 0x42, 0x94, 0x12, 0x2d,
+	0xa2, 0x80, 0x94, 0x42, 0x47, 0xe2, 0x7e, 0x59, 0x21, 0xb8, 0x01, 0xa1, 0x74, 0x05, 0x82, 0x9b,
+	0xc8, 0x4d, 0xbc, 0xa9, 0xd5, 0xda, 0xe9, 0xda, 0xce, 0xee, 0xcc, 0x0b, 0x70, 0x87, 0x84, 0xe0,
+	0x29, 0x78, 0x13, 0x2e, 0xe7, 0x11, 0xd0, 0xf0, 0x22, 0xc8, 0x27, 0x49, 0xff, 0x18, 0xa4, 0xbd,
+	0xaa, 0xbf, 0xef, 0x3b, 0x39, 0xe7, 0xf3, 0x39, 0xa7, 0x86, 0x6f, 0x4b, 0x61, 0x57, 0xf5, 0x32,
+	0xc9, 0x2b, 0x39, 0x93, 0x57, 0xc5, 0x72, 0x26, 0xaf, 0x66, 0x46, 0xe7, 0x33, 0x56, 0x96, 0x9a,
+	0x97, 0xcc, 0x56, 0x7a, 0x56, 0x72, 0xc5, 0x35, 0xb3, 0xbc, 0x98, 0x6d, 0x75, 0x65, 0xab, 0x59,
+	0xbe, 0xe2, 0xf9, 0x7a, 0x5b, 0x09, 0x65, 0x0f, 0x8e, 0x09, 0x6a, 0xf1, 0xef, 0x04, 0x2e, 0x17,
+	0x2b, 0xa6, 0x8b, 0x67, 0x3b, 0x85, 0x46, 0x30, 0x7c, 0xc5, 0xb5, 0x11, 0x95, 0x8a, 0xc8, 0x84,
+	0x4c, 0xfd, 0xb4, 0x83, 0xf4, 0x11, 0xf8, 0xc6, 0x05, 0x47, 0xbd, 0x09, 0x99, 0x5e, 0xa4, 0x0d,
+	0xa0, 0x53, 0x18, 0xe5, 0x9a, 0xbb, 0x82, 0x19, 0xb3, 0x99, 0x62, 0xaa, 0x32, 0x91, 0x37, 0x21,
+	0x53, 0x2f, 0x0d, 0x5b, 0xfe, 0xa9, 0xfd, 0xce, 0xb1, 0xf4, 0x43, 0xf0, 0xf9, 0x86, 0x4b, 0x13,
+	0xf5, 0x27, 0xde, 0x34, 0x98, 0x5f, 0x26, 0x5f, 0x6d, 0xb8, 0xdc, 0x57, 0x4e, 0x1b, 0x35, 0xbe,
+	0x25, 0x10, 0x1e, 0x2b, 0xf4, 0x5d, 0x38, 0xdb, 0x08, 0x63, 0x33, 0x7b, 0xb3, 0xe5, 0xad, 0xab,
+	0x07, 0x8e, 0x78, 0x7e, 0xb3, 0xe5, 0xf4, 0x7d, 0x08, 0x24, 0xb7, 0x5a, 0xe4, 0x8d, 0xdc, 0x43,
+	0x19, 0x1a, 0x0a, 0x03, 0x42, 0xe8, 0x89, 0x02, 0x3d, 0x9d, 0xa7, 0x3d, 0x51, 0xd0, 0x11, 0x78,
+	0x6b, 0x7e, 0x13, 0xf5, 0x91, 0x70, 0x47, 0xfa, 0x39, 0x3c, 0x16, 0x45, 0xb6, 0xd5, 0xfc, 0x85,
+	0xb8, 0xce, 0x4c, 0xfd, 0xc2, 0xfd, 0x60, 0x32, 0x1f, 0x93, 0x51, 0x51, 0x7c, 0x8f, 0xda, 0x02,
+	0x25, 0x4c, 0xfa, 0x29, 0x0c, 0x5f, 0x0b, 0x55, 0x54, 0xaf, 0x4d, 0x34, 0xc0, 0xeb, 0x3c, 0x4c,
+	0x7e, 0x44, 0x7c, 0x70, 0xa1, 0x2e, 0x22, 0xfe, 0x83, 0xc0, 0xe8, 0x54, 0xa5, 0x4f, 0x20, 0x34,
+	0x96, 0x69, 0xbb, 0x6f, 0x1b, 0xc1, 0xb6, 0x9d, 0x23, 0xdb, 0x35, 0xed, 0x03, 0x38, 0x37, 0x55,
+	0xad, 0x73, 0x6e, 0x32, 0xc3, 0xb9, 0x8a, 0x7a, 0x13, 0x6f, 0xda, 0x4f, 0x83, 0x96, 0x5b, 0x70,
+	0xae, 0xe8, 0x17, 0x10, 0x74, 0xf3, 0x77, 0x53, 0x73, 0x17, 0x0d, 0xe6, 0x8f, 0x92, 0xa7, 0x7b,
+	0x6e, 0xa1, 0xd8, 0xd6, 0xac, 0x2a, 0x9b, 0x1e, 0x06, 0xc6, 0xbf, 0x12, 0x78, 0xfb, 0x9e, 0x20,
+	0xfa, 0x09, 0x0c, 0xf3, 0xaa, 0x56, 0x96, 0x6b, 0x74, 0x14, 0xcc, 0x47, 0xc9, 0xb3, 0x06, 0xef,
+	0xf2, 0x74, 0x01, 0xf4, 0x09, 0xf8, 0x25, 0xab, 0xcb, 0xa6, 0xed, 0xc1, 0x3c, 0x4c, 0xbe, 0x76,
+	0x68, 0x17, 0xd7, 0x88, 0x2e, 0xca, 0x0a, 0xc9, 0x75, 0xeb, 0x2d, 0x4c, 0x9e, 0x3b, 0xb4, 0x8f,
+	0x42, 0x31, 0xb6, 0x70, 0x79, 0x52, 0xc7, 0x8d, 0xca, 0xd4, 0xb2, 0x6d, 0x8c, 0x3b, 0xd2, 0xc7,
+	0x30, 0x30, 0xb5, 0xcc, 0xcc, 0x4b, 0xac, 0xe8, 0xa5, 0xbe, 0xa9, 0xe5, 0xe2, 0xa5, 0xdb, 0x4d,
+	0xb4, 0xd4, 0xae, 0x5e, 0x03, 0xdc, 0xe7, 0x92, 0x5d, 0xe3, 0xa4, 0xbd, 0xd4, 0x1d, 0x91, 0x11,
+	0x2a, 0xf2, 0x5b, 0x46, 0xa8, 0xf8, 0x17, 0x02, 0x17, 0x47, 0xa6, 0x29, 0x85, 0xfe, 0x86, 0x19,
+	0x8b, 0x55, 0x49, 0x8a, 0xe7, 0xce, 0x48, 0x0f, 0xa9, 0x13, 0x23, 0x1e, 0x92, 0xa7, 0x46, 0xfa,
+	0xf7, 0x18, 0xf1, 0x9b, 0xcf, 0x0f, 0x8c, 0x0c, 0x5a, 0x46, 0xa8, 0xf8, 0x4f, 0x02, 0x17, 0x47,
+	0x7d, 0xd9, 0xe7, 0x22, 0x27, 0xb9, 0xde, 0xcc, 0xca, 0x47, 0x30, 0x30, 0x56, 0x73, 0x26, 0xd1,
+	0x8b, 0xfb, 0xc3, 0x2d, 0x10, 0xee, 0xfa, 0xde, 0xca, 0xf4, 0x33, 0x38, 0x5b, 0x09, 0x63, 0xab,
+	0x52, 0x33, 0x89, 0x1e, 0x83, 0x39, 0x4d, 0xbe, 0xe9, 0x98, 0x5d, 0xf8, 0x3e, 0x28, 0xfe, 0x19,
+	0xc2, 0xe3, 0x5c, 0xf4, 0x3d, 0x00, 0x55, 0xcb, 0xec, 0x15, 0xdb, 0xd4, 0xbc, 0xdb, 0xe4, 0x33,
+	0x55, 0xcb, 0x1f, 0x90, 0xa0, 0x1f, 0xc3, 0xd0, 0x30, 0xb9, 0xdd, 0x70, 0x83, 0x1b, 0x8c, 0x66,
+	0x10, 0xef, 0x57, 0xaa, 0xd5, 0xe3, 0x9f, 0x20, 0x3c, 0x96, 0x5c, 0x1f, 0x30, 0x6f, 0x3b, 0x91,
+	0x06, 0xb8, 0x47, 0xc1, 0x55, 0xd4, 0x4c, 0xad, 0x4d, 0xbb, 0x0c, 0x0f, 0x54, 0x2d, 0x53, 0x87,
+	0xdd, 0x27, 0x05, 0xdf, 0x58, 0xd6, 0xed, 0x03, 0x82, 0x58, 0xc2, 0xc3, 0xff, 0x5c, 0xcb, 0x3d,
+	0x78, 0xcb, 0x3a, 0x5f, 0x73, 0xeb, 0x6c, 0x7b, 0x53, 0x92, 0x76, 0x90, 0xbe, 0x03, 0x03, 0x6c,
+	0x79, 0xe3, 0xd9, 0x4b, 0x5b, 0xf4, 0xff, 0xcb, 0x66, 0xea, 0xa6, 0xd7, 0xcd, 0x5c, 0xbe, 0x1c,
+	0xfd, 0x75, 0x37, 0x26, 0xb7, 0x77, 0x63, 0xf2, 0xf7, 0xdd, 0x98, 0xfc, 0xf6, 0xcf, 0xf8, 0xad,
+	0xe5, 0x00, 0xdf, 0xdd, 0xab, 0x7f, 0x07, 0x00, 0x0a, 0xef, 0x3e, 0x43, 0xc8, 0x05, 0x00, 0x00,
+}
@@ -0,0 +1,344 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package m3msg
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/m3db/m3/src/aggregator/aggregator"
+	"github.com/m3db/m3/src/aggregator/rate"
+	"github.com/m3db/m3/src/msg/consumer"
+	"github.com/m3db/m3metrics/encoding"
+	"github.com/m3db/m3metrics/encoding/protobuf"
+	"github.com/m3db/m3metrics/metadata"
+	"github.com/m3db/m3metrics/metric/aggregated"
+	"github.com/m3db/m3metrics/metric/unaggregated"
+	"github.com/m3db/m3x/log"
+	xserver "github.com/m3db/m3x/server"
+
+	"github.com/uber-go/tally"
+)
+
+// NewServer creates a new m3msg server that consumes metrics from an m3msg topic.
+func NewServer(address string, aggregator aggregator.Aggregator, opts Options) xserver.Server {
+	iOpts := opts.InstrumentOptions()
+	handlerScope := iOpts.MetricsScope().Tagged(map[string]string{"handler": "m3msg"})
+	handler := NewHandler(aggregator, opts.SetInstrumentOptions(iOpts.SetMetricsScope(handlerScope)))
+	return xserver.NewServer(address, handler, opts.ServerOptions())
+}
+
+type handlerMetrics struct {
+	messagesAcked            tally.Counter
+	messageReadErrors        tally.Counter
+	unknownMessageTypeErrors tally.Counter
+	addUntimedErrors         tally.Counter
+	addTimedErrors           tally.Counter
+	addForwardedErrors       tally.Counter
+	unknownErrorTypeErrors   tally.Counter
+	nonRetryableErrors       tally.Counter
+	partialMessageErrors     tally.Counter
+	decodeErrors             tally.Counter
+	errLogRateLimited        tally.Counter
+}
+
+func newHandlerMetrics(scope tally.Scope) handlerMetrics {
+	return handlerMetrics{
+		messagesAcked:            scope.Counter("messages-acked"),
+		messageReadErrors:        scope.Counter("message-read-errors"),
+		unknownMessageTypeErrors: scope.Counter("unknown-message-type-errors"),
+		addUntimedErrors:         scope.Counter("add-untimed-errors"),
+		addTimedErrors:           scope.Counter("add-timed-errors"),
+		addForwardedErrors:       scope.Counter("add-forwarded-errors"),
+		unknownErrorTypeErrors:   scope.Counter("unknown-error-type-errors"),
+		nonRetryableErrors:       scope.Counter("non-retryable-errors"),
+		partialMessageErrors:     scope.Counter("partial-message-errors"),
+		decodeErrors:             scope.Counter("decode-errors"),
+		errLogRateLimited:        scope.Counter("error-log-rate-limited"),
+	}
+}
+
+type handler struct {
+	aggregator       aggregator.Aggregator
+	log              log.Logger
+	protobufItOpts   protobuf.UnaggregatedOptions
+	isTransientErrFn func(err error) bool
+
+	errLogRateLimiter *rate.Limiter
+	metrics           handlerMetrics
+}
+
+// NewHandler creates a new m3msg handler. Each message received is acked only
+// after all the metrics it carries have been processed, so that the producer
+// redelivers messages whose metrics failed to be added because of a transient
+// error. Metrics that can never be added, such as metrics that arrived too late
+// or carry invalid metadata, and messages that can not be decoded are acked and
+// dropped since redelivering them would never succeed. Messages whose metrics
+// have been partially added are acked as well since redelivering them would add
+// the same values twice.
+func NewHandler(aggregator aggregator.Aggregator, opts Options) xserver.Handler {
+	h := newHandler(aggregator, opts)
+	return consumer.NewHandler(h.Handle, opts.ConsumerOptions())
+}
+
+func newHandler(agg aggregator.Aggregator, opts Options) *handler {
+	nowFn := opts.ClockOptions().NowFn()
+	iOpts := opts.InstrumentOptions()
+	var limiter *rate.Limiter
+	if rateLimit := opts.ErrorLogLimitPerSecond(); rateLimit != 0 {
+		limiter = rate.NewLimiter(rateLimit, nowFn)
+	}
+	return &handler{
+		aggregator:        agg,
+		log:               iOpts.Logger(),
+		protobufItOpts:    opts.ProtobufUnaggregatedIteratorOptions(),
+		isTransientErrFn:  aggregator.IsTransientError,
+		errLogRateLimiter: limiter,
+		metrics:           newHandlerMetrics(iOpts.MetricsScope()),
+	}
+}
+
+// Handle processes the messages of a consumer until the consumer is closed.
+func (h *handler) Handle(c consumer.Consumer) {
+	var (
+		reader = bytes.NewReader(nil)
+		msg    consumer.Message
+		err    error
+	)
+	for {
+		msg, err = c.Message()
+		if err != nil {
+			break
+		}
+		if !h.processMessage(reader, msg) {
+			continue
+		}
+		msg.Ack()
+		h.metrics.messagesAcked.Inc(1)
+	}
+
+	// An EOF error is returned when the connection is closed by the producer.
+	if err != io.EOF {
+		h.metrics.messageReadErrors.Inc(1)
+		h.log.Errorf("could not read message from consumer: %v", err)
+	}
+	c.Close()
+}
+
+// processMessage adds the metrics carried by the message to the aggregator and
+// returns true if the message should be acked.
+func (h *handler) processMessage(reader *bytes.Reader, msg consumer.Message) bool {
+	reader.Reset(msg.Bytes())
+	it := protobuf.NewUnaggregatedIterator(reader, h.protobufItOpts)
+	defer it.Close()
+
+	var (
+		untimedMetric   unaggregated.MetricUnion
+		stagedMetadatas metadata.StagedMetadatas
+		forwardedMetric aggregated.ForwardedMetric
+		forwardMetadata metadata.ForwardMetadata
+		timedMetric     aggregated.Metric
+		timedMetadata   metadata.TimedMetadata
+		added           bool
+		err             error
+	)
+	for it.Next() {
+		current := it.Current()
+		switch current.Type {
+		case encoding.CounterWithMetadatasType:
+			untimedMetric = current.CounterWithMetadatas.Counter.ToUnion()
+			stagedMetadatas = current.CounterWithMetadatas.StagedMetadatas
+			err = toAddUntimedError(h.aggregator.AddUntimed(untimedMetric, stagedMetadatas))
+		case encoding.BatchTimerWithMetadatasType:
+			untimedMetric = current.BatchTimerWithMetadatas.BatchTimer.ToUnion()
+			stagedMetadatas = current.BatchTimerWithMetadatas.StagedMetadatas
+			err = toAddUntimedError(h.aggregator.AddUntimed(untimedMetric, stagedMetadatas))
+		case encoding.GaugeWithMetadatasType:
+			untimedMetric = current.GaugeWithMetadatas.Gauge.ToUnion()
+			stagedMetadatas = current.GaugeWithMetadatas.StagedMetadatas
+			err = toAddUntimedError(h.aggregator.AddUntimed(untimedMetric, stagedMetadatas))
+		case encoding.ForwardedMetricWithMetadataType:
+			forwardedMetric = current.ForwardedMetricWithMetadata.ForwardedMetric
+			forwardMetadata = current.ForwardedMetricWithMetadata.ForwardMetadata
+			err = toAddForwardedError(h.aggregator.AddForwarded(forwardedMetric, forwardMetadata))
+		case encoding.TimedMetricWithMetadataType:
+			timedMetric = current.TimedMetricWithMetadata.Metric
+			timedMetadata = current.TimedMetricWithMetadata.TimedMetadata
+			err = toAddTimedError(h.aggregator.AddTimed(timedMetric, timedMetadata))
+		default:
+			err = newUnknownMessageTypeError(current.Type)
+		}
+
+		if err == nil {
+			added = true
+			continue
+		}
+
+		// NB: Stop processing the message on the first transient failure and
+		// leave it unacked so it gets redelivered, unless some of its metrics
+		// have already been added in which case the message is acked and the
+		// remaining metrics are dropped so the added ones are not counted twice
+		// on redelivery. Metrics that failed with a non-retryable error are dropped.
+		retry := h.isRetryableError(err)
+		if retry && added {
+			h.metrics.partialMessageErrors.Inc(1)
+			retry = false
+		}
+		if !retry {
+			h.metrics.nonRetryableErrors.Inc(1)
+		}
+		switch err.(type) {
+		case unknownMessageTypeError:
+			h.metrics.unknownMessageTypeErrors.Inc(1)
+		case addUntimedError:
+			h.metrics.addUntimedErrors.Inc(1)
+		case addForwardedError:
+			h.metrics.addForwardedErrors.Inc(1)
+		case addTimedError:
+			h.metrics.addTimedErrors.Inc(1)
+		default:
+			h.metrics.unknownErrorTypeErrors.Inc(1)
+		}
+
+		// We rate limit the error log here because the error rate may scale with
+		// the metrics incoming rate and consume lots of cpu cycles.
+		if h.errLogRateLimiter != nil && !h.errLogRateLimiter.IsAllowed(1) {
+			h.metrics.errLogRateLimited.Inc(1)
+			if retry {
+				return false
+			}
+			continue
+		}
+		switch err.(type) {
+		case unknownMessageTypeError:
+			h.log.WithFields(
+				log.NewErrField(err),
+			).Error("unexpected message type")
+		case addUntimedError:
+			h.log.WithFields(
+				log.NewField("type", untimedMetric.Type.String()),
+				log.NewField("id", untimedMetric.ID.String()),
+				log.NewField("metadatas", stagedMetadatas),
+				log.NewErrField(err),
+			).Error("error adding untimed metric")
+		case addForwardedError:
+			h.log.WithFields(
+				log.NewField("id", forwardedMetric.ID.String()),
+				log.NewField("timestamp", time.Unix(0, forwardedMetric.TimeNanos).String()),
+				log.NewField("values", forwardedMetric.Values),
+				log.NewErrField(err),
+			).Error("error adding forwarded metric")
+		case addTimedError:
+			h.log.WithFields(
+				log.NewField("id", timedMetric.ID.String()),
+				log.NewField("timestamp", time.Unix(0, timedMetric.TimeNanos).String()),
+				log.NewField("value", timedMetric.Value),
+				log.NewErrField(err),
+			).Error("error adding timed metric")
+		default:
+			h.log.WithFields(
+				log.NewField("errorType", fmt.Sprintf("%T", err)),
+				log.NewErrField(err),
+			).Errorf("unknown error type")
+		}
+		if retry {
+			return false
+		}
+	}
+
+	if err := it.Err(); err != nil && err != io.EOF {
+		h.log.WithFields(
+			log.NewErrField(err),
+		).Error("decode error")
+		h.metrics.decodeErrors.Inc(1)
+	}
+	return true
+}
+
+// isRetryableError returns true if the metric that failed with the error may
+// be added successfully when the message is redelivered.
+func (h *handler) isRetryableError(err error) bool {
+	switch err := err.(type) {
+	case addUntimedError:
+		return h.isTransientErrFn(err.err)
+	case addTimedError:
+		return h.isTransientErrFn(err.err)
+	case addForwardedError:
+		return h.isTransientErrFn(err.err)
+	default:
+		return false
+	}
+}
+
+type unknownMessageTypeError struct {
+	msgType encoding.UnaggregatedMessageType
+}
+
+func newUnknownMessageTypeError(
+	msgType encoding.UnaggregatedMessageType,
+) unknownMessageTypeError {
+	return unknownMessageTypeError{msgType: msgType}
+}
+
+func (e unknownMessageTypeError) Error() string {
+	return fmt.Sprintf("unknown message type %v", e.msgType)
+}
+
+type addUntimedError struct {
+	err error
+}
+
+func toAddUntimedError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return addUntimedError{err: err}
+}
+
+func (e addUntimedError) Error() string { return e.err.Error() }
+
+type addTimedError struct {
+	err error
+}
+
+func toAddTimedError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return addTimedError{err: err}
+}
+
+func (e addTimedError) Error() string { return e.err.Error() }
+
+type addForwardedError struct {
+	err error
+}
+
+func toAddForwardedError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return addForwardedError{err: err}
+}
+
+func (e addForwardedError) Error() string { return e.err.Error() }
@@ -0,0 +1,286 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package m3msg
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/aggregator/aggregator"
+	"github.com/m3db/m3/src/aggregator/aggregator/capture"
+	"github.com/m3db/m3/src/msg/consumer"
+	"github.com/m3db/m3metrics/aggregation"
+	"github.com/m3db/m3metrics/encoding"
+	"github.com/m3db/m3metrics/encoding/protobuf"
+	"github.com/m3db/m3metrics/metadata"
+	"github.com/m3db/m3metrics/metric"
+	"github.com/m3db/m3metrics/metric/aggregated"
+	"github.com/m3db/m3metrics/metric/unaggregated"
+	"github.com/m3db/m3metrics/pipeline"
+	"github.com/m3db/m3metrics/pipeline/applied"
+	"github.com/m3db/m3metrics/policy"
+	"github.com/m3db/m3x/instrument"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+var (
+	errTestTransient = errors.New("transient error")
+
+	testCounterWithMetadatas = unaggregated.CounterWithMetadatas{
+		Counter: unaggregated.Counter{
+			ID:    []byte("testCounter"),
+			Value: 123,
+		},
+		StagedMetadatas: metadata.DefaultStagedMetadatas,
+	}
+	testBatchTimerWithMetadatas = unaggregated.BatchTimerWithMetadatas{
+		BatchTimer: unaggregated.BatchTimer{
+			ID:     []byte("testBatchTimer"),
+			Values: []float64{1.0, 2.0, 3.0},
+		},
+		StagedMetadatas: metadata.DefaultStagedMetadatas,
+	}
+	testGaugeWithMetadatas = unaggregated.GaugeWithMetadatas{
+		Gauge: unaggregated.Gauge{
+			ID:    []byte("testGauge"),
+			Value: 456.780,
+		},
+		StagedMetadatas: metadata.DefaultStagedMetadatas,
+	}
+	testTimedMetricWithMetadata = aggregated.TimedMetricWithMetadata{
+		Metric: aggregated.Metric{
+			Type:      metric.CounterType,
+			ID:        []byte("testTimed"),
+			TimeNanos: 12345,
+			Value:     -13,
+		},
+		TimedMetadata: metadata.TimedMetadata{
+			AggregationID: aggregation.DefaultID,
+			StoragePolicy: policy.NewStoragePolicy(time.Minute, xtime.Minute, 12*time.Hour),
+		},
+	}
+	testForwardedMetricWithMetadata = aggregated.ForwardedMetricWithMetadata{
+		ForwardedMetric: aggregated.ForwardedMetric{
+			Type:      metric.CounterType,
+			ID:        []byte("testForwarded"),
+			TimeNanos: 12345,
+			Values:    []float64{908, -13},
+		},
+		ForwardMetadata: metadata.ForwardMetadata{
+			AggregationID: aggregation.DefaultID,
+			StoragePolicy: policy.NewStoragePolicy(time.Minute, xtime.Minute, 12*time.Hour),
+			Pipeline: applied.NewPipeline([]applied.OpUnion{
+				{
+					Type: pipeline.RollupOpType,
+					Rollup: applied.RollupOp{
+						ID:            []byte("foo"),
+						AggregationID: aggregation.MustCompressTypes(aggregation.Count),
+					},
+				},
+			}),
+			SourceID:          1234,
+			NumForwardedTimes: 3,
+		},
+	}
+	testMessages = []encoding.UnaggregatedMessageUnion{
+		{
+			Type:                 encoding.CounterWithMetadatasType,
+			CounterWithMetadatas: testCounterWithMetadatas,
+		},
+		{
+			Type:                    encoding.BatchTimerWithMetadatasType,
+			BatchTimerWithMetadatas: testBatchTimerWithMetadatas,
+		},
+		{
+			Type:               encoding.GaugeWithMetadatasType,
+			GaugeWithMetadatas: testGaugeWithMetadatas,
+		},
+		{
+			Type:                    encoding.TimedMetricWithMetadataType,
+			TimedMetricWithMetadata: testTimedMetricWithMetadata,
+		},
+		{
+			Type:                        encoding.ForwardedMetricWithMetadataType,
+			ForwardedMetricWithMetadata: testForwardedMetricWithMetadata,
+		},
+	}
+	testCmpOpts = []cmp.Option{
+		cmpopts.EquateEmpty(),
+		cmp.AllowUnexported(policy.StoragePolicy{}),
+	}
+)
+
+func TestHandlerAcksAddedMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var msgs []consumer.Message
+	for _, m := range testMessages {
+		msg := consumer.NewMockMessage(ctrl)
+		msg.EXPECT().Bytes().Return(encodeTestMessage(t, m))
+		msg.EXPECT().Ack()
+		msgs = append(msgs, msg)
+	}
+
+	agg := capture.NewAggregator()
+	c := &testConsumer{msgs: msgs}
+	newHandler(agg, NewOptions()).Handle(c)
+	require.True(t, c.closed)
+	require.Equal(t, len(testMessages), agg.NumMetricsAdded())
+
+	expected := capture.SnapshotResult{
+		CountersWithMetadatas:        []unaggregated.CounterWithMetadatas{testCounterWithMetadatas},
+		BatchTimersWithMetadatas:     []unaggregated.BatchTimerWithMetadatas{testBatchTimerWithMetadatas},
+		GaugesWithMetadatas:          []unaggregated.GaugeWithMetadatas{testGaugeWithMetadatas},
+		TimedMetricWithMetadata:      []aggregated.TimedMetricWithMetadata{testTimedMetricWithMetadata},
+		ForwardedMetricsWithMetadata: []aggregated.ForwardedMetricWithMetadata{testForwardedMetricWithMetadata},
+	}
+	snapshot := agg.Snapshot()
+	require.True(t, cmp.Equal(expected, snapshot, testCmpOpts...), expected, snapshot)
+}
+
+func TestHandlerDoesNotAckFailedAdds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	failed := consumer.NewMockMessage(ctrl)
+	failed.EXPECT().Bytes().Return(encodeTestMessage(t, testMessages[0]))
+	added := consumer.NewMockMessage(ctrl)
+	added.EXPECT().Bytes().Return(encodeTestMessage(t, testMessages[3]))
+	added.EXPECT().Ack()
+
+	agg := &untimedErrorAggregator{
+		Aggregator: capture.NewAggregator(),
+		err:        errTestTransient,
+	}
+	c := &testConsumer{msgs: []consumer.Message{failed, added}}
+	newTestHandler(agg, NewOptions()).Handle(c)
+	require.True(t, c.closed)
+	require.Equal(t, 1, agg.NumMetricsAdded())
+}
+
+func TestHandlerAcksPartiallyAddedMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	partial := consumer.NewMockMessage(ctrl)
+	partial.EXPECT().Bytes().Return(encodeTestMessage(t, testMessages[3], testMessages[0]))
+	partial.EXPECT().Ack()
+
+	scope := tally.NewTestScope("", nil)
+	opts := NewOptions().SetInstrumentOptions(
+		instrument.NewOptions().SetMetricsScope(scope))
+	agg := &untimedErrorAggregator{
+		Aggregator: capture.NewAggregator(),
+		err:        errTestTransient,
+	}
+	c := &testConsumer{msgs: []consumer.Message{partial}}
+	newTestHandler(agg, opts).Handle(c)
+	require.True(t, c.closed)
+	require.Equal(t, 1, agg.NumMetricsAdded())
+
+	counters := scope.Snapshot().Counters()
+	require.Equal(t, int64(1), counters["partial-message-errors+"].Value())
+	require.Equal(t, int64(1), counters["messages-acked+"].Value())
+}
+
+func TestHandlerAcksNonRetryableErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	failed := consumer.NewMockMessage(ctrl)
+	failed.EXPECT().Bytes().Return(encodeTestMessage(t, testMessages[0]))
+	failed.EXPECT().Ack()
+	added := consumer.NewMockMessage(ctrl)
+	added.EXPECT().Bytes().Return(encodeTestMessage(t, testMessages[3]))
+	added.EXPECT().Ack()
+
+	scope := tally.NewTestScope("", nil)
+	opts := NewOptions().SetInstrumentOptions(
+		instrument.NewOptions().SetMetricsScope(scope))
+	agg := &untimedErrorAggregator{
+		Aggregator: capture.NewAggregator(),
+		err:        errors.New("add untimed error"),
+	}
+	c := &testConsumer{msgs: []consumer.Message{failed, added}}
+	newHandler(agg, opts).Handle(c)
+	require.True(t, c.closed)
+	require.Equal(t, 1, agg.NumMetricsAdded())
+
+	counters := scope.Snapshot().Counters()
+	require.Equal(t, int64(1), counters["non-retryable-errors+"].Value())
+	require.Equal(t, int64(2), counters["messages-acked+"].Value())
+}
+
+func encodeTestMessage(t *testing.T, msgs ...encoding.UnaggregatedMessageUnion) []byte {
+	encoder := protobuf.NewUnaggregatedEncoder(protobuf.NewUnaggregatedOptions())
+	for _, m := range msgs {
+		require.NoError(t, encoder.EncodeMessage(m))
+	}
+	return encoder.Relinquish().Bytes()
+}
+
+// newTestHandler returns a handler treating errTestTransient as the only
+// transient error.
+func newTestHandler(agg aggregator.Aggregator, opts Options) *handler {
+	h := newHandler(agg, opts)
+	h.isTransientErrFn = func(err error) bool { return err == errTestTransient }
+	return h
+}
+
+type testConsumer struct {
+	msgs   []consumer.Message
+	closed bool
+}
+
+func (c *testConsumer) Message() (consumer.Message, error) {
+	if len(c.msgs) == 0 {
+		return nil, io.EOF
+	}
+	msg := c.msgs[0]
+	c.msgs = c.msgs[1:]
+	return msg, nil
+}
+
+func (c *testConsumer) Init() {}
+
+func (c *testConsumer) Close() { c.closed = true }
+
+type untimedErrorAggregator struct {
+	capture.Aggregator
+
+	err error
+}
+
+func (agg *untimedErrorAggregator) AddUntimed(
+	unaggregated.MetricUnion,
+	metadata.StagedMetadatas,
+) error {
+	return agg.err
+}
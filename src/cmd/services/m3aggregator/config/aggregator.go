@@ -29,6 +29,7 @@ import (
 	"sort"
 	"time"
 
+	raggregation "github.com/m3db/m3/src/aggregator/aggregation"
 	"github.com/m3db/m3/src/aggregator/aggregation/quantile/cm"
 	"github.com/m3db/m3/src/aggregator/aggregator"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler"
@@ -53,6 +54,8 @@ import (
 var (
 	errNoKVClientConfiguration = errors.New("no kv client configuration")
 	errEmptyJitterBucketList   = errors.New("empty jitter bucket list")
+
+	errInvalidHistogramBucketsConfig = errors.New("exactly one of bounds, fixed and exponential histogram buckets must be set")
 )
 
 // AggregatorConfiguration contains aggregator configuration.
@@ -102,6 +105,9 @@ type AggregatorConfiguration struct {
 	// Resign timeout.
 	ResignTimeout time.Duration `yaml:"resignTimeout"`
 
+	// Checkpointing of the in-flight aggregation state, disabled if not set.
+	Checkpoint *checkpointConfiguration `yaml:"checkpoint"`
+
 	// Flush times manager.
 	FlushTimesManager flushTimesManagerConfiguration `yaml:"flushTimesManager"`
 
@@ -138,6 +144,9 @@ type AggregatorConfiguration struct {
 	// Whether to discard NaN aggregated values.
 	DiscardNaNAggregatedValues *bool `yaml:"discardNaNAggregatedValues"`
 
+	// Buckets of the histogram tracked by timers, timers do not track a histogram if not set.
+	TimerHistogramBuckets *histogramBucketsConfiguration `yaml:"timerHistogramBuckets"`
+
 	// Pool of counter elements.
 	CounterElemPool pool.ObjectPoolConfiguration `yaml:"counterElemPool"`
 
@@ -240,6 +249,14 @@ func (c *AggregatorConfiguration) NewAggregatorOptions(
 		opts = opts.SetResignTimeout(c.ResignTimeout)
 	}
 
+	// Set checkpointing of the in-flight aggregation state.
+	if c.Checkpoint != nil {
+		opts = opts.SetCheckpointStore(c.Checkpoint.NewCheckpointStore())
+		if c.Checkpoint.Every != 0 {
+			opts = opts.SetCheckpointEvery(c.Checkpoint.Every)
+		}
+	}
+
 	// Set flush times manager.
 	iOpts = instrumentOpts.SetMetricsScope(scope.SubScope("flush-times-manager"))
 	flushTimesManager, err := c.FlushTimesManager.NewFlushTimesManager(client, iOpts)
@@ -321,6 +338,15 @@ func (c *AggregatorConfiguration) NewAggregatorOptions(
 		opts = opts.SetDiscardNaNAggregatedValues(*c.DiscardNaNAggregatedValues)
 	}
 
+	// Set timer histogram buckets.
+	if c.TimerHistogramBuckets != nil {
+		buckets, err := c.TimerHistogramBuckets.NewHistogramBuckets()
+		if err != nil {
+			return nil, err
+		}
+		opts = opts.SetTimerHistogramBuckets(buckets)
+	}
+
 	// Set counter elem pool.
 	iOpts = instrumentOpts.SetMetricsScope(scope.SubScope("counter-elem-pool"))
 	counterElemPoolOpts := c.CounterElemPool.NewObjectPoolOptions(iOpts)
@@ -600,6 +626,70 @@ func (c electionManagerConfiguration) NewElectionManager(
 	return electionManager, nil
 }
 
+// checkpointConfiguration configures checkpointing of the in-flight
+// aggregation state to local disk.
+type checkpointConfiguration struct {
+	// Directory the checkpoints are stored in.
+	Path string `yaml:"path" validate:"nonzero"`
+
+	// How frequently the in-flight aggregation state is checkpointed.
+	Every time.Duration `yaml:"every"`
+}
+
+func (c checkpointConfiguration) NewCheckpointStore() aggregator.CheckpointStore {
+	return aggregator.NewFileCheckpointStore(c.Path)
+}
+
+// histogramBucketsConfiguration configures the buckets of a histogram, where
+// exactly one of the bucket layouts should be set.
+type histogramBucketsConfiguration struct {
+	// Upper bounds of the buckets in ascending order.
+	Bounds []float64 `yaml:"bounds"`
+
+	// Buckets of the same width.
+	Fixed *fixedHistogramBucketsConfiguration `yaml:"fixed"`
+
+	// Buckets whose upper bounds grow exponentially.
+	Exponential *exponentialHistogramBucketsConfiguration `yaml:"exponential"`
+}
+
+func (c histogramBucketsConfiguration) NewHistogramBuckets() (raggregation.HistogramBuckets, error) {
+	switch {
+	case len(c.Bounds) > 0 && c.Fixed == nil && c.Exponential == nil:
+		buckets := make(raggregation.HistogramBuckets, len(c.Bounds))
+		copy(buckets, c.Bounds)
+		return buckets, buckets.Validate()
+	case len(c.Bounds) == 0 && c.Fixed != nil && c.Exponential == nil:
+		return raggregation.NewFixedHistogramBuckets(c.Fixed.Start, c.Fixed.Width, c.Fixed.Count)
+	case len(c.Bounds) == 0 && c.Fixed == nil && c.Exponential != nil:
+		return raggregation.NewExponentialHistogramBuckets(c.Exponential.Start, c.Exponential.Factor, c.Exponential.Count)
+	default:
+		return nil, errInvalidHistogramBucketsConfig
+	}
+}
+
+type fixedHistogramBucketsConfiguration struct {
+	// Upper bound of the first bucket.
+	Start float64 `yaml:"start"`
+
+	// Width of each bucket.
+	Width float64 `yaml:"width"`
+
+	// Number of buckets, excluding the overflow bucket.
+	Count int `yaml:"count"`
+}
+
+type exponentialHistogramBucketsConfiguration struct {
+	// Upper bound of the first bucket.
+	Start float64 `yaml:"start"`
+
+	// Factor by which the upper bound grows from one bucket to the next.
+	Factor float64 `yaml:"factor"`
+
+	// Number of buckets, excluding the overflow bucket.
+	Count int `yaml:"count"`
+}
+
 type electionConfiguration struct {
 	LeaderTimeout time.Duration `yaml:"leaderTimeout"`
 	ResignTimeout time.Duration `yaml:"resignTimeout"`
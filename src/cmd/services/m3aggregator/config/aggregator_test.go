@@ -118,3 +118,56 @@ func TestMaxAllowedForwardingDelayFnJitterDisabled(t *testing.T) {
 		require.Equal(t, input.expected, fn(input.resolution, input.numForwardedTimes))
 	}
 }
+
+func TestHistogramBucketsConfiguration(t *testing.T) {
+	inputs := []struct {
+		config   string
+		expected []float64
+	}{
+		{
+			config:   `bounds: [0.1, 0.5, 1]`,
+			expected: []float64{0.1, 0.5, 1},
+		},
+		{
+			config: `
+fixed:
+  start: 10
+  width: 10
+  count: 3`,
+			expected: []float64{10, 20, 30},
+		},
+		{
+			config: `
+exponential:
+  start: 1
+  factor: 10
+  count: 3`,
+			expected: []float64{1, 10, 100},
+		},
+	}
+	for _, input := range inputs {
+		var cfg histogramBucketsConfiguration
+		require.NoError(t, yaml.Unmarshal([]byte(input.config), &cfg))
+		buckets, err := cfg.NewHistogramBuckets()
+		require.NoError(t, err)
+		require.Equal(t, input.expected, []float64(buckets))
+	}
+}
+
+func TestHistogramBucketsConfigurationInvalid(t *testing.T) {
+	for _, config := range []string{
+		`bounds: []`,
+		`bounds: [1, 0.5]`,
+		`
+bounds: [1]
+fixed:
+  start: 10
+  width: 10
+  count: 3`,
+	} {
+		var cfg histogramBucketsConfiguration
+		require.NoError(t, yaml.Unmarshal([]byte(config), &cfg))
+		_, err := cfg.NewHistogramBuckets()
+		require.Error(t, err)
+	}
+}
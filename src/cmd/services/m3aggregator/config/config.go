@@ -36,6 +36,9 @@ type Configuration struct {
 	// Raw TCP server configuration.
 	RawTCP RawTCPServerConfiguration `yaml:"rawtcp"`
 
+	// M3msg server configuration, the m3msg server is disabled if not set.
+	M3Msg *M3MsgServerConfiguration `yaml:"m3msg"`
+
 	// HTTP server configuration.
 	HTTP HTTPServerConfiguration `yaml:"http"`
 
@@ -20,9 +20,25 @@
 
 package downsample
 
+import (
+	"github.com/m3db/m3metrics/rules"
+)
+
 // Downsampler is a downsampler.
 type Downsampler interface {
 	NewMetricsAppender() MetricsAppender
+
+	// NewRuleFilter parses a rule filter with the same options the
+	// downsampler uses to match series against rules.
+	NewRuleFilter(filter string) (RuleFilter, error)
+
+	// NewRulesValidator returns a validator for rule sets matched by the
+	// downsampler.
+	NewRulesValidator() rules.Validator
+
+	// MatchRules matches the series with the given tags against the current
+	// rules without aggregating the series.
+	MatchRules(tags map[string]string) (RulesMatchResult, error)
 }
 
 // MetricsAppender is a metrics appender that can build a samples
@@ -39,6 +55,7 @@ type MetricsAppender interface {
 type SamplesAppender interface {
 	AppendCounterSample(value int64) error
 	AppendGaugeSample(value float64) error
+	AppendTimerSample(value float64) error
 }
 
 type downsampler struct {
@@ -214,6 +214,81 @@ func tagsToStringMap(tags models.Tags) map[string]string {
 	return stringMap
 }
 
+func TestDownsamplerNewRuleFilter(t *testing.T) {
+	testDownsampler := newTestDownsampler(t, testDownsamplerOptions{})
+	downsampler := testDownsampler.downsampler
+
+	filter, err := downsampler.NewRuleFilter("__name__:foo* app:test")
+	require.NoError(t, err)
+
+	matched, err := filter.Matches(map[string]string{
+		"__name__": "foobar",
+		"app":      "test",
+	})
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	matched, err = filter.Matches(map[string]string{
+		"__name__": "foobar",
+		"app":      "other",
+	})
+	require.NoError(t, err)
+	assert.False(t, matched)
+
+	_, err = downsampler.NewRuleFilter("app")
+	require.Error(t, err)
+}
+
+func TestDownsamplerMatchRules(t *testing.T) {
+	testDownsampler := newTestDownsampler(t, testDownsamplerOptions{})
+	downsampler := testDownsampler.downsampler
+	rulesStore := testDownsampler.rulesStore
+
+	_, err := rulesStore.CreateNamespace("default", store.NewUpdateOptions())
+	require.NoError(t, err)
+
+	rule := view.MappingRule{
+		ID:              "mappingrule",
+		Name:            "mappingrule",
+		Filter:          "app:test*",
+		AggregationID:   aggregation.MustCompressTypes(testAggregationType),
+		StoragePolicies: testAggregationStoragePolicies,
+	}
+	_, err = rulesStore.CreateMappingRule("default", rule,
+		store.NewUpdateOptions())
+	require.NoError(t, err)
+
+	// Wait for mapping rule to appear
+	var result RulesMatchResult
+	for {
+		result, err = downsampler.MatchRules(map[string]string{
+			"__name__": "foo",
+			"app":      "test123",
+		})
+		require.NoError(t, err)
+		if len(result.Metadatas) != 0 {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	require.Equal(t, 1, len(result.Metadatas))
+	pipelines := result.Metadatas[0].Pipelines
+	require.Equal(t, 1, len(pipelines))
+	assert.Equal(t, rule.AggregationID, pipelines[0].AggregationID)
+	assert.Equal(t, testAggregationStoragePolicies,
+		[]policy.StoragePolicy(pipelines[0].StoragePolicies))
+	assert.Equal(t, 0, len(result.Rollups))
+
+	result, err = downsampler.MatchRules(map[string]string{
+		"__name__": "foo",
+		"app":      "other",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(result.Metadatas))
+	assert.Equal(t, 0, len(result.Rollups))
+}
+
 type testDownsampler struct {
 	opts           DownsamplerOptions
 	downsampler    Downsampler
@@ -25,6 +25,7 @@ import (
 	"sync"
 	"time"
 
+	raggregation "github.com/m3db/m3/src/aggregator/aggregation"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler/writer"
 	"github.com/m3db/m3/src/query/models"
@@ -40,7 +41,9 @@ import (
 )
 
 var (
-	aggregationSuffixTag = []byte("agg")
+	aggregationSuffixTag         = []byte("agg")
+	histogramBucketTagValue      = []byte("bucket")
+	histogramBucketUpperBoundTag = []byte("le")
 )
 
 type downsamplerFlushHandler struct {
@@ -119,9 +122,13 @@ func (w *downsamplerFlushHandlerWriter) Write(
 
 		expected := iter.NumTags()
 		chunkSuffix := mp.ChunkedID.Suffix
+		bucketUpperBound, isHistogramBucket := raggregation.ParseHistogramBucketSuffix(chunkSuffix)
 		if len(chunkSuffix) != 0 {
 			expected++
 		}
+		if isHistogramBucket {
+			expected++
+		}
 
 		tags := models.NewTags(expected, w.tagOptions)
 		for iter.Next() {
@@ -129,7 +136,12 @@ func (w *downsamplerFlushHandlerWriter) Write(
 			tags = tags.AddTag(models.Tag{Name: name, Value: value}.Clone())
 		}
 
-		if len(chunkSuffix) != 0 {
+		if isHistogramBucket {
+			// NB: Histogram bucket series are tagged the same way as Prometheus
+			// histogram buckets so they can be queried with histogram functions.
+			tags = tags.AddTag(models.Tag{Name: aggregationSuffixTag, Value: histogramBucketTagValue}.Clone())
+			tags = tags.AddTag(models.Tag{Name: histogramBucketUpperBoundTag, Value: []byte(bucketUpperBound)})
+		} else if len(chunkSuffix) != 0 {
 			tags = tags.AddTag(models.Tag{Name: aggregationSuffixTag, Value: chunkSuffix}.Clone())
 		}
 
@@ -24,6 +24,7 @@ import (
 	"bytes"
 	"testing"
 
+	raggregation "github.com/m3db/m3/src/aggregator/aggregation"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage/mock"
 	"github.com/m3db/m3/src/x/serialize"
@@ -104,3 +105,66 @@ func TestDownsamplerFlushHandlerCopiesTags(t *testing.T) {
 	assert.False(t, xtest.ByteSlicesBackedBySameData(tagName, tag.Name))
 	assert.False(t, xtest.ByteSlicesBackedBySameData(tagValue, tag.Value))
 }
+
+func TestDownsamplerFlushHandlerHistogramBucketTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock.NewMockStorage()
+	pool := serialize.NewMockMetricTagsIteratorPool(ctrl)
+
+	workers := xsync.NewWorkerPool(1)
+	workers.Init()
+
+	handler := newDownsamplerFlushHandler(store, pool,
+		workers, models.NewTagOptions(), instrument.NewOptions())
+	writer, err := handler.NewWriter(tally.NoopScope)
+	require.NoError(t, err)
+
+	var (
+		expectedID = []byte("foo")
+		tagName    = []byte("name")
+		tagValue   = []byte("value")
+	)
+	iter := serialize.NewMockMetricTagsIterator(ctrl)
+	gomock.InOrder(
+		iter.EXPECT().Reset(expectedID),
+		iter.EXPECT().NumTags().Return(1),
+		iter.EXPECT().Next().Return(true),
+		iter.EXPECT().Current().Return(tagName, tagValue),
+		iter.EXPECT().Next().Return(false),
+		iter.EXPECT().Err().Return(nil),
+		iter.EXPECT().Close(),
+	)
+
+	pool.EXPECT().Get().Return(iter)
+
+	// Write the series of a histogram bucket.
+	err = writer.Write(aggregated.ChunkedMetricWithStoragePolicy{
+		ChunkedMetric: aggregated.ChunkedMetric{
+			ChunkedID: id.ChunkedID{
+				Data:   expectedID,
+				Suffix: raggregation.HistogramBucketSuffix(0.5),
+			},
+			TimeNanos: 123,
+			Value:     42,
+		},
+		StoragePolicy: policy.MustParseStoragePolicy("1s:1d"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, writer.Flush())
+
+	writes := store.Writes()
+	require.Equal(t, 1, len(writes))
+
+	expected := map[string]string{
+		"name": "value",
+		"agg":  "bucket",
+		"le":   "0.5",
+	}
+	tags := writes[0].Tags.Tags
+	require.Equal(t, len(expected), len(tags))
+	for _, tag := range tags {
+		assert.Equal(t, expected[string(tag.Name)], string(tag.Value))
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"runtime"
 	"time"
 
+	raggregation "github.com/m3db/m3/src/aggregator/aggregation"
 	"github.com/m3db/m3/src/aggregator/aggregator"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler"
 	"github.com/m3db/m3/src/aggregator/client"
@@ -89,6 +90,7 @@ type DownsamplerOptions struct {
 	TagDecoderPoolOptions   pool.ObjectPoolOptions
 	OpenTimeout             time.Duration
 	TagOptions              models.TagOptions
+	TimerHistogramBuckets   raggregation.HistogramBuckets
 }
 
 // MappingRule is a mapping rule to apply to metrics.
@@ -144,6 +146,11 @@ func (o DownsamplerOptions) validate() error {
 	if o.TagDecoderPoolOptions == nil {
 		return errNoTagDecoderPoolOptions
 	}
+	if o.TimerHistogramBuckets != nil {
+		if err := o.TimerHistogramBuckets.Validate(); err != nil {
+			return fmt.Errorf("invalid timer histogram buckets: %v", err)
+		}
+	}
 	return nil
 }
 
@@ -152,6 +159,7 @@ type agg struct {
 	defaultStagedMetadatas []metadata.StagedMetadatas
 	clockOpts              clock.Options
 	matcher                matcher.Matcher
+	ruleSetOpts            rules.Options
 	pools                  aggPools
 }
 
@@ -247,7 +255,8 @@ func (o DownsamplerOptions) newAggregator() (agg, error) {
 		SetFlushTimesManager(flushTimesManager).
 		SetElectionManager(electionManager).
 		SetFlushManager(flushManager).
-		SetFlushHandler(flushHandler)
+		SetFlushHandler(flushHandler).
+		SetTimerHistogramBuckets(o.TimerHistogramBuckets)
 
 	aggregatorInstance := aggregator.NewAggregator(aggregatorOpts)
 	if err := aggregatorInstance.Open(); err != nil {
@@ -271,6 +280,7 @@ func (o DownsamplerOptions) newAggregator() (agg, error) {
 		aggregator:             aggregatorInstance,
 		defaultStagedMetadatas: defaultStagedMetadatas,
 		matcher:                matcher,
+		ruleSetOpts:            ruleSetOpts,
 		pools:                  pools,
 	}, nil
 }
@@ -0,0 +1,61 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package downsample
+
+import (
+	"github.com/m3db/m3/src/x/serialize"
+	"github.com/m3db/m3metrics/filters"
+)
+
+// RuleFilter is a rule filter that series can be matched against.
+type RuleFilter interface {
+	// Matches returns true if the series with the given tags matches the filter.
+	Matches(tags map[string]string) (bool, error)
+}
+
+type ruleFilter struct {
+	filter         filters.Filter
+	tagEncoderPool serialize.TagEncoderPool
+}
+
+func (d *downsampler) NewRuleFilter(filter string) (RuleFilter, error) {
+	tagFilters, err := filters.ParseTagFilterValueMap(filter)
+	if err != nil {
+		return nil, err
+	}
+	f, err := filters.NewTagsFilter(tagFilters, filters.Conjunction,
+		d.agg.ruleSetOpts.TagsFilterOptions())
+	if err != nil {
+		return nil, err
+	}
+	return ruleFilter{
+		filter:         f,
+		tagEncoderPool: d.agg.pools.tagEncoderPool,
+	}, nil
+}
+
+func (f ruleFilter) Matches(tags map[string]string) (bool, error) {
+	id, err := encodeSortedTags(f.tagEncoderPool, tags)
+	if err != nil {
+		return false, err
+	}
+	return f.filter.Matches(id), nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package downsample
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/x/serialize"
+	"github.com/m3db/m3metrics/filters"
+	"github.com/m3db/m3metrics/metadata"
+	"github.com/m3db/m3metrics/metric"
+	"github.com/m3db/m3metrics/rules"
+	"github.com/m3db/m3metrics/rules/validator"
+)
+
+// RulesMatchResult is the result of matching a series against the rules
+// the downsampler aggregates with.
+type RulesMatchResult struct {
+	// Metadatas are the staged metadatas the series itself is aggregated with.
+	Metadatas metadata.StagedMetadatas
+	// Rollups are the rollup series the series is aggregated into.
+	Rollups []RollupMatchResult
+}
+
+// RollupMatchResult is a rollup series a matched series is aggregated into.
+type RollupMatchResult struct {
+	Tags      map[string]string
+	Metadatas metadata.StagedMetadatas
+}
+
+func (d *downsampler) NewRulesValidator() rules.Validator {
+	// The downsampler aggregates every metric type with any storage policy
+	// and aggregation type, so rules are only validated structurally and
+	// never against per metric type allow lists.
+	tagsFilterOpts := d.agg.ruleSetOpts.TagsFilterOptions()
+	metricTypesFn := func(tagFilters filters.TagFilterValueMap) ([]metric.Type, error) {
+		if _, err := filters.NewTagsFilter(tagFilters, filters.Conjunction,
+			tagsFilterOpts); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	return validator.NewValidator(validator.NewOptions().
+		SetMetricTypesFn(metricTypesFn))
+}
+
+func (d *downsampler) MatchRules(tags map[string]string) (RulesMatchResult, error) {
+	encoded, err := encodeSortedTags(d.agg.pools.tagEncoderPool, tags)
+	if err != nil {
+		return RulesMatchResult{}, err
+	}
+
+	id := d.agg.pools.metricTagsIteratorPool.Get()
+	id.Reset(encoded)
+	nowNanos := time.Now().UnixNano()
+	matchResult := d.agg.matcher.ForwardMatch(id, nowNanos, nowNanos+1)
+	id.Close()
+
+	var result RulesMatchResult
+	if stagedMetadatas := matchResult.ForExistingIDAt(nowNanos); !stagedMetadatas.IsDefault() {
+		result.Metadatas = stagedMetadatas
+	}
+
+	numRollups := matchResult.NumNewRollupIDs()
+	for i := 0; i < numRollups; i++ {
+		rollup := matchResult.ForNewRollupIDsAt(i, nowNanos)
+		rollupTags, err := d.decodeTags(rollup.ID)
+		if err != nil {
+			return RulesMatchResult{}, err
+		}
+		result.Rollups = append(result.Rollups, RollupMatchResult{
+			Tags:      rollupTags,
+			Metadatas: rollup.Metadatas,
+		})
+	}
+	return result, nil
+}
+
+func (d *downsampler) decodeTags(encoded []byte) (map[string]string, error) {
+	iter := d.agg.pools.metricTagsIteratorPool.Get()
+	iter.Reset(encoded)
+	defer iter.Close()
+
+	tags := make(map[string]string, iter.NumTags())
+	for iter.Next() {
+		name, value := iter.Current()
+		tags[string(name)] = string(value)
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// encodeSortedTags encodes the sorted tags the same way the metrics appender
+// does, so the result is the same ID the series is matched against rules with.
+func encodeSortedTags(
+	tagEncoderPool serialize.TagEncoderPool,
+	tags map[string]string,
+) ([]byte, error) {
+	seriesTags := newTags()
+	for name, value := range tags {
+		seriesTags.append([]byte(name), []byte(value))
+	}
+	sort.Sort(seriesTags)
+
+	tagEncoder := tagEncoderPool.Get()
+	defer tagEncoder.Finalize()
+	if err := tagEncoder.Encode(seriesTags); err != nil {
+		return nil, err
+	}
+	data, ok := tagEncoder.Data()
+	if !ok {
+		return nil, fmt.Errorf("unable to encode tags: names=%v, values=%v",
+			seriesTags.names, seriesTags.values)
+	}
+	return append([]byte(nil), data.Bytes()...), nil
+}
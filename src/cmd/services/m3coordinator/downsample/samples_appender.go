@@ -52,6 +52,15 @@ func (a samplesAppender) AppendGaugeSample(value float64) error {
 	return a.agg.AddUntimed(sample, a.stagedMetadatas)
 }
 
+func (a samplesAppender) AppendTimerSample(value float64) error {
+	sample := unaggregated.MetricUnion{
+		Type:          metric.TimerType,
+		ID:            a.unownedID,
+		BatchTimerVal: []float64{value},
+	}
+	return a.agg.AddUntimed(sample, a.stagedMetadatas)
+}
+
 // Ensure multiSamplesAppender implements SamplesAppender
 var _ SamplesAppender = (*multiSamplesAppender)(nil)
 
@@ -89,3 +98,11 @@ func (a *multiSamplesAppender) AppendGaugeSample(value float64) error {
 	}
 	return multiErr.FinalError()
 }
+
+func (a *multiSamplesAppender) AppendTimerSample(value float64) error {
+	var multiErr xerrors.MultiError
+	for _, appender := range a.appenders {
+		multiErr = multiErr.Add(appender.AppendTimerSample(value))
+	}
+	return multiErr.FinalError()
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package carbon
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3x/instrument"
+	xserver "github.com/m3db/m3x/server"
+	xsync "github.com/m3db/m3x/sync"
+)
+
+const defaultMaxConcurrency = 1024
+
+// Configuration configures the carbon ingestion server.
+type Configuration struct {
+	// ListenAddress is the TCP address to accept carbon lines on.
+	ListenAddress string `yaml:"listenAddress" validate:"nonzero"`
+
+	// MaxConcurrency is the maximum number of concurrent writes.
+	MaxConcurrency int `yaml:"maxConcurrency" validate:"min=0"`
+
+	// WriteTimeout is the timeout for writing a single datapoint.
+	WriteTimeout time.Duration `yaml:"writeTimeout" validate:"min=0"`
+}
+
+// NewServer creates a new carbon ingestion server.
+func (c Configuration) NewServer(
+	appender storage.Appender,
+	tagOptions models.TagOptions,
+	instrumentOptions instrument.Options,
+) (xserver.Server, error) {
+	maxConcurrency := c.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = defaultMaxConcurrency
+	}
+
+	scope := instrumentOptions.MetricsScope().Tagged(
+		map[string]string{"component": "carbon-ingester"},
+	)
+	iOpts := instrumentOptions.SetMetricsScope(scope)
+	workerPool, err := xsync.NewPooledWorkerPool(
+		maxConcurrency,
+		xsync.NewPooledWorkerPoolOptions().SetInstrumentOptions(iOpts),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	workerPool.Init()
+	handler := NewIngester(appender, Options{
+		InstrumentOptions: iOpts,
+		WorkerPool:        workerPool,
+		TagOptions:        tagOptions,
+		WriteTimeout:      c.WriteTimeout,
+	})
+
+	serverOpts := xserver.NewOptions().SetInstrumentOptions(iOpts)
+	return xserver.NewServer(c.ListenAddress, handler, serverOpts), nil
+}
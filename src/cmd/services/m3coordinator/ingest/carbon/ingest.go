@@ -0,0 +1,172 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package carbon implements ingestion of the Graphite carbon plaintext
+// protocol, storing each dotted metric path as indexed path tags.
+package carbon
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/query/graphite"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3x/instrument"
+	"github.com/m3db/m3x/log"
+	xserver "github.com/m3db/m3x/server"
+	xsync "github.com/m3db/m3x/sync"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/uber-go/tally"
+)
+
+const (
+	unknownRemoteHostAddress = "<unknown>"
+	defaultWriteTimeout      = 10 * time.Second
+)
+
+// Options configures the carbon ingester.
+type Options struct {
+	InstrumentOptions instrument.Options
+	WorkerPool        xsync.PooledWorkerPool
+	TagOptions        models.TagOptions
+	WriteTimeout      time.Duration
+}
+
+type ingesterMetrics struct {
+	success   tally.Counter
+	malformed tally.Counter
+	err       tally.Counter
+}
+
+func newIngesterMetrics(scope tally.Scope) ingesterMetrics {
+	return ingesterMetrics{
+		success:   scope.Counter("success"),
+		malformed: scope.Counter("malformed"),
+		err:       scope.Counter("error"),
+	}
+}
+
+type ingester struct {
+	appender     storage.Appender
+	workerPool   xsync.PooledWorkerPool
+	tagOptions   models.TagOptions
+	writeTimeout time.Duration
+	logger       log.Logger
+	metrics      ingesterMetrics
+}
+
+// NewIngester returns a TCP handler that ingests carbon plaintext lines
+// into the appender.
+func NewIngester(appender storage.Appender, opts Options) xserver.Handler {
+	writeTimeout := opts.WriteTimeout
+	if writeTimeout <= 0 {
+		writeTimeout = defaultWriteTimeout
+	}
+
+	return &ingester{
+		appender:     appender,
+		workerPool:   opts.WorkerPool,
+		tagOptions:   opts.TagOptions,
+		writeTimeout: writeTimeout,
+		logger:       opts.InstrumentOptions.Logger(),
+		metrics:      newIngesterMetrics(opts.InstrumentOptions.MetricsScope()),
+	}
+}
+
+func (i *ingester) Handle(conn net.Conn) {
+	remoteAddress := unknownRemoteHostAddress
+	if remoteAddr := conn.RemoteAddr(); remoteAddr != nil {
+		remoteAddress = remoteAddr.String()
+	}
+
+	var (
+		wg      sync.WaitGroup
+		scanner = bufio.NewScanner(conn)
+	)
+
+	for scanner.Scan() {
+		name, timestamp, value, err := ParseLine(scanner.Bytes())
+		if err != nil {
+			i.metrics.malformed.Inc(1)
+			continue
+		}
+
+		// NB: the generated tags copy the name since the scanner reuses its buffer.
+		tags, err := graphite.GenerateTags(name, i.tagOptions)
+		if err != nil {
+			i.metrics.malformed.Inc(1)
+			continue
+		}
+
+		wg.Add(1)
+		i.workerPool.Go(func() {
+			i.write(tags, timestamp, value)
+			wg.Done()
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		i.logger.WithFields(
+			log.NewField("remoteAddress", remoteAddress),
+			log.NewErrField(err),
+		).Error("error reading carbon lines")
+	}
+
+	wg.Wait()
+}
+
+func (i *ingester) write(tags models.Tags, timestamp time.Time, value float64) {
+	// NB: carbon writes are trusted and not restricted to a tenant, the
+	// carbon server is not run if tenants are configured.
+	ctx, cancel := context.WithTimeout(context.Background(), i.writeTimeout)
+	defer cancel()
+
+	err := i.appender.Write(ctx, &storage.WriteQuery{
+		Tags: tags,
+		Datapoints: ts.Datapoints{
+			{
+				Timestamp: timestamp,
+				Value:     value,
+			},
+		},
+		Unit: xtime.Second,
+		Attributes: storage.Attributes{
+			MetricsType: storage.UnaggregatedMetricsType,
+		},
+	})
+	if err != nil {
+		i.metrics.err.Inc(1)
+		i.logger.WithFields(
+			log.NewField("id", tags.ID()),
+			log.NewErrField(err),
+		).Error("error writing carbon metric")
+		return
+	}
+
+	i.metrics.success.Inc(1)
+}
+
+func (i *ingester) Close() {}
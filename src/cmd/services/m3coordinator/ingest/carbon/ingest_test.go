@@ -0,0 +1,88 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package carbon
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/graphite"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3x/instrument"
+	xsync "github.com/m3db/m3x/sync"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngesterHandle(t *testing.T) {
+	workerPool, err := xsync.NewPooledWorkerPool(4, xsync.NewPooledWorkerPoolOptions())
+	require.NoError(t, err)
+	workerPool.Init()
+
+	store := mock.NewMockStorage()
+	handler := NewIngester(store, Options{
+		InstrumentOptions: instrument.NewOptions(),
+		WorkerPool:        workerPool,
+		TagOptions:        models.NewTagOptions(),
+	})
+
+	server, client := net.Pipe()
+	done := make(chan struct{})
+	go func() {
+		handler.Handle(server)
+		close(done)
+	}()
+
+	_, err = client.Write([]byte("servers.host-1.cpu 1 1539172800\n" +
+		"malformed line\n" +
+		"servers.host-2.cpu 2 1539172810\n"))
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+	<-done
+
+	writes := store.Writes()
+	require.Len(t, writes, 2)
+
+	byName := make(map[string]float64, len(writes))
+	for _, write := range writes {
+		assert.Equal(t, xtime.Second, write.Unit)
+		require.Len(t, write.Datapoints, 1)
+		host, ok := write.Tags.Get(graphite.TagName(1))
+		require.True(t, ok)
+		assert.Len(t, write.Tags.Tags, 3)
+		byName[graphite.NameFromTags(write.Tags)] = write.Datapoints[0].Value
+
+		expectedTime := time.Unix(1539172800, 0)
+		if string(host) == "host-2" {
+			expectedTime = expectedTime.Add(10 * time.Second)
+		}
+		assert.Equal(t, expectedTime, write.Datapoints[0].Timestamp)
+	}
+
+	assert.Equal(t, map[string]float64{
+		"servers.host-1.cpu": 1,
+		"servers.host-2.cpu": 2,
+	}, byName)
+}
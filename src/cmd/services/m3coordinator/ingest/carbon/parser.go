@@ -0,0 +1,76 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package carbon
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+)
+
+var (
+	errInvalidLine      = errors.New("carbon line must contain a name, value and timestamp")
+	errInvalidValue     = errors.New("carbon line contains an invalid value")
+	errInvalidTimestamp = errors.New("carbon line contains an invalid timestamp")
+)
+
+// ParseLine parses a carbon plaintext protocol line of the form
+// "<metric path> <value> <unix timestamp>". The returned name references the
+// line and must be copied if the line buffer is reused.
+func ParseLine(line []byte) ([]byte, time.Time, float64, error) {
+	name, rest := nextField(line)
+	valueField, rest := nextField(rest)
+	timestampField, rest := nextField(rest)
+	if len(name) == 0 || len(valueField) == 0 || len(timestampField) == 0 {
+		return nil, time.Time{}, 0, errInvalidLine
+	}
+
+	if extra, _ := nextField(rest); len(extra) != 0 {
+		return nil, time.Time{}, 0, errInvalidLine
+	}
+
+	value, err := strconv.ParseFloat(string(valueField), 64)
+	if err != nil {
+		return nil, time.Time{}, 0, errInvalidValue
+	}
+
+	// NB: some clients send fractional timestamps.
+	timestamp, err := strconv.ParseFloat(string(timestampField), 64)
+	if err != nil || math.IsNaN(timestamp) || math.IsInf(timestamp, 0) {
+		return nil, time.Time{}, 0, errInvalidTimestamp
+	}
+
+	secs, frac := math.Modf(timestamp)
+	return name, time.Unix(int64(secs), int64(frac*float64(time.Second))), value, nil
+}
+
+// nextField returns the next whitespace separated field and the remainder.
+func nextField(b []byte) ([]byte, []byte) {
+	b = bytes.TrimLeft(b, " \t\r")
+	end := bytes.IndexAny(b, " \t\r")
+	if end == -1 {
+		return b, nil
+	}
+
+	return b[:end], b[end:]
+}
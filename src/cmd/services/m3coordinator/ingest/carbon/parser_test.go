@@ -0,0 +1,58 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLine(t *testing.T) {
+	name, timestamp, value, err := ParseLine([]byte("servers.host-1.cpu 42.5 1539172800"))
+	require.NoError(t, err)
+	assert.Equal(t, "servers.host-1.cpu", string(name))
+	assert.Equal(t, time.Unix(1539172800, 0), timestamp)
+	assert.Equal(t, 42.5, value)
+
+	name, timestamp, value, err = ParseLine([]byte("  foo\t-1e3  1539172800.5\r"))
+	require.NoError(t, err)
+	assert.Equal(t, "foo", string(name))
+	assert.Equal(t, time.Unix(1539172800, int64(500*time.Millisecond)), timestamp)
+	assert.Equal(t, -1000.0, value)
+}
+
+func TestParseLineErrors(t *testing.T) {
+	for _, line := range []string{
+		"",
+		"foo",
+		"foo 1",
+		"foo 1 2 3",
+		"foo bar 1539172800",
+		"foo 1 yesterday",
+		"foo 1 NaN",
+	} {
+		_, _, _, err := ParseLine([]byte(line))
+		assert.Error(t, err, line)
+	}
+}
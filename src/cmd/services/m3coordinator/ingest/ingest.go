@@ -99,7 +99,8 @@ func NewIngester(
 	}
 }
 
-// Ingest ingests a metric asynchronously with callback.
+// Ingest ingests a metric asynchronously with callback. Metrics ingested
+// are trusted and not restricted to a tenant.
 func (i *Ingester) Ingest(
 	ctx context.Context,
 	id []byte,
@@ -0,0 +1,77 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
+	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
+	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
+)
+
+// IndexCompactionConfiguration is the configuration for background
+// compaction of index segments, zero values fall back to the defaults.
+type IndexCompactionConfiguration struct {
+	// MaxCompactionSize is the maximum number of documents merged by a single
+	// compaction, it bounds the memory used to merge segments.
+	MaxCompactionSize int64 `yaml:"maxCompactionSize" validate:"min=0"`
+
+	// Concurrency is the maximum number of compactions performed concurrently
+	// across the index blocks.
+	Concurrency int `yaml:"concurrency" validate:"min=0"`
+
+	// MutableSegmentSizeThreshold is the number of documents the active
+	// segment of a block holds before it is rotated out for compaction.
+	MutableSegmentSizeThreshold int64 `yaml:"mutableSegmentSizeThreshold" validate:"min=0"`
+
+	// MutableCompactionAgeThreshold is the age of the active segment of a block
+	// after which it is rotated out for compaction.
+	MutableCompactionAgeThreshold time.Duration `yaml:"mutableCompactionAgeThreshold" validate:"min=0"`
+}
+
+// PlannerOptions returns the compaction planner options.
+func (c IndexCompactionConfiguration) PlannerOptions() compaction.PlannerOptions {
+	opts := compaction.DefaultOptions
+	if c.MutableSegmentSizeThreshold > 0 {
+		opts.MutableSegmentSizeThreshold = c.MutableSegmentSizeThreshold
+	}
+	if c.MutableCompactionAgeThreshold > 0 {
+		opts.MutableCompactionAgeThreshold = c.MutableCompactionAgeThreshold
+	}
+	return opts
+}
+
+// NewCompactor returns a new compactor.
+func (c IndexCompactionConfiguration) NewCompactor(
+	memOpts mem.Options,
+	fstOpts fst.Options,
+) (*compaction.Compactor, error) {
+	maxSize := c.MaxCompactionSize
+	if maxSize <= 0 {
+		maxSize = compaction.DefaultMaxCompactionSize
+	}
+	concurrency := c.Concurrency
+	if concurrency <= 0 {
+		concurrency = compaction.DefaultConcurrency
+	}
+	return compaction.NewCompactor(maxSize, concurrency, memOpts, fstOpts)
+}
@@ -131,6 +131,9 @@ type DBConfiguration struct {
 
 	// Write new series asynchronously for fast ingestion of new ID bursts.
 	WriteNewSeriesAsync bool `yaml:"writeNewSeriesAsync"`
+
+	// Write quotas keyed by the value of a tag, disabled if not set.
+	WriteQuotas *WriteQuotasConfiguration `yaml:"writeQuotas"`
 }
 
 // IndexConfiguration contains index-specific configuration.
@@ -140,6 +143,10 @@ type IndexConfiguration struct {
 	// important to prevent index queries from overloading the database entirely
 	// as they are very CPU-intensive (regex and FST matching.)
 	MaxQueryIDsConcurrency int `yaml:"maxQueryIDsConcurrency" validate:"min=0"`
+
+	// Compaction configures background compaction of the segments of index
+	// blocks still taking writes, background compactions are disabled if unset.
+	Compaction *IndexCompactionConfiguration `yaml:"compaction"`
 }
 
 // TickConfiguration is the tick configuration for background processing of
@@ -220,6 +227,10 @@ type RepairPolicy struct {
 
 	// The repair check interval.
 	CheckInterval time.Duration `yaml:"checkInterval" validate:"nonzero"`
+
+	// The throughput limit in Mbps when persisting repaired blocks,
+	// the default limit is used if unset.
+	ThroughputLimitMbps float64 `yaml:"throughputLimitMbps" validate:"min=0.0"`
 }
 
 // HashingConfiguration is the configuration for hashing.
@@ -301,6 +301,7 @@ db:
 	expected := `db:
   index:
     maxQueryIDsConcurrency: 0
+    compaction: null
   logging:
     file: /var/log/m3dbnode.log
     level: info
@@ -383,6 +384,9 @@ db:
     newFileMode: null
     newDirectoryMode: null
     mmap: null
+    compression: ""
+    compressionPageSize: 0
+    tiering: null
   commitlog:
     flushMaxBytes: 524288
     flushEvery: 1s
@@ -397,6 +401,7 @@ db:
     jitter: 1h0m0s
     throttle: 2m0s
     checkInterval: 1m0s
+    throughputLimitMbps: 0
   pooling:
     blockAllocSize: 16
     type: simple
@@ -578,6 +583,7 @@ db:
   hashing:
     seed: 42
   writeNewSeriesAsync: true
+  writeQuotas: null
 coordinator: null
 `
 
@@ -23,6 +23,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist"
 )
 
 const (
@@ -75,6 +78,34 @@ type FilesystemConfiguration struct {
 
 	// Mmap is the mmap options which features are primarily platform dependent
 	Mmap *MmapConfiguration `yaml:"mmap"`
+
+	// Compression is the compression to use for the pages of the index and
+	// data files of written filesets, one of none or snappy.
+	Compression string `yaml:"compression"`
+
+	// CompressionPageSize is the minimum uncompressed size of the pages of
+	// compressed filesets.
+	CompressionPageSize int `yaml:"compressionPageSize" validate:"min=0"`
+
+	// Tiering is the configuration for moving the data files of older
+	// blocks to a secondary backend.
+	Tiering *TieringConfiguration `yaml:"tiering"`
+}
+
+// TieringConfiguration is the configuration for moving the data files of
+// flushed blocks to a secondary backend once they reach a certain age.
+type TieringConfiguration struct {
+	// Path is the directory that tiered data files are stored beneath,
+	// typically on a slower mount than the file path prefix.
+	Path string `yaml:"path" validate:"nonzero"`
+
+	// MinBlockAge is the age past the end of a block after which its data
+	// files are moved to the tiered directory.
+	MinBlockAge time.Duration `yaml:"minBlockAge" validate:"nonzero"`
+
+	// CacheTTL is how long data files fetched from the tiered directory are
+	// kept open after their last use.
+	CacheTTL time.Duration `yaml:"cacheTTL"`
 }
 
 // MmapConfiguration is the mmap configuration.
@@ -141,6 +172,11 @@ func (p FilesystemConfiguration) ParseNewDirectoryMode() (os.FileMode, error) {
 	return os.ModeDir | os.FileMode(v), nil
 }
 
+// ParseCompression parses the specified fileset compression.
+func (p FilesystemConfiguration) ParseCompression() (persist.FileSetCompression, error) {
+	return persist.ParseFileSetCompression(p.Compression)
+}
+
 // MmapConfiguration returns the effective mmap configuration.
 func (p FilesystemConfiguration) MmapConfiguration() MmapConfiguration {
 	if p.Mmap == nil {
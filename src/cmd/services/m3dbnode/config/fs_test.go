@@ -24,6 +24,8 @@ import (
 	"os"
 	"testing"
 
+	"github.com/m3db/m3/src/dbnode/persist"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -47,3 +49,20 @@ func TestFilesystemConfigurationParseNewDirectoryMode(t *testing.T) {
 
 	assert.Equal(t, os.FileMode(0775)|os.ModeDir, v)
 }
+
+func TestFilesystemConfigurationParseCompression(t *testing.T) {
+	cfg := FilesystemConfiguration{}
+
+	v, err := cfg.ParseCompression()
+	require.NoError(t, err)
+	assert.Equal(t, persist.FileSetNoCompression, v)
+
+	cfg.Compression = "snappy"
+	v, err = cfg.ParseCompression()
+	require.NoError(t, err)
+	assert.Equal(t, persist.FileSetSnappyCompression, v)
+
+	cfg.Compression = "unknown"
+	_, err = cfg.ParseCompression()
+	require.Error(t, err)
+}
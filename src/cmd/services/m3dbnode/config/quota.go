@@ -0,0 +1,63 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import "github.com/m3db/m3/src/dbnode/storage/quota"
+
+// WriteQuotasConfiguration is the configuration for write quotas keyed by
+// the value of a tag of the series written.
+type WriteQuotasConfiguration struct {
+	// Tag is the name of the tag whose value keys the quotas.
+	Tag string `yaml:"tag" validate:"nonzero"`
+
+	// Default is the quota applied to keys without a specific quota.
+	Default WriteQuotaConfiguration `yaml:"default"`
+
+	// Keys are the quotas of specific tag values.
+	Keys map[string]WriteQuotaConfiguration `yaml:"keys"`
+}
+
+// WriteQuotaConfiguration is the configuration of a single write quota, a
+// zero value means unlimited.
+type WriteQuotaConfiguration struct {
+	MaxActiveSeries        int64 `yaml:"maxActiveSeries"`
+	MaxDatapointsPerSecond int64 `yaml:"maxDatapointsPerSecond"`
+}
+
+// Limits returns the quota limits.
+func (c WriteQuotaConfiguration) Limits() quota.Limits {
+	return quota.Limits{
+		MaxActiveSeries:        c.MaxActiveSeries,
+		MaxDatapointsPerSecond: c.MaxDatapointsPerSecond,
+	}
+}
+
+// NewOptions returns the write quota options.
+func (c WriteQuotasConfiguration) NewOptions() quota.Options {
+	keyLimits := make(map[string]quota.Limits, len(c.Keys))
+	for key, cfg := range c.Keys {
+		keyLimits[key] = cfg.Limits()
+	}
+	return quota.NewOptions().
+		SetTagName([]byte(c.Tag)).
+		SetDefaultLimits(c.Default.Limits()).
+		SetKeyLimits(keyLimits)
+}
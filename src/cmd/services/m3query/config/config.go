@@ -21,12 +21,17 @@
 package config
 
 import (
+	"errors"
 	"time"
 
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest/carbon"
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/server/m3msg"
+	"github.com/m3db/m3/src/query/api/v1/handler/influxdb"
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/tenant"
 	etcdclient "github.com/m3db/m3cluster/client/etcd"
 	xconfig "github.com/m3db/m3x/config"
 	"github.com/m3db/m3x/config/listenaddress"
@@ -41,6 +46,8 @@ const (
 	GRPCStorageType BackendStorageType = "grpc"
 	// M3DBStorageType is for m3db backend.
 	M3DBStorageType BackendStorageType = "m3db"
+
+	defaultLookbackDuration = 5 * time.Minute
 )
 
 // Configuration is the configuration for the query service.
@@ -78,8 +85,114 @@ type Configuration struct {
 	// WriteWorkerPool is the worker pool policy for write requests.
 	WriteWorkerPool xconfig.WorkerPoolPolicy `yaml:"writeWorkerPoolPolicy"`
 
-	// Ingest is the ingest server.
+	// Ingest is the ingest server, its writes are trusted and not restricted
+	// to a tenant so it cannot be set if tenants are set.
 	Ingest *IngestConfiguration `yaml:"ingest"`
+
+	// Carbon is the carbon plaintext protocol ingestion server, its writes
+	// are trusted and not restricted to a tenant so it cannot be set if
+	// tenants are set.
+	Carbon *carbon.Configuration `yaml:"carbon"`
+
+	// InfluxDB is the configuration for the InfluxDB line protocol
+	// write endpoint.
+	InfluxDB InfluxDBConfiguration `yaml:"influxdb"`
+
+	// LookbackDuration determines the lookback duration for queries
+	LookbackDuration *time.Duration `yaml:"lookbackDuration"`
+
+	// Limits specifies limits on the resources used by each query.
+	Limits LimitsConfiguration `yaml:"limits"`
+
+	// Tenants restricts the read and write endpoints to authenticated tenants
+	// if set, each restricted to its own namespaces and series, and the admin
+	// endpoints to the admin tokens. Cannot be set with the m3msg or carbon
+	// ingest servers, which are not authenticated.
+	Tenants *tenant.Configuration `yaml:"tenants"`
+}
+
+// LimitsConfiguration is the configuration for limits on the resources used
+// by each query; a zero value for any limit means it is unlimited.
+type LimitsConfiguration struct {
+	// MaxFetchedSeries is the maximum number of series fetched by a query.
+	MaxFetchedSeries int64 `yaml:"maxFetchedSeries" validate:"min=0"`
+
+	// MaxFetchedDatapoints is the maximum number of datapoints
+	// decompressed by a query.
+	MaxFetchedDatapoints int64 `yaml:"maxFetchedDatapoints" validate:"min=0"`
+
+	// MaxFetchedBlocks is the maximum number of blocks fetched by a query.
+	MaxFetchedBlocks int64 `yaml:"maxFetchedBlocks" validate:"min=0"`
+
+	// MaxQueryDuration is the maximum wall time a query may run for.
+	MaxQueryDuration time.Duration `yaml:"maxQueryDuration" validate:"min=0"`
+}
+
+// AsLimits returns the query cost limits for the configuration.
+func (c LimitsConfiguration) AsLimits() cost.Limits {
+	return cost.Limits{
+		MaxFetchedSeries:     c.MaxFetchedSeries,
+		MaxFetchedDatapoints: c.MaxFetchedDatapoints,
+		MaxFetchedBlocks:     c.MaxFetchedBlocks,
+		MaxDuration:          c.MaxQueryDuration,
+	}
+}
+
+// InfluxDBConfiguration is the configuration for the InfluxDB line
+// protocol write endpoint.
+type InfluxDBConfiguration struct {
+	// NamingScheme determines how each field of a measurement is mapped to
+	// a series, either "measurement_field" (the default) or "field_tag".
+	NamingScheme influxdb.NamingScheme `yaml:"namingScheme"`
+
+	// FieldTagName is the tag name for the field name when using the
+	// "field_tag" naming scheme, defaults to "field".
+	FieldTagName string `yaml:"fieldTagName"`
+
+	// MaxBodySize is the maximum size in bytes of a request body after
+	// decompression, defaults to 32MiB.
+	MaxBodySize int64 `yaml:"maxBodySize"`
+}
+
+// NamingOptions returns the naming options for the configuration.
+func (c InfluxDBConfiguration) NamingOptions() influxdb.NamingOptions {
+	opts := influxdb.NamingOptions{
+		Scheme:       c.NamingScheme,
+		FieldTagName: c.FieldTagName,
+	}
+
+	if opts.Scheme == "" {
+		opts.Scheme = influxdb.DefaultNamingScheme
+	}
+
+	if opts.FieldTagName == "" {
+		opts.FieldTagName = influxdb.DefaultFieldTagName
+	}
+
+	return opts
+}
+
+// MaxBodySizeOrDefault returns the maximum request body size or the default.
+func (c InfluxDBConfiguration) MaxBodySizeOrDefault() int64 {
+	if c.MaxBodySize <= 0 {
+		return influxdb.DefaultMaxBodySize
+	}
+
+	return c.MaxBodySize
+}
+
+// LookbackDurationOrDefault validates the LookbackDuration
+func (c Configuration) LookbackDurationOrDefault() (time.Duration, error) {
+	if c.LookbackDuration == nil {
+		return defaultLookbackDuration, nil
+	}
+
+	v := *c.LookbackDuration
+	if v <= 0 {
+		return 0, errors.New("lookbackDuration must be > 0")
+	}
+
+	return v, nil
 }
 
 // IngestConfiguration is the configuration for ingestion server.
@@ -22,6 +22,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -45,3 +46,24 @@ func TestTagOptionsFromConfig(t *testing.T) {
 	require.NotNil(t, opts)
 	assert.Equal(t, []byte(name), opts.MetricName())
 }
+
+func TestLookbackDurationOrDefault(t *testing.T) {
+	cfg := Configuration{}
+	v, err := cfg.LookbackDurationOrDefault()
+	require.NoError(t, err)
+	assert.Equal(t, defaultLookbackDuration, v)
+
+	lookback := time.Minute
+	cfg.LookbackDuration = &lookback
+	v, err = cfg.LookbackDurationOrDefault()
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, v)
+
+	lookback = -time.Minute
+	_, err = cfg.LookbackDurationOrDefault()
+	require.Error(t, err)
+
+	lookback = 0
+	_, err = cfg.LookbackDurationOrDefault()
+	require.Error(t, err)
+}
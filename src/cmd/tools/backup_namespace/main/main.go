@@ -0,0 +1,234 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/network/server/httpjson/node"
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
+	"github.com/m3db/m3/src/query/api/v1/handler/namespace"
+	"github.com/m3db/m3/src/query/api/v1/handler/placement"
+	"github.com/m3db/m3/src/query/generated/proto/admin"
+	"github.com/m3db/m3cluster/generated/proto/placementpb"
+	xlog "github.com/m3db/m3x/log"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+)
+
+var (
+	optCoordinator  = flag.String("coordinator", "http://localhost:7201", "Coordinator API address")
+	optNamespace    = flag.String("namespace", "metrics", "Namespace to backup")
+	optNodeHTTPPort = flag.Int("node-http-port", 9002, "Port of the node HTTP API of the dbnodes")
+	optOutput       = flag.String("output", "", "Path of the backup archive to write")
+)
+
+func main() {
+	flag.Parse()
+	if *optCoordinator == "" || *optNamespace == "" || *optOutput == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	log := xlog.NewLogger(os.Stderr)
+
+	var placementResp admin.PlacementGetResponse
+	if err := getJSON(*optCoordinator+placement.M3DBGetURL, &placementResp); err != nil {
+		log.Fatalf("unable to get placement: %v", err)
+	}
+	var nsResp admin.NamespaceGetResponse
+	if err := getJSON(*optCoordinator+namespace.GetURL, &nsResp); err != nil {
+		log.Fatalf("unable to get namespaces: %v", err)
+	}
+
+	nsOpts, ok := nsResp.GetRegistry().GetNamespaces()[*optNamespace]
+	if !ok {
+		log.Fatalf("namespace not found: %s", *optNamespace)
+	}
+	nsOptsBytes, err := proto.Marshal(nsOpts)
+	if err != nil {
+		log.Fatalf("unable to marshal namespace options: %v", err)
+	}
+	placementBytes, err := proto.Marshal(placementResp.GetPlacement())
+	if err != nil {
+		log.Fatalf("unable to marshal placement: %v", err)
+	}
+
+	// Backup each shard from a single replica that has it available.
+	instanceShards, err := selectInstanceShards(placementResp.GetPlacement())
+	if err != nil {
+		log.Fatalf("unable to select replicas: %v", err)
+	}
+
+	tmpDir, err := ioutil.TempDir("", "m3db-backup")
+	if err != nil {
+		log.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	var archives []backup.SourceArchive
+	for _, instance := range sortedInstances(instanceShards) {
+		shards := instanceShards[instance]
+		log.Infof("backing up %d shards from %s", len(shards), instance.Id)
+		fd, err := backupInstance(tmpDir, instance, shards)
+		if err != nil {
+			log.Fatalf("unable to backup %s: %v", instance.Id, err)
+		}
+		defer fd.Close()
+		archives = append(archives, backup.SourceArchive{ID: instance.Id, Reader: fd})
+	}
+
+	out, err := os.Create(*optOutput)
+	if err != nil {
+		log.Fatalf("unable to create output: %v", err)
+	}
+	manifest, err := backup.Merge(out, nsOptsBytes, placementBytes, archives, time.Now)
+	if err != nil {
+		log.Fatalf("unable to write backup archive: %v", err)
+	}
+	if err := out.Close(); err != nil {
+		log.Fatalf("unable to close output: %v", err)
+	}
+
+	log.Infof("successfully backed up namespace %s from %d sources to %s",
+		manifest.Namespace, len(manifest.Sources), *optOutput)
+}
+
+func getJSON(apiURL string, msg proto.Message) error {
+	resp, err := http.Get(apiURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, body)
+	}
+	return jsonpb.Unmarshal(resp.Body, msg)
+}
+
+// selectInstanceShards selects an instance with each shard available.
+func selectInstanceShards(
+	p *placementpb.Placement,
+) (map[*placementpb.Instance][]uint32, error) {
+	var (
+		shards    = make(map[uint32]struct{})
+		available = make(map[uint32][]*placementpb.Instance)
+	)
+	for _, instance := range p.GetInstances() {
+		for _, shard := range instance.GetShards() {
+			shards[shard.Id] = struct{}{}
+			if shard.State == placementpb.ShardState_AVAILABLE {
+				available[shard.Id] = append(available[shard.Id], instance)
+			}
+		}
+	}
+
+	result := make(map[*placementpb.Instance][]uint32)
+	for shard := range shards {
+		instances := available[shard]
+		if len(instances) == 0 {
+			return nil, fmt.Errorf("no replica has shard %d available", shard)
+		}
+		sort.Slice(instances, func(i, j int) bool {
+			return instances[i].Id < instances[j].Id
+		})
+		// Spread shards across replicas so that the nodes share the load.
+		selected := instances[0]
+		for _, instance := range instances[1:] {
+			if len(result[instance]) < len(result[selected]) {
+				selected = instance
+			}
+		}
+		result[selected] = append(result[selected], shard)
+	}
+	for _, shards := range result {
+		sort.Slice(shards, func(i, j int) bool { return shards[i] < shards[j] })
+	}
+	return result, nil
+}
+
+func sortedInstances(
+	instanceShards map[*placementpb.Instance][]uint32,
+) []*placementpb.Instance {
+	instances := make([]*placementpb.Instance, 0, len(instanceShards))
+	for instance := range instanceShards {
+		instances = append(instances, instance)
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].Id < instances[j].Id
+	})
+	return instances
+}
+
+// backupInstance downloads the backup archive of the shards from the instance
+// to a temporary file and returns the file positioned at its start.
+func backupInstance(
+	dir string,
+	instance *placementpb.Instance,
+	shards []uint32,
+) (*os.File, error) {
+	shardStrs := make([]string, 0, len(shards))
+	for _, shard := range shards {
+		shardStrs = append(shardStrs, strconv.FormatUint(uint64(shard), 10))
+	}
+	query := url.Values{}
+	query.Set("namespace", *optNamespace)
+	query.Set("shards", strings.Join(shardStrs, ","))
+	query.Set("source", instance.Id)
+	backupURL := fmt.Sprintf("http://%s:%d%s?%s", instance.Hostname, *optNodeHTTPPort,
+		node.BackupPath, query.Encode())
+
+	resp, err := http.Post(backupURL, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, body)
+	}
+
+	fd, err := ioutil.TempFile(dir, "archive")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(fd, resp.Body); err != nil {
+		fd.Close()
+		return nil, err
+	}
+	if _, err := fd.Seek(0, io.SeekStart); err != nil {
+		fd.Close()
+		return nil, err
+	}
+	return fd, nil
+}
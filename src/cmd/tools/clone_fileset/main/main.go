@@ -24,6 +24,7 @@ import (
 	"flag"
 	"os"
 
+	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs/clone"
 	xlog "github.com/m3db/m3x/log"
 	xtime "github.com/m3db/m3x/time"
@@ -39,6 +40,7 @@ var (
 	optDestShard      = flag.Uint("dest-shard", 0, "Destination Shard ID")
 	optDestBlockstart = flag.Int64("dest-block-start", 0, "Destination Block Start Time [in nsec]")
 	optDestBlockSize  = flag.Duration("dest-block-size", 0, "Destination Block Size")
+	optDestCompress   = flag.String("dest-compression", "none", "Destination Compression [none|snappy]")
 )
 
 func main() {
@@ -67,10 +69,16 @@ func main() {
 		Blockstart: xtime.FromNanoseconds(*optDestBlockstart),
 	}
 
+	compression, err := persist.ParseFileSetCompression(*optDestCompress)
+	if err != nil {
+		log.Fatalf("unable to parse destination compression: %v", err)
+	}
+
 	log.Infof("source: %+v", src)
 	log.Infof("destination: %+v", dest)
 
-	opts := clone.NewOptions()
+	opts := clone.NewOptions().
+		SetCompression(compression)
 	cloner := clone.New(opts)
 	if err := cloner.Clone(src, dest, *optDestBlockSize); err != nil {
 		log.Fatalf("unable to clone: %v", err)
@@ -0,0 +1,100 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"flag"
+	"os"
+	"strconv"
+	"strings"
+
+	nsproto "github.com/m3db/m3/src/dbnode/generated/proto/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
+	"github.com/m3db/m3/src/query/generated/proto/admin"
+	xlog "github.com/m3db/m3x/log"
+
+	"github.com/gogo/protobuf/jsonpb"
+)
+
+var (
+	optArchive         = flag.String("archive", "", "Path of the backup archive to restore")
+	optPathPrefix      = flag.String("path-prefix", "/var/lib/m3db", "Path prefix to restore to")
+	optShards          = flag.String("shards", "", "Comma separated shards to restore [all shards if empty]")
+	optNamespaceOutput = flag.String("namespace-output", "", "Path to write the namespace add request to [stdout if empty]")
+)
+
+func main() {
+	flag.Parse()
+	if *optArchive == "" || *optPathPrefix == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	log := xlog.NewLogger(os.Stderr)
+
+	var shards []uint32
+	if *optShards != "" {
+		for _, str := range strings.Split(*optShards, ",") {
+			shard, err := strconv.ParseUint(strings.TrimSpace(str), 10, 32)
+			if err != nil {
+				log.Fatalf("invalid shard %s: %v", str, err)
+			}
+			shards = append(shards, uint32(shard))
+		}
+	}
+
+	archive, err := os.Open(*optArchive)
+	if err != nil {
+		log.Fatalf("unable to open archive: %v", err)
+	}
+	defer archive.Close()
+
+	opts := fs.NewOptions().SetFilePathPrefix(*optPathPrefix)
+	manifest, err := backup.Restore(archive, opts, shards)
+	if err != nil {
+		log.Fatalf("unable to restore archive: %v", err)
+	}
+	log.Infof("successfully restored namespace %s from %d sources to %s",
+		manifest.Namespace, len(manifest.Sources), *optPathPrefix)
+
+	// Output the request to register the namespace with the coordinator
+	// before the restored nodes are started.
+	var nsOpts nsproto.NamespaceOptions
+	if err := nsOpts.Unmarshal(manifest.NamespaceOptions); err != nil {
+		log.Fatalf("unable to unmarshal namespace options: %v", err)
+	}
+	out := os.Stdout
+	if *optNamespaceOutput != "" {
+		out, err = os.Create(*optNamespaceOutput)
+		if err != nil {
+			log.Fatalf("unable to create namespace output: %v", err)
+		}
+		defer out.Close()
+	}
+	marshaler := jsonpb.Marshaler{Indent: "  "}
+	if err := marshaler.Marshal(out, &admin.NamespaceAddRequest{
+		Name:    manifest.Namespace,
+		Options: &nsOpts,
+	}); err != nil {
+		log.Fatalf("unable to write namespace add request: %v", err)
+	}
+}
@@ -54,6 +54,18 @@ func IsBadRequestError(err error) bool {
 	return false
 }
 
+// IsResourceExhaustedError determines if the error is a resource exhausted
+// error, returned when a write is rejected by a write quota
+func IsResourceExhaustedError(err error) bool {
+	for err != nil {
+		if e, ok := err.(*rpc.Error); ok && tterrors.IsResourceExhaustedError(e) {
+			return true
+		}
+		err = xerrors.InnerError(err)
+	}
+	return false
+}
+
 // NumResponded returns how many nodes responded for a given error
 func NumResponded(err error) int {
 	for err != nil {
@@ -110,15 +122,16 @@ func newConsistencyResultError(
 	enqueued, responded int,
 	errs []error,
 ) consistencyResultError {
-	// NB(r): if any errors are bad request errors, encapsulate that error
-	// to ensure the error itself is wholly classified as a bad request error
+	// NB(r): if any errors are bad request or resource exhausted errors,
+	// encapsulate that error to ensure the error itself is wholly classified
+	// as a bad request or resource exhausted error
 	var topLevelErr error
 	for i := 0; i < len(errs); i++ {
 		if topLevelErr == nil {
 			topLevelErr = errs[i]
 			continue
 		}
-		if IsBadRequestError(errs[i]) {
+		if IsBadRequestError(errs[i]) || IsResourceExhaustedError(errs[i]) {
 			topLevelErr = errs[i]
 			break
 		}
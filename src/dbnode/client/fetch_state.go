@@ -173,6 +173,7 @@ func (f *fetchState) asEncodingSeriesIterators(pools fetchTaggedPools) (encoding
 type fetchTaggedPools interface {
 	MultiReaderIteratorArray() encoding.MultiReaderIteratorArrayPool
 	MultiReaderIterator() encoding.MultiReaderIteratorPool
+	MultiReaderIteratorForScheme(scheme string) (encoding.MultiReaderIterator, error)
 	MutableSeriesIterators() encoding.MutableSeriesIteratorsPool
 	SeriesIterator() encoding.SeriesIteratorPool
 	CheckedBytesWrapper() xpool.CheckedBytesWrapperPool
@@ -215,13 +215,22 @@ func (accum *fetchTaggedResultAccumulator) Reset(
 func (accum *fetchTaggedResultAccumulator) sliceResponsesAsSeriesIter(
 	pools fetchTaggedPools,
 	elems fetchTaggedIDResults,
-) encoding.SeriesIterator {
+) (encoding.SeriesIterator, error) {
 	numElems := len(elems)
 	iters := pools.MultiReaderIteratorArray().Get(numElems)[:numElems]
 	for idx, elem := range elems {
+		// Decode with the encoding scheme returned by the host, it is only
+		// set for namespaces not using the default encoding scheme.
+		multiIter, err := pools.MultiReaderIteratorForScheme(elem.GetEncodingScheme())
+		if err != nil {
+			for _, iter := range iters[:idx] {
+				iter.Close()
+			}
+			pools.MultiReaderIteratorArray().Put(iters)
+			return nil, err
+		}
 		slicesIter := pools.ReaderSliceOfSlicesIterator().Get()
 		slicesIter.Reset(elem.Segments)
-		multiIter := pools.MultiReaderIterator().Get()
 		multiIter.ResetSliceOfSlices(slicesIter)
 		iters[idx] = multiIter
 	}
@@ -247,7 +256,7 @@ func (accum *fetchTaggedResultAccumulator) sliceResponsesAsSeriesIter(
 		Replicas:       iters,
 	})
 
-	return seriesIter
+	return seriesIter, nil
 }
 
 func (accum *fetchTaggedResultAccumulator) AsEncodingSeriesIterators(
@@ -267,13 +276,22 @@ func (accum *fetchTaggedResultAccumulator) AsEncodingSeriesIterators(
 	result.Reset(numElements)
 	count := 0
 	moreElems := false
+	var err error
 	accum.responses.forEachID(func(elems fetchTaggedIDResults, hasMore bool) bool {
-		seriesIter := accum.sliceResponsesAsSeriesIter(pools, elems)
+		var seriesIter encoding.SeriesIterator
+		seriesIter, err = accum.sliceResponsesAsSeriesIter(pools, elems)
+		if err != nil {
+			return false
+		}
 		result.SetAt(count, seriesIter)
 		count++
 		moreElems = hasMore
 		return count < limit
 	})
+	if err != nil {
+		result.Close()
+		return nil, false, err
+	}
 
 	exhaustive := accum.exhaustive && count <= limit && !moreElems
 	return result, exhaustive, nil
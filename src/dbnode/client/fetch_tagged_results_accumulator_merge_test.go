@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/vdelta"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
 	"github.com/m3db/m3/src/dbnode/topology"
@@ -290,6 +291,63 @@ func TestFetchTaggedResultsAccumulatorSeriesItersDatapointsNSplit(t *testing.T)
 	sg0.assertMatchesEncodingIters(t, iters)
 }
 
+func TestFetchTaggedResultsAccumulatorSeriesItersEncodingScheme(t *testing.T) {
+	topoMap := testutil.MustNewTopologyMap(1, map[string][]shard.Shard{
+		"testhost0": testutil.ShardsRange(0, 29, shard.Available),
+	})
+
+	th := newTestFetchTaggedHelper(t)
+	series := newTestSeries(1)
+	series.datapoints = newTestDatapoints(10, testStartTime, testEndTime)
+
+	enc := vdelta.NewEncoder(testStartTime, nil, nil)
+	for _, dp := range series.datapoints {
+		require.NoError(t, enc.Encode(dp, testFetchTaggedTimeUnit, nil))
+	}
+	segments, err := convert.ToSegments([]xio.BlockReader{
+		xio.BlockReader{
+			SegmentReader: enc.Stream(),
+		},
+	})
+	require.NoError(t, err)
+
+	scheme := vdelta.Scheme
+	elem := series.toRPCResult(th, testStartTime)
+	elem.Segments = []*rpc.Segments{segments.Segments}
+	elem.EncodingScheme = &scheme
+
+	workflow := testFetchTaggedWorkflow{
+		t:         t,
+		topoMap:   topoMap,
+		level:     topology.ReadConsistencyLevelOne,
+		startTime: testStartTime,
+		endTime:   testEndTime,
+		steps: []testFetchTaggedWorklowStep{
+			testFetchTaggedWorklowStep{
+				hostname: "testhost0",
+				response: &rpc.FetchTaggedResult_{
+					Elements:   []*rpc.FetchTaggedIDResult_{elem},
+					Exhaustive: true,
+				},
+				expectedDone: true,
+			},
+		},
+	}
+	accum := workflow.run()
+
+	iters, exhaustive, err := accum.AsEncodingSeriesIterators(10, th.pools)
+	require.NoError(t, err)
+	require.True(t, exhaustive)
+	testSerieses{series}.assertMatchesEncodingIters(t, iters)
+
+	unknown := "unknown"
+	elem.EncodingScheme = &unknown
+	accum = workflow.run()
+
+	_, _, err = accum.AsEncodingSeriesIterators(10, th.pools)
+	require.Error(t, err)
+}
+
 type testFetchTaggedWorkflow struct {
 	t         *testing.T
 	topoMap   topology.Map
@@ -32,6 +32,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/x/xpool"
 	"github.com/m3db/m3/src/x/serialize"
 	"github.com/m3db/m3x/ident"
@@ -272,6 +273,8 @@ func initTestFetchTaggedPools() *testFetchTaggedPools {
 		return m3tsz.NewReaderIterator(r, m3tsz.DefaultIntOptimizationEnabled, encoding.NewOptions())
 	})
 
+	pools.schemeMultiReader = newSchemeMultiReaderIteratorPools(block.NewDefaultCodecRegistry(), opts)
+
 	pools.seriesIter = encoding.NewSeriesIteratorPool(opts)
 	pools.seriesIter.Init()
 
@@ -302,6 +305,7 @@ var _ fetchTaggedPools = testFetchTaggedPools{}
 type testFetchTaggedPools struct {
 	readerSlices             *readerSliceOfSlicesIteratorPool
 	multiReader              encoding.MultiReaderIteratorPool
+	schemeMultiReader        *schemeMultiReaderIteratorPools
 	seriesIter               encoding.SeriesIteratorPool
 	mutableSeriesIter        encoding.MutableSeriesIteratorsPool
 	multiReaderIteratorArray encoding.MultiReaderIteratorArrayPool
@@ -318,6 +322,12 @@ func (p testFetchTaggedPools) MultiReaderIterator() encoding.MultiReaderIterator
 	return p.multiReader
 }
 
+func (p testFetchTaggedPools) MultiReaderIteratorForScheme(
+	scheme string,
+) (encoding.MultiReaderIterator, error) {
+	return p.schemeMultiReader.get(scheme, p.multiReader)
+}
+
 func (p testFetchTaggedPools) SeriesIterator() encoding.SeriesIteratorPool {
 	return p.seriesIter
 }
@@ -259,6 +259,10 @@ func (q *queue) drain() {
 				q.asyncFetchTagged(v)
 			case *truncateOp:
 				q.asyncTruncate(v)
+			case *deleteTaggedOp:
+				q.asyncDeleteTagged(v)
+			case *aggregateOp:
+				q.asyncAggregate(v)
 			default:
 				completionFn := ops[i].CompletionFn()
 				completionFn(nil, errQueueUnknownOperation(q.host.ID()))
@@ -466,7 +470,7 @@ func (q *queue) asyncFetch(op *fetchBatchOp) {
 				op.complete(i, nil, result.Elements[i].Err)
 				continue
 			}
-			op.complete(i, result.Elements[i].Segments, nil)
+			op.complete(i, result.Elements[i], nil)
 		}
 		cleanup()
 	})
@@ -530,6 +534,56 @@ func (q *queue) asyncTruncate(op *truncateOp) {
 	})
 }
 
+func (q *queue) asyncDeleteTagged(op *deleteTaggedOp) {
+	q.Add(1)
+
+	q.workerPool.Go(func() {
+		cleanup := q.Done
+
+		client, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(nil, err)
+			cleanup()
+			return
+		}
+
+		ctx, _ := thrift.NewContext(q.opts.DeleteTaggedRequestTimeout())
+		if res, err := client.DeleteTagged(ctx, &op.request); err != nil {
+			op.completionFn(nil, err)
+		} else {
+			op.completionFn(res, nil)
+		}
+
+		cleanup()
+	})
+}
+
+func (q *queue) asyncAggregate(op *aggregateOp) {
+	q.Add(1)
+
+	q.workerPool.Go(func() {
+		cleanup := q.Done
+
+		client, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(nil, err)
+			cleanup()
+			return
+		}
+
+		ctx, _ := thrift.NewContext(q.opts.FetchRequestTimeout())
+		if res, err := client.Aggregate(ctx, &op.request); err != nil {
+			op.completionFn(nil, err)
+		} else {
+			op.completionFn(res, nil)
+		}
+
+		cleanup()
+	})
+}
+
 func (q *queue) Len() int {
 	q.RLock()
 	v := q.opsSumSize
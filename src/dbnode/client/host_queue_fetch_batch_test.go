@@ -41,7 +41,7 @@ func TestHostQueueFetchBatches(t *testing.T) {
 	}
 	var expected []hostQueueResult
 	for i := range ids {
-		expected = append(expected, hostQueueResult{result.Elements[i].Segments, nil})
+		expected = append(expected, hostQueueResult{result.Elements[i], nil})
 	}
 	testHostQueueFetchBatches(t, namespace, ids, result, expected, nil, func(results []hostQueueResult) {
 		assert.Equal(t, expected, results)
@@ -89,7 +89,7 @@ func TestHostQueueFetchBatchesErrorOnFetchNoResponse(t *testing.T) {
 	}
 	var expected []hostQueueResult
 	for i := range ids[:len(ids)-1] {
-		expected = append(expected, hostQueueResult{result.Elements[i].Segments, nil})
+		expected = append(expected, hostQueueResult{result.Elements[i], nil})
 	}
 
 	testHostQueueFetchBatches(t, namespace, ids, result, expected, nil, func(results []hostQueueResult) {
@@ -111,7 +111,7 @@ func TestHostQueueFetchBatchesErrorOnResultError(t *testing.T) {
 	result.Elements = append(result.Elements, &rpc.FetchRawResult_{Err: anError})
 	var expected []hostQueueResult
 	for i := range ids[:len(ids)-1] {
-		expected = append(expected, hostQueueResult{result.Elements[i].Segments, nil})
+		expected = append(expected, hostQueueResult{result.Elements[i], nil})
 	}
 	testHostQueueFetchBatches(t, namespace, ids, result, expected, nil, func(results []hostQueueResult) {
 		assert.Equal(t, expected, results[:len(results)-1])
@@ -31,6 +31,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
 	m3dbruntime "github.com/m3db/m3/src/dbnode/runtime"
+	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3/src/x/serialize"
 	"github.com/m3db/m3x/context"
@@ -79,6 +80,9 @@ const (
 	// defaultTruncateRequestTimeout is the default truncate request timeout
 	defaultTruncateRequestTimeout = 60 * time.Second
 
+	// defaultDeleteTaggedRequestTimeout is the default delete tagged request timeout
+	defaultDeleteTaggedRequestTimeout = 60 * time.Second
+
 	// defaultIdentifierPoolSize is the default identifier pool size
 	defaultIdentifierPoolSize = 8192
 
@@ -199,6 +203,7 @@ type options struct {
 	writeRequestTimeout                     time.Duration
 	fetchRequestTimeout                     time.Duration
 	truncateRequestTimeout                  time.Duration
+	deleteTaggedRequestTimeout              time.Duration
 	backgroundConnectInterval               time.Duration
 	backgroundConnectStutter                time.Duration
 	backgroundHealthCheckInterval           time.Duration
@@ -213,6 +218,7 @@ type options struct {
 	fetchRetrier                            xretry.Retrier
 	streamBlocksRetrier                     xretry.Retrier
 	readerIteratorAllocate                  encoding.ReaderIteratorAllocate
+	codecRegistry                           encoding.CodecRegistry
 	writeOperationPoolSize                  int
 	writeTaggedOperationPoolSize            int
 	fetchBatchOpPoolSize                    int
@@ -279,6 +285,7 @@ func newOptions() *options {
 		writeRequestTimeout:                     defaultWriteRequestTimeout,
 		fetchRequestTimeout:                     defaultFetchRequestTimeout,
 		truncateRequestTimeout:                  defaultTruncateRequestTimeout,
+		deleteTaggedRequestTimeout:              defaultDeleteTaggedRequestTimeout,
 		backgroundConnectInterval:               defaultBackgroundConnectInterval,
 		backgroundConnectStutter:                defaultBackgroundConnectStutter,
 		backgroundHealthCheckInterval:           defaultBackgroundHealthCheckInterval,
@@ -310,6 +317,7 @@ func newOptions() *options {
 		fetchSeriesBlocksMetadataBatchTimeout:   defaultFetchSeriesBlocksMetadataBatchTimeout,
 		fetchSeriesBlocksBatchTimeout:           defaultFetchSeriesBlocksBatchTimeout,
 		fetchSeriesBlocksBatchConcurrency:       defaultFetchSeriesBlocksBatchConcurrency,
+		codecRegistry:                           block.NewDefaultCodecRegistry(),
 	}
 	return opts.SetEncodingM3TSZ().(*options)
 }
@@ -509,6 +517,16 @@ func (o *options) TruncateRequestTimeout() time.Duration {
 	return o.truncateRequestTimeout
 }
 
+func (o *options) SetDeleteTaggedRequestTimeout(value time.Duration) Options {
+	opts := *o
+	opts.deleteTaggedRequestTimeout = value
+	return &opts
+}
+
+func (o *options) DeleteTaggedRequestTimeout() time.Duration {
+	return o.deleteTaggedRequestTimeout
+}
+
 func (o *options) SetBackgroundConnectInterval(value time.Duration) Options {
 	opts := *o
 	opts.backgroundConnectInterval = value
@@ -779,6 +797,16 @@ func (o *options) ReaderIteratorAllocate() encoding.ReaderIteratorAllocate {
 	return o.readerIteratorAllocate
 }
 
+func (o *options) SetCodecRegistry(value encoding.CodecRegistry) Options {
+	opts := *o
+	opts.codecRegistry = value
+	return &opts
+}
+
+func (o *options) CodecRegistry() encoding.CodecRegistry {
+	return o.codecRegistry
+}
+
 func (o *options) SetOrigin(value topology.Host) AdminOptions {
 	opts := *o
 	opts.origin = value
@@ -766,6 +766,15 @@ func (s *session) setTopologyWithLock(topoMap topology.Map, queues []hostQueue,
 		s.pools.multiReaderIterator = encoding.NewMultiReaderIteratorPool(poolOpts)
 		s.pools.multiReaderIterator.Init(s.opts.ReaderIteratorAllocate())
 	}
+	if s.pools.schemeMultiReaderIterators == nil {
+		poolOpts := pool.NewObjectPoolOptions().
+			SetSize(replicas * s.opts.SeriesIteratorPoolSize()).
+			SetInstrumentOptions(s.opts.InstrumentOptions().SetMetricsScope(
+				s.scope.SubScope("scheme-multi-reader-iterator-pool"),
+			))
+		s.pools.schemeMultiReaderIterators = newSchemeMultiReaderIteratorPools(
+			s.opts.CodecRegistry(), poolOpts)
+	}
 	if replicas > len(s.metrics.writeNodesRespondingErrors) {
 		curr := len(s.metrics.writeNodesRespondingErrors)
 		for i := curr; i < replicas; i++ {
@@ -1390,7 +1399,16 @@ func (s *session) fetchIDsAttempt(
 			wg.Done()
 		}
 		completionFn := func(result interface{}, err error) {
-			var snapshotSuccess int32
+			var (
+				snapshotSuccess int32
+				multiIter       encoding.MultiReaderIterator
+			)
+			if err == nil {
+				// Decode with the encoding scheme returned by the host, it is
+				// only set for namespaces not using the default encoding scheme.
+				scheme := result.(*rpc.FetchRawResult_).GetEncodingScheme()
+				multiIter, err = s.pools.MultiReaderIteratorForScheme(scheme)
+			}
 			if err != nil {
 				atomic.AddInt32(&errs, 1)
 				// NB(r): reuse the error lock here as we do not want to create
@@ -1403,8 +1421,7 @@ func (s *session) fetchIDsAttempt(
 				resultErrLock.Unlock()
 			} else {
 				slicesIter := s.pools.readerSliceOfSlicesIterator.Get()
-				slicesIter.Reset(result.([]*rpc.Segments))
-				multiIter := s.pools.multiReaderIterator.Get()
+				slicesIter.Reset(result.(*rpc.FetchRawResult_).Segments)
 				multiIter.ResetSliceOfSlices(slicesIter)
 				// Results is pre-allocated after creating fetch ops for this ID below
 				resultsLock.Lock()
@@ -1648,6 +1665,186 @@ func (s *session) Truncate(namespace ident.ID) (int64, error) {
 	return truncated, resultErr.FinalError()
 }
 
+func (s *session) Aggregate(
+	namespace ident.ID,
+	q index.Query,
+	opts index.AggregationOptions,
+) (*index.AggregateResults, bool, error) {
+	request, err := convert.ToRPCAggregateQueryRequest(namespace, q, opts)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var (
+		wg           sync.WaitGroup
+		resultLock   sync.Mutex
+		resultErrs   []error
+		responded    int
+		shardSuccess = make(map[uint32]int)
+		results      = index.NewAggregateResults(opts)
+		exhaustive   = true
+	)
+
+	s.state.RLock()
+	if s.state.status != statusOpen {
+		s.state.RUnlock()
+		return nil, false, errSessionStatusNotOpen
+	}
+	var (
+		level    = s.state.readLevel
+		majority = s.state.topoMap.MajorityReplicas()
+		replicas = s.state.topoMap.Replicas()
+		shards   = s.state.topoMap.ShardSet().AllIDs()
+		numHosts = len(s.state.queues)
+	)
+	for _, queue := range s.state.queues {
+		var hostShards []uint32
+		if hostShardSet, ok := s.state.topoMap.LookupHostShardSet(queue.Host().ID()); ok {
+			hostShards = hostShardSet.ShardSet().AllIDs()
+		}
+
+		// NB: each host aggregates the matching series from every shard it
+		// owns, so a response from a host counts towards the consistency of
+		// each of its shards.
+		a := &aggregateOp{request: request}
+		a.completionFn = func(result interface{}, err error) {
+			resultLock.Lock()
+			responded++
+			if err != nil {
+				resultErrs = append(resultErrs, err)
+			} else {
+				res := result.(*rpc.AggregateQueryResult_)
+				exhaustive = exhaustive && res.Exhaustive
+				for _, elem := range res.Results {
+					results.AddName(elem.TagName)
+					for _, value := range elem.TagValues {
+						results.Add(elem.TagName, value)
+					}
+				}
+				for _, shard := range hostShards {
+					shardSuccess[shard]++
+				}
+			}
+			resultLock.Unlock()
+			wg.Done()
+		}
+
+		wg.Add(1)
+		if err := queue.Enqueue(a); err != nil {
+			wg.Done()
+			resultLock.Lock()
+			resultErrs = append(resultErrs, err)
+			resultLock.Unlock()
+		}
+	}
+	s.state.RUnlock()
+
+	wg.Wait()
+
+	for _, shard := range shards {
+		if !topology.ReadConsistencyAchieved(level, majority, replicas, shardSuccess[shard]) {
+			return nil, false, newConsistencyResultError(level, numHosts, responded, resultErrs)
+		}
+	}
+	return results, exhaustive, nil
+}
+
+func (s *session) DeleteTagged(
+	namespace ident.ID,
+	q index.Query,
+	opts index.QueryOptions,
+) (int64, error) {
+	request, err := convert.ToRPCDeleteTaggedRequest(namespace, q, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		wg            sync.WaitGroup
+		resultLock    sync.Mutex
+		resultErrs    []error
+		responded     int
+		shardSuccess  = make(map[uint32]int)
+		shardDeleted  = make(map[uint32]int64)
+		legacyDeleted int64
+	)
+
+	s.state.RLock()
+	if s.state.status != statusOpen {
+		s.state.RUnlock()
+		return 0, errSessionStatusNotOpen
+	}
+	var (
+		level    = s.state.writeLevel
+		majority = s.state.topoMap.MajorityReplicas()
+		replicas = s.state.topoMap.Replicas()
+		shards   = s.state.topoMap.ShardSet().AllIDs()
+		numHosts = len(s.state.queues)
+	)
+	for _, queue := range s.state.queues {
+		var hostShards []uint32
+		if hostShardSet, ok := s.state.topoMap.LookupHostShardSet(queue.Host().ID()); ok {
+			hostShards = hostShardSet.ShardSet().AllIDs()
+		}
+
+		// NB: each host deletes the matching series from every shard it owns,
+		// so a response from a host counts towards the consistency of each of
+		// its shards.
+		d := &deleteTaggedOp{request: request}
+		d.completionFn = func(result interface{}, err error) {
+			resultLock.Lock()
+			responded++
+			if err != nil {
+				resultErrs = append(resultErrs, err)
+			} else {
+				res := result.(*rpc.DeleteTaggedResult_)
+				if !res.IsSetShardNumSeries() {
+					// Hosts that do not report per shard counts can only be
+					// approximated as each series is deleted from every replica.
+					legacyDeleted += res.NumSeries
+				}
+				for _, shard := range hostShards {
+					shardSuccess[shard]++
+					if n := res.ShardNumSeries[int32(shard)]; n > shardDeleted[shard] {
+						shardDeleted[shard] = n
+					}
+				}
+			}
+			resultLock.Unlock()
+			wg.Done()
+		}
+
+		wg.Add(1)
+		if err := queue.Enqueue(d); err != nil {
+			wg.Done()
+			resultLock.Lock()
+			resultErrs = append(resultErrs, err)
+			resultLock.Unlock()
+		}
+	}
+	s.state.RUnlock()
+
+	// Wait for the series to be deleted on all replicas
+	wg.Wait()
+
+	// NB: the series deleted from shards that did not meet the consistency
+	// level are still reported alongside the error as partial results.
+	var deleted int64
+	for _, n := range shardDeleted {
+		deleted += n
+	}
+	if replicas > 0 {
+		deleted += legacyDeleted / int64(replicas)
+	}
+
+	for _, shard := range shards {
+		if !topology.WriteConsistencyAchieved(level, majority, replicas, shardSuccess[shard]) {
+			return deleted, newConsistencyResultError(level, numHosts, responded, resultErrs)
+		}
+	}
+	return deleted, nil
+}
+
 // NB(r): Excluding maligned struct check here as we can
 // live with a few extra bytes since this struct is only
 // ever passed by stack, its much more readable not optimized
@@ -3340,8 +3537,8 @@ func newPeerBlocksIter(
 	}
 }
 
-func (it *peerBlocksIter) Current() (topology.Host, ident.ID, block.DatabaseBlock) {
-	return it.current.peer, it.current.id, it.current.block
+func (it *peerBlocksIter) Current() (topology.Host, ident.ID, ident.Tags, block.DatabaseBlock) {
+	return it.current.peer, it.current.id, it.current.tags, it.current.block
 }
 
 func (it *peerBlocksIter) Err() error {
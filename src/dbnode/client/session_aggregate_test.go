@@ -0,0 +1,117 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionAggregateMergesResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Only the first host fails, which still meets majority for every shard.
+	results, exhaustive, err := testSessionAggregate(t, ctrl, map[int]bool{0: true})
+	require.NoError(t, err)
+	assert.False(t, exhaustive)
+
+	actual := make(map[string][]string)
+	results.ForEach(func(name []byte, values [][]byte) {
+		for _, value := range values {
+			actual[string(name)] = append(actual[string(name)], string(value))
+		}
+	})
+	assert.Equal(t, map[string][]string{
+		"foo": {"bar", "baz"},
+		"qux": {"quz"},
+	}, actual)
+}
+
+func TestSessionAggregateFailsConsistency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, _, err := testSessionAggregate(t, ctrl, map[int]bool{0: true, 1: true})
+	require.Error(t, err)
+	assert.Equal(t, 1, NumSuccess(err))
+	assert.Equal(t, 2, NumError(err))
+}
+
+func testSessionAggregate(
+	t *testing.T,
+	ctrl *gomock.Controller,
+	failedHosts map[int]bool,
+) (*index.AggregateResults, bool, error) {
+	opts := newSessionTestOptions().
+		SetReadConsistencyLevel(topology.ReadConsistencyLevelMajority)
+	s, err := newSession(opts)
+	require.NoError(t, err)
+	session := s.(*session)
+
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			a, ok := op.(*aggregateOp)
+			require.True(t, ok)
+			assert.Equal(t, []byte("metrics"), a.request.NameSpace)
+
+			if failedHosts[idx] {
+				a.completionFn(nil, errors.New("aggregate error"))
+				return
+			}
+			res := &rpc.AggregateQueryResult_{
+				Results: []*rpc.AggregateQueryResultTagNameElement{
+					{TagName: []byte("foo"), TagValues: [][]byte{[]byte("bar")}},
+				},
+				Exhaustive: idx != 1,
+			}
+			if idx == 2 {
+				res.Results = append(res.Results,
+					&rpc.AggregateQueryResultTagNameElement{
+						TagName:   []byte("foo"),
+						TagValues: [][]byte{[]byte("baz")},
+					},
+					&rpc.AggregateQueryResultTagNameElement{
+						TagName:   []byte("qux"),
+						TagValues: [][]byte{[]byte("quz")},
+					})
+			}
+			a.completionFn(res, nil)
+		},
+	})
+
+	require.NoError(t, session.Open())
+	defer func() {
+		require.NoError(t, session.Close())
+	}()
+
+	return s.Aggregate(ident.StringID("metrics"), testSessionFetchTaggedQuery,
+		index.AggregationOptions{})
+}
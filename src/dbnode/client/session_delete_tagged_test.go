@@ -0,0 +1,94 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionDeleteTaggedMeetsConsistency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Only the first host fails, which still meets majority for every shard.
+	n, err := testSessionDeleteTagged(t, ctrl, map[int]bool{0: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+}
+
+func TestSessionDeleteTaggedFailsConsistency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Only the last host succeeds so its deletes are returned as partial results.
+	n, err := testSessionDeleteTagged(t, ctrl, map[int]bool{0: true, 1: true})
+	require.Error(t, err)
+	assert.Equal(t, int64(3), n)
+	assert.Equal(t, 1, NumSuccess(err))
+	assert.Equal(t, 2, NumError(err))
+}
+
+func testSessionDeleteTagged(
+	t *testing.T,
+	ctrl *gomock.Controller,
+	failedHosts map[int]bool,
+) (int64, error) {
+	opts := newSessionTestOptions().
+		SetWriteConsistencyLevel(topology.ConsistencyLevelMajority)
+	s, err := newSession(opts)
+	require.NoError(t, err)
+	session := s.(*session)
+
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			d, ok := op.(*deleteTaggedOp)
+			require.True(t, ok)
+			assert.Equal(t, []byte("metrics"), d.request.NameSpace)
+
+			if failedHosts[idx] {
+				d.completionFn(nil, errors.New("delete tagged error"))
+				return
+			}
+			d.completionFn(&rpc.DeleteTaggedResult_{
+				NumSeries:      3,
+				ShardNumSeries: map[int32]int64{0: 2, 1: 1},
+			}, nil)
+		},
+	})
+
+	require.NoError(t, session.Open())
+	defer func() {
+		require.NoError(t, session.Close())
+	}()
+
+	return s.DeleteTagged(ident.StringID("metrics"), testSessionFetchTaggedQuery,
+		index.QueryOptions{})
+}
@@ -488,9 +488,11 @@ func fulfillTszFetchBatchOps(
 					encoder.Encode(dp, value.unit, value.annotation)
 				}
 				seg := encoder.Discard()
-				op.completionFns[i]([]*rpc.Segments{&rpc.Segments{
-					Merged: &rpc.Segment{Head: seg.Head.Bytes(), Tail: seg.Tail.Bytes()},
-				}}, nil)
+				op.completionFns[i](&rpc.FetchRawResult_{
+					Segments: []*rpc.Segments{&rpc.Segments{
+						Merged: &rpc.Segment{Head: seg.Head.Bytes(), Tail: seg.Tail.Bytes()},
+					}},
+				}, nil)
 				calledCompletionFn = true
 				break
 			}
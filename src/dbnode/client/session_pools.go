@@ -21,11 +21,14 @@
 package client
 
 import (
+	"sync"
+
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/x/xpool"
 	"github.com/m3db/m3/src/x/serialize"
 	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
+	"github.com/m3db/m3x/pool"
 )
 
 type sessionPools struct {
@@ -42,6 +45,7 @@ type sessionPools struct {
 	tagDecoder                  serialize.TagDecoderPool
 	readerSliceOfSlicesIterator *readerSliceOfSlicesIteratorPool
 	multiReaderIterator         encoding.MultiReaderIteratorPool
+	schemeMultiReaderIterators  *schemeMultiReaderIteratorPools
 	seriesIterator              encoding.SeriesIteratorPool
 	seriesIterators             encoding.MutableSeriesIteratorsPool
 	writeAttempt                *writeAttemptPool
@@ -82,6 +86,15 @@ func (s sessionPools) MultiReaderIterator() encoding.MultiReaderIteratorPool {
 	return s.multiReaderIterator
 }
 
+func (s sessionPools) MultiReaderIteratorForScheme(
+	scheme string,
+) (encoding.MultiReaderIterator, error) {
+	if s.schemeMultiReaderIterators == nil {
+		return s.multiReaderIterator.Get(), nil
+	}
+	return s.schemeMultiReaderIterators.get(scheme, s.multiReaderIterator)
+}
+
 func (s sessionPools) CheckedBytesWrapper() xpool.CheckedBytesWrapperPool {
 	return s.checkedBytesWrapper
 }
@@ -89,3 +102,58 @@ func (s sessionPools) CheckedBytesWrapper() xpool.CheckedBytesWrapperPool {
 func (s sessionPools) MutableSeriesIterators() encoding.MutableSeriesIteratorsPool {
 	return s.seriesIterators
 }
+
+// schemeMultiReaderIteratorPools lazily creates a pool of multi reader
+// iterators for each non-default encoding scheme segments are fetched with.
+type schemeMultiReaderIteratorPools struct {
+	sync.RWMutex
+
+	registry encoding.CodecRegistry
+	poolOpts pool.ObjectPoolOptions
+	pools    map[string]encoding.MultiReaderIteratorPool
+}
+
+func newSchemeMultiReaderIteratorPools(
+	registry encoding.CodecRegistry,
+	poolOpts pool.ObjectPoolOptions,
+) *schemeMultiReaderIteratorPools {
+	return &schemeMultiReaderIteratorPools{
+		registry: registry,
+		poolOpts: poolOpts,
+		pools:    make(map[string]encoding.MultiReaderIteratorPool),
+	}
+}
+
+// get returns an iterator that decodes segments encoded with the scheme, the
+// default pool is used for the default scheme and when no scheme is set.
+func (p *schemeMultiReaderIteratorPools) get(
+	scheme string,
+	defaultPool encoding.MultiReaderIteratorPool,
+) (encoding.MultiReaderIterator, error) {
+	if scheme == "" || p.registry == nil || p.registry.IsDefault(scheme) {
+		return defaultPool.Get(), nil
+	}
+
+	p.RLock()
+	schemePool, ok := p.pools[scheme]
+	p.RUnlock()
+	if ok {
+		return schemePool.Get(), nil
+	}
+
+	codec, err := p.registry.Codec(scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Lock()
+	schemePool, ok = p.pools[scheme]
+	if !ok {
+		schemePool = encoding.NewMultiReaderIteratorPool(p.poolOpts)
+		schemePool.Init(encoding.NewReaderIteratorAllocate(codec, encoding.NewOptions()))
+		p.pools[scheme] = schemePool
+	}
+	p.Unlock()
+
+	return schemePool.Get(), nil
+}
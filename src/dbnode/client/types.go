@@ -78,6 +78,19 @@ type Session interface {
 	// FetchTaggedIDs resolves the provided query to known IDs.
 	FetchTaggedIDs(namespace ident.ID, q index.Query, opts index.QueryOptions) (iter TaggedIDsIterator, exhaustive bool, err error)
 
+	// Aggregate resolves the provided query to the tag names, and the values
+	// unless only names are requested, of the matching series. An error is
+	// returned if any shard does not meet the read consistency level.
+	Aggregate(namespace ident.ID, q index.Query, opts index.AggregationOptions) (results *index.AggregateResults, exhaustive bool, err error)
+
+	// DeleteTagged deletes all series matching the provided query within the
+	// range of the options from every replica, up to the limit of the options
+	// per replica if set, and returns the number of series deleted. The entire
+	// retention is searched if the range is not set. An error is returned if
+	// any shard does not meet the write consistency level, along with the number
+	// of series deleted by the replicas that succeeded.
+	DeleteTagged(namespace ident.ID, q index.Query, opts index.QueryOptions) (int64, error)
+
 	// ShardID returns the given shard for an ID for callers
 	// to easily discern what shard is failing when operations
 	// for given IDs begin failing
@@ -136,9 +149,9 @@ type PeerBlocksIter interface {
 	// Next returns whether there are more items in the collection
 	Next() bool
 
-	// Current returns the metadata, and block data for a single block replica.
-	// These remain valid until Next() is called again
-	Current() (topology.Host, ident.ID, block.DatabaseBlock)
+	// Current returns the metadata, tags and block data for a single block
+	// replica. These remain valid until Next() is called again
+	Current() (topology.Host, ident.ID, ident.Tags, block.DatabaseBlock)
 
 	// Err returns any error encountered
 	Err() error
@@ -303,6 +316,12 @@ type Options interface {
 	// TruncateRequestTimeout returns the truncateRequestTimeout
 	TruncateRequestTimeout() time.Duration
 
+	// SetDeleteTaggedRequestTimeout sets the deleteTaggedRequestTimeout
+	SetDeleteTaggedRequestTimeout(value time.Duration) Options
+
+	// DeleteTaggedRequestTimeout returns the deleteTaggedRequestTimeout
+	DeleteTaggedRequestTimeout() time.Duration
+
 	// SetBackgroundConnectInterval sets the backgroundConnectInterval
 	SetBackgroundConnectInterval(value time.Duration) Options
 
@@ -476,6 +495,14 @@ type Options interface {
 
 	// ReaderIteratorAllocate returns the readerIteratorAllocate
 	ReaderIteratorAllocate() encoding.ReaderIteratorAllocate
+
+	// SetCodecRegistry sets the codec registry used to decode segments
+	// fetched from namespaces that use a non-default encoding scheme
+	SetCodecRegistry(value encoding.CodecRegistry) Options
+
+	// CodecRegistry returns the codec registry used to decode segments
+	// fetched from namespaces that use a non-default encoding scheme
+	CodecRegistry() encoding.CodecRegistry
 }
 
 // AdminOptions is a set of administration client options
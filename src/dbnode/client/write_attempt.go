@@ -69,8 +69,8 @@ func (w *writeAttempt) perform() error {
 		w.args.namespace, w.args.id, w.args.tags, w.args.t,
 		w.args.value, w.args.unit, w.args.annotation)
 
-	if IsBadRequestError(err) {
-		// Do not retry bad request errors
+	if IsBadRequestError(err) || IsResourceExhaustedError(err) {
+		// Do not retry bad request or resource exhausted errors
 		err = xerrors.NewNonRetryableError(err)
 	}
 
@@ -93,6 +93,10 @@ func TestBadRequestErrorTagged(t *testing.T) {
 	simpleRetryableTestTagged(t, tterrors.NewBadRequestError(errors.New("")), nil, IsBadRequestError)
 }
 
+func TestResourceExhaustedErrorTagged(t *testing.T) {
+	simpleRetryableTestTagged(t, tterrors.NewResourceExhaustedError(errors.New("")), nil, IsResourceExhaustedError)
+}
+
 func TestRetryableErrorTagged(t *testing.T) {
 	simpleRetryableTestTagged(t, xerrors.NewRetryableError(errors.New("")), nil, xerrors.IsRetryableError)
 }
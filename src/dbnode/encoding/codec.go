@@ -0,0 +1,146 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encoding
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+)
+
+var (
+	errCodecSchemeEmpty = errors.New("codec encoding scheme must not be empty")
+)
+
+type codecRegistry struct {
+	sync.RWMutex
+
+	defaultCodec Codec
+	codecs       map[string]Codec
+}
+
+// NewCodecRegistry creates a new codec registry with the codec for the
+// default encoding scheme registered.
+func NewCodecRegistry(defaultCodec Codec) CodecRegistry {
+	return &codecRegistry{
+		defaultCodec: defaultCodec,
+		codecs: map[string]Codec{
+			defaultCodec.Scheme(): defaultCodec,
+		},
+	}
+}
+
+func (r *codecRegistry) Register(codec Codec) error {
+	scheme := codec.Scheme()
+	if scheme == "" {
+		return errCodecSchemeEmpty
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.codecs[scheme]; ok {
+		return fmt.Errorf("codec already registered for encoding scheme: %s", scheme)
+	}
+	r.codecs[scheme] = codec
+	return nil
+}
+
+func (r *codecRegistry) Codec(scheme string) (Codec, error) {
+	if scheme == "" {
+		return r.defaultCodec, nil
+	}
+
+	r.RLock()
+	codec, ok := r.codecs[scheme]
+	r.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown encoding scheme: %s", scheme)
+	}
+	return codec, nil
+}
+
+func (r *codecRegistry) Default() Codec {
+	return r.defaultCodec
+}
+
+func (r *codecRegistry) IsDefault(scheme string) bool {
+	return scheme == "" || scheme == r.defaultCodec.Scheme()
+}
+
+func (r *codecRegistry) Schemes() []string {
+	r.RLock()
+	schemes := make([]string, 0, len(r.codecs))
+	for scheme := range r.codecs {
+		schemes = append(schemes, scheme)
+	}
+	r.RUnlock()
+
+	sort.Strings(schemes)
+	return schemes
+}
+
+// NewReaderIteratorAllocate returns an allocate function that creates
+// iterators for an encoding scheme that are not returned to any pool, it
+// is useful when decoding data encoded with a scheme other than the one
+// the pools of an encoding options are initialized with.
+func NewReaderIteratorAllocate(codec Codec, opts Options) ReaderIteratorAllocate {
+	opts = opts.SetEncoderPool(nil).SetReaderIteratorPool(nil)
+	return func(reader io.Reader) ReaderIterator {
+		return codec.NewReaderIterator(reader, opts)
+	}
+}
+
+// Transcode decodes a segment encoded with the codec from and encodes its
+// datapoints with the codec to, returning the segment encoded with the codec
+// to. The segment is not finalized.
+func Transcode(
+	segment ts.Segment,
+	from Codec,
+	to Codec,
+	start time.Time,
+	opts Options,
+) (ts.Segment, error) {
+	opts = opts.SetEncoderPool(nil).SetReaderIteratorPool(nil)
+	iter := from.NewReaderIterator(xio.NewSegmentReader(segment), opts)
+	defer iter.Close()
+
+	encoder := to.NewEncoder(start, nil, opts)
+	for iter.Next() {
+		dp, unit, annotation := iter.Current()
+		if err := encoder.Encode(dp, unit, annotation); err != nil {
+			encoder.Close()
+			return ts.Segment{}, err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		encoder.Close()
+		return ts.Segment{}, err
+	}
+	return encoder.Discard(), nil
+}
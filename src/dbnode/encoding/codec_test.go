@@ -0,0 +1,76 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encoding
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCodec(ctrl *gomock.Controller, scheme string) *MockCodec {
+	codec := NewMockCodec(ctrl)
+	codec.EXPECT().Scheme().Return(scheme).AnyTimes()
+	return codec
+}
+
+func TestCodecRegistry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		defaultCodec = newTestCodec(ctrl, "default")
+		otherCodec   = newTestCodec(ctrl, "other")
+		registry     = NewCodecRegistry(defaultCodec)
+	)
+	require.NoError(t, registry.Register(otherCodec))
+	assert.Equal(t, []string{"default", "other"}, registry.Schemes())
+	assert.Equal(t, defaultCodec, registry.Default())
+
+	for _, test := range []struct {
+		scheme    string
+		expected  Codec
+		isDefault bool
+	}{
+		{scheme: "", expected: defaultCodec, isDefault: true},
+		{scheme: "default", expected: defaultCodec, isDefault: true},
+		{scheme: "other", expected: otherCodec, isDefault: false},
+	} {
+		codec, err := registry.Codec(test.scheme)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, codec)
+		assert.Equal(t, test.isDefault, registry.IsDefault(test.scheme))
+	}
+
+	_, err := registry.Codec("unknown")
+	assert.Error(t, err)
+}
+
+func TestCodecRegistryRegisterInvalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	registry := NewCodecRegistry(newTestCodec(ctrl, "default"))
+	assert.Error(t, registry.Register(newTestCodec(ctrl, "default")))
+	assert.Equal(t, errCodecSchemeEmpty, registry.Register(newTestCodec(ctrl, "")))
+}
@@ -238,6 +238,40 @@ type Decoder interface {
 // NewDecoderFn creates a new decoder
 type NewDecoderFn func() Decoder
 
+// Codec is an encoding scheme for series data, it creates the encoders that
+// encode datapoints with the scheme and the iterators that decode them.
+type Codec interface {
+	// Scheme returns the name of the encoding scheme.
+	Scheme() string
+
+	// NewEncoder creates a new encoder for the encoding scheme.
+	NewEncoder(start time.Time, bytes checked.Bytes, opts Options) Encoder
+
+	// NewReaderIterator creates a new iterator for the encoding scheme.
+	NewReaderIterator(reader io.Reader, opts Options) ReaderIterator
+}
+
+// CodecRegistry is a registry of the encoding schemes series data can be
+// encoded with.
+type CodecRegistry interface {
+	// Register registers the codec for an encoding scheme.
+	Register(codec Codec) error
+
+	// Codec returns the codec for an encoding scheme, the empty scheme
+	// refers to the default encoding scheme.
+	Codec(scheme string) (Codec, error)
+
+	// Default returns the codec for the default encoding scheme.
+	Default() Codec
+
+	// IsDefault returns whether an encoding scheme refers to the default
+	// encoding scheme.
+	IsDefault(scheme string) bool
+
+	// Schemes returns the names of the registered encoding schemes.
+	Schemes() []string
+}
+
 // EncoderAllocate allocates an encoder for a pool.
 type EncoderAllocate func() Encoder
 
@@ -0,0 +1,251 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package vdelta
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3x/checked"
+	xtime "github.com/m3db/m3x/time"
+)
+
+var (
+	errEncoderClosed       = errors.New("encoder is closed")
+	errNoEncodedDatapoints = errors.New("encoder has no encoded datapoints")
+	errAnnotationTooLarge  = errors.New("annotation is too large")
+)
+
+type encoder struct {
+	os   encoding.OStream
+	opts encoding.Options
+	buf  [binary.MaxVarintLen64]byte
+
+	// internal bookkeeping
+	t          int64      // current time in nanoseconds
+	tu         xtime.Unit // current time unit
+	intVal     int64      // current int value
+	value      float64    // current value
+	numEncoded int        // number of encoded datapoints
+	closed     bool
+}
+
+// NewEncoder creates a new encoder.
+func NewEncoder(
+	start time.Time,
+	bytes checked.Bytes,
+	opts encoding.Options,
+) encoding.Encoder {
+	if opts == nil {
+		opts = encoding.NewOptions()
+	}
+	// NB: only perform an initial allocation if there is no pool that
+	// will be used for this encoder, if a pool is being used alloc when
+	// the `Reset` method is called.
+	initAllocIfEmpty := opts.EncoderPool() == nil
+	return &encoder{
+		os:   encoding.NewOStream(bytes, initAllocIfEmpty, opts.BytesPool()),
+		opts: opts,
+	}
+}
+
+// Encode encodes the timestamp and the value of a datapoint.
+func (enc *encoder) Encode(dp ts.Datapoint, tu xtime.Unit, ant ts.Annotation) error {
+	if enc.closed {
+		return errEncoderClosed
+	}
+
+	tv, err := tu.Value()
+	if err != nil {
+		return err
+	}
+	if len(ant) > maxAnnotationSize {
+		return errAnnotationTooLarge
+	}
+
+	var flags byte
+	if tu != enc.tu {
+		flags |= flagTimeUnit
+	}
+	if len(ant) > 0 {
+		flags |= flagAnnotation
+	}
+	intVal, isInt := asInt(dp.Value)
+	if !isInt {
+		flags |= flagFloat
+	}
+
+	enc.os.WriteByte(flags)
+	if flags&flagTimeUnit != 0 {
+		enc.os.WriteByte(byte(tu))
+		enc.tu = tu
+	}
+	if flags&flagAnnotation != 0 {
+		enc.writeUvarint(uint64(len(ant)))
+		enc.os.WriteBytes(ant)
+	}
+
+	// Times are truncated to the time unit and encoded as a delta in
+	// multiples of the time unit so that regularly spaced datapoints
+	// encode with a small constant delta.
+	units := xtime.ToNanoseconds(dp.Timestamp) / int64(tv)
+	enc.writeVarint(units - enc.t/int64(tv))
+	enc.t = units * int64(tv)
+
+	if isInt {
+		enc.writeVarint(intVal - enc.intVal)
+		enc.intVal = intVal
+	} else {
+		binary.BigEndian.PutUint64(enc.buf[:8], math.Float64bits(dp.Value))
+		enc.os.WriteBytes(enc.buf[:8])
+	}
+	enc.value = dp.Value
+	enc.numEncoded++
+	return nil
+}
+
+func (enc *encoder) writeVarint(v int64) {
+	n := binary.PutVarint(enc.buf[:], v)
+	enc.os.WriteBytes(enc.buf[:n])
+}
+
+func (enc *encoder) writeUvarint(v uint64) {
+	n := binary.PutUvarint(enc.buf[:], v)
+	enc.os.WriteBytes(enc.buf[:n])
+}
+
+func (enc *encoder) newBuffer(capacity int) checked.Bytes {
+	if bytesPool := enc.opts.BytesPool(); bytesPool != nil {
+		return bytesPool.Get(capacity)
+	}
+	return checked.NewBytes(make([]byte, 0, capacity), nil)
+}
+
+func (enc *encoder) Reset(start time.Time, capacity int) {
+	enc.os.Reset(enc.newBuffer(capacity))
+	enc.t = 0
+	enc.tu = xtime.None
+	enc.intVal = 0
+	enc.value = 0
+	enc.numEncoded = 0
+	enc.closed = false
+}
+
+func (enc *encoder) Stream() xio.SegmentReader {
+	segment := enc.segment(byCopyResultType)
+	if segment.Len() == 0 {
+		return nil
+	}
+	if readerPool := enc.opts.SegmentReaderPool(); readerPool != nil {
+		reader := readerPool.Get()
+		reader.Reset(segment)
+		return reader
+	}
+	return xio.NewSegmentReader(segment)
+}
+
+func (enc *encoder) NumEncoded() int {
+	return enc.numEncoded
+}
+
+func (enc *encoder) LastEncoded() (ts.Datapoint, error) {
+	if enc.numEncoded == 0 {
+		return ts.Datapoint{}, errNoEncodedDatapoints
+	}
+	return ts.Datapoint{
+		Timestamp: xtime.FromNanoseconds(enc.t),
+		Value:     enc.value,
+	}, nil
+}
+
+func (enc *encoder) Len() int {
+	return enc.os.Len()
+}
+
+func (enc *encoder) Close() {
+	if enc.closed {
+		return
+	}
+
+	enc.closed = true
+
+	// Ensure to free ref to ostream bytes
+	enc.os.Reset(nil)
+
+	if pool := enc.opts.EncoderPool(); pool != nil {
+		pool.Put(enc)
+	}
+}
+
+func (enc *encoder) Discard() ts.Segment {
+	segment := enc.segment(byRefResultType)
+
+	// Close the encoder no longer needed
+	enc.Close()
+
+	return segment
+}
+
+func (enc *encoder) DiscardReset(start time.Time, capacity int) ts.Segment {
+	segment := enc.segment(byRefResultType)
+	enc.Reset(start, capacity)
+	return segment
+}
+
+func (enc *encoder) segment(resType resultType) ts.Segment {
+	length := enc.os.Len()
+	if length == 0 {
+		return ts.Segment{}
+	}
+
+	// The stream is byte aligned so unlike m3tsz no tail is required to
+	// capture an immutable snapshot of the encoder data.
+	var head checked.Bytes
+	if resType == byRefResultType {
+		// Take ref from the ostream
+		head = enc.os.Discard()
+	} else {
+		// Copy into new buffer
+		buffer, _ := enc.os.Rawbytes()
+		head = enc.newBuffer(length)
+
+		head.IncRef()
+		head.AppendAll(buffer.Bytes())
+		head.DecRef()
+	}
+
+	// NB: Finalize the head bytes whether this is by ref or copy. If by
+	// ref we have no ref to it anymore and if by copy then the owner should
+	// be finalizing the bytes when the segment is finalized.
+	return ts.NewSegment(head, nil, ts.FinalizeHead)
+}
+
+type resultType int
+
+const (
+	byCopyResultType resultType = iota
+	byRefResultType
+)
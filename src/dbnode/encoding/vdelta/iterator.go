@@ -0,0 +1,205 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package vdelta
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/ts"
+	xtime "github.com/m3db/m3x/time"
+)
+
+var errInvalidAnnotationLength = errors.New("invalid annotation length")
+
+// readerIterator provides an interface for clients to incrementally
+// read datapoints off of an encoded stream.
+type readerIterator struct {
+	is   encoding.IStream
+	opts encoding.Options
+
+	// internal bookkeeping
+	t      int64         // current time in nanoseconds
+	tu     xtime.Unit    // current time unit
+	intVal int64         // current int value
+	value  float64       // current value
+	ant    ts.Annotation // current annotation
+	err    error         // current error
+
+	done   bool // has reached the end
+	closed bool
+}
+
+// NewReaderIterator returns a new iterator for a given reader.
+func NewReaderIterator(reader io.Reader, opts encoding.Options) encoding.ReaderIterator {
+	if opts == nil {
+		opts = encoding.NewOptions()
+	}
+	return &readerIterator{
+		is:   encoding.NewIStream(reader),
+		opts: opts,
+	}
+}
+
+// Next moves to the next item.
+func (it *readerIterator) Next() bool {
+	if !it.hasNext() {
+		return false
+	}
+
+	flags, err := it.is.ReadByte()
+	if err == io.EOF {
+		it.done = true
+		return false
+	}
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.readNext(flags)
+	return it.hasNext()
+}
+
+func (it *readerIterator) readNext(flags byte) {
+	if flags&flagTimeUnit != 0 {
+		tu, err := it.is.ReadByte()
+		if err != nil {
+			it.setReadErr(err)
+			return
+		}
+		it.tu = xtime.Unit(tu)
+	}
+
+	it.ant = nil
+	if flags&flagAnnotation != 0 {
+		if it.ant = it.readAnnotation(); it.err != nil {
+			return
+		}
+	}
+
+	tv, err := it.tu.Value()
+	if err != nil {
+		it.err = err
+		return
+	}
+	delta, err := binary.ReadVarint(it.is)
+	if err != nil {
+		it.setReadErr(err)
+		return
+	}
+	it.t = (it.t/int64(tv) + delta) * int64(tv)
+
+	if flags&flagFloat == 0 {
+		delta, err := binary.ReadVarint(it.is)
+		if err != nil {
+			it.setReadErr(err)
+			return
+		}
+		it.intVal += delta
+		it.value = float64(it.intVal)
+		return
+	}
+
+	var bits uint64
+	for i := 0; i < 8; i++ {
+		b, err := it.is.ReadByte()
+		if err != nil {
+			it.setReadErr(err)
+			return
+		}
+		bits = bits<<8 | uint64(b)
+	}
+	it.value = math.Float64frombits(bits)
+}
+
+func (it *readerIterator) readAnnotation() ts.Annotation {
+	length, err := binary.ReadUvarint(it.is)
+	if err != nil {
+		it.setReadErr(err)
+		return nil
+	}
+	if length > maxAnnotationSize {
+		it.err = errInvalidAnnotationLength
+		return nil
+	}
+	ant := make(ts.Annotation, length)
+	for i := range ant {
+		if ant[i], err = it.is.ReadByte(); err != nil {
+			it.setReadErr(err)
+			return nil
+		}
+	}
+	return ant
+}
+
+func (it *readerIterator) setReadErr(err error) {
+	if err == io.EOF {
+		// The stream ended part way through a datapoint
+		err = io.ErrUnexpectedEOF
+	}
+	it.err = err
+}
+
+// Current returns the value as well as the annotation associated with the
+// current datapoint. Users should not hold on to the returned Annotation
+// object as it may get invalidated when the iterator calls Next().
+func (it *readerIterator) Current() (ts.Datapoint, xtime.Unit, ts.Annotation) {
+	return ts.Datapoint{
+		Timestamp: xtime.FromNanoseconds(it.t),
+		Value:     it.value,
+	}, it.tu, it.ant
+}
+
+// Err returns the error encountered
+func (it *readerIterator) Err() error {
+	return it.err
+}
+
+func (it *readerIterator) hasNext() bool {
+	return it.err == nil && !it.done && !it.closed
+}
+
+func (it *readerIterator) Reset(reader io.Reader) {
+	it.is.Reset(reader)
+	it.t = 0
+	it.tu = xtime.None
+	it.intVal = 0
+	it.value = 0
+	it.ant = nil
+	it.err = nil
+	it.done = false
+	it.closed = false
+}
+
+func (it *readerIterator) Close() {
+	if it.closed {
+		return
+	}
+	it.closed = true
+	pool := it.opts.ReaderIteratorPool()
+	if pool != nil {
+		pool.Put(it)
+	}
+}
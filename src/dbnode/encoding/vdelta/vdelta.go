@@ -0,0 +1,93 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package vdelta implements a byte aligned encoding scheme that encodes
+// timestamps and integer values as varint deltas, it suits sparse integer
+// data such as counts of events better than m3tsz which is optimized for
+// dense regularly spaced datapoints.
+package vdelta
+
+import (
+	"io"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3x/checked"
+)
+
+// Scheme is the name of the varint delta encoding scheme.
+const Scheme = "vdelta"
+
+const (
+	// Each datapoint begins with a byte of flags describing what follows
+	// the flags, the time delta and the value.
+	flagTimeUnit   byte = 1 << 0
+	flagAnnotation byte = 1 << 1
+	flagFloat      byte = 1 << 2
+
+	// maxIntValue is the largest magnitude of a value encoded as an int, the
+	// ints beyond it are not all exactly representable as a float64.
+	maxIntValue = 1 << 53
+
+	// maxAnnotationSize is the size of the largest annotation that can be
+	// encoded, it bounds the allocation for an annotation read from a
+	// corrupt stream.
+	maxAnnotationSize = 1 << 16
+)
+
+type codec struct{}
+
+// NewCodec returns the codec for the varint delta encoding scheme.
+func NewCodec() encoding.Codec {
+	return codec{}
+}
+
+func (c codec) Scheme() string {
+	return Scheme
+}
+
+func (c codec) NewEncoder(
+	start time.Time,
+	bytes checked.Bytes,
+	opts encoding.Options,
+) encoding.Encoder {
+	return NewEncoder(start, bytes, opts)
+}
+
+func (c codec) NewReaderIterator(
+	reader io.Reader,
+	opts encoding.Options,
+) encoding.ReaderIterator {
+	return NewReaderIterator(reader, opts)
+}
+
+// asInt returns the value as an int if it is encoded as an int.
+func asInt(v float64) (int64, bool) {
+	if v != math.Trunc(v) || math.Abs(v) > maxIntValue {
+		// Also excludes NaN and infinities
+		return 0, false
+	}
+	if v == 0 && math.Signbit(v) {
+		// Negative zero does not survive a roundtrip as an int
+		return 0, false
+	}
+	return int64(v), true
+}
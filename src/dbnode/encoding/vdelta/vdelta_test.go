@@ -0,0 +1,229 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package vdelta
+
+import (
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3x/checked"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStartTime = time.Unix(1427162400, 0)
+
+type testDatapoint struct {
+	dp   ts.Datapoint
+	unit xtime.Unit
+	ant  ts.Annotation
+}
+
+func testRoundTrip(t *testing.T, input []testDatapoint) {
+	encoder := NewEncoder(testStartTime, nil, nil)
+	for _, v := range input {
+		require.NoError(t, encoder.Encode(v.dp, v.unit, v.ant))
+	}
+	require.Equal(t, len(input), encoder.NumEncoded())
+
+	last, err := encoder.LastEncoded()
+	require.NoError(t, err)
+	assert.True(t, input[len(input)-1].dp.Timestamp.Equal(last.Timestamp))
+
+	stream := encoder.Stream()
+	require.NotNil(t, stream)
+
+	iter := NewReaderIterator(stream, nil)
+	defer iter.Close()
+
+	var i int
+	for iter.Next() {
+		require.True(t, i < len(input))
+		dp, unit, ant := iter.Current()
+		expected := input[i]
+		assert.True(t, expected.dp.Timestamp.Equal(dp.Timestamp),
+			"expected %v, actual %v", expected.dp.Timestamp, dp.Timestamp)
+		if math.IsNaN(expected.dp.Value) {
+			assert.True(t, math.IsNaN(dp.Value))
+		} else {
+			assert.Equal(t, math.Float64bits(expected.dp.Value), math.Float64bits(dp.Value))
+		}
+		assert.Equal(t, expected.unit, unit)
+		assert.Equal(t, expected.ant, ant)
+		i++
+	}
+	require.NoError(t, iter.Err())
+	require.Equal(t, len(input), i)
+}
+
+func TestRoundTripSparseInts(t *testing.T) {
+	var (
+		input  []testDatapoint
+		curr   = testStartTime
+		values = []float64{0, 1, 1, 5, -3, 1 << 40, -(1 << 52), 2}
+	)
+	for i, v := range values {
+		curr = curr.Add(time.Duration(i*i+1) * time.Minute)
+		input = append(input, testDatapoint{
+			dp:   ts.Datapoint{Timestamp: curr, Value: v},
+			unit: xtime.Second,
+		})
+	}
+	testRoundTrip(t, input)
+}
+
+func TestRoundTripFloats(t *testing.T) {
+	var (
+		input  []testDatapoint
+		values = []float64{
+			0.5, math.Pi, -1.25, math.Copysign(0, -1), math.Inf(1),
+			math.Inf(-1), math.NaN(), 1 << 60, 3,
+		}
+	)
+	for i, v := range values {
+		input = append(input, testDatapoint{
+			dp: ts.Datapoint{
+				Timestamp: testStartTime.Add(time.Duration(i) * time.Second),
+				Value:     v,
+			},
+			unit: xtime.Second,
+		})
+	}
+	testRoundTrip(t, input)
+}
+
+func TestRoundTripTimeUnitsAndAnnotations(t *testing.T) {
+	input := []testDatapoint{
+		{
+			dp:   ts.Datapoint{Timestamp: testStartTime, Value: 1},
+			unit: xtime.Second,
+			ant:  ts.Annotation("first"),
+		},
+		{
+			dp:   ts.Datapoint{Timestamp: testStartTime.Add(1500 * time.Millisecond), Value: 2},
+			unit: xtime.Millisecond,
+		},
+		{
+			dp:   ts.Datapoint{Timestamp: testStartTime.Add(1500*time.Millisecond + time.Nanosecond), Value: 3},
+			unit: xtime.Nanosecond,
+			ant:  ts.Annotation("third"),
+		},
+		{
+			dp:   ts.Datapoint{Timestamp: testStartTime.Add(time.Hour), Value: 4},
+			unit: xtime.Second,
+		},
+	}
+	testRoundTrip(t, input)
+}
+
+func TestEncoderTruncatesToTimeUnit(t *testing.T) {
+	encoder := NewEncoder(testStartTime, nil, nil)
+	require.NoError(t, encoder.Encode(ts.Datapoint{
+		Timestamp: testStartTime.Add(1500 * time.Millisecond),
+		Value:     1,
+	}, xtime.Second, nil))
+
+	last, err := encoder.LastEncoded()
+	require.NoError(t, err)
+	assert.True(t, testStartTime.Add(time.Second).Equal(last.Timestamp))
+}
+
+func TestEncoderInvalidTimeUnit(t *testing.T) {
+	encoder := NewEncoder(testStartTime, nil, nil)
+	err := encoder.Encode(ts.Datapoint{Timestamp: testStartTime}, xtime.None, nil)
+	require.Error(t, err)
+	assert.Equal(t, 0, encoder.NumEncoded())
+}
+
+func TestEncoderEmpty(t *testing.T) {
+	encoder := NewEncoder(testStartTime, nil, nil)
+	assert.Nil(t, encoder.Stream())
+
+	_, err := encoder.LastEncoded()
+	assert.Equal(t, errNoEncodedDatapoints, err)
+}
+
+func TestEncoderClosed(t *testing.T) {
+	encoder := NewEncoder(testStartTime, nil, nil)
+	encoder.Close()
+	err := encoder.Encode(ts.Datapoint{Timestamp: testStartTime}, xtime.Second, nil)
+	assert.Equal(t, errEncoderClosed, err)
+}
+
+func TestEncoderDiscardReset(t *testing.T) {
+	encoder := NewEncoder(testStartTime, nil, nil)
+	require.NoError(t, encoder.Encode(ts.Datapoint{Timestamp: testStartTime, Value: 1},
+		xtime.Second, nil))
+
+	segment := encoder.DiscardReset(testStartTime, 0)
+	require.True(t, segment.Len() > 0)
+	assert.Equal(t, 0, encoder.NumEncoded())
+	assert.Equal(t, 0, encoder.Len())
+
+	iter := NewReaderIterator(xio.NewSegmentReader(segment), nil)
+	require.True(t, iter.Next())
+	dp, _, _ := iter.Current()
+	assert.True(t, testStartTime.Equal(dp.Timestamp))
+	assert.Equal(t, 1.0, dp.Value)
+	require.False(t, iter.Next())
+	require.NoError(t, iter.Err())
+}
+
+func TestReaderIteratorTruncatedStream(t *testing.T) {
+	encoder := NewEncoder(testStartTime, nil, nil)
+	require.NoError(t, encoder.Encode(ts.Datapoint{Timestamp: testStartTime, Value: 0.5},
+		xtime.Second, nil))
+
+	segment := encoder.Discard()
+	bytes := segment.Head.Bytes()
+
+	iter := NewReaderIterator(xio.NewSegmentReader(ts.Segment{
+		Head: checked.NewBytes(bytes[:len(bytes)-1], nil),
+	}), nil)
+	require.False(t, iter.Next())
+	assert.Equal(t, io.ErrUnexpectedEOF, iter.Err())
+}
+
+func TestEncoderAnnotationTooLarge(t *testing.T) {
+	encoder := NewEncoder(testStartTime, nil, nil)
+	err := encoder.Encode(ts.Datapoint{Timestamp: testStartTime}, xtime.Second,
+		make(ts.Annotation, maxAnnotationSize+1))
+	assert.Equal(t, errAnnotationTooLarge, err)
+	assert.Equal(t, 0, encoder.NumEncoded())
+}
+
+func TestReaderIteratorInvalidAnnotationLength(t *testing.T) {
+	// A corrupt annotation length must not be allocated.
+	bytes := []byte{flagTimeUnit | flagAnnotation, byte(xtime.Second)}
+	bytes = append(bytes, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f)
+
+	iter := NewReaderIterator(xio.NewSegmentReader(ts.Segment{
+		Head: checked.NewBytes(bytes, nil),
+	}), nil)
+	require.False(t, iter.Next())
+	assert.Equal(t, errInvalidAnnotationLength, iter.Err())
+}
@@ -32,6 +32,7 @@
 		IndexOptions
 		NamespaceOptions
 		Registry
+		DownsampleOptions
 */
 package namespace
 
@@ -133,14 +134,17 @@ func (m *IndexOptions) GetBlockSizeNanos() int64 {
 }
 
 type NamespaceOptions struct {
-	BootstrapEnabled  bool              `protobuf:"varint,1,opt,name=bootstrapEnabled,proto3" json:"bootstrapEnabled,omitempty"`
-	FlushEnabled      bool              `protobuf:"varint,2,opt,name=flushEnabled,proto3" json:"flushEnabled,omitempty"`
-	WritesToCommitLog bool              `protobuf:"varint,3,opt,name=writesToCommitLog,proto3" json:"writesToCommitLog,omitempty"`
-	CleanupEnabled    bool              `protobuf:"varint,4,opt,name=cleanupEnabled,proto3" json:"cleanupEnabled,omitempty"`
-	RepairEnabled     bool              `protobuf:"varint,5,opt,name=repairEnabled,proto3" json:"repairEnabled,omitempty"`
-	RetentionOptions  *RetentionOptions `protobuf:"bytes,6,opt,name=retentionOptions" json:"retentionOptions,omitempty"`
-	SnapshotEnabled   bool              `protobuf:"varint,7,opt,name=snapshotEnabled,proto3" json:"snapshotEnabled,omitempty"`
-	IndexOptions      *IndexOptions     `protobuf:"bytes,8,opt,name=indexOptions" json:"indexOptions,omitempty"`
+	BootstrapEnabled  bool               `protobuf:"varint,1,opt,name=bootstrapEnabled,proto3" json:"bootstrapEnabled,omitempty"`
+	FlushEnabled      bool               `protobuf:"varint,2,opt,name=flushEnabled,proto3" json:"flushEnabled,omitempty"`
+	WritesToCommitLog bool               `protobuf:"varint,3,opt,name=writesToCommitLog,proto3" json:"writesToCommitLog,omitempty"`
+	CleanupEnabled    bool               `protobuf:"varint,4,opt,name=cleanupEnabled,proto3" json:"cleanupEnabled,omitempty"`
+	RepairEnabled     bool               `protobuf:"varint,5,opt,name=repairEnabled,proto3" json:"repairEnabled,omitempty"`
+	RetentionOptions  *RetentionOptions  `protobuf:"bytes,6,opt,name=retentionOptions" json:"retentionOptions,omitempty"`
+	SnapshotEnabled   bool               `protobuf:"varint,7,opt,name=snapshotEnabled,proto3" json:"snapshotEnabled,omitempty"`
+	IndexOptions      *IndexOptions      `protobuf:"bytes,8,opt,name=indexOptions" json:"indexOptions,omitempty"`
+	ColdWritesEnabled bool               `protobuf:"varint,9,opt,name=coldWritesEnabled,proto3" json:"coldWritesEnabled,omitempty"`
+	DownsampleOptions *DownsampleOptions `protobuf:"bytes,10,opt,name=downsampleOptions" json:"downsampleOptions,omitempty"`
+	EncodingScheme    string             `protobuf:"bytes,11,opt,name=encodingScheme,proto3" json:"encodingScheme,omitempty"`
 }
 
 func (m *NamespaceOptions) Reset()                    { *m = NamespaceOptions{} }
@@ -204,6 +208,27 @@ func (m *NamespaceOptions) GetIndexOptions() *IndexOptions {
 	return nil
 }
 
+func (m *NamespaceOptions) GetColdWritesEnabled() bool {
+	if m != nil {
+		return m.ColdWritesEnabled
+	}
+	return false
+}
+
+func (m *NamespaceOptions) GetDownsampleOptions() *DownsampleOptions {
+	if m != nil {
+		return m.DownsampleOptions
+	}
+	return nil
+}
+
+func (m *NamespaceOptions) GetEncodingScheme() string {
+	if m != nil {
+		return m.EncodingScheme
+	}
+	return ""
+}
+
 type Registry struct {
 	Namespaces map[string]*NamespaceOptions `protobuf:"bytes,1,rep,name=namespaces" json:"namespaces,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
 }
@@ -220,11 +245,60 @@ func (m *Registry) GetNamespaces() map[string]*NamespaceOptions {
 	return nil
 }
 
+type DownsampleOptions struct {
+	Enabled         bool   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	AfterNanos      int64  `protobuf:"varint,2,opt,name=afterNanos,proto3" json:"afterNanos,omitempty"`
+	ResolutionNanos int64  `protobuf:"varint,3,opt,name=resolutionNanos,proto3" json:"resolutionNanos,omitempty"`
+	Aggregation     string `protobuf:"bytes,4,opt,name=aggregation,proto3" json:"aggregation,omitempty"`
+	TargetNamespace string `protobuf:"bytes,5,opt,name=targetNamespace,proto3" json:"targetNamespace,omitempty"`
+}
+
+func (m *DownsampleOptions) Reset()                    { *m = DownsampleOptions{} }
+func (m *DownsampleOptions) String() string            { return proto.CompactTextString(m) }
+func (*DownsampleOptions) ProtoMessage()               {}
+func (*DownsampleOptions) Descriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{4} }
+
+func (m *DownsampleOptions) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *DownsampleOptions) GetAfterNanos() int64 {
+	if m != nil {
+		return m.AfterNanos
+	}
+	return 0
+}
+
+func (m *DownsampleOptions) GetResolutionNanos() int64 {
+	if m != nil {
+		return m.ResolutionNanos
+	}
+	return 0
+}
+
+func (m *DownsampleOptions) GetAggregation() string {
+	if m != nil {
+		return m.Aggregation
+	}
+	return ""
+}
+
+func (m *DownsampleOptions) GetTargetNamespace() string {
+	if m != nil {
+		return m.TargetNamespace
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*RetentionOptions)(nil), "namespace.RetentionOptions")
 	proto.RegisterType((*IndexOptions)(nil), "namespace.IndexOptions")
 	proto.RegisterType((*NamespaceOptions)(nil), "namespace.NamespaceOptions")
 	proto.RegisterType((*Registry)(nil), "namespace.Registry")
+	proto.RegisterType((*DownsampleOptions)(nil), "namespace.DownsampleOptions")
 }
 func (m *RetentionOptions) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -407,6 +481,32 @@ func (m *NamespaceOptions) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n2
 	}
+	if m.ColdWritesEnabled {
+		dAtA[i] = 0x48
+		i++
+		if m.ColdWritesEnabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.DownsampleOptions != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.DownsampleOptions.Size()))
+		n3, err := m.DownsampleOptions.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if len(m.EncodingScheme) > 0 {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(len(m.EncodingScheme)))
+		i += copy(dAtA[i:], m.EncodingScheme)
+	}
 	return i, nil
 }
 
@@ -456,6 +556,56 @@ func (m *Registry) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *DownsampleOptions) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DownsampleOptions) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Enabled {
+		dAtA[i] = 0x8
+		i++
+		if m.Enabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.AfterNanos != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.AfterNanos))
+	}
+	if m.ResolutionNanos != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.ResolutionNanos))
+	}
+	if len(m.Aggregation) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(len(m.Aggregation)))
+		i += copy(dAtA[i:], m.Aggregation)
+	}
+	if len(m.TargetNamespace) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(len(m.TargetNamespace)))
+		i += copy(dAtA[i:], m.TargetNamespace)
+	}
+	return i, nil
+}
+
 func encodeVarintNamespace(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -530,6 +680,17 @@ func (m *NamespaceOptions) Size() (n int) {
 		l = m.IndexOptions.Size()
 		n += 1 + l + sovNamespace(uint64(l))
 	}
+	if m.ColdWritesEnabled {
+		n += 2
+	}
+	if m.DownsampleOptions != nil {
+		l = m.DownsampleOptions.Size()
+		n += 1 + l + sovNamespace(uint64(l))
+	}
+	l = len(m.EncodingScheme)
+	if l > 0 {
+		n += 1 + l + sovNamespace(uint64(l))
+	}
 	return n
 }
 
@@ -552,6 +713,29 @@ func (m *Registry) Size() (n int) {
 	return n
 }
 
+func (m *DownsampleOptions) Size() (n int) {
+	var l int
+	_ = l
+	if m.Enabled {
+		n += 2
+	}
+	if m.AfterNanos != 0 {
+		n += 1 + sovNamespace(uint64(m.AfterNanos))
+	}
+	if m.ResolutionNanos != 0 {
+		n += 1 + sovNamespace(uint64(m.ResolutionNanos))
+	}
+	l = len(m.Aggregation)
+	if l > 0 {
+		n += 1 + l + sovNamespace(uint64(l))
+	}
+	l = len(m.TargetNamespace)
+	if l > 0 {
+		n += 1 + l + sovNamespace(uint64(l))
+	}
+	return n
+}
+
 func sovNamespace(x uint64) (n int) {
 	for {
 		n++
@@ -1034,6 +1218,88 @@ func (m *NamespaceOptions) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColdWritesEnabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ColdWritesEnabled = bool(v != 0)
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DownsampleOptions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DownsampleOptions == nil {
+				m.DownsampleOptions = &DownsampleOptions{}
+			}
+			if err := m.DownsampleOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncodingScheme", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncodingScheme = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNamespace(dAtA[iNdEx:])
@@ -1228,6 +1494,172 @@ func (m *Registry) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *DownsampleOptions) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNamespace
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DownsampleOptions: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DownsampleOptions: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Enabled = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AfterNanos", wireType)
+			}
+			m.AfterNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AfterNanos |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResolutionNanos", wireType)
+			}
+			m.ResolutionNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResolutionNanos |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Aggregation", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Aggregation = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetNamespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TargetNamespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNamespace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipNamespace(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1338,38 +1770,44 @@ func init() {
 }
 
 var fileDescriptorNamespace = []byte{
-	// 513 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xdf, 0x6e, 0xd3, 0x30,
-	0x14, 0xc6, 0x49, 0xbb, 0x3f, 0xdd, 0x59, 0x61, 0xc1, 0x42, 0xa2, 0x02, 0xa9, 0x9a, 0x0a, 0x42,
-	0x15, 0x42, 0x8d, 0x68, 0x6f, 0x10, 0x5c, 0x8d, 0x51, 0x26, 0x24, 0x54, 0x2a, 0xc3, 0xd5, 0xee,
-	0x9c, 0xe4, 0xb4, 0xb5, 0xd6, 0xd8, 0x91, 0xed, 0xc0, 0xca, 0x43, 0x20, 0xde, 0x83, 0x17, 0xe1,
-	0x82, 0x0b, 0x1e, 0x01, 0x95, 0x17, 0x41, 0x71, 0x48, 0x97, 0xb8, 0xbb, 0xd8, 0x4d, 0xe5, 0x7e,
-	0xe7, 0x67, 0x1f, 0xe7, 0x7c, 0x5f, 0x02, 0x67, 0x73, 0x6e, 0x16, 0x59, 0x38, 0x88, 0x64, 0x12,
-	0x24, 0xa3, 0x38, 0x0c, 0x92, 0x51, 0xa0, 0x55, 0x14, 0xc4, 0xa1, 0x90, 0x31, 0x06, 0x73, 0x14,
-	0xa8, 0x98, 0xc1, 0x38, 0x48, 0x95, 0x34, 0x32, 0x10, 0x2c, 0x41, 0x9d, 0xb2, 0x08, 0xaf, 0x56,
-	0x03, 0x5b, 0x21, 0x07, 0x1b, 0xa1, 0xf7, 0xab, 0x01, 0x3e, 0x45, 0x83, 0xc2, 0x70, 0x29, 0x3e,
-	0xa4, 0xf9, 0xaf, 0x26, 0x43, 0xb8, 0xa7, 0x4a, 0x6d, 0x8a, 0x8a, 0xcb, 0x78, 0xc2, 0x84, 0xd4,
-	0x1d, 0xef, 0xd8, 0xeb, 0x37, 0xe9, 0xb5, 0x35, 0xf2, 0x04, 0xee, 0x84, 0x4b, 0x19, 0x5d, 0x7c,
-	0xe4, 0x5f, 0xb1, 0xa0, 0x1b, 0x96, 0x76, 0x54, 0xf2, 0x0c, 0xee, 0x86, 0xd9, 0x6c, 0x86, 0xea,
-	0x6d, 0x66, 0x32, 0xf5, 0x1f, 0x6d, 0x5a, 0x74, 0xbb, 0x40, 0xfa, 0x70, 0x54, 0x88, 0x53, 0xa6,
-	0x4d, 0xc1, 0xee, 0x58, 0xd6, 0x95, 0x2d, 0x99, 0x77, 0x7a, 0xc3, 0x0c, 0x1b, 0x5f, 0xa6, 0x5c,
-	0xad, 0x3a, 0xbb, 0xc7, 0x5e, 0xbf, 0x45, 0x5d, 0x99, 0x9c, 0x43, 0xdf, 0x91, 0x4e, 0x66, 0x06,
-	0xd5, 0x44, 0x9a, 0x93, 0x28, 0x42, 0xad, 0xab, 0x4f, 0xbc, 0x67, 0x9b, 0xdd, 0x98, 0xef, 0x4d,
-	0xa1, 0xfd, 0x4e, 0xc4, 0x78, 0x59, 0x4e, 0xb2, 0x03, 0xfb, 0x28, 0x58, 0xb8, 0xc4, 0xd8, 0x0e,
-	0xaf, 0x45, 0xcb, 0xbf, 0x37, 0x9d, 0x57, 0xef, 0x5b, 0x13, 0xfc, 0x49, 0x69, 0x57, 0x79, 0xec,
-	0x53, 0xf0, 0x43, 0x29, 0x8d, 0x36, 0x8a, 0xa5, 0xe3, 0xda, 0xf9, 0x5b, 0x3a, 0xe9, 0x41, 0x7b,
-	0xb6, 0xcc, 0xf4, 0xa2, 0xe4, 0x1a, 0x96, 0xab, 0x69, 0xb9, 0x29, 0x5f, 0x14, 0x37, 0xa8, 0x3f,
-	0xc9, 0x53, 0x99, 0x24, 0xdc, 0xbc, 0x97, 0x73, 0x6b, 0x4a, 0x8b, 0x6e, 0x17, 0xf2, 0xab, 0x47,
-	0x4b, 0x64, 0x22, 0xdb, 0xf4, 0xde, 0xb1, 0xa8, 0xa3, 0x92, 0xc7, 0x70, 0x5b, 0x61, 0xca, 0xb8,
-	0x2a, 0xb1, 0xc2, 0x90, 0xba, 0x48, 0xce, 0xc0, 0x57, 0x4e, 0x00, 0xed, 0xd8, 0x0f, 0x87, 0x0f,
-	0x07, 0x57, 0xc1, 0x75, 0x33, 0x4a, 0xb7, 0x36, 0xe5, 0x09, 0xd0, 0x82, 0xa5, 0x7a, 0x21, 0x4d,
-	0xd9, 0x70, 0xbf, 0x48, 0x80, 0x23, 0x93, 0x57, 0xd0, 0xe6, 0x15, 0x97, 0x3a, 0x2d, 0xdb, 0xee,
-	0x7e, 0xa5, 0x5d, 0xd5, 0x44, 0x5a, 0x83, 0x7b, 0x3f, 0x3c, 0x68, 0x51, 0x9c, 0x73, 0x6d, 0xd4,
-	0x8a, 0x9c, 0x02, 0x6c, 0x36, 0xe5, 0xef, 0x47, 0xb3, 0x7f, 0x38, 0x7c, 0x54, 0xbb, 0x76, 0x01,
-	0x0e, 0x36, 0x16, 0xea, 0xb1, 0x30, 0x6a, 0x45, 0x2b, 0xdb, 0x1e, 0x9c, 0xc3, 0x91, 0x53, 0x26,
-	0x3e, 0x34, 0x2f, 0x70, 0x65, 0x3d, 0x3d, 0xa0, 0xf9, 0x92, 0x3c, 0x87, 0xdd, 0xcf, 0x6c, 0x99,
-	0xa1, 0xf5, 0xaf, 0x3e, 0x1b, 0x37, 0x1e, 0xb4, 0x20, 0x5f, 0x36, 0x5e, 0x78, 0xaf, 0xfd, 0x9f,
-	0xeb, 0xae, 0xf7, 0x7b, 0xdd, 0xf5, 0xfe, 0xac, 0xbb, 0xde, 0xf7, 0xbf, 0xdd, 0x5b, 0xe1, 0x9e,
-	0xfd, 0x06, 0x8c, 0xfe, 0x05, 0x00, 0x00, 0xff, 0xff, 0x30, 0x42, 0xc6, 0x09, 0x4e, 0x04, 0x00,
-	0x00,
+	// 624 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x03, 0x8d, 0x54, 0xcb, 0x6e, 0xd4, 0x30,
+	0x14, 0x65, 0x1e, 0x6d, 0x67, 0xee, 0x14, 0x3a, 0xb5, 0x90, 0x18, 0x01, 0xaa, 0xaa, 0x01, 0xa1,
+	0x51, 0x85, 0x66, 0x44, 0xbb, 0x41, 0xb0, 0x2a, 0x6d, 0xa9, 0x40, 0xa8, 0x54, 0x2e, 0x12, 0x52,
+	0x77, 0x4e, 0x72, 0x27, 0x13, 0x35, 0xb1, 0x23, 0xdb, 0xa1, 0x2d, 0x5f, 0xc1, 0x7f, 0xb0, 0xe1,
+	0x1f, 0xd8, 0xb0, 0x60, 0xc1, 0x27, 0x20, 0xf8, 0x11, 0x62, 0x87, 0x4c, 0x33, 0x0e, 0x42, 0x5d,
+	0x24, 0x4a, 0xce, 0x3d, 0xbe, 0x27, 0x3e, 0xf7, 0x38, 0x70, 0x18, 0x46, 0x7a, 0x96, 0x79, 0x63,
+	0x5f, 0x24, 0x93, 0x64, 0x27, 0xf0, 0xf2, 0xdb, 0x44, 0x49, 0x7f, 0x12, 0x78, 0x5c, 0x04, 0x38,
+	0x09, 0x91, 0xa3, 0x64, 0x1a, 0x83, 0x49, 0x2a, 0x85, 0x16, 0x13, 0xce, 0x12, 0x54, 0x29, 0xf3,
+	0xf1, 0xea, 0x69, 0x6c, 0x2b, 0xa4, 0x3b, 0x07, 0x86, 0xdf, 0x9b, 0xd0, 0xa7, 0xa8, 0x91, 0xeb,
+	0x48, 0xf0, 0xb7, 0xa9, 0xb9, 0x2b, 0xb2, 0x0d, 0xb7, 0x65, 0x89, 0x1d, 0xa3, 0x8c, 0x44, 0x70,
+	0xc4, 0xb8, 0x50, 0x83, 0xc6, 0x66, 0x63, 0xd4, 0xa2, 0xff, 0xac, 0x91, 0x47, 0x70, 0xcb, 0x8b,
+	0x85, 0x7f, 0x76, 0x12, 0x7d, 0xc4, 0x82, 0xdd, 0xb4, 0x6c, 0x07, 0x25, 0x8f, 0x61, 0xdd, 0xcb,
+	0xa6, 0x53, 0x94, 0x2f, 0x33, 0x9d, 0xc9, 0xbf, 0xd4, 0x96, 0xa5, 0xd6, 0x0b, 0x64, 0x04, 0x6b,
+	0x05, 0x78, 0xcc, 0x94, 0x2e, 0xb8, 0x6d, 0xcb, 0x75, 0x61, 0xcb, 0x34, 0x4a, 0xfb, 0x4c, 0xb3,
+	0x83, 0x8b, 0x34, 0x92, 0x97, 0x83, 0xa5, 0x9c, 0xd9, 0xa1, 0x2e, 0x4c, 0x4e, 0x61, 0xe4, 0x40,
+	0xbb, 0x53, 0x8d, 0xf2, 0x48, 0xe8, 0x5d, 0xdf, 0x47, 0xa5, 0xaa, 0x3b, 0x5e, 0xb6, 0x62, 0xd7,
+	0xe6, 0x0f, 0x8f, 0x61, 0xf5, 0x15, 0x0f, 0xf0, 0xa2, 0x74, 0x72, 0x00, 0x2b, 0xc8, 0x99, 0x17,
+	0x63, 0x60, 0xcd, 0xeb, 0xd0, 0xf2, 0xf5, 0xba, 0x7e, 0x0d, 0xbf, 0xb4, 0xa1, 0x7f, 0x54, 0x8e,
+	0xab, 0x6c, 0xbb, 0x05, 0x7d, 0x4f, 0x08, 0xad, 0xb4, 0x64, 0xe9, 0xc1, 0x42, 0xff, 0x1a, 0x4e,
+	0x86, 0xb0, 0x3a, 0x8d, 0x33, 0x35, 0x2b, 0x79, 0x4d, 0xcb, 0x5b, 0xc0, 0xcc, 0x50, 0xce, 0x65,
+	0xa4, 0x51, 0xbd, 0x13, 0x7b, 0x22, 0x49, 0x22, 0xfd, 0x46, 0x84, 0x76, 0x28, 0x1d, 0x5a, 0x2f,
+	0x98, 0x4f, 0xf7, 0x63, 0x64, 0x3c, 0x9b, 0x6b, 0xb7, 0x2d, 0xd5, 0x41, 0xc9, 0x43, 0xb8, 0x29,
+	0x31, 0x65, 0x91, 0x2c, 0x69, 0xc5, 0x40, 0x16, 0x41, 0x72, 0x08, 0x7d, 0xe9, 0x04, 0xd0, 0xda,
+	0xde, 0xdb, 0xbe, 0x37, 0xbe, 0x0a, 0xae, 0x9b, 0x51, 0x5a, 0x5b, 0x64, 0x12, 0xa0, 0x38, 0x4b,
+	0xd5, 0x4c, 0xe8, 0x52, 0x70, 0xa5, 0x48, 0x80, 0x03, 0x93, 0xe7, 0xb0, 0x1a, 0x55, 0xa6, 0x34,
+	0xe8, 0x58, 0xb9, 0x3b, 0x15, 0xb9, 0xea, 0x10, 0xe9, 0x02, 0xd9, 0x78, 0xe5, 0x8b, 0x38, 0x78,
+	0x6f, 0x6d, 0x29, 0x85, 0xba, 0x85, 0x57, 0xb5, 0x02, 0x79, 0x0d, 0xeb, 0x81, 0x38, 0xe7, 0x8a,
+	0x25, 0x69, 0x5c, 0x8e, 0x6f, 0x00, 0x56, 0xef, 0x7e, 0x45, 0x6f, 0xdf, 0xe5, 0xd0, 0xfa, 0x32,
+	0xe3, 0x3b, 0x72, 0x5f, 0x04, 0x11, 0x0f, 0x4f, 0xfc, 0x19, 0x26, 0x38, 0xe8, 0xe5, 0x8d, 0xba,
+	0xd4, 0x41, 0x87, 0x9f, 0x1b, 0xd0, 0xa1, 0x18, 0x46, 0x79, 0x0c, 0x2e, 0xc9, 0x1e, 0xc0, 0x5c,
+	0xc6, 0x9c, 0xe0, 0x56, 0xae, 0xfc, 0x60, 0xc1, 0xd8, 0x82, 0x38, 0x9e, 0x87, 0x2c, 0xff, 0xf6,
+	0xfc, 0x9d, 0x56, 0x96, 0xdd, 0x3d, 0x85, 0x35, 0xa7, 0x4c, 0xfa, 0xd0, 0x3a, 0xc3, 0x4b, 0x9b,
+	0xba, 0x2e, 0x35, 0x8f, 0xe4, 0x09, 0x2c, 0x7d, 0x60, 0x71, 0x86, 0x36, 0x61, 0x8b, 0xd3, 0x73,
+	0x03, 0x4c, 0x0b, 0xe6, 0xb3, 0xe6, 0xd3, 0xc6, 0xf0, 0x6b, 0x03, 0xd6, 0x6b, 0xdb, 0xff, 0xcf,
+	0xc1, 0xd9, 0x00, 0x60, 0xf6, 0xfc, 0x55, 0x0e, 0x4d, 0x05, 0x31, 0x31, 0x90, 0xa8, 0x44, 0x9c,
+	0x99, 0x46, 0xd5, 0xdf, 0x8b, 0x0b, 0x93, 0x4d, 0xe8, 0xb1, 0x30, 0x94, 0x18, 0x32, 0x83, 0xd9,
+	0x10, 0x77, 0x69, 0x15, 0x32, 0xbd, 0x34, 0x93, 0x21, 0xea, 0xf9, 0x06, 0x6c, 0x86, 0xbb, 0xd4,
+	0x85, 0x5f, 0xf4, 0xbf, 0xfd, 0xda, 0x68, 0xfc, 0xc8, 0xaf, 0x9f, 0xf9, 0xf5, 0xe9, 0xf7, 0xc6,
+	0x0d, 0x6f, 0xd9, 0xfe, 0x6b, 0x77, 0xfe, 0x00, 0x1d, 0x78, 0x0e, 0xe3, 0xb6, 0x05, 0x00, 0x00,
 }
@@ -29,7 +29,8 @@ enum TimeType {
 
 enum ErrorType {
 	INTERNAL_ERROR,
-	BAD_REQUEST
+	BAD_REQUEST,
+	RESOURCE_EXHAUSTED
 }
 
 exception Error {
@@ -60,6 +61,8 @@ service Node {
 	void writeTaggedBatchRaw(1: WriteTaggedBatchRawRequest req) throws (1: WriteBatchRawErrors err)
 	void repair() throws (1: Error err)
 	TruncateResult truncate(1: TruncateRequest req) throws (1: Error err)
+	DeleteTaggedResult deleteTagged(1: DeleteTaggedRequest req) throws (1: Error err)
+	AggregateQueryResult aggregate(1: AggregateQueryRequest req) throws (1: Error err)
 
 	// Management endpoints
 	NodeHealthResult health() throws (1: Error err)
@@ -122,6 +125,7 @@ struct FetchBatchRawResult {
 struct FetchRawResult {
 	1: required list<Segments> segments
 	2: optional Error err
+	3: optional string encodingScheme
 }
 
 struct Segments {
@@ -157,6 +161,7 @@ struct FetchTaggedIDResult {
 	3: required binary encodedTags
 	4: optional list<Segments> segments
 	5: optional Error err
+	6: optional string encodingScheme
 }
 
 struct FetchBlocksRawRequest {
@@ -288,6 +293,40 @@ struct TruncateResult {
 	1: required i64 numSeries
 }
 
+struct DeleteTaggedRequest {
+	1: required binary nameSpace
+	2: required binary query
+	3: optional i64 rangeStart
+	4: optional i64 rangeEnd
+	5: optional i64 limit
+}
+
+struct DeleteTaggedResult {
+	1: required i64 numSeries
+	2: optional map<i32, i64> shardNumSeries
+}
+
+struct AggregateQueryRequest {
+	1: required binary nameSpace
+	2: required binary query
+	3: required i64 rangeStart
+	4: required i64 rangeEnd
+	5: required bool nameOnly
+	6: optional i64 limit
+	7: optional TimeType rangeTimeType = TimeType.UNIX_SECONDS
+	8: optional list<binary> tagNameFilter
+}
+
+struct AggregateQueryResult {
+	1: required list<AggregateQueryResultTagNameElement> results
+	2: required bool exhaustive
+}
+
+struct AggregateQueryResultTagNameElement {
+	1: required binary tagName
+	2: required list<binary> tagValues
+}
+
 struct NodeHealthResult {
 	1: required bool ok
 	2: required string status
@@ -107,8 +107,9 @@ func (p *TimeType) Value() (driver.Value, error) {
 type ErrorType int64
 
 const (
-	ErrorType_INTERNAL_ERROR ErrorType = 0
-	ErrorType_BAD_REQUEST    ErrorType = 1
+	ErrorType_INTERNAL_ERROR     ErrorType = 0
+	ErrorType_BAD_REQUEST        ErrorType = 1
+	ErrorType_RESOURCE_EXHAUSTED ErrorType = 2
 )
 
 func (p ErrorType) String() string {
@@ -117,6 +118,8 @@ func (p ErrorType) String() string {
 		return "INTERNAL_ERROR"
 	case ErrorType_BAD_REQUEST:
 		return "BAD_REQUEST"
+	case ErrorType_RESOURCE_EXHAUSTED:
+		return "RESOURCE_EXHAUSTED"
 	}
 	return "<UNSET>"
 }
@@ -127,6 +130,8 @@ func ErrorTypeFromString(s string) (ErrorType, error) {
 		return ErrorType_INTERNAL_ERROR, nil
 	case "BAD_REQUEST":
 		return ErrorType_BAD_REQUEST, nil
+	case "RESOURCE_EXHAUSTED":
+		return ErrorType_RESOURCE_EXHAUSTED, nil
 	}
 	return ErrorType(0), fmt.Errorf("not a valid ErrorType string")
 }
@@ -1933,9 +1938,11 @@ func (p *FetchBatchRawResult_) String() string {
 // Attributes:
 //  - Segments
 //  - Err
+//  - EncodingScheme
 type FetchRawResult_ struct {
-	Segments []*Segments `thrift:"segments,1,required" db:"segments" json:"segments"`
-	Err      *Error      `thrift:"err,2" db:"err" json:"err,omitempty"`
+	Segments       []*Segments `thrift:"segments,1,required" db:"segments" json:"segments"`
+	Err            *Error      `thrift:"err,2" db:"err" json:"err,omitempty"`
+	EncodingScheme *string     `thrift:"encodingScheme,3" db:"encodingScheme" json:"encodingScheme,omitempty"`
 }
 
 func NewFetchRawResult_() *FetchRawResult_ {
@@ -1954,10 +1961,23 @@ func (p *FetchRawResult_) GetErr() *Error {
 	}
 	return p.Err
 }
+
+var FetchRawResult__EncodingScheme_DEFAULT string
+
+func (p *FetchRawResult_) GetEncodingScheme() string {
+	if !p.IsSetEncodingScheme() {
+		return FetchRawResult__EncodingScheme_DEFAULT
+	}
+	return *p.EncodingScheme
+}
 func (p *FetchRawResult_) IsSetErr() bool {
 	return p.Err != nil
 }
 
+func (p *FetchRawResult_) IsSetEncodingScheme() bool {
+	return p.EncodingScheme != nil
+}
+
 func (p *FetchRawResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -1983,6 +2003,10 @@ func (p *FetchRawResult_) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField2(iprot); err != nil {
 				return err
 			}
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -2031,6 +2055,15 @@ func (p *FetchRawResult_) ReadField2(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *FetchRawResult_) ReadField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.EncodingScheme = &v
+	}
+	return nil
+}
+
 func (p *FetchRawResult_) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("FetchRawResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -2042,6 +2075,9 @@ func (p *FetchRawResult_) Write(oprot thrift.TProtocol) error {
 		if err := p.writeField2(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField3(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -2088,6 +2124,21 @@ func (p *FetchRawResult_) writeField2(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *FetchRawResult_) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetEncodingScheme() {
+		if err := oprot.WriteFieldBegin("encodingScheme", thrift.STRING, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:encodingScheme: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.EncodingScheme)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.encodingScheme (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:encodingScheme: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *FetchRawResult_) String() string {
 	if p == nil {
 		return "<nil>"
@@ -3006,12 +3057,14 @@ func (p *FetchTaggedResult_) String() string {
 //  - EncodedTags
 //  - Segments
 //  - Err
+//  - EncodingScheme
 type FetchTaggedIDResult_ struct {
-	ID          []byte      `thrift:"id,1,required" db:"id" json:"id"`
-	NameSpace   []byte      `thrift:"nameSpace,2,required" db:"nameSpace" json:"nameSpace"`
-	EncodedTags []byte      `thrift:"encodedTags,3,required" db:"encodedTags" json:"encodedTags"`
-	Segments    []*Segments `thrift:"segments,4" db:"segments" json:"segments,omitempty"`
-	Err         *Error      `thrift:"err,5" db:"err" json:"err,omitempty"`
+	ID             []byte      `thrift:"id,1,required" db:"id" json:"id"`
+	NameSpace      []byte      `thrift:"nameSpace,2,required" db:"nameSpace" json:"nameSpace"`
+	EncodedTags    []byte      `thrift:"encodedTags,3,required" db:"encodedTags" json:"encodedTags"`
+	Segments       []*Segments `thrift:"segments,4" db:"segments" json:"segments,omitempty"`
+	Err            *Error      `thrift:"err,5" db:"err" json:"err,omitempty"`
+	EncodingScheme *string     `thrift:"encodingScheme,6" db:"encodingScheme" json:"encodingScheme,omitempty"`
 }
 
 func NewFetchTaggedIDResult_() *FetchTaggedIDResult_ {
@@ -3044,6 +3097,15 @@ func (p *FetchTaggedIDResult_) GetErr() *Error {
 	}
 	return p.Err
 }
+
+var FetchTaggedIDResult__EncodingScheme_DEFAULT string
+
+func (p *FetchTaggedIDResult_) GetEncodingScheme() string {
+	if !p.IsSetEncodingScheme() {
+		return FetchTaggedIDResult__EncodingScheme_DEFAULT
+	}
+	return *p.EncodingScheme
+}
 func (p *FetchTaggedIDResult_) IsSetSegments() bool {
 	return p.Segments != nil
 }
@@ -3052,6 +3114,10 @@ func (p *FetchTaggedIDResult_) IsSetErr() bool {
 	return p.Err != nil
 }
 
+func (p *FetchTaggedIDResult_) IsSetEncodingScheme() bool {
+	return p.EncodingScheme != nil
+}
+
 func (p *FetchTaggedIDResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -3093,6 +3159,10 @@ func (p *FetchTaggedIDResult_) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField5(iprot); err != nil {
 				return err
 			}
+		case 6:
+			if err := p.ReadField6(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -3174,6 +3244,15 @@ func (p *FetchTaggedIDResult_) ReadField5(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *FetchTaggedIDResult_) ReadField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.EncodingScheme = &v
+	}
+	return nil
+}
+
 func (p *FetchTaggedIDResult_) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("FetchTaggedIDResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -3194,6 +3273,9 @@ func (p *FetchTaggedIDResult_) Write(oprot thrift.TProtocol) error {
 		if err := p.writeField5(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField6(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -3281,6 +3363,21 @@ func (p *FetchTaggedIDResult_) writeField5(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *FetchTaggedIDResult_) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetEncodingScheme() {
+		if err := oprot.WriteFieldBegin("encodingScheme", thrift.STRING, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:encodingScheme: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.EncodingScheme)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.encodingScheme (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:encodingScheme: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *FetchTaggedIDResult_) String() string {
 	if p == nil {
 		return "<nil>"
@@ -7413,38 +7510,76 @@ func (p *TruncateResult_) String() string {
 }
 
 // Attributes:
-//  - Ok
-//  - Status
-//  - Bootstrapped
-type NodeHealthResult_ struct {
-	Ok           bool   `thrift:"ok,1,required" db:"ok" json:"ok"`
-	Status       string `thrift:"status,2,required" db:"status" json:"status"`
-	Bootstrapped bool   `thrift:"bootstrapped,3,required" db:"bootstrapped" json:"bootstrapped"`
+//  - NameSpace
+//  - Query
+//  - RangeStart
+//  - RangeEnd
+//  - Limit
+type DeleteTaggedRequest struct {
+	NameSpace  []byte `thrift:"nameSpace,1,required" db:"nameSpace" json:"nameSpace"`
+	Query      []byte `thrift:"query,2,required" db:"query" json:"query"`
+	RangeStart *int64 `thrift:"rangeStart,3" db:"rangeStart" json:"rangeStart,omitempty"`
+	RangeEnd   *int64 `thrift:"rangeEnd,4" db:"rangeEnd" json:"rangeEnd,omitempty"`
+	Limit      *int64 `thrift:"limit,5" db:"limit" json:"limit,omitempty"`
 }
 
-func NewNodeHealthResult_() *NodeHealthResult_ {
-	return &NodeHealthResult_{}
+func NewDeleteTaggedRequest() *DeleteTaggedRequest {
+	return &DeleteTaggedRequest{}
 }
 
-func (p *NodeHealthResult_) GetOk() bool {
-	return p.Ok
+func (p *DeleteTaggedRequest) GetNameSpace() []byte {
+	return p.NameSpace
 }
 
-func (p *NodeHealthResult_) GetStatus() string {
-	return p.Status
+func (p *DeleteTaggedRequest) GetQuery() []byte {
+	return p.Query
 }
 
-func (p *NodeHealthResult_) GetBootstrapped() bool {
-	return p.Bootstrapped
+var DeleteTaggedRequest_RangeStart_DEFAULT int64
+
+func (p *DeleteTaggedRequest) GetRangeStart() int64 {
+	if !p.IsSetRangeStart() {
+		return DeleteTaggedRequest_RangeStart_DEFAULT
+	}
+	return *p.RangeStart
 }
-func (p *NodeHealthResult_) Read(iprot thrift.TProtocol) error {
+
+var DeleteTaggedRequest_RangeEnd_DEFAULT int64
+
+func (p *DeleteTaggedRequest) GetRangeEnd() int64 {
+	if !p.IsSetRangeEnd() {
+		return DeleteTaggedRequest_RangeEnd_DEFAULT
+	}
+	return *p.RangeEnd
+}
+
+var DeleteTaggedRequest_Limit_DEFAULT int64
+
+func (p *DeleteTaggedRequest) GetLimit() int64 {
+	if !p.IsSetLimit() {
+		return DeleteTaggedRequest_Limit_DEFAULT
+	}
+	return *p.Limit
+}
+func (p *DeleteTaggedRequest) IsSetRangeStart() bool {
+	return p.RangeStart != nil
+}
+
+func (p *DeleteTaggedRequest) IsSetRangeEnd() bool {
+	return p.RangeEnd != nil
+}
+
+func (p *DeleteTaggedRequest) IsSetLimit() bool {
+	return p.Limit != nil
+}
+
+func (p *DeleteTaggedRequest) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetOk bool = false
-	var issetStatus bool = false
-	var issetBootstrapped bool = false
+	var issetNameSpace bool = false
+	var issetQuery bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -7459,17 +7594,24 @@ func (p *NodeHealthResult_) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetOk = true
+			issetNameSpace = true
 		case 2:
 			if err := p.ReadField2(iprot); err != nil {
 				return err
 			}
-			issetStatus = true
+			issetQuery = true
 		case 3:
 			if err := p.ReadField3(iprot); err != nil {
 				return err
 			}
-			issetBootstrapped = true
+		case 4:
+			if err := p.ReadField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.ReadField5(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -7482,47 +7624,62 @@ func (p *NodeHealthResult_) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetOk {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Ok is not set"))
-	}
-	if !issetStatus {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Status is not set"))
+	if !issetNameSpace {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field NameSpace is not set"))
 	}
-	if !issetBootstrapped {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Bootstrapped is not set"))
+	if !issetQuery {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Query is not set"))
 	}
 	return nil
 }
 
-func (p *NodeHealthResult_) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBool(); err != nil {
+func (p *DeleteTaggedRequest) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.Ok = v
+		p.NameSpace = v
 	}
 	return nil
 }
 
-func (p *NodeHealthResult_) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
+func (p *DeleteTaggedRequest) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		p.Status = v
+		p.Query = v
 	}
 	return nil
 }
 
-func (p *NodeHealthResult_) ReadField3(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBool(); err != nil {
+func (p *DeleteTaggedRequest) ReadField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
 		return thrift.PrependError("error reading field 3: ", err)
 	} else {
-		p.Bootstrapped = v
+		p.RangeStart = &v
 	}
 	return nil
 }
 
-func (p *NodeHealthResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NodeHealthResult"); err != nil {
+func (p *DeleteTaggedRequest) ReadField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.RangeEnd = &v
+	}
+	return nil
+}
+
+func (p *DeleteTaggedRequest) ReadField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Limit = &v
+	}
+	return nil
+}
+
+func (p *DeleteTaggedRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DeleteTaggedRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -7535,6 +7692,12 @@ func (p *NodeHealthResult_) Write(oprot thrift.TProtocol) error {
 		if err := p.writeField3(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField4(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField5(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -7545,64 +7708,116 @@ func (p *NodeHealthResult_) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeHealthResult_) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("ok", thrift.BOOL, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ok: ", p), err)
+func (p *DeleteTaggedRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("nameSpace", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:nameSpace: ", p), err)
 	}
-	if err := oprot.WriteBool(bool(p.Ok)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.ok (1) field write error: ", p), err)
+	if err := oprot.WriteBinary(p.NameSpace); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.nameSpace (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ok: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:nameSpace: ", p), err)
 	}
 	return err
 }
 
-func (p *NodeHealthResult_) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("status", thrift.STRING, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:status: ", p), err)
+func (p *DeleteTaggedRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("query", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:query: ", p), err)
 	}
-	if err := oprot.WriteString(string(p.Status)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.status (2) field write error: ", p), err)
+	if err := oprot.WriteBinary(p.Query); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.query (2) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:status: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:query: ", p), err)
 	}
 	return err
 }
 
-func (p *NodeHealthResult_) writeField3(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("bootstrapped", thrift.BOOL, 3); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:bootstrapped: ", p), err)
+func (p *DeleteTaggedRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRangeStart() {
+		if err := oprot.WriteFieldBegin("rangeStart", thrift.I64, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:rangeStart: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.RangeStart)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.rangeStart (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:rangeStart: ", p), err)
+		}
 	}
-	if err := oprot.WriteBool(bool(p.Bootstrapped)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.bootstrapped (3) field write error: ", p), err)
+	return err
+}
+
+func (p *DeleteTaggedRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRangeEnd() {
+		if err := oprot.WriteFieldBegin("rangeEnd", thrift.I64, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:rangeEnd: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.RangeEnd)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.rangeEnd (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:rangeEnd: ", p), err)
+		}
 	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:bootstrapped: ", p), err)
+	return err
+}
+
+func (p *DeleteTaggedRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLimit() {
+		if err := oprot.WriteFieldBegin("limit", thrift.I64, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:limit: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.Limit)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.limit (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:limit: ", p), err)
+		}
 	}
 	return err
 }
 
-func (p *NodeHealthResult_) String() string {
+func (p *DeleteTaggedRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeHealthResult_(%+v)", *p)
+	return fmt.Sprintf("DeleteTaggedRequest(%+v)", *p)
 }
 
-type NodeBootstrappedResult_ struct {
+// Attributes:
+//  - NumSeries
+//  - ShardNumSeries
+type DeleteTaggedResult_ struct {
+	NumSeries      int64           `thrift:"numSeries,1,required" db:"numSeries" json:"numSeries"`
+	ShardNumSeries map[int32]int64 `thrift:"shardNumSeries,2" db:"shardNumSeries" json:"shardNumSeries,omitempty"`
 }
 
-func NewNodeBootstrappedResult_() *NodeBootstrappedResult_ {
-	return &NodeBootstrappedResult_{}
+func NewDeleteTaggedResult_() *DeleteTaggedResult_ {
+	return &DeleteTaggedResult_{}
 }
 
-func (p *NodeBootstrappedResult_) Read(iprot thrift.TProtocol) error {
+func (p *DeleteTaggedResult_) GetNumSeries() int64 {
+	return p.NumSeries
+}
+
+var DeleteTaggedResult__ShardNumSeries_DEFAULT map[int32]int64
+
+func (p *DeleteTaggedResult_) GetShardNumSeries() map[int32]int64 {
+	return p.ShardNumSeries
+}
+func (p *DeleteTaggedResult_) IsSetShardNumSeries() bool {
+	return p.ShardNumSeries != nil
+}
+
+func (p *DeleteTaggedResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
+	var issetNumSeries bool = false
+
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
@@ -7611,8 +7826,20 @@ func (p *NodeBootstrappedResult_) Read(iprot thrift.TProtocol) error {
 		if fieldTypeId == thrift.STOP {
 			break
 		}
-		if err := iprot.Skip(fieldTypeId); err != nil {
-			return err
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+			issetNumSeries = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
 		}
 		if err := iprot.ReadFieldEnd(); err != nil {
 			return err
@@ -7621,14 +7848,60 @@ func (p *NodeBootstrappedResult_) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
+	if !issetNumSeries {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field NumSeries is not set"))
+	}
 	return nil
 }
 
-func (p *NodeBootstrappedResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NodeBootstrappedResult"); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+func (p *DeleteTaggedResult_) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.NumSeries = v
+	}
+	return nil
+}
+
+func (p *DeleteTaggedResult_) ReadField2(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[int32]int64, size)
+	p.ShardNumSeries = tMap
+	for i := 0; i < size; i++ {
+		var _key0 int32
+		if v, err := iprot.ReadI32(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key0 = v
+		}
+		var _val1 int64
+		if v, err := iprot.ReadI64(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_val1 = v
+		}
+		p.ShardNumSeries[_key0] = _val1
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *DeleteTaggedResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DeleteTaggedResult"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -7639,46 +7912,140 @@ func (p *NodeBootstrappedResult_) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeBootstrappedResult_) String() string {
+func (p *DeleteTaggedResult_) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("numSeries", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:numSeries: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.NumSeries)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.numSeries (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:numSeries: ", p), err)
+	}
+	return err
+}
+
+func (p *DeleteTaggedResult_) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetShardNumSeries() {
+		if err := oprot.WriteFieldBegin("shardNumSeries", thrift.MAP, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:shardNumSeries: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.I32, thrift.I64, len(p.ShardNumSeries)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.ShardNumSeries {
+			if err := oprot.WriteI32(int32(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteI64(int64(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:shardNumSeries: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *DeleteTaggedResult_) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeBootstrappedResult_(%+v)", *p)
+	return fmt.Sprintf("DeleteTaggedResult_(%+v)", *p)
 }
 
 // Attributes:
-//  - LimitEnabled
-//  - LimitMbps
-//  - LimitCheckEvery
-type NodePersistRateLimitResult_ struct {
-	LimitEnabled    bool    `thrift:"limitEnabled,1,required" db:"limitEnabled" json:"limitEnabled"`
-	LimitMbps       float64 `thrift:"limitMbps,2,required" db:"limitMbps" json:"limitMbps"`
-	LimitCheckEvery int64   `thrift:"limitCheckEvery,3,required" db:"limitCheckEvery" json:"limitCheckEvery"`
+//  - NameSpace
+//  - Query
+//  - RangeStart
+//  - RangeEnd
+//  - NameOnly
+//  - Limit
+//  - RangeTimeType
+//  - TagNameFilter
+type AggregateQueryRequest struct {
+	NameSpace     []byte   `thrift:"nameSpace,1,required" db:"nameSpace" json:"nameSpace"`
+	Query         []byte   `thrift:"query,2,required" db:"query" json:"query"`
+	RangeStart    int64    `thrift:"rangeStart,3,required" db:"rangeStart" json:"rangeStart"`
+	RangeEnd      int64    `thrift:"rangeEnd,4,required" db:"rangeEnd" json:"rangeEnd"`
+	NameOnly      bool     `thrift:"nameOnly,5,required" db:"nameOnly" json:"nameOnly"`
+	Limit         *int64   `thrift:"limit,6" db:"limit" json:"limit,omitempty"`
+	RangeTimeType TimeType `thrift:"rangeTimeType,7" db:"rangeTimeType" json:"rangeTimeType,omitempty"`
+	TagNameFilter [][]byte `thrift:"tagNameFilter,8" db:"tagNameFilter" json:"tagNameFilter,omitempty"`
 }
 
-func NewNodePersistRateLimitResult_() *NodePersistRateLimitResult_ {
-	return &NodePersistRateLimitResult_{}
+func NewAggregateQueryRequest() *AggregateQueryRequest {
+	return &AggregateQueryRequest{
+		RangeTimeType: 0,
+	}
 }
 
-func (p *NodePersistRateLimitResult_) GetLimitEnabled() bool {
-	return p.LimitEnabled
+func (p *AggregateQueryRequest) GetNameSpace() []byte {
+	return p.NameSpace
 }
 
-func (p *NodePersistRateLimitResult_) GetLimitMbps() float64 {
-	return p.LimitMbps
+func (p *AggregateQueryRequest) GetQuery() []byte {
+	return p.Query
 }
 
-func (p *NodePersistRateLimitResult_) GetLimitCheckEvery() int64 {
-	return p.LimitCheckEvery
+func (p *AggregateQueryRequest) GetRangeStart() int64 {
+	return p.RangeStart
 }
-func (p *NodePersistRateLimitResult_) Read(iprot thrift.TProtocol) error {
+
+func (p *AggregateQueryRequest) GetRangeEnd() int64 {
+	return p.RangeEnd
+}
+
+func (p *AggregateQueryRequest) GetNameOnly() bool {
+	return p.NameOnly
+}
+
+var AggregateQueryRequest_Limit_DEFAULT int64
+
+func (p *AggregateQueryRequest) GetLimit() int64 {
+	if !p.IsSetLimit() {
+		return AggregateQueryRequest_Limit_DEFAULT
+	}
+	return *p.Limit
+}
+
+var AggregateQueryRequest_RangeTimeType_DEFAULT TimeType = 0
+
+func (p *AggregateQueryRequest) GetRangeTimeType() TimeType {
+	return p.RangeTimeType
+}
+
+var AggregateQueryRequest_TagNameFilter_DEFAULT [][]byte
+
+func (p *AggregateQueryRequest) GetTagNameFilter() [][]byte {
+	return p.TagNameFilter
+}
+func (p *AggregateQueryRequest) IsSetLimit() bool {
+	return p.Limit != nil
+}
+
+func (p *AggregateQueryRequest) IsSetRangeTimeType() bool {
+	return p.RangeTimeType != AggregateQueryRequest_RangeTimeType_DEFAULT
+}
+
+func (p *AggregateQueryRequest) IsSetTagNameFilter() bool {
+	return p.TagNameFilter != nil
+}
+
+func (p *AggregateQueryRequest) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetLimitEnabled bool = false
-	var issetLimitMbps bool = false
-	var issetLimitCheckEvery bool = false
+	var issetNameSpace bool = false
+	var issetQuery bool = false
+	var issetRangeStart bool = false
+	var issetRangeEnd bool = false
+	var issetNameOnly bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -7693,17 +8060,39 @@ func (p *NodePersistRateLimitResult_) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetLimitEnabled = true
+			issetNameSpace = true
 		case 2:
 			if err := p.ReadField2(iprot); err != nil {
 				return err
 			}
-			issetLimitMbps = true
+			issetQuery = true
 		case 3:
 			if err := p.ReadField3(iprot); err != nil {
 				return err
 			}
-			issetLimitCheckEvery = true
+			issetRangeStart = true
+		case 4:
+			if err := p.ReadField4(iprot); err != nil {
+				return err
+			}
+			issetRangeEnd = true
+		case 5:
+			if err := p.ReadField5(iprot); err != nil {
+				return err
+			}
+			issetNameOnly = true
+		case 6:
+			if err := p.ReadField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.ReadField7(iprot); err != nil {
+				return err
+			}
+		case 8:
+			if err := p.ReadField8(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -7716,47 +8105,112 @@ func (p *NodePersistRateLimitResult_) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetLimitEnabled {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field LimitEnabled is not set"))
+	if !issetNameSpace {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field NameSpace is not set"))
 	}
-	if !issetLimitMbps {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field LimitMbps is not set"))
+	if !issetQuery {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Query is not set"))
 	}
-	if !issetLimitCheckEvery {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field LimitCheckEvery is not set"))
+	if !issetRangeStart {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field RangeStart is not set"))
+	}
+	if !issetRangeEnd {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field RangeEnd is not set"))
+	}
+	if !issetNameOnly {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field NameOnly is not set"))
 	}
 	return nil
 }
 
-func (p *NodePersistRateLimitResult_) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBool(); err != nil {
+func (p *AggregateQueryRequest) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.LimitEnabled = v
+		p.NameSpace = v
 	}
 	return nil
 }
 
-func (p *NodePersistRateLimitResult_) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadDouble(); err != nil {
+func (p *AggregateQueryRequest) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		p.LimitMbps = v
+		p.Query = v
 	}
 	return nil
 }
 
-func (p *NodePersistRateLimitResult_) ReadField3(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRequest) ReadField3(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI64(); err != nil {
 		return thrift.PrependError("error reading field 3: ", err)
 	} else {
-		p.LimitCheckEvery = v
+		p.RangeStart = v
 	}
 	return nil
 }
 
-func (p *NodePersistRateLimitResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NodePersistRateLimitResult"); err != nil {
+func (p *AggregateQueryRequest) ReadField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.RangeEnd = v
+	}
+	return nil
+}
+
+func (p *AggregateQueryRequest) ReadField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.NameOnly = v
+	}
+	return nil
+}
+
+func (p *AggregateQueryRequest) ReadField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.Limit = &v
+	}
+	return nil
+}
+
+func (p *AggregateQueryRequest) ReadField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		temp := TimeType(v)
+		p.RangeTimeType = temp
+	}
+	return nil
+}
+
+func (p *AggregateQueryRequest) ReadField8(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([][]byte, 0, size)
+	p.TagNameFilter = tSlice
+	for i := 0; i < size; i++ {
+		var _elem23 []byte
+		if v, err := iprot.ReadBinary(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem23 = v
+		}
+		p.TagNameFilter = append(p.TagNameFilter, _elem23)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *AggregateQueryRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AggregateQueryRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -7769,6 +8223,21 @@ func (p *NodePersistRateLimitResult_) Write(oprot thrift.TProtocol) error {
 		if err := p.writeField3(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField4(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField5(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField6(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField7(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField8(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -7779,109 +8248,158 @@ func (p *NodePersistRateLimitResult_) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodePersistRateLimitResult_) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("limitEnabled", thrift.BOOL, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:limitEnabled: ", p), err)
+func (p *AggregateQueryRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("nameSpace", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:nameSpace: ", p), err)
 	}
-	if err := oprot.WriteBool(bool(p.LimitEnabled)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.limitEnabled (1) field write error: ", p), err)
+	if err := oprot.WriteBinary(p.NameSpace); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.nameSpace (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:limitEnabled: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:nameSpace: ", p), err)
 	}
 	return err
 }
 
-func (p *NodePersistRateLimitResult_) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("limitMbps", thrift.DOUBLE, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:limitMbps: ", p), err)
+func (p *AggregateQueryRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("query", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:query: ", p), err)
 	}
-	if err := oprot.WriteDouble(float64(p.LimitMbps)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.limitMbps (2) field write error: ", p), err)
+	if err := oprot.WriteBinary(p.Query); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.query (2) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:limitMbps: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:query: ", p), err)
 	}
 	return err
 }
 
-func (p *NodePersistRateLimitResult_) writeField3(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("limitCheckEvery", thrift.I64, 3); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:limitCheckEvery: ", p), err)
+func (p *AggregateQueryRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("rangeStart", thrift.I64, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:rangeStart: ", p), err)
 	}
-	if err := oprot.WriteI64(int64(p.LimitCheckEvery)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.limitCheckEvery (3) field write error: ", p), err)
+	if err := oprot.WriteI64(int64(p.RangeStart)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.rangeStart (3) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:limitCheckEvery: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:rangeStart: ", p), err)
 	}
 	return err
 }
 
-func (p *NodePersistRateLimitResult_) String() string {
-	if p == nil {
-		return "<nil>"
+func (p *AggregateQueryRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("rangeEnd", thrift.I64, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:rangeEnd: ", p), err)
 	}
-	return fmt.Sprintf("NodePersistRateLimitResult_(%+v)", *p)
+	if err := oprot.WriteI64(int64(p.RangeEnd)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.rangeEnd (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:rangeEnd: ", p), err)
+	}
+	return err
 }
 
-// Attributes:
-//  - LimitEnabled
-//  - LimitMbps
-//  - LimitCheckEvery
-type NodeSetPersistRateLimitRequest struct {
-	LimitEnabled    *bool    `thrift:"limitEnabled,1" db:"limitEnabled" json:"limitEnabled,omitempty"`
-	LimitMbps       *float64 `thrift:"limitMbps,2" db:"limitMbps" json:"limitMbps,omitempty"`
-	LimitCheckEvery *int64   `thrift:"limitCheckEvery,3" db:"limitCheckEvery" json:"limitCheckEvery,omitempty"`
+func (p *AggregateQueryRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("nameOnly", thrift.BOOL, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:nameOnly: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.NameOnly)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.nameOnly (5) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:nameOnly: ", p), err)
+	}
+	return err
 }
 
-func NewNodeSetPersistRateLimitRequest() *NodeSetPersistRateLimitRequest {
-	return &NodeSetPersistRateLimitRequest{}
+func (p *AggregateQueryRequest) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLimit() {
+		if err := oprot.WriteFieldBegin("limit", thrift.I64, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:limit: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.Limit)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.limit (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:limit: ", p), err)
+		}
+	}
+	return err
 }
 
-var NodeSetPersistRateLimitRequest_LimitEnabled_DEFAULT bool
-
-func (p *NodeSetPersistRateLimitRequest) GetLimitEnabled() bool {
-	if !p.IsSetLimitEnabled() {
-		return NodeSetPersistRateLimitRequest_LimitEnabled_DEFAULT
+func (p *AggregateQueryRequest) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRangeTimeType() {
+		if err := oprot.WriteFieldBegin("rangeTimeType", thrift.I32, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:rangeTimeType: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(p.RangeTimeType)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.rangeTimeType (7) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:rangeTimeType: ", p), err)
+		}
 	}
-	return *p.LimitEnabled
+	return err
 }
 
-var NodeSetPersistRateLimitRequest_LimitMbps_DEFAULT float64
-
-func (p *NodeSetPersistRateLimitRequest) GetLimitMbps() float64 {
-	if !p.IsSetLimitMbps() {
-		return NodeSetPersistRateLimitRequest_LimitMbps_DEFAULT
+func (p *AggregateQueryRequest) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTagNameFilter() {
+		if err := oprot.WriteFieldBegin("tagNameFilter", thrift.LIST, 8); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:tagNameFilter: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.TagNameFilter)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.TagNameFilter {
+			if err := oprot.WriteBinary(v); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:tagNameFilter: ", p), err)
+		}
 	}
-	return *p.LimitMbps
+	return err
 }
 
-var NodeSetPersistRateLimitRequest_LimitCheckEvery_DEFAULT int64
-
-func (p *NodeSetPersistRateLimitRequest) GetLimitCheckEvery() int64 {
-	if !p.IsSetLimitCheckEvery() {
-		return NodeSetPersistRateLimitRequest_LimitCheckEvery_DEFAULT
+func (p *AggregateQueryRequest) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	return *p.LimitCheckEvery
+	return fmt.Sprintf("AggregateQueryRequest(%+v)", *p)
 }
-func (p *NodeSetPersistRateLimitRequest) IsSetLimitEnabled() bool {
-	return p.LimitEnabled != nil
+
+// Attributes:
+//  - Results
+//  - Exhaustive
+type AggregateQueryResult_ struct {
+	Results    []*AggregateQueryResultTagNameElement `thrift:"results,1,required" db:"results" json:"results"`
+	Exhaustive bool                                  `thrift:"exhaustive,2,required" db:"exhaustive" json:"exhaustive"`
 }
 
-func (p *NodeSetPersistRateLimitRequest) IsSetLimitMbps() bool {
-	return p.LimitMbps != nil
+func NewAggregateQueryResult_() *AggregateQueryResult_ {
+	return &AggregateQueryResult_{}
 }
 
-func (p *NodeSetPersistRateLimitRequest) IsSetLimitCheckEvery() bool {
-	return p.LimitCheckEvery != nil
+func (p *AggregateQueryResult_) GetResults() []*AggregateQueryResultTagNameElement {
+	return p.Results
 }
 
-func (p *NodeSetPersistRateLimitRequest) Read(iprot thrift.TProtocol) error {
+func (p *AggregateQueryResult_) GetExhaustive() bool {
+	return p.Exhaustive
+}
+func (p *AggregateQueryResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
+	var issetResults bool = false
+	var issetExhaustive bool = false
+
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
@@ -7895,14 +8413,12 @@ func (p *NodeSetPersistRateLimitRequest) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
+			issetResults = true
 		case 2:
 			if err := p.ReadField2(iprot); err != nil {
 				return err
 			}
-		case 3:
-			if err := p.ReadField3(iprot); err != nil {
-				return err
-			}
+			issetExhaustive = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -7915,38 +8431,46 @@ func (p *NodeSetPersistRateLimitRequest) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	return nil
-}
-
-func (p *NodeSetPersistRateLimitRequest) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBool(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.LimitEnabled = &v
+	if !issetResults {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Results is not set"))
+	}
+	if !issetExhaustive {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Exhaustive is not set"))
 	}
 	return nil
 }
 
-func (p *NodeSetPersistRateLimitRequest) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadDouble(); err != nil {
-		return thrift.PrependError("error reading field 2: ", err)
-	} else {
-		p.LimitMbps = &v
+func (p *AggregateQueryResult_) ReadField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*AggregateQueryResultTagNameElement, 0, size)
+	p.Results = tSlice
+	for i := 0; i < size; i++ {
+		_elem24 := &AggregateQueryResultTagNameElement{}
+		if err := _elem24.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem24), err)
+		}
+		p.Results = append(p.Results, _elem24)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
 	}
 	return nil
 }
 
-func (p *NodeSetPersistRateLimitRequest) ReadField3(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(); err != nil {
-		return thrift.PrependError("error reading field 3: ", err)
+func (p *AggregateQueryResult_) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		p.LimitCheckEvery = &v
+		p.Exhaustive = v
 	}
 	return nil
 }
 
-func (p *NodeSetPersistRateLimitRequest) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NodeSetPersistRateLimitRequest"); err != nil {
+func (p *AggregateQueryResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AggregateQueryResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -7956,9 +8480,6 @@ func (p *NodeSetPersistRateLimitRequest) Write(oprot thrift.TProtocol) error {
 		if err := p.writeField2(oprot); err != nil {
 			return err
 		}
-		if err := p.writeField3(oprot); err != nil {
-			return err
-		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -7969,77 +8490,73 @@ func (p *NodeSetPersistRateLimitRequest) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeSetPersistRateLimitRequest) writeField1(oprot thrift.TProtocol) (err error) {
-	if p.IsSetLimitEnabled() {
-		if err := oprot.WriteFieldBegin("limitEnabled", thrift.BOOL, 1); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:limitEnabled: ", p), err)
-		}
-		if err := oprot.WriteBool(bool(*p.LimitEnabled)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.limitEnabled (1) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:limitEnabled: ", p), err)
-		}
+func (p *AggregateQueryResult_) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("results", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:results: ", p), err)
 	}
-	return err
-}
-
-func (p *NodeSetPersistRateLimitRequest) writeField2(oprot thrift.TProtocol) (err error) {
-	if p.IsSetLimitMbps() {
-		if err := oprot.WriteFieldBegin("limitMbps", thrift.DOUBLE, 2); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:limitMbps: ", p), err)
-		}
-		if err := oprot.WriteDouble(float64(*p.LimitMbps)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.limitMbps (2) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:limitMbps: ", p), err)
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Results)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Results {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
 		}
 	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:results: ", p), err)
+	}
 	return err
 }
 
-func (p *NodeSetPersistRateLimitRequest) writeField3(oprot thrift.TProtocol) (err error) {
-	if p.IsSetLimitCheckEvery() {
-		if err := oprot.WriteFieldBegin("limitCheckEvery", thrift.I64, 3); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:limitCheckEvery: ", p), err)
-		}
-		if err := oprot.WriteI64(int64(*p.LimitCheckEvery)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.limitCheckEvery (3) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:limitCheckEvery: ", p), err)
-		}
+func (p *AggregateQueryResult_) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("exhaustive", thrift.BOOL, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:exhaustive: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Exhaustive)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.exhaustive (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:exhaustive: ", p), err)
 	}
 	return err
 }
 
-func (p *NodeSetPersistRateLimitRequest) String() string {
+func (p *AggregateQueryResult_) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeSetPersistRateLimitRequest(%+v)", *p)
+	return fmt.Sprintf("AggregateQueryResult_(%+v)", *p)
 }
 
 // Attributes:
-//  - WriteNewSeriesAsync
-type NodeWriteNewSeriesAsyncResult_ struct {
-	WriteNewSeriesAsync bool `thrift:"writeNewSeriesAsync,1,required" db:"writeNewSeriesAsync" json:"writeNewSeriesAsync"`
+//  - TagName
+//  - TagValues
+type AggregateQueryResultTagNameElement struct {
+	TagName   []byte   `thrift:"tagName,1,required" db:"tagName" json:"tagName"`
+	TagValues [][]byte `thrift:"tagValues,2,required" db:"tagValues" json:"tagValues"`
 }
 
-func NewNodeWriteNewSeriesAsyncResult_() *NodeWriteNewSeriesAsyncResult_ {
-	return &NodeWriteNewSeriesAsyncResult_{}
+func NewAggregateQueryResultTagNameElement() *AggregateQueryResultTagNameElement {
+	return &AggregateQueryResultTagNameElement{}
 }
 
-func (p *NodeWriteNewSeriesAsyncResult_) GetWriteNewSeriesAsync() bool {
-	return p.WriteNewSeriesAsync
+func (p *AggregateQueryResultTagNameElement) GetTagName() []byte {
+	return p.TagName
 }
-func (p *NodeWriteNewSeriesAsyncResult_) Read(iprot thrift.TProtocol) error {
+
+func (p *AggregateQueryResultTagNameElement) GetTagValues() [][]byte {
+	return p.TagValues
+}
+func (p *AggregateQueryResultTagNameElement) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetWriteNewSeriesAsync bool = false
+	var issetTagName bool = false
+	var issetTagValues bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -8054,7 +8571,12 @@ func (p *NodeWriteNewSeriesAsyncResult_) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetWriteNewSeriesAsync = true
+			issetTagName = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+			issetTagValues = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -8067,28 +8589,56 @@ func (p *NodeWriteNewSeriesAsyncResult_) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetWriteNewSeriesAsync {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesAsync is not set"))
+	if !issetTagName {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field TagName is not set"))
+	}
+	if !issetTagValues {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field TagValues is not set"))
 	}
 	return nil
 }
 
-func (p *NodeWriteNewSeriesAsyncResult_) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBool(); err != nil {
+func (p *AggregateQueryResultTagNameElement) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.WriteNewSeriesAsync = v
+		p.TagName = v
 	}
 	return nil
 }
 
-func (p *NodeWriteNewSeriesAsyncResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NodeWriteNewSeriesAsyncResult"); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+func (p *AggregateQueryResultTagNameElement) ReadField2(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
 	}
-	if p != nil {
-		if err := p.writeField1(oprot); err != nil {
-			return err
+	tSlice := make([][]byte, 0, size)
+	p.TagValues = tSlice
+	for i := 0; i < size; i++ {
+		var _elem25 []byte
+		if v, err := iprot.ReadBinary(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem25 = v
+		}
+		p.TagValues = append(p.TagValues, _elem25)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *AggregateQueryResultTagNameElement) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AggregateQueryResultTagNameElement"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(oprot); err != nil {
+			return err
 		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
@@ -8100,45 +8650,80 @@ func (p *NodeWriteNewSeriesAsyncResult_) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteNewSeriesAsyncResult_) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("writeNewSeriesAsync", thrift.BOOL, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesAsync: ", p), err)
+func (p *AggregateQueryResultTagNameElement) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("tagName", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:tagName: ", p), err)
 	}
-	if err := oprot.WriteBool(bool(p.WriteNewSeriesAsync)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesAsync (1) field write error: ", p), err)
+	if err := oprot.WriteBinary(p.TagName); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.tagName (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesAsync: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:tagName: ", p), err)
 	}
 	return err
 }
 
-func (p *NodeWriteNewSeriesAsyncResult_) String() string {
+func (p *AggregateQueryResultTagNameElement) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("tagValues", thrift.LIST, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:tagValues: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRING, len(p.TagValues)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.TagValues {
+		if err := oprot.WriteBinary(v); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:tagValues: ", p), err)
+	}
+	return err
+}
+
+func (p *AggregateQueryResultTagNameElement) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteNewSeriesAsyncResult_(%+v)", *p)
+	return fmt.Sprintf("AggregateQueryResultTagNameElement(%+v)", *p)
 }
 
 // Attributes:
-//  - WriteNewSeriesAsync
-type NodeSetWriteNewSeriesAsyncRequest struct {
-	WriteNewSeriesAsync bool `thrift:"writeNewSeriesAsync,1,required" db:"writeNewSeriesAsync" json:"writeNewSeriesAsync"`
+//  - Ok
+//  - Status
+//  - Bootstrapped
+type NodeHealthResult_ struct {
+	Ok           bool   `thrift:"ok,1,required" db:"ok" json:"ok"`
+	Status       string `thrift:"status,2,required" db:"status" json:"status"`
+	Bootstrapped bool   `thrift:"bootstrapped,3,required" db:"bootstrapped" json:"bootstrapped"`
 }
 
-func NewNodeSetWriteNewSeriesAsyncRequest() *NodeSetWriteNewSeriesAsyncRequest {
-	return &NodeSetWriteNewSeriesAsyncRequest{}
+func NewNodeHealthResult_() *NodeHealthResult_ {
+	return &NodeHealthResult_{}
 }
 
-func (p *NodeSetWriteNewSeriesAsyncRequest) GetWriteNewSeriesAsync() bool {
-	return p.WriteNewSeriesAsync
+func (p *NodeHealthResult_) GetOk() bool {
+	return p.Ok
 }
-func (p *NodeSetWriteNewSeriesAsyncRequest) Read(iprot thrift.TProtocol) error {
+
+func (p *NodeHealthResult_) GetStatus() string {
+	return p.Status
+}
+
+func (p *NodeHealthResult_) GetBootstrapped() bool {
+	return p.Bootstrapped
+}
+func (p *NodeHealthResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetWriteNewSeriesAsync bool = false
+	var issetOk bool = false
+	var issetStatus bool = false
+	var issetBootstrapped bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -8153,7 +8738,17 @@ func (p *NodeSetWriteNewSeriesAsyncRequest) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetWriteNewSeriesAsync = true
+			issetOk = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+			issetStatus = true
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
+			issetBootstrapped = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -8166,29 +8761,59 @@ func (p *NodeSetWriteNewSeriesAsyncRequest) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetWriteNewSeriesAsync {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesAsync is not set"))
+	if !issetOk {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Ok is not set"))
+	}
+	if !issetStatus {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Status is not set"))
+	}
+	if !issetBootstrapped {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Bootstrapped is not set"))
 	}
 	return nil
 }
 
-func (p *NodeSetWriteNewSeriesAsyncRequest) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeHealthResult_) ReadField1(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadBool(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.WriteNewSeriesAsync = v
+		p.Ok = v
 	}
 	return nil
 }
 
-func (p *NodeSetWriteNewSeriesAsyncRequest) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NodeSetWriteNewSeriesAsyncRequest"); err != nil {
+func (p *NodeHealthResult_) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Status = v
+	}
+	return nil
+}
+
+func (p *NodeHealthResult_) ReadField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Bootstrapped = v
+	}
+	return nil
+}
+
+func (p *NodeHealthResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodeHealthResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField2(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField3(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -8199,53 +8824,64 @@ func (p *NodeSetWriteNewSeriesAsyncRequest) Write(oprot thrift.TProtocol) error
 	return nil
 }
 
-func (p *NodeSetWriteNewSeriesAsyncRequest) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("writeNewSeriesAsync", thrift.BOOL, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesAsync: ", p), err)
+func (p *NodeHealthResult_) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("ok", thrift.BOOL, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ok: ", p), err)
 	}
-	if err := oprot.WriteBool(bool(p.WriteNewSeriesAsync)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesAsync (1) field write error: ", p), err)
+	if err := oprot.WriteBool(bool(p.Ok)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.ok (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesAsync: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ok: ", p), err)
 	}
 	return err
 }
 
-func (p *NodeSetWriteNewSeriesAsyncRequest) String() string {
-	if p == nil {
-		return "<nil>"
+func (p *NodeHealthResult_) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("status", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:status: ", p), err)
 	}
-	return fmt.Sprintf("NodeSetWriteNewSeriesAsyncRequest(%+v)", *p)
+	if err := oprot.WriteString(string(p.Status)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.status (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:status: ", p), err)
+	}
+	return err
 }
 
-// Attributes:
-//  - WriteNewSeriesBackoffDuration
-//  - DurationType
-type NodeWriteNewSeriesBackoffDurationResult_ struct {
-	WriteNewSeriesBackoffDuration int64    `thrift:"writeNewSeriesBackoffDuration,1,required" db:"writeNewSeriesBackoffDuration" json:"writeNewSeriesBackoffDuration"`
-	DurationType                  TimeType `thrift:"durationType,2,required" db:"durationType" json:"durationType"`
+func (p *NodeHealthResult_) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("bootstrapped", thrift.BOOL, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:bootstrapped: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Bootstrapped)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.bootstrapped (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:bootstrapped: ", p), err)
+	}
+	return err
 }
 
-func NewNodeWriteNewSeriesBackoffDurationResult_() *NodeWriteNewSeriesBackoffDurationResult_ {
-	return &NodeWriteNewSeriesBackoffDurationResult_{}
+func (p *NodeHealthResult_) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("NodeHealthResult_(%+v)", *p)
 }
 
-func (p *NodeWriteNewSeriesBackoffDurationResult_) GetWriteNewSeriesBackoffDuration() int64 {
-	return p.WriteNewSeriesBackoffDuration
+type NodeBootstrappedResult_ struct {
 }
 
-func (p *NodeWriteNewSeriesBackoffDurationResult_) GetDurationType() TimeType {
-	return p.DurationType
+func NewNodeBootstrappedResult_() *NodeBootstrappedResult_ {
+	return &NodeBootstrappedResult_{}
 }
-func (p *NodeWriteNewSeriesBackoffDurationResult_) Read(iprot thrift.TProtocol) error {
+
+func (p *NodeBootstrappedResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetWriteNewSeriesBackoffDuration bool = false
-	var issetDurationType bool = false
-
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
@@ -8254,21 +8890,8 @@ func (p *NodeWriteNewSeriesBackoffDurationResult_) Read(iprot thrift.TProtocol)
 		if fieldTypeId == thrift.STOP {
 			break
 		}
-		switch fieldId {
-		case 1:
-			if err := p.ReadField1(iprot); err != nil {
-				return err
-			}
-			issetWriteNewSeriesBackoffDuration = true
-		case 2:
-			if err := p.ReadField2(iprot); err != nil {
-				return err
-			}
-			issetDurationType = true
-		default:
-			if err := iprot.Skip(fieldTypeId); err != nil {
-				return err
-			}
+		if err := iprot.Skip(fieldTypeId); err != nil {
+			return err
 		}
 		if err := iprot.ReadFieldEnd(); err != nil {
 			return err
@@ -8277,45 +8900,14 @@ func (p *NodeWriteNewSeriesBackoffDurationResult_) Read(iprot thrift.TProtocol)
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetWriteNewSeriesBackoffDuration {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesBackoffDuration is not set"))
-	}
-	if !issetDurationType {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field DurationType is not set"))
-	}
-	return nil
-}
-
-func (p *NodeWriteNewSeriesBackoffDurationResult_) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.WriteNewSeriesBackoffDuration = v
-	}
-	return nil
-}
-
-func (p *NodeWriteNewSeriesBackoffDurationResult_) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(); err != nil {
-		return thrift.PrependError("error reading field 2: ", err)
-	} else {
-		temp := TimeType(v)
-		p.DurationType = temp
-	}
 	return nil
 }
 
-func (p *NodeWriteNewSeriesBackoffDurationResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NodeWriteNewSeriesBackoffDurationResult"); err != nil {
+func (p *NodeBootstrappedResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodeBootstrappedResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
-		if err := p.writeField1(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField2(oprot); err != nil {
-			return err
-		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -8326,72 +8918,46 @@ func (p *NodeWriteNewSeriesBackoffDurationResult_) Write(oprot thrift.TProtocol)
 	return nil
 }
 
-func (p *NodeWriteNewSeriesBackoffDurationResult_) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("writeNewSeriesBackoffDuration", thrift.I64, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesBackoffDuration: ", p), err)
-	}
-	if err := oprot.WriteI64(int64(p.WriteNewSeriesBackoffDuration)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesBackoffDuration (1) field write error: ", p), err)
+func (p *NodeBootstrappedResult_) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesBackoffDuration: ", p), err)
-	}
-	return err
-}
-
-func (p *NodeWriteNewSeriesBackoffDurationResult_) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("durationType", thrift.I32, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:durationType: ", p), err)
-	}
-	if err := oprot.WriteI32(int32(p.DurationType)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.durationType (2) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:durationType: ", p), err)
-	}
-	return err
-}
-
-func (p *NodeWriteNewSeriesBackoffDurationResult_) String() string {
-	if p == nil {
-		return "<nil>"
-	}
-	return fmt.Sprintf("NodeWriteNewSeriesBackoffDurationResult_(%+v)", *p)
+	return fmt.Sprintf("NodeBootstrappedResult_(%+v)", *p)
 }
 
 // Attributes:
-//  - WriteNewSeriesBackoffDuration
-//  - DurationType
-type NodeSetWriteNewSeriesBackoffDurationRequest struct {
-	WriteNewSeriesBackoffDuration int64    `thrift:"writeNewSeriesBackoffDuration,1,required" db:"writeNewSeriesBackoffDuration" json:"writeNewSeriesBackoffDuration"`
-	DurationType                  TimeType `thrift:"durationType,2" db:"durationType" json:"durationType,omitempty"`
+//  - LimitEnabled
+//  - LimitMbps
+//  - LimitCheckEvery
+type NodePersistRateLimitResult_ struct {
+	LimitEnabled    bool    `thrift:"limitEnabled,1,required" db:"limitEnabled" json:"limitEnabled"`
+	LimitMbps       float64 `thrift:"limitMbps,2,required" db:"limitMbps" json:"limitMbps"`
+	LimitCheckEvery int64   `thrift:"limitCheckEvery,3,required" db:"limitCheckEvery" json:"limitCheckEvery"`
 }
 
-func NewNodeSetWriteNewSeriesBackoffDurationRequest() *NodeSetWriteNewSeriesBackoffDurationRequest {
-	return &NodeSetWriteNewSeriesBackoffDurationRequest{
-		DurationType: 2,
-	}
+func NewNodePersistRateLimitResult_() *NodePersistRateLimitResult_ {
+	return &NodePersistRateLimitResult_{}
 }
 
-func (p *NodeSetWriteNewSeriesBackoffDurationRequest) GetWriteNewSeriesBackoffDuration() int64 {
-	return p.WriteNewSeriesBackoffDuration
+func (p *NodePersistRateLimitResult_) GetLimitEnabled() bool {
+	return p.LimitEnabled
 }
 
-var NodeSetWriteNewSeriesBackoffDurationRequest_DurationType_DEFAULT TimeType = 2
-
-func (p *NodeSetWriteNewSeriesBackoffDurationRequest) GetDurationType() TimeType {
-	return p.DurationType
-}
-func (p *NodeSetWriteNewSeriesBackoffDurationRequest) IsSetDurationType() bool {
-	return p.DurationType != NodeSetWriteNewSeriesBackoffDurationRequest_DurationType_DEFAULT
+func (p *NodePersistRateLimitResult_) GetLimitMbps() float64 {
+	return p.LimitMbps
 }
 
-func (p *NodeSetWriteNewSeriesBackoffDurationRequest) Read(iprot thrift.TProtocol) error {
+func (p *NodePersistRateLimitResult_) GetLimitCheckEvery() int64 {
+	return p.LimitCheckEvery
+}
+func (p *NodePersistRateLimitResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetWriteNewSeriesBackoffDuration bool = false
+	var issetLimitEnabled bool = false
+	var issetLimitMbps bool = false
+	var issetLimitCheckEvery bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -8406,11 +8972,17 @@ func (p *NodeSetWriteNewSeriesBackoffDurationRequest) Read(iprot thrift.TProtoco
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetWriteNewSeriesBackoffDuration = true
+			issetLimitEnabled = true
 		case 2:
 			if err := p.ReadField2(iprot); err != nil {
 				return err
 			}
+			issetLimitMbps = true
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
+			issetLimitCheckEvery = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -8423,33 +8995,47 @@ func (p *NodeSetWriteNewSeriesBackoffDurationRequest) Read(iprot thrift.TProtoco
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetWriteNewSeriesBackoffDuration {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesBackoffDuration is not set"))
+	if !issetLimitEnabled {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field LimitEnabled is not set"))
+	}
+	if !issetLimitMbps {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field LimitMbps is not set"))
+	}
+	if !issetLimitCheckEvery {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field LimitCheckEvery is not set"))
 	}
 	return nil
 }
 
-func (p *NodeSetWriteNewSeriesBackoffDurationRequest) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(); err != nil {
+func (p *NodePersistRateLimitResult_) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.WriteNewSeriesBackoffDuration = v
+		p.LimitEnabled = v
 	}
 	return nil
 }
 
-func (p *NodeSetWriteNewSeriesBackoffDurationRequest) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(); err != nil {
+func (p *NodePersistRateLimitResult_) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadDouble(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		temp := TimeType(v)
-		p.DurationType = temp
+		p.LimitMbps = v
 	}
 	return nil
 }
 
-func (p *NodeSetWriteNewSeriesBackoffDurationRequest) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NodeSetWriteNewSeriesBackoffDurationRequest"); err != nil {
+func (p *NodePersistRateLimitResult_) ReadField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.LimitCheckEvery = v
+	}
+	return nil
+}
+
+func (p *NodePersistRateLimitResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodePersistRateLimitResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -8459,6 +9045,9 @@ func (p *NodeSetWriteNewSeriesBackoffDurationRequest) Write(oprot thrift.TProtoc
 		if err := p.writeField2(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField3(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -8469,61 +9058,109 @@ func (p *NodeSetWriteNewSeriesBackoffDurationRequest) Write(oprot thrift.TProtoc
 	return nil
 }
 
-func (p *NodeSetWriteNewSeriesBackoffDurationRequest) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("writeNewSeriesBackoffDuration", thrift.I64, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesBackoffDuration: ", p), err)
+func (p *NodePersistRateLimitResult_) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("limitEnabled", thrift.BOOL, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:limitEnabled: ", p), err)
 	}
-	if err := oprot.WriteI64(int64(p.WriteNewSeriesBackoffDuration)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesBackoffDuration (1) field write error: ", p), err)
+	if err := oprot.WriteBool(bool(p.LimitEnabled)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.limitEnabled (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesBackoffDuration: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:limitEnabled: ", p), err)
 	}
 	return err
 }
 
-func (p *NodeSetWriteNewSeriesBackoffDurationRequest) writeField2(oprot thrift.TProtocol) (err error) {
-	if p.IsSetDurationType() {
-		if err := oprot.WriteFieldBegin("durationType", thrift.I32, 2); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:durationType: ", p), err)
-		}
-		if err := oprot.WriteI32(int32(p.DurationType)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.durationType (2) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:durationType: ", p), err)
-		}
+func (p *NodePersistRateLimitResult_) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("limitMbps", thrift.DOUBLE, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:limitMbps: ", p), err)
+	}
+	if err := oprot.WriteDouble(float64(p.LimitMbps)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.limitMbps (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:limitMbps: ", p), err)
 	}
 	return err
 }
 
-func (p *NodeSetWriteNewSeriesBackoffDurationRequest) String() string {
+func (p *NodePersistRateLimitResult_) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("limitCheckEvery", thrift.I64, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:limitCheckEvery: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.LimitCheckEvery)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.limitCheckEvery (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:limitCheckEvery: ", p), err)
+	}
+	return err
+}
+
+func (p *NodePersistRateLimitResult_) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeSetWriteNewSeriesBackoffDurationRequest(%+v)", *p)
+	return fmt.Sprintf("NodePersistRateLimitResult_(%+v)", *p)
 }
 
 // Attributes:
-//  - WriteNewSeriesLimitPerShardPerSecond
-type NodeWriteNewSeriesLimitPerShardPerSecondResult_ struct {
-	WriteNewSeriesLimitPerShardPerSecond int64 `thrift:"writeNewSeriesLimitPerShardPerSecond,1,required" db:"writeNewSeriesLimitPerShardPerSecond" json:"writeNewSeriesLimitPerShardPerSecond"`
+//  - LimitEnabled
+//  - LimitMbps
+//  - LimitCheckEvery
+type NodeSetPersistRateLimitRequest struct {
+	LimitEnabled    *bool    `thrift:"limitEnabled,1" db:"limitEnabled" json:"limitEnabled,omitempty"`
+	LimitMbps       *float64 `thrift:"limitMbps,2" db:"limitMbps" json:"limitMbps,omitempty"`
+	LimitCheckEvery *int64   `thrift:"limitCheckEvery,3" db:"limitCheckEvery" json:"limitCheckEvery,omitempty"`
 }
 
-func NewNodeWriteNewSeriesLimitPerShardPerSecondResult_() *NodeWriteNewSeriesLimitPerShardPerSecondResult_ {
-	return &NodeWriteNewSeriesLimitPerShardPerSecondResult_{}
+func NewNodeSetPersistRateLimitRequest() *NodeSetPersistRateLimitRequest {
+	return &NodeSetPersistRateLimitRequest{}
 }
 
-func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) GetWriteNewSeriesLimitPerShardPerSecond() int64 {
-	return p.WriteNewSeriesLimitPerShardPerSecond
+var NodeSetPersistRateLimitRequest_LimitEnabled_DEFAULT bool
+
+func (p *NodeSetPersistRateLimitRequest) GetLimitEnabled() bool {
+	if !p.IsSetLimitEnabled() {
+		return NodeSetPersistRateLimitRequest_LimitEnabled_DEFAULT
+	}
+	return *p.LimitEnabled
 }
-func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) Read(iprot thrift.TProtocol) error {
+
+var NodeSetPersistRateLimitRequest_LimitMbps_DEFAULT float64
+
+func (p *NodeSetPersistRateLimitRequest) GetLimitMbps() float64 {
+	if !p.IsSetLimitMbps() {
+		return NodeSetPersistRateLimitRequest_LimitMbps_DEFAULT
+	}
+	return *p.LimitMbps
+}
+
+var NodeSetPersistRateLimitRequest_LimitCheckEvery_DEFAULT int64
+
+func (p *NodeSetPersistRateLimitRequest) GetLimitCheckEvery() int64 {
+	if !p.IsSetLimitCheckEvery() {
+		return NodeSetPersistRateLimitRequest_LimitCheckEvery_DEFAULT
+	}
+	return *p.LimitCheckEvery
+}
+func (p *NodeSetPersistRateLimitRequest) IsSetLimitEnabled() bool {
+	return p.LimitEnabled != nil
+}
+
+func (p *NodeSetPersistRateLimitRequest) IsSetLimitMbps() bool {
+	return p.LimitMbps != nil
+}
+
+func (p *NodeSetPersistRateLimitRequest) IsSetLimitCheckEvery() bool {
+	return p.LimitCheckEvery != nil
+}
+
+func (p *NodeSetPersistRateLimitRequest) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetWriteNewSeriesLimitPerShardPerSecond bool = false
-
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
@@ -8537,7 +9174,14 @@ func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) Read(iprot thrift.TPro
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetWriteNewSeriesLimitPerShardPerSecond = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -8550,31 +9194,52 @@ func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) Read(iprot thrift.TPro
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetWriteNewSeriesLimitPerShardPerSecond {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesLimitPerShardPerSecond is not set"))
+	return nil
+}
+
+func (p *NodeSetPersistRateLimitRequest) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.LimitEnabled = &v
 	}
 	return nil
 }
 
-func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeSetPersistRateLimitRequest) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadDouble(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.LimitMbps = &v
+	}
+	return nil
+}
+
+func (p *NodeSetPersistRateLimitRequest) ReadField3(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI64(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
+		return thrift.PrependError("error reading field 3: ", err)
 	} else {
-		p.WriteNewSeriesLimitPerShardPerSecond = v
+		p.LimitCheckEvery = &v
 	}
 	return nil
 }
 
-func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NodeWriteNewSeriesLimitPerShardPerSecondResult"); err != nil {
+func (p *NodeSetPersistRateLimitRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodeSetPersistRateLimitRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
-	}
-	if err := oprot.WriteFieldStop(); err != nil {
+		if err := p.writeField2(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField3(oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
 	if err := oprot.WriteStructEnd(); err != nil {
@@ -8583,45 +9248,77 @@ func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) Write(oprot thrift.TPr
 	return nil
 }
 
-func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("writeNewSeriesLimitPerShardPerSecond", thrift.I64, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesLimitPerShardPerSecond: ", p), err)
+func (p *NodeSetPersistRateLimitRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLimitEnabled() {
+		if err := oprot.WriteFieldBegin("limitEnabled", thrift.BOOL, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:limitEnabled: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.LimitEnabled)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.limitEnabled (1) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:limitEnabled: ", p), err)
+		}
 	}
-	if err := oprot.WriteI64(int64(p.WriteNewSeriesLimitPerShardPerSecond)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesLimitPerShardPerSecond (1) field write error: ", p), err)
+	return err
+}
+
+func (p *NodeSetPersistRateLimitRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLimitMbps() {
+		if err := oprot.WriteFieldBegin("limitMbps", thrift.DOUBLE, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:limitMbps: ", p), err)
+		}
+		if err := oprot.WriteDouble(float64(*p.LimitMbps)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.limitMbps (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:limitMbps: ", p), err)
+		}
 	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesLimitPerShardPerSecond: ", p), err)
+	return err
+}
+
+func (p *NodeSetPersistRateLimitRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLimitCheckEvery() {
+		if err := oprot.WriteFieldBegin("limitCheckEvery", thrift.I64, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:limitCheckEvery: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.LimitCheckEvery)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.limitCheckEvery (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:limitCheckEvery: ", p), err)
+		}
 	}
 	return err
 }
 
-func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) String() string {
+func (p *NodeSetPersistRateLimitRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteNewSeriesLimitPerShardPerSecondResult_(%+v)", *p)
+	return fmt.Sprintf("NodeSetPersistRateLimitRequest(%+v)", *p)
 }
 
 // Attributes:
-//  - WriteNewSeriesLimitPerShardPerSecond
-type NodeSetWriteNewSeriesLimitPerShardPerSecondRequest struct {
-	WriteNewSeriesLimitPerShardPerSecond int64 `thrift:"writeNewSeriesLimitPerShardPerSecond,1,required" db:"writeNewSeriesLimitPerShardPerSecond" json:"writeNewSeriesLimitPerShardPerSecond"`
+//  - WriteNewSeriesAsync
+type NodeWriteNewSeriesAsyncResult_ struct {
+	WriteNewSeriesAsync bool `thrift:"writeNewSeriesAsync,1,required" db:"writeNewSeriesAsync" json:"writeNewSeriesAsync"`
 }
 
-func NewNodeSetWriteNewSeriesLimitPerShardPerSecondRequest() *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest {
-	return &NodeSetWriteNewSeriesLimitPerShardPerSecondRequest{}
+func NewNodeWriteNewSeriesAsyncResult_() *NodeWriteNewSeriesAsyncResult_ {
+	return &NodeWriteNewSeriesAsyncResult_{}
 }
 
-func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) GetWriteNewSeriesLimitPerShardPerSecond() int64 {
-	return p.WriteNewSeriesLimitPerShardPerSecond
+func (p *NodeWriteNewSeriesAsyncResult_) GetWriteNewSeriesAsync() bool {
+	return p.WriteNewSeriesAsync
 }
-func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteNewSeriesAsyncResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetWriteNewSeriesLimitPerShardPerSecond bool = false
+	var issetWriteNewSeriesAsync bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -8636,7 +9333,7 @@ func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) Read(iprot thrift.T
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetWriteNewSeriesLimitPerShardPerSecond = true
+			issetWriteNewSeriesAsync = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -8649,23 +9346,23 @@ func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) Read(iprot thrift.T
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetWriteNewSeriesLimitPerShardPerSecond {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesLimitPerShardPerSecond is not set"))
+	if !issetWriteNewSeriesAsync {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesAsync is not set"))
 	}
 	return nil
 }
 
-func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(); err != nil {
+func (p *NodeWriteNewSeriesAsyncResult_) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.WriteNewSeriesLimitPerShardPerSecond = v
+		p.WriteNewSeriesAsync = v
 	}
 	return nil
 }
 
-func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NodeSetWriteNewSeriesLimitPerShardPerSecondRequest"); err != nil {
+func (p *NodeWriteNewSeriesAsyncResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodeWriteNewSeriesAsyncResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -8682,52 +9379,45 @@ func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) Write(oprot thrift.
 	return nil
 }
 
-func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("writeNewSeriesLimitPerShardPerSecond", thrift.I64, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesLimitPerShardPerSecond: ", p), err)
+func (p *NodeWriteNewSeriesAsyncResult_) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("writeNewSeriesAsync", thrift.BOOL, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesAsync: ", p), err)
 	}
-	if err := oprot.WriteI64(int64(p.WriteNewSeriesLimitPerShardPerSecond)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesLimitPerShardPerSecond (1) field write error: ", p), err)
+	if err := oprot.WriteBool(bool(p.WriteNewSeriesAsync)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesAsync (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesLimitPerShardPerSecond: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesAsync: ", p), err)
 	}
 	return err
 }
 
-func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) String() string {
+func (p *NodeWriteNewSeriesAsyncResult_) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeSetWriteNewSeriesLimitPerShardPerSecondRequest(%+v)", *p)
+	return fmt.Sprintf("NodeWriteNewSeriesAsyncResult_(%+v)", *p)
 }
 
 // Attributes:
-//  - Ok
-//  - Status
-type HealthResult_ struct {
-	Ok     bool   `thrift:"ok,1,required" db:"ok" json:"ok"`
-	Status string `thrift:"status,2,required" db:"status" json:"status"`
-}
-
-func NewHealthResult_() *HealthResult_ {
-	return &HealthResult_{}
+//  - WriteNewSeriesAsync
+type NodeSetWriteNewSeriesAsyncRequest struct {
+	WriteNewSeriesAsync bool `thrift:"writeNewSeriesAsync,1,required" db:"writeNewSeriesAsync" json:"writeNewSeriesAsync"`
 }
 
-func (p *HealthResult_) GetOk() bool {
-	return p.Ok
+func NewNodeSetWriteNewSeriesAsyncRequest() *NodeSetWriteNewSeriesAsyncRequest {
+	return &NodeSetWriteNewSeriesAsyncRequest{}
 }
 
-func (p *HealthResult_) GetStatus() string {
-	return p.Status
+func (p *NodeSetWriteNewSeriesAsyncRequest) GetWriteNewSeriesAsync() bool {
+	return p.WriteNewSeriesAsync
 }
-func (p *HealthResult_) Read(iprot thrift.TProtocol) error {
+func (p *NodeSetWriteNewSeriesAsyncRequest) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetOk bool = false
-	var issetStatus bool = false
+	var issetWriteNewSeriesAsync bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -8742,12 +9432,7 @@ func (p *HealthResult_) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetOk = true
-		case 2:
-			if err := p.ReadField2(iprot); err != nil {
-				return err
-			}
-			issetStatus = true
+			issetWriteNewSeriesAsync = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -8760,44 +9445,29 @@ func (p *HealthResult_) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetOk {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Ok is not set"))
-	}
-	if !issetStatus {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Status is not set"))
+	if !issetWriteNewSeriesAsync {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesAsync is not set"))
 	}
 	return nil
 }
 
-func (p *HealthResult_) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeSetWriteNewSeriesAsyncRequest) ReadField1(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadBool(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.Ok = v
-	}
-	return nil
-}
-
-func (p *HealthResult_) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 2: ", err)
-	} else {
-		p.Status = v
+		p.WriteNewSeriesAsync = v
 	}
 	return nil
 }
 
-func (p *HealthResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("HealthResult"); err != nil {
+func (p *NodeSetWriteNewSeriesAsyncRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodeSetWriteNewSeriesAsyncRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
-		if err := p.writeField2(oprot); err != nil {
-			return err
-		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -8808,146 +9478,52 @@ func (p *HealthResult_) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *HealthResult_) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("ok", thrift.BOOL, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ok: ", p), err)
-	}
-	if err := oprot.WriteBool(bool(p.Ok)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.ok (1) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ok: ", p), err)
-	}
-	return err
-}
-
-func (p *HealthResult_) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("status", thrift.STRING, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:status: ", p), err)
+func (p *NodeSetWriteNewSeriesAsyncRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("writeNewSeriesAsync", thrift.BOOL, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesAsync: ", p), err)
 	}
-	if err := oprot.WriteString(string(p.Status)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.status (2) field write error: ", p), err)
+	if err := oprot.WriteBool(bool(p.WriteNewSeriesAsync)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesAsync (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:status: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesAsync: ", p), err)
 	}
 	return err
 }
 
-func (p *HealthResult_) String() string {
+func (p *NodeSetWriteNewSeriesAsyncRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("HealthResult_(%+v)", *p)
+	return fmt.Sprintf("NodeSetWriteNewSeriesAsyncRequest(%+v)", *p)
 }
 
 // Attributes:
-//  - Query
-//  - RangeStart
-//  - RangeEnd
-//  - NameSpace
-//  - Limit
-//  - NoData
-//  - RangeType
-//  - ResultTimeType
-type QueryRequest struct {
-	Query          *Query   `thrift:"query,1,required" db:"query" json:"query"`
-	RangeStart     int64    `thrift:"rangeStart,2,required" db:"rangeStart" json:"rangeStart"`
-	RangeEnd       int64    `thrift:"rangeEnd,3,required" db:"rangeEnd" json:"rangeEnd"`
-	NameSpace      string   `thrift:"nameSpace,4,required" db:"nameSpace" json:"nameSpace"`
-	Limit          *int64   `thrift:"limit,5" db:"limit" json:"limit,omitempty"`
-	NoData         *bool    `thrift:"noData,6" db:"noData" json:"noData,omitempty"`
-	RangeType      TimeType `thrift:"rangeType,7" db:"rangeType" json:"rangeType,omitempty"`
-	ResultTimeType TimeType `thrift:"resultTimeType,8" db:"resultTimeType" json:"resultTimeType,omitempty"`
-}
-
-func NewQueryRequest() *QueryRequest {
-	return &QueryRequest{
-		RangeType: 0,
-
-		ResultTimeType: 0,
-	}
-}
-
-var QueryRequest_Query_DEFAULT *Query
-
-func (p *QueryRequest) GetQuery() *Query {
-	if !p.IsSetQuery() {
-		return QueryRequest_Query_DEFAULT
-	}
-	return p.Query
+//  - WriteNewSeriesBackoffDuration
+//  - DurationType
+type NodeWriteNewSeriesBackoffDurationResult_ struct {
+	WriteNewSeriesBackoffDuration int64    `thrift:"writeNewSeriesBackoffDuration,1,required" db:"writeNewSeriesBackoffDuration" json:"writeNewSeriesBackoffDuration"`
+	DurationType                  TimeType `thrift:"durationType,2,required" db:"durationType" json:"durationType"`
 }
 
-func (p *QueryRequest) GetRangeStart() int64 {
-	return p.RangeStart
+func NewNodeWriteNewSeriesBackoffDurationResult_() *NodeWriteNewSeriesBackoffDurationResult_ {
+	return &NodeWriteNewSeriesBackoffDurationResult_{}
 }
 
-func (p *QueryRequest) GetRangeEnd() int64 {
-	return p.RangeEnd
+func (p *NodeWriteNewSeriesBackoffDurationResult_) GetWriteNewSeriesBackoffDuration() int64 {
+	return p.WriteNewSeriesBackoffDuration
 }
 
-func (p *QueryRequest) GetNameSpace() string {
-	return p.NameSpace
+func (p *NodeWriteNewSeriesBackoffDurationResult_) GetDurationType() TimeType {
+	return p.DurationType
 }
+func (p *NodeWriteNewSeriesBackoffDurationResult_) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
 
-var QueryRequest_Limit_DEFAULT int64
-
-func (p *QueryRequest) GetLimit() int64 {
-	if !p.IsSetLimit() {
-		return QueryRequest_Limit_DEFAULT
-	}
-	return *p.Limit
-}
-
-var QueryRequest_NoData_DEFAULT bool
-
-func (p *QueryRequest) GetNoData() bool {
-	if !p.IsSetNoData() {
-		return QueryRequest_NoData_DEFAULT
-	}
-	return *p.NoData
-}
-
-var QueryRequest_RangeType_DEFAULT TimeType = 0
-
-func (p *QueryRequest) GetRangeType() TimeType {
-	return p.RangeType
-}
-
-var QueryRequest_ResultTimeType_DEFAULT TimeType = 0
-
-func (p *QueryRequest) GetResultTimeType() TimeType {
-	return p.ResultTimeType
-}
-func (p *QueryRequest) IsSetQuery() bool {
-	return p.Query != nil
-}
-
-func (p *QueryRequest) IsSetLimit() bool {
-	return p.Limit != nil
-}
-
-func (p *QueryRequest) IsSetNoData() bool {
-	return p.NoData != nil
-}
-
-func (p *QueryRequest) IsSetRangeType() bool {
-	return p.RangeType != QueryRequest_RangeType_DEFAULT
-}
-
-func (p *QueryRequest) IsSetResultTimeType() bool {
-	return p.ResultTimeType != QueryRequest_ResultTimeType_DEFAULT
-}
-
-func (p *QueryRequest) Read(iprot thrift.TProtocol) error {
-	if _, err := iprot.ReadStructBegin(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
-	}
-
-	var issetQuery bool = false
-	var issetRangeStart bool = false
-	var issetRangeEnd bool = false
-	var issetNameSpace bool = false
+	var issetWriteNewSeriesBackoffDuration bool = false
+	var issetDurationType bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -8962,38 +9538,12 @@ func (p *QueryRequest) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetQuery = true
+			issetWriteNewSeriesBackoffDuration = true
 		case 2:
 			if err := p.ReadField2(iprot); err != nil {
 				return err
 			}
-			issetRangeStart = true
-		case 3:
-			if err := p.ReadField3(iprot); err != nil {
-				return err
-			}
-			issetRangeEnd = true
-		case 4:
-			if err := p.ReadField4(iprot); err != nil {
-				return err
-			}
-			issetNameSpace = true
-		case 5:
-			if err := p.ReadField5(iprot); err != nil {
-				return err
-			}
-		case 6:
-			if err := p.ReadField6(iprot); err != nil {
-				return err
-			}
-		case 7:
-			if err := p.ReadField7(iprot); err != nil {
-				return err
-			}
-		case 8:
-			if err := p.ReadField8(iprot); err != nil {
-				return err
-			}
+			issetDurationType = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -9006,96 +9556,36 @@ func (p *QueryRequest) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetQuery {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Query is not set"))
-	}
-	if !issetRangeStart {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field RangeStart is not set"))
-	}
-	if !issetRangeEnd {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field RangeEnd is not set"))
-	}
-	if !issetNameSpace {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field NameSpace is not set"))
-	}
-	return nil
-}
-
-func (p *QueryRequest) ReadField1(iprot thrift.TProtocol) error {
-	p.Query = &Query{}
-	if err := p.Query.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Query), err)
-	}
-	return nil
-}
-
-func (p *QueryRequest) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(); err != nil {
-		return thrift.PrependError("error reading field 2: ", err)
-	} else {
-		p.RangeStart = v
-	}
-	return nil
-}
-
-func (p *QueryRequest) ReadField3(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(); err != nil {
-		return thrift.PrependError("error reading field 3: ", err)
-	} else {
-		p.RangeEnd = v
+	if !issetWriteNewSeriesBackoffDuration {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesBackoffDuration is not set"))
 	}
-	return nil
-}
-
-func (p *QueryRequest) ReadField4(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 4: ", err)
-	} else {
-		p.NameSpace = v
+	if !issetDurationType {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field DurationType is not set"))
 	}
 	return nil
 }
 
-func (p *QueryRequest) ReadField5(iprot thrift.TProtocol) error {
+func (p *NodeWriteNewSeriesBackoffDurationResult_) ReadField1(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI64(); err != nil {
-		return thrift.PrependError("error reading field 5: ", err)
-	} else {
-		p.Limit = &v
-	}
-	return nil
-}
-
-func (p *QueryRequest) ReadField6(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBool(); err != nil {
-		return thrift.PrependError("error reading field 6: ", err)
-	} else {
-		p.NoData = &v
-	}
-	return nil
-}
-
-func (p *QueryRequest) ReadField7(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(); err != nil {
-		return thrift.PrependError("error reading field 7: ", err)
+		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		temp := TimeType(v)
-		p.RangeType = temp
+		p.WriteNewSeriesBackoffDuration = v
 	}
 	return nil
 }
 
-func (p *QueryRequest) ReadField8(iprot thrift.TProtocol) error {
+func (p *NodeWriteNewSeriesBackoffDurationResult_) ReadField2(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI32(); err != nil {
-		return thrift.PrependError("error reading field 8: ", err)
+		return thrift.PrependError("error reading field 2: ", err)
 	} else {
 		temp := TimeType(v)
-		p.ResultTimeType = temp
+		p.DurationType = temp
 	}
 	return nil
 }
 
-func (p *QueryRequest) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("QueryRequest"); err != nil {
+func (p *NodeWriteNewSeriesBackoffDurationResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodeWriteNewSeriesBackoffDurationResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -9105,24 +9595,6 @@ func (p *QueryRequest) Write(oprot thrift.TProtocol) error {
 		if err := p.writeField2(oprot); err != nil {
 			return err
 		}
-		if err := p.writeField3(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField4(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField5(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField6(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField7(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField8(oprot); err != nil {
-			return err
-		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -9133,151 +9605,72 @@ func (p *QueryRequest) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *QueryRequest) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("query", thrift.STRUCT, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:query: ", p), err)
+func (p *NodeWriteNewSeriesBackoffDurationResult_) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("writeNewSeriesBackoffDuration", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesBackoffDuration: ", p), err)
 	}
-	if err := p.Query.Write(oprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Query), err)
+	if err := oprot.WriteI64(int64(p.WriteNewSeriesBackoffDuration)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesBackoffDuration (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:query: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesBackoffDuration: ", p), err)
 	}
 	return err
 }
 
-func (p *QueryRequest) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("rangeStart", thrift.I64, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:rangeStart: ", p), err)
+func (p *NodeWriteNewSeriesBackoffDurationResult_) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("durationType", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:durationType: ", p), err)
 	}
-	if err := oprot.WriteI64(int64(p.RangeStart)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.rangeStart (2) field write error: ", p), err)
+	if err := oprot.WriteI32(int32(p.DurationType)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.durationType (2) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:rangeStart: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:durationType: ", p), err)
 	}
 	return err
 }
 
-func (p *QueryRequest) writeField3(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("rangeEnd", thrift.I64, 3); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:rangeEnd: ", p), err)
-	}
-	if err := oprot.WriteI64(int64(p.RangeEnd)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.rangeEnd (3) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:rangeEnd: ", p), err)
+func (p *NodeWriteNewSeriesBackoffDurationResult_) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	return err
+	return fmt.Sprintf("NodeWriteNewSeriesBackoffDurationResult_(%+v)", *p)
 }
 
-func (p *QueryRequest) writeField4(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("nameSpace", thrift.STRING, 4); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:nameSpace: ", p), err)
-	}
-	if err := oprot.WriteString(string(p.NameSpace)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.nameSpace (4) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:nameSpace: ", p), err)
-	}
-	return err
+// Attributes:
+//  - WriteNewSeriesBackoffDuration
+//  - DurationType
+type NodeSetWriteNewSeriesBackoffDurationRequest struct {
+	WriteNewSeriesBackoffDuration int64    `thrift:"writeNewSeriesBackoffDuration,1,required" db:"writeNewSeriesBackoffDuration" json:"writeNewSeriesBackoffDuration"`
+	DurationType                  TimeType `thrift:"durationType,2" db:"durationType" json:"durationType,omitempty"`
 }
 
-func (p *QueryRequest) writeField5(oprot thrift.TProtocol) (err error) {
-	if p.IsSetLimit() {
-		if err := oprot.WriteFieldBegin("limit", thrift.I64, 5); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:limit: ", p), err)
-		}
-		if err := oprot.WriteI64(int64(*p.Limit)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.limit (5) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:limit: ", p), err)
-		}
+func NewNodeSetWriteNewSeriesBackoffDurationRequest() *NodeSetWriteNewSeriesBackoffDurationRequest {
+	return &NodeSetWriteNewSeriesBackoffDurationRequest{
+		DurationType: 2,
 	}
-	return err
-}
-
-func (p *QueryRequest) writeField6(oprot thrift.TProtocol) (err error) {
-	if p.IsSetNoData() {
-		if err := oprot.WriteFieldBegin("noData", thrift.BOOL, 6); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:noData: ", p), err)
-		}
-		if err := oprot.WriteBool(bool(*p.NoData)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.noData (6) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:noData: ", p), err)
-		}
-	}
-	return err
-}
-
-func (p *QueryRequest) writeField7(oprot thrift.TProtocol) (err error) {
-	if p.IsSetRangeType() {
-		if err := oprot.WriteFieldBegin("rangeType", thrift.I32, 7); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:rangeType: ", p), err)
-		}
-		if err := oprot.WriteI32(int32(p.RangeType)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.rangeType (7) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:rangeType: ", p), err)
-		}
-	}
-	return err
-}
-
-func (p *QueryRequest) writeField8(oprot thrift.TProtocol) (err error) {
-	if p.IsSetResultTimeType() {
-		if err := oprot.WriteFieldBegin("resultTimeType", thrift.I32, 8); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:resultTimeType: ", p), err)
-		}
-		if err := oprot.WriteI32(int32(p.ResultTimeType)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.resultTimeType (8) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:resultTimeType: ", p), err)
-		}
-	}
-	return err
 }
 
-func (p *QueryRequest) String() string {
-	if p == nil {
-		return "<nil>"
-	}
-	return fmt.Sprintf("QueryRequest(%+v)", *p)
+func (p *NodeSetWriteNewSeriesBackoffDurationRequest) GetWriteNewSeriesBackoffDuration() int64 {
+	return p.WriteNewSeriesBackoffDuration
 }
 
-// Attributes:
-//  - Results
-//  - Exhaustive
-type QueryResult_ struct {
-	Results    []*QueryResultElement `thrift:"results,1,required" db:"results" json:"results"`
-	Exhaustive bool                  `thrift:"exhaustive,2,required" db:"exhaustive" json:"exhaustive"`
-}
+var NodeSetWriteNewSeriesBackoffDurationRequest_DurationType_DEFAULT TimeType = 2
 
-func NewQueryResult_() *QueryResult_ {
-	return &QueryResult_{}
+func (p *NodeSetWriteNewSeriesBackoffDurationRequest) GetDurationType() TimeType {
+	return p.DurationType
 }
-
-func (p *QueryResult_) GetResults() []*QueryResultElement {
-	return p.Results
+func (p *NodeSetWriteNewSeriesBackoffDurationRequest) IsSetDurationType() bool {
+	return p.DurationType != NodeSetWriteNewSeriesBackoffDurationRequest_DurationType_DEFAULT
 }
 
-func (p *QueryResult_) GetExhaustive() bool {
-	return p.Exhaustive
-}
-func (p *QueryResult_) Read(iprot thrift.TProtocol) error {
+func (p *NodeSetWriteNewSeriesBackoffDurationRequest) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetResults bool = false
-	var issetExhaustive bool = false
+	var issetWriteNewSeriesBackoffDuration bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -9292,12 +9685,11 @@ func (p *QueryResult_) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetResults = true
+			issetWriteNewSeriesBackoffDuration = true
 		case 2:
 			if err := p.ReadField2(iprot); err != nil {
 				return err
 			}
-			issetExhaustive = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -9310,46 +9702,33 @@ func (p *QueryResult_) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetResults {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Results is not set"))
-	}
-	if !issetExhaustive {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Exhaustive is not set"))
+	if !issetWriteNewSeriesBackoffDuration {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesBackoffDuration is not set"))
 	}
 	return nil
 }
 
-func (p *QueryResult_) ReadField1(iprot thrift.TProtocol) error {
-	_, size, err := iprot.ReadListBegin()
-	if err != nil {
-		return thrift.PrependError("error reading list begin: ", err)
-	}
-	tSlice := make([]*QueryResultElement, 0, size)
-	p.Results = tSlice
-	for i := 0; i < size; i++ {
-		_elem18 := &QueryResultElement{}
-		if err := _elem18.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem18), err)
-		}
-		p.Results = append(p.Results, _elem18)
-	}
-	if err := iprot.ReadListEnd(); err != nil {
-		return thrift.PrependError("error reading list end: ", err)
+func (p *NodeSetWriteNewSeriesBackoffDurationRequest) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.WriteNewSeriesBackoffDuration = v
 	}
 	return nil
 }
 
-func (p *QueryResult_) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBool(); err != nil {
+func (p *NodeSetWriteNewSeriesBackoffDurationRequest) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		p.Exhaustive = v
+		temp := TimeType(v)
+		p.DurationType = temp
 	}
 	return nil
 }
 
-func (p *QueryResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("QueryResult"); err != nil {
+func (p *NodeSetWriteNewSeriesBackoffDurationRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodeSetWriteNewSeriesBackoffDurationRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -9369,80 +9748,60 @@ func (p *QueryResult_) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *QueryResult_) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("results", thrift.LIST, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:results: ", p), err)
-	}
-	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Results)); err != nil {
-		return thrift.PrependError("error writing list begin: ", err)
-	}
-	for _, v := range p.Results {
-		if err := v.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
-		}
+func (p *NodeSetWriteNewSeriesBackoffDurationRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("writeNewSeriesBackoffDuration", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesBackoffDuration: ", p), err)
 	}
-	if err := oprot.WriteListEnd(); err != nil {
-		return thrift.PrependError("error writing list end: ", err)
+	if err := oprot.WriteI64(int64(p.WriteNewSeriesBackoffDuration)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesBackoffDuration (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:results: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesBackoffDuration: ", p), err)
 	}
 	return err
 }
 
-func (p *QueryResult_) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("exhaustive", thrift.BOOL, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:exhaustive: ", p), err)
-	}
-	if err := oprot.WriteBool(bool(p.Exhaustive)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.exhaustive (2) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:exhaustive: ", p), err)
+func (p *NodeSetWriteNewSeriesBackoffDurationRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDurationType() {
+		if err := oprot.WriteFieldBegin("durationType", thrift.I32, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:durationType: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(p.DurationType)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.durationType (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:durationType: ", p), err)
+		}
 	}
 	return err
 }
 
-func (p *QueryResult_) String() string {
+func (p *NodeSetWriteNewSeriesBackoffDurationRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("QueryResult_(%+v)", *p)
+	return fmt.Sprintf("NodeSetWriteNewSeriesBackoffDurationRequest(%+v)", *p)
 }
 
 // Attributes:
-//  - ID
-//  - Tags
-//  - Datapoints
-type QueryResultElement struct {
-	ID         string       `thrift:"id,1,required" db:"id" json:"id"`
-	Tags       []*Tag       `thrift:"tags,2,required" db:"tags" json:"tags"`
-	Datapoints []*Datapoint `thrift:"datapoints,3,required" db:"datapoints" json:"datapoints"`
-}
-
-func NewQueryResultElement() *QueryResultElement {
-	return &QueryResultElement{}
-}
-
-func (p *QueryResultElement) GetID() string {
-	return p.ID
+//  - WriteNewSeriesLimitPerShardPerSecond
+type NodeWriteNewSeriesLimitPerShardPerSecondResult_ struct {
+	WriteNewSeriesLimitPerShardPerSecond int64 `thrift:"writeNewSeriesLimitPerShardPerSecond,1,required" db:"writeNewSeriesLimitPerShardPerSecond" json:"writeNewSeriesLimitPerShardPerSecond"`
 }
 
-func (p *QueryResultElement) GetTags() []*Tag {
-	return p.Tags
+func NewNodeWriteNewSeriesLimitPerShardPerSecondResult_() *NodeWriteNewSeriesLimitPerShardPerSecondResult_ {
+	return &NodeWriteNewSeriesLimitPerShardPerSecondResult_{}
 }
 
-func (p *QueryResultElement) GetDatapoints() []*Datapoint {
-	return p.Datapoints
+func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) GetWriteNewSeriesLimitPerShardPerSecond() int64 {
+	return p.WriteNewSeriesLimitPerShardPerSecond
 }
-func (p *QueryResultElement) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetID bool = false
-	var issetTags bool = false
-	var issetDatapoints bool = false
+	var issetWriteNewSeriesLimitPerShardPerSecond bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -9457,17 +9816,7 @@ func (p *QueryResultElement) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetID = true
-		case 2:
-			if err := p.ReadField2(iprot); err != nil {
-				return err
-			}
-			issetTags = true
-		case 3:
-			if err := p.ReadField3(iprot); err != nil {
-				return err
-			}
-			issetDatapoints = true
+			issetWriteNewSeriesLimitPerShardPerSecond = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -9480,181 +9829,78 @@ func (p *QueryResultElement) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetID {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
-	}
-	if !issetTags {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Tags is not set"))
-	}
-	if !issetDatapoints {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Datapoints is not set"))
+	if !issetWriteNewSeriesLimitPerShardPerSecond {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesLimitPerShardPerSecond is not set"))
 	}
 	return nil
 }
 
-func (p *QueryResultElement) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
+func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.ID = v
+		p.WriteNewSeriesLimitPerShardPerSecond = v
 	}
 	return nil
 }
 
-func (p *QueryResultElement) ReadField2(iprot thrift.TProtocol) error {
-	_, size, err := iprot.ReadListBegin()
-	if err != nil {
-		return thrift.PrependError("error reading list begin: ", err)
+func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodeWriteNewSeriesLimitPerShardPerSecondResult"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
-	tSlice := make([]*Tag, 0, size)
-	p.Tags = tSlice
-	for i := 0; i < size; i++ {
-		_elem19 := &Tag{}
-		if err := _elem19.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem19), err)
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
 		}
-		p.Tags = append(p.Tags, _elem19)
 	}
-	if err := iprot.ReadListEnd(); err != nil {
-		return thrift.PrependError("error reading list end: ", err)
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
 	return nil
 }
 
-func (p *QueryResultElement) ReadField3(iprot thrift.TProtocol) error {
-	_, size, err := iprot.ReadListBegin()
-	if err != nil {
-		return thrift.PrependError("error reading list begin: ", err)
+func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("writeNewSeriesLimitPerShardPerSecond", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesLimitPerShardPerSecond: ", p), err)
 	}
-	tSlice := make([]*Datapoint, 0, size)
-	p.Datapoints = tSlice
-	for i := 0; i < size; i++ {
-		_elem20 := &Datapoint{
-			TimestampTimeType: 0,
-		}
-		if err := _elem20.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem20), err)
-		}
-		p.Datapoints = append(p.Datapoints, _elem20)
-	}
-	if err := iprot.ReadListEnd(); err != nil {
-		return thrift.PrependError("error reading list end: ", err)
-	}
-	return nil
-}
-
-func (p *QueryResultElement) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("QueryResultElement"); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
-	}
-	if p != nil {
-		if err := p.writeField1(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField2(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField3(oprot); err != nil {
-			return err
-		}
-	}
-	if err := oprot.WriteFieldStop(); err != nil {
-		return thrift.PrependError("write field stop error: ", err)
-	}
-	if err := oprot.WriteStructEnd(); err != nil {
-		return thrift.PrependError("write struct stop error: ", err)
-	}
-	return nil
-}
-
-func (p *QueryResultElement) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
-	}
-	if err := oprot.WriteString(string(p.ID)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
-	}
-	return err
-}
-
-func (p *QueryResultElement) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("tags", thrift.LIST, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:tags: ", p), err)
-	}
-	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Tags)); err != nil {
-		return thrift.PrependError("error writing list begin: ", err)
-	}
-	for _, v := range p.Tags {
-		if err := v.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
-		}
-	}
-	if err := oprot.WriteListEnd(); err != nil {
-		return thrift.PrependError("error writing list end: ", err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:tags: ", p), err)
-	}
-	return err
-}
-
-func (p *QueryResultElement) writeField3(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("datapoints", thrift.LIST, 3); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:datapoints: ", p), err)
-	}
-	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Datapoints)); err != nil {
-		return thrift.PrependError("error writing list begin: ", err)
-	}
-	for _, v := range p.Datapoints {
-		if err := v.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
-		}
-	}
-	if err := oprot.WriteListEnd(); err != nil {
-		return thrift.PrependError("error writing list end: ", err)
+	if err := oprot.WriteI64(int64(p.WriteNewSeriesLimitPerShardPerSecond)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesLimitPerShardPerSecond (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:datapoints: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesLimitPerShardPerSecond: ", p), err)
 	}
 	return err
 }
 
-func (p *QueryResultElement) String() string {
+func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("QueryResultElement(%+v)", *p)
+	return fmt.Sprintf("NodeWriteNewSeriesLimitPerShardPerSecondResult_(%+v)", *p)
 }
 
 // Attributes:
-//  - Field
-//  - Term
-type TermQuery struct {
-	Field string `thrift:"field,1,required" db:"field" json:"field"`
-	Term  string `thrift:"term,2,required" db:"term" json:"term"`
-}
-
-func NewTermQuery() *TermQuery {
-	return &TermQuery{}
+//  - WriteNewSeriesLimitPerShardPerSecond
+type NodeSetWriteNewSeriesLimitPerShardPerSecondRequest struct {
+	WriteNewSeriesLimitPerShardPerSecond int64 `thrift:"writeNewSeriesLimitPerShardPerSecond,1,required" db:"writeNewSeriesLimitPerShardPerSecond" json:"writeNewSeriesLimitPerShardPerSecond"`
 }
 
-func (p *TermQuery) GetField() string {
-	return p.Field
+func NewNodeSetWriteNewSeriesLimitPerShardPerSecondRequest() *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest {
+	return &NodeSetWriteNewSeriesLimitPerShardPerSecondRequest{}
 }
 
-func (p *TermQuery) GetTerm() string {
-	return p.Term
+func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) GetWriteNewSeriesLimitPerShardPerSecond() int64 {
+	return p.WriteNewSeriesLimitPerShardPerSecond
 }
-func (p *TermQuery) Read(iprot thrift.TProtocol) error {
+func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetField bool = false
-	var issetTerm bool = false
+	var issetWriteNewSeriesLimitPerShardPerSecond bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -9669,12 +9915,7 @@ func (p *TermQuery) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetField = true
-		case 2:
-			if err := p.ReadField2(iprot); err != nil {
-				return err
-			}
-			issetTerm = true
+			issetWriteNewSeriesLimitPerShardPerSecond = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -9687,44 +9928,29 @@ func (p *TermQuery) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetField {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Field is not set"))
-	}
-	if !issetTerm {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Term is not set"))
+	if !issetWriteNewSeriesLimitPerShardPerSecond {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesLimitPerShardPerSecond is not set"))
 	}
 	return nil
 }
 
-func (p *TermQuery) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
+func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.Field = v
-	}
-	return nil
-}
-
-func (p *TermQuery) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 2: ", err)
-	} else {
-		p.Term = v
+		p.WriteNewSeriesLimitPerShardPerSecond = v
 	}
 	return nil
 }
 
-func (p *TermQuery) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("TermQuery"); err != nil {
+func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodeSetWriteNewSeriesLimitPerShardPerSecondRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
-		if err := p.writeField2(oprot); err != nil {
-			return err
-		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -9735,65 +9961,52 @@ func (p *TermQuery) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *TermQuery) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("field", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:field: ", p), err)
-	}
-	if err := oprot.WriteString(string(p.Field)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.field (1) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:field: ", p), err)
-	}
-	return err
-}
-
-func (p *TermQuery) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("term", thrift.STRING, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:term: ", p), err)
+func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("writeNewSeriesLimitPerShardPerSecond", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesLimitPerShardPerSecond: ", p), err)
 	}
-	if err := oprot.WriteString(string(p.Term)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.term (2) field write error: ", p), err)
+	if err := oprot.WriteI64(int64(p.WriteNewSeriesLimitPerShardPerSecond)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesLimitPerShardPerSecond (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:term: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesLimitPerShardPerSecond: ", p), err)
 	}
 	return err
 }
 
-func (p *TermQuery) String() string {
+func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TermQuery(%+v)", *p)
+	return fmt.Sprintf("NodeSetWriteNewSeriesLimitPerShardPerSecondRequest(%+v)", *p)
 }
 
 // Attributes:
-//  - Field
-//  - Regexp
-type RegexpQuery struct {
-	Field  string `thrift:"field,1,required" db:"field" json:"field"`
-	Regexp string `thrift:"regexp,2,required" db:"regexp" json:"regexp"`
+//  - Ok
+//  - Status
+type HealthResult_ struct {
+	Ok     bool   `thrift:"ok,1,required" db:"ok" json:"ok"`
+	Status string `thrift:"status,2,required" db:"status" json:"status"`
 }
 
-func NewRegexpQuery() *RegexpQuery {
-	return &RegexpQuery{}
+func NewHealthResult_() *HealthResult_ {
+	return &HealthResult_{}
 }
 
-func (p *RegexpQuery) GetField() string {
-	return p.Field
+func (p *HealthResult_) GetOk() bool {
+	return p.Ok
 }
 
-func (p *RegexpQuery) GetRegexp() string {
-	return p.Regexp
+func (p *HealthResult_) GetStatus() string {
+	return p.Status
 }
-func (p *RegexpQuery) Read(iprot thrift.TProtocol) error {
+func (p *HealthResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetField bool = false
-	var issetRegexp bool = false
+	var issetOk bool = false
+	var issetStatus bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -9808,12 +10021,12 @@ func (p *RegexpQuery) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetField = true
+			issetOk = true
 		case 2:
 			if err := p.ReadField2(iprot); err != nil {
 				return err
 			}
-			issetRegexp = true
+			issetStatus = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -9826,35 +10039,35 @@ func (p *RegexpQuery) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetField {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Field is not set"))
+	if !issetOk {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Ok is not set"))
 	}
-	if !issetRegexp {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Regexp is not set"))
+	if !issetStatus {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Status is not set"))
 	}
 	return nil
 }
 
-func (p *RegexpQuery) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
+func (p *HealthResult_) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.Field = v
+		p.Ok = v
 	}
 	return nil
 }
 
-func (p *RegexpQuery) ReadField2(iprot thrift.TProtocol) error {
+func (p *HealthResult_) ReadField2(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		p.Regexp = v
+		p.Status = v
 	}
 	return nil
 }
 
-func (p *RegexpQuery) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("RegexpQuery"); err != nil {
+func (p *HealthResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("HealthResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -9874,67 +10087,146 @@ func (p *RegexpQuery) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *RegexpQuery) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("field", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:field: ", p), err)
+func (p *HealthResult_) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("ok", thrift.BOOL, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ok: ", p), err)
 	}
-	if err := oprot.WriteString(string(p.Field)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.field (1) field write error: ", p), err)
+	if err := oprot.WriteBool(bool(p.Ok)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.ok (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:field: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ok: ", p), err)
 	}
 	return err
 }
 
-func (p *RegexpQuery) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("regexp", thrift.STRING, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:regexp: ", p), err)
-	}
-	if err := oprot.WriteString(string(p.Regexp)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.regexp (2) field write error: ", p), err)
+func (p *HealthResult_) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("status", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:status: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Status)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.status (2) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:regexp: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:status: ", p), err)
 	}
 	return err
 }
 
-func (p *RegexpQuery) String() string {
+func (p *HealthResult_) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("RegexpQuery(%+v)", *p)
+	return fmt.Sprintf("HealthResult_(%+v)", *p)
 }
 
 // Attributes:
 //  - Query
-type NegationQuery struct {
-	Query *Query `thrift:"query,1,required" db:"query" json:"query"`
+//  - RangeStart
+//  - RangeEnd
+//  - NameSpace
+//  - Limit
+//  - NoData
+//  - RangeType
+//  - ResultTimeType
+type QueryRequest struct {
+	Query          *Query   `thrift:"query,1,required" db:"query" json:"query"`
+	RangeStart     int64    `thrift:"rangeStart,2,required" db:"rangeStart" json:"rangeStart"`
+	RangeEnd       int64    `thrift:"rangeEnd,3,required" db:"rangeEnd" json:"rangeEnd"`
+	NameSpace      string   `thrift:"nameSpace,4,required" db:"nameSpace" json:"nameSpace"`
+	Limit          *int64   `thrift:"limit,5" db:"limit" json:"limit,omitempty"`
+	NoData         *bool    `thrift:"noData,6" db:"noData" json:"noData,omitempty"`
+	RangeType      TimeType `thrift:"rangeType,7" db:"rangeType" json:"rangeType,omitempty"`
+	ResultTimeType TimeType `thrift:"resultTimeType,8" db:"resultTimeType" json:"resultTimeType,omitempty"`
 }
 
-func NewNegationQuery() *NegationQuery {
-	return &NegationQuery{}
+func NewQueryRequest() *QueryRequest {
+	return &QueryRequest{
+		RangeType: 0,
+
+		ResultTimeType: 0,
+	}
 }
 
-var NegationQuery_Query_DEFAULT *Query
+var QueryRequest_Query_DEFAULT *Query
 
-func (p *NegationQuery) GetQuery() *Query {
+func (p *QueryRequest) GetQuery() *Query {
 	if !p.IsSetQuery() {
-		return NegationQuery_Query_DEFAULT
+		return QueryRequest_Query_DEFAULT
 	}
 	return p.Query
 }
-func (p *NegationQuery) IsSetQuery() bool {
+
+func (p *QueryRequest) GetRangeStart() int64 {
+	return p.RangeStart
+}
+
+func (p *QueryRequest) GetRangeEnd() int64 {
+	return p.RangeEnd
+}
+
+func (p *QueryRequest) GetNameSpace() string {
+	return p.NameSpace
+}
+
+var QueryRequest_Limit_DEFAULT int64
+
+func (p *QueryRequest) GetLimit() int64 {
+	if !p.IsSetLimit() {
+		return QueryRequest_Limit_DEFAULT
+	}
+	return *p.Limit
+}
+
+var QueryRequest_NoData_DEFAULT bool
+
+func (p *QueryRequest) GetNoData() bool {
+	if !p.IsSetNoData() {
+		return QueryRequest_NoData_DEFAULT
+	}
+	return *p.NoData
+}
+
+var QueryRequest_RangeType_DEFAULT TimeType = 0
+
+func (p *QueryRequest) GetRangeType() TimeType {
+	return p.RangeType
+}
+
+var QueryRequest_ResultTimeType_DEFAULT TimeType = 0
+
+func (p *QueryRequest) GetResultTimeType() TimeType {
+	return p.ResultTimeType
+}
+func (p *QueryRequest) IsSetQuery() bool {
 	return p.Query != nil
 }
 
-func (p *NegationQuery) Read(iprot thrift.TProtocol) error {
+func (p *QueryRequest) IsSetLimit() bool {
+	return p.Limit != nil
+}
+
+func (p *QueryRequest) IsSetNoData() bool {
+	return p.NoData != nil
+}
+
+func (p *QueryRequest) IsSetRangeType() bool {
+	return p.RangeType != QueryRequest_RangeType_DEFAULT
+}
+
+func (p *QueryRequest) IsSetResultTimeType() bool {
+	return p.ResultTimeType != QueryRequest_ResultTimeType_DEFAULT
+}
+
+func (p *QueryRequest) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
 	var issetQuery bool = false
+	var issetRangeStart bool = false
+	var issetRangeEnd bool = false
+	var issetNameSpace bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -9950,6 +10242,37 @@ func (p *NegationQuery) Read(iprot thrift.TProtocol) error {
 				return err
 			}
 			issetQuery = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+			issetRangeStart = true
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
+			issetRangeEnd = true
+		case 4:
+			if err := p.ReadField4(iprot); err != nil {
+				return err
+			}
+			issetNameSpace = true
+		case 5:
+			if err := p.ReadField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.ReadField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.ReadField7(iprot); err != nil {
+				return err
+			}
+		case 8:
+			if err := p.ReadField8(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -9965,10 +10288,19 @@ func (p *NegationQuery) Read(iprot thrift.TProtocol) error {
 	if !issetQuery {
 		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Query is not set"))
 	}
+	if !issetRangeStart {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field RangeStart is not set"))
+	}
+	if !issetRangeEnd {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field RangeEnd is not set"))
+	}
+	if !issetNameSpace {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field NameSpace is not set"))
+	}
 	return nil
 }
 
-func (p *NegationQuery) ReadField1(iprot thrift.TProtocol) error {
+func (p *QueryRequest) ReadField1(iprot thrift.TProtocol) error {
 	p.Query = &Query{}
 	if err := p.Query.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Query), err)
@@ -9976,14 +10308,100 @@ func (p *NegationQuery) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NegationQuery) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NegationQuery"); err != nil {
+func (p *QueryRequest) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.RangeStart = v
+	}
+	return nil
+}
+
+func (p *QueryRequest) ReadField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.RangeEnd = v
+	}
+	return nil
+}
+
+func (p *QueryRequest) ReadField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.NameSpace = v
+	}
+	return nil
+}
+
+func (p *QueryRequest) ReadField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Limit = &v
+	}
+	return nil
+}
+
+func (p *QueryRequest) ReadField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.NoData = &v
+	}
+	return nil
+}
+
+func (p *QueryRequest) ReadField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		temp := TimeType(v)
+		p.RangeType = temp
+	}
+	return nil
+}
+
+func (p *QueryRequest) ReadField8(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 8: ", err)
+	} else {
+		temp := TimeType(v)
+		p.ResultTimeType = temp
+	}
+	return nil
+}
+
+func (p *QueryRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("QueryRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField2(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField3(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField4(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField5(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField6(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField7(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField8(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -9994,7 +10412,7 @@ func (p *NegationQuery) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NegationQuery) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *QueryRequest) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("query", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:query: ", p), err)
 	}
@@ -10007,32 +10425,138 @@ func (p *NegationQuery) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NegationQuery) String() string {
-	if p == nil {
-		return "<nil>"
+func (p *QueryRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("rangeStart", thrift.I64, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:rangeStart: ", p), err)
 	}
-	return fmt.Sprintf("NegationQuery(%+v)", *p)
-}
-
-// Attributes:
-//  - Queries
-type ConjunctionQuery struct {
-	Queries []*Query `thrift:"queries,1,required" db:"queries" json:"queries"`
+	if err := oprot.WriteI64(int64(p.RangeStart)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.rangeStart (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:rangeStart: ", p), err)
+	}
+	return err
 }
 
-func NewConjunctionQuery() *ConjunctionQuery {
-	return &ConjunctionQuery{}
+func (p *QueryRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("rangeEnd", thrift.I64, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:rangeEnd: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.RangeEnd)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.rangeEnd (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:rangeEnd: ", p), err)
+	}
+	return err
 }
 
-func (p *ConjunctionQuery) GetQueries() []*Query {
-	return p.Queries
+func (p *QueryRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("nameSpace", thrift.STRING, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:nameSpace: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.NameSpace)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.nameSpace (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:nameSpace: ", p), err)
+	}
+	return err
 }
-func (p *ConjunctionQuery) Read(iprot thrift.TProtocol) error {
+
+func (p *QueryRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLimit() {
+		if err := oprot.WriteFieldBegin("limit", thrift.I64, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:limit: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.Limit)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.limit (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:limit: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *QueryRequest) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetNoData() {
+		if err := oprot.WriteFieldBegin("noData", thrift.BOOL, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:noData: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.NoData)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.noData (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:noData: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *QueryRequest) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRangeType() {
+		if err := oprot.WriteFieldBegin("rangeType", thrift.I32, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:rangeType: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(p.RangeType)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.rangeType (7) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:rangeType: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *QueryRequest) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetResultTimeType() {
+		if err := oprot.WriteFieldBegin("resultTimeType", thrift.I32, 8); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:resultTimeType: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(p.ResultTimeType)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.resultTimeType (8) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:resultTimeType: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *QueryRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("QueryRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - Results
+//  - Exhaustive
+type QueryResult_ struct {
+	Results    []*QueryResultElement `thrift:"results,1,required" db:"results" json:"results"`
+	Exhaustive bool                  `thrift:"exhaustive,2,required" db:"exhaustive" json:"exhaustive"`
+}
+
+func NewQueryResult_() *QueryResult_ {
+	return &QueryResult_{}
+}
+
+func (p *QueryResult_) GetResults() []*QueryResultElement {
+	return p.Results
+}
+
+func (p *QueryResult_) GetExhaustive() bool {
+	return p.Exhaustive
+}
+func (p *QueryResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetQueries bool = false
+	var issetResults bool = false
+	var issetExhaustive bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -10047,7 +10571,12 @@ func (p *ConjunctionQuery) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetQueries = true
+			issetResults = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+			issetExhaustive = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -10060,25 +10589,28 @@ func (p *ConjunctionQuery) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetQueries {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Queries is not set"))
+	if !issetResults {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Results is not set"))
+	}
+	if !issetExhaustive {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Exhaustive is not set"))
 	}
 	return nil
 }
 
-func (p *ConjunctionQuery) ReadField1(iprot thrift.TProtocol) error {
+func (p *QueryResult_) ReadField1(iprot thrift.TProtocol) error {
 	_, size, err := iprot.ReadListBegin()
 	if err != nil {
 		return thrift.PrependError("error reading list begin: ", err)
 	}
-	tSlice := make([]*Query, 0, size)
-	p.Queries = tSlice
+	tSlice := make([]*QueryResultElement, 0, size)
+	p.Results = tSlice
 	for i := 0; i < size; i++ {
-		_elem21 := &Query{}
-		if err := _elem21.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem21), err)
+		_elem18 := &QueryResultElement{}
+		if err := _elem18.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem18), err)
 		}
-		p.Queries = append(p.Queries, _elem21)
+		p.Results = append(p.Results, _elem18)
 	}
 	if err := iprot.ReadListEnd(); err != nil {
 		return thrift.PrependError("error reading list end: ", err)
@@ -10086,14 +10618,26 @@ func (p *ConjunctionQuery) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *ConjunctionQuery) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("ConjunctionQuery"); err != nil {
+func (p *QueryResult_) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Exhaustive = v
+	}
+	return nil
+}
+
+func (p *QueryResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("QueryResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField2(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -10104,14 +10648,14 @@ func (p *ConjunctionQuery) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *ConjunctionQuery) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("queries", thrift.LIST, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:queries: ", p), err)
+func (p *QueryResult_) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("results", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:results: ", p), err)
 	}
-	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Queries)); err != nil {
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Results)); err != nil {
 		return thrift.PrependError("error writing list begin: ", err)
 	}
-	for _, v := range p.Queries {
+	for _, v := range p.Results {
 		if err := v.Write(oprot); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
 		}
@@ -10120,37 +10664,64 @@ func (p *ConjunctionQuery) writeField1(oprot thrift.TProtocol) (err error) {
 		return thrift.PrependError("error writing list end: ", err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:queries: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:results: ", p), err)
 	}
 	return err
 }
 
-func (p *ConjunctionQuery) String() string {
+func (p *QueryResult_) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("exhaustive", thrift.BOOL, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:exhaustive: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Exhaustive)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.exhaustive (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:exhaustive: ", p), err)
+	}
+	return err
+}
+
+func (p *QueryResult_) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("ConjunctionQuery(%+v)", *p)
+	return fmt.Sprintf("QueryResult_(%+v)", *p)
 }
 
 // Attributes:
-//  - Queries
-type DisjunctionQuery struct {
-	Queries []*Query `thrift:"queries,1,required" db:"queries" json:"queries"`
+//  - ID
+//  - Tags
+//  - Datapoints
+type QueryResultElement struct {
+	ID         string       `thrift:"id,1,required" db:"id" json:"id"`
+	Tags       []*Tag       `thrift:"tags,2,required" db:"tags" json:"tags"`
+	Datapoints []*Datapoint `thrift:"datapoints,3,required" db:"datapoints" json:"datapoints"`
 }
 
-func NewDisjunctionQuery() *DisjunctionQuery {
-	return &DisjunctionQuery{}
+func NewQueryResultElement() *QueryResultElement {
+	return &QueryResultElement{}
 }
 
-func (p *DisjunctionQuery) GetQueries() []*Query {
-	return p.Queries
+func (p *QueryResultElement) GetID() string {
+	return p.ID
 }
-func (p *DisjunctionQuery) Read(iprot thrift.TProtocol) error {
+
+func (p *QueryResultElement) GetTags() []*Tag {
+	return p.Tags
+}
+
+func (p *QueryResultElement) GetDatapoints() []*Datapoint {
+	return p.Datapoints
+}
+func (p *QueryResultElement) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetQueries bool = false
+	var issetID bool = false
+	var issetTags bool = false
+	var issetDatapoints bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -10165,7 +10736,17 @@ func (p *DisjunctionQuery) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetQueries = true
+			issetID = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+			issetTags = true
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
+			issetDatapoints = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -10178,25 +10759,40 @@ func (p *DisjunctionQuery) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetQueries {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Queries is not set"))
+	if !issetID {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
+	}
+	if !issetTags {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Tags is not set"))
+	}
+	if !issetDatapoints {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Datapoints is not set"))
 	}
 	return nil
 }
 
-func (p *DisjunctionQuery) ReadField1(iprot thrift.TProtocol) error {
-	_, size, err := iprot.ReadListBegin()
+func (p *QueryResultElement) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.ID = v
+	}
+	return nil
+}
+
+func (p *QueryResultElement) ReadField2(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
 	if err != nil {
 		return thrift.PrependError("error reading list begin: ", err)
 	}
-	tSlice := make([]*Query, 0, size)
-	p.Queries = tSlice
+	tSlice := make([]*Tag, 0, size)
+	p.Tags = tSlice
 	for i := 0; i < size; i++ {
-		_elem22 := &Query{}
-		if err := _elem22.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem22), err)
+		_elem19 := &Tag{}
+		if err := _elem19.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem19), err)
 		}
-		p.Queries = append(p.Queries, _elem22)
+		p.Tags = append(p.Tags, _elem19)
 	}
 	if err := iprot.ReadListEnd(); err != nil {
 		return thrift.PrependError("error reading list end: ", err)
@@ -10204,14 +10800,42 @@ func (p *DisjunctionQuery) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *DisjunctionQuery) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("DisjunctionQuery"); err != nil {
+func (p *QueryResultElement) ReadField3(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*Datapoint, 0, size)
+	p.Datapoints = tSlice
+	for i := 0; i < size; i++ {
+		_elem20 := &Datapoint{
+			TimestampTimeType: 0,
+		}
+		if err := _elem20.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem20), err)
+		}
+		p.Datapoints = append(p.Datapoints, _elem20)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *QueryResultElement) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("QueryResultElement"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField2(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField3(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -10222,14 +10846,27 @@ func (p *DisjunctionQuery) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *DisjunctionQuery) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("queries", thrift.LIST, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:queries: ", p), err)
+func (p *QueryResultElement) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
 	}
-	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Queries)); err != nil {
+	if err := oprot.WriteString(string(p.ID)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
+	}
+	return err
+}
+
+func (p *QueryResultElement) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("tags", thrift.LIST, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:tags: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Tags)); err != nil {
 		return thrift.PrependError("error writing list begin: ", err)
 	}
-	for _, v := range p.Queries {
+	for _, v := range p.Tags {
 		if err := v.Write(oprot); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
 		}
@@ -10238,105 +10875,66 @@ func (p *DisjunctionQuery) writeField1(oprot thrift.TProtocol) (err error) {
 		return thrift.PrependError("error writing list end: ", err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:queries: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:tags: ", p), err)
 	}
 	return err
 }
 
-func (p *DisjunctionQuery) String() string {
-	if p == nil {
-		return "<nil>"
+func (p *QueryResultElement) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("datapoints", thrift.LIST, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:datapoints: ", p), err)
 	}
-	return fmt.Sprintf("DisjunctionQuery(%+v)", *p)
-}
-
-// Attributes:
-//  - Term
-//  - Regexp
-//  - Negation
-//  - Conjunction
-//  - Disjunction
-type Query struct {
-	Term        *TermQuery        `thrift:"term,1" db:"term" json:"term,omitempty"`
-	Regexp      *RegexpQuery      `thrift:"regexp,2" db:"regexp" json:"regexp,omitempty"`
-	Negation    *NegationQuery    `thrift:"negation,3" db:"negation" json:"negation,omitempty"`
-	Conjunction *ConjunctionQuery `thrift:"conjunction,4" db:"conjunction" json:"conjunction,omitempty"`
-	Disjunction *DisjunctionQuery `thrift:"disjunction,5" db:"disjunction" json:"disjunction,omitempty"`
-}
-
-func NewQuery() *Query {
-	return &Query{}
-}
-
-var Query_Term_DEFAULT *TermQuery
-
-func (p *Query) GetTerm() *TermQuery {
-	if !p.IsSetTerm() {
-		return Query_Term_DEFAULT
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Datapoints)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
 	}
-	return p.Term
-}
-
-var Query_Regexp_DEFAULT *RegexpQuery
-
-func (p *Query) GetRegexp() *RegexpQuery {
-	if !p.IsSetRegexp() {
-		return Query_Regexp_DEFAULT
+	for _, v := range p.Datapoints {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
 	}
-	return p.Regexp
-}
-
-var Query_Negation_DEFAULT *NegationQuery
-
-func (p *Query) GetNegation() *NegationQuery {
-	if !p.IsSetNegation() {
-		return Query_Negation_DEFAULT
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
 	}
-	return p.Negation
-}
-
-var Query_Conjunction_DEFAULT *ConjunctionQuery
-
-func (p *Query) GetConjunction() *ConjunctionQuery {
-	if !p.IsSetConjunction() {
-		return Query_Conjunction_DEFAULT
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:datapoints: ", p), err)
 	}
-	return p.Conjunction
+	return err
 }
 
-var Query_Disjunction_DEFAULT *DisjunctionQuery
-
-func (p *Query) GetDisjunction() *DisjunctionQuery {
-	if !p.IsSetDisjunction() {
-		return Query_Disjunction_DEFAULT
+func (p *QueryResultElement) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	return p.Disjunction
-}
-func (p *Query) IsSetTerm() bool {
-	return p.Term != nil
+	return fmt.Sprintf("QueryResultElement(%+v)", *p)
 }
 
-func (p *Query) IsSetRegexp() bool {
-	return p.Regexp != nil
+// Attributes:
+//  - Field
+//  - Term
+type TermQuery struct {
+	Field string `thrift:"field,1,required" db:"field" json:"field"`
+	Term  string `thrift:"term,2,required" db:"term" json:"term"`
 }
 
-func (p *Query) IsSetNegation() bool {
-	return p.Negation != nil
+func NewTermQuery() *TermQuery {
+	return &TermQuery{}
 }
 
-func (p *Query) IsSetConjunction() bool {
-	return p.Conjunction != nil
+func (p *TermQuery) GetField() string {
+	return p.Field
 }
 
-func (p *Query) IsSetDisjunction() bool {
-	return p.Disjunction != nil
+func (p *TermQuery) GetTerm() string {
+	return p.Term
 }
-
-func (p *Query) Read(iprot thrift.TProtocol) error {
+func (p *TermQuery) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
+	var issetField bool = false
+	var issetTerm bool = false
+
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
@@ -10350,22 +10948,12 @@ func (p *Query) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
+			issetField = true
 		case 2:
 			if err := p.ReadField2(iprot); err != nil {
 				return err
 			}
-		case 3:
-			if err := p.ReadField3(iprot); err != nil {
-				return err
-			}
-		case 4:
-			if err := p.ReadField4(iprot); err != nil {
-				return err
-			}
-		case 5:
-			if err := p.ReadField5(iprot); err != nil {
-				return err
-			}
+			issetTerm = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -10378,51 +10966,35 @@ func (p *Query) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	return nil
-}
-
-func (p *Query) ReadField1(iprot thrift.TProtocol) error {
-	p.Term = &TermQuery{}
-	if err := p.Term.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Term), err)
-	}
-	return nil
-}
-
-func (p *Query) ReadField2(iprot thrift.TProtocol) error {
-	p.Regexp = &RegexpQuery{}
-	if err := p.Regexp.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Regexp), err)
+	if !issetField {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Field is not set"))
 	}
-	return nil
-}
-
-func (p *Query) ReadField3(iprot thrift.TProtocol) error {
-	p.Negation = &NegationQuery{}
-	if err := p.Negation.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Negation), err)
+	if !issetTerm {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Term is not set"))
 	}
 	return nil
 }
 
-func (p *Query) ReadField4(iprot thrift.TProtocol) error {
-	p.Conjunction = &ConjunctionQuery{}
-	if err := p.Conjunction.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Conjunction), err)
+func (p *TermQuery) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Field = v
 	}
 	return nil
 }
 
-func (p *Query) ReadField5(iprot thrift.TProtocol) error {
-	p.Disjunction = &DisjunctionQuery{}
-	if err := p.Disjunction.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Disjunction), err)
+func (p *TermQuery) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Term = v
 	}
 	return nil
 }
 
-func (p *Query) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("Query"); err != nil {
+func (p *TermQuery) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("TermQuery"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -10432,15 +11004,6 @@ func (p *Query) Write(oprot thrift.TProtocol) error {
 		if err := p.writeField2(oprot); err != nil {
 			return err
 		}
-		if err := p.writeField3(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField4(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField5(oprot); err != nil {
-			return err
-		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -10451,562 +11014,1688 @@ func (p *Query) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *Query) writeField1(oprot thrift.TProtocol) (err error) {
-	if p.IsSetTerm() {
-		if err := oprot.WriteFieldBegin("term", thrift.STRUCT, 1); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:term: ", p), err)
-		}
-		if err := p.Term.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Term), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:term: ", p), err)
-		}
+func (p *TermQuery) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("field", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:field: ", p), err)
 	}
-	return err
-}
-
-func (p *Query) writeField2(oprot thrift.TProtocol) (err error) {
-	if p.IsSetRegexp() {
-		if err := oprot.WriteFieldBegin("regexp", thrift.STRUCT, 2); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:regexp: ", p), err)
-		}
-		if err := p.Regexp.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Regexp), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:regexp: ", p), err)
-		}
+	if err := oprot.WriteString(string(p.Field)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.field (1) field write error: ", p), err)
 	}
-	return err
-}
-
-func (p *Query) writeField3(oprot thrift.TProtocol) (err error) {
-	if p.IsSetNegation() {
-		if err := oprot.WriteFieldBegin("negation", thrift.STRUCT, 3); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:negation: ", p), err)
-		}
-		if err := p.Negation.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Negation), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:negation: ", p), err)
-		}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:field: ", p), err)
 	}
 	return err
 }
 
-func (p *Query) writeField4(oprot thrift.TProtocol) (err error) {
-	if p.IsSetConjunction() {
-		if err := oprot.WriteFieldBegin("conjunction", thrift.STRUCT, 4); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:conjunction: ", p), err)
-		}
-		if err := p.Conjunction.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Conjunction), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:conjunction: ", p), err)
-		}
+func (p *TermQuery) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("term", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:term: ", p), err)
 	}
-	return err
-}
-
-func (p *Query) writeField5(oprot thrift.TProtocol) (err error) {
-	if p.IsSetDisjunction() {
-		if err := oprot.WriteFieldBegin("disjunction", thrift.STRUCT, 5); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:disjunction: ", p), err)
-		}
-		if err := p.Disjunction.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Disjunction), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:disjunction: ", p), err)
-		}
+	if err := oprot.WriteString(string(p.Term)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.term (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:term: ", p), err)
 	}
 	return err
 }
 
-func (p *Query) String() string {
+func (p *TermQuery) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("Query(%+v)", *p)
+	return fmt.Sprintf("TermQuery(%+v)", *p)
 }
 
-type Node interface {
-	// Parameters:
-	//  - Req
-	Query(req *QueryRequest) (r *QueryResult_, err error)
-	// Parameters:
-	//  - Req
-	Fetch(req *FetchRequest) (r *FetchResult_, err error)
-	// Parameters:
-	//  - Req
-	FetchTagged(req *FetchTaggedRequest) (r *FetchTaggedResult_, err error)
-	// Parameters:
-	//  - Req
-	Write(req *WriteRequest) (err error)
-	// Parameters:
-	//  - Req
-	WriteTagged(req *WriteTaggedRequest) (err error)
-	// Parameters:
-	//  - Req
-	FetchBatchRaw(req *FetchBatchRawRequest) (r *FetchBatchRawResult_, err error)
-	// Parameters:
-	//  - Req
-	FetchBlocksRaw(req *FetchBlocksRawRequest) (r *FetchBlocksRawResult_, err error)
-	// Parameters:
-	//  - Req
-	FetchBlocksMetadataRaw(req *FetchBlocksMetadataRawRequest) (r *FetchBlocksMetadataRawResult_, err error)
-	// Parameters:
-	//  - Req
-	FetchBlocksMetadataRawV2(req *FetchBlocksMetadataRawV2Request) (r *FetchBlocksMetadataRawV2Result_, err error)
-	// Parameters:
-	//  - Req
-	WriteBatchRaw(req *WriteBatchRawRequest) (err error)
-	// Parameters:
-	//  - Req
-	WriteTaggedBatchRaw(req *WriteTaggedBatchRawRequest) (err error)
-	Repair() (err error)
-	// Parameters:
-	//  - Req
-	Truncate(req *TruncateRequest) (r *TruncateResult_, err error)
-	Health() (r *NodeHealthResult_, err error)
-	Bootstrapped() (r *NodeBootstrappedResult_, err error)
-	GetPersistRateLimit() (r *NodePersistRateLimitResult_, err error)
-	// Parameters:
-	//  - Req
-	SetPersistRateLimit(req *NodeSetPersistRateLimitRequest) (r *NodePersistRateLimitResult_, err error)
-	GetWriteNewSeriesAsync() (r *NodeWriteNewSeriesAsyncResult_, err error)
-	// Parameters:
-	//  - Req
-	SetWriteNewSeriesAsync(req *NodeSetWriteNewSeriesAsyncRequest) (r *NodeWriteNewSeriesAsyncResult_, err error)
-	GetWriteNewSeriesBackoffDuration() (r *NodeWriteNewSeriesBackoffDurationResult_, err error)
-	// Parameters:
-	//  - Req
-	SetWriteNewSeriesBackoffDuration(req *NodeSetWriteNewSeriesBackoffDurationRequest) (r *NodeWriteNewSeriesBackoffDurationResult_, err error)
-	GetWriteNewSeriesLimitPerShardPerSecond() (r *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error)
-	// Parameters:
-	//  - Req
-	SetWriteNewSeriesLimitPerShardPerSecond(req *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) (r *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error)
+// Attributes:
+//  - Field
+//  - Regexp
+type RegexpQuery struct {
+	Field  string `thrift:"field,1,required" db:"field" json:"field"`
+	Regexp string `thrift:"regexp,2,required" db:"regexp" json:"regexp"`
 }
 
-type NodeClient struct {
-	Transport       thrift.TTransport
-	ProtocolFactory thrift.TProtocolFactory
-	InputProtocol   thrift.TProtocol
-	OutputProtocol  thrift.TProtocol
-	SeqId           int32
+func NewRegexpQuery() *RegexpQuery {
+	return &RegexpQuery{}
 }
 
-func NewNodeClientFactory(t thrift.TTransport, f thrift.TProtocolFactory) *NodeClient {
-	return &NodeClient{Transport: t,
-		ProtocolFactory: f,
-		InputProtocol:   f.GetProtocol(t),
-		OutputProtocol:  f.GetProtocol(t),
-		SeqId:           0,
-	}
+func (p *RegexpQuery) GetField() string {
+	return p.Field
 }
 
-func NewNodeClientProtocol(t thrift.TTransport, iprot thrift.TProtocol, oprot thrift.TProtocol) *NodeClient {
-	return &NodeClient{Transport: t,
-		ProtocolFactory: nil,
-		InputProtocol:   iprot,
-		OutputProtocol:  oprot,
-		SeqId:           0,
-	}
+func (p *RegexpQuery) GetRegexp() string {
+	return p.Regexp
 }
-
-// Parameters:
-//  - Req
-func (p *NodeClient) Query(req *QueryRequest) (r *QueryResult_, err error) {
-	if err = p.sendQuery(req); err != nil {
-		return
+func (p *RegexpQuery) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
-	return p.recvQuery()
-}
 
-func (p *NodeClient) sendQuery(req *QueryRequest) (err error) {
-	oprot := p.OutputProtocol
-	if oprot == nil {
-		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.OutputProtocol = oprot
+	var issetField bool = false
+	var issetRegexp bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+			issetField = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+			issetRegexp = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
 	}
-	p.SeqId++
-	if err = oprot.WriteMessageBegin("query", thrift.CALL, p.SeqId); err != nil {
-		return
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	args := NodeQueryArgs{
-		Req: req,
+	if !issetField {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Field is not set"))
 	}
-	if err = args.Write(oprot); err != nil {
-		return
+	if !issetRegexp {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Regexp is not set"))
 	}
-	if err = oprot.WriteMessageEnd(); err != nil {
-		return
+	return nil
+}
+
+func (p *RegexpQuery) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Field = v
 	}
-	return oprot.Flush()
+	return nil
 }
 
-func (p *NodeClient) recvQuery() (value *QueryResult_, err error) {
-	iprot := p.InputProtocol
-	if iprot == nil {
-		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.InputProtocol = iprot
+func (p *RegexpQuery) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Regexp = v
 	}
-	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-	if err != nil {
-		return
-	}
-	if method != "query" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "query failed: wrong method name")
-		return
-	}
-	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "query failed: out of sequence response")
-		return
+	return nil
+}
+
+func (p *RegexpQuery) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RegexpQuery"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
-	if mTypeId == thrift.EXCEPTION {
-		error23 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error24 error
-		error24, err = error23.Read(iprot)
-		if err != nil {
-			return
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
 		}
-		if err = iprot.ReadMessageEnd(); err != nil {
-			return
+		if err := p.writeField2(oprot); err != nil {
+			return err
 		}
-		err = error24
-		return
-	}
-	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "query failed: invalid message type")
-		return
-	}
-	result := NodeQueryResult{}
-	if err = result.Read(iprot); err != nil {
-		return
 	}
-	if err = iprot.ReadMessageEnd(); err != nil {
-		return
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
 	}
-	if result.Err != nil {
-		err = result.Err
-		return
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
-	value = result.GetSuccess()
-	return
+	return nil
 }
 
-// Parameters:
-//  - Req
-func (p *NodeClient) Fetch(req *FetchRequest) (r *FetchResult_, err error) {
-	if err = p.sendFetch(req); err != nil {
-		return
+func (p *RegexpQuery) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("field", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:field: ", p), err)
 	}
-	return p.recvFetch()
-}
-
-func (p *NodeClient) sendFetch(req *FetchRequest) (err error) {
-	oprot := p.OutputProtocol
-	if oprot == nil {
-		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.OutputProtocol = oprot
+	if err := oprot.WriteString(string(p.Field)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.field (1) field write error: ", p), err)
 	}
-	p.SeqId++
-	if err = oprot.WriteMessageBegin("fetch", thrift.CALL, p.SeqId); err != nil {
-		return
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:field: ", p), err)
 	}
-	args := NodeFetchArgs{
-		Req: req,
+	return err
+}
+
+func (p *RegexpQuery) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("regexp", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:regexp: ", p), err)
 	}
-	if err = args.Write(oprot); err != nil {
-		return
+	if err := oprot.WriteString(string(p.Regexp)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.regexp (2) field write error: ", p), err)
 	}
-	if err = oprot.WriteMessageEnd(); err != nil {
-		return
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:regexp: ", p), err)
 	}
-	return oprot.Flush()
+	return err
 }
 
-func (p *NodeClient) recvFetch() (value *FetchResult_, err error) {
-	iprot := p.InputProtocol
-	if iprot == nil {
-		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.InputProtocol = iprot
-	}
-	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-	if err != nil {
-		return
+func (p *RegexpQuery) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	if method != "fetch" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetch failed: wrong method name")
-		return
+	return fmt.Sprintf("RegexpQuery(%+v)", *p)
+}
+
+// Attributes:
+//  - Query
+type NegationQuery struct {
+	Query *Query `thrift:"query,1,required" db:"query" json:"query"`
+}
+
+func NewNegationQuery() *NegationQuery {
+	return &NegationQuery{}
+}
+
+var NegationQuery_Query_DEFAULT *Query
+
+func (p *NegationQuery) GetQuery() *Query {
+	if !p.IsSetQuery() {
+		return NegationQuery_Query_DEFAULT
 	}
-	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetch failed: out of sequence response")
-		return
+	return p.Query
+}
+func (p *NegationQuery) IsSetQuery() bool {
+	return p.Query != nil
+}
+
+func (p *NegationQuery) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
-	if mTypeId == thrift.EXCEPTION {
-		error25 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error26 error
-		error26, err = error25.Read(iprot)
+
+	var issetQuery bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
-			return
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
 		}
-		if err = iprot.ReadMessageEnd(); err != nil {
-			return
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+			issetQuery = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
 		}
-		err = error26
-		return
-	}
-	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetch failed: invalid message type")
-		return
-	}
-	result := NodeFetchResult{}
-	if err = result.Read(iprot); err != nil {
-		return
 	}
-	if err = iprot.ReadMessageEnd(); err != nil {
-		return
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if result.Err != nil {
-		err = result.Err
-		return
+	if !issetQuery {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Query is not set"))
 	}
-	value = result.GetSuccess()
-	return
+	return nil
 }
 
-// Parameters:
-//  - Req
-func (p *NodeClient) FetchTagged(req *FetchTaggedRequest) (r *FetchTaggedResult_, err error) {
-	if err = p.sendFetchTagged(req); err != nil {
-		return
+func (p *NegationQuery) ReadField1(iprot thrift.TProtocol) error {
+	p.Query = &Query{}
+	if err := p.Query.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Query), err)
 	}
-	return p.recvFetchTagged()
+	return nil
 }
 
-func (p *NodeClient) sendFetchTagged(req *FetchTaggedRequest) (err error) {
-	oprot := p.OutputProtocol
-	if oprot == nil {
-		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.OutputProtocol = oprot
-	}
-	p.SeqId++
-	if err = oprot.WriteMessageBegin("fetchTagged", thrift.CALL, p.SeqId); err != nil {
-		return
+func (p *NegationQuery) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NegationQuery"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
-	args := NodeFetchTaggedArgs{
-		Req: req,
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
 	}
-	if err = args.Write(oprot); err != nil {
-		return
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
 	}
-	if err = oprot.WriteMessageEnd(); err != nil {
-		return
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
-	return oprot.Flush()
+	return nil
 }
 
-func (p *NodeClient) recvFetchTagged() (value *FetchTaggedResult_, err error) {
-	iprot := p.InputProtocol
-	if iprot == nil {
-		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.InputProtocol = iprot
+func (p *NegationQuery) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("query", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:query: ", p), err)
 	}
-	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-	if err != nil {
-		return
+	if err := p.Query.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Query), err)
 	}
-	if method != "fetchTagged" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetchTagged failed: wrong method name")
-		return
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:query: ", p), err)
 	}
-	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetchTagged failed: out of sequence response")
-		return
+	return err
+}
+
+func (p *NegationQuery) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	if mTypeId == thrift.EXCEPTION {
-		error27 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error28 error
-		error28, err = error27.Read(iprot)
+	return fmt.Sprintf("NegationQuery(%+v)", *p)
+}
+
+// Attributes:
+//  - Queries
+type ConjunctionQuery struct {
+	Queries []*Query `thrift:"queries,1,required" db:"queries" json:"queries"`
+}
+
+func NewConjunctionQuery() *ConjunctionQuery {
+	return &ConjunctionQuery{}
+}
+
+func (p *ConjunctionQuery) GetQueries() []*Query {
+	return p.Queries
+}
+func (p *ConjunctionQuery) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetQueries bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
-			return
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
 		}
-		if err = iprot.ReadMessageEnd(); err != nil {
-			return
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+			issetQueries = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
 		}
-		err = error28
-		return
-	}
-	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetchTagged failed: invalid message type")
-		return
-	}
-	result := NodeFetchTaggedResult{}
-	if err = result.Read(iprot); err != nil {
-		return
 	}
-	if err = iprot.ReadMessageEnd(); err != nil {
-		return
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if result.Err != nil {
-		err = result.Err
-		return
+	if !issetQueries {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Queries is not set"))
 	}
-	value = result.GetSuccess()
-	return
+	return nil
 }
 
-// Parameters:
-//  - Req
-func (p *NodeClient) Write(req *WriteRequest) (err error) {
-	if err = p.sendWrite(req); err != nil {
-		return
+func (p *ConjunctionQuery) ReadField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
 	}
-	return p.recvWrite()
+	tSlice := make([]*Query, 0, size)
+	p.Queries = tSlice
+	for i := 0; i < size; i++ {
+		_elem21 := &Query{}
+		if err := _elem21.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem21), err)
+		}
+		p.Queries = append(p.Queries, _elem21)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
 }
 
-func (p *NodeClient) sendWrite(req *WriteRequest) (err error) {
-	oprot := p.OutputProtocol
-	if oprot == nil {
-		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.OutputProtocol = oprot
-	}
-	p.SeqId++
-	if err = oprot.WriteMessageBegin("write", thrift.CALL, p.SeqId); err != nil {
-		return
+func (p *ConjunctionQuery) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ConjunctionQuery"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
-	args := NodeWriteArgs{
-		Req: req,
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
 	}
-	if err = args.Write(oprot); err != nil {
-		return
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
 	}
-	if err = oprot.WriteMessageEnd(); err != nil {
-		return
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
-	return oprot.Flush()
+	return nil
 }
 
-func (p *NodeClient) recvWrite() (err error) {
-	iprot := p.InputProtocol
-	if iprot == nil {
-		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.InputProtocol = iprot
-	}
-	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-	if err != nil {
-		return
-	}
-	if method != "write" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "write failed: wrong method name")
-		return
+func (p *ConjunctionQuery) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("queries", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:queries: ", p), err)
 	}
-	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "write failed: out of sequence response")
-		return
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Queries)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
 	}
-	if mTypeId == thrift.EXCEPTION {
-		error29 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error30 error
-		error30, err = error29.Read(iprot)
-		if err != nil {
-			return
-		}
-		if err = iprot.ReadMessageEnd(); err != nil {
-			return
+	for _, v := range p.Queries {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
 		}
-		err = error30
-		return
-	}
-	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "write failed: invalid message type")
-		return
-	}
-	result := NodeWriteResult{}
-	if err = result.Read(iprot); err != nil {
-		return
 	}
-	if err = iprot.ReadMessageEnd(); err != nil {
-		return
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
 	}
-	if result.Err != nil {
-		err = result.Err
-		return
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:queries: ", p), err)
 	}
-	return
+	return err
 }
 
-// Parameters:
-//  - Req
-func (p *NodeClient) WriteTagged(req *WriteTaggedRequest) (err error) {
-	if err = p.sendWriteTagged(req); err != nil {
-		return
+func (p *ConjunctionQuery) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	return p.recvWriteTagged()
+	return fmt.Sprintf("ConjunctionQuery(%+v)", *p)
 }
 
-func (p *NodeClient) sendWriteTagged(req *WriteTaggedRequest) (err error) {
-	oprot := p.OutputProtocol
-	if oprot == nil {
-		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.OutputProtocol = oprot
-	}
-	p.SeqId++
-	if err = oprot.WriteMessageBegin("writeTagged", thrift.CALL, p.SeqId); err != nil {
-		return
+// Attributes:
+//  - Queries
+type DisjunctionQuery struct {
+	Queries []*Query `thrift:"queries,1,required" db:"queries" json:"queries"`
+}
+
+func NewDisjunctionQuery() *DisjunctionQuery {
+	return &DisjunctionQuery{}
+}
+
+func (p *DisjunctionQuery) GetQueries() []*Query {
+	return p.Queries
+}
+func (p *DisjunctionQuery) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
-	args := NodeWriteTaggedArgs{
-		Req: req,
+
+	var issetQueries bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+			issetQueries = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
 	}
-	if err = args.Write(oprot); err != nil {
-		return
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if err = oprot.WriteMessageEnd(); err != nil {
-		return
+	if !issetQueries {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Queries is not set"))
 	}
-	return oprot.Flush()
+	return nil
 }
 
-func (p *NodeClient) recvWriteTagged() (err error) {
-	iprot := p.InputProtocol
-	if iprot == nil {
-		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.InputProtocol = iprot
-	}
-	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+func (p *DisjunctionQuery) ReadField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
 	if err != nil {
-		return
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*Query, 0, size)
+	p.Queries = tSlice
+	for i := 0; i < size; i++ {
+		_elem22 := &Query{}
+		if err := _elem22.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem22), err)
+		}
+		p.Queries = append(p.Queries, _elem22)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *DisjunctionQuery) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DisjunctionQuery"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *DisjunctionQuery) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("queries", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:queries: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Queries)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Queries {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:queries: ", p), err)
+	}
+	return err
+}
+
+func (p *DisjunctionQuery) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DisjunctionQuery(%+v)", *p)
+}
+
+// Attributes:
+//  - Term
+//  - Regexp
+//  - Negation
+//  - Conjunction
+//  - Disjunction
+type Query struct {
+	Term        *TermQuery        `thrift:"term,1" db:"term" json:"term,omitempty"`
+	Regexp      *RegexpQuery      `thrift:"regexp,2" db:"regexp" json:"regexp,omitempty"`
+	Negation    *NegationQuery    `thrift:"negation,3" db:"negation" json:"negation,omitempty"`
+	Conjunction *ConjunctionQuery `thrift:"conjunction,4" db:"conjunction" json:"conjunction,omitempty"`
+	Disjunction *DisjunctionQuery `thrift:"disjunction,5" db:"disjunction" json:"disjunction,omitempty"`
+}
+
+func NewQuery() *Query {
+	return &Query{}
+}
+
+var Query_Term_DEFAULT *TermQuery
+
+func (p *Query) GetTerm() *TermQuery {
+	if !p.IsSetTerm() {
+		return Query_Term_DEFAULT
+	}
+	return p.Term
+}
+
+var Query_Regexp_DEFAULT *RegexpQuery
+
+func (p *Query) GetRegexp() *RegexpQuery {
+	if !p.IsSetRegexp() {
+		return Query_Regexp_DEFAULT
+	}
+	return p.Regexp
+}
+
+var Query_Negation_DEFAULT *NegationQuery
+
+func (p *Query) GetNegation() *NegationQuery {
+	if !p.IsSetNegation() {
+		return Query_Negation_DEFAULT
+	}
+	return p.Negation
+}
+
+var Query_Conjunction_DEFAULT *ConjunctionQuery
+
+func (p *Query) GetConjunction() *ConjunctionQuery {
+	if !p.IsSetConjunction() {
+		return Query_Conjunction_DEFAULT
+	}
+	return p.Conjunction
+}
+
+var Query_Disjunction_DEFAULT *DisjunctionQuery
+
+func (p *Query) GetDisjunction() *DisjunctionQuery {
+	if !p.IsSetDisjunction() {
+		return Query_Disjunction_DEFAULT
+	}
+	return p.Disjunction
+}
+func (p *Query) IsSetTerm() bool {
+	return p.Term != nil
+}
+
+func (p *Query) IsSetRegexp() bool {
+	return p.Regexp != nil
+}
+
+func (p *Query) IsSetNegation() bool {
+	return p.Negation != nil
+}
+
+func (p *Query) IsSetConjunction() bool {
+	return p.Conjunction != nil
+}
+
+func (p *Query) IsSetDisjunction() bool {
+	return p.Disjunction != nil
+}
+
+func (p *Query) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.ReadField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.ReadField5(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *Query) ReadField1(iprot thrift.TProtocol) error {
+	p.Term = &TermQuery{}
+	if err := p.Term.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Term), err)
+	}
+	return nil
+}
+
+func (p *Query) ReadField2(iprot thrift.TProtocol) error {
+	p.Regexp = &RegexpQuery{}
+	if err := p.Regexp.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Regexp), err)
+	}
+	return nil
+}
+
+func (p *Query) ReadField3(iprot thrift.TProtocol) error {
+	p.Negation = &NegationQuery{}
+	if err := p.Negation.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Negation), err)
+	}
+	return nil
+}
+
+func (p *Query) ReadField4(iprot thrift.TProtocol) error {
+	p.Conjunction = &ConjunctionQuery{}
+	if err := p.Conjunction.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Conjunction), err)
+	}
+	return nil
+}
+
+func (p *Query) ReadField5(iprot thrift.TProtocol) error {
+	p.Disjunction = &DisjunctionQuery{}
+	if err := p.Disjunction.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Disjunction), err)
+	}
+	return nil
+}
+
+func (p *Query) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Query"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField3(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField4(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField5(oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *Query) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTerm() {
+		if err := oprot.WriteFieldBegin("term", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:term: ", p), err)
+		}
+		if err := p.Term.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Term), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:term: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Query) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRegexp() {
+		if err := oprot.WriteFieldBegin("regexp", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:regexp: ", p), err)
+		}
+		if err := p.Regexp.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Regexp), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:regexp: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Query) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetNegation() {
+		if err := oprot.WriteFieldBegin("negation", thrift.STRUCT, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:negation: ", p), err)
+		}
+		if err := p.Negation.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Negation), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:negation: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Query) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetConjunction() {
+		if err := oprot.WriteFieldBegin("conjunction", thrift.STRUCT, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:conjunction: ", p), err)
+		}
+		if err := p.Conjunction.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Conjunction), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:conjunction: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Query) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDisjunction() {
+		if err := oprot.WriteFieldBegin("disjunction", thrift.STRUCT, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:disjunction: ", p), err)
+		}
+		if err := p.Disjunction.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Disjunction), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:disjunction: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Query) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Query(%+v)", *p)
+}
+
+type Node interface {
+	// Parameters:
+	//  - Req
+	Query(req *QueryRequest) (r *QueryResult_, err error)
+	// Parameters:
+	//  - Req
+	Fetch(req *FetchRequest) (r *FetchResult_, err error)
+	// Parameters:
+	//  - Req
+	FetchTagged(req *FetchTaggedRequest) (r *FetchTaggedResult_, err error)
+	// Parameters:
+	//  - Req
+	Write(req *WriteRequest) (err error)
+	// Parameters:
+	//  - Req
+	WriteTagged(req *WriteTaggedRequest) (err error)
+	// Parameters:
+	//  - Req
+	FetchBatchRaw(req *FetchBatchRawRequest) (r *FetchBatchRawResult_, err error)
+	// Parameters:
+	//  - Req
+	FetchBlocksRaw(req *FetchBlocksRawRequest) (r *FetchBlocksRawResult_, err error)
+	// Parameters:
+	//  - Req
+	FetchBlocksMetadataRaw(req *FetchBlocksMetadataRawRequest) (r *FetchBlocksMetadataRawResult_, err error)
+	// Parameters:
+	//  - Req
+	FetchBlocksMetadataRawV2(req *FetchBlocksMetadataRawV2Request) (r *FetchBlocksMetadataRawV2Result_, err error)
+	// Parameters:
+	//  - Req
+	WriteBatchRaw(req *WriteBatchRawRequest) (err error)
+	// Parameters:
+	//  - Req
+	WriteTaggedBatchRaw(req *WriteTaggedBatchRawRequest) (err error)
+	Repair() (err error)
+	// Parameters:
+	//  - Req
+	Truncate(req *TruncateRequest) (r *TruncateResult_, err error)
+	// Parameters:
+	//  - Req
+	DeleteTagged(req *DeleteTaggedRequest) (r *DeleteTaggedResult_, err error)
+	// Parameters:
+	//  - Req
+	Aggregate(req *AggregateQueryRequest) (r *AggregateQueryResult_, err error)
+	Health() (r *NodeHealthResult_, err error)
+	Bootstrapped() (r *NodeBootstrappedResult_, err error)
+	GetPersistRateLimit() (r *NodePersistRateLimitResult_, err error)
+	// Parameters:
+	//  - Req
+	SetPersistRateLimit(req *NodeSetPersistRateLimitRequest) (r *NodePersistRateLimitResult_, err error)
+	GetWriteNewSeriesAsync() (r *NodeWriteNewSeriesAsyncResult_, err error)
+	// Parameters:
+	//  - Req
+	SetWriteNewSeriesAsync(req *NodeSetWriteNewSeriesAsyncRequest) (r *NodeWriteNewSeriesAsyncResult_, err error)
+	GetWriteNewSeriesBackoffDuration() (r *NodeWriteNewSeriesBackoffDurationResult_, err error)
+	// Parameters:
+	//  - Req
+	SetWriteNewSeriesBackoffDuration(req *NodeSetWriteNewSeriesBackoffDurationRequest) (r *NodeWriteNewSeriesBackoffDurationResult_, err error)
+	GetWriteNewSeriesLimitPerShardPerSecond() (r *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error)
+	// Parameters:
+	//  - Req
+	SetWriteNewSeriesLimitPerShardPerSecond(req *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) (r *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error)
+}
+
+type NodeClient struct {
+	Transport       thrift.TTransport
+	ProtocolFactory thrift.TProtocolFactory
+	InputProtocol   thrift.TProtocol
+	OutputProtocol  thrift.TProtocol
+	SeqId           int32
+}
+
+func NewNodeClientFactory(t thrift.TTransport, f thrift.TProtocolFactory) *NodeClient {
+	return &NodeClient{Transport: t,
+		ProtocolFactory: f,
+		InputProtocol:   f.GetProtocol(t),
+		OutputProtocol:  f.GetProtocol(t),
+		SeqId:           0,
+	}
+}
+
+func NewNodeClientProtocol(t thrift.TTransport, iprot thrift.TProtocol, oprot thrift.TProtocol) *NodeClient {
+	return &NodeClient{Transport: t,
+		ProtocolFactory: nil,
+		InputProtocol:   iprot,
+		OutputProtocol:  oprot,
+		SeqId:           0,
+	}
+}
+
+// Parameters:
+//  - Req
+func (p *NodeClient) Query(req *QueryRequest) (r *QueryResult_, err error) {
+	if err = p.sendQuery(req); err != nil {
+		return
+	}
+	return p.recvQuery()
+}
+
+func (p *NodeClient) sendQuery(req *QueryRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("query", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := NodeQueryArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *NodeClient) recvQuery() (value *QueryResult_, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "query" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "query failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "query failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error23 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error24 error
+		error24, err = error23.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error24
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "query failed: invalid message type")
+		return
+	}
+	result := NodeQueryResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *NodeClient) Fetch(req *FetchRequest) (r *FetchResult_, err error) {
+	if err = p.sendFetch(req); err != nil {
+		return
+	}
+	return p.recvFetch()
+}
+
+func (p *NodeClient) sendFetch(req *FetchRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("fetch", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := NodeFetchArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *NodeClient) recvFetch() (value *FetchResult_, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "fetch" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetch failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetch failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error25 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error26 error
+		error26, err = error25.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error26
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetch failed: invalid message type")
+		return
+	}
+	result := NodeFetchResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *NodeClient) FetchTagged(req *FetchTaggedRequest) (r *FetchTaggedResult_, err error) {
+	if err = p.sendFetchTagged(req); err != nil {
+		return
+	}
+	return p.recvFetchTagged()
+}
+
+func (p *NodeClient) sendFetchTagged(req *FetchTaggedRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("fetchTagged", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := NodeFetchTaggedArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *NodeClient) recvFetchTagged() (value *FetchTaggedResult_, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "fetchTagged" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetchTagged failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetchTagged failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error27 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error28 error
+		error28, err = error27.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error28
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetchTagged failed: invalid message type")
+		return
+	}
+	result := NodeFetchTaggedResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *NodeClient) Write(req *WriteRequest) (err error) {
+	if err = p.sendWrite(req); err != nil {
+		return
+	}
+	return p.recvWrite()
+}
+
+func (p *NodeClient) sendWrite(req *WriteRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("write", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := NodeWriteArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *NodeClient) recvWrite() (err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "write" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "write failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "write failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error29 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error30 error
+		error30, err = error29.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error30
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "write failed: invalid message type")
+		return
+	}
+	result := NodeWriteResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *NodeClient) WriteTagged(req *WriteTaggedRequest) (err error) {
+	if err = p.sendWriteTagged(req); err != nil {
+		return
+	}
+	return p.recvWriteTagged()
+}
+
+func (p *NodeClient) sendWriteTagged(req *WriteTaggedRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("writeTagged", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := NodeWriteTaggedArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *NodeClient) recvWriteTagged() (err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
 	}
 	if method != "writeTagged" {
 		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "writeTagged failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "writeTagged failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "writeTagged failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error31 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error32 error
+		error32, err = error31.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error32
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "writeTagged failed: invalid message type")
+		return
+	}
+	result := NodeWriteTaggedResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *NodeClient) FetchBatchRaw(req *FetchBatchRawRequest) (r *FetchBatchRawResult_, err error) {
+	if err = p.sendFetchBatchRaw(req); err != nil {
+		return
+	}
+	return p.recvFetchBatchRaw()
+}
+
+func (p *NodeClient) sendFetchBatchRaw(req *FetchBatchRawRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("fetchBatchRaw", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := NodeFetchBatchRawArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *NodeClient) recvFetchBatchRaw() (value *FetchBatchRawResult_, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "fetchBatchRaw" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetchBatchRaw failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetchBatchRaw failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error33 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error34 error
+		error34, err = error33.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error34
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetchBatchRaw failed: invalid message type")
+		return
+	}
+	result := NodeFetchBatchRawResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *NodeClient) FetchBlocksRaw(req *FetchBlocksRawRequest) (r *FetchBlocksRawResult_, err error) {
+	if err = p.sendFetchBlocksRaw(req); err != nil {
+		return
+	}
+	return p.recvFetchBlocksRaw()
+}
+
+func (p *NodeClient) sendFetchBlocksRaw(req *FetchBlocksRawRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("fetchBlocksRaw", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := NodeFetchBlocksRawArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *NodeClient) recvFetchBlocksRaw() (value *FetchBlocksRawResult_, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "fetchBlocksRaw" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetchBlocksRaw failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetchBlocksRaw failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error35 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error36 error
+		error36, err = error35.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error36
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetchBlocksRaw failed: invalid message type")
+		return
+	}
+	result := NodeFetchBlocksRawResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *NodeClient) FetchBlocksMetadataRaw(req *FetchBlocksMetadataRawRequest) (r *FetchBlocksMetadataRawResult_, err error) {
+	if err = p.sendFetchBlocksMetadataRaw(req); err != nil {
+		return
+	}
+	return p.recvFetchBlocksMetadataRaw()
+}
+
+func (p *NodeClient) sendFetchBlocksMetadataRaw(req *FetchBlocksMetadataRawRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("fetchBlocksMetadataRaw", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := NodeFetchBlocksMetadataRawArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *NodeClient) recvFetchBlocksMetadataRaw() (value *FetchBlocksMetadataRawResult_, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "fetchBlocksMetadataRaw" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetchBlocksMetadataRaw failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetchBlocksMetadataRaw failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error37 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error38 error
+		error38, err = error37.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error38
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetchBlocksMetadataRaw failed: invalid message type")
+		return
+	}
+	result := NodeFetchBlocksMetadataRawResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *NodeClient) FetchBlocksMetadataRawV2(req *FetchBlocksMetadataRawV2Request) (r *FetchBlocksMetadataRawV2Result_, err error) {
+	if err = p.sendFetchBlocksMetadataRawV2(req); err != nil {
+		return
+	}
+	return p.recvFetchBlocksMetadataRawV2()
+}
+
+func (p *NodeClient) sendFetchBlocksMetadataRawV2(req *FetchBlocksMetadataRawV2Request) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("fetchBlocksMetadataRawV2", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := NodeFetchBlocksMetadataRawV2Args{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *NodeClient) recvFetchBlocksMetadataRawV2() (value *FetchBlocksMetadataRawV2Result_, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "fetchBlocksMetadataRawV2" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetchBlocksMetadataRawV2 failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetchBlocksMetadataRawV2 failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error39 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error40 error
+		error40, err = error39.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error40
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetchBlocksMetadataRawV2 failed: invalid message type")
+		return
+	}
+	result := NodeFetchBlocksMetadataRawV2Result{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *NodeClient) WriteBatchRaw(req *WriteBatchRawRequest) (err error) {
+	if err = p.sendWriteBatchRaw(req); err != nil {
+		return
+	}
+	return p.recvWriteBatchRaw()
+}
+
+func (p *NodeClient) sendWriteBatchRaw(req *WriteBatchRawRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("writeBatchRaw", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := NodeWriteBatchRawArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *NodeClient) recvWriteBatchRaw() (err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "writeBatchRaw" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "writeBatchRaw failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "writeBatchRaw failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error31 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error32 error
-		error32, err = error31.Read(iprot)
+		error41 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error42 error
+		error42, err = error41.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error32
+		err = error42
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "writeTagged failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "writeBatchRaw failed: invalid message type")
 		return
 	}
-	result := NodeWriteTaggedResult{}
+	result := NodeWriteBatchRawResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -11022,24 +12711,24 @@ func (p *NodeClient) recvWriteTagged() (err error) {
 
 // Parameters:
 //  - Req
-func (p *NodeClient) FetchBatchRaw(req *FetchBatchRawRequest) (r *FetchBatchRawResult_, err error) {
-	if err = p.sendFetchBatchRaw(req); err != nil {
+func (p *NodeClient) WriteTaggedBatchRaw(req *WriteTaggedBatchRawRequest) (err error) {
+	if err = p.sendWriteTaggedBatchRaw(req); err != nil {
 		return
 	}
-	return p.recvFetchBatchRaw()
+	return p.recvWriteTaggedBatchRaw()
 }
 
-func (p *NodeClient) sendFetchBatchRaw(req *FetchBatchRawRequest) (err error) {
+func (p *NodeClient) sendWriteTaggedBatchRaw(req *WriteTaggedBatchRawRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("fetchBatchRaw", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("writeTaggedBatchRaw", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeFetchBatchRawArgs{
+	args := NodeWriteTaggedBatchRawArgs{
 		Req: req,
 	}
 	if err = args.Write(oprot); err != nil {
@@ -11051,7 +12740,7 @@ func (p *NodeClient) sendFetchBatchRaw(req *FetchBatchRawRequest) (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvFetchBatchRaw() (value *FetchBatchRawResult_, err error) {
+func (p *NodeClient) recvWriteTaggedBatchRaw() (err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -11061,32 +12750,108 @@ func (p *NodeClient) recvFetchBatchRaw() (value *FetchBatchRawResult_, err error
 	if err != nil {
 		return
 	}
-	if method != "fetchBatchRaw" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetchBatchRaw failed: wrong method name")
+	if method != "writeTaggedBatchRaw" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "writeTaggedBatchRaw failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetchBatchRaw failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "writeTaggedBatchRaw failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error33 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error34 error
-		error34, err = error33.Read(iprot)
+		error43 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error44 error
+		error44, err = error43.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error34
+		err = error44
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetchBatchRaw failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "writeTaggedBatchRaw failed: invalid message type")
 		return
 	}
-	result := NodeFetchBatchRawResult{}
+	result := NodeWriteTaggedBatchRawResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	return
+}
+
+func (p *NodeClient) Repair() (err error) {
+	if err = p.sendRepair(); err != nil {
+		return
+	}
+	return p.recvRepair()
+}
+
+func (p *NodeClient) sendRepair() (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("repair", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := NodeRepairArgs{}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *NodeClient) recvRepair() (err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "repair" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "repair failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "repair failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error45 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error46 error
+		error46, err = error45.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error46
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "repair failed: invalid message type")
+		return
+	}
+	result := NodeRepairResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -11097,30 +12862,29 @@ func (p *NodeClient) recvFetchBatchRaw() (value *FetchBatchRawResult_, err error
 		err = result.Err
 		return
 	}
-	value = result.GetSuccess()
 	return
 }
 
 // Parameters:
 //  - Req
-func (p *NodeClient) FetchBlocksRaw(req *FetchBlocksRawRequest) (r *FetchBlocksRawResult_, err error) {
-	if err = p.sendFetchBlocksRaw(req); err != nil {
+func (p *NodeClient) Truncate(req *TruncateRequest) (r *TruncateResult_, err error) {
+	if err = p.sendTruncate(req); err != nil {
 		return
 	}
-	return p.recvFetchBlocksRaw()
+	return p.recvTruncate()
 }
 
-func (p *NodeClient) sendFetchBlocksRaw(req *FetchBlocksRawRequest) (err error) {
+func (p *NodeClient) sendTruncate(req *TruncateRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("fetchBlocksRaw", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("truncate", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeFetchBlocksRawArgs{
+	args := NodeTruncateArgs{
 		Req: req,
 	}
 	if err = args.Write(oprot); err != nil {
@@ -11132,7 +12896,7 @@ func (p *NodeClient) sendFetchBlocksRaw(req *FetchBlocksRawRequest) (err error)
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvFetchBlocksRaw() (value *FetchBlocksRawResult_, err error) {
+func (p *NodeClient) recvTruncate() (value *TruncateResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -11142,32 +12906,32 @@ func (p *NodeClient) recvFetchBlocksRaw() (value *FetchBlocksRawResult_, err err
 	if err != nil {
 		return
 	}
-	if method != "fetchBlocksRaw" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetchBlocksRaw failed: wrong method name")
+	if method != "truncate" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "truncate failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetchBlocksRaw failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "truncate failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error35 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error36 error
-		error36, err = error35.Read(iprot)
+		error47 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error48 error
+		error48, err = error47.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error36
+		err = error48
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetchBlocksRaw failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "truncate failed: invalid message type")
 		return
 	}
-	result := NodeFetchBlocksRawResult{}
+	result := NodeTruncateResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -11184,24 +12948,24 @@ func (p *NodeClient) recvFetchBlocksRaw() (value *FetchBlocksRawResult_, err err
 
 // Parameters:
 //  - Req
-func (p *NodeClient) FetchBlocksMetadataRaw(req *FetchBlocksMetadataRawRequest) (r *FetchBlocksMetadataRawResult_, err error) {
-	if err = p.sendFetchBlocksMetadataRaw(req); err != nil {
+func (p *NodeClient) DeleteTagged(req *DeleteTaggedRequest) (r *DeleteTaggedResult_, err error) {
+	if err = p.sendDeleteTagged(req); err != nil {
 		return
 	}
-	return p.recvFetchBlocksMetadataRaw()
+	return p.recvDeleteTagged()
 }
 
-func (p *NodeClient) sendFetchBlocksMetadataRaw(req *FetchBlocksMetadataRawRequest) (err error) {
+func (p *NodeClient) sendDeleteTagged(req *DeleteTaggedRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("fetchBlocksMetadataRaw", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("deleteTagged", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeFetchBlocksMetadataRawArgs{
+	args := NodeDeleteTaggedArgs{
 		Req: req,
 	}
 	if err = args.Write(oprot); err != nil {
@@ -11213,7 +12977,7 @@ func (p *NodeClient) sendFetchBlocksMetadataRaw(req *FetchBlocksMetadataRawReque
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvFetchBlocksMetadataRaw() (value *FetchBlocksMetadataRawResult_, err error) {
+func (p *NodeClient) recvDeleteTagged() (value *DeleteTaggedResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -11223,32 +12987,32 @@ func (p *NodeClient) recvFetchBlocksMetadataRaw() (value *FetchBlocksMetadataRaw
 	if err != nil {
 		return
 	}
-	if method != "fetchBlocksMetadataRaw" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetchBlocksMetadataRaw failed: wrong method name")
+	if method != "deleteTagged" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "deleteTagged failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetchBlocksMetadataRaw failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "deleteTagged failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error37 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error38 error
-		error38, err = error37.Read(iprot)
+		error47 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error48 error
+		error48, err = error47.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error38
+		err = error48
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetchBlocksMetadataRaw failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "deleteTagged failed: invalid message type")
 		return
 	}
-	result := NodeFetchBlocksMetadataRawResult{}
+	result := NodeDeleteTaggedResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -11265,24 +13029,24 @@ func (p *NodeClient) recvFetchBlocksMetadataRaw() (value *FetchBlocksMetadataRaw
 
 // Parameters:
 //  - Req
-func (p *NodeClient) FetchBlocksMetadataRawV2(req *FetchBlocksMetadataRawV2Request) (r *FetchBlocksMetadataRawV2Result_, err error) {
-	if err = p.sendFetchBlocksMetadataRawV2(req); err != nil {
+func (p *NodeClient) Aggregate(req *AggregateQueryRequest) (r *AggregateQueryResult_, err error) {
+	if err = p.sendAggregate(req); err != nil {
 		return
 	}
-	return p.recvFetchBlocksMetadataRawV2()
+	return p.recvAggregate()
 }
 
-func (p *NodeClient) sendFetchBlocksMetadataRawV2(req *FetchBlocksMetadataRawV2Request) (err error) {
+func (p *NodeClient) sendAggregate(req *AggregateQueryRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("fetchBlocksMetadataRawV2", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("aggregate", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeFetchBlocksMetadataRawV2Args{
+	args := NodeAggregateArgs{
 		Req: req,
 	}
 	if err = args.Write(oprot); err != nil {
@@ -11294,7 +13058,7 @@ func (p *NodeClient) sendFetchBlocksMetadataRawV2(req *FetchBlocksMetadataRawV2R
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvFetchBlocksMetadataRawV2() (value *FetchBlocksMetadataRawV2Result_, err error) {
+func (p *NodeClient) recvAggregate() (value *AggregateQueryResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -11304,32 +13068,32 @@ func (p *NodeClient) recvFetchBlocksMetadataRawV2() (value *FetchBlocksMetadataR
 	if err != nil {
 		return
 	}
-	if method != "fetchBlocksMetadataRawV2" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetchBlocksMetadataRawV2 failed: wrong method name")
+	if method != "aggregate" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "aggregate failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetchBlocksMetadataRawV2 failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "aggregate failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error39 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error40 error
-		error40, err = error39.Read(iprot)
+		error182 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error183 error
+		error183, err = error182.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error40
+		err = error183
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetchBlocksMetadataRawV2 failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "aggregate failed: invalid message type")
 		return
 	}
-	result := NodeFetchBlocksMetadataRawV2Result{}
+	result := NodeAggregateResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -11344,28 +13108,24 @@ func (p *NodeClient) recvFetchBlocksMetadataRawV2() (value *FetchBlocksMetadataR
 	return
 }
 
-// Parameters:
-//  - Req
-func (p *NodeClient) WriteBatchRaw(req *WriteBatchRawRequest) (err error) {
-	if err = p.sendWriteBatchRaw(req); err != nil {
+func (p *NodeClient) Health() (r *NodeHealthResult_, err error) {
+	if err = p.sendHealth(); err != nil {
 		return
 	}
-	return p.recvWriteBatchRaw()
+	return p.recvHealth()
 }
 
-func (p *NodeClient) sendWriteBatchRaw(req *WriteBatchRawRequest) (err error) {
+func (p *NodeClient) sendHealth() (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("writeBatchRaw", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("health", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeWriteBatchRawArgs{
-		Req: req,
-	}
+	args := NodeHealthArgs{}
 	if err = args.Write(oprot); err != nil {
 		return
 	}
@@ -11375,7 +13135,7 @@ func (p *NodeClient) sendWriteBatchRaw(req *WriteBatchRawRequest) (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvWriteBatchRaw() (err error) {
+func (p *NodeClient) recvHealth() (value *NodeHealthResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -11385,32 +13145,32 @@ func (p *NodeClient) recvWriteBatchRaw() (err error) {
 	if err != nil {
 		return
 	}
-	if method != "writeBatchRaw" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "writeBatchRaw failed: wrong method name")
+	if method != "health" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "health failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "writeBatchRaw failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "health failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error41 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error42 error
-		error42, err = error41.Read(iprot)
+		error49 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error50 error
+		error50, err = error49.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error42
+		err = error50
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "writeBatchRaw failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "health failed: invalid message type")
 		return
 	}
-	result := NodeWriteBatchRawResult{}
+	result := NodeHealthResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -11421,31 +13181,28 @@ func (p *NodeClient) recvWriteBatchRaw() (err error) {
 		err = result.Err
 		return
 	}
+	value = result.GetSuccess()
 	return
 }
 
-// Parameters:
-//  - Req
-func (p *NodeClient) WriteTaggedBatchRaw(req *WriteTaggedBatchRawRequest) (err error) {
-	if err = p.sendWriteTaggedBatchRaw(req); err != nil {
+func (p *NodeClient) Bootstrapped() (r *NodeBootstrappedResult_, err error) {
+	if err = p.sendBootstrapped(); err != nil {
 		return
 	}
-	return p.recvWriteTaggedBatchRaw()
+	return p.recvBootstrapped()
 }
 
-func (p *NodeClient) sendWriteTaggedBatchRaw(req *WriteTaggedBatchRawRequest) (err error) {
+func (p *NodeClient) sendBootstrapped() (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("writeTaggedBatchRaw", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("bootstrapped", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeWriteTaggedBatchRawArgs{
-		Req: req,
-	}
+	args := NodeBootstrappedArgs{}
 	if err = args.Write(oprot); err != nil {
 		return
 	}
@@ -11455,7 +13212,7 @@ func (p *NodeClient) sendWriteTaggedBatchRaw(req *WriteTaggedBatchRawRequest) (e
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvWriteTaggedBatchRaw() (err error) {
+func (p *NodeClient) recvBootstrapped() (value *NodeBootstrappedResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -11465,32 +13222,32 @@ func (p *NodeClient) recvWriteTaggedBatchRaw() (err error) {
 	if err != nil {
 		return
 	}
-	if method != "writeTaggedBatchRaw" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "writeTaggedBatchRaw failed: wrong method name")
+	if method != "bootstrapped" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "bootstrapped failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "writeTaggedBatchRaw failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "bootstrapped failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error43 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error44 error
-		error44, err = error43.Read(iprot)
+		error51 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error52 error
+		error52, err = error51.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error44
+		err = error52
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "writeTaggedBatchRaw failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "bootstrapped failed: invalid message type")
 		return
 	}
-	result := NodeWriteTaggedBatchRawResult{}
+	result := NodeBootstrappedResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -11501,27 +13258,28 @@ func (p *NodeClient) recvWriteTaggedBatchRaw() (err error) {
 		err = result.Err
 		return
 	}
+	value = result.GetSuccess()
 	return
 }
 
-func (p *NodeClient) Repair() (err error) {
-	if err = p.sendRepair(); err != nil {
+func (p *NodeClient) GetPersistRateLimit() (r *NodePersistRateLimitResult_, err error) {
+	if err = p.sendGetPersistRateLimit(); err != nil {
 		return
 	}
-	return p.recvRepair()
+	return p.recvGetPersistRateLimit()
 }
 
-func (p *NodeClient) sendRepair() (err error) {
+func (p *NodeClient) sendGetPersistRateLimit() (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("repair", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("getPersistRateLimit", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeRepairArgs{}
+	args := NodeGetPersistRateLimitArgs{}
 	if err = args.Write(oprot); err != nil {
 		return
 	}
@@ -11531,7 +13289,7 @@ func (p *NodeClient) sendRepair() (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvRepair() (err error) {
+func (p *NodeClient) recvGetPersistRateLimit() (value *NodePersistRateLimitResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -11541,32 +13299,32 @@ func (p *NodeClient) recvRepair() (err error) {
 	if err != nil {
 		return
 	}
-	if method != "repair" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "repair failed: wrong method name")
+	if method != "getPersistRateLimit" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "getPersistRateLimit failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "repair failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "getPersistRateLimit failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error45 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error46 error
-		error46, err = error45.Read(iprot)
+		error53 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error54 error
+		error54, err = error53.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error46
+		err = error54
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "repair failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "getPersistRateLimit failed: invalid message type")
 		return
 	}
-	result := NodeRepairResult{}
+	result := NodeGetPersistRateLimitResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -11577,29 +13335,30 @@ func (p *NodeClient) recvRepair() (err error) {
 		err = result.Err
 		return
 	}
+	value = result.GetSuccess()
 	return
 }
 
 // Parameters:
 //  - Req
-func (p *NodeClient) Truncate(req *TruncateRequest) (r *TruncateResult_, err error) {
-	if err = p.sendTruncate(req); err != nil {
+func (p *NodeClient) SetPersistRateLimit(req *NodeSetPersistRateLimitRequest) (r *NodePersistRateLimitResult_, err error) {
+	if err = p.sendSetPersistRateLimit(req); err != nil {
 		return
 	}
-	return p.recvTruncate()
+	return p.recvSetPersistRateLimit()
 }
 
-func (p *NodeClient) sendTruncate(req *TruncateRequest) (err error) {
+func (p *NodeClient) sendSetPersistRateLimit(req *NodeSetPersistRateLimitRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("truncate", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("setPersistRateLimit", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeTruncateArgs{
+	args := NodeSetPersistRateLimitArgs{
 		Req: req,
 	}
 	if err = args.Write(oprot); err != nil {
@@ -11611,7 +13370,7 @@ func (p *NodeClient) sendTruncate(req *TruncateRequest) (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvTruncate() (value *TruncateResult_, err error) {
+func (p *NodeClient) recvSetPersistRateLimit() (value *NodePersistRateLimitResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -11621,32 +13380,32 @@ func (p *NodeClient) recvTruncate() (value *TruncateResult_, err error) {
 	if err != nil {
 		return
 	}
-	if method != "truncate" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "truncate failed: wrong method name")
+	if method != "setPersistRateLimit" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "setPersistRateLimit failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "truncate failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "setPersistRateLimit failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error47 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error48 error
-		error48, err = error47.Read(iprot)
+		error55 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error56 error
+		error56, err = error55.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error48
+		err = error56
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "truncate failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "setPersistRateLimit failed: invalid message type")
 		return
 	}
-	result := NodeTruncateResult{}
+	result := NodeSetPersistRateLimitResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -11661,24 +13420,24 @@ func (p *NodeClient) recvTruncate() (value *TruncateResult_, err error) {
 	return
 }
 
-func (p *NodeClient) Health() (r *NodeHealthResult_, err error) {
-	if err = p.sendHealth(); err != nil {
+func (p *NodeClient) GetWriteNewSeriesAsync() (r *NodeWriteNewSeriesAsyncResult_, err error) {
+	if err = p.sendGetWriteNewSeriesAsync(); err != nil {
 		return
 	}
-	return p.recvHealth()
+	return p.recvGetWriteNewSeriesAsync()
 }
 
-func (p *NodeClient) sendHealth() (err error) {
+func (p *NodeClient) sendGetWriteNewSeriesAsync() (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("health", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("getWriteNewSeriesAsync", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeHealthArgs{}
+	args := NodeGetWriteNewSeriesAsyncArgs{}
 	if err = args.Write(oprot); err != nil {
 		return
 	}
@@ -11688,7 +13447,7 @@ func (p *NodeClient) sendHealth() (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvHealth() (value *NodeHealthResult_, err error) {
+func (p *NodeClient) recvGetWriteNewSeriesAsync() (value *NodeWriteNewSeriesAsyncResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -11698,32 +13457,32 @@ func (p *NodeClient) recvHealth() (value *NodeHealthResult_, err error) {
 	if err != nil {
 		return
 	}
-	if method != "health" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "health failed: wrong method name")
+	if method != "getWriteNewSeriesAsync" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "getWriteNewSeriesAsync failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "health failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "getWriteNewSeriesAsync failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error49 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error50 error
-		error50, err = error49.Read(iprot)
+		error57 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error58 error
+		error58, err = error57.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error50
+		err = error58
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "health failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "getWriteNewSeriesAsync failed: invalid message type")
 		return
 	}
-	result := NodeHealthResult{}
+	result := NodeGetWriteNewSeriesAsyncResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -11738,24 +13497,28 @@ func (p *NodeClient) recvHealth() (value *NodeHealthResult_, err error) {
 	return
 }
 
-func (p *NodeClient) Bootstrapped() (r *NodeBootstrappedResult_, err error) {
-	if err = p.sendBootstrapped(); err != nil {
+// Parameters:
+//  - Req
+func (p *NodeClient) SetWriteNewSeriesAsync(req *NodeSetWriteNewSeriesAsyncRequest) (r *NodeWriteNewSeriesAsyncResult_, err error) {
+	if err = p.sendSetWriteNewSeriesAsync(req); err != nil {
 		return
 	}
-	return p.recvBootstrapped()
+	return p.recvSetWriteNewSeriesAsync()
 }
 
-func (p *NodeClient) sendBootstrapped() (err error) {
+func (p *NodeClient) sendSetWriteNewSeriesAsync(req *NodeSetWriteNewSeriesAsyncRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("bootstrapped", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("setWriteNewSeriesAsync", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeBootstrappedArgs{}
+	args := NodeSetWriteNewSeriesAsyncArgs{
+		Req: req,
+	}
 	if err = args.Write(oprot); err != nil {
 		return
 	}
@@ -11765,7 +13528,7 @@ func (p *NodeClient) sendBootstrapped() (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvBootstrapped() (value *NodeBootstrappedResult_, err error) {
+func (p *NodeClient) recvSetWriteNewSeriesAsync() (value *NodeWriteNewSeriesAsyncResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -11775,32 +13538,32 @@ func (p *NodeClient) recvBootstrapped() (value *NodeBootstrappedResult_, err err
 	if err != nil {
 		return
 	}
-	if method != "bootstrapped" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "bootstrapped failed: wrong method name")
+	if method != "setWriteNewSeriesAsync" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "setWriteNewSeriesAsync failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "bootstrapped failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "setWriteNewSeriesAsync failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error51 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error52 error
-		error52, err = error51.Read(iprot)
+		error59 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error60 error
+		error60, err = error59.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error52
+		err = error60
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "bootstrapped failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "setWriteNewSeriesAsync failed: invalid message type")
 		return
 	}
-	result := NodeBootstrappedResult{}
+	result := NodeSetWriteNewSeriesAsyncResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -11815,24 +13578,24 @@ func (p *NodeClient) recvBootstrapped() (value *NodeBootstrappedResult_, err err
 	return
 }
 
-func (p *NodeClient) GetPersistRateLimit() (r *NodePersistRateLimitResult_, err error) {
-	if err = p.sendGetPersistRateLimit(); err != nil {
+func (p *NodeClient) GetWriteNewSeriesBackoffDuration() (r *NodeWriteNewSeriesBackoffDurationResult_, err error) {
+	if err = p.sendGetWriteNewSeriesBackoffDuration(); err != nil {
 		return
 	}
-	return p.recvGetPersistRateLimit()
+	return p.recvGetWriteNewSeriesBackoffDuration()
 }
 
-func (p *NodeClient) sendGetPersistRateLimit() (err error) {
+func (p *NodeClient) sendGetWriteNewSeriesBackoffDuration() (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("getPersistRateLimit", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("getWriteNewSeriesBackoffDuration", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeGetPersistRateLimitArgs{}
+	args := NodeGetWriteNewSeriesBackoffDurationArgs{}
 	if err = args.Write(oprot); err != nil {
 		return
 	}
@@ -11842,7 +13605,7 @@ func (p *NodeClient) sendGetPersistRateLimit() (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvGetPersistRateLimit() (value *NodePersistRateLimitResult_, err error) {
+func (p *NodeClient) recvGetWriteNewSeriesBackoffDuration() (value *NodeWriteNewSeriesBackoffDurationResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -11852,32 +13615,32 @@ func (p *NodeClient) recvGetPersistRateLimit() (value *NodePersistRateLimitResul
 	if err != nil {
 		return
 	}
-	if method != "getPersistRateLimit" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "getPersistRateLimit failed: wrong method name")
+	if method != "getWriteNewSeriesBackoffDuration" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "getWriteNewSeriesBackoffDuration failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "getPersistRateLimit failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "getWriteNewSeriesBackoffDuration failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error53 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error54 error
-		error54, err = error53.Read(iprot)
+		error61 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error62 error
+		error62, err = error61.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error54
+		err = error62
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "getPersistRateLimit failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "getWriteNewSeriesBackoffDuration failed: invalid message type")
 		return
 	}
-	result := NodeGetPersistRateLimitResult{}
+	result := NodeGetWriteNewSeriesBackoffDurationResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -11894,24 +13657,24 @@ func (p *NodeClient) recvGetPersistRateLimit() (value *NodePersistRateLimitResul
 
 // Parameters:
 //  - Req
-func (p *NodeClient) SetPersistRateLimit(req *NodeSetPersistRateLimitRequest) (r *NodePersistRateLimitResult_, err error) {
-	if err = p.sendSetPersistRateLimit(req); err != nil {
+func (p *NodeClient) SetWriteNewSeriesBackoffDuration(req *NodeSetWriteNewSeriesBackoffDurationRequest) (r *NodeWriteNewSeriesBackoffDurationResult_, err error) {
+	if err = p.sendSetWriteNewSeriesBackoffDuration(req); err != nil {
 		return
 	}
-	return p.recvSetPersistRateLimit()
+	return p.recvSetWriteNewSeriesBackoffDuration()
 }
 
-func (p *NodeClient) sendSetPersistRateLimit(req *NodeSetPersistRateLimitRequest) (err error) {
+func (p *NodeClient) sendSetWriteNewSeriesBackoffDuration(req *NodeSetWriteNewSeriesBackoffDurationRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("setPersistRateLimit", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("setWriteNewSeriesBackoffDuration", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeSetPersistRateLimitArgs{
+	args := NodeSetWriteNewSeriesBackoffDurationArgs{
 		Req: req,
 	}
 	if err = args.Write(oprot); err != nil {
@@ -11923,7 +13686,7 @@ func (p *NodeClient) sendSetPersistRateLimit(req *NodeSetPersistRateLimitRequest
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvSetPersistRateLimit() (value *NodePersistRateLimitResult_, err error) {
+func (p *NodeClient) recvSetWriteNewSeriesBackoffDuration() (value *NodeWriteNewSeriesBackoffDurationResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -11933,32 +13696,32 @@ func (p *NodeClient) recvSetPersistRateLimit() (value *NodePersistRateLimitResul
 	if err != nil {
 		return
 	}
-	if method != "setPersistRateLimit" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "setPersistRateLimit failed: wrong method name")
+	if method != "setWriteNewSeriesBackoffDuration" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "setWriteNewSeriesBackoffDuration failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "setPersistRateLimit failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "setWriteNewSeriesBackoffDuration failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error55 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error56 error
-		error56, err = error55.Read(iprot)
+		error63 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error64 error
+		error64, err = error63.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error56
+		err = error64
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "setPersistRateLimit failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "setWriteNewSeriesBackoffDuration failed: invalid message type")
 		return
 	}
-	result := NodeSetPersistRateLimitResult{}
+	result := NodeSetWriteNewSeriesBackoffDurationResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -11973,24 +13736,24 @@ func (p *NodeClient) recvSetPersistRateLimit() (value *NodePersistRateLimitResul
 	return
 }
 
-func (p *NodeClient) GetWriteNewSeriesAsync() (r *NodeWriteNewSeriesAsyncResult_, err error) {
-	if err = p.sendGetWriteNewSeriesAsync(); err != nil {
+func (p *NodeClient) GetWriteNewSeriesLimitPerShardPerSecond() (r *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error) {
+	if err = p.sendGetWriteNewSeriesLimitPerShardPerSecond(); err != nil {
 		return
 	}
-	return p.recvGetWriteNewSeriesAsync()
+	return p.recvGetWriteNewSeriesLimitPerShardPerSecond()
 }
 
-func (p *NodeClient) sendGetWriteNewSeriesAsync() (err error) {
+func (p *NodeClient) sendGetWriteNewSeriesLimitPerShardPerSecond() (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("getWriteNewSeriesAsync", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("getWriteNewSeriesLimitPerShardPerSecond", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeGetWriteNewSeriesAsyncArgs{}
+	args := NodeGetWriteNewSeriesLimitPerShardPerSecondArgs{}
 	if err = args.Write(oprot); err != nil {
 		return
 	}
@@ -12000,7 +13763,7 @@ func (p *NodeClient) sendGetWriteNewSeriesAsync() (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvGetWriteNewSeriesAsync() (value *NodeWriteNewSeriesAsyncResult_, err error) {
+func (p *NodeClient) recvGetWriteNewSeriesLimitPerShardPerSecond() (value *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -12010,32 +13773,32 @@ func (p *NodeClient) recvGetWriteNewSeriesAsync() (value *NodeWriteNewSeriesAsyn
 	if err != nil {
 		return
 	}
-	if method != "getWriteNewSeriesAsync" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "getWriteNewSeriesAsync failed: wrong method name")
+	if method != "getWriteNewSeriesLimitPerShardPerSecond" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "getWriteNewSeriesLimitPerShardPerSecond failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "getWriteNewSeriesAsync failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "getWriteNewSeriesLimitPerShardPerSecond failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error57 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error58 error
-		error58, err = error57.Read(iprot)
+		error65 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error66 error
+		error66, err = error65.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error58
+		err = error66
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "getWriteNewSeriesAsync failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "getWriteNewSeriesLimitPerShardPerSecond failed: invalid message type")
 		return
 	}
-	result := NodeGetWriteNewSeriesAsyncResult{}
+	result := NodeGetWriteNewSeriesLimitPerShardPerSecondResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -12052,24 +13815,24 @@ func (p *NodeClient) recvGetWriteNewSeriesAsync() (value *NodeWriteNewSeriesAsyn
 
 // Parameters:
 //  - Req
-func (p *NodeClient) SetWriteNewSeriesAsync(req *NodeSetWriteNewSeriesAsyncRequest) (r *NodeWriteNewSeriesAsyncResult_, err error) {
-	if err = p.sendSetWriteNewSeriesAsync(req); err != nil {
+func (p *NodeClient) SetWriteNewSeriesLimitPerShardPerSecond(req *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) (r *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error) {
+	if err = p.sendSetWriteNewSeriesLimitPerShardPerSecond(req); err != nil {
 		return
 	}
-	return p.recvSetWriteNewSeriesAsync()
+	return p.recvSetWriteNewSeriesLimitPerShardPerSecond()
 }
 
-func (p *NodeClient) sendSetWriteNewSeriesAsync(req *NodeSetWriteNewSeriesAsyncRequest) (err error) {
+func (p *NodeClient) sendSetWriteNewSeriesLimitPerShardPerSecond(req *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("setWriteNewSeriesAsync", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("setWriteNewSeriesLimitPerShardPerSecond", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeSetWriteNewSeriesAsyncArgs{
+	args := NodeSetWriteNewSeriesLimitPerShardPerSecondArgs{
 		Req: req,
 	}
 	if err = args.Write(oprot); err != nil {
@@ -12081,7 +13844,7 @@ func (p *NodeClient) sendSetWriteNewSeriesAsync(req *NodeSetWriteNewSeriesAsyncR
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvSetWriteNewSeriesAsync() (value *NodeWriteNewSeriesAsyncResult_, err error) {
+func (p *NodeClient) recvSetWriteNewSeriesLimitPerShardPerSecond() (value *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -12091,32 +13854,32 @@ func (p *NodeClient) recvSetWriteNewSeriesAsync() (value *NodeWriteNewSeriesAsyn
 	if err != nil {
 		return
 	}
-	if method != "setWriteNewSeriesAsync" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "setWriteNewSeriesAsync failed: wrong method name")
+	if method != "setWriteNewSeriesLimitPerShardPerSecond" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "setWriteNewSeriesLimitPerShardPerSecond failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "setWriteNewSeriesAsync failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "setWriteNewSeriesLimitPerShardPerSecond failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error59 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error60 error
-		error60, err = error59.Read(iprot)
+		error67 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error68 error
+		error68, err = error67.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error60
+		err = error68
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "setWriteNewSeriesAsync failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "setWriteNewSeriesLimitPerShardPerSecond failed: invalid message type")
 		return
 	}
-	result := NodeSetWriteNewSeriesAsyncResult{}
+	result := NodeSetWriteNewSeriesLimitPerShardPerSecondResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -12131,398 +13894,502 @@ func (p *NodeClient) recvSetWriteNewSeriesAsync() (value *NodeWriteNewSeriesAsyn
 	return
 }
 
-func (p *NodeClient) GetWriteNewSeriesBackoffDuration() (r *NodeWriteNewSeriesBackoffDurationResult_, err error) {
-	if err = p.sendGetWriteNewSeriesBackoffDuration(); err != nil {
-		return
+type NodeProcessor struct {
+	processorMap map[string]thrift.TProcessorFunction
+	handler      Node
+}
+
+func (p *NodeProcessor) AddToProcessorMap(key string, processor thrift.TProcessorFunction) {
+	p.processorMap[key] = processor
+}
+
+func (p *NodeProcessor) GetProcessorFunction(key string) (processor thrift.TProcessorFunction, ok bool) {
+	processor, ok = p.processorMap[key]
+	return processor, ok
+}
+
+func (p *NodeProcessor) ProcessorMap() map[string]thrift.TProcessorFunction {
+	return p.processorMap
+}
+
+func NewNodeProcessor(handler Node) *NodeProcessor {
+
+	self69 := &NodeProcessor{handler: handler, processorMap: make(map[string]thrift.TProcessorFunction)}
+	self69.processorMap["query"] = &nodeProcessorQuery{handler: handler}
+	self69.processorMap["fetch"] = &nodeProcessorFetch{handler: handler}
+	self69.processorMap["fetchTagged"] = &nodeProcessorFetchTagged{handler: handler}
+	self69.processorMap["write"] = &nodeProcessorWrite{handler: handler}
+	self69.processorMap["writeTagged"] = &nodeProcessorWriteTagged{handler: handler}
+	self69.processorMap["fetchBatchRaw"] = &nodeProcessorFetchBatchRaw{handler: handler}
+	self69.processorMap["fetchBlocksRaw"] = &nodeProcessorFetchBlocksRaw{handler: handler}
+	self69.processorMap["fetchBlocksMetadataRaw"] = &nodeProcessorFetchBlocksMetadataRaw{handler: handler}
+	self69.processorMap["fetchBlocksMetadataRawV2"] = &nodeProcessorFetchBlocksMetadataRawV2{handler: handler}
+	self69.processorMap["writeBatchRaw"] = &nodeProcessorWriteBatchRaw{handler: handler}
+	self69.processorMap["writeTaggedBatchRaw"] = &nodeProcessorWriteTaggedBatchRaw{handler: handler}
+	self69.processorMap["repair"] = &nodeProcessorRepair{handler: handler}
+	self69.processorMap["truncate"] = &nodeProcessorTruncate{handler: handler}
+	self69.processorMap["deleteTagged"] = &nodeProcessorDeleteTagged{handler: handler}
+	self69.processorMap["aggregate"] = &nodeProcessorAggregate{handler: handler}
+	self69.processorMap["health"] = &nodeProcessorHealth{handler: handler}
+	self69.processorMap["bootstrapped"] = &nodeProcessorBootstrapped{handler: handler}
+	self69.processorMap["getPersistRateLimit"] = &nodeProcessorGetPersistRateLimit{handler: handler}
+	self69.processorMap["setPersistRateLimit"] = &nodeProcessorSetPersistRateLimit{handler: handler}
+	self69.processorMap["getWriteNewSeriesAsync"] = &nodeProcessorGetWriteNewSeriesAsync{handler: handler}
+	self69.processorMap["setWriteNewSeriesAsync"] = &nodeProcessorSetWriteNewSeriesAsync{handler: handler}
+	self69.processorMap["getWriteNewSeriesBackoffDuration"] = &nodeProcessorGetWriteNewSeriesBackoffDuration{handler: handler}
+	self69.processorMap["setWriteNewSeriesBackoffDuration"] = &nodeProcessorSetWriteNewSeriesBackoffDuration{handler: handler}
+	self69.processorMap["getWriteNewSeriesLimitPerShardPerSecond"] = &nodeProcessorGetWriteNewSeriesLimitPerShardPerSecond{handler: handler}
+	self69.processorMap["setWriteNewSeriesLimitPerShardPerSecond"] = &nodeProcessorSetWriteNewSeriesLimitPerShardPerSecond{handler: handler}
+	return self69
+}
+
+func (p *NodeProcessor) Process(iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	name, _, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return false, err
 	}
-	return p.recvGetWriteNewSeriesBackoffDuration()
+	if processor, ok := p.GetProcessorFunction(name); ok {
+		return processor.Process(seqId, iprot, oprot)
+	}
+	iprot.Skip(thrift.STRUCT)
+	iprot.ReadMessageEnd()
+	x70 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function "+name)
+	oprot.WriteMessageBegin(name, thrift.EXCEPTION, seqId)
+	x70.Write(oprot)
+	oprot.WriteMessageEnd()
+	oprot.Flush()
+	return false, x70
+
 }
 
-func (p *NodeClient) sendGetWriteNewSeriesBackoffDuration() (err error) {
-	oprot := p.OutputProtocol
-	if oprot == nil {
-		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.OutputProtocol = oprot
+type nodeProcessorQuery struct {
+	handler Node
+}
+
+func (p *nodeProcessorQuery) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeQueryArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("query", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
 	}
-	p.SeqId++
-	if err = oprot.WriteMessageBegin("getWriteNewSeriesBackoffDuration", thrift.CALL, p.SeqId); err != nil {
-		return
+
+	iprot.ReadMessageEnd()
+	result := NodeQueryResult{}
+	var retval *QueryResult_
+	var err2 error
+	if retval, err2 = p.handler.Query(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *Error:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing query: "+err2.Error())
+			oprot.WriteMessageBegin("query", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
 	}
-	args := NodeGetWriteNewSeriesBackoffDurationArgs{}
-	if err = args.Write(oprot); err != nil {
-		return
+	if err2 = oprot.WriteMessageBegin("query", thrift.REPLY, seqId); err2 != nil {
+		err = err2
 	}
-	if err = oprot.WriteMessageEnd(); err != nil {
-		return
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
 	}
-	return oprot.Flush()
-}
-
-func (p *NodeClient) recvGetWriteNewSeriesBackoffDuration() (value *NodeWriteNewSeriesBackoffDurationResult_, err error) {
-	iprot := p.InputProtocol
-	if iprot == nil {
-		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.InputProtocol = iprot
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
 	}
-	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-	if err != nil {
-		return
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
 	}
-	if method != "getWriteNewSeriesBackoffDuration" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "getWriteNewSeriesBackoffDuration failed: wrong method name")
+	if err != nil {
 		return
 	}
-	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "getWriteNewSeriesBackoffDuration failed: out of sequence response")
-		return
+	return true, err
+}
+
+type nodeProcessorFetch struct {
+	handler Node
+}
+
+func (p *nodeProcessorFetch) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeFetchArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("fetch", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
 	}
-	if mTypeId == thrift.EXCEPTION {
-		error61 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error62 error
-		error62, err = error61.Read(iprot)
-		if err != nil {
-			return
-		}
-		if err = iprot.ReadMessageEnd(); err != nil {
-			return
+
+	iprot.ReadMessageEnd()
+	result := NodeFetchResult{}
+	var retval *FetchResult_
+	var err2 error
+	if retval, err2 = p.handler.Fetch(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *Error:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetch: "+err2.Error())
+			oprot.WriteMessageBegin("fetch", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
 		}
-		err = error62
-		return
+	} else {
+		result.Success = retval
 	}
-	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "getWriteNewSeriesBackoffDuration failed: invalid message type")
-		return
+	if err2 = oprot.WriteMessageBegin("fetch", thrift.REPLY, seqId); err2 != nil {
+		err = err2
 	}
-	result := NodeGetWriteNewSeriesBackoffDurationResult{}
-	if err = result.Read(iprot); err != nil {
-		return
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
 	}
-	if err = iprot.ReadMessageEnd(); err != nil {
-		return
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
 	}
-	if result.Err != nil {
-		err = result.Err
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
 		return
 	}
-	value = result.GetSuccess()
-	return
+	return true, err
 }
 
-// Parameters:
-//  - Req
-func (p *NodeClient) SetWriteNewSeriesBackoffDuration(req *NodeSetWriteNewSeriesBackoffDurationRequest) (r *NodeWriteNewSeriesBackoffDurationResult_, err error) {
-	if err = p.sendSetWriteNewSeriesBackoffDuration(req); err != nil {
-		return
-	}
-	return p.recvSetWriteNewSeriesBackoffDuration()
+type nodeProcessorFetchTagged struct {
+	handler Node
 }
 
-func (p *NodeClient) sendSetWriteNewSeriesBackoffDuration(req *NodeSetWriteNewSeriesBackoffDurationRequest) (err error) {
-	oprot := p.OutputProtocol
-	if oprot == nil {
-		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.OutputProtocol = oprot
+func (p *nodeProcessorFetchTagged) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeFetchTaggedArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("fetchTagged", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
 	}
-	p.SeqId++
-	if err = oprot.WriteMessageBegin("setWriteNewSeriesBackoffDuration", thrift.CALL, p.SeqId); err != nil {
-		return
+
+	iprot.ReadMessageEnd()
+	result := NodeFetchTaggedResult{}
+	var retval *FetchTaggedResult_
+	var err2 error
+	if retval, err2 = p.handler.FetchTagged(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *Error:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetchTagged: "+err2.Error())
+			oprot.WriteMessageBegin("fetchTagged", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
 	}
-	args := NodeSetWriteNewSeriesBackoffDurationArgs{
-		Req: req,
+	if err2 = oprot.WriteMessageBegin("fetchTagged", thrift.REPLY, seqId); err2 != nil {
+		err = err2
 	}
-	if err = args.Write(oprot); err != nil {
-		return
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
 	}
-	if err = oprot.WriteMessageEnd(); err != nil {
-		return
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
 	}
-	return oprot.Flush()
-}
-
-func (p *NodeClient) recvSetWriteNewSeriesBackoffDuration() (value *NodeWriteNewSeriesBackoffDurationResult_, err error) {
-	iprot := p.InputProtocol
-	if iprot == nil {
-		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.InputProtocol = iprot
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
 	}
-	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
 	if err != nil {
 		return
 	}
-	if method != "setWriteNewSeriesBackoffDuration" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "setWriteNewSeriesBackoffDuration failed: wrong method name")
-		return
-	}
-	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "setWriteNewSeriesBackoffDuration failed: out of sequence response")
-		return
+	return true, err
+}
+
+type nodeProcessorWrite struct {
+	handler Node
+}
+
+func (p *nodeProcessorWrite) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeWriteArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("write", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
 	}
-	if mTypeId == thrift.EXCEPTION {
-		error63 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error64 error
-		error64, err = error63.Read(iprot)
-		if err != nil {
-			return
-		}
-		if err = iprot.ReadMessageEnd(); err != nil {
-			return
+
+	iprot.ReadMessageEnd()
+	result := NodeWriteResult{}
+	var err2 error
+	if err2 = p.handler.Write(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *Error:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing write: "+err2.Error())
+			oprot.WriteMessageBegin("write", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
 		}
-		err = error64
-		return
 	}
-	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "setWriteNewSeriesBackoffDuration failed: invalid message type")
-		return
+	if err2 = oprot.WriteMessageBegin("write", thrift.REPLY, seqId); err2 != nil {
+		err = err2
 	}
-	result := NodeSetWriteNewSeriesBackoffDurationResult{}
-	if err = result.Read(iprot); err != nil {
-		return
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
 	}
-	if err = iprot.ReadMessageEnd(); err != nil {
-		return
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
 	}
-	if result.Err != nil {
-		err = result.Err
-		return
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
 	}
-	value = result.GetSuccess()
-	return
-}
-
-func (p *NodeClient) GetWriteNewSeriesLimitPerShardPerSecond() (r *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error) {
-	if err = p.sendGetWriteNewSeriesLimitPerShardPerSecond(); err != nil {
+	if err != nil {
 		return
 	}
-	return p.recvGetWriteNewSeriesLimitPerShardPerSecond()
+	return true, err
 }
 
-func (p *NodeClient) sendGetWriteNewSeriesLimitPerShardPerSecond() (err error) {
-	oprot := p.OutputProtocol
-	if oprot == nil {
-		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.OutputProtocol = oprot
-	}
-	p.SeqId++
-	if err = oprot.WriteMessageBegin("getWriteNewSeriesLimitPerShardPerSecond", thrift.CALL, p.SeqId); err != nil {
-		return
-	}
-	args := NodeGetWriteNewSeriesLimitPerShardPerSecondArgs{}
-	if err = args.Write(oprot); err != nil {
-		return
-	}
-	if err = oprot.WriteMessageEnd(); err != nil {
-		return
-	}
-	return oprot.Flush()
+type nodeProcessorWriteTagged struct {
+	handler Node
 }
 
-func (p *NodeClient) recvGetWriteNewSeriesLimitPerShardPerSecond() (value *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error) {
-	iprot := p.InputProtocol
-	if iprot == nil {
-		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.InputProtocol = iprot
-	}
-	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-	if err != nil {
-		return
-	}
-	if method != "getWriteNewSeriesLimitPerShardPerSecond" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "getWriteNewSeriesLimitPerShardPerSecond failed: wrong method name")
-		return
-	}
-	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "getWriteNewSeriesLimitPerShardPerSecond failed: out of sequence response")
-		return
+func (p *nodeProcessorWriteTagged) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeWriteTaggedArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("writeTagged", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
 	}
-	if mTypeId == thrift.EXCEPTION {
-		error65 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error66 error
-		error66, err = error65.Read(iprot)
-		if err != nil {
-			return
-		}
-		if err = iprot.ReadMessageEnd(); err != nil {
-			return
+
+	iprot.ReadMessageEnd()
+	result := NodeWriteTaggedResult{}
+	var err2 error
+	if err2 = p.handler.WriteTagged(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *Error:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing writeTagged: "+err2.Error())
+			oprot.WriteMessageBegin("writeTagged", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
 		}
-		err = error66
-		return
 	}
-	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "getWriteNewSeriesLimitPerShardPerSecond failed: invalid message type")
-		return
+	if err2 = oprot.WriteMessageBegin("writeTagged", thrift.REPLY, seqId); err2 != nil {
+		err = err2
 	}
-	result := NodeGetWriteNewSeriesLimitPerShardPerSecondResult{}
-	if err = result.Read(iprot); err != nil {
-		return
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
 	}
-	if err = iprot.ReadMessageEnd(); err != nil {
-		return
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
 	}
-	if result.Err != nil {
-		err = result.Err
+	if err != nil {
 		return
 	}
-	value = result.GetSuccess()
-	return
+	return true, err
 }
 
-// Parameters:
-//  - Req
-func (p *NodeClient) SetWriteNewSeriesLimitPerShardPerSecond(req *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) (r *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error) {
-	if err = p.sendSetWriteNewSeriesLimitPerShardPerSecond(req); err != nil {
-		return
-	}
-	return p.recvSetWriteNewSeriesLimitPerShardPerSecond()
+type nodeProcessorFetchBatchRaw struct {
+	handler Node
 }
 
-func (p *NodeClient) sendSetWriteNewSeriesLimitPerShardPerSecond(req *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) (err error) {
-	oprot := p.OutputProtocol
-	if oprot == nil {
-		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.OutputProtocol = oprot
+func (p *nodeProcessorFetchBatchRaw) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeFetchBatchRawArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("fetchBatchRaw", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
 	}
-	p.SeqId++
-	if err = oprot.WriteMessageBegin("setWriteNewSeriesLimitPerShardPerSecond", thrift.CALL, p.SeqId); err != nil {
-		return
+
+	iprot.ReadMessageEnd()
+	result := NodeFetchBatchRawResult{}
+	var retval *FetchBatchRawResult_
+	var err2 error
+	if retval, err2 = p.handler.FetchBatchRaw(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *Error:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetchBatchRaw: "+err2.Error())
+			oprot.WriteMessageBegin("fetchBatchRaw", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
 	}
-	args := NodeSetWriteNewSeriesLimitPerShardPerSecondArgs{
-		Req: req,
+	if err2 = oprot.WriteMessageBegin("fetchBatchRaw", thrift.REPLY, seqId); err2 != nil {
+		err = err2
 	}
-	if err = args.Write(oprot); err != nil {
-		return
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
 	}
-	if err = oprot.WriteMessageEnd(); err != nil {
-		return
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
 	}
-	return oprot.Flush()
-}
-
-func (p *NodeClient) recvSetWriteNewSeriesLimitPerShardPerSecond() (value *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error) {
-	iprot := p.InputProtocol
-	if iprot == nil {
-		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.InputProtocol = iprot
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
 	}
-	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
 	if err != nil {
 		return
 	}
-	if method != "setWriteNewSeriesLimitPerShardPerSecond" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "setWriteNewSeriesLimitPerShardPerSecond failed: wrong method name")
-		return
-	}
-	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "setWriteNewSeriesLimitPerShardPerSecond failed: out of sequence response")
-		return
+	return true, err
+}
+
+type nodeProcessorFetchBlocksRaw struct {
+	handler Node
+}
+
+func (p *nodeProcessorFetchBlocksRaw) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeFetchBlocksRawArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("fetchBlocksRaw", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
 	}
-	if mTypeId == thrift.EXCEPTION {
-		error67 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error68 error
-		error68, err = error67.Read(iprot)
-		if err != nil {
-			return
-		}
-		if err = iprot.ReadMessageEnd(); err != nil {
-			return
+
+	iprot.ReadMessageEnd()
+	result := NodeFetchBlocksRawResult{}
+	var retval *FetchBlocksRawResult_
+	var err2 error
+	if retval, err2 = p.handler.FetchBlocksRaw(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *Error:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetchBlocksRaw: "+err2.Error())
+			oprot.WriteMessageBegin("fetchBlocksRaw", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
 		}
-		err = error68
-		return
+	} else {
+		result.Success = retval
 	}
-	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "setWriteNewSeriesLimitPerShardPerSecond failed: invalid message type")
-		return
+	if err2 = oprot.WriteMessageBegin("fetchBlocksRaw", thrift.REPLY, seqId); err2 != nil {
+		err = err2
 	}
-	result := NodeSetWriteNewSeriesLimitPerShardPerSecondResult{}
-	if err = result.Read(iprot); err != nil {
-		return
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
 	}
-	if err = iprot.ReadMessageEnd(); err != nil {
-		return
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
 	}
-	if result.Err != nil {
-		err = result.Err
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
 		return
 	}
-	value = result.GetSuccess()
-	return
-}
-
-type NodeProcessor struct {
-	processorMap map[string]thrift.TProcessorFunction
-	handler      Node
-}
-
-func (p *NodeProcessor) AddToProcessorMap(key string, processor thrift.TProcessorFunction) {
-	p.processorMap[key] = processor
-}
-
-func (p *NodeProcessor) GetProcessorFunction(key string) (processor thrift.TProcessorFunction, ok bool) {
-	processor, ok = p.processorMap[key]
-	return processor, ok
-}
-
-func (p *NodeProcessor) ProcessorMap() map[string]thrift.TProcessorFunction {
-	return p.processorMap
+	return true, err
 }
 
-func NewNodeProcessor(handler Node) *NodeProcessor {
-
-	self69 := &NodeProcessor{handler: handler, processorMap: make(map[string]thrift.TProcessorFunction)}
-	self69.processorMap["query"] = &nodeProcessorQuery{handler: handler}
-	self69.processorMap["fetch"] = &nodeProcessorFetch{handler: handler}
-	self69.processorMap["fetchTagged"] = &nodeProcessorFetchTagged{handler: handler}
-	self69.processorMap["write"] = &nodeProcessorWrite{handler: handler}
-	self69.processorMap["writeTagged"] = &nodeProcessorWriteTagged{handler: handler}
-	self69.processorMap["fetchBatchRaw"] = &nodeProcessorFetchBatchRaw{handler: handler}
-	self69.processorMap["fetchBlocksRaw"] = &nodeProcessorFetchBlocksRaw{handler: handler}
-	self69.processorMap["fetchBlocksMetadataRaw"] = &nodeProcessorFetchBlocksMetadataRaw{handler: handler}
-	self69.processorMap["fetchBlocksMetadataRawV2"] = &nodeProcessorFetchBlocksMetadataRawV2{handler: handler}
-	self69.processorMap["writeBatchRaw"] = &nodeProcessorWriteBatchRaw{handler: handler}
-	self69.processorMap["writeTaggedBatchRaw"] = &nodeProcessorWriteTaggedBatchRaw{handler: handler}
-	self69.processorMap["repair"] = &nodeProcessorRepair{handler: handler}
-	self69.processorMap["truncate"] = &nodeProcessorTruncate{handler: handler}
-	self69.processorMap["health"] = &nodeProcessorHealth{handler: handler}
-	self69.processorMap["bootstrapped"] = &nodeProcessorBootstrapped{handler: handler}
-	self69.processorMap["getPersistRateLimit"] = &nodeProcessorGetPersistRateLimit{handler: handler}
-	self69.processorMap["setPersistRateLimit"] = &nodeProcessorSetPersistRateLimit{handler: handler}
-	self69.processorMap["getWriteNewSeriesAsync"] = &nodeProcessorGetWriteNewSeriesAsync{handler: handler}
-	self69.processorMap["setWriteNewSeriesAsync"] = &nodeProcessorSetWriteNewSeriesAsync{handler: handler}
-	self69.processorMap["getWriteNewSeriesBackoffDuration"] = &nodeProcessorGetWriteNewSeriesBackoffDuration{handler: handler}
-	self69.processorMap["setWriteNewSeriesBackoffDuration"] = &nodeProcessorSetWriteNewSeriesBackoffDuration{handler: handler}
-	self69.processorMap["getWriteNewSeriesLimitPerShardPerSecond"] = &nodeProcessorGetWriteNewSeriesLimitPerShardPerSecond{handler: handler}
-	self69.processorMap["setWriteNewSeriesLimitPerShardPerSecond"] = &nodeProcessorSetWriteNewSeriesLimitPerShardPerSecond{handler: handler}
-	return self69
+type nodeProcessorFetchBlocksMetadataRaw struct {
+	handler Node
 }
 
-func (p *NodeProcessor) Process(iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	name, _, seqId, err := iprot.ReadMessageBegin()
-	if err != nil {
+func (p *nodeProcessorFetchBlocksMetadataRaw) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeFetchBlocksMetadataRawArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("fetchBlocksMetadataRaw", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
 		return false, err
 	}
-	if processor, ok := p.GetProcessorFunction(name); ok {
-		return processor.Process(seqId, iprot, oprot)
-	}
-	iprot.Skip(thrift.STRUCT)
-	iprot.ReadMessageEnd()
-	x70 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function "+name)
-	oprot.WriteMessageBegin(name, thrift.EXCEPTION, seqId)
-	x70.Write(oprot)
-	oprot.WriteMessageEnd()
-	oprot.Flush()
-	return false, x70
 
+	iprot.ReadMessageEnd()
+	result := NodeFetchBlocksMetadataRawResult{}
+	var retval *FetchBlocksMetadataRawResult_
+	var err2 error
+	if retval, err2 = p.handler.FetchBlocksMetadataRaw(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *Error:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetchBlocksMetadataRaw: "+err2.Error())
+			oprot.WriteMessageBegin("fetchBlocksMetadataRaw", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("fetchBlocksMetadataRaw", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
 }
 
-type nodeProcessorQuery struct {
+type nodeProcessorFetchBlocksMetadataRawV2 struct {
 	handler Node
 }
 
-func (p *nodeProcessorQuery) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeQueryArgs{}
+func (p *nodeProcessorFetchBlocksMetadataRawV2) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeFetchBlocksMetadataRawV2Args{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("query", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("fetchBlocksMetadataRawV2", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -12530,16 +14397,16 @@ func (p *nodeProcessorQuery) Process(seqId int32, iprot, oprot thrift.TProtocol)
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeQueryResult{}
-	var retval *QueryResult_
+	result := NodeFetchBlocksMetadataRawV2Result{}
+	var retval *FetchBlocksMetadataRawV2Result_
 	var err2 error
-	if retval, err2 = p.handler.Query(args.Req); err2 != nil {
+	if retval, err2 = p.handler.FetchBlocksMetadataRawV2(args.Req); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing query: "+err2.Error())
-			oprot.WriteMessageBegin("query", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetchBlocksMetadataRawV2: "+err2.Error())
+			oprot.WriteMessageBegin("fetchBlocksMetadataRawV2", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -12548,7 +14415,7 @@ func (p *nodeProcessorQuery) Process(seqId int32, iprot, oprot thrift.TProtocol)
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("query", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("fetchBlocksMetadataRawV2", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -12566,16 +14433,16 @@ func (p *nodeProcessorQuery) Process(seqId int32, iprot, oprot thrift.TProtocol)
 	return true, err
 }
 
-type nodeProcessorFetch struct {
+type nodeProcessorWriteBatchRaw struct {
 	handler Node
 }
 
-func (p *nodeProcessorFetch) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeFetchArgs{}
+func (p *nodeProcessorWriteBatchRaw) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeWriteBatchRawArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("fetch", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("writeBatchRaw", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -12583,25 +14450,22 @@ func (p *nodeProcessorFetch) Process(seqId int32, iprot, oprot thrift.TProtocol)
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeFetchResult{}
-	var retval *FetchResult_
+	result := NodeWriteBatchRawResult{}
 	var err2 error
-	if retval, err2 = p.handler.Fetch(args.Req); err2 != nil {
+	if err2 = p.handler.WriteBatchRaw(args.Req); err2 != nil {
 		switch v := err2.(type) {
-		case *Error:
+		case *WriteBatchRawErrors:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetch: "+err2.Error())
-			oprot.WriteMessageBegin("fetch", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing writeBatchRaw: "+err2.Error())
+			oprot.WriteMessageBegin("writeBatchRaw", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
 			return true, err2
 		}
-	} else {
-		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("fetch", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("writeBatchRaw", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -12619,16 +14483,16 @@ func (p *nodeProcessorFetch) Process(seqId int32, iprot, oprot thrift.TProtocol)
 	return true, err
 }
 
-type nodeProcessorFetchTagged struct {
+type nodeProcessorWriteTaggedBatchRaw struct {
 	handler Node
 }
 
-func (p *nodeProcessorFetchTagged) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeFetchTaggedArgs{}
+func (p *nodeProcessorWriteTaggedBatchRaw) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeWriteTaggedBatchRawArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("fetchTagged", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("writeTaggedBatchRaw", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -12636,25 +14500,22 @@ func (p *nodeProcessorFetchTagged) Process(seqId int32, iprot, oprot thrift.TPro
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeFetchTaggedResult{}
-	var retval *FetchTaggedResult_
+	result := NodeWriteTaggedBatchRawResult{}
 	var err2 error
-	if retval, err2 = p.handler.FetchTagged(args.Req); err2 != nil {
+	if err2 = p.handler.WriteTaggedBatchRaw(args.Req); err2 != nil {
 		switch v := err2.(type) {
-		case *Error:
+		case *WriteBatchRawErrors:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetchTagged: "+err2.Error())
-			oprot.WriteMessageBegin("fetchTagged", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing writeTaggedBatchRaw: "+err2.Error())
+			oprot.WriteMessageBegin("writeTaggedBatchRaw", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
 			return true, err2
 		}
-	} else {
-		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("fetchTagged", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("writeTaggedBatchRaw", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -12672,16 +14533,16 @@ func (p *nodeProcessorFetchTagged) Process(seqId int32, iprot, oprot thrift.TPro
 	return true, err
 }
 
-type nodeProcessorWrite struct {
+type nodeProcessorRepair struct {
 	handler Node
 }
 
-func (p *nodeProcessorWrite) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeWriteArgs{}
+func (p *nodeProcessorRepair) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeRepairArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("write", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("repair", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -12689,22 +14550,22 @@ func (p *nodeProcessorWrite) Process(seqId int32, iprot, oprot thrift.TProtocol)
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeWriteResult{}
+	result := NodeRepairResult{}
 	var err2 error
-	if err2 = p.handler.Write(args.Req); err2 != nil {
+	if err2 = p.handler.Repair(); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing write: "+err2.Error())
-			oprot.WriteMessageBegin("write", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing repair: "+err2.Error())
+			oprot.WriteMessageBegin("repair", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
 			return true, err2
 		}
 	}
-	if err2 = oprot.WriteMessageBegin("write", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("repair", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -12722,16 +14583,16 @@ func (p *nodeProcessorWrite) Process(seqId int32, iprot, oprot thrift.TProtocol)
 	return true, err
 }
 
-type nodeProcessorWriteTagged struct {
+type nodeProcessorTruncate struct {
 	handler Node
 }
 
-func (p *nodeProcessorWriteTagged) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeWriteTaggedArgs{}
+func (p *nodeProcessorTruncate) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeTruncateArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("writeTagged", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("truncate", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -12739,22 +14600,25 @@ func (p *nodeProcessorWriteTagged) Process(seqId int32, iprot, oprot thrift.TPro
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeWriteTaggedResult{}
+	result := NodeTruncateResult{}
+	var retval *TruncateResult_
 	var err2 error
-	if err2 = p.handler.WriteTagged(args.Req); err2 != nil {
+	if retval, err2 = p.handler.Truncate(args.Req); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing writeTagged: "+err2.Error())
-			oprot.WriteMessageBegin("writeTagged", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing truncate: "+err2.Error())
+			oprot.WriteMessageBegin("truncate", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
 			return true, err2
 		}
+	} else {
+		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("writeTagged", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("truncate", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -12772,16 +14636,16 @@ func (p *nodeProcessorWriteTagged) Process(seqId int32, iprot, oprot thrift.TPro
 	return true, err
 }
 
-type nodeProcessorFetchBatchRaw struct {
+type nodeProcessorDeleteTagged struct {
 	handler Node
 }
 
-func (p *nodeProcessorFetchBatchRaw) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeFetchBatchRawArgs{}
+func (p *nodeProcessorDeleteTagged) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeDeleteTaggedArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("fetchBatchRaw", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("deleteTagged", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -12789,16 +14653,16 @@ func (p *nodeProcessorFetchBatchRaw) Process(seqId int32, iprot, oprot thrift.TP
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeFetchBatchRawResult{}
-	var retval *FetchBatchRawResult_
+	result := NodeDeleteTaggedResult{}
+	var retval *DeleteTaggedResult_
 	var err2 error
-	if retval, err2 = p.handler.FetchBatchRaw(args.Req); err2 != nil {
+	if retval, err2 = p.handler.DeleteTagged(args.Req); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetchBatchRaw: "+err2.Error())
-			oprot.WriteMessageBegin("fetchBatchRaw", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing deleteTagged: "+err2.Error())
+			oprot.WriteMessageBegin("deleteTagged", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -12807,7 +14671,7 @@ func (p *nodeProcessorFetchBatchRaw) Process(seqId int32, iprot, oprot thrift.TP
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("fetchBatchRaw", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("deleteTagged", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -12825,16 +14689,16 @@ func (p *nodeProcessorFetchBatchRaw) Process(seqId int32, iprot, oprot thrift.TP
 	return true, err
 }
 
-type nodeProcessorFetchBlocksRaw struct {
+type nodeProcessorAggregate struct {
 	handler Node
 }
 
-func (p *nodeProcessorFetchBlocksRaw) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeFetchBlocksRawArgs{}
+func (p *nodeProcessorAggregate) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeAggregateArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("fetchBlocksRaw", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("aggregate", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -12842,16 +14706,16 @@ func (p *nodeProcessorFetchBlocksRaw) Process(seqId int32, iprot, oprot thrift.T
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeFetchBlocksRawResult{}
-	var retval *FetchBlocksRawResult_
+	result := NodeAggregateResult{}
+	var retval *AggregateQueryResult_
 	var err2 error
-	if retval, err2 = p.handler.FetchBlocksRaw(args.Req); err2 != nil {
+	if retval, err2 = p.handler.Aggregate(args.Req); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetchBlocksRaw: "+err2.Error())
-			oprot.WriteMessageBegin("fetchBlocksRaw", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing aggregate: "+err2.Error())
+			oprot.WriteMessageBegin("aggregate", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -12860,7 +14724,7 @@ func (p *nodeProcessorFetchBlocksRaw) Process(seqId int32, iprot, oprot thrift.T
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("fetchBlocksRaw", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("aggregate", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -12878,16 +14742,16 @@ func (p *nodeProcessorFetchBlocksRaw) Process(seqId int32, iprot, oprot thrift.T
 	return true, err
 }
 
-type nodeProcessorFetchBlocksMetadataRaw struct {
+type nodeProcessorHealth struct {
 	handler Node
 }
 
-func (p *nodeProcessorFetchBlocksMetadataRaw) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeFetchBlocksMetadataRawArgs{}
+func (p *nodeProcessorHealth) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeHealthArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("fetchBlocksMetadataRaw", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("health", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -12895,16 +14759,16 @@ func (p *nodeProcessorFetchBlocksMetadataRaw) Process(seqId int32, iprot, oprot
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeFetchBlocksMetadataRawResult{}
-	var retval *FetchBlocksMetadataRawResult_
+	result := NodeHealthResult{}
+	var retval *NodeHealthResult_
 	var err2 error
-	if retval, err2 = p.handler.FetchBlocksMetadataRaw(args.Req); err2 != nil {
+	if retval, err2 = p.handler.Health(); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetchBlocksMetadataRaw: "+err2.Error())
-			oprot.WriteMessageBegin("fetchBlocksMetadataRaw", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing health: "+err2.Error())
+			oprot.WriteMessageBegin("health", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -12913,7 +14777,7 @@ func (p *nodeProcessorFetchBlocksMetadataRaw) Process(seqId int32, iprot, oprot
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("fetchBlocksMetadataRaw", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("health", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -12931,16 +14795,16 @@ func (p *nodeProcessorFetchBlocksMetadataRaw) Process(seqId int32, iprot, oprot
 	return true, err
 }
 
-type nodeProcessorFetchBlocksMetadataRawV2 struct {
+type nodeProcessorBootstrapped struct {
 	handler Node
 }
 
-func (p *nodeProcessorFetchBlocksMetadataRawV2) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeFetchBlocksMetadataRawV2Args{}
+func (p *nodeProcessorBootstrapped) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeBootstrappedArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("fetchBlocksMetadataRawV2", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("bootstrapped", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -12948,16 +14812,16 @@ func (p *nodeProcessorFetchBlocksMetadataRawV2) Process(seqId int32, iprot, opro
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeFetchBlocksMetadataRawV2Result{}
-	var retval *FetchBlocksMetadataRawV2Result_
+	result := NodeBootstrappedResult{}
+	var retval *NodeBootstrappedResult_
 	var err2 error
-	if retval, err2 = p.handler.FetchBlocksMetadataRawV2(args.Req); err2 != nil {
+	if retval, err2 = p.handler.Bootstrapped(); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetchBlocksMetadataRawV2: "+err2.Error())
-			oprot.WriteMessageBegin("fetchBlocksMetadataRawV2", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing bootstrapped: "+err2.Error())
+			oprot.WriteMessageBegin("bootstrapped", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -12966,7 +14830,7 @@ func (p *nodeProcessorFetchBlocksMetadataRawV2) Process(seqId int32, iprot, opro
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("fetchBlocksMetadataRawV2", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("bootstrapped", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -12984,16 +14848,16 @@ func (p *nodeProcessorFetchBlocksMetadataRawV2) Process(seqId int32, iprot, opro
 	return true, err
 }
 
-type nodeProcessorWriteBatchRaw struct {
+type nodeProcessorGetPersistRateLimit struct {
 	handler Node
 }
 
-func (p *nodeProcessorWriteBatchRaw) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeWriteBatchRawArgs{}
+func (p *nodeProcessorGetPersistRateLimit) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeGetPersistRateLimitArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("writeBatchRaw", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("getPersistRateLimit", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -13001,22 +14865,25 @@ func (p *nodeProcessorWriteBatchRaw) Process(seqId int32, iprot, oprot thrift.TP
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeWriteBatchRawResult{}
+	result := NodeGetPersistRateLimitResult{}
+	var retval *NodePersistRateLimitResult_
 	var err2 error
-	if err2 = p.handler.WriteBatchRaw(args.Req); err2 != nil {
+	if retval, err2 = p.handler.GetPersistRateLimit(); err2 != nil {
 		switch v := err2.(type) {
-		case *WriteBatchRawErrors:
+		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing writeBatchRaw: "+err2.Error())
-			oprot.WriteMessageBegin("writeBatchRaw", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing getPersistRateLimit: "+err2.Error())
+			oprot.WriteMessageBegin("getPersistRateLimit", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
 			return true, err2
 		}
+	} else {
+		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("writeBatchRaw", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("getPersistRateLimit", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -13034,16 +14901,16 @@ func (p *nodeProcessorWriteBatchRaw) Process(seqId int32, iprot, oprot thrift.TP
 	return true, err
 }
 
-type nodeProcessorWriteTaggedBatchRaw struct {
+type nodeProcessorSetPersistRateLimit struct {
 	handler Node
 }
 
-func (p *nodeProcessorWriteTaggedBatchRaw) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeWriteTaggedBatchRawArgs{}
+func (p *nodeProcessorSetPersistRateLimit) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeSetPersistRateLimitArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("writeTaggedBatchRaw", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("setPersistRateLimit", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -13051,22 +14918,25 @@ func (p *nodeProcessorWriteTaggedBatchRaw) Process(seqId int32, iprot, oprot thr
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeWriteTaggedBatchRawResult{}
+	result := NodeSetPersistRateLimitResult{}
+	var retval *NodePersistRateLimitResult_
 	var err2 error
-	if err2 = p.handler.WriteTaggedBatchRaw(args.Req); err2 != nil {
+	if retval, err2 = p.handler.SetPersistRateLimit(args.Req); err2 != nil {
 		switch v := err2.(type) {
-		case *WriteBatchRawErrors:
+		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing writeTaggedBatchRaw: "+err2.Error())
-			oprot.WriteMessageBegin("writeTaggedBatchRaw", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing setPersistRateLimit: "+err2.Error())
+			oprot.WriteMessageBegin("setPersistRateLimit", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
 			return true, err2
 		}
+	} else {
+		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("writeTaggedBatchRaw", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("setPersistRateLimit", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -13084,16 +14954,16 @@ func (p *nodeProcessorWriteTaggedBatchRaw) Process(seqId int32, iprot, oprot thr
 	return true, err
 }
 
-type nodeProcessorRepair struct {
+type nodeProcessorGetWriteNewSeriesAsync struct {
 	handler Node
 }
 
-func (p *nodeProcessorRepair) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeRepairArgs{}
+func (p *nodeProcessorGetWriteNewSeriesAsync) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeGetWriteNewSeriesAsyncArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("repair", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("getWriteNewSeriesAsync", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -13101,22 +14971,25 @@ func (p *nodeProcessorRepair) Process(seqId int32, iprot, oprot thrift.TProtocol
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeRepairResult{}
+	result := NodeGetWriteNewSeriesAsyncResult{}
+	var retval *NodeWriteNewSeriesAsyncResult_
 	var err2 error
-	if err2 = p.handler.Repair(); err2 != nil {
+	if retval, err2 = p.handler.GetWriteNewSeriesAsync(); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing repair: "+err2.Error())
-			oprot.WriteMessageBegin("repair", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing getWriteNewSeriesAsync: "+err2.Error())
+			oprot.WriteMessageBegin("getWriteNewSeriesAsync", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
 			return true, err2
 		}
+	} else {
+		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("repair", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("getWriteNewSeriesAsync", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -13134,16 +15007,16 @@ func (p *nodeProcessorRepair) Process(seqId int32, iprot, oprot thrift.TProtocol
 	return true, err
 }
 
-type nodeProcessorTruncate struct {
+type nodeProcessorSetWriteNewSeriesAsync struct {
 	handler Node
 }
 
-func (p *nodeProcessorTruncate) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeTruncateArgs{}
+func (p *nodeProcessorSetWriteNewSeriesAsync) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeSetWriteNewSeriesAsyncArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("truncate", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("setWriteNewSeriesAsync", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -13151,16 +15024,16 @@ func (p *nodeProcessorTruncate) Process(seqId int32, iprot, oprot thrift.TProtoc
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeTruncateResult{}
-	var retval *TruncateResult_
+	result := NodeSetWriteNewSeriesAsyncResult{}
+	var retval *NodeWriteNewSeriesAsyncResult_
 	var err2 error
-	if retval, err2 = p.handler.Truncate(args.Req); err2 != nil {
+	if retval, err2 = p.handler.SetWriteNewSeriesAsync(args.Req); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing truncate: "+err2.Error())
-			oprot.WriteMessageBegin("truncate", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing setWriteNewSeriesAsync: "+err2.Error())
+			oprot.WriteMessageBegin("setWriteNewSeriesAsync", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -13169,7 +15042,7 @@ func (p *nodeProcessorTruncate) Process(seqId int32, iprot, oprot thrift.TProtoc
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("truncate", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("setWriteNewSeriesAsync", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -13187,16 +15060,16 @@ func (p *nodeProcessorTruncate) Process(seqId int32, iprot, oprot thrift.TProtoc
 	return true, err
 }
 
-type nodeProcessorHealth struct {
+type nodeProcessorGetWriteNewSeriesBackoffDuration struct {
 	handler Node
 }
 
-func (p *nodeProcessorHealth) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeHealthArgs{}
+func (p *nodeProcessorGetWriteNewSeriesBackoffDuration) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeGetWriteNewSeriesBackoffDurationArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("health", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("getWriteNewSeriesBackoffDuration", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -13204,16 +15077,16 @@ func (p *nodeProcessorHealth) Process(seqId int32, iprot, oprot thrift.TProtocol
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeHealthResult{}
-	var retval *NodeHealthResult_
+	result := NodeGetWriteNewSeriesBackoffDurationResult{}
+	var retval *NodeWriteNewSeriesBackoffDurationResult_
 	var err2 error
-	if retval, err2 = p.handler.Health(); err2 != nil {
+	if retval, err2 = p.handler.GetWriteNewSeriesBackoffDuration(); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing health: "+err2.Error())
-			oprot.WriteMessageBegin("health", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing getWriteNewSeriesBackoffDuration: "+err2.Error())
+			oprot.WriteMessageBegin("getWriteNewSeriesBackoffDuration", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -13222,7 +15095,7 @@ func (p *nodeProcessorHealth) Process(seqId int32, iprot, oprot thrift.TProtocol
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("health", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("getWriteNewSeriesBackoffDuration", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -13240,16 +15113,16 @@ func (p *nodeProcessorHealth) Process(seqId int32, iprot, oprot thrift.TProtocol
 	return true, err
 }
 
-type nodeProcessorBootstrapped struct {
+type nodeProcessorSetWriteNewSeriesBackoffDuration struct {
 	handler Node
 }
 
-func (p *nodeProcessorBootstrapped) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeBootstrappedArgs{}
+func (p *nodeProcessorSetWriteNewSeriesBackoffDuration) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeSetWriteNewSeriesBackoffDurationArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("bootstrapped", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("setWriteNewSeriesBackoffDuration", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -13257,16 +15130,16 @@ func (p *nodeProcessorBootstrapped) Process(seqId int32, iprot, oprot thrift.TPr
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeBootstrappedResult{}
-	var retval *NodeBootstrappedResult_
+	result := NodeSetWriteNewSeriesBackoffDurationResult{}
+	var retval *NodeWriteNewSeriesBackoffDurationResult_
 	var err2 error
-	if retval, err2 = p.handler.Bootstrapped(); err2 != nil {
+	if retval, err2 = p.handler.SetWriteNewSeriesBackoffDuration(args.Req); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing bootstrapped: "+err2.Error())
-			oprot.WriteMessageBegin("bootstrapped", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing setWriteNewSeriesBackoffDuration: "+err2.Error())
+			oprot.WriteMessageBegin("setWriteNewSeriesBackoffDuration", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -13275,7 +15148,7 @@ func (p *nodeProcessorBootstrapped) Process(seqId int32, iprot, oprot thrift.TPr
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("bootstrapped", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("setWriteNewSeriesBackoffDuration", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -13293,16 +15166,16 @@ func (p *nodeProcessorBootstrapped) Process(seqId int32, iprot, oprot thrift.TPr
 	return true, err
 }
 
-type nodeProcessorGetPersistRateLimit struct {
+type nodeProcessorGetWriteNewSeriesLimitPerShardPerSecond struct {
 	handler Node
 }
 
-func (p *nodeProcessorGetPersistRateLimit) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeGetPersistRateLimitArgs{}
+func (p *nodeProcessorGetWriteNewSeriesLimitPerShardPerSecond) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeGetWriteNewSeriesLimitPerShardPerSecondArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("getPersistRateLimit", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("getWriteNewSeriesLimitPerShardPerSecond", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -13310,16 +15183,16 @@ func (p *nodeProcessorGetPersistRateLimit) Process(seqId int32, iprot, oprot thr
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeGetPersistRateLimitResult{}
-	var retval *NodePersistRateLimitResult_
+	result := NodeGetWriteNewSeriesLimitPerShardPerSecondResult{}
+	var retval *NodeWriteNewSeriesLimitPerShardPerSecondResult_
 	var err2 error
-	if retval, err2 = p.handler.GetPersistRateLimit(); err2 != nil {
+	if retval, err2 = p.handler.GetWriteNewSeriesLimitPerShardPerSecond(); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing getPersistRateLimit: "+err2.Error())
-			oprot.WriteMessageBegin("getPersistRateLimit", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing getWriteNewSeriesLimitPerShardPerSecond: "+err2.Error())
+			oprot.WriteMessageBegin("getWriteNewSeriesLimitPerShardPerSecond", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -13328,7 +15201,7 @@ func (p *nodeProcessorGetPersistRateLimit) Process(seqId int32, iprot, oprot thr
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("getPersistRateLimit", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("getWriteNewSeriesLimitPerShardPerSecond", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -13346,16 +15219,16 @@ func (p *nodeProcessorGetPersistRateLimit) Process(seqId int32, iprot, oprot thr
 	return true, err
 }
 
-type nodeProcessorSetPersistRateLimit struct {
+type nodeProcessorSetWriteNewSeriesLimitPerShardPerSecond struct {
 	handler Node
 }
 
-func (p *nodeProcessorSetPersistRateLimit) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeSetPersistRateLimitArgs{}
+func (p *nodeProcessorSetWriteNewSeriesLimitPerShardPerSecond) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeSetWriteNewSeriesLimitPerShardPerSecondArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("setPersistRateLimit", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("setWriteNewSeriesLimitPerShardPerSecond", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -13363,16 +15236,16 @@ func (p *nodeProcessorSetPersistRateLimit) Process(seqId int32, iprot, oprot thr
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeSetPersistRateLimitResult{}
-	var retval *NodePersistRateLimitResult_
+	result := NodeSetWriteNewSeriesLimitPerShardPerSecondResult{}
+	var retval *NodeWriteNewSeriesLimitPerShardPerSecondResult_
 	var err2 error
-	if retval, err2 = p.handler.SetPersistRateLimit(args.Req); err2 != nil {
+	if retval, err2 = p.handler.SetWriteNewSeriesLimitPerShardPerSecond(args.Req); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing setPersistRateLimit: "+err2.Error())
-			oprot.WriteMessageBegin("setPersistRateLimit", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing setWriteNewSeriesLimitPerShardPerSecond: "+err2.Error())
+			oprot.WriteMessageBegin("setWriteNewSeriesLimitPerShardPerSecond", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -13381,7 +15254,7 @@ func (p *nodeProcessorSetPersistRateLimit) Process(seqId int32, iprot, oprot thr
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("setPersistRateLimit", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("setWriteNewSeriesLimitPerShardPerSecond", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -13393,355 +15266,547 @@ func (p *nodeProcessorSetPersistRateLimit) Process(seqId int32, iprot, oprot thr
 	if err2 = oprot.Flush(); err == nil && err2 != nil {
 		err = err2
 	}
-	if err != nil {
-		return
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+// HELPER FUNCTIONS AND STRUCTURES
+
+// Attributes:
+//  - Req
+type NodeQueryArgs struct {
+	Req *QueryRequest `thrift:"req,1" db:"req" json:"req"`
+}
+
+func NewNodeQueryArgs() *NodeQueryArgs {
+	return &NodeQueryArgs{}
+}
+
+var NodeQueryArgs_Req_DEFAULT *QueryRequest
+
+func (p *NodeQueryArgs) GetReq() *QueryRequest {
+	if !p.IsSetReq() {
+		return NodeQueryArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *NodeQueryArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *NodeQueryArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *NodeQueryArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &QueryRequest{
+		RangeType: 0,
+
+		ResultTimeType: 0,
+	}
+	if err := p.Req.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+	}
+	return nil
+}
+
+func (p *NodeQueryArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("query_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *NodeQueryArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
+	}
+	if err := p.Req.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
+	}
+	return err
+}
+
+func (p *NodeQueryArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("NodeQueryArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - Err
+type NodeQueryResult struct {
+	Success *QueryResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error        `thrift:"err,1" db:"err" json:"err,omitempty"`
+}
+
+func NewNodeQueryResult() *NodeQueryResult {
+	return &NodeQueryResult{}
+}
+
+var NodeQueryResult_Success_DEFAULT *QueryResult_
+
+func (p *NodeQueryResult) GetSuccess() *QueryResult_ {
+	if !p.IsSetSuccess() {
+		return NodeQueryResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var NodeQueryResult_Err_DEFAULT *Error
+
+func (p *NodeQueryResult) GetErr() *Error {
+	if !p.IsSetErr() {
+		return NodeQueryResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *NodeQueryResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *NodeQueryResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *NodeQueryResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.ReadField0(iprot); err != nil {
+				return err
+			}
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	return true, err
+	return nil
 }
 
-type nodeProcessorGetWriteNewSeriesAsync struct {
-	handler Node
+func (p *NodeQueryResult) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &QueryResult_{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
 }
 
-func (p *nodeProcessorGetWriteNewSeriesAsync) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeGetWriteNewSeriesAsyncArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("getWriteNewSeriesAsync", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func (p *NodeQueryResult) ReadField1(iprot thrift.TProtocol) error {
+	p.Err = &Error{
+		Type: 0,
 	}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
+	}
+	return nil
+}
 
-	iprot.ReadMessageEnd()
-	result := NodeGetWriteNewSeriesAsyncResult{}
-	var retval *NodeWriteNewSeriesAsyncResult_
-	var err2 error
-	if retval, err2 = p.handler.GetWriteNewSeriesAsync(); err2 != nil {
-		switch v := err2.(type) {
-		case *Error:
-			result.Err = v
-		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing getWriteNewSeriesAsync: "+err2.Error())
-			oprot.WriteMessageBegin("getWriteNewSeriesAsync", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
+func (p *NodeQueryResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("query_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField0(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField1(oprot); err != nil {
+			return err
 		}
-	} else {
-		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("getWriteNewSeriesAsync", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	return nil
+}
+
+func (p *NodeQueryResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	return err
+}
+
+func (p *NodeQueryResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:err: ", p), err)
+		}
 	}
-	if err != nil {
-		return
+	return err
+}
+
+func (p *NodeQueryResult) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	return true, err
+	return fmt.Sprintf("NodeQueryResult(%+v)", *p)
 }
 
-type nodeProcessorSetWriteNewSeriesAsync struct {
-	handler Node
+// Attributes:
+//  - Req
+type NodeFetchArgs struct {
+	Req *FetchRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func (p *nodeProcessorSetWriteNewSeriesAsync) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeSetWriteNewSeriesAsyncArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("setWriteNewSeriesAsync", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func NewNodeFetchArgs() *NodeFetchArgs {
+	return &NodeFetchArgs{}
+}
+
+var NodeFetchArgs_Req_DEFAULT *FetchRequest
+
+func (p *NodeFetchArgs) GetReq() *FetchRequest {
+	if !p.IsSetReq() {
+		return NodeFetchArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *NodeFetchArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *NodeFetchArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	iprot.ReadMessageEnd()
-	result := NodeSetWriteNewSeriesAsyncResult{}
-	var retval *NodeWriteNewSeriesAsyncResult_
-	var err2 error
-	if retval, err2 = p.handler.SetWriteNewSeriesAsync(args.Req); err2 != nil {
-		switch v := err2.(type) {
-		case *Error:
-			result.Err = v
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing setWriteNewSeriesAsync: "+err2.Error())
-			oprot.WriteMessageBegin("setWriteNewSeriesAsync", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
 		}
-	} else {
-		result.Success = retval
-	}
-	if err2 = oprot.WriteMessageBegin("setWriteNewSeriesAsync", thrift.REPLY, seqId); err2 != nil {
-		err = err2
-	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
 	}
-	if err != nil {
-		return
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	return true, err
+	return nil
 }
 
-type nodeProcessorGetWriteNewSeriesBackoffDuration struct {
-	handler Node
-}
+func (p *NodeFetchArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &FetchRequest{
+		RangeType: 0,
 
-func (p *nodeProcessorGetWriteNewSeriesBackoffDuration) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeGetWriteNewSeriesBackoffDurationArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("getWriteNewSeriesBackoffDuration", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+		ResultTimeType: 0,
+	}
+	if err := p.Req.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
+	return nil
+}
 
-	iprot.ReadMessageEnd()
-	result := NodeGetWriteNewSeriesBackoffDurationResult{}
-	var retval *NodeWriteNewSeriesBackoffDurationResult_
-	var err2 error
-	if retval, err2 = p.handler.GetWriteNewSeriesBackoffDuration(); err2 != nil {
-		switch v := err2.(type) {
-		case *Error:
-			result.Err = v
-		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing getWriteNewSeriesBackoffDuration: "+err2.Error())
-			oprot.WriteMessageBegin("getWriteNewSeriesBackoffDuration", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
+func (p *NodeFetchArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetch_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
 		}
-	} else {
-		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("getWriteNewSeriesBackoffDuration", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	return nil
+}
+
+func (p *NodeFetchArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	if err := p.Req.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
 	}
-	if err != nil {
-		return
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
 	}
-	return true, err
+	return err
 }
 
-type nodeProcessorSetWriteNewSeriesBackoffDuration struct {
-	handler Node
+func (p *NodeFetchArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("NodeFetchArgs(%+v)", *p)
 }
 
-func (p *nodeProcessorSetWriteNewSeriesBackoffDuration) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeSetWriteNewSeriesBackoffDurationArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("setWriteNewSeriesBackoffDuration", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
-	}
+// Attributes:
+//  - Success
+//  - Err
+type NodeFetchResult struct {
+	Success *FetchResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error        `thrift:"err,1" db:"err" json:"err,omitempty"`
+}
 
-	iprot.ReadMessageEnd()
-	result := NodeSetWriteNewSeriesBackoffDurationResult{}
-	var retval *NodeWriteNewSeriesBackoffDurationResult_
-	var err2 error
-	if retval, err2 = p.handler.SetWriteNewSeriesBackoffDuration(args.Req); err2 != nil {
-		switch v := err2.(type) {
-		case *Error:
-			result.Err = v
-		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing setWriteNewSeriesBackoffDuration: "+err2.Error())
-			oprot.WriteMessageBegin("setWriteNewSeriesBackoffDuration", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
-		}
-	} else {
-		result.Success = retval
-	}
-	if err2 = oprot.WriteMessageBegin("setWriteNewSeriesBackoffDuration", thrift.REPLY, seqId); err2 != nil {
-		err = err2
-	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+func NewNodeFetchResult() *NodeFetchResult {
+	return &NodeFetchResult{}
+}
+
+var NodeFetchResult_Success_DEFAULT *FetchResult_
+
+func (p *NodeFetchResult) GetSuccess() *FetchResult_ {
+	if !p.IsSetSuccess() {
+		return NodeFetchResult_Success_DEFAULT
 	}
-	if err != nil {
-		return
+	return p.Success
+}
+
+var NodeFetchResult_Err_DEFAULT *Error
+
+func (p *NodeFetchResult) GetErr() *Error {
+	if !p.IsSetErr() {
+		return NodeFetchResult_Err_DEFAULT
 	}
-	return true, err
+	return p.Err
+}
+func (p *NodeFetchResult) IsSetSuccess() bool {
+	return p.Success != nil
 }
 
-type nodeProcessorGetWriteNewSeriesLimitPerShardPerSecond struct {
-	handler Node
+func (p *NodeFetchResult) IsSetErr() bool {
+	return p.Err != nil
 }
 
-func (p *nodeProcessorGetWriteNewSeriesLimitPerShardPerSecond) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeGetWriteNewSeriesLimitPerShardPerSecondArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("getWriteNewSeriesLimitPerShardPerSecond", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func (p *NodeFetchResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	iprot.ReadMessageEnd()
-	result := NodeGetWriteNewSeriesLimitPerShardPerSecondResult{}
-	var retval *NodeWriteNewSeriesLimitPerShardPerSecondResult_
-	var err2 error
-	if retval, err2 = p.handler.GetWriteNewSeriesLimitPerShardPerSecond(); err2 != nil {
-		switch v := err2.(type) {
-		case *Error:
-			result.Err = v
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.ReadField0(iprot); err != nil {
+				return err
+			}
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing getWriteNewSeriesLimitPerShardPerSecond: "+err2.Error())
-			oprot.WriteMessageBegin("getWriteNewSeriesLimitPerShardPerSecond", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
 		}
-	} else {
-		result.Success = retval
-	}
-	if err2 = oprot.WriteMessageBegin("getWriteNewSeriesLimitPerShardPerSecond", thrift.REPLY, seqId); err2 != nil {
-		err = err2
-	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
 	}
-	if err != nil {
-		return
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	return true, err
+	return nil
 }
 
-type nodeProcessorSetWriteNewSeriesLimitPerShardPerSecond struct {
-	handler Node
+func (p *NodeFetchResult) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &FetchResult_{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
 }
 
-func (p *nodeProcessorSetWriteNewSeriesLimitPerShardPerSecond) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeSetWriteNewSeriesLimitPerShardPerSecondArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("setWriteNewSeriesLimitPerShardPerSecond", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func (p *NodeFetchResult) ReadField1(iprot thrift.TProtocol) error {
+	p.Err = &Error{
+		Type: 0,
 	}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
+	}
+	return nil
+}
 
-	iprot.ReadMessageEnd()
-	result := NodeSetWriteNewSeriesLimitPerShardPerSecondResult{}
-	var retval *NodeWriteNewSeriesLimitPerShardPerSecondResult_
-	var err2 error
-	if retval, err2 = p.handler.SetWriteNewSeriesLimitPerShardPerSecond(args.Req); err2 != nil {
-		switch v := err2.(type) {
-		case *Error:
-			result.Err = v
-		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing setWriteNewSeriesLimitPerShardPerSecond: "+err2.Error())
-			oprot.WriteMessageBegin("setWriteNewSeriesLimitPerShardPerSecond", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
-		}
-	} else {
-		result.Success = retval
+func (p *NodeFetchResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetch_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
-	if err2 = oprot.WriteMessageBegin("setWriteNewSeriesLimitPerShardPerSecond", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+	if p != nil {
+		if err := p.writeField0(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	return nil
+}
+
+func (p *NodeFetchResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
 	}
-	if err != nil {
-		return
+	return err
+}
+
+func (p *NodeFetchResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:err: ", p), err)
+		}
 	}
-	return true, err
+	return err
 }
 
-// HELPER FUNCTIONS AND STRUCTURES
+func (p *NodeFetchResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("NodeFetchResult(%+v)", *p)
+}
 
 // Attributes:
 //  - Req
-type NodeQueryArgs struct {
-	Req *QueryRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeFetchTaggedArgs struct {
+	Req *FetchTaggedRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeQueryArgs() *NodeQueryArgs {
-	return &NodeQueryArgs{}
+func NewNodeFetchTaggedArgs() *NodeFetchTaggedArgs {
+	return &NodeFetchTaggedArgs{}
 }
 
-var NodeQueryArgs_Req_DEFAULT *QueryRequest
+var NodeFetchTaggedArgs_Req_DEFAULT *FetchTaggedRequest
 
-func (p *NodeQueryArgs) GetReq() *QueryRequest {
+func (p *NodeFetchTaggedArgs) GetReq() *FetchTaggedRequest {
 	if !p.IsSetReq() {
-		return NodeQueryArgs_Req_DEFAULT
+		return NodeFetchTaggedArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeQueryArgs) IsSetReq() bool {
+func (p *NodeFetchTaggedArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeQueryArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeFetchTaggedArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -13774,11 +15839,9 @@ func (p *NodeQueryArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeQueryArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &QueryRequest{
-		RangeType: 0,
-
-		ResultTimeType: 0,
+func (p *NodeFetchTaggedArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &FetchTaggedRequest{
+		RangeTimeType: 0,
 	}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
@@ -13786,8 +15849,8 @@ func (p *NodeQueryArgs) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeQueryArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("query_args"); err != nil {
+func (p *NodeFetchTaggedArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetchTagged_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -13804,7 +15867,7 @@ func (p *NodeQueryArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeQueryArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchTaggedArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -13817,51 +15880,51 @@ func (p *NodeQueryArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeQueryArgs) String() string {
+func (p *NodeFetchTaggedArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeQueryArgs(%+v)", *p)
+	return fmt.Sprintf("NodeFetchTaggedArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - Err
-type NodeQueryResult struct {
-	Success *QueryResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
-	Err     *Error        `thrift:"err,1" db:"err" json:"err,omitempty"`
+type NodeFetchTaggedResult struct {
+	Success *FetchTaggedResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error              `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-func NewNodeQueryResult() *NodeQueryResult {
-	return &NodeQueryResult{}
+func NewNodeFetchTaggedResult() *NodeFetchTaggedResult {
+	return &NodeFetchTaggedResult{}
 }
 
-var NodeQueryResult_Success_DEFAULT *QueryResult_
+var NodeFetchTaggedResult_Success_DEFAULT *FetchTaggedResult_
 
-func (p *NodeQueryResult) GetSuccess() *QueryResult_ {
+func (p *NodeFetchTaggedResult) GetSuccess() *FetchTaggedResult_ {
 	if !p.IsSetSuccess() {
-		return NodeQueryResult_Success_DEFAULT
+		return NodeFetchTaggedResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var NodeQueryResult_Err_DEFAULT *Error
+var NodeFetchTaggedResult_Err_DEFAULT *Error
 
-func (p *NodeQueryResult) GetErr() *Error {
+func (p *NodeFetchTaggedResult) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeQueryResult_Err_DEFAULT
+		return NodeFetchTaggedResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeQueryResult) IsSetSuccess() bool {
+func (p *NodeFetchTaggedResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *NodeQueryResult) IsSetErr() bool {
+func (p *NodeFetchTaggedResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeQueryResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeFetchTaggedResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -13898,15 +15961,15 @@ func (p *NodeQueryResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeQueryResult) ReadField0(iprot thrift.TProtocol) error {
-	p.Success = &QueryResult_{}
+func (p *NodeFetchTaggedResult) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &FetchTaggedResult_{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *NodeQueryResult) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeFetchTaggedResult) ReadField1(iprot thrift.TProtocol) error {
 	p.Err = &Error{
 		Type: 0,
 	}
@@ -13916,8 +15979,8 @@ func (p *NodeQueryResult) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeQueryResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("query_result"); err != nil {
+func (p *NodeFetchTaggedResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetchTagged_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -13937,7 +16000,7 @@ func (p *NodeQueryResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeQueryResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchTaggedResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -13952,7 +16015,7 @@ func (p *NodeQueryResult) writeField0(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeQueryResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchTaggedResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -13967,36 +16030,36 @@ func (p *NodeQueryResult) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeQueryResult) String() string {
+func (p *NodeFetchTaggedResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeQueryResult(%+v)", *p)
+	return fmt.Sprintf("NodeFetchTaggedResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeFetchArgs struct {
-	Req *FetchRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeWriteArgs struct {
+	Req *WriteRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeFetchArgs() *NodeFetchArgs {
-	return &NodeFetchArgs{}
+func NewNodeWriteArgs() *NodeWriteArgs {
+	return &NodeWriteArgs{}
 }
 
-var NodeFetchArgs_Req_DEFAULT *FetchRequest
+var NodeWriteArgs_Req_DEFAULT *WriteRequest
 
-func (p *NodeFetchArgs) GetReq() *FetchRequest {
+func (p *NodeWriteArgs) GetReq() *WriteRequest {
 	if !p.IsSetReq() {
-		return NodeFetchArgs_Req_DEFAULT
+		return NodeWriteArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeFetchArgs) IsSetReq() bool {
+func (p *NodeWriteArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeFetchArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -14029,20 +16092,16 @@ func (p *NodeFetchArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &FetchRequest{
-		RangeType: 0,
-
-		ResultTimeType: 0,
-	}
+func (p *NodeWriteArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &WriteRequest{}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeFetchArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetch_args"); err != nil {
+func (p *NodeWriteArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("write_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -14059,7 +16118,7 @@ func (p *NodeFetchArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeWriteArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -14072,51 +16131,36 @@ func (p *NodeFetchArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeFetchArgs) String() string {
+func (p *NodeWriteArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchArgs(%+v)", *p)
+	return fmt.Sprintf("NodeWriteArgs(%+v)", *p)
 }
 
 // Attributes:
-//  - Success
 //  - Err
-type NodeFetchResult struct {
-	Success *FetchResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
-	Err     *Error        `thrift:"err,1" db:"err" json:"err,omitempty"`
-}
-
-func NewNodeFetchResult() *NodeFetchResult {
-	return &NodeFetchResult{}
+type NodeWriteResult struct {
+	Err *Error `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-var NodeFetchResult_Success_DEFAULT *FetchResult_
-
-func (p *NodeFetchResult) GetSuccess() *FetchResult_ {
-	if !p.IsSetSuccess() {
-		return NodeFetchResult_Success_DEFAULT
-	}
-	return p.Success
+func NewNodeWriteResult() *NodeWriteResult {
+	return &NodeWriteResult{}
 }
 
-var NodeFetchResult_Err_DEFAULT *Error
+var NodeWriteResult_Err_DEFAULT *Error
 
-func (p *NodeFetchResult) GetErr() *Error {
+func (p *NodeWriteResult) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeFetchResult_Err_DEFAULT
+		return NodeWriteResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeFetchResult) IsSetSuccess() bool {
-	return p.Success != nil
-}
-
-func (p *NodeFetchResult) IsSetErr() bool {
+func (p *NodeWriteResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeFetchResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -14130,10 +16174,6 @@ func (p *NodeFetchResult) Read(iprot thrift.TProtocol) error {
 			break
 		}
 		switch fieldId {
-		case 0:
-			if err := p.ReadField0(iprot); err != nil {
-				return err
-			}
 		case 1:
 			if err := p.ReadField1(iprot); err != nil {
 				return err
@@ -14153,15 +16193,7 @@ func (p *NodeFetchResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchResult) ReadField0(iprot thrift.TProtocol) error {
-	p.Success = &FetchResult_{}
-	if err := p.Success.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
-	}
-	return nil
-}
-
-func (p *NodeFetchResult) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeWriteResult) ReadField1(iprot thrift.TProtocol) error {
 	p.Err = &Error{
 		Type: 0,
 	}
@@ -14171,14 +16203,11 @@ func (p *NodeFetchResult) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetch_result"); err != nil {
+func (p *NodeWriteResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("write_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
-		if err := p.writeField0(oprot); err != nil {
-			return err
-		}
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
@@ -14192,22 +16221,7 @@ func (p *NodeFetchResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchResult) writeField0(oprot thrift.TProtocol) (err error) {
-	if p.IsSetSuccess() {
-		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
-		}
-		if err := p.Success.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
-		}
-	}
-	return err
-}
-
-func (p *NodeFetchResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeWriteResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -14222,36 +16236,36 @@ func (p *NodeFetchResult) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeFetchResult) String() string {
+func (p *NodeWriteResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchResult(%+v)", *p)
+	return fmt.Sprintf("NodeWriteResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeFetchTaggedArgs struct {
-	Req *FetchTaggedRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeWriteTaggedArgs struct {
+	Req *WriteTaggedRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeFetchTaggedArgs() *NodeFetchTaggedArgs {
-	return &NodeFetchTaggedArgs{}
+func NewNodeWriteTaggedArgs() *NodeWriteTaggedArgs {
+	return &NodeWriteTaggedArgs{}
 }
 
-var NodeFetchTaggedArgs_Req_DEFAULT *FetchTaggedRequest
+var NodeWriteTaggedArgs_Req_DEFAULT *WriteTaggedRequest
 
-func (p *NodeFetchTaggedArgs) GetReq() *FetchTaggedRequest {
+func (p *NodeWriteTaggedArgs) GetReq() *WriteTaggedRequest {
 	if !p.IsSetReq() {
-		return NodeFetchTaggedArgs_Req_DEFAULT
+		return NodeWriteTaggedArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeFetchTaggedArgs) IsSetReq() bool {
+func (p *NodeWriteTaggedArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeFetchTaggedArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteTaggedArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -14284,18 +16298,16 @@ func (p *NodeFetchTaggedArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchTaggedArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &FetchTaggedRequest{
-		RangeTimeType: 0,
-	}
+func (p *NodeWriteTaggedArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &WriteTaggedRequest{}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeFetchTaggedArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetchTagged_args"); err != nil {
+func (p *NodeWriteTaggedArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("writeTagged_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -14312,7 +16324,7 @@ func (p *NodeFetchTaggedArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchTaggedArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeWriteTaggedArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -14325,51 +16337,36 @@ func (p *NodeFetchTaggedArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeFetchTaggedArgs) String() string {
+func (p *NodeWriteTaggedArgs) String() string {
 	if p == nil {
-		return "<nil>"
-	}
-	return fmt.Sprintf("NodeFetchTaggedArgs(%+v)", *p)
-}
-
-// Attributes:
-//  - Success
-//  - Err
-type NodeFetchTaggedResult struct {
-	Success *FetchTaggedResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
-	Err     *Error              `thrift:"err,1" db:"err" json:"err,omitempty"`
+		return "<nil>"
+	}
+	return fmt.Sprintf("NodeWriteTaggedArgs(%+v)", *p)
 }
 
-func NewNodeFetchTaggedResult() *NodeFetchTaggedResult {
-	return &NodeFetchTaggedResult{}
+// Attributes:
+//  - Err
+type NodeWriteTaggedResult struct {
+	Err *Error `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-var NodeFetchTaggedResult_Success_DEFAULT *FetchTaggedResult_
-
-func (p *NodeFetchTaggedResult) GetSuccess() *FetchTaggedResult_ {
-	if !p.IsSetSuccess() {
-		return NodeFetchTaggedResult_Success_DEFAULT
-	}
-	return p.Success
+func NewNodeWriteTaggedResult() *NodeWriteTaggedResult {
+	return &NodeWriteTaggedResult{}
 }
 
-var NodeFetchTaggedResult_Err_DEFAULT *Error
+var NodeWriteTaggedResult_Err_DEFAULT *Error
 
-func (p *NodeFetchTaggedResult) GetErr() *Error {
+func (p *NodeWriteTaggedResult) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeFetchTaggedResult_Err_DEFAULT
+		return NodeWriteTaggedResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeFetchTaggedResult) IsSetSuccess() bool {
-	return p.Success != nil
-}
-
-func (p *NodeFetchTaggedResult) IsSetErr() bool {
+func (p *NodeWriteTaggedResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeFetchTaggedResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteTaggedResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -14383,10 +16380,6 @@ func (p *NodeFetchTaggedResult) Read(iprot thrift.TProtocol) error {
 			break
 		}
 		switch fieldId {
-		case 0:
-			if err := p.ReadField0(iprot); err != nil {
-				return err
-			}
 		case 1:
 			if err := p.ReadField1(iprot); err != nil {
 				return err
@@ -14406,15 +16399,7 @@ func (p *NodeFetchTaggedResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchTaggedResult) ReadField0(iprot thrift.TProtocol) error {
-	p.Success = &FetchTaggedResult_{}
-	if err := p.Success.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
-	}
-	return nil
-}
-
-func (p *NodeFetchTaggedResult) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeWriteTaggedResult) ReadField1(iprot thrift.TProtocol) error {
 	p.Err = &Error{
 		Type: 0,
 	}
@@ -14424,14 +16409,11 @@ func (p *NodeFetchTaggedResult) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchTaggedResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetchTagged_result"); err != nil {
+func (p *NodeWriteTaggedResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("writeTagged_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
-		if err := p.writeField0(oprot); err != nil {
-			return err
-		}
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
@@ -14445,22 +16427,7 @@ func (p *NodeFetchTaggedResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchTaggedResult) writeField0(oprot thrift.TProtocol) (err error) {
-	if p.IsSetSuccess() {
-		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
-		}
-		if err := p.Success.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
-		}
-	}
-	return err
-}
-
-func (p *NodeFetchTaggedResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeWriteTaggedResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -14475,36 +16442,36 @@ func (p *NodeFetchTaggedResult) writeField1(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *NodeFetchTaggedResult) String() string {
+func (p *NodeWriteTaggedResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchTaggedResult(%+v)", *p)
+	return fmt.Sprintf("NodeWriteTaggedResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeWriteArgs struct {
-	Req *WriteRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeFetchBatchRawArgs struct {
+	Req *FetchBatchRawRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeWriteArgs() *NodeWriteArgs {
-	return &NodeWriteArgs{}
+func NewNodeFetchBatchRawArgs() *NodeFetchBatchRawArgs {
+	return &NodeFetchBatchRawArgs{}
 }
 
-var NodeWriteArgs_Req_DEFAULT *WriteRequest
+var NodeFetchBatchRawArgs_Req_DEFAULT *FetchBatchRawRequest
 
-func (p *NodeWriteArgs) GetReq() *WriteRequest {
+func (p *NodeFetchBatchRawArgs) GetReq() *FetchBatchRawRequest {
 	if !p.IsSetReq() {
-		return NodeWriteArgs_Req_DEFAULT
+		return NodeFetchBatchRawArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeWriteArgs) IsSetReq() bool {
+func (p *NodeFetchBatchRawArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeWriteArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeFetchBatchRawArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -14537,16 +16504,18 @@ func (p *NodeWriteArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &WriteRequest{}
+func (p *NodeFetchBatchRawArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &FetchBatchRawRequest{
+		RangeTimeType: 0,
+	}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeWriteArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("write_args"); err != nil {
+func (p *NodeFetchBatchRawArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetchBatchRaw_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -14563,7 +16532,7 @@ func (p *NodeWriteArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchBatchRawArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -14576,36 +16545,51 @@ func (p *NodeWriteArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeWriteArgs) String() string {
+func (p *NodeFetchBatchRawArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteArgs(%+v)", *p)
+	return fmt.Sprintf("NodeFetchBatchRawArgs(%+v)", *p)
 }
 
 // Attributes:
+//  - Success
 //  - Err
-type NodeWriteResult struct {
-	Err *Error `thrift:"err,1" db:"err" json:"err,omitempty"`
+type NodeFetchBatchRawResult struct {
+	Success *FetchBatchRawResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error                `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-func NewNodeWriteResult() *NodeWriteResult {
-	return &NodeWriteResult{}
+func NewNodeFetchBatchRawResult() *NodeFetchBatchRawResult {
+	return &NodeFetchBatchRawResult{}
 }
 
-var NodeWriteResult_Err_DEFAULT *Error
+var NodeFetchBatchRawResult_Success_DEFAULT *FetchBatchRawResult_
 
-func (p *NodeWriteResult) GetErr() *Error {
+func (p *NodeFetchBatchRawResult) GetSuccess() *FetchBatchRawResult_ {
+	if !p.IsSetSuccess() {
+		return NodeFetchBatchRawResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var NodeFetchBatchRawResult_Err_DEFAULT *Error
+
+func (p *NodeFetchBatchRawResult) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeWriteResult_Err_DEFAULT
+		return NodeFetchBatchRawResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeWriteResult) IsSetErr() bool {
+func (p *NodeFetchBatchRawResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *NodeFetchBatchRawResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeWriteResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeFetchBatchRawResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -14619,6 +16603,10 @@ func (p *NodeWriteResult) Read(iprot thrift.TProtocol) error {
 			break
 		}
 		switch fieldId {
+		case 0:
+			if err := p.ReadField0(iprot); err != nil {
+				return err
+			}
 		case 1:
 			if err := p.ReadField1(iprot); err != nil {
 				return err
@@ -14638,7 +16626,15 @@ func (p *NodeWriteResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteResult) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeFetchBatchRawResult) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &FetchBatchRawResult_{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *NodeFetchBatchRawResult) ReadField1(iprot thrift.TProtocol) error {
 	p.Err = &Error{
 		Type: 0,
 	}
@@ -14648,11 +16644,14 @@ func (p *NodeWriteResult) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("write_result"); err != nil {
+func (p *NodeFetchBatchRawResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetchBatchRaw_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
+		if err := p.writeField0(oprot); err != nil {
+			return err
+		}
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
@@ -14666,7 +16665,22 @@ func (p *NodeWriteResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchBatchRawResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *NodeFetchBatchRawResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -14681,36 +16695,36 @@ func (p *NodeWriteResult) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeWriteResult) String() string {
+func (p *NodeFetchBatchRawResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteResult(%+v)", *p)
+	return fmt.Sprintf("NodeFetchBatchRawResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeWriteTaggedArgs struct {
-	Req *WriteTaggedRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeFetchBlocksRawArgs struct {
+	Req *FetchBlocksRawRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeWriteTaggedArgs() *NodeWriteTaggedArgs {
-	return &NodeWriteTaggedArgs{}
+func NewNodeFetchBlocksRawArgs() *NodeFetchBlocksRawArgs {
+	return &NodeFetchBlocksRawArgs{}
 }
 
-var NodeWriteTaggedArgs_Req_DEFAULT *WriteTaggedRequest
+var NodeFetchBlocksRawArgs_Req_DEFAULT *FetchBlocksRawRequest
 
-func (p *NodeWriteTaggedArgs) GetReq() *WriteTaggedRequest {
+func (p *NodeFetchBlocksRawArgs) GetReq() *FetchBlocksRawRequest {
 	if !p.IsSetReq() {
-		return NodeWriteTaggedArgs_Req_DEFAULT
+		return NodeFetchBlocksRawArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeWriteTaggedArgs) IsSetReq() bool {
+func (p *NodeFetchBlocksRawArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeWriteTaggedArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeFetchBlocksRawArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -14743,16 +16757,16 @@ func (p *NodeWriteTaggedArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &WriteTaggedRequest{}
+func (p *NodeFetchBlocksRawArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &FetchBlocksRawRequest{}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeWriteTaggedArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("writeTagged_args"); err != nil {
+func (p *NodeFetchBlocksRawArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetchBlocksRaw_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -14769,7 +16783,7 @@ func (p *NodeWriteTaggedArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchBlocksRawArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -14782,36 +16796,51 @@ func (p *NodeWriteTaggedArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeWriteTaggedArgs) String() string {
+func (p *NodeFetchBlocksRawArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteTaggedArgs(%+v)", *p)
+	return fmt.Sprintf("NodeFetchBlocksRawArgs(%+v)", *p)
 }
 
 // Attributes:
+//  - Success
 //  - Err
-type NodeWriteTaggedResult struct {
-	Err *Error `thrift:"err,1" db:"err" json:"err,omitempty"`
+type NodeFetchBlocksRawResult struct {
+	Success *FetchBlocksRawResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error                 `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-func NewNodeWriteTaggedResult() *NodeWriteTaggedResult {
-	return &NodeWriteTaggedResult{}
+func NewNodeFetchBlocksRawResult() *NodeFetchBlocksRawResult {
+	return &NodeFetchBlocksRawResult{}
 }
 
-var NodeWriteTaggedResult_Err_DEFAULT *Error
+var NodeFetchBlocksRawResult_Success_DEFAULT *FetchBlocksRawResult_
 
-func (p *NodeWriteTaggedResult) GetErr() *Error {
+func (p *NodeFetchBlocksRawResult) GetSuccess() *FetchBlocksRawResult_ {
+	if !p.IsSetSuccess() {
+		return NodeFetchBlocksRawResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var NodeFetchBlocksRawResult_Err_DEFAULT *Error
+
+func (p *NodeFetchBlocksRawResult) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeWriteTaggedResult_Err_DEFAULT
+		return NodeFetchBlocksRawResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeWriteTaggedResult) IsSetErr() bool {
+func (p *NodeFetchBlocksRawResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *NodeFetchBlocksRawResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeWriteTaggedResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeFetchBlocksRawResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -14825,6 +16854,10 @@ func (p *NodeWriteTaggedResult) Read(iprot thrift.TProtocol) error {
 			break
 		}
 		switch fieldId {
+		case 0:
+			if err := p.ReadField0(iprot); err != nil {
+				return err
+			}
 		case 1:
 			if err := p.ReadField1(iprot); err != nil {
 				return err
@@ -14844,7 +16877,15 @@ func (p *NodeWriteTaggedResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedResult) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeFetchBlocksRawResult) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &FetchBlocksRawResult_{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *NodeFetchBlocksRawResult) ReadField1(iprot thrift.TProtocol) error {
 	p.Err = &Error{
 		Type: 0,
 	}
@@ -14854,11 +16895,14 @@ func (p *NodeWriteTaggedResult) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("writeTagged_result"); err != nil {
+func (p *NodeFetchBlocksRawResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetchBlocksRaw_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
+		if err := p.writeField0(oprot); err != nil {
+			return err
+		}
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
@@ -14872,7 +16916,22 @@ func (p *NodeWriteTaggedResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchBlocksRawResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *NodeFetchBlocksRawResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -14887,36 +16946,36 @@ func (p *NodeWriteTaggedResult) writeField1(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *NodeWriteTaggedResult) String() string {
+func (p *NodeFetchBlocksRawResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteTaggedResult(%+v)", *p)
+	return fmt.Sprintf("NodeFetchBlocksRawResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeFetchBatchRawArgs struct {
-	Req *FetchBatchRawRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeFetchBlocksMetadataRawArgs struct {
+	Req *FetchBlocksMetadataRawRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeFetchBatchRawArgs() *NodeFetchBatchRawArgs {
-	return &NodeFetchBatchRawArgs{}
+func NewNodeFetchBlocksMetadataRawArgs() *NodeFetchBlocksMetadataRawArgs {
+	return &NodeFetchBlocksMetadataRawArgs{}
 }
 
-var NodeFetchBatchRawArgs_Req_DEFAULT *FetchBatchRawRequest
+var NodeFetchBlocksMetadataRawArgs_Req_DEFAULT *FetchBlocksMetadataRawRequest
 
-func (p *NodeFetchBatchRawArgs) GetReq() *FetchBatchRawRequest {
+func (p *NodeFetchBlocksMetadataRawArgs) GetReq() *FetchBlocksMetadataRawRequest {
 	if !p.IsSetReq() {
-		return NodeFetchBatchRawArgs_Req_DEFAULT
+		return NodeFetchBlocksMetadataRawArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeFetchBatchRawArgs) IsSetReq() bool {
+func (p *NodeFetchBlocksMetadataRawArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeFetchBatchRawArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeFetchBlocksMetadataRawArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -14949,18 +17008,16 @@ func (p *NodeFetchBatchRawArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBatchRawArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &FetchBatchRawRequest{
-		RangeTimeType: 0,
-	}
+func (p *NodeFetchBlocksMetadataRawArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &FetchBlocksMetadataRawRequest{}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeFetchBatchRawArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetchBatchRaw_args"); err != nil {
+func (p *NodeFetchBlocksMetadataRawArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetchBlocksMetadataRaw_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -14977,7 +17034,7 @@ func (p *NodeFetchBatchRawArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBatchRawArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchBlocksMetadataRawArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -14990,51 +17047,51 @@ func (p *NodeFetchBatchRawArgs) writeField1(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *NodeFetchBatchRawArgs) String() string {
+func (p *NodeFetchBlocksMetadataRawArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchBatchRawArgs(%+v)", *p)
+	return fmt.Sprintf("NodeFetchBlocksMetadataRawArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - Err
-type NodeFetchBatchRawResult struct {
-	Success *FetchBatchRawResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
-	Err     *Error                `thrift:"err,1" db:"err" json:"err,omitempty"`
+type NodeFetchBlocksMetadataRawResult struct {
+	Success *FetchBlocksMetadataRawResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error                         `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-func NewNodeFetchBatchRawResult() *NodeFetchBatchRawResult {
-	return &NodeFetchBatchRawResult{}
+func NewNodeFetchBlocksMetadataRawResult() *NodeFetchBlocksMetadataRawResult {
+	return &NodeFetchBlocksMetadataRawResult{}
 }
 
-var NodeFetchBatchRawResult_Success_DEFAULT *FetchBatchRawResult_
+var NodeFetchBlocksMetadataRawResult_Success_DEFAULT *FetchBlocksMetadataRawResult_
 
-func (p *NodeFetchBatchRawResult) GetSuccess() *FetchBatchRawResult_ {
+func (p *NodeFetchBlocksMetadataRawResult) GetSuccess() *FetchBlocksMetadataRawResult_ {
 	if !p.IsSetSuccess() {
-		return NodeFetchBatchRawResult_Success_DEFAULT
+		return NodeFetchBlocksMetadataRawResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var NodeFetchBatchRawResult_Err_DEFAULT *Error
+var NodeFetchBlocksMetadataRawResult_Err_DEFAULT *Error
 
-func (p *NodeFetchBatchRawResult) GetErr() *Error {
+func (p *NodeFetchBlocksMetadataRawResult) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeFetchBatchRawResult_Err_DEFAULT
+		return NodeFetchBlocksMetadataRawResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeFetchBatchRawResult) IsSetSuccess() bool {
+func (p *NodeFetchBlocksMetadataRawResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *NodeFetchBatchRawResult) IsSetErr() bool {
+func (p *NodeFetchBlocksMetadataRawResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeFetchBatchRawResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeFetchBlocksMetadataRawResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -15071,15 +17128,15 @@ func (p *NodeFetchBatchRawResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBatchRawResult) ReadField0(iprot thrift.TProtocol) error {
-	p.Success = &FetchBatchRawResult_{}
+func (p *NodeFetchBlocksMetadataRawResult) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &FetchBlocksMetadataRawResult_{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *NodeFetchBatchRawResult) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeFetchBlocksMetadataRawResult) ReadField1(iprot thrift.TProtocol) error {
 	p.Err = &Error{
 		Type: 0,
 	}
@@ -15089,8 +17146,8 @@ func (p *NodeFetchBatchRawResult) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBatchRawResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetchBatchRaw_result"); err != nil {
+func (p *NodeFetchBlocksMetadataRawResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetchBlocksMetadataRaw_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -15110,7 +17167,7 @@ func (p *NodeFetchBatchRawResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBatchRawResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchBlocksMetadataRawResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -15125,7 +17182,7 @@ func (p *NodeFetchBatchRawResult) writeField0(oprot thrift.TProtocol) (err error
 	return err
 }
 
-func (p *NodeFetchBatchRawResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchBlocksMetadataRawResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -15140,36 +17197,36 @@ func (p *NodeFetchBatchRawResult) writeField1(oprot thrift.TProtocol) (err error
 	return err
 }
 
-func (p *NodeFetchBatchRawResult) String() string {
+func (p *NodeFetchBlocksMetadataRawResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchBatchRawResult(%+v)", *p)
+	return fmt.Sprintf("NodeFetchBlocksMetadataRawResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeFetchBlocksRawArgs struct {
-	Req *FetchBlocksRawRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeFetchBlocksMetadataRawV2Args struct {
+	Req *FetchBlocksMetadataRawV2Request `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeFetchBlocksRawArgs() *NodeFetchBlocksRawArgs {
-	return &NodeFetchBlocksRawArgs{}
+func NewNodeFetchBlocksMetadataRawV2Args() *NodeFetchBlocksMetadataRawV2Args {
+	return &NodeFetchBlocksMetadataRawV2Args{}
 }
 
-var NodeFetchBlocksRawArgs_Req_DEFAULT *FetchBlocksRawRequest
+var NodeFetchBlocksMetadataRawV2Args_Req_DEFAULT *FetchBlocksMetadataRawV2Request
 
-func (p *NodeFetchBlocksRawArgs) GetReq() *FetchBlocksRawRequest {
+func (p *NodeFetchBlocksMetadataRawV2Args) GetReq() *FetchBlocksMetadataRawV2Request {
 	if !p.IsSetReq() {
-		return NodeFetchBlocksRawArgs_Req_DEFAULT
+		return NodeFetchBlocksMetadataRawV2Args_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeFetchBlocksRawArgs) IsSetReq() bool {
+func (p *NodeFetchBlocksMetadataRawV2Args) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeFetchBlocksRawArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeFetchBlocksMetadataRawV2Args) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -15202,16 +17259,16 @@ func (p *NodeFetchBlocksRawArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBlocksRawArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &FetchBlocksRawRequest{}
+func (p *NodeFetchBlocksMetadataRawV2Args) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &FetchBlocksMetadataRawV2Request{}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeFetchBlocksRawArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetchBlocksRaw_args"); err != nil {
+func (p *NodeFetchBlocksMetadataRawV2Args) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetchBlocksMetadataRawV2_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -15228,7 +17285,7 @@ func (p *NodeFetchBlocksRawArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBlocksRawArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchBlocksMetadataRawV2Args) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -15241,51 +17298,51 @@ func (p *NodeFetchBlocksRawArgs) writeField1(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *NodeFetchBlocksRawArgs) String() string {
+func (p *NodeFetchBlocksMetadataRawV2Args) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchBlocksRawArgs(%+v)", *p)
+	return fmt.Sprintf("NodeFetchBlocksMetadataRawV2Args(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - Err
-type NodeFetchBlocksRawResult struct {
-	Success *FetchBlocksRawResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
-	Err     *Error                 `thrift:"err,1" db:"err" json:"err,omitempty"`
+type NodeFetchBlocksMetadataRawV2Result struct {
+	Success *FetchBlocksMetadataRawV2Result_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error                           `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-func NewNodeFetchBlocksRawResult() *NodeFetchBlocksRawResult {
-	return &NodeFetchBlocksRawResult{}
+func NewNodeFetchBlocksMetadataRawV2Result() *NodeFetchBlocksMetadataRawV2Result {
+	return &NodeFetchBlocksMetadataRawV2Result{}
 }
 
-var NodeFetchBlocksRawResult_Success_DEFAULT *FetchBlocksRawResult_
+var NodeFetchBlocksMetadataRawV2Result_Success_DEFAULT *FetchBlocksMetadataRawV2Result_
 
-func (p *NodeFetchBlocksRawResult) GetSuccess() *FetchBlocksRawResult_ {
+func (p *NodeFetchBlocksMetadataRawV2Result) GetSuccess() *FetchBlocksMetadataRawV2Result_ {
 	if !p.IsSetSuccess() {
-		return NodeFetchBlocksRawResult_Success_DEFAULT
+		return NodeFetchBlocksMetadataRawV2Result_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var NodeFetchBlocksRawResult_Err_DEFAULT *Error
+var NodeFetchBlocksMetadataRawV2Result_Err_DEFAULT *Error
 
-func (p *NodeFetchBlocksRawResult) GetErr() *Error {
+func (p *NodeFetchBlocksMetadataRawV2Result) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeFetchBlocksRawResult_Err_DEFAULT
+		return NodeFetchBlocksMetadataRawV2Result_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeFetchBlocksRawResult) IsSetSuccess() bool {
+func (p *NodeFetchBlocksMetadataRawV2Result) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *NodeFetchBlocksRawResult) IsSetErr() bool {
+func (p *NodeFetchBlocksMetadataRawV2Result) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeFetchBlocksRawResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeFetchBlocksMetadataRawV2Result) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -15322,15 +17379,15 @@ func (p *NodeFetchBlocksRawResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBlocksRawResult) ReadField0(iprot thrift.TProtocol) error {
-	p.Success = &FetchBlocksRawResult_{}
+func (p *NodeFetchBlocksMetadataRawV2Result) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &FetchBlocksMetadataRawV2Result_{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *NodeFetchBlocksRawResult) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeFetchBlocksMetadataRawV2Result) ReadField1(iprot thrift.TProtocol) error {
 	p.Err = &Error{
 		Type: 0,
 	}
@@ -15340,8 +17397,8 @@ func (p *NodeFetchBlocksRawResult) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBlocksRawResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetchBlocksRaw_result"); err != nil {
+func (p *NodeFetchBlocksMetadataRawV2Result) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetchBlocksMetadataRawV2_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -15361,7 +17418,7 @@ func (p *NodeFetchBlocksRawResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBlocksRawResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchBlocksMetadataRawV2Result) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -15376,7 +17433,7 @@ func (p *NodeFetchBlocksRawResult) writeField0(oprot thrift.TProtocol) (err erro
 	return err
 }
 
-func (p *NodeFetchBlocksRawResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchBlocksMetadataRawV2Result) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -15391,36 +17448,36 @@ func (p *NodeFetchBlocksRawResult) writeField1(oprot thrift.TProtocol) (err erro
 	return err
 }
 
-func (p *NodeFetchBlocksRawResult) String() string {
+func (p *NodeFetchBlocksMetadataRawV2Result) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchBlocksRawResult(%+v)", *p)
+	return fmt.Sprintf("NodeFetchBlocksMetadataRawV2Result(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeFetchBlocksMetadataRawArgs struct {
-	Req *FetchBlocksMetadataRawRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeWriteBatchRawArgs struct {
+	Req *WriteBatchRawRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeFetchBlocksMetadataRawArgs() *NodeFetchBlocksMetadataRawArgs {
-	return &NodeFetchBlocksMetadataRawArgs{}
+func NewNodeWriteBatchRawArgs() *NodeWriteBatchRawArgs {
+	return &NodeWriteBatchRawArgs{}
 }
 
-var NodeFetchBlocksMetadataRawArgs_Req_DEFAULT *FetchBlocksMetadataRawRequest
+var NodeWriteBatchRawArgs_Req_DEFAULT *WriteBatchRawRequest
 
-func (p *NodeFetchBlocksMetadataRawArgs) GetReq() *FetchBlocksMetadataRawRequest {
+func (p *NodeWriteBatchRawArgs) GetReq() *WriteBatchRawRequest {
 	if !p.IsSetReq() {
-		return NodeFetchBlocksMetadataRawArgs_Req_DEFAULT
+		return NodeWriteBatchRawArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeFetchBlocksMetadataRawArgs) IsSetReq() bool {
+func (p *NodeWriteBatchRawArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeFetchBlocksMetadataRawArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteBatchRawArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -15453,16 +17510,16 @@ func (p *NodeFetchBlocksMetadataRawArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBlocksMetadataRawArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &FetchBlocksMetadataRawRequest{}
+func (p *NodeWriteBatchRawArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &WriteBatchRawRequest{}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeFetchBlocksMetadataRawArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetchBlocksMetadataRaw_args"); err != nil {
+func (p *NodeWriteBatchRawArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("writeBatchRaw_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -15479,7 +17536,7 @@ func (p *NodeFetchBlocksMetadataRawArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBlocksMetadataRawArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeWriteBatchRawArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -15492,51 +17549,36 @@ func (p *NodeFetchBlocksMetadataRawArgs) writeField1(oprot thrift.TProtocol) (er
 	return err
 }
 
-func (p *NodeFetchBlocksMetadataRawArgs) String() string {
+func (p *NodeWriteBatchRawArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchBlocksMetadataRawArgs(%+v)", *p)
+	return fmt.Sprintf("NodeWriteBatchRawArgs(%+v)", *p)
 }
 
 // Attributes:
-//  - Success
 //  - Err
-type NodeFetchBlocksMetadataRawResult struct {
-	Success *FetchBlocksMetadataRawResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
-	Err     *Error                         `thrift:"err,1" db:"err" json:"err,omitempty"`
-}
-
-func NewNodeFetchBlocksMetadataRawResult() *NodeFetchBlocksMetadataRawResult {
-	return &NodeFetchBlocksMetadataRawResult{}
+type NodeWriteBatchRawResult struct {
+	Err *WriteBatchRawErrors `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-var NodeFetchBlocksMetadataRawResult_Success_DEFAULT *FetchBlocksMetadataRawResult_
-
-func (p *NodeFetchBlocksMetadataRawResult) GetSuccess() *FetchBlocksMetadataRawResult_ {
-	if !p.IsSetSuccess() {
-		return NodeFetchBlocksMetadataRawResult_Success_DEFAULT
-	}
-	return p.Success
+func NewNodeWriteBatchRawResult() *NodeWriteBatchRawResult {
+	return &NodeWriteBatchRawResult{}
 }
 
-var NodeFetchBlocksMetadataRawResult_Err_DEFAULT *Error
+var NodeWriteBatchRawResult_Err_DEFAULT *WriteBatchRawErrors
 
-func (p *NodeFetchBlocksMetadataRawResult) GetErr() *Error {
+func (p *NodeWriteBatchRawResult) GetErr() *WriteBatchRawErrors {
 	if !p.IsSetErr() {
-		return NodeFetchBlocksMetadataRawResult_Err_DEFAULT
+		return NodeWriteBatchRawResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeFetchBlocksMetadataRawResult) IsSetSuccess() bool {
-	return p.Success != nil
-}
-
-func (p *NodeFetchBlocksMetadataRawResult) IsSetErr() bool {
+func (p *NodeWriteBatchRawResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeFetchBlocksMetadataRawResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteBatchRawResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -15550,10 +17592,6 @@ func (p *NodeFetchBlocksMetadataRawResult) Read(iprot thrift.TProtocol) error {
 			break
 		}
 		switch fieldId {
-		case 0:
-			if err := p.ReadField0(iprot); err != nil {
-				return err
-			}
 		case 1:
 			if err := p.ReadField1(iprot); err != nil {
 				return err
@@ -15573,32 +17611,19 @@ func (p *NodeFetchBlocksMetadataRawResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBlocksMetadataRawResult) ReadField0(iprot thrift.TProtocol) error {
-	p.Success = &FetchBlocksMetadataRawResult_{}
-	if err := p.Success.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
-	}
-	return nil
-}
-
-func (p *NodeFetchBlocksMetadataRawResult) ReadField1(iprot thrift.TProtocol) error {
-	p.Err = &Error{
-		Type: 0,
-	}
+func (p *NodeWriteBatchRawResult) ReadField1(iprot thrift.TProtocol) error {
+	p.Err = &WriteBatchRawErrors{}
 	if err := p.Err.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
 	}
 	return nil
 }
 
-func (p *NodeFetchBlocksMetadataRawResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetchBlocksMetadataRaw_result"); err != nil {
+func (p *NodeWriteBatchRawResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("writeBatchRaw_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
-		if err := p.writeField0(oprot); err != nil {
-			return err
-		}
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
@@ -15612,22 +17637,7 @@ func (p *NodeFetchBlocksMetadataRawResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBlocksMetadataRawResult) writeField0(oprot thrift.TProtocol) (err error) {
-	if p.IsSetSuccess() {
-		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
-		}
-		if err := p.Success.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
-		}
-	}
-	return err
-}
-
-func (p *NodeFetchBlocksMetadataRawResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeWriteBatchRawResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -15642,36 +17652,36 @@ func (p *NodeFetchBlocksMetadataRawResult) writeField1(oprot thrift.TProtocol) (
 	return err
 }
 
-func (p *NodeFetchBlocksMetadataRawResult) String() string {
+func (p *NodeWriteBatchRawResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchBlocksMetadataRawResult(%+v)", *p)
+	return fmt.Sprintf("NodeWriteBatchRawResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeFetchBlocksMetadataRawV2Args struct {
-	Req *FetchBlocksMetadataRawV2Request `thrift:"req,1" db:"req" json:"req"`
+type NodeWriteTaggedBatchRawArgs struct {
+	Req *WriteTaggedBatchRawRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeFetchBlocksMetadataRawV2Args() *NodeFetchBlocksMetadataRawV2Args {
-	return &NodeFetchBlocksMetadataRawV2Args{}
+func NewNodeWriteTaggedBatchRawArgs() *NodeWriteTaggedBatchRawArgs {
+	return &NodeWriteTaggedBatchRawArgs{}
 }
 
-var NodeFetchBlocksMetadataRawV2Args_Req_DEFAULT *FetchBlocksMetadataRawV2Request
+var NodeWriteTaggedBatchRawArgs_Req_DEFAULT *WriteTaggedBatchRawRequest
 
-func (p *NodeFetchBlocksMetadataRawV2Args) GetReq() *FetchBlocksMetadataRawV2Request {
+func (p *NodeWriteTaggedBatchRawArgs) GetReq() *WriteTaggedBatchRawRequest {
 	if !p.IsSetReq() {
-		return NodeFetchBlocksMetadataRawV2Args_Req_DEFAULT
+		return NodeWriteTaggedBatchRawArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeFetchBlocksMetadataRawV2Args) IsSetReq() bool {
+func (p *NodeWriteTaggedBatchRawArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeFetchBlocksMetadataRawV2Args) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteTaggedBatchRawArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -15704,16 +17714,16 @@ func (p *NodeFetchBlocksMetadataRawV2Args) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBlocksMetadataRawV2Args) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &FetchBlocksMetadataRawV2Request{}
+func (p *NodeWriteTaggedBatchRawArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &WriteTaggedBatchRawRequest{}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeFetchBlocksMetadataRawV2Args) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetchBlocksMetadataRawV2_args"); err != nil {
+func (p *NodeWriteTaggedBatchRawArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("writeTaggedBatchRaw_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -15730,7 +17740,7 @@ func (p *NodeFetchBlocksMetadataRawV2Args) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBlocksMetadataRawV2Args) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeWriteTaggedBatchRawArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -15743,51 +17753,36 @@ func (p *NodeFetchBlocksMetadataRawV2Args) writeField1(oprot thrift.TProtocol) (
 	return err
 }
 
-func (p *NodeFetchBlocksMetadataRawV2Args) String() string {
+func (p *NodeWriteTaggedBatchRawArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchBlocksMetadataRawV2Args(%+v)", *p)
+	return fmt.Sprintf("NodeWriteTaggedBatchRawArgs(%+v)", *p)
 }
 
 // Attributes:
-//  - Success
 //  - Err
-type NodeFetchBlocksMetadataRawV2Result struct {
-	Success *FetchBlocksMetadataRawV2Result_ `thrift:"success,0" db:"success" json:"success,omitempty"`
-	Err     *Error                           `thrift:"err,1" db:"err" json:"err,omitempty"`
-}
-
-func NewNodeFetchBlocksMetadataRawV2Result() *NodeFetchBlocksMetadataRawV2Result {
-	return &NodeFetchBlocksMetadataRawV2Result{}
+type NodeWriteTaggedBatchRawResult struct {
+	Err *WriteBatchRawErrors `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-var NodeFetchBlocksMetadataRawV2Result_Success_DEFAULT *FetchBlocksMetadataRawV2Result_
-
-func (p *NodeFetchBlocksMetadataRawV2Result) GetSuccess() *FetchBlocksMetadataRawV2Result_ {
-	if !p.IsSetSuccess() {
-		return NodeFetchBlocksMetadataRawV2Result_Success_DEFAULT
-	}
-	return p.Success
+func NewNodeWriteTaggedBatchRawResult() *NodeWriteTaggedBatchRawResult {
+	return &NodeWriteTaggedBatchRawResult{}
 }
 
-var NodeFetchBlocksMetadataRawV2Result_Err_DEFAULT *Error
+var NodeWriteTaggedBatchRawResult_Err_DEFAULT *WriteBatchRawErrors
 
-func (p *NodeFetchBlocksMetadataRawV2Result) GetErr() *Error {
+func (p *NodeWriteTaggedBatchRawResult) GetErr() *WriteBatchRawErrors {
 	if !p.IsSetErr() {
-		return NodeFetchBlocksMetadataRawV2Result_Err_DEFAULT
+		return NodeWriteTaggedBatchRawResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeFetchBlocksMetadataRawV2Result) IsSetSuccess() bool {
-	return p.Success != nil
-}
-
-func (p *NodeFetchBlocksMetadataRawV2Result) IsSetErr() bool {
+func (p *NodeWriteTaggedBatchRawResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeFetchBlocksMetadataRawV2Result) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteTaggedBatchRawResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -15801,10 +17796,6 @@ func (p *NodeFetchBlocksMetadataRawV2Result) Read(iprot thrift.TProtocol) error
 			break
 		}
 		switch fieldId {
-		case 0:
-			if err := p.ReadField0(iprot); err != nil {
-				return err
-			}
 		case 1:
 			if err := p.ReadField1(iprot); err != nil {
 				return err
@@ -15824,32 +17815,19 @@ func (p *NodeFetchBlocksMetadataRawV2Result) Read(iprot thrift.TProtocol) error
 	return nil
 }
 
-func (p *NodeFetchBlocksMetadataRawV2Result) ReadField0(iprot thrift.TProtocol) error {
-	p.Success = &FetchBlocksMetadataRawV2Result_{}
-	if err := p.Success.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
-	}
-	return nil
-}
-
-func (p *NodeFetchBlocksMetadataRawV2Result) ReadField1(iprot thrift.TProtocol) error {
-	p.Err = &Error{
-		Type: 0,
-	}
+func (p *NodeWriteTaggedBatchRawResult) ReadField1(iprot thrift.TProtocol) error {
+	p.Err = &WriteBatchRawErrors{}
 	if err := p.Err.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
 	}
 	return nil
 }
 
-func (p *NodeFetchBlocksMetadataRawV2Result) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetchBlocksMetadataRawV2_result"); err != nil {
+func (p *NodeWriteTaggedBatchRawResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("writeTaggedBatchRaw_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
-		if err := p.writeField0(oprot); err != nil {
-			return err
-		}
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
@@ -15863,22 +17841,7 @@ func (p *NodeFetchBlocksMetadataRawV2Result) Write(oprot thrift.TProtocol) error
 	return nil
 }
 
-func (p *NodeFetchBlocksMetadataRawV2Result) writeField0(oprot thrift.TProtocol) (err error) {
-	if p.IsSetSuccess() {
-		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
-		}
-		if err := p.Success.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
-		}
-	}
-	return err
-}
-
-func (p *NodeFetchBlocksMetadataRawV2Result) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeWriteTaggedBatchRawResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -15893,36 +17856,21 @@ func (p *NodeFetchBlocksMetadataRawV2Result) writeField1(oprot thrift.TProtocol)
 	return err
 }
 
-func (p *NodeFetchBlocksMetadataRawV2Result) String() string {
+func (p *NodeWriteTaggedBatchRawResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchBlocksMetadataRawV2Result(%+v)", *p)
-}
-
-// Attributes:
-//  - Req
-type NodeWriteBatchRawArgs struct {
-	Req *WriteBatchRawRequest `thrift:"req,1" db:"req" json:"req"`
+	return fmt.Sprintf("NodeWriteTaggedBatchRawResult(%+v)", *p)
 }
 
-func NewNodeWriteBatchRawArgs() *NodeWriteBatchRawArgs {
-	return &NodeWriteBatchRawArgs{}
+type NodeRepairArgs struct {
 }
 
-var NodeWriteBatchRawArgs_Req_DEFAULT *WriteBatchRawRequest
-
-func (p *NodeWriteBatchRawArgs) GetReq() *WriteBatchRawRequest {
-	if !p.IsSetReq() {
-		return NodeWriteBatchRawArgs_Req_DEFAULT
-	}
-	return p.Req
-}
-func (p *NodeWriteBatchRawArgs) IsSetReq() bool {
-	return p.Req != nil
+func NewNodeRepairArgs() *NodeRepairArgs {
+	return &NodeRepairArgs{}
 }
 
-func (p *NodeWriteBatchRawArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeRepairArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -15935,15 +17883,8 @@ func (p *NodeWriteBatchRawArgs) Read(iprot thrift.TProtocol) error {
 		if fieldTypeId == thrift.STOP {
 			break
 		}
-		switch fieldId {
-		case 1:
-			if err := p.ReadField1(iprot); err != nil {
-				return err
-			}
-		default:
-			if err := iprot.Skip(fieldTypeId); err != nil {
-				return err
-			}
+		if err := iprot.Skip(fieldTypeId); err != nil {
+			return err
 		}
 		if err := iprot.ReadFieldEnd(); err != nil {
 			return err
@@ -15955,22 +17896,11 @@ func (p *NodeWriteBatchRawArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteBatchRawArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &WriteBatchRawRequest{}
-	if err := p.Req.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
-	}
-	return nil
-}
-
-func (p *NodeWriteBatchRawArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("writeBatchRaw_args"); err != nil {
+func (p *NodeRepairArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("repair_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
-		if err := p.writeField1(oprot); err != nil {
-			return err
-		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -15981,49 +17911,36 @@ func (p *NodeWriteBatchRawArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteBatchRawArgs) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
-	}
-	if err := p.Req.Write(oprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
-	}
-	return err
-}
-
-func (p *NodeWriteBatchRawArgs) String() string {
+func (p *NodeRepairArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteBatchRawArgs(%+v)", *p)
+	return fmt.Sprintf("NodeRepairArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Err
-type NodeWriteBatchRawResult struct {
-	Err *WriteBatchRawErrors `thrift:"err,1" db:"err" json:"err,omitempty"`
+type NodeRepairResult struct {
+	Err *Error `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-func NewNodeWriteBatchRawResult() *NodeWriteBatchRawResult {
-	return &NodeWriteBatchRawResult{}
+func NewNodeRepairResult() *NodeRepairResult {
+	return &NodeRepairResult{}
 }
 
-var NodeWriteBatchRawResult_Err_DEFAULT *WriteBatchRawErrors
+var NodeRepairResult_Err_DEFAULT *Error
 
-func (p *NodeWriteBatchRawResult) GetErr() *WriteBatchRawErrors {
+func (p *NodeRepairResult) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeWriteBatchRawResult_Err_DEFAULT
+		return NodeRepairResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeWriteBatchRawResult) IsSetErr() bool {
+func (p *NodeRepairResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeWriteBatchRawResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeRepairResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -16056,16 +17973,18 @@ func (p *NodeWriteBatchRawResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteBatchRawResult) ReadField1(iprot thrift.TProtocol) error {
-	p.Err = &WriteBatchRawErrors{}
+func (p *NodeRepairResult) ReadField1(iprot thrift.TProtocol) error {
+	p.Err = &Error{
+		Type: 0,
+	}
 	if err := p.Err.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
 	}
 	return nil
 }
 
-func (p *NodeWriteBatchRawResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("writeBatchRaw_result"); err != nil {
+func (p *NodeRepairResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("repair_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -16082,7 +18001,7 @@ func (p *NodeWriteBatchRawResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteBatchRawResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeRepairResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -16097,36 +18016,36 @@ func (p *NodeWriteBatchRawResult) writeField1(oprot thrift.TProtocol) (err error
 	return err
 }
 
-func (p *NodeWriteBatchRawResult) String() string {
+func (p *NodeRepairResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteBatchRawResult(%+v)", *p)
+	return fmt.Sprintf("NodeRepairResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeWriteTaggedBatchRawArgs struct {
-	Req *WriteTaggedBatchRawRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeTruncateArgs struct {
+	Req *TruncateRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeWriteTaggedBatchRawArgs() *NodeWriteTaggedBatchRawArgs {
-	return &NodeWriteTaggedBatchRawArgs{}
+func NewNodeTruncateArgs() *NodeTruncateArgs {
+	return &NodeTruncateArgs{}
 }
 
-var NodeWriteTaggedBatchRawArgs_Req_DEFAULT *WriteTaggedBatchRawRequest
+var NodeTruncateArgs_Req_DEFAULT *TruncateRequest
 
-func (p *NodeWriteTaggedBatchRawArgs) GetReq() *WriteTaggedBatchRawRequest {
+func (p *NodeTruncateArgs) GetReq() *TruncateRequest {
 	if !p.IsSetReq() {
-		return NodeWriteTaggedBatchRawArgs_Req_DEFAULT
+		return NodeTruncateArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeWriteTaggedBatchRawArgs) IsSetReq() bool {
+func (p *NodeTruncateArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeWriteTaggedBatchRawArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeTruncateArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -16159,16 +18078,16 @@ func (p *NodeWriteTaggedBatchRawArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedBatchRawArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &WriteTaggedBatchRawRequest{}
+func (p *NodeTruncateArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &TruncateRequest{}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeWriteTaggedBatchRawArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("writeTaggedBatchRaw_args"); err != nil {
+func (p *NodeTruncateArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("truncate_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -16185,7 +18104,7 @@ func (p *NodeWriteTaggedBatchRawArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedBatchRawArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeTruncateArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -16198,36 +18117,51 @@ func (p *NodeWriteTaggedBatchRawArgs) writeField1(oprot thrift.TProtocol) (err e
 	return err
 }
 
-func (p *NodeWriteTaggedBatchRawArgs) String() string {
+func (p *NodeTruncateArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteTaggedBatchRawArgs(%+v)", *p)
+	return fmt.Sprintf("NodeTruncateArgs(%+v)", *p)
 }
 
 // Attributes:
+//  - Success
 //  - Err
-type NodeWriteTaggedBatchRawResult struct {
-	Err *WriteBatchRawErrors `thrift:"err,1" db:"err" json:"err,omitempty"`
+type NodeTruncateResult struct {
+	Success *TruncateResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error           `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-func NewNodeWriteTaggedBatchRawResult() *NodeWriteTaggedBatchRawResult {
-	return &NodeWriteTaggedBatchRawResult{}
+func NewNodeTruncateResult() *NodeTruncateResult {
+	return &NodeTruncateResult{}
 }
 
-var NodeWriteTaggedBatchRawResult_Err_DEFAULT *WriteBatchRawErrors
+var NodeTruncateResult_Success_DEFAULT *TruncateResult_
 
-func (p *NodeWriteTaggedBatchRawResult) GetErr() *WriteBatchRawErrors {
+func (p *NodeTruncateResult) GetSuccess() *TruncateResult_ {
+	if !p.IsSetSuccess() {
+		return NodeTruncateResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var NodeTruncateResult_Err_DEFAULT *Error
+
+func (p *NodeTruncateResult) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeWriteTaggedBatchRawResult_Err_DEFAULT
+		return NodeTruncateResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeWriteTaggedBatchRawResult) IsSetErr() bool {
+func (p *NodeTruncateResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *NodeTruncateResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeWriteTaggedBatchRawResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeTruncateResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -16241,6 +18175,10 @@ func (p *NodeWriteTaggedBatchRawResult) Read(iprot thrift.TProtocol) error {
 			break
 		}
 		switch fieldId {
+		case 0:
+			if err := p.ReadField0(iprot); err != nil {
+				return err
+			}
 		case 1:
 			if err := p.ReadField1(iprot); err != nil {
 				return err
@@ -16260,19 +18198,32 @@ func (p *NodeWriteTaggedBatchRawResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedBatchRawResult) ReadField1(iprot thrift.TProtocol) error {
-	p.Err = &WriteBatchRawErrors{}
+func (p *NodeTruncateResult) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &TruncateResult_{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *NodeTruncateResult) ReadField1(iprot thrift.TProtocol) error {
+	p.Err = &Error{
+		Type: 0,
+	}
 	if err := p.Err.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
 	}
 	return nil
 }
 
-func (p *NodeWriteTaggedBatchRawResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("writeTaggedBatchRaw_result"); err != nil {
+func (p *NodeTruncateResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("truncate_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
+		if err := p.writeField0(oprot); err != nil {
+			return err
+		}
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
@@ -16286,7 +18237,22 @@ func (p *NodeWriteTaggedBatchRawResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedBatchRawResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeTruncateResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *NodeTruncateResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -16301,21 +18267,36 @@ func (p *NodeWriteTaggedBatchRawResult) writeField1(oprot thrift.TProtocol) (err
 	return err
 }
 
-func (p *NodeWriteTaggedBatchRawResult) String() string {
+func (p *NodeTruncateResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteTaggedBatchRawResult(%+v)", *p)
+	return fmt.Sprintf("NodeTruncateResult(%+v)", *p)
 }
 
-type NodeRepairArgs struct {
+// Attributes:
+//  - Req
+type NodeDeleteTaggedArgs struct {
+	Req *DeleteTaggedRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeRepairArgs() *NodeRepairArgs {
-	return &NodeRepairArgs{}
+func NewNodeDeleteTaggedArgs() *NodeDeleteTaggedArgs {
+	return &NodeDeleteTaggedArgs{}
 }
 
-func (p *NodeRepairArgs) Read(iprot thrift.TProtocol) error {
+var NodeDeleteTaggedArgs_Req_DEFAULT *DeleteTaggedRequest
+
+func (p *NodeDeleteTaggedArgs) GetReq() *DeleteTaggedRequest {
+	if !p.IsSetReq() {
+		return NodeDeleteTaggedArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *NodeDeleteTaggedArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *NodeDeleteTaggedArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -16328,8 +18309,15 @@ func (p *NodeRepairArgs) Read(iprot thrift.TProtocol) error {
 		if fieldTypeId == thrift.STOP {
 			break
 		}
-		if err := iprot.Skip(fieldTypeId); err != nil {
-			return err
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
 		}
 		if err := iprot.ReadFieldEnd(); err != nil {
 			return err
@@ -16341,11 +18329,22 @@ func (p *NodeRepairArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeRepairArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("repair_args"); err != nil {
+func (p *NodeDeleteTaggedArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &DeleteTaggedRequest{}
+	if err := p.Req.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+	}
+	return nil
+}
+
+func (p *NodeDeleteTaggedArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("deleteTagged_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -16356,36 +18355,64 @@ func (p *NodeRepairArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeRepairArgs) String() string {
+func (p *NodeDeleteTaggedArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
+	}
+	if err := p.Req.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
+	}
+	return err
+}
+
+func (p *NodeDeleteTaggedArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeRepairArgs(%+v)", *p)
+	return fmt.Sprintf("NodeDeleteTaggedArgs(%+v)", *p)
 }
 
 // Attributes:
+//  - Success
 //  - Err
-type NodeRepairResult struct {
-	Err *Error `thrift:"err,1" db:"err" json:"err,omitempty"`
+type NodeDeleteTaggedResult struct {
+	Success *DeleteTaggedResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error           `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-func NewNodeRepairResult() *NodeRepairResult {
-	return &NodeRepairResult{}
+func NewNodeDeleteTaggedResult() *NodeDeleteTaggedResult {
+	return &NodeDeleteTaggedResult{}
 }
 
-var NodeRepairResult_Err_DEFAULT *Error
+var NodeDeleteTaggedResult_Success_DEFAULT *DeleteTaggedResult_
 
-func (p *NodeRepairResult) GetErr() *Error {
+func (p *NodeDeleteTaggedResult) GetSuccess() *DeleteTaggedResult_ {
+	if !p.IsSetSuccess() {
+		return NodeDeleteTaggedResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var NodeDeleteTaggedResult_Err_DEFAULT *Error
+
+func (p *NodeDeleteTaggedResult) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeRepairResult_Err_DEFAULT
+		return NodeDeleteTaggedResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeRepairResult) IsSetErr() bool {
+func (p *NodeDeleteTaggedResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *NodeDeleteTaggedResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeRepairResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeDeleteTaggedResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -16399,6 +18426,10 @@ func (p *NodeRepairResult) Read(iprot thrift.TProtocol) error {
 			break
 		}
 		switch fieldId {
+		case 0:
+			if err := p.ReadField0(iprot); err != nil {
+				return err
+			}
 		case 1:
 			if err := p.ReadField1(iprot); err != nil {
 				return err
@@ -16418,7 +18449,15 @@ func (p *NodeRepairResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeRepairResult) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeDeleteTaggedResult) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &DeleteTaggedResult_{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *NodeDeleteTaggedResult) ReadField1(iprot thrift.TProtocol) error {
 	p.Err = &Error{
 		Type: 0,
 	}
@@ -16428,11 +18467,14 @@ func (p *NodeRepairResult) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeRepairResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("repair_result"); err != nil {
+func (p *NodeDeleteTaggedResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("deleteTagged_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
+		if err := p.writeField0(oprot); err != nil {
+			return err
+		}
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
@@ -16446,7 +18488,22 @@ func (p *NodeRepairResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeRepairResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeDeleteTaggedResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *NodeDeleteTaggedResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -16461,36 +18518,36 @@ func (p *NodeRepairResult) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeRepairResult) String() string {
+func (p *NodeDeleteTaggedResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeRepairResult(%+v)", *p)
+	return fmt.Sprintf("NodeDeleteTaggedResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeTruncateArgs struct {
-	Req *TruncateRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeAggregateArgs struct {
+	Req *AggregateQueryRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeTruncateArgs() *NodeTruncateArgs {
-	return &NodeTruncateArgs{}
+func NewNodeAggregateArgs() *NodeAggregateArgs {
+	return &NodeAggregateArgs{}
 }
 
-var NodeTruncateArgs_Req_DEFAULT *TruncateRequest
+var NodeAggregateArgs_Req_DEFAULT *AggregateQueryRequest
 
-func (p *NodeTruncateArgs) GetReq() *TruncateRequest {
+func (p *NodeAggregateArgs) GetReq() *AggregateQueryRequest {
 	if !p.IsSetReq() {
-		return NodeTruncateArgs_Req_DEFAULT
+		return NodeAggregateArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeTruncateArgs) IsSetReq() bool {
+func (p *NodeAggregateArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeTruncateArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeAggregateArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -16523,16 +18580,16 @@ func (p *NodeTruncateArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeTruncateArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &TruncateRequest{}
+func (p *NodeAggregateArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &AggregateQueryRequest{}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeTruncateArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("truncate_args"); err != nil {
+func (p *NodeAggregateArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("aggregate_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -16549,7 +18606,7 @@ func (p *NodeTruncateArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeTruncateArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeAggregateArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -16562,51 +18619,51 @@ func (p *NodeTruncateArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeTruncateArgs) String() string {
+func (p *NodeAggregateArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeTruncateArgs(%+v)", *p)
+	return fmt.Sprintf("NodeAggregateArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - Err
-type NodeTruncateResult struct {
-	Success *TruncateResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
-	Err     *Error           `thrift:"err,1" db:"err" json:"err,omitempty"`
+type NodeAggregateResult struct {
+	Success *AggregateQueryResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error                 `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-func NewNodeTruncateResult() *NodeTruncateResult {
-	return &NodeTruncateResult{}
+func NewNodeAggregateResult() *NodeAggregateResult {
+	return &NodeAggregateResult{}
 }
 
-var NodeTruncateResult_Success_DEFAULT *TruncateResult_
+var NodeAggregateQueryResult_Success_DEFAULT *AggregateQueryResult_
 
-func (p *NodeTruncateResult) GetSuccess() *TruncateResult_ {
+func (p *NodeAggregateResult) GetSuccess() *AggregateQueryResult_ {
 	if !p.IsSetSuccess() {
-		return NodeTruncateResult_Success_DEFAULT
+		return NodeAggregateQueryResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var NodeTruncateResult_Err_DEFAULT *Error
+var NodeAggregateQueryResult_Err_DEFAULT *Error
 
-func (p *NodeTruncateResult) GetErr() *Error {
+func (p *NodeAggregateResult) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeTruncateResult_Err_DEFAULT
+		return NodeAggregateQueryResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeTruncateResult) IsSetSuccess() bool {
+func (p *NodeAggregateResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *NodeTruncateResult) IsSetErr() bool {
+func (p *NodeAggregateResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeTruncateResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeAggregateResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -16643,15 +18700,15 @@ func (p *NodeTruncateResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeTruncateResult) ReadField0(iprot thrift.TProtocol) error {
-	p.Success = &TruncateResult_{}
+func (p *NodeAggregateResult) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &AggregateQueryResult_{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *NodeTruncateResult) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeAggregateResult) ReadField1(iprot thrift.TProtocol) error {
 	p.Err = &Error{
 		Type: 0,
 	}
@@ -16661,8 +18718,8 @@ func (p *NodeTruncateResult) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeTruncateResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("truncate_result"); err != nil {
+func (p *NodeAggregateResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("aggregate_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -16682,7 +18739,7 @@ func (p *NodeTruncateResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeTruncateResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *NodeAggregateResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -16697,7 +18754,7 @@ func (p *NodeTruncateResult) writeField0(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeTruncateResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeAggregateResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -16712,11 +18769,11 @@ func (p *NodeTruncateResult) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeTruncateResult) String() string {
+func (p *NodeAggregateResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeTruncateResult(%+v)", *p)
+	return fmt.Sprintf("NodeAggregateResult(%+v)", *p)
 }
 
 type NodeHealthArgs struct {
@@ -45,7 +45,9 @@ type TChanCluster interface {
 
 // TChanNode is the interface that defines the server handler and client interface.
 type TChanNode interface {
+	Aggregate(ctx thrift.Context, req *AggregateQueryRequest) (*AggregateQueryResult_, error)
 	Bootstrapped(ctx thrift.Context) (*NodeBootstrappedResult_, error)
+	DeleteTagged(ctx thrift.Context, req *DeleteTaggedRequest) (*DeleteTaggedResult_, error)
 	Fetch(ctx thrift.Context, req *FetchRequest) (*FetchResult_, error)
 	FetchBatchRaw(ctx thrift.Context, req *FetchBatchRawRequest) (*FetchBatchRawResult_, error)
 	FetchBlocksMetadataRaw(ctx thrift.Context, req *FetchBlocksMetadataRawRequest) (*FetchBlocksMetadataRawResult_, error)
@@ -474,6 +476,24 @@ func NewTChanNodeClient(client thrift.TChanClient) TChanNode {
 	return NewTChanNodeInheritedClient("Node", client)
 }
 
+func (c *tchanNodeClient) Aggregate(ctx thrift.Context, req *AggregateQueryRequest) (*AggregateQueryResult_, error) {
+	var resp NodeAggregateResult
+	args := NodeAggregateArgs{
+		Req: req,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "aggregate", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.Err != nil:
+			err = resp.Err
+		default:
+			err = fmt.Errorf("received no result or unknown exception for aggregate")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
 func (c *tchanNodeClient) Bootstrapped(ctx thrift.Context) (*NodeBootstrappedResult_, error) {
 	var resp NodeBootstrappedResult
 	args := NodeBootstrappedArgs{}
@@ -490,6 +510,24 @@ func (c *tchanNodeClient) Bootstrapped(ctx thrift.Context) (*NodeBootstrappedRes
 	return resp.GetSuccess(), err
 }
 
+func (c *tchanNodeClient) DeleteTagged(ctx thrift.Context, req *DeleteTaggedRequest) (*DeleteTaggedResult_, error) {
+	var resp NodeDeleteTaggedResult
+	args := NodeDeleteTaggedArgs{
+		Req: req,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "deleteTagged", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.Err != nil:
+			err = resp.Err
+		default:
+			err = fmt.Errorf("received no result or unknown exception for deleteTagged")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
 func (c *tchanNodeClient) Fetch(ctx thrift.Context, req *FetchRequest) (*FetchResult_, error) {
 	var resp NodeFetchResult
 	args := NodeFetchArgs{
@@ -892,7 +930,9 @@ func (s *tchanNodeServer) Service() string {
 
 func (s *tchanNodeServer) Methods() []string {
 	return []string{
+		"aggregate",
 		"bootstrapped",
+		"deleteTagged",
 		"fetch",
 		"fetchBatchRaw",
 		"fetchBlocksMetadataRaw",
@@ -920,8 +960,12 @@ func (s *tchanNodeServer) Methods() []string {
 
 func (s *tchanNodeServer) Handle(ctx thrift.Context, methodName string, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
 	switch methodName {
+	case "aggregate":
+		return s.handleAggregate(ctx, protocol)
 	case "bootstrapped":
 		return s.handleBootstrapped(ctx, protocol)
+	case "deleteTagged":
+		return s.handleDeleteTagged(ctx, protocol)
 	case "fetch":
 		return s.handleFetch(ctx, protocol)
 	case "fetchBatchRaw":
@@ -972,6 +1016,34 @@ func (s *tchanNodeServer) Handle(ctx thrift.Context, methodName string, protocol
 	}
 }
 
+func (s *tchanNodeServer) handleAggregate(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req NodeAggregateArgs
+	var res NodeAggregateResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	r, err :=
+		s.handler.Aggregate(ctx, req.Req)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *Error:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for err returned non-nil error type *Error but nil value")
+			}
+			res.Err = v
+		default:
+			return false, nil, err
+		}
+	} else {
+		res.Success = r
+	}
+
+	return err == nil, &res, nil
+}
+
 func (s *tchanNodeServer) handleBootstrapped(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
 	var req NodeBootstrappedArgs
 	var res NodeBootstrappedResult
@@ -1000,6 +1072,34 @@ func (s *tchanNodeServer) handleBootstrapped(ctx thrift.Context, protocol athrif
 	return err == nil, &res, nil
 }
 
+func (s *tchanNodeServer) handleDeleteTagged(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req NodeDeleteTaggedArgs
+	var res NodeDeleteTaggedResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	r, err :=
+		s.handler.DeleteTagged(ctx, req.Req)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *Error:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for err returned non-nil error type *Error but nil value")
+			}
+			res.Err = v
+		default:
+			return false, nil, err
+		}
+	} else {
+		res.Success = r
+	}
+
+	return err == nil, &res, nil
+}
+
 func (s *tchanNodeServer) handleFetch(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
 	var req NodeFetchArgs
 	var res NodeFetchResult
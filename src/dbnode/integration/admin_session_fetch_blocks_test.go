@@ -183,7 +183,7 @@ func testSetupToSeriesMaps(
 		require.NotNil(t, blocksIter)
 
 		for blocksIter.Next() {
-			_, id, blk := blocksIter.Current()
+			_, id, _, blk := blocksIter.Current()
 			ctx := context.NewContext()
 			reader, err := blk.Stream(ctx)
 			require.NoError(t, err)
@@ -0,0 +1,190 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package node
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3x/ident"
+)
+
+const (
+	// BackupPath is the path of the endpoint that flushes and snapshots the
+	// node and responds with a backup archive of a namespace on the node.
+	BackupPath = "/backup"
+
+	backupNamespaceParam = "namespace"
+	backupShardsParam    = "shards"
+	backupSourceParam    = "source"
+	backupContentType    = "application/x-tar"
+	backupBufferSize     = 1 << 16
+)
+
+// serveBackup flushes and snapshots the node and then writes a backup archive
+// of the namespace to the response. The archive contains the shards given as
+// a comma separated list or all of the shards the node owns, the source of
+// the archive is named after the host unless a source is given. The archive
+// is streamed over the hijacked connection so that each write is bounded by
+// the write timeout rather than the whole archive.
+func (s *server) serveBackup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "backup requires a POST request", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	nsID := query.Get(backupNamespaceParam)
+	ns, ok := s.db.Namespace(ident.StringID(nsID))
+	if !ok {
+		http.Error(w, fmt.Sprintf("namespace not found: %s", nsID), http.StatusNotFound)
+		return
+	}
+
+	var shards []uint32
+	if value := query.Get(backupShardsParam); value != "" {
+		parsed, err := parseShards(value)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		shards = parsed
+	} else {
+		for _, shard := range ns.Shards() {
+			shards = append(shards, shard.ID())
+		}
+	}
+
+	sourceID := query.Get(backupSourceParam)
+	if sourceID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sourceID = hostname
+	}
+
+	nsOpts, err := namespace.OptionsToProto(ns.Options()).Marshal()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "backup requires a connection that can be hijacked",
+			http.StatusInternalServerError)
+		return
+	}
+
+	var (
+		dbOpts = s.db.Options()
+		fsOpts = dbOpts.CommitLogOptions().FilesystemOptions()
+		log    = dbOpts.InstrumentOptions().Logger()
+		staged *backup.StagedBackup
+	)
+	// NB: the backup is staged while file operations are still disabled so
+	// that cleanups and flushes do not modify the filesets as they are staged,
+	// staging only hard links the files so the archive is written after file
+	// operations are enabled again.
+	err = s.db.FlushAndSnapshot(func() error {
+		var err error
+		staged, err = backup.Stage(fsOpts, backup.Metadata{
+			SourceID:         sourceID,
+			Namespace:        ns.ID(),
+			NamespaceOptions: nsOpts,
+			Shards:           shards,
+		})
+		return err
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("unable to flush and snapshot: %v", err),
+			http.StatusInternalServerError)
+		return
+	}
+	defer func() {
+		if err := staged.Close(); err != nil {
+			log.Errorf("unable to remove staged backup of namespace %s: %v", nsID, err)
+		}
+	}()
+
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		log.Errorf("unable to hijack backup connection: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	bw := bufio.NewWriterSize(deadlineWriter{
+		conn:    conn,
+		timeout: s.opts.WriteTimeout(),
+	}, backupBufferSize)
+	fmt.Fprintf(bw, "HTTP/1.1 200 OK\r\nContent-Type: %s\r\nConnection: close\r\n\r\n",
+		backupContentType)
+	if _, err := staged.Write(bw); err != nil {
+		// NB: the archive is already being streamed so the error can only be
+		// logged, readers reject the truncated archive as it has no manifest.
+		log.Errorf("unable to backup namespace %s: %v", nsID, err)
+		return
+	}
+	if err := bw.Flush(); err != nil {
+		log.Errorf("unable to backup namespace %s: %v", nsID, err)
+	}
+}
+
+// deadlineWriter sets the write deadline of the connection before each write,
+// so that a stalled reader fails the backup instead of holding it open.
+type deadlineWriter struct {
+	conn    net.Conn
+	timeout time.Duration
+}
+
+func (w deadlineWriter) Write(p []byte) (int, error) {
+	var deadline time.Time
+	if w.timeout > 0 {
+		deadline = time.Now().Add(w.timeout)
+	}
+	if err := w.conn.SetWriteDeadline(deadline); err != nil {
+		return 0, err
+	}
+	return w.conn.Write(p)
+}
+
+func parseShards(value string) ([]uint32, error) {
+	var shards []uint32
+	for _, str := range strings.Split(value, ",") {
+		shard, err := strconv.ParseUint(strings.TrimSpace(str), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid shard: %s", str)
+		}
+		shards = append(shards, uint32(shard))
+	}
+	return shards, nil
+}
@@ -69,6 +69,7 @@ func (s *server) ListenAndServe() (ns.Close, error) {
 	if err := httpjson.RegisterHandlers(mux, ttnode.NewService(s.db, s.ttopts), s.opts); err != nil {
 		return nil, err
 	}
+	mux.HandleFunc(BackupPath, s.serveBackup)
 
 	listener, err := net.Listen("tcp", s.address)
 	if err != nil {
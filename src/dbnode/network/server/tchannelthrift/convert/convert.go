@@ -29,6 +29,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
 	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/storage/quota"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3/src/dbnode/x/xpool"
 	"github.com/m3db/m3/src/m3ninx/generated/proto/querypb"
@@ -190,6 +191,9 @@ func ToRPCError(err error) *rpc.Error {
 	if xerrors.IsInvalidParams(err) {
 		return tterrors.NewBadRequestError(err)
 	}
+	if quota.IsExceededError(err) {
+		return tterrors.NewResourceExhaustedError(err)
+	}
 	return tterrors.NewInternalError(err)
 }
 
@@ -277,6 +281,149 @@ func ToRPCFetchTaggedRequest(
 	return request, nil
 }
 
+// FromRPCDeleteTaggedRequest converts the rpc request type for DeleteTaggedRequest into corresponding Go object.
+// The range is left unset unless it is set on the request.
+func FromRPCDeleteTaggedRequest(
+	req *rpc.DeleteTaggedRequest, pools FetchTaggedConversionPools,
+) (ident.ID, index.Query, index.QueryOptions, error) {
+	var opts index.QueryOptions
+	if req.IsSetRangeStart() {
+		opts.StartInclusive = time.Unix(0, req.GetRangeStart())
+	}
+	if req.IsSetRangeEnd() {
+		opts.EndExclusive = time.Unix(0, req.GetRangeEnd())
+	}
+	if req.IsSetLimit() {
+		opts.Limit = int(req.GetLimit())
+	}
+
+	q, err := idx.Unmarshal(req.Query)
+	if err != nil {
+		return nil, index.Query{}, index.QueryOptions{}, err
+	}
+
+	var ns ident.ID
+	if pools != nil {
+		nsBytes := pools.CheckedBytesWrapper().Get(req.NameSpace)
+		ns = pools.ID().BinaryID(nsBytes)
+	} else {
+		ns = ident.StringID(string(req.NameSpace))
+	}
+	return ns, index.Query{Query: q}, opts, nil
+}
+
+// ToRPCDeleteTaggedRequest converts the Go `client/` types into rpc request type for DeleteTaggedRequest.
+// The range is sent as unix nanoseconds and only if it is set.
+func ToRPCDeleteTaggedRequest(
+	ns ident.ID,
+	q index.Query,
+	opts index.QueryOptions,
+) (rpc.DeleteTaggedRequest, error) {
+	query, err := idx.Marshal(q.Query)
+	if err != nil {
+		return rpc.DeleteTaggedRequest{}, err
+	}
+
+	request := rpc.DeleteTaggedRequest{
+		NameSpace: ns.Bytes(),
+		Query:     query,
+	}
+
+	if !opts.StartInclusive.IsZero() {
+		start := opts.StartInclusive.UnixNano()
+		request.RangeStart = &start
+	}
+	if !opts.EndExclusive.IsZero() {
+		end := opts.EndExclusive.UnixNano()
+		request.RangeEnd = &end
+	}
+	if opts.Limit > 0 {
+		l := int64(opts.Limit)
+		request.Limit = &l
+	}
+
+	return request, nil
+}
+
+// FromRPCAggregateQueryRequest converts the rpc request type for AggregateQueryRequest into corresponding Go API types.
+func FromRPCAggregateQueryRequest(
+	req *rpc.AggregateQueryRequest, pools FetchTaggedConversionPools,
+) (ident.ID, index.Query, index.AggregationOptions, error) {
+	start, rangeStartErr := ToTime(req.RangeStart, fetchTaggedTimeType)
+	if rangeStartErr != nil {
+		return nil, index.Query{}, index.AggregationOptions{}, rangeStartErr
+	}
+
+	end, rangeEndErr := ToTime(req.RangeEnd, fetchTaggedTimeType)
+	if rangeEndErr != nil {
+		return nil, index.Query{}, index.AggregationOptions{}, rangeEndErr
+	}
+
+	opts := index.AggregationOptions{
+		QueryOptions: index.QueryOptions{
+			StartInclusive: start,
+			EndExclusive:   end,
+		},
+		FieldFilter: req.TagNameFilter,
+		NameOnly:    req.NameOnly,
+	}
+	if l := req.Limit; l != nil {
+		opts.Limit = int(*l)
+	}
+
+	q, err := idx.Unmarshal(req.Query)
+	if err != nil {
+		return nil, index.Query{}, index.AggregationOptions{}, err
+	}
+
+	var ns ident.ID
+	if pools != nil {
+		nsBytes := pools.CheckedBytesWrapper().Get(req.NameSpace)
+		ns = pools.ID().BinaryID(nsBytes)
+	} else {
+		ns = ident.StringID(string(req.NameSpace))
+	}
+	return ns, index.Query{Query: q}, opts, nil
+}
+
+// ToRPCAggregateQueryRequest converts the Go `client/` types into rpc request type for AggregateQueryRequest.
+func ToRPCAggregateQueryRequest(
+	ns ident.ID,
+	q index.Query,
+	opts index.AggregationOptions,
+) (rpc.AggregateQueryRequest, error) {
+	rangeStart, tsErr := ToValue(opts.StartInclusive, fetchTaggedTimeType)
+	if tsErr != nil {
+		return rpc.AggregateQueryRequest{}, tsErr
+	}
+
+	rangeEnd, tsErr := ToValue(opts.EndExclusive, fetchTaggedTimeType)
+	if tsErr != nil {
+		return rpc.AggregateQueryRequest{}, tsErr
+	}
+
+	query, queryErr := idx.Marshal(q.Query)
+	if queryErr != nil {
+		return rpc.AggregateQueryRequest{}, queryErr
+	}
+
+	request := rpc.AggregateQueryRequest{
+		NameSpace:     ns.Bytes(),
+		RangeStart:    rangeStart,
+		RangeEnd:      rangeEnd,
+		NameOnly:      opts.NameOnly,
+		Query:         query,
+		TagNameFilter: opts.FieldFilter,
+	}
+
+	if opts.Limit > 0 {
+		l := int64(opts.Limit)
+		request.Limit = &l
+	}
+
+	return request, nil
+}
+
 // ToTagsIter returns a tag iterator over the given request.
 func ToTagsIter(r *rpc.WriteTaggedRequest) (ident.TagIterator, error) {
 	if r == nil {
@@ -28,6 +28,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
@@ -35,6 +36,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/storage/quota"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3/src/dbnode/x/xpool"
 	"github.com/m3db/m3/src/x/serialize"
@@ -82,12 +84,14 @@ var (
 type serviceMetrics struct {
 	fetch               instrument.MethodMetrics
 	fetchTagged         instrument.MethodMetrics
+	aggregate           instrument.MethodMetrics
 	write               instrument.MethodMetrics
 	writeTagged         instrument.MethodMetrics
 	fetchBlocks         instrument.MethodMetrics
 	fetchBlocksMetadata instrument.MethodMetrics
 	repair              instrument.MethodMetrics
 	truncate            instrument.MethodMetrics
+	deleteTagged        instrument.MethodMetrics
 	fetchBatchRaw       instrument.BatchMethodMetrics
 	writeBatchRaw       instrument.BatchMethodMetrics
 	writeTaggedBatchRaw instrument.BatchMethodMetrics
@@ -98,12 +102,14 @@ func newServiceMetrics(scope tally.Scope, samplingRate float64) serviceMetrics {
 	return serviceMetrics{
 		fetch:               instrument.NewMethodMetrics(scope, "fetch", samplingRate),
 		fetchTagged:         instrument.NewMethodMetrics(scope, "fetchTagged", samplingRate),
+		aggregate:           instrument.NewMethodMetrics(scope, "aggregate", samplingRate),
 		write:               instrument.NewMethodMetrics(scope, "write", samplingRate),
 		writeTagged:         instrument.NewMethodMetrics(scope, "writeTagged", samplingRate),
 		fetchBlocks:         instrument.NewMethodMetrics(scope, "fetchBlocks", samplingRate),
 		fetchBlocksMetadata: instrument.NewMethodMetrics(scope, "fetchBlocksMetadata", samplingRate),
 		repair:              instrument.NewMethodMetrics(scope, "repair", samplingRate),
 		truncate:            instrument.NewMethodMetrics(scope, "truncate", samplingRate),
+		deleteTagged:        instrument.NewMethodMetrics(scope, "deleteTagged", samplingRate),
 		fetchBatchRaw:       instrument.NewBatchMethodMetrics(scope, "fetchBatchRaw", samplingRate),
 		writeBatchRaw:       instrument.NewBatchMethodMetrics(scope, "writeBatchRaw", samplingRate),
 		writeTaggedBatchRaw: instrument.NewBatchMethodMetrics(scope, "writeTaggedBatchRaw", samplingRate),
@@ -362,7 +368,10 @@ func (s *service) readDatapoints(
 	// Make datapoints an initialized empty array for JSON serialization as empty array than null
 	datapoints := make([]*rpc.Datapoint, 0)
 
-	multiIt := s.db.Options().MultiReaderIteratorPool().Get()
+	multiIt, err := s.newMultiReaderIterator(nsID)
+	if err != nil {
+		return nil, err
+	}
 	multiIt.ResetSliceOfSlices(xio.NewReaderSliceOfSlicesFromBlockReadersIterator(encoded))
 	defer multiIt.Close()
 
@@ -389,6 +398,52 @@ func (s *service) readDatapoints(
 	return datapoints, nil
 }
 
+// newMultiReaderIterator returns an iterator that decodes data encoded with
+// the encoding scheme of the namespace, the pooled iterators decode the
+// default encoding scheme.
+func (s *service) newMultiReaderIterator(nsID ident.ID) (encoding.MultiReaderIterator, error) {
+	opts := s.db.Options()
+	ns, ok := s.db.Namespace(nsID)
+	if !ok {
+		return opts.MultiReaderIteratorPool().Get(), nil
+	}
+
+	var (
+		scheme   = ns.Options().EncodingScheme()
+		registry = opts.DatabaseBlockOptions().CodecRegistry()
+	)
+	if registry.IsDefault(scheme) {
+		return opts.MultiReaderIteratorPool().Get(), nil
+	}
+	codec, err := registry.Codec(scheme)
+	if err != nil {
+		return nil, err
+	}
+	encodingOpts := encoding.NewOptions().
+		SetBytesPool(opts.BytesPool()).
+		SetSegmentReaderPool(opts.SegmentReaderPool())
+	alloc := encoding.NewReaderIteratorAllocate(codec, encodingOpts)
+	return encoding.NewMultiReaderIterator(alloc, nil), nil
+}
+
+// encodingScheme returns the encoding scheme to return with segments fetched
+// from the namespace, or nil when the segments use the default encoding scheme.
+func (s *service) encodingScheme(nsID ident.ID) *string {
+	ns, ok := s.db.Namespace(nsID)
+	if !ok {
+		return nil
+	}
+
+	var (
+		scheme   = ns.Options().EncodingScheme()
+		registry = s.db.Options().DatabaseBlockOptions().CodecRegistry()
+	)
+	if registry.IsDefault(scheme) {
+		return nil
+	}
+	return &scheme
+}
+
 func (s *service) FetchTagged(tctx thrift.Context, req *rpc.FetchTaggedRequest) (*rpc.FetchTaggedResult_, error) {
 	if s.isOverloaded() {
 		s.metrics.overloadRejected.Inc(1)
@@ -414,6 +469,7 @@ func (s *service) FetchTagged(tctx thrift.Context, req *rpc.FetchTaggedRequest)
 	}
 	results := queryResult.Results
 	nsID := results.Namespace()
+	scheme := s.encodingScheme(nsID)
 	tagsIter := ident.NewTagsIterator(ident.Tags{})
 	for _, entry := range results.Map().Iter() {
 		tsID := entry.Key()
@@ -442,12 +498,47 @@ func (s *service) FetchTagged(tctx thrift.Context, req *rpc.FetchTaggedRequest)
 			continue
 		}
 		elem.Segments = segments
+		elem.EncodingScheme = scheme
 	}
 
 	s.metrics.fetchTagged.ReportSuccess(s.nowFn().Sub(callStart))
 	return response, nil
 }
 
+func (s *service) Aggregate(tctx thrift.Context, req *rpc.AggregateQueryRequest) (*rpc.AggregateQueryResult_, error) {
+	if s.isOverloaded() {
+		s.metrics.overloadRejected.Inc(1)
+		return nil, tterrors.NewInternalError(errServerIsOverloaded)
+	}
+
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+	ns, query, opts, err := convert.FromRPCAggregateQueryRequest(req, s.pools)
+	if err != nil {
+		s.metrics.aggregate.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(err)
+	}
+
+	queryResult, err := s.db.AggregateQuery(ctx, ns, query, opts)
+	if err != nil {
+		s.metrics.aggregate.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewInternalError(err)
+	}
+
+	response := &rpc.AggregateQueryResult_{
+		Exhaustive: queryResult.Exhaustive,
+	}
+	queryResult.Results.ForEach(func(name []byte, values [][]byte) {
+		response.Results = append(response.Results, &rpc.AggregateQueryResultTagNameElement{
+			TagName:   name,
+			TagValues: values,
+		})
+	})
+
+	s.metrics.aggregate.ReportSuccess(s.nowFn().Sub(callStart))
+	return response, nil
+}
+
 func (s *service) encodeTags(
 	enc serialize.TagEncoder,
 	tags ident.TagIterator,
@@ -489,6 +580,7 @@ func (s *service) FetchBatchRaw(tctx thrift.Context, req *rpc.FetchBatchRawReque
 	}
 
 	nsID := s.newID(ctx, req.NameSpace)
+	scheme := s.encodingScheme(nsID)
 
 	result := rpc.NewFetchBatchRawResult_()
 
@@ -516,6 +608,7 @@ func (s *service) FetchBatchRaw(tctx thrift.Context, req *rpc.FetchBatchRawReque
 
 		success++
 		rawResult.Segments = segments
+		rawResult.EncodingScheme = scheme
 	}
 
 	s.metrics.fetchBatchRaw.ReportSuccess(success)
@@ -1037,6 +1130,9 @@ func (s *service) WriteTaggedBatchRaw(tctx thrift.Context, req *rpc.WriteTaggedB
 		); err != nil && xerrors.IsInvalidParams(err) {
 			nonRetryableErrors++
 			errs = append(errs, tterrors.NewBadRequestWriteBatchRawError(i, err))
+		} else if err != nil && quota.IsExceededError(err) {
+			nonRetryableErrors++
+			errs = append(errs, tterrors.NewResourceExhaustedWriteBatchRawError(i, err))
 		} else if err != nil {
 			retryableErrors++
 			errs = append(errs, tterrors.NewWriteBatchRawError(i, err))
@@ -1090,6 +1186,33 @@ func (s *service) Truncate(tctx thrift.Context, req *rpc.TruncateRequest) (r *rp
 	return res, nil
 }
 
+func (s *service) DeleteTagged(tctx thrift.Context, req *rpc.DeleteTaggedRequest) (*rpc.DeleteTaggedResult_, error) {
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+	ns, query, opts, err := convert.FromRPCDeleteTaggedRequest(req, s.pools)
+	if err != nil {
+		s.metrics.deleteTagged.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(err)
+	}
+
+	deleted, err := s.db.DeleteTagged(ctx, ns, query, opts)
+	if err != nil {
+		s.metrics.deleteTagged.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	res := rpc.NewDeleteTaggedResult_()
+	res.ShardNumSeries = make(map[int32]int64, len(deleted))
+	for shard, n := range deleted {
+		res.NumSeries += n
+		res.ShardNumSeries[int32(shard)] = n
+	}
+
+	s.metrics.deleteTagged.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return res, nil
+}
+
 func (s *service) GetPersistRateLimit(
 	ctx thrift.Context,
 ) (*rpc.NodePersistRateLimitResult_, error) {
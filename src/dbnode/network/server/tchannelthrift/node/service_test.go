@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/encoding/vdelta"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
@@ -124,6 +125,7 @@ func TestServiceQuery(t *testing.T) {
 
 	mockDB := storage.NewMockDatabase(ctrl)
 	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().Namespace(gomock.Any()).Return(nil, false).AnyTimes()
 	mockDB.EXPECT().IsOverloaded().Return(false)
 
 	service := NewService(mockDB, nil).(*service)
@@ -298,6 +300,7 @@ func TestServiceFetch(t *testing.T) {
 
 	mockDB := storage.NewMockDatabase(ctrl)
 	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().Namespace(gomock.Any()).Return(nil, false).AnyTimes()
 	mockDB.EXPECT().IsOverloaded().Return(false)
 
 	service := NewService(mockDB, nil).(*service)
@@ -397,6 +400,7 @@ func TestServiceFetchBatchRaw(t *testing.T) {
 
 	mockDB := storage.NewMockDatabase(ctrl)
 	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().Namespace(gomock.Any()).Return(nil, false).AnyTimes()
 	mockDB.EXPECT().IsOverloaded().Return(false)
 
 	service := NewService(mockDB, nil).(*service)
@@ -488,6 +492,46 @@ func TestServiceFetchBatchRaw(t *testing.T) {
 	}
 }
 
+func TestServiceFetchBatchRawEncodingScheme(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nsID := "metrics"
+	mockNs := storage.NewMockNamespace(ctrl)
+	mockNs.EXPECT().Options().Return(namespace.NewOptions().SetEncodingScheme(vdelta.Scheme)).AnyTimes()
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Namespace(ident.NewIDMatcher(nsID)).Return(mockNs, true).AnyTimes()
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false)
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	start := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	end := start.Add(2 * time.Hour)
+
+	mockDB.EXPECT().
+		ReadEncoded(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher("foo"), start, end).
+		Return(nil, nil)
+
+	r, err := service.FetchBatchRaw(tctx, &rpc.FetchBatchRawRequest{
+		RangeStart:    start.Unix(),
+		RangeEnd:      end.Unix(),
+		RangeTimeType: rpc.TimeType_UNIX_SECONDS,
+		NameSpace:     []byte(nsID),
+		Ids:           [][]byte{[]byte("foo")},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, len(r.Elements))
+	assert.Nil(t, r.Elements[0].Err)
+	assert.True(t, r.Elements[0].IsSetEncodingScheme())
+	assert.Equal(t, vdelta.Scheme, r.Elements[0].GetEncodingScheme())
+}
+
 func TestServiceFetchBatchRawIsOverloaded(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1013,6 +1057,7 @@ func TestServiceFetchTagged(t *testing.T) {
 
 	mockDB := storage.NewMockDatabase(ctrl)
 	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().Namespace(gomock.Any()).Return(nil, false).AnyTimes()
 	mockDB.EXPECT().IsOverloaded().Return(false)
 
 	service := NewService(mockDB, nil).(*service)
@@ -1199,6 +1244,7 @@ func TestServiceFetchTaggedNoData(t *testing.T) {
 
 	mockDB := storage.NewMockDatabase(ctrl)
 	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().Namespace(gomock.Any()).Return(nil, false).AnyTimes()
 	mockDB.EXPECT().IsOverloaded().Return(false)
 
 	service := NewService(mockDB, nil).(*service)
@@ -1554,6 +1600,154 @@ func TestServiceTruncate(t *testing.T) {
 	assert.Equal(t, truncated, r.NumSeries)
 }
 
+func TestServiceDeleteTagged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	nsID := "metrics"
+
+	req, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	qry := index.Query{Query: req}
+
+	var (
+		start = time.Unix(0, 1000)
+		end   = time.Unix(0, 2000)
+		limit = int64(10)
+	)
+	deleted := map[uint32]int64{0: 2, 3: 1}
+	mockDB.EXPECT().DeleteTagged(
+		ctx,
+		ident.NewIDMatcher(nsID),
+		index.NewQueryMatcher(qry),
+		index.QueryOptions{
+			StartInclusive: start,
+			EndExclusive:   end,
+			Limit:          10,
+		},
+	).Return(deleted, nil)
+
+	data, err := idx.Marshal(req)
+	require.NoError(t, err)
+	rangeStart, rangeEnd := start.UnixNano(), end.UnixNano()
+	r, err := service.DeleteTagged(tctx, &rpc.DeleteTaggedRequest{
+		NameSpace:  []byte(nsID),
+		Query:      data,
+		RangeStart: &rangeStart,
+		RangeEnd:   &rangeEnd,
+		Limit:      &limit,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), r.NumSeries)
+	assert.Equal(t, map[int32]int64{0: 2, 3: 1}, r.ShardNumSeries)
+}
+
+func TestServiceDeleteTaggedInvalidQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	_, err := service.DeleteTagged(tctx, &rpc.DeleteTaggedRequest{
+		NameSpace: []byte("metrics"),
+		Query:     []byte("not a query"),
+	})
+	require.Error(t, err)
+}
+
+func TestServiceAggregate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false)
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	start := time.Now().Add(-2 * time.Hour)
+	end := start.Add(2 * time.Hour)
+	start, end = start.Truncate(time.Second), end.Truncate(time.Second)
+
+	nsID := "metrics"
+
+	req, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	qry := index.Query{Query: req}
+
+	opts := index.AggregationOptions{
+		QueryOptions: index.QueryOptions{
+			StartInclusive: start,
+			EndExclusive:   end,
+			Limit:          10,
+		},
+		FieldFilter: [][]byte{[]byte("foo"), []byte("baz")},
+	}
+	results := index.NewAggregateResults(opts)
+	results.AddTags(ident.NewTags(
+		ident.StringTag("foo", "bar"),
+		ident.StringTag("baz", "dxk"),
+		ident.StringTag("qux", "dzk"),
+	))
+	results.AddTags(ident.NewTags(
+		ident.StringTag("foo", "baz"),
+	))
+
+	mockDB.EXPECT().AggregateQuery(
+		ctx,
+		ident.NewIDMatcher(nsID),
+		index.NewQueryMatcher(qry),
+		opts,
+	).Return(index.AggregateQueryResult{Results: results, Exhaustive: true}, nil)
+
+	startNanos, err := convert.ToValue(start, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	endNanos, err := convert.ToValue(end, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	var limit int64 = 10
+	data, err := idx.Marshal(req)
+	require.NoError(t, err)
+	r, err := service.Aggregate(tctx, &rpc.AggregateQueryRequest{
+		NameSpace:     []byte(nsID),
+		Query:         data,
+		RangeStart:    startNanos,
+		RangeEnd:      endNanos,
+		Limit:         &limit,
+		TagNameFilter: [][]byte{[]byte("foo"), []byte("baz")},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, r.Exhaustive)
+	require.Equal(t, 2, len(r.Results))
+	assert.Equal(t, "baz", string(r.Results[0].TagName))
+	require.Equal(t, 1, len(r.Results[0].TagValues))
+	assert.Equal(t, "dxk", string(r.Results[0].TagValues[0]))
+	assert.Equal(t, "foo", string(r.Results[1].TagName))
+	require.Equal(t, 2, len(r.Results[1].TagValues))
+	assert.Equal(t, "bar", string(r.Results[1].TagValues[0]))
+	assert.Equal(t, "baz", string(r.Results[1].TagValues[1]))
+}
+
 func TestServiceSetPersistRateLimit(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
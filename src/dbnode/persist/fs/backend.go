@@ -0,0 +1,114 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const directoryFileSetBackendTempFilePrefix = ".tmp-"
+
+type directoryFileSetBackend struct {
+	path             string
+	newFileMode      os.FileMode
+	newDirectoryMode os.FileMode
+}
+
+// NewDirectoryFileSetBackend returns a fileset backend that stores files
+// beneath a directory, such as a directory on a slower mount than the file
+// path prefix. It also serves as a local stand-in for an object store.
+func NewDirectoryFileSetBackend(
+	path string,
+	newFileMode os.FileMode,
+	newDirectoryMode os.FileMode,
+) FileSetBackend {
+	return &directoryFileSetBackend{
+		path:             path,
+		newFileMode:      newFileMode,
+		newDirectoryMode: newDirectoryMode,
+	}
+}
+
+func (b *directoryFileSetBackend) filePath(key string) string {
+	// Clean the key as if it were absolute so it cannot refer to a path
+	// outside of the backend directory
+	return filepath.Join(b.path, filepath.Clean(string(filepath.Separator)+key))
+}
+
+func (b *directoryFileSetBackend) Put(key string, r io.Reader) error {
+	filePath := b.filePath(key)
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, b.newDirectoryMode); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that readers never observe
+	// partially written contents
+	fd, err := ioutil.TempFile(dir, directoryFileSetBackendTempFilePrefix)
+	if err != nil {
+		return err
+	}
+	tempFilePath := fd.Name()
+	if err := b.writeFile(fd, r); err != nil {
+		os.Remove(tempFilePath)
+		return err
+	}
+	if err := os.Rename(tempFilePath, filePath); err != nil {
+		os.Remove(tempFilePath)
+		return err
+	}
+	return nil
+}
+
+func (b *directoryFileSetBackend) writeFile(fd *os.File, r io.Reader) error {
+	if _, err := io.Copy(fd, r); err != nil {
+		fd.Close()
+		return err
+	}
+	if err := fd.Chmod(b.newFileMode); err != nil {
+		fd.Close()
+		return err
+	}
+	if err := fd.Sync(); err != nil {
+		fd.Close()
+		return err
+	}
+	return fd.Close()
+}
+
+func (b *directoryFileSetBackend) Get(key string) (io.ReadCloser, error) {
+	return os.Open(b.filePath(key))
+}
+
+func (b *directoryFileSetBackend) Exists(key string) (bool, error) {
+	return FileExists(b.filePath(key))
+}
+
+func (b *directoryFileSetBackend) Delete(key string) error {
+	err := os.Remove(b.filePath(key))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
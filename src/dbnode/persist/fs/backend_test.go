@@ -0,0 +1,92 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectoryFileSetBackend(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	backend := NewDirectoryFileSetBackend(dir, defaultNewFileMode, defaultNewDirectoryMode)
+	key := filepath.Join("data", "ns", "0", "fileset-0-data.db")
+
+	exists, err := backend.Exists(key)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = backend.Get(key)
+	assert.True(t, os.IsNotExist(err))
+
+	contents := []byte{1, 2, 3}
+	require.NoError(t, backend.Put(key, bytes.NewReader(contents)))
+
+	exists, err = backend.Exists(key)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	r, err := backend.Get(key)
+	require.NoError(t, err)
+	result, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, contents, result)
+
+	// No temporary files should be left behind
+	files, err := ioutil.ReadDir(filepath.Join(dir, "data", "ns", "0"))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(files))
+	assert.Equal(t, "fileset-0-data.db", files[0].Name())
+
+	require.NoError(t, backend.Delete(key))
+	exists, err = backend.Exists(key)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// Deleting a key that does not exist is not an error
+	require.NoError(t, backend.Delete(key))
+}
+
+func TestDirectoryFileSetBackendKeyOutsideDirectory(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	backend := NewDirectoryFileSetBackend(filepath.Join(dir, "backend"),
+		defaultNewFileMode, defaultNewDirectoryMode)
+	require.NoError(t, backend.Put("../escaped", bytes.NewReader([]byte{1})))
+
+	exists, err := FileExists(filepath.Join(dir, "escaped"))
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	exists, err = FileExists(filepath.Join(dir, "backend", "escaped"))
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
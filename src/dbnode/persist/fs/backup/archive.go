@@ -0,0 +1,258 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+
+	"github.com/m3db/stackadler32"
+)
+
+// Archives are tar archives with an entry for each file of each source
+// followed by the manifest, file entries are named after the index of their
+// source in the manifest and their path relative to the file path prefix.
+const (
+	manifestEntryName = "manifest.json"
+	sourcesDirName    = "sources"
+	archiveFileMode   = 0644
+)
+
+var (
+	errManifestMissing      = errors.New("archive does not contain a manifest")
+	errEntryAfterManifest   = errors.New("archive contains entries after the manifest")
+	errArchiveFileMissing   = errors.New("archive is missing a file listed in the manifest")
+	errArchiveFileUnlisted  = errors.New("archive contains a file not listed in the manifest")
+	errArchiveFileChecksum  = errors.New("archive file checksum does not match the manifest")
+	errArchiveFileSize      = errors.New("archive file size does not match the manifest")
+	errArchiveFileTruncated = errors.New("archive file is shorter than its header")
+)
+
+// checksumWriter computes the checksum of the bytes written to it.
+type checksumWriter struct {
+	digest stackadler32.Digest
+	size   int64
+}
+
+func newChecksumWriter() *checksumWriter {
+	return &checksumWriter{digest: digest.NewDigest()}
+}
+
+func (w *checksumWriter) Write(p []byte) (int, error) {
+	w.digest = w.digest.Update(p)
+	w.size += int64(len(p))
+	return len(p), nil
+}
+
+func (w *checksumWriter) file(filePath string) File {
+	return File{Path: filePath, Size: w.size, Checksum: w.digest.Sum32()}
+}
+
+func sourceEntryName(sourceIdx int, filePath string) string {
+	return path.Join(sourcesDirName, strconv.Itoa(sourceIdx), filePath)
+}
+
+// parseSourceEntryName returns the source index and the relative file path of
+// a file entry, relative file paths must not escape the file path prefix.
+func parseSourceEntryName(name string) (int, string, error) {
+	parts := strings.SplitN(name, "/", 3)
+	if len(parts) != 3 || parts[0] != sourcesDirName {
+		return 0, "", fmt.Errorf("unexpected archive entry: %s", name)
+	}
+	sourceIdx, err := strconv.Atoi(parts[1])
+	if err != nil || sourceIdx < 0 {
+		return 0, "", fmt.Errorf("unexpected archive entry: %s", name)
+	}
+	if err := validateFilePath(parts[2]); err != nil {
+		return 0, "", err
+	}
+	return sourceIdx, parts[2], nil
+}
+
+func validateFilePath(filePath string) error {
+	if filePath == "" || path.IsAbs(filePath) || path.Clean(filePath) != filePath ||
+		filePath == ".." || strings.HasPrefix(filePath, "../") {
+		return fmt.Errorf("invalid archive file path: %s", filePath)
+	}
+	return nil
+}
+
+type archiveWriter struct {
+	tw  *tar.Writer
+	now time.Time
+}
+
+func newArchiveWriter(w io.Writer, now time.Time) *archiveWriter {
+	return &archiveWriter{tw: tar.NewWriter(w), now: now}
+}
+
+// writeFile writes the contents of a file to the archive and returns the size
+// and checksum of the contents written.
+func (w *archiveWriter) writeFile(
+	sourceIdx int,
+	filePath string,
+	size int64,
+	r io.Reader,
+) (File, error) {
+	if err := w.tw.WriteHeader(&tar.Header{
+		Name:    sourceEntryName(sourceIdx, filePath),
+		Mode:    archiveFileMode,
+		Size:    size,
+		ModTime: w.now,
+	}); err != nil {
+		return File{}, err
+	}
+	checksum := newChecksumWriter()
+	n, err := io.Copy(io.MultiWriter(w.tw, checksum), r)
+	if err != nil {
+		return File{}, err
+	}
+	if n != size {
+		return File{}, errArchiveFileTruncated
+	}
+	return checksum.file(filePath), nil
+}
+
+func (w *archiveWriter) writeManifest(m Manifest) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	if err := w.tw.WriteHeader(&tar.Header{
+		Name:    manifestEntryName,
+		Mode:    archiveFileMode,
+		Size:    int64(len(data)),
+		ModTime: w.now,
+	}); err != nil {
+		return err
+	}
+	if _, err := w.tw.Write(data); err != nil {
+		return err
+	}
+	return w.tw.Close()
+}
+
+// archiveFileFn is called with the contents of each file entry of an archive,
+// the contents are not yet validated against the manifest when it is called.
+type archiveFileFn func(sourceIdx int, filePath string, size int64, r io.Reader) error
+
+// readArchive reads an archive calling fn for each file entry and validates
+// the files read against the manifest, which is returned.
+func readArchive(r io.Reader, fn archiveFileFn) (Manifest, error) {
+	var (
+		tr       = tar.NewReader(r)
+		read     = make(map[string]File)
+		manifest *Manifest
+	)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Manifest{}, err
+		}
+		if manifest != nil {
+			return Manifest{}, errEntryAfterManifest
+		}
+
+		if hdr.Name == manifestEntryName {
+			data, err := ioutil.ReadAll(tr)
+			if err != nil {
+				return Manifest{}, err
+			}
+			var m Manifest
+			if err := json.Unmarshal(data, &m); err != nil {
+				return Manifest{}, err
+			}
+			manifest = &m
+			continue
+		}
+
+		sourceIdx, filePath, err := parseSourceEntryName(hdr.Name)
+		if err != nil {
+			return Manifest{}, err
+		}
+		checksum := newChecksumWriter()
+		tee := io.TeeReader(tr, checksum)
+		if err := fn(sourceIdx, filePath, hdr.Size, tee); err != nil {
+			return Manifest{}, err
+		}
+		// Consume anything the callback did not read so the checksum covers
+		// the whole entry.
+		if _, err := io.Copy(ioutil.Discard, tee); err != nil {
+			return Manifest{}, err
+		}
+		read[hdr.Name] = checksum.file(filePath)
+	}
+
+	if manifest == nil {
+		return Manifest{}, errManifestMissing
+	}
+	if err := validateManifest(*manifest, read); err != nil {
+		return Manifest{}, err
+	}
+	return *manifest, nil
+}
+
+// validateManifest validates that the files read from an archive are exactly
+// the files listed in its manifest.
+func validateManifest(m Manifest, read map[string]File) error {
+	if m.Version != ManifestVersion {
+		return fmt.Errorf("unsupported manifest version: %d", m.Version)
+	}
+	listed := 0
+	for i, source := range m.Sources {
+		for _, fileSet := range source.FileSets {
+			for _, expected := range fileSet.Files {
+				if err := validateFilePath(expected.Path); err != nil {
+					return err
+				}
+				name := sourceEntryName(i, expected.Path)
+				actual, ok := read[name]
+				if !ok {
+					return fmt.Errorf("%v: %s", errArchiveFileMissing, name)
+				}
+				if actual.Size != expected.Size {
+					return fmt.Errorf("%v: %s", errArchiveFileSize, name)
+				}
+				if actual.Checksum != expected.Checksum {
+					return fmt.Errorf("%v: %s", errArchiveFileChecksum, name)
+				}
+				listed++
+			}
+		}
+	}
+	if listed != len(read) {
+		return errArchiveFileUnlisted
+	}
+	return nil
+}
@@ -0,0 +1,378 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+)
+
+const stagingDirName = "backup-staging"
+
+var errMismatchedNamespace = errors.New("archives are not of the same namespace")
+
+type fileSetFiles struct {
+	fileSet   FileSet
+	filePaths []string
+}
+
+// Backup writes a backup archive of the complete filesets of a namespace on
+// the local node to the writer and returns the manifest of the archive. Only
+// the latest volume of data and snapshot filesets of each block start is
+// captured, data files that have been moved to the fileset backend are read
+// from the backend.
+func Backup(w io.Writer, opts fs.Options, md Metadata) (Manifest, error) {
+	staged, err := Stage(opts, md)
+	if err != nil {
+		return Manifest{}, err
+	}
+	defer staged.Close()
+
+	return staged.Write(w)
+}
+
+// StagedBackup is a backup of the complete filesets of a namespace on the
+// local node that is staged to be written. Staging hard links the local files
+// of the filesets into a staging directory, so that the backup can be written
+// after cleanups and flushes resume without the files changing or being
+// removed beneath it. Data files that have been moved to the fileset backend
+// are only read from the backend when the backup is written.
+type StagedBackup struct {
+	opts     fs.Options
+	md       Metadata
+	dir      string
+	fileSets []fileSetFiles
+	staged   map[string]string
+}
+
+// Stage stages a backup of the complete filesets of a namespace on the local
+// node, the staged backup must be closed to remove the staging directory.
+func Stage(opts fs.Options, md Metadata) (*StagedBackup, error) {
+	fileSets, err := namespaceFileSets(opts, md)
+	if err != nil {
+		return nil, err
+	}
+
+	filePathPrefix := opts.FilePathPrefix()
+	parentDir := filepath.Join(filePathPrefix, stagingDirName)
+	if err := os.MkdirAll(parentDir, opts.NewDirectoryMode()); err != nil {
+		return nil, err
+	}
+	dir, err := ioutil.TempDir(parentDir, "")
+	if err != nil {
+		return nil, err
+	}
+
+	b := &StagedBackup{
+		opts:     opts,
+		md:       md,
+		dir:      dir,
+		fileSets: fileSets,
+		staged:   make(map[string]string),
+	}
+	for _, f := range fileSets {
+		for _, filePath := range f.filePaths {
+			if err := b.stageFile(filePath); err != nil {
+				b.Close()
+				return nil, err
+			}
+		}
+	}
+	return b, nil
+}
+
+// stageFile hard links a local file into the staging directory, files that
+// do not exist locally are data files on the fileset backend.
+func (b *StagedBackup) stageFile(filePath string) error {
+	exists, err := fs.FileExists(filePath)
+	if err != nil || !exists {
+		return err
+	}
+	relPath, err := filepath.Rel(b.opts.FilePathPrefix(), filePath)
+	if err != nil {
+		return err
+	}
+	stagedPath := filepath.Join(b.dir, relPath)
+	if err := os.MkdirAll(filepath.Dir(stagedPath), b.opts.NewDirectoryMode()); err != nil {
+		return err
+	}
+	if err := os.Link(filePath, stagedPath); err != nil {
+		return err
+	}
+	b.staged[filePath] = stagedPath
+	return nil
+}
+
+// Write writes the backup archive of the staged filesets to the writer and
+// returns the manifest of the archive.
+func (b *StagedBackup) Write(w io.Writer) (Manifest, error) {
+	var (
+		now    = b.opts.ClockOptions().NowFn()()
+		aw     = newArchiveWriter(w, now)
+		source = Source{ID: b.md.SourceID, Shards: b.md.Shards}
+	)
+	for _, f := range b.fileSets {
+		fileSet := f.fileSet
+		fileSet.Files = nil
+		for _, filePath := range f.filePaths {
+			file, err := b.writeFileSetFile(aw, filePath)
+			if err != nil {
+				return Manifest{}, err
+			}
+			fileSet.Files = append(fileSet.Files, file)
+		}
+		source.FileSets = append(source.FileSets, fileSet)
+	}
+
+	manifest := Manifest{
+		Version:          ManifestVersion,
+		CreatedAt:        now,
+		Namespace:        b.md.Namespace.String(),
+		NamespaceOptions: b.md.NamespaceOptions,
+		Placement:        b.md.Placement,
+		Sources:          []Source{source},
+	}
+	if err := aw.writeManifest(manifest); err != nil {
+		return Manifest{}, err
+	}
+	return manifest, nil
+}
+
+// Close removes the staging directory of the backup.
+func (b *StagedBackup) Close() error {
+	return os.RemoveAll(b.dir)
+}
+
+func (b *StagedBackup) writeFileSetFile(aw *archiveWriter, filePath string) (File, error) {
+	filePathPrefix := b.opts.FilePathPrefix()
+	relPath, err := filepath.Rel(filePathPrefix, filePath)
+	if err != nil {
+		return File{}, err
+	}
+	var fd *os.File
+	if stagedPath, ok := b.staged[filePath]; ok {
+		fd, err = os.Open(stagedPath)
+	} else {
+		fd, err = fs.OpenFileSetFile(filePathPrefix, b.opts.FileSetBackend(), filePath)
+	}
+	if err != nil {
+		return File{}, err
+	}
+	defer fd.Close()
+
+	stat, err := fd.Stat()
+	if err != nil {
+		return File{}, err
+	}
+	return aw.writeFile(0, filepath.ToSlash(relPath), stat.Size(), fd)
+}
+
+// namespaceFileSets returns the complete filesets of the namespace for the
+// shards being backed up.
+func namespaceFileSets(opts fs.Options, md Metadata) ([]fileSetFiles, error) {
+	var (
+		filePathPrefix = opts.FilePathPrefix()
+		results        []fileSetFiles
+	)
+	for _, shard := range md.Shards {
+		dataFiles, err := fs.DataFiles(filePathPrefix, md.Namespace, shard)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range latestCompleteVolumes(dataFiles) {
+			filePaths, err := dataFileSetFilePaths(opts, f)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, fileSetFiles{
+				fileSet:   newFileSet(DataFileSetType, f.ID),
+				filePaths: filePaths,
+			})
+		}
+
+		snapshotFiles, err := fs.SnapshotFiles(filePathPrefix, md.Namespace, shard)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range latestCompleteVolumes(snapshotFiles) {
+			results = append(results, fileSetFiles{
+				fileSet:   newFileSet(SnapshotFileSetType, f.ID),
+				filePaths: f.AbsoluteFilepaths,
+			})
+		}
+	}
+
+	indexFiles, err := fs.IndexFiles(filePathPrefix, md.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	indexShards := make(map[fs.FileSetFileIdentifier][]uint32)
+	infoFiles := fs.ReadIndexInfoFiles(filePathPrefix, md.Namespace,
+		opts.InfoReaderBufferSize())
+	for _, result := range infoFiles {
+		if result.Err.Error() != nil {
+			return nil, result.Err.Error()
+		}
+		indexShards[indexFileSetKey(result.ID)] = result.Info.Shards
+	}
+	for _, f := range indexFiles {
+		if !f.HasCheckpointFile() {
+			continue
+		}
+		fileSet := newFileSet(IndexFileSetType, f.ID)
+		fileSet.Shards = indexShards[indexFileSetKey(f.ID)]
+		results = append(results, fileSetFiles{
+			fileSet:   fileSet,
+			filePaths: f.AbsoluteFilepaths,
+		})
+	}
+	return results, nil
+}
+
+func indexFileSetKey(id fs.FileSetFileIdentifier) fs.FileSetFileIdentifier {
+	return fs.FileSetFileIdentifier{
+		BlockStart:  id.BlockStart,
+		VolumeIndex: id.VolumeIndex,
+	}
+}
+
+func newFileSet(fileSetType FileSetType, id fs.FileSetFileIdentifier) FileSet {
+	return FileSet{
+		Type:        fileSetType,
+		BlockStart:  id.BlockStart,
+		VolumeIndex: id.VolumeIndex,
+		Shard:       id.Shard,
+	}
+}
+
+// latestCompleteVolumes returns the latest complete volume of each block start.
+func latestCompleteVolumes(files fs.FileSetFilesSlice) fs.FileSetFilesSlice {
+	latest := make(map[int64]fs.FileSetFile)
+	for _, f := range files {
+		if !f.HasCheckpointFile() {
+			continue
+		}
+		blockStart := f.ID.BlockStart.UnixNano()
+		if curr, ok := latest[blockStart]; !ok || f.ID.VolumeIndex > curr.ID.VolumeIndex {
+			latest[blockStart] = f
+		}
+	}
+	result := make(fs.FileSetFilesSlice, 0, len(latest))
+	for _, f := range latest {
+		result = append(result, f)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID.BlockStart.Before(result[j].ID.BlockStart)
+	})
+	return result
+}
+
+// dataFileSetFilePaths returns the file paths of a data fileset including
+// the data file if it has been moved to the fileset backend.
+func dataFileSetFilePaths(opts fs.Options, f fs.FileSetFile) ([]string, error) {
+	filePaths := append([]string(nil), f.AbsoluteFilepaths...)
+	if opts.FileSetBackend() == nil {
+		return filePaths, nil
+	}
+	for _, filePath := range f.AbsoluteFilepaths {
+		dataFilePath, ok := fs.DataFilePathFromCheckpointFilePath(filePath)
+		if !ok {
+			continue
+		}
+		exists, err := fs.FileExists(dataFilePath)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			filePaths = append(filePaths, dataFilePath)
+		}
+	}
+	return filePaths, nil
+}
+
+// Merge merges backup archives taken from the nodes of a cluster into a
+// single archive and returns the manifest of the merged archive. The
+// archives must all be of the same namespace, the namespace options and
+// placement replace those of the archives if set. Files are validated against
+// the manifest of their archive while they are merged.
+func Merge(
+	w io.Writer,
+	namespaceOptions []byte,
+	placement []byte,
+	archives []SourceArchive,
+	nowFn func() time.Time,
+) (Manifest, error) {
+	var (
+		now    = nowFn()
+		aw     = newArchiveWriter(w, now)
+		merged = Manifest{
+			Version:          ManifestVersion,
+			CreatedAt:        now,
+			NamespaceOptions: namespaceOptions,
+			Placement:        placement,
+		}
+	)
+	for i, archive := range archives {
+		sourceOffset := len(merged.Sources)
+		m, err := readArchive(archive.Reader, func(
+			sourceIdx int,
+			filePath string,
+			size int64,
+			r io.Reader,
+		) error {
+			_, err := aw.writeFile(sourceOffset+sourceIdx, filePath, size, r)
+			return err
+		})
+		if err != nil {
+			return Manifest{}, err
+		}
+
+		if i == 0 {
+			merged.Namespace = m.Namespace
+			if merged.NamespaceOptions == nil {
+				merged.NamespaceOptions = m.NamespaceOptions
+			}
+			if merged.Placement == nil {
+				merged.Placement = m.Placement
+			}
+		} else if m.Namespace != merged.Namespace {
+			return Manifest{}, errMismatchedNamespace
+		}
+		for _, source := range m.Sources {
+			if archive.ID != "" {
+				source.ID = archive.ID
+			}
+			merged.Sources = append(merged.Sources, source)
+		}
+	}
+
+	if err := aw.writeManifest(merged); err != nil {
+		return Manifest{}, err
+	}
+	return merged, nil
+}
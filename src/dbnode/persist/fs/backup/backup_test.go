@@ -0,0 +1,264 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3x/checked"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testNamespace = ident.StringID("testns")
+	testBlockSize = 2 * time.Hour
+)
+
+func newTestOptions(t *testing.T) (fs.Options, func()) {
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	return fs.NewOptions().SetFilePathPrefix(dir), func() {
+		os.RemoveAll(dir)
+	}
+}
+
+func writeTestFileSet(t *testing.T, opts fs.Options, shard uint32, blockStart time.Time) {
+	w, err := fs.NewWriter(opts)
+	require.NoError(t, err)
+	require.NoError(t, w.Open(fs.DataWriterOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  testNamespace,
+			Shard:      shard,
+			BlockStart: blockStart,
+		},
+		BlockSize: testBlockSize,
+	}))
+	data := []byte("somelongstringofdata")
+	dataBytes := checked.NewBytes(data, nil)
+	dataBytes.IncRef()
+	require.NoError(t, w.Write(ident.StringID("foo"), ident.Tags{}, dataBytes,
+		digest.Checksum(data)))
+	require.NoError(t, w.Close())
+}
+
+// testFiles returns the contents of the files under the file path prefix
+// keyed by their path relative to the file path prefix.
+func testFiles(t *testing.T, opts fs.Options) map[string][]byte {
+	files := make(map[string][]byte)
+	prefix := opts.FilePathPrefix()
+	require.NoError(t, filepath.Walk(prefix, func(
+		filePath string,
+		info os.FileInfo,
+		err error,
+	) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(prefix, filePath)
+		if err != nil {
+			return err
+		}
+		files[relPath] = data
+		return nil
+	}))
+	return files
+}
+
+func testBackup(
+	t *testing.T,
+	opts fs.Options,
+	sourceID string,
+	shards []uint32,
+) *bytes.Buffer {
+	var buf bytes.Buffer
+	manifest, err := Backup(&buf, opts, Metadata{
+		SourceID:         sourceID,
+		Namespace:        testNamespace,
+		NamespaceOptions: []byte("options"),
+		Shards:           shards,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(manifest.Sources))
+	require.Equal(t, sourceID, manifest.Sources[0].ID)
+	return &buf
+}
+
+func TestBackupRestore(t *testing.T) {
+	srcOpts, srcCleanup := newTestOptions(t)
+	defer srcCleanup()
+	destOpts, destCleanup := newTestOptions(t)
+	defer destCleanup()
+
+	blockStart := time.Now().Truncate(testBlockSize)
+	for _, shard := range []uint32{0, 1} {
+		writeTestFileSet(t, srcOpts, shard, blockStart.Add(-testBlockSize))
+		writeTestFileSet(t, srcOpts, shard, blockStart)
+	}
+
+	archive := testBackup(t, srcOpts, "host0", []uint32{0, 1})
+
+	manifest, err := Restore(archive, destOpts, nil)
+	require.NoError(t, err)
+	require.Equal(t, testNamespace.String(), manifest.Namespace)
+	require.Equal(t, []byte("options"), manifest.NamespaceOptions)
+	require.Equal(t, 4, len(manifest.Sources[0].FileSets))
+	require.Equal(t, testFiles(t, srcOpts), testFiles(t, destOpts))
+
+	for _, shard := range []uint32{0, 1} {
+		exists, err := fs.DataFileSetExistsAt(destOpts.FilePathPrefix(),
+			testNamespace, shard, blockStart)
+		require.NoError(t, err)
+		require.True(t, exists)
+	}
+}
+
+func TestStagedBackupSurvivesCleanup(t *testing.T) {
+	srcOpts, srcCleanup := newTestOptions(t)
+	defer srcCleanup()
+	destOpts, destCleanup := newTestOptions(t)
+	defer destCleanup()
+
+	blockStart := time.Now().Truncate(testBlockSize)
+	writeTestFileSet(t, srcOpts, 0, blockStart)
+	expected := testFiles(t, srcOpts)
+
+	staged, err := Stage(srcOpts, Metadata{
+		SourceID:  "host0",
+		Namespace: testNamespace,
+		Shards:    []uint32{0},
+	})
+	require.NoError(t, err)
+
+	// Remove the filesets as a cleanup would once file operations resume.
+	require.NoError(t, fs.DeleteDirectories([]string{
+		fs.ShardDataDirPath(srcOpts.FilePathPrefix(), testNamespace, 0),
+	}))
+
+	var archive bytes.Buffer
+	_, err = staged.Write(&archive)
+	require.NoError(t, err)
+	require.NoError(t, staged.Close())
+	require.Empty(t, testFiles(t, srcOpts))
+
+	_, err = Restore(&archive, destOpts, nil)
+	require.NoError(t, err)
+	require.Equal(t, expected, testFiles(t, destOpts))
+}
+
+func TestMergeRestoreShards(t *testing.T) {
+	src0Opts, src0Cleanup := newTestOptions(t)
+	defer src0Cleanup()
+	src1Opts, src1Cleanup := newTestOptions(t)
+	defer src1Cleanup()
+	destOpts, destCleanup := newTestOptions(t)
+	defer destCleanup()
+
+	blockStart := time.Now().Truncate(testBlockSize)
+	writeTestFileSet(t, src0Opts, 0, blockStart)
+	writeTestFileSet(t, src1Opts, 1, blockStart)
+	writeTestFileSet(t, src1Opts, 2, blockStart)
+
+	var merged bytes.Buffer
+	manifest, err := Merge(&merged, nil, []byte("placement"), []SourceArchive{
+		{ID: "host0", Reader: testBackup(t, src0Opts, "", []uint32{0})},
+		{ID: "host1", Reader: testBackup(t, src1Opts, "", []uint32{1, 2})},
+	}, time.Now)
+	require.NoError(t, err)
+	require.Equal(t, []byte("options"), manifest.NamespaceOptions)
+	require.Equal(t, []byte("placement"), manifest.Placement)
+	require.Equal(t, 2, len(manifest.Sources))
+	require.Equal(t, "host0", manifest.Sources[0].ID)
+	require.Equal(t, "host1", manifest.Sources[1].ID)
+
+	_, err = Restore(&merged, destOpts, []uint32{0, 2})
+	require.NoError(t, err)
+
+	var restored []uint32
+	for _, shard := range []uint32{0, 1, 2} {
+		exists, err := fs.DataFileSetExistsAt(destOpts.FilePathPrefix(),
+			testNamespace, shard, blockStart)
+		require.NoError(t, err)
+		if exists {
+			restored = append(restored, shard)
+		}
+	}
+	sort.Slice(restored, func(i, j int) bool { return restored[i] < restored[j] })
+	require.Equal(t, []uint32{0, 2}, restored)
+}
+
+func TestRestoreChecksumMismatch(t *testing.T) {
+	srcOpts, srcCleanup := newTestOptions(t)
+	defer srcCleanup()
+	destOpts, destCleanup := newTestOptions(t)
+	defer destCleanup()
+
+	writeTestFileSet(t, srcOpts, 0, time.Now().Truncate(testBlockSize))
+	archive := testBackup(t, srcOpts, "host0", []uint32{0}).Bytes()
+
+	// Corrupt the contents of the first file, which directly follows the
+	// header of the first entry.
+	archive[512] ^= 0xff
+
+	_, err := Restore(bytes.NewReader(archive), destOpts, nil)
+	require.Error(t, err)
+	require.Empty(t, testFiles(t, destOpts))
+}
+
+func TestRestoreExistingFiles(t *testing.T) {
+	opts, cleanup := newTestOptions(t)
+	defer cleanup()
+
+	writeTestFileSet(t, opts, 0, time.Now().Truncate(testBlockSize))
+	files := testFiles(t, opts)
+	archive := testBackup(t, opts, "host0", []uint32{0})
+
+	_, err := Restore(archive, opts, nil)
+	require.Error(t, err)
+	require.Equal(t, files, testFiles(t, opts))
+}
+
+func TestReadArchiveRejectsUnlistedFiles(t *testing.T) {
+	var buf bytes.Buffer
+	w := newArchiveWriter(&buf, time.Now())
+	_, err := w.writeFile(0, "data/testns/0/file.db", 4, bytes.NewReader([]byte("data")))
+	require.NoError(t, err)
+	require.NoError(t, w.writeManifest(Manifest{Version: ManifestVersion}))
+
+	_, err = readArchive(&buf, func(int, string, int64, io.Reader) error {
+		return nil
+	})
+	require.Equal(t, errArchiveFileUnlisted, err)
+}
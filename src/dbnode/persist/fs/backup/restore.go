@@ -0,0 +1,227 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3x/ident"
+)
+
+const restoreStagingDirPrefix = ".restore-"
+
+type restoreFile struct {
+	stagingPath string
+	destPath    string
+}
+
+// Restore validates a backup archive and installs its filesets under the file
+// path prefix so that they are picked up by the filesystem bootstrapper when
+// the node next bootstraps, it returns the manifest of the archive.
+//
+// If shards is not nil only data and snapshot filesets of those shards, and
+// index filesets that cover only those shards, are installed. Index filesets
+// are installed with the next free volume indexes of their block starts so
+// that those taken from different nodes do not collide. The archive is fully
+// validated before any file is installed and the restore fails without
+// installing any file if a file it would install already exists.
+func Restore(
+	r io.Reader,
+	opts fs.Options,
+	shards []uint32,
+) (Manifest, error) {
+	filePathPrefix := opts.FilePathPrefix()
+	if err := os.MkdirAll(filePathPrefix, opts.NewDirectoryMode()); err != nil {
+		return Manifest{}, err
+	}
+	// Stage files under the file path prefix so that they can be installed
+	// by renaming them.
+	stagingDir, err := ioutil.TempDir(filePathPrefix, restoreStagingDirPrefix)
+	if err != nil {
+		return Manifest{}, err
+	}
+	defer os.RemoveAll(stagingDir)
+
+	manifest, err := readArchive(r, func(
+		sourceIdx int,
+		filePath string,
+		size int64,
+		r io.Reader,
+	) error {
+		stagingPath := filepath.Join(stagingDir,
+			filepath.FromSlash(sourceEntryName(sourceIdx, filePath)))
+		return stageFile(opts, stagingPath, r)
+	})
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	files, err := restoreFiles(opts, stagingDir, manifest, shards)
+	if err != nil {
+		return Manifest{}, err
+	}
+	for _, f := range files {
+		exists, err := fs.FileExists(f.destPath)
+		if err != nil {
+			return Manifest{}, err
+		}
+		if exists {
+			return Manifest{}, fmt.Errorf("restore file already exists: %s", f.destPath)
+		}
+	}
+	for _, f := range files {
+		if err := os.MkdirAll(filepath.Dir(f.destPath), opts.NewDirectoryMode()); err != nil {
+			return Manifest{}, err
+		}
+		if err := os.Rename(f.stagingPath, f.destPath); err != nil {
+			return Manifest{}, err
+		}
+	}
+	return manifest, nil
+}
+
+func stageFile(opts fs.Options, stagingPath string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(stagingPath), opts.NewDirectoryMode()); err != nil {
+		return err
+	}
+	fd, err := fs.OpenWritable(stagingPath, opts.NewFileMode())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(fd, r); err != nil {
+		fd.Close()
+		return err
+	}
+	if err := fd.Sync(); err != nil {
+		fd.Close()
+		return err
+	}
+	return fd.Close()
+}
+
+// restoreFiles returns the files to install in the order that they should be
+// installed, the checkpoint file of each fileset is installed last so that a
+// fileset is only considered complete once all of its files are installed.
+func restoreFiles(
+	opts fs.Options,
+	stagingDir string,
+	manifest Manifest,
+	shards []uint32,
+) ([]restoreFile, error) {
+	var (
+		filePathPrefix = opts.FilePathPrefix()
+		namespace      = ident.StringID(manifest.Namespace)
+		restoreShards  map[uint32]struct{}
+		indexVolumes   = make(map[int64]int)
+		results        []restoreFile
+	)
+	if shards != nil {
+		restoreShards = make(map[uint32]struct{}, len(shards))
+		for _, shard := range shards {
+			restoreShards[shard] = struct{}{}
+		}
+	}
+	restoreShard := func(shard uint32) bool {
+		if restoreShards == nil {
+			return true
+		}
+		_, ok := restoreShards[shard]
+		return ok
+	}
+
+	for i, source := range manifest.Sources {
+		for _, fileSet := range source.FileSets {
+			rename := func(filePath string) string { return filePath }
+			switch fileSet.Type {
+			case DataFileSetType, SnapshotFileSetType:
+				if !restoreShard(fileSet.Shard) {
+					continue
+				}
+			case IndexFileSetType:
+				restore := true
+				for _, shard := range fileSet.Shards {
+					restore = restore && restoreShard(shard)
+				}
+				if !restore {
+					continue
+				}
+				volumeIndex, ok := indexVolumes[fileSet.BlockStart.UnixNano()]
+				if !ok {
+					var err error
+					volumeIndex, err = fs.NextIndexFileSetVolumeIndex(filePathPrefix,
+						namespace, fileSet.BlockStart)
+					if err != nil {
+						return nil, err
+					}
+				}
+				indexVolumes[fileSet.BlockStart.UnixNano()] = volumeIndex + 1
+				rename = indexFileSetRenameFn(fileSet, volumeIndex)
+			default:
+				return nil, fmt.Errorf("unknown fileset type: %s", fileSet.Type)
+			}
+
+			var checkpoint *restoreFile
+			for _, file := range fileSet.Files {
+				f := restoreFile{
+					stagingPath: filepath.Join(stagingDir,
+						filepath.FromSlash(sourceEntryName(i, file.Path))),
+					destPath: filepath.Join(filePathPrefix,
+						filepath.FromSlash(rename(file.Path))),
+				}
+				if _, ok := fs.DataFilePathFromCheckpointFilePath(file.Path); ok {
+					checkpoint = &f
+					continue
+				}
+				results = append(results, f)
+			}
+			if checkpoint != nil {
+				results = append(results, *checkpoint)
+			}
+		}
+	}
+	return results, nil
+}
+
+// indexFileSetRenameFn returns a function that renames the files of an index
+// fileset to the given volume index.
+func indexFileSetRenameFn(
+	fileSet FileSet,
+	volumeIndex int,
+) func(filePath string) string {
+	volumePrefix := func(volumeIndex int) string {
+		return fmt.Sprintf("fileset-%d-%d-", fileSet.BlockStart.UnixNano(), volumeIndex)
+	}
+	from, to := volumePrefix(fileSet.VolumeIndex), volumePrefix(volumeIndex)
+	return func(filePath string) string {
+		dir, name := path.Split(filePath)
+		if !strings.HasPrefix(name, from) {
+			return filePath
+		}
+		return dir + to + strings.TrimPrefix(name, from)
+	}
+}
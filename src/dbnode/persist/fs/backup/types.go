@@ -0,0 +1,110 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"io"
+	"time"
+
+	"github.com/m3db/m3x/ident"
+)
+
+// ManifestVersion is the version of the manifest written to archives.
+const ManifestVersion = 1
+
+// FileSetType is the type of a fileset in a backup archive.
+type FileSetType string
+
+const (
+	// DataFileSetType is a flushed data fileset of a shard.
+	DataFileSetType FileSetType = "data"
+
+	// SnapshotFileSetType is a snapshot data fileset of a shard.
+	SnapshotFileSetType FileSetType = "snapshot"
+
+	// IndexFileSetType is a flushed index fileset of a namespace.
+	IndexFileSetType FileSetType = "index"
+)
+
+// Manifest describes the contents of a backup archive, it is written as the
+// last entry of the archive so that archives can be written and read in a
+// single pass.
+type Manifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	Namespace string    `json:"namespace"`
+
+	// NamespaceOptions is the marshalled namespace options protobuf of the
+	// namespace as registered in KV.
+	NamespaceOptions []byte `json:"namespaceOptions,omitempty"`
+
+	// Placement is the marshalled placement protobuf of the cluster that
+	// the backup was taken from.
+	Placement []byte `json:"placement,omitempty"`
+
+	Sources []Source `json:"sources"`
+}
+
+// Source is the set of filesets captured from a single node.
+type Source struct {
+	ID       string    `json:"id"`
+	Shards   []uint32  `json:"shards"`
+	FileSets []FileSet `json:"fileSets"`
+}
+
+// FileSet is a fileset captured in a backup archive.
+type FileSet struct {
+	Type        FileSetType `json:"type"`
+	BlockStart  time.Time   `json:"blockStart"`
+	VolumeIndex int         `json:"volumeIndex"`
+
+	// Shard is the shard of data and snapshot filesets.
+	Shard uint32 `json:"shard"`
+
+	// Shards are the shards covered by index filesets.
+	Shards []uint32 `json:"shards,omitempty"`
+
+	Files []File `json:"files"`
+}
+
+// File is a fileset file captured in a backup archive.
+type File struct {
+	// Path is the path of the file relative to the file path prefix.
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	Checksum uint32 `json:"checksum"`
+}
+
+// Metadata describes what to capture in a backup archive.
+type Metadata struct {
+	SourceID         string
+	Namespace        ident.ID
+	NamespaceOptions []byte
+	Placement        []byte
+	Shards           []uint32
+}
+
+// SourceArchive is a backup archive to be merged with others.
+type SourceArchive struct {
+	// ID replaces the source ID of the sources in the archive if set.
+	ID     string
+	Reader io.Reader
+}
@@ -66,7 +66,9 @@ func (c *cloner) Clone(src FileSetID, dest FileSetID, destBlocksize time.Duratio
 		return fmt.Errorf("unable to read source fileset: %v", err)
 	}
 
-	writer, err := fs.NewWriter(fsopts.SetFilePathPrefix(dest.PathPrefix))
+	writer, err := fs.NewWriter(fsopts.
+		SetFilePathPrefix(dest.PathPrefix).
+		SetCompression(c.opts.Compression()))
 	if err != nil {
 		return fmt.Errorf("unable to create fileset writer: %v", err)
 	}
@@ -77,6 +79,7 @@ func (c *cloner) Clone(src FileSetID, dest FileSetID, destBlocksize time.Duratio
 			Shard:      dest.Shard,
 			BlockStart: dest.Blockstart,
 		},
+		EncodingScheme: reader.EncodingScheme(),
 	}
 	if err := writer.Open(writerOpts); err != nil {
 		return fmt.Errorf("unable to open fileset writer: %v", err)
@@ -29,6 +29,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3x/checked"
 	"github.com/m3db/m3x/ident"
@@ -45,10 +46,17 @@ var (
 )
 
 func TestCloner(t *testing.T) {
+	testCloner(t, NewOptions())
+}
+
+func TestClonerCompressed(t *testing.T) {
+	testCloner(t, NewOptions().SetCompression(persist.FileSetSnappyCompression))
+}
+
+func testCloner(t *testing.T, opts Options) {
 	dir, err := ioutil.TempDir("", "clone")
 	require.NoError(t, err)
 	defer os.RemoveAll(dir)
-	opts := NewOptions()
 
 	// generate some fake source data
 	srcBlockSize := time.Hour
@@ -23,6 +23,7 @@ package clone
 import (
 	"os"
 
+	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3x/pool"
 )
@@ -34,11 +35,12 @@ const (
 )
 
 type opts struct {
-	pool       pool.CheckedBytesPool
-	dOpts      msgpack.DecodingOptions
-	bufferSize int
-	fileMode   os.FileMode
-	dirMode    os.FileMode
+	pool        pool.CheckedBytesPool
+	dOpts       msgpack.DecodingOptions
+	bufferSize  int
+	fileMode    os.FileMode
+	dirMode     os.FileMode
+	compression persist.FileSetCompression
 }
 
 // NewOptions returns the new options
@@ -96,3 +98,12 @@ func (o *opts) SetDirMode(d os.FileMode) Options {
 func (o *opts) DirMode() os.FileMode {
 	return o.dirMode
 }
+
+func (o *opts) SetCompression(c persist.FileSetCompression) Options {
+	o.compression = c
+	return o
+}
+
+func (o *opts) Compression() persist.FileSetCompression {
+	return o.compression
+}
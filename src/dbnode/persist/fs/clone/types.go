@@ -24,6 +24,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3x/pool"
 )
@@ -74,4 +75,10 @@ type Options interface {
 
 	// DirMode returns the file mode used for dir creation
 	DirMode() os.FileMode
+
+	// SetCompression sets the compression used for the cloned fileset
+	SetCompression(persist.FileSetCompression) Options
+
+	// Compression returns the compression used for the cloned fileset
+	Compression() persist.FileSetCompression
 }
@@ -0,0 +1,172 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/m3db/m3/src/dbnode/persist"
+
+	"github.com/golang/snappy"
+)
+
+// Compressed filesets write the index and data files as a sequence of pages,
+// each page is written as a header holding the length of the compressed page
+// followed by the compressed page. Index entries and summaries refer to the
+// offset of the page that contains them in the data and index file.
+const compressedPageHeaderLen = 4
+
+var (
+	// errCompressedPageTruncated returned when a compressed page is shorter
+	// than its header indicates
+	errCompressedPageTruncated = errors.New("compressed page is truncated")
+
+	// errInvalidPageOffset returned when an index entry refers to data outside
+	// of the page that contains it
+	errInvalidPageOffset = errors.New("invalid page offset")
+)
+
+// compressPage returns the header and the compressed contents of a page, the
+// destination buffer is reused if it is large enough.
+func compressPage(
+	dst []byte,
+	compression persist.FileSetCompression,
+	page []byte,
+) ([]byte, error) {
+	switch compression {
+	case persist.FileSetSnappyCompression:
+		maxLen := compressedPageHeaderLen + snappy.MaxEncodedLen(len(page))
+		if cap(dst) < maxLen {
+			dst = make([]byte, maxLen)
+		}
+		dst = dst[:maxLen]
+		compressed := snappy.Encode(dst[compressedPageHeaderLen:], page)
+		binary.BigEndian.PutUint32(dst, uint32(len(compressed)))
+		return dst[:compressedPageHeaderLen+len(compressed)], nil
+	default:
+		return nil, fmt.Errorf("unable to compress page with compression: %v", compression)
+	}
+}
+
+// decompressPage decompresses the page that starts at the beginning of the
+// buffer, it returns the decompressed page and the number of bytes of the
+// buffer that the page occupies.
+func decompressPage(
+	dst []byte,
+	compression persist.FileSetCompression,
+	buf []byte,
+) ([]byte, int, error) {
+	if len(buf) < compressedPageHeaderLen {
+		return nil, 0, errCompressedPageTruncated
+	}
+	size := int(binary.BigEndian.Uint32(buf))
+	end := compressedPageHeaderLen + size
+	if len(buf) < end {
+		return nil, 0, errCompressedPageTruncated
+	}
+
+	page, err := decompressPageContents(dst, compression, buf[compressedPageHeaderLen:end])
+	if err != nil {
+		return nil, 0, err
+	}
+	return page, end, nil
+}
+
+// readCompressedPage reads and decompresses the next page from the reader.
+func readCompressedPage(
+	dst []byte,
+	compressed []byte,
+	compression persist.FileSetCompression,
+	r io.Reader,
+) ([]byte, []byte, error) {
+	var header [compressedPageHeaderLen]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, compressed, err
+	}
+	size := int(binary.BigEndian.Uint32(header[:]))
+	if cap(compressed) < size {
+		compressed = make([]byte, size)
+	}
+	compressed = compressed[:size]
+	if _, err := io.ReadFull(r, compressed); err != nil {
+		if err == io.EOF {
+			err = errCompressedPageTruncated
+		}
+		return nil, compressed, err
+	}
+
+	page, err := decompressPageContents(dst, compression, compressed)
+	return page, compressed, err
+}
+
+// decompressPages decompresses all the pages of a file.
+func decompressPages(
+	dst []byte,
+	compression persist.FileSetCompression,
+	buf []byte,
+) ([]byte, error) {
+	var page []byte
+	dst = dst[:0]
+	for len(buf) > 0 {
+		var (
+			n   int
+			err error
+		)
+		page, n, err = decompressPage(page, compression, buf)
+		if err != nil {
+			return nil, err
+		}
+		dst = append(dst, page...)
+		buf = buf[n:]
+	}
+	return dst, nil
+}
+
+func decompressPageContents(
+	dst []byte,
+	compression persist.FileSetCompression,
+	compressed []byte,
+) ([]byte, error) {
+	switch compression {
+	case persist.FileSetSnappyCompression:
+		size, err := snappy.DecodedLen(compressed)
+		if err != nil {
+			return nil, err
+		}
+		if cap(dst) < size {
+			dst = make([]byte, size)
+		}
+		return snappy.Decode(dst[:size], compressed)
+	default:
+		return nil, fmt.Errorf("unable to decompress page with compression: %v", compression)
+	}
+}
+
+// pageSlice returns the data of an entry within a decompressed page.
+func pageSlice(page []byte, pageOffset int64, size int64) ([]byte, error) {
+	if pageOffset < 0 || size < 0 || pageOffset+size > int64(len(page)) {
+		return nil, errInvalidPageOffset
+	}
+	return page[pageOffset : pageOffset+size], nil
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCompressionPageSize = 64
+
+func newTestCompressedWriter(t *testing.T, filePathPrefix string) DataFileSetWriter {
+	writer, err := NewWriter(testDefaultOpts.
+		SetFilePathPrefix(filePathPrefix).
+		SetWriterBufferSize(testWriterBufferSize).
+		SetCompression(persist.FileSetSnappyCompression).
+		SetCompressionPageSize(testCompressionPageSize))
+	require.NoError(t, err)
+	return writer
+}
+
+func testCompressedEntries() []testEntry {
+	entries := []testEntry{
+		{"foo", nil, []byte{1, 2, 3}},
+		{"bar", nil, []byte{4, 5, 6}},
+		{"baz", nil, make([]byte, 65536)},
+		{"cat", nil, make([]byte, 100000)},
+		{"foo+bar=baz,qux=qaz", map[string]string{
+			"bar": "baz",
+			"qux": "qaz",
+		}, []byte{7, 8, 9}},
+	}
+	for i := 0; i < 100; i++ {
+		id := string([]byte{'a' + byte(i%26), 'a' + byte(i/26)})
+		entries = append(entries, testEntry{id, nil, bytes.Repeat([]byte{byte(i)}, i+1)})
+	}
+	return entries
+}
+
+func TestCompressPageRoundTrip(t *testing.T) {
+	page := bytes.Repeat([]byte("compressible"), 100)
+
+	compressed, err := compressPage(nil, persist.FileSetSnappyCompression, page)
+	require.NoError(t, err)
+	assert.True(t, len(compressed) < len(page))
+
+	decompressed, n, err := decompressPage(nil, persist.FileSetSnappyCompression, compressed)
+	require.NoError(t, err)
+	assert.Equal(t, len(compressed), n)
+	assert.Equal(t, page, decompressed)
+
+	_, _, err = decompressPage(nil, persist.FileSetSnappyCompression, compressed[:len(compressed)-1])
+	assert.Equal(t, errCompressedPageTruncated, err)
+
+	_, err = compressPage(nil, persist.FileSetNoCompression, page)
+	assert.Error(t, err)
+}
+
+func TestDecompressPages(t *testing.T) {
+	first := bytes.Repeat([]byte{1}, 100)
+	second := bytes.Repeat([]byte{2}, 50)
+
+	firstCompressed, err := compressPage(nil, persist.FileSetSnappyCompression, first)
+	require.NoError(t, err)
+	secondCompressed, err := compressPage(nil, persist.FileSetSnappyCompression, second)
+	require.NoError(t, err)
+
+	buf := append(append([]byte(nil), firstCompressed...), secondCompressed...)
+	result, err := decompressPages(nil, persist.FileSetSnappyCompression, buf)
+	require.NoError(t, err)
+	assert.Equal(t, append(append([]byte(nil), first...), second...), result)
+}
+
+func TestPageSlice(t *testing.T) {
+	page := []byte{1, 2, 3, 4}
+
+	data, err := pageSlice(page, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2, 3}, data)
+
+	_, err = pageSlice(page, 3, 2)
+	assert.Equal(t, errInvalidPageOffset, err)
+	_, err = pageSlice(page, -1, 2)
+	assert.Equal(t, errInvalidPageOffset, err)
+}
+
+func TestCompressedReadWrite(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	entries := testCompressedEntries()
+
+	w := newTestCompressedWriter(t, filePathPrefix)
+	writeTestData(t, w, 0, testWriterStart, entries, persist.FileSetFlushType)
+
+	r := newTestReader(t, filePathPrefix)
+	readTestData(t, r, 0, testWriterStart, entries)
+
+	rOpenOpts := DataReaderOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      0,
+			BlockStart: testWriterStart,
+		},
+	}
+	require.NoError(t, r.Open(rOpenOpts))
+	for i := 0; i < r.Entries(); i++ {
+		id, tags, data, _, err := r.Read()
+		require.NoError(t, err)
+		id.Finalize()
+		tags.Close()
+		data.Finalize()
+	}
+	require.NoError(t, r.Validate())
+	require.NoError(t, r.Close())
+}
+
+func TestCompressedSeek(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	entries := testCompressedEntries()
+
+	w := newTestCompressedWriter(t, filePathPrefix)
+	writeTestData(t, w, 0, testWriterStart, entries, persist.FileSetFlushType)
+
+	s := newTestSeeker(filePathPrefix)
+	require.NoError(t, s.Open(testNs1ID, 0, testWriterStart, 0))
+	defer s.Close()
+
+	for _, entry := range entries {
+		data, err := s.SeekByID(ident.StringID(entry.id))
+		require.NoError(t, err)
+
+		data.IncRef()
+		assert.True(t, bytes.Equal(entry.data, data.Bytes()))
+		data.DecRef()
+
+		indexEntry, err := s.SeekIndexEntry(ident.StringID(entry.id))
+		require.NoError(t, err)
+
+		data, err = s.SeekByIndexEntry(indexEntry)
+		require.NoError(t, err)
+
+		data.IncRef()
+		assert.True(t, bytes.Equal(entry.data, data.Bytes()))
+		data.DecRef()
+	}
+
+	_, err := s.SeekByID(ident.StringID("not-exists"))
+	assert.Equal(t, errSeekIDNotFound, err)
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+)
+
+const (
+	downsampledVolumesFileName = "downsampled" + fileSuffix
+	downsampledVolumesVersion  = 1
+)
+
+var errDownsampledVolumesFileTruncated = errors.New("downsampled volumes file truncated")
+
+// DownsampledVolumesFilePath returns the path of the file recording the
+// fileset volumes of a shard that have been downsampled.
+func DownsampledVolumesFilePath(prefix string, namespace ident.ID, shard uint32) string {
+	return path.Join(ShardDataDirPath(prefix, namespace, shard), downsampledVolumesFileName)
+}
+
+// WriteDownsampledVolumes atomically replaces the downsampled fileset volumes
+// persisted for a shard, keyed by the block start of the volume.
+func WriteDownsampledVolumes(
+	opts Options,
+	namespace ident.ID,
+	shard uint32,
+	volumes map[xtime.UnixNano]int,
+) error {
+	var (
+		buf     = make([]byte, 0, 2*binary.MaxVarintLen64*(len(volumes)+1))
+		scratch [binary.MaxVarintLen64]byte
+	)
+	putUvarint := func(v uint64) {
+		n := binary.PutUvarint(scratch[:], v)
+		buf = append(buf, scratch[:n]...)
+	}
+	putUvarint(downsampledVolumesVersion)
+	putUvarint(uint64(len(volumes)))
+	for blockStart, volumeIndex := range volumes {
+		n := binary.PutVarint(scratch[:], int64(blockStart))
+		buf = append(buf, scratch[:n]...)
+		putUvarint(uint64(volumeIndex))
+	}
+
+	filePath := DownsampledVolumesFilePath(opts.FilePathPrefix(), namespace, shard)
+	return writeShardStateFile(filePath, buf, opts.NewFileMode(), opts.NewDirectoryMode())
+}
+
+// ReadDownsampledVolumes reads the downsampled fileset volumes persisted for
+// a shard, returning no volumes if none have been persisted.
+func ReadDownsampledVolumes(
+	filePathPrefix string,
+	namespace ident.ID,
+	shard uint32,
+) (map[xtime.UnixNano]int, error) {
+	data, ok, err := readShardStateFile(DownsampledVolumesFilePath(filePathPrefix, namespace, shard))
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	uvarint := func() (uint64, error) {
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			return 0, errDownsampledVolumesFileTruncated
+		}
+		data = data[n:]
+		return v, nil
+	}
+	version, err := uvarint()
+	if err != nil {
+		return nil, err
+	}
+	if version != downsampledVolumesVersion {
+		return nil, fmt.Errorf("unsupported downsampled volumes file version %d", version)
+	}
+	count, err := uvarint()
+	if err != nil {
+		return nil, err
+	}
+
+	// Bound the capacity by the remaining bytes as every volume takes at
+	// least two bytes.
+	capacity := count
+	if max := uint64(len(data) / 2); capacity > max {
+		capacity = max
+	}
+	volumes := make(map[xtime.UnixNano]int, capacity)
+	for i := uint64(0); i < count; i++ {
+		blockStart, n := binary.Varint(data)
+		if n <= 0 {
+			return nil, errDownsampledVolumesFileTruncated
+		}
+		data = data[n:]
+		volumeIndex, err := uvarint()
+		if err != nil {
+			return nil, err
+		}
+		volumes[xtime.UnixNano(blockStart)] = int(volumeIndex)
+	}
+	return volumes, nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteReadDownsampledVolumes(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		opts       = testDefaultOpts.SetFilePathPrefix(dir)
+		namespace  = ident.StringID("ns")
+		blockStart = time.Now().Truncate(time.Hour)
+	)
+
+	volumes, err := ReadDownsampledVolumes(dir, namespace, 1)
+	require.NoError(t, err)
+	require.Empty(t, volumes)
+
+	expected := map[xtime.UnixNano]int{
+		xtime.ToUnixNano(blockStart):                     0,
+		xtime.ToUnixNano(blockStart.Add(-1 * time.Hour)): 3,
+	}
+	require.NoError(t, WriteDownsampledVolumes(opts, namespace, 1, expected))
+
+	volumes, err = ReadDownsampledVolumes(dir, namespace, 1)
+	require.NoError(t, err)
+	require.Equal(t, expected, volumes)
+
+	// Volumes of other shards are unaffected.
+	volumes, err = ReadDownsampledVolumes(dir, namespace, 2)
+	require.NoError(t, err)
+	require.Empty(t, volumes)
+}
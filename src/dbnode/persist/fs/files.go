@@ -119,7 +119,7 @@ func (f FileSetFilesSlice) Filepaths() []string {
 }
 
 // LatestVolumeForBlock returns the latest (highest index) FileSetFile in the
-// slice for a given block start, only applicable for index and snapshot file set files.
+// slice for a given block start.
 func (f FileSetFilesSlice) LatestVolumeForBlock(blockStart time.Time) (FileSetFile, bool) {
 	// Make sure we're already sorted
 	f.sortByTimeAndVolumeIndexAscending()
@@ -285,6 +285,22 @@ func (a fileSetFilesByTimeAndVolumeIndexAscending) Less(i, j int) bool {
 	return ti.Equal(tj) && ii < ij
 }
 
+// dataFileSetFilesByTimeAndVolumeIndexAscending sorts data file sets files by their block start
+// times and volume index in ascending order. Files without a volume index in their name are the
+// first volume of their block start.
+type dataFileSetFilesByTimeAndVolumeIndexAscending []string
+
+func (a dataFileSetFilesByTimeAndVolumeIndexAscending) Len() int      { return len(a) }
+func (a dataFileSetFilesByTimeAndVolumeIndexAscending) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a dataFileSetFilesByTimeAndVolumeIndexAscending) Less(i, j int) bool {
+	ti, ii, _ := TimeAndVolumeIndexFromDataFileSetFilename(a[i])
+	tj, ij, _ := TimeAndVolumeIndexFromDataFileSetFilename(a[j])
+	if ti.Before(tj) {
+		return true
+	}
+	return ti.Equal(tj) && ii < ij
+}
+
 func componentsAndTimeFromFileName(fname string) ([]string, time.Time, error) {
 	components := strings.Split(filepath.Base(fname), separator)
 	if len(components) < 3 {
@@ -314,6 +330,22 @@ func TimeAndVolumeIndexFromFileSetFilename(fname string) (time.Time, int, error)
 	return timeAndIndexFromFileName(fname, indexFileSetComponentPosition)
 }
 
+// TimeAndVolumeIndexFromDataFileSetFilename extracts the block start and volume index from a
+// data fileset file name. The first volume of a block start is written without a volume index
+// in its file name so that it remains readable by nodes that do not know about volumes.
+func TimeAndVolumeIndexFromDataFileSetFilename(fname string) (time.Time, int, error) {
+	components, t, err := componentsAndTimeFromFileName(fname)
+	if err != nil {
+		return timeZero, 0, err
+	}
+
+	if len(components) == 3 {
+		return t, 0, nil
+	}
+
+	return timeAndIndexFromFileName(fname, indexFileSetComponentPosition)
+}
+
 func timeAndIndexFromFileName(fname string, componentPosition int) (time.Time, int, error) {
 	components, t, err := componentsAndTimeFromFileName(fname)
 	if err != nil {
@@ -442,7 +474,7 @@ func forEachInfoFile(
 
 	var indexDigests index.IndexDigests
 	digestBuf := digest.NewBuffer()
-	for i := range matched {
+	readInfoFile := func(i int) bool {
 		t := matched[i].ID.BlockStart
 		volume := matched[i].ID.VolumeIndex
 
@@ -455,9 +487,9 @@ func forEachInfoFile(
 		case persist.FileSetFlushType:
 			switch args.contentType {
 			case persist.FileSetDataContentType:
-				checkpointFilePath = filesetPathFromTime(dir, t, checkpointFileSuffix)
-				digestsFilePath = filesetPathFromTime(dir, t, digestFileSuffix)
-				infoFilePath = filesetPathFromTime(dir, t, infoFileSuffix)
+				checkpointFilePath = dataFileSetPathFromTimeAndIndex(dir, t, volume, checkpointFileSuffix)
+				digestsFilePath = dataFileSetPathFromTimeAndIndex(dir, t, volume, digestFileSuffix)
+				infoFilePath = dataFileSetPathFromTimeAndIndex(dir, t, volume, infoFileSuffix)
 			case persist.FileSetIndexContentType:
 				checkpointFilePath = filesetPathFromTimeAndIndex(dir, t, volume, checkpointFileSuffix)
 				digestsFilePath = filesetPathFromTimeAndIndex(dir, t, volume, digestFileSuffix)
@@ -470,27 +502,27 @@ func forEachInfoFile(
 		}
 		checkpointExists, err := FileExists(checkpointFilePath)
 		if err != nil {
-			continue
+			return false
 		}
 		if !checkpointExists {
-			continue
+			return false
 		}
 		checkpointFd, err := os.Open(checkpointFilePath)
 		if err != nil {
-			continue
+			return false
 		}
 
 		// Read digest of digests from the checkpoint file
 		expectedDigestOfDigest, err := digestBuf.ReadDigestFromFile(checkpointFd)
 		checkpointFd.Close()
 		if err != nil {
-			continue
+			return false
 		}
 		// Read and validate the digest file
 		digestData, err := readAndValidate(digestsFilePath, readerBufferSize,
 			expectedDigestOfDigest)
 		if err != nil {
-			continue
+			return false
 		}
 
 		// Read and validate the info file
@@ -500,7 +532,7 @@ func forEachInfoFile(
 			expectedInfoDigest = digest.ToBuffer(digestData).ReadDigest()
 		case persist.FileSetIndexContentType:
 			if err := indexDigests.Unmarshal(digestData); err != nil {
-				continue
+				return false
 			}
 			expectedInfoDigest = indexDigests.GetInfoDigest()
 		}
@@ -508,18 +540,44 @@ func forEachInfoFile(
 		infoData, err := readAndValidate(infoFilePath, readerBufferSize,
 			expectedInfoDigest)
 		if err != nil {
-			continue
+			return false
 		}
 		if len(matched[i].AbsoluteFilepaths) != 1 {
-			continue
+			return false
 		}
 
 		fn(matched[i].AbsoluteFilepaths[0], matched[i].ID, infoData)
+		return true
+	}
+
+	if args.fileSetType != persist.FileSetFlushType ||
+		args.contentType != persist.FileSetDataContentType {
+		for i := range matched {
+			readInfoFile(i)
+		}
+		return
+	}
+
+	// Only the latest complete volume of a data fileset is live for a block
+	// start, earlier volumes have been superseded (i.e. by a repair) and are
+	// only read if every later volume is incomplete or corrupt.
+	for start := 0; start < len(matched); {
+		end := start + 1
+		for end < len(matched) && matched[end].ID.BlockStart.Equal(matched[start].ID.BlockStart) {
+			end++
+		}
+		for i := end - 1; i >= start; i-- {
+			if readInfoFile(i) {
+				break
+			}
+		}
+		start = end
 	}
 }
 
 // ReadInfoFileResult is the result of reading an info file
 type ReadInfoFileResult struct {
+	ID   FileSetFileIdentifier
 	Info schema.IndexInfo
 	Err  ReadInfoFileResultError
 }
@@ -570,6 +628,7 @@ func ReadInfoFiles(
 			decoder.Reset(msgpack.NewDecoderStream(data))
 			info, err := decoder.DecodeIndexInfo()
 			infoFileResults = append(infoFileResults, ReadInfoFileResult{
+				ID:   id,
 				Info: info,
 				Err: readInfoFileResultError{
 					err:      err,
@@ -618,6 +677,19 @@ func ReadIndexInfoFiles(
 	return infoFileResults
 }
 
+// DataFiles returns a slice of all the names for all the flush fileset files
+// for a given namespace and shard combination.
+func DataFiles(filePathPrefix string, namespace ident.ID, shard uint32) (FileSetFilesSlice, error) {
+	return filesetFiles(filesetFilesSelector{
+		fileSetType:    persist.FileSetFlushType,
+		contentType:    persist.FileSetDataContentType,
+		filePathPrefix: filePathPrefix,
+		namespace:      namespace,
+		shard:          shard,
+		pattern:        filesetFilePattern,
+	})
+}
+
 // SnapshotFiles returns a slice of all the names for all the fileset files
 // for a given namespace and shard combination.
 func SnapshotFiles(filePathPrefix string, namespace ident.ID, shard uint32) (FileSetFilesSlice, error) {
@@ -631,6 +703,18 @@ func SnapshotFiles(filePathPrefix string, namespace ident.ID, shard uint32) (Fil
 	})
 }
 
+// IndexFiles returns a slice of all the names for all the flush index fileset
+// files for a given namespace.
+func IndexFiles(filePathPrefix string, namespace ident.ID) (FileSetFilesSlice, error) {
+	return filesetFiles(filesetFilesSelector{
+		fileSetType:    persist.FileSetFlushType,
+		contentType:    persist.FileSetIndexContentType,
+		filePathPrefix: filePathPrefix,
+		namespace:      namespace,
+		pattern:        filesetFilePattern,
+	})
+}
+
 // IndexSnapshotFiles returns a slice of all the names for all the index fileset files
 // for a given namespace.
 func IndexSnapshotFiles(filePathPrefix string, namespace ident.ID) (FileSetFilesSlice, error) {
@@ -644,40 +728,46 @@ func IndexSnapshotFiles(filePathPrefix string, namespace ident.ID) (FileSetFiles
 }
 
 // FileSetAt returns a FileSetFile for the given namespace/shard/blockStart combination if it exists.
+// If more than one volume exists for the block start then the latest complete volume is returned.
 func FileSetAt(filePathPrefix string, namespace ident.ID, shard uint32, blockStart time.Time) (FileSetFile, bool, error) {
+	matched, err := dataFileSetsAt(filePathPrefix, namespace, shard, blockStart)
+	if err != nil {
+		return FileSetFile{}, false, err
+	}
+
+	for i := len(matched) - 1; i >= 0; i-- {
+		if matched[i].HasCheckpointFile() {
+			return matched[i], true, nil
+		}
+	}
+
+	return FileSetFile{}, false, nil
+}
+
+// dataFileSetsAt returns all volumes of the data fileset for the given
+// namespace/shard/blockStart combination sorted by volume index ascending.
+func dataFileSetsAt(filePathPrefix string, namespace ident.ID, shard uint32, blockStart time.Time) (FileSetFilesSlice, error) {
 	matched, err := filesetFiles(filesetFilesSelector{
 		fileSetType:    persist.FileSetFlushType,
 		contentType:    persist.FileSetDataContentType,
 		filePathPrefix: filePathPrefix,
 		namespace:      namespace,
 		shard:          shard,
-		pattern:        filesetFileForTime(blockStart, anyLowerCaseCharsPattern),
+		pattern:        filesetFileForTime(blockStart, anyLowerCaseCharsNumbersPattern),
 	})
 	if err != nil {
-		return FileSetFile{}, false, err
+		return nil, err
 	}
 
-	matched.sortByTimeAscending()
-	for i, fileset := range matched {
+	filesets := make(FileSetFilesSlice, 0, len(matched))
+	matched.sortByTimeAndVolumeIndexAscending()
+	for _, fileset := range matched {
 		if fileset.ID.BlockStart.Equal(blockStart) {
-			nextIdx := i + 1
-			if nextIdx < len(matched) && matched[nextIdx].ID.BlockStart.Equal(blockStart) {
-				// Should never happen
-				return FileSetFile{}, false, fmt.Errorf(
-					"found multiple fileset files for blockStart: %d",
-					blockStart.Unix(),
-				)
-			}
-
-			if !fileset.HasCheckpointFile() {
-				continue
-			}
-
-			return fileset, true, nil
+			filesets = append(filesets, fileset)
 		}
 	}
 
-	return FileSetFile{}, false, nil
+	return filesets, nil
 }
 
 // IndexFileSetsAt returns all FileSetFile(s) for the given namespace/blockStart combination.
@@ -710,15 +800,59 @@ func IndexFileSetsAt(filePathPrefix string, namespace ident.ID, blockStart time.
 
 // DeleteFileSetAt deletes a FileSetFile for a given namespace/shard/blockStart combination if it exists.
 func DeleteFileSetAt(filePathPrefix string, namespace ident.ID, shard uint32, t time.Time) error {
-	fileset, ok, err := FileSetAt(filePathPrefix, namespace, shard, t)
+	filesets, err := dataFileSetsAt(filePathPrefix, namespace, shard, t)
 	if err != nil {
 		return err
 	}
-	if !ok {
+
+	var complete bool
+	for _, fileset := range filesets {
+		if fileset.HasCheckpointFile() {
+			complete = true
+			break
+		}
+	}
+	if !complete {
 		return fmt.Errorf("fileset for blockStart: %d does not exist", t.Unix())
 	}
 
-	return DeleteFiles(fileset.AbsoluteFilepaths)
+	return DeleteFiles(filesets.Filepaths())
+}
+
+// DeleteSupersededFileSetsAt deletes all volumes of the data fileset for the given
+// namespace/shard/blockStart combination that precede the given volume index,
+// including the data files of the volumes moved to the fileset backend if one
+// is given.
+func DeleteSupersededFileSetsAt(
+	filePathPrefix string,
+	backend FileSetBackend,
+	namespace ident.ID,
+	shard uint32,
+	t time.Time,
+	volumeIndex int,
+) error {
+	filesets, err := dataFileSetsAt(filePathPrefix, namespace, shard, t)
+	if err != nil {
+		return err
+	}
+
+	var superseded FileSetFilesSlice
+	for _, fileset := range filesets {
+		if fileset.ID.VolumeIndex < volumeIndex {
+			superseded = append(superseded, fileset)
+		}
+	}
+
+	if backend != nil {
+		// Delete the tiered data files first so that the volumes remain
+		// discoverable on local disk to retry the deletion if it fails.
+		if err := DeleteTieredDataFiles(filePathPrefix, backend,
+			superseded.Filepaths()); err != nil {
+			return err
+		}
+	}
+
+	return DeleteFiles(superseded.Filepaths())
 }
 
 // DataFileSetsBefore returns all the flush data fileset files whose timestamps are earlier than a given time.
@@ -836,7 +970,7 @@ func filesetFiles(args filesetFilesSelector) (FileSetFilesSlice, error) {
 		case persist.FileSetDataContentType:
 			dir := ShardDataDirPath(args.filePathPrefix, args.namespace, args.shard)
 			byTimeAsc, err = findFiles(dir, args.pattern, func(files []string) sort.Interface {
-				return byTimeAscending(files)
+				return dataFileSetFilesByTimeAndVolumeIndexAscending(files)
 			})
 		case persist.FileSetIndexContentType:
 			dir := NamespaceIndexDataDirPath(args.filePathPrefix, args.namespace)
@@ -886,7 +1020,7 @@ func filesetFiles(args filesetFilesSelector) (FileSetFilesSlice, error) {
 		case persist.FileSetFlushType:
 			switch args.contentType {
 			case persist.FileSetDataContentType:
-				currentFileBlockStart, err = TimeFromFileName(file)
+				currentFileBlockStart, volumeIndex, err = TimeAndVolumeIndexFromDataFileSetFilename(file)
 			case persist.FileSetIndexContentType:
 				currentFileBlockStart, volumeIndex, err = TimeAndVolumeIndexFromFileSetFilename(file)
 			default:
@@ -1033,9 +1167,8 @@ func CommitLogsDirPath(prefix string) string {
 
 // DataFileSetExistsAt determines whether data fileset files exist for the given namespace, shard, and block start.
 func DataFileSetExistsAt(filePathPrefix string, namespace ident.ID, shard uint32, blockStart time.Time) (bool, error) {
-	shardDir := ShardDataDirPath(filePathPrefix, namespace, shard)
-	checkpointPath := filesetPathFromTime(shardDir, blockStart, checkpointFileSuffix)
-	return FileExists(checkpointPath)
+	_, ok, err := FileSetAt(filePathPrefix, namespace, shard, blockStart)
+	return ok, err
 }
 
 // SnapshotFileSetExistsAt determines whether snapshot fileset files exist for the given namespace, shard, and block start time.
@@ -1085,6 +1218,21 @@ func NextSnapshotFileSetVolumeIndex(filePathPrefix string, namespace ident.ID, s
 	return latestFile.ID.VolumeIndex + 1, nil
 }
 
+// NextDataFileSetVolumeIndex returns the next data file set index for a given
+// namespace/shard/blockStart combination.
+func NextDataFileSetVolumeIndex(filePathPrefix string, namespace ident.ID, shard uint32, blockStart time.Time) (int, error) {
+	filesets, err := dataFileSetsAt(filePathPrefix, namespace, shard, blockStart)
+	if err != nil {
+		return -1, err
+	}
+
+	if len(filesets) == 0 {
+		return 0, nil
+	}
+
+	return filesets[len(filesets)-1].ID.VolumeIndex + 1, nil
+}
+
 // NextIndexFileSetVolumeIndex returns the next index file set index for a given
 // namespace/blockStart combination.
 func NextIndexFileSetVolumeIndex(filePathPrefix string, namespace ident.ID, blockStart time.Time) (int, error) {
@@ -1157,6 +1305,16 @@ func filesetPathFromTimeAndIndex(prefix string, t time.Time, index int, suffix s
 	return path.Join(prefix, filesetFileForTime(t, fmt.Sprintf("%d%s%s", index, separator, suffix)))
 }
 
+// dataFileSetPathFromTimeAndIndex returns the path of a data fileset file, the
+// first volume keeps the file name used before data filesets had volumes.
+func dataFileSetPathFromTimeAndIndex(prefix string, t time.Time, index int, suffix string) string {
+	if index == 0 {
+		return filesetPathFromTime(prefix, t, suffix)
+	}
+
+	return filesetPathFromTimeAndIndex(prefix, t, index, suffix)
+}
+
 func filesetIndexSegmentFileSuffixFromTime(
 	t time.Time,
 	segmentIndex int,
@@ -281,6 +281,25 @@ func TestTimeAndVolumeIndexFromFileSetFilename(t *testing.T) {
 	require.Equal(t, filesetPathFromTimeAndIndex("foo/bar", exp.t, exp.i, "data"), validName)
 }
 
+func TestTimeAndVolumeIndexFromDataFileSetFilename(t *testing.T) {
+	_, _, err := TimeAndVolumeIndexFromDataFileSetFilename("foo/bar")
+	require.Error(t, err)
+
+	ts, i, err := TimeAndVolumeIndexFromDataFileSetFilename("foo/bar/fileset-1-data.db")
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(0, 1), ts)
+	require.Equal(t, 0, i)
+
+	validName := "foo/bar/fileset-21234567890-2-data.db"
+	ts, i, err = TimeAndVolumeIndexFromDataFileSetFilename(validName)
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(0, 21234567890), ts)
+	require.Equal(t, 2, i)
+	require.Equal(t, dataFileSetPathFromTimeAndIndex("foo/bar", ts, i, "data"), validName)
+	require.Equal(t, "foo/bar/fileset-1-data.db",
+		dataFileSetPathFromTimeAndIndex("foo/bar", time.Unix(0, 1), 0, "data"))
+}
+
 func TestFileExists(t *testing.T) {
 	dir := createTempDir(t)
 	defer os.RemoveAll(dir)
@@ -365,6 +384,52 @@ func TestFileSetAt(t *testing.T) {
 	}
 }
 
+func TestFileSetAtLatestVolume(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		shard      = uint32(0)
+		blockStart = time.Unix(0, 10)
+		shardDir   = ShardDataDirPath(dir, testNs1ID, shard)
+	)
+	require.NoError(t, os.MkdirAll(shardDir, defaultNewDirectoryMode))
+
+	next, err := NextDataFileSetVolumeIndex(dir, testNs1ID, shard, blockStart)
+	require.NoError(t, err)
+	require.Equal(t, 0, next)
+
+	// Volume 0 and 1 are complete, volume 2 is missing its checkpoint file
+	for volume := 0; volume < 3; volume++ {
+		suffixes := []string{infoFileSuffix, dataFileSuffix}
+		if volume < 2 {
+			suffixes = append(suffixes, checkpointFileSuffix)
+		}
+		for _, suffix := range suffixes {
+			path := dataFileSetPathFromTimeAndIndex(shardDir, blockStart, volume, suffix)
+			createFile(t, path, []byte{0})
+		}
+	}
+
+	res, ok, err := FileSetAt(dir, testNs1ID, shard, blockStart)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 1, res.ID.VolumeIndex)
+	require.True(t, blockStart.Equal(res.ID.BlockStart))
+
+	next, err = NextDataFileSetVolumeIndex(dir, testNs1ID, shard, blockStart)
+	require.NoError(t, err)
+	require.Equal(t, 3, next)
+
+	require.NoError(t, DeleteSupersededFileSetsAt(dir, nil, testNs1ID, shard, blockStart, 1))
+	res, ok, err = FileSetAt(dir, testNs1ID, shard, blockStart)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 1, res.ID.VolumeIndex)
+	_, err = os.Stat(dataFileSetPathFromTimeAndIndex(shardDir, blockStart, 0, checkpointFileSuffix))
+	require.True(t, os.IsNotExist(err))
+}
+
 func TestFileSetAtIgnoresWithoutCheckpoint(t *testing.T) {
 	shard := uint32(0)
 	numIters := 20
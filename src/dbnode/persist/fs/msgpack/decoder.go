@@ -230,6 +230,16 @@ func (dec *Decoder) decodeIndexInfo() schema.IndexInfo {
 		opts.override = true
 		opts.numExpectedMinFields = 6
 		opts.numExpectedCurrFields = 6
+	} else if dec.legacy.decodeLegacyV2IndexInfo {
+		// v2 had 8 fields
+		opts.override = true
+		opts.numExpectedMinFields = 6
+		opts.numExpectedCurrFields = 8
+	} else if dec.legacy.decodeLegacyV3IndexInfo {
+		// v3 had 9 fields
+		opts.override = true
+		opts.numExpectedMinFields = 6
+		opts.numExpectedCurrFields = 9
 	}
 	numFieldsToSkip, actual, ok := dec.checkNumFieldsFor(indexInfoType, opts)
 	if !ok {
@@ -252,6 +262,21 @@ func (dec *Decoder) decodeIndexInfo() schema.IndexInfo {
 	indexInfo.SnapshotTime = dec.decodeVarint()
 	indexInfo.FileType = persist.FileSetType(dec.decodeVarint())
 
+	if dec.legacy.decodeLegacyV2IndexInfo || actual < 9 {
+		dec.skip(numFieldsToSkip)
+		return indexInfo
+	}
+
+	encodingScheme, _, _ := dec.decodeBytes()
+	indexInfo.EncodingScheme = string(encodingScheme)
+
+	if dec.legacy.decodeLegacyV3IndexInfo || actual < 10 {
+		dec.skip(numFieldsToSkip)
+		return indexInfo
+	}
+
+	indexInfo.Compression = persist.FileSetCompression(dec.decodeVarint())
+
 	dec.skip(numFieldsToSkip)
 	return indexInfo
 }
@@ -292,6 +317,11 @@ func (dec *Decoder) decodeIndexEntry() schema.IndexEntry {
 		opts.override = true
 		opts.numExpectedMinFields = 5
 		opts.numExpectedCurrFields = 5
+	} else if dec.legacy.decodeLegacyV2IndexEntry {
+		// v2 had 6 fields
+		opts.override = true
+		opts.numExpectedMinFields = 5
+		opts.numExpectedCurrFields = 6
 	}
 	numFieldsToSkip, actual, ok := dec.checkNumFieldsFor(indexEntryType, opts)
 	if !ok {
@@ -312,6 +342,13 @@ func (dec *Decoder) decodeIndexEntry() schema.IndexEntry {
 
 	indexEntry.EncodedTags, _, _ = dec.decodeBytes()
 
+	if dec.legacy.decodeLegacyV2IndexEntry || actual < 7 {
+		dec.skip(numFieldsToSkip)
+		return indexEntry
+	}
+
+	indexEntry.PageOffset = dec.decodeVarint()
+
 	dec.skip(numFieldsToSkip)
 	return indexEntry
 }
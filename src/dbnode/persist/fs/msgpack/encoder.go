@@ -55,16 +55,28 @@ type Encoder struct {
 
 type legacyEncodingOptions struct {
 	encodeLegacyV1IndexInfo  bool
+	encodeLegacyV2IndexInfo  bool
+	encodeLegacyV3IndexInfo  bool
 	encodeLegacyV1IndexEntry bool
+	encodeLegacyV2IndexEntry bool
 	decodeLegacyV1IndexInfo  bool
+	decodeLegacyV2IndexInfo  bool
+	decodeLegacyV3IndexInfo  bool
 	decodeLegacyV1IndexEntry bool
+	decodeLegacyV2IndexEntry bool
 }
 
 var defaultlegacyEncodingOptions = legacyEncodingOptions{
 	encodeLegacyV1IndexInfo:  false,
+	encodeLegacyV2IndexInfo:  false,
+	encodeLegacyV3IndexInfo:  false,
 	encodeLegacyV1IndexEntry: false,
+	encodeLegacyV2IndexEntry: false,
 	decodeLegacyV1IndexInfo:  false,
+	decodeLegacyV2IndexInfo:  false,
+	decodeLegacyV3IndexInfo:  false,
 	decodeLegacyV1IndexEntry: false,
+	decodeLegacyV2IndexEntry: false,
 }
 
 // NewEncoder creates a new encoder
@@ -110,8 +122,12 @@ func (enc *Encoder) EncodeIndexInfo(info schema.IndexInfo) error {
 	enc.encodeRootObject(indexInfoVersion, indexInfoType)
 	if enc.legacy.encodeLegacyV1IndexInfo {
 		enc.encodeIndexInfoV1(info)
-	} else {
+	} else if enc.legacy.encodeLegacyV2IndexInfo {
 		enc.encodeIndexInfoV2(info)
+	} else if enc.legacy.encodeLegacyV3IndexInfo {
+		enc.encodeIndexInfoV3(info)
+	} else {
+		enc.encodeIndexInfoV4(info)
 	}
 	return enc.err
 }
@@ -124,8 +140,10 @@ func (enc *Encoder) EncodeIndexEntry(entry schema.IndexEntry) error {
 	enc.encodeRootObject(indexEntryVersion, indexEntryType)
 	if enc.legacy.encodeLegacyV1IndexEntry {
 		enc.encodeIndexEntryV1(entry)
-	} else {
+	} else if enc.legacy.encodeLegacyV2IndexEntry {
 		enc.encodeIndexEntryV2(entry)
+	} else {
+		enc.encodeIndexEntryV3(entry)
 	}
 	return enc.err
 }
@@ -183,7 +201,38 @@ func (enc *Encoder) encodeIndexInfoV1(info schema.IndexInfo) {
 	enc.encodeIndexBloomFilterInfo(info.BloomFilter)
 }
 
+// We only keep this method around for the sake of testing
+// backwards-compatbility
 func (enc *Encoder) encodeIndexInfoV2(info schema.IndexInfo) {
+	// Manually encode num fields for testing purposes
+	enc.encodeArrayLenFn(8) // v2 had 8 fields
+	enc.encodeVarintFn(info.BlockStart)
+	enc.encodeVarintFn(info.BlockSize)
+	enc.encodeVarintFn(info.Entries)
+	enc.encodeVarintFn(info.MajorVersion)
+	enc.encodeIndexSummariesInfo(info.Summaries)
+	enc.encodeIndexBloomFilterInfo(info.BloomFilter)
+	enc.encodeVarintFn(info.SnapshotTime)
+	enc.encodeVarintFn(int64(info.FileType))
+}
+
+// We only keep this method around for the sake of testing
+// backwards-compatbility
+func (enc *Encoder) encodeIndexInfoV3(info schema.IndexInfo) {
+	// Manually encode num fields for testing purposes
+	enc.encodeArrayLenFn(9) // v3 had 9 fields
+	enc.encodeVarintFn(info.BlockStart)
+	enc.encodeVarintFn(info.BlockSize)
+	enc.encodeVarintFn(info.Entries)
+	enc.encodeVarintFn(info.MajorVersion)
+	enc.encodeIndexSummariesInfo(info.Summaries)
+	enc.encodeIndexBloomFilterInfo(info.BloomFilter)
+	enc.encodeVarintFn(info.SnapshotTime)
+	enc.encodeVarintFn(int64(info.FileType))
+	enc.encodeBytesFn([]byte(info.EncodingScheme))
+}
+
+func (enc *Encoder) encodeIndexInfoV4(info schema.IndexInfo) {
 	enc.encodeNumObjectFieldsForFn(indexInfoType)
 	enc.encodeVarintFn(info.BlockStart)
 	enc.encodeVarintFn(info.BlockSize)
@@ -193,6 +242,8 @@ func (enc *Encoder) encodeIndexInfoV2(info schema.IndexInfo) {
 	enc.encodeIndexBloomFilterInfo(info.BloomFilter)
 	enc.encodeVarintFn(info.SnapshotTime)
 	enc.encodeVarintFn(int64(info.FileType))
+	enc.encodeBytesFn([]byte(info.EncodingScheme))
+	enc.encodeVarintFn(int64(info.Compression))
 }
 
 func (enc *Encoder) encodeIndexSummariesInfo(info schema.IndexSummariesInfo) {
@@ -218,7 +269,20 @@ func (enc *Encoder) encodeIndexEntryV1(entry schema.IndexEntry) {
 	enc.encodeVarintFn(entry.Checksum)
 }
 
+// We only keep this method around for the sake of testing
+// backwards-compatbility
 func (enc *Encoder) encodeIndexEntryV2(entry schema.IndexEntry) {
+	// Manually encode num fields for testing purposes
+	enc.encodeArrayLenFn(6) // v2 had 6 fields
+	enc.encodeVarintFn(entry.Index)
+	enc.encodeBytesFn(entry.ID)
+	enc.encodeVarintFn(entry.Size)
+	enc.encodeVarintFn(entry.Offset)
+	enc.encodeVarintFn(entry.Checksum)
+	enc.encodeBytesFn(entry.EncodedTags)
+}
+
+func (enc *Encoder) encodeIndexEntryV3(entry schema.IndexEntry) {
 	enc.encodeNumObjectFieldsForFn(indexEntryType)
 	enc.encodeVarintFn(entry.Index)
 	enc.encodeBytesFn(entry.ID)
@@ -226,6 +290,7 @@ func (enc *Encoder) encodeIndexEntryV2(entry schema.IndexEntry) {
 	enc.encodeVarintFn(entry.Offset)
 	enc.encodeVarintFn(entry.Checksum)
 	enc.encodeBytesFn(entry.EncodedTags)
+	enc.encodeVarintFn(entry.PageOffset)
 }
 
 func (enc *Encoder) encodeIndexSummary(summary schema.IndexSummary) {
@@ -80,6 +80,8 @@ func testExpectedResultForIndexInfo(t *testing.T, indexInfo schema.IndexInfo) []
 		indexInfo.BloomFilter.NumHashesK,
 		indexInfo.SnapshotTime,
 		int64(indexInfo.FileType),
+		[]byte(indexInfo.EncodingScheme),
+		int64(indexInfo.Compression),
 	}
 }
 
@@ -97,6 +99,7 @@ func testExpectedResultForIndexEntry(t *testing.T, indexEntry schema.IndexEntry)
 		indexEntry.Offset,
 		indexEntry.Checksum,
 		indexEntry.EncodedTags,
+		indexEntry.PageOffset,
 	}
 }
 
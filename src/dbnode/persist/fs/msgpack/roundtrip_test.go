@@ -43,8 +43,10 @@ var (
 			NumElementsM: 2075674,
 			NumHashesK:   7,
 		},
-		SnapshotTime: time.Now().UnixNano(),
-		FileType:     persist.FileSetSnapshotType,
+		SnapshotTime:   time.Now().UnixNano(),
+		FileType:       persist.FileSetSnapshotType,
+		EncodingScheme: "vdelta",
+		Compression:    persist.FileSetSnappyCompression,
 	}
 
 	testIndexEntry = schema.IndexEntry{
@@ -54,6 +56,7 @@ var (
 		Offset:      2390423,
 		Checksum:    134245634534,
 		EncodedTags: []byte("testEncodedTags"),
+		PageOffset:  1234,
 	}
 
 	testIndexSummary = schema.IndexSummary{
@@ -112,11 +115,17 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV1(t *testing.T) {
 	// the old file format
 	currSnapshotTime := testIndexInfo.SnapshotTime
 	currFileType := testIndexInfo.FileType
+	currEncodingScheme := testIndexInfo.EncodingScheme
+	currCompression := testIndexInfo.Compression
 	testIndexInfo.SnapshotTime = 0
 	testIndexInfo.FileType = 0
+	testIndexInfo.EncodingScheme = ""
+	testIndexInfo.Compression = 0
 	defer func() {
 		testIndexInfo.SnapshotTime = currSnapshotTime
 		testIndexInfo.FileType = currFileType
+		testIndexInfo.EncodingScheme = currEncodingScheme
+		testIndexInfo.Compression = currCompression
 	}()
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -139,6 +148,8 @@ func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 	// because the old decoder won't read the new fields
 	currSnapshotTime := testIndexInfo.SnapshotTime
 	currFileType := testIndexInfo.FileType
+	currEncodingScheme := testIndexInfo.EncodingScheme
+	currCompression := testIndexInfo.Compression
 
 	enc.EncodeIndexInfo(testIndexInfo)
 
@@ -146,9 +157,119 @@ func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 	// encoded the data
 	testIndexInfo.SnapshotTime = 0
 	testIndexInfo.FileType = 0
+	testIndexInfo.EncodingScheme = ""
+	testIndexInfo.Compression = 0
 	defer func() {
 		testIndexInfo.SnapshotTime = currSnapshotTime
 		testIndexInfo.FileType = currFileType
+		testIndexInfo.EncodingScheme = currEncodingScheme
+		testIndexInfo.Compression = currCompression
+	}()
+
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexInfo()
+	require.NoError(t, err)
+	require.Equal(t, testIndexInfo, res)
+}
+
+// Make sure the new decoding code can handle the V2 file format
+func TestIndexInfoRoundTripBackwardsCompatibilityV2(t *testing.T) {
+	var (
+		opts = legacyEncodingOptions{encodeLegacyV2IndexInfo: true}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	// Set the default value on the field that did not exist in V2
+	// and then restore it at the end of the test - This is required
+	// because the new decoder won't try and read the new field from
+	// the old file format
+	currEncodingScheme := testIndexInfo.EncodingScheme
+	currCompression := testIndexInfo.Compression
+	testIndexInfo.EncodingScheme = ""
+	testIndexInfo.Compression = 0
+	defer func() {
+		testIndexInfo.EncodingScheme = currEncodingScheme
+		testIndexInfo.Compression = currCompression
+	}()
+
+	enc.EncodeIndexInfo(testIndexInfo)
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexInfo()
+	require.NoError(t, err)
+	require.Equal(t, testIndexInfo, res)
+}
+
+// Make sure the V2 decoding code can handle the new file format
+func TestIndexInfoRoundTripForwardsCompatibilityV3(t *testing.T) {
+	var (
+		opts = legacyEncodingOptions{decodeLegacyV2IndexInfo: true}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	currEncodingScheme := testIndexInfo.EncodingScheme
+	currCompression := testIndexInfo.Compression
+
+	enc.EncodeIndexInfo(testIndexInfo)
+
+	// Make sure to zero it before we compare, but after we have
+	// encoded the data
+	testIndexInfo.EncodingScheme = ""
+	testIndexInfo.Compression = 0
+	defer func() {
+		testIndexInfo.EncodingScheme = currEncodingScheme
+		testIndexInfo.Compression = currCompression
+	}()
+
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexInfo()
+	require.NoError(t, err)
+	require.Equal(t, testIndexInfo, res)
+}
+
+// Make sure the new decoding code can handle the V3 file format
+func TestIndexInfoRoundTripBackwardsCompatibilityV3(t *testing.T) {
+	var (
+		opts = legacyEncodingOptions{encodeLegacyV3IndexInfo: true}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	// Set the default value on the field that did not exist in V3
+	// and then restore it at the end of the test - This is required
+	// because the new decoder won't try and read the new field from
+	// the old file format
+	currCompression := testIndexInfo.Compression
+	testIndexInfo.Compression = 0
+	defer func() {
+		testIndexInfo.Compression = currCompression
+	}()
+
+	enc.EncodeIndexInfo(testIndexInfo)
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexInfo()
+	require.NoError(t, err)
+	require.Equal(t, testIndexInfo, res)
+}
+
+// Make sure the V3 decoding code can handle the new file format
+func TestIndexInfoRoundTripForwardsCompatibilityV4(t *testing.T) {
+	var (
+		opts = legacyEncodingOptions{decodeLegacyV3IndexInfo: true}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	currCompression := testIndexInfo.Compression
+
+	enc.EncodeIndexInfo(testIndexInfo)
+
+	// Make sure to zero it before we compare, but after we have
+	// encoded the data
+	testIndexInfo.Compression = 0
+	defer func() {
+		testIndexInfo.Compression = currCompression
 	}()
 
 	dec.Reset(NewDecoderStream(enc.Bytes()))
@@ -182,9 +303,12 @@ func TestIndexEntryRoundTripBackwardsCompatibilityV1(t *testing.T) {
 	// because the new decoder won't try and read the new fields from
 	// the old file format
 	currEncodedTags := testIndexEntry.EncodedTags
+	currPageOffset := testIndexEntry.PageOffset
 	testIndexEntry.EncodedTags = nil
+	testIndexEntry.PageOffset = 0
 	defer func() {
 		testIndexEntry.EncodedTags = currEncodedTags
+		testIndexEntry.PageOffset = currPageOffset
 	}()
 
 	enc.EncodeIndexEntry(testIndexEntry)
@@ -206,14 +330,67 @@ func TestIndexEntryRoundTripForwardsCompatibilityV2(t *testing.T) {
 	// and then restore them at the end of the test - This is required
 	// because the old decoder won't read the new fields
 	currEncodedTags := testIndexEntry.EncodedTags
+	currPageOffset := testIndexEntry.PageOffset
 
 	enc.EncodeIndexEntry(testIndexEntry)
 
 	// Make sure to zero them before we compare, but after we have
 	// encoded the data
 	testIndexEntry.EncodedTags = nil
+	testIndexEntry.PageOffset = 0
 	defer func() {
 		testIndexEntry.EncodedTags = currEncodedTags
+		testIndexEntry.PageOffset = currPageOffset
+	}()
+
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexEntry()
+	require.NoError(t, err)
+	require.Equal(t, testIndexEntry, res)
+}
+
+// Make sure the new decoding code can handle the V2 file format
+func TestIndexEntryRoundTripBackwardsCompatibilityV2(t *testing.T) {
+	var (
+		opts = legacyEncodingOptions{encodeLegacyV2IndexEntry: true}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	// Set the default value on the field that did not exist in V2
+	// and then restore it at the end of the test - This is required
+	// because the new decoder won't try and read the new field from
+	// the old file format
+	currPageOffset := testIndexEntry.PageOffset
+	testIndexEntry.PageOffset = 0
+	defer func() {
+		testIndexEntry.PageOffset = currPageOffset
+	}()
+
+	enc.EncodeIndexEntry(testIndexEntry)
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexEntry()
+	require.NoError(t, err)
+	require.Equal(t, testIndexEntry, res)
+}
+
+// Make sure the V2 decoding code can handle the new file format
+func TestIndexEntryRoundTripForwardsCompatibilityV3(t *testing.T) {
+	var (
+		opts = legacyEncodingOptions{decodeLegacyV2IndexEntry: true}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	currPageOffset := testIndexEntry.PageOffset
+
+	enc.EncodeIndexEntry(testIndexEntry)
+
+	// Make sure to zero it before we compare, but after we have
+	// encoded the data
+	testIndexEntry.PageOffset = 0
+	defer func() {
+		testIndexEntry.PageOffset = currPageOffset
 	}()
 
 	dec.Reset(NewDecoderStream(enc.Bytes()))
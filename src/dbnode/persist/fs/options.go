@@ -24,8 +24,10 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
@@ -41,6 +43,15 @@ const (
 	// defaultIndexBloomFilterFalsePositivePercent is the false positive percent to use to calculate size for when writing bloom filters
 	defaultIndexBloomFilterFalsePositivePercent = 0.02
 
+	// defaultCompressionPageSize is the default minimum uncompressed size of the pages of compressed filesets
+	defaultCompressionPageSize = 16384
+
+	// defaultFileSetBackendMinBlockAge is the default age after which the data files of flushed blocks are moved to the fileset backend
+	defaultFileSetBackendMinBlockAge = 24 * time.Hour
+
+	// defaultFileSetBackendCacheTTL is the default duration that seekers of blocks fetched from the fileset backend are kept open for after their last use
+	defaultFileSetBackendCacheTTL = 10 * time.Minute
+
 	// defaultWriterBufferSize is the default buffer size for writing TSDB files
 	defaultWriterBufferSize = 65536
 
@@ -79,6 +90,11 @@ type options struct {
 	newDirectoryMode                     os.FileMode
 	indexSummariesPercent                float64
 	indexBloomFilterFalsePositivePercent float64
+	compression                          persist.FileSetCompression
+	compressionPageSize                  int
+	fileSetBackend                       FileSetBackend
+	fileSetBackendMinBlockAge            time.Duration
+	fileSetBackendCacheTTL               time.Duration
 	writerBufferSize                     int
 	dataReaderBufferSize                 int
 	infoReaderBufferSize                 int
@@ -110,6 +126,10 @@ func NewOptions() Options {
 		newDirectoryMode:                     defaultNewDirectoryMode,
 		indexSummariesPercent:                defaultIndexSummariesPercent,
 		indexBloomFilterFalsePositivePercent: defaultIndexBloomFilterFalsePositivePercent,
+		compression:                          persist.FileSetNoCompression,
+		compressionPageSize:                  defaultCompressionPageSize,
+		fileSetBackendMinBlockAge:            defaultFileSetBackendMinBlockAge,
+		fileSetBackendCacheTTL:               defaultFileSetBackendCacheTTL,
 		writerBufferSize:                     defaultWriterBufferSize,
 		dataReaderBufferSize:                 defaultDataReaderBufferSize,
 		infoReaderBufferSize:                 defaultInfoReaderBufferSize,
@@ -133,6 +153,26 @@ func (o *options) Validate() error {
 			"invalid index bloom filter false positive percent, must be >= 0 and <= 1: instead %f",
 			o.indexBloomFilterFalsePositivePercent)
 	}
+	switch o.compression {
+	case persist.FileSetNoCompression, persist.FileSetSnappyCompression:
+	default:
+		return fmt.Errorf("invalid compression: %v", o.compression)
+	}
+	if o.compressionPageSize <= 0 {
+		return fmt.Errorf(
+			"invalid compression page size, must be > 0: instead %d",
+			o.compressionPageSize)
+	}
+	if o.fileSetBackendMinBlockAge <= 0 {
+		return fmt.Errorf(
+			"invalid fileset backend min block age, must be > 0: instead %v",
+			o.fileSetBackendMinBlockAge)
+	}
+	if o.fileSetBackendCacheTTL <= 0 {
+		return fmt.Errorf(
+			"invalid fileset backend cache TTL, must be > 0: instead %v",
+			o.fileSetBackendCacheTTL)
+	}
 	if o.tagEncoderPool == nil {
 		return errTagEncoderPoolNotSet
 	}
@@ -232,6 +272,56 @@ func (o *options) IndexBloomFilterFalsePositivePercent() float64 {
 	return o.indexBloomFilterFalsePositivePercent
 }
 
+func (o *options) SetCompression(value persist.FileSetCompression) Options {
+	opts := *o
+	opts.compression = value
+	return &opts
+}
+
+func (o *options) Compression() persist.FileSetCompression {
+	return o.compression
+}
+
+func (o *options) SetCompressionPageSize(value int) Options {
+	opts := *o
+	opts.compressionPageSize = value
+	return &opts
+}
+
+func (o *options) CompressionPageSize() int {
+	return o.compressionPageSize
+}
+
+func (o *options) SetFileSetBackend(value FileSetBackend) Options {
+	opts := *o
+	opts.fileSetBackend = value
+	return &opts
+}
+
+func (o *options) FileSetBackend() FileSetBackend {
+	return o.fileSetBackend
+}
+
+func (o *options) SetFileSetBackendMinBlockAge(value time.Duration) Options {
+	opts := *o
+	opts.fileSetBackendMinBlockAge = value
+	return &opts
+}
+
+func (o *options) FileSetBackendMinBlockAge() time.Duration {
+	return o.fileSetBackendMinBlockAge
+}
+
+func (o *options) SetFileSetBackendCacheTTL(value time.Duration) Options {
+	opts := *o
+	opts.fileSetBackendCacheTTL = value
+	return &opts
+}
+
+func (o *options) FileSetBackendCacheTTL() time.Duration {
+	return o.fileSetBackendCacheTTL
+}
+
 func (o *options) SetWriterBufferSize(value int) Options {
 	opts := *o
 	opts.writerBufferSize = value
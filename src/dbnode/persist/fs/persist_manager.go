@@ -386,13 +386,22 @@ func (pm *persistManager) PrepareData(opts persist.DataPrepareOptions) (persist.
 	}
 
 	var volumeIndex int
-	if opts.FileSetType == persist.FileSetSnapshotType {
+	switch {
+	case opts.FileSetType == persist.FileSetSnapshotType:
 		// Need to work out the volume index for the next snapshot
 		volumeIndex, err = NextSnapshotFileSetVolumeIndex(pm.opts.FilePathPrefix(),
 			nsMetadata.ID(), shard, blockStart)
 		if err != nil {
 			return prepared, err
 		}
+	case opts.ColdFlush:
+		// Cold flushes write a new volume that supersedes the existing ones
+		volumeIndex, err = NextDataFileSetVolumeIndex(pm.opts.FilePathPrefix(),
+			nsMetadata.ID(), shard, blockStart)
+		if err != nil {
+			return prepared, err
+		}
+		exists = false
 	}
 
 	if exists && !opts.DeleteIfExists {
@@ -434,6 +443,7 @@ func (pm *persistManager) PrepareData(opts persist.DataPrepareOptions) (persist.
 			BlockStart:  blockStart,
 			VolumeIndex: volumeIndex,
 		},
+		EncodingScheme: nsMetadata.Options().EncodingScheme(),
 	}
 	if err := pm.dataPM.writer.Open(dataWriterOpts); err != nil {
 		return prepared, err
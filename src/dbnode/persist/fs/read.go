@@ -48,6 +48,9 @@ var (
 
 	// errReadNotExpectedSize returned when the size of the next read does not match size specified by the index
 	errReadNotExpectedSize = errors.New("next read not expected size")
+
+	// errIndexDigestMismatch returned when the digest of a compressed index file does not match the expected digest
+	errIndexDigestMismatch = errors.New("could not validate index file: digest mismatch")
 )
 
 type reader struct {
@@ -73,10 +76,23 @@ type reader struct {
 	dataMmap   []byte
 	dataReader digest.ReaderWithDigest
 
+	// tieredDataFilepath is the path of the data file if it is not on local
+	// disk and needs to be fetched from the fileset backend
+	tieredDataFilepath string
+
+	// Pages of compressed filesets
+	indexPages         []byte
+	dataPage           []byte
+	dataPageCompressed []byte
+	dataPageOffset     int64
+	nextDataPageOffset int64
+
 	bloomFilterFd *os.File
 
 	entries         int
 	bloomFilterInfo schema.IndexBloomFilterInfo
+	encodingScheme  string
+	compression     persist.FileSetCompression
 	entriesRead     int
 	metadataRead    int
 	decoder         *msgpack.Decoder
@@ -126,11 +142,11 @@ func NewReader(
 
 func (r *reader) Open(opts DataReaderOpenOptions) error {
 	var (
-		namespace     = opts.Identifier.Namespace
-		shard         = opts.Identifier.Shard
-		blockStart    = opts.Identifier.BlockStart
-		snapshotIndex = opts.Identifier.VolumeIndex
-		err           error
+		namespace   = opts.Identifier.Namespace
+		shard       = opts.Identifier.Shard
+		blockStart  = opts.Identifier.BlockStart
+		volumeIndex = opts.Identifier.VolumeIndex
+		err         error
 	)
 
 	var (
@@ -145,20 +161,20 @@ func (r *reader) Open(opts DataReaderOpenOptions) error {
 	switch opts.FileSetType {
 	case persist.FileSetSnapshotType:
 		shardDir = ShardSnapshotsDirPath(r.filePathPrefix, namespace, shard)
-		checkpointFilepath = filesetPathFromTimeAndIndex(shardDir, blockStart, snapshotIndex, checkpointFileSuffix)
-		infoFilepath = filesetPathFromTimeAndIndex(shardDir, blockStart, snapshotIndex, infoFileSuffix)
-		digestFilepath = filesetPathFromTimeAndIndex(shardDir, blockStart, snapshotIndex, digestFileSuffix)
-		bloomFilterFilepath = filesetPathFromTimeAndIndex(shardDir, blockStart, snapshotIndex, bloomFilterFileSuffix)
-		indexFilepath = filesetPathFromTimeAndIndex(shardDir, blockStart, snapshotIndex, indexFileSuffix)
-		dataFilepath = filesetPathFromTimeAndIndex(shardDir, blockStart, snapshotIndex, dataFileSuffix)
+		checkpointFilepath = filesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, checkpointFileSuffix)
+		infoFilepath = filesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, infoFileSuffix)
+		digestFilepath = filesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, digestFileSuffix)
+		bloomFilterFilepath = filesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, bloomFilterFileSuffix)
+		indexFilepath = filesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, indexFileSuffix)
+		dataFilepath = filesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, dataFileSuffix)
 	case persist.FileSetFlushType:
 		shardDir = ShardDataDirPath(r.filePathPrefix, namespace, shard)
-		checkpointFilepath = filesetPathFromTime(shardDir, blockStart, checkpointFileSuffix)
-		infoFilepath = filesetPathFromTime(shardDir, blockStart, infoFileSuffix)
-		digestFilepath = filesetPathFromTime(shardDir, blockStart, digestFileSuffix)
-		bloomFilterFilepath = filesetPathFromTime(shardDir, blockStart, bloomFilterFileSuffix)
-		indexFilepath = filesetPathFromTime(shardDir, blockStart, indexFileSuffix)
-		dataFilepath = filesetPathFromTime(shardDir, blockStart, dataFileSuffix)
+		checkpointFilepath = dataFileSetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, checkpointFileSuffix)
+		infoFilepath = dataFileSetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, infoFileSuffix)
+		digestFilepath = dataFileSetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, digestFileSuffix)
+		bloomFilterFilepath = dataFileSetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, bloomFilterFileSuffix)
+		indexFilepath = dataFileSetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, indexFileSuffix)
+		dataFilepath = dataFileSetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, dataFileSuffix)
 	default:
 		return fmt.Errorf("unable to open reader with fileset type: %s", opts.FileSetType)
 	}
@@ -187,18 +203,33 @@ func (r *reader) Open(opts DataReaderOpenOptions) error {
 		r.digestFdWithDigestContents.Close()
 	}()
 
-	result, err := mmap.Files(os.Open, map[string]mmap.FileDesc{
+	mmapFiles := map[string]mmap.FileDesc{
 		indexFilepath: mmap.FileDesc{
 			File:    &r.indexFd,
 			Bytes:   &r.indexMmap,
 			Options: mmap.Options{Read: true, HugeTLB: r.hugePagesOpts},
 		},
-		dataFilepath: mmap.FileDesc{
+	}
+	dataExists := true
+	if r.opts.FileSetBackend() != nil {
+		// The data file may have been moved to the fileset backend, in which
+		// case it is only fetched once data is first read
+		dataExists, err = FileExists(dataFilepath)
+		if err != nil {
+			return err
+		}
+	}
+	if dataExists {
+		mmapFiles[dataFilepath] = mmap.FileDesc{
 			File:    &r.dataFd,
 			Bytes:   &r.dataMmap,
 			Options: mmap.Options{Read: true, HugeTLB: r.hugePagesOpts},
-		},
-	})
+		}
+	} else {
+		r.tieredDataFilepath = dataFilepath
+	}
+
+	result, err := mmap.Files(os.Open, mmapFiles)
 	if err != nil {
 		return err
 	}
@@ -226,6 +257,15 @@ func (r *reader) Open(opts DataReaderOpenOptions) error {
 		r.Close()
 		return err
 	}
+	if r.compression != persist.FileSetNoCompression {
+		// The index is decoded from the decompressed pages
+		r.indexPages, err = decompressPages(r.indexPages, r.compression, r.indexMmap)
+		if err != nil {
+			r.Close()
+			return err
+		}
+		r.indexDecoderStream.Reset(r.indexPages)
+	}
 	if err := r.readIndexAndSortByOffsetAsc(); err != nil {
 		r.Close()
 		return err
@@ -306,6 +346,8 @@ func (r *reader) readInfo(size int) error {
 	r.entriesRead = 0
 	r.metadataRead = 0
 	r.bloomFilterInfo = info.BloomFilter
+	r.encodingScheme = info.EncodingScheme
+	r.compression = info.Compression
 	return nil
 }
 
@@ -337,6 +379,12 @@ func (r *reader) Read() (ident.ID, ident.TagIterator, checked.Bytes, uint32, err
 		return nil, nil, nil, 0, io.EOF
 	}
 
+	if r.dataFd == nil && r.tieredDataFilepath != "" {
+		if err := r.openTieredDataFile(); err != nil {
+			return nil, nil, nil, 0, err
+		}
+	}
+
 	entry := r.indexEntriesByOffsetAsc[r.entriesRead]
 
 	var data checked.Bytes
@@ -351,12 +399,18 @@ func (r *reader) Read() (ident.ID, ident.TagIterator, checked.Bytes, uint32, err
 		defer data.DecRef()
 	}
 
-	n, err := r.dataReader.Read(data.Bytes())
-	if err != nil {
-		return nil, nil, nil, 0, err
-	}
-	if n != int(entry.Size) {
-		return nil, nil, nil, 0, errReadNotExpectedSize
+	if r.compression != persist.FileSetNoCompression {
+		if err := r.readDataPage(entry, data.Bytes()); err != nil {
+			return nil, nil, nil, 0, err
+		}
+	} else {
+		n, err := r.dataReader.Read(data.Bytes())
+		if err != nil {
+			return nil, nil, nil, 0, err
+		}
+		if n != int(entry.Size) {
+			return nil, nil, nil, 0, errReadNotExpectedSize
+		}
 	}
 
 	id := r.entryClonedID(entry.ID)
@@ -366,6 +420,62 @@ func (r *reader) Read() (ident.ID, ident.TagIterator, checked.Bytes, uint32, err
 	return id, tags, data, uint32(entry.Checksum), nil
 }
 
+// openTieredDataFile fetches the data file from the fileset backend the first
+// time that data is read.
+func (r *reader) openTieredDataFile() error {
+	fd, err := openTieredDataFile(r.filePathPrefix,
+		r.opts.FileSetBackend(), r.tieredDataFilepath)
+	if err != nil {
+		return err
+	}
+	result, err := mmap.File(fd, mmap.Options{Read: true, HugeTLB: r.hugePagesOpts})
+	if err != nil {
+		fd.Close()
+		return err
+	}
+	if warning := result.Warning; warning != nil {
+		logger := r.opts.InstrumentOptions().Logger()
+		logger.Warnf("warning while mmapping tiered data file in reader: %s",
+			warning.Error())
+	}
+
+	r.dataFd = fd
+	r.dataMmap = result.Result
+	r.dataReader.Reset(bytes.NewReader(r.dataMmap))
+	return nil
+}
+
+// readDataPage copies the data of an entry from the page that contains it,
+// pages are read in order so that the digest of the data file is computed
+// over the entire file.
+func (r *reader) readDataPage(entry schema.IndexEntry, data []byte) error {
+	if entry.Size == 0 {
+		// Empty entries may refer to a page that was never written
+		return nil
+	}
+	for r.dataPage == nil || entry.Offset != r.dataPageOffset {
+		if entry.Offset < r.nextDataPageOffset {
+			return errInvalidDataFileOffset
+		}
+		page, compressed, err := readCompressedPage(r.dataPage,
+			r.dataPageCompressed, r.compression, r.dataReader)
+		r.dataPageCompressed = compressed
+		if err != nil {
+			return err
+		}
+		r.dataPage = page
+		r.dataPageOffset = r.nextDataPageOffset
+		r.nextDataPageOffset += int64(compressedPageHeaderLen + len(compressed))
+	}
+
+	entryData, err := pageSlice(r.dataPage, entry.PageOffset, entry.Size)
+	if err != nil {
+		return err
+	}
+	copy(data, entryData)
+	return nil
+}
+
 func (r *reader) ReadMetadata() (ident.ID, ident.TagIterator, int, uint32, error) {
 	if r.metadataRead >= r.entries {
 		return nil, nil, 0, 0, io.EOF
@@ -430,6 +540,14 @@ func (r *reader) Validate() error {
 // NB(r): ValidateMetadata can be called immediately after Open(...) since
 // the metadata is read upfront.
 func (r *reader) ValidateMetadata() error {
+	if r.compression != persist.FileSetNoCompression {
+		// The index was decoded from the decompressed pages rather than
+		// being read through the digest reader
+		if digest.Checksum(r.indexMmap) != r.expectedIndexDigest {
+			return errIndexDigestMismatch
+		}
+		return nil
+	}
 	err := r.indexDecoderStream.reader().Validate(r.expectedIndexDigest)
 	if err != nil {
 		return fmt.Errorf("could not validate index file: %v", err)
@@ -455,6 +573,10 @@ func (r *reader) Entries() int {
 	return r.entries
 }
 
+func (r *reader) EncodingScheme() string {
+	return r.encodingScheme
+}
+
 func (r *reader) EntriesRead() int {
 	return r.entriesRead
 }
@@ -469,7 +591,10 @@ func (r *reader) Close() error {
 	multiErr = multiErr.Add(mmap.Munmap(r.indexMmap))
 	multiErr = multiErr.Add(mmap.Munmap(r.dataMmap))
 	multiErr = multiErr.Add(r.indexFd.Close())
-	multiErr = multiErr.Add(r.dataFd.Close())
+	if r.dataFd != nil {
+		// The data file of tiered filesets is only opened once data is read
+		multiErr = multiErr.Add(r.dataFd.Close())
+	}
 	multiErr = multiErr.Add(r.bloomFilterFd.Close())
 	r.indexDecoderStream.Reset(nil)
 	r.dataReader.Reset(nil)
@@ -492,6 +617,8 @@ func (r *reader) Close() error {
 	bytesPool := r.bytesPool
 	tagDecoderPool := r.tagDecoderPool
 	indexEntriesByOffsetAsc := r.indexEntriesByOffsetAsc
+	indexPages := r.indexPages[:0]
+	dataPageCompressed := r.dataPageCompressed[:0]
 
 	// Reset struct
 	*r = reader{}
@@ -510,6 +637,8 @@ func (r *reader) Close() error {
 	r.bytesPool = bytesPool
 	r.tagDecoderPool = tagDecoderPool
 	r.indexEntriesByOffsetAsc = indexEntriesByOffsetAsc
+	r.indexPages = indexPages
+	r.dataPageCompressed = dataPageCompressed
 
 	return multiErr.FinalError()
 }
@@ -522,6 +651,10 @@ func (e indexEntriesByOffsetAsc) Len() int {
 }
 
 func (e indexEntriesByOffsetAsc) Less(i, j int) bool {
+	if e[i].Offset == e[j].Offset {
+		// Entries within the same page of compressed filesets
+		return e[i].PageOffset < e[j].PageOffset
+	}
 	return e[i].Offset < e[j].Offset
 }
 
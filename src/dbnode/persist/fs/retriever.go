@@ -38,6 +38,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/ts"
@@ -88,7 +89,9 @@ type blockRetriever struct {
 	idPool     ident.Pool
 	nsMetadata namespace.Metadata
 
-	blockSize time.Duration
+	blockSize    time.Duration
+	nsCodec      encoding.Codec
+	encodingOpts encoding.Options
 
 	status                     blockRetrieverStatus
 	reqsByShardIdx             []*shardRetrieveRequests
@@ -115,8 +118,11 @@ func NewBlockRetriever(
 		reqPool:        reqPool,
 		bytesPool:      opts.BytesPool(),
 		idPool:         opts.IdentifierPool(),
-		status:         blockRetrieverNotOpen,
-		notifyFetch:    make(chan struct{}, 1),
+		encodingOpts: encoding.NewOptions().
+			SetBytesPool(opts.BytesPool()).
+			SetSegmentReaderPool(segmentReaderPool),
+		status:      blockRetrieverNotOpen,
+		notifyFetch: make(chan struct{}, 1),
 		// We just close this channel when the fetchLoops should shutdown, so no
 		// buffering is required
 		fetchLoopsShouldShutdownCh: make(chan struct{}),
@@ -132,12 +138,18 @@ func (r *blockRetriever) Open(ns namespace.Metadata) error {
 		return errBlockRetrieverAlreadyOpenOrClosed
 	}
 
+	nsCodec, err := r.opts.CodecRegistry().Codec(ns.Options().EncodingScheme())
+	if err != nil {
+		return err
+	}
+
 	seekerMgr := r.newSeekerMgrFn(r.bytesPool, r.fsOpts, r.opts.FetchConcurrency())
 	if err := seekerMgr.Open(ns); err != nil {
 		return err
 	}
 
 	r.nsMetadata = ns
+	r.nsCodec = nsCodec
 	r.status = blockRetrieverOpen
 	r.seekerMgr = seekerMgr
 
@@ -160,6 +172,31 @@ func (r *blockRetriever) CacheShardIndices(shards []uint32) error {
 	return r.seekerMgr.CacheShardIndices(shards)
 }
 
+func (r *blockRetriever) ReloadFileSet(shard uint32, blockStart time.Time) error {
+	r.RLock()
+	defer r.RUnlock()
+
+	if r.status != blockRetrieverOpen {
+		return errBlockRetrieverNotOpen
+	}
+	return r.seekerMgr.ReloadFileSet(shard, blockStart)
+}
+
+func (r *blockRetriever) IsSupersededFileSetInUse(
+	shard uint32,
+	blockStart time.Time,
+	volumeIndex int,
+) bool {
+	r.RLock()
+	defer r.RUnlock()
+
+	if r.status != blockRetrieverOpen {
+		// No seekers are open unless the retriever is open
+		return false
+	}
+	return r.seekerMgr.IsSupersededFileSetInUse(shard, blockStart, volumeIndex)
+}
+
 func (r *blockRetriever) fetchLoop(seekerMgr DataFileSetSeekerManager) {
 	var (
 		inFlight      []*retrieveRequest
@@ -270,9 +307,19 @@ func (r *blockRetriever) fetchBatch(
 		return
 	}
 
+	// Data of volumes persisted with an encoding scheme other than the scheme
+	// of the namespace is transcoded, so that retrieved blocks are always
+	// encoded with the scheme of the namespace
+	volumeCodec, codecErr := r.volumeCodec(seeker)
+
 	// Sort the requests by offset into the file before seeking
 	// to ensure all seeks are in ascending order
 	for _, req := range reqs {
+		if codecErr != nil {
+			req.onError(codecErr)
+			continue
+		}
+
 		entry, err := seeker.SeekIndexEntry(req.id)
 		if err != nil && err != errSeekIDNotFound {
 			req.onError(err)
@@ -303,6 +350,14 @@ func (r *blockRetriever) fetchBatch(
 			}
 		}
 
+		if data != nil && volumeCodec != nil {
+			data, err = r.transcode(data, volumeCodec, blockStart)
+			if err != nil {
+				req.onError(err)
+				continue
+			}
+		}
+
 		var (
 			seg, onRetrieveSeg ts.Segment
 		)
@@ -358,6 +413,50 @@ func (r *blockRetriever) fetchBatch(
 	}
 }
 
+// volumeCodec returns the codec the data of the volume of the seeker is
+// encoded with, or nil if it is encoded with the scheme of the namespace.
+func (r *blockRetriever) volumeCodec(
+	seeker ConcurrentDataFileSetSeeker,
+) (encoding.Codec, error) {
+	codec, err := r.opts.CodecRegistry().Codec(seeker.EncodingScheme())
+	if err != nil {
+		return nil, err
+	}
+	if codec.Scheme() == r.nsCodec.Scheme() {
+		return nil, nil
+	}
+	return codec, nil
+}
+
+// transcode decodes data encoded with the codec of a volume and encodes it
+// with the codec of the namespace.
+func (r *blockRetriever) transcode(
+	data checked.Bytes,
+	volumeCodec encoding.Codec,
+	blockStart time.Time,
+) (checked.Bytes, error) {
+	segment := ts.NewSegment(data, nil, ts.FinalizeHead)
+	defer segment.Finalize()
+
+	transcoded, err := encoding.Transcode(segment, volumeCodec, r.nsCodec,
+		blockStart, r.encodingOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer transcoded.Finalize()
+
+	result := r.bytesPool.Get(transcoded.Len())
+	result.IncRef()
+	if transcoded.Head != nil {
+		result.AppendAll(transcoded.Head.Bytes())
+	}
+	if transcoded.Tail != nil {
+		result.AppendAll(transcoded.Tail.Bytes())
+	}
+	result.DecRef()
+	return result, nil
+}
+
 func (r *blockRetriever) Stream(
 	ctx context.Context,
 	shard uint32,
@@ -31,6 +31,9 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/encoding/vdelta"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3x/checked"
@@ -306,3 +309,83 @@ func TestBlockRetrieverIDDoesNotExist(t *testing.T) {
 	assert.Equal(t, nil, segment.Head)
 	assert.Equal(t, nil, segment.Tail)
 }
+
+func TestBlockRetrieverTranscodesVolumeEncodingScheme(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testdb")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filePathPrefix := filepath.Join(dir, "")
+
+	fsOpts := testDefaultOpts.SetFilePathPrefix(filePathPrefix)
+	rOpts := testNs1Metadata(t).Options().RetentionOptions()
+	shard := uint32(0)
+	blockStart := time.Now().Truncate(rOpts.BlockSize())
+
+	// The namespace uses the default encoding scheme
+	opts := testBlockRetrieverOptions{
+		retrieverOpts: NewBlockRetrieverOptions(),
+		fsOpts:        fsOpts,
+	}
+	retriever, cleanup := newOpenTestBlockRetriever(t, opts)
+	defer cleanup()
+
+	// Write out a volume encoded with another encoding scheme
+	var datapoints []ts.Datapoint
+	encoder := vdelta.NewEncoder(blockStart, nil, nil)
+	for i := 0; i < 10; i++ {
+		dp := ts.Datapoint{
+			Timestamp: blockStart.Add(time.Duration(i) * time.Second),
+			Value:     float64(i * 3),
+		}
+		require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+		datapoints = append(datapoints, dp)
+	}
+	stream := encoder.Stream()
+	segment, err := stream.Segment()
+	require.NoError(t, err)
+	encoded := append([]byte(nil), segment.Head.Bytes()...)
+	if segment.Tail != nil {
+		encoded = append(encoded, segment.Tail.Bytes()...)
+	}
+	data := checked.NewBytes(encoded, nil)
+	data.IncRef()
+	defer data.DecRef()
+	stream.Finalize()
+
+	w := newTestWriter(t, filePathPrefix)
+	require.NoError(t, w.Open(DataWriterOpenOptions{
+		BlockSize: testBlockSize,
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      shard,
+			BlockStart: blockStart,
+		},
+		EncodingScheme: vdelta.Scheme,
+	}))
+	require.NoError(t, w.Write(ident.StringID("foo"), ident.Tags{}, data,
+		digest.Checksum(data.Bytes())))
+	require.NoError(t, w.Close())
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+	segmentReader, err := retriever.Stream(ctx, shard,
+		ident.StringID("foo"), blockStart, nil)
+	require.NoError(t, err)
+
+	// The retrieved block is decoded with the encoding scheme of the namespace
+	iter := m3tsz.NewReaderIterator(segmentReader,
+		m3tsz.DefaultIntOptimizationEnabled, encoding.NewOptions())
+	defer iter.Close()
+
+	var retrieved []ts.Datapoint
+	for iter.Next() {
+		dp, _, _ := iter.Current()
+		retrieved = append(retrieved, dp)
+	}
+	require.NoError(t, iter.Err())
+	require.Equal(t, len(datapoints), len(retrieved))
+	for i := range datapoints {
+		assert.True(t, datapoints[i].Timestamp.Equal(retrieved[i].Timestamp))
+		assert.Equal(t, datapoints[i].Value, retrieved[i].Value)
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/x/mmap"
@@ -48,6 +49,9 @@ var (
 	// errInvalidDataFileOffset returned when the provided offset into the data file is not valid
 	errInvalidDataFileOffset = errors.New("invalid data file offset")
 
+	// errInvalidIndexFileOffset returned when the offset into the index file is not valid
+	errInvalidIndexFileOffset = errors.New("invalid index file offset")
+
 	// errNotEnoughBytes returned when the data file doesn't have enough bytes to satisfy a read
 	errNotEnoughBytes = errors.New("invalid data file, not enough bytes to satisfy read")
 
@@ -65,12 +69,18 @@ type seeker struct {
 	entries         int
 	bloomFilterInfo schema.IndexBloomFilterInfo
 	summariesInfo   schema.IndexSummariesInfo
+	compression     persist.FileSetCompression
+	encodingScheme  string
 
 	dataMmap  []byte
 	indexMmap []byte
 
 	unreadBuf []byte
 
+	// Decompressed pages of compressed filesets
+	indexPage []byte
+	dataPage  []byte
+
 	decoder      *msgpack.Decoder
 	decodingOpts msgpack.DecodingOptions
 	bytesPool    pool.CheckedBytesPool
@@ -91,6 +101,7 @@ type IndexEntry struct {
 	Size        uint32
 	Checksum    uint32
 	Offset      int64
+	PageOffset  int64
 	EncodedTags []byte
 }
 
@@ -156,7 +167,7 @@ func (s *seeker) ConcurrentIDBloomFilter() *ManagedConcurrentBloomFilter {
 	return s.bloomFilter
 }
 
-func (s *seeker) Open(namespace ident.ID, shard uint32, blockStart time.Time) error {
+func (s *seeker) Open(namespace ident.ID, shard uint32, blockStart time.Time, volumeIndex int) error {
 	if s.isClone {
 		return errClonesShouldNotBeOpened
 	}
@@ -166,12 +177,12 @@ func (s *seeker) Open(namespace ident.ID, shard uint32, blockStart time.Time) er
 
 	// Open necessary files
 	if err := openFiles(os.Open, map[string]**os.File{
-		filesetPathFromTime(shardDir, blockStart, infoFileSuffix):        &infoFd,
-		filesetPathFromTime(shardDir, blockStart, indexFileSuffix):       &indexFd,
-		filesetPathFromTime(shardDir, blockStart, dataFileSuffix):        &dataFd,
-		filesetPathFromTime(shardDir, blockStart, digestFileSuffix):      &digestFd,
-		filesetPathFromTime(shardDir, blockStart, bloomFilterFileSuffix): &bloomFilterFd,
-		filesetPathFromTime(shardDir, blockStart, summariesFileSuffix):   &summariesFd,
+		dataFileSetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, infoFileSuffix):        &infoFd,
+		dataFileSetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, indexFileSuffix):       &indexFd,
+		dataFileSetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, dataFileSuffix):        &dataFd,
+		dataFileSetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, digestFileSuffix):      &digestFd,
+		dataFileSetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, bloomFilterFileSuffix): &bloomFilterFd,
+		dataFileSetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, summariesFileSuffix):   &summariesFd,
 	}); err != nil {
 		return err
 	}
@@ -207,13 +218,15 @@ func (s *seeker) Open(namespace ident.ID, shard uint32, blockStart time.Time) er
 			Threshold: s.opts.opts.MmapHugeTLBThreshold(),
 		},
 	}
-	mmapResult, err := mmap.Files(os.Open, map[string]mmap.FileDesc{
-		filesetPathFromTime(shardDir, blockStart, indexFileSuffix): mmap.FileDesc{
+	// The data file is fetched from the fileset backend if it was tiered
+	opener := newFileSetFileOpener(s.filePathPrefix, s.opts.opts.FileSetBackend())
+	mmapResult, err := mmap.Files(opener, map[string]mmap.FileDesc{
+		dataFileSetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, indexFileSuffix): mmap.FileDesc{
 			File:    &indexFd,
 			Bytes:   &s.indexMmap,
 			Options: mmapOptions,
 		},
-		filesetPathFromTime(shardDir, blockStart, dataFileSuffix): mmap.FileDesc{
+		dataFileSetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, dataFileSuffix): mmap.FileDesc{
 			File:    &dataFd,
 			Bytes:   &s.dataMmap,
 			Options: mmapOptions,
@@ -250,7 +263,7 @@ func (s *seeker) Open(namespace ident.ID, shard uint32, blockStart time.Time) er
 		s.Close()
 		return fmt.Errorf(
 			"index file digest for file: %s does not match the expected digest",
-			filesetPathFromTime(shardDir, blockStart, indexFileSuffix),
+			dataFileSetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, indexFileSuffix),
 		)
 	}
 
@@ -321,6 +334,8 @@ func (s *seeker) readInfo(size int, infoDigestReader digest.FdWithDigestReader,
 	s.entries = int(info.Entries)
 	s.bloomFilterInfo = info.BloomFilter
 	s.summariesInfo = info.Summaries
+	s.compression = info.Compression
+	s.encodingScheme = info.EncodingScheme
 
 	return nil
 }
@@ -350,6 +365,18 @@ func (s *seeker) SeekByIndexEntry(entry IndexEntry) (checked.Bytes, error) {
 	// reslice it such that the first byte is the next byte we want to read.
 	data := s.dataMmap[entry.Offset:]
 
+	if s.compression != persist.FileSetNoCompression {
+		page, _, err := decompressPage(s.dataPage, s.compression, data)
+		if err != nil {
+			return nil, err
+		}
+		s.dataPage = page
+		data, err = pageSlice(page, entry.PageOffset, int64(entry.Size))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Should never happen, but prevents panics in the case of malformed data
 	if len(data) < int(entry.Size) {
 		return nil, errNotEnoughBytes
@@ -389,7 +416,24 @@ func (s *seeker) SeekIndexEntry(id ident.ID) (IndexEntry, error) {
 		return IndexEntry{}, err
 	}
 
-	stream := msgpack.NewDecoderStream(s.indexMmap[offset:])
+	// Should never happen, but prevent panics if the summaries file refers
+	// to an offset beyond the end of the index file
+	if int(offset) > len(s.indexMmap) {
+		return IndexEntry{}, errInvalidIndexFileOffset
+	}
+
+	index := s.indexMmap[offset:]
+	if s.compression != persist.FileSetNoCompression {
+		// The entries between two summaries are always within the same page
+		page, _, err := decompressPage(s.indexPage, s.compression, index)
+		if err != nil {
+			return IndexEntry{}, err
+		}
+		s.indexPage = page
+		index = page
+	}
+
+	stream := msgpack.NewDecoderStream(index)
 	s.decoder.Reset(stream)
 
 	idBytes := id.Bytes()
@@ -407,6 +451,7 @@ func (s *seeker) SeekIndexEntry(id ident.ID) (IndexEntry, error) {
 				Size:        uint32(entry.Size),
 				Checksum:    uint32(entry.Checksum),
 				Offset:      entry.Offset,
+				PageOffset:  entry.PageOffset,
 				EncodedTags: entry.EncodedTags,
 			}, nil
 		}
@@ -432,6 +477,10 @@ func (s *seeker) Entries() int {
 	return s.entries
 }
 
+func (s *seeker) EncodingScheme() string {
+	return s.encodingScheme
+}
+
 func (s *seeker) Close() error {
 	// Parent should handle cleaning up shared resources
 	if s.isClone {
@@ -474,8 +523,10 @@ func (s *seeker) ConcurrentClone() (ConcurrentDataFileSetSeeker, error) {
 		dataMmap:  s.dataMmap,
 		indexMmap: s.indexMmap,
 		// bloomFilter is concurrency safe
-		bloomFilter: s.bloomFilter,
-		indexLookup: indexLookupClone,
-		isClone:     true,
+		bloomFilter:    s.bloomFilter,
+		indexLookup:    indexLookupClone,
+		compression:    s.compression,
+		encodingScheme: s.encodingScheme,
+		isClone:        true,
 	}, nil
 }
@@ -48,10 +48,12 @@ const seekManagerCloseInterval = time.Second
 
 type openAnyUnopenSeekersFn func(*seekersByTime) error
 
+// newOpenSeekerFn opens a seeker for the latest fileset volume of a shard and
+// block start, it returns the seeker and the index of the volume it opened.
 type newOpenSeekerFn func(
 	shard uint32,
 	blockStart time.Time,
-) (DataFileSetSeeker, error)
+) (DataFileSetSeeker, int, error)
 
 type seekerManagerStatus int
 
@@ -94,6 +96,33 @@ type seekersAndBloom struct {
 	wg          *sync.WaitGroup
 	seekers     []borrowableSeeker
 	bloomFilter *ManagedConcurrentBloomFilter
+	// blockStart and volumeIndex identify the fileset volume the seekers
+	// were opened against.
+	blockStart  xtime.UnixNano
+	volumeIndex int
+	// lastAccessed is used to close the seekers of tiered blocks, whose data
+	// files are fetched from the fileset backend, once they are no longer used.
+	lastAccessed time.Time
+}
+
+func (s seekersAndBloom) returnSeeker(seeker ConcurrentDataFileSetSeeker) bool {
+	for i, compareSeeker := range s.seekers {
+		if seeker == compareSeeker.seeker {
+			compareSeeker.isBorrowed = false
+			s.seekers[i] = compareSeeker
+			return true
+		}
+	}
+	return false
+}
+
+func (s seekersAndBloom) allSeekersReturned() bool {
+	for _, seeker := range s.seekers {
+		if seeker.isBorrowed {
+			return false
+		}
+	}
+	return true
 }
 
 // borrowableSeeker is just a seeker with an additional field for keeping track of whether or not it has been borrowed.
@@ -107,6 +136,10 @@ type seekersByTime struct {
 	shard    uint32
 	accessed bool
 	seekers  map[xtime.UnixNano]seekersAndBloom
+	// superseded holds seekers for fileset volumes that have since been
+	// replaced by a newer volume, they are closed by the open/close loop
+	// once all of them have been returned.
+	superseded []seekersAndBloom
 }
 
 type seekerManagerPendingClose struct {
@@ -223,6 +256,8 @@ func (m *seekerManager) Borrow(shard uint32, start time.Time) (ConcurrentDataFil
 
 	availableSeeker.isBorrowed = true
 	seekers[availableSeekerIdx] = availableSeeker
+	seekersAndBloom.lastAccessed = m.now()
+	byTime.seekers[startNano] = seekersAndBloom
 	return availableSeeker.seeker, nil
 }
 
@@ -234,6 +269,17 @@ func (m *seekerManager) Return(shard uint32, start time.Time, seeker ConcurrentD
 
 	startNano := xtime.ToUnixNano(start)
 	seekersAndBloom, ok := byTime.seekers[startNano]
+	if ok && seekersAndBloom.returnSeeker(seeker) {
+		return nil
+	}
+
+	// The seeker may have been borrowed before its fileset volume was superseded
+	for _, superseded := range byTime.superseded {
+		if superseded.returnSeeker(seeker) {
+			return nil
+		}
+	}
+
 	// Should never happen - This either means that the caller (DataBlockRetriever) is trying to return seekers
 	// that it never requested, OR its trying to return seekers after the openCloseLoop has already
 	// determined that they were all no longer in use and safe to close. Either way it indicates there is
@@ -242,22 +288,69 @@ func (m *seekerManager) Return(shard uint32, start time.Time, seeker ConcurrentD
 		return errSeekersDontExist
 	}
 
-	found := false
-	for i, compareSeeker := range seekersAndBloom.seekers {
-		if seeker == compareSeeker.seeker {
-			found = true
-			compareSeeker.isBorrowed = false
-			seekersAndBloom.seekers[i] = compareSeeker
-			break
-		}
-	}
 	// Should never happen with a well behaved caller. Either they are trying to return a seeker
 	// that we're not managing, or they provided the wrong shard/start.
-	if !found {
-		return errReturnedUnmanagedSeeker
+	return errReturnedUnmanagedSeeker
+}
+
+// ReloadFileSet makes subsequent borrows for a given shard and block start use
+// the latest fileset volume on disk. Seekers for the previous volume that are
+// still borrowed are closed once they have all been returned.
+func (m *seekerManager) ReloadFileSet(shard uint32, start time.Time) error {
+	byTime := m.seekersByTime(shard)
+
+	byTime.Lock()
+	defer byTime.Unlock()
+
+	startNano := xtime.ToUnixNano(start)
+	for {
+		seekers, ok := byTime.seekers[startNano]
+		if !ok {
+			// Nothing open, the next borrow will open the latest volume
+			return nil
+		}
+
+		if seekers.wg != nil {
+			// Seekers are being opened, wait for that to complete so the
+			// seekers being opened are not left open against a stale volume
+			byTime.Unlock()
+			seekers.wg.Wait()
+			byTime.Lock()
+			continue
+		}
+
+		delete(byTime.seekers, startNano)
+		byTime.superseded = append(byTime.superseded, seekers)
+		return nil
 	}
+}
 
-	return nil
+// IsSupersededFileSetInUse returns whether seekers for a fileset volume of a
+// given shard and block start that precedes the given volume index are still
+// open, such volumes must not be deleted until their seekers are closed.
+func (m *seekerManager) IsSupersededFileSetInUse(
+	shard uint32,
+	start time.Time,
+	volumeIndex int,
+) bool {
+	byTime := m.seekersByTime(shard)
+
+	byTime.RLock()
+	defer byTime.RUnlock()
+
+	startNano := xtime.ToUnixNano(start)
+	if seekers, ok := byTime.seekers[startNano]; ok {
+		// Seekers that are still being opened may be opening any volume
+		if seekers.wg != nil || seekers.volumeIndex < volumeIndex {
+			return true
+		}
+	}
+	for _, superseded := range byTime.superseded {
+		if superseded.blockStart == startNano && superseded.volumeIndex < volumeIndex {
+			return true
+		}
+	}
+	return false
 }
 
 // getOrOpenSeekersWithLock checks if the seekers are already open / initialized. If they are, then it
@@ -297,7 +390,7 @@ func (m *seekerManager) getOrOpenSeekersWithLock(start xtime.UnixNano, byTime *s
 	byTime.Unlock()
 	// Open first one - Do this outside the context of the lock because opening
 	// a seeker can be an expensive operation (validating index files)
-	seeker, err := m.newOpenSeekerFn(byTime.shard, start.ToTime())
+	seeker, volumeIndex, err := m.newOpenSeekerFn(byTime.shard, start.ToTime())
 	// Immediately re-lock once the seeker is open regardless of errors because
 	// thats the contract of this function
 	byTime.Lock()
@@ -331,6 +424,9 @@ func (m *seekerManager) getOrOpenSeekersWithLock(start xtime.UnixNano, byTime *s
 
 	seekers.wg = nil
 	seekers.seekers = borrowableSeekers
+	seekers.blockStart = start
+	seekers.volumeIndex = volumeIndex
+	seekers.lastAccessed = m.now()
 	// Doesn't matter which seeker we pick to grab the bloom filter from, they all share the same underlying one.
 	// Use index 0 because its guaranteed to be there.
 	seekers.bloomFilter = borrowableSeekers[0].seeker.ConcurrentIDBloomFilter()
@@ -342,9 +438,15 @@ func (m *seekerManager) openAnyUnopenSeekers(byTime *seekersByTime) error {
 	start := m.earliestSeekableBlockStart()
 	end := m.latestSeekableBlockStart()
 	blockSize := m.namespaceMetadata.Options().RetentionOptions().BlockSize()
+	now := m.now()
 	multiErr := xerrors.NewMultiError()
 
 	for t := start; !t.After(end); t = t.Add(blockSize) {
+		if IsTieredBlock(m.opts, t, blockSize, now) {
+			// Seekers of tiered blocks are only opened on demand since their
+			// data files need to be fetched from the fileset backend
+			continue
+		}
 		byTime.Lock()
 		_, err := m.getOrOpenSeekersWithLock(xtime.ToUnixNano(t), byTime)
 		byTime.Unlock()
@@ -359,13 +461,13 @@ func (m *seekerManager) openAnyUnopenSeekers(byTime *seekersByTime) error {
 func (m *seekerManager) newOpenSeeker(
 	shard uint32,
 	blockStart time.Time,
-) (DataFileSetSeeker, error) {
-	exists, err := DataFileSetExistsAt(m.filePathPrefix, m.namespace, shard, blockStart)
+) (DataFileSetSeeker, int, error) {
+	fileset, exists, err := FileSetAt(m.filePathPrefix, m.namespace, shard, blockStart)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	if !exists {
-		return nil, errSeekerManagerFileSetNotFound
+		return nil, 0, errSeekerManagerFileSetNotFound
 	}
 
 	// NB(r): Use a lock on the unread buffer to avoid multiple
@@ -389,8 +491,9 @@ func (m *seekerManager) newOpenSeeker(
 	// Set the unread buffer to reuse it amongst all seekers.
 	seeker.setUnreadBuffer(m.unreadBuf.value)
 
-	if err := seeker.Open(m.namespace, shard, blockStart); err != nil {
-		return nil, err
+	volumeIndex := fileset.ID.VolumeIndex
+	if err := seeker.Open(m.namespace, shard, blockStart, volumeIndex); err != nil {
+		return nil, 0, err
 	}
 
 	// Retrieve the buffer, it may have changed due to
@@ -398,7 +501,7 @@ func (m *seekerManager) newOpenSeeker(
 	m.unreadBuf.value = seeker.unreadBuffer()
 	seeker.setUnreadBuffer(nil)
 
-	return seeker, nil
+	return seeker, volumeIndex, nil
 }
 
 func (m *seekerManager) seekersByTime(shard uint32) *seekersByTime {
@@ -451,12 +554,17 @@ func (m *seekerManager) Close() error {
 	for _, byTime := range m.seekersByShardIdx {
 		byTime.Lock()
 		for _, seekersByTime := range byTime.seekers {
-			for _, seeker := range seekersByTime.seekers {
-				if seeker.isBorrowed {
-					byTime.Unlock()
-					m.Unlock()
-					return errCantCloseSeekerManagerWhileSeekersAreBorrowed
-				}
+			if !seekersByTime.allSeekersReturned() {
+				byTime.Unlock()
+				m.Unlock()
+				return errCantCloseSeekerManagerWhileSeekersAreBorrowed
+			}
+		}
+		for _, superseded := range byTime.superseded {
+			if !superseded.allSeekersReturned() {
+				byTime.Unlock()
+				m.Unlock()
+				return errCantCloseSeekerManagerWhileSeekersAreBorrowed
 			}
 		}
 		byTime.Unlock()
@@ -469,6 +577,10 @@ func (m *seekerManager) Close() error {
 	return nil
 }
 
+func (m *seekerManager) now() time.Time {
+	return m.opts.ClockOptions().NowFn()()
+}
+
 func (m *seekerManager) earliestSeekableBlockStart() time.Time {
 	nowFn := m.opts.ClockOptions().NowFn()
 	now := nowFn()
@@ -533,12 +645,23 @@ func (m *seekerManager) openCloseLoop() {
 			m.openAnyUnopenSeekersFn(byTime)
 		}
 
+		var (
+			now       = m.now()
+			blockSize = m.namespaceMetadata.Options().RetentionOptions().BlockSize()
+			cacheTTL  = m.opts.FileSetBackendCacheTTL()
+		)
 		m.RLock()
 		for shard, byTime := range m.seekersByShardIdx {
 			byTime.RLock()
-			for blockStartNano := range byTime.seekers {
+			for blockStartNano, seekers := range byTime.seekers {
 				blockStart := blockStartNano.ToTime()
-				if blockStart.Before(earliestSeekableBlockStart) {
+				// Seekers of tiered blocks hold the data file fetched from the
+				// fileset backend and are closed once they have not been used
+				// for the cache TTL
+				idleTiered := seekers.wg == nil &&
+					IsTieredBlock(m.opts, blockStart, blockSize, now) &&
+					now.Sub(seekers.lastAccessed) >= cacheTTL
+				if blockStart.Before(earliestSeekableBlockStart) || idleTiered {
 					shouldClose = append(shouldClose, seekerManagerPendingClose{
 						shard:      uint32(shard),
 						blockStart: blockStart,
@@ -548,23 +671,33 @@ func (m *seekerManager) openCloseLoop() {
 			byTime.RUnlock()
 		}
 
+		for _, byTime := range m.seekersByShardIdx {
+			byTime.Lock()
+			remaining := byTime.superseded[:0]
+			for _, superseded := range byTime.superseded {
+				if superseded.allSeekersReturned() {
+					closing = append(closing, superseded.seekers...)
+					continue
+				}
+				remaining = append(remaining, superseded)
+			}
+			for i := len(remaining); i < len(byTime.superseded); i++ {
+				byTime.superseded[i] = seekersAndBloom{}
+			}
+			byTime.superseded = remaining
+			byTime.Unlock()
+		}
+
 		if len(shouldClose) > 0 {
 			for _, elem := range shouldClose {
 				byTime := m.seekersByShardIdx[elem.shard]
 				blockStartNano := xtime.ToUnixNano(elem.blockStart)
 				byTime.Lock()
 				seekersAndBloom := byTime.seekers[blockStartNano]
-				allSeekersAreReturned := true
-				for _, seeker := range seekersAndBloom.seekers {
-					if seeker.isBorrowed {
-						allSeekersAreReturned = false
-						break
-					}
-				}
 				// Never close seekers unless they've all been returned because
 				// some of them are clones of the original and can't be used once
 				// the parent is closed (because they share underlying resources)
-				if allSeekersAreReturned {
+				if seekersAndBloom.allSeekersReturned() {
 					closing = append(closing, seekersAndBloom.seekers...)
 					delete(byTime.seekers, blockStartNano)
 				}
@@ -592,7 +725,11 @@ func (m *seekerManager) openCloseLoop() {
 	m.Lock()
 	for _, byTime := range m.seekersByShardIdx {
 		byTime.Lock()
+		all := byTime.superseded
 		for _, seekersByTime := range byTime.seekers {
+			all = append(all, seekersByTime)
+		}
+		for _, seekersByTime := range all {
 			for _, seeker := range seekersByTime.seekers {
 				// We don't need to check if the seeker is borrowed here because we don't allow the
 				// SeekerManager to be closed if any seekers are still outstanding.
@@ -605,6 +742,7 @@ func (m *seekerManager) openCloseLoop() {
 			}
 		}
 		byTime.seekers = nil
+		byTime.superseded = nil
 		byTime.Unlock()
 	}
 	m.seekersByShardIdx = nil
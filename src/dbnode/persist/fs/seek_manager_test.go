@@ -21,6 +21,7 @@
 package fs
 
 import (
+	"os"
 	"sync"
 	"testing"
 	"time"
@@ -79,15 +80,15 @@ func TestSeekerManagerBorrowOpenSeekersLazy(t *testing.T) {
 	m.newOpenSeekerFn = func(
 		shard uint32,
 		blockStart time.Time,
-	) (DataFileSetSeeker, error) {
+	) (DataFileSetSeeker, int, error) {
 		mock := NewMockDataFileSetSeeker(ctrl)
-		mock.EXPECT().Open(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mock.EXPECT().Open(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		mock.EXPECT().ConcurrentClone().Return(mock, nil)
 		for i := 0; i < NewBlockRetrieverOptions().FetchConcurrency(); i++ {
 			mock.EXPECT().Close().Return(nil)
 			mock.EXPECT().ConcurrentIDBloomFilter().Return(nil)
 		}
-		return mock, nil
+		return mock, 0, nil
 	}
 	m.sleepFn = func(_ time.Duration) {
 		time.Sleep(time.Millisecond)
@@ -109,9 +110,107 @@ func TestSeekerManagerBorrowOpenSeekersLazy(t *testing.T) {
 	require.NoError(t, m.Close())
 }
 
+// TestSeekerManagerReloadFileSet tests that reloading a fileset makes the
+// next Borrow() open new seekers while seekers still borrowed can be returned.
+func TestSeekerManagerReloadFileSet(t *testing.T) {
+	defer leaktest.CheckTimeout(t, 1*time.Minute)()
+
+	ctrl := gomock.NewController(t)
+
+	var (
+		shard    = uint32(2)
+		numOpens int
+	)
+	m := NewSeekerManager(nil, testDefaultOpts, NewBlockRetrieverOptions().FetchConcurrency()).(*seekerManager)
+	m.newOpenSeekerFn = func(
+		shard uint32,
+		blockStart time.Time,
+	) (DataFileSetSeeker, int, error) {
+		numOpens++
+		mock := NewMockDataFileSetSeeker(ctrl)
+		mock.EXPECT().ConcurrentClone().Return(mock, nil)
+		for i := 0; i < NewBlockRetrieverOptions().FetchConcurrency(); i++ {
+			mock.EXPECT().Close().Return(nil)
+			mock.EXPECT().ConcurrentIDBloomFilter().Return(nil)
+		}
+		// Each open opens the volume written after the previous one
+		return mock, numOpens - 1, nil
+	}
+	m.openAnyUnopenSeekersFn = func(_ *seekersByTime) error {
+		return nil
+	}
+	m.sleepFn = func(_ time.Duration) {
+		time.Sleep(time.Millisecond)
+	}
+
+	metadata := testNs1Metadata(t)
+	require.NoError(t, m.Open(metadata))
+
+	borrowed, err := m.Borrow(shard, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, 1, numOpens)
+
+	require.NoError(t, m.ReloadFileSet(shard, time.Time{}))
+	byTime := m.seekersByTime(shard)
+	byTime.RLock()
+	_, ok := byTime.seekers[xtime.ToUnixNano(time.Time{})]
+	numSuperseded := len(byTime.superseded)
+	byTime.RUnlock()
+	require.False(t, ok)
+	require.Equal(t, 1, numSuperseded)
+	require.True(t, m.IsSupersededFileSetInUse(shard, time.Time{}, 1))
+
+	seeker, err := m.Borrow(shard, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, 2, numOpens)
+	require.True(t, m.IsSupersededFileSetInUse(shard, time.Time{}, 1))
+	require.False(t, m.IsSupersededFileSetInUse(shard, time.Time{}, 0))
+
+	require.NoError(t, m.Return(shard, time.Time{}, borrowed))
+	require.NoError(t, m.Return(shard, time.Time{}, seeker))
+
+	require.NoError(t, m.Close())
+}
+
 // TestSeekerManagerOpenCloseLoop tests the openCloseLoop of the SeekerManager
 // by making sure that it makes the right decisions with regards to cleaning
 // up resources based on their state.
+// TestSeekerManagerOpenAnyUnopenSeekersSkipsTieredBlocks tests that seekers
+// for tiered blocks are not opened ahead of being borrowed.
+func TestSeekerManagerOpenAnyUnopenSeekersSkipsTieredBlocks(t *testing.T) {
+	opts := testDefaultOpts.SetFileSetBackend(NewDirectoryFileSetBackend(
+		os.TempDir(), defaultNewFileMode, defaultNewDirectoryMode))
+	m := NewSeekerManager(nil, opts, NewBlockRetrieverOptions().FetchConcurrency()).(*seekerManager)
+	m.namespaceMetadata = testNs1Metadata(t)
+
+	var opened []time.Time
+	m.newOpenSeekerFn = func(
+		shard uint32,
+		blockStart time.Time,
+	) (DataFileSetSeeker, int, error) {
+		opened = append(opened, blockStart)
+		return nil, 0, errSeekerManagerFileSetNotFound
+	}
+
+	require.NoError(t, m.openAnyUnopenSeekers(m.seekersByTime(0)))
+
+	var (
+		now       = m.now()
+		blockSize = m.namespaceMetadata.Options().RetentionOptions().BlockSize()
+		tiered    int
+	)
+	for blockStart := m.earliestSeekableBlockStart(); !blockStart.After(m.latestSeekableBlockStart()); blockStart = blockStart.Add(blockSize) {
+		if IsTieredBlock(opts, blockStart, blockSize, now) {
+			tiered++
+		}
+	}
+	require.True(t, tiered > 0)
+	require.True(t, len(opened) > 0)
+	for _, blockStart := range opened {
+		require.False(t, IsTieredBlock(opts, blockStart, blockSize, now))
+	}
+}
+
 func TestSeekerManagerOpenCloseLoop(t *testing.T) {
 	defer leaktest.CheckTimeout(t, 1*time.Minute)()
 
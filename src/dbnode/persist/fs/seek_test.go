@@ -69,7 +69,7 @@ func TestSeekEmptyIndex(t *testing.T) {
 	assert.NoError(t, w.Close())
 
 	s := newTestSeeker(filePathPrefix)
-	err = s.Open(testNs1ID, 0, testWriterStart)
+	err = s.Open(testNs1ID, 0, testWriterStart, 0)
 	assert.NoError(t, err)
 	assert.Equal(t, 0, s.Entries())
 	_, err = s.SeekByID(ident.StringID("foo"))
@@ -109,7 +109,7 @@ func TestSeekDataUnexpectedSize(t *testing.T) {
 	assert.NoError(t, os.Truncate(dataFile, 1))
 
 	s := newTestSeeker(filePathPrefix)
-	err = s.Open(testNs1ID, 0, testWriterStart)
+	err = s.Open(testNs1ID, 0, testWriterStart, 0)
 	assert.NoError(t, err)
 
 	_, err = s.SeekByID(ident.StringID("foo"))
@@ -147,7 +147,7 @@ func TestSeekBadChecksum(t *testing.T) {
 	assert.NoError(t, w.Close())
 
 	s := newTestSeeker(filePathPrefix)
-	err = s.Open(testNs1ID, 0, testWriterStart)
+	err = s.Open(testNs1ID, 0, testWriterStart, 0)
 	assert.NoError(t, err)
 
 	_, err = s.SeekByID(ident.StringID("foo"))
@@ -196,7 +196,7 @@ func TestSeek(t *testing.T) {
 	assert.NoError(t, w.Close())
 
 	s := newTestSeeker(filePathPrefix)
-	err = s.Open(testNs1ID, 0, testWriterStart)
+	err = s.Open(testNs1ID, 0, testWriterStart, 0)
 	assert.NoError(t, err)
 
 	data, err := s.SeekByID(ident.StringID("foo3"))
@@ -263,7 +263,7 @@ func TestSeekIDNotExists(t *testing.T) {
 	assert.NoError(t, w.Close())
 
 	s := newTestSeeker(filePathPrefix)
-	err = s.Open(testNs1ID, 0, testWriterStart)
+	err = s.Open(testNs1ID, 0, testWriterStart, 0)
 	assert.NoError(t, err)
 
 	// Test errSeekIDNotFound when we scan far enough into the index file that
@@ -324,7 +324,7 @@ func TestReuseSeeker(t *testing.T) {
 	assert.NoError(t, w.Close())
 
 	s := newTestSeeker(filePathPrefix)
-	err = s.Open(testNs1ID, 0, testWriterStart.Add(-time.Hour))
+	err = s.Open(testNs1ID, 0, testWriterStart.Add(-time.Hour), 0)
 	assert.NoError(t, err)
 
 	data, err := s.SeekByID(ident.StringID("foo"))
@@ -334,7 +334,7 @@ func TestReuseSeeker(t *testing.T) {
 	defer data.DecRef()
 	assert.Equal(t, []byte{1, 2, 1}, data.Bytes())
 
-	err = s.Open(testNs1ID, 0, testWriterStart)
+	err = s.Open(testNs1ID, 0, testWriterStart, 0)
 	assert.NoError(t, err)
 
 	data, err = s.SeekByID(ident.StringID("foo"))
@@ -388,7 +388,7 @@ func TestCloneSeeker(t *testing.T) {
 	assert.NoError(t, w.Close())
 
 	s := newTestSeeker(filePathPrefix)
-	err = s.Open(testNs1ID, 0, testWriterStart.Add(-time.Hour))
+	err = s.Open(testNs1ID, 0, testWriterStart.Add(-time.Hour), 0)
 	assert.NoError(t, err)
 
 	clone, err := s.ConcurrentClone()
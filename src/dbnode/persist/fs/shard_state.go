@@ -0,0 +1,110 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+)
+
+const (
+	shardStateTempFilePrefix = ".tmp-"
+	shardStateDigestLen      = 4
+)
+
+var errShardStateFileDigestMismatch = errors.New("shard state file digest mismatch")
+
+// writeShardStateFile atomically replaces the contents of a file holding
+// state of a shard which is not part of any fileset, a digest of the
+// contents is appended so that corrupt files are detected when read.
+func writeShardStateFile(
+	filePath string,
+	data []byte,
+	newFileMode os.FileMode,
+	newDirectoryMode os.FileMode,
+) error {
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, newDirectoryMode); err != nil {
+		return err
+	}
+
+	fd, err := ioutil.TempFile(dir, shardStateTempFilePrefix)
+	if err != nil {
+		return err
+	}
+	tempFilePath := fd.Name()
+
+	digestBuf := digest.NewBuffer()
+	digestBuf.WriteDigest(digest.Checksum(data))
+	err = writeAndSync(fd, newFileMode, data, digestBuf)
+	if err == nil {
+		err = os.Rename(tempFilePath, filePath)
+	}
+	if err != nil {
+		os.Remove(tempFilePath)
+		return err
+	}
+	return nil
+}
+
+func writeAndSync(fd *os.File, newFileMode os.FileMode, bufs ...[]byte) error {
+	for _, buf := range bufs {
+		if _, err := fd.Write(buf); err != nil {
+			fd.Close()
+			return err
+		}
+	}
+	if err := fd.Chmod(newFileMode); err != nil {
+		fd.Close()
+		return err
+	}
+	if err := fd.Sync(); err != nil {
+		fd.Close()
+		return err
+	}
+	return fd.Close()
+}
+
+// readShardStateFile reads and validates the contents of a shard state file,
+// returning false if the file does not exist.
+func readShardStateFile(filePath string) ([]byte, bool, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if len(data) < shardStateDigestLen {
+		return nil, false, errShardStateFileDigestMismatch
+	}
+
+	contents := data[:len(data)-shardStateDigestLen]
+	expected := digest.ToBuffer(data[len(data)-shardStateDigestLen:]).ReadDigest()
+	if digest.Checksum(contents) != expected {
+		return nil, false, errShardStateFileDigestMismatch
+	}
+	return contents, true, nil
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	xerrors "github.com/m3db/m3x/errors"
+)
+
+// Filesets are tiered by moving their data file to the fileset backend, the
+// remaining files are kept on local disk so that the fileset can still be
+// discovered and its metadata read without accessing the backend.
+const tieredDataFileTempFilePrefix = ".tiered-"
+
+// IsTieredBlock returns whether the data files of the block with the given
+// start are moved to the fileset backend at the given time.
+func IsTieredBlock(
+	opts Options,
+	blockStart time.Time,
+	blockSize time.Duration,
+	now time.Time,
+) bool {
+	if opts.FileSetBackend() == nil {
+		return false
+	}
+	tierBefore := now.Add(-opts.FileSetBackendMinBlockAge())
+	return !blockStart.Add(blockSize).After(tierBefore)
+}
+
+// fileSetBackendKey returns the key of a fileset file in the backend.
+func fileSetBackendKey(filePathPrefix string, filePath string) (string, error) {
+	return filepath.Rel(filePathPrefix, filePath)
+}
+
+func isDataFilePath(filePath string) bool {
+	return strings.HasSuffix(filePath, separator+dataFileSuffix+fileSuffix)
+}
+
+// TierDataFileSet moves the data file of a complete data fileset from local
+// disk to the fileset backend, it is a no-op if the data file is no longer on
+// local disk.
+func TierDataFileSet(
+	filePathPrefix string,
+	backend FileSetBackend,
+	id FileSetFileIdentifier,
+) error {
+	shardDir := ShardDataDirPath(filePathPrefix, id.Namespace, id.Shard)
+	dataFilePath := dataFileSetPathFromTimeAndIndex(shardDir,
+		id.BlockStart, id.VolumeIndex, dataFileSuffix)
+	key, err := fileSetBackendKey(filePathPrefix, dataFilePath)
+	if err != nil {
+		return err
+	}
+
+	fd, err := os.Open(dataFilePath)
+	if os.IsNotExist(err) {
+		// Already tiered
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := backend.Put(key, fd); err != nil {
+		fd.Close()
+		return err
+	}
+	if err := fd.Close(); err != nil {
+		return err
+	}
+	return os.Remove(dataFilePath)
+}
+
+// DeleteTieredDataFiles deletes the data files of the given fileset files from
+// the fileset backend, the data file of each fileset is identified by the
+// fileset's checkpoint file.
+func DeleteTieredDataFiles(
+	filePathPrefix string,
+	backend FileSetBackend,
+	filePaths []string,
+) error {
+	multiErr := xerrors.NewMultiError()
+	for _, filePath := range filePaths {
+		dataFilePath, ok := DataFilePathFromCheckpointFilePath(filePath)
+		if !ok {
+			continue
+		}
+		key, err := fileSetBackendKey(filePathPrefix, dataFilePath)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		multiErr = multiErr.Add(backend.Delete(key))
+	}
+	return multiErr.FinalError()
+}
+
+// DataFilePathFromCheckpointFilePath returns the path of the data file of the
+// fileset that the given checkpoint file belongs to, it returns false if the
+// path is not the path of a checkpoint file.
+func DataFilePathFromCheckpointFilePath(filePath string) (string, bool) {
+	checkpointSuffix := separator + checkpointFileSuffix + fileSuffix
+	if !strings.HasSuffix(filePath, checkpointSuffix) {
+		return "", false
+	}
+	return strings.TrimSuffix(filePath, checkpointSuffix) +
+		separator + dataFileSuffix + fileSuffix, true
+}
+
+// OpenFileSetFile opens a fileset file for reading, data files that have been
+// moved to the fileset backend are fetched from the backend.
+func OpenFileSetFile(
+	filePathPrefix string,
+	backend FileSetBackend,
+	filePath string,
+) (*os.File, error) {
+	return newFileSetFileOpener(filePathPrefix, backend)(filePath)
+}
+
+// openTieredDataFile fetches a data file from the fileset backend into a
+// temporary file beside where it would be on local disk. The temporary file is
+// unlinked before it is written to so that the space it uses is released as
+// soon as it is closed, even if the process exits.
+func openTieredDataFile(
+	filePathPrefix string,
+	backend FileSetBackend,
+	dataFilePath string,
+) (*os.File, error) {
+	key, err := fileSetBackendKey(filePathPrefix, dataFilePath)
+	if err != nil {
+		return nil, err
+	}
+	r, err := backend.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	fd, err := ioutil.TempFile(filepath.Dir(dataFilePath), tieredDataFileTempFilePrefix)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Remove(fd.Name()); err != nil {
+		fd.Close()
+		return nil, err
+	}
+	if _, err := io.Copy(fd, r); err != nil {
+		fd.Close()
+		return nil, err
+	}
+	if _, err := fd.Seek(0, io.SeekStart); err != nil {
+		fd.Close()
+		return nil, err
+	}
+	return fd, nil
+}
+
+// newFileSetFileOpener returns a function that opens fileset files and falls
+// back to fetching data files from the fileset backend if they are not on
+// local disk.
+func newFileSetFileOpener(
+	filePathPrefix string,
+	backend FileSetBackend,
+) func(filePath string) (*os.File, error) {
+	if backend == nil {
+		return os.Open
+	}
+	return func(filePath string) (*os.File, error) {
+		fd, err := os.Open(filePath)
+		if err == nil || !os.IsNotExist(err) || !isDataFilePath(filePath) {
+			return fd, err
+		}
+		return openTieredDataFile(filePathPrefix, backend, filePath)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingFileSetBackend struct {
+	FileSetBackend
+	gets int
+}
+
+func (b *countingFileSetBackend) Get(key string) (io.ReadCloser, error) {
+	b.gets++
+	return b.FileSetBackend.Get(key)
+}
+
+func TestTierDataFileSet(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+	filePathPrefix := filepath.Join(dir, "local")
+	backend := &countingFileSetBackend{
+		FileSetBackend: NewDirectoryFileSetBackend(filepath.Join(dir, "tiered"),
+			defaultNewFileMode, defaultNewDirectoryMode),
+	}
+
+	entries := []testEntry{
+		{"foo", nil, []byte{1, 2, 3}},
+		{"bar", nil, []byte{4, 5, 6}},
+		{"baz", map[string]string{"qux": "qaz"}, []byte{7, 8, 9}},
+	}
+	w := newTestWriter(t, filePathPrefix)
+	writeTestData(t, w, 0, testWriterStart, entries, persist.FileSetFlushType)
+
+	id := FileSetFileIdentifier{
+		Namespace:  testNs1ID,
+		Shard:      0,
+		BlockStart: testWriterStart,
+	}
+	require.NoError(t, TierDataFileSet(filePathPrefix, backend, id))
+	// Tiering a fileset that was already tiered is a no-op
+	require.NoError(t, TierDataFileSet(filePathPrefix, backend, id))
+
+	shardDir := ShardDataDirPath(filePathPrefix, testNs1ID, 0)
+	dataFilePath := dataFileSetPathFromTimeAndIndex(shardDir, testWriterStart, 0, dataFileSuffix)
+	exists, err := FileExists(dataFilePath)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	key, err := fileSetBackendKey(filePathPrefix, dataFilePath)
+	require.NoError(t, err)
+	exists, err = backend.Exists(key)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	// The fileset is still discoverable from local disk
+	_, exists, err = FileSetAt(filePathPrefix, testNs1ID, 0, testWriterStart)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	opts := testDefaultOpts.
+		SetFilePathPrefix(filePathPrefix).
+		SetInfoReaderBufferSize(testReaderBufferSize).
+		SetDataReaderBufferSize(testReaderBufferSize).
+		SetFileSetBackend(backend)
+
+	// Reading metadata does not fetch the data file
+	r, err := NewReader(testBytesPool, opts)
+	require.NoError(t, err)
+	require.NoError(t, r.Open(DataReaderOpenOptions{Identifier: id}))
+	for i := 0; i < r.Entries(); i++ {
+		_, _, _, _, err := r.ReadMetadata()
+		require.NoError(t, err)
+	}
+	require.NoError(t, r.Close())
+	assert.Equal(t, 0, backend.gets)
+
+	readTestData(t, r, 0, testWriterStart, entries)
+	assert.Equal(t, 1, backend.gets)
+
+	s := NewSeeker(filePathPrefix, testReaderBufferSize, testReaderBufferSize,
+		testReaderBufferSize, testBytesPool, false, nil, opts)
+	require.NoError(t, s.Open(testNs1ID, 0, testWriterStart, 0))
+	for _, entry := range entries {
+		data, err := s.SeekByID(ident.StringID(entry.id))
+		require.NoError(t, err)
+
+		data.IncRef()
+		assert.True(t, bytes.Equal(entry.data, data.Bytes()))
+		data.DecRef()
+	}
+	require.NoError(t, s.Close())
+	assert.Equal(t, 2, backend.gets)
+
+	// Fetched data files do not remain on local disk
+	files, err := ioutil.ReadDir(shardDir)
+	require.NoError(t, err)
+	for _, f := range files {
+		assert.False(t, isDataFilePath(f.Name()))
+		assert.NotContains(t, f.Name(), tieredDataFileTempFilePrefix)
+	}
+
+	filesets, err := DataFiles(filePathPrefix, testNs1ID, 0)
+	require.NoError(t, err)
+	require.NoError(t, DeleteTieredDataFiles(filePathPrefix, backend, filesets.Filepaths()))
+	exists, err = backend.Exists(key)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestDeleteSupersededFileSetsAtDeletesTieredDataFiles(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+	filePathPrefix := filepath.Join(dir, "local")
+	backend := NewDirectoryFileSetBackend(filepath.Join(dir, "tiered"),
+		defaultNewFileMode, defaultNewDirectoryMode)
+
+	entries := []testEntry{
+		{"foo", nil, []byte{1, 2, 3}},
+	}
+	shardDir := ShardDataDirPath(filePathPrefix, testNs1ID, 0)
+	keys := make([]string, 0, 2)
+	for volume := 0; volume < 2; volume++ {
+		w := newTestWriter(t, filePathPrefix)
+		writeTestDataWithVolume(t, w, 0, testWriterStart, volume, entries,
+			persist.FileSetFlushType)
+		require.NoError(t, TierDataFileSet(filePathPrefix, backend, FileSetFileIdentifier{
+			Namespace:   testNs1ID,
+			Shard:       0,
+			BlockStart:  testWriterStart,
+			VolumeIndex: volume,
+		}))
+
+		dataFilePath := dataFileSetPathFromTimeAndIndex(shardDir, testWriterStart,
+			volume, dataFileSuffix)
+		key, err := fileSetBackendKey(filePathPrefix, dataFilePath)
+		require.NoError(t, err)
+		keys = append(keys, key)
+	}
+
+	require.NoError(t, DeleteSupersededFileSetsAt(filePathPrefix, backend,
+		testNs1ID, 0, testWriterStart, 1))
+
+	// Only the data file of the superseded volume is deleted from the backend
+	exists, err := backend.Exists(keys[0])
+	require.NoError(t, err)
+	assert.False(t, exists)
+	exists, err = backend.Exists(keys[1])
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	res, ok, err := FileSetAt(filePathPrefix, testNs1ID, 0, testWriterStart)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, 1, res.ID.VolumeIndex)
+}
+
+func TestIsTieredBlock(t *testing.T) {
+	var (
+		blockSize = 2 * time.Hour
+		minAge    = 24 * time.Hour
+		now       = time.Now().Truncate(blockSize)
+		opts      = testDefaultOpts.SetFileSetBackendMinBlockAge(minAge)
+		tiered    = now.Add(-minAge).Add(-blockSize)
+		untiered  = tiered.Add(blockSize)
+	)
+
+	// Nothing is tiered without a backend
+	assert.False(t, IsTieredBlock(opts, tiered, blockSize, now))
+
+	opts = opts.SetFileSetBackend(NewDirectoryFileSetBackend(os.TempDir(),
+		defaultNewFileMode, defaultNewDirectoryMode))
+	assert.True(t, IsTieredBlock(opts, tiered, blockSize, now))
+	assert.False(t, IsTieredBlock(opts, untiered, blockSize, now))
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/m3db/m3x/ident"
+)
+
+const (
+	tombstonesFileName = "tombstones" + fileSuffix
+	tombstonesVersion  = 1
+)
+
+var errTombstonesFileTruncated = errors.New("tombstones file truncated")
+
+// Tombstone records the deletion of a series from a shard.
+type Tombstone struct {
+	// DeletedAt is the time at which the series was deleted.
+	DeletedAt time.Time
+	// Purged is whether the series has been dropped from all fileset volumes
+	// of the shard that existed at the time of the deletion.
+	Purged bool
+}
+
+// TombstonesFilePath returns the path of the tombstones file of a shard.
+func TombstonesFilePath(prefix string, namespace ident.ID, shard uint32) string {
+	return path.Join(ShardDataDirPath(prefix, namespace, shard), tombstonesFileName)
+}
+
+// WriteTombstones atomically replaces the tombstones persisted for a shard.
+func WriteTombstones(
+	opts Options,
+	namespace ident.ID,
+	shard uint32,
+	tombstones map[string]Tombstone,
+) error {
+	var (
+		buf     = make([]byte, 0, 64*(len(tombstones)+1))
+		scratch [binary.MaxVarintLen64]byte
+	)
+	putUvarint := func(v uint64) {
+		n := binary.PutUvarint(scratch[:], v)
+		buf = append(buf, scratch[:n]...)
+	}
+	putUvarint(tombstonesVersion)
+	putUvarint(uint64(len(tombstones)))
+	for id, tombstone := range tombstones {
+		putUvarint(uint64(len(id)))
+		buf = append(buf, id...)
+		n := binary.PutVarint(scratch[:], tombstone.DeletedAt.UnixNano())
+		buf = append(buf, scratch[:n]...)
+		purged := byte(0)
+		if tombstone.Purged {
+			purged = 1
+		}
+		buf = append(buf, purged)
+	}
+
+	filePath := TombstonesFilePath(opts.FilePathPrefix(), namespace, shard)
+	return writeShardStateFile(filePath, buf, opts.NewFileMode(), opts.NewDirectoryMode())
+}
+
+// ReadTombstones reads the tombstones persisted for a shard, returning no
+// tombstones if none have been persisted.
+func ReadTombstones(
+	filePathPrefix string,
+	namespace ident.ID,
+	shard uint32,
+) (map[string]Tombstone, error) {
+	data, ok, err := readShardStateFile(TombstonesFilePath(filePathPrefix, namespace, shard))
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	uvarint := func() (uint64, error) {
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			return 0, errTombstonesFileTruncated
+		}
+		data = data[n:]
+		return v, nil
+	}
+	version, err := uvarint()
+	if err != nil {
+		return nil, err
+	}
+	if version != tombstonesVersion {
+		return nil, fmt.Errorf("unsupported tombstones file version %d", version)
+	}
+	count, err := uvarint()
+	if err != nil {
+		return nil, err
+	}
+
+	// Bound the capacity by the remaining bytes as every tombstone takes at
+	// least three bytes.
+	capacity := count
+	if max := uint64(len(data) / 3); capacity > max {
+		capacity = max
+	}
+	tombstones := make(map[string]Tombstone, capacity)
+	for i := uint64(0); i < count; i++ {
+		idLen, err := uvarint()
+		if err != nil {
+			return nil, err
+		}
+		if idLen > uint64(len(data)) {
+			return nil, errTombstonesFileTruncated
+		}
+		id := string(data[:idLen])
+		data = data[idLen:]
+
+		deletedAt, n := binary.Varint(data)
+		if n <= 0 || n >= len(data) {
+			return nil, errTombstonesFileTruncated
+		}
+		purged := data[n] == 1
+		data = data[n+1:]
+
+		tombstones[id] = Tombstone{
+			DeletedAt: time.Unix(0, deletedAt),
+			Purged:    purged,
+		}
+	}
+	return tombstones, nil
+}
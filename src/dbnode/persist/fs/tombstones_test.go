@@ -0,0 +1,93 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteReadTombstones(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		opts      = testDefaultOpts.SetFilePathPrefix(dir)
+		namespace = ident.StringID("ns")
+		now       = time.Unix(0, time.Now().UnixNano())
+	)
+
+	tombstones, err := ReadTombstones(dir, namespace, 1)
+	require.NoError(t, err)
+	require.Empty(t, tombstones)
+
+	expected := map[string]Tombstone{
+		"foo": {DeletedAt: now},
+		"bar": {DeletedAt: now.Add(-time.Hour), Purged: true},
+	}
+	require.NoError(t, WriteTombstones(opts, namespace, 1, expected))
+
+	tombstones, err = ReadTombstones(dir, namespace, 1)
+	require.NoError(t, err)
+	require.Equal(t, len(expected), len(tombstones))
+	for id, tombstone := range expected {
+		require.True(t, tombstone.DeletedAt.Equal(tombstones[id].DeletedAt))
+		require.Equal(t, tombstone.Purged, tombstones[id].Purged)
+	}
+
+	// Tombstones of other shards are unaffected.
+	tombstones, err = ReadTombstones(dir, namespace, 2)
+	require.NoError(t, err)
+	require.Empty(t, tombstones)
+
+	require.NoError(t, WriteTombstones(opts, namespace, 1, nil))
+	tombstones, err = ReadTombstones(dir, namespace, 1)
+	require.NoError(t, err)
+	require.Empty(t, tombstones)
+}
+
+func TestReadTombstonesCorrupt(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		opts      = testDefaultOpts.SetFilePathPrefix(dir)
+		namespace = ident.StringID("ns")
+	)
+	require.NoError(t, WriteTombstones(opts, namespace, 1, map[string]Tombstone{
+		"foo": {DeletedAt: time.Now()},
+	}))
+
+	filePath := TombstonesFilePath(dir, namespace, 1)
+	data, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	data[len(data)/2] ^= 0xff
+	require.NoError(t, ioutil.WriteFile(filePath, data, 0644))
+
+	_, err = ReadTombstones(dir, namespace, 1)
+	require.Equal(t, errShardStateFileDigestMismatch, err)
+}
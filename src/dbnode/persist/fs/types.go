@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/runtime"
@@ -59,6 +60,9 @@ type DataWriterOpenOptions struct {
 	FileSetContentType persist.FileSetContentType
 	Identifier         FileSetFileIdentifier
 	BlockSize          time.Duration
+	// EncodingScheme is the scheme the series data is encoded with, it is
+	// persisted in the info file so readers can pick the right decoder.
+	EncodingScheme string
 	// Only used when writing snapshot files
 	Snapshot DataWriterSnapshotOptions
 }
@@ -144,6 +148,10 @@ type DataFileSetReader interface {
 	// Entries returns the count of entries in the volume
 	Entries() int
 
+	// EncodingScheme returns the scheme the series data in the volume is
+	// encoded with, the empty scheme refers to the default encoding scheme
+	EncodingScheme() string
+
 	// EntriesRead returns the position read into the volume
 	EntriesRead() int
 
@@ -155,8 +163,8 @@ type DataFileSetReader interface {
 type DataFileSetSeeker interface {
 	io.Closer
 
-	// Open opens the files for the given shard and version for reading
-	Open(namespace ident.ID, shard uint32, start time.Time) error
+	// Open opens the files for the given shard, block start and volume for reading
+	Open(namespace ident.ID, shard uint32, start time.Time, volumeIndex int) error
 
 	// SeekByID returns the data for specified ID provided the index was loaded upon open. An
 	// error will be returned if the index was not loaded or ID cannot be found.
@@ -179,6 +187,10 @@ type DataFileSetSeeker interface {
 	// Entries returns the count of entries in the volume
 	Entries() int
 
+	// EncodingScheme returns the scheme the series data in the volume is
+	// encoded with, the empty scheme refers to the default encoding scheme
+	EncodingScheme() string
+
 	// ConcurrentIDBloomFilter returns a concurrency-safe bloom filter that can
 	// be used to quickly disqualify ID's that definitely do not exist. I.E if the
 	// Test() method returns true, the ID may exist on disk, but if it returns
@@ -207,6 +219,9 @@ type ConcurrentDataFileSetSeeker interface {
 	// SeekIndexEntry is the same as in DataFileSetSeeker
 	SeekIndexEntry(id ident.ID) (IndexEntry, error)
 
+	// EncodingScheme is the same as in DataFileSetSeeker
+	EncodingScheme() string
+
 	// ConcurrentIDBloomFilter is the same as in DataFileSetSeeker
 	ConcurrentIDBloomFilter() *ManagedConcurrentBloomFilter
 }
@@ -231,6 +246,15 @@ type DataFileSetSeekerManager interface {
 	// ConcurrentIDBloomFilter returns a concurrent ID bloom filter for a given
 	// shard and block start time
 	ConcurrentIDBloomFilter(shard uint32, start time.Time) (*ManagedConcurrentBloomFilter, error)
+
+	// ReloadFileSet makes subsequent borrows for a given shard and block start
+	// time use the latest fileset volume on disk.
+	ReloadFileSet(shard uint32, start time.Time) error
+
+	// IsSupersededFileSetInUse returns whether seekers are still open for a
+	// fileset volume of a given shard and block start time that precedes the
+	// given volume index.
+	IsSupersededFileSetInUse(shard uint32, start time.Time, volumeIndex int) bool
 }
 
 // DataBlockRetriever provides a block retriever for TSDB file sets
@@ -312,6 +336,26 @@ type IndexFileSetReader interface {
 	Validate() error
 }
 
+// FileSetBackend is a secondary storage backend for fileset files, such as a
+// directory on a slow mount or an object store. Files are addressed by keys
+// that are their paths relative to the file path prefix.
+type FileSetBackend interface {
+	// Put stores the contents of the reader under the key, replacing any
+	// existing contents.
+	Put(key string, r io.Reader) error
+
+	// Get returns a reader for the contents stored under the key, the
+	// returned error satisfies os.IsNotExist if the key does not exist.
+	Get(key string) (io.ReadCloser, error)
+
+	// Exists returns whether contents are stored under the key.
+	Exists(key string) (bool, error)
+
+	// Delete removes the contents stored under the key, it is not an error
+	// if the key does not exist.
+	Delete(key string) error
+}
+
 // Options represents the options for filesystem persistence
 type Options interface {
 	// Validate will validate the options and return an error if not valid
@@ -373,6 +417,48 @@ type Options interface {
 	// rate to use for the index bloom filter size and k hashes estimation
 	IndexBloomFilterFalsePositivePercent() float64
 
+	// SetCompression sets the compression to use for the pages of the index
+	// and data files of written filesets
+	SetCompression(value persist.FileSetCompression) Options
+
+	// Compression returns the compression to use for the pages of the index
+	// and data files of written filesets
+	Compression() persist.FileSetCompression
+
+	// SetCompressionPageSize sets the minimum uncompressed size of the pages
+	// of the index and data files of compressed filesets
+	SetCompressionPageSize(value int) Options
+
+	// CompressionPageSize returns the minimum uncompressed size of the pages
+	// of the index and data files of compressed filesets
+	CompressionPageSize() int
+
+	// SetFileSetBackend sets the secondary backend that the data files of
+	// flushed blocks are moved to once they are older than the min block age,
+	// nil keeps all filesets on local disk
+	SetFileSetBackend(value FileSetBackend) Options
+
+	// FileSetBackend returns the secondary backend that the data files of
+	// flushed blocks are moved to once they are older than the min block age,
+	// nil keeps all filesets on local disk
+	FileSetBackend() FileSetBackend
+
+	// SetFileSetBackendMinBlockAge sets the age past the end of a block after
+	// which its data files are moved to the fileset backend
+	SetFileSetBackendMinBlockAge(value time.Duration) Options
+
+	// FileSetBackendMinBlockAge returns the age past the end of a block after
+	// which its data files are moved to the fileset backend
+	FileSetBackendMinBlockAge() time.Duration
+
+	// SetFileSetBackendCacheTTL sets how long seekers of blocks whose data
+	// files were fetched from the fileset backend are kept open after last use
+	SetFileSetBackendCacheTTL(value time.Duration) Options
+
+	// FileSetBackendCacheTTL returns how long seekers of blocks whose data
+	// files were fetched from the fileset backend are kept open after last use
+	FileSetBackendCacheTTL() time.Duration
+
 	// SetWriterBufferSize sets the buffer size for writing TSDB files
 	SetWriterBufferSize(value int) Options
 
@@ -459,4 +545,12 @@ type BlockRetrieverOptions interface {
 
 	// IdentifierPool returns the identifierPool
 	IdentifierPool() ident.Pool
+
+	// SetCodecRegistry sets the registry of encoding schemes data retrieved
+	// from volumes is decoded with
+	SetCodecRegistry(value encoding.CodecRegistry) BlockRetrieverOptions
+
+	// CodecRegistry returns the registry of encoding schemes data retrieved
+	// from volumes is decoded with
+	CodecRegistry() encoding.CodecRegistry
 }
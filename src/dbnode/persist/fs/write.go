@@ -53,6 +53,8 @@ type writer struct {
 
 	summariesPercent                float64
 	bloomFilterFalsePositivePercent float64
+	compression                     persist.FileSetCompression
+	compressionPageSize             int
 
 	infoFdWithDigest           digest.FdWithDigestWriter
 	indexFdWithDigest          digest.FdWithDigestWriter
@@ -65,12 +67,15 @@ type writer struct {
 
 	start              time.Time
 	snapshotTime       time.Time
+	encodingScheme     string
 	currIdx            int64
 	currOffset         int64
 	encoder            *msgpack.Encoder
 	digestBuf          digest.Buffer
 	singleCheckedBytes []checked.Bytes
 	tagEncoderPool     serialize.TagEncoderPool
+	page               []byte
+	compressedPage     []byte
 	err                error
 }
 
@@ -79,6 +84,7 @@ type indexEntry struct {
 	id              ident.ID
 	tags            ident.Tags
 	dataFileOffset  int64
+	dataPageOffset  int64
 	indexFileOffset int64
 	size            uint32
 	checksum        uint32
@@ -116,6 +122,8 @@ func NewWriter(opts Options) (DataFileSetWriter, error) {
 		newDirectoryMode:                opts.NewDirectoryMode(),
 		summariesPercent:                opts.IndexSummariesPercent(),
 		bloomFilterFalsePositivePercent: opts.IndexBloomFilterFalsePositivePercent(),
+		compression:                     opts.Compression(),
+		compressionPageSize:             opts.CompressionPageSize(),
 		infoFdWithDigest:                digest.NewFdWithDigestWriter(bufferSize),
 		indexFdWithDigest:               digest.NewFdWithDigestWriter(bufferSize),
 		summariesFdWithDigest:           digest.NewFdWithDigestWriter(bufferSize),
@@ -144,8 +152,10 @@ func (w *writer) Open(opts DataWriterOpenOptions) error {
 	w.blockSize = opts.BlockSize
 	w.start = blockStart
 	w.snapshotTime = opts.Snapshot.SnapshotTime
+	w.encodingScheme = opts.EncodingScheme
 	w.currIdx = 0
 	w.currOffset = 0
+	w.page = w.page[:0]
 	w.err = nil
 
 	var (
@@ -180,13 +190,13 @@ func (w *writer) Open(opts DataWriterOpenOptions) error {
 			return err
 		}
 
-		w.checkpointFilePath = filesetPathFromTime(shardDir, blockStart, checkpointFileSuffix)
-		infoFilepath = filesetPathFromTime(shardDir, blockStart, infoFileSuffix)
-		indexFilepath = filesetPathFromTime(shardDir, blockStart, indexFileSuffix)
-		summariesFilepath = filesetPathFromTime(shardDir, blockStart, summariesFileSuffix)
-		bloomFilterFilepath = filesetPathFromTime(shardDir, blockStart, bloomFilterFileSuffix)
-		dataFilepath = filesetPathFromTime(shardDir, blockStart, dataFileSuffix)
-		digestFilepath = filesetPathFromTime(shardDir, blockStart, digestFileSuffix)
+		w.checkpointFilePath = dataFileSetPathFromTimeAndIndex(shardDir, blockStart, opts.Identifier.VolumeIndex, checkpointFileSuffix)
+		infoFilepath = dataFileSetPathFromTimeAndIndex(shardDir, blockStart, opts.Identifier.VolumeIndex, infoFileSuffix)
+		indexFilepath = dataFileSetPathFromTimeAndIndex(shardDir, blockStart, opts.Identifier.VolumeIndex, indexFileSuffix)
+		summariesFilepath = dataFileSetPathFromTimeAndIndex(shardDir, blockStart, opts.Identifier.VolumeIndex, summariesFileSuffix)
+		bloomFilterFilepath = dataFileSetPathFromTimeAndIndex(shardDir, blockStart, opts.Identifier.VolumeIndex, bloomFilterFileSuffix)
+		dataFilepath = dataFileSetPathFromTimeAndIndex(shardDir, blockStart, opts.Identifier.VolumeIndex, dataFileSuffix)
+		digestFilepath = dataFileSetPathFromTimeAndIndex(shardDir, blockStart, opts.Identifier.VolumeIndex, digestFileSuffix)
 	default:
 		return fmt.Errorf("unable to open reader with fileset type: %s", opts.FileSetType)
 	}
@@ -220,6 +230,11 @@ func (w *writer) writeData(data []byte) error {
 	if len(data) == 0 {
 		return nil
 	}
+	if w.compressed() {
+		// Data is written out once the page is complete
+		w.page = append(w.page, data...)
+		return nil
+	}
 	written, err := w.dataFdWithDigest.Write(data)
 	if err != nil {
 		return err
@@ -228,6 +243,35 @@ func (w *writer) writeData(data []byte) error {
 	return nil
 }
 
+func (w *writer) compressed() bool {
+	return w.compression != persist.FileSetNoCompression
+}
+
+// writePage compresses and writes out the current page, it returns the
+// number of bytes written.
+func (w *writer) writePage(fd digest.FdWithDigestWriter) (int64, error) {
+	if len(w.page) == 0 {
+		return 0, nil
+	}
+	compressed, err := compressPage(w.compressedPage, w.compression, w.page)
+	if err != nil {
+		return 0, err
+	}
+	w.compressedPage = compressed
+	w.page = w.page[:0]
+	written, err := fd.Write(compressed)
+	return int64(written), err
+}
+
+func (w *writer) writeDataPage() error {
+	written, err := w.writePage(w.dataFdWithDigest)
+	if err != nil {
+		return err
+	}
+	w.currOffset += written
+	return nil
+}
+
 func (w *writer) Write(
 	id ident.ID,
 	tags ident.Tags,
@@ -280,6 +324,10 @@ func (w *writer) writeAll(
 		size:           uint32(size),
 		checksum:       checksum,
 	}
+	if w.compressed() {
+		// The current offset is that of the page the data is written to
+		entry.dataPageOffset = int64(len(w.page))
+	}
 	for _, d := range data {
 		if d == nil {
 			continue
@@ -292,6 +340,9 @@ func (w *writer) writeAll(
 	w.indexEntries = append(w.indexEntries, entry)
 	w.currIdx++
 
+	if w.compressed() && len(w.page) >= w.compressionPageSize {
+		return w.writeDataPage()
+	}
 	return nil
 }
 
@@ -314,6 +365,12 @@ func (w *writer) Close() error {
 }
 
 func (w *writer) close() error {
+	if w.compressed() {
+		if err := w.writeDataPage(); err != nil {
+			return err
+		}
+	}
+
 	if err := w.writeIndexRelatedFiles(); err != nil {
 		return err
 	}
@@ -415,6 +472,16 @@ func (w *writer) writeIndexFileContents(
 	)
 	defer tagsEncoder.Finalize()
 	for i := range w.indexEntries {
+		if w.compressed() && i%summaryEvery == 0 && len(w.page) >= w.compressionPageSize {
+			// Pages only end before entries that are summarized so that the
+			// entries between two summaries are always within the same page
+			written, err := w.writePage(w.indexFdWithDigest)
+			if err != nil {
+				return err
+			}
+			offset += written
+		}
+
 		id := w.indexEntries[i].id.Bytes()
 		// Need to check if i > 0 or we can never write an empty string ID
 		if i > 0 && bytes.Equal(id, prevID) {
@@ -443,6 +510,7 @@ func (w *writer) writeIndexFileContents(
 			Offset:      w.indexEntries[i].dataFileOffset,
 			Checksum:    int64(w.indexEntries[i].checksum),
 			EncodedTags: encodedTags,
+			PageOffset:  w.indexEntries[i].dataPageOffset,
 		}
 
 		w.encoder.Reset()
@@ -451,7 +519,9 @@ func (w *writer) writeIndexFileContents(
 		}
 
 		data := w.encoder.Bytes()
-		if _, err := w.indexFdWithDigest.Write(data); err != nil {
+		if w.compressed() {
+			w.page = append(w.page, data...)
+		} else if _, err := w.indexFdWithDigest.Write(data); err != nil {
 			return err
 		}
 
@@ -462,15 +532,24 @@ func (w *writer) writeIndexFileContents(
 
 		if i%summaryEvery == 0 {
 			// Capture the offset for when we write this summary back, only capture
-			// for every summary we'll actually write to avoid a few memcopies
+			// for every summary we'll actually write to avoid a few memcopies,
+			// for compressed files this is the offset of the current page
 			w.indexEntries[i].indexFileOffset = offset
 		}
 
-		offset += int64(len(data))
+		if !w.compressed() {
+			offset += int64(len(data))
+		}
 
 		prevID = id
 	}
 
+	if w.compressed() {
+		if _, err := w.writePage(w.indexFdWithDigest); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -528,6 +607,8 @@ func (w *writer) writeInfoFileContents(
 			NumElementsM: int64(bloomFilter.M()),
 			NumHashesK:   int64(bloomFilter.K()),
 		},
+		EncodingScheme: w.encodingScheme,
+		Compression:    w.compression,
 	}
 
 	w.encoder.Reset()
@@ -27,18 +27,23 @@ import (
 // MajorVersion is the major schema version for a set of fileset files,
 // this is only incremented when breaking changes are introduced and
 // tooling needs to upgrade older files to newer files before a server restart
-const MajorVersion = 1
+//
+// Version 2 introduced optional compression of the pages of the index and
+// data files, files written with version 1 are never compressed.
+const MajorVersion = 2
 
 // IndexInfo stores metadata information about block filesets
 type IndexInfo struct {
-	MajorVersion int64
-	BlockStart   int64
-	BlockSize    int64
-	Entries      int64
-	Summaries    IndexSummariesInfo
-	BloomFilter  IndexBloomFilterInfo
-	SnapshotTime int64
-	FileType     persist.FileSetType
+	MajorVersion   int64
+	BlockStart     int64
+	BlockSize      int64
+	Entries        int64
+	Summaries      IndexSummariesInfo
+	BloomFilter    IndexBloomFilterInfo
+	SnapshotTime   int64
+	FileType       persist.FileSetType
+	EncodingScheme string
+	Compression    persist.FileSetCompression
 }
 
 // IndexSummariesInfo stores metadata about the summaries
@@ -52,7 +57,9 @@ type IndexBloomFilterInfo struct {
 	NumHashesK   int64
 }
 
-// IndexEntry stores entry-level data indexing
+// IndexEntry stores entry-level data indexing, for compressed filesets the
+// offset is that of the page in the data file that contains the data and the
+// page offset is the offset of the data within the uncompressed page
 type IndexEntry struct {
 	Index       int64
 	ID          []byte
@@ -60,9 +67,12 @@ type IndexEntry struct {
 	Offset      int64
 	Checksum    int64
 	EncodedTags []byte
+	PageOffset  int64
 }
 
-// IndexSummary stores a summary of an index entry to lookup
+// IndexSummary stores a summary of an index entry to lookup, for compressed
+// filesets the index entry offset is that of the page in the index file that
+// contains the index entry
 type IndexSummary struct {
 	Index            int64
 	ID               []byte
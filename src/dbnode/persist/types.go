@@ -98,6 +98,10 @@ type DataPrepareOptions struct {
 	Shard             uint32
 	FileSetType       FileSetType
 	DeleteIfExists    bool
+	// ColdFlush is applicable to flushes, the data is written to the next
+	// volume of an already flushed fileset leaving the existing volumes
+	// in place for the caller to remove once the new volume is complete.
+	ColdFlush bool
 	// Snapshot options are applicable to snapshots (index yes, data yes)
 	Snapshot DataPrepareSnapshotOptions
 }
@@ -164,3 +168,36 @@ const (
 	// FileSetIndexContentType indicates that the fileset files contain time series index metadata
 	FileSetIndexContentType
 )
+
+// FileSetCompression is an enum that indicates how the pages of the index
+// and data files of a fileset are compressed
+type FileSetCompression int
+
+func (c FileSetCompression) String() string {
+	switch c {
+	case FileSetNoCompression:
+		return "none"
+	case FileSetSnappyCompression:
+		return "snappy"
+	}
+	return fmt.Sprintf("unknown: %d", c)
+}
+
+const (
+	// FileSetNoCompression indicates that the fileset files are not compressed
+	FileSetNoCompression FileSetCompression = iota
+	// FileSetSnappyCompression indicates that the pages of the index and data
+	// files are compressed with snappy
+	FileSetSnappyCompression
+)
+
+// ParseFileSetCompression parses a fileset compression from its string value.
+func ParseFileSetCompression(str string) (FileSetCompression, error) {
+	switch str {
+	case "", FileSetNoCompression.String():
+		return FileSetNoCompression, nil
+	case FileSetSnappyCompression.String():
+		return FileSetSnappyCompression, nil
+	}
+	return 0, fmt.Errorf("unknown fileset compression: %s", str)
+}
@@ -244,6 +244,10 @@ func Run(runOpts RunOptions) {
 	opts = opts.SetIndexOptions(
 		indexOpts.SetInsertMode(insertMode))
 
+	if quotasCfg := cfg.WriteQuotas; quotasCfg != nil {
+		opts = opts.SetWriteQuotaOptions(quotasCfg.NewOptions())
+	}
+
 	if tick := cfg.Tick; tick != nil {
 		runtimeOpts = runtimeOpts.
 			SetTickSeriesBatchSize(tick.SeriesBatchSize).
@@ -269,6 +273,11 @@ func Run(runOpts RunOptions) {
 		logger.Fatalf("could not parse new directory mode: %v", err)
 	}
 
+	compression, err := cfg.Filesystem.ParseCompression()
+	if err != nil {
+		logger.Fatalf("could not parse fileset compression: %v", err)
+	}
+
 	mmapCfg := cfg.Filesystem.MmapConfiguration()
 	shouldUseHugeTLB := mmapCfg.HugeTLB.Enabled
 	if shouldUseHugeTLB {
@@ -308,7 +317,21 @@ func Run(runOpts RunOptions) {
 		SetMmapHugeTLBThreshold(mmapCfg.HugeTLB.Threshold).
 		SetRuntimeOptionsManager(runtimeOptsMgr).
 		SetTagEncoderPool(tagEncoderPool).
-		SetTagDecoderPool(tagDecoderPool)
+		SetTagDecoderPool(tagDecoderPool).
+		SetCompression(compression)
+	if pageSize := cfg.Filesystem.CompressionPageSize; pageSize > 0 {
+		fsopts = fsopts.SetCompressionPageSize(pageSize)
+	}
+	if tieringCfg := cfg.Filesystem.Tiering; tieringCfg != nil {
+		backend := fs.NewDirectoryFileSetBackend(tieringCfg.Path,
+			newFileMode, newDirectoryMode)
+		fsopts = fsopts.
+			SetFileSetBackend(backend).
+			SetFileSetBackendMinBlockAge(tieringCfg.MinBlockAge)
+		if cacheTTL := tieringCfg.CacheTTL; cacheTTL > 0 {
+			fsopts = fsopts.SetFileSetBackendCacheTTL(cacheTTL)
+		}
+	}
 
 	var commitLogQueueSize int
 	specified := cfg.CommitLog.Queue.Size
@@ -331,6 +354,19 @@ func Run(runOpts RunOptions) {
 		SetBacklogQueueSize(commitLogQueueSize).
 		SetBlockSize(cfg.CommitLog.BlockSize))
 
+	if compactionCfg := cfg.Index.Compaction; compactionCfg != nil {
+		indexOpts := opts.IndexOptions()
+		compactor, err := compactionCfg.NewCompactor(
+			indexOpts.MemSegmentOptions().SetInstrumentOptions(iopts),
+			fsopts.FSTOptions())
+		if err != nil {
+			logger.Fatalf("could not create index compactor: %v", err)
+		}
+		opts = opts.SetIndexOptions(indexOpts.
+			SetCompactor(compactor).
+			SetCompactionPlannerOptions(compactionCfg.PlannerOptions()))
+	}
+
 	// Set the series cache policy
 	seriesCachePolicy := cfg.Cache.SeriesConfiguration().Policy
 	opts = opts.SetSeriesCachePolicy(seriesCachePolicy)
@@ -348,7 +384,8 @@ func Run(runOpts RunOptions) {
 		retrieverOpts := fs.NewBlockRetrieverOptions().
 			SetBytesPool(opts.BytesPool()).
 			SetSegmentReaderPool(opts.SegmentReaderPool()).
-			SetIdentifierPool(opts.IdentifierPool())
+			SetIdentifierPool(opts.IdentifierPool()).
+			SetCodecRegistry(opts.DatabaseBlockOptions().CodecRegistry())
 		if blockRetrieveCfg := cfg.BlockRetrieve; blockRetrieveCfg != nil {
 			retrieverOpts = retrieverOpts.
 				SetFetchConcurrency(blockRetrieveCfg.FetchConcurrency)
@@ -442,16 +479,22 @@ func Run(runOpts RunOptions) {
 			scope.SubScope("host-block-metadata-slice-pool")),
 		policy.HostBlockMetadataSlicePool.Capacity)
 
+	repairOpts := opts.RepairOptions().
+		SetAdminClient(m3dbClient).
+		SetRepairInterval(cfg.Repair.Interval).
+		SetRepairTimeOffset(cfg.Repair.Offset).
+		SetRepairTimeJitter(cfg.Repair.Jitter).
+		SetRepairThrottle(cfg.Repair.Throttle).
+		SetRepairCheckInterval(cfg.Repair.CheckInterval).
+		SetHostBlockMetadataSlicePool(hostBlockMetadataSlicePool)
+	if limitMbps := cfg.Repair.ThroughputLimitMbps; limitMbps > 0 {
+		repairOpts = repairOpts.SetRepairRateLimitOptions(
+			repairOpts.RepairRateLimitOptions().SetLimitMbps(limitMbps))
+	}
+
 	opts = opts.
 		SetRepairEnabled(cfg.Repair.Enabled).
-		SetRepairOptions(opts.RepairOptions().
-			SetAdminClient(m3dbClient).
-			SetRepairInterval(cfg.Repair.Interval).
-			SetRepairTimeOffset(cfg.Repair.Offset).
-			SetRepairTimeJitter(cfg.Repair.Jitter).
-			SetRepairThrottle(cfg.Repair.Throttle).
-			SetRepairCheckInterval(cfg.Repair.CheckInterval).
-			SetHostBlockMetadataSlicePool(hostBlockMetadataSlicePool))
+		SetRepairOptions(repairOpts)
 
 	// Set tchannelthrift options
 	blockMetadataPool := tchannelthrift.NewBlockMetadataPool(
@@ -25,6 +25,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/encoding/vdelta"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3x/clock"
@@ -55,6 +56,7 @@ type options struct {
 	bytesPool               pool.CheckedBytesPool
 	readerIteratorPool      encoding.ReaderIteratorPool
 	multiReaderIteratorPool encoding.MultiReaderIteratorPool
+	codecRegistry           encoding.CodecRegistry
 	wiredList               *WiredList
 }
 
@@ -75,6 +77,7 @@ func NewOptions() Options {
 		encoderPool:             encoderPool,
 		readerIteratorPool:      readerIteratorPool,
 		multiReaderIteratorPool: encoding.NewMultiReaderIteratorPool(nil),
+		codecRegistry:           NewDefaultCodecRegistry(),
 		segmentReaderPool:       segmentReaderPool,
 		bytesPool:               bytesPool,
 	}
@@ -105,6 +108,67 @@ func NewOptions() Options {
 	return o
 }
 
+// NewDefaultCodecRegistry returns a codec registry with the built in codecs
+// registered and m3tsz as the default codec.
+func NewDefaultCodecRegistry() encoding.CodecRegistry {
+	registry := encoding.NewCodecRegistry(m3tsz.NewCodec(m3tsz.DefaultIntOptimizationEnabled))
+	// NB: registering the built in codecs cannot fail as their schemes
+	// are distinct.
+	_ = registry.Register(vdelta.NewCodec())
+	return registry
+}
+
+// NewEncodingSchemeOptions returns a copy of the options with database block,
+// encoder and iterator pools that create blocks, encoders and iterators for
+// an encoding scheme, the options are returned as is for the default scheme.
+func NewEncodingSchemeOptions(
+	opts Options,
+	scheme string,
+	poolOpts pool.ObjectPoolOptions,
+) (Options, error) {
+	registry := opts.CodecRegistry()
+	codec, err := registry.Codec(scheme)
+	if err != nil {
+		return nil, err
+	}
+	if registry.IsDefault(scheme) {
+		return opts, nil
+	}
+
+	var (
+		blockPool               = NewDatabaseBlockPool(poolOpts)
+		encoderPool             = encoding.NewEncoderPool(poolOpts)
+		readerIteratorPool      = encoding.NewReaderIteratorPool(poolOpts)
+		multiReaderIteratorPool = encoding.NewMultiReaderIteratorPool(poolOpts)
+		encodingOpts            = encoding.NewOptions().
+					SetBytesPool(opts.BytesPool()).
+					SetEncoderPool(encoderPool).
+					SetReaderIteratorPool(readerIteratorPool).
+					SetSegmentReaderPool(opts.SegmentReaderPool())
+	)
+	opts = opts.
+		SetDatabaseBlockPool(blockPool).
+		SetEncoderPool(encoderPool).
+		SetReaderIteratorPool(readerIteratorPool).
+		SetMultiReaderIteratorPool(multiReaderIteratorPool)
+
+	blockPool.Init(func() DatabaseBlock {
+		return NewDatabaseBlock(timeZero, 0, ts.Segment{}, opts)
+	})
+	encoderPool.Init(func() encoding.Encoder {
+		return codec.NewEncoder(timeZero, nil, encodingOpts)
+	})
+	readerIteratorPool.Init(func(r io.Reader) encoding.ReaderIterator {
+		return codec.NewReaderIterator(r, encodingOpts)
+	})
+	multiReaderIteratorPool.Init(func(r io.Reader) encoding.ReaderIterator {
+		it := readerIteratorPool.Get()
+		it.Reset(r)
+		return it
+	})
+	return opts, nil
+}
+
 func (o *options) SetClockOptions(value clock.Options) Options {
 	opts := *o
 	opts.clockOpts = value
@@ -185,6 +249,16 @@ func (o *options) MultiReaderIteratorPool() encoding.MultiReaderIteratorPool {
 	return o.multiReaderIteratorPool
 }
 
+func (o *options) SetCodecRegistry(value encoding.CodecRegistry) Options {
+	opts := *o
+	opts.codecRegistry = value
+	return &opts
+}
+
+func (o *options) CodecRegistry() encoding.CodecRegistry {
+	return o.codecRegistry
+}
+
 func (o *options) SetSegmentReaderPool(value xio.SegmentReaderPool) Options {
 	opts := *o
 	opts.segmentReaderPool = value
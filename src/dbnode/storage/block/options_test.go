@@ -0,0 +1,82 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package block
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/encoding/vdelta"
+	"github.com/m3db/m3/src/dbnode/ts"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEncodingSchemeOptionsDefaultScheme(t *testing.T) {
+	opts := NewOptions()
+	for _, scheme := range []string{"", m3tsz.Scheme} {
+		schemeOpts, err := NewEncodingSchemeOptions(opts, scheme, nil)
+		require.NoError(t, err)
+		assert.Equal(t, opts, schemeOpts)
+	}
+}
+
+func TestNewEncodingSchemeOptionsUnknownScheme(t *testing.T) {
+	_, err := NewEncodingSchemeOptions(NewOptions(), "unknown", nil)
+	require.Error(t, err)
+}
+
+func TestNewEncodingSchemeOptionsRoundTrip(t *testing.T) {
+	opts, err := NewEncodingSchemeOptions(NewOptions(), vdelta.Scheme, nil)
+	require.NoError(t, err)
+
+	start := time.Now().Truncate(time.Hour)
+	encoder := opts.EncoderPool().Get()
+	encoder.Reset(start, 0)
+	for i := 0; i < 3; i++ {
+		dp := ts.Datapoint{Timestamp: start.Add(time.Duration(i) * time.Second), Value: float64(i)}
+		require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+	}
+	stream := encoder.Stream()
+	require.NotNil(t, stream)
+	encoder.Close()
+
+	iter := opts.ReaderIteratorPool().Get()
+	iter.Reset(stream)
+	defer iter.Close()
+
+	var i int
+	for iter.Next() {
+		dp, _, _ := iter.Current()
+		assert.True(t, start.Add(time.Duration(i)*time.Second).Equal(dp.Timestamp))
+		assert.Equal(t, float64(i), dp.Value)
+		i++
+	}
+	require.NoError(t, iter.Err())
+	assert.Equal(t, 3, i)
+
+	// Blocks from the pool must merge streams with the scheme's pools
+	block := opts.DatabaseBlockPool().Get()
+	assert.Equal(t, opts.EncoderPool(), block.(*dbBlock).opts.EncoderPool())
+}
@@ -275,6 +275,15 @@ type DatabaseBlockRetriever interface {
 	// to improve times when streaming a block.
 	CacheShardIndices(shards []uint32) error
 
+	// ReloadFileSet makes subsequent streams for a given shard and block
+	// start read from the latest fileset volume on disk.
+	ReloadFileSet(shard uint32, blockStart time.Time) error
+
+	// IsSupersededFileSetInUse returns whether a fileset volume for a given
+	// shard and block start that precedes the given volume index may still
+	// be read from.
+	IsSupersededFileSetInUse(shard uint32, blockStart time.Time, volumeIndex int) bool
+
 	// Stream will stream a block for a given shard, id and start.
 	Stream(
 		ctx context.Context,
@@ -427,6 +436,12 @@ type Options interface {
 	// MultiReaderIteratorPool returns the multiReaderIteratorPool
 	MultiReaderIteratorPool() encoding.MultiReaderIteratorPool
 
+	// SetCodecRegistry sets the registry of encoding schemes
+	SetCodecRegistry(value encoding.CodecRegistry) Options
+
+	// CodecRegistry returns the registry of encoding schemes
+	CodecRegistry() encoding.CodecRegistry
+
 	// SetSegmentReaderPool sets the contextPool
 	SetSegmentReaderPool(value xio.SegmentReaderPool) Options
 
@@ -183,6 +183,19 @@ func (s *commitLogSource) ReadData(
 		return result.NewDataBootstrapResult(), nil
 	}
 
+	// Blocks of namespaces using an encoding scheme other than the default
+	// must be created with pools for that scheme.
+	ropts, err := result.NewEncodingSchemeOptions(s.opts.ResultOptions(),
+		ns.Options().EncodingScheme())
+	if err != nil {
+		return nil, err
+	}
+	if ropts != s.opts.ResultOptions() {
+		source := *s
+		source.opts = s.opts.SetResultOptions(ropts)
+		s = &source
+	}
+
 	var (
 		fsOpts         = s.opts.CommitLogOptions().FilesystemOptions()
 		filePathPrefix = fsOpts.FilePathPrefix()
@@ -195,6 +208,13 @@ func (s *commitLogSource) ReadData(
 		return nil, err
 	}
 
+	// Datapoints of series deleted since they were written are skipped.
+	tombstonesByShard, err := s.tombstonesByShard(
+		ns.ID(), filePathPrefix, shardsTimeRanges)
+	if err != nil {
+		return nil, err
+	}
+
 	var (
 		bOpts     = s.opts.ResultOptions()
 		blOpts    = bOpts.DatabaseBlockOptions()
@@ -277,7 +297,8 @@ func (s *commitLogSource) ReadData(
 	// Read / M3TSZ encode all the datapoints in the commit log that we need to read.
 	for iter.Next() {
 		series, dp, unit, annotation := iter.Current()
-		if !s.shouldEncodeForData(shardDataByShard, blockSize, series, dp.Timestamp) {
+		if isDeleted(tombstonesByShard, series) ||
+			!s.shouldEncodeForData(shardDataByShard, blockSize, series, dp.Timestamp) {
 			datapointsSkipped++
 			continue
 		}
@@ -352,6 +373,42 @@ func (s *commitLogSource) snapshotFilesByShard(
 	return snapshotFilesByShard, nil
 }
 
+// tombstonesByShard reads the tombstones persisted by each of the shards for
+// the series deleted from them.
+func (s *commitLogSource) tombstonesByShard(
+	nsID ident.ID,
+	filePathPrefix string,
+	shardsTimeRanges result.ShardTimeRanges,
+) (map[uint32]map[string]fs.Tombstone, error) {
+	tombstonesByShard := map[uint32]map[string]fs.Tombstone{}
+	for shard := range shardsTimeRanges {
+		tombstones, err := fs.ReadTombstones(filePathPrefix, nsID, shard)
+		if err != nil {
+			return nil, err
+		}
+		if len(tombstones) > 0 {
+			tombstonesByShard[shard] = tombstones
+		}
+	}
+
+	return tombstonesByShard, nil
+}
+
+// isDeleted returns whether the series has been deleted. Writes to a series
+// after it has been deleted remove its tombstone, so all of the datapoints
+// of a series with a tombstone were written before it was deleted.
+func isDeleted(
+	tombstonesByShard map[uint32]map[string]fs.Tombstone,
+	series commitlog.Series,
+) bool {
+	tombstones, ok := tombstonesByShard[series.Shard]
+	if !ok {
+		return false
+	}
+	_, deleted := tombstones[string(series.ID.Bytes())]
+	return deleted
+}
+
 func (s *commitLogSource) newShardDataByShard(
 	shardsTimeRanges result.ShardTimeRanges,
 	numShards uint32,
@@ -1279,6 +1336,13 @@ func (s *commitLogSource) ReadIndex(
 		return nil, err
 	}
 
+	// Datapoints of series deleted since they were written are skipped.
+	tombstonesByShard, err := s.tombstonesByShard(
+		ns.ID(), filePathPrefix, shardsTimeRanges)
+	if err != nil {
+		return nil, err
+	}
+
 	var (
 		highestShard = s.findHighestShard(shardsTimeRanges)
 		// +1 so we can use the shard number as an index throughout without constantly
@@ -1349,6 +1413,9 @@ func (s *commitLogSource) ReadIndex(
 
 	for iter.Next() {
 		series, dp, _, _ := iter.Current()
+		if isDeleted(tombstonesByShard, series) {
+			continue
+		}
 
 		s.maybeAddToIndex(
 			series.ID, series.Tags, series.Shard, highestShard, dp.Timestamp, bootstrapRangesByShard,
@@ -23,6 +23,8 @@ package commitlog
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
 	"reflect"
 	"sort"
 	"testing"
@@ -171,6 +173,54 @@ func TestReadOrderedValues(t *testing.T) {
 		values[:4], blockSize, res.ShardResults(), opts))
 }
 
+func TestReadSkipsDeletedSeries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testdir")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	opts := testDefaultOpts
+	fsOpts := opts.CommitLogOptions().FilesystemOptions().SetFilePathPrefix(dir)
+	opts = opts.SetCommitLogOptions(opts.CommitLogOptions().SetFilesystemOptions(fsOpts))
+	md := testNsMetadata(t)
+	src := newCommitLogSource(opts, fs.Inspection{}).(*commitLogSource)
+
+	blockSize := md.Options().RetentionOptions().BlockSize()
+	now := time.Now()
+	start := now.Truncate(blockSize).Add(-blockSize)
+	end := now.Truncate(blockSize)
+
+	ranges := xtime.Ranges{}
+	ranges = ranges.AddRange(xtime.Range{
+		Start: start,
+		End:   end,
+	})
+
+	require.NoError(t, fs.WriteTombstones(fsOpts, testNamespaceID, 0, map[string]fs.Tombstone{
+		"foo": {DeletedAt: now},
+	}))
+
+	foo := commitlog.Series{Namespace: testNamespaceID, Shard: 0, ID: ident.StringID("foo")}
+	bar := commitlog.Series{Namespace: testNamespaceID, Shard: 0, ID: ident.StringID("bar")}
+
+	values := []testValue{
+		{bar, start, 1.0, xtime.Second, nil},
+		{bar, start.Add(1 * time.Minute), 2.0, xtime.Second, nil},
+		// "foo" has been deleted and should not be returned
+		{foo, start.Add(2 * time.Minute), 1.0, xtime.Second, nil},
+	}
+	src.newIteratorFn = func(_ commitlog.IteratorOpts) (commitlog.Iterator, error) {
+		return newTestCommitLogIterator(values, nil), nil
+	}
+
+	targetRanges := result.ShardTimeRanges{0: ranges}
+	res, err := src.ReadData(md, targetRanges, testDefaultRunOpts)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, 0, len(res.Unfulfilled()))
+	require.NoError(t, verifyShardResultsAreCorrect(
+		values[:2], blockSize, res.ShardResults(), opts))
+}
+
 func TestReadUnorderedValues(t *testing.T) {
 	opts := testDefaultOpts
 	md := testNsMetadata(t)
@@ -26,6 +26,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/storage/block"
@@ -54,6 +55,14 @@ const (
 	bootstrapIndexRunType
 )
 
+// volumeTranscoder transcodes the data of a volume persisted with an encoding
+// scheme other than the scheme of the namespace.
+type volumeTranscoder struct {
+	from encoding.Codec
+	to   encoding.Codec
+	opts encoding.Options
+}
+
 type newDataFileSetReaderFn func(
 	bytesPool pool.CheckedBytesPool,
 	opts fs.Options,
@@ -341,9 +350,10 @@ func (s *fileSystemSource) newShardReaders(
 
 		openOpts := fs.DataReaderOpenOptions{
 			Identifier: fs.FileSetFileIdentifier{
-				Namespace:  ns.ID(),
-				Shard:      shard,
-				BlockStart: blockStart,
+				Namespace:   ns.ID(),
+				Shard:       shard,
+				BlockStart:  blockStart,
+				VolumeIndex: result.ID.VolumeIndex,
 			},
 		}
 		if err := r.Open(openOpts); err != nil {
@@ -368,13 +378,13 @@ func (s *fileSystemSource) bootstrapFromReaders(
 	ns namespace.Metadata,
 	run runType,
 	runOpts bootstrap.RunOptions,
+	resultOpts result.Options,
 	readerPool *readerPool,
 	retriever block.DatabaseBlockRetriever,
 	readersCh <-chan timeWindowReaders,
 ) *runResult {
 	var (
 		runResult         = newRunResult()
-		resultOpts        = s.opts.ResultOptions()
 		shardRetrieverMgr block.DatabaseShardBlockRetrieverManager
 		wg                sync.WaitGroup
 		processors        xsync.WorkerPool
@@ -486,7 +496,8 @@ func (s *fileSystemSource) loadShardReadersDataIntoShardResult(
 	readerPool *readerPool,
 ) {
 	var (
-		blockPool         = ropts.DatabaseBlockOptions().DatabaseBlockPool()
+		blockOpts         = ropts.DatabaseBlockOptions()
+		blockPool         = blockOpts.DatabaseBlockPool()
 		seriesCachePolicy = ropts.SeriesCachePolicy()
 		indexBlockSegment segment.MutableSegment
 		timesWithErrors   []time.Time
@@ -535,12 +546,20 @@ func (s *fileSystemSource) loadShardReadersDataIntoShardResult(
 				panic(fmt.Errorf("invalid run type: %d", run))
 			}
 
+			// Data of volumes persisted with an encoding scheme other than the
+			// scheme of the namespace is transcoded, so that bootstrapped blocks
+			// are always encoded with the scheme of the namespace
+			var transcoder *volumeTranscoder
+			if err == nil && run == bootstrapDataRunType {
+				transcoder, err = newVolumeTranscoder(blockOpts, ns, r)
+			}
+
 			numEntries := r.Entries()
 			for i := 0; err == nil && i < numEntries; i++ {
 				switch run {
 				case bootstrapDataRunType:
 					err = s.readNextEntryAndRecordBlock(r, runResult, start, blockSize, shardResult,
-						shardRetriever, blockPool, seriesCachePolicy)
+						shardRetriever, blockPool, seriesCachePolicy, transcoder)
 				case bootstrapIndexRunType:
 					// We can just read the entry and index if performing an index run
 					err = s.readNextEntryAndIndex(r, runResult, indexBlockSegment)
@@ -633,6 +652,7 @@ func (s *fileSystemSource) readNextEntryAndRecordBlock(
 	shardRetriever block.DatabaseShardBlockRetriever,
 	blockPool block.DatabaseBlockPool,
 	seriesCachePolicy series.CachePolicy,
+	transcoder *volumeTranscoder,
 ) error {
 	var (
 		seriesBlock = blockPool.Get()
@@ -682,6 +702,15 @@ func (s *fileSystemSource) readNextEntryAndRecordBlock(
 	switch seriesCachePolicy {
 	case series.CacheAll:
 		seg := ts.NewSegment(data, nil, ts.FinalizeHead)
+		if transcoder != nil {
+			transcoded, err := encoding.Transcode(seg, transcoder.from, transcoder.to,
+				blockStart, transcoder.opts)
+			seg.Finalize()
+			if err != nil {
+				return fmt.Errorf("unable to transcode data: %v", err)
+			}
+			seg = transcoded
+		}
 		seriesBlock.Reset(blockStart, blockSize, seg)
 	case series.CacheAllMetadata:
 		metadata := block.RetrievableBlockMetadata{
@@ -702,6 +731,36 @@ func (s *fileSystemSource) readNextEntryAndRecordBlock(
 	return nil
 }
 
+// newVolumeTranscoder returns a transcoder for the data of the volume of the
+// reader, or nil if it is encoded with the scheme of the namespace. Only data
+// read in full is transcoded, retrievable blocks are transcoded by the block
+// retriever when they are retrieved.
+func newVolumeTranscoder(
+	opts block.Options,
+	ns namespace.Metadata,
+	r fs.DataFileSetReader,
+) (*volumeTranscoder, error) {
+	registry := opts.CodecRegistry()
+	from, err := registry.Codec(r.EncodingScheme())
+	if err != nil {
+		return nil, err
+	}
+	to, err := registry.Codec(ns.Options().EncodingScheme())
+	if err != nil {
+		return nil, err
+	}
+	if from.Scheme() == to.Scheme() {
+		return nil, nil
+	}
+	return &volumeTranscoder{
+		from: from,
+		to:   to,
+		opts: encoding.NewOptions().
+			SetBytesPool(opts.BytesPool()).
+			SetSegmentReaderPool(opts.SegmentReaderPool()),
+	}, nil
+}
+
 func (s *fileSystemSource) readNextEntryAndIndex(
 	r fs.DataFileSetReader,
 	runResult *runResult,
@@ -990,6 +1049,18 @@ func (s *fileSystemSource) read(
 		}
 	}
 
+	resultOpts := s.opts.ResultOptions()
+	if run == bootstrapDataRunType {
+		// Blocks of namespaces using an encoding scheme other than the default
+		// must be created with pools for that scheme.
+		var err error
+		resultOpts, err = result.NewEncodingSchemeOptions(resultOpts,
+			md.Options().EncodingScheme())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Create a reader pool once per bootstrap as we don't really want to
 	// allocate and keep around readers outside of the bootstrapping process,
 	// hence why its created on demand each time.
@@ -998,7 +1069,7 @@ func (s *fileSystemSource) read(
 	go s.enqueueReaders(md, run, runOpts, shardsTimeRanges,
 		readerPool, readersCh)
 	bootstrapFromDataReadersResult := s.bootstrapFromReaders(md, run, runOpts,
-		readerPool, blockRetriever, readersCh)
+		resultOpts, readerPool, blockRetriever, readersCh)
 
 	// Merge any existing results if necessary
 	setOrMergeResult(bootstrapFromDataReadersResult)
@@ -102,6 +102,19 @@ func (s *peersSource) ReadData(
 		return result.NewDataBootstrapResult(), nil
 	}
 
+	// Blocks of namespaces using an encoding scheme other than the default
+	// must be created with pools for that scheme.
+	ropts, err := result.NewEncodingSchemeOptions(s.opts.ResultOptions(),
+		nsMetadata.Options().EncodingScheme())
+	if err != nil {
+		return nil, err
+	}
+	if ropts != s.opts.ResultOptions() {
+		source := *s
+		source.opts = s.opts.SetResultOptions(ropts)
+		s = &source
+	}
+
 	var (
 		namespace         = nsMetadata.ID()
 		blockRetriever    block.DatabaseBlockRetriever
@@ -25,6 +25,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3x/instrument"
+	"github.com/m3db/m3x/pool"
 )
 
 const (
@@ -52,6 +53,24 @@ func NewOptions() Options {
 	}
 }
 
+// NewEncodingSchemeOptions returns a copy of the options with database block
+// options that create blocks, encoders and iterators for an encoding scheme,
+// the options are returned as is for the default scheme.
+func NewEncodingSchemeOptions(opts Options, scheme string) (Options, error) {
+	blockOpts := opts.DatabaseBlockOptions()
+	if blockOpts.CodecRegistry().IsDefault(scheme) {
+		return opts, nil
+	}
+
+	poolOpts := pool.NewObjectPoolOptions().
+		SetInstrumentOptions(opts.InstrumentOptions())
+	blockOpts, err := block.NewEncodingSchemeOptions(blockOpts, scheme, poolOpts)
+	if err != nil {
+		return nil, err
+	}
+	return opts.SetDatabaseBlockOptions(blockOpts), nil
+}
+
 func (o *options) SetClockOptions(value clock.Options) Options {
 	opts := *o
 	opts.clockOpts = value
@@ -41,6 +41,10 @@ type deleteFilesFn func(files []string) error
 
 type deleteInactiveDirectoriesFn func(parentDirPath string, activeDirNames []string) error
 
+type dataFilesFn func(filePathPrefix string, namespace ident.ID, shard uint32) (fs.FileSetFilesSlice, error)
+
+type tierDataFileSetFn func(filePathPrefix string, backend fs.FileSetBackend, id fs.FileSetFileIdentifier) error
+
 type cleanupManager struct {
 	sync.RWMutex
 
@@ -52,6 +56,8 @@ type cleanupManager struct {
 	commitLogFilesFn            commitLogFilesFn
 	deleteFilesFn               deleteFilesFn
 	deleteInactiveDirectoriesFn deleteInactiveDirectoriesFn
+	dataFilesFn                 dataFilesFn
+	tierDataFileSetFn           tierDataFileSetFn
 	cleanupInProgress           bool
 	status                      tally.Gauge
 }
@@ -70,6 +76,8 @@ func newCleanupManager(database database, scope tally.Scope) databaseCleanupMana
 		commitLogFilesFn:            commitlog.Files,
 		deleteFilesFn:               fs.DeleteFiles,
 		deleteInactiveDirectoriesFn: fs.DeleteInactiveDirectories,
+		dataFilesFn:                 fs.DataFiles,
+		tierDataFileSetFn:           fs.TierDataFileSet,
 		status: scope.Gauge("cleanup"),
 	}
 }
@@ -91,6 +99,16 @@ func (m *cleanupManager) Cleanup(t time.Time) error {
 			"encountered errors when cleaning up data files for %v: %v", t, err))
 	}
 
+	if err := m.cleanupSupersededDataFiles(); err != nil {
+		multiErr = multiErr.Add(fmt.Errorf(
+			"encountered errors when cleaning up superseded data files for %v: %v", t, err))
+	}
+
+	if err := m.tierDataFiles(t); err != nil {
+		multiErr = multiErr.Add(fmt.Errorf(
+			"encountered errors when tiering data files for %v: %v", t, err))
+	}
+
 	if err := m.cleanupExpiredIndexFiles(t); err != nil {
 		multiErr = multiErr.Add(fmt.Errorf(
 			"encountered errors when cleaning up index files for %v: %v", t, err))
@@ -209,6 +227,61 @@ func (m *cleanupManager) cleanupExpiredDataFiles(t time.Time) error {
 	return multiErr.FinalError()
 }
 
+// cleanupSupersededDataFiles deletes the fileset volumes that have been
+// superseded by volumes written by cold flushes and repairs.
+func (m *cleanupManager) cleanupSupersededDataFiles() error {
+	multiErr := xerrors.NewMultiError()
+	namespaces, err := m.database.GetOwnedNamespaces()
+	if err != nil {
+		return err
+	}
+	for _, n := range namespaces {
+		if !n.Options().CleanupEnabled() {
+			continue
+		}
+		for _, shard := range n.GetOwnedShards() {
+			multiErr = multiErr.Add(shard.CleanupSupersededFileSets())
+		}
+	}
+	return multiErr.FinalError()
+}
+
+// tierDataFiles moves the data files of flushed blocks that are older than
+// the fileset backend min block age to the fileset backend.
+func (m *cleanupManager) tierDataFiles(t time.Time) error {
+	fsOpts := m.opts.CommitLogOptions().FilesystemOptions()
+	backend := fsOpts.FileSetBackend()
+	if backend == nil {
+		return nil
+	}
+
+	namespaces, err := m.database.GetOwnedNamespaces()
+	if err != nil {
+		return err
+	}
+	multiErr := xerrors.NewMultiError()
+	for _, n := range namespaces {
+		blockSize := n.Options().RetentionOptions().BlockSize()
+		for _, s := range n.GetOwnedShards() {
+			filesets, err := m.dataFilesFn(m.filePathPrefix, n.ID(), s.ID())
+			if err != nil {
+				multiErr = multiErr.Add(err)
+				continue
+			}
+			for _, fileset := range filesets {
+				// Only complete filesets are tiered so that a fileset that is
+				// still being written is never moved
+				if !fileset.HasCheckpointFile() ||
+					!fs.IsTieredBlock(fsOpts, fileset.ID.BlockStart, blockSize, t) {
+					continue
+				}
+				multiErr = multiErr.Add(m.tierDataFileSetFn(m.filePathPrefix, backend, fileset.ID))
+			}
+		}
+	}
+	return multiErr.FinalError()
+}
+
 func (m *cleanupManager) cleanupExpiredIndexFiles(t time.Time) error {
 	namespaces, err := m.database.GetOwnedNamespaces()
 	if err != nil {
@@ -291,6 +364,13 @@ func (m *cleanupManager) commitLogTimes(t time.Time) ([]commitlog.File, error) {
 
 	shouldCleanupFile := func(start time.Time, duration time.Duration) (bool, error) {
 		for _, ns := range namespaces {
+			if ns.Options().ColdWritesEnabled() &&
+				!ns.ColdFlushedUntil().After(start.Add(duration)) {
+				// The commit log file may contain cold writes for any block in
+				// retention which are only persisted once cold flushed.
+				return false, nil
+			}
+
 			var (
 				ropts                      = ns.Options().RetentionOptions()
 				nsBlocksStart, nsBlocksEnd = commitLogNamespaceBlockTimes(start, duration, ropts)
@@ -27,6 +27,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
@@ -167,6 +168,7 @@ func TestCleanupDataAndSnapshotFileSetFiles(t *testing.T) {
 	expectedEarliestToRetain := retention.FlushTimeStart(ns.Options().RetentionOptions(), ts)
 	shard.EXPECT().CleanupExpiredFileSets(expectedEarliestToRetain).Return(nil)
 	shard.EXPECT().CleanupSnapshots(expectedEarliestToRetain)
+	shard.EXPECT().CleanupSupersededFileSets().Return(nil)
 	shard.EXPECT().ID().Return(uint32(0)).AnyTimes()
 	ns.EXPECT().GetOwnedShards().Return([]databaseShard{shard}).AnyTimes()
 	ns.EXPECT().ID().Return(ident.StringID("nsID")).AnyTimes()
@@ -180,6 +182,80 @@ func TestCleanupDataAndSnapshotFileSetFiles(t *testing.T) {
 	require.NoError(t, mgr.Cleanup(ts))
 }
 
+func TestCleanupManagerTierDataFiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := timeFor(36000)
+	blockSize := 3600 * time.Second
+	rOpts := retention.NewOptions().
+		SetRetentionPeriod(21600 * time.Second).
+		SetBlockSize(blockSize)
+	nsOpts := namespace.NewOptions().SetRetentionOptions(rOpts)
+
+	shard := NewMockdatabaseShard(ctrl)
+	shard.EXPECT().ID().Return(uint32(3)).AnyTimes()
+	ns := NewMockdatabaseNamespace(ctrl)
+	ns.EXPECT().ID().Return(ident.StringID("ns")).AnyTimes()
+	ns.EXPECT().Options().Return(nsOpts).AnyTimes()
+	ns.EXPECT().GetOwnedShards().Return([]databaseShard{shard}).AnyTimes()
+	namespaces := []databaseNamespace{ns}
+
+	db := newMockdatabase(ctrl, namespaces...)
+	db.EXPECT().GetOwnedNamespaces().Return(namespaces, nil).AnyTimes()
+	mgr := newCleanupManager(db, tally.NoopScope).(*cleanupManager)
+
+	backend := fs.NewDirectoryFileSetBackend("/tiered", 0666, 0755)
+	clOpts := mgr.opts.CommitLogOptions()
+	mgr.opts = mgr.opts.SetCommitLogOptions(clOpts.SetFilesystemOptions(
+		clOpts.FilesystemOptions().
+			SetFileSetBackend(backend).
+			SetFileSetBackendMinBlockAge(2 * blockSize)))
+
+	fileSet := func(blockStart time.Time, complete bool) fs.FileSetFile {
+		fileSet := fs.FileSetFile{
+			ID: fs.FileSetFileIdentifier{
+				Namespace:  ident.StringID("ns"),
+				Shard:      3,
+				BlockStart: blockStart,
+			},
+			AbsoluteFilepaths: []string{"fileset-data.db"},
+		}
+		if complete {
+			fileSet.AbsoluteFilepaths = append(fileSet.AbsoluteFilepaths,
+				"fileset-checkpoint.db")
+		}
+		return fileSet
+	}
+	var (
+		tiered     = fileSet(ts.Add(-3*blockSize), true)
+		incomplete = fileSet(ts.Add(-4*blockSize), false)
+		recent     = fileSet(ts.Add(-2*blockSize), true)
+	)
+	mgr.dataFilesFn = func(
+		_ string,
+		namespace ident.ID,
+		shard uint32,
+	) (fs.FileSetFilesSlice, error) {
+		require.Equal(t, "ns", namespace.String())
+		require.Equal(t, uint32(3), shard)
+		return fs.FileSetFilesSlice{incomplete, tiered, recent}, nil
+	}
+	var tieredIDs []fs.FileSetFileIdentifier
+	mgr.tierDataFileSetFn = func(
+		_ string,
+		b fs.FileSetBackend,
+		id fs.FileSetFileIdentifier,
+	) error {
+		require.Equal(t, backend, b)
+		tieredIDs = append(tieredIDs, id)
+		return nil
+	}
+
+	require.NoError(t, mgr.tierDataFiles(ts))
+	require.Equal(t, []fs.FileSetFileIdentifier{tiered.ID}, tieredIDs)
+}
+
 type deleteInactiveDirectoriesCall struct {
 	parentDirPath  string
 	activeDirNames []string
@@ -431,6 +507,7 @@ func newCleanupManagerCommitLogTimesTest(t *testing.T, ctrl *gomock.Controller)
 	)
 	no := namespace.NewMockOptions(ctrl)
 	no.EXPECT().RetentionOptions().Return(rOpts).AnyTimes()
+	no.EXPECT().ColdWritesEnabled().Return(false).AnyTimes()
 
 	ns := NewMockdatabaseNamespace(ctrl)
 	ns.EXPECT().Options().Return(no).AnyTimes()
@@ -457,6 +534,7 @@ func newCleanupManagerCommitLogTimesTestMultiNS(
 	)
 	no := namespace.NewMockOptions(ctrl)
 	no.EXPECT().RetentionOptions().Return(rOpts).AnyTimes()
+	no.EXPECT().ColdWritesEnabled().Return(false).AnyTimes()
 
 	ns1 := NewMockdatabaseNamespace(ctrl)
 	ns1.EXPECT().Options().Return(no).AnyTimes()
@@ -583,6 +661,46 @@ func TestCleanupManagerCommitLogTimesAllPendingFlush(t *testing.T) {
 	require.Equal(t, 0, len(filesToCleanup))
 }
 
+func TestCleanupManagerCommitLogTimesPendingColdFlush(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rOpts := retention.NewOptions().
+		SetRetentionPeriod(30 * time.Second).
+		SetBufferPast(0 * time.Second).
+		SetBufferFuture(0 * time.Second).
+		SetBlockSize(10 * time.Second)
+	no := namespace.NewMockOptions(ctrl)
+	no.EXPECT().RetentionOptions().Return(rOpts).AnyTimes()
+	no.EXPECT().ColdWritesEnabled().Return(true).AnyTimes()
+
+	ns := NewMockdatabaseNamespace(ctrl)
+	ns.EXPECT().Options().Return(no).AnyTimes()
+	ns.EXPECT().ColdFlushedUntil().Return(time20.Add(5 * time.Second)).AnyTimes()
+
+	db := newMockdatabase(ctrl, ns)
+	mgr := newCleanupManager(db, tally.NoopScope).(*cleanupManager)
+	mgr.opts = mgr.opts.SetCommitLogOptions(
+		mgr.opts.CommitLogOptions().
+			SetBlockSize(rOpts.BlockSize()))
+	mgr.commitLogFilesFn = func(_ commitlog.Options) ([]commitlog.File, error) {
+		return []commitlog.File{
+			commitlog.File{Start: time10, Duration: commitLogBlockSize},
+			commitlog.File{Start: time20, Duration: commitLogBlockSize},
+			commitlog.File{Start: time30, Duration: commitLogBlockSize},
+		}, nil
+	}
+
+	// Only the file that ends before the last complete cold flush is
+	// checked for pending warm flushes.
+	ns.EXPECT().NeedsFlush(time10, time20).Return(false)
+
+	filesToCleanup, err := mgr.commitLogTimes(currentTime)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(filesToCleanup))
+	require.True(t, contains(filesToCleanup, time10))
+}
+
 func timeFor(s int64) time.Time {
 	return time.Unix(s, 0)
 }
@@ -104,6 +104,8 @@ type databaseMetrics struct {
 	unknownNamespaceFetchBlocks         tally.Counter
 	unknownNamespaceFetchBlocksMetadata tally.Counter
 	unknownNamespaceQueryIDs            tally.Counter
+	unknownNamespaceAggregateQuery      tally.Counter
+	unknownNamespaceDeleteTagged        tally.Counter
 	errQueryIDsIndexDisabled            tally.Counter
 	errWriteTaggedIndexDisabled         tally.Counter
 }
@@ -118,6 +120,8 @@ func newDatabaseMetrics(scope tally.Scope) databaseMetrics {
 		unknownNamespaceFetchBlocks:         unknownNamespaceScope.Counter("fetch-blocks"),
 		unknownNamespaceFetchBlocksMetadata: unknownNamespaceScope.Counter("fetch-blocks-metadata"),
 		unknownNamespaceQueryIDs:            unknownNamespaceScope.Counter("query-ids"),
+		unknownNamespaceAggregateQuery:      unknownNamespaceScope.Counter("aggregate-query"),
+		unknownNamespaceDeleteTagged:        unknownNamespaceScope.Counter("delete-tagged"),
 		errQueryIDsIndexDisabled:            indexDisabledScope.Counter("err-query-ids"),
 		errWriteTaggedIndexDisabled:         indexDisabledScope.Counter("err-write-tagged"),
 	}
@@ -544,6 +548,46 @@ func (d *db) QueryIDs(
 	return queryResults, err
 }
 
+func (d *db) AggregateQuery(
+	ctx context.Context,
+	namespace ident.ID,
+	query index.Query,
+	opts index.AggregationOptions,
+) (index.AggregateQueryResult, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		d.metrics.unknownNamespaceAggregateQuery.Inc(1)
+		return index.AggregateQueryResult{}, err
+	}
+
+	var (
+		wg               = sync.WaitGroup{}
+		aggregateResults index.AggregateQueryResult
+	)
+	wg.Add(1)
+	d.opts.QueryIDsWorkerPool().Go(func() {
+		aggregateResults, err = n.AggregateQuery(ctx, query, opts)
+		wg.Done()
+	})
+	wg.Wait()
+	return aggregateResults, err
+}
+
+func (d *db) DeleteTagged(
+	ctx context.Context,
+	namespace ident.ID,
+	query index.Query,
+	opts index.QueryOptions,
+) (map[uint32]int64, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		d.metrics.unknownNamespaceDeleteTagged.Inc(1)
+		return nil, err
+	}
+
+	return n.DeleteTagged(ctx, query, opts)
+}
+
 func (d *db) ReadEncoded(
 	ctx context.Context,
 	namespace ident.ID,
@@ -628,6 +672,20 @@ func (d *db) Repair() error {
 	return d.mediator.Repair()
 }
 
+func (d *db) FlushAndSnapshot(fn func() error) error {
+	// NB: file operations are disabled for the duration of the forced run and
+	// fn so that the ongoing tick does not begin a concurrent cleanup or flush.
+	d.mediator.DisableFileOps()
+	defer d.mediator.EnableFileOps()
+	if err := d.mediator.FlushAndSnapshot(); err != nil {
+		return err
+	}
+	if fn == nil {
+		return nil
+	}
+	return fn()
+}
+
 func (d *db) Truncate(namespace ident.ID) (int64, error) {
 	n, err := d.namespaceFor(namespace)
 	if err != nil {
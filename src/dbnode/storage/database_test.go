@@ -515,6 +515,57 @@ func TestDatabaseBootstrappedAssignShardSet(t *testing.T) {
 	wg.Wait()
 }
 
+func TestDatabaseFlushAndSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	d, mapCh, _ := newTestDatabase(t, ctrl, Bootstrapped)
+	defer func() {
+		close(mapCh)
+	}()
+
+	mediator := NewMockdatabaseMediator(ctrl)
+	var called bool
+	gomock.InOrder(
+		mediator.EXPECT().DisableFileOps(),
+		mediator.EXPECT().FlushAndSnapshot().Return(nil),
+		mediator.EXPECT().EnableFileOps().Do(func() {
+			// File operations must remain disabled until fn returns.
+			require.True(t, called)
+		}),
+	)
+	d.mediator = mediator
+
+	require.NoError(t, d.FlushAndSnapshot(func() error {
+		called = true
+		return nil
+	}))
+}
+
+func TestDatabaseFlushAndSnapshotError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	d, mapCh, _ := newTestDatabase(t, ctrl, Bootstrapped)
+	defer func() {
+		close(mapCh)
+	}()
+
+	mediator := NewMockdatabaseMediator(ctrl)
+	gomock.InOrder(
+		mediator.EXPECT().DisableFileOps(),
+		mediator.EXPECT().FlushAndSnapshot().Return(fmt.Errorf("flush error")),
+		mediator.EXPECT().EnableFileOps(),
+	)
+	d.mediator = mediator
+
+	err := d.FlushAndSnapshot(func() error {
+		require.FailNow(t, "fn called after the flush failed")
+		return nil
+	})
+	require.EqualError(t, err, "flush error")
+}
+
 func TestDatabaseRemoveNamespace(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
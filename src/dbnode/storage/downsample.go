@@ -0,0 +1,106 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/dbnode/ts"
+	xtime "github.com/m3db/m3x/time"
+)
+
+type downsampleFn func(dp ts.Datapoint, unit xtime.Unit) error
+
+// downsampleWindow aggregates the datapoints of a single resolution window.
+type downsampleWindow struct {
+	aggregation namespace.DownsampleAggregation
+	start       time.Time
+	count       int
+	value       float64
+	unit        xtime.Unit
+}
+
+func (w *downsampleWindow) reset(start time.Time) {
+	w.start = start
+	w.count = 0
+	w.value = 0
+}
+
+func (w *downsampleWindow) add(value float64, unit xtime.Unit) {
+	w.count++
+	w.unit = unit
+	if w.count == 1 {
+		w.value = value
+		return
+	}
+	switch w.aggregation {
+	case namespace.DownsampleLast:
+		w.value = value
+	case namespace.DownsampleMean, namespace.DownsampleSum:
+		w.value += value
+	case namespace.DownsampleMin:
+		w.value = math.Min(w.value, value)
+	case namespace.DownsampleMax:
+		w.value = math.Max(w.value, value)
+	}
+}
+
+func (w *downsampleWindow) datapoint() ts.Datapoint {
+	value := w.value
+	if w.aggregation == namespace.DownsampleMean {
+		value /= float64(w.count)
+	}
+	return ts.Datapoint{Timestamp: w.start, Value: value}
+}
+
+// downsample aggregates the datapoints of the iterator into windows of the
+// given resolution, calling fn with a datapoint at the start of each window.
+func downsample(
+	iter encoding.Iterator,
+	resolution time.Duration,
+	aggregation namespace.DownsampleAggregation,
+	fn downsampleFn,
+) error {
+	window := downsampleWindow{aggregation: aggregation}
+	for iter.Next() {
+		dp, unit, _ := iter.Current()
+		start := dp.Timestamp.Truncate(resolution)
+		if window.count > 0 && !start.Equal(window.start) {
+			if err := fn(window.datapoint(), window.unit); err != nil {
+				return err
+			}
+		}
+		if window.count == 0 || !start.Equal(window.start) {
+			window.reset(start)
+		}
+		window.add(dp.Value, unit)
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	if window.count > 0 {
+		return fn(window.datapoint(), window.unit)
+	}
+	return nil
+}
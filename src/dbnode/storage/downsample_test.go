@@ -0,0 +1,101 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/dbnode/ts"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testDatapointsIterator struct {
+	datapoints []ts.Datapoint
+	idx        int
+}
+
+func (it *testDatapointsIterator) Next() bool {
+	it.idx++
+	return it.idx <= len(it.datapoints)
+}
+
+func (it *testDatapointsIterator) Current() (ts.Datapoint, xtime.Unit, ts.Annotation) {
+	return it.datapoints[it.idx-1], xtime.Second, nil
+}
+
+func (it *testDatapointsIterator) Err() error { return nil }
+func (it *testDatapointsIterator) Close()     {}
+
+func TestDownsample(t *testing.T) {
+	var (
+		start      = time.Unix(0, 0)
+		resolution = time.Minute
+		datapoints = []ts.Datapoint{
+			{Timestamp: start.Add(10 * time.Second), Value: 4},
+			{Timestamp: start.Add(20 * time.Second), Value: 1},
+			{Timestamp: start.Add(50 * time.Second), Value: 7},
+			{Timestamp: start.Add(3 * time.Minute), Value: 2},
+		}
+	)
+
+	tests := []struct {
+		aggregation namespace.DownsampleAggregation
+		expected    []float64
+	}{
+		{namespace.DownsampleLast, []float64{7, 2}},
+		{namespace.DownsampleMean, []float64{4, 2}},
+		{namespace.DownsampleMin, []float64{1, 2}},
+		{namespace.DownsampleMax, []float64{7, 2}},
+		{namespace.DownsampleSum, []float64{12, 2}},
+	}
+	for _, test := range tests {
+		var results []ts.Datapoint
+		iter := &testDatapointsIterator{datapoints: datapoints}
+		err := downsample(iter, resolution, test.aggregation,
+			func(dp ts.Datapoint, unit xtime.Unit) error {
+				require.Equal(t, xtime.Second, unit)
+				results = append(results, dp)
+				return nil
+			})
+		require.NoError(t, err)
+
+		require.Equal(t, len(test.expected), len(results), test.aggregation.String())
+		require.True(t, start.Equal(results[0].Timestamp))
+		require.True(t, start.Add(3*time.Minute).Equal(results[1].Timestamp))
+		for i, value := range test.expected {
+			require.Equal(t, value, results[i].Value, test.aggregation.String())
+		}
+	}
+}
+
+func TestDownsampleEmpty(t *testing.T) {
+	iter := &testDatapointsIterator{}
+	err := downsample(iter, time.Minute, namespace.DownsampleLast,
+		func(dp ts.Datapoint, unit xtime.Unit) error {
+			require.FailNow(t, "unexpected datapoint")
+			return nil
+		})
+	require.NoError(t, err)
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/retention"
 	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
 
 	"github.com/uber-go/tally"
 )
@@ -117,6 +118,63 @@ func (m *flushManager) Flush(
 		multiErr = multiErr.Add(m.flushNamespaceWithTimes(ns, shardBootstrapTimes, flushTimes, flush))
 	}
 
+	// Downsample after the warm flushes and before the cold flushes so that
+	// the downsampled data written to target namespaces is persisted by
+	// their cold flush in the same pass.
+	for _, ns := range namespaces {
+		dopts := ns.Options().DownsampleOptions()
+		if !dopts.Enabled() {
+			continue
+		}
+		shardBootstrapTimes, ok := dbBootstrapStateAtTickStart.NamespaceBootstrapStates[ns.ID().String()]
+		if !ok {
+			continue
+		}
+		target, ok := m.ownedNamespace(namespaces, dopts.TargetNamespace())
+		if !ok {
+			multiErr = multiErr.Add(fmt.Errorf(
+				"namespace %s downsample target namespace %s not found",
+				ns.ID().String(), dopts.TargetNamespace().String()))
+			continue
+		}
+		if err := ns.Downsample(tickStart, shardBootstrapTimes, target); err != nil {
+			detailedErr := fmt.Errorf("namespace %s failed to downsample data: %v",
+				ns.ID().String(), err)
+			multiErr = multiErr.Add(detailedErr)
+		}
+	}
+
+	// Cold flush after the warm flushes so that cold writes for blocks that
+	// were just flushed are merged into new volumes in the same pass.
+	for _, ns := range namespaces {
+		if !ns.Options().ColdWritesEnabled() {
+			continue
+		}
+		shardBootstrapTimes, ok := dbBootstrapStateAtTickStart.NamespaceBootstrapStates[ns.ID().String()]
+		if !ok {
+			continue
+		}
+		if err := ns.ColdFlush(shardBootstrapTimes, flush); err != nil {
+			detailedErr := fmt.Errorf("namespace %s failed to cold flush data: %v",
+				ns.ID().String(), err)
+			multiErr = multiErr.Add(detailedErr)
+		}
+	}
+
+	// Purge deleted series after the cold flushes as both write new volumes
+	// of the blocks on disk.
+	for _, ns := range namespaces {
+		shardBootstrapTimes, ok := dbBootstrapStateAtTickStart.NamespaceBootstrapStates[ns.ID().String()]
+		if !ok {
+			continue
+		}
+		if err := ns.PurgeTombstones(shardBootstrapTimes, flush); err != nil {
+			detailedErr := fmt.Errorf("namespace %s failed to purge deleted series: %v",
+				ns.ID().String(), err)
+			multiErr = multiErr.Add(detailedErr)
+		}
+	}
+
 	// Perform two separate loops through all the namespaces so that we can emit better
 	// gauges I.E all the flushing for all the namespaces happens at once and then all
 	// the snapshotting for all the namespaces happens at once. This is also slightly
@@ -253,3 +311,15 @@ func (m *flushManager) flushNamespaceWithTimes(
 	}
 	return multiErr.FinalError()
 }
+
+func (m *flushManager) ownedNamespace(
+	namespaces []databaseNamespace,
+	id ident.ID,
+) (databaseNamespace, bool) {
+	for _, ns := range namespaces {
+		if ns.ID().Equal(id) {
+			return ns, true
+		}
+	}
+	return nil, false
+}
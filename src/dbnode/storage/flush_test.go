@@ -172,6 +172,7 @@ func TestFlushManagerSkipNamespaceIndexingDisabled(t *testing.T) {
 	ns.EXPECT().ID().Return(defaultTestNs1ID).AnyTimes()
 	ns.EXPECT().NeedsFlush(gomock.Any(), gomock.Any()).Return(true).AnyTimes()
 	ns.EXPECT().Flush(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	ns.EXPECT().PurgeTombstones(gomock.Any(), gomock.Any()).Return(nil)
 
 	mockFlusher := persist.NewMockDataFlush(ctrl)
 	mockFlusher.EXPECT().DoneData().Return(nil)
@@ -209,6 +210,7 @@ func TestFlushManagerNamespaceIndexingEnabled(t *testing.T) {
 	ns.EXPECT().ID().Return(defaultTestNs1ID).AnyTimes()
 	ns.EXPECT().NeedsFlush(gomock.Any(), gomock.Any()).Return(true).AnyTimes()
 	ns.EXPECT().Flush(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	ns.EXPECT().PurgeTombstones(gomock.Any(), gomock.Any()).Return(nil)
 	ns.EXPECT().FlushIndex(gomock.Any()).Return(nil)
 
 	mockFlusher := persist.NewMockDataFlush(ctrl)
@@ -237,6 +239,49 @@ func TestFlushManagerNamespaceIndexingEnabled(t *testing.T) {
 	require.NoError(t, fm.Flush(now, bootstrapStates))
 }
 
+func TestFlushManagerNamespaceColdWritesEnabled(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{t})
+	defer ctrl.Finish()
+
+	nsOpts := defaultTestNs1Opts.
+		SetIndexOptions(namespace.NewIndexOptions().SetEnabled(false)).
+		SetColdWritesEnabled(true)
+	ns := NewMockdatabaseNamespace(ctrl)
+	ns.EXPECT().Options().Return(nsOpts).AnyTimes()
+	ns.EXPECT().ID().Return(defaultTestNs1ID).AnyTimes()
+	ns.EXPECT().NeedsFlush(gomock.Any(), gomock.Any()).Return(true).AnyTimes()
+	ns.EXPECT().Flush(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	ns.EXPECT().PurgeTombstones(gomock.Any(), gomock.Any()).Return(nil)
+
+	mockFlusher := persist.NewMockDataFlush(ctrl)
+	mockFlusher.EXPECT().DoneData().Return(nil)
+	mockPersistManager := persist.NewMockManager(ctrl)
+	mockPersistManager.EXPECT().StartDataPersist().Return(mockFlusher, nil)
+
+	mockIndexFlusher := persist.NewMockIndexFlush(ctrl)
+	mockIndexFlusher.EXPECT().DoneIndex().Return(nil)
+	mockPersistManager.EXPECT().StartIndexPersist().Return(mockIndexFlusher, nil)
+
+	shardBootstrapStates := ShardBootstrapStates{0: Bootstrapped}
+	ns.EXPECT().ColdFlush(shardBootstrapStates, mockFlusher).Return(nil)
+
+	testOpts := testDatabaseOptions().SetPersistManager(mockPersistManager)
+	db := newMockdatabase(ctrl)
+	db.EXPECT().Options().Return(testOpts).AnyTimes()
+	db.EXPECT().GetOwnedNamespaces().Return([]databaseNamespace{ns}, nil)
+
+	fm := newFlushManager(db, tally.NoopScope).(*flushManager)
+	fm.pm = mockPersistManager
+
+	now := time.Unix(0, 0)
+	bootstrapStates := DatabaseBootstrapState{
+		NamespaceBootstrapStates: map[string]ShardBootstrapStates{
+			ns.ID().String(): shardBootstrapStates,
+		},
+	}
+	require.NoError(t, fm.Flush(now, bootstrapStates))
+}
+
 func TestFlushManagerFlushTimeStart(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -58,6 +58,7 @@ var (
 	errDbIndexAlreadyClosed               = errors.New("database index has already been closed")
 	errDbIndexUnableToWriteClosed         = errors.New("unable to write to database index, already closed")
 	errDbIndexUnableToQueryClosed         = errors.New("unable to query database index, already closed")
+	errDbIndexUnableToDeleteClosed        = errors.New("unable to delete from database index, already closed")
 	errDbIndexUnableToFlushClosed         = errors.New("unable to flush database index, already closed")
 	errDbIndexUnableToCleanupClosed       = errors.New("unable to cleanup database index, already closed")
 	errDbIndexTerminatingTickCancellation = errors.New("terminating tick early due to cancellation")
@@ -89,6 +90,10 @@ type nsIndex struct {
 	nsMetadata          namespace.Metadata
 	runtimeOptsListener xclose.SimpleCloser
 
+	// tombstones tracks deleted series which are masked from query results
+	// until the index blocks containing them expire.
+	tombstones *seriesTombstones
+
 	metrics nsIndexMetrics
 }
 
@@ -228,6 +233,7 @@ func newNamespaceIndexWithOptions(
 		opts:       newIndexOpts.opts,
 		logger:     indexOpts.InstrumentOptions().Logger(),
 		nsMetadata: nsMD,
+		tombstones: newSeriesTombstones(),
 
 		metrics: newNamespaceIndexMetrics(instrumentOpts),
 	}
@@ -296,6 +302,19 @@ func (i *nsIndex) WriteBatch(
 		return err
 	}
 
+	// Series written after being deleted are indexed anew and so are
+	// no longer masked from query results.
+	if i.tombstones.Len() > 0 {
+		batch.ForEach(func(
+			_ int,
+			_ index.WriteBatchEntry,
+			d doc.Document,
+			_ index.WriteBatchEntryResult,
+		) {
+			i.tombstones.Remove(ident.BytesID(d.ID))
+		})
+	}
+
 	// NB(prateek): retrieving insertMode here while we have the RLock.
 	insertMode := i.state.runtimeOpts.insertMode
 	wg, err := i.state.insertQueue.InsertBatch(batch)
@@ -344,6 +363,13 @@ func (i *nsIndex) writeBatches(
 	now := i.nowFn()
 	futureLimit := now.Add(1 * i.bufferFuture)
 	pastLimit := now.Add(-1 * i.bufferPast)
+	nsOpts := i.nsMetadata.Options()
+	if nsOpts.ColdWritesEnabled() {
+		// Cold writes are accepted for any block within retention, the index
+		// needs to accept them too so that backfilled series are queryable.
+		// NB: the retention start itself is within retention.
+		pastLimit = retention.FlushTimeStart(nsOpts.RetentionOptions(), now).Add(-time.Nanosecond)
+	}
 	writeBatchFn := i.writeBatchForBlockStartWithRLock
 	for _, batch := range batches {
 		// Ensure timestamp is not too old/new based on retention policies and that
@@ -466,6 +492,10 @@ func (i *nsIndex) Tick(c context.Cancellable, tickStart time.Time) (namespaceInd
 
 	result.NumBlocks = int64(len(i.state.blocksByTime))
 
+	// tombstones are no longer required once every block that could have
+	// contained the deleted series has been dropped.
+	i.tombstones.ExpireBefore(earliestBlockStartToRetain.Add(-i.blockSize))
+
 	var multiErr xerrors.MultiError
 	for blockStart, block := range i.state.blocksByTime {
 		if c.IsCancelled() {
@@ -509,6 +539,11 @@ func (i *nsIndex) Flush(
 
 	var evictResults index.EvictMutableSegmentResults
 	for _, block := range flushable {
+		// Seal any cold writes made to the block before reading the shards so
+		// that only the cold writes the flush covers are evicted afterwards.
+		if err := block.SealColdMutableSegments(); err != nil {
+			return err
+		}
 		immutableSegments, err := i.flushBlock(flush, block, shards)
 		if err != nil {
 			return err
@@ -678,6 +713,11 @@ func (i *nsIndex) flushBlockSegment(
 			}
 
 			for _, result := range results.Results() {
+				if i.tombstones.Contains(result.ID) {
+					// Deleted series are dropped from flushed segments.
+					continue
+				}
+
 				id := result.ID.Bytes()
 				exists, err := seg.ContainsID(id)
 				if err != nil {
@@ -737,6 +777,13 @@ func (i *nsIndex) Query(
 	results.Reset(i.nsMetadata.ID())
 	ctx.RegisterFinalizer(results)
 
+	// mask any deleted series that still reside in the index blocks as they
+	// are added so that they do not count towards the limit.
+	blockResults := results
+	if i.tombstones.Len() > 0 {
+		blockResults = tombstoneMaskedResults{Results: results, tombstones: i.tombstones}
+	}
+
 	// Chunk the query request into bounds based on applicable blocks and
 	// execute the requests to each of them; and merge results.
 	queryRange := xtime.NewRanges(xtime.Range{
@@ -762,7 +809,7 @@ func (i *nsIndex) Query(
 			break
 		}
 
-		exhaustive, err = block.Query(query, opts, results)
+		exhaustive, err = block.Query(query, opts, blockResults)
 		if err != nil {
 			return index.QueryResults{}, err
 		}
@@ -789,6 +836,60 @@ func (i *nsIndex) Query(
 	}, nil
 }
 
+func (i *nsIndex) AggregateQuery(
+	ctx context.Context,
+	query index.Query,
+	opts index.AggregationOptions,
+) (index.AggregateQueryResult, error) {
+	res, err := i.Query(ctx, query, opts.QueryOptions)
+	if err != nil {
+		return index.AggregateQueryResult{}, err
+	}
+
+	results := index.NewAggregateResults(opts)
+	for _, entry := range res.Results.Map().Iter() {
+		results.AddTags(entry.Value())
+	}
+
+	return index.AggregateQueryResult{
+		Results:    results,
+		Exhaustive: res.Exhaustive,
+	}, nil
+}
+
+// tombstoneMaskedResults drops the documents of deleted series rather than
+// adding them to the results.
+type tombstoneMaskedResults struct {
+	index.Results
+	tombstones *seriesTombstones
+}
+
+func (r tombstoneMaskedResults) Add(d doc.Document) (bool, int, error) {
+	if r.tombstones.Contains(ident.BytesID(d.ID)) {
+		return false, r.Results.Size(), nil
+	}
+	return r.Results.Add(d)
+}
+
+func (i *nsIndex) DeleteSeries(ids []ident.ID) error {
+	i.state.RLock()
+	defer i.state.RUnlock()
+	if !i.isOpenWithRLock() {
+		return errDbIndexUnableToDeleteClosed
+	}
+
+	now := i.nowFn()
+	for _, id := range ids {
+		i.tombstones.Add(id, now)
+	}
+	i.metrics.SeriesDeleted.Inc(int64(len(ids)))
+	return nil
+}
+
+func (i *nsIndex) LoadTombstones(tombstones map[string]fs.Tombstone) {
+	i.tombstones.Load(tombstones)
+}
+
 // ensureBlockPresentWithRLock guarantees an index.Block exists for the specified
 // blockStart, allocating one if it does not. It returns the desired block, or
 // error if it's unable to do so.
@@ -949,6 +1050,7 @@ type nsIndexMetrics struct {
 	QueryAfterClose             tally.Counter
 	InsertEndToEndLatency       tally.Timer
 	FlushEvictedMutableSegments tally.Counter
+	SeriesDeleted               tally.Counter
 }
 
 func newNamespaceIndexMetrics(
@@ -969,6 +1071,7 @@ func newNamespaceIndexMetrics(
 			scope.Timer("insert-end-to-end-latency"),
 			iopts.MetricsSamplingRate()),
 		FlushEvictedMutableSegments: scope.Counter("mutable-segment-evicted"),
+		SeriesDeleted:               scope.Counter("series-deleted"),
 	}
 }
 
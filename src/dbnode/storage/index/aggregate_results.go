@@ -0,0 +1,121 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/m3db/m3x/ident"
+)
+
+// AggregateResults is a collection of the tag names and values of the series
+// matched by an aggregate query, values are not collected when only tag names
+// are aggregated.
+type AggregateResults struct {
+	opts   AggregationOptions
+	values map[string]map[string]struct{}
+}
+
+// NewAggregateResults returns a new aggregate results object.
+func NewAggregateResults(opts AggregationOptions) *AggregateResults {
+	return &AggregateResults{
+		opts:   opts,
+		values: make(map[string]map[string]struct{}),
+	}
+}
+
+// AddTags adds the tags of a matched series to the results.
+func (r *AggregateResults) AddTags(tags ident.Tags) {
+	for _, tag := range tags.Values() {
+		r.Add(tag.Name.Bytes(), tag.Value.Bytes())
+	}
+}
+
+// Add adds a tag name and value to the results, tags whose names do not match
+// the field filter are ignored. The bytes are copied so that they may be
+// modified after this function returns.
+func (r *AggregateResults) Add(name, value []byte) {
+	if !r.matchesFilter(name) {
+		return
+	}
+
+	values, ok := r.values[string(name)]
+	if !ok {
+		values = make(map[string]struct{})
+		r.values[string(name)] = values
+	}
+	if r.opts.NameOnly {
+		return
+	}
+	values[string(value)] = struct{}{}
+}
+
+// AddName adds a tag name without any values to the results.
+func (r *AggregateResults) AddName(name []byte) {
+	if !r.matchesFilter(name) {
+		return
+	}
+	if _, ok := r.values[string(name)]; !ok {
+		r.values[string(name)] = make(map[string]struct{})
+	}
+}
+
+func (r *AggregateResults) matchesFilter(name []byte) bool {
+	if len(r.opts.FieldFilter) == 0 {
+		return true
+	}
+	for _, filter := range r.opts.FieldFilter {
+		if bytes.Equal(filter, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of aggregated tag names.
+func (r *AggregateResults) Len() int {
+	return len(r.values)
+}
+
+// ForEach calls fn for each aggregated tag name in order with its values in
+// order, the values are empty when only tag names are aggregated.
+func (r *AggregateResults) ForEach(fn func(name []byte, values [][]byte)) {
+	names := make([]string, 0, len(r.values))
+	for name := range r.values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		values := make([]string, 0, len(r.values[name]))
+		for value := range r.values[name] {
+			values = append(values, value)
+		}
+		sort.Strings(values)
+
+		valueBytes := make([][]byte, 0, len(values))
+		for _, value := range values {
+			valueBytes = append(valueBytes, []byte(value))
+		}
+		fn([]byte(name), valueBytes)
+	}
+}
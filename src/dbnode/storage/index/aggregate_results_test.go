@@ -0,0 +1,73 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"testing"
+
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testAggregatedTag struct {
+	name   string
+	values []string
+}
+
+func aggregatedTags(r *AggregateResults) []testAggregatedTag {
+	var tags []testAggregatedTag
+	r.ForEach(func(name []byte, values [][]byte) {
+		tag := testAggregatedTag{name: string(name)}
+		for _, value := range values {
+			tag.values = append(tag.values, string(value))
+		}
+		tags = append(tags, tag)
+	})
+	return tags
+}
+
+func TestAggregateResultsAddTags(t *testing.T) {
+	r := NewAggregateResults(AggregationOptions{})
+	r.AddTags(ident.NewTags(ident.StringTag("foo", "b"), ident.StringTag("bar", "c")))
+	r.AddTags(ident.NewTags(ident.StringTag("foo", "a"), ident.StringTag("foo", "b")))
+
+	require.Equal(t, 2, r.Len())
+	require.Equal(t, []testAggregatedTag{
+		{name: "bar", values: []string{"c"}},
+		{name: "foo", values: []string{"a", "b"}},
+	}, aggregatedTags(r))
+}
+
+func TestAggregateResultsNameOnlyWithFilter(t *testing.T) {
+	r := NewAggregateResults(AggregationOptions{
+		FieldFilter: [][]byte{[]byte("foo"), []byte("qux")},
+		NameOnly:    true,
+	})
+	r.AddTags(ident.NewTags(ident.StringTag("foo", "a"), ident.StringTag("bar", "c")))
+	r.AddName([]byte("qux"))
+	r.AddName([]byte("baz"))
+
+	require.Equal(t, []testAggregatedTag{
+		{name: "foo"},
+		{name: "qux"},
+	}, aggregatedTags(r))
+}
@@ -26,7 +26,10 @@ import (
 	"sync"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
+	"github.com/m3db/m3/src/dbnode/storage/index/segments"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	m3ninxindex "github.com/m3db/m3/src/m3ninx/index"
 	"github.com/m3db/m3/src/m3ninx/index/segment"
@@ -37,7 +40,10 @@ import (
 	"github.com/m3db/m3x/context"
 	xerrors "github.com/m3db/m3x/errors"
 	"github.com/m3db/m3x/instrument"
+	xlog "github.com/m3db/m3x/log"
 	xtime "github.com/m3db/m3x/time"
+
+	"github.com/uber-go/tally"
 )
 
 var (
@@ -66,7 +72,16 @@ type block struct {
 	sync.RWMutex
 	state               blockState
 	activeSegment       segment.MutableSegment
+	activeSegmentStart  time.Time
+	compactedSegments   []*compactedSegment
 	shardRangesSegments []blockShardRangesSegments
+	compact             blockCompact
+
+	// coldSegment holds writes made to the block once sealed when the
+	// namespace has cold writes enabled, it is sealed and moved to the
+	// sealedColdSegments ahead of a flush to be evicted once flushed.
+	coldSegment        segment.MutableSegment
+	sealedColdSegments []segment.MutableSegment
 
 	newExecutorFn newExecutorFn
 	startTime     time.Time
@@ -74,6 +89,25 @@ type block struct {
 	blockSize     time.Duration
 	opts          Options
 	nsMD          namespace.Metadata
+	nowFn         clock.NowFn
+	metrics       blockMetrics
+}
+
+// compactedSegment is a segment holding writes made to the block while it
+// was open, it is either a mutable segment rotated out of the active segment
+// or an FST segment produced by a background compaction.
+type compactedSegment struct {
+	segment    segment.Segment
+	segType    segments.Type
+	createdAt  time.Time
+	compacting bool
+}
+
+// blockCompact is the state of background compactions of a block.
+type blockCompact struct {
+	compactor   *compaction.Compactor
+	plannerOpts compaction.PlannerOptions
+	compacting  bool
 }
 
 // blockShardsSegments is a collection of segments that has a mapping of what shards
@@ -102,15 +136,23 @@ func NewBlock(
 		return nil, err
 	}
 
+	nowFn := opts.ClockOptions().NowFn()
 	b := &block{
-		state:         blockStateOpen,
-		activeSegment: seg,
+		state:              blockStateOpen,
+		activeSegment:      seg,
+		activeSegmentStart: nowFn(),
+		compact: blockCompact{
+			compactor:   opts.Compactor(),
+			plannerOpts: opts.CompactionPlannerOptions(),
+		},
 
 		startTime: startTime,
 		endTime:   startTime.Add(blockSize),
 		blockSize: blockSize,
 		opts:      opts,
 		nsMD:      md,
+		nowFn:     nowFn,
+		metrics:   newBlockMetrics(opts.InstrumentOptions().MetricsScope()),
 	}
 	b.newExecutorFn = b.executorWithRLock
 
@@ -129,6 +171,10 @@ func (b *block) WriteBatch(inserts *WriteBatch) (WriteBatchResult, error) {
 	b.Lock()
 	defer b.Unlock()
 
+	if b.state == blockStateSealed && b.nsMD.Options().ColdWritesEnabled() {
+		return b.writeBatchColdWithLock(inserts)
+	}
+
 	if b.state != blockStateOpen {
 		err := b.writeBatchErrorInvalidState(b.state)
 		inserts.MarkUnmarkedEntriesError(err)
@@ -151,6 +197,39 @@ func (b *block) WriteBatch(inserts *WriteBatch) (WriteBatchResult, error) {
 		Docs:                inserts.PendingDocs(),
 		AllowPartialUpdates: true,
 	})
+
+	// NB: the active segment is rotated out for compaction once it has grown
+	// large enough, regardless of whether the inserts partially failed, aged
+	// segments are compacted on tick instead to keep the write path cheap.
+	if b.activeSegmentFullWithLock() {
+		b.maybeBackgroundCompactWithLock()
+	}
+
+	return b.writeBatchResult(inserts, err)
+}
+
+// writeBatchColdWithLock writes a batch of entries to a sealed block, these
+// are held in memory until the next flush of the block covers them.
+func (b *block) writeBatchColdWithLock(inserts *WriteBatch) (WriteBatchResult, error) {
+	if b.coldSegment == nil {
+		seg, err := mem.NewSegment(postings.ID(0), b.opts.MemSegmentOptions())
+		if err != nil {
+			inserts.MarkUnmarkedEntriesError(err)
+			return WriteBatchResult{
+				NumError: int64(inserts.Len()),
+			}, err
+		}
+		b.coldSegment = seg
+	}
+
+	err := b.coldSegment.InsertBatch(m3ninxindex.Batch{
+		Docs:                inserts.PendingDocs(),
+		AllowPartialUpdates: true,
+	})
+	return b.writeBatchResult(inserts, err)
+}
+
+func (b *block) writeBatchResult(inserts *WriteBatch, err error) (WriteBatchResult, error) {
 	if err == nil {
 		inserts.MarkUnmarkedEntriesSuccess()
 		return WriteBatchResult{
@@ -185,6 +264,11 @@ func (b *block) executorWithRLock() (search.Executor, error) {
 	if b.activeSegment != nil {
 		expectedReaders++
 	}
+	if b.coldSegment != nil {
+		expectedReaders++
+	}
+	expectedReaders += len(b.compactedSegments)
+	expectedReaders += len(b.sealedColdSegments)
 	for _, group := range b.shardRangesSegments {
 		expectedReaders += len(group.segments)
 	}
@@ -212,6 +296,31 @@ func (b *block) executorWithRLock() (search.Executor, error) {
 		readers = append(readers, reader)
 	}
 
+	// followed by the segments holding earlier writes to the block
+	for _, seg := range b.compactedSegments {
+		reader, err := seg.segment.Reader()
+		if err != nil {
+			return nil, err
+		}
+		readers = append(readers, reader)
+	}
+
+	// followed by any cold writes made to the block since it was sealed
+	if b.coldSegment != nil {
+		reader, err := b.coldSegment.Reader()
+		if err != nil {
+			return nil, err
+		}
+		readers = append(readers, reader)
+	}
+	for _, seg := range b.sealedColdSegments {
+		reader, err := seg.Reader()
+		if err != nil {
+			return nil, err
+		}
+		readers = append(readers, reader)
+	}
+
 	// loop over the segments associated to shard time ranges
 	for _, group := range b.shardRangesSegments {
 		for _, seg := range group.segments {
@@ -370,6 +479,9 @@ func (b *block) AddResults(
 }
 
 func (b *block) Tick(c context.Cancellable, tickStart time.Time) (BlockTickResult, error) {
+	// compact the active segment if it has aged since the last write.
+	b.maybeBackgroundCompact()
+
 	b.RLock()
 	defer b.RUnlock()
 	result := BlockTickResult{}
@@ -383,6 +495,22 @@ func (b *block) Tick(c context.Cancellable, tickStart time.Time) (BlockTickResul
 		result.NumDocs += b.activeSegment.Size()
 	}
 
+	// segments holding earlier writes to the block.
+	for _, seg := range b.compactedSegments {
+		result.NumSegments++
+		result.NumDocs += seg.segment.Size()
+	}
+
+	// segments holding cold writes to the block.
+	if b.coldSegment != nil {
+		result.NumSegments++
+		result.NumDocs += b.coldSegment.Size()
+	}
+	for _, seg := range b.sealedColdSegments {
+		result.NumSegments++
+		result.NumDocs += seg.Size()
+	}
+
 	// any other segments
 	for _, group := range b.shardRangesSegments {
 		for _, seg := range group.segments {
@@ -438,6 +566,13 @@ func (b *block) NeedsMutableSegmentsEvicted() bool {
 	defer b.RUnlock()
 	anyMutableSegmentNeedsEviction := b.activeSegment != nil && b.activeSegment.Size() > 0
 
+	// segments holding earlier writes to the block are in memory too.
+	anyMutableSegmentNeedsEviction = anyMutableSegmentNeedsEviction || len(b.compactedSegments) > 0
+
+	// as are segments holding cold writes to the block.
+	anyMutableSegmentNeedsEviction = anyMutableSegmentNeedsEviction ||
+		(b.coldSegment != nil && b.coldSegment.Size() > 0) || len(b.sealedColdSegments) > 0
+
 	// can early terminate if we already know we need to flush.
 	if anyMutableSegmentNeedsEviction {
 		return true
@@ -456,6 +591,24 @@ func (b *block) NeedsMutableSegmentsEvicted() bool {
 	return anyMutableSegmentNeedsEviction
 }
 
+func (b *block) SealColdMutableSegments() error {
+	b.Lock()
+	defer b.Unlock()
+	if b.state != blockStateSealed {
+		return fmt.Errorf("unable to seal cold mutable segments, block must be sealed, found: %v", b.state)
+	}
+	if b.coldSegment == nil || b.coldSegment.Size() == 0 {
+		return nil
+	}
+
+	if _, err := b.coldSegment.Seal(); err != nil {
+		return err
+	}
+	b.sealedColdSegments = append(b.sealedColdSegments, b.coldSegment)
+	b.coldSegment = nil
+	return nil
+}
+
 func (b *block) EvictMutableSegments() (EvictMutableSegmentResults, error) {
 	var results EvictMutableSegmentResults
 	b.Lock()
@@ -473,6 +626,24 @@ func (b *block) EvictMutableSegments() (EvictMutableSegmentResults, error) {
 		b.activeSegment = nil
 	}
 
+	// close the segments holding earlier writes to the block, these are all
+	// derived from writes held in memory.
+	for _, seg := range b.compactedSegments {
+		results.NumMutableSegments++
+		results.NumDocs += seg.segment.Size()
+		multiErr = multiErr.Add(b.closeCompactedSegmentWithLock(seg))
+	}
+	b.compactedSegments = nil
+
+	// close the sealed segments holding cold writes, the segment taking cold
+	// writes made since they were sealed is kept until the next flush.
+	for _, seg := range b.sealedColdSegments {
+		results.NumMutableSegments++
+		results.NumDocs += seg.Size()
+		multiErr = multiErr.Add(seg.Close())
+	}
+	b.sealedColdSegments = nil
+
 	// close any other mutable segments too.
 	for idx := range b.shardRangesSegments {
 		segments := make([]segment.Segment, 0, len(b.shardRangesSegments[idx].segments))
@@ -508,6 +679,22 @@ func (b *block) Close() error {
 		b.activeSegment = nil
 	}
 
+	// close the segments holding earlier writes to the block.
+	for _, seg := range b.compactedSegments {
+		multiErr = multiErr.Add(b.closeCompactedSegmentWithLock(seg))
+	}
+	b.compactedSegments = nil
+
+	// close the segments holding cold writes.
+	if b.coldSegment != nil {
+		multiErr = multiErr.Add(b.coldSegment.Close())
+		b.coldSegment = nil
+	}
+	for _, seg := range b.sealedColdSegments {
+		multiErr = multiErr.Add(seg.Close())
+	}
+	b.sealedColdSegments = nil
+
 	// close any other added segments too.
 	for _, group := range b.shardRangesSegments {
 		for _, seg := range group.segments {
@@ -519,6 +706,252 @@ func (b *block) Close() error {
 	return multiErr.FinalError()
 }
 
+// activeSegmentFullWithLock returns whether the active segment has grown
+// large enough to be rotated out for compaction.
+func (b *block) activeSegmentFullWithLock() bool {
+	return b.compact.compactor != nil && b.activeSegment != nil &&
+		b.activeSegment.Size() >= b.compact.plannerOpts.MutableSegmentSizeThreshold
+}
+
+// maybeBackgroundCompact starts a background compaction of the block if
+// there are segments to compact, the write lock is only taken if so.
+func (b *block) maybeBackgroundCompact() {
+	b.RLock()
+	shouldCompact := b.compact.compactor != nil && !b.compact.compacting &&
+		b.state == blockStateOpen &&
+		(len(b.compactedSegments) > 0 || (b.activeSegment != nil && b.activeSegment.Size() > 0))
+	b.RUnlock()
+	if !shouldCompact {
+		return
+	}
+
+	b.Lock()
+	b.maybeBackgroundCompactWithLock()
+	b.Unlock()
+}
+
+// maybeBackgroundCompactWithLock rotates the active segment out once it is
+// compactable and starts a background compaction of the segments holding
+// earlier writes to the block, if one is not already running.
+func (b *block) maybeBackgroundCompactWithLock() {
+	if b.compact.compactor == nil || b.compact.compacting || b.state != blockStateOpen {
+		return
+	}
+
+	now := b.nowFn()
+	if b.activeSegment != nil && b.activeSegment.Size() > 0 {
+		active := compaction.Segment{
+			Age:     now.Sub(b.activeSegmentStart),
+			Size:    b.activeSegment.Size(),
+			Type:    segments.MutableType,
+			Segment: b.activeSegment,
+		}
+		if active.Compactable(b.compact.plannerOpts) {
+			if err := b.rotateActiveSegmentWithLock(now); err != nil {
+				b.metrics.compactionErrors.Inc(1)
+				b.logger().Errorf("unable to rotate active index segment: %v", err)
+				return
+			}
+		}
+	}
+
+	if len(b.compactedSegments) == 0 {
+		return
+	}
+
+	candidates := make([]compaction.Segment, 0, len(b.compactedSegments))
+	for _, seg := range b.compactedSegments {
+		candidates = append(candidates, compaction.Segment{
+			Age:     now.Sub(seg.createdAt),
+			Size:    seg.segment.Size(),
+			Type:    seg.segType,
+			Segment: seg.segment,
+		})
+	}
+
+	plan, err := compaction.NewPlan(candidates, b.compact.plannerOpts)
+	if err != nil {
+		b.metrics.compactionErrors.Inc(1)
+		b.logger().Errorf("unable to plan index segment compaction: %v", err)
+		return
+	}
+
+	// NB: tasks larger than the compactor allows are skipped to bound the
+	// memory used by a compaction, their segments remain queryable as is.
+	maxSize := b.compact.compactor.MaxSize()
+	tasks := plan.Tasks[:0]
+	for _, task := range plan.Tasks {
+		if task.Summary().CumulativeSize > maxSize {
+			b.metrics.compactionsSkipped.Inc(1)
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	if len(tasks) == 0 {
+		return
+	}
+
+	b.compact.compacting = true
+	go b.backgroundCompact(tasks)
+}
+
+func (b *block) rotateActiveSegmentWithLock(now time.Time) error {
+	seg, err := mem.NewSegment(postings.ID(0), b.opts.MemSegmentOptions())
+	if err != nil {
+		return err
+	}
+
+	// NB: the rotated segment takes no more writes, it must be sealed
+	// before it can be compacted into an FST segment.
+	if _, err := b.activeSegment.Seal(); err != nil {
+		seg.Close()
+		return err
+	}
+
+	b.compactedSegments = append(b.compactedSegments, &compactedSegment{
+		segment:   b.activeSegment,
+		segType:   segments.MutableType,
+		createdAt: b.activeSegmentStart,
+	})
+	b.activeSegment = seg
+	b.activeSegmentStart = now
+	return nil
+}
+
+func (b *block) backgroundCompact(tasks []compaction.Task) {
+	defer func() {
+		b.Lock()
+		b.compact.compacting = false
+		b.Unlock()
+	}()
+
+	for _, task := range tasks {
+		sw := b.metrics.compactionLatency.Start()
+		replaced, ok := b.backgroundCompactTask(task)
+		sw.Stop()
+		if !ok {
+			return
+		}
+		b.metrics.compactions.Inc(1)
+		b.metrics.compactedSegments.Inc(int64(len(replaced)))
+	}
+}
+
+// backgroundCompactTask compacts the segments of the task and swaps them for
+// the compacted segment, it returns the segments that were replaced and
+// whether compactions of the block should continue.
+func (b *block) backgroundCompactTask(task compaction.Task) ([]*compactedSegment, bool) {
+	b.Lock()
+	if b.state != blockStateOpen {
+		b.Unlock()
+		return nil, false
+	}
+	var (
+		taskSegments = make([]*compactedSegment, 0, len(task.Segments))
+		sources      = make([]segment.Segment, 0, len(task.Segments))
+	)
+	for _, seg := range b.compactedSegments {
+		for _, taskSeg := range task.Segments {
+			if seg.segment == taskSeg.Segment {
+				seg.compacting = true
+				taskSegments = append(taskSegments, seg)
+				sources = append(sources, seg.segment)
+				break
+			}
+		}
+	}
+	b.Unlock()
+
+	if len(sources) == 0 {
+		return nil, true
+	}
+
+	// NB: the segments being compacted are not closed by the block while
+	// compacting, so they are safe to read without holding the lock.
+	compacted, err := b.compact.compactor.Compact(sources)
+
+	b.Lock()
+	for _, seg := range taskSegments {
+		seg.compacting = false
+	}
+
+	// the block may have been flushed or closed while compacting in which
+	// case it no longer holds the segments and they are closed here instead.
+	if !b.containsCompactedSegmentsWithLock(taskSegments) {
+		b.Unlock()
+		for _, seg := range taskSegments {
+			seg.segment.Close()
+		}
+		if compacted != nil {
+			compacted.Close()
+		}
+		return nil, false
+	}
+
+	if err != nil {
+		b.Unlock()
+		b.metrics.compactionErrors.Inc(1)
+		b.logger().Errorf("unable to compact index segments: %v", err)
+		return nil, false
+	}
+
+	// swap the compacted segments for the result atomically with respect to
+	// queries, which hold the read lock for the lifetime of their readers.
+	remaining := b.compactedSegments[:0]
+	for _, seg := range b.compactedSegments {
+		if !containsCompactedSegment(taskSegments, seg) {
+			remaining = append(remaining, seg)
+		}
+	}
+	for i := len(remaining); i < len(b.compactedSegments); i++ {
+		b.compactedSegments[i] = nil
+	}
+	b.compactedSegments = append(remaining, &compactedSegment{
+		segment:   compacted,
+		segType:   segments.FSTType,
+		createdAt: b.nowFn(),
+	})
+	b.Unlock()
+
+	for _, seg := range taskSegments {
+		if err := seg.segment.Close(); err != nil {
+			b.logger().Warnf("unable to close compacted index segment: %v", err)
+		}
+	}
+	return taskSegments, true
+}
+
+func (b *block) containsCompactedSegmentsWithLock(segs []*compactedSegment) bool {
+	for _, seg := range segs {
+		if !containsCompactedSegment(b.compactedSegments, seg) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsCompactedSegment(segs []*compactedSegment, seg *compactedSegment) bool {
+	for _, s := range segs {
+		if s == seg {
+			return true
+		}
+	}
+	return false
+}
+
+// closeCompactedSegmentWithLock closes the segment unless it is being
+// compacted, in which case the background compaction closes it once done.
+func (b *block) closeCompactedSegmentWithLock(seg *compactedSegment) error {
+	if seg.compacting {
+		return nil
+	}
+	return seg.segment.Close()
+}
+
+func (b *block) logger() xlog.Logger {
+	return b.opts.InstrumentOptions().Logger()
+}
+
 func (b *block) writeBatchErrorInvalidState(state blockState) error {
 	switch state {
 	case blockStateClosed:
@@ -566,3 +999,22 @@ func (c *safeCloser) Close() error {
 	c.closed = true
 	return c.closable.Close()
 }
+
+type blockMetrics struct {
+	compactions        tally.Counter
+	compactedSegments  tally.Counter
+	compactionErrors   tally.Counter
+	compactionsSkipped tally.Counter
+	compactionLatency  tally.Timer
+}
+
+func newBlockMetrics(s tally.Scope) blockMetrics {
+	s = s.SubScope("index").SubScope("compaction")
+	return blockMetrics{
+		compactions:        s.Counter("compactions"),
+		compactedSegments:  s.Counter("compacted-segments"),
+		compactionErrors:   s.Counter("compaction-errors"),
+		compactionsSkipped: s.Counter("compactions-skipped"),
+		compactionLatency:  s.Timer("compaction-latency"),
+	}
+}
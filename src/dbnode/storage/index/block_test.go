@@ -28,11 +28,14 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
+	"github.com/m3db/m3/src/dbnode/storage/index/segments"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/m3ninx/idx"
 	"github.com/m3db/m3/src/m3ninx/index"
 	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
 	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
 	"github.com/m3db/m3/src/m3ninx/search"
 	"github.com/m3db/m3x/ident"
@@ -163,6 +166,68 @@ func TestBlockWriteAfterSeal(t *testing.T) {
 	require.Equal(t, 1, verified)
 }
 
+func TestBlockWriteColdAfterSeal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blockSize := time.Hour
+	testMD := newTestNSMetadata(t)
+	testMD, err := namespace.NewMetadata(testMD.ID(),
+		testMD.Options().SetColdWritesEnabled(true))
+	require.NoError(t, err)
+
+	blockStart := time.Now().Truncate(blockSize).Add(-2 * blockSize)
+	blk, err := NewBlock(blockStart, testMD, testOpts)
+	require.NoError(t, err)
+	require.NoError(t, blk.Seal())
+	res, err := blk.EvictMutableSegments()
+	require.NoError(t, err)
+	require.False(t, blk.NeedsMutableSegmentsEvicted())
+
+	write := func(d doc.Document) {
+		lifecycle := NewMockOnIndexSeries(ctrl)
+		lifecycle.EXPECT().OnIndexFinalize(xtime.ToUnixNano(blockStart))
+		lifecycle.EXPECT().OnIndexSuccess(xtime.ToUnixNano(blockStart))
+
+		batch := NewWriteBatch(WriteBatchOptions{
+			IndexBlockSize: blockSize,
+		})
+		batch.Append(WriteBatchEntry{
+			Timestamp:     blockStart.Add(time.Minute),
+			OnIndexSeries: lifecycle,
+		}, d)
+
+		res, err := blk.WriteBatch(batch)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), res.NumSuccess)
+	}
+	query := func() int {
+		q, err := idx.NewRegexpQuery([]byte("bar"), []byte("b.*"))
+		require.NoError(t, err)
+		results := NewResults(testOpts)
+		_, err = blk.Query(Query{q}, QueryOptions{}, results)
+		require.NoError(t, err)
+		return results.Size()
+	}
+
+	write(testDoc1())
+	require.True(t, blk.NeedsMutableSegmentsEvicted())
+	require.Equal(t, 1, query())
+
+	// Cold writes made after the segment is sealed for a flush are kept.
+	require.NoError(t, blk.SealColdMutableSegments())
+	write(testDoc2())
+	require.Equal(t, 2, query())
+
+	res, err = blk.EvictMutableSegments()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), res.NumMutableSegments)
+	require.Equal(t, int64(1), res.NumDocs)
+	require.True(t, blk.NeedsMutableSegmentsEvicted())
+	require.Equal(t, 1, query())
+	require.NoError(t, blk.Close())
+}
+
 func TestBlockWriteMockSegment(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1468,6 +1533,174 @@ func testSegment(t *testing.T, docs ...doc.Document) segment.Segment {
 	return seg
 }
 
+func TestBlockBackgroundCompaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testMD := newTestNSMetadata(t)
+	blockSize := time.Hour
+
+	now := time.Now()
+	blockStart := now.Truncate(blockSize)
+
+	nowNotBlockStartAligned := now.
+		Truncate(blockSize).
+		Add(time.Minute)
+
+	compactor, err := compaction.NewCompactor(compaction.DefaultMaxCompactionSize,
+		compaction.DefaultConcurrency, testOpts.MemSegmentOptions(), fst.NewOptions())
+	require.NoError(t, err)
+	plannerOpts := compaction.DefaultOptions
+	plannerOpts.MutableSegmentSizeThreshold = 1
+	opts := testOpts.
+		SetCompactor(compactor).
+		SetCompactionPlannerOptions(plannerOpts)
+
+	blk, err := NewBlock(blockStart, testMD, opts)
+	require.NoError(t, err)
+	b, ok := blk.(*block)
+	require.True(t, ok)
+
+	for _, d := range []doc.Document{testDoc1(), testDoc2()} {
+		h := NewMockOnIndexSeries(ctrl)
+		h.EXPECT().OnIndexFinalize(xtime.ToUnixNano(blockStart))
+		h.EXPECT().OnIndexSuccess(xtime.ToUnixNano(blockStart))
+
+		batch := NewWriteBatch(WriteBatchOptions{
+			IndexBlockSize: blockSize,
+		})
+		batch.Append(WriteBatchEntry{
+			Timestamp:     nowNotBlockStartAligned,
+			OnIndexSeries: h,
+		}, d)
+
+		res, err := b.WriteBatch(batch)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), res.NumSuccess)
+		waitForBlockCompactions(t, b)
+	}
+
+	// the writes have been merged into a single FST segment.
+	b.RLock()
+	require.Equal(t, int64(0), b.activeSegment.Size())
+	require.Equal(t, 1, len(b.compactedSegments))
+	require.Equal(t, segments.FSTType, b.compactedSegments[0].segType)
+	require.Equal(t, int64(2), b.compactedSegments[0].segment.Size())
+	b.RUnlock()
+
+	q, err := idx.NewRegexpQuery([]byte("bar"), []byte("b.*"))
+	require.NoError(t, err)
+	results := NewResults(testOpts)
+	exhaustive, err := b.Query(Query{q}, QueryOptions{}, results)
+	require.NoError(t, err)
+	require.True(t, exhaustive)
+	require.Equal(t, 2, results.Size())
+
+	result, err := b.Tick(nil, now)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.NumSegments)
+	require.Equal(t, int64(2), result.NumDocs)
+
+	require.NoError(t, b.Seal())
+	require.True(t, b.NeedsMutableSegmentsEvicted())
+	evictResult, err := b.EvictMutableSegments()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), evictResult.NumMutableSegments)
+	require.Equal(t, int64(2), evictResult.NumDocs)
+	require.False(t, b.NeedsMutableSegmentsEvicted())
+}
+
+func TestBlockBackgroundCompactionOnTick(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testMD := newTestNSMetadata(t)
+	blockSize := time.Hour
+
+	now := time.Now()
+	blockStart := now.Truncate(blockSize)
+
+	compactor, err := compaction.NewCompactor(compaction.DefaultMaxCompactionSize,
+		compaction.DefaultConcurrency, testOpts.MemSegmentOptions(), fst.NewOptions())
+	require.NoError(t, err)
+	plannerOpts := compaction.DefaultOptions
+	plannerOpts.MutableCompactionAgeThreshold = time.Minute
+	opts := testOpts.
+		SetClockOptions(testOpts.ClockOptions().SetNowFn(func() time.Time { return now })).
+		SetCompactor(compactor).
+		SetCompactionPlannerOptions(plannerOpts)
+
+	blk, err := NewBlock(blockStart, testMD, opts)
+	require.NoError(t, err)
+	b, ok := blk.(*block)
+	require.True(t, ok)
+
+	h := NewMockOnIndexSeries(ctrl)
+	h.EXPECT().OnIndexFinalize(xtime.ToUnixNano(blockStart))
+	h.EXPECT().OnIndexSuccess(xtime.ToUnixNano(blockStart))
+
+	batch := NewWriteBatch(WriteBatchOptions{
+		IndexBlockSize: blockSize,
+	})
+	batch.Append(WriteBatchEntry{
+		Timestamp:     blockStart.Add(time.Minute),
+		OnIndexSeries: h,
+	}, testDoc1())
+
+	// the active segment is below the size threshold so the write does not
+	// start a compaction.
+	_, err = b.WriteBatch(batch)
+	require.NoError(t, err)
+	b.RLock()
+	require.False(t, b.compact.compacting)
+	require.Equal(t, int64(1), b.activeSegment.Size())
+	require.Equal(t, 0, len(b.compactedSegments))
+	b.RUnlock()
+
+	// the active segment has aged past the age threshold by the next tick.
+	now = now.Add(2 * time.Minute)
+	_, err = b.Tick(nil, now)
+	require.NoError(t, err)
+	waitForBlockCompactions(t, b)
+
+	b.RLock()
+	require.Equal(t, int64(0), b.activeSegment.Size())
+	require.Equal(t, 1, len(b.compactedSegments))
+	require.Equal(t, segments.FSTType, b.compactedSegments[0].segType)
+	b.RUnlock()
+}
+
+func TestBlockBackgroundCompactionDisabled(t *testing.T) {
+	testMD := newTestNSMetadata(t)
+	start := time.Now().Truncate(time.Hour)
+	blk, err := NewBlock(start, testMD, testOpts)
+	require.NoError(t, err)
+	b, ok := blk.(*block)
+	require.True(t, ok)
+
+	_, err = b.activeSegment.Insert(testDoc1())
+	require.NoError(t, err)
+
+	b.Lock()
+	b.maybeBackgroundCompactWithLock()
+	require.False(t, b.compact.compacting)
+	require.Equal(t, 0, len(b.compactedSegments))
+	b.Unlock()
+}
+
+func waitForBlockCompactions(t *testing.T, b *block) {
+	for start := time.Now(); time.Since(start) < 10*time.Second; {
+		b.RLock()
+		compacting := b.compact.compacting
+		b.RUnlock()
+		if !compacting {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.FailNow(t, "timed out waiting for compactions")
+}
+
 func testDoc1() doc.Document {
 	return doc.Document{
 		ID: []byte("foo"),
@@ -0,0 +1,230 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compaction
+
+import (
+	"bytes"
+	"errors"
+	"runtime"
+
+	"github.com/m3db/m3/src/m3ninx/index"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
+	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
+	"github.com/m3db/m3/src/m3ninx/postings"
+	"github.com/m3db/m3/src/m3ninx/x"
+)
+
+const (
+	// DefaultMaxCompactionSize is the default maximum number of documents
+	// merged by a single compaction, it matches the largest of DefaultLevels.
+	DefaultMaxCompactionSize = 1 << 23
+)
+
+var (
+	// DefaultConcurrency is the default number of compactions a compactor
+	// performs concurrently.
+	DefaultConcurrency = runtime.NumCPU()
+
+	errCompactionSizeNotPositive        = errors.New("max compaction size must be positive")
+	errCompactionConcurrencyNotPositive = errors.New("compaction concurrency must be positive")
+
+	// ErrCompactionTooLarge is returned when the segments to compact hold more
+	// documents than the compactor is allowed to merge at once.
+	ErrCompactionTooLarge = errors.New("segments to compact exceed max compaction size")
+)
+
+// Compactor merges segments into FST segments. A Compactor performs up to
+// a fixed number of compactions concurrently, each reusing the buffers of
+// a worker across compactions, together with the max compaction size this
+// bounds the memory used to merge segments.
+type Compactor struct {
+	maxSize int64
+	memOpts mem.Options
+	fstOpts fst.Options
+	workers chan *compactWorker
+}
+
+// compactWorker holds the writer and buffers used by a single compaction.
+type compactWorker struct {
+	writer fst.Writer
+
+	docsDataBuffer  bytes.Buffer
+	docsIndexBuffer bytes.Buffer
+	postingsBuffer  bytes.Buffer
+	fstTermsBuffer  bytes.Buffer
+	fstFieldsBuffer bytes.Buffer
+}
+
+// NewCompactor returns a new Compactor which merges at most maxSize documents
+// in a single compaction and performs up to concurrency compactions at once.
+func NewCompactor(
+	maxSize int64,
+	concurrency int,
+	memOpts mem.Options,
+	fstOpts fst.Options,
+) (*Compactor, error) {
+	if maxSize <= 0 {
+		return nil, errCompactionSizeNotPositive
+	}
+	if concurrency <= 0 {
+		return nil, errCompactionConcurrencyNotPositive
+	}
+	workers := make(chan *compactWorker, concurrency)
+	for i := 0; i < concurrency; i++ {
+		workers <- &compactWorker{writer: fst.NewWriter()}
+	}
+	return &Compactor{
+		maxSize: maxSize,
+		memOpts: memOpts,
+		fstOpts: fstOpts,
+		workers: workers,
+	}, nil
+}
+
+// MaxSize returns the maximum number of documents merged by a single compaction.
+func (c *Compactor) MaxSize() int64 {
+	return c.maxSize
+}
+
+// Compact merges the documents of the provided segments into a new FST
+// segment. The provided segments are left untouched and remain owned by
+// the caller.
+func (c *Compactor) Compact(segments []segment.Segment) (segment.Segment, error) {
+	var size int64
+	for _, seg := range segments {
+		size += seg.Size()
+	}
+	if size > c.maxSize {
+		return nil, ErrCompactionTooLarge
+	}
+
+	// NB: wait for a worker to be available, this bounds the number of
+	// concurrent compactions across all the blocks sharing the compactor.
+	w := <-c.workers
+	defer func() { c.workers <- w }()
+
+	// NB: the FST writer requires a sealed mutable segment so the documents
+	// are first merged into a temporary mutable segment.
+	merged, err := mem.NewSegment(postings.ID(0), c.memOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer merged.Close()
+
+	for _, seg := range segments {
+		if err := mergeInto(merged, seg); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := merged.Seal(); err != nil {
+		return nil, err
+	}
+
+	return w.writeFST(merged, c.fstOpts)
+}
+
+func (c *compactWorker) writeFST(
+	seg segment.MutableSegment,
+	fstOpts fst.Options,
+) (segment.Segment, error) {
+	if err := c.writer.Reset(seg); err != nil {
+		return nil, err
+	}
+
+	c.docsDataBuffer.Reset()
+	c.docsIndexBuffer.Reset()
+	c.postingsBuffer.Reset()
+	c.fstTermsBuffer.Reset()
+	c.fstFieldsBuffer.Reset()
+
+	if err := c.writer.WriteDocumentsData(&c.docsDataBuffer); err != nil {
+		return nil, err
+	}
+	if err := c.writer.WriteDocumentsIndex(&c.docsIndexBuffer); err != nil {
+		return nil, err
+	}
+	if err := c.writer.WritePostingsOffsets(&c.postingsBuffer); err != nil {
+		return nil, err
+	}
+	if err := c.writer.WriteFSTTerms(&c.fstTermsBuffer); err != nil {
+		return nil, err
+	}
+	if err := c.writer.WriteFSTFields(&c.fstFieldsBuffer); err != nil {
+		return nil, err
+	}
+
+	// NB: the buffers are reused by subsequent compactions so the FST segment
+	// must be given its own copy of the data.
+	return fst.NewSegment(fst.SegmentData{
+		MajorVersion:  c.writer.MajorVersion(),
+		MinorVersion:  c.writer.MinorVersion(),
+		Metadata:      copyBytes(c.writer.Metadata()),
+		DocsData:      copyBytes(c.docsDataBuffer.Bytes()),
+		DocsIdxData:   copyBytes(c.docsIndexBuffer.Bytes()),
+		PostingsData:  copyBytes(c.postingsBuffer.Bytes()),
+		FSTTermsData:  copyBytes(c.fstTermsBuffer.Bytes()),
+		FSTFieldsData: copyBytes(c.fstFieldsBuffer.Bytes()),
+	}, fstOpts)
+}
+
+func mergeInto(target segment.MutableSegment, src segment.Segment) error {
+	reader, err := src.Reader()
+	if err != nil {
+		return err
+	}
+
+	// ensure the reader is closed on all paths.
+	readerCloser := x.NewSafeCloser(reader)
+	defer readerCloser.Close()
+
+	iter, err := reader.AllDocs()
+	if err != nil {
+		return err
+	}
+
+	iterCloser := x.NewSafeCloser(iter)
+	defer iterCloser.Close()
+
+	for iter.Next() {
+		_, err := target.Insert(iter.Current())
+		if err == nil || err == index.ErrDuplicateID {
+			continue
+		}
+		return err
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	if err := iterCloser.Close(); err != nil {
+		return err
+	}
+	return readerCloser.Close()
+}
+
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append(make([]byte, 0, len(b)), b...)
+}
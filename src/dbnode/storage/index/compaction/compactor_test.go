@@ -0,0 +1,152 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compaction
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
+	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
+	"github.com/m3db/m3/src/m3ninx/postings"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMutableSegment(t *testing.T, docs ...doc.Document) segment.MutableSegment {
+	seg, err := mem.NewSegment(postings.ID(0), mem.NewOptions())
+	require.NoError(t, err)
+	for _, d := range docs {
+		_, err := seg.Insert(d)
+		require.NoError(t, err)
+	}
+	return seg
+}
+
+func newTestDoc(id string) doc.Document {
+	return doc.Document{
+		ID: []byte(id),
+		Fields: []doc.Field{
+			doc.Field{
+				Name:  []byte("name"),
+				Value: []byte(id),
+			},
+		},
+	}
+}
+
+func TestCompactorCompactMutableAndFSTSegments(t *testing.T) {
+	compactor, err := NewCompactor(10, 1, mem.NewOptions(), fst.NewOptions())
+	require.NoError(t, err)
+
+	mutable := newTestMutableSegment(t, newTestDoc("foo"), newTestDoc("bar"))
+	_, err = mutable.Seal()
+	require.NoError(t, err)
+	fstSeg := fst.ToTestSegment(t,
+		newTestMutableSegment(t, newTestDoc("baz"), newTestDoc("foo")), fst.NewOptions())
+
+	compacted, err := compactor.Compact([]segment.Segment{mutable, fstSeg})
+	require.NoError(t, err)
+	defer compacted.Close()
+
+	_, ok := compacted.(fst.Segment)
+	require.True(t, ok)
+	require.Equal(t, int64(3), compacted.Size())
+	for _, id := range []string{"foo", "bar", "baz"} {
+		exists, err := compacted.ContainsID([]byte(id))
+		require.NoError(t, err)
+		require.True(t, exists)
+	}
+
+	// ensure the source segments remain usable.
+	require.Equal(t, int64(2), mutable.Size())
+	require.Equal(t, int64(2), fstSeg.Size())
+	require.NoError(t, mutable.Close())
+	require.NoError(t, fstSeg.Close())
+
+	// ensure the compacted segment does not share buffers with the compactor.
+	other, err := compactor.Compact([]segment.Segment{
+		newTestMutableSegment(t, newTestDoc("qux")),
+	})
+	require.NoError(t, err)
+	defer other.Close()
+	require.Equal(t, int64(3), compacted.Size())
+	exists, err := compacted.ContainsID([]byte("bar"))
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+func TestCompactorCompactTooLarge(t *testing.T) {
+	compactor, err := NewCompactor(1, 1, mem.NewOptions(), fst.NewOptions())
+	require.NoError(t, err)
+
+	seg := newTestMutableSegment(t, newTestDoc("foo"), newTestDoc("bar"))
+	defer seg.Close()
+
+	_, err = compactor.Compact([]segment.Segment{seg})
+	require.Equal(t, ErrCompactionTooLarge, err)
+}
+
+func TestNewCompactorInvalidMaxSize(t *testing.T) {
+	_, err := NewCompactor(0, 1, mem.NewOptions(), fst.NewOptions())
+	require.Error(t, err)
+}
+
+func TestNewCompactorInvalidConcurrency(t *testing.T) {
+	_, err := NewCompactor(10, 0, mem.NewOptions(), fst.NewOptions())
+	require.Error(t, err)
+}
+
+func TestCompactorCompactConcurrently(t *testing.T) {
+	compactor, err := NewCompactor(10, 2, mem.NewOptions(), fst.NewOptions())
+	require.NoError(t, err)
+
+	var (
+		wg      sync.WaitGroup
+		sources = make([]segment.Segment, 4)
+		results = make([]segment.Segment, len(sources))
+		errs    = make([]error, len(sources))
+	)
+	for i := range sources {
+		sources[i] = newTestMutableSegment(t, newTestDoc(fmt.Sprintf("foo%d", i)))
+	}
+	for i := range sources {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = compactor.Compact([]segment.Segment{sources[i]})
+		}()
+	}
+	wg.Wait()
+
+	for i, compacted := range results {
+		require.NoError(t, errs[i])
+		exists, err := compacted.ContainsID([]byte(fmt.Sprintf("foo%d", i)))
+		require.NoError(t, err)
+		require.True(t, exists)
+		require.NoError(t, compacted.Close())
+		require.NoError(t, sources[i].Close())
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"errors"
 
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
 	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
 	"github.com/m3db/m3x/ident"
 	"github.com/m3db/m3x/instrument"
@@ -50,6 +51,8 @@ type opts struct {
 	idPool         ident.Pool
 	bytesPool      pool.CheckedBytesPool
 	resultsPool    ResultsPool
+	compactor      *compaction.Compactor
+	plannerOpts    compaction.PlannerOptions
 }
 
 var undefinedUUIDFn = func() ([]byte, error) { return nil, errIDGenerationDisabled }
@@ -70,12 +73,16 @@ func NewOptions() Options {
 		bytesPool:      bytesPool,
 		idPool:         idPool,
 		resultsPool:    resultsPool,
+		plannerOpts:    compaction.DefaultOptions,
 	}
 	resultsPool.Init(func() Results { return NewResults(opts) })
 	return opts
 }
 
 func (o *opts) Validate() error {
+	if err := o.plannerOpts.Validate(); err != nil {
+		return err
+	}
 	if o.idPool == nil {
 		return errOptionsIdentifierPoolUnspecified
 	}
@@ -159,3 +166,23 @@ func (o *opts) SetResultsPool(value ResultsPool) Options {
 func (o *opts) ResultsPool() ResultsPool {
 	return o.resultsPool
 }
+
+func (o *opts) SetCompactor(value *compaction.Compactor) Options {
+	opts := *o
+	opts.compactor = value
+	return &opts
+}
+
+func (o *opts) Compactor() *compaction.Compactor {
+	return o.compactor
+}
+
+func (o *opts) SetCompactionPlannerOptions(value compaction.PlannerOptions) Options {
+	opts := *o
+	opts.plannerOpts = value
+	return &opts
+}
+
+func (o *opts) CompactionPlannerOptions() compaction.PlannerOptions {
+	return o.plannerOpts
+}
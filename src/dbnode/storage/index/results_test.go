@@ -102,6 +102,21 @@ func TestResultsInsertContains(t *testing.T) {
 	require.Equal(t, 0, len(tags.Values()))
 }
 
+func TestResultsRemove(t *testing.T) {
+	res := NewResults(testOpts)
+	added, size, err := res.Add(doc.Document{ID: []byte("abc")})
+	require.NoError(t, err)
+	require.True(t, added)
+	require.Equal(t, 1, size)
+
+	require.False(t, res.Remove(ident.StringID("def")))
+	require.Equal(t, 1, res.Size())
+
+	require.True(t, res.Remove(ident.StringID("abc")))
+	require.Equal(t, 0, res.Size())
+	require.False(t, res.Map().Contains(ident.StringID("abc")))
+}
+
 func TestResultsInsertCopies(t *testing.T) {
 	res := NewResults(testOpts)
 	dValid := doc.Document{ID: []byte("abc"), Fields: []doc.Field{
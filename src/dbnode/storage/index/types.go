@@ -27,6 +27,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/m3ninx/idx"
 	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
@@ -70,6 +71,24 @@ type QueryResults struct {
 	Exhaustive bool
 }
 
+// AggregationOptions enables users to specify constraints on aggregations.
+type AggregationOptions struct {
+	QueryOptions
+
+	// FieldFilter restricts the aggregated tag names to the given names, all
+	// tag names are aggregated if it is empty.
+	FieldFilter [][]byte
+
+	// NameOnly aggregates only the tag names and not their values.
+	NameOnly bool
+}
+
+// AggregateQueryResult is the collection of results for an aggregate query.
+type AggregateQueryResult struct {
+	Results    *AggregateResults
+	Exhaustive bool
+}
+
 // Results is a collection of results for a query.
 type Results interface {
 	// Namespace returns the namespace associated with the result.
@@ -94,6 +113,10 @@ type Results interface {
 	// NB: it returns a bool to indicate if the doc was added (it won't be added
 	// if it already existed in the ResultsMap).
 	Add(d doc.Document) (added bool, size int, err error)
+
+	// Remove removes the result for the provided ID, returning whether it
+	// was present in the results.
+	Remove(id ident.ID) bool
 }
 
 // ResultsAllocator allocates Results types.
@@ -164,6 +187,11 @@ type Block interface {
 	// soon as it can be to reduce memory footprint.
 	NeedsMutableSegmentsEvicted() bool
 
+	// SealColdMutableSegments seals the mutable segment holding writes made
+	// to the block since it was sealed, writes made afterwards are held in a
+	// new mutable segment that is not evicted by the next EvictMutableSegments.
+	SealColdMutableSegments() error
+
 	// EvictMutableSegments closes any mutable segments, this is only applicable
 	// valid to be called once the block and hence mutable segments are sealed.
 	// It is expected that results have been added to the block that covers any
@@ -552,4 +580,20 @@ type Options interface {
 
 	// ResultsPool returns the results pool.
 	ResultsPool() ResultsPool
+
+	// SetCompactor sets the compactor used to merge the segments of open
+	// blocks in the background, background compactions are disabled if nil.
+	SetCompactor(value *compaction.Compactor) Options
+
+	// Compactor returns the compactor used to merge the segments of open
+	// blocks in the background.
+	Compactor() *compaction.Compactor
+
+	// SetCompactionPlannerOptions sets the options used to plan background
+	// compactions.
+	SetCompactionPlannerOptions(value compaction.PlannerOptions) Options
+
+	// CompactionPlannerOptions returns the options used to plan background
+	// compactions.
+	CompactionPlannerOptions() compaction.PlannerOptions
 }
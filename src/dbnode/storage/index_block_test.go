@@ -510,3 +510,112 @@ func TestNamespaceIndexBlockQuery(t *testing.T) {
 	_, err = idx.Query(ctx, q, qOpts)
 	require.NoError(t, err)
 }
+
+func TestNamespaceIndexBlockQueryExcludesDeletedSeries(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{t})
+	defer ctrl.Finish()
+
+	retention := 2 * time.Hour
+	blockSize := time.Hour
+	now := time.Now().Truncate(blockSize).Add(10 * time.Minute)
+	t0 := now.Truncate(blockSize)
+	nowFn := func() time.Time { return now }
+	opts := testDatabaseOptions()
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(nowFn))
+
+	b0 := index.NewMockBlock(ctrl)
+	b0.EXPECT().StartTime().Return(t0).AnyTimes()
+	b0.EXPECT().EndTime().Return(t0.Add(blockSize)).AnyTimes()
+	newBlockFn := func(ts time.Time, md namespace.Metadata, io index.Options) (index.Block, error) {
+		if ts.Equal(t0) {
+			return b0, nil
+		}
+		panic("should never get here")
+	}
+	md := testNamespaceMetadata(blockSize, retention)
+	idx, err := newNamespaceIndexWithNewBlockFn(md, newBlockFn, opts)
+	require.NoError(t, err)
+
+	ctx := context.NewContext()
+	q := index.Query{}
+	qOpts := index.QueryOptions{
+		StartInclusive: t0,
+		EndExclusive:   now.Add(time.Minute),
+	}
+	b0.EXPECT().Query(q, qOpts, gomock.Any()).DoAndReturn(
+		func(q index.Query, opts index.QueryOptions, results index.Results) (bool, error) {
+			for _, id := range []string{"foo", "bar"} {
+				_, _, err := results.Add(doc.Document{ID: []byte(id)})
+				require.NoError(t, err)
+			}
+			return true, nil
+		}).Times(2)
+
+	res, err := idx.Query(ctx, q, qOpts)
+	require.NoError(t, err)
+	require.Equal(t, 2, res.Results.Size())
+
+	require.NoError(t, idx.DeleteSeries([]ident.ID{ident.StringID("foo")}))
+
+	res, err = idx.Query(ctx, q, qOpts)
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Results.Size())
+	require.False(t, res.Results.Map().Contains(ident.StringID("foo")))
+	require.True(t, res.Results.Map().Contains(ident.StringID("bar")))
+}
+
+func TestNamespaceIndexBlockQueryLimitExcludesDeletedSeries(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{t})
+	defer ctrl.Finish()
+
+	retention := 2 * time.Hour
+	blockSize := time.Hour
+	now := time.Now().Truncate(blockSize).Add(10 * time.Minute)
+	t0 := now.Truncate(blockSize)
+	nowFn := func() time.Time { return now }
+	opts := testDatabaseOptions()
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(nowFn))
+
+	b0 := index.NewMockBlock(ctrl)
+	b0.EXPECT().StartTime().Return(t0).AnyTimes()
+	b0.EXPECT().EndTime().Return(t0.Add(blockSize)).AnyTimes()
+	newBlockFn := func(ts time.Time, md namespace.Metadata, io index.Options) (index.Block, error) {
+		if ts.Equal(t0) {
+			return b0, nil
+		}
+		panic("should never get here")
+	}
+	md := testNamespaceMetadata(blockSize, retention)
+	idx, err := newNamespaceIndexWithNewBlockFn(md, newBlockFn, opts)
+	require.NoError(t, err)
+
+	ctx := context.NewContext()
+	q := index.Query{}
+	qOpts := index.QueryOptions{
+		StartInclusive: t0,
+		EndExclusive:   now.Add(time.Minute),
+		Limit:          2,
+	}
+	b0.EXPECT().Query(q, qOpts, gomock.Any()).DoAndReturn(
+		func(q index.Query, opts index.QueryOptions, results index.Results) (bool, error) {
+			// Add documents until the limit is reached as the block does.
+			for _, id := range []string{"foo", "bar", "baz"} {
+				if results.Size() >= opts.Limit {
+					return false, nil
+				}
+				_, _, err := results.Add(doc.Document{ID: []byte(id)})
+				require.NoError(t, err)
+			}
+			return true, nil
+		})
+
+	require.NoError(t, idx.DeleteSeries([]ident.ID{ident.StringID("foo")}))
+
+	// The deleted series does not count towards the limit.
+	res, err := idx.Query(ctx, q, qOpts)
+	require.NoError(t, err)
+	require.True(t, res.Exhaustive)
+	require.Equal(t, 2, res.Results.Size())
+	require.True(t, res.Results.Map().Contains(ident.StringID("bar")))
+	require.True(t, res.Results.Map().Contains(ident.StringID("baz")))
+}
@@ -323,3 +323,75 @@ func TestNamespaceIndexInsertQuery(t *testing.T) {
 		ident.MustNewTagStringsIterator("name", "value")).Matches(
 		ident.NewTagsIterator(tags)))
 }
+
+func TestNamespaceIndexInsertColdWriteQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	md, err := namespace.NewMetadata(defaultTestNs1ID,
+		defaultTestNs1Opts.SetColdWritesEnabled(true))
+	require.NoError(t, err)
+
+	opts := testNamespaceIndexOptions().SetInsertMode(index.InsertSync)
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return now
+	}))
+	dbIdx, err := newNamespaceIndex(md, testDatabaseOptions().SetIndexOptions(opts))
+	require.NoError(t, err)
+	defer dbIdx.Close()
+
+	idx, ok := dbIdx.(*nsIndex)
+	require.True(t, ok)
+
+	var (
+		backfilled = now.Add(-24 * time.Hour)
+		blockStart = xtime.ToUnixNano(backfilled.Truncate(idx.blockSize))
+		tags       = ident.NewTags(ident.StringTag("name", "value"))
+		lifecycle  = index.NewMockOnIndexSeries(ctrl)
+	)
+	lifecycle.EXPECT().OnIndexSuccess(blockStart).Times(2)
+	lifecycle.EXPECT().OnIndexFinalize(blockStart).Times(2)
+
+	// Index a series to the backfilled block and seal it.
+	entry, document := testWriteBatchEntry(ident.StringID("foo"), tags, backfilled, lifecycle)
+	require.NoError(t, idx.WriteBatch(testWriteBatch(entry, document,
+		testWriteBatchBlockSizeOption(idx.blockSize))))
+	_, err = idx.Tick(context.NewCancellable(), now)
+	require.NoError(t, err)
+
+	// New series backfilled to the sealed block are indexed too.
+	entry, document = testWriteBatchEntry(ident.StringID("bar"), tags, backfilled, lifecycle)
+	require.NoError(t, idx.WriteBatch(testWriteBatch(entry, document,
+		testWriteBatchBlockSizeOption(idx.blockSize))))
+
+	reQuery, err := m3ninxidx.NewRegexpQuery([]byte("name"), []byte("val.*"))
+	require.NoError(t, err)
+	ctx := context.NewContext()
+	defer ctx.Close()
+	res, err := idx.Query(ctx, index.Query{reQuery}, index.QueryOptions{
+		StartInclusive: backfilled.Add(-1 * time.Minute),
+		EndExclusive:   backfilled.Add(1 * time.Minute),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, res.Results.Size())
+	for _, id := range []string{"foo", "bar"} {
+		_, ok := res.Results.Map().Get(ident.StringID(id))
+		assert.True(t, ok)
+	}
+
+	// Writes out of retention are still rejected.
+	tooOld := now.Add(-1 * idx.retentionPeriod).Add(-1 * idx.blockSize)
+	lifecycle.EXPECT().OnIndexFinalize(xtime.ToUnixNano(tooOld.Truncate(idx.blockSize)))
+	entry, document = testWriteBatchEntry(ident.StringID("baz"), tags, tooOld, lifecycle)
+	batch := testWriteBatch(entry, document, testWriteBatchBlockSizeOption(idx.blockSize))
+	require.Error(t, idx.WriteBatch(batch))
+	batch.ForEach(func(
+		idx int,
+		entry index.WriteBatchEntry,
+		doc doc.Document,
+		result index.WriteBatchEntryResult,
+	) {
+		require.Equal(t, m3dberrors.ErrTooPast, result.Err)
+	})
+}
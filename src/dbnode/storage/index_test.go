@@ -111,6 +111,7 @@ func TestNamespaceIndexFlushSuccess(t *testing.T) {
 
 	mockBlock.EXPECT().IsSealed().Return(true)
 	mockBlock.EXPECT().NeedsMutableSegmentsEvicted().Return(true)
+	mockBlock.EXPECT().SealColdMutableSegments().Return(nil)
 
 	mockShard := NewMockdatabaseShard(ctrl)
 	mockShard.EXPECT().ID().Return(uint32(0)).AnyTimes()
@@ -223,6 +224,7 @@ func TestNamespaceIndexFlushSuccessMultipleShards(t *testing.T) {
 
 	mockBlock.EXPECT().IsSealed().Return(true)
 	mockBlock.EXPECT().NeedsMutableSegmentsEvicted().Return(true)
+	mockBlock.EXPECT().SealColdMutableSegments().Return(nil)
 
 	mockShard1 := NewMockdatabaseShard(ctrl)
 	mockShard1.EXPECT().ID().Return(uint32(0)).AnyTimes()
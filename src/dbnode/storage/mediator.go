@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/clock"
+	xerrors "github.com/m3db/m3x/errors"
 
 	"github.com/uber-go/tally"
 )
@@ -169,6 +170,28 @@ func (m *mediator) Tick(runType runType, forceType forceType) error {
 	return nil
 }
 
+// FlushAndSnapshot forces a tick and then synchronously cleans up, flushes and
+// snapshots. Unlike a forced Tick the cleanup and flush errors are returned
+// rather than logged, so callers must disable file operations beforehand to
+// ensure no other file operation runs concurrently.
+func (m *mediator) FlushAndSnapshot() error {
+	tickStart := m.nowFn()
+	dbBootstrapStateAtTickStart := m.database.BootstrapState()
+
+	if err := m.databaseTickManager.Tick(force, tickStart); err != nil {
+		return err
+	}
+
+	multiErr := xerrors.NewMultiError()
+	if err := m.databaseFileSystemManager.Cleanup(tickStart); err != nil {
+		multiErr = multiErr.Add(err)
+	}
+	if err := m.databaseFileSystemManager.Flush(tickStart, dbBootstrapStateAtTickStart); err != nil {
+		multiErr = multiErr.Add(err)
+	}
+	return multiErr.FinalError()
+}
+
 func (m *mediator) Report() {
 	m.databaseBootstrapManager.Report()
 	m.databaseRepairer.Report()
@@ -21,6 +21,7 @@
 package storage
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -89,3 +90,35 @@ func TestDatabaseMediatorDisableFileOps(t *testing.T) {
 	m.DisableFileOps()
 	require.Equal(t, 3, len(slept))
 }
+
+func TestDatabaseMediatorFlushAndSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := testDatabaseOptions().SetRepairEnabled(false)
+	now := time.Now()
+	opts = opts.
+		SetBootstrapProcessProvider(nil).
+		SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+			return now
+		}))
+
+	db := NewMockdatabase(ctrl)
+	db.EXPECT().Options().Return(opts).AnyTimes()
+	db.EXPECT().BootstrapState().Return(DatabaseBootstrapState{}).AnyTimes()
+	med, err := newMediator(db, opts)
+	require.NoError(t, err)
+
+	m := med.(*mediator)
+	tm := NewMockdatabaseTickManager(ctrl)
+	fsm := NewMockdatabaseFileSystemManager(ctrl)
+	m.databaseTickManager = tm
+	m.databaseFileSystemManager = fsm
+
+	gomock.InOrder(
+		tm.EXPECT().Tick(force, now).Return(nil),
+		fsm.EXPECT().Cleanup(now).Return(nil),
+		fsm.EXPECT().Flush(now, DatabaseBootstrapState{}).Return(errors.New("flush error")),
+	)
+	require.EqualError(t, m.FlushAndSnapshot(), "flush error")
+}
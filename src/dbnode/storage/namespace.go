@@ -32,12 +32,14 @@ import (
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/dbnode/storage/quota"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
@@ -46,6 +48,7 @@ import (
 	"github.com/m3db/m3x/ident"
 	"github.com/m3db/m3x/instrument"
 	xlog "github.com/m3db/m3x/log"
+	"github.com/m3db/m3x/pool"
 	xsync "github.com/m3db/m3x/sync"
 	xtime "github.com/m3db/m3x/time"
 
@@ -55,6 +58,14 @@ import (
 var (
 	errNamespaceAlreadyClosed    = errors.New("namespace already closed")
 	errNamespaceIndexingDisabled = errors.New("namespace indexing is disabled")
+
+	errDownsampleTargetColdWritesDisabled = errors.New("downsample target namespace must have cold writes enabled")
+)
+
+const (
+	// deleteTaggedPageSize is the number of series resolved and deleted at a
+	// time by a delete tagged request.
+	deleteTaggedPageSize = 4096
 )
 
 type commitLogWriter interface {
@@ -112,6 +123,7 @@ type dbNamespace struct {
 	snapshotFilesFn    snapshotFilesFn
 	log                xlog.Logger
 	bootstrapState     BootstrapState
+	coldFlushedUntil   time.Time
 
 	// Contains an entry to all shards for fast shard lookup, an
 	// entry will be nil when this shard does not belong to current database
@@ -120,6 +132,7 @@ type dbNamespace struct {
 	increasingIndex increasingIndex
 	commitLogWriter commitLogWriter
 	reverseIndex    namespaceIndex
+	writeQuotas     quota.Tracker
 
 	tickWorkers            xsync.WorkerPool
 	tickWorkersConcurrency int
@@ -145,6 +158,9 @@ type databaseNamespaceMetrics struct {
 	bootstrap           instrument.MethodMetrics
 	flush               instrument.MethodMetrics
 	flushIndex          instrument.MethodMetrics
+	coldFlush           instrument.MethodMetrics
+	purgeTombstones     instrument.MethodMetrics
+	downsample          instrument.MethodMetrics
 	snapshot            instrument.MethodMetrics
 	write               instrument.MethodMetrics
 	writeTagged         instrument.MethodMetrics
@@ -152,9 +168,12 @@ type databaseNamespaceMetrics struct {
 	fetchBlocks         instrument.MethodMetrics
 	fetchBlocksMetadata instrument.MethodMetrics
 	queryIDs            instrument.MethodMetrics
+	aggregateQuery      instrument.MethodMetrics
+	deleteTagged        instrument.MethodMetrics
 	unfulfilled         tally.Counter
 	bootstrapStart      tally.Counter
 	bootstrapEnd        tally.Counter
+	coldWritesReplayed  tally.Counter
 	shards              databaseNamespaceShardMetrics
 	tick                databaseNamespaceTickMetrics
 	status              databaseNamespaceStatusMetrics
@@ -222,6 +241,9 @@ func newDatabaseNamespaceMetrics(scope tally.Scope, samplingRate float64) databa
 		bootstrap:           instrument.NewMethodMetrics(scope, "bootstrap", samplingRate),
 		flush:               instrument.NewMethodMetrics(scope, "flush", samplingRate),
 		flushIndex:          instrument.NewMethodMetrics(scope, "flushIndex", samplingRate),
+		coldFlush:           instrument.NewMethodMetrics(scope, "coldFlush", samplingRate),
+		purgeTombstones:     instrument.NewMethodMetrics(scope, "purgeTombstones", samplingRate),
+		downsample:          instrument.NewMethodMetrics(scope, "downsample", samplingRate),
 		snapshot:            instrument.NewMethodMetrics(scope, "snapshot", samplingRate),
 		write:               instrument.NewMethodMetrics(scope, "write", overrideWriteSamplingRate),
 		writeTagged:         instrument.NewMethodMetrics(scope, "write-tagged", overrideWriteSamplingRate),
@@ -229,9 +251,12 @@ func newDatabaseNamespaceMetrics(scope tally.Scope, samplingRate float64) databa
 		fetchBlocks:         instrument.NewMethodMetrics(scope, "fetchBlocks", samplingRate),
 		fetchBlocksMetadata: instrument.NewMethodMetrics(scope, "fetchBlocksMetadata", samplingRate),
 		queryIDs:            instrument.NewMethodMetrics(scope, "queryIDs", samplingRate),
+		aggregateQuery:      instrument.NewMethodMetrics(scope, "aggregateQuery", samplingRate),
+		deleteTagged:        instrument.NewMethodMetrics(scope, "deleteTagged", samplingRate),
 		unfulfilled:         scope.Counter("bootstrap.unfulfilled"),
 		bootstrapStart:      scope.Counter("bootstrap.start"),
 		bootstrapEnd:        scope.Counter("bootstrap.end"),
+		coldWritesReplayed:  scope.Counter("bootstrap.cold-writes-replayed"),
 		shards: databaseNamespaceShardMetrics{
 			add:         shardsScope.Counter("add"),
 			close:       shardsScope.Counter("close"),
@@ -299,7 +324,26 @@ func newDatabaseNamespace(
 	tickWorkers := xsync.NewWorkerPool(tickWorkersConcurrency)
 	tickWorkers.Init()
 
+	// Namespaces using an encoding scheme other than the default use their
+	// own encoder and iterator pools.
+	blockOpts, err := block.NewEncodingSchemeOptions(opts.DatabaseBlockOptions(),
+		nopts.EncodingScheme(), pool.NewObjectPoolOptions().
+			SetInstrumentOptions(iops.SetMetricsScope(scope.SubScope("encoding-pool"))))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"unable to create namespace %v, invalid encoding scheme: %v",
+			metadata.ID().String(), err)
+	}
+	if !blockOpts.CodecRegistry().IsDefault(nopts.EncodingScheme()) {
+		opts = opts.
+			SetDatabaseBlockOptions(blockOpts).
+			SetEncoderPool(blockOpts.EncoderPool()).
+			SetReaderIteratorPool(blockOpts.ReaderIteratorPool()).
+			SetMultiReaderIteratorPool(blockOpts.MultiReaderIteratorPool())
+	}
+
 	seriesOpts := NewSeriesOptionsFromOptions(opts, nopts.RetentionOptions()).
+		SetColdWritesEnabled(nopts.ColdWritesEnabled()).
 		SetStats(series.NewStats(scope))
 	if err := seriesOpts.Validate(); err != nil {
 		return nil, fmt.Errorf(
@@ -307,10 +351,7 @@ func newDatabaseNamespace(
 			metadata.ID().String(), err)
 	}
 
-	var (
-		index namespaceIndex
-		err   error
-	)
+	var index namespaceIndex
 	if metadata.Options().IndexOptions().Enabled() {
 		index, err = newNamespaceIndex(metadata, opts)
 		if err != nil {
@@ -318,6 +359,18 @@ func newDatabaseNamespace(
 		}
 	}
 
+	var writeQuotas quota.Tracker
+	if wqOpts := opts.WriteQuotaOptions(); wqOpts != nil {
+		writeQuotas, err = quota.NewTracker(wqOpts.
+			SetClockOptions(opts.ClockOptions()).
+			SetInstrumentOptions(iops.SetMetricsScope(scope)))
+		if err != nil {
+			return nil, fmt.Errorf(
+				"unable to create namespace %v, invalid write quota options: %v",
+				metadata.ID().String(), err)
+		}
+	}
+
 	n := &dbNamespace{
 		id:                     id,
 		shutdownCh:             make(chan struct{}),
@@ -334,6 +387,7 @@ func newDatabaseNamespace(
 		increasingIndex:        increasingIndex,
 		commitLogWriter:        commitLogWriter,
 		reverseIndex:           index,
+		writeQuotas:            writeQuotas,
 		tickWorkers:            tickWorkers,
 		tickWorkersConcurrency: tickWorkersConcurrency,
 		metrics:                newDatabaseNamespaceMetrics(scope, iops.MetricsSamplingRate()),
@@ -421,7 +475,7 @@ func (n *dbNamespace) AssignShardSet(shardSet sharding.ShardSet) {
 			bootstrapEnabled := n.nopts.BootstrapEnabled()
 			n.shards[shard] = newDatabaseShard(n.metadata, shard, n.blockRetriever,
 				n.namespaceReaderMgr, n.increasingIndex, n.commitLogWriter, n.reverseIndex,
-				bootstrapEnabled, n.opts, n.seriesOpts)
+				n.writeQuotas, bootstrapEnabled, n.opts, n.seriesOpts)
 			n.metrics.shards.add.Inc(1)
 		}
 	}
@@ -608,6 +662,120 @@ func (n *dbNamespace) QueryIDs(
 	return res, err
 }
 
+func (n *dbNamespace) AggregateQuery(
+	ctx context.Context,
+	query index.Query,
+	opts index.AggregationOptions,
+) (index.AggregateQueryResult, error) {
+	callStart := n.nowFn()
+	if n.reverseIndex == nil { // only happens if indexing is enabled.
+		n.metrics.aggregateQuery.ReportError(n.nowFn().Sub(callStart))
+		return index.AggregateQueryResult{}, errNamespaceIndexingDisabled
+	}
+	res, err := n.reverseIndex.AggregateQuery(ctx, query, opts)
+	n.metrics.aggregateQuery.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
+	return res, err
+}
+
+func (n *dbNamespace) DeleteTagged(
+	ctx context.Context,
+	query index.Query,
+	opts index.QueryOptions,
+) (map[uint32]int64, error) {
+	callStart := n.nowFn()
+	if n.reverseIndex == nil { // only happens if indexing is enabled.
+		n.metrics.deleteTagged.ReportError(n.nowFn().Sub(callStart))
+		return nil, errNamespaceIndexingDisabled
+	}
+
+	// Resolve the series matching the query across the entire retention
+	// unless the range is bounded by the request.
+	ropts := n.nopts.RetentionOptions()
+	if opts.StartInclusive.IsZero() {
+		opts.StartInclusive = callStart.Add(-ropts.RetentionPeriod() - ropts.BlockSize())
+	}
+	if opts.EndExclusive.IsZero() {
+		opts.EndExclusive = callStart.Add(ropts.BufferFuture() + ropts.BlockSize())
+	}
+
+	// Page through the matching series, each page is tombstoned before the
+	// next is queried so that the series deleted are masked from later pages.
+	var (
+		deleted = make(map[uint32]int64)
+		total   int
+	)
+	for {
+		pageOpts := opts
+		pageOpts.Limit = deleteTaggedPageSize
+		if opts.Limit > 0 && opts.Limit-total < pageOpts.Limit {
+			pageOpts.Limit = opts.Limit - total
+		}
+
+		numDeleted, exhaustive, err := n.deleteTaggedPage(query, pageOpts, deleted)
+		if err != nil {
+			n.metrics.deleteTagged.ReportError(n.nowFn().Sub(callStart))
+			return nil, err
+		}
+		total += numDeleted
+		if exhaustive || numDeleted == 0 || (opts.Limit > 0 && total >= opts.Limit) {
+			break
+		}
+	}
+
+	n.metrics.deleteTagged.ReportSuccess(n.nowFn().Sub(callStart))
+	return deleted, nil
+}
+
+// deleteTaggedPage deletes a single page of the series matching the query,
+// adding the number of series deleted from each shard to deleted, and returns
+// the number of series deleted and whether the query was exhaustive.
+func (n *dbNamespace) deleteTaggedPage(
+	query index.Query,
+	opts index.QueryOptions,
+	deleted map[uint32]int64,
+) (int, bool, error) {
+	// NB: the results of each page are released once the page is deleted.
+	ctx := n.opts.ContextPool().Get()
+	defer ctx.Close()
+
+	res, err := n.reverseIndex.Query(ctx, query, opts)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var (
+		ids     = make([]ident.ID, 0, res.Results.Size())
+		byShard = make(map[databaseShard][]ident.ID)
+	)
+	for _, entry := range res.Results.Map().Iter() {
+		id := entry.Key()
+		shard, err := n.shardFor(id)
+		if err != nil {
+			return 0, false, err
+		}
+		ids = append(ids, id)
+		byShard[shard] = append(byShard[shard], id)
+	}
+
+	// Tombstone in the index first so the series are excluded from queries
+	// before their in-memory data is dropped.
+	if err := n.reverseIndex.DeleteSeries(ids); err != nil {
+		return 0, false, err
+	}
+	var multiErr xerrors.MultiError
+	for shard, shardIDs := range byShard {
+		if _, err := shard.DeleteSeries(shardIDs); err != nil {
+			multiErr = multiErr.Add(fmt.Errorf(
+				"shard %d failed to delete series: %v", shard.ID(), err))
+		}
+		deleted[shard.ID()] += int64(len(shardIDs))
+	}
+	if err := multiErr.FinalError(); err != nil {
+		return 0, false, err
+	}
+	return len(ids), res.Exhaustive, nil
+}
+
 func (n *dbNamespace) ReadEncoded(
 	ctx context.Context,
 	id ident.ID,
@@ -788,6 +956,10 @@ func (n *dbNamespace) Bootstrap(start time.Time, process bootstrap.Process) erro
 		multiErr = multiErr.Add(err)
 	}
 
+	if n.nopts.ColdWritesEnabled() && n.nopts.WritesToCommitLog() {
+		multiErr = multiErr.Add(n.replayColdWrites(shards))
+	}
+
 	markAnyUnfulfilled := func(label string, unfulfilled result.ShardTimeRanges) {
 		shardsUnfulfilled := int64(len(unfulfilled))
 		n.metrics.unfulfilled.Inc(shardsUnfulfilled)
@@ -872,6 +1044,248 @@ func (n *dbNamespace) Flush(
 	return res
 }
 
+func (n *dbNamespace) ColdFlush(
+	shardBootstrapStatesAtTickStart ShardBootstrapStates,
+	flush persist.DataFlush,
+) error {
+	// NB(rartoul): This value can be used for emitting metrics, but should not be used
+	// for business logic.
+	callStart := n.nowFn()
+
+	n.RLock()
+	if n.bootstrapState != Bootstrapped {
+		n.RUnlock()
+		n.metrics.coldFlush.ReportError(n.nowFn().Sub(callStart))
+		return errNamespaceNotBootstrapped
+	}
+	n.RUnlock()
+
+	if !n.nopts.FlushEnabled() || !n.nopts.ColdWritesEnabled() {
+		n.metrics.coldFlush.ReportSuccess(n.nowFn().Sub(callStart))
+		return nil
+	}
+
+	var (
+		// Cold writes accepted before this point in time are persisted if
+		// every shard cold flushes successfully and none has pending writes.
+		coldFlushStart = n.nowFn()
+		complete       = true
+		multiErr       = xerrors.NewMultiError()
+		shards         = n.GetOwnedShards()
+	)
+	for _, shard := range shards {
+		shardBootstrapStateBeforeTick, ok := shardBootstrapStatesAtTickStart[shard.ID()]
+		if !ok || shardBootstrapStateBeforeTick != Bootstrapped {
+			// Same as warm flushes, wait until the shard was bootstrapped
+			// before the previous tick.
+			complete = false
+			continue
+		}
+
+		pending, err := shard.ColdFlush(flush)
+		if err != nil {
+			detailedErr := fmt.Errorf("shard %d failed to cold flush data: %v",
+				shard.ID(), err)
+			multiErr = multiErr.Add(detailedErr)
+		}
+		if pending {
+			complete = false
+		}
+	}
+
+	res := multiErr.FinalError()
+	if res == nil && complete {
+		n.Lock()
+		n.coldFlushedUntil = coldFlushStart
+		n.Unlock()
+	}
+
+	n.metrics.coldFlush.ReportSuccessOrError(res, n.nowFn().Sub(callStart))
+	return res
+}
+
+func (n *dbNamespace) PurgeTombstones(
+	shardBootstrapStatesAtTickStart ShardBootstrapStates,
+	flush persist.DataFlush,
+) error {
+	// NB(rartoul): This value can be used for emitting metrics, but should not be used
+	// for business logic.
+	callStart := n.nowFn()
+
+	n.RLock()
+	if n.bootstrapState != Bootstrapped {
+		n.RUnlock()
+		n.metrics.purgeTombstones.ReportError(n.nowFn().Sub(callStart))
+		return errNamespaceNotBootstrapped
+	}
+	n.RUnlock()
+
+	if !n.nopts.FlushEnabled() {
+		n.metrics.purgeTombstones.ReportSuccess(n.nowFn().Sub(callStart))
+		return nil
+	}
+
+	multiErr := xerrors.NewMultiError()
+	for _, shard := range n.GetOwnedShards() {
+		shardBootstrapStateBeforeTick, ok := shardBootstrapStatesAtTickStart[shard.ID()]
+		if !ok || shardBootstrapStateBeforeTick != Bootstrapped {
+			continue
+		}
+		if err := shard.PurgeTombstones(flush); err != nil {
+			detailedErr := fmt.Errorf("shard %d failed to purge deleted series: %v",
+				shard.ID(), err)
+			multiErr = multiErr.Add(detailedErr)
+		}
+	}
+
+	res := multiErr.FinalError()
+	n.metrics.purgeTombstones.ReportSuccessOrError(res, n.nowFn().Sub(callStart))
+	return res
+}
+
+func (n *dbNamespace) Downsample(
+	now time.Time,
+	shardBootstrapStatesAtTickStart ShardBootstrapStates,
+	target databaseNamespace,
+) error {
+	// NB(rartoul): This value can be used for emitting metrics, but should not be used
+	// for business logic.
+	callStart := n.nowFn()
+
+	n.RLock()
+	if n.bootstrapState != Bootstrapped {
+		n.RUnlock()
+		n.metrics.downsample.ReportError(n.nowFn().Sub(callStart))
+		return errNamespaceNotBootstrapped
+	}
+	n.RUnlock()
+
+	dopts := n.nopts.DownsampleOptions()
+	if !n.nopts.FlushEnabled() || !dopts.Enabled() {
+		n.metrics.downsample.ReportSuccess(n.nowFn().Sub(callStart))
+		return nil
+	}
+
+	// Downsampled data is written to blocks of the target namespace that have
+	// already been flushed, which requires them to accept cold writes.
+	if !target.Options().ColdWritesEnabled() {
+		n.metrics.downsample.ReportError(n.nowFn().Sub(callStart))
+		return errDownsampleTargetColdWritesDisabled
+	}
+
+	var (
+		ropts              = n.nopts.RetentionOptions()
+		blockSize          = ropts.BlockSize()
+		targetRopts        = target.Options().RetentionOptions()
+		targetFlushTimeEnd = retention.FlushTimeEnd(targetRopts, now)
+		// Only blocks that have aged entirely beyond the downsample after
+		// period are downsampled.
+		start    = retention.FlushTimeStart(ropts, now)
+		end      = now.Add(-dopts.After()).Add(-blockSize).Truncate(blockSize)
+		multiErr = xerrors.NewMultiError()
+		shards   = n.GetOwnedShards()
+	)
+	for blockStart := start; !blockStart.After(end); blockStart = blockStart.Add(blockSize) {
+		// Wait until all of the target blocks the block is downsampled into
+		// have been flushed so that none of the writes land in the target's
+		// buffer for blocks that are not yet flushable.
+		lastWindow := blockStart.Add(blockSize).Add(-dopts.Resolution())
+		if lastWindow.Truncate(targetRopts.BlockSize()).After(targetFlushTimeEnd) {
+			break
+		}
+
+		for _, shard := range shards {
+			shardBootstrapStateBeforeTick, ok := shardBootstrapStatesAtTickStart[shard.ID()]
+			if !ok || shardBootstrapStateBeforeTick != Bootstrapped {
+				continue
+			}
+			if shard.FlushState(blockStart).Status != fileOpSuccess {
+				continue
+			}
+			if shard.DownsampleState(blockStart).Status == fileOpSuccess {
+				continue
+			}
+			if err := shard.Downsample(blockStart, target); err != nil {
+				detailedErr := fmt.Errorf("shard %d failed to downsample block %s: %v",
+					shard.ID(), blockStart.String(), err)
+				multiErr = multiErr.Add(detailedErr)
+			}
+		}
+	}
+
+	res := multiErr.FinalError()
+	n.metrics.downsample.ReportSuccessOrError(res, n.nowFn().Sub(callStart))
+	return res
+}
+
+func (n *dbNamespace) ColdFlushedUntil() time.Time {
+	n.RLock()
+	v := n.coldFlushedUntil
+	n.RUnlock()
+	return v
+}
+
+// replayColdWrites replays the commit log writes for blocks that have already
+// been flushed into the series buffers of the given shards. The filesystem
+// bootstrapper fulfills flushed blocks from their filesets alone, so cold
+// writes that were not yet cold flushed are otherwise lost on restart. Writes
+// already contained in the filesets are deduplicated by the next cold flush.
+func (n *dbNamespace) replayColdWrites(shards []databaseShard) error {
+	var (
+		blockSize = n.nopts.RetentionOptions().BlockSize()
+		byID      = make(map[uint32]databaseShard, len(shards))
+		replayed  int64
+	)
+	for _, shard := range shards {
+		byID[shard.ID()] = shard
+	}
+
+	iter, err := commitlog.NewIterator(commitlog.IteratorOpts{
+		CommitLogOptions:    n.opts.CommitLogOptions(),
+		FileFilterPredicate: commitlog.ReadAllPredicate(),
+		SeriesFilterPredicate: func(_ ident.ID, namespace ident.ID) bool {
+			return n.id.Equal(namespace)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	ctx := n.opts.ContextPool().Get()
+	defer ctx.Close()
+
+	for iter.Next() {
+		entry, dp, unit, annotation := iter.Current()
+		shard, ok := byID[entry.Shard]
+		if !ok {
+			continue
+		}
+		blockStart := dp.Timestamp.Truncate(blockSize)
+		if shard.FlushState(blockStart).Status != fileOpSuccess {
+			// Data for unflushed blocks is bootstrapped from the commit log
+			continue
+		}
+
+		err := shard.ReplayColdWrite(ctx, entry.ID, dp.Timestamp, dp.Value,
+			unit, annotation)
+		if err != nil {
+			if xerrors.IsInvalidParams(err) {
+				// Writes that have since fallen out of retention
+				continue
+			}
+			return err
+		}
+		replayed++
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	n.metrics.coldWritesReplayed.Inc(replayed)
+	return nil
+}
+
 func (n *dbNamespace) FlushIndex(
 	flush persist.IndexFlush,
 ) error {
@@ -1202,7 +1616,7 @@ func (n *dbNamespace) initShards(needBootstrap bool) {
 	for _, shard := range shards {
 		dbShards[shard] = newDatabaseShard(n.metadata, shard, n.blockRetriever,
 			n.namespaceReaderMgr, n.increasingIndex, n.commitLogWriter, n.reverseIndex,
-			needBootstrap, n.opts, n.seriesOpts)
+			n.writeQuotas, needBootstrap, n.opts, n.seriesOpts)
 	}
 	n.shards = dbShards
 	n.Unlock()
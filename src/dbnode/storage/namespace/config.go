@@ -48,14 +48,17 @@ func (m *MapConfiguration) Map() (Map, error) {
 
 // MetadataConfiguration is the configuration for a single namespace
 type MetadataConfiguration struct {
-	ID                string                  `yaml:"id" validate:"nonzero"`
-	BootstrapEnabled  *bool                   `yaml:"bootstrapEnabled"`
-	FlushEnabled      *bool                   `yaml:"flushEnabled"`
-	WritesToCommitLog *bool                   `yaml:"writesToCommitLog"`
-	CleanupEnabled    *bool                   `yaml:"cleanupEnabled"`
-	RepairEnabled     *bool                   `yaml:"repairEnabled"`
-	Retention         retention.Configuration `yaml:"retention" validate:"nonzero"`
-	Index             IndexConfiguration      `yaml:"index"`
+	ID                string                   `yaml:"id" validate:"nonzero"`
+	BootstrapEnabled  *bool                    `yaml:"bootstrapEnabled"`
+	FlushEnabled      *bool                    `yaml:"flushEnabled"`
+	WritesToCommitLog *bool                    `yaml:"writesToCommitLog"`
+	CleanupEnabled    *bool                    `yaml:"cleanupEnabled"`
+	RepairEnabled     *bool                    `yaml:"repairEnabled"`
+	ColdWritesEnabled *bool                    `yaml:"coldWritesEnabled"`
+	Retention         retention.Configuration  `yaml:"retention" validate:"nonzero"`
+	Index             IndexConfiguration       `yaml:"index"`
+	Downsample        *DownsampleConfiguration `yaml:"downsample"`
+	EncodingScheme    string                   `yaml:"encodingScheme"`
 }
 
 // Metadata returns a Metadata corresponding to the receiver struct
@@ -80,6 +83,19 @@ func (mc *MetadataConfiguration) Metadata() (Metadata, error) {
 	if v := mc.RepairEnabled; v != nil {
 		opts = opts.SetRepairEnabled(*v)
 	}
+	if v := mc.ColdWritesEnabled; v != nil {
+		opts = opts.SetColdWritesEnabled(*v)
+	}
+	if v := mc.Downsample; v != nil {
+		dopts, err := v.Options()
+		if err != nil {
+			return nil, err
+		}
+		opts = opts.SetDownsampleOptions(dopts)
+	}
+	if v := mc.EncodingScheme; v != "" {
+		opts = opts.SetEncodingScheme(v)
+	}
 	return NewMetadata(ident.StringID(mc.ID), opts)
 }
 
@@ -95,3 +111,30 @@ func (ic *IndexConfiguration) Options() IndexOptions {
 		SetEnabled(ic.Enabled).
 		SetBlockSize(ic.BlockSize)
 }
+
+// DownsampleConfiguration controls downsampling of aged data into a lower
+// resolution target namespace.
+type DownsampleConfiguration struct {
+	Enabled         bool          `yaml:"enabled"`
+	After           time.Duration `yaml:"after" validate:"nonzero"`
+	Resolution      time.Duration `yaml:"resolution" validate:"nonzero"`
+	Aggregation     string        `yaml:"aggregation"`
+	TargetNamespace string        `yaml:"targetNamespace" validate:"nonzero"`
+}
+
+// Options returns the DownsampleOptions corresponding to the receiver struct.
+func (dc *DownsampleConfiguration) Options() (DownsampleOptions, error) {
+	dopts := NewDownsampleOptions().
+		SetEnabled(dc.Enabled).
+		SetAfter(dc.After).
+		SetResolution(dc.Resolution).
+		SetTargetNamespace(ident.StringID(dc.TargetNamespace))
+	if dc.Aggregation != "" {
+		aggregation, err := ParseDownsampleAggregation(dc.Aggregation)
+		if err != nil {
+			return nil, err
+		}
+		dopts = dopts.SetAggregation(aggregation)
+	}
+	return dopts, nil
+}
@@ -91,6 +91,7 @@ func TestMetadataConfig(t *testing.T) {
 		writesToCommitLog = true
 		cleanupEnabled    = false
 		repairEnabled     = false
+		coldWritesEnabled = true
 		retention         = retention.Configuration{
 			BlockSize:       time.Hour,
 			RetentionPeriod: time.Hour,
@@ -108,6 +109,7 @@ func TestMetadataConfig(t *testing.T) {
 			WritesToCommitLog: &writesToCommitLog,
 			CleanupEnabled:    &cleanupEnabled,
 			RepairEnabled:     &repairEnabled,
+			ColdWritesEnabled: &coldWritesEnabled,
 			Retention:         retention,
 			Index:             index,
 		}
@@ -123,10 +125,44 @@ func TestMetadataConfig(t *testing.T) {
 	require.Equal(t, writesToCommitLog, opts.WritesToCommitLog())
 	require.Equal(t, cleanupEnabled, opts.CleanupEnabled())
 	require.Equal(t, repairEnabled, opts.RepairEnabled())
+	require.Equal(t, coldWritesEnabled, opts.ColdWritesEnabled())
 	require.Equal(t, retention.Options(), opts.RetentionOptions())
 	require.Equal(t, index.Options(), opts.IndexOptions())
 }
 
+func TestMetadataConfigDownsample(t *testing.T) {
+	config := &MetadataConfiguration{
+		ID: "metrics-10s:2d",
+		Retention: retention.Configuration{
+			BlockSize:       2 * time.Hour,
+			RetentionPeriod: 48 * time.Hour,
+			BufferFuture:    time.Minute,
+			BufferPast:      time.Minute,
+		},
+		Downsample: &DownsampleConfiguration{
+			Enabled:         true,
+			After:           24 * time.Hour,
+			Resolution:      time.Minute,
+			Aggregation:     "max",
+			TargetNamespace: "metrics-1m:40d",
+		},
+	}
+
+	metadata, err := config.Metadata()
+	require.NoError(t, err)
+
+	dopts := metadata.Options().DownsampleOptions()
+	require.True(t, dopts.Enabled())
+	require.Equal(t, 24*time.Hour, dopts.After())
+	require.Equal(t, time.Minute, dopts.Resolution())
+	require.Equal(t, DownsampleMax, dopts.Aggregation())
+	require.Equal(t, "metrics-1m:40d", dopts.TargetNamespace().String())
+
+	config.Downsample.Aggregation = "median"
+	_, err = config.Metadata()
+	require.Error(t, err)
+}
+
 func TestRegistryConfigFromBytes(t *testing.T) {
 	yamlBytes := []byte(`
 metadatas:
@@ -166,6 +202,7 @@ metadatas:
     index:
       enabled: true
       blockSize: 24h
+    encodingScheme: vdelta
 `)
 
 	var conf MapConfiguration
@@ -224,6 +261,7 @@ metadatas:
 	require.Equal(t, true, opts.RepairEnabled())
 	require.Equal(t, true, opts.IndexOptions().Enabled())
 	require.Equal(t, 24*time.Hour, opts.IndexOptions().BlockSize())
+	require.Equal(t, "vdelta", opts.EncodingScheme())
 	testRetentionOpts = retention.NewOptions().
 		SetRetentionPeriod(960 * time.Hour).
 		SetBlockSize(12 * time.Hour).
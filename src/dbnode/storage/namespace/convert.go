@@ -78,6 +78,32 @@ func ToIndexOptions(
 	return iopts, nil
 }
 
+// ToDownsampleOptions converts nsproto.DownsampleOptions to DownsampleOptions
+func ToDownsampleOptions(
+	do *nsproto.DownsampleOptions,
+) (DownsampleOptions, error) {
+	dopts := NewDownsampleOptions()
+	if do == nil {
+		return dopts, nil
+	}
+
+	dopts = dopts.SetEnabled(do.Enabled).
+		SetAfter(fromNanos(do.AfterNanos)).
+		SetResolution(fromNanos(do.ResolutionNanos))
+	if do.TargetNamespace != "" {
+		dopts = dopts.SetTargetNamespace(ident.StringID(do.TargetNamespace))
+	}
+	if do.Aggregation != "" {
+		aggregation, err := ParseDownsampleAggregation(do.Aggregation)
+		if err != nil {
+			return nil, err
+		}
+		dopts = dopts.SetAggregation(aggregation)
+	}
+
+	return dopts, nil
+}
+
 // ToMetadata converts nsproto.Options to Metadata
 func ToMetadata(
 	id string,
@@ -97,6 +123,11 @@ func ToMetadata(
 		return nil, err
 	}
 
+	dopts, err := ToDownsampleOptions(opts.DownsampleOptions)
+	if err != nil {
+		return nil, err
+	}
+
 	mopts := NewOptions().
 		SetBootstrapEnabled(opts.BootstrapEnabled).
 		SetFlushEnabled(opts.FlushEnabled).
@@ -104,8 +135,11 @@ func ToMetadata(
 		SetRepairEnabled(opts.RepairEnabled).
 		SetWritesToCommitLog(opts.WritesToCommitLog).
 		SetSnapshotEnabled(opts.SnapshotEnabled).
+		SetColdWritesEnabled(opts.ColdWritesEnabled).
 		SetRetentionOptions(ropts).
-		SetIndexOptions(iopts)
+		SetIndexOptions(iopts).
+		SetDownsampleOptions(dopts).
+		SetEncodingScheme(opts.EncodingScheme)
 
 	return NewMetadata(ident.StringID(id), mopts)
 }
@@ -140,6 +174,12 @@ func FromProto(protoRegistry nsproto.Registry) (Map, error) {
 func OptionsToProto(opts Options) *nsproto.NamespaceOptions {
 	ropts := opts.RetentionOptions()
 	iopts := opts.IndexOptions()
+	dopts := opts.DownsampleOptions()
+
+	var targetNamespace string
+	if id := dopts.TargetNamespace(); id != nil {
+		targetNamespace = id.String()
+	}
 
 	return &nsproto.NamespaceOptions{
 		BootstrapEnabled:  opts.BootstrapEnabled(),
@@ -148,6 +188,7 @@ func OptionsToProto(opts Options) *nsproto.NamespaceOptions {
 		SnapshotEnabled:   opts.SnapshotEnabled(),
 		RepairEnabled:     opts.RepairEnabled(),
 		WritesToCommitLog: opts.WritesToCommitLog(),
+		ColdWritesEnabled: opts.ColdWritesEnabled(),
 		RetentionOptions: &nsproto.RetentionOptions{
 			BlockSizeNanos:                           ropts.BlockSize().Nanoseconds(),
 			RetentionPeriodNanos:                     ropts.RetentionPeriod().Nanoseconds(),
@@ -160,5 +201,13 @@ func OptionsToProto(opts Options) *nsproto.NamespaceOptions {
 			Enabled:        iopts.Enabled(),
 			BlockSizeNanos: iopts.BlockSize().Nanoseconds(),
 		},
+		DownsampleOptions: &nsproto.DownsampleOptions{
+			Enabled:         dopts.Enabled(),
+			AfterNanos:      dopts.After().Nanoseconds(),
+			ResolutionNanos: dopts.Resolution().Nanoseconds(),
+			Aggregation:     dopts.Aggregation().String(),
+			TargetNamespace: targetNamespace,
+		},
+		EncodingScheme: opts.EncodingScheme(),
 	}
 }
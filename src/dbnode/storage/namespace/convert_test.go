@@ -43,6 +43,14 @@ var (
 		BlockSizeNanos: toNanos(600), // 10h
 	}
 
+	validDownsampleOpts = nsproto.DownsampleOptions{
+		Enabled:         true,
+		AfterNanos:      toNanos(600), // 10h
+		ResolutionNanos: toNanos(1),   // 1m
+		Aggregation:     "mean",
+		TargetNamespace: "downsampled",
+	}
+
 	validRetentionOpts = nsproto.RetentionOptions{
 		RetentionPeriodNanos:                     toNanos(1200), // 20h
 		BlockSizeNanos:                           toNanos(120),  // 2h
@@ -67,8 +75,11 @@ var (
 			WritesToCommitLog: true,
 			CleanupEnabled:    true,
 			RepairEnabled:     true,
+			ColdWritesEnabled: true,
 			RetentionOptions:  &validRetentionOpts,
 			IndexOptions:      &validIndexOpts,
+			DownsampleOptions: &validDownsampleOpts,
+			EncodingScheme:    "vdelta",
 		},
 	}
 
@@ -226,10 +237,27 @@ func assertEqualMetadata(t *testing.T, name string, expected nsproto.NamespaceOp
 	require.Equal(t, expected.WritesToCommitLog, opts.WritesToCommitLog())
 	require.Equal(t, expected.CleanupEnabled, opts.CleanupEnabled())
 	require.Equal(t, expected.RepairEnabled, opts.RepairEnabled())
+	require.Equal(t, expected.ColdWritesEnabled, opts.ColdWritesEnabled())
+	require.Equal(t, expected.EncodingScheme, opts.EncodingScheme())
+	if expected.DownsampleOptions != nil {
+		assertEqualDownsample(t, *expected.DownsampleOptions, opts.DownsampleOptions())
+	}
 
 	assertEqualRetentions(t, *expected.RetentionOptions, opts.RetentionOptions())
 }
 
+func assertEqualDownsample(t *testing.T, expected nsproto.DownsampleOptions, observed namespace.DownsampleOptions) {
+	require.Equal(t, expected.Enabled, observed.Enabled())
+	require.Equal(t, expected.AfterNanos, observed.After().Nanoseconds())
+	require.Equal(t, expected.ResolutionNanos, observed.Resolution().Nanoseconds())
+	require.Equal(t, expected.Aggregation, observed.Aggregation().String())
+	var targetNamespace string
+	if id := observed.TargetNamespace(); id != nil {
+		targetNamespace = id.String()
+	}
+	require.Equal(t, expected.TargetNamespace, targetNamespace)
+}
+
 func assertEqualRetentions(t *testing.T, expected nsproto.RetentionOptions, observed retention.Options) {
 	require.Equal(t, expected.RetentionPeriodNanos, observed.RetentionPeriod().Nanoseconds())
 	require.Equal(t, expected.BlockSizeNanos, observed.BlockSize().Nanoseconds())
@@ -0,0 +1,210 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3x/ident"
+)
+
+// DownsampleAggregation is the aggregation applied to the datapoints that
+// fall into the same resolution window when downsampling.
+type DownsampleAggregation int
+
+const (
+	// DownsampleLast keeps the last datapoint of each window.
+	DownsampleLast DownsampleAggregation = iota
+	// DownsampleMean keeps the mean of the datapoints of each window.
+	DownsampleMean
+	// DownsampleMin keeps the minimum of the datapoints of each window.
+	DownsampleMin
+	// DownsampleMax keeps the maximum of the datapoints of each window.
+	DownsampleMax
+	// DownsampleSum keeps the sum of the datapoints of each window.
+	DownsampleSum
+)
+
+var (
+	// defaultDownsampleEnabled disables downsampling by default.
+	defaultDownsampleEnabled = false
+
+	// defaultDownsampleAggregation is the default downsample aggregation.
+	defaultDownsampleAggregation = DownsampleLast
+
+	validDownsampleAggregations = []DownsampleAggregation{
+		DownsampleLast,
+		DownsampleMean,
+		DownsampleMin,
+		DownsampleMax,
+		DownsampleSum,
+	}
+
+	errDownsampleAfterPositive          = errors.New("downsample after must be positive")
+	errDownsampleAfterTooLarge          = errors.New("downsample after needs to be < namespace retention period")
+	errDownsampleResolutionPositive     = errors.New("downsample resolution must be positive")
+	errDownsampleResolutionNotDivisor   = errors.New("downsample resolution must divide the data block size")
+	errDownsampleTargetNamespaceMissing = errors.New("downsample target namespace must be set")
+)
+
+func (a DownsampleAggregation) String() string {
+	switch a {
+	case DownsampleLast:
+		return "last"
+	case DownsampleMean:
+		return "mean"
+	case DownsampleMin:
+		return "min"
+	case DownsampleMax:
+		return "max"
+	case DownsampleSum:
+		return "sum"
+	}
+	return "unknown"
+}
+
+func (a DownsampleAggregation) isValid() bool {
+	for _, valid := range validDownsampleAggregations {
+		if a == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseDownsampleAggregation parses a downsample aggregation from its name.
+func ParseDownsampleAggregation(str string) (DownsampleAggregation, error) {
+	for _, valid := range validDownsampleAggregations {
+		if str == valid.String() {
+			return valid, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid downsample aggregation '%s' valid aggregations are: %v",
+		str, validDownsampleAggregations)
+}
+
+type downsampleOpts struct {
+	enabled         bool
+	after           time.Duration
+	resolution      time.Duration
+	aggregation     DownsampleAggregation
+	targetNamespace ident.ID
+}
+
+// NewDownsampleOptions returns a new DownsampleOptions.
+func NewDownsampleOptions() DownsampleOptions {
+	return &downsampleOpts{
+		enabled:     defaultDownsampleEnabled,
+		aggregation: defaultDownsampleAggregation,
+	}
+}
+
+func (d *downsampleOpts) Validate(blockSize, retentionPeriod time.Duration) error {
+	if !d.enabled {
+		return nil
+	}
+	if d.after <= 0 {
+		return errDownsampleAfterPositive
+	}
+	if d.after >= retentionPeriod {
+		return errDownsampleAfterTooLarge
+	}
+	if d.resolution <= 0 {
+		return errDownsampleResolutionPositive
+	}
+	if blockSize%d.resolution != 0 {
+		return errDownsampleResolutionNotDivisor
+	}
+	if d.targetNamespace == nil || len(d.targetNamespace.Bytes()) == 0 {
+		return errDownsampleTargetNamespaceMissing
+	}
+	if !d.aggregation.isValid() {
+		return fmt.Errorf("invalid downsample aggregation: %d", int(d.aggregation))
+	}
+	return nil
+}
+
+func (d *downsampleOpts) Equal(value DownsampleOptions) bool {
+	return d.Enabled() == value.Enabled() &&
+		d.After() == value.After() &&
+		d.Resolution() == value.Resolution() &&
+		d.Aggregation() == value.Aggregation() &&
+		equalIDs(d.TargetNamespace(), value.TargetNamespace())
+}
+
+func equalIDs(a, b ident.ID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(b)
+}
+
+func (d *downsampleOpts) SetEnabled(value bool) DownsampleOptions {
+	do := *d
+	do.enabled = value
+	return &do
+}
+
+func (d *downsampleOpts) Enabled() bool {
+	return d.enabled
+}
+
+func (d *downsampleOpts) SetAfter(value time.Duration) DownsampleOptions {
+	do := *d
+	do.after = value
+	return &do
+}
+
+func (d *downsampleOpts) After() time.Duration {
+	return d.after
+}
+
+func (d *downsampleOpts) SetResolution(value time.Duration) DownsampleOptions {
+	do := *d
+	do.resolution = value
+	return &do
+}
+
+func (d *downsampleOpts) Resolution() time.Duration {
+	return d.resolution
+}
+
+func (d *downsampleOpts) SetAggregation(value DownsampleAggregation) DownsampleOptions {
+	do := *d
+	do.aggregation = value
+	return &do
+}
+
+func (d *downsampleOpts) Aggregation() DownsampleAggregation {
+	return d.aggregation
+}
+
+func (d *downsampleOpts) SetTargetNamespace(value ident.ID) DownsampleOptions {
+	do := *d
+	do.targetNamespace = value
+	return &do
+}
+
+func (d *downsampleOpts) TargetNamespace() ident.ID {
+	return d.targetNamespace
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newValidDownsampleOptions() DownsampleOptions {
+	return NewDownsampleOptions().
+		SetEnabled(true).
+		SetAfter(24 * time.Hour).
+		SetResolution(time.Minute).
+		SetAggregation(DownsampleMean).
+		SetTargetNamespace(ident.StringID("target"))
+}
+
+func TestDownsampleOptionsEqual(t *testing.T) {
+	opts := newValidDownsampleOptions()
+	require.True(t, opts.Equal(newValidDownsampleOptions()))
+	require.False(t, opts.Equal(opts.SetEnabled(false)))
+	require.False(t, opts.Equal(opts.SetAfter(time.Hour)))
+	require.False(t, opts.Equal(opts.SetResolution(time.Second)))
+	require.False(t, opts.Equal(opts.SetAggregation(DownsampleMax)))
+	require.False(t, opts.Equal(opts.SetTargetNamespace(ident.StringID("other"))))
+	require.False(t, opts.Equal(opts.SetTargetNamespace(nil)))
+	require.True(t, NewDownsampleOptions().Equal(NewDownsampleOptions()))
+}
+
+func TestDownsampleOptionsValidate(t *testing.T) {
+	var (
+		blockSize       = 2 * time.Hour
+		retentionPeriod = 48 * time.Hour
+		opts            = newValidDownsampleOptions()
+	)
+	require.NoError(t, NewDownsampleOptions().Validate(blockSize, retentionPeriod))
+	require.NoError(t, opts.Validate(blockSize, retentionPeriod))
+
+	invalid := []DownsampleOptions{
+		opts.SetAfter(0),
+		opts.SetAfter(retentionPeriod),
+		opts.SetResolution(0),
+		opts.SetResolution(7 * time.Minute),
+		opts.SetTargetNamespace(nil),
+		opts.SetTargetNamespace(ident.StringID("")),
+		opts.SetAggregation(DownsampleAggregation(100)),
+	}
+	for _, dopts := range invalid {
+		require.Error(t, dopts.Validate(blockSize, retentionPeriod))
+	}
+}
+
+func TestParseDownsampleAggregation(t *testing.T) {
+	for _, aggregation := range validDownsampleAggregations {
+		parsed, err := ParseDownsampleAggregation(aggregation.String())
+		require.NoError(t, err)
+		require.Equal(t, aggregation, parsed)
+	}
+
+	_, err := ParseDownsampleAggregation("median")
+	require.Error(t, err)
+}
+
+func TestMetadataDownsampleIntoSelf(t *testing.T) {
+	opts := NewOptions().SetDownsampleOptions(newValidDownsampleOptions())
+	_, err := NewMetadata(ident.StringID("target"), opts)
+	require.Error(t, err)
+
+	_, err = NewMetadata(ident.StringID("source"), opts)
+	require.NoError(t, err)
+}
@@ -29,8 +29,9 @@ import (
 )
 
 var (
-	errIDNotSet   = errors.New("namespace ID is not set")
-	errOptsNotSet = errors.New("namespace options are not set")
+	errIDNotSet               = errors.New("namespace ID is not set")
+	errOptsNotSet             = errors.New("namespace options are not set")
+	errDownsampleTargetIsSelf = errors.New("namespace can not downsample into itself")
 )
 
 type metadata struct {
@@ -53,6 +54,10 @@ func NewMetadata(id ident.ID, opts Options) (Metadata, error) {
 
 	}
 
+	if dopts := opts.DownsampleOptions(); dopts.Enabled() && id.Equal(dopts.TargetNamespace()) {
+		return nil, errDownsampleTargetIsSelf
+	}
+
 	copiedID := checked.NewBytes(append([]byte(nil), id.Bytes()...), nil)
 	return &metadata{
 		id:   ident.BinaryID(copiedID),
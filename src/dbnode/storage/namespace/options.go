@@ -44,6 +44,9 @@ const (
 
 	// Namespace requires repair disabled by default
 	defaultRepairEnabled = false
+
+	// Namespace rejects writes older than the buffer past by default
+	defaultColdWritesEnabled = false
 )
 
 var (
@@ -59,8 +62,11 @@ type options struct {
 	writesToCommitLog bool
 	cleanupEnabled    bool
 	repairEnabled     bool
+	coldWritesEnabled bool
 	retentionOpts     retention.Options
 	indexOpts         IndexOptions
+	downsampleOpts    DownsampleOptions
+	encodingScheme    string
 }
 
 // NewOptions creates a new namespace options
@@ -72,8 +78,10 @@ func NewOptions() Options {
 		writesToCommitLog: defaultWritesToCommitLog,
 		cleanupEnabled:    defaultCleanupEnabled,
 		repairEnabled:     defaultRepairEnabled,
+		coldWritesEnabled: defaultColdWritesEnabled,
 		retentionOpts:     retention.NewOptions(),
 		indexOpts:         NewIndexOptions(),
+		downsampleOpts:    NewDownsampleOptions(),
 	}
 }
 
@@ -81,6 +89,11 @@ func (o *options) Validate() error {
 	if err := o.retentionOpts.Validate(); err != nil {
 		return err
 	}
+	err := o.downsampleOpts.Validate(o.retentionOpts.BlockSize(),
+		o.retentionOpts.RetentionPeriod())
+	if err != nil {
+		return err
+	}
 	if !o.indexOpts.Enabled() {
 		return nil
 	}
@@ -108,8 +121,11 @@ func (o *options) Equal(value Options) bool {
 		o.snapshotEnabled == value.SnapshotEnabled() &&
 		o.cleanupEnabled == value.CleanupEnabled() &&
 		o.repairEnabled == value.RepairEnabled() &&
+		o.coldWritesEnabled == value.ColdWritesEnabled() &&
 		o.retentionOpts.Equal(value.RetentionOptions()) &&
-		o.indexOpts.Equal(value.IndexOptions())
+		o.indexOpts.Equal(value.IndexOptions()) &&
+		o.downsampleOpts.Equal(value.DownsampleOptions()) &&
+		o.encodingScheme == value.EncodingScheme()
 }
 
 func (o *options) SetBootstrapEnabled(value bool) Options {
@@ -172,6 +188,16 @@ func (o *options) RepairEnabled() bool {
 	return o.repairEnabled
 }
 
+func (o *options) SetColdWritesEnabled(value bool) Options {
+	opts := *o
+	opts.coldWritesEnabled = value
+	return &opts
+}
+
+func (o *options) ColdWritesEnabled() bool {
+	return o.coldWritesEnabled
+}
+
 func (o *options) SetRetentionOptions(value retention.Options) Options {
 	opts := *o
 	opts.retentionOpts = value
@@ -191,3 +217,23 @@ func (o *options) SetIndexOptions(value IndexOptions) Options {
 func (o *options) IndexOptions() IndexOptions {
 	return o.indexOpts
 }
+
+func (o *options) SetDownsampleOptions(value DownsampleOptions) Options {
+	opts := *o
+	opts.downsampleOpts = value
+	return &opts
+}
+
+func (o *options) DownsampleOptions() DownsampleOptions {
+	return o.downsampleOpts
+}
+
+func (o *options) SetEncodingScheme(value string) Options {
+	opts := *o
+	opts.encodingScheme = value
+	return &opts
+}
+
+func (o *options) EncodingScheme() string {
+	return o.encodingScheme
+}
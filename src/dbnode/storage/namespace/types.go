@@ -73,6 +73,14 @@ type Options interface {
 	// RepairEnabled returns whether the data for this namespace needs to be repaired
 	RepairEnabled() bool
 
+	// SetColdWritesEnabled sets whether writes older than the buffer past are accepted
+	// for this namespace and persisted to new fileset volumes by a cold flush
+	SetColdWritesEnabled(value bool) Options
+
+	// ColdWritesEnabled returns whether writes older than the buffer past are accepted
+	// for this namespace and persisted to new fileset volumes by a cold flush
+	ColdWritesEnabled() bool
+
 	// SetRetentionOptions sets the retention options for this namespace
 	SetRetentionOptions(value retention.Options) Options
 
@@ -84,6 +92,20 @@ type Options interface {
 
 	// IndexOptions returns the IndexOptions.
 	IndexOptions() IndexOptions
+
+	// SetDownsampleOptions sets the DownsampleOptions.
+	SetDownsampleOptions(value DownsampleOptions) Options
+
+	// DownsampleOptions returns the DownsampleOptions.
+	DownsampleOptions() DownsampleOptions
+
+	// SetEncodingScheme sets the scheme the series data of the namespace is
+	// encoded with, the empty scheme selects the default encoding scheme.
+	SetEncodingScheme(value string) Options
+
+	// EncodingScheme returns the scheme the series data of the namespace is
+	// encoded with, the empty scheme selects the default encoding scheme.
+	EncodingScheme() string
 }
 
 // IndexOptions controls the indexing options for a namespace.
@@ -104,6 +126,47 @@ type IndexOptions interface {
 	BlockSize() time.Duration
 }
 
+// DownsampleOptions controls whether and how flushed data of a namespace is
+// downsampled into a lower resolution target namespace once it ages.
+type DownsampleOptions interface {
+	// Validate validates the options against the namespace block size
+	// and retention period.
+	Validate(blockSize, retentionPeriod time.Duration) error
+
+	// Equal returns true if the provide value is equal to this one.
+	Equal(value DownsampleOptions) bool
+
+	// SetEnabled sets whether downsampling is enabled.
+	SetEnabled(value bool) DownsampleOptions
+
+	// Enabled returns whether downsampling is enabled.
+	Enabled() bool
+
+	// SetAfter sets the age after which flushed blocks are downsampled.
+	SetAfter(value time.Duration) DownsampleOptions
+
+	// After returns the age after which flushed blocks are downsampled.
+	After() time.Duration
+
+	// SetResolution sets the resolution of the downsampled data.
+	SetResolution(value time.Duration) DownsampleOptions
+
+	// Resolution returns the resolution of the downsampled data.
+	Resolution() time.Duration
+
+	// SetAggregation sets the aggregation applied to each resolution window.
+	SetAggregation(value DownsampleAggregation) DownsampleOptions
+
+	// Aggregation returns the aggregation applied to each resolution window.
+	Aggregation() DownsampleAggregation
+
+	// SetTargetNamespace sets the namespace downsampled data is written to.
+	SetTargetNamespace(value ident.ID) DownsampleOptions
+
+	// TargetNamespace returns the namespace downsampled data is written to.
+	TargetNamespace() ident.ID
+}
+
 // Metadata represents namespace metadata information
 type Metadata interface {
 	// Equal returns true if the provide value is equal to this one
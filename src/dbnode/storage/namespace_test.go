@@ -27,6 +27,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/encoding/vdelta"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/runtime"
@@ -37,7 +38,10 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
+	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/metrics"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3cluster/shard"
 	"github.com/m3db/m3x/context"
 	xerrors "github.com/m3db/m3x/errors"
@@ -481,6 +485,159 @@ func TestNamespaceFlushSkipShardNotBootstrappedBeforeTick(t *testing.T) {
 	require.NoError(t, ns.Flush(blockStart, ShardBootstrapStates, nil))
 }
 
+func TestNamespaceColdFlush(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ns, closer := newTestNamespaceWithIDOpts(t, defaultTestNs1ID,
+		defaultTestNs1Opts.SetColdWritesEnabled(true))
+	defer closer()
+
+	now := time.Now()
+	ns.nowFn = func() time.Time { return now }
+	ns.bootstrapState = Bootstrapped
+
+	var (
+		shards               []*MockdatabaseShard
+		shardBootstrapStates = ShardBootstrapStates{}
+	)
+	for _, shardID := range testShardIDs {
+		shard := NewMockdatabaseShard(ctrl)
+		shard.EXPECT().ID().Return(shardID.ID()).AnyTimes()
+		ns.shards[shardID.ID()] = shard
+		shards = append(shards, shard)
+		shardBootstrapStates[shardID.ID()] = Bootstrapped
+	}
+
+	// A shard with cold writes pending for unflushed blocks means not
+	// all cold writes have been persisted yet
+	for i, shard := range shards {
+		shard.EXPECT().ColdFlush(nil).Return(i == 0, nil)
+	}
+	require.NoError(t, ns.ColdFlush(shardBootstrapStates, nil))
+	require.True(t, ns.ColdFlushedUntil().IsZero())
+
+	for _, shard := range shards {
+		shard.EXPECT().ColdFlush(nil).Return(false, nil)
+	}
+	require.NoError(t, ns.ColdFlush(shardBootstrapStates, nil))
+	require.Equal(t, now, ns.ColdFlushedUntil())
+
+	// Errors do not advance the cold flushed time
+	now = now.Add(time.Minute)
+	for i, shard := range shards {
+		var err error
+		if i == 0 {
+			err = errors.New("an error")
+		}
+		shard.EXPECT().ColdFlush(nil).Return(false, err)
+	}
+	require.Error(t, ns.ColdFlush(shardBootstrapStates, nil))
+	require.Equal(t, now.Add(-time.Minute), ns.ColdFlushedUntil())
+}
+
+func newTestDownsampleNamespace(t *testing.T) (*dbNamespace, closerFn) {
+	dopts := namespace.NewDownsampleOptions().
+		SetEnabled(true).
+		SetAfter(24 * time.Hour).
+		SetResolution(time.Minute).
+		SetTargetNamespace(defaultTestNs2ID)
+	return newTestNamespaceWithIDOpts(t, defaultTestNs1ID,
+		defaultTestNs1Opts.SetDownsampleOptions(dopts))
+}
+
+func TestNamespaceDownsample(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ns, closer := newTestDownsampleNamespace(t)
+	defer closer()
+	ns.bootstrapState = Bootstrapped
+
+	target := NewMockdatabaseNamespace(ctrl)
+	target.EXPECT().Options().
+		Return(defaultTestNs2Opts.SetColdWritesEnabled(true)).AnyTimes()
+
+	var (
+		ropts                = ns.Options().RetentionOptions()
+		now                  = time.Now().Truncate(ropts.BlockSize())
+		firstBlockStart      = retention.FlushTimeStart(ropts, now)
+		shardBootstrapStates = ShardBootstrapStates{}
+	)
+	for i, shardID := range testShardIDs {
+		shard := NewMockdatabaseShard(ctrl)
+		shard.EXPECT().ID().Return(shardID.ID()).AnyTimes()
+		shard.EXPECT().FlushState(gomock.Any()).
+			Return(fileOpState{Status: fileOpSuccess}).AnyTimes()
+		if i == 0 {
+			// Only the oldest block of the first shard is yet to be downsampled
+			shard.EXPECT().DownsampleState(firstBlockStart).
+				Return(fileOpState{Status: fileOpNotStarted})
+			shard.EXPECT().Downsample(firstBlockStart, target).Return(nil)
+		}
+		shard.EXPECT().DownsampleState(gomock.Any()).
+			Return(fileOpState{Status: fileOpSuccess}).AnyTimes()
+		ns.shards[shardID.ID()] = shard
+		shardBootstrapStates[shardID.ID()] = Bootstrapped
+	}
+
+	require.NoError(t, ns.Downsample(now, shardBootstrapStates, target))
+}
+
+func TestNamespaceDownsampleTargetColdWritesDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ns, closer := newTestDownsampleNamespace(t)
+	defer closer()
+	ns.bootstrapState = Bootstrapped
+
+	target := NewMockdatabaseNamespace(ctrl)
+	target.EXPECT().Options().Return(defaultTestNs2Opts).AnyTimes()
+
+	err := ns.Downsample(time.Now(), ShardBootstrapStates{}, target)
+	require.Equal(t, errDownsampleTargetColdWritesDisabled, err)
+}
+
+func TestNamespaceEncodingScheme(t *testing.T) {
+	opts := defaultTestNs1Opts.SetEncodingScheme(vdelta.Scheme)
+	ns, closer := newTestNamespaceWithIDOpts(t, defaultTestNs1ID, opts)
+	defer closer()
+
+	dopts := ns.opts.DatabaseBlockOptions()
+	require.True(t, ns.opts.EncoderPool() == dopts.EncoderPool())
+	require.True(t, ns.seriesOpts.EncoderPool() == dopts.EncoderPool())
+	require.False(t, testDatabaseOptions().EncoderPool() == dopts.EncoderPool())
+
+	enc := ns.seriesOpts.EncoderPool().Get()
+	defer enc.Close()
+	enc.Reset(time.Now(), 0)
+	require.NoError(t, enc.Encode(ts.Datapoint{Timestamp: time.Now(), Value: 42},
+		xtime.Second, nil))
+
+	iter := ns.seriesOpts.MultiReaderIteratorPool().Get()
+	defer iter.Close()
+	iter.Reset([]xio.SegmentReader{enc.Stream()}, time.Time{}, 0)
+	require.True(t, iter.Next())
+	dp, _, _ := iter.Current()
+	require.Equal(t, float64(42), dp.Value)
+	require.False(t, iter.Next())
+	require.NoError(t, iter.Err())
+}
+
+func TestNamespaceEncodingSchemeUnknown(t *testing.T) {
+	opts := defaultTestNs1Opts.SetEncodingScheme("unknown")
+	metadata, err := namespace.NewMetadata(defaultTestNs1ID, opts)
+	require.NoError(t, err)
+	hashFn := func(identifier ident.ID) uint32 { return testShardIDs[0].ID() }
+	shardSet, err := sharding.NewShardSet(testShardIDs, hashFn)
+	require.NoError(t, err)
+
+	_, err = newDatabaseNamespace(metadata, shardSet, nil, nil, nil,
+		testDatabaseOptions())
+	require.Error(t, err)
+}
+
 type snapshotTestCase struct {
 	isSnapshotting   bool
 	expectSnapshot   bool
@@ -1126,6 +1283,70 @@ func TestNamespaceIndexQuery(t *testing.T) {
 	require.NoError(t, ns.Close())
 }
 
+func TestNamespaceIndexDeleteTagged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idx := NewMocknamespaceIndex(ctrl)
+	ns, closer := newTestNamespaceWithIndex(t, idx)
+	defer closer()
+
+	var (
+		ctx   = context.NewContext()
+		query = index.Query{}
+		now   = time.Now()
+		opts  = index.QueryOptions{
+			StartInclusive: now.Add(-time.Hour),
+			EndExclusive:   now,
+			Limit:          10,
+		}
+	)
+
+	// The series are deleted a page at a time until the query is exhaustive.
+	pageResults := func(id string, exhaustive bool) index.QueryResults {
+		results := index.NewResults(testDatabaseOptions().IndexOptions())
+		_, _, err := results.Add(doc.Document{ID: []byte(id)})
+		require.NoError(t, err)
+		return index.QueryResults{Results: results, Exhaustive: exhaustive}
+	}
+	gomock.InOrder(
+		idx.EXPECT().Query(gomock.Any(), query, opts).Return(pageResults("a", false), nil),
+		idx.EXPECT().DeleteSeries(gomock.Any()).Do(func(ids []ident.ID) {
+			require.Equal(t, 1, len(ids))
+			require.Equal(t, "a", ids[0].String())
+		}).Return(nil),
+		idx.EXPECT().Query(gomock.Any(), query, index.QueryOptions{
+			StartInclusive: opts.StartInclusive,
+			EndExclusive:   opts.EndExclusive,
+			Limit:          9,
+		}).Return(pageResults("a", true), nil),
+		idx.EXPECT().DeleteSeries(gomock.Any()).Return(nil),
+	)
+
+	shard := NewMockdatabaseShard(ctrl)
+	shard.EXPECT().DeleteSeries(gomock.Any()).Return(int64(1), nil).Times(2)
+	shard.EXPECT().ID().Return(testShardIDs[0].ID()).AnyTimes()
+	ns.shards[testShardIDs[0].ID()] = shard
+
+	deleted, err := ns.DeleteTagged(ctx, query, opts)
+	require.NoError(t, err)
+	require.Equal(t, map[uint32]int64{testShardIDs[0].ID(): 2}, deleted)
+
+	shard.EXPECT().Close()
+	idx.EXPECT().Close().Return(nil)
+	require.NoError(t, ns.Close())
+}
+
+func TestNamespaceIndexDisabledDeleteTagged(t *testing.T) {
+	ns, closer := newTestNamespace(t)
+	defer closer()
+
+	_, err := ns.DeleteTagged(context.NewContext(), index.Query{}, index.QueryOptions{})
+	require.Error(t, err)
+
+	require.NoError(t, ns.Close())
+}
+
 func TestNamespaceTicksIndex(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -39,6 +39,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/dbnode/storage/quota"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/x/xcounter"
@@ -142,6 +143,7 @@ type options struct {
 	fetchBlockMetadataResultsPool  block.FetchBlockMetadataResultsPool
 	fetchBlocksMetadataResultsPool block.FetchBlocksMetadataResultsPool
 	queryIDsWorkerPool             xsync.WorkerPool
+	writeQuotaOpts                 quota.Options
 }
 
 // NewOptions creates a new set of storage options with defaults
@@ -232,6 +234,13 @@ func (o *options) Validate() error {
 		return fmt.Errorf("unable to validate index options, err: %v", err)
 	}
 
+	// validate write quota options if write quotas are enforced
+	if wqOpts := o.WriteQuotaOptions(); wqOpts != nil {
+		if err := wqOpts.Validate(); err != nil {
+			return fmt.Errorf("unable to validate write quota options, err: %v", err)
+		}
+	}
+
 	// validate that persist manager is present, if not return
 	// error if error occurred during default creation otherwise
 	// it was set to nil by a caller
@@ -622,3 +631,13 @@ func (o *options) SetQueryIDsWorkerPool(value xsync.WorkerPool) Options {
 func (o *options) QueryIDsWorkerPool() xsync.WorkerPool {
 	return o.queryIDsWorkerPool
 }
+
+func (o *options) SetWriteQuotaOptions(value quota.Options) Options {
+	opts := *o
+	opts.writeQuotaOpts = value
+	return &opts
+}
+
+func (o *options) WriteQuotaOptions() quota.Options {
+	return o.writeQuotaOpts
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package quota
+
+import (
+	"errors"
+
+	"github.com/m3db/m3x/clock"
+	"github.com/m3db/m3x/instrument"
+)
+
+var (
+	errTagNameEmpty   = errors.New("write quota tag name is empty")
+	errNegativeLimits = errors.New("write quota limits are negative")
+)
+
+type options struct {
+	tagName        []byte
+	defaultLimits  Limits
+	keyLimits      map[string]Limits
+	clockOpts      clock.Options
+	instrumentOpts instrument.Options
+}
+
+// NewOptions creates new write quota options.
+func NewOptions() Options {
+	return &options{
+		clockOpts:      clock.NewOptions(),
+		instrumentOpts: instrument.NewOptions(),
+	}
+}
+
+func (o *options) Validate() error {
+	if len(o.tagName) == 0 {
+		return errTagNameEmpty
+	}
+	if err := validateLimits(o.defaultLimits); err != nil {
+		return err
+	}
+	for _, limits := range o.keyLimits {
+		if err := validateLimits(limits); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateLimits(limits Limits) error {
+	if limits.MaxActiveSeries < 0 || limits.MaxDatapointsPerSecond < 0 {
+		return errNegativeLimits
+	}
+	return nil
+}
+
+func (o *options) SetTagName(value []byte) Options {
+	opts := *o
+	opts.tagName = value
+	return &opts
+}
+
+func (o *options) TagName() []byte {
+	return o.tagName
+}
+
+func (o *options) SetDefaultLimits(value Limits) Options {
+	opts := *o
+	opts.defaultLimits = value
+	return &opts
+}
+
+func (o *options) DefaultLimits() Limits {
+	return o.defaultLimits
+}
+
+func (o *options) SetKeyLimits(value map[string]Limits) Options {
+	opts := *o
+	opts.keyLimits = value
+	return &opts
+}
+
+func (o *options) KeyLimits() map[string]Limits {
+	return o.keyLimits
+}
+
+func (o *options) SetClockOptions(value clock.Options) Options {
+	opts := *o
+	opts.clockOpts = value
+	return &opts
+}
+
+func (o *options) ClockOptions() clock.Options {
+	return o.clockOpts
+}
+
+func (o *options) SetInstrumentOptions(value instrument.Options) Options {
+	opts := *o
+	opts.instrumentOpts = value
+	return &opts
+}
+
+func (o *options) InstrumentOptions() instrument.Options {
+	return o.instrumentOpts
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package quota
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/m3db/m3x/clock"
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/uber-go/tally"
+)
+
+const (
+	quotaKeyTagName = "quota-key"
+
+	exceededActiveSeries = "max active series"
+	exceededDatapoints   = "max datapoints per second"
+)
+
+// ExceededError is returned when a write is rejected as it would exceed the
+// write quota of its quota key.
+type ExceededError struct {
+	Key   string
+	Limit string
+}
+
+func (e ExceededError) Error() string {
+	return fmt.Sprintf("write quota exceeded for %s: %s", e.Key, e.Limit)
+}
+
+// IsExceededError returns whether the error is a write quota exceeded error.
+func IsExceededError(err error) bool {
+	for err != nil {
+		if _, ok := err.(ExceededError); ok {
+			return true
+		}
+		err = xerrors.InnerError(err)
+	}
+	return false
+}
+
+type keyMetrics struct {
+	activeSeries         tally.Gauge
+	rejectedActiveSeries tally.Counter
+	rejectedDatapoints   tally.Counter
+}
+
+func newKeyMetrics(scope tally.Scope) keyMetrics {
+	return keyMetrics{
+		activeSeries: scope.Gauge("active-series"),
+		rejectedActiveSeries: scope.Tagged(map[string]string{
+			"reason": "max-active-series",
+		}).Counter("rejected"),
+		rejectedDatapoints: scope.Tagged(map[string]string{
+			"reason": "max-datapoints-per-second",
+		}).Counter("rejected"),
+	}
+}
+
+type keyState struct {
+	sync.Mutex
+
+	key          string
+	limits       Limits
+	activeSeries int64
+	windowStart  int64
+	windowCount  int64
+	metrics      keyMetrics
+}
+
+func (s *keyState) admitWrite(newSeries bool, nowSeconds int64) error {
+	if newSeries && s.limits.MaxActiveSeries > 0 &&
+		atomic.LoadInt64(&s.activeSeries) >= s.limits.MaxActiveSeries {
+		s.metrics.rejectedActiveSeries.Inc(1)
+		return ExceededError{Key: s.key, Limit: exceededActiveSeries}
+	}
+
+	if s.limits.MaxDatapointsPerSecond <= 0 {
+		return nil
+	}
+	s.Lock()
+	if s.windowStart != nowSeconds {
+		s.windowStart = nowSeconds
+		s.windowCount = 0
+	}
+	admitted := s.windowCount < s.limits.MaxDatapointsPerSecond
+	if admitted {
+		s.windowCount++
+	}
+	s.Unlock()
+	if !admitted {
+		s.metrics.rejectedDatapoints.Inc(1)
+		return ExceededError{Key: s.key, Limit: exceededDatapoints}
+	}
+	return nil
+}
+
+func (s *keyState) addActiveSeries(delta int64) {
+	s.metrics.activeSeries.Update(float64(atomic.AddInt64(&s.activeSeries, delta)))
+}
+
+type tracker struct {
+	sync.RWMutex
+
+	tagName       []byte
+	defaultLimits Limits
+	keyLimits     map[string]Limits
+	nowFn         clock.NowFn
+	scope         tally.Scope
+	keys          map[string]*keyState
+}
+
+// NewTracker returns a new write quota tracker. The active series and
+// datapoint rates of each quota key are tracked from when the first series
+// with the key is written to, the active series quota is checked before new
+// series are inserted so it may be briefly exceeded by concurrent inserts.
+func NewTracker(opts Options) (Tracker, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return &tracker{
+		tagName:       opts.TagName(),
+		defaultLimits: opts.DefaultLimits(),
+		keyLimits:     opts.KeyLimits(),
+		nowFn:         opts.ClockOptions().NowFn(),
+		scope:         opts.InstrumentOptions().MetricsScope().SubScope("write-quota"),
+		keys:          make(map[string]*keyState),
+	}, nil
+}
+
+func (t *tracker) AdmitWrite(tags ident.TagIterator, newSeries bool) error {
+	state, ok := t.keyStateFromTagIter(tags)
+	if !ok {
+		return nil
+	}
+	return state.admitWrite(newSeries, t.nowFn().Unix())
+}
+
+func (t *tracker) SeriesAdded(tags ident.Tags) {
+	if key, ok := t.keyFromTags(tags); ok {
+		t.keyState(key).addActiveSeries(1)
+	}
+}
+
+func (t *tracker) SeriesRemoved(tags ident.Tags) {
+	if key, ok := t.keyFromTags(tags); ok {
+		t.keyState(key).addActiveSeries(-1)
+	}
+}
+
+// keyStateFromTagIter looks up the key state before the duplicated iterator
+// is closed as the tag values are only valid until then.
+func (t *tracker) keyStateFromTagIter(tags ident.TagIterator) (*keyState, bool) {
+	iter := tags.Duplicate()
+	defer iter.Close()
+	for iter.Next() {
+		tag := iter.Current()
+		if bytes.Equal(tag.Name.Bytes(), t.tagName) {
+			return t.keyState(tag.Value.Bytes()), true
+		}
+	}
+	return nil, false
+}
+
+func (t *tracker) keyFromTags(tags ident.Tags) ([]byte, bool) {
+	for _, tag := range tags.Values() {
+		if bytes.Equal(tag.Name.Bytes(), t.tagName) {
+			return tag.Value.Bytes(), true
+		}
+	}
+	return nil, false
+}
+
+func (t *tracker) keyState(key []byte) *keyState {
+	t.RLock()
+	state, ok := t.keys[string(key)]
+	t.RUnlock()
+	if ok {
+		return state
+	}
+
+	t.Lock()
+	defer t.Unlock()
+	if state, ok := t.keys[string(key)]; ok {
+		return state
+	}
+	limits, ok := t.keyLimits[string(key)]
+	if !ok {
+		limits = t.defaultLimits
+	}
+	state = &keyState{
+		key:    string(key),
+		limits: limits,
+		metrics: newKeyMetrics(t.scope.Tagged(map[string]string{
+			quotaKeyTagName: string(key),
+		})),
+	}
+	t.keys[state.key] = state
+	return state
+}
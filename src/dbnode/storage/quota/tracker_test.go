@@ -0,0 +1,112 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package quota
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3x/clock"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTracker(t *testing.T, nowFn clock.NowFn) Tracker {
+	opts := NewOptions().
+		SetTagName([]byte("tenant")).
+		SetDefaultLimits(Limits{MaxActiveSeries: 2, MaxDatapointsPerSecond: 3}).
+		SetKeyLimits(map[string]Limits{
+			"unlimited": Limits{},
+		}).
+		SetClockOptions(clock.NewOptions().SetNowFn(nowFn))
+	tracker, err := NewTracker(opts)
+	require.NoError(t, err)
+	return tracker
+}
+
+func testTags(tenant string) ident.Tags {
+	return ident.NewTags(
+		ident.StringTag("service", "foo"),
+		ident.StringTag("tenant", tenant),
+	)
+}
+
+func TestTrackerMaxDatapointsPerSecond(t *testing.T) {
+	now := time.Unix(100, 0)
+	tracker := newTestTracker(t, func() time.Time { return now })
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, tracker.AdmitWrite(ident.NewTagsIterator(testTags("a")), false))
+	}
+	err := tracker.AdmitWrite(ident.NewTagsIterator(testTags("a")), false)
+	require.Error(t, err)
+	require.True(t, IsExceededError(err))
+	require.Equal(t, ExceededError{Key: "a", Limit: exceededDatapoints}, err)
+
+	// Other keys have quotas of their own.
+	require.NoError(t, tracker.AdmitWrite(ident.NewTagsIterator(testTags("b")), false))
+
+	// The quota is reset every second.
+	now = now.Add(time.Second)
+	require.NoError(t, tracker.AdmitWrite(ident.NewTagsIterator(testTags("a")), false))
+}
+
+func TestTrackerMaxActiveSeries(t *testing.T) {
+	now := time.Unix(100, 0)
+	tracker := newTestTracker(t, func() time.Time { return now })
+
+	tracker.SeriesAdded(testTags("a"))
+	require.NoError(t, tracker.AdmitWrite(ident.NewTagsIterator(testTags("a")), true))
+	tracker.SeriesAdded(testTags("a"))
+
+	err := tracker.AdmitWrite(ident.NewTagsIterator(testTags("a")), true)
+	require.Equal(t, ExceededError{Key: "a", Limit: exceededActiveSeries}, err)
+
+	// Writes to existing series are still admitted.
+	require.NoError(t, tracker.AdmitWrite(ident.NewTagsIterator(testTags("a")), false))
+
+	tracker.SeriesRemoved(testTags("a"))
+	require.NoError(t, tracker.AdmitWrite(ident.NewTagsIterator(testTags("a")), true))
+}
+
+func TestTrackerKeyLimitsAndUntaggedSeries(t *testing.T) {
+	now := time.Unix(100, 0)
+	tracker := newTestTracker(t, func() time.Time { return now })
+
+	untagged := ident.NewTags(ident.StringTag("service", "foo"))
+	for i := 0; i < 10; i++ {
+		tracker.SeriesAdded(testTags("unlimited"))
+		tracker.SeriesAdded(untagged)
+		require.NoError(t, tracker.AdmitWrite(ident.NewTagsIterator(testTags("unlimited")), true))
+		require.NoError(t, tracker.AdmitWrite(ident.NewTagsIterator(untagged), true))
+	}
+}
+
+func TestNewTrackerInvalidOptions(t *testing.T) {
+	_, err := NewTracker(NewOptions())
+	require.Equal(t, errTagNameEmpty, err)
+
+	_, err = NewTracker(NewOptions().
+		SetTagName([]byte("tenant")).
+		SetDefaultLimits(Limits{MaxActiveSeries: -1}))
+	require.Equal(t, errNegativeLimits, err)
+}
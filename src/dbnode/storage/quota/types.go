@@ -0,0 +1,92 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package quota
+
+import (
+	"github.com/m3db/m3x/clock"
+	"github.com/m3db/m3x/ident"
+	"github.com/m3db/m3x/instrument"
+)
+
+// Limits are the write quota limits of a quota key, a zero limit means that
+// the quota is unlimited.
+type Limits struct {
+	// MaxActiveSeries is the maximum number of series with the quota key
+	// held in memory at once.
+	MaxActiveSeries int64
+
+	// MaxDatapointsPerSecond is the maximum number of datapoints written to
+	// series with the quota key per second.
+	MaxDatapointsPerSecond int64
+}
+
+// Tracker tracks the active series and datapoint rates of quota keys and
+// enforces their write quotas, the quota key of a series is the value of the
+// quota tag of the series.
+type Tracker interface {
+	// AdmitWrite returns an error that satisfies IsExceededError if a write to
+	// a series with the given tags would exceed the quota of the series' key.
+	// The tags are not consumed.
+	AdmitWrite(tags ident.TagIterator, newSeries bool) error
+
+	// SeriesAdded records that a series with the given tags became active.
+	SeriesAdded(tags ident.Tags)
+
+	// SeriesRemoved records that a series with the given tags is no longer
+	// active.
+	SeriesRemoved(tags ident.Tags)
+}
+
+// Options are the options for write quotas.
+type Options interface {
+	// Validate validates the options.
+	Validate() error
+
+	// SetTagName sets the name of the tag whose value is the quota key.
+	SetTagName(value []byte) Options
+
+	// TagName returns the name of the tag whose value is the quota key.
+	TagName() []byte
+
+	// SetDefaultLimits sets the limits of keys without limits of their own.
+	SetDefaultLimits(value Limits) Options
+
+	// DefaultLimits returns the limits of keys without limits of their own.
+	DefaultLimits() Limits
+
+	// SetKeyLimits sets the limits of individual keys.
+	SetKeyLimits(value map[string]Limits) Options
+
+	// KeyLimits returns the limits of individual keys.
+	KeyLimits() map[string]Limits
+
+	// SetClockOptions sets the clock options.
+	SetClockOptions(value clock.Options) Options
+
+	// ClockOptions returns the clock options.
+	ClockOptions() clock.Options
+
+	// SetInstrumentOptions sets the instrument options.
+	SetInstrumentOptions(value instrument.Options) Options
+
+	// InstrumentOptions returns the instrument options.
+	InstrumentOptions() instrument.Options
+}
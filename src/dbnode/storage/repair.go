@@ -32,9 +32,19 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/ratelimit"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/dbnode/storage/index/convert"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3x/checked"
 	"github.com/m3db/m3x/context"
 	xerrors "github.com/m3db/m3x/errors"
 	"github.com/m3db/m3x/ident"
@@ -44,6 +54,10 @@ import (
 	"github.com/uber-go/tally"
 )
 
+const (
+	repairBytesPerMegabit = 1024 * 1024 / 8
+)
+
 var (
 	errNoRepairOptions  = errors.New("no repair options")
 	errRepairInProgress = errors.New("repair already in progress")
@@ -51,14 +65,23 @@ var (
 
 type recordFn func(namespace ident.ID, shard databaseShard, diffRes repair.MetadataComparisonResult)
 
+type repairDiffFn func(
+	session client.AdminSession,
+	nsMeta namespace.Metadata,
+	shard databaseShard,
+	diffRes repair.MetadataComparisonResult,
+) error
+
 type shardRepairer struct {
-	opts     Options
-	rpopts   repair.Options
-	client   client.AdminClient
-	recordFn recordFn
-	logger   xlog.Logger
-	scope    tally.Scope
-	nowFn    clock.NowFn
+	opts         Options
+	rpopts       repair.Options
+	client       client.AdminClient
+	recordFn     recordFn
+	repairDiffFn repairDiffFn
+	logger       xlog.Logger
+	scope        tally.Scope
+	nowFn        clock.NowFn
+	sleepFn      sleepFn
 }
 
 func newShardRepairer(opts Options, rpopts repair.Options) databaseShardRepairer {
@@ -66,14 +89,16 @@ func newShardRepairer(opts Options, rpopts repair.Options) databaseShardRepairer
 	scope := iopts.MetricsScope().SubScope("repair")
 
 	r := shardRepairer{
-		opts:   opts,
-		rpopts: rpopts,
-		client: rpopts.AdminClient(),
-		logger: iopts.Logger(),
-		scope:  scope,
-		nowFn:  opts.ClockOptions().NowFn(),
+		opts:    opts,
+		rpopts:  rpopts,
+		client:  rpopts.AdminClient(),
+		logger:  iopts.Logger(),
+		scope:   scope,
+		nowFn:   opts.ClockOptions().NowFn(),
+		sleepFn: time.Sleep,
 	}
 	r.recordFn = r.recordDifferences
+	r.repairDiffFn = r.repairDifferences
 
 	return r
 }
@@ -84,7 +109,7 @@ func (r shardRepairer) Options() repair.Options {
 
 func (r shardRepairer) Repair(
 	ctx context.Context,
-	namespace ident.ID,
+	nsMeta namespace.Metadata,
 	tr xtime.Range,
 	shard databaseShard,
 ) (repair.MetadataComparisonResult, error) {
@@ -122,7 +147,7 @@ func (r shardRepairer) Repair(
 
 	// Add peer metadata
 	level := r.rpopts.RepairConsistencyLevel()
-	peerIter, err := session.FetchBlocksMetadataFromPeers(namespace, shard.ID(), start, end,
+	peerIter, err := session.FetchBlocksMetadataFromPeers(nsMeta.ID(), shard.ID(), start, end,
 		level, result.NewOptions(), client.FetchBlocksMetadataEndpointV2)
 	if err != nil {
 		return repair.MetadataComparisonResult{}, err
@@ -133,7 +158,13 @@ func (r shardRepairer) Repair(
 
 	metadataRes := metadata.Compare()
 
-	r.recordFn(namespace, shard, metadataRes)
+	r.recordFn(nsMeta.ID(), shard, metadataRes)
+
+	// Stream the differing blocks from peers and persist them merged with
+	// the local data as new fileset volumes
+	if err := r.repairDiffFn(session, nsMeta, shard, metadataRes); err != nil {
+		return repair.MetadataComparisonResult{}, err
+	}
 
 	return metadataRes, nil
 }
@@ -166,6 +197,516 @@ func (r shardRepairer) recordDifferences(
 	checksumDiffScope.Counter("blocks").Inc(diffRes.ChecksumDifferences.NumBlocks())
 }
 
+// repairedSeries holds the blocks streamed from peers for a single
+// series that differs from the local copy at a given block start.
+type repairedSeries struct {
+	id     ident.ID
+	tags   ident.Tags
+	blocks []block.DatabaseBlock
+}
+
+func (s *repairedSeries) close() {
+	for _, b := range s.blocks {
+		b.Close()
+	}
+	s.blocks = nil
+}
+
+// NB(r): Like repairStatesByNs this uses a map[string]element for series
+// keyed by ID since it is only used by the infrequent repair process.
+type repairedSeriesByBlockStart map[xtime.UnixNano]map[string]*repairedSeries
+
+func (r repairedSeriesByBlockStart) close() {
+	for _, bySeries := range r {
+		for _, s := range bySeries {
+			s.close()
+		}
+	}
+}
+
+// repairedSeriesBlock is a merged series block that was persisted by
+// the repair and which should replace any in-memory copy of the block.
+type repairedSeriesBlock struct {
+	id      ident.ID
+	segment ts.Segment
+}
+
+type repairProgress struct {
+	blocksPending  tally.Gauge
+	blocksRepaired tally.Counter
+	blocksErrors   tally.Counter
+	seriesRepaired tally.Counter
+	bytesWritten   tally.Counter
+	throttled      tally.Timer
+}
+
+func (r shardRepairer) newRepairProgress(
+	namespace ident.ID,
+	shard databaseShard,
+) repairProgress {
+	scope := r.scope.Tagged(map[string]string{
+		"namespace":  namespace.String(),
+		"shard":      strconv.Itoa(int(shard.ID())),
+		"resultType": "repaired",
+	})
+	return repairProgress{
+		blocksPending:  scope.Gauge("blocks-pending"),
+		blocksRepaired: scope.Counter("blocks"),
+		blocksErrors:   scope.Counter("blocks-errors"),
+		seriesRepaired: scope.Counter("series"),
+		bytesWritten:   scope.Counter("bytes"),
+		throttled:      scope.Timer("throttle"),
+	}
+}
+
+func (r shardRepairer) repairDifferences(
+	session client.AdminSession,
+	nsMeta namespace.Metadata,
+	shard databaseShard,
+	diffRes repair.MetadataComparisonResult,
+) error {
+	metadatas := r.peerMetadatasToRepair(session.Origin(), diffRes)
+	if len(metadatas) == 0 {
+		return nil
+	}
+
+	var (
+		level    = r.rpopts.RepairConsistencyLevel()
+		idPool   = r.opts.IdentifierPool()
+		repaired = make(repairedSeriesByBlockStart)
+	)
+	defer repaired.close()
+
+	blocksIter, err := session.FetchBlocksFromPeers(nsMeta, shard.ID(), level,
+		metadatas, result.NewOptions())
+	if err != nil {
+		return err
+	}
+	for blocksIter.Next() {
+		_, id, tags, blk := blocksIter.Current()
+		blockStart := xtime.ToUnixNano(blk.StartTime())
+		bySeries, ok := repaired[blockStart]
+		if !ok {
+			bySeries = make(map[string]*repairedSeries)
+			repaired[blockStart] = bySeries
+		}
+		s, ok := bySeries[id.String()]
+		if !ok {
+			// Clone the ID and tags since they are only valid until the
+			// next call to Next
+			s = &repairedSeries{
+				id:   idPool.Clone(id),
+				tags: idPool.Tags(),
+			}
+			for _, tag := range tags.Values() {
+				s.tags.Append(idPool.CloneTag(tag))
+			}
+			bySeries[id.String()] = s
+		}
+		s.blocks = append(s.blocks, blk)
+	}
+	if err := blocksIter.Err(); err != nil {
+		return err
+	}
+
+	var (
+		multiErr = xerrors.NewMultiError()
+		progress = r.newRepairProgress(nsMeta.ID(), shard)
+		pending  = len(repaired)
+	)
+	progress.blocksPending.Update(float64(pending))
+	for blockStart, bySeries := range repaired {
+		err := r.persistRepairedBlock(nsMeta, shard, blockStart.ToTime(),
+			bySeries, progress)
+		if err != nil {
+			progress.blocksErrors.Inc(1)
+			multiErr = multiErr.Add(fmt.Errorf(
+				"failed to persist repaired block %v for shard %d: %v",
+				blockStart.ToTime(), shard.ID(), err))
+		}
+		pending--
+		progress.blocksPending.Update(float64(pending))
+	}
+
+	return multiErr.FinalError()
+}
+
+// peerMetadatasToRepair returns the peer replica metadata of every series
+// block that has either a size or checksum difference with the local host.
+func (r shardRepairer) peerMetadatasToRepair(
+	origin topology.Host,
+	diffRes repair.MetadataComparisonResult,
+) []block.ReplicaMetadata {
+	var (
+		metadatas []block.ReplicaMetadata
+		seen      = make(map[string]map[xtime.UnixNano]struct{})
+	)
+	for _, diffs := range []repair.ReplicaSeriesMetadata{
+		diffRes.SizeDifferences,
+		diffRes.ChecksumDifferences,
+	} {
+		if diffs == nil {
+			continue
+		}
+		for _, entry := range diffs.Series().Iter() {
+			series := entry.Value()
+			seenBlocks, ok := seen[series.ID.String()]
+			if !ok {
+				seenBlocks = make(map[xtime.UnixNano]struct{})
+				seen[series.ID.String()] = seenBlocks
+			}
+			for blockStart, blockMetadata := range series.Metadata.Blocks() {
+				if _, ok := seenBlocks[blockStart]; ok {
+					continue
+				}
+				seenBlocks[blockStart] = struct{}{}
+				for _, hostMetadata := range blockMetadata.Metadata() {
+					if hostMetadata.Host.ID() == origin.ID() || hostMetadata.Size == 0 {
+						continue
+					}
+					metadatas = append(metadatas, block.ReplicaMetadata{
+						Host: hostMetadata.Host,
+						Metadata: block.NewMetadata(series.ID, ident.Tags{},
+							blockMetadata.Start(), hostMetadata.Size,
+							hostMetadata.Checksum, time.Time{}),
+					})
+				}
+			}
+		}
+	}
+	return metadatas
+}
+
+// persistRepairedBlock writes a new fileset volume for the block start that
+// contains all the series of the latest local volume with the repaired series
+// merged in and then notifies the shard, the volumes it supersedes are deleted
+// by the cleanup manager once they are no longer read from.
+func (r shardRepairer) persistRepairedBlock(
+	nsMeta namespace.Metadata,
+	shard databaseShard,
+	blockStart time.Time,
+	bySeries map[string]*repairedSeries,
+	progress repairProgress,
+) error {
+	// The volume is written under the shard's fileset volume lock so that it
+	// is not allocated the same volume index as a concurrent cold flush and
+	// merges with the latest volume written by one.
+	return shard.WithFileSetVolumeLock(func() error {
+		return r.persistRepairedBlockWithLock(nsMeta, shard, blockStart,
+			bySeries, progress)
+	})
+}
+
+func (r shardRepairer) persistRepairedBlockWithLock(
+	nsMeta namespace.Metadata,
+	shard databaseShard,
+	blockStart time.Time,
+	bySeries map[string]*repairedSeries,
+	progress repairProgress,
+) error {
+	var (
+		fsOpts    = r.opts.CommitLogOptions().FilesystemOptions()
+		prefix    = fsOpts.FilePathPrefix()
+		nsID      = nsMeta.ID()
+		shardID   = shard.ID()
+		blockSize = nsMeta.Options().RetentionOptions().BlockSize()
+		scheme    = nsMeta.Options().EncodingScheme()
+		limiter   = newRepairRateLimiter(r.rpopts.RepairRateLimitOptions(),
+			r.nowFn, r.sleepFn)
+		// Series IDs and tags must remain valid until the writer is closed
+		// as the index entries are written out on close.
+		finalizers []func()
+		updates    []repairedSeriesBlock
+	)
+	defer func() {
+		for _, fn := range finalizers {
+			fn()
+		}
+		for _, u := range updates {
+			u.segment.Finalize()
+		}
+	}()
+
+	existing, ok, err := fs.FileSetAt(prefix, nsID, shardID, blockStart)
+	if err != nil {
+		return err
+	}
+	if !ok && shard.FlushState(blockStart).Status != fileOpSuccess {
+		// The block has not been flushed yet, writing a volume for it would
+		// collide with the flush so it is repaired once it has been flushed.
+		r.logger.WithFields(
+			xlog.NewField("namespace", nsID.String()),
+			xlog.NewField("shard", shardID),
+			xlog.NewField("blockStart", blockStart.String()),
+		).Infof("skipping repair of block that has not been flushed")
+		return nil
+	}
+
+	volumeIndex, err := fs.NextDataFileSetVolumeIndex(prefix, nsID, shardID, blockStart)
+	if err != nil {
+		return err
+	}
+
+	writer, err := fs.NewWriter(fsOpts)
+	if err != nil {
+		return err
+	}
+	err = writer.Open(fs.DataWriterOpenOptions{
+		FileSetType: persist.FileSetFlushType,
+		BlockSize:   blockSize,
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:   nsID,
+			Shard:       shardID,
+			BlockStart:  blockStart,
+			VolumeIndex: volumeIndex,
+		},
+		EncodingScheme: scheme,
+	})
+	if err != nil {
+		return err
+	}
+
+	write := func(id ident.ID, tags ident.Tags, segment ts.Segment) error {
+		limiter.throttle(progress)
+		err := writer.WriteAll(id, tags, []checked.Bytes{segment.Head, segment.Tail},
+			digest.SegmentChecksum(segment))
+		limiter.written(segment.Len())
+		progress.bytesWritten.Inc(int64(segment.Len()))
+		return err
+	}
+
+	if ok {
+		reader, err := fs.NewReader(r.opts.BytesPool(), fsOpts)
+		if err != nil {
+			writer.Close()
+			return err
+		}
+		err = reader.Open(fs.DataReaderOpenOptions{
+			Identifier: existing.ID,
+		})
+		if err != nil {
+			writer.Close()
+			return err
+		}
+		for i := 0; i < reader.Entries(); i++ {
+			id, tagsIter, data, _, err := reader.Read()
+			if err != nil {
+				reader.Close()
+				writer.Close()
+				return err
+			}
+			tags, err := convert.TagsFromTagsIter(id, tagsIter, r.opts.IdentifierPool())
+			tagsIter.Close()
+			if err != nil {
+				reader.Close()
+				writer.Close()
+				return err
+			}
+			finalizers = append(finalizers, id.Finalize, tags.Finalize)
+
+			local := ts.NewSegment(data, nil, ts.FinalizeHead)
+			s, ok := bySeries[id.String()]
+			if !ok {
+				// Not repaired, write through as is
+				err = write(id, tags, local)
+				local.Finalize()
+			} else {
+				delete(bySeries, id.String())
+				err = r.writeMerged(write, blockStart, blockSize, scheme,
+					id, tags, &local, nil, s, &updates)
+				s.close()
+			}
+			if err != nil {
+				reader.Close()
+				writer.Close()
+				return err
+			}
+		}
+		if err := reader.Close(); err != nil {
+			writer.Close()
+			return err
+		}
+	}
+
+	// Write out any series that only exist on peers, without a local volume
+	// the data of the block held in memory by the series, if any, has not
+	// been persisted either and is merged in.
+	for _, s := range bySeries {
+		var (
+			ctx      = r.opts.ContextPool().Get()
+			inMemory [][]xio.BlockReader
+			err      error
+		)
+		if !ok {
+			inMemory, err = shard.ReadEncoded(ctx, s.id, blockStart,
+				blockStart.Add(blockSize))
+		}
+		if err == nil {
+			err = r.writeMerged(write, blockStart, blockSize, scheme,
+				s.id, s.tags, nil, inMemory, s, &updates)
+		}
+		ctx.BlockingClose()
+		s.close()
+		if err != nil {
+			writer.Close()
+			return err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	// The new volume is complete, have the shard start serving reads from
+	// the new volume.
+	repairedUpdates := updates
+	updates = nil
+	if err := shard.OnRepairedBlocks(blockStart, repairedUpdates); err != nil {
+		return err
+	}
+
+	progress.blocksRepaired.Inc(1)
+	progress.seriesRepaired.Inc(int64(len(repairedUpdates)))
+	r.logger.WithFields(
+		xlog.NewField("namespace", nsID.String()),
+		xlog.NewField("shard", shardID),
+		xlog.NewField("blockStart", blockStart.String()),
+		xlog.NewField("volumeIndex", volumeIndex),
+		xlog.NewField("numSeriesRepaired", len(repairedUpdates)),
+	).Infof("persisted repaired block")
+
+	return nil
+}
+
+// writeMerged merges the local segment and in-memory blocks, if any, with the
+// blocks streamed from peers and writes the result, the merged segment is
+// retained in updates.
+func (r shardRepairer) writeMerged(
+	write func(id ident.ID, tags ident.Tags, segment ts.Segment) error,
+	blockStart time.Time,
+	blockSize time.Duration,
+	scheme string,
+	id ident.ID,
+	tags ident.Tags,
+	local *ts.Segment,
+	inMemory [][]xio.BlockReader,
+	s *repairedSeries,
+	updates *[]repairedSeriesBlock,
+) error {
+	if local != nil {
+		defer local.Finalize()
+	}
+
+	// The database level pools are for the default encoding scheme
+	iter, err := newEncodingSchemeIterator(r.opts, "", scheme)
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	encoder, err := newEncodingSchemeEncoder(r.opts, "", scheme)
+	if err != nil {
+		return err
+	}
+	defer encoder.Close()
+
+	var (
+		ctx     = r.opts.ContextPool().Get()
+		readers = make([]xio.SegmentReader, 0, len(s.blocks)+1)
+	)
+	defer ctx.Close()
+
+	if local != nil {
+		readers = append(readers, xio.NewSegmentReader(*local))
+	}
+	for _, blockReaders := range inMemory {
+		for _, br := range blockReaders {
+			readers = append(readers, br.SegmentReader)
+		}
+	}
+	for _, b := range s.blocks {
+		stream, err := b.Stream(ctx)
+		if err != nil {
+			return err
+		}
+		if stream.SegmentReader != nil {
+			readers = append(readers, stream.SegmentReader)
+		}
+	}
+
+	encoder.Reset(blockStart, r.opts.DatabaseBlockOptions().DatabaseBlockAllocSize())
+	iter.Reset(readers, blockStart, blockSize)
+	for iter.Next() {
+		dp, unit, annotation := iter.Current()
+		if err := encoder.Encode(dp, unit, annotation); err != nil {
+			return err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	merged := encoder.Discard()
+	if err := write(id, tags, merged); err != nil {
+		merged.Finalize()
+		return err
+	}
+	*updates = append(*updates, repairedSeriesBlock{id: id, segment: merged})
+	return nil
+}
+
+// repairRateLimiter throttles the rate at which repaired blocks are written
+// to disk, following the same approach as the persist manager.
+type repairRateLimiter struct {
+	opts         ratelimit.Options
+	nowFn        clock.NowFn
+	sleepFn      sleepFn
+	start        time.Time
+	count        int
+	bytesWritten int64
+}
+
+func newRepairRateLimiter(
+	opts ratelimit.Options,
+	nowFn clock.NowFn,
+	sleepFn sleepFn,
+) *repairRateLimiter {
+	return &repairRateLimiter{
+		opts:    opts,
+		nowFn:   nowFn,
+		sleepFn: sleepFn,
+	}
+}
+
+func (l *repairRateLimiter) throttle(progress repairProgress) {
+	limitMbps := l.opts.LimitMbps()
+	if !l.opts.LimitEnabled() || limitMbps <= 0.0 {
+		return
+	}
+
+	now := l.nowFn()
+	if l.start.IsZero() {
+		l.start = now
+		return
+	}
+	if l.count < l.opts.LimitCheckEvery() {
+		return
+	}
+
+	target := time.Duration(float64(time.Second) * float64(l.bytesWritten) /
+		(limitMbps * repairBytesPerMegabit))
+	if elapsed := now.Sub(l.start); elapsed < target {
+		l.sleepFn(target - elapsed)
+		progress.throttled.Record(target - elapsed)
+	}
+	l.count = 0
+}
+
+func (l *repairRateLimiter) written(n int) {
+	l.count++
+	l.bytesWritten += int64(n)
+}
+
 type repairFn func() error
 
 type sleepFn func(d time.Duration)
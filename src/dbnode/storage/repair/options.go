@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/ratelimit"
 	"github.com/m3db/m3/src/dbnode/topology"
 )
 
@@ -37,6 +38,8 @@ const (
 	defaultRepairThrottle         = 90 * time.Second
 	defaultRepairMaxRetries       = 3
 	defaultRepairShardConcurrency = 1
+	defaultRepairLimitEnabled     = true
+	defaultRepairLimitMbps        = 50.0
 )
 
 var (
@@ -50,6 +53,7 @@ var (
 	errInvalidRepairThrottle        = errors.New("invalid repair throttle in repair options")
 	errInvalidRepairMaxRetries      = errors.New("invalid repair max retries in repair options")
 	errNoHostBlockMetadataSlicePool = errors.New("no host block metadata pool in repair options")
+	errNoRepairRateLimitOptions     = errors.New("no repair rate limit options in repair options")
 )
 
 type options struct {
@@ -63,6 +67,7 @@ type options struct {
 	repairThrottle             time.Duration
 	repairMaxRetries           int
 	hostBlockMetadataSlicePool HostBlockMetadataSlicePool
+	repairRateLimitOpts        ratelimit.Options
 }
 
 // NewOptions creates new bootstrap options
@@ -77,6 +82,9 @@ func NewOptions() Options {
 		repairThrottle:             defaultRepairThrottle,
 		repairMaxRetries:           defaultRepairMaxRetries,
 		hostBlockMetadataSlicePool: NewHostBlockMetadataSlicePool(nil, 0),
+		repairRateLimitOpts: ratelimit.NewOptions().
+			SetLimitEnabled(defaultRepairLimitEnabled).
+			SetLimitMbps(defaultRepairLimitMbps),
 	}
 }
 
@@ -180,6 +188,16 @@ func (o *options) HostBlockMetadataSlicePool() HostBlockMetadataSlicePool {
 	return o.hostBlockMetadataSlicePool
 }
 
+func (o *options) SetRepairRateLimitOptions(value ratelimit.Options) Options {
+	opts := *o
+	opts.repairRateLimitOpts = value
+	return &opts
+}
+
+func (o *options) RepairRateLimitOptions() ratelimit.Options {
+	return o.repairRateLimitOpts
+}
+
 func (o *options) Validate() error {
 	if o.adminClient == nil {
 		return errNoAdminClient
@@ -211,5 +229,8 @@ func (o *options) Validate() error {
 	if o.hostBlockMetadataSlicePool == nil {
 		return errNoHostBlockMetadataSlicePool
 	}
+	if o.repairRateLimitOpts == nil {
+		return errNoRepairRateLimitOptions
+	}
 	return nil
 }
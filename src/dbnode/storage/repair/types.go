@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/ratelimit"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3x/ident"
@@ -212,6 +213,14 @@ type Options interface {
 	// HostBlockMetadataSlicePool returns the hostBlockMetadataSlice pool
 	HostBlockMetadataSlicePool() HostBlockMetadataSlicePool
 
+	// SetRepairRateLimitOptions sets the rate limit options used when
+	// persisting repaired blocks
+	SetRepairRateLimitOptions(value ratelimit.Options) Options
+
+	// RepairRateLimitOptions returns the rate limit options used when
+	// persisting repaired blocks
+	RepairRateLimitOptions() ratelimit.Options
+
 	// Validate checks if the options are valid
 	Validate() error
 }
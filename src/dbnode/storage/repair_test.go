@@ -22,16 +22,27 @@ package storage
 
 import (
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3x/checked"
 	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
 	xtime "github.com/m3db/m3x/time"
@@ -231,8 +242,11 @@ func TestDatabaseShardRepairerRepair(t *testing.T) {
 		SetClockOptions(copts.SetNowFn(nowFn)).
 		SetInstrumentOptions(iopts.SetMetricsScope(tally.NoopScope))
 
+	nsMeta, err := namespace.NewMetadata(ident.StringID("testNamespace"), namespace.NewOptions())
+	require.NoError(t, err)
+
 	var (
-		namespace       = ident.StringID("testNamespace")
+		nsID            = nsMeta.ID()
 		start           = now
 		end             = now.Add(rtopts.BlockSize())
 		repairTimeRange = xtime.Range{Start: start, End: end}
@@ -291,7 +305,7 @@ func TestDatabaseShardRepairerRepair(t *testing.T) {
 		peerIter.EXPECT().Err().Return(nil),
 	)
 	session.EXPECT().
-		FetchBlocksMetadataFromPeers(namespace, shardID, start, end,
+		FetchBlocksMetadataFromPeers(nsID, shardID, start, end,
 			rpOpts.RepairConsistencyLevel(), gomock.Any(), client.FetchBlocksMetadataEndpointV2).
 		Return(peerIter, nil)
 
@@ -308,11 +322,23 @@ func TestDatabaseShardRepairerRepair(t *testing.T) {
 		resShard = shard
 		resDiff = diffRes
 	}
+	var repairedDiff repair.MetadataComparisonResult
+	repairer.repairDiffFn = func(
+		_ client.AdminSession,
+		_ namespace.Metadata,
+		_ databaseShard,
+		diffRes repair.MetadataComparisonResult,
+	) error {
+		repairedDiff = diffRes
+		return nil
+	}
 
 	ctx := context.NewContext()
-	repairer.Repair(ctx, namespace, repairTimeRange, shard)
-	require.Equal(t, namespace, resNamespace)
+	_, err = repairer.Repair(ctx, nsMeta, repairTimeRange, shard)
+	require.NoError(t, err)
+	require.Equal(t, nsID, resNamespace)
 	require.Equal(t, resShard, shard)
+	require.Equal(t, resDiff, repairedDiff)
 	require.Equal(t, int64(2), resDiff.NumSeries)
 	require.Equal(t, int64(3), resDiff.NumBlocks)
 	require.Equal(t, 0, resDiff.ChecksumDifferences.Series().Len())
@@ -471,3 +497,297 @@ func TestRepairerTimesMultipleNamespaces(t *testing.T) {
 		AddRange(xtime.Range{Start: tf4(7), End: tf4(13)})
 	require.Equal(t, expectedRanges, res)
 }
+
+func TestDatabaseShardRepairerRepairDifferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "testdir")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	opts := testDatabaseOptions()
+	fsOpts := opts.CommitLogOptions().FilesystemOptions().SetFilePathPrefix(dir)
+	opts = opts.SetCommitLogOptions(opts.CommitLogOptions().SetFilesystemOptions(fsOpts))
+
+	nsMeta, err := namespace.NewMetadata(defaultTestNs1ID, defaultTestNs1Opts)
+	require.NoError(t, err)
+
+	var (
+		shardID    = uint32(0)
+		blockSize  = nsMeta.Options().RetentionOptions().BlockSize()
+		blockStart = time.Now().Truncate(blockSize).Add(-2 * blockSize)
+		origin     = topology.NewHost("0", "addr0")
+		peer       = topology.NewHost("1", "addr1")
+		rpOpts     = testRepairOptions(ctrl)
+	)
+
+	encode := func(values map[time.Duration]float64) ts.Segment {
+		encoder := opts.EncoderPool().Get()
+		encoder.Reset(blockStart, 0)
+		for _, offset := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute} {
+			if v, ok := values[offset]; ok {
+				dp := ts.Datapoint{Timestamp: blockStart.Add(offset), Value: v}
+				require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+			}
+		}
+		return encoder.Discard()
+	}
+
+	// Write the local fileset volume
+	writer, err := fs.NewWriter(fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, writer.Open(fs.DataWriterOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  nsMeta.ID(),
+			Shard:      shardID,
+			BlockStart: blockStart,
+		},
+		BlockSize: blockSize,
+	}))
+	local := map[string]map[time.Duration]float64{
+		"foo": {time.Minute: 1, 2 * time.Minute: 2},
+		"bar": {time.Minute: 10},
+	}
+	for id, values := range local {
+		segment := encode(values)
+		require.NoError(t, writer.WriteAll(ident.StringID(id), ident.Tags{},
+			[]checked.Bytes{segment.Head, segment.Tail}, digest.SegmentChecksum(segment)))
+	}
+	require.NoError(t, writer.Close())
+
+	// Foo differs in size and baz is missing locally
+	sizeDiffs := repair.NewReplicaSeriesMetadata()
+	fooBlock := sizeDiffs.GetOrAdd(ident.StringID("foo")).
+		GetOrAdd(blockStart, rpOpts.HostBlockMetadataSlicePool())
+	fooBlock.Add(repair.HostBlockMetadata{Host: origin, Size: 1})
+	fooBlock.Add(repair.HostBlockMetadata{Host: peer, Size: 2})
+	checksumDiffs := repair.NewReplicaSeriesMetadata()
+	bazBlock := checksumDiffs.GetOrAdd(ident.StringID("baz")).
+		GetOrAdd(blockStart, rpOpts.HostBlockMetadataSlicePool())
+	bazBlock.Add(repair.HostBlockMetadata{Host: peer, Size: 1})
+	diffRes := repair.MetadataComparisonResult{
+		SizeDifferences:     sizeDiffs,
+		ChecksumDifferences: checksumDiffs,
+	}
+
+	blockOpts := opts.DatabaseBlockOptions()
+	peerIter := client.NewMockPeerBlocksIter(ctrl)
+	gomock.InOrder(
+		peerIter.EXPECT().Next().Return(true),
+		peerIter.EXPECT().Current().Return(peer, ident.StringID("foo"), ident.Tags{},
+			block.NewDatabaseBlock(blockStart, blockSize,
+				encode(map[time.Duration]float64{2 * time.Minute: 2, 3 * time.Minute: 3}), blockOpts)),
+		peerIter.EXPECT().Next().Return(true),
+		peerIter.EXPECT().Current().Return(peer, ident.StringID("baz"), ident.Tags{},
+			block.NewDatabaseBlock(blockStart, blockSize,
+				encode(map[time.Duration]float64{time.Minute: 5}), blockOpts)),
+		peerIter.EXPECT().Next().Return(false),
+		peerIter.EXPECT().Err().Return(nil),
+	)
+
+	session := client.NewMockAdminSession(ctrl)
+	session.EXPECT().Origin().Return(origin)
+	session.EXPECT().
+		FetchBlocksFromPeers(nsMeta, shardID, rpOpts.RepairConsistencyLevel(),
+			gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ namespace.Metadata,
+			_ uint32,
+			_ topology.ReadConsistencyLevel,
+			metadatas []block.ReplicaMetadata,
+			_ result.Options,
+		) (client.PeerBlocksIter, error) {
+			require.Equal(t, 2, len(metadatas))
+			for _, m := range metadatas {
+				require.Equal(t, peer.ID(), m.Host.ID())
+				require.True(t, blockStart.Equal(m.Start))
+			}
+			return peerIter, nil
+		})
+
+	var repaired []string
+	shard := NewMockdatabaseShard(ctrl)
+	shard.EXPECT().ID().Return(shardID).AnyTimes()
+	shard.EXPECT().WithFileSetVolumeLock(gomock.Any()).
+		DoAndReturn(func(fn func() error) error {
+			return fn()
+		})
+	shard.EXPECT().OnRepairedBlocks(blockStart, gomock.Any()).
+		DoAndReturn(func(_ time.Time, blocks []repairedSeriesBlock) error {
+			for _, b := range blocks {
+				repaired = append(repaired, b.id.String())
+				b.segment.Finalize()
+			}
+			return nil
+		})
+
+	repairer := newShardRepairer(opts, rpOpts).(shardRepairer)
+	require.NoError(t, repairer.repairDifferences(session, nsMeta, shard, diffRes))
+	sort.Strings(repaired)
+	require.Equal(t, []string{"baz", "foo"}, repaired)
+
+	// The repaired volume is the latest volume, the superseded volume is left
+	// for the cleanup manager to delete once it is no longer read from
+	fileset, ok, err := fs.FileSetAt(dir, nsMeta.ID(), shardID, blockStart)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 1, fileset.ID.VolumeIndex)
+	legacyCheckpoint := path.Join(fs.ShardDataDirPath(dir, nsMeta.ID(), shardID),
+		fmt.Sprintf("fileset-%d-checkpoint.db", blockStart.UnixNano()))
+	_, err = os.Stat(legacyCheckpoint)
+	require.NoError(t, err)
+
+	// Verify the repaired volume holds the merged data
+	reader, err := fs.NewReader(opts.BytesPool(), fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, reader.Open(fs.DataReaderOpenOptions{Identifier: fileset.ID}))
+	defer reader.Close()
+
+	expected := map[string][]float64{
+		"foo": {1, 2, 3},
+		"bar": {10},
+		"baz": {5},
+	}
+	require.Equal(t, len(expected), reader.Entries())
+	for i := 0; i < reader.Entries(); i++ {
+		id, tagsIter, data, _, err := reader.Read()
+		require.NoError(t, err)
+		tagsIter.Close()
+
+		segment := ts.NewSegment(data, nil, ts.FinalizeHead)
+		iter := opts.ReaderIteratorPool().Get()
+		iter.Reset(xio.NewSegmentReader(segment))
+		var values []float64
+		for iter.Next() {
+			dp, _, _ := iter.Current()
+			values = append(values, dp.Value)
+		}
+		require.NoError(t, iter.Err())
+		iter.Close()
+		segment.Finalize()
+
+		require.Equal(t, expected[id.String()], values, id.String())
+	}
+}
+
+func TestDatabaseShardRepairerRepairDifferencesWithoutLocalVolume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "testdir")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	opts := testDatabaseOptions()
+	fsOpts := opts.CommitLogOptions().FilesystemOptions().SetFilePathPrefix(dir)
+	opts = opts.SetCommitLogOptions(opts.CommitLogOptions().SetFilesystemOptions(fsOpts))
+
+	nsMeta, err := namespace.NewMetadata(defaultTestNs1ID, defaultTestNs1Opts)
+	require.NoError(t, err)
+
+	var (
+		shardID    = uint32(0)
+		blockSize  = nsMeta.Options().RetentionOptions().BlockSize()
+		blockStart = time.Now().Truncate(blockSize).Add(-2 * blockSize)
+		origin     = topology.NewHost("0", "addr0")
+		peer       = topology.NewHost("1", "addr1")
+		rpOpts     = testRepairOptions(ctrl)
+		blockOpts  = opts.DatabaseBlockOptions()
+	)
+
+	encode := func(values map[time.Duration]float64) ts.Segment {
+		encoder := opts.EncoderPool().Get()
+		encoder.Reset(blockStart, 0)
+		for _, offset := range []time.Duration{time.Minute, 2 * time.Minute} {
+			if v, ok := values[offset]; ok {
+				dp := ts.Datapoint{Timestamp: blockStart.Add(offset), Value: v}
+				require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+			}
+		}
+		return encoder.Discard()
+	}
+
+	checksumDiffs := repair.NewReplicaSeriesMetadata()
+	fooBlock := checksumDiffs.GetOrAdd(ident.StringID("foo")).
+		GetOrAdd(blockStart, rpOpts.HostBlockMetadataSlicePool())
+	fooBlock.Add(repair.HostBlockMetadata{Host: origin, Size: 1})
+	fooBlock.Add(repair.HostBlockMetadata{Host: peer, Size: 1})
+	diffRes := repair.MetadataComparisonResult{
+		SizeDifferences:     repair.NewReplicaSeriesMetadata(),
+		ChecksumDifferences: checksumDiffs,
+	}
+
+	peerIter := client.NewMockPeerBlocksIter(ctrl)
+	gomock.InOrder(
+		peerIter.EXPECT().Next().Return(true),
+		peerIter.EXPECT().Current().Return(peer, ident.StringID("foo"), ident.Tags{},
+			block.NewDatabaseBlock(blockStart, blockSize,
+				encode(map[time.Duration]float64{2 * time.Minute: 2}), blockOpts)),
+		peerIter.EXPECT().Next().Return(false),
+		peerIter.EXPECT().Err().Return(nil),
+	)
+
+	session := client.NewMockAdminSession(ctrl)
+	session.EXPECT().Origin().Return(origin)
+	session.EXPECT().
+		FetchBlocksFromPeers(nsMeta, shardID, rpOpts.RepairConsistencyLevel(),
+			gomock.Any(), gomock.Any()).
+		Return(peerIter, nil)
+
+	// The series holds data for the block in memory that is not persisted
+	shard := NewMockdatabaseShard(ctrl)
+	shard.EXPECT().ID().Return(shardID).AnyTimes()
+	shard.EXPECT().WithFileSetVolumeLock(gomock.Any()).
+		DoAndReturn(func(fn func() error) error {
+			return fn()
+		})
+	shard.EXPECT().FlushState(blockStart).Return(fileOpState{Status: fileOpSuccess})
+	shard.EXPECT().
+		ReadEncoded(gomock.Any(), ident.NewIDMatcher("foo"), blockStart, blockStart.Add(blockSize)).
+		Return([][]xio.BlockReader{{{
+			SegmentReader: xio.NewSegmentReader(encode(map[time.Duration]float64{time.Minute: 1})),
+			Start:         blockStart,
+			BlockSize:     blockSize,
+		}}}, nil)
+	shard.EXPECT().OnRepairedBlocks(blockStart, gomock.Any()).
+		DoAndReturn(func(_ time.Time, blocks []repairedSeriesBlock) error {
+			for _, b := range blocks {
+				b.segment.Finalize()
+			}
+			return nil
+		})
+
+	repairer := newShardRepairer(opts, rpOpts).(shardRepairer)
+	require.NoError(t, repairer.repairDifferences(session, nsMeta, shard, diffRes))
+
+	fileset, ok, err := fs.FileSetAt(dir, nsMeta.ID(), shardID, blockStart)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 0, fileset.ID.VolumeIndex)
+
+	// The persisted volume holds the in-memory data merged with the peer's
+	reader, err := fs.NewReader(opts.BytesPool(), fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, reader.Open(fs.DataReaderOpenOptions{Identifier: fileset.ID}))
+	defer reader.Close()
+
+	require.Equal(t, 1, reader.Entries())
+	id, tagsIter, data, _, err := reader.Read()
+	require.NoError(t, err)
+	tagsIter.Close()
+	require.Equal(t, "foo", id.String())
+
+	segment := ts.NewSegment(data, nil, ts.FinalizeHead)
+	iter := opts.ReaderIteratorPool().Get()
+	iter.Reset(xio.NewSegmentReader(segment))
+	var values []float64
+	for iter.Next() {
+		dp, _, _ := iter.Current()
+		values = append(values, dp.Value)
+	}
+	require.NoError(t, iter.Err())
+	iter.Close()
+	segment.Finalize()
+	require.Equal(t, []float64{1, 2}, values)
+}
@@ -28,6 +28,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	m3dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
 	"github.com/m3db/m3/src/dbnode/ts"
@@ -102,6 +103,24 @@ type databaseBuffer interface {
 
 	Bootstrap(bl block.DatabaseBlock) error
 
+	// ColdBlockStarts returns the block starts of the blocks that have
+	// cold writes buffered.
+	ColdBlockStarts() []time.Time
+
+	// ColdStreams returns the streams of the cold writes buffered for the
+	// block, these are returned with the warm bucket of the block instead
+	// if the block has not yet been drained from the buffer.
+	ColdStreams(ctx context.Context, blockStart time.Time) []xio.BlockReader
+
+	// PrepareColdFlush seals the cold writes buffered for the block so that
+	// writes arriving during the cold flush are kept for the next one and
+	// returns the streams of all the sealed cold writes for the block.
+	PrepareColdFlush(ctx context.Context, blockStart time.Time) []xio.BlockReader
+
+	// ColdFlushed discards the sealed cold writes for the block once they
+	// have been persisted.
+	ColdFlushed(blockStart time.Time)
+
 	Reset(opts Options)
 }
 
@@ -127,6 +146,12 @@ type dbBuffer struct {
 	blockSize         time.Duration
 	bufferPast        time.Duration
 	bufferFuture      time.Duration
+
+	// Cold writes are buffered per block until a cold flush persists them,
+	// the buckets being persisted by an in progress or failed cold flush
+	// are sealed so that they are not mutated by writes.
+	coldBuckets       map[xtime.UnixNano]*dbBufferBucket
+	sealedColdBuckets map[xtime.UnixNano]*dbBufferBucket
 }
 
 type databaseBufferDrainFn func(b block.DatabaseBlock)
@@ -147,6 +172,7 @@ func (b *dbBuffer) Reset(opts Options) {
 	b.blockSize = ropts.BlockSize()
 	b.bufferPast = ropts.BufferPast()
 	b.bufferFuture = ropts.BufferFuture()
+	b.resetColdBuckets()
 	// Avoid capturing any variables with callback
 	b.computedForEachBucketAsc(computeAndResetBucketIdx, bucketResetStart)
 }
@@ -189,7 +215,15 @@ func (b *dbBuffer) Write(
 		return m3dberrors.ErrTooFuture
 	}
 	if !pastLimit.Before(timestamp) {
-		return m3dberrors.ErrTooPast
+		if !b.opts.ColdWritesEnabled() {
+			return m3dberrors.ErrTooPast
+		}
+		// Blocks that are not yet flushable still take writes in the
+		// warm buckets, all other blocks are written by a cold flush.
+		flushTimeEnd := retention.FlushTimeEnd(b.opts.RetentionOptions(), now)
+		if !timestamp.Truncate(b.blockSize).After(flushTimeEnd) {
+			return b.writeCold(now, timestamp, value, unit, annotation)
+		}
 	}
 
 	bucketStart := timestamp.Truncate(b.blockSize)
@@ -202,6 +236,32 @@ func (b *dbBuffer) Write(
 	return b.buckets[idx].write(timestamp, value, unit, annotation)
 }
 
+func (b *dbBuffer) writeCold(
+	now time.Time,
+	timestamp time.Time,
+	value float64,
+	unit xtime.Unit,
+	annotation []byte,
+) error {
+	blockStart := timestamp.Truncate(b.blockSize)
+	if blockStart.Before(retention.FlushTimeStart(b.opts.RetentionOptions(), now)) {
+		return m3dberrors.ErrTooPast
+	}
+
+	if b.coldBuckets == nil {
+		b.coldBuckets = make(map[xtime.UnixNano]*dbBufferBucket)
+	}
+	key := xtime.ToUnixNano(blockStart)
+	bucket, ok := b.coldBuckets[key]
+	if !ok {
+		bucket = &dbBufferBucket{opts: b.opts}
+		bucket.resetTo(blockStart)
+		b.coldBuckets[key] = bucket
+	}
+
+	return bucket.write(timestamp, value, unit, annotation)
+}
+
 func (b *dbBuffer) writableBucketIdx(t time.Time) int {
 	return int(t.Truncate(b.blockSize).UnixNano() / int64(b.blockSize) % bucketsLen)
 }
@@ -211,6 +271,12 @@ func (b *dbBuffer) IsEmpty() bool {
 	for i := range b.buckets {
 		canReadAny = canReadAny || b.buckets[i].canRead()
 	}
+	for _, bucket := range b.coldBuckets {
+		canReadAny = canReadAny || bucket.canRead()
+	}
+	for _, bucket := range b.sealedColdBuckets {
+		canReadAny = canReadAny || bucket.canRead()
+	}
 	return !canReadAny
 }
 
@@ -244,11 +310,50 @@ func bucketNeedsDrain(now time.Time, b *dbBuffer, idx int, start time.Time) int
 func (b *dbBuffer) Tick() bufferTickResult {
 	// Avoid capturing any variables with callback
 	mergedOutOfOrder := b.computedForEachBucketAsc(computeAndResetBucketIdx, bucketTick)
+	mergedOutOfOrder += b.mergeColdBuckets()
+	b.expireColdBuckets()
 	return bufferTickResult{
 		mergedOutOfOrderBlocks: mergedOutOfOrder,
 	}
 }
 
+func (b *dbBuffer) mergeColdBuckets() int {
+	mergedOutOfOrderBlocks := 0
+	for _, bucket := range b.coldBuckets {
+		// Try to merge any out of order encoders to amortize the cost of a cold flush
+		r, err := bucket.merge()
+		if err != nil {
+			log := b.opts.InstrumentOptions().Logger()
+			log.Errorf("buffer cold bucket merge encode error: %v", err)
+		}
+		if r.merges > 0 {
+			mergedOutOfOrderBlocks++
+		}
+	}
+	return mergedOutOfOrderBlocks
+}
+
+func (b *dbBuffer) expireColdBuckets() {
+	if len(b.coldBuckets) == 0 && len(b.sealedColdBuckets) == 0 {
+		return
+	}
+
+	earliest := retention.FlushTimeStart(b.opts.RetentionOptions(), b.nowFn())
+	for _, buckets := range []map[xtime.UnixNano]*dbBufferBucket{
+		b.coldBuckets,
+		b.sealedColdBuckets,
+	} {
+		for key, bucket := range buckets {
+			if !bucket.start.Before(earliest) {
+				continue
+			}
+			// The block has fallen out of retention before being cold flushed
+			bucket.finalize()
+			delete(buckets, key)
+		}
+	}
+}
+
 func bucketTick(now time.Time, b *dbBuffer, idx int, start time.Time) int {
 	// Perform a drain and reset if necessary
 	mergedOutOfOrderBlocks := bucketDrainAndReset(now, b, idx, start)
@@ -333,6 +438,103 @@ func (b *dbBuffer) Bootstrap(bl block.DatabaseBlock) error {
 	return nil
 }
 
+func (b *dbBuffer) ColdBlockStarts() []time.Time {
+	if len(b.coldBuckets) == 0 && len(b.sealedColdBuckets) == 0 {
+		return nil
+	}
+
+	starts := make([]time.Time, 0, len(b.coldBuckets)+len(b.sealedColdBuckets))
+	for key, bucket := range b.sealedColdBuckets {
+		if bucket.canRead() {
+			starts = append(starts, key.ToTime())
+		}
+	}
+	for key, bucket := range b.coldBuckets {
+		if _, ok := b.sealedColdBuckets[key]; ok {
+			continue
+		}
+		if bucket.canRead() {
+			starts = append(starts, key.ToTime())
+		}
+	}
+	return starts
+}
+
+func (b *dbBuffer) ColdStreams(ctx context.Context, blockStart time.Time) []xio.BlockReader {
+	if len(b.coldBuckets) == 0 && len(b.sealedColdBuckets) == 0 {
+		return nil
+	}
+
+	for i := range b.buckets {
+		if b.buckets[i].start.Equal(blockStart) && b.buckets[i].canRead() {
+			// Returned along with the warm bucket of the block
+			return nil
+		}
+	}
+
+	return b.coldStreams(ctx, blockStart)
+}
+
+func (b *dbBuffer) coldStreams(ctx context.Context, blockStart time.Time) []xio.BlockReader {
+	var (
+		key     = xtime.ToUnixNano(blockStart)
+		streams []xio.BlockReader
+	)
+	// Sealed cold writes are returned first so that writes which arrived
+	// after they were sealed take precedence.
+	if bucket, ok := b.sealedColdBuckets[key]; ok && bucket.canRead() {
+		streams = append(streams, bucket.streams(ctx)...)
+	}
+	if bucket, ok := b.coldBuckets[key]; ok && bucket.canRead() {
+		streams = append(streams, bucket.streams(ctx)...)
+	}
+	return streams
+}
+
+func (b *dbBuffer) PrepareColdFlush(ctx context.Context, blockStart time.Time) []xio.BlockReader {
+	key := xtime.ToUnixNano(blockStart)
+	if bucket, ok := b.coldBuckets[key]; ok {
+		delete(b.coldBuckets, key)
+		if b.sealedColdBuckets == nil {
+			b.sealedColdBuckets = make(map[xtime.UnixNano]*dbBufferBucket)
+		}
+		if sealed, ok := b.sealedColdBuckets[key]; ok {
+			// A previous cold flush of the block failed, retry the writes that
+			// it sealed together with the writes that arrived since.
+			sealed.encoders = append(sealed.encoders, bucket.encoders...)
+			bucket.encoders = nil
+			bucket.finalize()
+		} else {
+			b.sealedColdBuckets[key] = bucket
+		}
+	}
+
+	sealed, ok := b.sealedColdBuckets[key]
+	if !ok || !sealed.canRead() {
+		return nil
+	}
+	return sealed.streams(ctx)
+}
+
+func (b *dbBuffer) ColdFlushed(blockStart time.Time) {
+	key := xtime.ToUnixNano(blockStart)
+	if bucket, ok := b.sealedColdBuckets[key]; ok {
+		bucket.finalize()
+		delete(b.sealedColdBuckets, key)
+	}
+}
+
+func (b *dbBuffer) resetColdBuckets() {
+	for key, bucket := range b.coldBuckets {
+		bucket.finalize()
+		delete(b.coldBuckets, key)
+	}
+	for key, bucket := range b.sealedColdBuckets {
+		bucket.finalize()
+		delete(b.sealedColdBuckets, key)
+	}
+}
+
 // forEachBucketAsc iterates over the buckets in time ascending order
 // to read bucket data
 func (b *dbBuffer) forEachBucketAsc(fn func(*dbBufferBucket)) {
@@ -425,7 +627,7 @@ func (b *dbBuffer) ReadEncoded(ctx context.Context, start, end time.Time) [][]xi
 			return
 		}
 
-		res = append(res, bucket.streams(ctx))
+		res = append(res, b.streamsWithColdWrites(ctx, bucket))
 
 		// NB(r): Store the last read time, should not set this when
 		// calling FetchBlocks as a read is differentiated from
@@ -459,13 +661,27 @@ func (b *dbBuffer) FetchBlocks(ctx context.Context, starts []time.Time) []block.
 			return
 		}
 
-		streams := bucket.streams(ctx)
+		streams := b.streamsWithColdWrites(ctx, bucket)
 		res = append(res, block.NewFetchBlockResult(bucket.start, streams, nil))
 	})
 
 	return res
 }
 
+// streamsWithColdWrites returns the streams of a warm bucket along with the
+// streams of any cold writes for the same block so that they are merged
+// when read.
+func (b *dbBuffer) streamsWithColdWrites(
+	ctx context.Context,
+	bucket *dbBufferBucket,
+) []xio.BlockReader {
+	streams := bucket.streams(ctx)
+	if len(b.coldBuckets) == 0 && len(b.sealedColdBuckets) == 0 {
+		return streams
+	}
+	return append(streams, b.coldStreams(ctx, bucket.start)...)
+}
+
 func (b *dbBuffer) FetchBlocksMetadata(
 	ctx context.Context,
 	start, end time.Time,
@@ -502,6 +718,44 @@ func (b *dbBuffer) FetchBlocksMetadata(
 		})
 	})
 
+	// Blocks with cold writes are reported too so that series written to
+	// blocks that have already been flushed are visible before a cold flush.
+	for _, blockStart := range b.ColdBlockStarts() {
+		if !start.Before(blockStart.Add(blockSize)) || !blockStart.Before(end) {
+			continue
+		}
+		var (
+			key      = xtime.ToUnixNano(blockStart)
+			size     int64
+			lastRead time.Time
+		)
+		for _, bucket := range []*dbBufferBucket{b.coldBuckets[key], b.sealedColdBuckets[key]} {
+			if bucket == nil || !bucket.canRead() {
+				continue
+			}
+			size += int64(bucket.streamsLen())
+			if bucketLastRead := bucket.lastRead(); bucketLastRead.After(lastRead) {
+				lastRead = bucketLastRead
+			}
+		}
+		if size == 0 {
+			continue
+		}
+		var resultSize int64
+		if opts.IncludeSizes {
+			resultSize = size
+		}
+		var resultLastRead time.Time
+		if opts.IncludeLastRead {
+			resultLastRead = lastRead
+		}
+		res.Add(block.FetchBlockMetadataResult{
+			Start:    blockStart,
+			Size:     resultSize,
+			LastRead: resultLastRead,
+		})
+	}
+
 	return res
 }
 
@@ -103,6 +103,92 @@ func TestBufferWriteTooPast(t *testing.T) {
 	assert.True(t, xerrors.IsInvalidParams(err))
 }
 
+func TestBufferWriteColdWrite(t *testing.T) {
+	opts := newBufferTestOptions().SetColdWritesEnabled(true)
+	rops := opts.RetentionOptions()
+	curr := time.Now().Truncate(rops.BlockSize())
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return curr
+	}))
+	buffer := newDatabaseBuffer(nil).(*dbBuffer)
+	buffer.Reset(opts)
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	data := []value{
+		{curr.Add(-mins(5)), 1, xtime.Second, nil},
+		{curr.Add(-mins(4.5)), 2, xtime.Second, nil},
+	}
+	for _, v := range data {
+		require.NoError(t, buffer.Write(ctx, v.timestamp, v.value, v.unit, v.annotation))
+	}
+
+	// Writes out of retention are still rejected
+	err := buffer.Write(ctx, curr.Add(-rops.RetentionPeriod()).Add(-rops.BlockSize()),
+		1, xtime.Second, nil)
+	assert.Error(t, err)
+	assert.True(t, xerrors.IsInvalidParams(err))
+
+	blockStart := curr.Add(-mins(6))
+	assert.Equal(t, []time.Time{blockStart}, buffer.ColdBlockStarts())
+	assertValuesEqual(t, data, [][]xio.BlockReader{
+		buffer.ColdStreams(ctx, blockStart),
+	}, opts)
+
+	metadata := buffer.FetchBlocksMetadata(ctx, timeZero, timeDistantFuture,
+		FetchBlocksMetadataOptions{}).Results()
+	require.Equal(t, 1, len(metadata))
+	assert.Equal(t, blockStart, metadata[0].Start)
+
+	results := buffer.ReadEncoded(ctx, timeZero, timeDistantFuture)
+	assertValuesEqual(t, data, results, opts)
+}
+
+func TestBufferColdFlush(t *testing.T) {
+	opts := newBufferTestOptions().SetColdWritesEnabled(true)
+	rops := opts.RetentionOptions()
+	curr := time.Now().Truncate(rops.BlockSize())
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return curr
+	}))
+	buffer := newDatabaseBuffer(nil).(*dbBuffer)
+	buffer.Reset(opts)
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	var (
+		blockStart = curr.Add(-mins(6))
+		sealed     = value{blockStart.Add(secs(10)), 1, xtime.Second, nil}
+		unsealed   = value{blockStart.Add(secs(20)), 2, xtime.Second, nil}
+	)
+	require.NoError(t, buffer.Write(ctx, sealed.timestamp, sealed.value,
+		sealed.unit, sealed.annotation))
+
+	streams := buffer.PrepareColdFlush(ctx, blockStart)
+	assertValuesEqual(t, []value{sealed}, [][]xio.BlockReader{streams}, opts)
+
+	// Writes arriving during the cold flush are buffered separately
+	require.NoError(t, buffer.Write(ctx, unsealed.timestamp, unsealed.value,
+		unsealed.unit, unsealed.annotation))
+	assertValuesEqual(t, []value{sealed, unsealed}, [][]xio.BlockReader{
+		buffer.ColdStreams(ctx, blockStart),
+	}, opts)
+
+	buffer.ColdFlushed(blockStart)
+	assert.Equal(t, []time.Time{blockStart}, buffer.ColdBlockStarts())
+	assertValuesEqual(t, []value{unsealed}, [][]xio.BlockReader{
+		buffer.ColdStreams(ctx, blockStart),
+	}, opts)
+
+	streams = buffer.PrepareColdFlush(ctx, blockStart)
+	assertValuesEqual(t, []value{unsealed}, [][]xio.BlockReader{streams}, opts)
+	buffer.ColdFlushed(blockStart)
+	assert.Empty(t, buffer.ColdBlockStarts())
+	assert.Nil(t, buffer.ColdStreams(ctx, blockStart))
+}
+
 func TestBufferWriteRead(t *testing.T) {
 	opts := newBufferTestOptions()
 	rops := opts.RetentionOptions()
@@ -93,7 +93,6 @@ func (r Reader) readersWithBlocksMapAndBuffer(
 	var (
 		nowFn        = r.opts.ClockOptions().NowFn()
 		now          = nowFn()
-		cachePolicy  = r.opts.CachePolicy()
 		ropts        = r.opts.RetentionOptions()
 		size         = ropts.BlockSize()
 		alignedStart = start.Truncate(size)
@@ -117,42 +116,18 @@ func (r Reader) readersWithBlocksMapAndBuffer(
 
 	first, last := alignedStart, alignedEnd
 	for blockAt := first; !blockAt.After(last); blockAt = blockAt.Add(size) {
-		if seriesBlocks != nil {
-			if block, ok := seriesBlocks.BlockAt(blockAt); ok {
-				// Block served from in-memory or in-memory metadata
-				// will defer to disk read
-				streamedBlock, err := block.Stream(ctx)
-				if err != nil {
-					return nil, err
-				}
-				if streamedBlock.IsNotEmpty() {
-					results = append(results, []xio.BlockReader{streamedBlock})
-					// NB(r): Mark this block as read now
-					block.SetLastReadTime(now)
-					if r.onRead != nil {
-						r.onRead.OnReadBlock(block)
-					}
-				}
-				continue
-			}
+		blockReaders, err := r.blockReadersAt(ctx, now, blockAt, seriesBlocks)
+		if err != nil {
+			return nil, err
 		}
 
-		switch {
-		case cachePolicy == CacheAll:
-			// No-op, block metadata should have been in-memory
-		case cachePolicy == CacheAllMetadata:
-			// No-op, block metadata should have been in-memory
-		case r.retriever != nil:
-			// Try to stream from disk
-			if r.retriever.IsBlockRetrievable(blockAt) {
-				streamedBlock, err := r.retriever.Stream(ctx, r.id, blockAt, r.onRetrieve)
-				if err != nil {
-					return nil, err
-				}
-				if streamedBlock.IsNotEmpty() {
-					results = append(results, []xio.BlockReader{streamedBlock})
-				}
-			}
+		if seriesBuffer != nil && r.opts.ColdWritesEnabled() {
+			// Cold writes are merged with the flushed data of the block
+			blockReaders = append(blockReaders, seriesBuffer.ColdStreams(ctx, blockAt)...)
+		}
+
+		if len(blockReaders) > 0 {
+			results = append(results, blockReaders)
 		}
 	}
 
@@ -166,6 +141,53 @@ func (r Reader) readersWithBlocksMapAndBuffer(
 	return results, nil
 }
 
+func (r Reader) blockReadersAt(
+	ctx context.Context,
+	now time.Time,
+	blockAt time.Time,
+	seriesBlocks block.DatabaseSeriesBlocks,
+) ([]xio.BlockReader, error) {
+	if seriesBlocks != nil {
+		if block, ok := seriesBlocks.BlockAt(blockAt); ok {
+			// Block served from in-memory or in-memory metadata
+			// will defer to disk read
+			streamedBlock, err := block.Stream(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if !streamedBlock.IsNotEmpty() {
+				return nil, nil
+			}
+			// NB(r): Mark this block as read now
+			block.SetLastReadTime(now)
+			if r.onRead != nil {
+				r.onRead.OnReadBlock(block)
+			}
+			return []xio.BlockReader{streamedBlock}, nil
+		}
+	}
+
+	switch {
+	case r.opts.CachePolicy() == CacheAll:
+		// No-op, block metadata should have been in-memory
+	case r.opts.CachePolicy() == CacheAllMetadata:
+		// No-op, block metadata should have been in-memory
+	case r.retriever != nil:
+		// Try to stream from disk
+		if r.retriever.IsBlockRetrievable(blockAt) {
+			streamedBlock, err := r.retriever.Stream(ctx, r.id, blockAt, r.onRetrieve)
+			if err != nil {
+				return nil, err
+			}
+			if streamedBlock.IsNotEmpty() {
+				return []xio.BlockReader{streamedBlock}, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
 // FetchBlocks returns data blocks given a list of block start times using
 // just a block retriever.
 func (r Reader) FetchBlocks(
@@ -193,45 +215,52 @@ func (r Reader) fetchBlocksWithBlocksMapAndBuffer(
 		onRetrieve block.OnRetrieveBlock
 	)
 	for _, start := range starts {
+		var coldStreams []xio.BlockReader
+		if seriesBuffer != nil && r.opts.ColdWritesEnabled() {
+			// Cold writes are merged with the flushed data of the block
+			coldStreams = seriesBuffer.ColdStreams(ctx, start)
+		}
+
+		var (
+			streamedBlock xio.BlockReader
+			err           error
+			found         bool
+		)
 		if seriesBlocks != nil {
 			if b, exists := seriesBlocks.BlockAt(start); exists {
-				streamedBlock, err := b.Stream(ctx)
-				if err != nil {
-					r := block.NewFetchBlockResult(start, nil,
-						fmt.Errorf("unable to retrieve block stream for series %s time %v: %v",
-							r.id.String(), start, err))
-					res = append(res, r)
-				}
-				if streamedBlock.IsNotEmpty() {
-					b := []xio.BlockReader{streamedBlock}
-					r := block.NewFetchBlockResult(start, b, nil)
-					res = append(res, r)
-				}
-				continue
+				streamedBlock, err = b.Stream(ctx)
+				found = true
 			}
 		}
-		switch {
-		case cachePolicy == CacheAll:
-			// No-op, block metadata should have been in-memory
-		case cachePolicy == CacheAllMetadata:
-			// No-op, block metadata should have been in-memory
-		case r.retriever != nil:
-			// Try to stream from disk
-			if r.retriever.IsBlockRetrievable(start) {
-				streamedBlock, err := r.retriever.Stream(ctx, r.id, start, onRetrieve)
-				if err != nil {
-					r := block.NewFetchBlockResult(start, nil,
-						fmt.Errorf("unable to retrieve block stream for series %s time %v: %v",
-							r.id.String(), start, err))
-					res = append(res, r)
-				}
-				if streamedBlock.IsNotEmpty() {
-					b := []xio.BlockReader{streamedBlock}
-					r := block.NewFetchBlockResult(start, b, nil)
-					res = append(res, r)
+		if !found {
+			switch {
+			case cachePolicy == CacheAll:
+				// No-op, block metadata should have been in-memory
+			case cachePolicy == CacheAllMetadata:
+				// No-op, block metadata should have been in-memory
+			case r.retriever != nil:
+				// Try to stream from disk
+				if r.retriever.IsBlockRetrievable(start) {
+					streamedBlock, err = r.retriever.Stream(ctx, r.id, start, onRetrieve)
 				}
 			}
 		}
+
+		if err != nil {
+			r := block.NewFetchBlockResult(start, nil,
+				fmt.Errorf("unable to retrieve block stream for series %s time %v: %v",
+					r.id.String(), start, err))
+			res = append(res, r)
+		}
+		if streamedBlock.IsNotEmpty() || len(coldStreams) > 0 {
+			var b []xio.BlockReader
+			if streamedBlock.IsNotEmpty() {
+				b = append(b, streamedBlock)
+			}
+			b = append(b, coldStreams...)
+			r := block.NewFetchBlockResult(start, b, nil)
+			res = append(res, r)
+		}
 	}
 
 	if seriesBuffer != nil && !seriesBuffer.IsEmpty() {
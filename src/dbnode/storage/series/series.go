@@ -716,6 +716,114 @@ func (s *dbSeries) Snapshot(
 	return persistFn(s.id, s.tags, segment, digest.SegmentChecksum(segment))
 }
 
+func (s *dbSeries) OnRepairedBlock(blockStart time.Time, segment ts.Segment) {
+	s.Lock()
+	s.replaceBlockWithLock(blockStart, segment)
+	s.Unlock()
+}
+
+func (s *dbSeries) ColdBlockStarts() []time.Time {
+	s.RLock()
+	starts := s.buffer.ColdBlockStarts()
+	s.RUnlock()
+	return starts
+}
+
+func (s *dbSeries) ColdFlush(
+	ctx context.Context,
+	blockStart time.Time,
+	flushed ts.Segment,
+	persistFn persist.DataFn,
+) (ts.Segment, error) {
+	// Need a write lock because preparing the buffer for a cold flush
+	// seals the cold writes of the block.
+	s.Lock()
+	defer s.Unlock()
+
+	if s.bs != bootstrapped {
+		return ts.Segment{}, errSeriesNotBootstrapped
+	}
+
+	coldStreams := s.buffer.PrepareColdFlush(ctx, blockStart)
+
+	var (
+		blockSize = s.opts.RetentionOptions().BlockSize()
+		bopts     = s.opts.DatabaseBlockOptions()
+		readers   = make([]xio.SegmentReader, 0, len(coldStreams)+1)
+		iter      = s.opts.MultiReaderIteratorPool().Get()
+		encoder   = bopts.EncoderPool().Get()
+	)
+	defer iter.Close()
+
+	// Rank the flushed data before the cold writes so that cold writes
+	// take precedence for datapoints written at the same timestamp.
+	if flushed.Len() > 0 {
+		readers = append(readers, xio.NewSegmentReader(flushed))
+	}
+	for _, stream := range coldStreams {
+		readers = append(readers, stream.SegmentReader)
+	}
+
+	encoder.Reset(blockStart, bopts.DatabaseBlockAllocSize())
+	iter.Reset(readers, blockStart, blockSize)
+	for iter.Next() {
+		dp, unit, annotation := iter.Current()
+		if err := encoder.Encode(dp, unit, annotation); err != nil {
+			encoder.Close()
+			return ts.Segment{}, err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		encoder.Close()
+		return ts.Segment{}, err
+	}
+
+	merged := encoder.Discard()
+	if merged.Len() == 0 {
+		// Nothing to persist, cold writes for the block may have expired
+		merged.Finalize()
+		return ts.Segment{}, nil
+	}
+	err := persistFn(s.id, s.tags, merged, digest.SegmentChecksum(merged))
+	if err != nil {
+		merged.Finalize()
+		return ts.Segment{}, err
+	}
+
+	return merged, nil
+}
+
+func (s *dbSeries) OnColdFlushed(blockStart time.Time, segment ts.Segment) {
+	s.Lock()
+	s.buffer.ColdFlushed(blockStart)
+	if segment.Len() > 0 {
+		s.replaceBlockWithLock(blockStart, segment)
+	}
+	s.Unlock()
+}
+
+func (s *dbSeries) replaceBlockWithLock(blockStart time.Time, segment ts.Segment) {
+	cachePolicy := s.opts.CachePolicy()
+	if existing, ok := s.blocks.BlockAt(blockStart); ok {
+		s.blocks.RemoveBlockAt(blockStart)
+		// See updateBlocksWithLock for why blocks retrieved from disk are
+		// left for the WiredList to close when using the LRU policy.
+		if !(cachePolicy == CacheLRU && existing.WasRetrievedFromDisk()) {
+			existing.Close()
+		}
+	}
+
+	if cachePolicy != CacheAll && s.blockRetriever != nil {
+		// The persisted block will be retrieved from disk when next read.
+		segment.Finalize()
+		return
+	}
+
+	b := s.opts.DatabaseBlockOptions().DatabaseBlockPool().Get()
+	b.Reset(blockStart, s.opts.RetentionOptions().BlockSize(), segment)
+	s.addBlockWithLock(b)
+}
+
 func (s *dbSeries) Close() {
 	s.Lock()
 	defer s.Unlock()
@@ -929,3 +929,116 @@ func TestSeriesCloseCacheLRUPolicy(t *testing.T) {
 	series.blocks = blocks
 	series.Close()
 }
+
+func TestSeriesOnRepairedBlockCacheAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSeriesTestOptions().
+		SetCachePolicy(CacheAll)
+	series := NewDatabaseSeries(ident.StringID("foo"), ident.Tags{}, opts).(*dbSeries)
+
+	start := time.Now().Truncate(opts.RetentionOptions().BlockSize())
+	existing := block.NewMockDatabaseBlock(ctrl)
+	existing.EXPECT().StartTime().Return(start).AnyTimes()
+	existing.EXPECT().Close()
+	series.blocks.AddBlock(existing)
+
+	data := checked.NewBytes([]byte{1, 2, 3}, nil)
+	series.OnRepairedBlock(start, ts.NewSegment(data, nil, ts.FinalizeNone))
+
+	repaired, ok := series.blocks.BlockAt(start)
+	require.True(t, ok)
+	require.NotEqual(t, existing, repaired)
+	require.Equal(t, 3, repaired.Len())
+}
+
+func TestSeriesOnRepairedBlockCacheLRU(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSeriesTestOptions().
+		SetCachePolicy(CacheLRU)
+	retriever := NewMockQueryableBlockRetriever(ctrl)
+	series := NewDatabaseSeries(ident.StringID("foo"), ident.Tags{}, opts).(*dbSeries)
+	series.blockRetriever = retriever
+
+	// A block retrieved from disk is owned by the WiredList and not closed
+	start := time.Now().Truncate(opts.RetentionOptions().BlockSize())
+	existing := block.NewMockDatabaseBlock(ctrl)
+	existing.EXPECT().StartTime().Return(start).AnyTimes()
+	existing.EXPECT().WasRetrievedFromDisk().Return(true)
+	series.blocks.AddBlock(existing)
+
+	data := checked.NewBytes([]byte{1, 2, 3}, nil)
+	series.OnRepairedBlock(start, ts.NewSegment(data, nil, ts.FinalizeNone))
+
+	_, ok := series.blocks.BlockAt(start)
+	require.False(t, ok)
+}
+
+func TestSeriesColdFlush(t *testing.T) {
+	opts := newSeriesTestOptions().
+		SetCachePolicy(CacheAll).
+		SetColdWritesEnabled(true)
+	curr := time.Now().Truncate(opts.RetentionOptions().BlockSize())
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return curr
+	}))
+	series := NewDatabaseSeries(ident.StringID("foo"), ident.Tags{}, opts).(*dbSeries)
+	_, err := series.Bootstrap(nil)
+	require.NoError(t, err)
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	blockStart := curr.Add(-mins(6))
+	flushedValues := []value{
+		{blockStart.Add(secs(10)), 1, xtime.Second, nil},
+		{blockStart.Add(secs(30)), 3, xtime.Second, nil},
+	}
+	coldValues := []value{
+		{blockStart.Add(secs(20)), 2, xtime.Second, nil},
+		{blockStart.Add(secs(30)), 4, xtime.Second, nil},
+	}
+	for _, v := range coldValues {
+		require.NoError(t, series.Write(ctx, v.timestamp, v.value, v.unit, v.annotation))
+	}
+	require.Equal(t, []time.Time{blockStart}, series.ColdBlockStarts())
+
+	encoder := opts.EncoderPool().Get()
+	encoder.Reset(blockStart, 0)
+	for _, v := range flushedValues {
+		dp := ts.Datapoint{Timestamp: v.timestamp, Value: v.value}
+		require.NoError(t, encoder.Encode(dp, v.unit, v.annotation))
+	}
+	flushed := encoder.Discard()
+
+	var persisted ts.Segment
+	persistFn := func(id ident.ID, _ ident.Tags, segment ts.Segment, checksum uint32) error {
+		assert.Equal(t, "foo", id.String())
+		assert.Equal(t, digest.SegmentChecksum(segment), checksum)
+		persisted = segment
+		return nil
+	}
+	merged, err := series.ColdFlush(ctx, blockStart, flushed, persistFn)
+	require.NoError(t, err)
+	require.Equal(t, persisted, merged)
+
+	// Cold writes take precedence over flushed data at the same timestamp
+	expected := []value{flushedValues[0], coldValues[0], coldValues[1]}
+	assertValuesEqual(t, expected, [][]xio.BlockReader{[]xio.BlockReader{
+		xio.BlockReader{SegmentReader: xio.NewSegmentReader(merged)},
+	}}, opts)
+
+	series.OnColdFlushed(blockStart, merged)
+	require.Empty(t, series.ColdBlockStarts())
+
+	block, ok := series.blocks.BlockAt(blockStart)
+	require.True(t, ok)
+	stream, err := block.Stream(ctx)
+	require.NoError(t, err)
+	assertValuesEqual(t, expected, [][]xio.BlockReader{[]xio.BlockReader{
+		stream,
+	}}, opts)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
@@ -98,6 +99,30 @@ type DatabaseSeries interface {
 	// not been rotated into a block yet
 	Snapshot(ctx context.Context, blockStart time.Time, persistFn persist.DataFn) error
 
+	// OnRepairedBlock replaces any in-memory block at the given block start
+	// with the repaired segment that was persisted to disk, the segment is
+	// owned by the series after the call returns
+	OnRepairedBlock(blockStart time.Time, segment ts.Segment)
+
+	// ColdBlockStarts returns the block starts of the blocks that have cold
+	// writes buffered that are yet to be cold flushed
+	ColdBlockStarts() []time.Time
+
+	// ColdFlush merges the cold writes buffered for the given block with the
+	// block's flushed data and persists the merged data, the returned segment
+	// must be passed to OnColdFlushed once the persisted volume is complete
+	ColdFlush(
+		ctx context.Context,
+		blockStart time.Time,
+		flushed ts.Segment,
+		persistFn persist.DataFn,
+	) (ts.Segment, error)
+
+	// OnColdFlushed discards the cold writes persisted by ColdFlush and
+	// replaces any in-memory block at the given block start with the merged
+	// segment, the segment is owned by the series after the call returns
+	OnColdFlushed(blockStart time.Time, segment ts.Segment)
+
 	// Close will close the series and if pooled returned to the pool
 	Close()
 
@@ -226,6 +251,14 @@ type Options interface {
 	// CachePolicy returns the series cache policy
 	CachePolicy() CachePolicy
 
+	// SetColdWritesEnabled sets whether writes older than the buffer past
+	// are accepted and buffered for a cold flush
+	SetColdWritesEnabled(value bool) Options
+
+	// ColdWritesEnabled returns whether writes older than the buffer past
+	// are accepted and buffered for a cold flush
+	ColdWritesEnabled() bool
+
 	// SetContextPool sets the contextPool
 	SetContextPool(value context.Pool) Options
 
@@ -31,6 +31,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/generated/proto/pagetoken"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
@@ -39,9 +40,11 @@ import (
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	m3dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/index/convert"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/dbnode/storage/quota"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/storage/series/lookup"
@@ -72,6 +75,7 @@ var (
 	errShardInvalidPageToken               = errors.New("shard could not unmarshal page token")
 	errNewShardEntryTagsTypeInvalid        = errors.New("new shard entry options error: tags type invalid")
 	errNewShardEntryTagsIterNotAtIndexZero = errors.New("new shard entry options error: tags iter not at index zero")
+	errColdFlushVolumeNotWritten           = errors.New("cold flush did not write a new fileset volume")
 )
 
 type filesetBeforeFn func(
@@ -148,17 +152,25 @@ type dbShard struct {
 	seriesPool               series.DatabaseSeriesPool
 	commitLogWriter          commitLogWriter
 	reverseIndex             namespaceIndex
+	writeQuotas              quota.Tracker
 	insertQueue              *dbShardInsertQueue
 	lookup                   *shardMap
 	list                     *list.List
+	tombstones               *seriesTombstones
+	tombstonesPersistLock    sync.Mutex
+	pendingDeletes           []*lookup.Entry
 	bootstrapState           BootstrapState
 	filesetBeforeFn          filesetBeforeFn
 	deleteFilesFn            deleteFilesFn
 	snapshotFilesFn          snapshotFilesFn
+	dataFilesFn              dataFilesFn
 	sleepFn                  func(time.Duration)
 	identifierPool           ident.Pool
 	contextPool              context.Pool
 	flushState               shardFlushState
+	downsampleState          shardDownsampleState
+	downsamplePersistLock    sync.Mutex
+	fileSetVolumeLock        sync.Mutex
 	snapshotState            shardSnapshotState
 	tickWg                   *sync.WaitGroup
 	runtimeOptsListenClosers []xclose.SimpleCloser
@@ -191,10 +203,13 @@ type dbShardMetrics struct {
 	insertAsyncWriteErrors        tally.Counter
 	seriesBootstrapBlocksToBuffer tally.Counter
 	seriesBootstrapBlocksMerged   tally.Counter
+	downsampleSkipped             tally.Counter
+	downsampleWriteErrors         tally.Counter
 }
 
 func newDatabaseShardMetrics(scope tally.Scope) dbShardMetrics {
 	seriesBootstrapScope := scope.SubScope("series-bootstrap")
+	downsampleScope := scope.SubScope("downsample")
 	return dbShardMetrics{
 		create:       scope.Counter("create"),
 		close:        scope.Counter("close"),
@@ -211,6 +226,8 @@ func newDatabaseShardMetrics(scope tally.Scope) dbShardMetrics {
 		}).Counter("insert-async.errors"),
 		seriesBootstrapBlocksToBuffer: seriesBootstrapScope.Counter("blocks-to-buffer"),
 		seriesBootstrapBlocksMerged:   seriesBootstrapScope.Counter("blocks-merged"),
+		downsampleSkipped:             downsampleScope.Counter("datapoints-skipped"),
+		downsampleWriteErrors:         downsampleScope.Counter("write-errors"),
 	}
 }
 
@@ -231,6 +248,20 @@ func newShardFlushState() shardFlushState {
 	}
 }
 
+// shardDownsampleState is the downsample state of the blocks of a shard
+// along with the fileset volume each downsampled block was read from.
+type shardDownsampleState struct {
+	shardFlushState
+	volumesByTime map[xtime.UnixNano]int
+}
+
+func newShardDownsampleState() shardDownsampleState {
+	return shardDownsampleState{
+		shardFlushState: newShardFlushState(),
+		volumesByTime:   make(map[xtime.UnixNano]int),
+	}
+}
+
 type shardSnapshotState struct {
 	sync.RWMutex
 	isSnapshotting         bool
@@ -245,6 +276,7 @@ func newDatabaseShard(
 	increasingIndex increasingIndex,
 	commitLogWriter commitLogWriter,
 	reverseIndex namespaceIndex,
+	writeQuotas quota.Tracker,
 	needsBootstrap bool,
 	opts Options,
 	seriesOpts series.Options,
@@ -264,15 +296,19 @@ func newDatabaseShard(
 		seriesPool:         opts.DatabaseSeriesPool(),
 		commitLogWriter:    commitLogWriter,
 		reverseIndex:       reverseIndex,
+		writeQuotas:        writeQuotas,
 		lookup:             newShardMap(shardMapOptions{}),
 		list:               list.New(),
+		tombstones:         newSeriesTombstones(),
 		filesetBeforeFn:    fs.DataFileSetsBefore,
 		deleteFilesFn:      fs.DeleteFiles,
 		snapshotFilesFn:    fs.SnapshotFiles,
+		dataFilesFn:        fs.DataFiles,
 		sleepFn:            time.Sleep,
 		identifierPool:     opts.IdentifierPool(),
 		contextPool:        opts.ContextPool(),
 		flushState:         newShardFlushState(),
+		downsampleState:    newShardDownsampleState(),
 		tickWg:             &sync.WaitGroup{},
 		logger:             opts.InstrumentOptions().Logger(),
 		metrics:            newDatabaseShardMetrics(scope),
@@ -554,6 +590,13 @@ func (s *dbShard) Close() error {
 	// any active ticks.
 	s.tickWg.Wait()
 
+	// Persist any deletions not yet persisted by a tick so that they are
+	// not lost when the shard is closed cleanly.
+	if err := s.persistTombstones(); err != nil {
+		s.logger.Errorf("failed to persist tombstones on close: %v", err)
+	}
+	s.closeAnyPendingDeletes()
+
 	// NB(r): Asynchronously we purge expired series to ensure pressure on the
 	// GC is not placed all at one time.  If the deadline is too low and still
 	// causes the GC to impact performance when closing shards the deadline
@@ -576,6 +619,11 @@ func (s *dbShard) isClosingWithLock() bool {
 
 func (s *dbShard) Tick(c context.Cancellable, tickStart time.Time) (tickResult, error) {
 	s.removeAnyFlushStatesTooEarly(tickStart)
+	s.removeAnyTombstonesTooEarly(tickStart)
+	if err := s.persistTombstones(); err != nil {
+		s.logger.Errorf("failed to persist tombstones: %v", err)
+	}
+	s.closeAnyPendingDeletes()
 	return s.tickAndExpire(c, tickPolicyRegular)
 }
 
@@ -738,13 +786,111 @@ func (s *dbShard) purgeExpiredSeries(expiredEntries []*lookup.Entry) {
 		// NB(xichen): if we get here, we are guaranteed that there can be
 		// no more reads/writes to this series while the lock is held, so it's
 		// safe to remove it.
+		s.removeShardEntryWithLock(elem, entry)
 		series.Close()
-		s.list.Remove(elem)
-		s.lookup.Delete(id)
 	}
 	s.Unlock()
 }
 
+func (s *dbShard) DeleteSeries(ids []ident.ID) (int64, error) {
+	var (
+		deleted int64
+		now     = s.nowFn()
+	)
+	s.Lock()
+	for _, id := range ids {
+		// Tombstone the series regardless of whether it is held in memory so
+		// that reads do not fall through to data held in filesets.
+		s.tombstones.Add(id, now)
+
+		elem, exists := s.lookup.Get(id)
+		if !exists {
+			continue
+		}
+
+		entry := elem.Value.(*lookup.Entry)
+		series := entry.Series
+		s.removeShardEntryWithLock(elem, entry)
+		deleted++
+
+		// NB: if the series is currently being read from or written to we
+		// cannot safely close it, it is no longer reachable from the shard
+		// however and is closed by a later tick once the reader or writer
+		// is done.
+		if entry.ReaderWriterCount() == 0 {
+			series.Close()
+			continue
+		}
+		s.pendingDeletes = append(s.pendingDeletes, entry)
+	}
+	s.Unlock()
+
+	// NB: the tombstones are persisted by the next tick rather than on the
+	// delete path, until then the deletion is not durable and the series
+	// may be restored if the node fails before the tick.
+	return deleted, nil
+}
+
+// closeAnyPendingDeletes closes the series deleted while being read from or
+// written to once they are no longer in use.
+func (s *dbShard) closeAnyPendingDeletes() {
+	s.Lock()
+	remaining := s.pendingDeletes[:0]
+	for _, entry := range s.pendingDeletes {
+		// Deleted series are no longer reachable from the shard, the count
+		// can only drop once it has reached zero.
+		if entry.ReaderWriterCount() > 0 {
+			remaining = append(remaining, entry)
+			continue
+		}
+		entry.Series.Close()
+	}
+	for i := len(remaining); i < len(s.pendingDeletes); i++ {
+		s.pendingDeletes[i] = nil
+	}
+	s.pendingDeletes = remaining
+	s.Unlock()
+}
+
+// removeAnyTombstonesTooEarly removes tombstones for deletions which occurred
+// before any fileset that is still retained could have been written.
+func (s *dbShard) removeAnyTombstonesTooEarly(tickStart time.Time) {
+	ropts := s.namespace.Options().RetentionOptions()
+	earliestFlush := retention.FlushTimeStart(ropts, tickStart)
+	s.tombstones.ExpireBefore(earliestFlush.Add(-ropts.BlockSize()))
+}
+
+// persistTombstones persists the tombstones of the shard if they have changed
+// since they were last persisted so that deleted series are not restored when
+// the shard is bootstrapped.
+func (s *dbShard) persistTombstones() error {
+	s.tombstonesPersistLock.Lock()
+	defer s.tombstonesPersistLock.Unlock()
+
+	snapshot, dirty := s.tombstones.SnapshotIfDirty()
+	if !dirty {
+		return nil
+	}
+	fsOpts := s.opts.CommitLogOptions().FilesystemOptions()
+	if err := fs.WriteTombstones(fsOpts, s.namespace.ID(), s.shard, snapshot); err != nil {
+		// Retry on the next tick.
+		s.tombstones.MarkDirty()
+		return err
+	}
+	return nil
+}
+
+// loadTombstones loads the tombstones persisted for the shard.
+func (s *dbShard) loadTombstones() (map[string]fs.Tombstone, error) {
+	fsOpts := s.opts.CommitLogOptions().FilesystemOptions()
+	tombstones, err := fs.ReadTombstones(fsOpts.FilePathPrefix(), s.namespace.ID(), s.shard)
+	if err != nil {
+		return nil, err
+	}
+	s.tombstones.Load(tombstones)
+	return tombstones, nil
+}
+
 func (s *dbShard) WriteTagged(
 	ctx context.Context,
 	id ident.ID,
@@ -780,6 +926,10 @@ func (s *dbShard) writeAndIndex(
 	annotation []byte,
 	shouldReverseIndex bool,
 ) error {
+	// Writes to a deleted series recreate the series, the removal of the
+	// tombstone is persisted by the next tick.
+	s.tombstones.Remove(id)
+
 	// Prepare write
 	entry, opts, err := s.tryRetrieveWritableSeries(id)
 	if err != nil {
@@ -788,6 +938,18 @@ func (s *dbShard) writeAndIndex(
 
 	writable := entry != nil
 
+	// Only tagged writes are subject to write quotas as quotas are keyed by
+	// the value of a tag of the series.
+	if s.writeQuotas != nil && shouldReverseIndex {
+		if err := s.writeQuotas.AdmitWrite(tags, !writable); err != nil {
+			if writable {
+				// release the reference we got on entry from `writableSeries`
+				entry.DecrementReaderWriterCount()
+			}
+			return err
+		}
+	}
+
 	// If no entry and we are not writing new series asynchronously
 	if !writable && !opts.writeNewSeriesAsync {
 		// Avoid double lookup by enqueueing insert immediately
@@ -907,6 +1069,10 @@ func (s *dbShard) ReadEncoded(
 	s.RUnlock()
 
 	if err == errShardEntryNotFound {
+		if s.tombstones.Contains(id) {
+			// Series has been deleted, mask any data still held on disk.
+			return nil, nil
+		}
 		switch s.opts.SeriesCachePolicy() {
 		case series.CacheAll:
 			// No-op, would be in memory if cached
@@ -1199,6 +1365,17 @@ func (s *dbShard) insertNewShardEntryWithLock(entry *lookup.Entry) {
 		NoCopyKey:     true,
 		NoFinalizeKey: true,
 	})
+	if s.writeQuotas != nil {
+		s.writeQuotas.SeriesAdded(entry.Series.Tags())
+	}
+}
+
+func (s *dbShard) removeShardEntryWithLock(elem *list.Element, entry *lookup.Entry) {
+	if s.writeQuotas != nil {
+		s.writeQuotas.SeriesRemoved(entry.Series.Tags())
+	}
+	s.list.Remove(elem)
+	s.lookup.Delete(entry.Series.ID())
 }
 
 func (s *dbShard) insertSeriesBatch(inserts []dbShardInsert) error {
@@ -1363,6 +1540,10 @@ func (s *dbShard) FetchBlocks(
 	s.RUnlock()
 
 	if err == errShardEntryNotFound {
+		if s.tombstones.Contains(id) {
+			// Series has been deleted, mask any data still held on disk.
+			return nil, nil
+		}
 		switch s.opts.SeriesCachePolicy() {
 		case series.CacheAll:
 			// No-op, would be in memory if cached
@@ -1659,6 +1840,14 @@ func (s *dbShard) FetchBlocksMetadataV2(
 					blockStart, err)
 			}
 
+			if s.tombstones.Contains(id) {
+				// Deleted series are excluded from metadata so they are not
+				// streamed to peers or written to index segments on flush.
+				id.Finalize()
+				tags.Close()
+				continue
+			}
+
 			blockResult := s.opts.FetchBlockMetadataResultsPool().Get()
 			value := block.FetchBlockMetadataResult{
 				Start: blockStart,
@@ -1723,9 +1912,24 @@ func (s *dbShard) Bootstrap(
 		shardBootstrapResult = dbShardBootstrapResult{}
 		multiErr             = xerrors.NewMultiError()
 	)
+	// Load the tombstones first so that series deleted before the shard was
+	// last closed are not restored from the bootstrapped data.
+	tombstones, err := s.loadTombstones()
+	if err != nil {
+		multiErr = multiErr.Add(fmt.Errorf("failed to load tombstones: %v", err))
+	}
+	if len(tombstones) > 0 && s.reverseIndex != nil {
+		s.reverseIndex.LoadTombstones(tombstones)
+	}
 	for _, elem := range bootstrappedSeries.Iter() {
 		dbBlocks := elem.Value()
 
+		if s.tombstones.Contains(dbBlocks.ID) {
+			dbBlocks.Tags.Finalize()
+			dbBlocks.Blocks.Close()
+			continue
+		}
+
 		// First lookup if series already exists
 		entry, _, err := s.tryRetrieveWritableSeries(dbBlocks.ID)
 		if err != nil {
@@ -1810,6 +2014,12 @@ func (s *dbShard) Bootstrap(
 		s.markFlushStateSuccess(at)
 	}
 
+	if s.namespace.Options().DownsampleOptions().Enabled() {
+		if err := s.loadDownsampledVolumes(); err != nil {
+			multiErr = multiErr.Add(fmt.Errorf("failed to load downsampled volumes: %v", err))
+		}
+	}
+
 	s.Lock()
 	s.bootstrapState = Bootstrapped
 	s.Unlock()
@@ -1850,6 +2060,9 @@ func (s *dbShard) Flush(
 	flushResult := dbShardFlushResult{}
 	s.forEachShardEntry(func(entry *lookup.Entry) bool {
 		curr := entry.Series
+		if s.tombstones.Contains(curr.ID()) {
+			return true
+		}
 		// Use a temporary context here so the stream readers can be returned to
 		// the pool after we finish fetching flushing the series.
 		tmpCtx.Reset()
@@ -1877,6 +2090,636 @@ func (s *dbShard) Flush(
 	return s.markFlushStateSuccessOrError(blockStart, multiErr.FinalError())
 }
 
+func (s *dbShard) ColdFlush(flush persist.DataFlush) (bool, error) {
+	// We don't flush data when the shard is still bootstrapping
+	s.RLock()
+	if s.bootstrapState != Bootstrapped {
+		s.RUnlock()
+		return false, errShardNotBootstrappedToFlush
+	}
+	s.RUnlock()
+
+	var (
+		pending             bool
+		entriesByBlockStart = make(map[xtime.UnixNano][]*lookup.Entry)
+	)
+	s.forEachShardEntry(func(entry *lookup.Entry) bool {
+		for _, blockStart := range entry.Series.ColdBlockStarts() {
+			// Cold writes can only be merged with blocks that have been flushed,
+			// cold writes for other blocks remain buffered until they are.
+			if s.FlushState(blockStart).Status != fileOpSuccess {
+				pending = true
+				continue
+			}
+			// Hold a reference so the series is not closed during the cold flush
+			entry.IncrementReaderWriterCount()
+			key := xtime.ToUnixNano(blockStart)
+			entriesByBlockStart[key] = append(entriesByBlockStart[key], entry)
+		}
+		return true
+	})
+
+	var multiErr xerrors.MultiError
+	for key, entries := range entriesByBlockStart {
+		blockStart := key.ToTime()
+		err := s.coldFlushBlock(blockStart, entries, flush)
+		for _, entry := range entries {
+			entry.DecrementReaderWriterCount()
+		}
+		if err != nil {
+			// Cold writes remain buffered and will be retried by the next cold flush
+			pending = true
+			multiErr = multiErr.Add(fmt.Errorf(
+				"failed to cold flush block %s: %v", blockStart.String(), err))
+		}
+	}
+
+	return pending, multiErr.FinalError()
+}
+
+type coldFlushedSeries struct {
+	entry   *lookup.Entry
+	segment ts.Segment
+}
+
+// coldFlushBlock merges the cold writes of the given series with the latest
+// volume of the block's fileset and writes the result to a new volume.
+func (s *dbShard) coldFlushBlock(
+	blockStart time.Time,
+	entries []*lookup.Entry,
+	flush persist.DataFlush,
+) error {
+	var (
+		fsOpts   = s.opts.CommitLogOptions().FilesystemOptions()
+		prefix   = fsOpts.FilePathPrefix()
+		nsID     = s.namespace.ID()
+		bySeries = make(map[string]*lookup.Entry, len(entries))
+		tmpCtx   = context.NewContext()
+		// Series IDs and tags must remain valid until the prepared persist
+		// is closed as the index entries are written out on close.
+		finalizers []func()
+		flushed    []coldFlushedSeries
+	)
+	defer func() {
+		for _, fn := range finalizers {
+			fn()
+		}
+		for i := range flushed {
+			flushed[i].segment.Finalize()
+		}
+	}()
+	for _, entry := range entries {
+		bySeries[entry.Series.ID().String()] = entry
+	}
+
+	s.fileSetVolumeLock.Lock()
+	defer s.fileSetVolumeLock.Unlock()
+
+	existing, existingOk, err := fs.FileSetAt(prefix, nsID, s.shard, blockStart)
+	if err != nil {
+		return err
+	}
+
+	prepared, err := flush.PrepareData(persist.DataPrepareOptions{
+		NamespaceMetadata: s.namespace,
+		Shard:             s.ID(),
+		BlockStart:        blockStart,
+		FileSetType:       persist.FileSetFlushType,
+		ColdFlush:         true,
+	})
+	if err != nil {
+		return err
+	}
+
+	coldFlush := func(entry *lookup.Entry, data ts.Segment) error {
+		// Use a temporary context here so the stream readers can be returned to
+		// the pool after we finish merging the series.
+		tmpCtx.Reset()
+		merged, err := entry.Series.ColdFlush(tmpCtx, blockStart, data, prepared.Persist)
+		tmpCtx.BlockingClose()
+		if err != nil {
+			return err
+		}
+		flushed = append(flushed, coldFlushedSeries{entry: entry, segment: merged})
+		return nil
+	}
+
+	var multiErr xerrors.MultiError
+	if existingOk {
+		multiErr = multiErr.Add(s.coldFlushExisting(existing, bySeries,
+			prepared.Persist, coldFlush, &finalizers))
+	}
+	if multiErr.Empty() {
+		// Write out the series that have no data in the existing volume
+		for _, entry := range bySeries {
+			if err := coldFlush(entry, ts.Segment{}); err != nil {
+				multiErr = multiErr.Add(err)
+				break
+			}
+		}
+	}
+
+	if err := prepared.Close(); err != nil {
+		multiErr = multiErr.Add(err)
+	}
+
+	// The newly written volume is now the latest complete volume unless
+	// closing it failed.
+	written, writtenOk, err := fs.FileSetAt(prefix, nsID, s.shard, blockStart)
+	if err != nil {
+		return multiErr.Add(err).FinalError()
+	}
+	isNewVolume := writtenOk &&
+		(!existingOk || written.ID.VolumeIndex > existing.ID.VolumeIndex)
+
+	if !multiErr.Empty() {
+		if isNewVolume {
+			// Remove the incomplete volume so that reads continue to be
+			// served from the existing volume.
+			multiErr = multiErr.Add(fs.DeleteFiles(written.AbsoluteFilepaths))
+		}
+		return multiErr.FinalError()
+	}
+	if !isNewVolume {
+		return errColdFlushVolumeNotWritten
+	}
+
+	// The new volume is complete, have the shard start serving reads from
+	// the new volume. The volumes it supersedes are deleted by the cleanup
+	// manager once they are no longer read from.
+	if s.DatabaseBlockRetriever != nil {
+		if err := s.DatabaseBlockRetriever.ReloadFileSet(s.shard, blockStart); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range flushed {
+		f.entry.Series.OnColdFlushed(blockStart, f.segment)
+	}
+
+	// Downsample the block again to include the cold writes
+	s.downsampleState.Lock()
+	delete(s.downsampleState.statesByTime, xtime.ToUnixNano(blockStart))
+	delete(s.downsampleState.volumesByTime, xtime.ToUnixNano(blockStart))
+	s.downsampleState.Unlock()
+	numSeries := len(flushed)
+	flushed = nil
+
+	s.logger.WithFields(
+		xlog.NewField("namespace", nsID.String()),
+		xlog.NewField("shard", s.ID()),
+		xlog.NewField("blockStart", blockStart.String()),
+		xlog.NewField("volumeIndex", written.ID.VolumeIndex),
+		xlog.NewField("numSeries", numSeries),
+	).Info("cold flushed block")
+
+	return nil
+}
+
+// coldFlushExisting writes the contents of an existing fileset volume to a
+// cold flush, merging the data of series that have cold writes.
+func (s *dbShard) coldFlushExisting(
+	existing fs.FileSetFile,
+	bySeries map[string]*lookup.Entry,
+	persistFn persist.DataFn,
+	coldFlush func(entry *lookup.Entry, data ts.Segment) error,
+	finalizers *[]func(),
+) error {
+	fsOpts := s.opts.CommitLogOptions().FilesystemOptions()
+	reader, err := fs.NewReader(s.opts.BytesPool(), fsOpts)
+	if err != nil {
+		return err
+	}
+	err = reader.Open(fs.DataReaderOpenOptions{
+		Identifier: existing.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	var multiErr xerrors.MultiError
+	for i := 0; i < reader.Entries(); i++ {
+		id, tagsIter, data, checksum, err := reader.Read()
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			break
+		}
+		*finalizers = append(*finalizers, id.Finalize)
+
+		segment := ts.NewSegment(data, nil, ts.FinalizeHead)
+		if s.tombstones.Contains(id) {
+			// Deleted series are dropped from the new volume.
+			tagsIter.Close()
+			segment.Finalize()
+			continue
+		}
+		if entry, ok := bySeries[id.String()]; ok {
+			delete(bySeries, id.String())
+			err = coldFlush(entry, segment)
+		} else {
+			// No cold writes for the series, write through as is
+			var tags ident.Tags
+			tags, err = convert.TagsFromTagsIter(id, tagsIter, s.identifierPool)
+			if err == nil {
+				*finalizers = append(*finalizers, tags.Finalize)
+				err = persistFn(id, tags, segment, checksum)
+			}
+		}
+		tagsIter.Close()
+		segment.Finalize()
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			break
+		}
+	}
+
+	if err := reader.Close(); err != nil {
+		multiErr = multiErr.Add(err)
+	}
+	return multiErr.FinalError()
+}
+
+func (s *dbShard) Downsample(blockStart time.Time, target databaseNamespace) error {
+	// We don't downsample data when the shard is still bootstrapping
+	s.RLock()
+	if s.bootstrapState != Bootstrapped {
+		s.RUnlock()
+		return errShardNotBootstrappedToFlush
+	}
+	s.RUnlock()
+
+	return s.markDownsampleStateSuccessOrError(blockStart,
+		s.downsampleBlock(blockStart, target))
+}
+
+// downsampleBlock reads the latest volume of the block's fileset and writes
+// the downsampled datapoints of each of its series to the target namespace.
+func (s *dbShard) downsampleBlock(blockStart time.Time, target databaseNamespace) error {
+	var (
+		dopts      = s.namespace.Options().DownsampleOptions()
+		blockSize  = s.namespace.Options().RetentionOptions().BlockSize()
+		tagged     = target.Options().IndexOptions().Enabled()
+		numSeries  int
+		numSkipped int
+	)
+
+	reader, fileSet, ok, err := s.openLatestVolume(blockStart)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// Nothing was flushed for the block
+		return nil
+	}
+
+	iter, err := newEncodingSchemeIterator(s.opts,
+		s.namespace.Options().EncodingScheme(), reader.EncodingScheme())
+	if err != nil {
+		reader.Close()
+		return err
+	}
+	defer iter.Close()
+
+	ctx := s.contextPool.Get()
+
+	var multiErr xerrors.MultiError
+	for i := 0; i < reader.Entries(); i++ {
+		id, tagsIter, data, _, err := reader.Read()
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			break
+		}
+
+		segment := ts.NewSegment(data, nil, ts.FinalizeHead)
+		iter.Reset([]xio.SegmentReader{xio.NewSegmentReader(segment)},
+			blockStart, blockSize)
+		err = downsample(iter, dopts.Resolution(), dopts.Aggregation(),
+			func(dp ts.Datapoint, unit xtime.Unit) error {
+				var err error
+				if tagged {
+					err = target.WriteTagged(ctx, id, tagsIter,
+						dp.Timestamp, dp.Value, unit, nil)
+				} else {
+					err = target.Write(ctx, id, dp.Timestamp, dp.Value, unit, nil)
+				}
+				switch err {
+				case nil:
+					return nil
+				case m3dberrors.ErrTooPast, m3dberrors.ErrTooFuture:
+					// Datapoints outside of the target namespace's retention
+					numSkipped++
+					s.metrics.downsampleSkipped.Inc(1)
+					return nil
+				}
+				s.metrics.downsampleWriteErrors.Inc(1)
+				s.logger.WithFields(
+					xlog.NewField("namespace", s.namespace.ID().String()),
+					xlog.NewField("shard", s.ID()),
+					xlog.NewField("targetNamespace", target.ID().String()),
+					xlog.NewField("id", id.String()),
+					xlog.NewField("err", err.Error()),
+				).Errorf("unable to write downsampled datapoint")
+				return err
+			})
+		segment.Finalize()
+		tagsIter.Close()
+		id.Finalize()
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			break
+		}
+		numSeries++
+	}
+
+	ctx.BlockingClose()
+	if err := reader.Close(); err != nil {
+		multiErr = multiErr.Add(err)
+	}
+	if err := multiErr.FinalError(); err != nil {
+		return err
+	}
+
+	s.logger.WithFields(
+		xlog.NewField("namespace", s.namespace.ID().String()),
+		xlog.NewField("shard", s.ID()),
+		xlog.NewField("blockStart", blockStart.String()),
+		xlog.NewField("targetNamespace", target.ID().String()),
+		xlog.NewField("numSeries", numSeries),
+		xlog.NewField("numSkipped", numSkipped),
+	).Info("downsampled block")
+
+	return s.persistDownsampledVolume(blockStart, fileSet.ID.VolumeIndex)
+}
+
+// openLatestVolume opens a reader for the latest volume of the block's fileset,
+// it returns false if there is no complete volume for the block.
+func (s *dbShard) openLatestVolume(
+	blockStart time.Time,
+) (fs.DataFileSetReader, fs.FileSetFile, bool, error) {
+	// Hold the lock while selecting and opening the volume so that it is not
+	// superseded by a volume being written concurrently.
+	s.fileSetVolumeLock.Lock()
+	defer s.fileSetVolumeLock.Unlock()
+
+	fsOpts := s.opts.CommitLogOptions().FilesystemOptions()
+	fileSet, ok, err := fs.FileSetAt(fsOpts.FilePathPrefix(), s.namespace.ID(),
+		s.shard, blockStart)
+	if err != nil || !ok {
+		return nil, fs.FileSetFile{}, false, err
+	}
+
+	reader, err := fs.NewReader(s.opts.BytesPool(), fsOpts)
+	if err != nil {
+		return nil, fs.FileSetFile{}, false, err
+	}
+	err = reader.Open(fs.DataReaderOpenOptions{
+		Identifier: fileSet.ID,
+	})
+	if err != nil {
+		return nil, fs.FileSetFile{}, false, err
+	}
+	return reader, fileSet, true, nil
+}
+
+// persistDownsampledVolume records the volume a block was downsampled from so
+// that the block is not downsampled again after a restart unless it has been
+// rewritten to a new volume since.
+func (s *dbShard) persistDownsampledVolume(blockStart time.Time, volumeIndex int) error {
+	s.downsamplePersistLock.Lock()
+	defer s.downsamplePersistLock.Unlock()
+
+	s.downsampleState.Lock()
+	s.downsampleState.volumesByTime[xtime.ToUnixNano(blockStart)] = volumeIndex
+	volumes := make(map[xtime.UnixNano]int, len(s.downsampleState.volumesByTime))
+	for t, volumeIndex := range s.downsampleState.volumesByTime {
+		volumes[t] = volumeIndex
+	}
+	s.downsampleState.Unlock()
+
+	fsOpts := s.opts.CommitLogOptions().FilesystemOptions()
+	return fs.WriteDownsampledVolumes(fsOpts, s.namespace.ID(), s.shard, volumes)
+}
+
+// loadDownsampledVolumes marks the blocks whose latest volume has already
+// been downsampled as downsampled.
+func (s *dbShard) loadDownsampledVolumes() error {
+	fsOpts := s.opts.CommitLogOptions().FilesystemOptions()
+	volumes, err := fs.ReadDownsampledVolumes(fsOpts.FilePathPrefix(), s.namespace.ID(), s.shard)
+	if err != nil {
+		return err
+	}
+
+	var multiErr xerrors.MultiError
+	for t, volumeIndex := range volumes {
+		fileSet, ok, err := fs.FileSetAt(fsOpts.FilePathPrefix(), s.namespace.ID(),
+			s.shard, t.ToTime())
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		if !ok || fileSet.ID.VolumeIndex != volumeIndex {
+			// The block has been removed or rewritten since it was downsampled
+			continue
+		}
+		s.downsampleState.Lock()
+		s.downsampleState.statesByTime[t] = fileOpState{Status: fileOpSuccess}
+		s.downsampleState.volumesByTime[t] = volumeIndex
+		s.downsampleState.Unlock()
+	}
+	return multiErr.FinalError()
+}
+
+// newEncodingSchemeIterator returns a multi reader iterator for data encoded
+// with an encoding scheme, the iterator is taken from the pool of the options
+// if the pools were created for the scheme and is unpooled otherwise.
+func newEncodingSchemeIterator(
+	opts Options,
+	poolScheme string,
+	scheme string,
+) (encoding.MultiReaderIterator, error) {
+	blockOpts := opts.DatabaseBlockOptions()
+	codec, err := encodingSchemeCodec(blockOpts, poolScheme, scheme)
+	if err != nil {
+		return nil, err
+	}
+	if codec == nil {
+		return opts.MultiReaderIteratorPool().Get(), nil
+	}
+	alloc := encoding.NewReaderIteratorAllocate(codec, encodingSchemeOptions(blockOpts))
+	return encoding.NewMultiReaderIterator(alloc, nil), nil
+}
+
+// newEncodingSchemeEncoder returns an encoder for an encoding scheme, the
+// encoder is taken from the pool of the options if the pools were created
+// for the scheme and is unpooled otherwise.
+func newEncodingSchemeEncoder(
+	opts Options,
+	poolScheme string,
+	scheme string,
+) (encoding.Encoder, error) {
+	blockOpts := opts.DatabaseBlockOptions()
+	codec, err := encodingSchemeCodec(blockOpts, poolScheme, scheme)
+	if err != nil {
+		return nil, err
+	}
+	if codec == nil {
+		return opts.EncoderPool().Get(), nil
+	}
+	return codec.NewEncoder(timeZero, nil, encodingSchemeOptions(blockOpts)), nil
+}
+
+// encodingSchemeCodec returns the codec for the scheme or nil if the scheme
+// is the one the pools were created for.
+func encodingSchemeCodec(
+	opts block.Options,
+	poolScheme string,
+	scheme string,
+) (encoding.Codec, error) {
+	registry := opts.CodecRegistry()
+	poolCodec, err := registry.Codec(poolScheme)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := registry.Codec(scheme)
+	if err != nil {
+		return nil, err
+	}
+	if codec.Scheme() == poolCodec.Scheme() {
+		return nil, nil
+	}
+	return codec, nil
+}
+
+func encodingSchemeOptions(opts block.Options) encoding.Options {
+	return encoding.NewOptions().
+		SetBytesPool(opts.BytesPool()).
+		SetSegmentReaderPool(opts.SegmentReaderPool())
+}
+
+func (s *dbShard) PurgeTombstones(flush persist.DataFlush) error {
+	// We don't flush data when the shard is still bootstrapping
+	s.RLock()
+	if s.bootstrapState != Bootstrapped {
+		s.RUnlock()
+		return errShardNotBootstrappedToFlush
+	}
+	s.RUnlock()
+
+	unpurged := s.tombstones.Unpurged()
+	if len(unpurged) == 0 {
+		return nil
+	}
+
+	fsOpts := s.opts.CommitLogOptions().FilesystemOptions()
+	filesets, err := fs.DataFiles(fsOpts.FilePathPrefix(), s.namespace.ID(), s.shard)
+	if err != nil {
+		return err
+	}
+
+	blockStarts := make(map[xtime.UnixNano]struct{}, len(filesets))
+	for _, fileset := range filesets {
+		blockStarts[xtime.ToUnixNano(fileset.ID.BlockStart)] = struct{}{}
+	}
+
+	var multiErr xerrors.MultiError
+	for key := range blockStarts {
+		blockStart := key.ToTime()
+		latest, ok := filesets.LatestVolumeForBlock(blockStart)
+		if !ok {
+			continue
+		}
+		contains, err := s.fileSetContainsAny(latest, unpurged)
+		if err != nil {
+			multiErr = multiErr.Add(fmt.Errorf(
+				"failed to read block %s: %v", blockStart.String(), err))
+			continue
+		}
+		if !contains {
+			continue
+		}
+		// Rewriting the block without any cold writes writes the latest volume
+		// to a new volume without the deleted series.
+		if err := s.coldFlushBlock(blockStart, nil, flush); err != nil {
+			multiErr = multiErr.Add(fmt.Errorf(
+				"failed to purge deleted series from block %s: %v", blockStart.String(), err))
+		}
+	}
+	if !multiErr.Empty() {
+		// The tombstones remain unpurged and are retried by the next flush.
+		return multiErr.FinalError()
+	}
+
+	s.tombstones.MarkPurged(unpurged)
+	return s.persistTombstones()
+}
+
+// fileSetContainsAny returns whether the fileset volume contains any of the
+// series with the given IDs.
+func (s *dbShard) fileSetContainsAny(
+	fileset fs.FileSetFile,
+	ids map[string]fs.Tombstone,
+) (bool, error) {
+	fsOpts := s.opts.CommitLogOptions().FilesystemOptions()
+	reader, err := fs.NewReader(s.opts.BytesPool(), fsOpts)
+	if err != nil {
+		return false, err
+	}
+	err = reader.Open(fs.DataReaderOpenOptions{
+		Identifier: fileset.ID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	var (
+		contains bool
+		multiErr xerrors.MultiError
+	)
+	for i := 0; i < reader.Entries(); i++ {
+		id, tagsIter, _, _, err := reader.ReadMetadata()
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			break
+		}
+		_, contains = ids[id.String()]
+		id.Finalize()
+		tagsIter.Close()
+		if contains {
+			break
+		}
+	}
+
+	if err := reader.Close(); err != nil {
+		multiErr = multiErr.Add(err)
+	}
+	return contains, multiErr.FinalError()
+}
+
+func (s *dbShard) ReplayColdWrite(
+	ctx context.Context,
+	id ident.ID,
+	timestamp time.Time,
+	value float64,
+	unit xtime.Unit,
+	annotation []byte,
+) error {
+	// Writes to series that have been deleted since were made before the
+	// deletion as writes after it remove the tombstone.
+	if s.tombstones.Contains(id) {
+		return nil
+	}
+
+	entry, err := s.writableSeries(id, ident.EmptyTagIterator)
+	if err != nil {
+		return err
+	}
+
+	err = entry.Series.Write(ctx, timestamp, value, unit, annotation)
+	// release the reference we got on entry from `writableSeries`
+	entry.DecrementReaderWriterCount()
+	return err
+}
+
 func (s *dbShard) Snapshot(
 	blockStart time.Time,
 	snapshotTime time.Time,
@@ -1980,6 +2823,31 @@ func (s *dbShard) markFlushStateFail(blockStart time.Time) {
 	s.flushState.Unlock()
 }
 
+func (s *dbShard) DownsampleState(blockStart time.Time) fileOpState {
+	s.downsampleState.RLock()
+	state, ok := s.downsampleState.statesByTime[xtime.ToUnixNano(blockStart)]
+	s.downsampleState.RUnlock()
+	if !ok {
+		return fileOpState{Status: fileOpNotStarted}
+	}
+	return state
+}
+
+func (s *dbShard) markDownsampleStateSuccessOrError(blockStart time.Time, err error) error {
+	key := xtime.ToUnixNano(blockStart)
+	s.downsampleState.Lock()
+	state := s.downsampleState.statesByTime[key]
+	if err == nil {
+		state = fileOpState{Status: fileOpSuccess}
+	} else {
+		state.Status = fileOpFailed
+		state.NumFailures++
+	}
+	s.downsampleState.statesByTime[key] = state
+	s.downsampleState.Unlock()
+	return err
+}
+
 func (s *dbShard) removeAnyFlushStatesTooEarly(tickStart time.Time) {
 	s.flushState.Lock()
 	earliestFlush := retention.FlushTimeStart(s.namespace.Options().RetentionOptions(), tickStart)
@@ -1989,6 +2857,19 @@ func (s *dbShard) removeAnyFlushStatesTooEarly(tickStart time.Time) {
 		}
 	}
 	s.flushState.Unlock()
+
+	s.downsampleState.Lock()
+	for t := range s.downsampleState.statesByTime {
+		if t.ToTime().Before(earliestFlush) {
+			delete(s.downsampleState.statesByTime, t)
+		}
+	}
+	for t := range s.downsampleState.volumesByTime {
+		if t.ToTime().Before(earliestFlush) {
+			delete(s.downsampleState.volumesByTime, t)
+		}
+	}
+	s.downsampleState.Unlock()
 }
 
 func (s *dbShard) SnapshotState() (bool, time.Time) {
@@ -2072,7 +2953,8 @@ func (s *dbShard) CleanupSnapshots(earliestToRetain time.Time) error {
 }
 
 func (s *dbShard) CleanupExpiredFileSets(earliestToRetain time.Time) error {
-	filePathPrefix := s.opts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
+	fsOpts := s.opts.CommitLogOptions().FilesystemOptions()
+	filePathPrefix := fsOpts.FilePathPrefix()
 	multiErr := xerrors.NewMultiError()
 	expired, err := s.filesetBeforeFn(filePathPrefix, s.namespace.ID(), s.ID(), earliestToRetain)
 	if err != nil {
@@ -2084,6 +2966,54 @@ func (s *dbShard) CleanupExpiredFileSets(earliestToRetain time.Time) error {
 	if err := s.deleteFilesFn(expired); err != nil {
 		multiErr = multiErr.Add(err)
 	}
+	if backend := fsOpts.FileSetBackend(); backend != nil {
+		// The data files of expired filesets may have been tiered
+		if err := fs.DeleteTieredDataFiles(filePathPrefix, backend, expired); err != nil {
+			multiErr = multiErr.Add(err)
+		}
+	}
+	return multiErr.FinalError()
+}
+
+func (s *dbShard) CleanupSupersededFileSets() error {
+	fsOpts := s.opts.CommitLogOptions().FilesystemOptions()
+	filePathPrefix := fsOpts.FilePathPrefix()
+	filesets, err := s.dataFilesFn(filePathPrefix, s.namespace.ID(), s.ID())
+	if err != nil {
+		return fmt.Errorf("encountered errors when getting fileset files for prefix %s namespace %s shard %d: %v",
+			filePathPrefix, s.namespace.ID(), s.ID(), err)
+	}
+
+	// Find the latest complete volume of each block, which is the volume
+	// that reads are served from
+	latestVolumes := make(map[xtime.UnixNano]int)
+	for _, fileset := range filesets {
+		if !fileset.HasCheckpointFile() {
+			continue
+		}
+		blockStart := xtime.ToUnixNano(fileset.ID.BlockStart)
+		if volume, ok := latestVolumes[blockStart]; !ok || fileset.ID.VolumeIndex > volume {
+			latestVolumes[blockStart] = fileset.ID.VolumeIndex
+		}
+	}
+
+	multiErr := xerrors.NewMultiError()
+	for blockStart, volumeIndex := range latestVolumes {
+		if volumeIndex == 0 {
+			// No volume precedes the latest volume
+			continue
+		}
+		start := blockStart.ToTime()
+		if s.DatabaseBlockRetriever != nil &&
+			s.DatabaseBlockRetriever.IsSupersededFileSetInUse(s.shard, start, volumeIndex) {
+			// Seekers may still read from the superseded volumes, they are
+			// deleted by a later cleanup once the seekers are closed
+			continue
+		}
+		err := fs.DeleteSupersededFileSetsAt(filePathPrefix, fsOpts.FileSetBackend(),
+			s.namespace.ID(), s.shard, start, volumeIndex)
+		multiErr = multiErr.Add(err)
+	}
 	return multiErr.FinalError()
 }
 
@@ -2092,7 +3022,52 @@ func (s *dbShard) Repair(
 	tr xtime.Range,
 	repairer databaseShardRepairer,
 ) (repair.MetadataComparisonResult, error) {
-	return repairer.Repair(ctx, s.namespace.ID(), tr, s)
+	return repairer.Repair(ctx, s.namespace, tr, s)
+}
+
+// WithFileSetVolumeLock calls fn while holding the lock that serializes writing
+// new volumes of a block's fileset, by cold flushes and repairs, and reading the
+// latest volume of a block to downsample it.
+func (s *dbShard) WithFileSetVolumeLock(fn func() error) error {
+	s.fileSetVolumeLock.Lock()
+	defer s.fileSetVolumeLock.Unlock()
+	return fn()
+}
+
+func (s *dbShard) OnRepairedBlocks(
+	blockStart time.Time,
+	blocks []repairedSeriesBlock,
+) error {
+	if s.DatabaseBlockRetriever != nil {
+		err := s.DatabaseBlockRetriever.ReloadFileSet(s.shard, blockStart)
+		if err != nil {
+			for i := range blocks {
+				blocks[i].segment.Finalize()
+			}
+			return err
+		}
+	}
+
+	for _, b := range blocks {
+		s.RLock()
+		entry, _, err := s.lookupEntryWithLock(b.id)
+		if entry != nil {
+			entry.IncrementReaderWriterCount()
+		}
+		s.RUnlock()
+
+		if err != nil {
+			// Series is not held in memory, reads of the block will be
+			// served from the new fileset volume.
+			b.segment.Finalize()
+			continue
+		}
+
+		entry.Series.OnRepairedBlock(blockStart, b.segment)
+		entry.DecrementReaderWriterCount()
+	}
+
+	return nil
 }
 
 func (s *dbShard) BootstrapState() BootstrapState {
@@ -23,6 +23,8 @@ package storage
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -30,18 +32,23 @@ import (
 	"time"
 	"unsafe"
 
+	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	m3dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
+	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/dbnode/storage/quota"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/storage/series/lookup"
 	"github.com/m3db/m3/src/dbnode/ts"
 	xmetrics "github.com/m3db/m3/src/dbnode/x/metrics"
 	"github.com/m3db/m3/src/dbnode/x/xio"
+	m3ninxidx "github.com/m3db/m3/src/m3ninx/idx"
 	"github.com/m3db/m3x/checked"
 	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
@@ -77,7 +84,7 @@ func testDatabaseShardWithIndexFn(
 	nsReaderMgr := newNamespaceReaderManager(metadata, tally.NoopScope, opts)
 	seriesOpts := NewSeriesOptionsFromOptions(opts, defaultTestNs1Opts.RetentionOptions())
 	return newDatabaseShard(metadata, 0, nil, nsReaderMgr,
-		&testIncreasingIndex{}, commitLogWriteNoOp, idx, true, opts, seriesOpts).(*dbShard)
+		&testIncreasingIndex{}, commitLogWriteNoOp, idx, nil, true, opts, seriesOpts).(*dbShard)
 }
 
 func addMockSeries(ctrl *gomock.Controller, shard *dbShard, id ident.ID, tags ident.Tags, index uint64) *series.MockDatabaseSeries {
@@ -97,7 +104,7 @@ func TestShardDontNeedBootstrap(t *testing.T) {
 	defer closer()
 	seriesOpts := NewSeriesOptionsFromOptions(opts, testNs.Options().RetentionOptions())
 	shard := newDatabaseShard(testNs.metadata, 0, nil, nil,
-		&testIncreasingIndex{}, commitLogWriteNoOp, nil, false, opts, seriesOpts).(*dbShard)
+		&testIncreasingIndex{}, commitLogWriteNoOp, nil, nil, false, opts, seriesOpts).(*dbShard)
 	defer shard.Close()
 
 	require.Equal(t, Bootstrapped, shard.bootstrapState)
@@ -110,7 +117,7 @@ func TestShardBootstrapState(t *testing.T) {
 	defer closer()
 	seriesOpts := NewSeriesOptionsFromOptions(opts, testNs.Options().RetentionOptions())
 	shard := newDatabaseShard(testNs.metadata, 0, nil, nil,
-		&testIncreasingIndex{}, commitLogWriteNoOp, nil, false, opts, seriesOpts).(*dbShard)
+		&testIncreasingIndex{}, commitLogWriteNoOp, nil, nil, false, opts, seriesOpts).(*dbShard)
 	defer shard.Close()
 
 	require.Equal(t, Bootstrapped, shard.bootstrapState)
@@ -861,6 +868,424 @@ func TestShardFetchBlocksIDExists(t *testing.T) {
 	require.Equal(t, expected, res)
 }
 
+// testDatabaseOptionsWithTempDir returns test options persisting files to a
+// new temporary directory.
+func testDatabaseOptionsWithTempDir(t *testing.T) (Options, string) {
+	dir, err := ioutil.TempDir("", "testdir")
+	require.NoError(t, err)
+
+	opts := testDatabaseOptions()
+	fsOpts := opts.CommitLogOptions().FilesystemOptions().SetFilePathPrefix(dir)
+	opts = opts.SetCommitLogOptions(opts.CommitLogOptions().SetFilesystemOptions(fsOpts))
+	return opts, dir
+}
+
+func TestShardDeleteSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts, dir := testDatabaseOptionsWithTempDir(t)
+	defer os.RemoveAll(dir)
+	opts = opts.SetSeriesCachePolicy(series.CacheRecentlyRead)
+	ctx := opts.ContextPool().Get()
+	defer ctx.Close()
+
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+
+	// No expectations are set on the retriever, reads for deleted series
+	// must not fall through to data held on disk.
+	shard.setBlockRetriever(block.NewMockDatabaseBlockRetriever(ctrl))
+
+	addTestSeries(shard, ident.StringID("foo"))
+	addTestSeries(shard, ident.StringID("bar"))
+
+	deleted, err := shard.DeleteSeries([]ident.ID{
+		ident.StringID("foo"),
+		ident.StringID("baz"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+	require.Equal(t, int64(1), shard.NumSeries())
+
+	// The deletions are persisted by the next tick.
+	persisted, err := fs.ReadTombstones(dir, shard.namespace.ID(), shard.ID())
+	require.NoError(t, err)
+	require.Empty(t, persisted)
+
+	nowFn := opts.ClockOptions().NowFn()
+	_, err = shard.Tick(context.NewNoOpCanncellable(), nowFn())
+	require.NoError(t, err)
+	persisted, err = fs.ReadTombstones(dir, shard.namespace.ID(), shard.ID())
+	require.NoError(t, err)
+	require.Equal(t, 2, len(persisted))
+
+	now := time.Now()
+	encoded, err := shard.ReadEncoded(ctx, ident.StringID("foo"), now.Add(-time.Hour), now)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(encoded))
+
+	fetched, err := shard.FetchBlocks(ctx, ident.StringID("baz"), []time.Time{now})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(fetched))
+
+	// Writing to a deleted series recreates it.
+	require.NoError(t, shard.Write(ctx, ident.StringID("foo"), nowFn(), 1.0, xtime.Second, nil))
+	require.False(t, shard.tombstones.Contains(ident.StringID("foo")))
+	require.True(t, shard.tombstones.Contains(ident.StringID("baz")))
+
+	_, err = shard.Tick(context.NewNoOpCanncellable(), nowFn())
+	require.NoError(t, err)
+	persisted, err = fs.ReadTombstones(dir, shard.namespace.ID(), shard.ID())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(persisted))
+	require.Contains(t, persisted, "baz")
+}
+
+func TestShardDeleteSeriesInUseClosedByTick(t *testing.T) {
+	opts := testDatabaseOptions()
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+
+	addTestSeries(shard, ident.StringID("foo"))
+	elem, exists := shard.lookup.Get(ident.StringID("foo"))
+	require.True(t, exists)
+	entry := elem.Value.(*lookup.Entry)
+
+	// A series held by a reader or writer is only closed once released.
+	entry.IncrementReaderWriterCount()
+	deleted, err := shard.DeleteSeries([]ident.ID{ident.StringID("foo")})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+	require.Equal(t, 1, len(shard.pendingDeletes))
+
+	shard.closeAnyPendingDeletes()
+	require.Equal(t, 1, len(shard.pendingDeletes))
+
+	entry.DecrementReaderWriterCount()
+	_, err = shard.Tick(context.NewNoOpCanncellable(), time.Now())
+	require.NoError(t, err)
+	require.Empty(t, shard.pendingDeletes)
+}
+
+func TestShardBootstrapSkipsDeletedSeries(t *testing.T) {
+	opts, dir := testDatabaseOptionsWithTempDir(t)
+	defer os.RemoveAll(dir)
+
+	shard := testDatabaseShard(t, opts)
+	shard.bootstrapState = Bootstrapped
+	_, err := shard.DeleteSeries([]ident.ID{ident.StringID("foo")})
+	require.NoError(t, err)
+	shard.Close()
+
+	// A new shard restores the tombstones and drops the deleted series from
+	// the bootstrapped data.
+	shard = testDatabaseShard(t, opts)
+	defer shard.Close()
+
+	blockOpts := opts.DatabaseBlockOptions()
+	bootstrappedSeries := result.NewMap(result.MapOptions{})
+	for _, id := range []string{"foo", "bar"} {
+		blocks := block.NewDatabaseSeriesBlocks(0)
+		blocks.AddBlock(block.NewDatabaseBlock(time.Now().Truncate(time.Hour), time.Hour,
+			ts.Segment{}, blockOpts))
+		bootstrappedSeries.Set(ident.StringID(id), result.DatabaseSeriesBlocks{
+			ID:     ident.StringID(id),
+			Blocks: blocks,
+		})
+	}
+	require.NoError(t, shard.Bootstrap(bootstrappedSeries))
+
+	require.Equal(t, int64(1), shard.NumSeries())
+	_, exists := shard.lookup.Get(ident.StringID("bar"))
+	require.True(t, exists)
+	require.True(t, shard.tombstones.Contains(ident.StringID("foo")))
+}
+
+func TestShardPurgeTombstones(t *testing.T) {
+	opts, dir := testDatabaseOptionsWithTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		fsOpts     = opts.CommitLogOptions().FilesystemOptions()
+		blockSize  = defaultTestNs1Opts.RetentionOptions().BlockSize()
+		blockStart = time.Now().Truncate(blockSize).Add(-2 * blockSize)
+	)
+
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+	shard.bootstrapState = Bootstrapped
+
+	// Write a volume holding a series that is then deleted.
+	writer, err := fs.NewWriter(fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, writer.Open(fs.DataWriterOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  shard.namespace.ID(),
+			Shard:      shard.ID(),
+			BlockStart: blockStart,
+		},
+		BlockSize: blockSize,
+	}))
+	for _, id := range []string{"foo", "bar"} {
+		data := checked.NewBytes([]byte(id), nil)
+		data.IncRef()
+		require.NoError(t, writer.Write(ident.StringID(id), ident.Tags{}, data,
+			digest.Checksum([]byte(id))))
+	}
+	require.NoError(t, writer.Close())
+
+	_, err = shard.DeleteSeries([]ident.ID{ident.StringID("foo")})
+	require.NoError(t, err)
+
+	pm, err := fs.NewPersistManager(fsOpts)
+	require.NoError(t, err)
+	flush, err := pm.StartDataPersist()
+	require.NoError(t, err)
+	require.NoError(t, shard.PurgeTombstones(flush))
+	require.NoError(t, flush.DoneData())
+
+	// The block is rewritten to a new volume without the deleted series.
+	fileset, ok, err := fs.FileSetAt(dir, shard.namespace.ID(), shard.ID(), blockStart)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 1, fileset.ID.VolumeIndex)
+
+	reader, err := fs.NewReader(opts.BytesPool(), fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, reader.Open(fs.DataReaderOpenOptions{Identifier: fileset.ID}))
+	require.Equal(t, 1, reader.Entries())
+	id, tagsIter, _, _, err := reader.ReadMetadata()
+	require.NoError(t, err)
+	tagsIter.Close()
+	require.Equal(t, "bar", id.String())
+	require.NoError(t, reader.Close())
+
+	// The tombstone is retained to mask the series until it expires but is
+	// no longer purged again.
+	persisted, err := fs.ReadTombstones(dir, shard.namespace.ID(), shard.ID())
+	require.NoError(t, err)
+	require.True(t, persisted["foo"].Purged)
+	require.Empty(t, shard.tombstones.Unpurged())
+}
+
+func TestShardDownsampleSkipsOnlyOutOfRetentionWrites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts, dir := testDatabaseOptionsWithTempDir(t)
+	defer os.RemoveAll(dir)
+
+	scope := tally.NewTestScope("", nil)
+	opts = opts.SetInstrumentOptions(opts.InstrumentOptions().SetMetricsScope(scope))
+
+	var (
+		fsOpts     = opts.CommitLogOptions().FilesystemOptions()
+		blockSize  = defaultTestNs1Opts.RetentionOptions().BlockSize()
+		blockStart = time.Now().Truncate(blockSize).Add(-2 * blockSize)
+		dopts      = namespace.NewDownsampleOptions().
+				SetEnabled(true).
+				SetAfter(24 * time.Hour).
+				SetResolution(time.Minute).
+				SetTargetNamespace(defaultTestNs2ID)
+	)
+
+	metadata, err := namespace.NewMetadata(defaultTestNs1ID,
+		defaultTestNs1Opts.SetDownsampleOptions(dopts))
+	require.NoError(t, err)
+	nsReaderMgr := newNamespaceReaderManager(metadata, tally.NoopScope, opts)
+	seriesOpts := NewSeriesOptionsFromOptions(opts, defaultTestNs1Opts.RetentionOptions())
+	shard := newDatabaseShard(metadata, 0, nil, nsReaderMgr, &testIncreasingIndex{},
+		commitLogWriteNoOp, nil, nil, true, opts, seriesOpts).(*dbShard)
+	shard.bootstrapState = Bootstrapped
+	defer shard.Close()
+
+	writer, err := fs.NewWriter(fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, writer.Open(fs.DataWriterOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  metadata.ID(),
+			Shard:      0,
+			BlockStart: blockStart,
+		},
+		BlockSize: blockSize,
+	}))
+	encoder := opts.EncoderPool().Get()
+	encoder.Reset(blockStart, 0)
+	for i, v := range []float64{1, 2, 3} {
+		dp := ts.Datapoint{Timestamp: blockStart.Add(time.Duration(i+1) * time.Minute), Value: v}
+		require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+	}
+	segment := encoder.Discard()
+	require.NoError(t, writer.WriteAll(ident.StringID("foo"), ident.Tags{},
+		[]checked.Bytes{segment.Head, segment.Tail}, digest.SegmentChecksum(segment)))
+	require.NoError(t, writer.Close())
+
+	target := NewMockdatabaseNamespace(ctrl)
+	target.EXPECT().Options().Return(defaultTestNs2Opts).AnyTimes()
+	target.EXPECT().ID().Return(defaultTestNs2ID).AnyTimes()
+
+	// Unexpected write errors fail the block.
+	target.EXPECT().
+		Write(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(errors.New("write error"))
+	require.Error(t, shard.Downsample(blockStart, target))
+	require.Equal(t, fileOpFailed, shard.DownsampleState(blockStart).Status)
+
+	// Datapoints outside of the target's retention are skipped.
+	target.EXPECT().
+		Write(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(m3dberrors.ErrTooPast).
+		Times(3)
+	require.NoError(t, shard.Downsample(blockStart, target))
+	require.Equal(t, fileOpSuccess, shard.DownsampleState(blockStart).Status)
+
+	counters := scope.Snapshot().Counters()
+	require.Equal(t, int64(1), counters["dbshard.downsample.write-errors+"].Value())
+	require.Equal(t, int64(3), counters["dbshard.downsample.datapoints-skipped+"].Value())
+}
+
+func TestShardDownsampleIndexesTargetSeries(t *testing.T) {
+	opts, dir := testDatabaseOptionsWithTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		fsOpts     = opts.CommitLogOptions().FilesystemOptions()
+		blockSize  = defaultTestNs1Opts.RetentionOptions().BlockSize()
+		blockStart = time.Now().Truncate(blockSize).Add(-2 * blockSize)
+		dopts      = namespace.NewDownsampleOptions().
+				SetEnabled(true).
+				SetAfter(24 * time.Hour).
+				SetResolution(time.Minute).
+				SetTargetNamespace(defaultTestNs2ID)
+	)
+
+	metadata, err := namespace.NewMetadata(defaultTestNs1ID,
+		defaultTestNs1Opts.SetDownsampleOptions(dopts))
+	require.NoError(t, err)
+	newShard := func() *dbShard {
+		nsReaderMgr := newNamespaceReaderManager(metadata, tally.NoopScope, opts)
+		seriesOpts := NewSeriesOptionsFromOptions(opts, defaultTestNs1Opts.RetentionOptions())
+		return newDatabaseShard(metadata, 0, nil, nsReaderMgr, &testIncreasingIndex{},
+			commitLogWriteNoOp, nil, nil, true, opts, seriesOpts).(*dbShard)
+	}
+
+	target, closer := newTestNamespaceWithIDOpts(t, defaultTestNs2ID, defaultTestNs2Opts.
+		SetColdWritesEnabled(true).
+		SetIndexOptions(namespace.NewIndexOptions().
+			SetEnabled(true).
+			SetBlockSize(defaultTestNs2Opts.RetentionOptions().BlockSize())))
+	defer closer()
+	defer target.Close()
+
+	// Write a volume of the block holding a tagged series.
+	writer, err := fs.NewWriter(fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, writer.Open(fs.DataWriterOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  metadata.ID(),
+			Shard:      0,
+			BlockStart: blockStart,
+		},
+		BlockSize: blockSize,
+	}))
+	encoder := opts.EncoderPool().Get()
+	encoder.Reset(blockStart, 0)
+	for i, v := range []float64{1, 2, 3} {
+		dp := ts.Datapoint{Timestamp: blockStart.Add(time.Duration(i+1) * time.Minute), Value: v}
+		require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+	}
+	segment := encoder.Discard()
+	require.NoError(t, writer.WriteAll(ident.StringID("foo"),
+		ident.NewTags(ident.StringTag("name", "value")),
+		[]checked.Bytes{segment.Head, segment.Tail}, digest.SegmentChecksum(segment)))
+	require.NoError(t, writer.Close())
+
+	shard := newShard()
+	shard.bootstrapState = Bootstrapped
+	require.NoError(t, shard.Downsample(blockStart, target))
+	require.Equal(t, fileOpSuccess, shard.DownsampleState(blockStart).Status)
+	shard.Close()
+
+	// The downsampled series is queryable in the target namespace.
+	ctx := context.NewContext()
+	defer ctx.Close()
+	query, err := m3ninxidx.NewRegexpQuery([]byte("name"), []byte("val.*"))
+	require.NoError(t, err)
+	res, err := target.QueryIDs(ctx, index.Query{query}, index.QueryOptions{
+		StartInclusive: blockStart,
+		EndExclusive:   blockStart.Add(blockSize),
+	})
+	require.NoError(t, err)
+	_, ok := res.Results.Map().Get(ident.StringID("foo"))
+	require.True(t, ok)
+
+	// The block is not downsampled again after a restart.
+	shard = newShard()
+	require.NoError(t, shard.Bootstrap(result.NewMap(result.MapOptions{})))
+	require.Equal(t, fileOpSuccess, shard.DownsampleState(blockStart).Status)
+	shard.Close()
+
+	// Unless it has been rewritten to a new volume since.
+	require.NoError(t, fs.WriteDownsampledVolumes(fsOpts, metadata.ID(), 0,
+		map[xtime.UnixNano]int{xtime.ToUnixNano(blockStart): 1}))
+	shard = newShard()
+	defer shard.Close()
+	require.NoError(t, shard.Bootstrap(result.NewMap(result.MapOptions{})))
+	require.Equal(t, fileOpNotStarted, shard.DownsampleState(blockStart).Status)
+}
+
+func TestShardWriteTaggedQuotas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blockSize := time.Hour
+	idx := NewMocknamespaceIndex(ctrl)
+	idx.EXPECT().BlockStartForWriteTime(gomock.Any()).
+		DoAndReturn(func(t time.Time) xtime.UnixNano {
+			return xtime.ToUnixNano(t.Truncate(blockSize))
+		}).
+		AnyTimes()
+	idx.EXPECT().WriteBatch(gomock.Any()).Return(nil).AnyTimes()
+
+	opts, dir := testDatabaseOptionsWithTempDir(t)
+	defer os.RemoveAll(dir)
+	tracker, err := quota.NewTracker(quota.NewOptions().
+		SetTagName([]byte("tenant")).
+		SetDefaultLimits(quota.Limits{MaxActiveSeries: 1}))
+	require.NoError(t, err)
+
+	shard := testDatabaseShardWithIndexFn(t, opts, idx)
+	shard.writeQuotas = tracker
+	defer shard.Close()
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	tags := func() ident.TagIterator {
+		return ident.NewTagsIterator(ident.NewTags(ident.StringTag("tenant", "a")))
+	}
+	now := opts.ClockOptions().NowFn()()
+	require.NoError(t, shard.WriteTagged(ctx, ident.StringID("foo"), tags(),
+		now, 1.0, xtime.Second, nil))
+
+	// Writes to active series are admitted while new series are rejected.
+	require.NoError(t, shard.WriteTagged(ctx, ident.StringID("foo"), tags(),
+		now.Add(time.Second), 2.0, xtime.Second, nil))
+	err = shard.WriteTagged(ctx, ident.StringID("bar"), tags(),
+		now, 1.0, xtime.Second, nil)
+	require.Error(t, err)
+	require.True(t, quota.IsExceededError(err))
+	require.Equal(t, int64(1), shard.NumSeries())
+
+	// Deleting the active series frees up the quota.
+	deleted, err := shard.DeleteSeries([]ident.ID{ident.StringID("foo")})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+	require.NoError(t, shard.WriteTagged(ctx, ident.StringID("bar"), tags(),
+		now, 1.0, xtime.Second, nil))
+}
+
 func TestShardCleanupExpiredFileSets(t *testing.T) {
 	opts := testDatabaseOptions()
 	shard := testDatabaseShard(t, opts)
@@ -877,6 +1302,63 @@ func TestShardCleanupExpiredFileSets(t *testing.T) {
 	require.Equal(t, []string{defaultTestNs1ID.String(), "0"}, deletedFiles)
 }
 
+func TestShardCleanupSupersededFileSets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts, dir := testDatabaseOptionsWithTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		fsOpts     = opts.CommitLogOptions().FilesystemOptions()
+		blockSize  = defaultTestNs1Opts.RetentionOptions().BlockSize()
+		blockStart = time.Now().Truncate(blockSize).Add(-2 * blockSize)
+	)
+
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+	retriever := block.NewMockDatabaseBlockRetriever(ctrl)
+	shard.DatabaseBlockRetriever = retriever
+
+	for volume := 0; volume < 2; volume++ {
+		writer, err := fs.NewWriter(fsOpts)
+		require.NoError(t, err)
+		require.NoError(t, writer.Open(fs.DataWriterOpenOptions{
+			Identifier: fs.FileSetFileIdentifier{
+				Namespace:   shard.namespace.ID(),
+				Shard:       shard.ID(),
+				BlockStart:  blockStart,
+				VolumeIndex: volume,
+			},
+			BlockSize: blockSize,
+		}))
+		require.NoError(t, writer.Close())
+	}
+
+	volumes := func() []int {
+		filesets, err := fs.DataFiles(dir, shard.namespace.ID(), shard.ID())
+		require.NoError(t, err)
+		var result []int
+		for _, fileset := range filesets {
+			result = append(result, fileset.ID.VolumeIndex)
+		}
+		return result
+	}
+
+	// Superseded volumes are kept while they may still be read from
+	retriever.EXPECT().
+		IsSupersededFileSetInUse(shard.ID(), gomock.Any(), 1).
+		Return(true)
+	require.NoError(t, shard.CleanupSupersededFileSets())
+	require.Equal(t, []int{0, 1}, volumes())
+
+	retriever.EXPECT().
+		IsSupersededFileSetInUse(shard.ID(), gomock.Any(), 1).
+		Return(false)
+	require.NoError(t, shard.CleanupSupersededFileSets())
+	require.Equal(t, []int{1}, volumes())
+}
+
 func TestShardCleanupSnapshot(t *testing.T) {
 	var (
 		opts                = testDatabaseOptions()
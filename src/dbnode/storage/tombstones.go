@@ -0,0 +1,197 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3x/ident"
+)
+
+// seriesTombstones tracks the IDs of series that have been deleted, keyed by
+// ID and holding the time at which the deletion occurred. Tombstones are used
+// to mask data for deleted series that still resides in immutable filesets or
+// index segments until that data is purged or expires by retention.
+type seriesTombstones struct {
+	sync.RWMutex
+	tombstones map[string]fs.Tombstone
+	// dirty is set when the tombstones have changed since they were last
+	// persisted.
+	dirty bool
+}
+
+func newSeriesTombstones() *seriesTombstones {
+	return &seriesTombstones{
+		tombstones: make(map[string]fs.Tombstone),
+	}
+}
+
+// Add records that the series with the given ID was deleted at time t.
+func (t *seriesTombstones) Add(id ident.ID, at time.Time) {
+	t.Lock()
+	t.tombstones[id.String()] = fs.Tombstone{DeletedAt: at}
+	t.dirty = true
+	t.Unlock()
+}
+
+// Load adds the given tombstones, retaining the latest deletion of series
+// that are already tombstoned.
+func (t *seriesTombstones) Load(tombstones map[string]fs.Tombstone) {
+	t.Lock()
+	for id, tombstone := range tombstones {
+		if existing, ok := t.tombstones[id]; ok && existing.DeletedAt.After(tombstone.DeletedAt) {
+			continue
+		}
+		t.tombstones[id] = tombstone
+	}
+	t.Unlock()
+}
+
+// Remove removes the tombstone for the given ID, if any, and returns the
+// removed tombstone.
+func (t *seriesTombstones) Remove(id ident.ID) (fs.Tombstone, bool) {
+	t.RLock()
+	_, exists := t.tombstones[string(id.Bytes())]
+	t.RUnlock()
+	if !exists {
+		return fs.Tombstone{}, false
+	}
+
+	t.Lock()
+	tombstone, exists := t.tombstones[string(id.Bytes())]
+	delete(t.tombstones, string(id.Bytes()))
+	if exists {
+		t.dirty = true
+	}
+	t.Unlock()
+	return tombstone, exists
+}
+
+// Contains returns whether the series with the given ID has been deleted.
+func (t *seriesTombstones) Contains(id ident.ID) bool {
+	t.RLock()
+	if len(t.tombstones) == 0 {
+		t.RUnlock()
+		return false
+	}
+	_, exists := t.tombstones[string(id.Bytes())]
+	t.RUnlock()
+	return exists
+}
+
+// Len returns the number of tombstones tracked.
+func (t *seriesTombstones) Len() int {
+	t.RLock()
+	n := len(t.tombstones)
+	t.RUnlock()
+	return n
+}
+
+// Unpurged returns the tombstones of series that may still reside in
+// fileset volumes.
+func (t *seriesTombstones) Unpurged() map[string]fs.Tombstone {
+	t.RLock()
+	var unpurged map[string]fs.Tombstone
+	for id, tombstone := range t.tombstones {
+		if tombstone.Purged {
+			continue
+		}
+		if unpurged == nil {
+			unpurged = make(map[string]fs.Tombstone)
+		}
+		unpurged[id] = tombstone
+	}
+	t.RUnlock()
+	return unpurged
+}
+
+// MarkPurged marks the given tombstones as purged unless the series have
+// been deleted again since.
+func (t *seriesTombstones) MarkPurged(purged map[string]fs.Tombstone) {
+	t.Lock()
+	for id, tombstone := range purged {
+		current, ok := t.tombstones[id]
+		if !ok || !current.DeletedAt.Equal(tombstone.DeletedAt) {
+			continue
+		}
+		current.Purged = true
+		t.tombstones[id] = current
+		t.dirty = true
+	}
+	t.Unlock()
+}
+
+// Snapshot returns a copy of all tombstones tracked.
+func (t *seriesTombstones) Snapshot() map[string]fs.Tombstone {
+	t.RLock()
+	snapshot := make(map[string]fs.Tombstone, len(t.tombstones))
+	for id, tombstone := range t.tombstones {
+		snapshot[id] = tombstone
+	}
+	t.RUnlock()
+	return snapshot
+}
+
+// ExpireBefore removes all tombstones for deletions that occurred before
+// the given time and returns the number of tombstones removed.
+func (t *seriesTombstones) ExpireBefore(earliestToRetain time.Time) int {
+	t.Lock()
+	removed := 0
+	for id, tombstone := range t.tombstones {
+		if tombstone.DeletedAt.Before(earliestToRetain) {
+			delete(t.tombstones, id)
+			removed++
+		}
+	}
+	if removed > 0 {
+		t.dirty = true
+	}
+	t.Unlock()
+	return removed
+}
+
+// SnapshotIfDirty returns a copy of all tombstones tracked if they have
+// changed since the last snapshot was taken, the tombstones are considered
+// clean once the snapshot is returned.
+func (t *seriesTombstones) SnapshotIfDirty() (map[string]fs.Tombstone, bool) {
+	t.Lock()
+	if !t.dirty {
+		t.Unlock()
+		return nil, false
+	}
+	snapshot := make(map[string]fs.Tombstone, len(t.tombstones))
+	for id, tombstone := range t.tombstones {
+		snapshot[id] = tombstone
+	}
+	t.dirty = false
+	t.Unlock()
+	return snapshot, true
+}
+
+// MarkDirty marks the tombstones as changed, used when a snapshot of the
+// tombstones could not be persisted.
+func (t *seriesTombstones) MarkDirty() {
+	t.Lock()
+	t.dirty = true
+	t.Unlock()
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeriesTombstonesAddRemoveContains(t *testing.T) {
+	tombstones := newSeriesTombstones()
+	require.False(t, tombstones.Contains(ident.StringID("foo")))
+
+	now := time.Now()
+	tombstones.Add(ident.StringID("foo"), now)
+	tombstones.Add(ident.StringID("bar"), now)
+	require.Equal(t, 2, tombstones.Len())
+	require.True(t, tombstones.Contains(ident.StringID("foo")))
+	require.True(t, tombstones.Contains(ident.BytesID("bar")))
+	require.False(t, tombstones.Contains(ident.StringID("baz")))
+
+	tombstones.Remove(ident.StringID("foo"))
+	tombstones.Remove(ident.StringID("baz"))
+	require.Equal(t, 1, tombstones.Len())
+	require.False(t, tombstones.Contains(ident.StringID("foo")))
+	require.True(t, tombstones.Contains(ident.StringID("bar")))
+}
+
+func TestSeriesTombstonesExpireBefore(t *testing.T) {
+	tombstones := newSeriesTombstones()
+
+	now := time.Now()
+	tombstones.Add(ident.StringID("old"), now.Add(-2*time.Hour))
+	tombstones.Add(ident.StringID("new"), now)
+
+	require.Equal(t, 1, tombstones.ExpireBefore(now.Add(-time.Hour)))
+	require.False(t, tombstones.Contains(ident.StringID("old")))
+	require.True(t, tombstones.Contains(ident.StringID("new")))
+	require.Equal(t, 0, tombstones.ExpireBefore(now.Add(-time.Hour)))
+}
+
+func TestSeriesTombstonesPurged(t *testing.T) {
+	tombstones := newSeriesTombstones()
+	require.Empty(t, tombstones.Unpurged())
+
+	now := time.Now()
+	tombstones.Add(ident.StringID("foo"), now)
+	tombstones.Add(ident.StringID("bar"), now)
+	unpurged := tombstones.Unpurged()
+	require.Equal(t, 2, len(unpurged))
+
+	// A series deleted again after the purge started remains unpurged.
+	tombstones.Add(ident.StringID("bar"), now.Add(time.Second))
+	tombstones.MarkPurged(unpurged)
+	unpurged = tombstones.Unpurged()
+	require.Equal(t, 1, len(unpurged))
+	require.Contains(t, unpurged, "bar")
+
+	snapshot := tombstones.Snapshot()
+	require.True(t, snapshot["foo"].Purged)
+	require.False(t, snapshot["bar"].Purged)
+
+	loaded := newSeriesTombstones()
+	loaded.Load(snapshot)
+	require.Equal(t, snapshot, loaded.Snapshot())
+}
+
+func TestSeriesTombstonesSnapshotIfDirty(t *testing.T) {
+	tombstones := newSeriesTombstones()
+	_, dirty := tombstones.SnapshotIfDirty()
+	require.False(t, dirty)
+
+	now := time.Now()
+	tombstones.Add(ident.StringID("foo"), now)
+	snapshot, dirty := tombstones.SnapshotIfDirty()
+	require.True(t, dirty)
+	require.Contains(t, snapshot, "foo")
+	_, dirty = tombstones.SnapshotIfDirty()
+	require.False(t, dirty)
+
+	// Removing a series that is not tombstoned leaves the tombstones clean.
+	tombstones.Remove(ident.StringID("bar"))
+	_, dirty = tombstones.SnapshotIfDirty()
+	require.False(t, dirty)
+
+	tombstones.Remove(ident.StringID("foo"))
+	snapshot, dirty = tombstones.SnapshotIfDirty()
+	require.True(t, dirty)
+	require.Empty(t, snapshot)
+
+	tombstones.MarkDirty()
+	_, dirty = tombstones.SnapshotIfDirty()
+	require.True(t, dirty)
+}
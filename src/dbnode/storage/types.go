@@ -28,6 +28,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/sharding"
@@ -36,6 +37,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/dbnode/storage/quota"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/x/xcounter"
@@ -111,6 +113,27 @@ type Database interface {
 		opts index.QueryOptions,
 	) (index.QueryResults, error)
 
+	// AggregateQuery resolves the given query into the tag names, and the
+	// values unless only names are requested, of the matching series.
+	AggregateQuery(
+		ctx context.Context,
+		namespace ident.ID,
+		query index.Query,
+		opts index.AggregationOptions,
+	) (index.AggregateQueryResult, error)
+
+	// DeleteTagged deletes all series matching the given query within the
+	// range of the options, up to the limit of the options if set, and returns
+	// the number of series deleted from each shard. Deleted series are excluded
+	// from queries immediately, dropped from in-memory buffers and are not
+	// written to any subsequently flushed filesets.
+	DeleteTagged(
+		ctx context.Context,
+		namespace ident.ID,
+		query index.Query,
+		opts index.QueryOptions,
+	) (map[uint32]int64, error)
+
 	// ReadEncoded retrieves encoded segments for an ID
 	ReadEncoded(
 		ctx context.Context,
@@ -166,6 +189,13 @@ type Database interface {
 	// Repair will issue a repair and return nil on success or error on error.
 	Repair() error
 
+	// FlushAndSnapshot waits for any in progress file operations to complete
+	// and then forces a tick followed by a synchronous cleanup, flush and
+	// snapshot so that all data is persisted to disk. If the flush and snapshot
+	// succeed fn is then called, if not nil, with file operations still
+	// disabled so that the persisted files are not modified while fn reads them.
+	FlushAndSnapshot(fn func() error) error
+
 	// Truncate truncates data for the given namespace
 	Truncate(namespace ident.ID) (int64, error)
 
@@ -254,6 +284,23 @@ type databaseNamespace interface {
 		opts index.QueryOptions,
 	) (index.QueryResults, error)
 
+	// AggregateQuery resolves the given query into the tag names, and the
+	// values unless only names are requested, of the matching series.
+	AggregateQuery(
+		ctx context.Context,
+		query index.Query,
+		opts index.AggregationOptions,
+	) (index.AggregateQueryResult, error)
+
+	// DeleteTagged deletes all series matching the given query within the
+	// range of the options and returns the number of series deleted from
+	// each shard.
+	DeleteTagged(
+		ctx context.Context,
+		query index.Query,
+		opts index.QueryOptions,
+	) (map[uint32]int64, error)
+
 	// ReadEncoded reads data for given id within [start, end)
 	ReadEncoded(
 		ctx context.Context,
@@ -299,6 +346,34 @@ type databaseNamespace interface {
 		flush persist.DataFlush,
 	) error
 
+	// ColdFlush persists the cold writes buffered for blocks that have
+	// been flushed to new fileset volumes.
+	ColdFlush(
+		ShardBootstrapStates ShardBootstrapStates,
+		flush persist.DataFlush,
+	) error
+
+	// PurgeTombstones drops deleted series from the fileset volumes of
+	// every shard by writing new volumes without them.
+	PurgeTombstones(
+		ShardBootstrapStates ShardBootstrapStates,
+		flush persist.DataFlush,
+	) error
+
+	// Downsample writes the flushed blocks that have aged beyond the
+	// namespace's downsample after period at a lower resolution to the
+	// target namespace.
+	Downsample(
+		now time.Time,
+		ShardBootstrapStates ShardBootstrapStates,
+		target databaseNamespace,
+	) error
+
+	// ColdFlushedUntil returns the start time of the most recent cold flush
+	// that persisted all of the buffered cold writes, any cold writes made
+	// before this time have been persisted to disk.
+	ColdFlushedUntil() time.Time
+
 	// FlushIndex flushes in-memory index data.
 	FlushIndex(
 		flush persist.IndexFlush,
@@ -387,6 +462,12 @@ type databaseShard interface {
 		start, end time.Time,
 	) ([][]xio.BlockReader, error)
 
+	// DeleteSeries removes the series with the given IDs from the shard and
+	// masks any of their data held in filesets until it is purged, returning
+	// the number of series that were removed from memory. The deletion is
+	// persisted so that the series are not restored by bootstrapping.
+	DeleteSeries(ids []ident.ID) (int64, error)
+
 	// FetchBlocks retrieves data blocks for a given id and a list of block start times.
 	FetchBlocks(
 		ctx context.Context,
@@ -423,6 +504,33 @@ type databaseShard interface {
 		flush persist.DataFlush,
 	) error
 
+	// ColdFlush persists the cold writes buffered for blocks that have been
+	// flushed to new fileset volumes, returning whether cold writes remain
+	// buffered for blocks that are yet to be flushed.
+	ColdFlush(flush persist.DataFlush) (bool, error)
+
+	// PurgeTombstones writes the fileset blocks containing deleted series
+	// that have not yet been purged to new volumes without those series.
+	PurgeTombstones(flush persist.DataFlush) error
+
+	// Downsample writes the flushed data of a block at the lower resolution
+	// of the namespace's downsample options to the target namespace.
+	Downsample(blockStart time.Time, target databaseNamespace) error
+
+	// DownsampleState returns the downsample state for a given block start.
+	DownsampleState(blockStart time.Time) fileOpState
+
+	// ReplayColdWrite applies a cold write read back from the commit log
+	// without writing it to the commit log again.
+	ReplayColdWrite(
+		ctx context.Context,
+		id ident.ID,
+		timestamp time.Time,
+		value float64,
+		unit xtime.Unit,
+		annotation []byte,
+	) error
+
 	// Snapshot snapshot's the unflushed series' in this shard.
 	Snapshot(blockStart, snapshotStart time.Time, flush persist.DataFlush) error
 
@@ -438,12 +546,25 @@ type databaseShard interface {
 	// CleanupExpiredFileSets removes expired fileset files.
 	CleanupExpiredFileSets(earliestToRetain time.Time) error
 
+	// CleanupSupersededFileSets removes fileset volumes that have been
+	// superseded by a newer volume and are no longer read from.
+	CleanupSupersededFileSets() error
+
 	// Repair repairs the shard data for a given time.
 	Repair(
 		ctx context.Context,
 		tr xtime.Range,
 		repairer databaseShardRepairer,
 	) (repair.MetadataComparisonResult, error)
+
+	// WithFileSetVolumeLock calls fn while holding the lock that serializes
+	// writing new volumes of the shard's filesets.
+	WithFileSetVolumeLock(fn func() error) error
+
+	// OnRepairedBlocks is called once a repair has persisted a new fileset
+	// volume for a block start, it reloads the fileset used to serve reads
+	// and replaces the in-memory blocks of the repaired series.
+	OnRepairedBlocks(blockStart time.Time, blocks []repairedSeriesBlock) error
 }
 
 // namespaceIndex indexes namespace writes.
@@ -466,6 +587,24 @@ type namespaceIndex interface {
 		opts index.QueryOptions,
 	) (index.QueryResults, error)
 
+	// AggregateQuery resolves the given query into the tag names, and the
+	// values unless only names are requested, of the matching series.
+	AggregateQuery(
+		ctx context.Context,
+		query index.Query,
+		opts index.AggregationOptions,
+	) (index.AggregateQueryResult, error)
+
+	// DeleteSeries tombstones the series with the given IDs so that they are
+	// excluded from query results and from any index segments flushed after
+	// the deletion. Series that are written to again after being deleted
+	// are no longer excluded.
+	DeleteSeries(ids []ident.ID) error
+
+	// LoadTombstones tombstones the series deleted before the index was
+	// opened, which are persisted by the shards.
+	LoadTombstones(tombstones map[string]fs.Tombstone)
+
 	// Bootstrap bootstraps the index the provided segments.
 	Bootstrap(
 		bootstrapResults result.IndexResults,
@@ -585,7 +724,7 @@ type databaseShardRepairer interface {
 	// Repair repairs the data for a given namespace and shard
 	Repair(
 		ctx context.Context,
-		namespace ident.ID,
+		nsMeta namespace.Metadata,
 		tr xtime.Range,
 		shard databaseShard,
 	) (repair.MetadataComparisonResult, error)
@@ -634,6 +773,10 @@ type databaseMediator interface {
 	// Tick performs a tick
 	Tick(runType runType, forceType forceType) error
 
+	// FlushAndSnapshot forces a tick followed by a synchronous cleanup, flush
+	// and snapshot, returning any errors encountered
+	FlushAndSnapshot() error
+
 	// Repair repairs the database
 	Repair() error
 
@@ -848,6 +991,13 @@ type Options interface {
 
 	// QueryIDsWorkerPool returns the QueryIDs worker pool.
 	QueryIDsWorkerPool() xsync.WorkerPool
+
+	// SetWriteQuotaOptions sets the write quota options, write quotas are
+	// not enforced if nil.
+	SetWriteQuotaOptions(value quota.Options) Options
+
+	// WriteQuotaOptions returns the write quota options.
+	WriteQuotaOptions() quota.Options
 }
 
 // DatabaseBootstrapState stores a snapshot of the bootstrap state for all shards across all
@@ -0,0 +1,594 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package buffer
+
+import (
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/msg/producer"
+	"github.com/m3db/m3x/retry"
+
+	"github.com/uber-go/tally"
+)
+
+type diskBufferMetrics struct {
+	messagePersisted     tally.Counter
+	bytePersisted        tally.Counter
+	persistErrors        tally.Counter
+	messageSpilled       tally.Counter
+	messageReplayed      tally.Counter
+	replayErrors         tally.Counter
+	messageReplayDropped tally.Counter
+	corruptSegments      tally.Counter
+	segmentsDeleted      tally.Counter
+	segmentsDropped      tally.Counter
+	segmentsDroppedAge   tally.Counter
+	messageDroppedOnDisk tally.Counter
+	segments             tally.Gauge
+	diskBytes            tally.Gauge
+	messagePending       tally.Gauge
+}
+
+func newDiskBufferMetrics(scope tally.Scope) diskBufferMetrics {
+	scope = scope.SubScope("disk")
+	return diskBufferMetrics{
+		messagePersisted:     scope.Counter("message-persisted"),
+		bytePersisted:        scope.Counter("byte-persisted"),
+		persistErrors:        scope.Counter("persist-errors"),
+		messageSpilled:       scope.Counter("message-spilled"),
+		messageReplayed:      scope.Counter("message-replayed"),
+		replayErrors:         scope.Counter("replay-errors"),
+		messageReplayDropped: scope.Counter("message-replay-dropped"),
+		corruptSegments:      scope.Counter("corrupt-segments"),
+		segmentsDeleted:      scope.Counter("segments-deleted"),
+		segmentsDropped:      scope.Counter("segments-dropped-max-disk-size"),
+		segmentsDroppedAge:   scope.Counter("segments-dropped-max-age"),
+		messageDroppedOnDisk: scope.Counter("message-dropped"),
+		segments:             scope.Gauge("segments"),
+		diskBytes:            scope.Gauge("segment-bytes"),
+		messagePending:       scope.Gauge("message-pending"),
+	}
+}
+
+// diskMessage is a message persisted in a segment file.
+type diskMessage struct {
+	producer.Message
+
+	buffer  *diskBuffer
+	segment *diskSegment
+	record  spilledRecord
+	rm      *producer.RefCountedMessage
+
+	// NB: guarded by the lock of the buffer.
+	finalized bool
+	abandoned bool
+}
+
+func (m *diskMessage) Finalize(r producer.FinalizeReason) {
+	m.buffer.onFinalize(m, r)
+	m.Message.Finalize(r)
+}
+
+// replayedMessage is a message read back from a segment file.
+type replayedMessage struct {
+	shard uint32
+	bytes []byte
+}
+
+func (m *replayedMessage) Shard() uint32                    { return m.shard }
+func (m *replayedMessage) Bytes() []byte                    { return m.bytes }
+func (m *replayedMessage) Size() int                        { return len(m.bytes) }
+func (m *replayedMessage) Finalize(producer.FinalizeReason) {}
+
+// nolint: maligned
+type diskBuffer struct {
+	sync.Mutex
+
+	buffer         producer.Buffer
+	opts           DiskOptions
+	maxMessageSize int
+	replayBudget   int64
+	replayRetry    retry.Options
+	nowFn          func() time.Time
+	m              diskBufferMetrics
+
+	// segments are ordered from oldest to newest, the newest segment is the
+	// active segment messages are appended to.
+	segments  []*diskSegment
+	nextID    uint64
+	inMemory  int64
+	encodeBuf []byte
+	replayFn  producer.ReplayFn
+	isClosed  bool
+	doneCh    chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewDiskBuffer returns a new buffer which persists messages in segment files
+// before they are produced. Messages evicted from memory are kept on disk and
+// produced again once there is room in memory, messages not consumed before
+// the buffer is closed are produced again once the buffer is reopened. A
+// segment file is deleted once all of its messages have been consumed, the
+// messages of a segment partially consumed before a restart are all produced
+// again.
+func NewDiskBuffer(opts DiskOptions) (producer.ReplayableBuffer, error) {
+	if opts == nil {
+		opts = NewDiskOptions()
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(opts.Path(), newDirectoryMode); err != nil {
+		return nil, err
+	}
+	buffer, err := NewBuffer(opts.BufferOptions())
+	if err != nil {
+		return nil, err
+	}
+	b := &diskBuffer{
+		buffer:         buffer,
+		opts:           opts,
+		maxMessageSize: opts.BufferOptions().MaxMessageSize(),
+		replayBudget:   int64(opts.BufferOptions().MaxBufferSize() / 2),
+		replayRetry:    opts.ReplayRetryOptions(),
+		nowFn:          time.Now,
+		m:              newDiskBufferMetrics(opts.InstrumentOptions().MetricsScope()),
+		doneCh:         make(chan struct{}),
+	}
+	if err := b.load(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// load reads the segment files left by a previous buffer and creates the
+// active segment.
+func (b *diskBuffer) load() error {
+	ids, err := segmentFileIDs(b.opts.Path())
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		b.nextID = id + 1
+		seg, corrupt, err := readSegment(b.opts.Path(), id)
+		if err != nil {
+			// NB: the file is left in place for inspection.
+			b.m.corruptSegments.Inc(1)
+			continue
+		}
+		if corrupt {
+			b.m.corruptSegments.Inc(1)
+		}
+		if seg.numPending == 0 {
+			if err := os.Remove(seg.path); err != nil {
+				return err
+			}
+			continue
+		}
+		b.segments = append(b.segments, seg)
+	}
+
+	active, err := createSegment(b.opts.Path(), b.nextID, b.nowFn())
+	if err != nil {
+		return err
+	}
+	b.nextID++
+	b.segments = append(b.segments, active)
+	return nil
+}
+
+func (b *diskBuffer) Add(m producer.Message) (*producer.RefCountedMessage, error) {
+	size := m.Size()
+	if size > b.maxMessageSize {
+		// NB: The in memory buffer rejects the message.
+		return b.buffer.Add(m)
+	}
+
+	b.Lock()
+	if b.isClosed {
+		b.Unlock()
+		return nil, errBufferClosed
+	}
+	seg, offset, err := b.persistWithLock(m.Shard(), m.Bytes())
+	if err != nil {
+		b.Unlock()
+		b.m.persistErrors.Inc(1)
+		return nil, err
+	}
+	seg.numPending++
+	b.inMemory += int64(size)
+	b.Unlock()
+
+	dm := &diskMessage{
+		Message: m,
+		buffer:  b,
+		segment: seg,
+		record:  spilledRecord{offset: offset, size: size},
+	}
+	rm, err := b.buffer.Add(dm)
+	b.Lock()
+	defer b.Unlock()
+	if err != nil {
+		// NB: The message is left in the segment file, it would only be
+		// produced again if the process restarts before the segment is deleted.
+		seg.numPending--
+		b.inMemory -= int64(size)
+		return nil, err
+	}
+	b.registerWithLock(dm, rm)
+	return rm, nil
+}
+
+func (b *diskBuffer) persistWithLock(shard uint32, data []byte) (*diskSegment, int64, error) {
+	active := b.segments[len(b.segments)-1]
+	recordSize := int64(recordHeaderLen + recordShardLen + len(data))
+	if active.failed ||
+		(active.size > segmentHeaderLen && active.size+recordSize > b.opts.MaxSegmentSize()) {
+		if err := b.rotateWithLock(); err != nil {
+			return nil, 0, err
+		}
+		active = b.segments[len(b.segments)-1]
+	}
+	offset, buf, err := active.append(shard, data, b.encodeBuf)
+	b.encodeBuf = buf
+	if err != nil {
+		return nil, 0, err
+	}
+	b.m.messagePersisted.Inc(1)
+	b.m.bytePersisted.Inc(recordSize)
+	return active, offset, nil
+}
+
+func (b *diskBuffer) rotateWithLock() error {
+	seg, err := createSegment(b.opts.Path(), b.nextID, b.nowFn())
+	if err != nil {
+		return err
+	}
+	b.nextID++
+	// NB: sealing syncs the messages of the rotated segment to disk.
+	if err := b.segments[len(b.segments)-1].seal(); err != nil {
+		b.m.persistErrors.Inc(1)
+	}
+	b.segments = append(b.segments, seg)
+	return nil
+}
+
+func (b *diskBuffer) registerWithLock(dm *diskMessage, rm *producer.RefCountedMessage) {
+	// NB: The message may already have been dropped by the in memory buffer
+	// to make room for another message.
+	if dm.finalized {
+		return
+	}
+	dm.rm = rm
+	dm.segment.messages[dm] = emptyStruct
+}
+
+func (b *diskBuffer) onFinalize(dm *diskMessage, r producer.FinalizeReason) {
+	b.Lock()
+	defer b.Unlock()
+
+	seg := dm.segment
+	dm.finalized = true
+	delete(seg.messages, dm)
+	b.inMemory -= int64(dm.record.size)
+	switch {
+	case r == producer.Consumed:
+		seg.numPending--
+	case seg.dropped:
+		// The segment was dropped for exceeding the disk limits.
+	case dm.abandoned:
+		// The message failed to be produced too many times after being
+		// replayed, it is not replayed again.
+		seg.numPending--
+		b.m.messageReplayDropped.Inc(1)
+	case b.isClosed:
+		// NB: The message is dropped from memory as the buffer is closing, it
+		// remains pending so it is produced again once the buffer is reopened.
+	default:
+		// The message was evicted from memory, it is produced again from the
+		// segment file once there is room in memory.
+		seg.spilled = append(seg.spilled, dm.record)
+		b.m.messageSpilled.Inc(1)
+	}
+}
+
+func (b *diskBuffer) Init() {
+	b.buffer.Init()
+
+	b.wg.Add(1)
+	go func() {
+		b.cleanupUntilClose()
+		b.wg.Done()
+	}()
+}
+
+func (b *diskBuffer) Replay(fn producer.ReplayFn) error {
+	b.Lock()
+	b.replayFn = fn
+	b.Unlock()
+	return nil
+}
+
+func (b *diskBuffer) cleanupUntilClose() {
+	ticker := time.NewTicker(b.opts.CleanupInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.cleanup()
+			b.replay()
+		case <-b.doneCh:
+			return
+		}
+	}
+}
+
+// cleanup deletes the segments whose messages have all been consumed and
+// drops the oldest segments exceeding the disk limits.
+func (b *diskBuffer) cleanup() {
+	var (
+		now      = b.nowFn()
+		maxAge   = b.opts.MaxSegmentAge()
+		dropped  []*producer.RefCountedMessage
+		toDelete []*diskSegment
+	)
+
+	b.Lock()
+	var diskSize int64
+	for _, seg := range b.segments {
+		diskSize += seg.size
+	}
+
+	active := b.segments[len(b.segments)-1]
+	if active.fd != nil {
+		if err := active.fd.Sync(); err != nil {
+			b.m.persistErrors.Inc(1)
+		}
+	}
+
+	segments := make([]*diskSegment, 0, len(b.segments))
+	for _, seg := range b.segments[:len(b.segments)-1] {
+		switch {
+		case seg.numPending <= 0:
+			b.m.segmentsDeleted.Inc(1)
+		case maxAge > 0 && now.Sub(seg.createdAt) > maxAge:
+			b.m.segmentsDroppedAge.Inc(1)
+			dropped = b.dropSegmentWithLock(seg, dropped)
+		case diskSize > b.opts.MaxDiskSize():
+			b.m.segmentsDropped.Inc(1)
+			dropped = b.dropSegmentWithLock(seg, dropped)
+		default:
+			segments = append(segments, seg)
+			continue
+		}
+		diskSize -= seg.size
+		toDelete = append(toDelete, seg)
+	}
+	b.segments = append(segments, active)
+
+	var numPending int
+	for _, seg := range b.segments {
+		numPending += seg.numPending
+	}
+	b.m.segments.Update(float64(len(b.segments)))
+	b.m.diskBytes.Update(float64(diskSize))
+	b.m.messagePending.Update(float64(numPending))
+	b.Unlock()
+
+	for _, rm := range dropped {
+		rm.Drop()
+	}
+	for _, seg := range toDelete {
+		if err := os.Remove(seg.path); err != nil && !os.IsNotExist(err) {
+			b.m.persistErrors.Inc(1)
+		}
+	}
+}
+
+func (b *diskBuffer) dropSegmentWithLock(
+	seg *diskSegment,
+	dropped []*producer.RefCountedMessage,
+) []*producer.RefCountedMessage {
+	seg.dropped = true
+	seg.spilled = nil
+	b.m.messageDroppedOnDisk.Inc(int64(seg.numPending))
+	for dm := range seg.messages {
+		dropped = append(dropped, dm.rm)
+	}
+	return dropped
+}
+
+// replay produces the messages spilled to disk while there is room for
+// them in memory.
+func (b *diskBuffer) replay() {
+	b.Lock()
+	if b.replayFn == nil || b.isClosed {
+		b.Unlock()
+		return
+	}
+	var (
+		fn       = b.replayFn
+		nowNanos = b.nowFn().UnixNano()
+		budget   = b.replayBudget - b.inMemory
+		records  = make(map[*diskSegment][]spilledRecord)
+		order    []*diskSegment
+	)
+	for _, seg := range b.segments {
+		var (
+			replayed []spilledRecord
+			n        int
+		)
+		// NB: records backing off after failing to be produced are skipped
+		// and keep their place in the segment.
+		for _, record := range seg.spilled {
+			if budget > 0 && record.replayAfterNanos <= nowNanos {
+				budget -= int64(record.size)
+				replayed = append(replayed, record)
+				continue
+			}
+			seg.spilled[n] = record
+			n++
+		}
+		if len(replayed) == 0 {
+			continue
+		}
+		seg.spilled = seg.spilled[:n]
+		records[seg] = replayed
+		order = append(order, seg)
+		b.inMemory += int64(spilledSize(replayed))
+	}
+	b.Unlock()
+
+	for _, seg := range order {
+		b.replaySegment(seg, records[seg], fn)
+	}
+}
+
+func (b *diskBuffer) replaySegment(
+	seg *diskSegment,
+	records []spilledRecord,
+	fn producer.ReplayFn,
+) {
+	fd, err := os.Open(seg.path)
+	if err != nil {
+		b.m.replayErrors.Inc(1)
+		b.abandonRecords(seg, records)
+		return
+	}
+	defer fd.Close()
+
+	for i, record := range records {
+		b.Lock()
+		dropped := seg.dropped
+		b.Unlock()
+		if dropped {
+			// The segment was dropped for exceeding the disk limits.
+			b.abandonRecords(seg, records[i:])
+			return
+		}
+
+		shard, data, err := readRecordAt(fd, record.offset)
+		if err != nil {
+			b.m.replayErrors.Inc(1)
+			b.abandonRecords(seg, records[i:i+1])
+			continue
+		}
+		dm := &diskMessage{
+			Message: &replayedMessage{shard: shard, bytes: data},
+			buffer:  b,
+			segment: seg,
+			record:  record,
+		}
+		rm, err := b.buffer.Add(dm)
+		if err != nil {
+			// NB: There is no more room in memory, the remaining records are
+			// replayed on a later tick.
+			b.Lock()
+			b.inMemory -= int64(spilledSize(records[i:]))
+			if !seg.dropped {
+				seg.spilled = append(records[i:len(records):len(records)], seg.spilled...)
+			}
+			b.Unlock()
+			return
+		}
+		b.Lock()
+		b.registerWithLock(dm, rm)
+		b.Unlock()
+
+		b.m.messageReplayed.Inc(1)
+		if err := fn(rm); err != nil {
+			// NB: Dropping the message spills it to disk again to be replayed
+			// after backing off, unless it failed too many times.
+			b.m.replayErrors.Inc(1)
+			b.Lock()
+			b.backoffReplayWithLock(dm)
+			b.Unlock()
+			rm.Drop()
+		}
+	}
+}
+
+func (b *diskBuffer) backoffReplayWithLock(dm *diskMessage) {
+	dm.record.replayAttempts++
+	if dm.record.replayAttempts > b.replayRetry.MaxRetries() {
+		dm.abandoned = true
+		return
+	}
+	backoff := retry.BackoffNanos(
+		dm.record.replayAttempts,
+		b.replayRetry.Jitter(),
+		b.replayRetry.BackoffFactor(),
+		b.replayRetry.InitialBackoff(),
+		b.replayRetry.MaxBackoff(),
+		rand.Int63n,
+	)
+	dm.record.replayAfterNanos = b.nowFn().UnixNano() + backoff
+}
+
+// abandonRecords gives up on records that can not be read back.
+func (b *diskBuffer) abandonRecords(seg *diskSegment, records []spilledRecord) {
+	b.Lock()
+	seg.numPending -= len(records)
+	b.inMemory -= int64(spilledSize(records))
+	b.Unlock()
+}
+
+func spilledSize(records []spilledRecord) int {
+	var size int
+	for _, r := range records {
+		size += r.size
+	}
+	return size
+}
+
+func (b *diskBuffer) Close(ct producer.CloseType) {
+	// Stop taking writes right away.
+	b.Lock()
+	if b.isClosed {
+		b.Unlock()
+		return
+	}
+	b.isClosed = true
+	b.Unlock()
+
+	// NB: Messages dropped from memory while closing remain in the segment
+	// files to be produced again once the buffer is reopened.
+	b.buffer.Close(ct)
+	close(b.doneCh)
+	b.wg.Wait()
+
+	b.cleanup()
+	b.Lock()
+	active := b.segments[len(b.segments)-1]
+	if err := active.seal(); err != nil {
+		b.m.persistErrors.Inc(1)
+	}
+	if active.numPending <= 0 {
+		if err := os.Remove(active.path); err != nil {
+			b.m.persistErrors.Inc(1)
+		}
+	}
+	b.Unlock()
+}
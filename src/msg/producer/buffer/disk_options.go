@@ -0,0 +1,183 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package buffer
+
+import (
+	"errors"
+	"time"
+
+	"github.com/m3db/m3x/instrument"
+	"github.com/m3db/m3x/retry"
+)
+
+const (
+	defaultMaxSegmentSize  = 64 * 1024 * 1024   // 64MB.
+	defaultMaxDiskSize     = 1024 * 1024 * 1024 // 1GB.
+	defaultCleanupInterval = time.Second
+
+	defaultReplayInitialBackoff = time.Second
+	defaultReplayMaxBackoff     = time.Minute
+	defaultReplayMaxRetries     = 10
+)
+
+var (
+	errNoDiskPath             = errors.New("no disk buffer path")
+	errInvalidMaxSegmentSize  = errors.New("invalid max segment size")
+	errInvalidMaxDiskSize     = errors.New("max disk size smaller than max segment size")
+	errNegativeMaxSegmentAge  = errors.New("negative max segment age")
+	errInvalidCleanupInterval = errors.New("invalid cleanup interval")
+	errNoBufferOptions        = errors.New("no buffer options")
+	errNoReplayRetryOptions   = errors.New("no replay retry options")
+)
+
+type diskOptions struct {
+	bufferOpts      Options
+	path            string
+	maxSegmentSize  int64
+	maxDiskSize     int64
+	maxSegmentAge   time.Duration
+	cleanupInterval time.Duration
+	replayRetryOpts retry.Options
+	iOpts           instrument.Options
+}
+
+// NewDiskOptions creates DiskOptions.
+func NewDiskOptions() DiskOptions {
+	return &diskOptions{
+		bufferOpts:      NewOptions(),
+		maxSegmentSize:  defaultMaxSegmentSize,
+		maxDiskSize:     defaultMaxDiskSize,
+		cleanupInterval: defaultCleanupInterval,
+		replayRetryOpts: retry.NewOptions().
+			SetInitialBackoff(defaultReplayInitialBackoff).
+			SetMaxBackoff(defaultReplayMaxBackoff).
+			SetMaxRetries(defaultReplayMaxRetries),
+		iOpts: instrument.NewOptions(),
+	}
+}
+
+func (opts *diskOptions) BufferOptions() Options {
+	return opts.bufferOpts
+}
+
+func (opts *diskOptions) SetBufferOptions(value Options) DiskOptions {
+	o := *opts
+	o.bufferOpts = value
+	return &o
+}
+
+func (opts *diskOptions) Path() string {
+	return opts.path
+}
+
+func (opts *diskOptions) SetPath(value string) DiskOptions {
+	o := *opts
+	o.path = value
+	return &o
+}
+
+func (opts *diskOptions) MaxSegmentSize() int64 {
+	return opts.maxSegmentSize
+}
+
+func (opts *diskOptions) SetMaxSegmentSize(value int64) DiskOptions {
+	o := *opts
+	o.maxSegmentSize = value
+	return &o
+}
+
+func (opts *diskOptions) MaxDiskSize() int64 {
+	return opts.maxDiskSize
+}
+
+func (opts *diskOptions) SetMaxDiskSize(value int64) DiskOptions {
+	o := *opts
+	o.maxDiskSize = value
+	return &o
+}
+
+func (opts *diskOptions) MaxSegmentAge() time.Duration {
+	return opts.maxSegmentAge
+}
+
+func (opts *diskOptions) SetMaxSegmentAge(value time.Duration) DiskOptions {
+	o := *opts
+	o.maxSegmentAge = value
+	return &o
+}
+
+func (opts *diskOptions) CleanupInterval() time.Duration {
+	return opts.cleanupInterval
+}
+
+func (opts *diskOptions) SetCleanupInterval(value time.Duration) DiskOptions {
+	o := *opts
+	o.cleanupInterval = value
+	return &o
+}
+
+func (opts *diskOptions) ReplayRetryOptions() retry.Options {
+	return opts.replayRetryOpts
+}
+
+func (opts *diskOptions) SetReplayRetryOptions(value retry.Options) DiskOptions {
+	o := *opts
+	o.replayRetryOpts = value
+	return &o
+}
+
+func (opts *diskOptions) InstrumentOptions() instrument.Options {
+	return opts.iOpts
+}
+
+func (opts *diskOptions) SetInstrumentOptions(value instrument.Options) DiskOptions {
+	o := *opts
+	o.iOpts = value
+	return &o
+}
+
+func (opts *diskOptions) Validate() error {
+	if opts.BufferOptions() == nil {
+		return errNoBufferOptions
+	}
+	if err := opts.BufferOptions().Validate(); err != nil {
+		return err
+	}
+	if opts.Path() == "" {
+		return errNoDiskPath
+	}
+	if opts.MaxSegmentSize() <= 0 {
+		return errInvalidMaxSegmentSize
+	}
+	if opts.MaxDiskSize() < opts.MaxSegmentSize() {
+		return errInvalidMaxDiskSize
+	}
+	if opts.MaxSegmentAge() < 0 {
+		return errNegativeMaxSegmentAge
+	}
+	if opts.CleanupInterval() <= 0 {
+		return errInvalidCleanupInterval
+	}
+	if opts.ReplayRetryOptions() == nil {
+		return errNoReplayRetryOptions
+	}
+	return nil
+}
@@ -0,0 +1,302 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package buffer
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	segmentFilePrefix = "segment-"
+	segmentFileSuffix = ".db"
+
+	// segmentMagic identifies a segment file, followed by the creation time
+	// of the segment in unix nanoseconds.
+	segmentMagic     uint32 = 0x6d336d67
+	segmentHeaderLen        = 12
+
+	// recordHeaderLen is the length of the header of a record made of the
+	// length of the payload and its checksum, the payload is made of the
+	// shard of the message followed by its bytes.
+	recordHeaderLen = 8
+	recordShardLen  = 4
+
+	newFileMode      = 0666
+	newDirectoryMode = 0755
+)
+
+var (
+	errInvalidSegmentHeader = errors.New("invalid segment file header")
+	errCorruptRecord        = errors.New("corrupt segment record")
+)
+
+// spilledRecord is a persisted message which is not held in memory.
+type spilledRecord struct {
+	offset int64
+	size   int
+
+	// replayAttempts is the number of times the message failed to be
+	// produced after being replayed, it is not replayed again before
+	// replayAfterNanos.
+	replayAttempts   int
+	replayAfterNanos int64
+}
+
+// diskSegment is a segment file persisting messages appended to it in order.
+type diskSegment struct {
+	id        uint64
+	path      string
+	createdAt time.Time
+	size      int64
+	fd        *os.File
+	failed    bool
+
+	// numPending is the number of messages of the segment not yet consumed,
+	// the segment is deleted once it reaches zero.
+	numPending int
+	spilled    []spilledRecord
+	messages   map[*diskMessage]struct{}
+	dropped    bool
+}
+
+func segmentFilePath(dir string, id uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("%s%020d%s", segmentFilePrefix, id, segmentFileSuffix))
+}
+
+// segmentFileIDs returns the IDs of the segment files in the directory in
+// ascending order.
+func segmentFileIDs(dir string) ([]uint64, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var ids []uint64
+	for _, f := range files {
+		name := f.Name()
+		if f.IsDir() ||
+			!strings.HasPrefix(name, segmentFilePrefix) ||
+			!strings.HasSuffix(name, segmentFileSuffix) {
+			continue
+		}
+		str := strings.TrimSuffix(strings.TrimPrefix(name, segmentFilePrefix), segmentFileSuffix)
+		id, err := strconv.ParseUint(str, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+func newDiskSegment(id uint64, path string, createdAt time.Time) *diskSegment {
+	return &diskSegment{
+		id:        id,
+		path:      path,
+		createdAt: createdAt,
+		messages:  make(map[*diskMessage]struct{}),
+	}
+}
+
+// createSegment creates a new segment file to append messages to.
+func createSegment(dir string, id uint64, now time.Time) (*diskSegment, error) {
+	path := segmentFilePath(dir, id)
+	fd, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, newFileMode)
+	if err != nil {
+		return nil, err
+	}
+	var header [segmentHeaderLen]byte
+	binary.BigEndian.PutUint32(header[:4], segmentMagic)
+	binary.BigEndian.PutUint64(header[4:], uint64(now.UnixNano()))
+	if _, err := fd.Write(header[:]); err != nil {
+		fd.Close()
+		os.Remove(path)
+		return nil, err
+	}
+	// NB: the directory is synced too so the segment file is not lost on a
+	// crash once messages are appended to it.
+	if err := fd.Sync(); err != nil {
+		fd.Close()
+		os.Remove(path)
+		return nil, err
+	}
+	if err := syncDir(dir); err != nil {
+		fd.Close()
+		os.Remove(path)
+		return nil, err
+	}
+	seg := newDiskSegment(id, path, now)
+	seg.fd = fd
+	seg.size = segmentHeaderLen
+	return seg, nil
+}
+
+func syncDir(dir string) error {
+	df, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	syncErr := df.Sync()
+	closeErr := df.Close()
+	if syncErr != nil {
+		return syncErr
+	}
+	return closeErr
+}
+
+// readSegment reads the records of an existing segment file, all of which
+// are considered spilled. A truncated or corrupt record ends the segment.
+func readSegment(dir string, id uint64) (*diskSegment, bool, error) {
+	path := segmentFilePath(dir, id)
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, false, err
+	}
+	defer fd.Close()
+
+	r := bufio.NewReader(fd)
+	var header [segmentHeaderLen]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, false, errInvalidSegmentHeader
+	}
+	if binary.BigEndian.Uint32(header[:4]) != segmentMagic {
+		return nil, false, errInvalidSegmentHeader
+	}
+	createdAt := time.Unix(0, int64(binary.BigEndian.Uint64(header[4:])))
+
+	var (
+		seg     = newDiskSegment(id, path, createdAt)
+		offset  = int64(segmentHeaderLen)
+		payload []byte
+		corrupt bool
+	)
+	for {
+		var recordHeader [recordHeaderLen]byte
+		if _, err := io.ReadFull(r, recordHeader[:]); err != nil {
+			corrupt = err != io.EOF
+			break
+		}
+		payload, err = readRecordPayload(r, recordHeader, payload)
+		if err != nil {
+			corrupt = true
+			break
+		}
+		seg.spilled = append(seg.spilled, spilledRecord{
+			offset: offset,
+			size:   len(payload) - recordShardLen,
+		})
+		offset += int64(recordHeaderLen + len(payload))
+	}
+	seg.size = offset
+	seg.numPending = len(seg.spilled)
+	return seg, corrupt, nil
+}
+
+func readRecordPayload(
+	r io.Reader,
+	header [recordHeaderLen]byte,
+	buf []byte,
+) ([]byte, error) {
+	var (
+		length   = binary.BigEndian.Uint32(header[:4])
+		checksum = binary.BigEndian.Uint32(header[4:])
+	)
+	if length < recordShardLen {
+		return nil, errCorruptRecord
+	}
+	if cap(buf) < int(length) {
+		buf = make([]byte, length)
+	}
+	buf = buf[:length]
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	if crc32.ChecksumIEEE(buf) != checksum {
+		return nil, errCorruptRecord
+	}
+	return buf, nil
+}
+
+// readRecordAt reads the shard and bytes of the message persisted at the
+// offset of the segment file.
+func readRecordAt(fd *os.File, offset int64) (uint32, []byte, error) {
+	var header [recordHeaderLen]byte
+	if _, err := fd.ReadAt(header[:], offset); err != nil {
+		return 0, nil, err
+	}
+	r := io.NewSectionReader(fd, offset+recordHeaderLen,
+		int64(binary.BigEndian.Uint32(header[:4])))
+	payload, err := readRecordPayload(r, header, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	return binary.BigEndian.Uint32(payload[:recordShardLen]), payload[recordShardLen:], nil
+}
+
+// append persists the message to the segment file and returns its offset.
+func (s *diskSegment) append(shard uint32, data []byte, buf []byte) (int64, []byte, error) {
+	length := recordShardLen + len(data)
+	if cap(buf) < recordHeaderLen+length {
+		buf = make([]byte, recordHeaderLen+length)
+	}
+	buf = buf[:recordHeaderLen+length]
+	binary.BigEndian.PutUint32(buf[recordHeaderLen:], shard)
+	copy(buf[recordHeaderLen+recordShardLen:], data)
+	binary.BigEndian.PutUint32(buf[:4], uint32(length))
+	binary.BigEndian.PutUint32(buf[4:8], crc32.ChecksumIEEE(buf[recordHeaderLen:]))
+
+	offset := s.size
+	n, err := s.fd.Write(buf)
+	s.size += int64(n)
+	if err != nil {
+		// NB: a partially written record ends the segment when read back so
+		// no more records are appended to it.
+		s.failed = true
+		return 0, buf, err
+	}
+	return offset, buf, nil
+}
+
+// seal syncs and closes the segment file, no more messages are appended.
+func (s *diskSegment) seal() error {
+	if s.fd == nil {
+		return nil
+	}
+	syncErr := s.fd.Sync()
+	closeErr := s.fd.Close()
+	s.fd = nil
+	if syncErr != nil {
+		return syncErr
+	}
+	return closeErr
+}
@@ -0,0 +1,378 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package buffer
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/msg/producer"
+	"github.com/m3db/m3x/retry"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testMessage struct {
+	sync.Mutex
+
+	shard     uint32
+	bytes     []byte
+	finalized []producer.FinalizeReason
+}
+
+func newTestMessage(shard uint32, bytes string) *testMessage {
+	return &testMessage{shard: shard, bytes: []byte(bytes)}
+}
+
+func (m *testMessage) Shard() uint32 { return m.shard }
+func (m *testMessage) Bytes() []byte { return m.bytes }
+func (m *testMessage) Size() int     { return len(m.bytes) }
+
+func (m *testMessage) Finalize(r producer.FinalizeReason) {
+	m.Lock()
+	m.finalized = append(m.finalized, r)
+	m.Unlock()
+}
+
+type replayedMessages struct {
+	sync.Mutex
+
+	messages []*producer.RefCountedMessage
+}
+
+func (r *replayedMessages) replay(rm *producer.RefCountedMessage) error {
+	r.Lock()
+	r.messages = append(r.messages, rm)
+	r.Unlock()
+	return nil
+}
+
+func (r *replayedMessages) bytes() []string {
+	r.Lock()
+	defer r.Unlock()
+	var res []string
+	for _, rm := range r.messages {
+		res = append(res, string(rm.Bytes()))
+	}
+	return res
+}
+
+func TestDiskOptionsValidation(t *testing.T) {
+	opts := NewDiskOptions()
+	require.Equal(t, errNoDiskPath, opts.Validate())
+
+	opts = opts.SetPath("/tmp")
+	require.NoError(t, opts.Validate())
+
+	opts = opts.SetMaxSegmentSize(0)
+	require.Equal(t, errInvalidMaxSegmentSize, opts.Validate())
+
+	opts = opts.SetMaxSegmentSize(100).SetMaxDiskSize(10)
+	require.Equal(t, errInvalidMaxDiskSize, opts.Validate())
+
+	opts = opts.SetMaxDiskSize(100).SetMaxSegmentAge(-time.Second)
+	require.Equal(t, errNegativeMaxSegmentAge, opts.Validate())
+
+	opts = opts.SetMaxSegmentAge(0).SetCleanupInterval(0)
+	require.Equal(t, errInvalidCleanupInterval, opts.Validate())
+
+	opts = opts.SetCleanupInterval(time.Second).SetReplayRetryOptions(nil)
+	require.Equal(t, errNoReplayRetryOptions, opts.Validate())
+
+	opts = opts.SetReplayRetryOptions(retry.NewOptions()).SetBufferOptions(NewOptions().SetScanBatchSize(0))
+	require.Equal(t, errInvalidScanBatchSize, opts.Validate())
+}
+
+func TestDiskBufferReplayAfterRestart(t *testing.T) {
+	dir := newTestDiskBufferDir(t)
+	defer os.RemoveAll(dir)
+
+	b := mustNewDiskBuffer(t, testDiskOptions(dir))
+	b.Init()
+
+	m1, m2, m3 := newTestMessage(1, "foo"), newTestMessage(2, "bar"), newTestMessage(3, "baz")
+	rm1, err := b.Add(m1)
+	require.NoError(t, err)
+	_, err = b.Add(m2)
+	require.NoError(t, err)
+	_, err = b.Add(m3)
+	require.NoError(t, err)
+
+	// Consume one message, the segment is still pending.
+	rm1.IncRef()
+	rm1.DecRef()
+	require.Equal(t, []producer.FinalizeReason{producer.Consumed}, m1.finalized)
+
+	b.Close(producer.DropEverything)
+	require.Equal(t, []producer.FinalizeReason{producer.Dropped}, m2.finalized)
+	require.Equal(t, 1, numSegmentFiles(t, dir))
+
+	b = mustNewDiskBuffer(t, testDiskOptions(dir))
+	b.Init()
+	defer b.Close(producer.DropEverything)
+
+	// NB: The messages of a partially consumed segment are all replayed.
+	var replayed replayedMessages
+	require.NoError(t, b.Replay(replayed.replay))
+	b.replay()
+	require.Equal(t, []string{"foo", "bar", "baz"}, replayed.bytes())
+	require.Equal(t, uint32(2), replayed.messages[1].Shard())
+
+	// Once all the replayed messages are consumed the segment is deleted.
+	for _, rm := range replayed.messages {
+		rm.IncRef()
+		rm.DecRef()
+	}
+	b.cleanup()
+	require.Equal(t, 1, numSegmentFiles(t, dir))
+	require.Equal(t, 1, len(b.segments))
+}
+
+func TestDiskBufferReplayBackoffAndDrop(t *testing.T) {
+	dir := newTestDiskBufferDir(t)
+	defer os.RemoveAll(dir)
+
+	b := mustNewDiskBuffer(t, testDiskOptions(dir))
+	b.Init()
+	_, err := b.Add(newTestMessage(1, "foo"))
+	require.NoError(t, err)
+	b.Close(producer.DropEverything)
+
+	rOpts := retry.NewOptions().
+		SetInitialBackoff(time.Minute).
+		SetMaxBackoff(time.Hour).
+		SetJitter(false).
+		SetMaxRetries(1)
+	b = mustNewDiskBuffer(t, testDiskOptions(dir).SetReplayRetryOptions(rOpts))
+	now := time.Now()
+	b.nowFn = func() time.Time { return now }
+	b.Init()
+	defer b.Close(producer.DropEverything)
+
+	var numReplays int
+	require.NoError(t, b.Replay(func(rm *producer.RefCountedMessage) error {
+		numReplays++
+		return errors.New("replay error")
+	}))
+
+	// The message is not replayed again until it backed off.
+	b.replay()
+	require.Equal(t, 1, numReplays)
+	b.replay()
+	require.Equal(t, 1, numReplays)
+
+	// The message is dropped once it failed more than the max retries.
+	now = now.Add(time.Minute)
+	b.replay()
+	require.Equal(t, 2, numReplays)
+	now = now.Add(time.Hour)
+	b.replay()
+	require.Equal(t, 2, numReplays)
+
+	b.Lock()
+	require.Equal(t, 0, b.segments[0].numPending)
+	require.Empty(t, b.segments[0].spilled)
+	require.Equal(t, int64(0), b.inMemory)
+	b.Unlock()
+}
+
+func TestDiskBufferDeletesConsumedSegments(t *testing.T) {
+	dir := newTestDiskBufferDir(t)
+	defer os.RemoveAll(dir)
+
+	b := mustNewDiskBuffer(t, testDiskOptions(dir).SetMaxSegmentSize(1))
+	b.Init()
+	defer b.Close(producer.DropEverything)
+
+	var rms []*producer.RefCountedMessage
+	for _, str := range []string{"foo", "bar", "baz"} {
+		rm, err := b.Add(newTestMessage(1, str))
+		require.NoError(t, err)
+		rms = append(rms, rm)
+	}
+	require.Equal(t, 3, numSegmentFiles(t, dir))
+
+	rms[0].IncRef()
+	rms[0].DecRef()
+	b.cleanup()
+	require.Equal(t, 2, numSegmentFiles(t, dir))
+
+	// The active segment is kept until it is rotated out.
+	rms[1].IncRef()
+	rms[1].DecRef()
+	rms[2].IncRef()
+	rms[2].DecRef()
+	b.cleanup()
+	require.Equal(t, 1, numSegmentFiles(t, dir))
+}
+
+func TestDiskBufferReplaySpilledMessages(t *testing.T) {
+	dir := newTestDiskBufferDir(t)
+	defer os.RemoveAll(dir)
+
+	bOpts := testOptions().
+		SetMaxBufferSize(6).
+		SetMaxMessageSize(6).
+		SetAllowedSpilloverRatio(0)
+	b := mustNewDiskBuffer(t, testDiskOptions(dir).SetBufferOptions(bOpts))
+	b.Init()
+	defer b.Close(producer.DropEverything)
+
+	var replayed replayedMessages
+	require.NoError(t, b.Replay(replayed.replay))
+
+	m1 := newTestMessage(1, "foo")
+	_, err := b.Add(m1)
+	require.NoError(t, err)
+	rm2, err := b.Add(newTestMessage(1, "bar"))
+	require.NoError(t, err)
+
+	// The oldest message is evicted from memory to make room.
+	rm3, err := b.Add(newTestMessage(1, "baz"))
+	require.NoError(t, err)
+	require.Equal(t, []producer.FinalizeReason{producer.Dropped}, m1.finalized)
+
+	// No room in memory to replay the evicted message.
+	b.replay()
+	require.Empty(t, replayed.bytes())
+
+	rm2.IncRef()
+	rm2.DecRef()
+	rm3.IncRef()
+	rm3.DecRef()
+	b.replay()
+	require.Equal(t, []string{"foo"}, replayed.bytes())
+
+	replayed.messages[0].IncRef()
+	replayed.messages[0].DecRef()
+	b.Lock()
+	require.Equal(t, 0, b.segments[0].numPending)
+	require.Equal(t, int64(0), b.inMemory)
+	b.Unlock()
+}
+
+func TestDiskBufferDropsSegmentsOverMaxDiskSize(t *testing.T) {
+	dir := newTestDiskBufferDir(t)
+	defer os.RemoveAll(dir)
+
+	opts := testDiskOptions(dir).
+		SetMaxSegmentSize(1).
+		SetMaxDiskSize(2 * (segmentHeaderLen + recordHeaderLen + recordShardLen + 3))
+	b := mustNewDiskBuffer(t, opts)
+	b.Init()
+	defer b.Close(producer.DropEverything)
+
+	var rms []*producer.RefCountedMessage
+	for _, str := range []string{"foo", "bar", "baz"} {
+		rm, err := b.Add(newTestMessage(1, str))
+		require.NoError(t, err)
+		rms = append(rms, rm)
+	}
+
+	b.cleanup()
+	require.Equal(t, 2, numSegmentFiles(t, dir))
+	require.True(t, rms[0].IsDroppedOrConsumed())
+	require.False(t, rms[1].IsDroppedOrConsumed())
+	require.False(t, rms[2].IsDroppedOrConsumed())
+}
+
+func TestDiskBufferDropsSegmentsOverMaxAge(t *testing.T) {
+	dir := newTestDiskBufferDir(t)
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	b := mustNewDiskBuffer(t, testDiskOptions(dir).
+		SetMaxSegmentSize(1).
+		SetMaxSegmentAge(time.Minute))
+	b.nowFn = func() time.Time { return now }
+	b.Init()
+	defer b.Close(producer.DropEverything)
+
+	rm1, err := b.Add(newTestMessage(1, "foo"))
+	require.NoError(t, err)
+	now = now.Add(2 * time.Minute)
+	rm2, err := b.Add(newTestMessage(1, "bar"))
+	require.NoError(t, err)
+	_, err = b.Add(newTestMessage(1, "baz"))
+	require.NoError(t, err)
+
+	b.cleanup()
+	require.True(t, rm1.IsDroppedOrConsumed())
+	require.False(t, rm2.IsDroppedOrConsumed())
+	require.Equal(t, 2, numSegmentFiles(t, dir))
+}
+
+func TestDiskBufferCorruptSegmentTail(t *testing.T) {
+	dir := newTestDiskBufferDir(t)
+	defer os.RemoveAll(dir)
+
+	b := mustNewDiskBuffer(t, testDiskOptions(dir))
+	b.Init()
+	_, err := b.Add(newTestMessage(1, "foo"))
+	require.NoError(t, err)
+	_, err = b.Add(newTestMessage(1, "bar"))
+	require.NoError(t, err)
+	path := b.segments[0].path
+	b.Close(producer.DropEverything)
+
+	// Truncate the last record as if the process crashed while writing it.
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, info.Size()-1))
+
+	b = mustNewDiskBuffer(t, testDiskOptions(dir))
+	b.Init()
+	defer b.Close(producer.DropEverything)
+
+	var replayed replayedMessages
+	require.NoError(t, b.Replay(replayed.replay))
+	b.replay()
+	require.Equal(t, []string{"foo"}, replayed.bytes())
+}
+
+func newTestDiskBufferDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "m3msg-disk-buffer")
+	require.NoError(t, err)
+	return dir
+}
+
+func numSegmentFiles(t *testing.T, dir string) int {
+	ids, err := segmentFileIDs(dir)
+	require.NoError(t, err)
+	return len(ids)
+}
+
+func mustNewDiskBuffer(t *testing.T, opts DiskOptions) *diskBuffer {
+	b, err := NewDiskBuffer(opts)
+	require.NoError(t, err)
+	return b.(*diskBuffer)
+}
+
+func testDiskOptions(dir string) DiskOptions {
+	return NewDiskOptions().
+		SetPath(dir).
+		SetBufferOptions(testOptions()).
+		SetCleanupInterval(time.Hour)
+}
@@ -105,3 +105,71 @@ type Options interface {
 	// Validate validates the options.
 	Validate() error
 }
+
+// DiskOptions configs the disk buffer.
+type DiskOptions interface {
+	// BufferOptions returns the options of the in memory buffer holding the
+	// messages being produced.
+	BufferOptions() Options
+
+	// SetBufferOptions sets the options of the in memory buffer holding the
+	// messages being produced.
+	SetBufferOptions(value Options) DiskOptions
+
+	// Path returns the directory of the segment files.
+	Path() string
+
+	// SetPath sets the directory of the segment files.
+	SetPath(value string) DiskOptions
+
+	// MaxSegmentSize returns the size a segment file grows to before a new
+	// segment file is started.
+	MaxSegmentSize() int64
+
+	// SetMaxSegmentSize sets the size a segment file grows to before a new
+	// segment file is started.
+	SetMaxSegmentSize(value int64) DiskOptions
+
+	// MaxDiskSize returns the max size of all segment files, the oldest
+	// segments are dropped once it is exceeded.
+	MaxDiskSize() int64
+
+	// SetMaxDiskSize sets the max size of all segment files, the oldest
+	// segments are dropped once it is exceeded.
+	SetMaxDiskSize(value int64) DiskOptions
+
+	// MaxSegmentAge returns the max age of a segment file before it is
+	// dropped, segments are never dropped for their age if zero.
+	MaxSegmentAge() time.Duration
+
+	// SetMaxSegmentAge sets the max age of a segment file before it is
+	// dropped, segments are never dropped for their age if zero.
+	SetMaxSegmentAge(value time.Duration) DiskOptions
+
+	// CleanupInterval returns the interval to delete consumed segments,
+	// enforce the disk limits and replay messages spilled to disk.
+	CleanupInterval() time.Duration
+
+	// SetCleanupInterval sets the interval to delete consumed segments,
+	// enforce the disk limits and replay messages spilled to disk.
+	SetCleanupInterval(value time.Duration) DiskOptions
+
+	// ReplayRetryOptions returns the options to back off replaying a message
+	// which failed to be produced, the message is dropped once it failed to
+	// be produced more than the max retries.
+	ReplayRetryOptions() retry.Options
+
+	// SetReplayRetryOptions sets the options to back off replaying a message
+	// which failed to be produced, the message is dropped once it failed to
+	// be produced more than the max retries.
+	SetReplayRetryOptions(value retry.Options) DiskOptions
+
+	// InstrumentOptions returns the instrument options.
+	InstrumentOptions() instrument.Options
+
+	// SetInstrumentOptions sets the instrument options.
+	SetInstrumentOptions(value instrument.Options) DiskOptions
+
+	// Validate validates the options.
+	Validate() error
+}
@@ -23,6 +23,7 @@ package config
 import (
 	"time"
 
+	"github.com/m3db/m3/src/msg/producer"
 	"github.com/m3db/m3/src/msg/producer/buffer"
 	"github.com/m3db/m3x/instrument"
 	"github.com/m3db/m3x/retry"
@@ -30,14 +31,15 @@ import (
 
 // BufferConfiguration configs the buffer.
 type BufferConfiguration struct {
-	OnFullStrategy        *buffer.OnFullStrategy `yaml:"onFullStrategy"`
-	MaxBufferSize         *int                   `yaml:"maxBufferSize"`
-	MaxMessageSize        *int                   `yaml:"maxMessageSize"`
-	CloseCheckInterval    *time.Duration         `yaml:"closeCheckInterval"`
-	DropOldestInterval    *time.Duration         `yaml:"dropOldestInterval"`
-	ScanBatchSize         *int                   `yaml:"scanBatchSize"`
-	AllowedSpilloverRatio *float64               `yaml:"allowedSpilloverRatio"`
-	CleanupRetry          *retry.Configuration   `yaml:"cleanupRetry"`
+	OnFullStrategy        *buffer.OnFullStrategy   `yaml:"onFullStrategy"`
+	MaxBufferSize         *int                     `yaml:"maxBufferSize"`
+	MaxMessageSize        *int                     `yaml:"maxMessageSize"`
+	CloseCheckInterval    *time.Duration           `yaml:"closeCheckInterval"`
+	DropOldestInterval    *time.Duration           `yaml:"dropOldestInterval"`
+	ScanBatchSize         *int                     `yaml:"scanBatchSize"`
+	AllowedSpilloverRatio *float64                 `yaml:"allowedSpilloverRatio"`
+	CleanupRetry          *retry.Configuration     `yaml:"cleanupRetry"`
+	Disk                  *DiskBufferConfiguration `yaml:"disk"`
 }
 
 // NewOptions creates new buffer options.
@@ -69,3 +71,49 @@ func (c *BufferConfiguration) NewOptions(iOpts instrument.Options) buffer.Option
 	}
 	return opts.SetInstrumentOptions(iOpts)
 }
+
+// NewBuffer creates a new buffer, the buffer persists messages to disk if
+// the disk buffer is configured.
+func (c *BufferConfiguration) NewBuffer(iOpts instrument.Options) (producer.Buffer, error) {
+	bOpts := c.NewOptions(iOpts)
+	if c.Disk == nil {
+		return buffer.NewBuffer(bOpts)
+	}
+	return buffer.NewDiskBuffer(c.Disk.NewOptions(bOpts, iOpts))
+}
+
+// DiskBufferConfiguration configs the disk buffer.
+type DiskBufferConfiguration struct {
+	Path            string               `yaml:"path" validate:"nonzero"`
+	MaxSegmentSize  *int64               `yaml:"maxSegmentSize"`
+	MaxDiskSize     *int64               `yaml:"maxDiskSize"`
+	MaxSegmentAge   *time.Duration       `yaml:"maxSegmentAge"`
+	CleanupInterval *time.Duration       `yaml:"cleanupInterval"`
+	ReplayRetry     *retry.Configuration `yaml:"replayRetry"`
+}
+
+// NewOptions creates new disk buffer options.
+func (c *DiskBufferConfiguration) NewOptions(
+	bOpts buffer.Options,
+	iOpts instrument.Options,
+) buffer.DiskOptions {
+	opts := buffer.NewDiskOptions().
+		SetBufferOptions(bOpts).
+		SetPath(c.Path)
+	if c.MaxSegmentSize != nil {
+		opts = opts.SetMaxSegmentSize(*c.MaxSegmentSize)
+	}
+	if c.MaxDiskSize != nil {
+		opts = opts.SetMaxDiskSize(*c.MaxDiskSize)
+	}
+	if c.MaxSegmentAge != nil {
+		opts = opts.SetMaxSegmentAge(*c.MaxSegmentAge)
+	}
+	if c.CleanupInterval != nil {
+		opts = opts.SetCleanupInterval(*c.CleanupInterval)
+	}
+	if c.ReplayRetry != nil {
+		opts = opts.SetReplayRetryOptions(c.ReplayRetry.NewOptions(iOpts.MetricsScope()))
+	}
+	return opts.SetInstrumentOptions(iOpts)
+}
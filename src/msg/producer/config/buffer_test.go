@@ -69,3 +69,33 @@ func TestEmptyBufferConfiguration(t *testing.T) {
 		cfg.NewOptions(instrument.NewOptions()).SetCleanupRetryOptions(rOpts),
 	)
 }
+
+func TestDiskBufferConfiguration(t *testing.T) {
+	str := `
+maxBufferSize: 100
+disk:
+  path: /var/lib/m3msg/buffer
+  maxSegmentSize: 1024
+  maxDiskSize: 4096
+  maxSegmentAge: 1h
+  cleanupInterval: 2s
+  replayRetry:
+    initialBackoff: 5s
+    maxRetries: 3
+`
+
+	var cfg BufferConfiguration
+	require.NoError(t, yaml.Unmarshal([]byte(str), &cfg))
+	require.NotNil(t, cfg.Disk)
+
+	bOpts := cfg.NewOptions(instrument.NewOptions())
+	dOpts := cfg.Disk.NewOptions(bOpts, instrument.NewOptions())
+	require.Equal(t, 100, dOpts.BufferOptions().MaxBufferSize())
+	require.Equal(t, "/var/lib/m3msg/buffer", dOpts.Path())
+	require.Equal(t, int64(1024), dOpts.MaxSegmentSize())
+	require.Equal(t, int64(4096), dOpts.MaxDiskSize())
+	require.Equal(t, time.Hour, dOpts.MaxSegmentAge())
+	require.Equal(t, 2*time.Second, dOpts.CleanupInterval())
+	require.Equal(t, 5*time.Second, dOpts.ReplayRetryOptions().InitialBackoff())
+	require.Equal(t, 3, dOpts.ReplayRetryOptions().MaxRetries())
+}
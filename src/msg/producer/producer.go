@@ -35,7 +35,14 @@ func NewProducer(opts Options) Producer {
 
 func (p *producer) Init() error {
 	p.Buffer.Init()
-	return p.Writer.Init()
+	if err := p.Writer.Init(); err != nil {
+		return err
+	}
+	// NB: Messages can only be replayed once the writer is initialized.
+	if rb, ok := p.Buffer.(ReplayableBuffer); ok {
+		return rb.Replay(p.Writer.Write)
+	}
+	return nil
 }
 
 func (p *producer) Produce(m Message) error {
@@ -113,6 +113,20 @@ type Buffer interface {
 	Close(ct CloseType)
 }
 
+// ReplayableBuffer is a buffer which persists the messages it buffers, the
+// messages that were not consumed are produced again through the replay
+// function, including those buffered before a restart.
+type ReplayableBuffer interface {
+	Buffer
+
+	// Replay sets the function used to produce the messages being replayed,
+	// it is called once the writer has been initialized.
+	Replay(fn ReplayFn) error
+}
+
+// ReplayFn produces a reference counted message being replayed.
+type ReplayFn func(rm *RefCountedMessage) error
+
 // Writer writes all the messages out to the consumer services.
 type Writer interface {
 	// Write writes a reference counted message out.
@@ -0,0 +1,253 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	m3graphite "github.com/m3db/m3/src/query/graphite"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/json"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// FindURL is the url for finding Graphite metric paths, this matches the
+	// path of the metrics find endpoint found on a Graphite server.
+	FindURL = handler.RoutePrefixV1 + "/graphite/metrics/find"
+
+	queryParam = "query"
+	fromParam  = "from"
+	untilParam = "until"
+)
+
+var (
+	// FindHTTPMethods are the HTTP methods used with this resource.
+	FindHTTPMethods = []string{http.MethodGet, http.MethodPost}
+
+	errNoQuery = errors.New("query parameter is required")
+)
+
+// FindHandler represents a handler for the Graphite metrics find endpoint.
+type FindHandler struct {
+	storage storage.Storage
+}
+
+// NewFindHandler returns a new instance of handler.
+func NewFindHandler(storage storage.Storage) http.Handler {
+	return &FindHandler{
+		storage: storage,
+	}
+}
+
+// findResult is a single path node matched by a find query.
+type findResult struct {
+	id   string
+	text string
+	leaf bool
+}
+
+func (h *FindHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := context.WithValue(r.Context(), handler.HeaderKey, r.Header)
+	logger := logging.WithContext(ctx)
+
+	results, rErr := h.find(ctx, r)
+	if rErr != nil {
+		logger.Error("unable to find graphite paths", zap.Error(rErr.Inner()))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	renderFindResultsJSON(w, results)
+}
+
+func (h *FindHandler) find(
+	ctx context.Context,
+	r *http.Request,
+) ([]findResult, *xhttp.ParseError) {
+	if err := r.ParseForm(); err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	query := r.Form.Get(queryParam)
+	if query == "" {
+		return nil, xhttp.NewParseError(errNoQuery, http.StatusBadRequest)
+	}
+
+	// NB: finding paths is not restricted by time unless explicitly requested.
+	start, end, err := parseTimeRange(r, time.Unix(0, 0))
+	if err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	components, err := m3graphite.SplitPath(query)
+	if err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	matchers, err := m3graphite.MatchersForPath(query, false)
+	if err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	timeout, err := prometheus.ParseRequestTimeout(r)
+	if err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := h.storage.FetchTags(ctx, &storage.FetchQuery{
+		Raw:         query,
+		TagMatchers: matchers,
+		Start:       start,
+		End:         end,
+	}, &storage.FetchOptions{})
+	if err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	return findResultsFromMetrics(result.Metrics, len(components)), nil
+}
+
+// findResultsFromMetrics returns the deduplicated path nodes at the given
+// depth, where a node with further components is a branch and a node without
+// is a leaf. A node may be both a leaf and a branch.
+func findResultsFromMetrics(metrics models.Metrics, depth int) []findResult {
+	var (
+		results []findResult
+		seen    = make(map[findResult]struct{})
+	)
+
+	for _, metric := range metrics {
+		components := make([]string, 0, depth)
+		for i := 0; i < depth; i++ {
+			value, ok := metric.Tags.Get(m3graphite.TagName(i))
+			if !ok {
+				break
+			}
+
+			components = append(components, string(value))
+		}
+
+		if len(components) != depth {
+			continue
+		}
+
+		_, hasChildren := metric.Tags.Get(m3graphite.TagName(depth))
+		result := findResult{
+			id:   strings.Join(components, "."),
+			text: components[depth-1],
+			leaf: !hasChildren,
+		}
+
+		if _, ok := seen[result]; ok {
+			continue
+		}
+
+		seen[result] = struct{}{}
+		results = append(results, result)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].id != results[j].id {
+			return results[i].id < results[j].id
+		}
+
+		// Branches sort before leaves with the same path.
+		return !results[i].leaf && results[j].leaf
+	})
+
+	return results
+}
+
+func renderFindResultsJSON(w io.Writer, results []findResult) {
+	jw := json.NewWriter(w)
+	jw.BeginArray()
+	for _, result := range results {
+		leaf, branch := 0, 1
+		if result.leaf {
+			leaf, branch = 1, 0
+		}
+
+		jw.BeginObject()
+		jw.BeginObjectField("id")
+		jw.WriteString(result.id)
+		jw.BeginObjectField("text")
+		jw.WriteString(result.text)
+		jw.BeginObjectField("leaf")
+		jw.WriteInt(leaf)
+		jw.BeginObjectField("expandable")
+		jw.WriteInt(branch)
+		jw.BeginObjectField("allowChildren")
+		jw.WriteInt(branch)
+		jw.EndObject()
+	}
+
+	jw.EndArray()
+	jw.Close()
+}
+
+// parseTimeRange parses the from and until parameters of a request, with
+// from defaulting to the given time and until defaulting to now.
+func parseTimeRange(r *http.Request, defaultFrom time.Time) (time.Time, time.Time, error) {
+	var (
+		now   = time.Now()
+		start = defaultFrom
+		end   = now
+		err   error
+	)
+
+	if from := r.Form.Get(fromParam); from != "" {
+		start, err = m3graphite.ParseTime(from, now)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+
+	if until := r.Form.Get(untilParam); until != "" {
+		end, err = m3graphite.ParseTime(until, now)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, errors.New("from must be before until")
+	}
+
+	return start, end, nil
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	m3graphite "github.com/m3db/m3/src/query/graphite"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func graphiteMetrics(t *testing.T, names ...string) models.Metrics {
+	metrics := make(models.Metrics, 0, len(names))
+	for _, name := range names {
+		tags, err := m3graphite.GenerateTags([]byte(name), models.NewTagOptions())
+		require.NoError(t, err)
+		metrics = append(metrics, models.Metric{ID: name, Tags: tags})
+	}
+
+	return metrics
+}
+
+type findResultJSON struct {
+	ID            string `json:"id"`
+	Text          string `json:"text"`
+	Leaf          int    `json:"leaf"`
+	Expandable    int    `json:"expandable"`
+	AllowChildren int    `json:"allowChildren"`
+}
+
+func TestFindHandler(t *testing.T) {
+	store := mock.NewMockStorage()
+	store.SetFetchTagsResult(&storage.SearchResults{
+		Metrics: graphiteMetrics(t,
+			"servers.host-2.cpu",
+			"servers.host-1.cpu.user",
+			"servers.host-1.cpu.system",
+			"servers.host-1.cpu",
+			"servers.host-1.mem",
+		),
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet,
+		FindURL+"?"+url.Values{queryParam: []string{"servers.*.cpu"}}.Encode(), nil)
+	w := httptest.NewRecorder()
+	NewFindHandler(store).ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var results []findResultJSON
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &results))
+	assert.Equal(t, []findResultJSON{
+		{ID: "servers.host-1.cpu", Text: "cpu", Leaf: 0, Expandable: 1, AllowChildren: 1},
+		{ID: "servers.host-1.cpu", Text: "cpu", Leaf: 1, Expandable: 0, AllowChildren: 0},
+		{ID: "servers.host-1.mem", Text: "mem", Leaf: 1, Expandable: 0, AllowChildren: 0},
+		{ID: "servers.host-2.cpu", Text: "cpu", Leaf: 1, Expandable: 0, AllowChildren: 0},
+	}, results)
+}
+
+func TestFindHandlerErrors(t *testing.T) {
+	handler := NewFindHandler(mock.NewMockStorage())
+	for _, query := range []url.Values{
+		{},
+		{queryParam: []string{"servers..cpu"}},
+		{queryParam: []string{"servers.*"}, fromParam: []string{"yesterday"}},
+		{queryParam: []string{"servers.*"}, fromParam: []string{"now"}, untilParam: []string{"-1h"}},
+	} {
+		req := httptest.NewRequest(http.MethodGet, FindURL+"?"+query.Encode(), nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query.Encode())
+	}
+}
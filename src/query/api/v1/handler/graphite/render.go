@@ -0,0 +1,192 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	m3graphite "github.com/m3db/m3/src/query/graphite"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/json"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// RenderURL is the url for rendering Graphite targets, this matches the
+	// path of the render endpoint found on a Graphite server.
+	RenderURL = handler.RoutePrefixV1 + "/graphite/render"
+
+	targetParam        = "target"
+	formatParam        = "format"
+	maxDataPointsParam = "maxDataPoints"
+	jsonFormat         = "json"
+
+	defaultRenderRange = 24 * time.Hour
+	defaultRenderStep  = 10 * time.Second
+)
+
+var (
+	// RenderHTTPMethods are the HTTP methods used with this resource.
+	RenderHTTPMethods = []string{http.MethodGet, http.MethodPost}
+
+	errNoTarget = errors.New("at least one target parameter is required")
+)
+
+// RenderHandler represents a handler for the Graphite render endpoint.
+type RenderHandler struct {
+	engine *m3graphite.Engine
+}
+
+// NewRenderHandler returns a new instance of handler.
+func NewRenderHandler(storage storage.Storage) http.Handler {
+	return &RenderHandler{
+		engine: m3graphite.NewEngine(storage),
+	}
+}
+
+func (h *RenderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := context.WithValue(r.Context(), handler.HeaderKey, r.Header)
+	logger := logging.WithContext(ctx)
+
+	series, rErr := h.render(ctx, r)
+	if rErr != nil {
+		logger.Error("unable to render graphite targets", zap.Error(rErr.Inner()))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	renderSeriesJSON(w, series)
+}
+
+func (h *RenderHandler) render(
+	ctx context.Context,
+	r *http.Request,
+) ([]*m3graphite.Series, *xhttp.ParseError) {
+	opts, targets, err := parseRenderRequest(r)
+	if err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	timeout, err := prometheus.ParseRequestTimeout(r)
+	if err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var results []*m3graphite.Series
+	for _, target := range targets {
+		series, err := h.engine.Render(ctx, target, opts)
+		if err != nil {
+			return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+		}
+
+		results = append(results, series...)
+	}
+
+	return results, nil
+}
+
+func parseRenderRequest(r *http.Request) (m3graphite.RenderOptions, []string, error) {
+	if err := r.ParseForm(); err != nil {
+		return m3graphite.RenderOptions{}, nil, err
+	}
+
+	targets := r.Form[targetParam]
+	if len(targets) == 0 {
+		return m3graphite.RenderOptions{}, nil, errNoTarget
+	}
+
+	if format := r.Form.Get(formatParam); format != "" && format != jsonFormat {
+		return m3graphite.RenderOptions{}, nil,
+			fmt.Errorf("unsupported render format: %s", format)
+	}
+
+	start, end, err := parseTimeRange(r, time.Now().Add(-defaultRenderRange))
+	if err != nil {
+		return m3graphite.RenderOptions{}, nil, err
+	}
+
+	step := defaultRenderStep
+	if str := r.Form.Get(maxDataPointsParam); str != "" {
+		maxDataPoints, err := strconv.Atoi(str)
+		if err != nil || maxDataPoints <= 0 {
+			return m3graphite.RenderOptions{}, nil,
+				fmt.Errorf("invalid %s: %s", maxDataPointsParam, str)
+		}
+
+		// Widen the step so that at most maxDataPoints values are returned.
+		if minStep := end.Sub(start) / time.Duration(maxDataPoints); minStep > step {
+			step = (minStep/defaultRenderStep + 1) * defaultRenderStep
+		}
+	}
+
+	return m3graphite.RenderOptions{
+		Start: start.Truncate(step),
+		End:   end.Truncate(step),
+		Step:  step,
+	}, targets, nil
+}
+
+func renderSeriesJSON(w io.Writer, series []*m3graphite.Series) {
+	jw := json.NewWriter(w)
+	jw.BeginArray()
+	for _, s := range series {
+		jw.BeginObject()
+		jw.BeginObjectField("target")
+		jw.WriteString(s.Name)
+
+		jw.BeginObjectField("datapoints")
+		jw.BeginArray()
+		for i, v := range s.Values {
+			jw.BeginArray()
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				jw.WriteNull()
+			} else {
+				jw.WriteFloat64(v)
+			}
+
+			jw.WriteInt(int(s.TimeAt(i).Unix()))
+			jw.EndArray()
+		}
+
+		jw.EndArray()
+		jw.EndObject()
+	}
+
+	jw.EndArray()
+	jw.Close()
+}
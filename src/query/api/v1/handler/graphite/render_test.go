@@ -0,0 +1,123 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderHandler(t *testing.T) {
+	start := time.Now().Add(-time.Hour).Truncate(defaultRenderStep)
+	bounds := models.Bounds{
+		Start:    start,
+		Duration: 3 * defaultRenderStep,
+		StepSize: defaultRenderStep,
+	}
+
+	metrics := graphiteMetrics(t, "servers.host-1.cpu", "servers.host-2.cpu")
+	metas := make([]block.SeriesMeta, 0, len(metrics))
+	for _, metric := range metrics {
+		metas = append(metas, block.SeriesMeta{Name: metric.ID, Tags: metric.Tags})
+	}
+
+	store := mock.NewMockStorage()
+	store.SetFetchBlocksResult(block.Result{
+		Blocks: []block.Block{
+			test.NewBlockFromValuesWithSeriesMeta(bounds, metas,
+				[][]float64{{1, 2, math.NaN()}, {10, 20, 30}}),
+		},
+	}, nil)
+
+	form := url.Values{
+		targetParam: []string{"servers.host-1.cpu", "sumSeries(servers.*.cpu)"},
+		fromParam:   []string{"-2h"},
+	}
+	req := httptest.NewRequest(http.MethodPost, RenderURL,
+		strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	NewRenderHandler(store).ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var results []struct {
+		Target     string          `json:"target"`
+		Datapoints [][]interface{} `json:"datapoints"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &results))
+
+	// The first target matches both series since the mock ignores matchers.
+	require.Len(t, results, 3)
+	assert.Equal(t, "servers.host-1.cpu", results[0].Target)
+	assert.Equal(t, "sumSeries(servers.*.cpu)", results[2].Target)
+
+	ts := float64(start.Unix())
+	assert.Equal(t, [][]interface{}{
+		{1.0, ts},
+		{2.0, ts + 10},
+		{nil, ts + 20},
+	}, results[0].Datapoints)
+	assert.Equal(t, [][]interface{}{
+		{11.0, ts},
+		{22.0, ts + 10},
+		{30.0, ts + 20},
+	}, results[2].Datapoints)
+}
+
+func TestParseRenderRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, RenderURL+"?"+url.Values{
+		targetParam:        []string{"a.b"},
+		fromParam:          []string{"-1d"},
+		maxDataPointsParam: []string{"100"},
+	}.Encode(), nil)
+
+	opts, targets, err := parseRenderRequest(req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.b"}, targets)
+	assert.True(t, opts.Step >= 24*time.Hour/100)
+	assert.Equal(t, time.Duration(0), opts.Step%defaultRenderStep)
+	assert.True(t, opts.End.Sub(opts.Start)/opts.Step <= 100)
+
+	for _, query := range []url.Values{
+		{},
+		{targetParam: []string{"a.b"}, formatParam: []string{"png"}},
+		{targetParam: []string{"a.b"}, maxDataPointsParam: []string{"-1"}},
+		{targetParam: []string{"a.b"}, untilParam: []string{"soon"}},
+	} {
+		req := httptest.NewRequest(http.MethodGet, RenderURL+"?"+query.Encode(), nil)
+		_, _, err := parseRenderRequest(req)
+		assert.Error(t, err, query.Encode())
+	}
+}
@@ -32,4 +32,16 @@ const (
 
 	// DeprecatedHeader is the M3 deprecated header
 	DeprecatedHeader = "M3-Deprecated"
+
+	// FetchedSeriesHeader is the number of series fetched by a query
+	FetchedSeriesHeader = "M3-Fetched-Series"
+
+	// FetchedDatapointsHeader is the number of datapoints decompressed by a query
+	FetchedDatapointsHeader = "M3-Fetched-Datapoints"
+
+	// FetchedBlocksHeader is the number of blocks fetched by a query
+	FetchedBlocksHeader = "M3-Fetched-Blocks"
+
+	// QueryDurationHeader is the wall time spent executing a query
+	QueryDurationHeader = "M3-Query-Duration"
 )
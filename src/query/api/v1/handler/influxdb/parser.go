@@ -0,0 +1,285 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package influxdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	xtime "github.com/m3db/m3x/time"
+)
+
+var (
+	errMissingMeasurement = errors.New("missing measurement")
+	errMissingFields      = errors.New("missing fields")
+	errMissingTagValue    = errors.New("missing tag value")
+	errMissingFieldValue  = errors.New("missing field value")
+	errUnterminatedString = errors.New("unterminated string field value")
+	errTimestampOverflow  = errors.New("timestamp out of range")
+)
+
+// precision is the resolution of the timestamps of a request.
+type precision struct {
+	// multiplier is the duration of a single timestamp unit.
+	multiplier time.Duration
+	// unit is the unit the datapoints are written to storage with.
+	unit xtime.Unit
+}
+
+var precisions = map[string]precision{
+	"":   {multiplier: time.Nanosecond, unit: xtime.Nanosecond},
+	"n":  {multiplier: time.Nanosecond, unit: xtime.Nanosecond},
+	"ns": {multiplier: time.Nanosecond, unit: xtime.Nanosecond},
+	"u":  {multiplier: time.Microsecond, unit: xtime.Microsecond},
+	"us": {multiplier: time.Microsecond, unit: xtime.Microsecond},
+	"ms": {multiplier: time.Millisecond, unit: xtime.Millisecond},
+	"s":  {multiplier: time.Second, unit: xtime.Second},
+	"m":  {multiplier: time.Minute, unit: xtime.Second},
+	"h":  {multiplier: time.Hour, unit: xtime.Second},
+}
+
+// parsePrecision parses the precision parameter of a request.
+func parsePrecision(str string) (precision, error) {
+	p, ok := precisions[str]
+	if !ok {
+		return precision{}, fmt.Errorf("invalid precision: %s", str)
+	}
+
+	return p, nil
+}
+
+// point is a single parsed line of line protocol.
+type point struct {
+	measurement []byte
+	tags        []tag
+	fields      []field
+	timestamp   time.Time
+}
+
+type tag struct {
+	name  []byte
+	value []byte
+}
+
+// field is a numeric field value, string fields are not representable as
+// series and are dropped during parsing.
+type field struct {
+	name  []byte
+	value float64
+}
+
+// parsePoints parses a batch of line protocol, with timestamps in units of
+// the given precision and points without a timestamp written at now.
+func parsePoints(data []byte, unit time.Duration, now time.Time) ([]point, error) {
+	var points []point
+	for lineNum := 1; len(data) > 0; lineNum++ {
+		line := data
+		if idx := bytes.IndexByte(data, '\n'); idx != -1 {
+			line, data = data[:idx], data[idx+1:]
+		} else {
+			data = nil
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+
+		p, err := parsePoint(line, unit, now)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse line %d: %v", lineNum, err)
+		}
+
+		if len(p.fields) > 0 {
+			points = append(points, p)
+		}
+	}
+
+	return points, nil
+}
+
+func parsePoint(line []byte, unit time.Duration, now time.Time) (point, error) {
+	s := &scanner{line: line}
+	measurement, delim := s.readUntil(", ")
+	if len(measurement) == 0 {
+		return point{}, errMissingMeasurement
+	}
+
+	p := point{measurement: measurement, timestamp: now}
+	for delim == ',' {
+		var name, value []byte
+		name, delim = s.readUntil("=")
+		if delim != '=' || len(name) == 0 {
+			return point{}, errMissingTagValue
+		}
+
+		value, delim = s.readUntil(", ")
+		if len(value) == 0 {
+			return point{}, errMissingTagValue
+		}
+
+		p.tags = append(p.tags, tag{name: name, value: value})
+	}
+
+	s.skipSpaces()
+	if s.done() {
+		return point{}, errMissingFields
+	}
+
+	for {
+		name, delim := s.readUntil("=")
+		if delim != '=' || len(name) == 0 {
+			return point{}, errMissingFieldValue
+		}
+
+		value, isNumeric, err := s.readFieldValue()
+		if err != nil {
+			return point{}, fmt.Errorf("invalid value for field %s: %v", name, err)
+		}
+
+		if isNumeric {
+			p.fields = append(p.fields, field{name: name, value: value})
+		}
+
+		if s.done() || s.line[s.pos] != ',' {
+			break
+		}
+
+		// Skip the field separator.
+		s.pos++
+	}
+
+	s.skipSpaces()
+	if s.done() {
+		return p, nil
+	}
+
+	timestamp, err := strconv.ParseInt(string(s.line[s.pos:]), 10, 64)
+	if err != nil {
+		return point{}, fmt.Errorf("invalid timestamp: %s", s.line[s.pos:])
+	}
+
+	// Timestamps with a coarse precision such as hours or minutes can
+	// overflow when converted to nanoseconds.
+	if timestamp > math.MaxInt64/int64(unit) || timestamp < math.MinInt64/int64(unit) {
+		return point{}, errTimestampOverflow
+	}
+
+	p.timestamp = time.Unix(0, timestamp*int64(unit))
+	return p, nil
+}
+
+type scanner struct {
+	line []byte
+	pos  int
+}
+
+func (s *scanner) done() bool {
+	return s.pos >= len(s.line)
+}
+
+func (s *scanner) skipSpaces() {
+	for !s.done() && s.line[s.pos] == ' ' {
+		s.pos++
+	}
+}
+
+// readUntil reads an unescaped token up to any of the given delimiters,
+// returning the delimiter found or zero at the end of the line.
+func (s *scanner) readUntil(delims string) ([]byte, byte) {
+	var token []byte
+	for !s.done() {
+		c := s.line[s.pos]
+		s.pos++
+		if c == '\\' && !s.done() {
+			token = append(token, s.line[s.pos])
+			s.pos++
+			continue
+		}
+
+		if bytes.IndexByte([]byte(delims), c) != -1 {
+			return token, c
+		}
+
+		token = append(token, c)
+	}
+
+	return token, 0
+}
+
+// readFieldValue reads a field value, returning false if the value is a
+// string and therefore cannot be stored as a numeric series.
+func (s *scanner) readFieldValue() (float64, bool, error) {
+	if s.done() {
+		return 0, false, errMissingFieldValue
+	}
+
+	if s.line[s.pos] == '"' {
+		for s.pos++; !s.done(); s.pos++ {
+			switch s.line[s.pos] {
+			case '\\':
+				s.pos++
+			case '"':
+				s.pos++
+				return 0, false, nil
+			}
+		}
+
+		return 0, false, errUnterminatedString
+	}
+
+	start := s.pos
+	for !s.done() && s.line[s.pos] != ',' && s.line[s.pos] != ' ' {
+		s.pos++
+	}
+
+	value, err := parseNumericValue(s.line[start:s.pos])
+	return value, err == nil, err
+}
+
+func parseNumericValue(value []byte) (float64, error) {
+	if len(value) == 0 {
+		return 0, errMissingFieldValue
+	}
+
+	str := string(value)
+	switch str {
+	case "t", "T", "true", "True", "TRUE":
+		return 1, nil
+	case "f", "F", "false", "False", "FALSE":
+		return 0, nil
+	}
+
+	switch value[len(value)-1] {
+	case 'i':
+		v, err := strconv.ParseInt(str[:len(str)-1], 10, 64)
+		return float64(v), err
+	case 'u':
+		v, err := strconv.ParseUint(str[:len(str)-1], 10, 64)
+		return float64(v), err
+	}
+
+	return strconv.ParseFloat(str, 64)
+}
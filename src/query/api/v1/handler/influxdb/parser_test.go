@@ -0,0 +1,145 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package influxdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePoints(t *testing.T) {
+	now := time.Unix(1000, 0)
+	data := []byte(`
+# comment
+weather,location=us-midwest,season=summer temperature=82,humidity=71i 1465839830100400200
+cpu\,total,host=server\ 01,region\=x=us\,west usage=0.5,idle=true,up=2u,msg="a \"quoted\", value"
+disk free=-1.5e3
+`)
+
+	points, err := parsePoints(data, time.Nanosecond, now)
+	require.NoError(t, err)
+	require.Len(t, points, 3)
+
+	weather := points[0]
+	assert.Equal(t, "weather", string(weather.measurement))
+	require.Len(t, weather.tags, 2)
+	assert.Equal(t, "location", string(weather.tags[0].name))
+	assert.Equal(t, "us-midwest", string(weather.tags[0].value))
+	assert.Equal(t, "season", string(weather.tags[1].name))
+	assert.Equal(t, "summer", string(weather.tags[1].value))
+	require.Len(t, weather.fields, 2)
+	assert.Equal(t, field{name: []byte("temperature"), value: 82}, weather.fields[0])
+	assert.Equal(t, field{name: []byte("humidity"), value: 71}, weather.fields[1])
+	assert.Equal(t, time.Unix(0, 1465839830100400200), weather.timestamp)
+
+	cpu := points[1]
+	assert.Equal(t, "cpu,total", string(cpu.measurement))
+	require.Len(t, cpu.tags, 2)
+	assert.Equal(t, "server 01", string(cpu.tags[0].value))
+	assert.Equal(t, "region=x", string(cpu.tags[1].name))
+	assert.Equal(t, "us,west", string(cpu.tags[1].value))
+
+	// The string field is dropped.
+	assert.Equal(t, []field{
+		{name: []byte("usage"), value: 0.5},
+		{name: []byte("idle"), value: 1},
+		{name: []byte("up"), value: 2},
+	}, cpu.fields)
+	assert.Equal(t, now, cpu.timestamp)
+
+	disk := points[2]
+	assert.Empty(t, disk.tags)
+	assert.Equal(t, []field{{name: []byte("free"), value: -1500}}, disk.fields)
+}
+
+func TestParsePointsPrecision(t *testing.T) {
+	for _, tt := range []struct {
+		precision string
+		expected  time.Time
+	}{
+		{"", time.Unix(0, 1500)},
+		{"ns", time.Unix(0, 1500)},
+		{"u", time.Unix(0, 1500*int64(time.Microsecond))},
+		{"ms", time.Unix(1, 500*int64(time.Millisecond))},
+		{"s", time.Unix(1500, 0)},
+		{"m", time.Unix(1500*60, 0)},
+		{"h", time.Unix(1500*3600, 0)},
+	} {
+		precision, err := parsePrecision(tt.precision)
+		require.NoError(t, err)
+
+		points, err := parsePoints([]byte("m v=1 1500"), precision.multiplier, time.Now())
+		require.NoError(t, err)
+		require.Len(t, points, 1)
+		assert.Equal(t, tt.expected, points[0].timestamp, tt.precision)
+	}
+
+	_, err := parsePrecision("d")
+	assert.Error(t, err)
+}
+
+func TestParsePointsTimestampOverflow(t *testing.T) {
+	for _, tt := range []struct {
+		precision string
+		timestamp string
+	}{
+		{"h", "2562048"},
+		{"h", "-2562048"},
+		{"m", "153722868"},
+		{"s", "9223372037"},
+	} {
+		precision, err := parsePrecision(tt.precision)
+		require.NoError(t, err)
+
+		_, err = parsePoints([]byte("m v=1 "+tt.timestamp), precision.multiplier, time.Now())
+		require.Error(t, err, tt.precision)
+		assert.Contains(t, err.Error(), errTimestampOverflow.Error(), tt.precision)
+	}
+}
+
+func TestParsePointsDropsPointsWithOnlyStrings(t *testing.T) {
+	points, err := parsePoints([]byte(`m msg="hello world"`), time.Nanosecond, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, points)
+}
+
+func TestParsePointsErrors(t *testing.T) {
+	for _, line := range []string{
+		"measurement",
+		"measurement,tag=value",
+		",tag=value v=1",
+		"m,tag v=1",
+		"m,tag= v=1",
+		"m v",
+		"m v=",
+		"m v=abc",
+		"m v=1x",
+		`m v="unterminated`,
+		"m v=1 notatimestamp",
+		"m v=1 1 2",
+	} {
+		_, err := parsePoints([]byte(line), time.Nanosecond, time.Now())
+		assert.Error(t, err, line)
+	}
+}
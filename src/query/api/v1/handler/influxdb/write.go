@@ -0,0 +1,452 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package influxdb
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/downsample"
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/tenant"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+	xerrors "github.com/m3db/m3x/errors"
+	xsync "github.com/m3db/m3x/sync"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+const (
+	// InfluxWriteURL is the url for the InfluxDB line protocol write handler.
+	InfluxWriteURL = handler.RoutePrefixV1 + "/influxdb/write"
+
+	// InfluxWriteHTTPMethod is the HTTP method used with this resource.
+	InfluxWriteHTTPMethod = http.MethodPost
+
+	precisionParam = "precision"
+	gzipEncoding   = "gzip"
+
+	// DefaultMaxBodySize is the default maximum size in bytes of a request
+	// body, after decompression.
+	DefaultMaxBodySize int64 = 32 << 20
+
+	// maxBytesReaderError is the message of the error returned by readers
+	// from http.MaxBytesReader when reading past their limit.
+	maxBytesReaderError = "http: request body too large"
+
+	// writeConcurrency bounds the number of unaggregated series written
+	// concurrently across all requests.
+	writeConcurrency = 128
+)
+
+var (
+	errNoStorageOrDownsampler = errors.New("no storage or downsampler set, requires at least one or both")
+	errEmptyBody              = errors.New("empty request body")
+	errInvalidMaxBodySize     = errors.New("max body size must be positive")
+)
+
+// InfluxWriteHandler represents a handler for the InfluxDB write endpoint.
+type InfluxWriteHandler struct {
+	store       storage.Storage
+	downsampler downsample.Downsampler
+	tagOptions  models.TagOptions
+	naming      NamingOptions
+	maxBodySize int64
+	metrics     influxWriteMetrics
+	workerPool  xsync.WorkerPool
+	nowFn       func() time.Time
+}
+
+// NewInfluxWriteHandler returns a new instance of handler.
+func NewInfluxWriteHandler(
+	store storage.Storage,
+	downsampler downsample.Downsampler,
+	tagOptions models.TagOptions,
+	naming NamingOptions,
+	maxBodySize int64,
+	scope tally.Scope,
+) (http.Handler, error) {
+	if store == nil && downsampler == nil {
+		return nil, errNoStorageOrDownsampler
+	}
+
+	if maxBodySize <= 0 {
+		return nil, errInvalidMaxBodySize
+	}
+
+	if err := naming.Validate(); err != nil {
+		return nil, err
+	}
+
+	workerPool := xsync.NewWorkerPool(writeConcurrency)
+	workerPool.Init()
+
+	return &InfluxWriteHandler{
+		store:       store,
+		downsampler: downsampler,
+		tagOptions:  tagOptions,
+		naming:      naming,
+		maxBodySize: maxBodySize,
+		metrics:     newInfluxWriteMetrics(scope),
+		workerPool:  workerPool,
+		nowFn:       time.Now,
+	}, nil
+}
+
+type influxWriteMetrics struct {
+	writeSuccess      tally.Counter
+	writeErrorsServer tally.Counter
+	writeErrorsClient tally.Counter
+}
+
+func newInfluxWriteMetrics(scope tally.Scope) influxWriteMetrics {
+	return influxWriteMetrics{
+		writeSuccess:      scope.Counter("write.success"),
+		writeErrorsServer: scope.Tagged(map[string]string{"code": "5XX"}).Counter("write.errors"),
+		writeErrorsClient: scope.Tagged(map[string]string{"code": "4XX"}).Counter("write.errors"),
+	}
+}
+
+func (h *InfluxWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	writes, rErr := h.parseRequest(w, r)
+	if rErr != nil {
+		h.metrics.writeErrorsClient.Inc(1)
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	if err := h.write(r.Context(), writes); err != nil {
+		h.metrics.writeErrorsServer.Inc(1)
+		logging.WithContext(r.Context()).Error("Write error", zap.Any("err", err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.metrics.writeSuccess.Inc(1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *InfluxWriteHandler) parseRequest(
+	w http.ResponseWriter,
+	r *http.Request,
+) ([]*storage.WriteQuery, *xhttp.ParseError) {
+	if r.Body == nil {
+		return nil, xhttp.NewParseError(errEmptyBody, http.StatusBadRequest)
+	}
+
+	defer r.Body.Close()
+
+	if r.ContentLength > h.maxBodySize {
+		return nil, h.bodyTooLargeError()
+	}
+
+	var body io.Reader = http.MaxBytesReader(w, r.Body, h.maxBodySize)
+	if r.Header.Get("Content-Encoding") == gzipEncoding {
+		gzipReader, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, h.readError(err)
+		}
+
+		defer gzipReader.Close()
+		body = gzipReader
+	}
+
+	// Read one byte past the limit to tell a body of exactly the maximum size
+	// apart from one that exceeds it once decompressed.
+	data, err := ioutil.ReadAll(io.LimitReader(body, h.maxBodySize+1))
+	if err != nil {
+		return nil, h.readError(err)
+	}
+
+	if int64(len(data)) > h.maxBodySize {
+		return nil, h.bodyTooLargeError()
+	}
+
+	precision, err := parsePrecision(r.URL.Query().Get(precisionParam))
+	if err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	points, err := parsePoints(data, precision.multiplier, h.nowFn())
+	if err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	return h.writeQueries(points, precision.unit), nil
+}
+
+func (h *InfluxWriteHandler) readError(err error) *xhttp.ParseError {
+	if err.Error() == maxBytesReaderError {
+		return h.bodyTooLargeError()
+	}
+
+	return xhttp.NewParseError(err, http.StatusBadRequest)
+}
+
+func (h *InfluxWriteHandler) bodyTooLargeError() *xhttp.ParseError {
+	err := fmt.Errorf("request body exceeds the maximum size of %d bytes", h.maxBodySize)
+	return xhttp.NewParseError(err, http.StatusRequestEntityTooLarge)
+}
+
+// writeQueries maps every field of every point to a tagged series, grouping
+// the datapoints of each series into a single write ordered by timestamp.
+func (h *InfluxWriteHandler) writeQueries(
+	points []point,
+	unit xtime.Unit,
+) []*storage.WriteQuery {
+	var (
+		writes []*storage.WriteQuery
+		series = make(map[string]*storage.WriteQuery)
+	)
+	for _, p := range points {
+		baseTags := make([]models.Tag, 0, len(p.tags))
+		for _, t := range p.tags {
+			baseTags = append(baseTags, models.Tag{Name: t.name, Value: t.value})
+		}
+
+		for _, f := range p.fields {
+			tags := models.NewTags(len(baseTags)+2, h.tagOptions).AddTags(baseTags)
+			tags = h.naming.apply(tags, p.measurement, f.name)
+
+			dp := ts.Datapoint{Timestamp: p.timestamp, Value: f.value}
+			id := tags.ID()
+			if write, ok := series[id]; ok {
+				write.Datapoints = append(write.Datapoints, dp)
+				continue
+			}
+
+			write := &storage.WriteQuery{
+				Tags:       tags,
+				Datapoints: ts.Datapoints{dp},
+				Unit:       unit,
+				Attributes: storage.Attributes{
+					MetricsType: storage.UnaggregatedMetricsType,
+				},
+			}
+			series[id] = write
+			writes = append(writes, write)
+		}
+	}
+
+	// Lines of a request are not necessarily in time order.
+	for _, write := range writes {
+		dps := write.Datapoints
+		sort.SliceStable(dps, func(i, j int) bool {
+			return dps[i].Timestamp.Before(dps[j].Timestamp)
+		})
+	}
+
+	return writes
+}
+
+func (h *InfluxWriteHandler) write(ctx context.Context, writes []*storage.WriteQuery) error {
+	var (
+		wg            sync.WaitGroup
+		writeUnaggErr error
+		writeAggErr   error
+	)
+	if h.downsampler != nil {
+		// If writing downsampled aggregations, write them async
+		wg.Add(1)
+		go func() {
+			writeAggErr = h.writeAggregated(ctx, writes)
+			wg.Done()
+		}()
+	}
+
+	if h.store != nil {
+		writeUnaggErr = h.writeUnaggregated(ctx, writes)
+	}
+
+	wg.Wait()
+
+	var multiErr xerrors.MultiError
+	multiErr = multiErr.Add(writeUnaggErr)
+	multiErr = multiErr.Add(writeAggErr)
+	return multiErr.FinalError()
+}
+
+func (h *InfluxWriteHandler) writeUnaggregated(
+	ctx context.Context,
+	writes []*storage.WriteQuery,
+) error {
+	var (
+		wg       sync.WaitGroup
+		errLock  sync.Mutex
+		multiErr xerrors.MultiError
+	)
+	for _, write := range writes {
+		write := write // Capture for goroutine
+
+		wg.Add(1)
+		h.workerPool.Go(func() {
+			if err := h.store.Write(ctx, write); err != nil {
+				errLock.Lock()
+				multiErr = multiErr.Add(err)
+				errLock.Unlock()
+			}
+
+			wg.Done()
+		})
+	}
+
+	wg.Wait()
+
+	return multiErr.FinalError()
+}
+
+func (h *InfluxWriteHandler) writeAggregated(
+	ctx context.Context,
+	writes []*storage.WriteQuery,
+) error {
+	var (
+		metricsAppender = h.downsampler.NewMetricsAppender()
+		multiErr        xerrors.MultiError
+	)
+	// Aggregated writes do not go through the storage so validate them
+	// against the namespaces and tag constraints of the tenant here
+	t, hasTenant := tenant.FromContext(ctx)
+	if hasTenant {
+		if err := t.ValidateAggregatedWrites(); err != nil {
+			return err
+		}
+	}
+	for _, write := range writes {
+		if hasTenant {
+			if err := t.ValidateTags(write.Tags); err != nil {
+				multiErr = multiErr.Add(err)
+				continue
+			}
+		}
+
+		metricsAppender.Reset()
+		for _, t := range write.Tags.Tags {
+			metricsAppender.AddTag(t.Name, t.Value)
+		}
+
+		samplesAppender, err := metricsAppender.SamplesAppender()
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+
+		for _, dp := range write.Datapoints {
+			if err := samplesAppender.AppendGaugeSample(dp.Value); err != nil {
+				multiErr = multiErr.Add(err)
+			}
+		}
+	}
+
+	metricsAppender.Finalize()
+
+	return multiErr.FinalError()
+}
+
+// NamingScheme determines how a measurement and field are mapped to a
+// series name.
+type NamingScheme string
+
+const (
+	// MeasurementFieldNamingScheme names each series <measurement>_<field>,
+	// the same as the Prometheus output of Telegraf.
+	MeasurementFieldNamingScheme NamingScheme = "measurement_field"
+	// FieldTagNamingScheme names each series after the measurement and adds
+	// the field name as a tag.
+	FieldTagNamingScheme NamingScheme = "field_tag"
+
+	// DefaultNamingScheme is the default naming scheme.
+	DefaultNamingScheme = MeasurementFieldNamingScheme
+	// DefaultFieldTagName is the default tag name for field names.
+	DefaultFieldTagName = "field"
+
+	nameSeparator = '_'
+)
+
+// NamingOptions configures how fields are mapped to series names.
+type NamingOptions struct {
+	Scheme       NamingScheme
+	FieldTagName string
+}
+
+// Validate validates the naming options.
+func (o NamingOptions) Validate() error {
+	switch o.Scheme {
+	case MeasurementFieldNamingScheme:
+		return nil
+	case FieldTagNamingScheme:
+		if o.FieldTagName == "" {
+			return errors.New("field tag name must be set for the field_tag naming scheme")
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("unknown influxdb naming scheme: %s", o.Scheme)
+}
+
+func (o NamingOptions) apply(tags models.Tags, measurement, fieldName []byte) models.Tags {
+	if o.Scheme == FieldTagNamingScheme {
+		tags = tags.AddOrUpdateTag(models.Tag{
+			Name:  []byte(o.FieldTagName),
+			Value: fieldName,
+		})
+
+		return tags.SetName(sanitizeName(measurement))
+	}
+
+	name := make([]byte, 0, len(measurement)+len(fieldName)+1)
+	name = append(name, measurement...)
+	name = append(name, nameSeparator)
+	name = append(name, fieldName...)
+	return tags.SetName(sanitizeName(name))
+}
+
+// sanitizeName replaces characters that are not valid in a Prometheus
+// metric name with underscores so that the series can be queried with PromQL.
+func sanitizeName(name []byte) []byte {
+	sanitized := make([]byte, len(name))
+	for i, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_', c == ':':
+			sanitized[i] = c
+		case c >= '0' && c <= '9' && i > 0:
+			sanitized[i] = c
+		default:
+			sanitized[i] = nameSeparator
+		}
+	}
+
+	return sanitized
+}
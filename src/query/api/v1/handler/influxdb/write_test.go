@@ -0,0 +1,230 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package influxdb
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func newTestHandler(
+	t *testing.T,
+	store storage.Storage,
+	naming NamingOptions,
+) *InfluxWriteHandler {
+	h, err := NewInfluxWriteHandler(store, nil, models.NewTagOptions(),
+		naming, DefaultMaxBodySize, tally.NoopScope)
+	require.NoError(t, err)
+	return h.(*InfluxWriteHandler)
+}
+
+func writtenIDs(store mock.Storage) []string {
+	var ids []string
+	for _, write := range store.Writes() {
+		ids = append(ids, write.Tags.ID())
+	}
+
+	sort.Strings(ids)
+	return ids
+}
+
+func tagsID(tags ...string) string {
+	t := models.NewTags(len(tags)/2, models.NewTagOptions())
+	for i := 0; i < len(tags); i += 2 {
+		t = t.AddTag(models.Tag{Name: []byte(tags[i]), Value: []byte(tags[i+1])})
+	}
+
+	return t.ID()
+}
+
+func TestInfluxWriteMeasurementFieldNaming(t *testing.T) {
+	store := mock.NewMockStorage()
+	h := newTestHandler(t, store, NamingOptions{Scheme: MeasurementFieldNamingScheme})
+
+	body := "disk.io,host=a read=1,write=2 1500\n"
+	req := httptest.NewRequest(http.MethodPost, InfluxWriteURL+"?precision=s",
+		strings.NewReader(body))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+	expected := []string{
+		tagsID("__name__", "disk_io_read", "host", "a"),
+		tagsID("__name__", "disk_io_write", "host", "a"),
+	}
+	sort.Strings(expected)
+	assert.Equal(t, expected, writtenIDs(store))
+
+	for _, write := range store.Writes() {
+		assert.Equal(t, xtime.Second, write.Unit)
+		require.Len(t, write.Datapoints, 1)
+		assert.Equal(t, time.Unix(1500, 0), write.Datapoints[0].Timestamp)
+	}
+}
+
+func TestInfluxWriteGroupsDatapointsPerSeries(t *testing.T) {
+	store := mock.NewMockStorage()
+	h := newTestHandler(t, store, NamingOptions{Scheme: MeasurementFieldNamingScheme})
+
+	body := "cpu,host=a usage=3 1510\ncpu,host=b usage=2 1500\ncpu,host=a usage=1 1500\n"
+	req := httptest.NewRequest(http.MethodPost, InfluxWriteURL+"?precision=s",
+		strings.NewReader(body))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+	hostA := tagsID("__name__", "cpu_usage", "host", "a")
+	expected := []string{
+		hostA,
+		tagsID("__name__", "cpu_usage", "host", "b"),
+	}
+	sort.Strings(expected)
+	assert.Equal(t, expected, writtenIDs(store))
+
+	for _, write := range store.Writes() {
+		if write.Tags.ID() != hostA {
+			continue
+		}
+
+		require.Len(t, write.Datapoints, 2)
+		assert.Equal(t, time.Unix(1500, 0), write.Datapoints[0].Timestamp)
+		assert.Equal(t, 1.0, write.Datapoints[0].Value)
+		assert.Equal(t, time.Unix(1510, 0), write.Datapoints[1].Timestamp)
+		assert.Equal(t, 3.0, write.Datapoints[1].Value)
+	}
+}
+
+func TestInfluxWriteFieldTagNaming(t *testing.T) {
+	store := mock.NewMockStorage()
+	h := newTestHandler(t, store, NamingOptions{
+		Scheme:       FieldTagNamingScheme,
+		FieldTagName: "field",
+	})
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte("cpu,host=a usage=0.5,idle=0.25\n"))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	req := httptest.NewRequest(http.MethodPost, InfluxWriteURL, &buf)
+	req.Header.Set("Content-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+	expected := []string{
+		tagsID("__name__", "cpu", "field", "idle", "host", "a"),
+		tagsID("__name__", "cpu", "field", "usage", "host", "a"),
+	}
+	sort.Strings(expected)
+	assert.Equal(t, expected, writtenIDs(store))
+}
+
+func TestInfluxWriteBadRequest(t *testing.T) {
+	store := mock.NewMockStorage()
+	h := newTestHandler(t, store, NamingOptions{Scheme: DefaultNamingScheme})
+
+	for _, tt := range []struct {
+		url  string
+		body string
+	}{
+		{InfluxWriteURL, "cpu usage=1\ncpu usage"},
+		{InfluxWriteURL + "?precision=d", "cpu usage=1"},
+	} {
+		req := httptest.NewRequest(http.MethodPost, tt.url, strings.NewReader(tt.body))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, tt.body)
+	}
+
+	// Nothing is written if any line of a request is malformed.
+	assert.Empty(t, store.Writes())
+}
+
+func TestNewInfluxWriteHandlerErrors(t *testing.T) {
+	opts := models.NewTagOptions()
+	naming := NamingOptions{Scheme: DefaultNamingScheme}
+	_, err := NewInfluxWriteHandler(nil, nil, opts, naming,
+		DefaultMaxBodySize, tally.NoopScope)
+	assert.Error(t, err)
+
+	store := mock.NewMockStorage()
+	for _, naming := range []NamingOptions{
+		{Scheme: "unknown"},
+		{Scheme: FieldTagNamingScheme},
+	} {
+		_, err := NewInfluxWriteHandler(store, nil, opts, naming,
+			DefaultMaxBodySize, tally.NoopScope)
+		assert.Error(t, err)
+	}
+
+	_, err = NewInfluxWriteHandler(store, nil, opts, naming, 0, tally.NoopScope)
+	assert.Error(t, err)
+}
+
+func TestInfluxWriteBodyTooLarge(t *testing.T) {
+	store := mock.NewMockStorage()
+	h := newTestHandler(t, store, NamingOptions{Scheme: DefaultNamingScheme})
+	h.maxBodySize = 16
+
+	body := "cpu,host=a usage=1 1500\n"
+	req := httptest.NewRequest(http.MethodPost, InfluxWriteURL, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	// Bodies are also limited once decompressed.
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(strings.Repeat(body, 8)))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	h.maxBodySize = int64(buf.Len())
+
+	req = httptest.NewRequest(http.MethodPost, InfluxWriteURL, &buf)
+	req.Header.Set("Content-Encoding", "gzip")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	assert.Empty(t, store.Writes())
+}
+
+func TestSanitizeName(t *testing.T) {
+	assert.Equal(t, "disk_io:rate_1m", string(sanitizeName([]byte("disk.io:rate-1m"))))
+	assert.Equal(t, "_xx", string(sanitizeName([]byte("9xx"))))
+}
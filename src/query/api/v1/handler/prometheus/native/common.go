@@ -21,16 +21,21 @@
 package native
 
 import (
+	"encoding/base64"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/errors"
 	"github.com/m3db/m3/src/query/functions/utils"
 	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/query/util"
 	"github.com/m3db/m3/src/query/util/json"
@@ -46,9 +51,16 @@ const (
 	queryParam        = "query"
 	stepParam         = "step"
 	debugParam        = "debug"
+	annotationsParam  = "annotations"
 	endExclusiveParam = "end-exclusive"
+	timeParam         = "time"
+	matchParam        = "match[]"
 
 	formatErrStr = "error parsing param: %s, error: %v"
+
+	// limitExceededErrorType is the error type returned when a
+	// query exceeds one of its cost limits.
+	limitExceededErrorType = "limit_exceeded"
 )
 
 func parseTime(r *http.Request, key string) (time.Time, error) {
@@ -125,6 +137,16 @@ func parseParams(r *http.Request) (models.RequestParams, *xhttp.ParseError) {
 		params.Debug = debug
 	}
 
+	// Annotations are only returned if explicitly requested
+	annotationsVal := r.FormValue(annotationsParam)
+	if annotationsVal != "" {
+		includeAnnotations, err := strconv.ParseBool(annotationsVal)
+		if err != nil {
+			return params, xhttp.NewParseError(fmt.Errorf(formatErrStr, annotationsParam, err), http.StatusBadRequest)
+		}
+		params.IncludeAnnotations = includeAnnotations
+	}
+
 	// Default to including end if unable to parse the flag
 	endExclusiveVal := r.FormValue(endExclusiveParam)
 	params.IncludeEnd = true
@@ -140,6 +162,128 @@ func parseParams(r *http.Request) (models.RequestParams, *xhttp.ParseError) {
 	return params, nil
 }
 
+// parseInstantaneousParams parses all params from the GET request for an
+// instantaneous query, evaluating the query at a single step
+func parseInstantaneousParams(
+	r *http.Request,
+	lookbackDuration time.Duration,
+) (models.RequestParams, *xhttp.ParseError) {
+	params := models.RequestParams{
+		Now:              time.Now(),
+		Step:             lookbackDuration,
+		LookbackDuration: lookbackDuration,
+		IncludeEnd:       true,
+		Instant:          true,
+	}
+
+	if lookbackDuration <= 0 {
+		err := fmt.Errorf("invalid lookback duration: %v", lookbackDuration)
+		return params, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	t, err := prometheus.ParseRequestTimeout(r)
+	if err != nil {
+		return params, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+	params.Timeout = t
+
+	// Default to evaluating at the current time if no time is specified
+	evalTime := params.Now
+	if r.FormValue(timeParam) != "" {
+		evalTime, err = parseTime(r, timeParam)
+		if err != nil {
+			return params, xhttp.NewParseError(fmt.Errorf(formatErrStr, timeParam, err), http.StatusBadRequest)
+		}
+	}
+	params.Start = evalTime
+	params.End = evalTime
+
+	query, err := parseQuery(r)
+	if err != nil {
+		return params, xhttp.NewParseError(fmt.Errorf(formatErrStr, queryParam, err), http.StatusBadRequest)
+	}
+	params.Query = query
+
+	debugVal := r.FormValue(debugParam)
+	if debugVal != "" {
+		debug, err := strconv.ParseBool(debugVal)
+		if err != nil {
+			logging.WithContext(r.Context()).Warn("unable to parse debug flag", zap.Any("error", err))
+		}
+		params.Debug = debug
+	}
+
+	return params, nil
+}
+
+// parseMetadataTimeRange parses the optional start and end params for the
+// metadata endpoints, defaulting to all time up until now
+func parseMetadataTimeRange(r *http.Request) (time.Time, time.Time, *xhttp.ParseError) {
+	start := time.Unix(0, 0)
+	if r.FormValue(startParam) != "" {
+		t, err := parseTime(r, startParam)
+		if err != nil {
+			return start, start, xhttp.NewParseError(fmt.Errorf(formatErrStr, startParam, err), http.StatusBadRequest)
+		}
+		start = t
+	}
+
+	end := time.Now()
+	if r.FormValue(endParam) != "" {
+		t, err := parseTime(r, endParam)
+		if err != nil {
+			return start, end, xhttp.NewParseError(fmt.Errorf(formatErrStr, endParam, err), http.StatusBadRequest)
+		}
+		end = t
+	}
+
+	if start.After(end) {
+		err := fmt.Errorf("start %v is after end %v", start, end)
+		return start, end, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	return start, end, nil
+}
+
+// parseMatchers parses the match[] params into a set of matchers per
+// selector, if no selectors are given all series with a name are matched
+func parseMatchers(
+	r *http.Request,
+	tagOpts models.TagOptions,
+	required bool,
+) ([]models.Matchers, *xhttp.ParseError) {
+	if err := r.ParseForm(); err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	selectors := r.Form[matchParam]
+	if len(selectors) == 0 {
+		if required {
+			err := fmt.Errorf(formatErrStr, matchParam, errors.ErrNoQueryFound)
+			return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+		}
+
+		matcher, err := models.NewMatcher(models.MatchRegexp, tagOpts.MetricName(), []byte(".+"))
+		if err != nil {
+			return nil, xhttp.NewParseError(err, http.StatusInternalServerError)
+		}
+
+		return []models.Matchers{{matcher}}, nil
+	}
+
+	matchers := make([]models.Matchers, 0, len(selectors))
+	for _, selector := range selectors {
+		m, err := promql.ParseSeriesMatchQuery(selector, tagOpts)
+		if err != nil {
+			return nil, xhttp.NewParseError(fmt.Errorf(formatErrStr, matchParam, err), http.StatusBadRequest)
+		}
+
+		matchers = append(matchers, m)
+	}
+
+	return matchers, nil
+}
+
 func parseQuery(r *http.Request) (string, error) {
 	queries, ok := r.URL.Query()[queryParam]
 	if !ok || len(queries) == 0 || queries[0] == "" {
@@ -201,6 +345,10 @@ func renderResultsJSON(w io.Writer, series []*ts.Series, params models.RequestPa
 		}
 		jw.EndArray()
 
+		if params.IncludeAnnotations {
+			renderAnnotationsJSON(jw, s.Annotations, params)
+		}
+
 		fixedStep, ok := s.Values().(ts.FixedResolutionMutableValues)
 		if ok {
 			jw.BeginObjectField("step_size_ms")
@@ -215,3 +363,214 @@ func renderResultsJSON(w io.Writer, series []*ts.Series, params models.RequestPa
 	jw.EndObject()
 	jw.Close()
 }
+
+// renderAnnotationsJSON renders the annotations of a series within the query
+// range, annotations are rendered with their timestamp in seconds and their
+// value base64 encoded since annotations are arbitrary bytes.
+func renderAnnotationsJSON(
+	jw *json.Writer,
+	annotations ts.Annotations,
+	params models.RequestParams,
+) {
+	jw.BeginObjectField("annotations")
+	jw.BeginArray()
+	for _, annotation := range annotations {
+		if annotation.Timestamp.Before(params.Start) ||
+			annotation.Timestamp.After(params.End) {
+			continue
+		}
+
+		jw.BeginArray()
+		jw.WriteFloat64(float64(annotation.Timestamp.UnixNano()) / float64(time.Second))
+		jw.WriteString(base64.StdEncoding.EncodeToString(annotation.Value))
+		jw.EndArray()
+	}
+	jw.EndArray()
+}
+
+// instantaneousValue returns the value of the series at the evaluation time,
+// which is the last step of the series since steps are aligned to the end
+func instantaneousValue(s *ts.Series) (ts.Datapoint, bool) {
+	length := s.Len()
+	if length == 0 {
+		return ts.Datapoint{}, false
+	}
+
+	return s.Values().DatapointAt(length - 1), true
+}
+
+func renderResultsInstantaneousJSON(
+	w io.Writer,
+	series []*ts.Series,
+	params models.RequestParams,
+) {
+	jw := json.NewWriter(w)
+	jw.BeginObject()
+
+	jw.BeginObjectField("status")
+	jw.WriteString("success")
+
+	jw.BeginObjectField("data")
+	jw.BeginObject()
+
+	jw.BeginObjectField("resultType")
+	jw.WriteString("vector")
+
+	jw.BeginObjectField("result")
+	jw.BeginArray()
+	for _, s := range series {
+		dp, ok := instantaneousValue(s)
+		// Series without a value within the lookback are not part of the result
+		if !ok || math.IsNaN(dp.Value) {
+			continue
+		}
+
+		jw.BeginObject()
+		jw.BeginObjectField("metric")
+		jw.BeginObject()
+		for _, t := range s.Tags.Tags {
+			jw.BeginObjectField(string(t.Name))
+			jw.WriteString(string(t.Value))
+		}
+		jw.EndObject()
+
+		jw.BeginObjectField("value")
+		jw.BeginArray()
+		jw.WriteFloat64(float64(params.End.UnixNano()) / float64(time.Second))
+		jw.WriteString(utils.FormatFloat(dp.Value))
+		jw.EndArray()
+		jw.EndObject()
+	}
+	jw.EndArray()
+
+	jw.EndObject()
+
+	jw.EndObject()
+	jw.Close()
+}
+
+func renderResultsInstantaneousScalarJSON(
+	w io.Writer,
+	series []*ts.Series,
+	params models.RequestParams,
+) {
+	value := math.NaN()
+	if len(series) > 0 {
+		if dp, ok := instantaneousValue(series[0]); ok {
+			value = dp.Value
+		}
+	}
+
+	jw := json.NewWriter(w)
+	jw.BeginObject()
+
+	jw.BeginObjectField("status")
+	jw.WriteString("success")
+
+	jw.BeginObjectField("data")
+	jw.BeginObject()
+
+	jw.BeginObjectField("resultType")
+	jw.WriteString("scalar")
+
+	jw.BeginObjectField("result")
+	jw.BeginArray()
+	jw.WriteFloat64(float64(params.End.UnixNano()) / float64(time.Second))
+	jw.WriteString(utils.FormatFloat(value))
+	jw.EndArray()
+
+	jw.EndObject()
+
+	jw.EndObject()
+	jw.Close()
+}
+
+func renderTagNamesJSON(w io.Writer, result storage.CompleteTagsResult) {
+	jw := json.NewWriter(w)
+	jw.BeginObject()
+
+	jw.BeginObjectField("status")
+	jw.WriteString("success")
+
+	jw.BeginObjectField("data")
+	jw.BeginArray()
+	for _, tag := range result.CompletedTags {
+		jw.WriteString(string(tag.Name))
+	}
+	jw.EndArray()
+
+	jw.EndObject()
+	jw.Close()
+}
+
+func renderTagValuesJSON(w io.Writer, result storage.CompleteTagsResult) {
+	jw := json.NewWriter(w)
+	jw.BeginObject()
+
+	jw.BeginObjectField("status")
+	jw.WriteString("success")
+
+	jw.BeginObjectField("data")
+	jw.BeginArray()
+	for _, tag := range result.CompletedTags {
+		for _, value := range tag.Values {
+			jw.WriteString(string(value))
+		}
+	}
+	jw.EndArray()
+
+	jw.EndObject()
+	jw.Close()
+}
+
+func renderSeriesMatchJSON(w io.Writer, metrics models.Metrics) {
+	jw := json.NewWriter(w)
+	jw.BeginObject()
+
+	jw.BeginObjectField("status")
+	jw.WriteString("success")
+
+	jw.BeginObjectField("data")
+	jw.BeginArray()
+	for _, m := range metrics {
+		jw.BeginObject()
+		for _, t := range m.Tags.Tags {
+			jw.BeginObjectField(string(t.Name))
+			jw.WriteString(string(t.Value))
+		}
+		jw.EndObject()
+	}
+	jw.EndArray()
+
+	jw.EndObject()
+	jw.Close()
+}
+
+func renderSeriesDeleteJSON(w io.Writer, deleted int64) {
+	jw := json.NewWriter(w)
+	jw.BeginObject()
+
+	jw.BeginObjectField("status")
+	jw.WriteString("success")
+
+	jw.BeginObjectField("data")
+	jw.BeginObject()
+	jw.BeginObjectField("deleted")
+	jw.WriteInt(int(deleted))
+	jw.EndObject()
+
+	jw.EndObject()
+	jw.Close()
+}
+
+// writeReadError serves an error from reading, with a typed error
+// if the query exceeded one of its cost limits.
+func writeReadError(w http.ResponseWriter, err error) {
+	if cost.IsLimitError(err) {
+		xhttp.ErrorWithType(w, err, limitExceededErrorType,
+			http.StatusUnprocessableEntity)
+		return
+	}
+
+	xhttp.Error(w, err, http.StatusBadRequest)
+}
@@ -23,11 +23,15 @@ package native
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/test"
 	"github.com/m3db/m3/src/query/ts"
@@ -60,6 +64,28 @@ func TestParamParsing(t *testing.T) {
 	require.Equal(t, promQuery, r.Query)
 }
 
+func TestAnnotationsParamParsing(t *testing.T) {
+	req, _ := http.NewRequest("GET", PromReadURL, nil)
+	req.URL.RawQuery = defaultParams().Encode()
+	r, err := parseParams(req)
+	require.Nil(t, err, "unable to parse request")
+	assert.False(t, r.IncludeAnnotations)
+
+	vals := defaultParams()
+	vals.Add(annotationsParam, "true")
+	req.URL.RawQuery = vals.Encode()
+	r, err = parseParams(req)
+	require.Nil(t, err, "unable to parse request")
+	assert.True(t, r.IncludeAnnotations)
+
+	vals = defaultParams()
+	vals.Add(annotationsParam, "maybe")
+	req.URL.RawQuery = vals.Encode()
+	_, err = parseParams(req)
+	require.NotNil(t, err)
+	require.Equal(t, http.StatusBadRequest, err.Code())
+}
+
 func TestInvalidStart(t *testing.T) {
 	req, _ := http.NewRequest("GET", PromReadURL, nil)
 	vals := defaultParams()
@@ -171,6 +197,62 @@ func TestRenderResultsJSON(t *testing.T) {
 	assert.Equal(t, expected, actual, xtest.Diff(expected, actual))
 }
 
+func TestRenderResultsJSONWithAnnotations(t *testing.T) {
+	start := time.Unix(1535948880, 0)
+
+	buffer := bytes.NewBuffer(nil)
+	params := models.RequestParams{
+		Start:              start,
+		End:                start.Add(10 * time.Second),
+		IncludeAnnotations: true,
+	}
+	series := ts.NewSeries("foo", ts.NewFixedStepValues(10*time.Second, 2, 1, start),
+		test.TagSliceToTags([]models.Tag{
+			models.Tag{Name: []byte("bar"), Value: []byte("baz")},
+		}))
+	series.Annotations = ts.Annotations{
+		{Timestamp: start.Add(-time.Second), Value: []byte("before")},
+		{Timestamp: start.Add(1500 * time.Millisecond), Value: []byte("trace")},
+	}
+
+	renderResultsJSON(buffer, []*ts.Series{series}, params)
+
+	expected := mustPrettyJSON(t, `
+	{
+		"status": "success",
+		"data": {
+			"resultType": "matrix",
+			"result": [
+				{
+					"metric": {
+						"bar": "baz"
+					},
+					"values": [
+						[
+							1535948880,
+							"1"
+						],
+						[
+							1535948890,
+							"1"
+						]
+					],
+					"annotations": [
+						[
+							1535948881.5,
+							"dHJhY2U="
+						]
+					],
+					"step_size_ms": 10000
+				}
+			]
+		}
+	}
+	`)
+	actual := mustPrettyJSON(t, buffer.String())
+	assert.Equal(t, expected, actual, xtest.Diff(expected, actual))
+}
+
 func mustPrettyJSON(t *testing.T, str string) string {
 	var unmarshalled map[string]interface{}
 	err := json.Unmarshal([]byte(str), &unmarshalled)
@@ -179,3 +261,124 @@ func mustPrettyJSON(t *testing.T, str string) string {
 	require.NoError(t, err)
 	return string(pretty)
 }
+
+func TestParseInstantaneousParams(t *testing.T) {
+	req, _ := http.NewRequest("GET", PromReadInstantURL, nil)
+	vals := url.Values{}
+	vals.Add(queryParam, promQuery)
+	vals.Add(timeParam, "1535948880")
+	req.URL.RawQuery = vals.Encode()
+
+	r, err := parseInstantaneousParams(req, time.Minute)
+	require.Nil(t, err, "unable to parse request")
+	assert.Equal(t, promQuery, r.Query)
+	assert.Equal(t, time.Unix(1535948880, 0), r.Start)
+	assert.Equal(t, time.Unix(1535948880, 0), r.End)
+	assert.Equal(t, time.Minute, r.Step)
+	assert.Equal(t, time.Minute, r.LookbackDuration)
+	assert.True(t, r.Instant)
+	assert.True(t, r.IncludeEnd)
+}
+
+func TestParseInstantaneousParamsInvalidLookback(t *testing.T) {
+	req, _ := http.NewRequest("GET", PromReadInstantURL, nil)
+	vals := make(url.Values)
+	vals.Add(queryParam, promQuery)
+	req.URL.RawQuery = vals.Encode()
+
+	_, err := parseInstantaneousParams(req, 0)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Code())
+}
+
+func TestParseInstantaneousParamsDefaultsToNow(t *testing.T) {
+	req, _ := http.NewRequest("GET", PromReadInstantURL, nil)
+	vals := url.Values{}
+	vals.Add(queryParam, promQuery)
+	req.URL.RawQuery = vals.Encode()
+
+	r, err := parseInstantaneousParams(req, time.Minute)
+	require.Nil(t, err, "unable to parse request")
+	assert.Equal(t, r.Now, r.End)
+}
+
+func TestRenderResultsInstantaneousJSON(t *testing.T) {
+	start := time.Unix(1535948870, 0)
+
+	buffer := bytes.NewBuffer(nil)
+	params := models.RequestParams{End: time.Unix(1535948880, int64(500*time.Millisecond))}
+	series := []*ts.Series{
+		ts.NewSeries("foo", ts.NewFixedStepValues(10*time.Second, 2, 1, start), test.TagSliceToTags([]models.Tag{
+			models.Tag{Name: []byte("bar"), Value: []byte("baz")},
+		})),
+		ts.NewSeries("bar", ts.NewFixedStepValues(10*time.Second, 2, math.NaN(), start), test.TagSliceToTags([]models.Tag{
+			models.Tag{Name: []byte("baz"), Value: []byte("bar")},
+		})),
+	}
+
+	renderResultsInstantaneousJSON(buffer, series, params)
+
+	expected := mustPrettyJSON(t, `
+	{
+		"status": "success",
+		"data": {
+			"resultType": "vector",
+			"result": [
+				{
+					"metric": {
+						"bar": "baz"
+					},
+					"value": [
+						1535948880.5,
+						"1"
+					]
+				}
+			]
+		}
+	}
+	`)
+	actual := mustPrettyJSON(t, buffer.String())
+	assert.Equal(t, expected, actual, xtest.Diff(expected, actual))
+}
+
+func TestRenderResultsInstantaneousScalarJSON(t *testing.T) {
+	start := time.Unix(1535948870, 0)
+
+	buffer := bytes.NewBuffer(nil)
+	params := models.RequestParams{End: time.Unix(1535948880, int64(500*time.Millisecond))}
+	series := []*ts.Series{
+		ts.NewSeries("", ts.NewFixedStepValues(10*time.Second, 2, 3, start), models.EmptyTags()),
+	}
+
+	renderResultsInstantaneousScalarJSON(buffer, series, params)
+
+	expected := mustPrettyJSON(t, `
+	{
+		"status": "success",
+		"data": {
+			"resultType": "scalar",
+			"result": [
+				1535948880.5,
+				"3"
+			]
+		}
+	}
+	`)
+	actual := mustPrettyJSON(t, buffer.String())
+	assert.Equal(t, expected, actual, xtest.Diff(expected, actual))
+}
+
+func TestWriteReadError(t *testing.T) {
+	w := httptest.NewRecorder()
+	writeReadError(w, cost.NewLimitError(cost.FetchedSeriesResource, 10))
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.JSONEq(t, `{
+		"error": "query exceeded limit of 10 fetched series",
+		"errorType": "limit_exceeded"
+	}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	writeReadError(w, errors.New("foo"))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error": "foo"}`, w.Body.String())
+}
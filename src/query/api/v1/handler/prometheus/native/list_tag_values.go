@@ -0,0 +1,96 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package native
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+const (
+	// NameReplace is the parameter that gets replaced
+	NameReplace = "name"
+
+	// ListTagValuesHTTPMethod is the HTTP method used with this resource.
+	ListTagValuesHTTPMethod = http.MethodGet
+
+	promDefaultName = "__name__"
+)
+
+var (
+	// ListTagValuesURL is the url for listing the values of a tag, this
+	// matches the default URL for the label values endpoint found on a
+	// Prometheus server
+	ListTagValuesURL = fmt.Sprintf("%s/label/{%s}/values", handler.RoutePrefixV1, NameReplace)
+)
+
+// ListTagValuesHandler represents a handler for the list tag values endpoint.
+type ListTagValuesHandler struct {
+	storage storage.Storage
+	tagOpts models.TagOptions
+}
+
+// NewListTagValuesHandler returns a new instance of handler.
+func NewListTagValuesHandler(
+	storage storage.Storage,
+	tagOpts models.TagOptions,
+) http.Handler {
+	return &ListTagValuesHandler{
+		storage: storage,
+		tagOpts: tagOpts,
+	}
+}
+
+func (h *ListTagValuesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := context.WithValue(r.Context(), handler.HeaderKey, r.Header)
+	logger := logging.WithContext(ctx)
+
+	name, ok := mux.Vars(r)[NameReplace]
+	if !ok || len(name) == 0 {
+		xhttp.Error(w, fmt.Errorf("missing tag name"), http.StatusBadRequest)
+		return
+	}
+
+	nameBytes := []byte(name)
+	if name == promDefaultName {
+		nameBytes = h.tagOpts.MetricName()
+	}
+
+	result, rErr := completeTags(ctx, h.storage, h.tagOpts, r, false, [][]byte{nameBytes})
+	if rErr != nil {
+		logger.Error("unable to complete tag values", zap.Error(rErr.Inner()))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	renderTagValuesJSON(w, result)
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package native
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// ListTagsURL is the url for listing tag names, this matches the default
+	// URL for the label names endpoint found on a Prometheus server
+	ListTagsURL = handler.RoutePrefixV1 + "/labels"
+
+	// ListTagsHTTPMethod is the HTTP method used with this resource.
+	ListTagsHTTPMethod = http.MethodGet
+)
+
+// ListTagsHandler represents a handler for the list tag names endpoint.
+type ListTagsHandler struct {
+	storage storage.Storage
+	tagOpts models.TagOptions
+}
+
+// NewListTagsHandler returns a new instance of handler.
+func NewListTagsHandler(
+	storage storage.Storage,
+	tagOpts models.TagOptions,
+) http.Handler {
+	return &ListTagsHandler{
+		storage: storage,
+		tagOpts: tagOpts,
+	}
+}
+
+func (h *ListTagsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := context.WithValue(r.Context(), handler.HeaderKey, r.Header)
+	logger := logging.WithContext(ctx)
+
+	result, rErr := completeTags(ctx, h.storage, h.tagOpts, r, true, nil)
+	if rErr != nil {
+		logger.Error("unable to complete tags", zap.Error(rErr.Inner()))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	renderTagNamesJSON(w, result)
+}
+
+// completeTags runs a complete tags query for each match[] selector in the
+// request and merges the deduplicated results
+func completeTags(
+	ctx context.Context,
+	store storage.Storage,
+	tagOpts models.TagOptions,
+	r *http.Request,
+	nameOnly bool,
+	filterNameTags [][]byte,
+) (storage.CompleteTagsResult, *xhttp.ParseError) {
+	start, end, rErr := parseMetadataTimeRange(r)
+	if rErr != nil {
+		return storage.CompleteTagsResult{}, rErr
+	}
+
+	matchers, rErr := parseMatchers(r, tagOpts, false)
+	if rErr != nil {
+		return storage.CompleteTagsResult{}, rErr
+	}
+
+	timeout, err := prometheus.ParseRequestTimeout(r)
+	if err != nil {
+		return storage.CompleteTagsResult{}, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	builder := storage.NewCompleteTagsResultBuilder(nameOnly, filterNameTags)
+	for _, m := range matchers {
+		result, err := store.CompleteTags(ctx, &storage.CompleteTagsQuery{
+			CompleteNameOnly: nameOnly,
+			FilterNameTags:   filterNameTags,
+			TagMatchers:      m,
+			Start:            start,
+			End:              end,
+		}, &storage.FetchOptions{})
+		if err != nil {
+			return storage.CompleteTagsResult{}, xhttp.NewParseError(err, http.StatusBadRequest)
+		}
+
+		if err := builder.Add(result); err != nil {
+			return storage.CompleteTagsResult{}, xhttp.NewParseError(err, http.StatusInternalServerError)
+		}
+	}
+
+	return builder.Build(), nil
+}
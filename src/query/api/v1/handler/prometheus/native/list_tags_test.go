@@ -0,0 +1,169 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package native
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListTags(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	mockStorage := mock.NewMockStorage()
+	mockStorage.SetCompleteTagsResult(&storage.CompleteTagsResult{
+		CompleteNameOnly: true,
+		CompletedTags: []storage.CompletedTag{
+			{Name: []byte("bar")},
+			{Name: []byte("foo")},
+		},
+	}, nil)
+
+	h := NewListTagsHandler(mockStorage, models.NewTagOptions())
+	req, _ := http.NewRequest(ListTagsHTTPMethod, ListTagsURL, nil)
+	vals := url.Values{}
+	vals.Add(matchParam, `up{job="prometheus"}`)
+	req.URL.RawQuery = vals.Encode()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"status":"success","data":["bar","foo"]}`, w.Body.String())
+}
+
+func TestListTagsInvalidMatcher(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	h := NewListTagsHandler(mock.NewMockStorage(), models.NewTagOptions())
+	req, _ := http.NewRequest(ListTagsHTTPMethod, ListTagsURL, nil)
+	vals := url.Values{}
+	vals.Add(matchParam, `sum(up)`)
+	req.URL.RawQuery = vals.Encode()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestListTagValues(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	mockStorage := mock.NewMockStorage()
+	mockStorage.SetCompleteTagsResult(&storage.CompleteTagsResult{
+		CompletedTags: []storage.CompletedTag{
+			{Name: []byte("job"), Values: [][]byte{[]byte("a"), []byte("b")}},
+		},
+	}, nil)
+
+	router := mux.NewRouter()
+	router.Handle(ListTagValuesURL, NewListTagValuesHandler(mockStorage, models.NewTagOptions()))
+	req, _ := http.NewRequest(ListTagValuesHTTPMethod, "/api/v1/label/job/values", nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"status":"success","data":["a","b"]}`, w.Body.String())
+}
+
+func TestPromSeriesMatch(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	tags := models.NewTags(1, models.NewTagOptions()).AddTag(models.Tag{
+		Name:  []byte("foo"),
+		Value: []byte("bar"),
+	})
+	mockStorage := mock.NewMockStorage()
+	mockStorage.SetFetchTagsResult(&storage.SearchResults{
+		Metrics: models.Metrics{
+			models.Metric{ID: "foo=bar", Tags: tags},
+		},
+	}, nil)
+
+	h := NewPromSeriesMatchHandler(mockStorage, models.NewTagOptions())
+	req, _ := http.NewRequest(http.MethodGet, PromSeriesMatchURL, nil)
+	vals := url.Values{}
+	vals.Add(matchParam, `up`)
+	vals.Add(matchParam, `{foo="bar"}`)
+	req.URL.RawQuery = vals.Encode()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"status":"success","data":[{"foo":"bar"}]}`, w.Body.String())
+}
+
+func TestPromSeriesMatchRequiresMatchers(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	h := NewPromSeriesMatchHandler(mock.NewMockStorage(), models.NewTagOptions())
+	req, _ := http.NewRequest(http.MethodGet, PromSeriesMatchURL, nil)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestPromSeriesDelete(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	mockStorage := mock.NewMockStorage()
+	mockStorage.SetDeleteResult(2, nil)
+
+	h := NewPromSeriesDeleteHandler(mockStorage, models.NewTagOptions())
+	req, _ := http.NewRequest(http.MethodPost, PromSeriesDeleteURL, nil)
+	vals := url.Values{}
+	vals.Add(matchParam, `up`)
+	vals.Add(matchParam, `{foo="bar"}`)
+	req.URL.RawQuery = vals.Encode()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"status":"success","data":{"deleted":4}}`, w.Body.String())
+
+	deletes := mockStorage.Deletes()
+	require.Len(t, deletes, 2)
+	assert.Equal(t, `up`, deletes[0].Raw)
+	assert.Equal(t, `{foo="bar"}`, deletes[1].Raw)
+}
+
+func TestPromSeriesDeleteRequiresMatchers(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	mockStorage := mock.NewMockStorage()
+	h := NewPromSeriesDeleteHandler(mockStorage, models.NewTagOptions())
+	req, _ := http.NewRequest(http.MethodPost, PromSeriesDeleteURL, nil)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Len(t, mockStorage.Deletes(), 0)
+}
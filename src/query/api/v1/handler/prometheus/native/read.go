@@ -21,11 +21,14 @@
 package native
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math"
 	"net/http"
 	"sort"
+	"strconv"
+	"time"
 
 	"github.com/m3db/m3/src/query/api/v1/handler"
 	"github.com/m3db/m3/src/query/block"
@@ -99,7 +102,7 @@ func (h *PromReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	result, err := h.read(ctx, w, params)
 	if err != nil {
 		logger.Error("unable to fetch data", zap.Error(err))
-		xhttp.Error(w, err, http.StatusBadRequest)
+		writeReadError(w, err)
 		return
 	}
 
@@ -113,6 +116,16 @@ func (h *PromReadHandler) read(
 	reqCtx context.Context,
 	w http.ResponseWriter,
 	params models.RequestParams,
+) ([]*ts.Series, error) {
+	return read(reqCtx, h.engine, h.tagOpts, w, params)
+}
+
+func read(
+	reqCtx context.Context,
+	engine *executor.Engine,
+	tagOpts models.TagOptions,
+	w http.ResponseWriter,
+	params models.RequestParams,
 ) ([]*ts.Series, error) {
 	ctx, cancel := context.WithTimeout(reqCtx, params.Timeout)
 	defer cancel()
@@ -123,25 +136,31 @@ func (h *PromReadHandler) read(
 	opts.AbortCh = abortCh
 
 	// TODO: Capture timing
-	parser, err := promql.Parse(params.Query, h.tagOpts)
+	parser, err := promql.Parse(params.Query, tagOpts)
 	if err != nil {
 		return nil, err
 	}
 
 	// Results is closed by execute
 	results := make(chan executor.Query)
-	go h.engine.ExecuteExpr(ctx, parser, opts, params, results)
+	go engine.ExecuteExpr(ctx, parser, opts, params, results)
 
 	// Block slices are sorted by start time
 	// TODO: Pooling
 	sortedBlockList := make([]blockWithMeta, 0, initialBlockAlloc)
-	var processErr error
+	var (
+		processErr error
+		queries    []executor.Query
+	)
+
 	for result := range results {
 		if result.Err != nil {
 			processErr = result.Err
 			break
 		}
 
+		queries = append(queries, result)
+
 		resultChan := result.Result.ResultChan()
 		firstElement := false
 		var numSteps, numSeries int
@@ -190,12 +209,36 @@ func (h *PromReadHandler) read(
 	if processErr != nil {
 		// Drain anything remaining
 		drainResultChan(results)
+		setQueryStatsHeaders(w, queries)
 		return nil, processErr
 	}
 
+	setQueryStatsHeaders(w, queries)
 	return sortedBlocksToSeriesList(sortedBlockList)
 }
 
+// setQueryStatsHeaders sets response headers describing the
+// resources used by the executed queries.
+func setQueryStatsHeaders(w http.ResponseWriter, queries []executor.Query) {
+	var stats executor.QueryStatistics
+	for _, query := range queries {
+		queryStats := query.Stats()
+		stats.FetchedSeries += queryStats.FetchedSeries
+		stats.FetchedDatapoints += queryStats.FetchedDatapoints
+		stats.FetchedBlocks += queryStats.FetchedBlocks
+		if queryStats.QueryExecutionDuration > stats.QueryExecutionDuration {
+			stats.QueryExecutionDuration = queryStats.QueryExecutionDuration
+		}
+	}
+
+	header := w.Header()
+	header.Set(handler.FetchedSeriesHeader, strconv.FormatInt(stats.FetchedSeries, 10))
+	header.Set(handler.FetchedDatapointsHeader, strconv.FormatInt(stats.FetchedDatapoints, 10))
+	header.Set(handler.FetchedBlocksHeader, strconv.FormatInt(stats.FetchedBlocks, 10))
+	header.Set(handler.QueryDurationHeader,
+		time.Duration(stats.QueryExecutionDuration).String())
+}
+
 func drainResultChan(resultsChan chan executor.Query) {
 	for result := range resultsChan {
 		// Ignore errors during drain
@@ -227,47 +270,124 @@ func sortedBlocksToSeriesList(blockList []blockWithMeta) ([]*ts.Series, error) {
 
 	numSeries := firstSeriesIter.SeriesCount()
 	seriesMeta := firstSeriesIter.SeriesMeta()
-	bounds := firstSeriesIter.Meta().Bounds
+	firstMeta := firstSeriesIter.Meta()
+	bounds := firstMeta.Bounds
+	numSteps := firstStepIter.StepCount()
+	numValues := numSteps * len(blockList)
+
+	seriesValues := make([]ts.FixedResolutionMutableValues, numSeries)
+	seriesAnnotations := make([]ts.Annotations, numSeries)
+	for i := range seriesValues {
+		seriesValues[i] = ts.NewFixedStepValues(bounds.StepSize, numValues, math.NaN(), bounds.Start)
+	}
 
-	seriesList := make([]*ts.Series, numSeries)
-	seriesIters := make([]block.SeriesIter, len(blockList))
-	// To create individual series, we iterate over seriesIterators for each block in the block list.
-	// For each iterator, the nth current() will be combined to give the nth series
-	for i, b := range blockList {
+	// NB: operations such as sort may order series differently in each block.
+	// Series are combined positionally when a block lists them in the same
+	// order as the first block, otherwise they are matched by their tags with
+	// the order of the first block determining the order of rendered series.
+	var seriesIndices map[string][]int
+	for blockIdx, b := range blockList {
 		seriesIter, err := b.block.SeriesIter()
 		if err != nil {
 			return nil, err
 		}
 
-		seriesIters[i] = seriesIter
-	}
+		blockMeta := seriesIter.Meta()
+		var remaining map[string][]int
+		if !sameSeriesOrder(firstMeta, seriesMeta, blockMeta, seriesIter.SeriesMeta()) {
+			if seriesIndices == nil {
+				seriesIndices = make(map[string][]int, numSeries)
+				for i, meta := range seriesMeta {
+					id := flattenedSeriesID(firstMeta, meta)
+					seriesIndices[id] = append(seriesIndices[id], i)
+				}
+			}
 
-	numValues := firstStepIter.StepCount() * len(blockList)
-	for i := 0; i < numSeries; i++ {
-		values := ts.NewFixedStepValues(bounds.StepSize, numValues, math.NaN(), bounds.Start)
-		valIdx := 0
-		for idx, iter := range seriesIters {
-			if !iter.Next() {
-				return nil, fmt.Errorf("invalid number of datapoints for series: %d, block: %d", i, idx)
+			remaining = make(map[string][]int, len(seriesIndices))
+			for id, indices := range seriesIndices {
+				remaining[id] = indices
 			}
+		}
 
-			blockSeries, err := iter.Current()
+		valIdx := blockIdx * numSteps
+		for i := 0; seriesIter.Next(); i++ {
+			blockSeries, err := seriesIter.Current()
 			if err != nil {
 				return nil, err
 			}
 
-			for i := 0; i < blockSeries.Len(); i++ {
-				values.SetValueAt(valIdx, blockSeries.ValueAtStep(i))
-				valIdx++
+			seriesIdx := i
+			if remaining != nil {
+				id := flattenedSeriesID(blockMeta, blockSeries.Meta)
+				indices := remaining[id]
+				if len(indices) == 0 {
+					return nil, fmt.Errorf("unmatched series: %d, block: %d", i, blockIdx)
+				}
+
+				seriesIdx = indices[0]
+				remaining[id] = indices[1:]
+			} else if i >= numSeries {
+				return nil, fmt.Errorf("invalid number of series: %d, block: %d", i, blockIdx)
+			}
+
+			for step := 0; step < blockSeries.Len(); step++ {
+				seriesValues[seriesIdx].SetValueAt(valIdx+step, blockSeries.ValueAtStep(step))
 			}
+			seriesAnnotations[seriesIdx] = append(seriesAnnotations[seriesIdx],
+				blockSeries.Meta.Annotations...)
 		}
+	}
 
+	seriesList := make([]*ts.Series, numSeries)
+	for i, values := range seriesValues {
 		seriesList[i] = ts.NewSeries(seriesMeta[i].Name, values, seriesMeta[i].Tags)
+		seriesList[i].Annotations = seriesAnnotations[i]
 	}
 
 	return seriesList, nil
 }
 
+// sameSeriesOrder returns true if both blocks list the same series in the
+// same order, in which case their series can be combined positionally.
+func sameSeriesOrder(
+	meta block.Metadata,
+	seriesMeta []block.SeriesMeta,
+	otherMeta block.Metadata,
+	otherSeriesMeta []block.SeriesMeta,
+) bool {
+	if len(seriesMeta) != len(otherSeriesMeta) || !tagsEqual(meta.Tags, otherMeta.Tags) {
+		return false
+	}
+
+	for i := range seriesMeta {
+		if !tagsEqual(seriesMeta[i].Tags, otherSeriesMeta[i].Tags) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func tagsEqual(a, b models.Tags) bool {
+	if len(a.Tags) != len(b.Tags) {
+		return false
+	}
+
+	for i, tag := range a.Tags {
+		if !bytes.Equal(tag.Name, b.Tags[i].Name) || !bytes.Equal(tag.Value, b.Tags[i].Value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// flattenedSeriesID returns an ID for the series including the tags
+// common to all series in the block.
+func flattenedSeriesID(meta block.Metadata, seriesMeta block.SeriesMeta) string {
+	return seriesMeta.Tags.Clone().Add(meta.Tags).ID()
+}
+
 func insertSortedBlock(
 	b block.Block,
 	blockList []blockWithMeta,
@@ -31,6 +31,7 @@ import (
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage/mock"
 	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/query/util/logging"
 
 	"github.com/stretchr/testify/assert"
@@ -66,3 +67,58 @@ func TestPromRead(t *testing.T) {
 		assert.Equal(t, float64(i), s.Values().ValueAt(i))
 	}
 }
+
+func TestSortedBlocksToSeriesListMatchesSeriesByTags(t *testing.T) {
+	tagsA := models.EmptyTags().AddTag(models.Tag{Name: []byte("a"), Value: []byte("1")})
+	tagsB := models.EmptyTags().AddTag(models.Tag{Name: []byte("a"), Value: []byte("2")})
+
+	_, bounds := test.GenerateValuesAndBounds(nil, nil)
+	first := test.NewBlockFromValuesWithSeriesMeta(bounds,
+		[]block.SeriesMeta{{Name: "a", Tags: tagsA}, {Name: "b", Tags: tagsB}},
+		[][]float64{{1, 2}, {3, 4}})
+
+	nextBounds := bounds
+	nextBounds.Start = bounds.End()
+	// NB: series are ordered differently in the second block.
+	second := test.NewBlockFromValuesWithSeriesMeta(nextBounds,
+		[]block.SeriesMeta{{Name: "b", Tags: tagsB}, {Name: "a", Tags: tagsA}},
+		[][]float64{{7, 8}, {5, 6}})
+
+	seriesList, err := sortedBlocksToSeriesList([]blockWithMeta{
+		{block: first},
+		{block: second},
+	})
+	require.NoError(t, err)
+	require.Len(t, seriesList, 2)
+
+	assert.Equal(t, "a", seriesList[0].Name())
+	assert.Equal(t, []float64{1, 2, 5, 6}, seriesValues(seriesList[0].Values()))
+	assert.Equal(t, "b", seriesList[1].Name())
+	assert.Equal(t, []float64{3, 4, 7, 8}, seriesValues(seriesList[1].Values()))
+}
+
+func TestSameSeriesOrder(t *testing.T) {
+	tagsA := models.EmptyTags().AddTag(models.Tag{Name: []byte("a"), Value: []byte("1")})
+	tagsB := models.EmptyTags().AddTag(models.Tag{Name: []byte("a"), Value: []byte("2")})
+	common := models.EmptyTags().AddTag(models.Tag{Name: []byte("c"), Value: []byte("3")})
+
+	meta := block.Metadata{Tags: common}
+	ordered := []block.SeriesMeta{{Tags: tagsA}, {Tags: tagsB}}
+	assert.True(t, sameSeriesOrder(meta, ordered, meta,
+		[]block.SeriesMeta{{Tags: tagsA}, {Tags: tagsB}}))
+	assert.False(t, sameSeriesOrder(meta, ordered, meta,
+		[]block.SeriesMeta{{Tags: tagsB}, {Tags: tagsA}}))
+	assert.False(t, sameSeriesOrder(meta, ordered, meta,
+		[]block.SeriesMeta{{Tags: tagsA}}))
+	assert.False(t, sameSeriesOrder(meta, ordered, block.Metadata{Tags: models.EmptyTags()},
+		[]block.SeriesMeta{{Tags: tagsA}, {Tags: tagsB}}))
+}
+
+func seriesValues(values ts.Values) []float64 {
+	result := make([]float64, values.Len())
+	for i := range result {
+		result[i] = values.ValueAt(i)
+	}
+
+	return result
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/errors"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// PromSeriesDeleteURL is the url for deleting the series matching a set
+	// of selectors, this matches the URL for the delete series admin endpoint
+	// found on a Prometheus server
+	PromSeriesDeleteURL = handler.RoutePrefixV1 + "/admin/tsdb/delete_series"
+)
+
+var (
+	// PromSeriesDeleteHTTPMethods are the HTTP methods used with this resource.
+	PromSeriesDeleteHTTPMethods = []string{http.MethodPost, http.MethodPut}
+)
+
+// PromSeriesDeleteHandler represents a handler for the series delete endpoint.
+type PromSeriesDeleteHandler struct {
+	storage storage.Storage
+	tagOpts models.TagOptions
+}
+
+// NewPromSeriesDeleteHandler returns a new instance of handler.
+func NewPromSeriesDeleteHandler(
+	storage storage.Storage,
+	tagOpts models.TagOptions,
+) http.Handler {
+	return &PromSeriesDeleteHandler{
+		storage: storage,
+		tagOpts: tagOpts,
+	}
+}
+
+func (h *PromSeriesDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := context.WithValue(r.Context(), handler.HeaderKey, r.Header)
+	logger := logging.WithContext(ctx)
+
+	// Deleting every series is never intended, so require explicit selectors
+	matchers, rErr := parseMatchers(r, h.tagOpts, true)
+	if rErr != nil {
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	// The range is optional, series are deleted across the entire retention
+	// of each namespace if it is not set.
+	start, err := parseOptionalTime(r, startParam)
+	if err != nil {
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+	end, err := parseOptionalTime(r, endParam)
+	if err != nil {
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	timeout, err := prometheus.ParseRequestTimeout(r)
+	if err != nil {
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var deleted int64
+	for idx, m := range matchers {
+		n, err := h.storage.Delete(ctx, &storage.DeleteQuery{
+			Raw:         r.Form[matchParam][idx],
+			TagMatchers: m,
+			Start:       start,
+			End:         end,
+		})
+		if err != nil {
+			logger.Error("unable to delete series",
+				zap.Int64("deleted", deleted+n), zap.Error(err))
+			xhttp.Error(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		deleted += n
+	}
+
+	logger.Info("deleted series",
+		zap.Strings("selectors", r.Form[matchParam]),
+		zap.Int64("deleted", deleted))
+
+	w.Header().Set("Content-Type", "application/json")
+	renderSeriesDeleteJSON(w, deleted)
+}
+
+// parseOptionalTime parses the time of the given param, returning the zero
+// time if the param is not set.
+func parseOptionalTime(r *http.Request, key string) (time.Time, error) {
+	t, err := parseTime(r, key)
+	if err == errors.ErrNotFound {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf(formatErrStr, key, err)
+	}
+	return t, nil
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package native
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// PromSeriesMatchURL is the url for listing the series matching a set
+	// of selectors, this matches the default URL for the series endpoint
+	// found on a Prometheus server
+	PromSeriesMatchURL = handler.RoutePrefixV1 + "/series"
+)
+
+var (
+	// PromSeriesMatchHTTPMethods are the HTTP methods used with this resource.
+	PromSeriesMatchHTTPMethods = []string{http.MethodGet, http.MethodPost}
+)
+
+// PromSeriesMatchHandler represents a handler for the series match endpoint.
+type PromSeriesMatchHandler struct {
+	storage storage.Storage
+	tagOpts models.TagOptions
+}
+
+// NewPromSeriesMatchHandler returns a new instance of handler.
+func NewPromSeriesMatchHandler(
+	storage storage.Storage,
+	tagOpts models.TagOptions,
+) http.Handler {
+	return &PromSeriesMatchHandler{
+		storage: storage,
+		tagOpts: tagOpts,
+	}
+}
+
+func (h *PromSeriesMatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := context.WithValue(r.Context(), handler.HeaderKey, r.Header)
+	logger := logging.WithContext(ctx)
+
+	start, end, rErr := parseMetadataTimeRange(r)
+	if rErr != nil {
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	matchers, rErr := parseMatchers(r, h.tagOpts, true)
+	if rErr != nil {
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	timeout, err := prometheus.ParseRequestTimeout(r)
+	if err != nil {
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var (
+		metrics models.Metrics
+		seen    = make(map[string]struct{})
+	)
+	for _, m := range matchers {
+		result, err := h.storage.FetchTags(ctx, &storage.FetchQuery{
+			TagMatchers: m,
+			Start:       start,
+			End:         end,
+		}, &storage.FetchOptions{})
+		if err != nil {
+			logger.Error("unable to match series", zap.Error(err))
+			xhttp.Error(w, err, http.StatusBadRequest)
+			return
+		}
+
+		// Dedupe series matched by more than one selector
+		for _, metric := range result.Metrics {
+			if _, ok := seen[metric.ID]; ok {
+				continue
+			}
+
+			seen[metric.ID] = struct{}{}
+			metrics = append(metrics, metric)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	renderSeriesMatchJSON(w, metrics)
+}
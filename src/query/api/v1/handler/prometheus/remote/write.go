@@ -32,6 +32,7 @@ import (
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/query/util/logging"
 	"github.com/m3db/m3/src/x/net/http"
 	xerrors "github.com/m3db/m3x/errors"
@@ -196,14 +197,30 @@ func (h *PromWriteHandler) writeUnaggregated(
 }
 
 func (h *PromWriteHandler) writeAggregated(
-	_ context.Context,
+	ctx context.Context,
 	r *prompb.WriteRequest,
 ) error {
 	var (
 		metricsAppender = h.downsampler.NewMetricsAppender()
 		multiErr        xerrors.MultiError
 	)
+	// Aggregated writes do not go through the storage so validate them
+	// against the namespaces and tag constraints of the tenant here
+	t, hasTenant := tenant.FromContext(ctx)
+	if hasTenant {
+		if err := t.ValidateAggregatedWrites(); err != nil {
+			return err
+		}
+	}
 	for _, ts := range r.Timeseries {
+		if hasTenant {
+			tags := storage.PromLabelsToM3Tags(ts.Labels, h.tagOptions)
+			if err := t.ValidateTags(tags); err != nil {
+				multiErr = multiErr.Add(err)
+				continue
+			}
+		}
+
 		metricsAppender.Reset()
 		for _, label := range ts.Labels {
 			metricsAppender.AddTag(label.Name, label.Value)
@@ -0,0 +1,290 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/downsample"
+	"github.com/m3db/m3/src/query/util/logging"
+	clusterclient "github.com/m3db/m3cluster/client"
+	"github.com/m3db/m3cluster/kv"
+	"github.com/m3db/m3ctl/service/r2/store"
+	r2kv "github.com/m3db/m3ctl/service/r2/store/kv"
+	merrors "github.com/m3db/m3metrics/errors"
+	"github.com/m3db/m3metrics/generated/proto/rulepb"
+	"github.com/m3db/m3metrics/matcher"
+	ruleskv "github.com/m3db/m3metrics/rules/store/kv"
+	"github.com/m3db/m3metrics/rules/view"
+	"github.com/m3db/m3metrics/rules/view/changes"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	namespaceIDVar = "namespace"
+	ruleIDVar      = "id"
+
+	// HeaderAuthor is the header used to specify the author of rule changes.
+	HeaderAuthor = "rules-author"
+
+	// versionParam is the query parameter used to specify the expected
+	// version of the rule set a single rule change is applied to.
+	versionParam = "version"
+)
+
+var (
+	errEmptyNamespaceID       = errors.New("must specify namespace ID")
+	errEmptyRuleID            = errors.New("must specify rule ID")
+	errNamespaceNotFound      = errors.New("unable to find a namespace with specified ID")
+	errRuleNotFound           = errors.New("unable to find a rule with specified ID")
+	errRuleSetVersionMismatch = errors.New("rule set version does not match the current version")
+	errRuleSetVersionNotSet   = errors.New("must specify the rule set version to update")
+	errRuleChangeDataNotSet   = errors.New("rule change must specify rule data")
+	errNamespaceAlreadyExists = errors.New("namespace already exists")
+	errInvalidRuleSetVersion  = errors.New("invalid rule set version")
+)
+
+type newRuleFilterFn func(filter string) (downsample.RuleFilter, error)
+
+type matchRulesFn func(tags map[string]string) (downsample.RulesMatchResult, error)
+
+// Handler represents a generic handler for rules endpoints.
+// nolint: structcheck
+type Handler struct {
+	// This is used by other rules Handlers
+	kvStore         kv.Store
+	namespacesKey   string
+	store           store.Store
+	newRuleFilterFn newRuleFilterFn
+	matchRulesFn    matchRulesFn
+	namespaceTag    string
+}
+
+// NewHandler returns a new Handler for the mapping and rollup rules stored in
+// the given KV store, under the same keys the downsampler matcher reads rules
+// from. Rule sets are validated and series are matched against rules the same
+// way the given downsampler does.
+func NewHandler(kvStore kv.Store, downsampler downsample.Downsampler) Handler {
+	var (
+		matcherOpts   = matcher.NewOptions()
+		namespacesKey = matcherOpts.NamespacesKey()
+		ruleSetKeyFmt = matcherOpts.RuleSetKeyFn()([]byte("%s"))
+		rulesStorage  = ruleskv.NewStore(kvStore,
+			ruleskv.NewStoreOptions(namespacesKey, ruleSetKeyFmt,
+				downsampler.NewRulesValidator()))
+	)
+	return Handler{
+		kvStore:         kvStore,
+		namespacesKey:   namespacesKey,
+		store:           r2kv.NewStore(rulesStorage, r2kv.NewStoreOptions()),
+		newRuleFilterFn: downsampler.NewRuleFilter,
+		matchRulesFn:    downsampler.MatchRules,
+		namespaceTag:    string(matcherOpts.NamespaceTag()),
+	}
+}
+
+// RegisterRoutes registers the rules routes
+func RegisterRoutes(
+	r *mux.Router,
+	client clusterclient.Client,
+	downsampler downsample.Downsampler,
+) error {
+	kvStore, err := client.KV()
+	if err != nil {
+		return err
+	}
+
+	var (
+		logged = logging.WithResponseTimeLogging
+		h      = NewHandler(kvStore, downsampler)
+	)
+	r.HandleFunc(GetNamespacesURL, logged(NewGetNamespacesHandler(h)).ServeHTTP).Methods(GetNamespacesHTTPMethod)
+	r.HandleFunc(AddNamespaceURL, logged(NewAddNamespaceHandler(h)).ServeHTTP).Methods(AddNamespaceHTTPMethod)
+	r.HandleFunc(DeleteNamespaceURL, logged(NewDeleteNamespaceHandler(h)).ServeHTTP).Methods(DeleteNamespaceHTTPMethod)
+	r.HandleFunc(GetRuleSetURL, logged(NewGetRuleSetHandler(h)).ServeHTTP).Methods(GetRuleSetHTTPMethod)
+	r.HandleFunc(UpdateRuleSetURL, logged(NewUpdateRuleSetHandler(h)).ServeHTTP).Methods(UpdateRuleSetHTTPMethod)
+	r.HandleFunc(MatchURL, logged(NewMatchHandler(h)).ServeHTTP).Methods(MatchHTTPMethod)
+	for _, kind := range []ruleKind{mappingRuleKind, rollupRuleKind} {
+		r.HandleFunc(AddRuleURL(kind), logged(NewAddRuleHandler(h, kind)).ServeHTTP).Methods(AddRuleHTTPMethod)
+		r.HandleFunc(RuleURL(kind), logged(NewUpdateRuleHandler(h, kind)).ServeHTTP).Methods(UpdateRuleHTTPMethod)
+		r.HandleFunc(RuleURL(kind), logged(NewDeleteRuleHandler(h, kind)).ServeHTTP).Methods(DeleteRuleHTTPMethod)
+	}
+	return nil
+}
+
+// namespaceExists returns true if the namespace exists and has not been deleted.
+func namespaceExists(s store.Store, namespaceID string) (bool, error) {
+	namespaces, err := s.FetchNamespaces()
+	if err != nil {
+		return false, err
+	}
+	for _, ns := range namespaces.Namespaces {
+		if ns.ID == namespaceID && !ns.Tombstoned {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ruleSet returns the latest snapshot of the rule set of a namespace.
+func ruleSet(s store.Store, namespaceID string) (view.RuleSet, error) {
+	exists, err := namespaceExists(s, namespaceID)
+	if err != nil {
+		return view.RuleSet{}, err
+	}
+	if !exists {
+		return view.RuleSet{}, errNamespaceNotFound
+	}
+	return s.FetchRuleSetSnapshot(namespaceID)
+}
+
+// updateRuleSet applies the rule changes to the rule set of a namespace if the
+// current version of the rule set matches the given version.
+func updateRuleSet(
+	s store.Store,
+	newRuleFilterFn newRuleFilterFn,
+	rsChanges changes.RuleSetChanges,
+	version int,
+	uOpts store.UpdateOptions,
+) (view.RuleSet, error) {
+	if err := validateRuleSetChanges(newRuleFilterFn, rsChanges); err != nil {
+		return view.RuleSet{}, err
+	}
+	current, err := ruleSet(s, rsChanges.Namespace)
+	if err != nil {
+		return view.RuleSet{}, err
+	}
+	if current.Version != version {
+		return view.RuleSet{}, errRuleSetVersionMismatch
+	}
+	return s.UpdateRuleSet(rsChanges, version, uOpts)
+}
+
+// validateRuleSetChanges validates the filters of the changed rules with the
+// same options the downsampler uses to match series against rules.
+func validateRuleSetChanges(
+	newRuleFilterFn newRuleFilterFn,
+	rsChanges changes.RuleSetChanges,
+) error {
+	for _, change := range rsChanges.MappingRuleChanges {
+		if change.Op == changes.DeleteOp {
+			continue
+		}
+		if change.RuleData == nil {
+			return errRuleChangeDataNotSet
+		}
+		if err := validateFilter(newRuleFilterFn, change.RuleData.Name, change.RuleData.Filter); err != nil {
+			return err
+		}
+	}
+	for _, change := range rsChanges.RollupRuleChanges {
+		if change.Op == changes.DeleteOp {
+			continue
+		}
+		if change.RuleData == nil {
+			return errRuleChangeDataNotSet
+		}
+		if err := validateFilter(newRuleFilterFn, change.RuleData.Name, change.RuleData.Filter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateFilter(newRuleFilterFn newRuleFilterFn, ruleName, filter string) error {
+	if _, err := newRuleFilterFn(filter); err != nil {
+		return invalidFilterError{ruleName: ruleName, err: err}
+	}
+	return nil
+}
+
+// initNamespaces initializes the namespaces of the rules store, which the
+// rules store requires to exist before the first namespace is created.
+func initNamespaces(kvStore kv.Store, namespacesKey string) error {
+	_, err := kvStore.SetIfNotExists(namespacesKey, &rulepb.Namespaces{})
+	if err != nil && err != kv.ErrAlreadyExists {
+		return err
+	}
+	return nil
+}
+
+type badRequestError struct {
+	err error
+}
+
+func (e badRequestError) Error() string { return e.err.Error() }
+
+type invalidFilterError struct {
+	ruleName string
+	err      error
+}
+
+func (e invalidFilterError) Error() string {
+	return fmt.Sprintf("invalid filter for rule %s: %v", e.ruleName, e.err)
+}
+
+// statusCode returns the status code of an error returned by the rules
+// handlers, or the default status code if the error is not a known error.
+// Errors from the rules store are only client errors if they are known
+// validation errors.
+func statusCode(err error, defaultCode int) int {
+	switch err {
+	case errNamespaceNotFound, errRuleNotFound:
+		return http.StatusNotFound
+	case errRuleSetVersionMismatch, errNamespaceAlreadyExists:
+		return http.StatusConflict
+	case errEmptyNamespaceID, errRuleChangeDataNotSet, errRuleSetVersionNotSet, errInvalidRuleSetVersion:
+		return http.StatusBadRequest
+	}
+	switch err.(type) {
+	case badRequestError, invalidFilterError, merrors.InvalidInputError, merrors.ValidationError:
+		return http.StatusBadRequest
+	case merrors.StaleDataError, merrors.RuleConflictError:
+		return http.StatusConflict
+	}
+	return defaultCode
+}
+
+func namespaceID(r *http.Request) (string, error) {
+	id := strings.TrimSpace(mux.Vars(r)[namespaceIDVar])
+	if id == "" {
+		return "", errEmptyNamespaceID
+	}
+	return id, nil
+}
+
+func updateOptions(r *http.Request) store.UpdateOptions {
+	uOpts := store.NewUpdateOptions()
+	if author := strings.TrimSpace(r.Header.Get(HeaderAuthor)); author != "" {
+		uOpts = uOpts.SetAuthor(author)
+	}
+	return uOpts
+}
+
+func parseRequest(r *http.Request, v interface{}) error {
+	defer r.Body.Close()
+	return json.NewDecoder(r.Body).Decode(v)
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+	"github.com/m3db/m3metrics/metadata"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// MatchHTTPMethod is the HTTP method used with this resource.
+	MatchHTTPMethod = http.MethodPost
+)
+
+var (
+	// MatchURL is the url for the rules dry-run match handler.
+	MatchURL = fmt.Sprintf("%s/rules/namespace/{%s}/match", handler.RoutePrefixV1, namespaceIDVar)
+
+	errEmptyTags = errors.New("must specify the tags of the series to match")
+)
+
+// MatchRequest is the request to match a series against the rules of a namespace.
+type MatchRequest struct {
+	Tags map[string]string `json:"tags"`
+}
+
+// MatchResponse is how the current rules of a namespace aggregate a series.
+type MatchResponse struct {
+	Metadatas []MatchMetadata `json:"metadatas"`
+	Rollups   []MatchRollup   `json:"rollups"`
+}
+
+// MatchRollup is a rollup series a matched series is aggregated into.
+type MatchRollup struct {
+	Tags      map[string]string `json:"tags"`
+	Metadatas []MatchMetadata   `json:"metadatas"`
+}
+
+// MatchMetadata is how a series is aggregated and stored from a cutover time.
+type MatchMetadata struct {
+	CutoverNanos     int64    `json:"cutoverNanos"`
+	AggregationTypes []string `json:"aggregationTypes"`
+	StoragePolicies  []string `json:"storagePolicies"`
+	Pipeline         string   `json:"pipeline,omitempty"`
+}
+
+// MatchHandler is the handler that shows which rules of a namespace match a
+// given series without writing the series.
+type MatchHandler Handler
+
+// NewMatchHandler returns a new instance of MatchHandler.
+func NewMatchHandler(h Handler) *MatchHandler {
+	mh := MatchHandler(h)
+	return &mh
+}
+
+func (h *MatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	id, err := namespaceID(r)
+	if err != nil {
+		logger.Error("no rules namespace ID to match", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	var req MatchRequest
+	if err := parseRequest(r, &req); err != nil {
+		logger.Error("unable to parse request", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.Match(id, req.Tags)
+	if err != nil {
+		logger.Error("unable to match rules", zap.Any("error", err))
+		xhttp.Error(w, err, statusCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	xhttp.WriteJSONResponse(w, resp, logger)
+}
+
+// Match returns how the series with the given tags is aggregated by the
+// current rules of a namespace, as matched by the downsampler.
+func (h *MatchHandler) Match(namespaceID string, tags map[string]string) (MatchResponse, error) {
+	if len(tags) == 0 {
+		return MatchResponse{}, badRequestError{err: errEmptyTags}
+	}
+
+	// Make sure the namespace exists before matching against its rules.
+	if _, err := ruleSet(h.store, namespaceID); err != nil {
+		return MatchResponse{}, err
+	}
+
+	seriesTags := make(map[string]string, len(tags)+1)
+	for name, value := range tags {
+		seriesTags[name] = value
+	}
+	seriesTags[h.namespaceTag] = namespaceID
+
+	result, err := h.matchRulesFn(seriesTags)
+	if err != nil {
+		return MatchResponse{}, err
+	}
+
+	metadatas, err := newMatchMetadatas(result.Metadatas)
+	if err != nil {
+		return MatchResponse{}, err
+	}
+	resp := MatchResponse{
+		Metadatas: metadatas,
+		Rollups:   make([]MatchRollup, 0, len(result.Rollups)),
+	}
+	for _, rollup := range result.Rollups {
+		metadatas, err := newMatchMetadatas(rollup.Metadatas)
+		if err != nil {
+			return MatchResponse{}, err
+		}
+		resp.Rollups = append(resp.Rollups, MatchRollup{
+			Tags:      rollup.Tags,
+			Metadatas: metadatas,
+		})
+	}
+	return resp, nil
+}
+
+func newMatchMetadatas(stagedMetadatas metadata.StagedMetadatas) ([]MatchMetadata, error) {
+	metadatas := []MatchMetadata{}
+	for _, staged := range stagedMetadatas {
+		if staged.Tombstoned {
+			continue
+		}
+		for _, pipeline := range staged.Pipelines {
+			aggTypes, err := pipeline.AggregationID.Types()
+			if err != nil {
+				return nil, err
+			}
+			m := MatchMetadata{
+				CutoverNanos:     staged.CutoverNanos,
+				AggregationTypes: make([]string, 0, len(aggTypes)),
+				StoragePolicies:  make([]string, 0, len(pipeline.StoragePolicies)),
+			}
+			for _, aggType := range aggTypes {
+				m.AggregationTypes = append(m.AggregationTypes, aggType.String())
+			}
+			for _, sp := range pipeline.StoragePolicies {
+				m.StoragePolicies = append(m.StoragePolicies, sp.String())
+			}
+			if !pipeline.Pipeline.IsEmpty() {
+				m.Pipeline = pipeline.Pipeline.String()
+			}
+			metadatas = append(metadatas, m)
+		}
+	}
+	return metadatas, nil
+}
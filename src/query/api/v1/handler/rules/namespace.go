@@ -0,0 +1,179 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+	"github.com/m3db/m3ctl/service/r2/store"
+	"github.com/m3db/m3metrics/rules/view"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// GetNamespacesURL is the url for the rules namespace get handler.
+	GetNamespacesURL = handler.RoutePrefixV1 + "/rules/namespace"
+
+	// GetNamespacesHTTPMethod is the HTTP method used with this resource.
+	GetNamespacesHTTPMethod = http.MethodGet
+
+	// AddNamespaceURL is the url for the rules namespace add handler.
+	AddNamespaceURL = handler.RoutePrefixV1 + "/rules/namespace"
+
+	// AddNamespaceHTTPMethod is the HTTP method used with this resource.
+	AddNamespaceHTTPMethod = http.MethodPost
+
+	// DeleteNamespaceHTTPMethod is the HTTP method used with this resource.
+	DeleteNamespaceHTTPMethod = http.MethodDelete
+)
+
+var (
+	// DeleteNamespaceURL is the url for the rules namespace delete handler.
+	DeleteNamespaceURL = fmt.Sprintf("%s/rules/namespace/{%s}", handler.RoutePrefixV1, namespaceIDVar)
+)
+
+// GetNamespacesHandler is the handler for rules namespace gets.
+type GetNamespacesHandler Handler
+
+// NewGetNamespacesHandler returns a new instance of GetNamespacesHandler.
+func NewGetNamespacesHandler(h Handler) *GetNamespacesHandler {
+	gh := GetNamespacesHandler(h)
+	return &gh
+}
+
+func (h *GetNamespacesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	namespaces, err := h.store.FetchNamespaces()
+	if err != nil {
+		logger.Error("unable to get rules namespaces", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	xhttp.WriteJSONResponse(w, namespaces, logger)
+}
+
+// AddNamespaceRequest is the request to add a rules namespace.
+type AddNamespaceRequest struct {
+	ID string `json:"id"`
+}
+
+// AddNamespaceHandler is the handler for rules namespace adds.
+type AddNamespaceHandler Handler
+
+// NewAddNamespaceHandler returns a new instance of AddNamespaceHandler.
+func NewAddNamespaceHandler(h Handler) *AddNamespaceHandler {
+	ah := AddNamespaceHandler(h)
+	return &ah
+}
+
+func (h *AddNamespaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	var req AddNamespaceRequest
+	if err := parseRequest(r, &req); err != nil {
+		logger.Error("unable to parse request", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	ns, err := h.Add(strings.TrimSpace(req.ID), updateOptions(r))
+	if err != nil {
+		logger.Error("unable to add rules namespace", zap.Any("error", err))
+		xhttp.Error(w, err, statusCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	xhttp.WriteJSONResponse(w, ns, logger)
+}
+
+// Add adds a rules namespace with an empty rule set.
+func (h *AddNamespaceHandler) Add(namespaceID string, uOpts store.UpdateOptions) (view.Namespace, error) {
+	if namespaceID == "" {
+		return view.Namespace{}, errEmptyNamespaceID
+	}
+	if err := initNamespaces(h.kvStore, h.namespacesKey); err != nil {
+		return view.Namespace{}, err
+	}
+	exists, err := namespaceExists(h.store, namespaceID)
+	if err != nil {
+		return view.Namespace{}, err
+	}
+	if exists {
+		return view.Namespace{}, errNamespaceAlreadyExists
+	}
+	return h.store.CreateNamespace(namespaceID, uOpts)
+}
+
+// DeleteNamespaceHandler is the handler for rules namespace deletes.
+type DeleteNamespaceHandler Handler
+
+// NewDeleteNamespaceHandler returns a new instance of DeleteNamespaceHandler.
+func NewDeleteNamespaceHandler(h Handler) *DeleteNamespaceHandler {
+	dh := DeleteNamespaceHandler(h)
+	return &dh
+}
+
+func (h *DeleteNamespaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	id, err := namespaceID(r)
+	if err != nil {
+		logger.Error("no rules namespace ID to delete", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Delete(id, updateOptions(r)); err != nil {
+		logger.Error("unable to delete rules namespace", zap.Any("error", err))
+		xhttp.Error(w, err, statusCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	json.NewEncoder(w).Encode(struct {
+		Deleted bool `json:"deleted"`
+	}{
+		Deleted: true,
+	})
+}
+
+// Delete deletes a rules namespace along with its rule set.
+func (h *DeleteNamespaceHandler) Delete(namespaceID string, uOpts store.UpdateOptions) error {
+	exists, err := namespaceExists(h.store, namespaceID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errNamespaceNotFound
+	}
+	return h.store.DeleteNamespace(namespaceID, uOpts)
+}
@@ -0,0 +1,288 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+	"github.com/m3db/m3metrics/rules/view"
+	"github.com/m3db/m3metrics/rules/view/changes"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+const (
+	mappingRuleKind ruleKind = "mapping"
+	rollupRuleKind  ruleKind = "rollup"
+
+	// AddRuleHTTPMethod is the HTTP method used with this resource.
+	AddRuleHTTPMethod = http.MethodPost
+
+	// UpdateRuleHTTPMethod is the HTTP method used with this resource.
+	UpdateRuleHTTPMethod = http.MethodPut
+
+	// DeleteRuleHTTPMethod is the HTTP method used with this resource.
+	DeleteRuleHTTPMethod = http.MethodDelete
+)
+
+// ruleKind is the kind of a rule, either a mapping rule or a rollup rule.
+type ruleKind string
+
+// AddRuleURL returns the url for the add handler of the given rule kind.
+func AddRuleURL(kind ruleKind) string {
+	return fmt.Sprintf("%s/rules/namespace/{%s}/%s", handler.RoutePrefixV1, namespaceIDVar, kind)
+}
+
+// RuleURL returns the url for the update and delete handlers of the given rule kind.
+func RuleURL(kind ruleKind) string {
+	return fmt.Sprintf("%s/{%s}", AddRuleURL(kind), ruleIDVar)
+}
+
+// ruleSetChanges returns the rule set changes applying the op to a single rule,
+// where the rule data is decoded from data unless the rule is being deleted.
+func (k ruleKind) ruleSetChanges(
+	namespaceID string,
+	op changes.Op,
+	ruleID *string,
+	data []byte,
+) (changes.RuleSetChanges, error) {
+	rsChanges := changes.RuleSetChanges{Namespace: namespaceID}
+	switch k {
+	case mappingRuleKind:
+		change := changes.MappingRuleChange{Op: op, RuleID: ruleID}
+		if op != changes.DeleteOp {
+			change.RuleData = &view.MappingRule{}
+			if err := json.Unmarshal(data, change.RuleData); err != nil {
+				return changes.RuleSetChanges{}, err
+			}
+		}
+		rsChanges.MappingRuleChanges = []changes.MappingRuleChange{change}
+	case rollupRuleKind:
+		change := changes.RollupRuleChange{Op: op, RuleID: ruleID}
+		if op != changes.DeleteOp {
+			change.RuleData = &view.RollupRule{}
+			if err := json.Unmarshal(data, change.RuleData); err != nil {
+				return changes.RuleSetChanges{}, err
+			}
+		}
+		rsChanges.RollupRuleChanges = []changes.RollupRuleChange{change}
+	default:
+		return changes.RuleSetChanges{}, fmt.Errorf("unknown rule kind %s", k)
+	}
+	return rsChanges, nil
+}
+
+// findRule returns the active rule of this kind in the rule set matching the
+// given predicate on the rule ID and name.
+func (k ruleKind) findRule(
+	rs view.RuleSet,
+	matchFn func(id, name string) bool,
+) (interface{}, bool) {
+	switch k {
+	case mappingRuleKind:
+		for _, rule := range rs.MappingRules {
+			if !rule.Tombstoned && matchFn(rule.ID, rule.Name) {
+				return rule, true
+			}
+		}
+	case rollupRuleKind:
+		for _, rule := range rs.RollupRules {
+			if !rule.Tombstoned && matchFn(rule.ID, rule.Name) {
+				return rule, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// ruleName returns the name of the single rule changed by the rule set changes.
+func ruleName(rsChanges changes.RuleSetChanges) string {
+	for _, change := range rsChanges.MappingRuleChanges {
+		if change.RuleData != nil {
+			return change.RuleData.Name
+		}
+	}
+	for _, change := range rsChanges.RollupRuleChanges {
+		if change.RuleData != nil {
+			return change.RuleData.Name
+		}
+	}
+	return ""
+}
+
+// applyRuleChange applies the op to a single rule in the current rule set of
+// the namespace, or in the rule set version given in the request if it is set.
+// It returns the updated rule set along with the name of the changed rule.
+func (h Handler) applyRuleChange(
+	r *http.Request,
+	kind ruleKind,
+	op changes.Op,
+	ruleID *string,
+) (view.RuleSet, string, error) {
+	id, err := namespaceID(r)
+	if err != nil {
+		return view.RuleSet{}, "", err
+	}
+
+	defer r.Body.Close()
+	var data []byte
+	if op != changes.DeleteOp {
+		var raw json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+			return view.RuleSet{}, "", badRequestError{err: err}
+		}
+		data = raw
+	}
+	rsChanges, err := kind.ruleSetChanges(id, op, ruleID, data)
+	if err != nil {
+		return view.RuleSet{}, "", badRequestError{err: err}
+	}
+
+	current, err := ruleSet(h.store, id)
+	if err != nil {
+		return view.RuleSet{}, "", err
+	}
+	if ruleID != nil {
+		if _, exists := kind.findRule(current, func(id, _ string) bool {
+			return id == *ruleID
+		}); !exists {
+			return view.RuleSet{}, "", errRuleNotFound
+		}
+	}
+
+	version := current.Version
+	if v := strings.TrimSpace(r.URL.Query().Get(versionParam)); v != "" {
+		if version, err = strconv.Atoi(v); err != nil {
+			return view.RuleSet{}, "", errInvalidRuleSetVersion
+		}
+	}
+	rs, err := updateRuleSet(h.store, h.newRuleFilterFn, rsChanges, version, updateOptions(r))
+	if err != nil {
+		return view.RuleSet{}, "", err
+	}
+	return rs, ruleName(rsChanges), nil
+}
+
+// AddRuleHandler is the handler for mapping and rollup rule adds.
+type AddRuleHandler struct {
+	Handler
+	kind ruleKind
+}
+
+// NewAddRuleHandler returns a new instance of AddRuleHandler.
+func NewAddRuleHandler(h Handler, kind ruleKind) *AddRuleHandler {
+	return &AddRuleHandler{Handler: h, kind: kind}
+}
+
+func (h *AddRuleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	rs, name, err := h.applyRuleChange(r, h.kind, changes.AddOp, nil)
+	if err != nil {
+		logger.Error("unable to add rule", zap.String("kind", string(h.kind)), zap.Any("error", err))
+		xhttp.Error(w, err, statusCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	rule, _ := h.kind.findRule(rs, func(_, ruleName string) bool {
+		return ruleName == name
+	})
+	xhttp.WriteJSONResponse(w, rule, logger)
+}
+
+// UpdateRuleHandler is the handler for mapping and rollup rule updates.
+type UpdateRuleHandler struct {
+	Handler
+	kind ruleKind
+}
+
+// NewUpdateRuleHandler returns a new instance of UpdateRuleHandler.
+func NewUpdateRuleHandler(h Handler, kind ruleKind) *UpdateRuleHandler {
+	return &UpdateRuleHandler{Handler: h, kind: kind}
+}
+
+func (h *UpdateRuleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	ruleID := strings.TrimSpace(mux.Vars(r)[ruleIDVar])
+	if ruleID == "" {
+		logger.Error("no rule ID to update", zap.Any("error", errEmptyRuleID))
+		xhttp.Error(w, errEmptyRuleID, http.StatusBadRequest)
+		return
+	}
+
+	rs, _, err := h.applyRuleChange(r, h.kind, changes.ChangeOp, &ruleID)
+	if err != nil {
+		logger.Error("unable to update rule", zap.String("kind", string(h.kind)), zap.Any("error", err))
+		xhttp.Error(w, err, statusCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	rule, _ := h.kind.findRule(rs, func(id, _ string) bool {
+		return id == ruleID
+	})
+	xhttp.WriteJSONResponse(w, rule, logger)
+}
+
+// DeleteRuleHandler is the handler for mapping and rollup rule deletes.
+type DeleteRuleHandler struct {
+	Handler
+	kind ruleKind
+}
+
+// NewDeleteRuleHandler returns a new instance of DeleteRuleHandler.
+func NewDeleteRuleHandler(h Handler, kind ruleKind) *DeleteRuleHandler {
+	return &DeleteRuleHandler{Handler: h, kind: kind}
+}
+
+func (h *DeleteRuleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	ruleID := strings.TrimSpace(mux.Vars(r)[ruleIDVar])
+	if ruleID == "" {
+		logger.Error("no rule ID to delete", zap.Any("error", errEmptyRuleID))
+		xhttp.Error(w, errEmptyRuleID, http.StatusBadRequest)
+		return
+	}
+
+	if _, _, err := h.applyRuleChange(r, h.kind, changes.DeleteOp, &ruleID); err != nil {
+		logger.Error("unable to delete rule", zap.String("kind", string(h.kind)), zap.Any("error", err))
+		xhttp.Error(w, err, statusCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	json.NewEncoder(w).Encode(struct {
+		Deleted bool `json:"deleted"`
+	}{
+		Deleted: true,
+	})
+}
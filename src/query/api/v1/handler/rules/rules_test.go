@@ -0,0 +1,311 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/downsample"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3cluster/kv/mem"
+	"github.com/m3db/m3metrics/aggregation"
+	merrors "github.com/m3db/m3metrics/errors"
+	"github.com/m3db/m3metrics/matcher"
+	"github.com/m3db/m3metrics/metadata"
+	"github.com/m3db/m3metrics/policy"
+	"github.com/m3db/m3metrics/rules"
+	"github.com/m3db/m3metrics/rules/view"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+const testNamespace = "test-ns"
+
+// testRuleFilter matches series with all of the space separated name:value
+// pairs of its filter.
+type testRuleFilter map[string]string
+
+func newTestRuleFilter(filter string) (downsample.RuleFilter, error) {
+	f := make(testRuleFilter)
+	for _, pair := range strings.Fields(filter) {
+		parts := strings.Split(pair, ":")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid filter pair %s", pair)
+		}
+		f[parts[0]] = parts[1]
+	}
+	if len(f) == 0 {
+		return nil, errors.New("empty filter")
+	}
+	return f, nil
+}
+
+func (f testRuleFilter) Matches(tags map[string]string) (bool, error) {
+	for name, value := range f {
+		if tags[name] != value {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// testDownsampler matches every series against the same mapping and rollup
+// metadatas and records the tags of the last matched series.
+type testDownsampler struct {
+	matchedTags map[string]string
+}
+
+var testMatchMetadatas = metadata.StagedMetadatas{
+	{
+		CutoverNanos: 1,
+		Metadata: metadata.Metadata{
+			Pipelines: []metadata.PipelineMetadata{
+				{
+					AggregationID:   aggregation.MustCompressTypes(aggregation.Sum),
+					StoragePolicies: policy.StoragePolicies{policy.MustParseStoragePolicy("10s:2d")},
+				},
+			},
+		},
+	},
+}
+
+func (d *testDownsampler) NewMetricsAppender() downsample.MetricsAppender {
+	return nil
+}
+
+func (d *testDownsampler) NewRuleFilter(filter string) (downsample.RuleFilter, error) {
+	return newTestRuleFilter(filter)
+}
+
+func (d *testDownsampler) NewRulesValidator() rules.Validator {
+	return nil
+}
+
+func (d *testDownsampler) MatchRules(tags map[string]string) (downsample.RulesMatchResult, error) {
+	d.matchedTags = tags
+	return downsample.RulesMatchResult{
+		Metadatas: testMatchMetadatas,
+		Rollups: []downsample.RollupMatchResult{
+			{
+				Tags:      map[string]string{"__name__": "foo_rollup"},
+				Metadatas: testMatchMetadatas,
+			},
+		},
+	}, nil
+}
+
+func newTestRouter() *mux.Router {
+	r, _ := newTestRouterWithDownsampler()
+	return r
+}
+
+func newTestRouterWithDownsampler() (*mux.Router, *testDownsampler) {
+	logging.InitWithCores(nil)
+
+	downsampler := &testDownsampler{}
+	h := NewHandler(mem.NewStore(), downsampler)
+	r := mux.NewRouter()
+	r.Handle(GetNamespacesURL, NewGetNamespacesHandler(h)).Methods(GetNamespacesHTTPMethod)
+	r.Handle(AddNamespaceURL, NewAddNamespaceHandler(h)).Methods(AddNamespaceHTTPMethod)
+	r.Handle(DeleteNamespaceURL, NewDeleteNamespaceHandler(h)).Methods(DeleteNamespaceHTTPMethod)
+	r.Handle(GetRuleSetURL, NewGetRuleSetHandler(h)).Methods(GetRuleSetHTTPMethod)
+	r.Handle(UpdateRuleSetURL, NewUpdateRuleSetHandler(h)).Methods(UpdateRuleSetHTTPMethod)
+	r.Handle(MatchURL, NewMatchHandler(h)).Methods(MatchHTTPMethod)
+	for _, kind := range []ruleKind{mappingRuleKind, rollupRuleKind} {
+		r.Handle(AddRuleURL(kind), NewAddRuleHandler(h, kind)).Methods(AddRuleHTTPMethod)
+		r.Handle(RuleURL(kind), NewUpdateRuleHandler(h, kind)).Methods(UpdateRuleHTTPMethod)
+		r.Handle(RuleURL(kind), NewDeleteRuleHandler(h, kind)).Methods(DeleteRuleHTTPMethod)
+	}
+	return r, downsampler
+}
+
+func serve(
+	t *testing.T,
+	r *mux.Router,
+	method, url string,
+	body interface{},
+	expectedStatus int,
+	result interface{},
+) {
+	var reqBody bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&reqBody).Encode(body))
+	}
+	req := httptest.NewRequest(method, url, &reqBody)
+	req.Header.Set(HeaderAuthor, "test")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	resp := w.Result()
+	require.Equal(t, expectedStatus, resp.StatusCode, w.Body.String())
+	if result != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(result))
+	}
+}
+
+func namespaceURL(url string) string {
+	return strings.Replace(url, fmt.Sprintf("{%s}", namespaceIDVar), testNamespace, 1)
+}
+
+func ruleURL(kind ruleKind, ruleID string) string {
+	return strings.Replace(namespaceURL(RuleURL(kind)), fmt.Sprintf("{%s}", ruleIDVar), ruleID, 1)
+}
+
+func testMappingRule(name, filter string) view.MappingRule {
+	return view.MappingRule{
+		Name:            name,
+		Filter:          filter,
+		StoragePolicies: policy.StoragePolicies{policy.MustParseStoragePolicy("10s:2d")},
+	}
+}
+
+func addTestNamespace(t *testing.T, r *mux.Router) {
+	serve(t, r, AddNamespaceHTTPMethod, AddNamespaceURL,
+		AddNamespaceRequest{ID: testNamespace}, http.StatusOK, nil)
+}
+
+func TestNamespaceHandlers(t *testing.T) {
+	r := newTestRouter()
+
+	addTestNamespace(t, r)
+	serve(t, r, AddNamespaceHTTPMethod, AddNamespaceURL,
+		AddNamespaceRequest{ID: testNamespace}, http.StatusConflict, nil)
+	serve(t, r, AddNamespaceHTTPMethod, AddNamespaceURL,
+		AddNamespaceRequest{}, http.StatusBadRequest, nil)
+
+	var namespaces view.Namespaces
+	serve(t, r, GetNamespacesHTTPMethod, GetNamespacesURL, nil, http.StatusOK, &namespaces)
+	require.Len(t, namespaces.Namespaces, 1)
+	require.Equal(t, testNamespace, namespaces.Namespaces[0].ID)
+
+	serve(t, r, DeleteNamespaceHTTPMethod, namespaceURL(DeleteNamespaceURL), nil, http.StatusOK, nil)
+	serve(t, r, DeleteNamespaceHTTPMethod, namespaceURL(DeleteNamespaceURL), nil, http.StatusNotFound, nil)
+	serve(t, r, GetRuleSetHTTPMethod, namespaceURL(GetRuleSetURL), nil, http.StatusNotFound, nil)
+}
+
+func TestMappingRuleHandlers(t *testing.T) {
+	r := newTestRouter()
+	addTestNamespace(t, r)
+
+	var added view.MappingRule
+	serve(t, r, AddRuleHTTPMethod, namespaceURL(AddRuleURL(mappingRuleKind)),
+		testMappingRule("foo", "app:foo"), http.StatusOK, &added)
+	require.NotEmpty(t, added.ID)
+	require.Equal(t, "foo", added.Name)
+	require.Equal(t, "app:foo", added.Filter)
+
+	// Invalid filters are rejected before the rule set is updated.
+	serve(t, r, AddRuleHTTPMethod, namespaceURL(AddRuleURL(mappingRuleKind)),
+		testMappingRule("bar", "app"), http.StatusBadRequest, nil)
+
+	var rs view.RuleSet
+	serve(t, r, GetRuleSetHTTPMethod, namespaceURL(GetRuleSetURL), nil, http.StatusOK, &rs)
+	require.Len(t, rs.MappingRules, 1)
+
+	// Updates against a stale rule set version conflict.
+	staleURL := fmt.Sprintf("%s?%s=%d", ruleURL(mappingRuleKind, added.ID), versionParam, rs.Version-1)
+	serve(t, r, UpdateRuleHTTPMethod, staleURL,
+		testMappingRule("foo", "app:bar"), http.StatusConflict, nil)
+
+	var updated view.MappingRule
+	serve(t, r, UpdateRuleHTTPMethod, ruleURL(mappingRuleKind, added.ID),
+		testMappingRule("foo", "app:bar"), http.StatusOK, &updated)
+	require.Equal(t, added.ID, updated.ID)
+	require.Equal(t, "app:bar", updated.Filter)
+
+	serve(t, r, UpdateRuleHTTPMethod, ruleURL(mappingRuleKind, "unknown"),
+		testMappingRule("foo", "app:bar"), http.StatusNotFound, nil)
+
+	serve(t, r, DeleteRuleHTTPMethod, ruleURL(mappingRuleKind, added.ID), nil, http.StatusOK, nil)
+	serve(t, r, DeleteRuleHTTPMethod, ruleURL(mappingRuleKind, added.ID), nil, http.StatusNotFound, nil)
+}
+
+func TestUpdateRuleSetHandlerRequiresVersion(t *testing.T) {
+	r := newTestRouter()
+	addTestNamespace(t, r)
+
+	serve(t, r, UpdateRuleSetHTTPMethod, namespaceURL(UpdateRuleSetURL),
+		UpdateRuleSetRequest{}, http.StatusBadRequest, nil)
+}
+
+func TestMatchHandler(t *testing.T) {
+	r, downsampler := newTestRouterWithDownsampler()
+
+	req := MatchRequest{Tags: map[string]string{"app": "foo", "env": "dev"}}
+	serve(t, r, MatchHTTPMethod, namespaceURL(MatchURL), req, http.StatusNotFound, nil)
+
+	addTestNamespace(t, r)
+
+	var resp MatchResponse
+	serve(t, r, MatchHTTPMethod, namespaceURL(MatchURL), req, http.StatusOK, &resp)
+
+	// The series is matched in the namespace of the request.
+	namespaceTag := string(matcher.NewOptions().NamespaceTag())
+	require.Equal(t, map[string]string{
+		"app":        "foo",
+		"env":        "dev",
+		namespaceTag: testNamespace,
+	}, downsampler.matchedTags)
+
+	expectedMetadatas := []MatchMetadata{
+		{
+			CutoverNanos:     1,
+			AggregationTypes: []string{aggregation.Sum.String()},
+			StoragePolicies:  []string{"10s:2d"},
+		},
+	}
+	require.Equal(t, expectedMetadatas, resp.Metadatas)
+	require.Equal(t, []MatchRollup{
+		{
+			Tags:      map[string]string{"__name__": "foo_rollup"},
+			Metadatas: expectedMetadatas,
+		},
+	}, resp.Rollups)
+
+	serve(t, r, MatchHTTPMethod, namespaceURL(MatchURL),
+		MatchRequest{}, http.StatusBadRequest, nil)
+}
+
+func TestStatusCode(t *testing.T) {
+	for _, tt := range []struct {
+		err      error
+		expected int
+	}{
+		{errEmptyNamespaceID, http.StatusBadRequest},
+		{badRequestError{err: errors.New("bad json")}, http.StatusBadRequest},
+		{merrors.NewValidationError("duplicate rule"), http.StatusBadRequest},
+		{merrors.NewInvalidInputError("invalid input"), http.StatusBadRequest},
+		{errRuleNotFound, http.StatusNotFound},
+		{errRuleSetVersionMismatch, http.StatusConflict},
+		{merrors.NewStaleDataError("stale"), http.StatusConflict},
+		{errors.New("kv unavailable"), http.StatusInternalServerError},
+	} {
+		require.Equal(t, tt.expected, statusCode(tt.err, http.StatusInternalServerError), tt.err.Error())
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+	"github.com/m3db/m3ctl/service/r2/store"
+	"github.com/m3db/m3metrics/rules/view"
+	"github.com/m3db/m3metrics/rules/view/changes"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// GetRuleSetHTTPMethod is the HTTP method used with this resource.
+	GetRuleSetHTTPMethod = http.MethodGet
+
+	// UpdateRuleSetHTTPMethod is the HTTP method used with this resource.
+	UpdateRuleSetHTTPMethod = http.MethodPost
+)
+
+var (
+	// GetRuleSetURL is the url for the rule set get handler.
+	GetRuleSetURL = fmt.Sprintf("%s/rules/namespace/{%s}", handler.RoutePrefixV1, namespaceIDVar)
+
+	// UpdateRuleSetURL is the url for the rule set update handler.
+	UpdateRuleSetURL = fmt.Sprintf("%s/rules/namespace/{%s}/update", handler.RoutePrefixV1, namespaceIDVar)
+)
+
+// GetRuleSetHandler is the handler for rule set gets.
+type GetRuleSetHandler Handler
+
+// NewGetRuleSetHandler returns a new instance of GetRuleSetHandler.
+func NewGetRuleSetHandler(h Handler) *GetRuleSetHandler {
+	gh := GetRuleSetHandler(h)
+	return &gh
+}
+
+func (h *GetRuleSetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	id, err := namespaceID(r)
+	if err != nil {
+		logger.Error("no rules namespace ID to get", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	rs, err := ruleSet(h.store, id)
+	if err != nil {
+		logger.Error("unable to get rule set", zap.Any("error", err))
+		xhttp.Error(w, err, statusCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	xhttp.WriteJSONResponse(w, rs, logger)
+}
+
+// UpdateRuleSetRequest is the request to apply a set of rule changes to the
+// rule set of a namespace. The changes are only applied if the rule set
+// version matches the current version of the rule set.
+type UpdateRuleSetRequest struct {
+	RuleSetVersion     int                         `json:"rulesetVersion"`
+	MappingRuleChanges []changes.MappingRuleChange `json:"mappingRuleChanges"`
+	RollupRuleChanges  []changes.RollupRuleChange  `json:"rollupRuleChanges"`
+}
+
+// UpdateRuleSetHandler is the handler for rule set updates.
+type UpdateRuleSetHandler Handler
+
+// NewUpdateRuleSetHandler returns a new instance of UpdateRuleSetHandler.
+func NewUpdateRuleSetHandler(h Handler) *UpdateRuleSetHandler {
+	uh := UpdateRuleSetHandler(h)
+	return &uh
+}
+
+func (h *UpdateRuleSetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	id, err := namespaceID(r)
+	if err != nil {
+		logger.Error("no rules namespace ID to update", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	var req UpdateRuleSetRequest
+	if err := parseRequest(r, &req); err != nil {
+		logger.Error("unable to parse request", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	rs, err := h.Update(id, req, updateOptions(r))
+	if err != nil {
+		logger.Error("unable to update rule set", zap.Any("error", err))
+		xhttp.Error(w, err, statusCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	xhttp.WriteJSONResponse(w, rs, logger)
+}
+
+// Update applies the rule changes to the rule set of a namespace.
+func (h *UpdateRuleSetHandler) Update(
+	namespaceID string,
+	req UpdateRuleSetRequest,
+	uOpts store.UpdateOptions,
+) (view.RuleSet, error) {
+	if req.RuleSetVersion <= 0 {
+		return view.RuleSet{}, errRuleSetVersionNotSet
+	}
+	rsChanges := changes.RuleSetChanges{
+		Namespace:          namespaceID,
+		MappingRuleChanges: req.MappingRuleChanges,
+		RollupRuleChanges:  req.RollupRuleChanges,
+	}
+	return updateRuleSet(h.store, h.newRuleFilterFn, rsChanges, req.RuleSetVersion, uOpts)
+}
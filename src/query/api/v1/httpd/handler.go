@@ -31,20 +31,25 @@ import (
 	"github.com/m3db/m3/src/cmd/services/m3query/config"
 	"github.com/m3db/m3/src/query/api/v1/handler"
 	"github.com/m3db/m3/src/query/api/v1/handler/database"
+	"github.com/m3db/m3/src/query/api/v1/handler/graphite"
+	"github.com/m3db/m3/src/query/api/v1/handler/influxdb"
 	m3json "github.com/m3db/m3/src/query/api/v1/handler/json"
 	"github.com/m3db/m3/src/query/api/v1/handler/namespace"
 	"github.com/m3db/m3/src/query/api/v1/handler/openapi"
 	"github.com/m3db/m3/src/query/api/v1/handler/placement"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/native"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/remote"
+	"github.com/m3db/m3/src/query/api/v1/handler/rules"
 	"github.com/m3db/m3/src/query/api/v1/handler/topic"
 	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/query/util/logging"
 	"github.com/m3db/m3/src/x/net/http"
 	clusterclient "github.com/m3db/m3cluster/client"
+	"github.com/m3db/m3x/ident"
 
 	"github.com/gorilla/mux"
 	"github.com/uber-go/tally"
@@ -57,7 +62,8 @@ const (
 )
 
 var (
-	remoteSource = map[string]string{"source": "remote"}
+	remoteSource   = map[string]string{"source": "remote"}
+	influxDBSource = map[string]string{"source": "influxdb"}
 )
 
 // Handler represents an HTTP handler.
@@ -73,6 +79,7 @@ type Handler struct {
 	scope         tally.Scope
 	createdAt     time.Time
 	tagOptions    models.TagOptions
+	tenants       *tenant.Authenticator
 }
 
 // NewHandler returns a new instance of handler with routes.
@@ -101,18 +108,35 @@ func NewHandler(
 		createdAt:     time.Now(),
 		tagOptions:    tagOptions,
 	}
+	if cfg.Tenants != nil {
+		// The downsampler writes aggregations to the aggregated namespaces
+		// outside of any tenant, so only tenants allowed to access all of
+		// them may write aggregations
+		tenants, err := cfg.Tenants.NewAuthenticator(
+			aggregatedNamespaceIDs(m3dbClusters), scope.SubScope("tenant"))
+		if err != nil {
+			return nil, err
+		}
+		h.tenants = tenants
+	}
 	return h, nil
 }
 
 // RegisterRoutes registers all http routes.
 func (h *Handler) RegisterRoutes() error {
 	logged := logging.WithResponseTimeLogging
+	tenanted := h.tenanted
 
 	h.Router.HandleFunc(openapi.URL,
 		logged(&openapi.DocHandler{}).ServeHTTP,
 	).Methods(openapi.HTTPMethod)
 	h.Router.PathPrefix(openapi.StaticURLPrefix).Handler(logged(openapi.StaticHandler()))
 
+	lookbackDuration, err := h.config.LookbackDurationOrDefault()
+	if err != nil {
+		return err
+	}
+
 	// Prometheus remote read/write endpoints
 	promRemoteReadHandler := remote.NewPromReadHandler(h.engine, h.scope.Tagged(remoteSource))
 	promRemoteWriteHandler, err := remote.NewPromWriteHandler(
@@ -125,25 +149,67 @@ func (h *Handler) RegisterRoutes() error {
 		return err
 	}
 
+	influxWriteHandler, err := influxdb.NewInfluxWriteHandler(
+		h.storage,
+		h.downsampler,
+		h.tagOptions,
+		h.config.InfluxDB.NamingOptions(),
+		h.config.InfluxDB.MaxBodySizeOrDefault(),
+		h.scope.Tagged(influxDBSource),
+	)
+	if err != nil {
+		return err
+	}
+
 	h.Router.HandleFunc(
 		remote.PromReadURL,
-		logged(promRemoteReadHandler).ServeHTTP,
+		logged(tenanted(promRemoteReadHandler)).ServeHTTP,
 	).Methods(remote.PromReadHTTPMethod)
 	h.Router.HandleFunc(remote.PromWriteURL,
-		logged(promRemoteWriteHandler).ServeHTTP,
+		logged(tenanted(promRemoteWriteHandler)).ServeHTTP,
 	).Methods(remote.PromWriteHTTPMethod)
 	h.Router.HandleFunc(native.PromReadURL,
-		logged(native.NewPromReadHandler(h.engine, h.tagOptions)).ServeHTTP,
+		logged(tenanted(native.NewPromReadHandler(h.engine, h.tagOptions))).ServeHTTP,
 	).Methods(native.PromReadHTTPMethod)
+	h.Router.HandleFunc(native.PromReadInstantURL,
+		logged(tenanted(native.NewPromReadInstantHandler(h.engine, h.tagOptions, lookbackDuration))).ServeHTTP,
+	).Methods(native.PromReadInstantHTTPMethod)
+
+	// Prometheus metadata endpoints
+	h.Router.HandleFunc(native.ListTagsURL,
+		logged(tenanted(native.NewListTagsHandler(h.storage, h.tagOptions))).ServeHTTP,
+	).Methods(native.ListTagsHTTPMethod)
+	h.Router.HandleFunc(native.ListTagValuesURL,
+		logged(tenanted(native.NewListTagValuesHandler(h.storage, h.tagOptions))).ServeHTTP,
+	).Methods(native.ListTagValuesHTTPMethod)
+	h.Router.HandleFunc(native.PromSeriesMatchURL,
+		logged(tenanted(native.NewPromSeriesMatchHandler(h.storage, h.tagOptions))).ServeHTTP,
+	).Methods(native.PromSeriesMatchHTTPMethods...)
+	h.Router.HandleFunc(native.PromSeriesDeleteURL,
+		logged(tenanted(native.NewPromSeriesDeleteHandler(h.storage, h.tagOptions))).ServeHTTP,
+	).Methods(native.PromSeriesDeleteHTTPMethods...)
 
 	// Native M3 search and write endpoints
 	h.Router.HandleFunc(handler.SearchURL,
-		logged(handler.NewSearchHandler(h.storage)).ServeHTTP,
+		logged(tenanted(handler.NewSearchHandler(h.storage))).ServeHTTP,
 	).Methods(handler.SearchHTTPMethod)
 	h.Router.HandleFunc(m3json.WriteJSONURL,
-		logged(m3json.NewWriteJSONHandler(h.storage)).ServeHTTP,
+		logged(tenanted(m3json.NewWriteJSONHandler(h.storage))).ServeHTTP,
 	).Methods(m3json.JSONWriteHTTPMethod)
 
+	// InfluxDB line protocol write endpoint
+	h.Router.HandleFunc(influxdb.InfluxWriteURL,
+		logged(tenanted(influxWriteHandler)).ServeHTTP,
+	).Methods(influxdb.InfluxWriteHTTPMethod)
+
+	// Graphite endpoints
+	h.Router.HandleFunc(graphite.RenderURL,
+		logged(tenanted(graphite.NewRenderHandler(h.storage))).ServeHTTP,
+	).Methods(graphite.RenderHTTPMethods...)
+	h.Router.HandleFunc(graphite.FindURL,
+		logged(tenanted(graphite.NewFindHandler(h.storage))).ServeHTTP,
+	).Methods(graphite.FindHTTPMethods...)
+
 	if h.clusterClient != nil {
 		placementOpts := placement.HandlerOptions{
 			ClusterClient:       h.clusterClient,
@@ -151,10 +217,22 @@ func (h *Handler) RegisterRoutes() error {
 			M3AggServiceOptions: h.m3AggServiceOptions(),
 		}
 
-		placement.RegisterRoutes(h.Router, placementOpts)
-		namespace.RegisterRoutes(h.Router, h.clusterClient)
-		database.RegisterRoutes(h.Router, h.clusterClient, h.config, h.embeddedDbCfg)
-		topic.RegisterRoutes(h.Router, h.clusterClient, h.config)
+		err := h.adminRoutes(func() error {
+			placement.RegisterRoutes(h.Router, placementOpts)
+			namespace.RegisterRoutes(h.Router, h.clusterClient)
+			database.RegisterRoutes(h.Router, h.clusterClient, h.config, h.embeddedDbCfg)
+			topic.RegisterRoutes(h.Router, h.clusterClient, h.config)
+
+			// Rules are validated and matched with the same options as the
+			// downsampler, so they are only managed when downsampling is enabled.
+			if h.downsampler != nil {
+				return rules.RegisterRoutes(h.Router, h.clusterClient, h.downsampler)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
 	}
 
 	h.registerHealthEndpoints()
@@ -164,6 +242,59 @@ func (h *Handler) RegisterRoutes() error {
 	return nil
 }
 
+// tenanted restricts a handler to authenticated tenants if tenants are
+// configured.
+func (h *Handler) tenanted(next http.Handler) http.Handler {
+	if h.tenants == nil {
+		return next
+	}
+	return h.tenants.Handler(next)
+}
+
+func aggregatedNamespaceIDs(clusters m3.Clusters) []ident.ID {
+	if clusters == nil {
+		return nil
+	}
+
+	var ids []ident.ID
+	for _, ns := range clusters.ClusterNamespaces() {
+		if ns.Options().Attributes().MetricsType == storage.AggregatedMetricsType {
+			ids = append(ids, ns.NamespaceID())
+		}
+	}
+	return ids
+}
+
+// adminRoutes restricts the routes registered by register to admin tokens if
+// tenants are configured.
+func (h *Handler) adminRoutes(register func() error) error {
+	if h.tenants == nil {
+		return register()
+	}
+
+	existing := make(map[*mux.Route]struct{})
+	err := h.Router.Walk(
+		func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+			existing[route] = struct{}{}
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	if err := register(); err != nil {
+		return err
+	}
+
+	return h.Router.Walk(
+		func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+			if _, ok := existing[route]; !ok {
+				route.Handler(h.tenants.AdminHandler(route.GetHandler()))
+			}
+			return nil
+		})
+}
+
 func (h *Handler) m3AggServiceOptions() *placement.M3AggServiceOptions {
 	if h.clusters == nil {
 		return nil
@@ -29,12 +29,15 @@ import (
 
 	"github.com/m3db/m3/src/cmd/services/m3query/config"
 	m3json "github.com/m3db/m3/src/query/api/v1/handler/json"
+	"github.com/m3db/m3/src/query/api/v1/handler/namespace"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/native"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/remote"
 	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/query/test/m3"
 	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3cluster/client"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -112,6 +115,22 @@ func TestPromNativeReadPost(t *testing.T) {
 	require.Equal(t, res.Code, http.StatusMethodNotAllowed, "POST method not defined")
 }
 
+func TestPromNativeReadInstantGet(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	req, _ := http.NewRequest("GET", native.PromReadInstantURL, nil)
+	res := httptest.NewRecorder()
+	ctrl := gomock.NewController(t)
+	storage, _ := m3.NewStorageAndSession(t, ctrl)
+
+	h, err := NewHandler(storage, makeTagOptions(), nil, executor.NewEngine(storage), nil,
+		nil, config.Configuration{}, nil, tally.NewTestScope("", nil))
+	require.NoError(t, err, "unable to setup handler")
+	h.RegisterRoutes()
+	h.Router.ServeHTTP(res, req)
+	require.Equal(t, res.Code, http.StatusBadRequest, "Empty request")
+}
+
 func TestJSONWritePost(t *testing.T) {
 	logging.InitWithCores(nil)
 
@@ -190,3 +209,40 @@ func TestHealthGet(t *testing.T) {
 
 	assert.True(t, result > 0)
 }
+
+func TestAdminRoutesRequireAdminToken(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	storage, _ := m3.NewStorageAndSession(t, ctrl)
+	clusterClient := client.NewMockClient(ctrl)
+
+	cfg := config.Configuration{
+		Tenants: &tenant.Configuration{
+			Tenants: []tenant.TenantConfiguration{
+				{Name: "team-a", Tokens: []string{"token-a"}},
+			},
+			AdminTokens: []string{"admin"},
+		},
+	}
+	h, err := NewHandler(storage, makeTagOptions(), nil, executor.NewEngine(storage),
+		nil, clusterClient, cfg, nil, tally.NewTestScope("", nil))
+	require.NoError(t, err, "unable to setup handler")
+	require.NoError(t, h.RegisterRoutes())
+
+	for _, authorization := range []string{"", "Bearer token-a", "Bearer other"} {
+		req, _ := http.NewRequest(namespace.GetHTTPMethod, namespace.GetURL, nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		res := httptest.NewRecorder()
+		h.Router.ServeHTTP(res, req)
+		assert.Equal(t, http.StatusUnauthorized, res.Code, authorization)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, healthURL, nil)
+	res := httptest.NewRecorder()
+	h.Router.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusOK, res.Code)
+}
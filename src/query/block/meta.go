@@ -0,0 +1,233 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package block
+
+// NewUpdatedMetaBlock wraps a block, replacing its block and series metadata
+// while reading datapoints directly from the underlying block.
+// NB: the number and order of series metadata must match the wrapped block.
+func NewUpdatedMetaBlock(
+	underlying Block,
+	meta Metadata,
+	seriesMetas []SeriesMeta,
+) Block {
+	return &updatedMetaBlock{
+		underlying:  underlying,
+		meta:        meta,
+		seriesMetas: seriesMetas,
+	}
+}
+
+type updatedMetaBlock struct {
+	underlying  Block
+	meta        Metadata
+	seriesMetas []SeriesMeta
+}
+
+func (b *updatedMetaBlock) Unconsolidated() (UnconsolidatedBlock, error) {
+	unconsolidated, err := b.underlying.Unconsolidated()
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedMetaUnconsolidatedBlock{
+		underlying:  unconsolidated,
+		meta:        b.meta,
+		seriesMetas: b.seriesMetas,
+	}, nil
+}
+
+func (b *updatedMetaBlock) StepIter() (StepIter, error) {
+	iter, err := b.underlying.StepIter()
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedMetaStepIter{
+		StepIter:    iter,
+		meta:        b.meta,
+		seriesMetas: b.seriesMetas,
+	}, nil
+}
+
+func (b *updatedMetaBlock) SeriesIter() (SeriesIter, error) {
+	iter, err := b.underlying.SeriesIter()
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedMetaSeriesIter{
+		SeriesIter:  iter,
+		meta:        b.meta,
+		seriesMetas: b.seriesMetas,
+		idx:         -1,
+	}, nil
+}
+
+func (b *updatedMetaBlock) Close() error {
+	return b.underlying.Close()
+}
+
+type updatedMetaStepIter struct {
+	StepIter
+	meta        Metadata
+	seriesMetas []SeriesMeta
+}
+
+func (it *updatedMetaStepIter) Meta() Metadata {
+	return it.meta
+}
+
+func (it *updatedMetaStepIter) SeriesMeta() []SeriesMeta {
+	return it.seriesMetas
+}
+
+type updatedMetaSeriesIter struct {
+	SeriesIter
+	meta        Metadata
+	seriesMetas []SeriesMeta
+	idx         int
+}
+
+func (it *updatedMetaSeriesIter) Meta() Metadata {
+	return it.meta
+}
+
+func (it *updatedMetaSeriesIter) SeriesMeta() []SeriesMeta {
+	return it.seriesMetas
+}
+
+func (it *updatedMetaSeriesIter) Next() bool {
+	next := it.SeriesIter.Next()
+	if next {
+		it.idx++
+	}
+
+	return next
+}
+
+func (it *updatedMetaSeriesIter) Current() (Series, error) {
+	series, err := it.SeriesIter.Current()
+	if err != nil {
+		return Series{}, err
+	}
+
+	if it.idx >= 0 && it.idx < len(it.seriesMetas) {
+		series.Meta = it.seriesMetas[it.idx]
+	}
+
+	return series, nil
+}
+
+type updatedMetaUnconsolidatedBlock struct {
+	underlying  UnconsolidatedBlock
+	meta        Metadata
+	seriesMetas []SeriesMeta
+}
+
+func (b *updatedMetaUnconsolidatedBlock) StepIter() (UnconsolidatedStepIter, error) {
+	iter, err := b.underlying.StepIter()
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedMetaUnconsolidatedStepIter{
+		UnconsolidatedStepIter: iter,
+		meta:                   b.meta,
+		seriesMetas:            b.seriesMetas,
+	}, nil
+}
+
+func (b *updatedMetaUnconsolidatedBlock) SeriesIter() (UnconsolidatedSeriesIter, error) {
+	iter, err := b.underlying.SeriesIter()
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedMetaUnconsolidatedSeriesIter{
+		UnconsolidatedSeriesIter: iter,
+		meta:                     b.meta,
+		seriesMetas:              b.seriesMetas,
+		idx:                      -1,
+	}, nil
+}
+
+func (b *updatedMetaUnconsolidatedBlock) Consolidate() (Block, error) {
+	consolidated, err := b.underlying.Consolidate()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewUpdatedMetaBlock(consolidated, b.meta, b.seriesMetas), nil
+}
+
+func (b *updatedMetaUnconsolidatedBlock) Close() error {
+	return b.underlying.Close()
+}
+
+type updatedMetaUnconsolidatedStepIter struct {
+	UnconsolidatedStepIter
+	meta        Metadata
+	seriesMetas []SeriesMeta
+}
+
+func (it *updatedMetaUnconsolidatedStepIter) Meta() Metadata {
+	return it.meta
+}
+
+func (it *updatedMetaUnconsolidatedStepIter) SeriesMeta() []SeriesMeta {
+	return it.seriesMetas
+}
+
+type updatedMetaUnconsolidatedSeriesIter struct {
+	UnconsolidatedSeriesIter
+	meta        Metadata
+	seriesMetas []SeriesMeta
+	idx         int
+}
+
+func (it *updatedMetaUnconsolidatedSeriesIter) Meta() Metadata {
+	return it.meta
+}
+
+func (it *updatedMetaUnconsolidatedSeriesIter) SeriesMeta() []SeriesMeta {
+	return it.seriesMetas
+}
+
+func (it *updatedMetaUnconsolidatedSeriesIter) Next() bool {
+	next := it.UnconsolidatedSeriesIter.Next()
+	if next {
+		it.idx++
+	}
+
+	return next
+}
+
+func (it *updatedMetaUnconsolidatedSeriesIter) Current() (UnconsolidatedSeries, error) {
+	series, err := it.UnconsolidatedSeriesIter.Current()
+	if err != nil {
+		return UnconsolidatedSeries{}, err
+	}
+
+	if it.idx >= 0 && it.idx < len(it.seriesMetas) {
+		series.Meta = it.seriesMetas[it.idx]
+	}
+
+	return series, nil
+}
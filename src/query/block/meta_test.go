@@ -0,0 +1,71 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package block
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/query/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdatedMetaBlock(t *testing.T) {
+	oldMetas := []SeriesMeta{{Name: "a"}, {Name: "b"}}
+	builder := NewColumnBlockBuilder(Metadata{Bounds: bounds}, oldMetas)
+	require.NoError(t, builder.AddCols(2))
+	require.NoError(t, builder.AppendValues(0, []float64{1, 2}))
+	require.NoError(t, builder.AppendValues(1, []float64{3, 4}))
+
+	tags := models.EmptyTags().AddTag(models.Tag{
+		Name:  []byte("foo"),
+		Value: []byte("bar"),
+	})
+
+	meta := Metadata{Bounds: bounds, Tags: tags}
+	newMetas := []SeriesMeta{{Name: "c"}, {Name: "d"}}
+	bl := NewUpdatedMetaBlock(builder.Build(), meta, newMetas)
+
+	stepIter, err := bl.StepIter()
+	require.NoError(t, err)
+	assert.Equal(t, meta, stepIter.Meta())
+	assert.Equal(t, newMetas, stepIter.SeriesMeta())
+	assert.Equal(t, 2, stepIter.StepCount())
+
+	require.True(t, stepIter.Next())
+	step, err := stepIter.Current()
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1, 2}, step.Values())
+
+	seriesIter, err := bl.SeriesIter()
+	require.NoError(t, err)
+	assert.Equal(t, meta, seriesIter.Meta())
+	assert.Equal(t, newMetas, seriesIter.SeriesMeta())
+
+	var names []string
+	for seriesIter.Next() {
+		series, err := seriesIter.Current()
+		require.NoError(t, err)
+		names = append(names, series.Meta.Name)
+	}
+
+	assert.Equal(t, []string{"c", "d"}, names)
+}
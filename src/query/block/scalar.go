@@ -32,14 +32,23 @@ import (
 // allowing them to treat this as a regular block, while at the same time
 // having an option to optimize by accessing the scalar value directly instead
 type Scalar struct {
-	val  float64
+	s    ScalarFunc
 	meta Metadata
 }
 
+// ScalarFunc determines the value of a scalar block at a given time
+type ScalarFunc func(t time.Time) float64
+
 // NewScalar creates a scalar block containing val over the bounds
 func NewScalar(val float64, bounds models.Bounds) Block {
+	return NewScalarWithFunc(func(_ time.Time) float64 { return val }, bounds)
+}
+
+// NewScalarWithFunc creates a scalar block over the bounds, whose value at
+// each step is determined by the given scalar function
+func NewScalarWithFunc(s ScalarFunc, bounds models.Bounds) Block {
 	return &Scalar{
-		val: val,
+		s: s,
 		meta: Metadata{
 			Bounds: bounds,
 			Tags:   models.EmptyTags(),
@@ -58,8 +67,9 @@ func (b *Scalar) StepIter() (StepIter, error) {
 	steps := bounds.Steps()
 	return &scalarStepIter{
 		meta: b.meta,
+		s:    b.s,
 		step: scalarStep{
-			vals: []float64{b.val},
+			vals: make([]float64, 1),
 		},
 		numVals: steps,
 		idx:     -1,
@@ -72,7 +82,8 @@ func (b *Scalar) SeriesIter() (SeriesIter, error) {
 	steps := bounds.Steps()
 	vals := make([]float64, steps)
 	for i := range vals {
-		vals[i] = b.val
+		t := bounds.Start.Add(time.Duration(i) * bounds.StepSize)
+		vals[i] = b.s(t)
 	}
 	return &scalarSeriesIter{
 		meta: b.meta,
@@ -84,11 +95,12 @@ func (b *Scalar) SeriesIter() (SeriesIter, error) {
 // Close closes the scalar block
 func (b *Scalar) Close() error { return nil }
 
-// Value returns the value for the scalar block
-func (b *Scalar) Value() float64 { return b.val }
+// Value returns the value for the scalar block at the given time
+func (b *Scalar) Value(t time.Time) float64 { return b.s(t) }
 
 type scalarStepIter struct {
 	meta         Metadata
+	s            ScalarFunc
 	step         scalarStep
 	numVals, idx int
 }
@@ -109,7 +121,12 @@ func (it *scalarStepIter) Next() bool {
 	it.idx++
 	bounds := it.meta.Bounds
 	it.step.time = bounds.Start.Add(time.Duration(it.idx) * bounds.StepSize)
-	return it.idx < it.numVals
+	next := it.idx < it.numVals
+	if next {
+		it.step.vals[0] = it.s(it.step.time)
+	}
+
+	return next
 }
 
 func (it *scalarStepIter) Current() (Step, error) {
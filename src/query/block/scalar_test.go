@@ -46,7 +46,7 @@ func TestScalarBlock(t *testing.T) {
 	if scalar, ok := block.(*Scalar); !ok {
 		require.FailNow(t, "unexpected type for new scalar blocks")
 	} else {
-		assert.Equal(t, val, scalar.Value())
+		assert.Equal(t, val, scalar.Value(start))
 	}
 
 	stepIter, err := block.StepIter()
@@ -112,6 +112,39 @@ func TestScalarBlock(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestScalarBlockWithFunc(t *testing.T) {
+	fn := func(t time.Time) float64 {
+		return float64(t.Sub(start) / time.Second)
+	}
+
+	block := NewScalarWithFunc(fn, bounds)
+	scalar, ok := block.(*Scalar)
+	require.True(t, ok)
+	assert.Equal(t, 20.0, scalar.Value(start.Add(20*time.Second)))
+
+	stepIter, err := block.StepIter()
+	require.NoError(t, err)
+	verifyMetas(t, stepIter.Meta(), stepIter.SeriesMeta())
+
+	expected := []float64{0, 10, 20, 30, 40, 50}
+	actual := make([]float64, 0, len(expected))
+	for stepIter.Next() {
+		v, err := stepIter.Current()
+		require.NoError(t, err)
+		require.Len(t, v.Values(), 1)
+		actual = append(actual, v.Values()[0])
+	}
+
+	assert.Equal(t, expected, actual)
+
+	seriesIter, err := block.SeriesIter()
+	require.NoError(t, err)
+	require.True(t, seriesIter.Next())
+	series, err := seriesIter.Current()
+	require.NoError(t, err)
+	assert.Equal(t, expected, series.Values())
+}
+
 func verifyMetas(t *testing.T, meta Metadata, seriesMeta []SeriesMeta) {
 	// Verify meta
 	assert.Equal(t, bounds, meta.Bounds)
@@ -57,6 +57,9 @@ type UnconsolidatedBlock interface {
 type SeriesMeta struct {
 	Tags models.Tags
 	Name string
+	// Annotations are the annotations of the datapoints of the series, only
+	// set for fetched series if annotations are requested.
+	Annotations ts.Annotations
 }
 
 // Iterator is the base iterator
@@ -0,0 +1,162 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+// Package cost enforces limits on the resources used by a single query.
+package cost
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// FetchedSeriesResource is the number of series fetched from storage.
+	FetchedSeriesResource = "fetched series"
+
+	// FetchedDatapointsResource is the number of datapoints decompressed.
+	FetchedDatapointsResource = "fetched datapoints"
+
+	// FetchedBlocksResource is the number of blocks fetched from storage.
+	FetchedBlocksResource = "fetched blocks"
+
+	// DurationResource is the wall time spent executing the query.
+	DurationResource = "wall time"
+)
+
+// Limits are the limits for a single query; a zero value for any limit
+// means that resource is unlimited.
+type Limits struct {
+	MaxFetchedSeries     int64
+	MaxFetchedDatapoints int64
+	MaxFetchedBlocks     int64
+	MaxDuration          time.Duration
+}
+
+// Stats are the resources used by a query so far.
+type Stats struct {
+	FetchedSeries     int64
+	FetchedDatapoints int64
+	FetchedBlocks     int64
+	Duration          time.Duration
+}
+
+// Enforcer tracks the resources used by a single query and returns a
+// LimitError once any of its limits are exceeded. It is safe for concurrent
+// use, and a nil Enforcer tracks nothing and never returns an error.
+type Enforcer struct {
+	// NB: counters are first to guarantee 64-bit alignment for atomics.
+	fetchedSeries     int64
+	fetchedDatapoints int64
+	fetchedBlocks     int64
+
+	limits Limits
+	start  time.Time
+	nowFn  func() time.Time
+}
+
+// NewEnforcer creates a new enforcer for a query starting now.
+func NewEnforcer(limits Limits) *Enforcer {
+	return newEnforcer(limits, time.Now)
+}
+
+func newEnforcer(limits Limits, nowFn func() time.Time) *Enforcer {
+	return &Enforcer{
+		limits: limits,
+		start:  nowFn(),
+		nowFn:  nowFn,
+	}
+}
+
+// Limits returns the limits for the query.
+func (e *Enforcer) Limits() Limits {
+	if e == nil {
+		return Limits{}
+	}
+
+	return e.limits
+}
+
+// AddFetchedSeries adds to the count of fetched series.
+func (e *Enforcer) AddFetchedSeries(n int) error {
+	if e == nil {
+		return nil
+	}
+
+	count := atomic.AddInt64(&e.fetchedSeries, int64(n))
+	return e.check(count, e.limits.MaxFetchedSeries, FetchedSeriesResource)
+}
+
+// AddFetchedDatapoints adds to the count of decompressed datapoints.
+func (e *Enforcer) AddFetchedDatapoints(n int) error {
+	if e == nil {
+		return nil
+	}
+
+	count := atomic.AddInt64(&e.fetchedDatapoints, int64(n))
+	return e.check(count, e.limits.MaxFetchedDatapoints, FetchedDatapointsResource)
+}
+
+// AddFetchedBlocks adds to the count of fetched blocks.
+func (e *Enforcer) AddFetchedBlocks(n int) error {
+	if e == nil {
+		return nil
+	}
+
+	count := atomic.AddInt64(&e.fetchedBlocks, int64(n))
+	return e.check(count, e.limits.MaxFetchedBlocks, FetchedBlocksResource)
+}
+
+// CheckDuration returns an error if the query has run for longer
+// than its maximum duration.
+func (e *Enforcer) CheckDuration() error {
+	if e == nil {
+		return nil
+	}
+
+	max := e.limits.MaxDuration
+	if max > 0 && e.nowFn().Sub(e.start) > max {
+		return NewLimitError(DurationResource, max)
+	}
+
+	return nil
+}
+
+// Stats returns the resources used by the query so far.
+func (e *Enforcer) Stats() Stats {
+	if e == nil {
+		return Stats{}
+	}
+
+	return Stats{
+		FetchedSeries:     atomic.LoadInt64(&e.fetchedSeries),
+		FetchedDatapoints: atomic.LoadInt64(&e.fetchedDatapoints),
+		FetchedBlocks:     atomic.LoadInt64(&e.fetchedBlocks),
+		Duration:          e.nowFn().Sub(e.start),
+	}
+}
+
+func (e *Enforcer) check(count, max int64, resource string) error {
+	if max > 0 && count > max {
+		return NewLimitError(resource, max)
+	}
+
+	// NB: since storage calls are not context aware, every resource
+	// added is also an opportunity to enforce the maximum duration.
+	return e.CheckDuration()
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cost
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnforcerLimits(t *testing.T) {
+	enforcer := NewEnforcer(Limits{
+		MaxFetchedSeries:     2,
+		MaxFetchedDatapoints: 10,
+		MaxFetchedBlocks:     1,
+	})
+
+	require.NoError(t, enforcer.AddFetchedSeries(2))
+	err := enforcer.AddFetchedSeries(1)
+	require.Error(t, err)
+	assert.True(t, IsLimitError(err))
+	assert.Equal(t, FetchedSeriesResource, err.(*LimitError).Resource())
+	assert.Equal(t, "query exceeded limit of 2 fetched series", err.Error())
+
+	require.NoError(t, enforcer.AddFetchedDatapoints(10))
+	err = enforcer.AddFetchedDatapoints(1)
+	require.Error(t, err)
+	assert.Equal(t, FetchedDatapointsResource, err.(*LimitError).Resource())
+
+	require.NoError(t, enforcer.AddFetchedBlocks(1))
+	err = enforcer.AddFetchedBlocks(1)
+	require.Error(t, err)
+	assert.Equal(t, FetchedBlocksResource, err.(*LimitError).Resource())
+
+	stats := enforcer.Stats()
+	assert.Equal(t, int64(3), stats.FetchedSeries)
+	assert.Equal(t, int64(11), stats.FetchedDatapoints)
+	assert.Equal(t, int64(2), stats.FetchedBlocks)
+}
+
+func TestEnforcerUnlimited(t *testing.T) {
+	enforcer := NewEnforcer(Limits{})
+	require.NoError(t, enforcer.AddFetchedSeries(1000000))
+	require.NoError(t, enforcer.AddFetchedDatapoints(1000000))
+	require.NoError(t, enforcer.AddFetchedBlocks(1000000))
+	require.NoError(t, enforcer.CheckDuration())
+}
+
+func TestEnforcerDuration(t *testing.T) {
+	now := time.Now()
+	nowFn := func() time.Time { return now }
+	enforcer := newEnforcer(Limits{MaxDuration: time.Second}, nowFn)
+	require.NoError(t, enforcer.CheckDuration())
+
+	now = now.Add(2 * time.Second)
+	err := enforcer.CheckDuration()
+	require.Error(t, err)
+	assert.Equal(t, DurationResource, err.(*LimitError).Resource())
+	assert.Equal(t, 2*time.Second, enforcer.Stats().Duration)
+
+	// Adding resources also enforces the maximum duration.
+	err = enforcer.AddFetchedSeries(1)
+	require.Error(t, err)
+	assert.Equal(t, DurationResource, err.(*LimitError).Resource())
+}
+
+func TestNilEnforcer(t *testing.T) {
+	var enforcer *Enforcer
+	require.NoError(t, enforcer.AddFetchedSeries(1))
+	require.NoError(t, enforcer.AddFetchedDatapoints(1))
+	require.NoError(t, enforcer.AddFetchedBlocks(1))
+	require.NoError(t, enforcer.CheckDuration())
+	assert.Equal(t, Stats{}, enforcer.Stats())
+	assert.Equal(t, Limits{}, enforcer.Limits())
+}
+
+func TestIsLimitError(t *testing.T) {
+	assert.False(t, IsLimitError(nil))
+	assert.False(t, IsLimitError(errors.New("foo")))
+	assert.True(t, IsLimitError(NewLimitError(FetchedSeriesResource, 1)))
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cost
+
+import (
+	"fmt"
+)
+
+// LimitError is returned when a query exceeds one of its cost limits.
+type LimitError struct {
+	resource string
+	limit    string
+}
+
+// NewLimitError creates a new limit error for the given resource and limit.
+func NewLimitError(resource string, limit interface{}) *LimitError {
+	return &LimitError{
+		resource: resource,
+		limit:    fmt.Sprint(limit),
+	}
+}
+
+// Error returns the error string.
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("query exceeded limit of %s %s", e.limit, e.resource)
+}
+
+// Resource returns the resource for which the limit was exceeded.
+func (e *LimitError) Resource() string {
+	return e.resource
+}
+
+// IsLimitError returns true if the error is a limit error.
+func IsLimitError(err error) bool {
+	_, ok := err.(*LimitError)
+	return ok
+}
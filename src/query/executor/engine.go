@@ -22,7 +22,9 @@ package executor
 
 import (
 	"context"
+	"sync/atomic"
 
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 	"github.com/m3db/m3/src/query/plan"
@@ -38,6 +40,7 @@ type Engine struct {
 	tracker *Tracker
 	Stats   *QueryStatistics
 	store   storage.Storage
+	limits  cost.Limits
 }
 
 // EngineOptions can be used to pass custom flags to engine
@@ -48,25 +51,62 @@ type EngineOptions struct {
 
 // Query is the result after execution
 type Query struct {
-	Err    error
-	Result Result
+	Err      error
+	Result   Result
+	enforcer *cost.Enforcer
+}
+
+// Stats returns the resources used by the query; these are only final
+// once all results have been consumed.
+func (q Query) Stats() QueryStatistics {
+	stats := q.enforcer.Stats()
+	return QueryStatistics{
+		QueryExecutionDuration: int64(stats.Duration),
+		FetchedSeries:          stats.FetchedSeries,
+		FetchedDatapoints:      stats.FetchedDatapoints,
+		FetchedBlocks:          stats.FetchedBlocks,
+	}
 }
 
 // NewEngine returns a new instance of QueryExecutor.
 func NewEngine(store storage.Storage) *Engine {
+	return NewEngineWithLimits(store, cost.Limits{})
+}
+
+// NewEngineWithLimits returns a new instance of QueryExecutor which
+// enforces the given cost limits on each query.
+func NewEngineWithLimits(store storage.Storage, limits cost.Limits) *Engine {
 	return &Engine{
 		tracker: NewTracker(),
 		Stats:   &QueryStatistics{},
 		store:   store,
+		limits:  limits,
 	}
 }
 
-// QueryStatistics keeps statistics related to the QueryExecutor.
+// QueryStatistics keeps statistics related to the QueryExecutor. When
+// returned for a single query, only the execution duration and fetched
+// resources are set.
 type QueryStatistics struct {
 	ActiveQueries          int64
 	ExecutedQueries        int64
 	FinishedQueries        int64
 	QueryExecutionDuration int64
+
+	// FetchedSeries is the number of series fetched from storage.
+	FetchedSeries int64
+	// FetchedDatapoints is the number of datapoints decompressed.
+	FetchedDatapoints int64
+	// FetchedBlocks is the number of blocks fetched from storage.
+	FetchedBlocks int64
+}
+
+func (s *QueryStatistics) add(stats cost.Stats) {
+	atomic.AddInt64(&s.ExecutedQueries, 1)
+	atomic.AddInt64(&s.QueryExecutionDuration, int64(stats.Duration))
+	atomic.AddInt64(&s.FetchedSeries, stats.FetchedSeries)
+	atomic.AddInt64(&s.FetchedDatapoints, stats.FetchedDatapoints)
+	atomic.AddInt64(&s.FetchedBlocks, stats.FetchedBlocks)
 }
 
 // Execute runs the query and closes the results channel once done
@@ -85,6 +125,7 @@ func (e *Engine) Execute(ctx context.Context, query *storage.FetchQuery, opts *E
 
 	result, err := e.store.Fetch(ctx, query, &storage.FetchOptions{
 		KillChan: task.closing,
+		Enforcer: cost.NewEnforcer(e.limits),
 	})
 	if err != nil {
 		results <- &storage.QueryResult{Err: err}
@@ -125,7 +166,8 @@ func (e *Engine) ExecuteExpr(ctx context.Context, parser parser.Parser, opts *En
 		logging.WithContext(ctx).Info("physical plan", zap.String("plan", pp.String()))
 	}
 
-	state, err := GenerateExecutionState(pp, e.store)
+	enforcer := cost.NewEnforcer(e.limits)
+	state, err := GenerateExecutionState(pp, e.store, enforcer)
 	// free up resources
 	if err != nil {
 		results <- Query{Err: err}
@@ -136,13 +178,28 @@ func (e *Engine) ExecuteExpr(ctx context.Context, parser parser.Parser, opts *En
 		logging.WithContext(ctx).Info("execution state", zap.String("state", state.String()))
 	}
 
+	if maxDuration := e.limits.MaxDuration; maxDuration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, maxDuration)
+		defer cancel()
+	}
+
 	result := state.resultNode
-	results <- Query{Result: result}
+	results <- Query{Result: result, enforcer: enforcer}
 	if err := state.Execute(ctx); err != nil {
+		// Surface queries cancelled for running too long as a limit error.
+		if ctx.Err() == context.DeadlineExceeded {
+			if limitErr := enforcer.CheckDuration(); limitErr != nil {
+				err = limitErr
+			}
+		}
+
 		result.abort(err)
 	} else {
 		result.done()
 	}
+
+	e.Stats.add(enforcer.Stats())
 }
 
 // Close kills all running queries and prevents new queries from being attached.
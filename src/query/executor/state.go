@@ -24,6 +24,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/executor/transform"
 	"github.com/m3db/m3/src/query/parser"
 	"github.com/m3db/m3/src/query/plan"
@@ -101,10 +102,12 @@ type ScalarParams interface {
 	) parser.Source
 }
 
-// GenerateExecutionState creates an execution state from the physical plan
+// GenerateExecutionState creates an execution state from the physical plan,
+// with the optional enforcer tracking the resources used during execution
 func GenerateExecutionState(
 	pplan plan.PhysicalPlan,
 	storage storage.Storage,
+	enforcer *cost.Enforcer,
 ) (*ExecutionState, error) {
 	result := pplan.ResultStep
 	state := &ExecutionState{
@@ -118,8 +121,10 @@ func GenerateExecutionState(
 	}
 
 	options := transform.Options{
-		TimeSpec: pplan.TimeSpec,
-		Debug:    pplan.Debug,
+		TimeSpec:           pplan.TimeSpec,
+		Debug:              pplan.Debug,
+		Enforcer:           enforcer,
+		IncludeAnnotations: pplan.IncludeAnnotations,
 	}
 	controller, err := state.createNode(step, options)
 	if err != nil {
@@ -24,14 +24,17 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 )
 
 // Options to create transform nodes
 type Options struct {
-	TimeSpec TimeSpec
-	Debug    bool
+	TimeSpec           TimeSpec
+	Debug              bool
+	Enforcer           *cost.Enforcer
+	IncludeAnnotations bool
 }
 
 // OpNode represents the execution node
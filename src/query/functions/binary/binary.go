@@ -21,6 +21,8 @@
 package binary
 
 import (
+	"time"
+
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor/transform"
 	"github.com/m3db/m3/src/query/functions/utils"
@@ -28,7 +30,7 @@ import (
 
 // Function is a function that applies on two floats
 type Function func(x, y float64) float64
-type singleScalarFunc func(x float64) float64
+type singleScalarFunc func(x float64, t time.Time) float64
 
 // processes two logical blocks, performing a logical operation on them
 func processBinary(
@@ -49,15 +51,13 @@ func processBinary(
 			return nil, errLeftScalar
 		}
 
-		lVal := scalarL.Value()
-
 		// rhs is a series; use rhs metadata and series meta
 		if !params.RIsScalar {
 			return processSingleBlock(
 				rhs,
 				controller,
-				func(x float64) float64 {
-					return fn(lVal, x)
+				func(x float64, t time.Time) float64 {
+					return fn(scalarL.Value(t), x)
 				},
 			)
 		}
@@ -76,8 +76,10 @@ func processBinary(
 			return nil, errNoModifierForComparison
 		}
 
-		return block.NewScalar(
-			fn(lVal, scalarR.Value()),
+		return block.NewScalarWithFunc(
+			func(t time.Time) float64 {
+				return fn(scalarL.Value(t), scalarR.Value(t))
+			},
 			lIter.Meta().Bounds,
 		), nil
 	}
@@ -88,13 +90,12 @@ func processBinary(
 			return nil, errRightScalar
 		}
 
-		rVal := scalarR.Value()
 		// lhs is a series; use lhs metadata and series meta
 		return processSingleBlock(
 			lhs,
 			controller,
-			func(x float64) float64 {
-				return fn(x, rVal)
+			func(x float64, t time.Time) float64 {
+				return fn(x, scalarR.Value(t))
 			},
 		)
 	}
@@ -142,7 +143,7 @@ func processSingleBlock(
 
 		values := step.Values()
 		for _, value := range values {
-			builder.AppendValue(index, fn(value))
+			builder.AppendValue(index, fn(value, step.Time()))
 		}
 	}
 
@@ -0,0 +1,79 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+// Package conversion contains functions which convert between
+// scalar and vector values.
+package conversion
+
+import (
+	"fmt"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+// convertFunc converts the input block into the output block.
+type convertFunc func(
+	controller *transform.Controller,
+	b block.Block,
+) (block.Block, error)
+
+// baseOp stores required properties for conversion ops.
+type baseOp struct {
+	opType  string
+	convert convertFunc
+}
+
+// OpType for the operator.
+func (o baseOp) OpType() string {
+	return o.opType
+}
+
+// String representation.
+func (o baseOp) String() string {
+	return fmt.Sprintf("type: %s", o.OpType())
+}
+
+// Node creates an execution node.
+func (o baseOp) Node(
+	controller *transform.Controller,
+	_ transform.Options,
+) transform.OpNode {
+	return &baseNode{
+		op:         o,
+		controller: controller,
+	}
+}
+
+type baseNode struct {
+	op         baseOp
+	controller *transform.Controller
+}
+
+// Process converts the block and passes it on to the next node.
+func (n *baseNode) Process(ID parser.NodeID, b block.Block) error {
+	nextBlock, err := n.op.convert(n.controller, b)
+	if err != nil {
+		return err
+	}
+
+	defer nextBlock.Close()
+	return n.controller.Process(nextBlock)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package conversion
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var nan = math.NaN()
+
+func testBounds() models.Bounds {
+	return models.Bounds{
+		Start:    time.Unix(0, 0),
+		Duration: 4 * time.Minute,
+		StepSize: time.Minute,
+	}
+}
+
+func TestInvalidArgs(t *testing.T) {
+	_, err := NewScalarOp([]interface{}{1.0})
+	assert.Error(t, err)
+
+	_, err = NewVectorOp([]interface{}{1.0})
+	assert.Error(t, err)
+}
+
+func TestScalar(t *testing.T) {
+	bounds := testBounds()
+	values := [][]float64{
+		{1, nan, 3, nan},
+		{nan, 2, 4, nan},
+	}
+
+	bl := test.NewBlockFromValues(bounds, values)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	op, err := NewScalarOp(nil)
+	require.NoError(t, err)
+
+	node := op.(baseOp).Node(c, transform.Options{})
+	err = node.Process(parser.NodeID(0), bl)
+	require.NoError(t, err)
+
+	require.Len(t, sink.Metas, 1)
+	assert.Equal(t, 0, sink.Metas[0].Tags.Len())
+	test.EqualsWithNans(t, [][]float64{{1, 2, nan, nan}}, sink.Values)
+}
+
+func TestVector(t *testing.T) {
+	bounds := testBounds()
+	bl := block.NewScalarWithFunc(func(t time.Time) float64 {
+		return float64(t.Unix())
+	}, bounds)
+
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	op, err := NewVectorOp(nil)
+	require.NoError(t, err)
+
+	node := op.(baseOp).Node(c, transform.Options{})
+	err = node.Process(parser.NodeID(0), bl)
+	require.NoError(t, err)
+
+	require.Len(t, sink.Metas, 1)
+	assert.Equal(t, 0, sink.Metas[0].Tags.Len())
+	assert.Equal(t, 0, sink.Meta.Tags.Len())
+	assert.Equal(t, [][]float64{{0, 60, 120, 180}}, sink.Values)
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package conversion
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+const (
+	// ScalarType converts a single element vector into a scalar; if the
+	// vector does not have exactly one element at a step, the value is NaN.
+	ScalarType = "scalar_conversion"
+
+	// ScalarFunctionName is the name of the PromQL function performing
+	// the scalar conversion.
+	ScalarFunctionName = "scalar"
+)
+
+// NewScalarOp creates a new vector to scalar conversion operation.
+func NewScalarOp(args []interface{}) (parser.Params, error) {
+	if len(args) != 0 {
+		return baseOp{}, fmt.Errorf(
+			"invalid number of args for %s: %d", ScalarFunctionName, len(args))
+	}
+
+	return baseOp{
+		opType:  ScalarType,
+		convert: toScalar,
+	}, nil
+}
+
+func toScalar(
+	_ *transform.Controller,
+	b block.Block,
+) (block.Block, error) {
+	stepIter, err := b.StepIter()
+	if err != nil {
+		return nil, err
+	}
+
+	defer stepIter.Close()
+	bounds := stepIter.Meta().Bounds
+	values := make([]float64, 0, stepIter.StepCount())
+	for stepIter.Next() {
+		step, err := stepIter.Current()
+		if err != nil {
+			return nil, err
+		}
+
+		// NB: series missing a value at this step are not elements of the
+		// vector, so a step may have exactly one element across many series.
+		values = append(values, singleValue(step.Values()))
+	}
+
+	return block.NewScalarWithFunc(func(t time.Time) float64 {
+		if bounds.StepSize <= 0 {
+			return math.NaN()
+		}
+
+		idx := int(t.Sub(bounds.Start) / bounds.StepSize)
+		if idx < 0 || idx >= len(values) {
+			return math.NaN()
+		}
+
+		return values[idx]
+	}, bounds), nil
+}
+
+// singleValue returns the only non-NaN value, or NaN if there
+// are none or more than one.
+func singleValue(values []float64) float64 {
+	result := math.NaN()
+	found := false
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+
+		if found {
+			return math.NaN()
+		}
+
+		result, found = v, true
+	}
+
+	return result
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package conversion
+
+import (
+	"fmt"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+const (
+	// VectorType converts a scalar into a vector with a single,
+	// label-free element.
+	VectorType = "vector"
+)
+
+// NewVectorOp creates a new scalar to vector conversion operation.
+func NewVectorOp(args []interface{}) (parser.Params, error) {
+	if len(args) != 0 {
+		return baseOp{}, fmt.Errorf(
+			"invalid number of args for %s: %d", VectorType, len(args))
+	}
+
+	return baseOp{
+		opType:  VectorType,
+		convert: toVector,
+	}, nil
+}
+
+func toVector(
+	controller *transform.Controller,
+	b block.Block,
+) (block.Block, error) {
+	stepIter, err := b.StepIter()
+	if err != nil {
+		return nil, err
+	}
+
+	defer stepIter.Close()
+	meta := block.Metadata{
+		Bounds: stepIter.Meta().Bounds,
+		Tags:   models.EmptyTags(),
+	}
+
+	seriesMetas := []block.SeriesMeta{{
+		Tags: models.EmptyTags(),
+		Name: VectorType,
+	}}
+
+	builder, err := controller.BlockBuilder(meta, seriesMetas)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := builder.AddCols(stepIter.StepCount()); err != nil {
+		return nil, err
+	}
+
+	for index := 0; stepIter.Next(); index++ {
+		step, err := stepIter.Current()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := builder.AppendValue(index, singleValue(step.Values())); err != nil {
+			return nil, err
+		}
+	}
+
+	return builder.Build(), nil
+}
@@ -25,6 +25,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/executor/transform"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
@@ -49,11 +51,13 @@ type FetchOp struct {
 // FetchNode is the execution node
 // TODO: Make FetchNode private
 type FetchNode struct {
-	op         FetchOp
-	controller *transform.Controller
-	storage    storage.Storage
-	timespec   transform.TimeSpec
-	debug      bool
+	op                 FetchOp
+	controller         *transform.Controller
+	storage            storage.Storage
+	timespec           transform.TimeSpec
+	debug              bool
+	enforcer           *cost.Enforcer
+	includeAnnotations bool
 }
 
 // OpType for the operator
@@ -76,7 +80,15 @@ func (o FetchOp) String() string {
 
 // Node creates an execution node
 func (o FetchOp) Node(controller *transform.Controller, storage storage.Storage, options transform.Options) parser.Source {
-	return &FetchNode{op: o, controller: controller, storage: storage, timespec: options.TimeSpec, debug: options.Debug}
+	return &FetchNode{
+		op:                 o,
+		controller:         controller,
+		storage:            storage,
+		timespec:           options.TimeSpec,
+		debug:              options.Debug,
+		enforcer:           options.Enforcer,
+		includeAnnotations: options.IncludeAnnotations,
+	}
 }
 
 // Execute runs the fetch node operation
@@ -90,12 +102,20 @@ func (n *FetchNode) Execute(ctx context.Context) error {
 		End:         endTime,
 		TagMatchers: n.op.Matchers,
 		Interval:    timeSpec.Step,
-	}, &storage.FetchOptions{})
+	}, &storage.FetchOptions{
+		Enforcer:           n.enforcer,
+		IncludeAnnotations: n.includeAnnotations,
+	})
 	if err != nil {
 		return err
 	}
 
-	for _, block := range blockResult.Blocks {
+	for i, block := range blockResult.Blocks {
+		if err := n.enforcer.CheckDuration(); err != nil {
+			closeBlocks(blockResult.Blocks[i:])
+			return err
+		}
+
 		if n.debug {
 			// Ignore any errors
 			iter, _ := block.StepIter()
@@ -105,7 +125,7 @@ func (n *FetchNode) Execute(ctx context.Context) error {
 		}
 
 		if err := n.controller.Process(block); err != nil {
-			block.Close()
+			closeBlocks(blockResult.Blocks[i:])
 			// Fail on first error
 			return err
 		}
@@ -115,3 +135,9 @@ func (n *FetchNode) Execute(ctx context.Context) error {
 
 	return nil
 }
+
+func closeBlocks(blocks []block.Block) {
+	for _, b := range blocks {
+		b.Close()
+	}
+}
@@ -0,0 +1,368 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+// Package histogram contains functions which operate on bucketed series,
+// where each bucket is a separate series distinguished by its upper bound.
+package histogram
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/utils"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+const (
+	// HistogramQuantileType calculates the quantile for histogram buckets.
+	//
+	// NB: each sample must contain a bucket tag that denotes the upper bound
+	// of that bucket; series without this tag are ignored.
+	HistogramQuantileType = "histogram_quantile"
+
+	// BucketTagName is the tag which denotes the upper bound of a bucket.
+	BucketTagName = "le"
+)
+
+var (
+	bucketTag = []byte(BucketTagName)
+)
+
+// NewHistogramQuantileOp creates a new histogram quantile operation.
+func NewHistogramQuantileOp(
+	args []interface{},
+	opType string,
+) (parser.Params, error) {
+	if len(args) != 1 {
+		return emptyOp, fmt.Errorf(
+			"invalid number of args for histogram_quantile: %d", len(args))
+	}
+
+	if opType != HistogramQuantileType {
+		return emptyOp, fmt.Errorf("operator not supported: %s", opType)
+	}
+
+	q, ok := args[0].(float64)
+	if !ok {
+		return emptyOp, fmt.Errorf("unable to cast to scalar argument: %v", args[0])
+	}
+
+	return newHistogramQuantileOp(q, opType), nil
+}
+
+// histogramQuantileOp stores required properties for histogram quantile ops.
+type histogramQuantileOp struct {
+	q      float64
+	opType string
+}
+
+var emptyOp = histogramQuantileOp{}
+
+// OpType for the operator.
+func (o histogramQuantileOp) OpType() string {
+	return o.opType
+}
+
+// String representation.
+func (o histogramQuantileOp) String() string {
+	return fmt.Sprintf("type: %s, q: %v", o.OpType(), o.q)
+}
+
+// Node creates an execution node.
+func (o histogramQuantileOp) Node(
+	controller *transform.Controller,
+	_ transform.Options,
+) transform.OpNode {
+	return &histogramQuantileNode{
+		op:         o,
+		controller: controller,
+	}
+}
+
+func newHistogramQuantileOp(
+	q float64,
+	opType string,
+) histogramQuantileOp {
+	return histogramQuantileOp{
+		q:      q,
+		opType: opType,
+	}
+}
+
+type histogramQuantileNode struct {
+	op         histogramQuantileOp
+	controller *transform.Controller
+}
+
+type indexedBucket struct {
+	upperBound float64
+	idx        int
+}
+
+type indexedBuckets struct {
+	buckets []indexedBucket
+	tags    models.Tags
+}
+
+func (b indexedBuckets) Len() int { return len(b.buckets) }
+func (b indexedBuckets) Swap(i, j int) {
+	b.buckets[i], b.buckets[j] = b.buckets[j], b.buckets[i]
+}
+func (b indexedBuckets) Less(i, j int) bool {
+	return b.buckets[i].upperBound < b.buckets[j].upperBound
+}
+
+type bucketValue struct {
+	upperBound float64
+	value      float64
+}
+
+// gatherSeriesToBuckets groups series by all tags except the bucket tag and
+// the series name, sorting the buckets in each group by their upper bound.
+func gatherSeriesToBuckets(metas []block.SeriesMeta) []indexedBuckets {
+	bucketsForID := make(map[uint64]*indexedBuckets, len(metas))
+	// Keep the order of groups stable to give consistent output ordering
+	ids := make([]uint64, 0, len(metas))
+	for i, meta := range metas {
+		tags := meta.Tags
+		bucketValue, found := tags.Get(bucketTag)
+		// This series does not have a bucket tag; drop it from the output.
+		if !found {
+			continue
+		}
+
+		bound, err := strconv.ParseFloat(string(bucketValue), 64)
+		// Invalid bounds for this series; drop it from the output.
+		if err != nil || math.IsNaN(bound) {
+			continue
+		}
+
+		excludeTags := [][]byte{tags.Opts.MetricName(), bucketTag}
+		id := tags.IDWithExcludes(excludeTags...)
+		newBucket := indexedBucket{
+			upperBound: bound,
+			idx:        i,
+		}
+
+		if buckets, found := bucketsForID[id]; !found {
+			// Add a single indexed bucket, with tags stripped of the bucket
+			// tag and the series name.
+			ids = append(ids, id)
+			bucketsForID[id] = &indexedBuckets{
+				buckets: []indexedBucket{newBucket},
+				tags:    tags.TagsWithoutKeys(excludeTags),
+			}
+		} else {
+			buckets.buckets = append(buckets.buckets, newBucket)
+		}
+	}
+
+	grouped := make([]indexedBuckets, 0, len(bucketsForID))
+	for _, id := range ids {
+		buckets := bucketsForID[id]
+		sort.Stable(buckets)
+		grouped = append(grouped, *buckets)
+	}
+
+	return grouped
+}
+
+// sanitizeBuckets removes groups of buckets which can never produce a valid
+// quantile, i.e. those without a +Inf bucket or with fewer than two buckets.
+func sanitizeBuckets(buckets []indexedBuckets) []indexedBuckets {
+	sanitized := buckets[:0]
+	for _, b := range buckets {
+		if len(b.buckets) < 2 {
+			continue
+		}
+
+		if !math.IsInf(b.buckets[len(b.buckets)-1].upperBound, 1) {
+			continue
+		}
+
+		sanitized = append(sanitized, b)
+	}
+
+	return sanitized
+}
+
+// Process the block.
+func (n *histogramQuantileNode) Process(ID parser.NodeID, b block.Block) error {
+	stepIter, err := b.StepIter()
+	if err != nil {
+		return err
+	}
+
+	meta := stepIter.Meta()
+	seriesMetas := utils.FlattenMetadata(meta, stepIter.SeriesMeta())
+	bucketedSeries := sanitizeBuckets(gatherSeriesToBuckets(seriesMetas))
+
+	metas := make([]block.SeriesMeta, len(bucketedSeries))
+	for i, v := range bucketedSeries {
+		metas[i] = block.SeriesMeta{
+			Tags: v.tags,
+			Name: n.op.OpType(),
+		}
+	}
+
+	meta.Tags, metas = utils.DedupeMetadata(metas)
+	builder, err := n.controller.BlockBuilder(meta, metas)
+	if err != nil {
+		return err
+	}
+
+	if err := builder.AddCols(stepIter.StepCount()); err != nil {
+		return err
+	}
+
+	q := n.op.q
+	aggregatedValues := make([]float64, len(bucketedSeries))
+	bucketValues := make([]bucketValue, 0, initBucketCount)
+	for index := 0; stepIter.Next(); index++ {
+		step, err := stepIter.Current()
+		if err != nil {
+			return err
+		}
+
+		values := step.Values()
+		for i, buckets := range bucketedSeries {
+			bucketValues = bucketValues[:0]
+			for _, bucket := range buckets.buckets {
+				// Only add non-NaN values to contention for the calculation.
+				val := values[bucket.idx]
+				if !math.IsNaN(val) {
+					bucketValues = append(bucketValues, bucketValue{
+						upperBound: bucket.upperBound,
+						value:      val,
+					})
+				}
+			}
+
+			aggregatedValues[i] = bucketQuantile(q, bucketValues)
+		}
+
+		builder.AppendValues(index, aggregatedValues)
+	}
+
+	nextBlock := builder.Build()
+	defer nextBlock.Close()
+	return n.controller.Process(nextBlock)
+}
+
+const initBucketCount = 10
+
+// coalesceBuckets merges buckets with the same upper bound, which can happen
+// if the same bound is written in different formats (e.g. "1" and "1.0").
+func coalesceBuckets(bucketValues []bucketValue) []bucketValue {
+	if len(bucketValues) == 0 {
+		return bucketValues
+	}
+
+	last := 0
+	for i := 1; i < len(bucketValues); i++ {
+		if bucketValues[i].upperBound == bucketValues[last].upperBound {
+			bucketValues[last].value += bucketValues[i].value
+			continue
+		}
+
+		last++
+		bucketValues[last] = bucketValues[i]
+	}
+
+	return bucketValues[:last+1]
+}
+
+// ensureMonotonic makes sure that the bucket counts are monotonically
+// increasing, which they may not be due to scrape or ingestion skew; any
+// decreasing count is lifted to the maximum count seen so far.
+func ensureMonotonic(bucketValues []bucketValue) {
+	max := math.Inf(-1)
+	for i := range bucketValues {
+		if bucketValues[i].value > max {
+			max = bucketValues[i].value
+		} else if bucketValues[i].value < max {
+			bucketValues[i].value = max
+		}
+	}
+}
+
+// bucketQuantile calculates the quantile 'q' based on the given buckets,
+// which must be sorted by upper bound. The buckets will be treated as a
+// cumulative histogram, with linear interpolation within the bucket that
+// contains the quantile. Matches the semantics of Prometheus:
+//
+// If 'buckets' has fewer than 2 elements, or if the highest bucket is not
+// +Inf, NaN is returned. If q<0, -Inf is returned. If q>1, +Inf is returned.
+// If the quantile falls into the highest bucket, the upper bound of the 2nd
+// highest bucket is returned. If the lowest bucket has an upper bound <= 0
+// and the quantile falls into it, that upper bound is returned.
+func bucketQuantile(q float64, bucketValues []bucketValue) float64 {
+	if q < 0 {
+		return math.Inf(-1)
+	}
+
+	if q > 1 {
+		return math.Inf(1)
+	}
+
+	// NB: some buckets may have been purged if the values at that index were
+	// NaNs, so the +Inf check must happen again here.
+	if len(bucketValues) == 0 ||
+		!math.IsInf(bucketValues[len(bucketValues)-1].upperBound, 1) {
+		return math.NaN()
+	}
+
+	bucketValues = coalesceBuckets(bucketValues)
+	ensureMonotonic(bucketValues)
+	if len(bucketValues) < 2 {
+		return math.NaN()
+	}
+
+	rank := q * bucketValues[len(bucketValues)-1].value
+	bucketIndex := sort.Search(len(bucketValues)-1, func(i int) bool {
+		return bucketValues[i].value >= rank
+	})
+
+	if bucketIndex == len(bucketValues)-1 {
+		return bucketValues[len(bucketValues)-2].upperBound
+	}
+
+	if bucketIndex == 0 && bucketValues[0].upperBound <= 0 {
+		return bucketValues[0].upperBound
+	}
+
+	var (
+		bucketStart float64
+		bucketEnd   = bucketValues[bucketIndex].upperBound
+		count       = bucketValues[bucketIndex].value
+	)
+
+	if bucketIndex > 0 {
+		bucketStart = bucketValues[bucketIndex-1].upperBound
+		count -= bucketValues[bucketIndex-1].value
+		rank -= bucketValues[bucketIndex-1].value
+	}
+
+	return bucketStart + (bucketEnd-bucketStart)*rank/count
+}
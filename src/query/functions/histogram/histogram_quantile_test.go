@@ -0,0 +1,245 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package histogram
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatherSeriesToBuckets(t *testing.T) {
+	name := []byte("name")
+	bucket := []byte("bucket")
+	tagOpts := models.NewTagOptions().
+		SetMetricName(name)
+
+	tags := models.NewTags(3, tagOpts).SetName([]byte("foo")).AddTag(models.Tag{
+		Name:  []byte("bar"),
+		Value: []byte("baz"),
+	})
+
+	noBucketMeta := block.SeriesMeta{Tags: tags}
+	invalidBucketMeta := block.SeriesMeta{Tags: tags.Clone().AddTag(models.Tag{
+		Name: bucketTag, Value: []byte("string"),
+	})}
+	validMeta := block.SeriesMeta{Tags: tags.Clone().AddTag(models.Tag{
+		Name: bucketTag, Value: []byte("0.1"),
+	})}
+	validMeta2 := block.SeriesMeta{Tags: tags.Clone().AddTag(models.Tag{
+		Name: bucketTag, Value: []byte("0.1"),
+	})}
+	validMetaMoreTags := block.SeriesMeta{Tags: tags.Clone().SetName(bucket).AddTag(models.Tag{
+		Name: bucketTag, Value: []byte("0.1"),
+	}).AddTag(models.Tag{
+		Name: []byte("qux"), Value: []byte("qar"),
+	})}
+	infMeta := block.SeriesMeta{Tags: tags.Clone().AddTag(models.Tag{
+		Name: bucketTag, Value: []byte("+Inf"),
+	})}
+
+	metas := []block.SeriesMeta{
+		validMeta, noBucketMeta, invalidBucketMeta, infMeta, validMetaMoreTags, validMeta2,
+	}
+
+	actual := gatherSeriesToBuckets(metas)
+	expected := []indexedBuckets{
+		{
+			buckets: []indexedBucket{
+				{upperBound: 0.1, idx: 0},
+				{upperBound: 0.1, idx: 5},
+				{upperBound: math.Inf(1), idx: 3},
+			},
+			tags: models.NewTags(1, tagOpts).AddTag(models.Tag{
+				Name: []byte("bar"), Value: []byte("baz"),
+			}),
+		},
+		{
+			buckets: []indexedBucket{{upperBound: 0.1, idx: 4}},
+			tags: models.NewTags(2, tagOpts).AddTag(models.Tag{
+				Name: []byte("bar"), Value: []byte("baz"),
+			}).AddTag(models.Tag{
+				Name: []byte("qux"), Value: []byte("qar"),
+			}),
+		},
+	}
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestSanitizeBuckets(t *testing.T) {
+	bucketed := []indexedBuckets{
+		{
+			buckets: []indexedBucket{
+				{upperBound: 10, idx: 0},
+				{upperBound: math.Inf(1), idx: 1},
+			},
+		},
+		{
+			buckets: []indexedBucket{
+				{upperBound: 1, idx: 2},
+				{upperBound: 10, idx: 3},
+			},
+		},
+		{
+			buckets: []indexedBucket{
+				{upperBound: math.Inf(1), idx: 4},
+			},
+		},
+	}
+
+	expected := []indexedBuckets{bucketed[0]}
+	assert.Equal(t, expected, sanitizeBuckets(bucketed))
+}
+
+func TestBucketQuantile(t *testing.T) {
+	buckets := []bucketValue{
+		{upperBound: 1, value: 1},
+		{upperBound: 2, value: 10},
+		{upperBound: 5, value: 15},
+		{upperBound: 10, value: 20},
+		{upperBound: math.Inf(1), value: 20},
+	}
+
+	assert.Equal(t, math.Inf(-1), bucketQuantile(-0.1, buckets))
+	assert.Equal(t, math.Inf(1), bucketQuantile(1.1, buckets))
+	assert.InDelta(t, 1.0, bucketQuantile(0.05, buckets), 0.0001)
+	assert.InDelta(t, 1.0+1.0*9.0/9.0, bucketQuantile(0.5, buckets), 0.0001)
+	assert.InDelta(t, 5+5*3.0/5.0, bucketQuantile(0.9, buckets), 0.0001)
+
+	// Quantile in the highest bucket returns the second highest bound
+	overflow := []bucketValue{
+		{upperBound: 1, value: 1},
+		{upperBound: math.Inf(1), value: 10},
+	}
+	assert.Equal(t, 1.0, bucketQuantile(0.9, overflow))
+
+	// No +Inf bucket returns NaN
+	noInf := []bucketValue{
+		{upperBound: 1, value: 1},
+		{upperBound: 2, value: 10},
+	}
+	assert.True(t, math.IsNaN(bucketQuantile(0.5, noInf)))
+
+	// Too few buckets returns NaN
+	assert.True(t, math.IsNaN(bucketQuantile(0.5, buckets[4:])))
+}
+
+func TestBucketQuantileNonMonotonic(t *testing.T) {
+	buckets := []bucketValue{
+		{upperBound: 1, value: 10},
+		{upperBound: 2, value: 5},
+		{upperBound: 3, value: 20},
+		{upperBound: math.Inf(1), value: 20},
+	}
+
+	// Counts are made monotonic; second bucket is lifted to 10
+	assert.InDelta(t, 2+0.5, bucketQuantile(0.75, buckets), 0.0001)
+	assert.Equal(t, 10.0, buckets[1].value)
+}
+
+func TestBucketQuantileCoalescesBuckets(t *testing.T) {
+	buckets := []bucketValue{
+		{upperBound: 1, value: 2},
+		{upperBound: 1, value: 3},
+		{upperBound: 2, value: 10},
+		{upperBound: math.Inf(1), value: 10},
+	}
+
+	assert.InDelta(t, 1.0, bucketQuantile(0.5, buckets), 0.0001)
+}
+
+func testHistogramQuantileWithArg(t *testing.T, q float64) [][]float64 {
+	name := []byte("name")
+	tagOpts := models.NewTagOptions().
+		SetMetricName(name)
+
+	tags := models.NewTags(2, tagOpts).AddTag(models.Tag{
+		Name:  []byte("bar"),
+		Value: []byte("baz"),
+	})
+
+	seriesMetas := []block.SeriesMeta{
+		{Tags: tags.Clone().SetName([]byte("first")).AddTag(models.Tag{Name: bucketTag, Value: []byte("1")})},
+		{Tags: tags.Clone().SetName([]byte("first")).AddTag(models.Tag{Name: bucketTag, Value: []byte("2")})},
+		{Tags: tags.Clone().SetName([]byte("first")).AddTag(models.Tag{Name: bucketTag, Value: []byte("+Inf")})},
+		{Tags: tags.Clone().SetName([]byte("no_bucket"))},
+	}
+
+	v := [][]float64{
+		{1, 2, math.NaN()},
+		{10, 10, 10},
+		{10, 20, 20},
+		{1, 1, 1},
+	}
+
+	bounds := models.Bounds{
+		Start:    time.Now(),
+		Duration: time.Minute * 3,
+		StepSize: time.Minute,
+	}
+
+	bl := test.NewBlockFromValuesWithSeriesMeta(bounds, seriesMetas, v)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	op, err := NewHistogramQuantileOp([]interface{}{q}, HistogramQuantileType)
+	require.NoError(t, err)
+
+	node := op.(histogramQuantileOp).Node(c, transform.Options{})
+	err = node.Process(parser.NodeID(0), bl)
+	require.NoError(t, err)
+
+	require.Len(t, sink.Metas, 1)
+	assert.Equal(t, HistogramQuantileType, sink.Metas[0].Name)
+	return sink.Values
+}
+
+func TestHistogramQuantile(t *testing.T) {
+	actual := testHistogramQuantileWithArg(t, 0.5)
+	// NB: the first bucket is NaN at the third step so is excluded.
+	expected := [][]float64{{1 + 4.0/9.0, 2, 2}}
+	test.EqualsWithNansWithDelta(t, expected, actual, 0.0001)
+}
+
+func TestHistogramQuantileOutOfRange(t *testing.T) {
+	actual := testHistogramQuantileWithArg(t, -1)
+	expected := [][]float64{{math.Inf(-1), math.Inf(-1), math.Inf(-1)}}
+	test.EqualsWithNans(t, expected, actual)
+}
+
+func TestNewHistogramQuantileOpInvalidArgs(t *testing.T) {
+	_, err := NewHistogramQuantileOp([]interface{}{}, HistogramQuantileType)
+	assert.Error(t, err)
+
+	_, err = NewHistogramQuantileOp([]interface{}{"0.5"}, HistogramQuantileType)
+	assert.Error(t, err)
+
+	_, err = NewHistogramQuantileOp([]interface{}{0.5}, "bad")
+	assert.Error(t, err)
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package linear
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+const (
+	// SortType returns series sorted by value in ascending order
+	SortType = "sort"
+
+	// SortDescType returns series sorted by value in descending order
+	SortDescType = "sort_desc"
+)
+
+// NewSortOp creates a new sort operation based on the type
+func NewSortOp(opType string) (parser.Params, error) {
+	if opType != SortType && opType != SortDescType {
+		return sortOp{}, fmt.Errorf("unknown sort type: %s", opType)
+	}
+
+	return sortOp{opType: opType}, nil
+}
+
+type sortOp struct {
+	opType string
+}
+
+// OpType for the operator
+func (o sortOp) OpType() string {
+	return o.opType
+}
+
+// String representation
+func (o sortOp) String() string {
+	return fmt.Sprintf("type: %s", o.OpType())
+}
+
+// Node creates an execution node
+func (o sortOp) Node(controller *transform.Controller, _ transform.Options) transform.OpNode {
+	return &sortNode{op: o, controller: controller}
+}
+
+type sortNode struct {
+	op         sortOp
+	controller *transform.Controller
+}
+
+type indexedValue struct {
+	idx   int
+	value float64
+}
+
+// Process reorders the series in the block by their value at the last step,
+// which is the evaluation time for instant queries. Series with a NaN value
+// are always sorted last, matching Prometheus.
+func (n *sortNode) Process(ID parser.NodeID, b block.Block) error {
+	seriesIter, err := b.SeriesIter()
+	if err != nil {
+		return err
+	}
+
+	defer seriesIter.Close()
+	seriesMetas := seriesIter.SeriesMeta()
+	allSeries := make([]block.Series, 0, seriesIter.SeriesCount())
+	values := make([]indexedValue, 0, seriesIter.SeriesCount())
+	for idx := 0; seriesIter.Next(); idx++ {
+		series, err := seriesIter.Current()
+		if err != nil {
+			return err
+		}
+
+		value := math.NaN()
+		if series.Len() > 0 {
+			value = series.ValueAtStep(series.Len() - 1)
+		}
+
+		allSeries = append(allSeries, series)
+		values = append(values, indexedValue{idx: idx, value: value})
+	}
+
+	desc := n.op.opType == SortDescType
+	sort.SliceStable(values, func(i, j int) bool {
+		a, b := values[i].value, values[j].value
+		if math.IsNaN(a) {
+			return false
+		}
+
+		if math.IsNaN(b) {
+			return true
+		}
+
+		if desc {
+			return a > b
+		}
+
+		return a < b
+	})
+
+	sortedMetas := make([]block.SeriesMeta, len(values))
+	for i, v := range values {
+		sortedMetas[i] = seriesMetas[v.idx]
+	}
+
+	builder, err := n.controller.BlockBuilder(seriesIter.Meta(), sortedMetas)
+	if err != nil {
+		return err
+	}
+
+	stepCount := 0
+	if len(allSeries) > 0 {
+		stepCount = allSeries[0].Len()
+	}
+
+	if err := builder.AddCols(stepCount); err != nil {
+		return err
+	}
+
+	for _, v := range values {
+		series := allSeries[v.idx]
+		for step := 0; step < stepCount; step++ {
+			if err := builder.AppendValue(step, series.ValueAtStep(step)); err != nil {
+				return err
+			}
+		}
+	}
+
+	nextBlock := builder.Build()
+	defer nextBlock.Close()
+	return n.controller.Process(nextBlock)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package linear
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortInvalidType(t *testing.T) {
+	_, err := NewSortOp("bad")
+	assert.Error(t, err)
+}
+
+var sortTests = []struct {
+	opType        string
+	expected      [][]float64
+	expectedNames []string
+}{
+	{
+		SortType,
+		[][]float64{{5, 1}, {2, 2}, {0, 3}, {1, nan}},
+		[]string{"dummy1", "dummy3", "dummy0", "dummy2"},
+	},
+	{
+		SortDescType,
+		[][]float64{{0, 3}, {2, 2}, {5, 1}, {1, nan}},
+		[]string{"dummy0", "dummy3", "dummy1", "dummy2"},
+	},
+}
+
+func TestSort(t *testing.T) {
+	values := [][]float64{
+		{0, 3},
+		{5, 1},
+		{1, nan},
+		{2, 2},
+	}
+
+	for _, tt := range sortTests {
+		t.Run(tt.opType, func(t *testing.T) {
+			_, bounds := test.GenerateValuesAndBounds(nil, nil)
+			block := test.NewBlockFromValues(bounds, values)
+			c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+			op, err := NewSortOp(tt.opType)
+			require.NoError(t, err)
+
+			node := op.(sortOp).Node(c, transform.Options{})
+			err = node.Process(parser.NodeID(0), block)
+			require.NoError(t, err)
+			test.EqualsWithNans(t, tt.expected, sink.Values)
+
+			names := make([]string, len(sink.Metas))
+			for i, meta := range sink.Metas {
+				names[i] = meta.Name
+			}
+
+			assert.Equal(t, tt.expectedNames, names)
+		})
+	}
+}
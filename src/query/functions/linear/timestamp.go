@@ -0,0 +1,106 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package linear
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/utils"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+// TimestampType returns the timestamp of each sample, in seconds since the
+// epoch; since samples are aligned to steps, this is the step time
+const TimestampType = "timestamp"
+
+// NewTimestampOp creates a new timestamp operation
+func NewTimestampOp() parser.Params {
+	return timestampOp{}
+}
+
+type timestampOp struct{}
+
+// OpType for the operator
+func (o timestampOp) OpType() string {
+	return TimestampType
+}
+
+// String representation
+func (o timestampOp) String() string {
+	return fmt.Sprintf("type: %s", o.OpType())
+}
+
+// Node creates an execution node
+func (o timestampOp) Node(controller *transform.Controller, _ transform.Options) transform.OpNode {
+	return &timestampNode{controller: controller}
+}
+
+type timestampNode struct {
+	controller *transform.Controller
+}
+
+// Process replaces every non-NaN value with its step time, dropping
+// the metric name from each series as Prometheus does
+func (n *timestampNode) Process(ID parser.NodeID, b block.Block) error {
+	stepIter, err := b.StepIter()
+	if err != nil {
+		return err
+	}
+
+	meta := stepIter.Meta()
+	seriesMetas := utils.FlattenMetadata(meta, stepIter.SeriesMeta())
+	for i, seriesMeta := range seriesMetas {
+		seriesMetas[i].Tags = seriesMeta.Tags.WithoutName()
+	}
+
+	meta.Tags, seriesMetas = utils.DedupeMetadata(seriesMetas)
+	builder, err := n.controller.BlockBuilder(meta, seriesMetas)
+	if err != nil {
+		return err
+	}
+
+	if err := builder.AddCols(stepIter.StepCount()); err != nil {
+		return err
+	}
+
+	for index := 0; stepIter.Next(); index++ {
+		step, err := stepIter.Current()
+		if err != nil {
+			return err
+		}
+
+		ts := float64(step.Time().UnixNano()) / float64(time.Second)
+		for _, value := range step.Values() {
+			if !math.IsNaN(value) {
+				value = ts
+			}
+
+			builder.AppendValue(index, value)
+		}
+	}
+
+	nextBlock := builder.Build()
+	defer nextBlock.Close()
+	return n.controller.Process(nextBlock)
+}
@@ -23,6 +23,7 @@ package functions
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor/transform"
@@ -32,18 +33,30 @@ import (
 	"go.uber.org/zap"
 )
 
-// ScalarType is a scalar series
-const ScalarType = "scalar"
+const (
+	// ScalarType is a scalar series
+	ScalarType = "scalar"
+
+	// TimeType is a scalar series whose value at each step is the
+	// step time, in seconds since the epoch
+	TimeType = "time"
+)
 
 type scalarOp struct {
-	val float64
+	val          float64
+	operatorType string
+	fn           block.ScalarFunc
 }
 
 func (o scalarOp) OpType() string {
-	return ScalarType
+	return o.operatorType
 }
 
 func (o scalarOp) String() string {
+	if o.operatorType == TimeType {
+		return fmt.Sprintf("type: %s", o.OpType())
+	}
+
 	return fmt.Sprintf("type: %s. val: %f", o.OpType(), o.val)
 }
 
@@ -60,7 +73,21 @@ func (o scalarOp) Node(
 
 // NewScalarOp creates a new scalar op
 func NewScalarOp(val float64) parser.Params {
-	return &scalarOp{val}
+	return &scalarOp{
+		val:          val,
+		operatorType: ScalarType,
+		fn:           func(_ time.Time) float64 { return val },
+	}
+}
+
+// NewTimeOp creates a new scalar op which returns the time at each step
+func NewTimeOp() parser.Params {
+	return &scalarOp{
+		operatorType: TimeType,
+		fn: func(t time.Time) float64 {
+			return float64(t.UnixNano()) / float64(time.Second)
+		},
+	}
 }
 
 // scalarNode is the execution node
@@ -75,7 +102,7 @@ type scalarNode struct {
 func (n *scalarNode) Execute(ctx context.Context) error {
 	bounds := n.timespec.Bounds()
 
-	block := block.NewScalar(n.op.val, bounds)
+	block := block.NewScalarWithFunc(n.op.fn, bounds)
 	if n.debug {
 		// Ignore any errors
 		iter, _ := block.StepIter()
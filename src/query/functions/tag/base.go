@@ -0,0 +1,107 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+// Package tag contains functions which rewrite series tags without
+// modifying the underlying datapoints.
+package tag
+
+import (
+	"fmt"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/utils"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+// tagTransformFunc rewrites the tags of a single series.
+type tagTransformFunc func(models.Tags) models.Tags
+
+// baseOp stores required properties for tag rewriting ops.
+type baseOp struct {
+	opType       string
+	params       string
+	tagTransform tagTransformFunc
+}
+
+// OpType for the operator.
+func (o baseOp) OpType() string {
+	return o.opType
+}
+
+// String representation.
+func (o baseOp) String() string {
+	return fmt.Sprintf("type: %s, %s", o.OpType(), o.params)
+}
+
+// Node creates an execution node.
+func (o baseOp) Node(
+	controller *transform.Controller,
+	_ transform.Options,
+) transform.OpNode {
+	return &baseNode{
+		op:         o,
+		controller: controller,
+	}
+}
+
+type baseNode struct {
+	op         baseOp
+	controller *transform.Controller
+}
+
+// Process rewrites the series tags of the block, recomputing the tags common
+// to all series; datapoints are passed through untouched.
+func (n *baseNode) Process(ID parser.NodeID, b block.Block) error {
+	stepIter, err := b.StepIter()
+	if err != nil {
+		return err
+	}
+
+	meta := stepIter.Meta()
+	seriesMetas := make([]block.SeriesMeta, len(stepIter.SeriesMeta()))
+	for i, seriesMeta := range stepIter.SeriesMeta() {
+		// NB: clone tags since series metadata may be shared with
+		// the underlying block.
+		seriesMetas[i] = block.SeriesMeta{
+			Tags: seriesMeta.Tags.Clone(),
+			Name: seriesMeta.Name,
+		}
+	}
+
+	stepIter.Close()
+	seriesMetas = utils.FlattenMetadata(meta, seriesMetas)
+	for i, seriesMeta := range seriesMetas {
+		seriesMetas[i].Tags = n.op.tagTransform(seriesMeta.Tags)
+	}
+
+	meta.Tags, seriesMetas = utils.DedupeMetadata(seriesMetas)
+	return n.controller.Process(block.NewUpdatedMetaBlock(b, meta, seriesMetas))
+}
+
+// setOrRemoveTag sets the tag with the given name to the given value,
+// removing the tag entirely if the value is empty.
+func setOrRemoveTag(tags models.Tags, name, value []byte) models.Tags {
+	if len(value) == 0 {
+		return tags.TagsWithoutKeys([][]byte{name})
+	}
+
+	return tags.AddOrUpdateTag(models.Tag{Name: name, Value: value})
+}
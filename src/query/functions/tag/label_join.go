@@ -0,0 +1,91 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package tag
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+
+	"github.com/prometheus/common/model"
+)
+
+const (
+	// LabelJoinType joins the values of all source tags using the separator,
+	// setting the destination tag to the joined value.
+	LabelJoinType = "label_join"
+)
+
+// NewLabelJoinOp creates a new label join operation, expecting arguments
+// of destination tag, separator, and any number of source tags.
+func NewLabelJoinOp(args []interface{}) (parser.Params, error) {
+	if len(args) < 2 {
+		return baseOp{}, fmt.Errorf(
+			"invalid number of args for %s: %d", LabelJoinType, len(args))
+	}
+
+	strArgs, err := stringArgs(args)
+	if err != nil {
+		return baseOp{}, err
+	}
+
+	var (
+		dst       = strArgs[0]
+		separator = strArgs[1]
+		srcs      = strArgs[2:]
+	)
+
+	if !model.LabelName(dst).IsValid() {
+		return baseOp{}, fmt.Errorf("invalid destination label name in %s: %s",
+			LabelJoinType, dst)
+	}
+
+	srcTags := make([][]byte, len(srcs))
+	for i, src := range srcs {
+		if !model.LabelName(src).IsValid() {
+			return baseOp{}, fmt.Errorf("invalid source label name in %s: %s",
+				LabelJoinType, src)
+		}
+
+		srcTags[i] = []byte(src)
+	}
+
+	return baseOp{
+		opType: LabelJoinType,
+		params: fmt.Sprintf("dst: %s, separator: %s, srcs: [%s]",
+			dst, separator, strings.Join(srcs, ", ")),
+		tagTransform: makeLabelJoinFn([]byte(dst), []byte(separator), srcTags),
+	}, nil
+}
+
+func makeLabelJoinFn(dst, separator []byte, srcs [][]byte) tagTransformFunc {
+	return func(tags models.Tags) models.Tags {
+		// NB: missing source tags are treated as empty values.
+		values := make([][]byte, len(srcs))
+		for i, src := range srcs {
+			values[i], _ = tags.Get(src)
+		}
+
+		return setOrRemoveTag(tags, dst, bytes.Join(values, separator))
+	}
+}
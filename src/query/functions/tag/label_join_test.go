@@ -0,0 +1,81 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package tag
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/query/block"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelJoinInvalidArgs(t *testing.T) {
+	_, err := NewLabelJoinOp([]interface{}{"dst"})
+	assert.Error(t, err)
+
+	_, err = NewLabelJoinOp([]interface{}{"dst", ",", 1.0})
+	assert.Error(t, err)
+
+	_, err = NewLabelJoinOp([]interface{}{"invalid-dst", ",", "a"})
+	assert.Error(t, err)
+
+	_, err = NewLabelJoinOp([]interface{}{"dst", ",", "invalid-src"})
+	assert.Error(t, err)
+}
+
+func TestLabelJoin(t *testing.T) {
+	op, err := NewLabelJoinOp([]interface{}{"dst", "-", "a", "missing", "b"})
+	require.NoError(t, err)
+
+	meta := block.Metadata{
+		Bounds: testBounds(),
+		Tags:   tagsFromPairs("a", "1"),
+	}
+
+	seriesMetas := []block.SeriesMeta{
+		{Tags: tagsFromPairs("b", "2")},
+		{Tags: tagsFromPairs("b", "3")},
+	}
+
+	values := [][]float64{{1, 2}, {3, 4}}
+	sink := processTagOp(t, op, meta, seriesMetas, values)
+	assert.Equal(t, values, sink.Values)
+	assert.Equal(t, tagsFromPairs("a", "1").Tags, sink.Meta.Tags.Tags)
+	require.Len(t, sink.Metas, 2)
+	assert.Equal(t, tagsFromPairs("b", "2", "dst", "1--2").Tags,
+		sink.Metas[0].Tags.Tags)
+	assert.Equal(t, tagsFromPairs("b", "3", "dst", "1--3").Tags,
+		sink.Metas[1].Tags.Tags)
+}
+
+func TestLabelJoinEmptyRemovesTag(t *testing.T) {
+	op, err := NewLabelJoinOp([]interface{}{"a", ","})
+	require.NoError(t, err)
+
+	meta := block.Metadata{Bounds: testBounds()}
+	seriesMetas := []block.SeriesMeta{
+		{Tags: tagsFromPairs("a", "1", "b", "2")},
+	}
+
+	sink := processTagOp(t, op, meta, seriesMetas, [][]float64{{1, 2}})
+	assert.Equal(t, tagsFromPairs("b", "2").Tags, sink.Meta.Tags.Tags)
+}
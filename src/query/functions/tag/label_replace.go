@@ -0,0 +1,108 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package tag
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+
+	"github.com/prometheus/common/model"
+)
+
+const (
+	// LabelReplaceType matches the regex against the value of the source tag
+	// and, on a match, sets the destination tag to the expanded replacement.
+	LabelReplaceType = "label_replace"
+)
+
+// NewLabelReplaceOp creates a new label replace operation, expecting
+// arguments of destination tag, replacement, source tag and regex.
+func NewLabelReplaceOp(args []interface{}) (parser.Params, error) {
+	if len(args) != 4 {
+		return baseOp{}, fmt.Errorf(
+			"invalid number of args for %s: %d", LabelReplaceType, len(args))
+	}
+
+	strArgs, err := stringArgs(args)
+	if err != nil {
+		return baseOp{}, err
+	}
+
+	var (
+		dst         = strArgs[0]
+		replacement = strArgs[1]
+		src         = strArgs[2]
+		regexStr    = strArgs[3]
+	)
+
+	// NB: Prometheus anchors the regex to match the entire source value.
+	regex, err := regexp.Compile("^(?:" + regexStr + ")$")
+	if err != nil {
+		return baseOp{}, fmt.Errorf(
+			"invalid regular expression in %s: %s", LabelReplaceType, regexStr)
+	}
+
+	if !model.LabelName(dst).IsValid() {
+		return baseOp{}, fmt.Errorf("invalid destination label name in %s: %s",
+			LabelReplaceType, dst)
+	}
+
+	return baseOp{
+		opType: LabelReplaceType,
+		params: fmt.Sprintf("dst: %s, replacement: %s, src: %s, regex: %s",
+			dst, replacement, src, regexStr),
+		tagTransform: makeLabelReplaceFn([]byte(dst), []byte(replacement),
+			[]byte(src), regex),
+	}, nil
+}
+
+func makeLabelReplaceFn(
+	dst, replacement, src []byte,
+	regex *regexp.Regexp,
+) tagTransformFunc {
+	return func(tags models.Tags) models.Tags {
+		srcVal, _ := tags.Get(src)
+		indexes := regex.FindSubmatchIndex(srcVal)
+		if indexes == nil {
+			return tags
+		}
+
+		// NB: allocate a fresh slice since the value is retained by the tags.
+		value := regex.Expand(nil, replacement, srcVal, indexes)
+		return setOrRemoveTag(tags, dst, value)
+	}
+}
+
+func stringArgs(args []interface{}) ([]string, error) {
+	strArgs := make([]string, len(args))
+	for i, arg := range args {
+		str, ok := arg.(string)
+		if !ok {
+			return nil, fmt.Errorf("unable to cast to string argument: %v", arg)
+		}
+
+		strArgs[i] = str
+	}
+
+	return strArgs, nil
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package tag
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tagsFromPairs(pairs ...string) models.Tags {
+	tags := models.NewTags(len(pairs)/2, nil)
+	for i := 0; i < len(pairs); i += 2 {
+		tags = tags.AddTag(models.Tag{
+			Name:  []byte(pairs[i]),
+			Value: []byte(pairs[i+1]),
+		})
+	}
+
+	return tags
+}
+
+func processTagOp(
+	t *testing.T,
+	op parser.Params,
+	meta block.Metadata,
+	seriesMetas []block.SeriesMeta,
+	values [][]float64,
+) *executor.SinkNode {
+	bl := test.NewBlockFromValuesWithMetaAndSeriesMeta(meta, seriesMetas, values)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	node := op.(baseOp).Node(c, transform.Options{})
+	err := node.Process(parser.NodeID(0), bl)
+	require.NoError(t, err)
+	return sink
+}
+
+func testBounds() models.Bounds {
+	return models.Bounds{
+		Start:    time.Now(),
+		Duration: time.Minute * 2,
+		StepSize: time.Minute,
+	}
+}
+
+func TestLabelReplaceInvalidArgs(t *testing.T) {
+	_, err := NewLabelReplaceOp([]interface{}{"dst", "$1", "src"})
+	assert.Error(t, err)
+
+	_, err = NewLabelReplaceOp([]interface{}{"dst", "$1", "src", 1.0})
+	assert.Error(t, err)
+
+	_, err = NewLabelReplaceOp([]interface{}{"dst", "$1", "src", "(.*"})
+	assert.Error(t, err)
+
+	_, err = NewLabelReplaceOp([]interface{}{"invalid-dst", "$1", "src", "(.*)"})
+	assert.Error(t, err)
+}
+
+func TestLabelReplace(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []interface{}
+		expected models.Tags
+	}{
+		{
+			name:     "capture group expansion",
+			args:     []interface{}{"dst", "$1-$2", "instance", "(.*):(.*)"},
+			expected: tagsFromPairs("dst", "host-9090", "instance", "host:9090", "job", "api"),
+		},
+		{
+			name:     "named capture group expansion",
+			args:     []interface{}{"port", "${port}", "instance", ".*:(?P<port>.*)"},
+			expected: tagsFromPairs("instance", "host:9090", "job", "api", "port", "9090"),
+		},
+		{
+			name:     "regex must match entire value",
+			args:     []interface{}{"dst", "$1", "instance", "(host)"},
+			expected: tagsFromPairs("instance", "host:9090", "job", "api"),
+		},
+		{
+			name:     "overwrites existing tag",
+			args:     []interface{}{"job", "new-$1", "job", "(.*)"},
+			expected: tagsFromPairs("instance", "host:9090", "job", "new-api"),
+		},
+		{
+			name:     "empty replacement removes tag",
+			args:     []interface{}{"job", "", "job", ".*"},
+			expected: tagsFromPairs("instance", "host:9090"),
+		},
+		{
+			name:     "missing source matches empty value",
+			args:     []interface{}{"dst", "default", "missing", ""},
+			expected: tagsFromPairs("dst", "default", "instance", "host:9090", "job", "api"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := NewLabelReplaceOp(tt.args)
+			require.NoError(t, err)
+
+			meta := block.Metadata{
+				Bounds: testBounds(),
+				Tags:   tagsFromPairs("job", "api"),
+			}
+
+			seriesMetas := []block.SeriesMeta{
+				{Tags: tagsFromPairs("instance", "host:9090")},
+			}
+
+			values := [][]float64{{1, 2}}
+			sink := processTagOp(t, op, meta, seriesMetas, values)
+			assert.Equal(t, values, sink.Values)
+			require.Len(t, sink.Metas, 1)
+
+			// NB: with a single series, all tags are common tags.
+			assert.Equal(t, tt.expected.Tags, sink.Meta.Tags.Tags)
+			assert.Len(t, sink.Metas[0].Tags.Tags, 0)
+		})
+	}
+}
+
+func TestLabelReplaceRecomputesCommonTags(t *testing.T) {
+	op, err := NewLabelReplaceOp([]interface{}{"instance", "$1", "instance", "(.*):.*"})
+	require.NoError(t, err)
+
+	meta := block.Metadata{
+		Bounds: testBounds(),
+		Tags:   tagsFromPairs("job", "api"),
+	}
+
+	seriesMetas := []block.SeriesMeta{
+		{Tags: tagsFromPairs("instance", "host:9090")},
+		{Tags: tagsFromPairs("instance", "host:9091")},
+	}
+
+	values := [][]float64{{1, 2}, {3, 4}}
+	sink := processTagOp(t, op, meta, seriesMetas, values)
+	assert.Equal(t, values, sink.Values)
+	assert.Equal(t, tagsFromPairs("instance", "host", "job", "api").Tags,
+		sink.Meta.Tags.Tags)
+	require.Len(t, sink.Metas, 2)
+	assert.Len(t, sink.Metas[0].Tags.Tags, 0)
+	assert.Len(t, sink.Metas[1].Tags.Tags, 0)
+
+	// Ensure the original series metadata is not modified.
+	assert.Equal(t, []byte("host:9090"), seriesMetas[0].Tags.Tags[0].Value)
+}
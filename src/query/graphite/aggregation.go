@@ -0,0 +1,166 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"math"
+)
+
+// aggregateFunc reduces a set of values, which may contain NaNs, to one value.
+type aggregateFunc func(values []float64) float64
+
+func sum(values []float64) float64 {
+	result, found := 0.0, false
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			result += v
+			found = true
+		}
+	}
+
+	if !found {
+		return math.NaN()
+	}
+
+	return result
+}
+
+func average(values []float64) float64 {
+	result, count := 0.0, 0
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			result += v
+			count++
+		}
+	}
+
+	if count == 0 {
+		return math.NaN()
+	}
+
+	return result / float64(count)
+}
+
+func minimum(values []float64) float64 {
+	result := math.NaN()
+	for _, v := range values {
+		if !math.IsNaN(v) && (math.IsNaN(result) || v < result) {
+			result = v
+		}
+	}
+
+	return result
+}
+
+func maximum(values []float64) float64 {
+	result := math.NaN()
+	for _, v := range values {
+		if !math.IsNaN(v) && (math.IsNaN(result) || v > result) {
+			result = v
+		}
+	}
+
+	return result
+}
+
+func multiply(values []float64) float64 {
+	result, found := 1.0, false
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			result *= v
+			found = true
+		}
+	}
+
+	if !found {
+		return math.NaN()
+	}
+
+	return result
+}
+
+// diff subtracts every other value from the first value.
+func diff(values []float64) float64 {
+	if len(values) == 0 || math.IsNaN(values[0]) {
+		return math.NaN()
+	}
+
+	result := values[0]
+	for _, v := range values[1:] {
+		if !math.IsNaN(v) {
+			result -= v
+		}
+	}
+
+	return result
+}
+
+// current returns the last non-NaN value.
+func current(values []float64) float64 {
+	for i := len(values) - 1; i >= 0; i-- {
+		if !math.IsNaN(values[i]) {
+			return values[i]
+		}
+	}
+
+	return math.NaN()
+}
+
+func removeIf(v float64, remove bool) float64 {
+	if remove {
+		return math.NaN()
+	}
+
+	return v
+}
+
+// combine aggregates every input series into a single series per step.
+func combine(fn aggregateFunc) function {
+	return func(c *call) ([]*Series, error) {
+		series, err := c.allSeries()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(series) == 0 {
+			return nil, nil
+		}
+
+		var (
+			numSteps = len(series[0].Values)
+			values   = make([]float64, numSteps)
+			step     = make([]float64, len(series))
+		)
+
+		for i := 0; i < numSteps; i++ {
+			for j, s := range series {
+				step[j] = math.NaN()
+				if i < len(s.Values) {
+					step[j] = s.Values[i]
+				}
+			}
+
+			values[i] = fn(step)
+		}
+
+		return []*Series{series[0].derive(c.e.raw, values)}, nil
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/query/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCombineFunctions(t *testing.T) {
+	input := []*Series{
+		testSeries("a.x", 1, nan, 3, nan),
+		testSeries("a.y", 2, 4, 5, nan),
+	}
+
+	tests := []struct {
+		target   string
+		expected []float64
+	}{
+		{"sumSeries(a.*)", []float64{3, 4, 8, nan}},
+		{"averageSeries(a.*)", []float64{1.5, 4, 4, nan}},
+		{"minSeries(a.*)", []float64{1, 4, 3, nan}},
+		{"maxSeries(a.*)", []float64{2, 4, 5, nan}},
+		{"diffSeries(a.*)", []float64{-1, nan, -2, nan}},
+		{"multiplySeries(a.*)", []float64{2, 4, 15, nan}},
+	}
+
+	for _, tt := range tests {
+		result := apply(t, tt.target, input)
+		require.Len(t, result, 1, tt.target)
+		assert.Equal(t, tt.target, result[0].Name)
+		test.EqualsWithNans(t, tt.expected, result[0].Values)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/storage"
+)
+
+// RenderOptions describes the time range and resolution to render.
+type RenderOptions struct {
+	Start time.Time
+	End   time.Time
+	Step  time.Duration
+}
+
+// Engine evaluates Graphite render targets against a storage.
+type Engine struct {
+	store storage.Querier
+}
+
+// NewEngine returns a new Graphite engine.
+func NewEngine(store storage.Querier) *Engine {
+	return &Engine{store: store}
+}
+
+// Render evaluates a Graphite target and returns the resulting series.
+func (e *Engine) Render(
+	ctx context.Context,
+	target string,
+	opts RenderOptions,
+) ([]*Series, error) {
+	parsed, err := parseExpr(target)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := e.eval(ctx, parsed, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	series, ok := result.([]*Series)
+	if !ok {
+		return nil, fmt.Errorf("graphite target %q does not evaluate to series", target)
+	}
+
+	return series, nil
+}
+
+func (e *Engine) eval(
+	ctx context.Context,
+	ex *expr,
+	opts RenderOptions,
+) (interface{}, error) {
+	switch ex.exprType {
+	case pathExpr:
+		return e.fetch(ctx, ex.path, opts)
+	case numberExpr:
+		return ex.number, nil
+	case stringExpr:
+		return ex.str, nil
+	case boolExpr:
+		return ex.boolean, nil
+	}
+
+	fn, ok := functions[ex.fn]
+	if !ok {
+		return nil, fmt.Errorf("unsupported graphite function: %s", ex.fn)
+	}
+
+	c := &call{e: ex, args: make([]interface{}, 0, len(ex.args))}
+	for _, arg := range ex.args {
+		evaluated, err := e.eval(ctx, arg, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		c.args = append(c.args, evaluated)
+	}
+
+	return fn(c)
+}
+
+func (e *Engine) fetch(
+	ctx context.Context,
+	path string,
+	opts RenderOptions,
+) ([]*Series, error) {
+	matchers, err := MatchersForPath(path, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := e.store.FetchBlocks(ctx, &storage.FetchQuery{
+		Raw:         path,
+		TagMatchers: matchers,
+		Start:       opts.Start,
+		End:         opts.End,
+		Interval:    opts.Step,
+	}, &storage.FetchOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeBlocks(result.Blocks)
+	return seriesFromBlocks(result.Blocks)
+}
+
+func closeBlocks(blocks []block.Block) {
+	for _, b := range blocks {
+		b.Close()
+	}
+}
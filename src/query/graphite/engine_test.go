@@ -0,0 +1,104 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func graphiteSeriesMeta(t *testing.T, names ...string) []block.SeriesMeta {
+	metas := make([]block.SeriesMeta, 0, len(names))
+	for _, name := range names {
+		tags, err := GenerateTags([]byte(name), models.NewTagOptions())
+		require.NoError(t, err)
+		metas = append(metas, block.SeriesMeta{Name: name, Tags: tags})
+	}
+
+	return metas
+}
+
+func TestEngineRender(t *testing.T) {
+	var (
+		store  = mock.NewMockStorage()
+		bounds = models.Bounds{
+			Start:    testStart,
+			Duration: 30 * time.Second,
+			StepSize: 10 * time.Second,
+		}
+		next = bounds.Next(1)
+	)
+
+	metas := graphiteSeriesMeta(t, "servers.host-1.cpu", "servers.host-2.cpu")
+	store.SetFetchBlocksResult(block.Result{
+		Blocks: []block.Block{
+			test.NewBlockFromValuesWithSeriesMeta(bounds, metas,
+				[][]float64{{1, 2, 3}, {10, 20, 30}}),
+			// The second block lists its series in a different order.
+			test.NewBlockFromValuesWithSeriesMeta(next,
+				[]block.SeriesMeta{metas[1], metas[0]},
+				[][]float64{{40, 50, 60}, {4, 5, 6}}),
+		},
+	}, nil)
+
+	engine := NewEngine(store)
+	opts := RenderOptions{
+		Start: bounds.Start,
+		End:   next.End(),
+		Step:  bounds.StepSize,
+	}
+
+	series, err := engine.Render(context.Background(), "servers.*.cpu", opts)
+	require.NoError(t, err)
+	require.Len(t, series, 2)
+	assert.Equal(t, []string{"servers.host-1.cpu", "servers.host-2.cpu"}, names(series))
+	assert.Equal(t, []float64{1, 2, 3, 4, 5, 6}, series[0].Values)
+	assert.Equal(t, []float64{10, 20, 30, 40, 50, 60}, series[1].Values)
+	assert.Equal(t, testStart.Add(50*time.Second), series[0].TimeAt(5))
+
+	series, err = engine.Render(context.Background(),
+		"alias(sumSeries(servers.*.cpu), 'total')", opts)
+	require.NoError(t, err)
+	require.Len(t, series, 1)
+	assert.Equal(t, "total", series[0].Name)
+	assert.Equal(t, []float64{11, 22, 33, 44, 55, 66}, series[0].Values)
+}
+
+func TestEngineRenderErrors(t *testing.T) {
+	engine := NewEngine(mock.NewMockStorage())
+	for _, target := range []string{
+		"unknownFunction(a.b)",
+		"sumSeries(a.b",
+		"10",
+	} {
+		_, err := engine.Render(context.Background(), target, RenderOptions{})
+		assert.Error(t, err, target)
+	}
+}
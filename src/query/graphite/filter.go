@@ -0,0 +1,132 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"math"
+	"sort"
+)
+
+func group(c *call) ([]*Series, error) {
+	return c.allSeries()
+}
+
+func limit(c *call) ([]*Series, error) {
+	if err := c.checkArgs(2, 2); err != nil {
+		return nil, err
+	}
+
+	series, err := c.series(0)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := c.number(1)
+	if err != nil {
+		return nil, err
+	}
+
+	if n < 0 || math.IsNaN(n) {
+		return nil, c.errorf("n must not be negative")
+	}
+
+	if n < float64(len(series)) {
+		series = series[:int(n)]
+	}
+
+	return series, nil
+}
+
+func sortByName(c *call) ([]*Series, error) {
+	if err := c.checkArgs(1, 1); err != nil {
+		return nil, err
+	}
+
+	series, err := c.series(0)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := append([]*Series(nil), series...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	return sorted, nil
+}
+
+// selectN returns the n series with the highest or lowest aggregate value.
+func selectN(fn aggregateFunc, highest bool) function {
+	return func(c *call) ([]*Series, error) {
+		if err := c.checkArgs(1, 2); err != nil {
+			return nil, err
+		}
+
+		series, err := c.series(0)
+		if err != nil {
+			return nil, err
+		}
+
+		n, err := c.optionalNumber(1, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		if n < 0 || math.IsNaN(n) {
+			return nil, c.errorf("n must not be negative")
+		}
+
+		if n > float64(len(series)) {
+			n = float64(len(series))
+		}
+
+		type ranked struct {
+			series *Series
+			value  float64
+		}
+
+		ranks := make([]ranked, 0, len(series))
+		for _, s := range series {
+			ranks = append(ranks, ranked{series: s, value: fn(s.Values)})
+		}
+
+		// NB: series without any values always rank last.
+		sort.SliceStable(ranks, func(i, j int) bool {
+			a, b := ranks[i].value, ranks[j].value
+			if math.IsNaN(a) || math.IsNaN(b) {
+				return !math.IsNaN(a) && math.IsNaN(b)
+			}
+
+			if highest {
+				return a > b
+			}
+
+			return a < b
+		})
+
+		results := make([]*Series, 0, int(n))
+		for i := 0; i < len(ranks) && i < int(n); i++ {
+			results = append(results, ranks[i].series)
+		}
+
+		return results, nil
+	}
+}
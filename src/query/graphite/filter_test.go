@@ -0,0 +1,49 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectionFunctions(t *testing.T) {
+	input := []*Series{
+		testSeries("c", 1, 1),
+		testSeries("a", 5, nan),
+		testSeries("b", 9, 3),
+		testSeries("d", nan, nan),
+	}
+
+	assert.Equal(t, []string{"a", "b", "c", "d"},
+		names(apply(t, "sortByName(x)", input)))
+	assert.Equal(t, []string{"c", "a"},
+		names(apply(t, "limit(x,2)", input, 2.0)))
+	assert.Equal(t, []string{"a", "b"},
+		names(apply(t, "highestCurrent(x,2)", input, 2.0)))
+	assert.Equal(t, []string{"c"},
+		names(apply(t, "lowestCurrent(x)", input)))
+	assert.Equal(t, []string{"b", "a", "c", "d"},
+		names(apply(t, "highestMax(x,10)", input, 10.0)))
+	assert.Equal(t, []string{"c", "a", "b", "d", "c"},
+		names(apply(t, "group(x,y)", input, input[:1])))
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// function evaluates a Graphite function call with evaluated arguments.
+type function func(c *call) ([]*Series, error)
+
+// call is a Graphite function call with its evaluated arguments, which are
+// each one of []*Series, float64, string or bool.
+type call struct {
+	e    *expr
+	args []interface{}
+}
+
+func (c *call) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%s: %s", c.e.fn, fmt.Sprintf(format, args...))
+}
+
+func (c *call) checkArgs(min, max int) error {
+	if len(c.args) < min || (max >= 0 && len(c.args) > max) {
+		return c.errorf("unexpected number of arguments: %d", len(c.args))
+	}
+
+	return nil
+}
+
+func (c *call) series(idx int) ([]*Series, error) {
+	if idx >= len(c.args) {
+		return nil, c.errorf("missing series argument %d", idx)
+	}
+
+	series, ok := c.args[idx].([]*Series)
+	if !ok {
+		return nil, c.errorf("argument %d is not a series list", idx)
+	}
+
+	return series, nil
+}
+
+func (c *call) number(idx int) (float64, error) {
+	if idx >= len(c.args) {
+		return 0, c.errorf("missing numeric argument %d", idx)
+	}
+
+	number, ok := c.args[idx].(float64)
+	if !ok {
+		return 0, c.errorf("argument %d is not a number", idx)
+	}
+
+	return number, nil
+}
+
+func (c *call) optionalNumber(idx int, defaultValue float64) (float64, error) {
+	if idx >= len(c.args) {
+		return defaultValue, nil
+	}
+
+	return c.number(idx)
+}
+
+func (c *call) string(idx int) (string, error) {
+	if idx >= len(c.args) {
+		return "", c.errorf("missing string argument %d", idx)
+	}
+
+	str, ok := c.args[idx].(string)
+	if !ok {
+		return "", c.errorf("argument %d is not a string", idx)
+	}
+
+	return str, nil
+}
+
+// allSeries flattens every argument into a single series list.
+func (c *call) allSeries() ([]*Series, error) {
+	var all []*Series
+	for i := range c.args {
+		series, err := c.series(i)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, series...)
+	}
+
+	return all, nil
+}
+
+// name returns a transformed series name, e.g. scale(a.b,10).
+func (c *call) name(s *Series) string {
+	parts := []string{s.Name}
+	for _, arg := range c.e.args[1:] {
+		parts = append(parts, arg.raw)
+	}
+
+	return fmt.Sprintf("%s(%s)", c.e.fn, strings.Join(parts, ","))
+}
+
+var functions = map[string]function{
+	"absolute":              transform(1, math.Abs),
+	"alias":                 alias,
+	"aliasByNode":           aliasByNode,
+	"averageSeries":         combine(average),
+	"avg":                   combine(average),
+	"derivative":            derivative,
+	"diffSeries":            combine(diff),
+	"group":                 group,
+	"highestCurrent":        selectN(current, true),
+	"highestMax":            selectN(maximum, true),
+	"integral":              integral,
+	"keepLastValue":         keepLastValue,
+	"limit":                 limit,
+	"lowestCurrent":         selectN(current, false),
+	"maxSeries":             combine(maximum),
+	"minSeries":             combine(minimum),
+	"movingAverage":         movingAverage,
+	"multiplySeries":        combine(multiply),
+	"nonNegativeDerivative": nonNegativeDerivative,
+	"offset":                pointwise(func(v, n float64) float64 { return v + n }),
+	"perSecond":             perSecond,
+	"removeAboveValue":      pointwise(func(v, n float64) float64 { return removeIf(v, v > n) }),
+	"removeBelowValue":      pointwise(func(v, n float64) float64 { return removeIf(v, v < n) }),
+	"scale":                 pointwise(func(v, n float64) float64 { return v * n }),
+	"sortByName":            sortByName,
+	"sum":                   combine(sum),
+	"sumSeries":             combine(sum),
+	"transformNull":         transformNull,
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	nan       = math.NaN()
+	testStart = time.Unix(1000, 0)
+)
+
+func testSeries(name string, values ...float64) *Series {
+	return &Series{
+		Name:   name,
+		Start:  testStart,
+		Step:   10 * time.Second,
+		Values: values,
+	}
+}
+
+// apply invokes the function of the target with pre-evaluated arguments.
+func apply(t *testing.T, target string, args ...interface{}) []*Series {
+	e, err := parseExpr(target)
+	require.NoError(t, err)
+	require.Equal(t, callExpr, e.exprType)
+	fn, ok := functions[e.fn]
+	require.True(t, ok, e.fn)
+
+	result, err := fn(&call{e: e, args: args})
+	require.NoError(t, err)
+	return result
+}
+
+func names(series []*Series) []string {
+	result := make([]string, 0, len(series))
+	for _, s := range series {
+		result = append(result, s.Name)
+	}
+
+	return result
+}
+
+func TestFunctionArgumentErrors(t *testing.T) {
+	input := []*Series{testSeries("a.b", 1)}
+	tests := []struct {
+		target string
+		args   []interface{}
+	}{
+		{"scale(a.b)", []interface{}{input}},
+		{`scale(a.b,"x")`, []interface{}{input, "x"}},
+		{"alias(a.b,1)", []interface{}{input, 1.0}},
+		{"sumSeries(1)", []interface{}{1.0}},
+		{"movingAverage(a.b,0)", []interface{}{input, 0.0}},
+		{"limit(a.b,-1)", []interface{}{input, -1.0}},
+		{"highestCurrent(a.b,-1)", []interface{}{input, -1.0}},
+		{"lowestCurrent(a.b,-2)", []interface{}{input, -2.0}},
+	}
+
+	for _, tt := range tests {
+		e, err := parseExpr(tt.target)
+		require.NoError(t, err)
+		_, err = functions[e.fn](&call{e: e, args: tt.args})
+		assert.Error(t, err, tt.target)
+	}
+}
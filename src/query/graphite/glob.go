@@ -0,0 +1,196 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/m3db/m3/src/query/models"
+)
+
+// matchAllPattern matches any value of a tag that is present.
+const matchAllPattern = ".*"
+
+// GlobToRegexPattern converts a single Graphite glob path component into a
+// regular expression pattern. If the component contains no glob characters
+// it is returned unchanged along with false, since it can be matched exactly.
+func GlobToRegexPattern(glob string) (string, bool, error) {
+	var (
+		pattern     bytes.Buffer
+		isRegex     bool
+		inGroup     bool
+		inCharClass bool
+	)
+
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		if inCharClass {
+			pattern.WriteByte(c)
+			if c == ']' {
+				inCharClass = false
+			}
+			continue
+		}
+
+		switch c {
+		case '*':
+			isRegex = true
+			pattern.WriteString(matchAllPattern)
+		case '?':
+			isRegex = true
+			pattern.WriteByte('.')
+		case '[':
+			isRegex = true
+			inCharClass = true
+			pattern.WriteByte(c)
+		case '{':
+			if inGroup {
+				return "", false, fmt.Errorf("nested groups are not supported in glob: %s", glob)
+			}
+			isRegex = true
+			inGroup = true
+			pattern.WriteString("(")
+		case '}':
+			if !inGroup {
+				return "", false, fmt.Errorf("unbalanced group in glob: %s", glob)
+			}
+			inGroup = false
+			pattern.WriteString(")")
+		case ',':
+			if inGroup {
+				pattern.WriteByte('|')
+				continue
+			}
+			pattern.WriteByte(c)
+		default:
+			if strings.IndexByte(`\.+()|^$`, c) != -1 {
+				pattern.WriteByte('\\')
+			}
+			pattern.WriteByte(c)
+		}
+	}
+
+	if inGroup {
+		return "", false, fmt.Errorf("unbalanced group in glob: %s", glob)
+	}
+
+	if inCharClass {
+		return "", false, fmt.Errorf("unbalanced character class in glob: %s", glob)
+	}
+
+	if !isRegex {
+		return glob, false, nil
+	}
+
+	return pattern.String(), true, nil
+}
+
+// SplitPath splits a Graphite path glob into its components, ignoring path
+// separators that appear inside of a group or character class.
+func SplitPath(path string) ([]string, error) {
+	if len(path) == 0 {
+		return nil, errEmptyName
+	}
+
+	var (
+		components []string
+		depth      int
+		start      int
+	)
+
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+		case pathSeparator:
+			if depth == 0 {
+				components = append(components, path[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	components = append(components, path[start:])
+	for _, component := range components {
+		if len(component) == 0 {
+			return nil, errEmptyPathComponent
+		}
+
+		if strings.IndexByte(component, pathSeparator) != -1 {
+			return nil, fmt.Errorf("path separators are not supported "+
+				"inside groups or character classes: %s", path)
+		}
+	}
+
+	if len(components) > MaxPathComponents {
+		return nil, errTooManyComponents
+	}
+
+	return components, nil
+}
+
+// MatchersForPath returns the tag matchers that select every series
+// matching a Graphite path glob. If exact is set then only series with
+// exactly as many path components as the glob are matched, otherwise series
+// with additional trailing components are matched too.
+func MatchersForPath(path string, exact bool) (models.Matchers, error) {
+	components, err := SplitPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	matchers := make(models.Matchers, 0, len(components)+1)
+	for i, component := range components {
+		pattern, isRegex, err := GlobToRegexPattern(component)
+		if err != nil {
+			return nil, err
+		}
+
+		matchType := models.MatchEqual
+		if isRegex {
+			matchType = models.MatchRegexp
+		}
+
+		matcher, err := models.NewMatcher(matchType, TagName(i), []byte(pattern))
+		if err != nil {
+			return nil, err
+		}
+
+		matchers = append(matchers, matcher)
+	}
+
+	if exact {
+		// Series that have a tag beyond the last component are too deep.
+		matcher, err := models.NewMatcher(models.MatchNotRegexp,
+			TagName(len(components)), []byte(matchAllPattern))
+		if err != nil {
+			return nil, err
+		}
+
+		matchers = append(matchers, matcher)
+	}
+
+	return matchers, nil
+}
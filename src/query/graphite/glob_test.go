@@ -0,0 +1,101 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/query/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobToRegexPattern(t *testing.T) {
+	tests := []struct {
+		glob    string
+		pattern string
+		isRegex bool
+	}{
+		{"foo", "foo", false},
+		{"foo-bar_baz", "foo-bar_baz", false},
+		{"*", ".*", true},
+		{"foo*", "foo.*", true},
+		{"fo?", "fo.", true},
+		{"{foo,bar}", "(foo|bar)", true},
+		{"ba[rz]", "ba[rz]", true},
+		{"a+b", "a+b", false},
+		{"a+b*", `a\+b.*`, true},
+		{"a(b)?", `a\(b\).`, true},
+	}
+
+	for _, tt := range tests {
+		pattern, isRegex, err := GlobToRegexPattern(tt.glob)
+		require.NoError(t, err, tt.glob)
+		assert.Equal(t, tt.pattern, pattern, tt.glob)
+		assert.Equal(t, tt.isRegex, isRegex, tt.glob)
+	}
+}
+
+func TestGlobToRegexPatternErrors(t *testing.T) {
+	for _, glob := range []string{"{foo", "foo}", "{a,{b}}", "[ab"} {
+		_, _, err := GlobToRegexPattern(glob)
+		assert.Error(t, err, glob)
+	}
+}
+
+func TestSplitPath(t *testing.T) {
+	components, err := SplitPath("a.{b,c}.d*.[xy]")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "{b,c}", "d*", "[xy]"}, components)
+
+	for _, path := range []string{"", "a..b", "a.{b.c}"} {
+		_, err := SplitPath(path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestMatchersForPath(t *testing.T) {
+	matchers, err := MatchersForPath("servers.*.cpu", true)
+	require.NoError(t, err)
+	require.Len(t, matchers, 4)
+
+	expected := []struct {
+		matchType models.MatchType
+		name      string
+		value     string
+	}{
+		{models.MatchEqual, "__g0__", "servers"},
+		{models.MatchRegexp, "__g1__", ".*"},
+		{models.MatchEqual, "__g2__", "cpu"},
+		{models.MatchNotRegexp, "__g3__", ".*"},
+	}
+
+	for i, ex := range expected {
+		assert.Equal(t, ex.matchType, matchers[i].Type)
+		assert.Equal(t, ex.name, string(matchers[i].Name))
+		assert.Equal(t, ex.value, string(matchers[i].Value))
+	}
+
+	matchers, err = MatchersForPath("servers.*", false)
+	require.NoError(t, err)
+	assert.Len(t, matchers, 2)
+}
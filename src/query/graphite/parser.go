@@ -0,0 +1,196 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type exprType int
+
+const (
+	pathExpr exprType = iota
+	callExpr
+	numberExpr
+	stringExpr
+	boolExpr
+)
+
+// expr is a parsed Graphite target expression.
+type expr struct {
+	exprType exprType
+	// raw is the source text of the expression, used to name results.
+	raw     string
+	path    string
+	fn      string
+	args    []*expr
+	number  float64
+	str     string
+	boolean bool
+}
+
+// parseExpr parses a Graphite target such as sumSeries(a.b.*, c.d).
+func parseExpr(target string) (*expr, error) {
+	p := &parser{input: target}
+	e, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	if p.pos != len(p.input) {
+		return nil, p.errorf("unexpected trailing input")
+	}
+
+	return e, nil
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid graphite target %q at position %d: %s",
+		p.input, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *parser) parse() (*expr, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return nil, p.errorf("unexpected end of input")
+	}
+
+	start := p.pos
+	if c := p.input[p.pos]; c == '"' || c == '\'' {
+		return p.parseString(c)
+	}
+
+	token := p.scanToken()
+	if len(token) == 0 {
+		return nil, p.errorf("expected expression")
+	}
+
+	if strings.ContainsAny(token, " \t") {
+		return nil, p.errorf("unexpected whitespace in %q", token)
+	}
+
+	if p.pos < len(p.input) && p.input[p.pos] == '(' {
+		return p.parseCall(start, token)
+	}
+
+	if number, err := strconv.ParseFloat(token, 64); err == nil {
+		return &expr{exprType: numberExpr, raw: token, number: number}, nil
+	}
+
+	if boolean, err := strconv.ParseBool(token); err == nil {
+		return &expr{exprType: boolExpr, raw: token, boolean: boolean}, nil
+	}
+
+	return &expr{exprType: pathExpr, raw: token, path: token}, nil
+}
+
+// scanToken reads up to the next argument delimiter, treating commas inside
+// of glob groups as part of the token.
+func (p *parser) scanToken() string {
+	var (
+		start = p.pos
+		depth int
+	)
+
+	for ; p.pos < len(p.input); p.pos++ {
+		switch p.input[p.pos] {
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+		case ',', ')':
+			if depth == 0 {
+				return strings.TrimSpace(p.input[start:p.pos])
+			}
+		case '(':
+			return strings.TrimSpace(p.input[start:p.pos])
+		}
+	}
+
+	return strings.TrimSpace(p.input[start:p.pos])
+}
+
+func (p *parser) parseString(quote byte) (*expr, error) {
+	start := p.pos
+	p.pos++
+	end := strings.IndexByte(p.input[p.pos:], quote)
+	if end == -1 {
+		return nil, p.errorf("unterminated string")
+	}
+
+	str := p.input[p.pos : p.pos+end]
+	p.pos += end + 1
+	return &expr{
+		exprType: stringExpr,
+		raw:      p.input[start:p.pos],
+		str:      str,
+	}, nil
+}
+
+func (p *parser) parseCall(start int, fn string) (*expr, error) {
+	// Skip the opening parenthesis.
+	p.pos++
+	e := &expr{exprType: callExpr, fn: fn}
+	p.skipSpaces()
+	if p.pos < len(p.input) && p.input[p.pos] == ')' {
+		p.pos++
+		e.raw = p.input[start:p.pos]
+		return e, nil
+	}
+
+	for {
+		arg, err := p.parse()
+		if err != nil {
+			return nil, err
+		}
+
+		e.args = append(e.args, arg)
+		p.skipSpaces()
+		if p.pos >= len(p.input) {
+			return nil, p.errorf("unterminated call to %s", fn)
+		}
+
+		switch p.input[p.pos] {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			e.raw = p.input[start:p.pos]
+			return e, nil
+		default:
+			return nil, p.errorf("unexpected character %q", p.input[p.pos])
+		}
+	}
+}
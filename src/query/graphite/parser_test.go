@@ -0,0 +1,71 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePath(t *testing.T) {
+	e, err := parseExpr("servers.{a,b}.cpu")
+	require.NoError(t, err)
+	assert.Equal(t, pathExpr, e.exprType)
+	assert.Equal(t, "servers.{a,b}.cpu", e.path)
+}
+
+func TestParseCall(t *testing.T) {
+	e, err := parseExpr(`alias(sumSeries(a.{b,c}.d, e.f), "total", -1.5, true)`)
+	require.NoError(t, err)
+	require.Equal(t, callExpr, e.exprType)
+	assert.Equal(t, "alias", e.fn)
+	require.Len(t, e.args, 4)
+
+	inner := e.args[0]
+	assert.Equal(t, callExpr, inner.exprType)
+	assert.Equal(t, "sumSeries", inner.fn)
+	assert.Equal(t, "sumSeries(a.{b,c}.d, e.f)", inner.raw)
+	require.Len(t, inner.args, 2)
+	assert.Equal(t, "a.{b,c}.d", inner.args[0].path)
+	assert.Equal(t, "e.f", inner.args[1].path)
+
+	assert.Equal(t, stringExpr, e.args[1].exprType)
+	assert.Equal(t, "total", e.args[1].str)
+	assert.Equal(t, numberExpr, e.args[2].exprType)
+	assert.Equal(t, -1.5, e.args[2].number)
+	assert.Equal(t, boolExpr, e.args[3].exprType)
+	assert.True(t, e.args[3].boolean)
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, target := range []string{
+		"",
+		"sumSeries(a.b",
+		"sumSeries(a.b))",
+		"alias(a.b, 'unterminated)",
+		"sumSeries(a.b c.d)",
+	} {
+		_, err := parseExpr(target)
+		assert.Error(t, err, target)
+	}
+}
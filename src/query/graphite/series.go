@@ -0,0 +1,110 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+)
+
+// Series is a named Graphite series of values at a fixed resolution.
+type Series struct {
+	Name   string
+	Tags   models.Tags
+	Start  time.Time
+	Step   time.Duration
+	Values []float64
+}
+
+// TimeAt returns the timestamp of the value at the given index.
+func (s *Series) TimeAt(idx int) time.Time {
+	return s.Start.Add(time.Duration(idx) * s.Step)
+}
+
+// derive returns a copy of the series with the given name and values.
+func (s *Series) derive(name string, values []float64) *Series {
+	return &Series{
+		Name:   name,
+		Tags:   s.Tags,
+		Start:  s.Start,
+		Step:   s.Step,
+		Values: values,
+	}
+}
+
+// seriesFromBlocks converts fetched blocks into Graphite series, joining
+// series that span several consecutive blocks by their tags.
+func seriesFromBlocks(blocks []block.Block) ([]*Series, error) {
+	var (
+		series  []*Series
+		indices = make(map[string]int)
+	)
+
+	for _, b := range blocks {
+		iter, err := b.SeriesIter()
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			meta   = iter.Meta()
+			bounds = meta.Bounds
+			metas  = iter.SeriesMeta()
+		)
+
+		for i := 0; iter.Next(); i++ {
+			current, err := iter.Current()
+			if err != nil {
+				iter.Close()
+				return nil, err
+			}
+
+			if i >= len(metas) {
+				iter.Close()
+				return nil, fmt.Errorf("series index %d out of range of %d series metas",
+					i, len(metas))
+			}
+
+			tags := metas[i].Tags.Clone().Add(meta.Tags)
+			id := tags.ID()
+			if idx, ok := indices[id]; ok {
+				series[idx].Values = append(series[idx].Values, current.Values()...)
+				continue
+			}
+
+			indices[id] = len(series)
+			series = append(series, &Series{
+				Name:   NameFromTags(tags),
+				Tags:   tags,
+				Start:  bounds.Start,
+				Step:   bounds.StepSize,
+				Values: append([]float64(nil), current.Values()...),
+			})
+		}
+
+		iter.Close()
+	}
+
+	return series, nil
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package graphite contains helpers to store and query Graphite metrics,
+// whose dotted paths are indexed as one tag per path component.
+package graphite
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/m3db/m3/src/query/models"
+)
+
+const (
+	// MaxPathComponents is the maximum number of path components supported
+	// in a single Graphite metric name.
+	MaxPathComponents = 128
+
+	pathSeparator = '.'
+	tagPrefix     = "__g"
+	tagSuffix     = "__"
+)
+
+var (
+	errEmptyName          = errors.New("graphite metric name is empty")
+	errEmptyPathComponent = errors.New("graphite metric name contains an empty path component")
+	errTooManyComponents  = fmt.Errorf("graphite metric name exceeds %d path components", MaxPathComponents)
+
+	tagPrefixBytes      = []byte(tagPrefix)
+	tagSuffixBytes      = []byte(tagSuffix)
+	precomputedTagNames = generateTagNames(MaxPathComponents)
+)
+
+func generateTagNames(n int) [][]byte {
+	names := make([][]byte, 0, n)
+	for i := 0; i < n; i++ {
+		names = append(names, []byte(fmt.Sprintf("%s%d%s", tagPrefix, i, tagSuffix)))
+	}
+
+	return names
+}
+
+// TagName returns the tag name used to index the path component at the
+// given position, e.g. __g0__ for the first component.
+func TagName(idx int) []byte {
+	if idx >= 0 && idx < len(precomputedTagNames) {
+		return precomputedTagNames[idx]
+	}
+
+	return []byte(fmt.Sprintf("%s%d%s", tagPrefix, idx, tagSuffix))
+}
+
+// TagIndex returns the path component position for a Graphite tag name,
+// and false if the name is not a Graphite path tag.
+func TagIndex(name []byte) (int, bool) {
+	if !bytes.HasPrefix(name, tagPrefixBytes) ||
+		!bytes.HasSuffix(name, tagSuffixBytes) ||
+		len(name) <= len(tagPrefix)+len(tagSuffix) {
+		return 0, false
+	}
+
+	idx, err := strconv.Atoi(string(name[len(tagPrefix) : len(name)-len(tagSuffix)]))
+	if err != nil || idx < 0 {
+		return 0, false
+	}
+
+	return idx, true
+}
+
+// GenerateTags splits a dotted Graphite metric name into indexed path tags.
+// The name is copied so callers are free to reuse the underlying buffer.
+func GenerateTags(name []byte, opts models.TagOptions) (models.Tags, error) {
+	if len(name) == 0 {
+		return models.EmptyTags(), errEmptyName
+	}
+
+	numComponents := bytes.Count(name, []byte{pathSeparator}) + 1
+	if numComponents > MaxPathComponents {
+		return models.EmptyTags(), errTooManyComponents
+	}
+
+	name = append([]byte(nil), name...)
+	tags := make([]models.Tag, 0, numComponents)
+	for i := 0; i < numComponents; i++ {
+		component := name
+		if idx := bytes.IndexByte(name, pathSeparator); idx != -1 {
+			component, name = name[:idx], name[idx+1:]
+		}
+
+		if len(component) == 0 {
+			return models.EmptyTags(), errEmptyPathComponent
+		}
+
+		tags = append(tags, models.Tag{
+			Name:  TagName(i),
+			Value: component,
+		})
+	}
+
+	return models.NewTags(numComponents, opts).AddTags(tags), nil
+}
+
+// NameFromTags reconstructs the dotted Graphite metric name from a set of
+// indexed path tags. Tags that are not path tags are ignored.
+func NameFromTags(tags models.Tags) string {
+	components := make([][]byte, 0, tags.Len())
+	for i := 0; ; i++ {
+		value, ok := tags.Get(TagName(i))
+		if !ok {
+			break
+		}
+
+		components = append(components, value)
+	}
+
+	return string(bytes.Join(components, []byte{pathSeparator}))
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/query/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagName(t *testing.T) {
+	assert.Equal(t, []byte("__g0__"), TagName(0))
+	assert.Equal(t, []byte("__g12__"), TagName(12))
+	assert.Equal(t, []byte("__g500__"), TagName(500))
+}
+
+func TestTagIndex(t *testing.T) {
+	idx, ok := TagIndex([]byte("__g3__"))
+	require.True(t, ok)
+	assert.Equal(t, 3, idx)
+
+	for _, name := range []string{"__g__", "__gx__", "g3", "__name__", "__g-1__"} {
+		_, ok := TagIndex([]byte(name))
+		assert.False(t, ok, name)
+	}
+}
+
+func TestGenerateTags(t *testing.T) {
+	name := []byte("servers.host-1.cpu")
+	tags, err := GenerateTags(name, models.NewTagOptions())
+	require.NoError(t, err)
+
+	// Mutating the input must not affect the generated tags.
+	copy(name, "XXXXXXX")
+	require.Equal(t, 3, tags.Len())
+	for i, expected := range []string{"servers", "host-1", "cpu"} {
+		value, ok := tags.Get(TagName(i))
+		require.True(t, ok)
+		assert.Equal(t, expected, string(value))
+	}
+
+	assert.Equal(t, "servers.host-1.cpu", NameFromTags(tags))
+}
+
+func TestGenerateTagsErrors(t *testing.T) {
+	opts := models.NewTagOptions()
+	for _, name := range []string{"", "a..b", ".a", "a."} {
+		_, err := GenerateTags([]byte(name), opts)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestNameFromTagsOrdersComponents(t *testing.T) {
+	tags := models.EmptyTags()
+	for i := 11; i >= 0; i-- {
+		tags = tags.AddTag(models.Tag{
+			Name:  TagName(i),
+			Value: []byte{byte('a' + i)},
+		})
+	}
+
+	tags = tags.AddTag(models.Tag{Name: []byte("other"), Value: []byte("x")})
+	assert.Equal(t, "a.b.c.d.e.f.g.h.i.j.k.l", NameFromTags(tags))
+}
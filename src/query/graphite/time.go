@@ -0,0 +1,123 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	nowTime = "now"
+	day     = 24 * time.Hour
+)
+
+var (
+	relativeUnits = []struct {
+		suffix   string
+		duration time.Duration
+	}{
+		{"seconds", time.Second},
+		{"second", time.Second},
+		{"sec", time.Second},
+		{"s", time.Second},
+		{"minutes", time.Minute},
+		{"minute", time.Minute},
+		{"min", time.Minute},
+		{"hours", time.Hour},
+		{"hour", time.Hour},
+		{"h", time.Hour},
+		{"days", day},
+		{"day", day},
+		{"d", day},
+		{"weeks", 7 * day},
+		{"week", 7 * day},
+		{"w", 7 * day},
+		{"months", 30 * day},
+		{"month", 30 * day},
+		{"mon", 30 * day},
+		{"years", 365 * day},
+		{"year", 365 * day},
+		{"y", 365 * day},
+	}
+
+	absoluteFormats = []string{
+		"15:04_20060102",
+		"20060102",
+		"01/02/06",
+	}
+)
+
+// ParseTime parses a Graphite from/until time, which is either "now",
+// an offset relative to now such as "-1h", a unix timestamp in seconds,
+// or an absolute time in one of the Graphite formats.
+func ParseTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == nowTime:
+		return now, nil
+	case strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+"):
+		offset, err := ParseDuration(s[1:])
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if s[0] == '-' {
+			offset = -offset
+		}
+
+		return now.Add(offset), nil
+	}
+
+	if secs, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(secs, 0), nil
+	}
+
+	for _, format := range absoluteFormats {
+		if t, err := time.ParseInLocation(format, s, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unable to parse graphite time: %s", s)
+}
+
+// ParseDuration parses a Graphite duration such as "5min" or "1d".
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	for _, unit := range relativeUnits {
+		if !strings.HasSuffix(s, unit.suffix) {
+			continue
+		}
+
+		value, err := strconv.Atoi(s[:len(s)-len(unit.suffix)])
+		if err != nil {
+			// A shorter unit may share this suffix, e.g. "s" in "5minutes".
+			continue
+		}
+
+		return time.Duration(value) * unit.duration, nil
+	}
+
+	return 0, fmt.Errorf("unable to parse graphite duration: %s", s)
+}
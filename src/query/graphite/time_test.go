@@ -0,0 +1,68 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2018, time.October, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in       string
+		expected time.Time
+	}{
+		{"now", now},
+		{"-1h", now.Add(-time.Hour)},
+		{"-5min", now.Add(-5 * time.Minute)},
+		{"-5minutes", now.Add(-5 * time.Minute)},
+		{"-2d", now.Add(-48 * time.Hour)},
+		{"+30s", now.Add(30 * time.Second)},
+		{"1539172800", time.Unix(1539172800, 0)},
+		{"10:30_20181009", time.Date(2018, time.October, 9, 10, 30, 0, 0, time.UTC)},
+		{"20181009", time.Date(2018, time.October, 9, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		actual, err := ParseTime(tt.in, now)
+		require.NoError(t, err, tt.in)
+		assert.True(t, tt.expected.Equal(actual), tt.in)
+	}
+
+	for _, in := range []string{"", "-1", "-1fortnight", "yesterday"} {
+		_, err := ParseTime(in, now)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	d, err := ParseDuration("1month")
+	require.NoError(t, err)
+	assert.Equal(t, 30*24*time.Hour, d)
+
+	d, err = ParseDuration("10s")
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, d)
+}
@@ -0,0 +1,381 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"math"
+	"strings"
+)
+
+// transform applies a function to every value of every input series.
+func transform(numArgs int, fn func(float64) float64) function {
+	return func(c *call) ([]*Series, error) {
+		if err := c.checkArgs(numArgs, numArgs); err != nil {
+			return nil, err
+		}
+
+		series, err := c.series(0)
+		if err != nil {
+			return nil, err
+		}
+
+		results := make([]*Series, 0, len(series))
+		for _, s := range series {
+			values := make([]float64, len(s.Values))
+			for i, v := range s.Values {
+				values[i] = fn(v)
+			}
+
+			results = append(results, s.derive(c.name(s), values))
+		}
+
+		return results, nil
+	}
+}
+
+// pointwise applies a function taking a numeric argument to every value.
+func pointwise(fn func(v, n float64) float64) function {
+	return func(c *call) ([]*Series, error) {
+		if err := c.checkArgs(2, 2); err != nil {
+			return nil, err
+		}
+
+		n, err := c.number(1)
+		if err != nil {
+			return nil, err
+		}
+
+		return transform(2, func(v float64) float64 {
+			return fn(v, n)
+		})(c)
+	}
+}
+
+func transformNull(c *call) ([]*Series, error) {
+	if err := c.checkArgs(1, 2); err != nil {
+		return nil, err
+	}
+
+	defaultValue, err := c.optionalNumber(1, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return transform(len(c.args), func(v float64) float64 {
+		if math.IsNaN(v) {
+			return defaultValue
+		}
+
+		return v
+	})(c)
+}
+
+// accumulate applies a function to every value of every input series that
+// also receives the previous non-NaN value of the series.
+func accumulate(
+	c *call,
+	fn func(s *Series, prev, v float64) float64,
+) ([]*Series, error) {
+	series, err := c.series(0)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*Series, 0, len(series))
+	for _, s := range series {
+		var (
+			values = make([]float64, len(s.Values))
+			prev   = math.NaN()
+		)
+
+		for i, v := range s.Values {
+			values[i] = fn(s, prev, v)
+			if !math.IsNaN(v) {
+				prev = v
+			}
+		}
+
+		results = append(results, s.derive(c.name(s), values))
+	}
+
+	return results, nil
+}
+
+func derivative(c *call) ([]*Series, error) {
+	if err := c.checkArgs(1, 1); err != nil {
+		return nil, err
+	}
+
+	return accumulate(c, func(_ *Series, prev, v float64) float64 {
+		return v - prev
+	})
+}
+
+func nonNegativeDerivative(c *call) ([]*Series, error) {
+	if err := c.checkArgs(1, 2); err != nil {
+		return nil, err
+	}
+
+	maxValue, err := c.optionalNumber(1, math.NaN())
+	if err != nil {
+		return nil, err
+	}
+
+	return accumulate(c, func(_ *Series, prev, v float64) float64 {
+		return nonNegativeDelta(prev, v, maxValue)
+	})
+}
+
+func perSecond(c *call) ([]*Series, error) {
+	if err := c.checkArgs(1, 2); err != nil {
+		return nil, err
+	}
+
+	maxValue, err := c.optionalNumber(1, math.NaN())
+	if err != nil {
+		return nil, err
+	}
+
+	return accumulate(c, func(s *Series, prev, v float64) float64 {
+		return nonNegativeDelta(prev, v, maxValue) / s.Step.Seconds()
+	})
+}
+
+// nonNegativeDelta returns the delta between two values, accounting for a
+// counter wrapping at maxValue, or NaN if the counter was reset.
+func nonNegativeDelta(prev, v, maxValue float64) float64 {
+	if math.IsNaN(prev) || math.IsNaN(v) {
+		return math.NaN()
+	}
+
+	delta := v - prev
+	if delta >= 0 {
+		return delta
+	}
+
+	if !math.IsNaN(maxValue) && maxValue >= v {
+		return maxValue - prev + v + 1
+	}
+
+	return math.NaN()
+}
+
+func integral(c *call) ([]*Series, error) {
+	if err := c.checkArgs(1, 1); err != nil {
+		return nil, err
+	}
+
+	series, err := c.series(0)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*Series, 0, len(series))
+	for _, s := range series {
+		var (
+			values = make([]float64, len(s.Values))
+			total  float64
+		)
+
+		for i, v := range s.Values {
+			if math.IsNaN(v) {
+				values[i] = math.NaN()
+				continue
+			}
+
+			total += v
+			values[i] = total
+		}
+
+		results = append(results, s.derive(c.name(s), values))
+	}
+
+	return results, nil
+}
+
+func keepLastValue(c *call) ([]*Series, error) {
+	if err := c.checkArgs(1, 2); err != nil {
+		return nil, err
+	}
+
+	limit, err := c.optionalNumber(1, math.Inf(1))
+	if err != nil {
+		return nil, err
+	}
+
+	series, err := c.series(0)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*Series, 0, len(series))
+	for _, s := range series {
+		var (
+			values  = make([]float64, len(s.Values))
+			last    = math.NaN()
+			missing = 0
+		)
+
+		for i, v := range s.Values {
+			if !math.IsNaN(v) {
+				last, missing = v, 0
+				values[i] = v
+				continue
+			}
+
+			missing++
+			values[i] = math.NaN()
+			if float64(missing) <= limit {
+				values[i] = last
+			}
+		}
+
+		results = append(results, s.derive(c.name(s), values))
+	}
+
+	return results, nil
+}
+
+func movingAverage(c *call) ([]*Series, error) {
+	if err := c.checkArgs(2, 2); err != nil {
+		return nil, err
+	}
+
+	series, err := c.series(0)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*Series, 0, len(series))
+	for _, s := range series {
+		window, err := windowPoints(c, s)
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([]float64, len(s.Values))
+		for i := range s.Values {
+			start := i - window + 1
+			if start < 0 {
+				start = 0
+			}
+
+			values[i] = average(s.Values[start : i+1])
+		}
+
+		results = append(results, s.derive(c.name(s), values))
+	}
+
+	return results, nil
+}
+
+// windowPoints resolves a window argument given either as a number of
+// points or as a duration string such as "5min".
+func windowPoints(c *call, s *Series) (int, error) {
+	if window, ok := c.args[1].(string); ok {
+		duration, err := ParseDuration(window)
+		if err != nil {
+			return 0, err
+		}
+
+		if s.Step <= 0 || duration < s.Step {
+			return 1, nil
+		}
+
+		return int(duration / s.Step), nil
+	}
+
+	points, err := c.number(1)
+	if err != nil {
+		return 0, err
+	}
+
+	if points < 1 {
+		return 0, c.errorf("window must be at least one point")
+	}
+
+	return int(points), nil
+}
+
+func alias(c *call) ([]*Series, error) {
+	if err := c.checkArgs(2, 2); err != nil {
+		return nil, err
+	}
+
+	series, err := c.series(0)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := c.string(1)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*Series, 0, len(series))
+	for _, s := range series {
+		results = append(results, s.derive(name, s.Values))
+	}
+
+	return results, nil
+}
+
+func aliasByNode(c *call) ([]*Series, error) {
+	if err := c.checkArgs(2, -1); err != nil {
+		return nil, err
+	}
+
+	series, err := c.series(0)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]int, 0, len(c.args)-1)
+	for i := 1; i < len(c.args); i++ {
+		node, err := c.number(i)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, int(node))
+	}
+
+	results := make([]*Series, 0, len(series))
+	for _, s := range series {
+		components := strings.Split(s.Name, string(pathSeparator))
+		parts := make([]string, 0, len(nodes))
+		for _, node := range nodes {
+			// Negative nodes index from the end of the path.
+			if node < 0 {
+				node += len(components)
+			}
+
+			if node >= 0 && node < len(components) {
+				parts = append(parts, components[node])
+			}
+		}
+
+		results = append(results, s.derive(
+			strings.Join(parts, string(pathSeparator)), s.Values))
+	}
+
+	return results, nil
+}
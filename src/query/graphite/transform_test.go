@@ -0,0 +1,89 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/query/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformFunctions(t *testing.T) {
+	input := []*Series{testSeries("a.b", -1, nan, 2, 6)}
+	tests := []struct {
+		target   string
+		args     []interface{}
+		name     string
+		expected []float64
+	}{
+		{"scale(a.b,2)", []interface{}{2.0}, "scale(a.b,2)", []float64{-2, nan, 4, 12}},
+		{"offset(a.b,1)", []interface{}{1.0}, "offset(a.b,1)", []float64{0, nan, 3, 7}},
+		{"absolute(a.b)", nil, "absolute(a.b)", []float64{1, nan, 2, 6}},
+		{"transformNull(a.b)", nil, "transformNull(a.b)", []float64{-1, 0, 2, 6}},
+		{"transformNull(a.b,-5)", []interface{}{-5.0}, "transformNull(a.b,-5)", []float64{-1, -5, 2, 6}},
+		{"removeBelowValue(a.b,2)", []interface{}{2.0}, "removeBelowValue(a.b,2)", []float64{nan, nan, 2, 6}},
+		{"removeAboveValue(a.b,2)", []interface{}{2.0}, "removeAboveValue(a.b,2)", []float64{-1, nan, 2, nan}},
+		{"derivative(a.b)", nil, "derivative(a.b)", []float64{nan, nan, 3, 4}},
+		{"integral(a.b)", nil, "integral(a.b)", []float64{-1, nan, 1, 7}},
+		{"keepLastValue(a.b)", nil, "keepLastValue(a.b)", []float64{-1, -1, 2, 6}},
+		{"movingAverage(a.b,2)", []interface{}{2.0}, "movingAverage(a.b,2)", []float64{-1, -1, 2, 4}},
+		{`movingAverage(a.b,"20s")`, []interface{}{"20s"}, `movingAverage(a.b,"20s")`, []float64{-1, -1, 2, 4}},
+	}
+
+	for _, tt := range tests {
+		result := apply(t, tt.target, append([]interface{}{input}, tt.args...)...)
+		require.Len(t, result, 1, tt.target)
+		assert.Equal(t, tt.name, result[0].Name)
+		test.EqualsWithNans(t, tt.expected, result[0].Values)
+	}
+
+	// Inputs must never be mutated.
+	test.EqualsWithNans(t, []float64{-1, nan, 2, 6}, input[0].Values)
+}
+
+func TestCounterFunctions(t *testing.T) {
+	input := []*Series{testSeries("a.b", 10, 30, 5, nan, 25)}
+
+	result := apply(t, "nonNegativeDerivative(a.b)", input)
+	test.EqualsWithNans(t, []float64{nan, 20, nan, nan, 20}, result[0].Values)
+
+	result = apply(t, "nonNegativeDerivative(a.b,40)", input, 40.0)
+	test.EqualsWithNans(t, []float64{nan, 20, 16, nan, 20}, result[0].Values)
+
+	result = apply(t, "perSecond(a.b)", input)
+	test.EqualsWithNans(t, []float64{nan, 2, nan, nan, 2}, result[0].Values)
+}
+
+func TestNamingFunctions(t *testing.T) {
+	input := []*Series{
+		testSeries("servers.host-1.cpu.user", 1),
+		testSeries("servers.host-2.cpu.user", 2),
+	}
+
+	result := apply(t, `alias(servers.*.cpu.user,"cpu")`, input, "cpu")
+	assert.Equal(t, []string{"cpu", "cpu"}, names(result))
+
+	result = apply(t, "aliasByNode(servers.*.cpu.user,1,-1)", input, 1.0, -1.0)
+	assert.Equal(t, []string{"host-1.user", "host-2.user"}, names(result))
+}
@@ -35,6 +35,14 @@ type RequestParams struct {
 	Query      string
 	Debug      bool
 	IncludeEnd bool
+	// IncludeAnnotations returns the annotations of the datapoints of
+	// fetched series with the results
+	IncludeAnnotations bool
+	// LookbackDuration is how far back vector selectors look for a datapoint
+	// when evaluating a step, zero disables the lookback
+	LookbackDuration time.Duration
+	// Instant is set for instant queries evaluated at a single time
+	Instant bool
 }
 
 // ExclusiveEnd returns the end exclusive
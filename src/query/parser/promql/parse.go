@@ -23,6 +23,7 @@ package promql
 import (
 	"fmt"
 
+	"github.com/m3db/m3/src/query/functions/conversion"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 
@@ -47,6 +48,20 @@ func Parse(q string, tagOpts models.TagOptions) (parser.Parser, error) {
 	}, nil
 }
 
+// ParseSeriesMatchQuery parses a series selector, such as those given as
+// match[] params to the metadata endpoints, into coordinator matchers
+func ParseSeriesMatchQuery(
+	expr string,
+	tagOpts models.TagOptions,
+) (models.Matchers, error) {
+	labelMatchers, err := pql.ParseMetricSelector(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return labelMatchersToModelMatcher(labelMatchers, tagOpts)
+}
+
 func (p *promParser) DAG() (parser.Nodes, parser.Edges, error) {
 	state := &parseState{tagOpts: p.tagOpts}
 	err := state.walk(p.expr)
@@ -127,15 +142,39 @@ func (p *parseState) walk(node pql.Node) error {
 		expressions := n.Args
 		argTypes := n.Func.ArgTypes
 		argValues := make([]interface{}, 0, len(expressions))
+		hasParent := false
 		for i, expr := range expressions {
-			if argTypes[i] == pql.ValueTypeScalar {
+			// NB: variadic functions repeat their last argument type.
+			argType := argTypes[len(argTypes)-1]
+			if i < len(argTypes) {
+				argType = argTypes[i]
+			}
+
+			// NB: vector converts the result of its scalar argument, which
+			// may vary over time, so it must be evaluated as a node rather
+			// than being resolved to a constant.
+			if argType == pql.ValueTypeScalar && n.Func.Name == conversion.VectorType {
+				argType = pql.ValueTypeVector
+			}
+
+			switch argType {
+			case pql.ValueTypeScalar:
 				val, err := resolveScalarArgument(expr)
 				if err != nil {
 					return err
 				}
 
 				argValues = append(argValues, val)
-			} else {
+
+			case pql.ValueTypeString:
+				str, ok := expr.(*pql.StringLiteral)
+				if !ok {
+					return fmt.Errorf("expected string literal argument, got: %v", expr)
+				}
+
+				argValues = append(argValues, str.Val)
+
+			default:
 				if e, ok := expr.(*pql.MatrixSelector); ok {
 					argValues = append(argValues, e.Range)
 				}
@@ -143,6 +182,8 @@ func (p *parseState) walk(node pql.Node) error {
 				if err := p.walk(expr); err != nil {
 					return err
 				}
+
+				hasParent = true
 			}
 
 		}
@@ -153,10 +194,15 @@ func (p *parseState) walk(node pql.Node) error {
 		}
 
 		opTransform := parser.NewTransformFromOperation(op, p.transformLen())
-		p.edges = append(p.edges, parser.Edge{
-			ParentID: p.lastTransformID(),
-			ChildID:  opTransform.ID,
-		})
+		// NB: functions without expression arguments, such as time(),
+		// are sources and have no parent.
+		if hasParent {
+			p.edges = append(p.edges, parser.Edge{
+				ParentID: p.lastTransformID(),
+				ChildID:  opTransform.ID,
+			})
+		}
+
 		p.transforms = append(p.transforms, opTransform)
 		return nil
 
@@ -26,7 +26,10 @@ import (
 	"github.com/m3db/m3/src/query/functions"
 	"github.com/m3db/m3/src/query/functions/aggregation"
 	"github.com/m3db/m3/src/query/functions/binary"
+	"github.com/m3db/m3/src/query/functions/conversion"
+	"github.com/m3db/m3/src/query/functions/histogram"
 	"github.com/m3db/m3/src/query/functions/linear"
+	"github.com/m3db/m3/src/query/functions/tag"
 	"github.com/m3db/m3/src/query/functions/temporal"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
@@ -127,6 +130,16 @@ var linearParseTests = []struct {
 	{"minute(up)", linear.MinuteType},
 	{"month(up)", linear.MonthType},
 	{"year(up)", linear.YearType},
+
+	{"histogram_quantile(0.9, up)", histogram.HistogramQuantileType},
+
+	{`label_replace(up, "dst", "$1", "src", "(.*)")`, tag.LabelReplaceType},
+	{`label_join(up, "dst", ",", "a", "b", "c")`, tag.LabelJoinType},
+
+	{"sort(up)", linear.SortType},
+	{"sort_desc(up)", linear.SortDescType},
+	{"timestamp(up)", linear.TimestampType},
+	{"scalar(up)", conversion.ScalarType},
 }
 
 func TestLinearParses(t *testing.T) {
@@ -149,6 +162,41 @@ func TestLinearParses(t *testing.T) {
 	}
 }
 
+func TestTimeParses(t *testing.T) {
+	p, err := Parse("time()", models.NewTagOptions())
+	require.NoError(t, err)
+	transforms, edges, err := p.DAG()
+	require.NoError(t, err)
+	require.Len(t, transforms, 1)
+	assert.Equal(t, functions.TimeType, transforms[0].Op.OpType())
+	assert.Len(t, edges, 0)
+}
+
+var vectorParseTests = []struct {
+	q          string
+	sourceType string
+}{
+	{"vector(1)", functions.ScalarType},
+	{"vector(time())", functions.TimeType},
+}
+
+func TestVectorParses(t *testing.T) {
+	for _, tt := range vectorParseTests {
+		t.Run(tt.q, func(t *testing.T) {
+			p, err := Parse(tt.q, models.NewTagOptions())
+			require.NoError(t, err)
+			transforms, edges, err := p.DAG()
+			require.NoError(t, err)
+			require.Len(t, transforms, 2)
+			assert.Equal(t, tt.sourceType, transforms[0].Op.OpType())
+			assert.Equal(t, conversion.VectorType, transforms[1].Op.OpType())
+			require.Len(t, edges, 1)
+			assert.Equal(t, parser.NodeID("0"), edges[0].ParentID)
+			assert.Equal(t, parser.NodeID("1"), edges[0].ChildID)
+		})
+	}
+}
+
 var binaryParseTests = []struct {
 	q                string
 	LHSType, RHSType string
@@ -161,6 +209,7 @@ var binaryParseTests = []struct {
 	{"10 + 10", functions.ScalarType, functions.ScalarType, binary.PlusType},
 	{"up % up", functions.FetchType, functions.FetchType, binary.ModType},
 	{"up * 10", functions.FetchType, functions.ScalarType, binary.MultiplyType},
+	{"time() - up", functions.TimeType, functions.FetchType, binary.MinusType},
 
 	// Equality
 	{"up == up", functions.FetchType, functions.FetchType, binary.EqType},
@@ -285,3 +334,21 @@ func TestFailedTemporalParse(t *testing.T) {
 	_, err := Parse(q, models.NewTagOptions())
 	require.Error(t, err)
 }
+
+func TestParseSeriesMatchQuery(t *testing.T) {
+	matchers, err := ParseSeriesMatchQuery(`up{job=~"pro.*",env!="dev"}`, models.NewTagOptions())
+	require.NoError(t, err)
+	require.Len(t, matchers, 3)
+
+	assert.Equal(t, models.MatchRegexp, matchers[0].Type)
+	assert.Equal(t, "job", string(matchers[0].Name))
+	assert.Equal(t, "pro.*", string(matchers[0].Value))
+	assert.Equal(t, models.MatchNotEqual, matchers[1].Type)
+	assert.Equal(t, "env", string(matchers[1].Name))
+	assert.Equal(t, models.MatchEqual, matchers[2].Type)
+	assert.Equal(t, "__name__", string(matchers[2].Name))
+	assert.Equal(t, "up", string(matchers[2].Value))
+
+	_, err = ParseSeriesMatchQuery(`sum(up)`, models.NewTagOptions())
+	require.Error(t, err)
+}
@@ -25,6 +25,7 @@ import (
 	"math"
 
 	"github.com/m3db/m3/src/query/functions/binary"
+	"github.com/m3db/m3/src/query/functions/conversion"
 
 	pql "github.com/prometheus/prometheus/promql"
 )
@@ -91,11 +92,10 @@ func resolveScalarArgumentWithNesting(expr pql.Expr, nesting int) (float64, int,
 		return math.NaN(), nesting - 1, nil
 
 	case *pql.Call:
-		// TODO: once these functions exist, use those constants here
 		// If the function called is `scalar`, evaluate inside and insure a scalar
-		if n.Func.Name == "scalar" {
+		if n.Func.Name == conversion.ScalarFunctionName {
 			return resolveScalarArgumentWithNesting(n.Args[0], nesting+1)
-		} else if n.Func.Name == "vector" {
+		} else if n.Func.Name == conversion.VectorType {
 			// If the function called is `vector`, evaluate inside and insure a vector
 			if nesting < 1 {
 				return 0, 0, errInvalidNestingVector
@@ -26,7 +26,10 @@ import (
 	"github.com/m3db/m3/src/query/functions"
 	"github.com/m3db/m3/src/query/functions/aggregation"
 	"github.com/m3db/m3/src/query/functions/binary"
+	"github.com/m3db/m3/src/query/functions/conversion"
+	"github.com/m3db/m3/src/query/functions/histogram"
 	"github.com/m3db/m3/src/query/functions/linear"
+	"github.com/m3db/m3/src/query/functions/tag"
 	"github.com/m3db/m3/src/query/functions/temporal"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
@@ -169,6 +172,12 @@ func NewFunctionExpr(name string, argValues []interface{}) (parser.Params, error
 	case linear.AbsentType:
 		return linear.NewAbsentOp(), nil
 
+	case linear.SortType, linear.SortDescType:
+		return linear.NewSortOp(name)
+
+	case linear.TimestampType:
+		return linear.NewTimestampOp(), nil
+
 	case linear.ClampMinType, linear.ClampMaxType:
 		return linear.NewClampOp(argValues, name)
 
@@ -179,6 +188,24 @@ func NewFunctionExpr(name string, argValues []interface{}) (parser.Params, error
 		linear.MinuteType, linear.MonthType, linear.YearType:
 		return linear.NewDateOp(name)
 
+	case histogram.HistogramQuantileType:
+		return histogram.NewHistogramQuantileOp(argValues, name)
+
+	case conversion.ScalarFunctionName:
+		return conversion.NewScalarOp(argValues)
+
+	case conversion.VectorType:
+		return conversion.NewVectorOp(argValues)
+
+	case functions.TimeType:
+		return functions.NewTimeOp(), nil
+
+	case tag.LabelReplaceType:
+		return tag.NewLabelReplaceOp(argValues)
+
+	case tag.LabelJoinType:
+		return tag.NewLabelJoinOp(argValues)
+
 	case temporal.AvgType, temporal.CountType, temporal.MinType,
 		temporal.MaxType, temporal.SumType, temporal.StdDevType,
 		temporal.StdVarType:
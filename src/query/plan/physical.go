@@ -37,6 +37,10 @@ type PhysicalPlan struct {
 	ResultStep ResultOp
 	TimeSpec   transform.TimeSpec
 	Debug      bool
+	// IncludeAnnotations fetches the annotations of datapoints with the series
+	IncludeAnnotations bool
+	lookback           time.Duration
+	instant            bool
 }
 
 // ResultOp is resonsible for delivering results to the clients
@@ -59,7 +63,10 @@ func NewPhysicalPlan(lp LogicalPlan, storage storage.Storage, params models.Requ
 			Now:   params.Now,
 			Step:  params.Step,
 		},
-		Debug: params.Debug,
+		Debug:              params.Debug,
+		IncludeAnnotations: params.IncludeAnnotations,
+		lookback:           params.LookbackDuration,
+		instant:            params.Instant,
 	}
 
 	pl, err := p.createResultNode()
@@ -91,7 +98,18 @@ func (p PhysicalPlan) shiftTime() PhysicalPlan {
 		}
 	}
 
+	// Vector selectors need to see datapoints up to the lookback before the first step
+	if p.lookback > maxRange {
+		maxRange = p.lookback
+	}
+
 	startShift := maxOffset + maxRange
+	if step := p.TimeSpec.Step; p.instant && step > 0 && startShift%step != 0 {
+		// Keep the step of instant queries aligned to the requested time so the
+		// step at the requested time is actually evaluated
+		startShift += step - startShift%step
+	}
+
 	// keeping end the same for now, might optimize later
 	p.TimeSpec.Start = p.TimeSpec.Start.Add(-1 * startShift)
 	return p
@@ -82,3 +82,49 @@ func TestShiftTime(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, p.TimeSpec.Start, start.Add(-1*(time.Minute+time.Hour)), "start time offset by fetch")
 }
+
+func TestShiftTimeWithLookback(t *testing.T) {
+	fetchTransform := parser.NewTransformFromOperation(functions.FetchOp{}, 1)
+	agg, err := aggregation.NewAggregationOp(aggregation.CountType, aggregation.NodeParams{})
+	require.NoError(t, err)
+	countTransform := parser.NewTransformFromOperation(agg, 2)
+	transforms := parser.Nodes{fetchTransform, countTransform}
+	edges := parser.Edges{
+		parser.Edge{
+			ParentID: fetchTransform.ID,
+			ChildID:  countTransform.ID,
+		},
+	}
+
+	lp, _ := NewLogicalPlan(transforms, edges)
+	now := time.Now()
+	start := now.Add(-1 * time.Hour)
+	p, err := NewPhysicalPlan(lp, nil, models.RequestParams{
+		Now:              now,
+		Start:            start,
+		Step:             time.Minute,
+		LookbackDuration: 5 * time.Minute,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, start.Add(-5*time.Minute), p.TimeSpec.Start, "start time offset by lookback")
+
+	fetchTransform = parser.NewTransformFromOperation(functions.FetchOp{Range: 90 * time.Second}, 1)
+	transforms = parser.Nodes{fetchTransform, countTransform}
+	lp, _ = NewLogicalPlan(transforms, edges)
+	p, err = NewPhysicalPlan(lp, nil, models.RequestParams{
+		Now:   now,
+		Start: start,
+		Step:  time.Minute,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, start.Add(-90*time.Second), p.TimeSpec.Start, "start time of range query not aligned to step")
+
+	p, err = NewPhysicalPlan(lp, nil, models.RequestParams{
+		Now:     now,
+		Start:   start,
+		Step:    time.Minute,
+		Instant: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, start.Add(-2*time.Minute), p.TimeSpec.Start, "start time of instant query aligned to step")
+}
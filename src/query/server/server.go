@@ -46,6 +46,7 @@ import (
 	"github.com/m3db/m3/src/query/storage/m3"
 	"github.com/m3db/m3/src/query/storage/remote"
 	"github.com/m3db/m3/src/query/stores/m3db"
+	"github.com/m3db/m3/src/query/tenant"
 	tsdbRemote "github.com/m3db/m3/src/query/tsdb/remote"
 	"github.com/m3db/m3/src/query/util/logging"
 	"github.com/m3db/m3/src/x/serialize"
@@ -203,7 +204,19 @@ func Run(runOpts RunOptions) {
 		defer cleanup()
 	}
 
-	engine := executor.NewEngine(backendStorage)
+	if cfg.Tenants != nil {
+		// The m3msg and carbon ingest servers are not authenticated and
+		// their writes are trusted, so they cannot be served alongside
+		// tenants without bypassing the restrictions of the tenants
+		if cfg.Ingest != nil || cfg.Carbon != nil {
+			logger.Fatal("tenants cannot be configured with the m3msg or carbon ingest servers")
+		}
+
+		// Restrict the queries of tenants to their series
+		backendStorage = tenant.NewStorage(backendStorage)
+	}
+
+	engine := executor.NewEngineWithLimits(backendStorage, cfg.Limits.AsLimits())
 
 	handler, err := httpd.NewHandler(backendStorage, tagOptions, downsampler, engine,
 		m3dbClusters, clusterClient, cfg, runOpts.DBConfig, scope)
@@ -262,6 +275,26 @@ func Run(runOpts RunOptions) {
 		logger.Info("no m3msg server configured")
 	}
 
+	if cfg.Carbon != nil {
+		logger.Info("starting carbon ingestion server",
+			zap.String("address", cfg.Carbon.ListenAddress))
+		server, err := cfg.Carbon.NewServer(
+			backendStorage,
+			tagOptions,
+			instrumentOptions.SetMetricsScope(scope.SubScope("carbon")),
+		)
+		if err != nil {
+			logger.Fatal("unable to create carbon ingestion server", zap.Error(err))
+		}
+
+		if err := server.ListenAndServe(); err != nil {
+			logger.Fatal("unable to listen on carbon ingestion server", zap.Error(err))
+		}
+
+		logger.Info("started carbon ingestion server")
+		defer server.Close()
+	}
+
 	var interruptCh <-chan error = make(chan error)
 	if runOpts.InterruptCh != nil {
 		interruptCh = runOpts.InterruptCh
@@ -133,6 +133,7 @@ func (m multiSeriesBlock) SeriesMeta() []block.SeriesMeta {
 	for i, s := range m.seriesList {
 		metas[i].Tags = s.Tags
 		metas[i].Name = s.Name()
+		metas[i].Annotations = s.Annotations
 	}
 
 	return metas
@@ -248,8 +249,9 @@ func (m *multiSeriesBlockSeriesIter) Current() (block.UnconsolidatedSeries, erro
 	}
 
 	return block.NewUnconsolidatedSeries(values, block.SeriesMeta{
-		Tags: s.Tags,
-		Name: s.Name(),
+		Tags:        s.Tags,
+		Name:        s.Name(),
+		Annotations: s.Annotations,
 	}), nil
 }
 
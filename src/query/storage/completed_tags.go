@@ -0,0 +1,146 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"sync"
+)
+
+var (
+	errMismatchedCompleteNameOnly = errors.New("cannot merge name only results with value results")
+)
+
+// CompleteTagsResultBuilder builds a deduplicated complete tags result from
+// tag name and value pairs, or from other complete tags results.
+type CompleteTagsResultBuilder struct {
+	sync.Mutex
+	nameOnly    bool
+	filterNames [][]byte
+	tagValues   map[string]map[string]struct{}
+}
+
+// NewCompleteTagsResultBuilder creates a new complete tags result builder.
+func NewCompleteTagsResultBuilder(
+	nameOnly bool,
+	filterNames [][]byte,
+) *CompleteTagsResultBuilder {
+	return &CompleteTagsResultBuilder{
+		nameOnly:    nameOnly,
+		filterNames: filterNames,
+		tagValues:   make(map[string]map[string]struct{}),
+	}
+}
+
+// AddTag adds a single tag name and value pair to the builder, tags not
+// matching the name filter are ignored.
+func (b *CompleteTagsResultBuilder) AddTag(name, value []byte) {
+	b.Lock()
+	b.addTagWithLock(name, value)
+	b.Unlock()
+}
+
+func (b *CompleteTagsResultBuilder) addTagWithLock(name, value []byte) {
+	if !b.matchesFilter(name) {
+		return
+	}
+
+	values, ok := b.tagValues[string(name)]
+	if !ok {
+		values = make(map[string]struct{})
+		b.tagValues[string(name)] = values
+	}
+
+	if b.nameOnly {
+		return
+	}
+
+	values[string(value)] = struct{}{}
+}
+
+func (b *CompleteTagsResultBuilder) matchesFilter(name []byte) bool {
+	if len(b.filterNames) == 0 {
+		return true
+	}
+
+	for _, filter := range b.filterNames {
+		if bytes.Equal(filter, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Add merges an existing complete tags result into the builder.
+func (b *CompleteTagsResultBuilder) Add(result *CompleteTagsResult) error {
+	if result.CompleteNameOnly != b.nameOnly {
+		return errMismatchedCompleteNameOnly
+	}
+
+	b.Lock()
+	defer b.Unlock()
+	for _, tag := range result.CompletedTags {
+		if b.nameOnly {
+			b.addTagWithLock(tag.Name, nil)
+			continue
+		}
+
+		for _, value := range tag.Values {
+			b.addTagWithLock(tag.Name, value)
+		}
+	}
+
+	return nil
+}
+
+// Build builds a complete tags result, sorting tag names and values.
+func (b *CompleteTagsResultBuilder) Build() CompleteTagsResult {
+	b.Lock()
+	defer b.Unlock()
+
+	completedTags := make([]CompletedTag, 0, len(b.tagValues))
+	for name, values := range b.tagValues {
+		tag := CompletedTag{Name: []byte(name)}
+		if !b.nameOnly {
+			tag.Values = make([][]byte, 0, len(values))
+			for value := range values {
+				tag.Values = append(tag.Values, []byte(value))
+			}
+
+			sort.Slice(tag.Values, func(i, j int) bool {
+				return bytes.Compare(tag.Values[i], tag.Values[j]) < 0
+			})
+		}
+
+		completedTags = append(completedTags, tag)
+	}
+
+	sort.Slice(completedTags, func(i, j int) bool {
+		return bytes.Compare(completedTags[i].Name, completedTags[j].Name) < 0
+	})
+
+	return CompleteTagsResult{
+		CompleteNameOnly: b.nameOnly,
+		CompletedTags:    completedTags,
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompleteTagsResultBuilder(t *testing.T) {
+	builder := NewCompleteTagsResultBuilder(false, nil)
+	builder.AddTag([]byte("foo"), []byte("b"))
+	builder.AddTag([]byte("foo"), []byte("a"))
+	builder.AddTag([]byte("bar"), []byte("c"))
+	builder.AddTag([]byte("foo"), []byte("b"))
+
+	err := builder.Add(&CompleteTagsResult{
+		CompletedTags: []CompletedTag{
+			{Name: []byte("baz"), Values: [][]byte{[]byte("d")}},
+			{Name: []byte("foo"), Values: [][]byte{[]byte("c")}},
+		},
+	})
+	require.NoError(t, err)
+
+	result := builder.Build()
+	assert.False(t, result.CompleteNameOnly)
+	assert.Equal(t, []CompletedTag{
+		{Name: []byte("bar"), Values: [][]byte{[]byte("c")}},
+		{Name: []byte("baz"), Values: [][]byte{[]byte("d")}},
+		{Name: []byte("foo"), Values: [][]byte{[]byte("a"), []byte("b"), []byte("c")}},
+	}, result.CompletedTags)
+}
+
+func TestCompleteTagsResultBuilderNameOnlyWithFilter(t *testing.T) {
+	builder := NewCompleteTagsResultBuilder(true, [][]byte{[]byte("foo"), []byte("bar")})
+	builder.AddTag([]byte("foo"), []byte("a"))
+	builder.AddTag([]byte("qux"), []byte("b"))
+	builder.AddTag([]byte("bar"), []byte("c"))
+
+	result := builder.Build()
+	assert.True(t, result.CompleteNameOnly)
+	assert.Equal(t, []CompletedTag{
+		{Name: []byte("bar")},
+		{Name: []byte("foo")},
+	}, result.CompletedTags)
+}
+
+func TestCompleteTagsResultBuilderSkipsTagsWithoutValues(t *testing.T) {
+	builder := NewCompleteTagsResultBuilder(false, nil)
+	err := builder.Add(&CompleteTagsResult{
+		CompletedTags: []CompletedTag{
+			{Name: []byte("bar")},
+			{Name: []byte("foo"), Values: [][]byte{[]byte("a")}},
+		},
+	})
+	require.NoError(t, err)
+
+	result := builder.Build()
+	assert.Equal(t, []CompletedTag{
+		{Name: []byte("foo"), Values: [][]byte{[]byte("a")}},
+	}, result.CompletedTags)
+}
+
+func TestCompleteTagsResultBuilderMismatchedNameOnly(t *testing.T) {
+	builder := NewCompleteTagsResultBuilder(true, nil)
+	err := builder.Add(&CompleteTagsResult{CompleteNameOnly: false})
+	assert.Error(t, err)
+}
@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/ts"
@@ -37,6 +38,9 @@ import (
 const (
 	xTimeUnit             = xtime.Millisecond
 	initRawFetchAllocSize = 32
+	// fetchedDatapointsBatchSize is how many datapoints are decompressed
+	// between charges to the query's cost enforcer.
+	fetchedDatapointsBatchSize = 128
 )
 
 // PromWriteTSToM3 converts a prometheus write query to an M3 one
@@ -239,31 +243,62 @@ func SeriesToPromSamples(series *ts.Series) []*prompb.Sample {
 
 func iteratorToTsSeries(
 	iter encoding.SeriesIterator,
+	enforcer *cost.Enforcer,
 	tagOptions models.TagOptions,
+	includeAnnotations bool,
 ) (*ts.Series, error) {
 	metric, err := FromM3IdentToMetric(iter.ID(), iter.Tags(), tagOptions)
 	if err != nil {
 		return nil, err
 	}
 
-	datapoints := make(ts.Datapoints, 0, initRawFetchAllocSize)
+	var (
+		datapoints  = make(ts.Datapoints, 0, initRawFetchAllocSize)
+		annotations ts.Annotations
+		uncharged   int
+	)
 	for iter.Next() {
-		dp, _, _ := iter.Current()
+		dp, _, annotation := iter.Current()
 		datapoints = append(datapoints, ts.Datapoint{Timestamp: dp.Timestamp, Value: dp.Value})
+		// NB: charge the enforcer as the series is decompressed so that a
+		// single large series cannot exceed the datapoint limit unbounded.
+		if uncharged++; uncharged == fetchedDatapointsBatchSize {
+			if err := enforcer.AddFetchedDatapoints(uncharged); err != nil {
+				return nil, err
+			}
+
+			uncharged = 0
+		}
+
+		if includeAnnotations && len(annotation) > 0 {
+			// NB: the annotation is only valid until the iterator is advanced
+			annotations = append(annotations, ts.Annotation{
+				Timestamp: dp.Timestamp,
+				Value:     append([]byte(nil), annotation...),
+			})
+		}
+	}
+
+	if err := enforcer.AddFetchedDatapoints(uncharged); err != nil {
+		return nil, err
 	}
 
-	return ts.NewSeries(metric.ID, datapoints, metric.Tags), nil
+	series := ts.NewSeries(metric.ID, datapoints, metric.Tags)
+	series.Annotations = annotations
+	return series, nil
 }
 
 // Fall back to sequential decompression if unable to decompress concurrently
 func decompressSequentially(
 	iterLength int,
 	iters []encoding.SeriesIterator,
+	enforcer *cost.Enforcer,
 	tagOptions models.TagOptions,
+	includeAnnotations bool,
 ) (*FetchResult, error) {
 	seriesList := make([]*ts.Series, 0, len(iters))
 	for _, iter := range iters {
-		series, err := iteratorToTsSeries(iter, tagOptions)
+		series, err := iteratorToTsSeries(iter, enforcer, tagOptions, includeAnnotations)
 		if err != nil {
 			return nil, err
 		}
@@ -279,7 +314,9 @@ func decompressConcurrently(
 	iterLength int,
 	iters []encoding.SeriesIterator,
 	readWorkerPool xsync.PooledWorkerPool,
+	enforcer *cost.Enforcer,
 	tagOptions models.TagOptions,
+	includeAnnotations bool,
 ) (*FetchResult, error) {
 	seriesList := make([]*ts.Series, iterLength)
 	var wg sync.WaitGroup
@@ -305,7 +342,7 @@ func decompressConcurrently(
 				return
 			}
 
-			series, err := iteratorToTsSeries(iter, tagOptions)
+			series, err := iteratorToTsSeries(iter, enforcer, tagOptions, includeAnnotations)
 			if err != nil {
 				// Return the first error that is encountered.
 				select {
@@ -330,12 +367,16 @@ func decompressConcurrently(
 	}, nil
 }
 
-// SeriesIteratorsToFetchResult converts SeriesIterators into a fetch result
+// SeriesIteratorsToFetchResult converts SeriesIterators into a fetch result,
+// accounting decompressed datapoints against the optional enforcer and
+// keeping the annotations of datapoints if requested
 func SeriesIteratorsToFetchResult(
 	seriesIterators encoding.SeriesIterators,
 	readWorkerPool xsync.PooledWorkerPool,
 	cleanupSeriesIters bool,
+	enforcer *cost.Enforcer,
 	tagOptions models.TagOptions,
+	includeAnnotations bool,
 ) (*FetchResult, error) {
 	if cleanupSeriesIters {
 		defer seriesIterators.Close()
@@ -344,8 +385,10 @@ func SeriesIteratorsToFetchResult(
 	iters := seriesIterators.Iters()
 	iterLength := seriesIterators.Len()
 	if readWorkerPool == nil {
-		return decompressSequentially(iterLength, iters, tagOptions)
+		return decompressSequentially(iterLength, iters, enforcer,
+			tagOptions, includeAnnotations)
 	}
 
-	return decompressConcurrently(iterLength, iters, readWorkerPool, tagOptions)
+	return decompressConcurrently(iterLength, iters, readWorkerPool, enforcer,
+		tagOptions, includeAnnotations)
 }
@@ -28,12 +28,15 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/encoding"
+	m3ts "github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/test/seriesiter"
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3x/ident"
 	xsync "github.com/m3db/m3x/sync"
+	xtime "github.com/m3db/m3x/time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -44,7 +47,7 @@ func verifyExpandSeries(t *testing.T, ctrl *gomock.Controller, num int, pools xs
 	testTags := seriesiter.GenerateTag()
 	iters := seriesiter.NewMockSeriesIters(ctrl, testTags, num, 2)
 
-	results, err := SeriesIteratorsToFetchResult(iters, pools, true, nil)
+	results, err := SeriesIteratorsToFetchResult(iters, pools, true, nil, nil, false)
 	assert.NoError(t, err)
 
 	require.NotNil(t, results)
@@ -84,6 +87,35 @@ func TestExpandSeriesSmallValidPools(t *testing.T) {
 	testExpandSeries(t, pool)
 }
 
+func TestExpandSeriesExceedsDatapointLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	iters := seriesiter.NewMockSeriesIters(ctrl, seriesiter.GenerateTag(), 2, 2)
+	enforcer := cost.NewEnforcer(cost.Limits{MaxFetchedDatapoints: 3})
+	result, err := SeriesIteratorsToFetchResult(iters, nil, true, enforcer, nil, false)
+	require.Nil(t, result)
+	require.Error(t, err)
+	assert.True(t, cost.IsLimitError(err))
+	assert.Equal(t, int64(4), enforcer.Stats().FetchedDatapoints)
+}
+
+func TestExpandSeriesChargesDatapointsDuringDecompression(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	iters := seriesiter.NewMockSeriesIters(ctrl, seriesiter.GenerateTag(),
+		1, 3*fetchedDatapointsBatchSize)
+	enforcer := cost.NewEnforcer(cost.Limits{MaxFetchedDatapoints: 10})
+	result, err := SeriesIteratorsToFetchResult(iters, nil, true, enforcer, nil, false)
+	require.Nil(t, result)
+	require.Error(t, err)
+	assert.True(t, cost.IsLimitError(err))
+	// Decompression stops after the first batch exceeds the limit.
+	assert.Equal(t, int64(fetchedDatapointsBatchSize),
+		enforcer.Stats().FetchedDatapoints)
+}
+
 func TestFailingExpandSeriesValidPools(t *testing.T) {
 	var (
 		numValidSeries = 8
@@ -134,11 +166,51 @@ func TestFailingExpandSeriesValidPools(t *testing.T) {
 		pool,
 		true,
 		nil,
+		nil,
+		false,
 	)
 	require.Nil(t, result)
 	require.EqualError(t, err, "error")
 }
 
+func TestSeriesIteratorsToFetchResultAnnotations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		now        = time.Now()
+		annotation = []byte("trace-id")
+		iter       = encoding.NewMockSeriesIterator(ctrl)
+	)
+	iter.EXPECT().ID().Return(ident.StringID("foo")).AnyTimes()
+	iter.EXPECT().Tags().Return(
+		seriesiter.GenerateSingleSampleTagIterator(ctrl, seriesiter.GenerateTag()))
+	gomock.InOrder(
+		iter.EXPECT().Next().Return(true),
+		iter.EXPECT().Current().Return(
+			m3ts.Datapoint{Timestamp: now, Value: 1}, xtime.Second, m3ts.Annotation(annotation)),
+		iter.EXPECT().Next().Return(true),
+		iter.EXPECT().Current().Return(
+			m3ts.Datapoint{Timestamp: now.Add(time.Second), Value: 2}, xtime.Second, nil),
+		iter.EXPECT().Next().Return(false),
+	)
+
+	iters := encoding.NewMockSeriesIterators(ctrl)
+	iters.EXPECT().Iters().Return([]encoding.SeriesIterator{iter})
+	iters.EXPECT().Len().Return(1)
+
+	result, err := SeriesIteratorsToFetchResult(iters, nil, false, nil, nil, true)
+	require.NoError(t, err)
+	require.Len(t, result.SeriesList, 1)
+
+	// The annotation must be copied since iterators reuse annotation buffers
+	annotation[0] = 'x'
+	assert.Equal(t, ts.Annotations{
+		{Timestamp: now, Value: []byte("trace-id")},
+	}, result.SeriesList[0].Annotations)
+	assert.Equal(t, 2, result.SeriesList[0].Len())
+}
+
 var (
 	name  = []byte("foo")
 	value = []byte("bar")
@@ -128,6 +128,41 @@ func (s *fanoutStorage) FetchTags(ctx context.Context, query *storage.FetchQuery
 	return result, nil
 }
 
+func (s *fanoutStorage) CompleteTags(
+	ctx context.Context,
+	query *storage.CompleteTagsQuery,
+	options *storage.FetchOptions,
+) (*storage.CompleteTagsResult, error) {
+	stores := filterStores(s.stores, s.fetchFilter, query)
+	// short circuit complete tags
+	if len(stores) == 1 {
+		return stores[0].CompleteTags(ctx, query, options)
+	}
+
+	builder := storage.NewCompleteTagsResultBuilder(query.CompleteNameOnly, query.FilterNameTags)
+	for _, store := range stores {
+		result, err := store.CompleteTags(ctx, query, options)
+		if err == errors.ErrNotImplemented {
+			// NB: stores that do not support completing tags, such as remote
+			// storages, are skipped rather than failing the whole request.
+			logging.WithContext(ctx).Warn("skipping store that does not support complete tags",
+				zap.Int("store", int(store.Type())))
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := builder.Add(result); err != nil {
+			return nil, err
+		}
+	}
+
+	result := builder.Build()
+	return &result, nil
+}
+
 func (s *fanoutStorage) Write(ctx context.Context, query *storage.WriteQuery) error {
 	// TODO: Consider removing this lookup on every write by maintaining different read/write lists
 	stores := filterStores(s.stores, s.writeFilter, query)
@@ -144,6 +179,34 @@ func (s *fanoutStorage) Write(ctx context.Context, query *storage.WriteQuery) er
 	return execution.ExecuteParallel(ctx, requests)
 }
 
+func (s *fanoutStorage) Delete(
+	ctx context.Context,
+	query *storage.DeleteQuery,
+) (int64, error) {
+	stores := filterStores(s.stores, s.writeFilter, query)
+	if len(stores) == 1 {
+		return stores[0].Delete(ctx, query)
+	}
+
+	requests := make([]execution.Request, len(stores))
+	deletes := make([]*deleteRequest, len(stores))
+	for idx, store := range stores {
+		deletes[idx] = newDeleteRequest(store, query)
+		requests[idx] = deletes[idx]
+	}
+
+	if err := execution.ExecuteParallel(ctx, requests); err != nil {
+		return 0, err
+	}
+
+	var deleted int64
+	for _, req := range deletes {
+		deleted += req.deleted
+	}
+
+	return deleted, nil
+}
+
 func (s *fanoutStorage) Type() storage.Type {
 	return storage.TypeMultiDC
 }
@@ -211,3 +274,26 @@ func newWriteRequest(store storage.Storage, query *storage.WriteQuery) execution
 func (f *writeRequest) Process(ctx context.Context) error {
 	return f.store.Write(ctx, f.query)
 }
+
+type deleteRequest struct {
+	store   storage.Storage
+	query   *storage.DeleteQuery
+	deleted int64
+}
+
+func newDeleteRequest(store storage.Storage, query *storage.DeleteQuery) *deleteRequest {
+	return &deleteRequest{
+		store: store,
+		query: query,
+	}
+}
+
+func (f *deleteRequest) Process(ctx context.Context) error {
+	deleted, err := f.store.Delete(ctx, f.query)
+	if err != nil {
+		return err
+	}
+
+	f.deleted = deleted
+	return nil
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/m3db/m3/src/query/errors"
 	"github.com/m3db/m3/src/query/policy/filter"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
 	"github.com/m3db/m3/src/query/test/m3"
 	"github.com/m3db/m3/src/query/test/seriesiter"
 	"github.com/m3db/m3/src/query/ts"
@@ -184,3 +185,39 @@ func TestFanoutWriteSuccess(t *testing.T) {
 	})
 	assert.NoError(t, err)
 }
+
+func TestFanoutCompleteTagsSkipsNotImplemented(t *testing.T) {
+	setup()
+	local := mock.NewMockStorage()
+	local.SetCompleteTagsResult(&storage.CompleteTagsResult{
+		CompletedTags: []storage.CompletedTag{
+			{Name: []byte("foo"), Values: [][]byte{[]byte("bar")}},
+		},
+	}, nil)
+	remote := mock.NewMockStorage()
+	remote.SetTypeResult(storage.TypeRemoteDC)
+	remote.SetCompleteTagsResult(nil, errors.ErrNotImplemented)
+
+	store := NewStorage([]storage.Storage{local, remote},
+		filterFunc(true), filterFunc(true))
+	result, err := store.CompleteTags(context.TODO(),
+		&storage.CompleteTagsQuery{}, &storage.FetchOptions{})
+	require.NoError(t, err)
+	require.Len(t, result.CompletedTags, 1)
+	assert.Equal(t, []byte("foo"), result.CompletedTags[0].Name)
+	assert.Equal(t, [][]byte{[]byte("bar")}, result.CompletedTags[0].Values)
+}
+
+func TestFanoutCompleteTagsError(t *testing.T) {
+	setup()
+	local := mock.NewMockStorage()
+	local.SetCompleteTagsResult(nil, fmt.Errorf("complete tags error"))
+	remote := mock.NewMockStorage()
+	remote.SetCompleteTagsResult(nil, errors.ErrNotImplemented)
+
+	store := NewStorage([]storage.Storage{local, remote},
+		filterFunc(true), filterFunc(true))
+	_, err := store.CompleteTags(context.TODO(),
+		&storage.CompleteTagsQuery{}, &storage.FetchOptions{})
+	assert.Error(t, err)
+}
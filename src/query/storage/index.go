@@ -93,7 +93,15 @@ func FetchOptionsToM3Options(fetchOptions *FetchOptions, fetchQuery *FetchQuery)
 
 // FetchQueryToM3Query converts an m3coordinator fetch query to an M3 query
 func FetchQueryToM3Query(fetchQuery *FetchQuery) (index.Query, error) {
-	matchers := fetchQuery.TagMatchers
+	return matchersToM3Query(fetchQuery.TagMatchers)
+}
+
+// DeleteQueryToM3Query converts an m3coordinator delete query to an M3 query
+func DeleteQueryToM3Query(deleteQuery *DeleteQuery) (index.Query, error) {
+	return matchersToM3Query(deleteQuery.TagMatchers)
+}
+
+func matchersToM3Query(matchers models.Matchers) (index.Query, error) {
 	idxQueries := make([]idx.Query, len(matchers))
 	var err error
 	for i, matcher := range matchers {
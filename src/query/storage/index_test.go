@@ -149,3 +149,25 @@ func TestFetchQueryToM3Query(t *testing.T) {
 	}
 
 }
+
+func TestDeleteQueryToM3Query(t *testing.T) {
+	deleteQuery := &DeleteQuery{
+		Raw: `up{t1="v1",t2=~"v2"}`,
+		TagMatchers: models.Matchers{
+			{
+				Type:  models.MatchEqual,
+				Name:  []byte("t1"),
+				Value: []byte("v1"),
+			},
+			{
+				Type:  models.MatchRegexp,
+				Name:  []byte("t2"),
+				Value: []byte("v2"),
+			},
+		},
+	}
+
+	m3Query, err := DeleteQueryToM3Query(deleteQuery)
+	require.NoError(t, err)
+	assert.Equal(t, "conjunction(term(t1, v1), regexp(t2, v2))", m3Query.String())
+}
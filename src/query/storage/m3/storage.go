@@ -34,13 +34,16 @@ import (
 	"github.com/m3db/m3/src/query/errors"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/query/ts"
+	xerrors "github.com/m3db/m3x/errors"
 	"github.com/m3db/m3x/ident"
 	xsync "github.com/m3db/m3x/sync"
 )
 
 var (
 	errNoNamespacesConfigured = goerrors.New("no namespaces configured")
+	errNamespaceNotAllowed    = goerrors.New("namespace not allowed for tenant")
 )
 
 type queryFanoutType uint
@@ -85,7 +88,8 @@ func (s *m3storage) Fetch(
 		return nil, err
 	}
 
-	return storage.SeriesIteratorsToFetchResult(raw, s.readWorkerPool, false, s.tagOptions)
+	return storage.SeriesIteratorsToFetchResult(raw, s.readWorkerPool,
+		false, options.Enforcer, s.tagOptions, options.IncludeAnnotations)
 }
 
 func (s *m3storage) FetchBlocks(
@@ -98,7 +102,20 @@ func (s *m3storage) FetchBlocks(
 		return block.Result{}, err
 	}
 
-	return storage.FetchResultToBlockResult(fetchResult, query)
+	result, err := storage.FetchResultToBlockResult(fetchResult, query)
+	if err != nil {
+		return block.Result{}, err
+	}
+
+	if err := options.Enforcer.AddFetchedBlocks(len(result.Blocks)); err != nil {
+		for _, b := range result.Blocks {
+			b.Close()
+		}
+
+		return block.Result{}, err
+	}
+
+	return result, nil
 }
 
 func (s *m3storage) FetchRaw(
@@ -124,7 +141,8 @@ func (s *m3storage) FetchRaw(
 	// cluster that can completely fulfill this range and then prefer the
 	// highest resolution (most fine grained) results.
 	// This needs to be optimized, however this is a start.
-	fanout, namespaces, err := s.resolveClusterNamespacesForQuery(query.Start, query.End)
+	fanout, namespaces, err := s.resolveClusterNamespacesForQuery(query.Start,
+		query.End, allowedNamespaces(ctx))
 	if err != nil {
 		return nil, noop, err
 	}
@@ -137,6 +155,14 @@ func (s *m3storage) FetchRaw(
 		return nil, noop, errNoNamespacesConfigured
 	}
 
+	// NB: fetch at most one series more than the series limit allows so
+	// that the limit is detected without materializing every match.
+	if max := options.Enforcer.Limits().MaxFetchedSeries; max > 0 {
+		if opts.Limit <= 0 || int64(opts.Limit) > max+1 {
+			opts.Limit = int(max + 1)
+		}
+	}
+
 	pools, err := namespaces[0].Session().IteratorPools()
 	if err != nil {
 		return nil, noop, fmt.Errorf("unable to retrieve iterator pools: %v", err)
@@ -166,6 +192,11 @@ func (s *m3storage) FetchRaw(
 		return nil, noop, err
 	}
 
+	if err := options.Enforcer.AddFetchedSeries(iters.Len()); err != nil {
+		result.Close()
+		return nil, noop, err
+	}
+
 	return iters, result.Close, nil
 }
 
@@ -190,7 +221,7 @@ func (s *m3storage) FetchTags(
 
 	var (
 		opts       = storage.FetchOptionsToM3Options(options, query)
-		namespaces = s.clusters.ClusterNamespaces()
+		namespaces = filterNamespaces(s.clusters.ClusterNamespaces(), allowedNamespaces(ctx))
 		result     multiFetchTagsResult
 		wg         sync.WaitGroup
 	)
@@ -250,6 +281,99 @@ func (s *m3storage) fetchTags(
 	}, nil
 }
 
+func (s *m3storage) CompleteTags(
+	ctx context.Context,
+	query *storage.CompleteTagsQuery,
+	options *storage.FetchOptions,
+) (*storage.CompleteTagsResult, error) {
+	// Check if the query was interrupted.
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-options.KillChan:
+		return nil, errors.ErrQueryInterrupted
+	default:
+	}
+
+	fetchQuery := &storage.FetchQuery{
+		TagMatchers: query.TagMatchers,
+		Start:       query.Start,
+		End:         query.End,
+	}
+
+	m3query, err := storage.FetchQueryToM3Query(fetchQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		opts = index.AggregationOptions{
+			QueryOptions: storage.FetchOptionsToM3Options(options, fetchQuery),
+			FieldFilter:  query.FilterNameTags,
+			NameOnly:     query.CompleteNameOnly,
+		}
+		namespaces = filterNamespaces(s.clusters.ClusterNamespaces(), allowedNamespaces(ctx))
+		builder    = storage.NewCompleteTagsResultBuilder(query.CompleteNameOnly, query.FilterNameTags)
+		multiErr   xerrors.MultiError
+		errLock    sync.Mutex
+		wg         sync.WaitGroup
+	)
+	if len(namespaces) == 0 {
+		return nil, errNoNamespacesConfigured
+	}
+
+	for _, namespace := range namespaces {
+		namespace := namespace // Capture var
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.completeTags(namespace, m3query, opts, builder); err != nil {
+				errLock.Lock()
+				multiErr = multiErr.Add(err)
+				errLock.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	if err := multiErr.FinalError(); err != nil {
+		return nil, err
+	}
+
+	result := builder.Build()
+	return &result, nil
+}
+
+// completeTags adds the tag names, and the values unless only names are
+// requested, aggregated by the database nodes to the builder.
+func (s *m3storage) completeTags(
+	namespace ClusterNamespace,
+	query index.Query,
+	opts index.AggregationOptions,
+	builder *storage.CompleteTagsResultBuilder,
+) error {
+	namespaceID := namespace.NamespaceID()
+	session := namespace.Session()
+
+	results, _, err := session.Aggregate(namespaceID, query, opts)
+	if err != nil {
+		return err
+	}
+
+	results.ForEach(func(name []byte, values [][]byte) {
+		if opts.NameOnly {
+			builder.AddTag(name, nil)
+			return
+		}
+		for _, value := range values {
+			builder.AddTag(name, value)
+		}
+	})
+
+	return nil
+}
+
 func (s *m3storage) Write(
 	ctx context.Context,
 	query *storage.WriteQuery,
@@ -308,6 +432,64 @@ func (s *m3storage) Write(
 	return multiErr.finalError()
 }
 
+func (s *m3storage) Delete(
+	ctx context.Context,
+	query *storage.DeleteQuery,
+) (int64, error) {
+	// Check if the query was interrupted.
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	default:
+	}
+
+	m3query, err := storage.DeleteQueryToM3Query(query)
+	if err != nil {
+		return 0, err
+	}
+	opts := index.QueryOptions{
+		StartInclusive: query.Start,
+		EndExclusive:   query.End,
+	}
+
+	namespaces := filterNamespaces(s.clusters.ClusterNamespaces(),
+		allowedNamespaces(ctx))
+	if len(namespaces) == 0 {
+		return 0, errNoNamespacesConfigured
+	}
+
+	var (
+		wg       sync.WaitGroup
+		multiErr syncMultiErrs
+		mu       sync.Mutex
+		deleted  int64
+	)
+	for _, namespace := range namespaces {
+		namespace := namespace // Capture var
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// NB: the session reports the series it managed to delete even when
+			// the delete fails to meet the consistency level.
+			n, err := namespace.Session().DeleteTagged(namespace.NamespaceID(), m3query, opts)
+			if err != nil {
+				multiErr.add(err)
+			}
+
+			mu.Lock()
+			deleted += n
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+	if err := multiErr.finalError(); err != nil {
+		return deleted, err
+	}
+
+	return deleted, nil
+}
+
 func (s *m3storage) Type() storage.Type {
 	return storage.TypeLocalDC
 }
@@ -352,6 +534,10 @@ func (s *m3storage) writeSingle(
 		return err
 	}
 
+	if t, ok := tenant.FromContext(ctx); ok && !t.AllowsNamespace(namespace.NamespaceID()) {
+		return xerrors.NewInvalidParamsError(errNamespaceNotAllowed)
+	}
+
 	namespaceID := namespace.NamespaceID()
 	session := namespace.Session()
 	return session.WriteTagged(namespaceID, identID, iterator,
@@ -359,17 +545,21 @@ func (s *m3storage) writeSingle(
 }
 
 // resolveClusterNamespacesForQuery returns the namespaces that need to be
-// fanned out to depending on the query time and the namespaces configured.
+// fanned out to depending on the query time and the namespaces configured,
+// only namespaces accepted by the allowed filter are considered if set.
 func (s *m3storage) resolveClusterNamespacesForQuery(
 	start time.Time,
 	end time.Time,
+	allowed func(ClusterNamespace) bool,
 ) (queryFanoutType, ClusterNamespaces, error) {
 	now := s.nowFn()
 
 	unaggregated := s.clusters.UnaggregatedClusterNamespace()
+	unaggregatedAllowed := allowed == nil || allowed(unaggregated)
 	unaggregatedRetention := unaggregated.Options().Attributes().Retention
 	unaggregatedStart := now.Add(-1 * unaggregatedRetention)
-	if unaggregatedStart.Before(start) || unaggregatedStart.Equal(start) {
+	if unaggregatedAllowed &&
+		(unaggregatedStart.Before(start) || unaggregatedStart.Equal(start)) {
 		// Highest resolution is unaggregated, return if it can fulfill it
 		return namespaceCoversAllQueryRange, ClusterNamespaces{unaggregated}, nil
 	}
@@ -380,6 +570,10 @@ func (s *m3storage) resolveClusterNamespacesForQuery(
 	// even more granular resolutions
 	var r reusedAggregatedNamespaceSlices
 	r = s.aggregatedNamespaces(r, func(namespace ClusterNamespace) bool {
+		if allowed != nil && !allowed(namespace) {
+			return false
+		}
+
 		// Include only if can fulfill the entire time range of the query
 		clusterStart := now.Add(-1 * namespace.Options().Attributes().Retention)
 		return clusterStart.Before(start) || clusterStart.Equal(start)
@@ -412,13 +606,16 @@ func (s *m3storage) resolveClusterNamespacesForQuery(
 	// as much data as possible, along with any partially aggregated namespaces
 	// that have either same retention and lower resolution or longer retention
 	// than the complete aggregated namespace
-	r = s.aggregatedNamespaces(r, nil)
+	r = s.aggregatedNamespaces(r, allowed)
 
 	if len(r.completeAggregated) == 0 {
 		// Absolutely no complete aggregated namespaces, need to fanout to all
 		// partial aggregated namespaces as well as the unaggregated cluster
 		// as we have no idea who has the longest retention
-		result := append(r.partialAggregated, unaggregated)
+		result := r.partialAggregated
+		if unaggregatedAllowed {
+			result = append(result, unaggregated)
+		}
 		return namespaceCoversPartialQueryRange, result, nil
 	}
 
@@ -431,7 +628,7 @@ func (s *m3storage) resolveClusterNamespacesForQuery(
 	// cluster if that is longer than the longest aggregated namespace
 	result := r.completeAggregated[:1]
 	completedAttrs := result[0].Options().Attributes()
-	if completedAttrs.Retention <= unaggregatedRetention {
+	if unaggregatedAllowed && completedAttrs.Retention <= unaggregatedRetention {
 		// If the longest aggregated cluster for some reason has lower retention
 		// than the unaggregated cluster then we prefer the unaggregated cluster
 		// as it has a complete data set and is always the most granular
@@ -504,3 +701,31 @@ func (s *m3storage) aggregatedNamespaces(
 
 	return slices
 }
+
+// allowedNamespaces returns a filter of the namespaces that the tenant of the
+// context is allowed to access, or nil if the context has no tenant.
+func allowedNamespaces(ctx context.Context) func(ClusterNamespace) bool {
+	t, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return func(namespace ClusterNamespace) bool {
+		return t.AllowsNamespace(namespace.NamespaceID())
+	}
+}
+
+func filterNamespaces(
+	namespaces ClusterNamespaces,
+	filter func(ClusterNamespace) bool,
+) ClusterNamespaces {
+	if filter == nil {
+		return namespaces
+	}
+	result := make(ClusterNamespaces, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		if filter(namespace) {
+			result = append(result, namespace)
+		}
+	}
+	return result
+}
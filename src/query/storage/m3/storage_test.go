@@ -30,10 +30,14 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/query/test/seriesiter"
 	"github.com/m3db/m3/src/query/ts"
+	xerrors "github.com/m3db/m3x/errors"
 	"github.com/m3db/m3x/ident"
 	"github.com/m3db/m3x/sync"
 	xtest "github.com/m3db/m3x/test"
@@ -42,6 +46,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
 )
 
 const (
@@ -247,6 +252,42 @@ func TestLocalWriteAggregatedSuccess(t *testing.T) {
 	assert.NoError(t, store.Close())
 }
 
+func newTenantContext(t *testing.T, namespaces ...string) context.Context {
+	tnt, err := tenant.NewTenant("test", namespaces, nil, tally.NoopScope)
+	require.NoError(t, err)
+	return tenant.NewContext(context.TODO(), tnt)
+}
+
+func TestLocalWriteTenantNamespaceNotAllowed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, _ := setup(t, ctrl)
+
+	ctx := newTenantContext(t, "metrics_aggregated_1m:30d")
+	err := store.Write(ctx, newWriteQuery())
+	require.Error(t, err)
+	assert.True(t, xerrors.IsInvalidParams(err))
+}
+
+func TestLocalReadTenantNamespaces(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{T: t})
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+	testTag := seriesiter.GenerateTag()
+
+	// The unaggregated namespace would serve the query if it was allowed
+	session := sessions.aggregated1MonthRetention1MinuteResolution
+	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(seriesiter.NewMockSeriesIters(ctrl, testTag, 1, 2), true, nil)
+	session.EXPECT().IteratorPools().
+		Return(newTestIteratorPools(ctrl), nil).AnyTimes()
+
+	ctx := newTenantContext(t, "metrics_aggregated_1m:30d")
+	results, err := store.Fetch(ctx, newFetchReq(), &storage.FetchOptions{Limit: 100})
+	require.NoError(t, err)
+	assertFetchResult(t, results, testTag)
+}
+
 func TestLocalRead(t *testing.T) {
 	ctrl := gomock.NewController(xtest.Reporter{T: t})
 	defer ctrl.Finish()
@@ -271,6 +312,35 @@ func TestLocalRead(t *testing.T) {
 	assert.Equal(t, []byte("name"), results.SeriesList[0].Tags.Opts.MetricName())
 }
 
+func TestLocalReadExceedsSeriesLimit(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{T: t})
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+	testTags := seriesiter.GenerateTag()
+
+	session := sessions.unaggregated1MonthRetention
+	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ ident.ID,
+			_ index.Query,
+			opts index.QueryOptions,
+		) (encoding.SeriesIterators, bool, error) {
+			// The fetch is limited to one more series than the enforcer allows.
+			assert.Equal(t, 3, opts.Limit)
+			return seriesiter.NewMockSeriesIters(ctrl, testTags, 3, 2), false, nil
+		})
+	session.EXPECT().IteratorPools().
+		Return(newTestIteratorPools(ctrl), nil).AnyTimes()
+
+	enforcer := cost.NewEnforcer(cost.Limits{MaxFetchedSeries: 2})
+	results, err := store.Fetch(context.TODO(), newFetchReq(),
+		&storage.FetchOptions{Enforcer: enforcer})
+	require.Error(t, err)
+	assert.True(t, cost.IsLimitError(err))
+	assert.Nil(t, results)
+	assert.Equal(t, int64(3), enforcer.Stats().FetchedSeries)
+}
+
 func TestLocalReadExceedsRetention(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -552,6 +622,66 @@ func TestLocalSearchSuccess(t *testing.T) {
 	}
 }
 
+func TestLocalCompleteTagsSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+
+	sessions.forEach(func(session *client.MockSession) {
+		session.EXPECT().Aggregate(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(
+				_ ident.ID,
+				_ index.Query,
+				opts index.AggregationOptions,
+			) (*index.AggregateResults, bool, error) {
+				assert.Equal(t, [][]byte{[]byte("foo")}, opts.FieldFilter)
+				assert.False(t, opts.NameOnly)
+
+				results := index.NewAggregateResults(opts)
+				results.AddTags(ident.NewTags(
+					ident.StringTag("foo", "bar"),
+					ident.StringTag("qux", "qaz"),
+				))
+				results.AddTags(ident.NewTags(
+					ident.StringTag("foo", "baz"),
+				))
+				return results, true, nil
+			})
+	})
+
+	req := &storage.CompleteTagsQuery{
+		FilterNameTags: [][]byte{[]byte("foo")},
+		TagMatchers:    newFetchReq().TagMatchers,
+		Start:          time.Now().Add(-time.Hour),
+		End:            time.Now(),
+	}
+	result, err := store.CompleteTags(context.TODO(), req, &storage.FetchOptions{})
+	require.NoError(t, err)
+
+	require.False(t, result.CompleteNameOnly)
+	require.Equal(t, []storage.CompletedTag{{
+		Name:   []byte("foo"),
+		Values: [][]byte{[]byte("bar"), []byte("baz")},
+	}}, result.CompletedTags)
+}
+
+func TestLocalCompleteTagsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+	sessions.forEach(func(session *client.MockSession) {
+		session.EXPECT().Aggregate(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, false, fmt.Errorf("an error"))
+	})
+
+	req := &storage.CompleteTagsQuery{
+		CompleteNameOnly: true,
+		TagMatchers:      newFetchReq().TagMatchers,
+	}
+	_, err := store.CompleteTags(context.TODO(), req, &storage.FetchOptions{})
+	assert.Error(t, err)
+}
+
 func newTestIteratorPools(ctrl *gomock.Controller) encoding.IteratorPools {
 	pools := encoding.NewMockIteratorPools(ctrl)
 
@@ -568,3 +698,44 @@ func newTestIteratorPools(ctrl *gomock.Controller) encoding.IteratorPools {
 
 	return pools
 }
+
+func newDeleteQuery() *storage.DeleteQuery {
+	matchers := models.Matchers{
+		{
+			Type:  models.MatchEqual,
+			Name:  []byte("foo"),
+			Value: []byte("bar"),
+		},
+	}
+	return &storage.DeleteQuery{
+		Raw:         `{foo="bar"}`,
+		TagMatchers: matchers,
+	}
+}
+
+func TestLocalDeleteSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+	sessions.forEach(func(session *client.MockSession) {
+		session.EXPECT().DeleteTagged(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(2), nil)
+	})
+
+	deleted, err := store.Delete(context.TODO(), newDeleteQuery())
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), deleted)
+}
+
+func TestLocalDeleteError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+	sessions.forEach(func(session *client.MockSession) {
+		session.EXPECT().DeleteTagged(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(int64(1), fmt.Errorf("an error"))
+	})
+
+	deleted, err := store.Delete(context.TODO(), newDeleteQuery())
+	assert.Error(t, err)
+	assert.Equal(t, int64(5), deleted)
+}
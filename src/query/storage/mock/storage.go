@@ -36,10 +36,13 @@ type Storage interface {
 	SetTypeResult(storage.Type)
 	SetFetchResult(*storage.FetchResult, error)
 	SetFetchTagsResult(*storage.SearchResults, error)
+	SetCompleteTagsResult(*storage.CompleteTagsResult, error)
 	SetWriteResult(error)
+	SetDeleteResult(int64, error)
 	SetFetchBlocksResult(block.Result, error)
 	SetCloseResult(error)
 	Writes() []*storage.WriteQuery
+	Deletes() []*storage.DeleteQuery
 }
 
 type mockStorage struct {
@@ -55,9 +58,17 @@ type mockStorage struct {
 		result *storage.SearchResults
 		err    error
 	}
+	completeTagsResult struct {
+		result *storage.CompleteTagsResult
+		err    error
+	}
 	writeResult struct {
 		err error
 	}
+	deleteResult struct {
+		result int64
+		err    error
+	}
 	fetchBlocksResult struct {
 		result block.Result
 		err    error
@@ -65,7 +76,8 @@ type mockStorage struct {
 	closeResult struct {
 		err error
 	}
-	writes []*storage.WriteQuery
+	writes  []*storage.WriteQuery
+	deletes []*storage.DeleteQuery
 }
 
 // NewMockStorage creates a new mock Storage instance.
@@ -93,12 +105,26 @@ func (s *mockStorage) SetFetchTagsResult(result *storage.SearchResults, err erro
 	s.fetchTagsResult.err = err
 }
 
+func (s *mockStorage) SetCompleteTagsResult(result *storage.CompleteTagsResult, err error) {
+	s.Lock()
+	defer s.Unlock()
+	s.completeTagsResult.result = result
+	s.completeTagsResult.err = err
+}
+
 func (s *mockStorage) SetWriteResult(err error) {
 	s.Lock()
 	defer s.Unlock()
 	s.writeResult.err = err
 }
 
+func (s *mockStorage) SetDeleteResult(result int64, err error) {
+	s.Lock()
+	defer s.Unlock()
+	s.deleteResult.result = result
+	s.deleteResult.err = err
+}
+
 func (s *mockStorage) SetFetchBlocksResult(result block.Result, err error) {
 	s.Lock()
 	defer s.Unlock()
@@ -118,6 +144,12 @@ func (s *mockStorage) Writes() []*storage.WriteQuery {
 	return s.writes
 }
 
+func (s *mockStorage) Deletes() []*storage.DeleteQuery {
+	s.RLock()
+	defer s.RUnlock()
+	return s.deletes
+}
+
 func (s *mockStorage) Fetch(
 	ctx context.Context,
 	query *storage.FetchQuery,
@@ -148,6 +180,16 @@ func (s *mockStorage) FetchTags(
 	return s.fetchTagsResult.result, s.fetchTagsResult.err
 }
 
+func (s *mockStorage) CompleteTags(
+	ctx context.Context,
+	query *storage.CompleteTagsQuery,
+	_ *storage.FetchOptions,
+) (*storage.CompleteTagsResult, error) {
+	s.RLock()
+	defer s.RUnlock()
+	return s.completeTagsResult.result, s.completeTagsResult.err
+}
+
 func (s *mockStorage) Write(
 	ctx context.Context,
 	query *storage.WriteQuery,
@@ -158,6 +200,16 @@ func (s *mockStorage) Write(
 	return s.writeResult.err
 }
 
+func (s *mockStorage) Delete(
+	ctx context.Context,
+	query *storage.DeleteQuery,
+) (int64, error) {
+	s.Lock()
+	defer s.Unlock()
+	s.deletes = append(s.deletes, query)
+	return s.deleteResult.result, s.deleteResult.err
+}
+
 func (s *mockStorage) Type() storage.Type {
 	s.RLock()
 	defer s.RUnlock()
@@ -62,10 +62,27 @@ func (s *remoteStorage) FetchTags(
 	return s.client.FetchTags(ctx, query, options)
 }
 
+func (s *remoteStorage) CompleteTags(
+	ctx context.Context,
+	query *storage.CompleteTagsQuery,
+	options *storage.FetchOptions,
+) (*storage.CompleteTagsResult, error) {
+	// TODO: add complete tags support to the remote coordinator RPC
+	return nil, errors.ErrNotImplemented
+}
+
 func (s *remoteStorage) Write(ctx context.Context, query *storage.WriteQuery) error {
 	return errors.ErrRemoteWriteQuery
 }
 
+func (s *remoteStorage) Delete(
+	ctx context.Context,
+	query *storage.DeleteQuery,
+) (int64, error) {
+	// TODO: add delete support to the remote coordinator RPC
+	return 0, errors.ErrNotImplemented
+}
+
 func (s *remoteStorage) Type() storage.Type {
 	return storage.TypeRemoteDC
 }
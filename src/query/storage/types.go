@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/ts"
 	xtime "github.com/m3db/m3x/time"
@@ -47,6 +48,7 @@ const (
 type Storage interface {
 	Querier
 	Appender
+	Deleter
 	// Type identifies the type of the underlying storage
 	Type() Type
 	// Close is used to close the underlying storage and free up resources
@@ -60,8 +62,10 @@ type Query interface {
 	query()
 }
 
-func (q *FetchQuery) query() {}
-func (q *WriteQuery) query() {}
+func (q *FetchQuery) query()        {}
+func (q *WriteQuery) query()        {}
+func (q *CompleteTagsQuery) query() {}
+func (q *DeleteQuery) query()       {}
 
 // FetchQuery represents the input query which is fetched from M3DB
 type FetchQuery struct {
@@ -80,6 +84,11 @@ func (q *FetchQuery) String() string {
 type FetchOptions struct {
 	Limit    int
 	KillChan chan struct{}
+	// Enforcer tracks and limits the resources used by the query.
+	Enforcer *cost.Enforcer
+	// IncludeAnnotations returns the annotations of the datapoints with the
+	// fetched series, such as trace IDs of exemplars.
+	IncludeAnnotations bool
 }
 
 // Querier handles queries against a storage.
@@ -104,6 +113,45 @@ type Querier interface {
 		query *FetchQuery,
 		options *FetchOptions,
 	) (*SearchResults, error)
+
+	// CompleteTags returns autocompleted tag results
+	CompleteTags(
+		ctx context.Context,
+		query *CompleteTagsQuery,
+		options *FetchOptions,
+	) (*CompleteTagsResult, error)
+}
+
+// CompleteTagsQuery represents a query that returns an autocompleted
+// set of tags that exist in the db
+type CompleteTagsQuery struct {
+	// CompleteNameOnly determines if only tag names are returned
+	CompleteNameOnly bool
+	// FilterNameTags restricts results to the given tag names, if set
+	FilterNameTags [][]byte
+	TagMatchers    models.Matchers
+	Start          time.Time
+	End            time.Time
+}
+
+func (q *CompleteTagsQuery) String() string {
+	if q.CompleteNameOnly {
+		return fmt.Sprintf("completing tag name for query %v", q.TagMatchers)
+	}
+
+	return fmt.Sprintf("completing tag values for query %v", q.TagMatchers)
+}
+
+// CompletedTag represents a tag retrieved by a complete tags query
+type CompletedTag struct {
+	Name   []byte
+	Values [][]byte
+}
+
+// CompleteTagsResult represents a set of autocompleted tag names and values
+type CompleteTagsResult struct {
+	CompleteNameOnly bool
+	CompletedTags    []CompletedTag
 }
 
 // WriteQuery represents the input timeseries that is written to M3DB
@@ -125,6 +173,27 @@ type Appender interface {
 	Write(ctx context.Context, query *WriteQuery) error
 }
 
+// DeleteQuery represents a query that deletes all series matching its tag matchers
+type DeleteQuery struct {
+	Raw         string
+	TagMatchers models.Matchers `json:"matchers"`
+	// Start and End bound the series deleted to those with data in the
+	// range, series are deleted across the entire retention if unset.
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+func (q *DeleteQuery) String() string {
+	return q.Raw
+}
+
+// Deleter deletes series from a storage.
+type Deleter interface {
+	// Delete deletes all series matching the query and returns the number
+	// of series deleted, which is a partial count if an error is returned
+	Delete(ctx context.Context, query *DeleteQuery) (int64, error)
+}
+
 // SearchResults is the result from a search
 type SearchResults struct {
 	Metrics models.Metrics
@@ -158,6 +158,39 @@ func (s *AsyncSession) FetchTaggedIDs(namespace ident.ID, q index.Query, opts in
 	return s.session.FetchTaggedIDs(namespace, q, opts)
 }
 
+// Aggregate resolves the provided query to the tag names, and the values
+// unless only names are requested, of the matching series.
+func (s *AsyncSession) Aggregate(
+	namespace ident.ID,
+	q index.Query,
+	opts index.AggregationOptions,
+) (*index.AggregateResults, bool, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return nil, false, s.err
+	}
+
+	return s.session.Aggregate(namespace, q, opts)
+}
+
+// DeleteTagged deletes all series matching the provided query within the
+// range of the options from every replica and returns the number of series
+// deleted.
+func (s *AsyncSession) DeleteTagged(
+	namespace ident.ID,
+	q index.Query,
+	opts index.QueryOptions,
+) (int64, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	return s.session.DeleteTagged(namespace, q, opts)
+}
+
 // ShardID returns the given shard for an ID for callers
 // to easily discern what shard is failing when operations
 // for given IDs begin failing
@@ -106,6 +106,9 @@ func TestAsyncSessionUninitialized(t *testing.T) {
 	_, _, err = asyncSession.FetchTaggedIDs(namespace, index.Query{}, index.QueryOptions{})
 	assert.Equal(t, err, errSessionUninitialized)
 
+	_, err = asyncSession.DeleteTagged(namespace, index.Query{}, index.QueryOptions{})
+	assert.Equal(t, err, errSessionUninitialized)
+
 	id, err := asyncSession.ShardID(nil)
 	assert.Equal(t, uint32(0), id)
 	assert.Equal(t, err, errSessionUninitialized)
@@ -154,6 +157,10 @@ func TestAsyncSessionInitialized(t *testing.T) {
 	_, _, err = asyncSession.FetchTaggedIDs(namespace, index.Query{}, index.QueryOptions{})
 	assert.NoError(t, err)
 
+	mockSession.EXPECT().DeleteTagged(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), nil)
+	_, err = asyncSession.DeleteTagged(namespace, index.Query{}, index.QueryOptions{})
+	assert.NoError(t, err)
+
 	mockSession.EXPECT().ShardID(gomock.Any()).Return(uint32(0), nil)
 	_, err = asyncSession.ShardID(nil)
 	assert.NoError(t, err)
@@ -0,0 +1,175 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tenant
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/m3db/m3/src/x/net/http"
+
+	"github.com/uber-go/tally"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+)
+
+var (
+	errUnauthorized      = errors.New("unauthorized: no tenant for token")
+	errUnauthorizedAdmin = errors.New("unauthorized: not an admin token")
+)
+
+// Authenticator authenticates the tenant of HTTP requests by the token the
+// request carries.
+type Authenticator struct {
+	header      string
+	tokens      []tenantToken
+	adminTokens [][]byte
+	metrics     authenticatorMetrics
+}
+
+type tenantToken struct {
+	token  []byte
+	tenant *Tenant
+}
+
+type authenticatorMetrics struct {
+	unauthorized      tally.Counter
+	unauthorizedAdmin tally.Counter
+}
+
+// NewAuthenticator returns a new authenticator that maps tokens to tenants
+// and authenticates admin requests by the admin tokens given. The token is
+// read from the header given, or from a bearer token of the Authorization
+// header if no header is given.
+func NewAuthenticator(
+	header string,
+	tenants map[string]*Tenant,
+	adminTokens []string,
+	scope tally.Scope,
+) *Authenticator {
+	tokens := make([]tenantToken, 0, len(tenants))
+	for token, t := range tenants {
+		tokens = append(tokens, tenantToken{token: []byte(token), tenant: t})
+	}
+	admin := make([][]byte, 0, len(adminTokens))
+	for _, token := range adminTokens {
+		admin = append(admin, []byte(token))
+	}
+	return &Authenticator{
+		header:      header,
+		tokens:      tokens,
+		adminTokens: admin,
+		metrics: authenticatorMetrics{
+			unauthorized:      scope.Counter("unauthorized"),
+			unauthorizedAdmin: scope.Counter("unauthorized-admin"),
+		},
+	}
+}
+
+// Authenticate returns the tenant of the request.
+func (a *Authenticator) Authenticate(r *http.Request) (*Tenant, error) {
+	token, ok := a.token(r)
+	if !ok {
+		return nil, errUnauthorized
+	}
+
+	// NB: compare all tokens in constant time to not leak how close a
+	// token is to a valid one.
+	var result *Tenant
+	for _, t := range a.tokens {
+		if subtle.ConstantTimeCompare(t.token, token) == 1 {
+			result = t.tenant
+		}
+	}
+	if result == nil {
+		return nil, errUnauthorized
+	}
+	return result, nil
+}
+
+// AuthenticateAdmin returns an error if the request does not carry an admin
+// token.
+func (a *Authenticator) AuthenticateAdmin(r *http.Request) error {
+	token, ok := a.token(r)
+	if !ok {
+		return errUnauthorizedAdmin
+	}
+
+	admin := false
+	for _, t := range a.adminTokens {
+		if subtle.ConstantTimeCompare(t, token) == 1 {
+			admin = true
+		}
+	}
+	if !admin {
+		return errUnauthorizedAdmin
+	}
+	return nil
+}
+
+func (a *Authenticator) token(r *http.Request) ([]byte, bool) {
+	if a.header != "" {
+		value := r.Header.Get(a.header)
+		return []byte(value), value != ""
+	}
+
+	value := r.Header.Get(authorizationHeader)
+	if !strings.HasPrefix(value, bearerPrefix) {
+		return nil, false
+	}
+	token := strings.TrimSpace(strings.TrimPrefix(value, bearerPrefix))
+	return []byte(token), token != ""
+}
+
+// Handler returns a handler that rejects requests without a valid token and
+// serves requests with the tenant of the token set on the request context.
+func (a *Authenticator) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t, err := a.Authenticate(r)
+		if err != nil {
+			a.metrics.unauthorized.Inc(1)
+			xhttp.Error(w, err, http.StatusUnauthorized)
+			return
+		}
+
+		t.metrics.requests.Inc(1)
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), t)))
+	})
+}
+
+// AdminHandler returns a handler that rejects requests without an admin
+// token.
+func (a *Authenticator) AdminHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := a.AuthenticateAdmin(r); err != nil {
+			a.metrics.unauthorizedAdmin.Inc(1)
+			xhttp.Error(w, err, http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
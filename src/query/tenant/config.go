@@ -0,0 +1,117 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tenant
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/m3db/m3x/ident"
+
+	"github.com/uber-go/tally"
+)
+
+var (
+	errNoTenants          = errors.New("no tenants configured")
+	errEmptyAdminToken    = errors.New("empty admin token")
+	errAdminTokenOfTenant = errors.New("admin token is also the token of a tenant")
+)
+
+// Configuration is the configuration for the tenants of the coordinator.
+type Configuration struct {
+	// Header is the HTTP header that carries the token of the tenant, if not
+	// set the token is read from a bearer token Authorization header.
+	Header string `yaml:"header"`
+
+	// Tenants is the set of tenants.
+	Tenants []TenantConfiguration `yaml:"tenants" validate:"nonzero"`
+
+	// AdminTokens are the tokens that authenticate requests to the admin
+	// endpoints, such as the placement, namespace and rules endpoints. The
+	// admin endpoints reject all requests if not set.
+	AdminTokens []string `yaml:"adminTokens"`
+}
+
+// TenantConfiguration is the configuration for a tenant.
+type TenantConfiguration struct {
+	// Name is the name of the tenant.
+	Name string `yaml:"name" validate:"nonzero"`
+
+	// Tokens are the tokens that authenticate the tenant.
+	Tokens []string `yaml:"tokens" validate:"nonzero"`
+
+	// Namespaces are the namespaces the tenant is allowed to access, all
+	// namespaces are allowed if not set.
+	Namespaces []string `yaml:"namespaces"`
+
+	// Tags are the tags every series of the tenant has, they are added as
+	// matchers to every query and required on every write.
+	Tags map[string]string `yaml:"tags"`
+}
+
+// NewAuthenticator returns a new authenticator for the tenants, tenants are
+// only allowed aggregated writes if they are allowed to access all the
+// aggregated namespaces given.
+func (c Configuration) NewAuthenticator(
+	aggregatedNamespaces []ident.ID,
+	scope tally.Scope,
+) (*Authenticator, error) {
+	if len(c.Tenants) == 0 {
+		return nil, errNoTenants
+	}
+
+	var (
+		names   = make(map[string]struct{}, len(c.Tenants))
+		tenants = make(map[string]*Tenant, len(c.Tenants))
+	)
+	for _, cfg := range c.Tenants {
+		if _, ok := names[cfg.Name]; ok {
+			return nil, fmt.Errorf("duplicate tenant: %s", cfg.Name)
+		}
+		names[cfg.Name] = struct{}{}
+
+		t, err := NewTenant(cfg.Name, cfg.Namespaces, cfg.Tags, scope)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tenant %s: %v", cfg.Name, err)
+		}
+		t.allowAggregatedWrites(aggregatedNamespaces)
+		for _, token := range cfg.Tokens {
+			if token == "" {
+				return nil, fmt.Errorf("empty token for tenant: %s", cfg.Name)
+			}
+			if _, ok := tenants[token]; ok {
+				return nil, fmt.Errorf("token of tenant %s is not unique", cfg.Name)
+			}
+			tenants[token] = t
+		}
+	}
+
+	for _, token := range c.AdminTokens {
+		if token == "" {
+			return nil, errEmptyAdminToken
+		}
+		if _, ok := tenants[token]; ok {
+			return nil, errAdminTokenOfTenant
+		}
+	}
+
+	return NewAuthenticator(c.Header, tenants, c.AdminTokens, scope), nil
+}
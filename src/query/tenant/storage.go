@@ -0,0 +1,153 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tenant
+
+import (
+	"context"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/storage"
+)
+
+type tenantStorage struct {
+	storage.Storage
+}
+
+// NewStorage returns a storage that restricts the queries of the tenant set
+// on the context to the series of the tenant: the tag constraints of the
+// tenant are added as matchers to every fetch and delete, and every write
+// is validated against them. Queries without a tenant are not restricted.
+func NewStorage(s storage.Storage) storage.Storage {
+	return &tenantStorage{Storage: s}
+}
+
+func (s *tenantStorage) Fetch(
+	ctx context.Context,
+	query *storage.FetchQuery,
+	options *storage.FetchOptions,
+) (*storage.FetchResult, error) {
+	t, ok := FromContext(ctx)
+	if !ok {
+		return s.Storage.Fetch(ctx, query, options)
+	}
+
+	result, err := s.Storage.Fetch(ctx, tenantFetchQuery(t, query), options)
+	t.metrics.reportFetch(err)
+	return result, err
+}
+
+func (s *tenantStorage) FetchBlocks(
+	ctx context.Context,
+	query *storage.FetchQuery,
+	options *storage.FetchOptions,
+) (block.Result, error) {
+	t, ok := FromContext(ctx)
+	if !ok {
+		return s.Storage.FetchBlocks(ctx, query, options)
+	}
+
+	result, err := s.Storage.FetchBlocks(ctx, tenantFetchQuery(t, query), options)
+	t.metrics.reportFetch(err)
+	return result, err
+}
+
+func (s *tenantStorage) FetchTags(
+	ctx context.Context,
+	query *storage.FetchQuery,
+	options *storage.FetchOptions,
+) (*storage.SearchResults, error) {
+	t, ok := FromContext(ctx)
+	if !ok {
+		return s.Storage.FetchTags(ctx, query, options)
+	}
+
+	result, err := s.Storage.FetchTags(ctx, tenantFetchQuery(t, query), options)
+	t.metrics.reportFetch(err)
+	return result, err
+}
+
+func (s *tenantStorage) CompleteTags(
+	ctx context.Context,
+	query *storage.CompleteTagsQuery,
+	options *storage.FetchOptions,
+) (*storage.CompleteTagsResult, error) {
+	t, ok := FromContext(ctx)
+	if !ok {
+		return s.Storage.CompleteTags(ctx, query, options)
+	}
+
+	tenantQuery := *query
+	tenantQuery.TagMatchers = t.Matchers(query.TagMatchers)
+	result, err := s.Storage.CompleteTags(ctx, &tenantQuery, options)
+	t.metrics.reportFetch(err)
+	return result, err
+}
+
+func (s *tenantStorage) Write(
+	ctx context.Context,
+	query *storage.WriteQuery,
+) error {
+	t, ok := FromContext(ctx)
+	if !ok || query == nil {
+		return s.Storage.Write(ctx, query)
+	}
+
+	if err := t.ValidateTags(query.Tags); err != nil {
+		t.metrics.writesRejected.Inc(1)
+		return err
+	}
+
+	err := s.Storage.Write(ctx, query)
+	if err != nil {
+		t.metrics.writeErrors.Inc(1)
+	} else {
+		t.metrics.writes.Inc(1)
+	}
+	return err
+}
+
+func (s *tenantStorage) Delete(
+	ctx context.Context,
+	query *storage.DeleteQuery,
+) (int64, error) {
+	t, ok := FromContext(ctx)
+	if !ok {
+		return s.Storage.Delete(ctx, query)
+	}
+
+	tenantQuery := *query
+	tenantQuery.TagMatchers = t.Matchers(query.TagMatchers)
+	return s.Storage.Delete(ctx, &tenantQuery)
+}
+
+func tenantFetchQuery(t *Tenant, query *storage.FetchQuery) *storage.FetchQuery {
+	tenantQuery := *query
+	tenantQuery.TagMatchers = t.Matchers(query.TagMatchers)
+	return &tenantQuery
+}
+
+func (m tenantMetrics) reportFetch(err error) {
+	if err != nil {
+		m.fetchErrors.Inc(1)
+		return
+	}
+	m.fetches.Inc(1)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fetchCapturingStorage struct {
+	mock.Storage
+	fetches []*storage.FetchQuery
+}
+
+func (s *fetchCapturingStorage) FetchBlocks(
+	ctx context.Context,
+	query *storage.FetchQuery,
+	options *storage.FetchOptions,
+) (block.Result, error) {
+	s.fetches = append(s.fetches, query)
+	return s.Storage.FetchBlocks(ctx, query, options)
+}
+
+func TestStorageFetchAddsTenantMatchers(t *testing.T) {
+	underlying := &fetchCapturingStorage{Storage: mock.NewMockStorage()}
+	store := NewStorage(underlying)
+
+	matcher, err := models.NewMatcher(models.MatchEqual, []byte("foo"), []byte("bar"))
+	require.NoError(t, err)
+	query := &storage.FetchQuery{TagMatchers: models.Matchers{matcher}}
+
+	// Queries without a tenant are not restricted
+	_, err = store.FetchBlocks(context.Background(), query, &storage.FetchOptions{})
+	require.NoError(t, err)
+
+	ctx := NewContext(context.Background(), newTestTenant(t))
+	_, err = store.FetchBlocks(ctx, query, &storage.FetchOptions{})
+	require.NoError(t, err)
+
+	require.Len(t, underlying.fetches, 2)
+	assert.Len(t, underlying.fetches[0].TagMatchers, 1)
+	assert.Len(t, underlying.fetches[1].TagMatchers, 3)
+
+	// The query of the caller is not modified
+	assert.Len(t, query.TagMatchers, 1)
+}
+
+func TestStorageWriteValidatesTenantTags(t *testing.T) {
+	underlying := mock.NewMockStorage()
+	store := NewStorage(underlying)
+	ctx := NewContext(context.Background(), newTestTenant(t))
+
+	allowed := &storage.WriteQuery{
+		Tags: models.EmptyTags().AddTags([]models.Tag{
+			{Name: []byte("team"), Value: []byte("a")},
+			{Name: []byte("env"), Value: []byte("prod")},
+		}),
+	}
+	require.NoError(t, store.Write(ctx, allowed))
+
+	rejected := &storage.WriteQuery{
+		Tags: models.EmptyTags().AddTags([]models.Tag{
+			{Name: []byte("team"), Value: []byte("b")},
+		}),
+	}
+	require.Error(t, store.Write(ctx, rejected))
+
+	assert.Equal(t, []*storage.WriteQuery{allowed}, underlying.Writes())
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package tenant provides isolation of the namespaces and series that the
+// tenants of the coordinator are allowed to read and write.
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/m3db/m3/src/query/models"
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/uber-go/tally"
+)
+
+const tenantTagName = "tenant"
+
+// Tenant is a tenant of the coordinator, it is restricted to a set of
+// namespaces and to the series that match a set of tag constraints.
+type Tenant struct {
+	name             string
+	namespaces       map[string]struct{}
+	matchers         models.Matchers
+	aggregatedWrites bool
+	scope            tally.Scope
+	metrics          tenantMetrics
+}
+
+type tenantMetrics struct {
+	requests       tally.Counter
+	fetches        tally.Counter
+	fetchErrors    tally.Counter
+	writes         tally.Counter
+	writeErrors    tally.Counter
+	writesRejected tally.Counter
+}
+
+func newTenantMetrics(scope tally.Scope) tenantMetrics {
+	return tenantMetrics{
+		requests:       scope.Counter("requests"),
+		fetches:        scope.Counter("fetch"),
+		fetchErrors:    scope.Counter("fetch-errors"),
+		writes:         scope.Counter("write"),
+		writeErrors:    scope.Counter("write-errors"),
+		writesRejected: scope.Counter("write-rejected"),
+	}
+}
+
+// NewTenant returns a new tenant restricted to the namespaces and to the
+// series with the tags given, an empty set of namespaces allows all
+// namespaces. Aggregated writes are only allowed if the tenant is allowed
+// all namespaces. The metrics of the tenant are emitted to the scope tagged
+// with the name of the tenant.
+func NewTenant(
+	name string,
+	namespaces []string,
+	tags map[string]string,
+	scope tally.Scope,
+) (*Tenant, error) {
+	tagNames := make([]string, 0, len(tags))
+	for tagName := range tags {
+		tagNames = append(tagNames, tagName)
+	}
+	sort.Strings(tagNames)
+
+	matchers := make(models.Matchers, 0, len(tagNames))
+	for _, tagName := range tagNames {
+		matcher, err := models.NewMatcher(models.MatchEqual,
+			[]byte(tagName), []byte(tags[tagName]))
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, matcher)
+	}
+
+	var namespaceSet map[string]struct{}
+	if len(namespaces) > 0 {
+		namespaceSet = make(map[string]struct{}, len(namespaces))
+		for _, namespace := range namespaces {
+			namespaceSet[namespace] = struct{}{}
+		}
+	}
+
+	scope = scope.Tagged(map[string]string{tenantTagName: name})
+	return &Tenant{
+		name:             name,
+		namespaces:       namespaceSet,
+		matchers:         matchers,
+		aggregatedWrites: namespaceSet == nil,
+		scope:            scope,
+		metrics:          newTenantMetrics(scope),
+	}, nil
+}
+
+// Name returns the name of the tenant.
+func (t *Tenant) Name() string {
+	return t.name
+}
+
+// Scope returns the metrics scope of the tenant.
+func (t *Tenant) Scope() tally.Scope {
+	return t.scope
+}
+
+// AllowsNamespace returns whether the tenant is allowed to access a namespace.
+func (t *Tenant) AllowsNamespace(namespace ident.ID) bool {
+	if t.namespaces == nil {
+		return true
+	}
+	_, ok := t.namespaces[string(namespace.Bytes())]
+	return ok
+}
+
+// allowAggregatedWrites allows aggregated writes if the tenant is allowed to
+// access every aggregated namespace.
+func (t *Tenant) allowAggregatedWrites(aggregatedNamespaces []ident.ID) {
+	t.aggregatedWrites = true
+	for _, namespace := range aggregatedNamespaces {
+		if !t.AllowsNamespace(namespace) {
+			t.aggregatedWrites = false
+			return
+		}
+	}
+}
+
+// Matchers returns the matchers with the tag constraints of the tenant
+// added, restricting a query to the series of the tenant.
+func (t *Tenant) Matchers(matchers models.Matchers) models.Matchers {
+	if len(t.matchers) == 0 {
+		return matchers
+	}
+	result := make(models.Matchers, 0, len(matchers)+len(t.matchers))
+	result = append(result, matchers...)
+	return append(result, t.matchers...)
+}
+
+// ValidateTags returns an invalid params error if the tags of a series do not
+// satisfy the tag constraints of the tenant.
+func (t *Tenant) ValidateTags(tags models.Tags) error {
+	for i := range t.matchers {
+		matcher := t.matchers[i]
+		value, ok := tags.Get(matcher.Name)
+		if !ok || !matcher.Matches(value) {
+			return xerrors.NewInvalidParamsError(fmt.Errorf(
+				"series not allowed for tenant %s: requires tag %s=%s",
+				t.name, matcher.Name, matcher.Value))
+		}
+	}
+	return nil
+}
+
+// ValidateAggregatedWrites returns an invalid params error if the tenant is
+// not allowed to write aggregations. The downsampler writes the aggregations
+// of a series to any of the aggregated namespaces, so only tenants allowed
+// to access all of them may write aggregations.
+func (t *Tenant) ValidateAggregatedWrites() error {
+	if t.aggregatedWrites {
+		return nil
+	}
+	return xerrors.NewInvalidParamsError(fmt.Errorf(
+		"aggregated writes not allowed for tenant %s: requires all aggregated namespaces",
+		t.name))
+}
+
+type contextKey struct{}
+
+// NewContext returns a new context carrying the tenant.
+func NewContext(ctx context.Context, t *Tenant) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext returns the tenant carried by the context if any, requests
+// without a tenant are not restricted.
+func FromContext(ctx context.Context) (*Tenant, bool) {
+	t, ok := ctx.Value(contextKey{}).(*Tenant)
+	return t, ok && t != nil
+}
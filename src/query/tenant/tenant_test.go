@@ -0,0 +1,256 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tenant
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m3db/m3/src/query/models"
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func newTestTenant(t *testing.T) *Tenant {
+	tnt, err := NewTenant("team-a", []string{"metrics_a"},
+		map[string]string{"team": "a", "env": "prod"}, tally.NoopScope)
+	require.NoError(t, err)
+	return tnt
+}
+
+func TestTenantAllowsNamespace(t *testing.T) {
+	tnt := newTestTenant(t)
+	assert.True(t, tnt.AllowsNamespace(ident.StringID("metrics_a")))
+	assert.False(t, tnt.AllowsNamespace(ident.StringID("metrics_b")))
+
+	all, err := NewTenant("all", nil, nil, tally.NoopScope)
+	require.NoError(t, err)
+	assert.True(t, all.AllowsNamespace(ident.StringID("metrics_b")))
+}
+
+func TestTenantMatchers(t *testing.T) {
+	tnt := newTestTenant(t)
+	matcher, err := models.NewMatcher(models.MatchRegexp, []byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+
+	matchers := tnt.Matchers(models.Matchers{matcher})
+	require.Len(t, matchers, 3)
+	assert.Equal(t, matcher, matchers[0])
+	assert.Equal(t, "env=\"prod\"", matchers[1].String())
+	assert.Equal(t, "team=\"a\"", matchers[2].String())
+}
+
+func TestTenantValidateTags(t *testing.T) {
+	tnt := newTestTenant(t)
+	tags := models.EmptyTags().AddTags([]models.Tag{
+		{Name: []byte("team"), Value: []byte("a")},
+		{Name: []byte("env"), Value: []byte("prod")},
+		{Name: []byte("foo"), Value: []byte("bar")},
+	})
+	require.NoError(t, tnt.ValidateTags(tags))
+
+	tags = models.EmptyTags().AddTags([]models.Tag{
+		{Name: []byte("team"), Value: []byte("b")},
+		{Name: []byte("env"), Value: []byte("prod")},
+	})
+	err := tnt.ValidateTags(tags)
+	require.Error(t, err)
+	assert.True(t, xerrors.IsInvalidParams(err))
+
+	tags = models.EmptyTags().AddTags([]models.Tag{
+		{Name: []byte("team"), Value: []byte("a")},
+	})
+	require.Error(t, tnt.ValidateTags(tags))
+}
+
+func TestTenantContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+
+	tnt := newTestTenant(t)
+	result, ok := FromContext(NewContext(context.Background(), tnt))
+	require.True(t, ok)
+	assert.Equal(t, tnt, result)
+}
+
+func TestAuthenticatorHandler(t *testing.T) {
+	cfg := Configuration{
+		Tenants: []TenantConfiguration{
+			{Name: "team-a", Tokens: []string{"token-a"}},
+			{Name: "team-b", Tokens: []string{"token-b1", "token-b2"}},
+		},
+	}
+	authenticator, err := cfg.NewAuthenticator(nil, tally.NoopScope)
+	require.NoError(t, err)
+
+	var served string
+	handler := authenticator.Handler(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			tnt, ok := FromContext(r.Context())
+			require.True(t, ok)
+			served = tnt.Name()
+		}))
+
+	for _, test := range []struct {
+		authorization string
+		code          int
+		tenant        string
+	}{
+		{authorization: "Bearer token-a", code: http.StatusOK, tenant: "team-a"},
+		{authorization: "Bearer token-b2", code: http.StatusOK, tenant: "team-b"},
+		{authorization: "Bearer token-c", code: http.StatusUnauthorized},
+		{authorization: "token-a", code: http.StatusUnauthorized},
+		{code: http.StatusUnauthorized},
+	} {
+		served = ""
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if test.authorization != "" {
+			req.Header.Set("Authorization", test.authorization)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		assert.Equal(t, test.code, recorder.Code, test.authorization)
+		assert.Equal(t, test.tenant, served, test.authorization)
+	}
+}
+
+func TestAuthenticatorHeader(t *testing.T) {
+	cfg := Configuration{
+		Header: "M3-Tenant-Token",
+		Tenants: []TenantConfiguration{
+			{Name: "team-a", Tokens: []string{"token-a"}},
+		},
+	}
+	authenticator, err := cfg.NewAuthenticator(nil, tally.NoopScope)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("M3-Tenant-Token", "token-a")
+	tnt, err := authenticator.Authenticate(req)
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", tnt.Name())
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer token-a")
+	_, err = authenticator.Authenticate(req)
+	require.Error(t, err)
+}
+
+func TestConfigurationDuplicateToken(t *testing.T) {
+	cfg := Configuration{
+		Tenants: []TenantConfiguration{
+			{Name: "team-a", Tokens: []string{"token"}},
+			{Name: "team-b", Tokens: []string{"token"}},
+		},
+	}
+	_, err := cfg.NewAuthenticator(nil, tally.NoopScope)
+	require.Error(t, err)
+}
+
+func TestAuthenticatorAdminHandler(t *testing.T) {
+	cfg := Configuration{
+		Tenants: []TenantConfiguration{
+			{Name: "team-a", Tokens: []string{"token-a"}},
+		},
+		AdminTokens: []string{"admin"},
+	}
+	authenticator, err := cfg.NewAuthenticator(nil, tally.NoopScope)
+	require.NoError(t, err)
+
+	var served bool
+	handler := authenticator.AdminHandler(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			served = true
+		}))
+
+	for _, test := range []struct {
+		authorization string
+		code          int
+	}{
+		{authorization: "Bearer admin", code: http.StatusOK},
+		{authorization: "Bearer token-a", code: http.StatusUnauthorized},
+		{code: http.StatusUnauthorized},
+	} {
+		served = false
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if test.authorization != "" {
+			req.Header.Set("Authorization", test.authorization)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		assert.Equal(t, test.code, recorder.Code, test.authorization)
+		assert.Equal(t, test.code == http.StatusOK, served, test.authorization)
+	}
+}
+
+func TestConfigurationAdminTokenOfTenant(t *testing.T) {
+	cfg := Configuration{
+		Tenants: []TenantConfiguration{
+			{Name: "team-a", Tokens: []string{"token"}},
+		},
+		AdminTokens: []string{"token"},
+	}
+	_, err := cfg.NewAuthenticator(nil, tally.NoopScope)
+	require.Error(t, err)
+}
+
+func TestTenantValidateAggregatedWrites(t *testing.T) {
+	cfg := Configuration{
+		Tenants: []TenantConfiguration{
+			{Name: "all", Tokens: []string{"token-all"}},
+			{Name: "team-a", Tokens: []string{"token-a"},
+				Namespaces: []string{"metrics_a", "metrics_agg"}},
+			{Name: "team-b", Tokens: []string{"token-b"},
+				Namespaces: []string{"metrics_b"}},
+		},
+	}
+	aggregated := []ident.ID{ident.StringID("metrics_agg")}
+	authenticator, err := cfg.NewAuthenticator(aggregated, tally.NoopScope)
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		token   string
+		allowed bool
+	}{
+		{token: "token-all", allowed: true},
+		{token: "token-a", allowed: true},
+		{token: "token-b", allowed: false},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+test.token)
+		tnt, err := authenticator.Authenticate(req)
+		require.NoError(t, err)
+
+		err = tnt.ValidateAggregatedWrites()
+		if test.allowed {
+			assert.NoError(t, err, test.token)
+		} else {
+			require.Error(t, err, test.token)
+			assert.True(t, xerrors.IsInvalidParams(err), test.token)
+		}
+	}
+}
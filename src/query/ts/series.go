@@ -30,6 +30,9 @@ type Series struct {
 	name string
 	vals Values
 	Tags models.Tags
+	// Annotations are the annotations of the datapoints of the series, only
+	// set if annotations are requested.
+	Annotations Annotations
 }
 
 // NewSeries creates a new Series at a given start time, backed by the provided values
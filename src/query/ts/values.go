@@ -84,7 +84,8 @@ func (d Datapoints) Values() []float64 {
 	return values
 }
 
-// AlignToBounds returns values aligned to given bounds.
+// AlignToBounds returns values aligned to given bounds, each step holds the
+// datapoints after the previous step up to and including the step time.
 func (d Datapoints) AlignToBounds(bounds models.Bounds) []Datapoints {
 	numDatapoints := d.Len()
 	steps := bounds.Steps()
@@ -94,7 +95,7 @@ func (d Datapoints) AlignToBounds(bounds models.Bounds) []Datapoints {
 	t := bounds.Start
 	for i := 0; i < steps; i++ {
 		startIdx := dpIdx
-		for dpIdx < numDatapoints && d[dpIdx].Timestamp.Before(t) {
+		for dpIdx < numDatapoints && !d[dpIdx].Timestamp.After(t) {
 			dpIdx++
 		}
 
@@ -26,6 +26,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/query/models"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -132,3 +134,22 @@ func TestRawPointsToFixedStep(t *testing.T) {
 		}
 	}
 }
+
+func TestDatapointsAlignToBounds(t *testing.T) {
+	start := time.Unix(1535948880, 0)
+	dps := Datapoints{
+		{Timestamp: start.Add(-time.Second), Value: 1},
+		{Timestamp: start, Value: 2},
+		{Timestamp: start.Add(30 * time.Second), Value: 3},
+		{Timestamp: start.Add(time.Minute), Value: 4},
+	}
+
+	aligned := dps.AlignToBounds(models.Bounds{
+		Start:    start,
+		Duration: 2 * time.Minute,
+		StepSize: time.Minute,
+	})
+	require.Len(t, aligned, 2)
+	assert.Equal(t, []float64{1, 2}, aligned[0].Values(), "datapoint at the step time is included")
+	assert.Equal(t, []float64{3, 4}, aligned[1].Values())
+}
@@ -96,7 +96,8 @@ func (c *grpcClient) Fetch(
 		return nil, err
 	}
 
-	return storage.SeriesIteratorsToFetchResult(iters, c.readWorkerPool, true, c.tagOptions)
+	return storage.SeriesIteratorsToFetchResult(iters, c.readWorkerPool, true,
+		options.Enforcer, c.tagOptions, options.IncludeAnnotations)
 }
 
 func (c *grpcClient) fetchRaw(
@@ -178,7 +179,9 @@ func (c *grpcClient) FetchBlocks(
 		iters,
 		c.readWorkerPool,
 		true,
+		options.Enforcer,
 		c.tagOptions,
+		options.IncludeAnnotations,
 	)
 	if err != nil {
 		return block.Result{}, err
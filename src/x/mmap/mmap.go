@@ -120,8 +120,10 @@ func Files(opener FileOpener, files map[string]FileDesc) (FilesResult, error) {
 
 // File mmap's a file
 func File(file *os.File, opts Options) (Result, error) {
+	// Stat the file descriptor rather than the file name so that files that
+	// have already been unlinked can be mmap'd
 	name := file.Name()
-	stat, err := os.Stat(name)
+	stat, err := file.Stat()
 	if err != nil {
 		return Result{}, fmt.Errorf("mmap file could not stat %s: %v", name, err)
 	}
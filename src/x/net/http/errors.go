@@ -34,7 +34,8 @@ var (
 )
 
 type errorResponse struct {
-	Error string `json:"error"`
+	Error     string `json:"error"`
+	ErrorType string `json:"errorType,omitempty"`
 }
 
 // Error will serve an HTTP error
@@ -43,6 +44,16 @@ func Error(w http.ResponseWriter, err error, code int) {
 	json.NewEncoder(w).Encode(errorResponse{Error: err.Error()})
 }
 
+// ErrorWithType will serve an HTTP error along with a type which
+// allows clients to distinguish between errors
+func ErrorWithType(w http.ResponseWriter, err error, errType string, code int) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error:     err.Error(),
+		ErrorType: errType,
+	})
+}
+
 // ParseError is the error from parsing requests
 type ParseError struct {
 	inner error